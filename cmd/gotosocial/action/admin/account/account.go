@@ -19,19 +19,31 @@ package account
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/netip"
 	"os"
+	"strings"
 	"text/tabwriter"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/gotosocial/internal/validate"
+	"github.com/superseriousbusiness/oauth2/v4"
+	"github.com/superseriousbusiness/oauth2/v4/generates"
+	"github.com/superseriousbusiness/oauth2/v4/models"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -72,7 +84,7 @@ var Create action.GTSAction = func(ctx context.Context) error {
 	}()
 
 	username := config.GetAdminAccountUsername()
-	if err := validate.Username(username); err != nil {
+	if err := validate.NewUsername(username); err != nil {
 		return err
 	}
 
@@ -114,6 +126,177 @@ var Create action.GTSAction = func(ctx context.Context) error {
 	return err
 }
 
+// BotCreate creates a new bot account and user in
+// the database using the provided flags, along with
+// an application and a long-lived access token for it.
+//
+// The access token is printed to stdout on success.
+var BotCreate action.GTSAction = func(ctx context.Context) error {
+	state, err := initState(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		// Ensure state gets stopped on return.
+		if err := stopState(state); err != nil {
+			log.Error(ctx, err)
+		}
+	}()
+
+	username := config.GetAdminAccountUsername()
+	if err := validate.NewUsername(username); err != nil {
+		return err
+	}
+
+	usernameAvailable, err := state.DB.IsUsernameAvailable(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	if !usernameAvailable {
+		return fmt.Errorf("username %s is already in use", username)
+	}
+
+	email := config.GetAdminAccountEmail()
+	if err := validate.Email(email); err != nil {
+		return err
+	}
+
+	emailAvailable, err := state.DB.IsEmailAvailable(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	if !emailAvailable {
+		return fmt.Errorf("email address %s is already in use", email)
+	}
+
+	// Bots log in with their access token,
+	// so a password is optional. Generate a
+	// throwaway one if none was provided.
+	password := config.GetAdminAccountPassword()
+	if password == "" {
+		password = uuid.NewString()
+	}
+
+	if err := validate.Password(password); err != nil {
+		return err
+	}
+
+	// Unknown scopes are ignored in the OAuth flow, but
+	// here they're most likely a typo, so reject them.
+	scopes := config.GetAdminAccountScopes()
+	if len(oauth.ParseScopes(scopes)) != len(strings.Fields(scopes)) {
+		return fmt.Errorf("scopes %q contain unknown scopes", scopes)
+	}
+
+	rateLimit := config.GetAdminAccountRateLimit()
+	if rateLimit < 0 {
+		return fmt.Errorf("status-rate-limit must be 0 or greater, got %d", rateLimit)
+	}
+
+	// The admin is creating this account on
+	// behalf of the bot operator, so mark any
+	// current instance terms as accepted.
+	instance, err := state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return err
+	}
+
+	user, err := state.DB.NewSignup(ctx, gtsmodel.NewSignup{
+		Username:       username,
+		Email:          email,
+		Password:       password,
+		EmailVerified:  true, // Assume cli user wants email marked as verified already.
+		PreApproved:    true, // Assume cli user wants account marked as approved already.
+		TermsVersionID: instance.TermsVersionID,
+	})
+	if err != nil {
+		return err
+	}
+
+	account, err := state.DB.GetAccountByID(ctx, user.AccountID)
+	if err != nil {
+		return err
+	}
+
+	account.Bot = util.Ptr(true)
+	if err := state.DB.UpdateAccount(ctx, account, "bot"); err != nil {
+		return err
+	}
+
+	if rateLimit > 0 {
+		account.Settings.StatusRateLimit = rateLimit
+		if err := state.DB.UpdateAccountSettings(ctx, account.Settings, "status_rate_limit"); err != nil {
+			return err
+		}
+	}
+
+	// Create an application + client
+	// for the bot, owned by its user.
+	clientID, err := id.NewRandomULID()
+	if err != nil {
+		return err
+	}
+	clientSecret := uuid.NewString()
+
+	appID, err := id.NewRandomULID()
+	if err != nil {
+		return err
+	}
+
+	app := &gtsmodel.Application{
+		ID:           appID,
+		Name:         username,
+		RedirectURI:  oauth.OOBURI,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Scopes:       scopes,
+	}
+	if err := state.DB.PutApplication(ctx, app); err != nil {
+		return err
+	}
+
+	client := &gtsmodel.Client{
+		ID:     clientID,
+		Secret: clientSecret,
+		Domain: oauth.OOBURI,
+		UserID: user.ID,
+	}
+	if err := state.DB.PutClient(ctx, client); err != nil {
+		return err
+	}
+
+	// Generate a non-expiring access token
+	// for the bot user, using the same scheme
+	// as tokens issued through the oauth flow.
+	now := time.Now()
+	access, _, err := generates.NewAccessGenerate().Token(ctx, &oauth2.GenerateBasic{
+		Client:   models.New(clientID, clientSecret, oauth.OOBURI, user.ID),
+		UserID:   user.ID,
+		CreateAt: now,
+	}, false)
+	if err != nil {
+		return err
+	}
+
+	if err := state.DB.PutToken(ctx, &gtsmodel.Token{
+		ID:             id.NewULID(),
+		ClientID:       clientID,
+		UserID:         user.ID,
+		RedirectURI:    oauth.OOBURI,
+		Scope:          scopes,
+		Access:         access,
+		AccessCreateAt: now,
+	}); err != nil {
+		return err
+	}
+
+	fmt.Println(access)
+	return nil
+}
+
 // List returns all existing local accounts.
 var List action.GTSAction = func(ctx context.Context) error {
 	state, err := initState(ctx)
@@ -297,10 +480,14 @@ var Disable action.GTSAction = func(ctx context.Context) error {
 		return err
 	}
 
+	// Clear any freeze, so that the account
+	// isn't reenabled when the freeze elapses.
 	user.Disabled = util.Ptr(true)
+	user.FrozenUntil = time.Time{}
 	return state.DB.UpdateUser(
 		ctx, user,
 		"disabled",
+		"frozen_until",
 	)
 }
 
@@ -333,10 +520,14 @@ var Enable action.GTSAction = func(ctx context.Context) error {
 		return err
 	}
 
+	// Clear any freeze, so that the account
+	// stays enabled instead of being left frozen.
 	user.Disabled = util.Ptr(false)
+	user.FrozenUntil = time.Time{}
 	return state.DB.UpdateUser(
 		ctx, user,
 		"disabled",
+		"frozen_until",
 	)
 }
 
@@ -385,3 +576,211 @@ var Password action.GTSAction = func(ctx context.Context) error {
 		"encrypted_password",
 	)
 }
+
+// EmailChange sets a new unconfirmed email address on
+// the target account, and prints a one-time link to
+// stdout which can be used to confirm the new address.
+//
+// This allows admins to recover accounts whose owners
+// have lost access to their email address, without
+// needing to have SMTP configured on the instance.
+var EmailChange action.GTSAction = func(ctx context.Context) error {
+	state, err := initState(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		// Ensure state gets stopped on return.
+		if err := stopState(state); err != nil {
+			log.Error(ctx, err)
+		}
+	}()
+
+	username := config.GetAdminAccountUsername()
+	if err := validate.Username(username); err != nil {
+		return err
+	}
+
+	email := config.GetAdminAccountEmail()
+	if err := validate.Email(email); err != nil {
+		return err
+	}
+
+	emailAvailable, err := state.DB.IsEmailAvailable(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	if !emailAvailable {
+		return fmt.Errorf("email address %s is already in use", email)
+	}
+
+	account, err := state.DB.GetAccountByUsernameDomain(ctx, username, "")
+	if err != nil {
+		return err
+	}
+
+	user, err := state.DB.GetUserByAccountID(ctx, account.ID)
+	if err != nil {
+		return err
+	}
+
+	user.UnconfirmedEmail = email
+	user.ConfirmationToken = uuid.NewString()
+	user.ConfirmationSentAt = time.Now()
+
+	if err := state.DB.UpdateUser(
+		ctx, user,
+		"unconfirmed_email",
+		"confirmation_token",
+		"confirmation_sent_at",
+	); err != nil {
+		return err
+	}
+
+	fmt.Printf(
+		"confirm the new email address %s for %s by visiting the following link (valid for one week):\n%s\n",
+		email, username, uris.GenerateURIForEmailConfirm(user.ConfirmationToken),
+	)
+	return nil
+}
+
+// PasswordReset generates a password reset token for the
+// target account, and prints a one-time link to stdout
+// which can be used to set a new password.
+//
+// Unlike Password, this allows the account owner to choose
+// their new password themselves, without the admin knowing it.
+var PasswordReset action.GTSAction = func(ctx context.Context) error {
+	state, err := initState(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		// Ensure state gets stopped on return.
+		if err := stopState(state); err != nil {
+			log.Error(ctx, err)
+		}
+	}()
+
+	username := config.GetAdminAccountUsername()
+	if err := validate.Username(username); err != nil {
+		return err
+	}
+
+	account, err := state.DB.GetAccountByUsernameDomain(ctx, username, "")
+	if err != nil {
+		return err
+	}
+
+	user, err := state.DB.GetUserByAccountID(ctx, account.ID)
+	if err != nil {
+		return err
+	}
+
+	user.ResetPasswordToken = uuid.NewString()
+	user.ResetPasswordSentAt = time.Now()
+
+	if err := state.DB.UpdateUser(
+		ctx, user,
+		"reset_password_token",
+		"reset_password_sent_at",
+	); err != nil {
+		return err
+	}
+
+	fmt.Printf(
+		"reset the password for %s by visiting the following link (valid for one day):\n%s\n",
+		username, uris.GenerateURIForPasswordReset(user.ResetPasswordToken),
+	)
+	return nil
+}
+
+// Recount regenerates the stats (followers, following,
+// statuses counts etc) of the local account with the
+// provided username, or of all accounts in the database
+// (local and remote) if no username was provided.
+var Recount action.GTSAction = func(ctx context.Context) error {
+	state, err := initState(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		// Ensure state gets stopped on return.
+		if err := stopState(state); err != nil {
+			log.Error(ctx, err)
+		}
+	}()
+
+	if username := config.GetAdminAccountUsername(); username != "" {
+		if err := validate.Username(username); err != nil {
+			return err
+		}
+
+		account, err := state.DB.GetAccountByUsernameDomain(ctx, username, "")
+		if err != nil {
+			return err
+		}
+
+		if err := state.DB.RegenerateAccountStats(ctx, account); err != nil {
+			return fmt.Errorf("error recounting stats for %s: %w", username, err)
+		}
+
+		fmt.Printf("recounted stats for %s\n", username)
+		return nil
+	}
+
+	var (
+		page    = &paging.Page{Limit: 100}
+		counted int
+		failed  int
+	)
+
+	for {
+		// Page through all accounts in
+		// alphabetical order of [domain]/@[username].
+		accounts, err := state.DB.GetAccounts(
+			ctx, "", "", false, "", "", "", "", "",
+			netip.Addr{}, page,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return fmt.Errorf("error getting accounts: %w", err)
+		}
+
+		if len(accounts) == 0 {
+			// No more accounts.
+			break
+		}
+
+		for _, account := range accounts {
+			if err := state.DB.RegenerateAccountStats(ctx, account); err != nil {
+				// Log and carry on, one account
+				// shouldn't stop the whole recount.
+				log.Errorf(ctx, "error recounting stats for %s: %v", account.URI, err)
+				failed++
+				continue
+			}
+			counted++
+		}
+
+		fmt.Printf("recounted stats for %d accounts so far\n", counted)
+
+		// Get the next page, starting
+		// from the last account we saw.
+		last := accounts[len(accounts)-1]
+		page = &paging.Page{
+			Max:   paging.MaxID(last.Domain + "/@" + last.Username),
+			Limit: page.Limit,
+		}
+	}
+
+	if failed != 0 {
+		return fmt.Errorf("recounted stats for %d accounts, failed for %d accounts (see logs)", counted, failed)
+	}
+
+	fmt.Printf("done, recounted stats for %d accounts\n", counted)
+	return nil
+}
@@ -0,0 +1,114 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
+	"github.com/superseriousbusiness/gotosocial/internal/cleaner"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
+)
+
+// Orphans scans the database for orphaned rows
+// (ie., rows pointing at statuses or accounts that
+// no longer exist), prints the number found by
+// category, and deletes them if not a dry run.
+var Orphans action.GTSAction = func(ctx context.Context) error {
+	var state state.State
+
+	state.Caches.Init()
+	state.Caches.Start()
+
+	// Scheduler is required for the
+	// cleaner, but no other workers
+	// are needed for this CLI action.
+	state.Workers.StartScheduler()
+
+	dbService, err := bundb.NewBunDBService(ctx, &state)
+	if err != nil {
+		return fmt.Errorf("error creating dbservice: %w", err)
+	}
+	state.DB = dbService
+
+	//nolint:contextcheck
+	storage, err := gtsstorage.AutoConfig()
+	if err != nil {
+		return fmt.Errorf("error creating storage backend: %w", err)
+	}
+	state.Storage = storage
+
+	defer func() {
+		// Ensure everything gets shutdown on exit.
+		errs := gtserror.NewMultiError(2)
+
+		if err := storage.Close(); err != nil {
+			errs.Appendf("error closing storage backend: %w", err)
+		}
+
+		if err := dbService.Close(); err != nil {
+			errs.Appendf("error stopping database: %w", err)
+		}
+
+		state.Workers.Scheduler.Stop()
+		state.Caches.Stop()
+
+		if err := errs.Combine(); err != nil {
+			log.Error(ctx, err)
+		}
+	}()
+
+	dryRun := config.GetAdminMediaPruneDryRun()
+	if dryRun {
+		log.Info(ctx, "orphans DRY RUN")
+		ctx = gtscontext.SetDryRun(ctx)
+	}
+
+	//nolint:contextcheck
+	counts, pruneErr := cleaner.New(&state).Orphans().Prune(ctx)
+
+	verb := "deleted"
+	if dryRun {
+		verb = "found"
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "category\t%s\n", verb)
+	fmt.Fprintf(w, "media attachments of missing statuses\t%d\n", counts.Attachments)
+	fmt.Fprintf(w, "mentions of missing statuses\t%d\n", counts.Mentions)
+	fmt.Fprintf(w, "notifications of missing accounts\t%d\n", counts.Notifications)
+	fmt.Fprintf(w, "total\t%d\n", counts.Total())
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if pruneErr != nil {
+		return fmt.Errorf("error(s) pruning orphans: %w", pruneErr)
+	}
+
+	return nil
+}
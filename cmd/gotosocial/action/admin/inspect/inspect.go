@@ -0,0 +1,194 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package inspect
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+)
+
+func initState(ctx context.Context) (*state.State, error) {
+	var state state.State
+	state.Caches.Init()
+	state.Caches.Start()
+
+	// Set the state DB connection
+	dbConn, err := bundb.NewBunDBService(ctx, &state)
+	if err != nil {
+		return nil, fmt.Errorf("error creating dbConn: %w", err)
+	}
+	state.DB = dbConn
+
+	return &state, nil
+}
+
+func stopState(state *state.State) error {
+	err := state.DB.Close()
+	state.Caches.Stop()
+	return err
+}
+
+// Status returns an action which prints the status with
+// the given ID, URI or URL, along with its populated
+// relations (account, attachments, mentions etc), as JSON.
+func Status(target string) action.GTSAction {
+	return func(ctx context.Context) error {
+		state, err := initState(ctx)
+		if err != nil {
+			return err
+		}
+
+		defer func() {
+			// Ensure state gets stopped on return.
+			if err := stopState(state); err != nil {
+				log.Error(ctx, err)
+			}
+		}()
+
+		status, err := getByTarget(ctx, target,
+			state.DB.GetStatusByID,
+			state.DB.GetStatusByURI,
+			state.DB.GetStatusByURL,
+		)
+		if err != nil {
+			return fmt.Errorf("error getting status %s: %w", target, err)
+		}
+
+		return printJSON(status)
+	}
+}
+
+// Account returns an action which prints the account with
+// the given ID, URI or URL, along with its populated
+// relations (avatar, emojis, settings etc), as JSON.
+//
+// The private key of local accounts is never printed.
+func Account(target string) action.GTSAction {
+	return func(ctx context.Context) error {
+		state, err := initState(ctx)
+		if err != nil {
+			return err
+		}
+
+		defer func() {
+			// Ensure state gets stopped on return.
+			if err := stopState(state); err != nil {
+				log.Error(ctx, err)
+			}
+		}()
+
+		account, err := getByTarget(ctx, target,
+			state.DB.GetAccountByID,
+			state.DB.GetAccountByURI,
+			state.DB.GetAccountByURL,
+		)
+		if err != nil {
+			return fmt.Errorf("error getting account %s: %w", target, err)
+		}
+
+		return printJSON(account)
+	}
+}
+
+// getByTarget fetches a model using the given db functions, treating
+// target as a URI (then URL) if it looks like one, else as an ID.
+func getByTarget[T any](
+	ctx context.Context,
+	target string,
+	byID func(context.Context, string) (T, error),
+	byURI func(context.Context, string) (T, error),
+	byURL func(context.Context, string) (T, error),
+) (T, error) {
+	if !strings.HasPrefix(target, "http://") &&
+		!strings.HasPrefix(target, "https://") {
+		return byID(ctx, target)
+	}
+
+	// Most statuses and accounts are
+	// referred to by their URI, but
+	// accept web URLs too, since that's
+	// what people tend to copy + paste.
+	model, err := byURI(ctx, target)
+	if errors.Is(err, db.ErrNoEntries) {
+		model, err = byURL(ctx, target)
+	}
+
+	return model, err
+}
+
+// printJSON prints the given model to stdout
+// as indented JSON, with any account private
+// keys stripped out along the way.
+func printJSON(model any) error {
+	b, err := json.Marshal(model)
+	if err != nil {
+		return fmt.Errorf("error marshaling to json: %w", err)
+	}
+
+	// Unmarshal into a generic value so that
+	// private keys can be dropped wherever
+	// an account appears in the output.
+	//
+	// Use json.Number to avoid mangling large
+	// numbers (eg., public key moduli) by
+	// decoding them as float64.
+	var generic any
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&generic); err != nil {
+		return fmt.Errorf("error unmarshaling json: %w", err)
+	}
+	stripPrivateKeys(generic)
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(generic)
+}
+
+// privateKeyField is the JSON name of
+// gtsmodel.Account{}.PrivateKey, which
+// has no json tag so uses the field name.
+const privateKeyField = "PrivateKey"
+
+// stripPrivateKeys recursively removes
+// private key fields from the given
+// generic JSON value, in place.
+func stripPrivateKeys(v any) {
+	switch v := v.(type) {
+	case map[string]any:
+		delete(v, privateKeyField)
+		for _, child := range v {
+			stripPrivateKeys(child)
+		}
+	case []any:
+		for _, child := range v {
+			stripPrivateKeys(child)
+		}
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
 	"syscall"
@@ -35,6 +36,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/filter/spam"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/metrics"
 	"github.com/superseriousbusiness/gotosocial/internal/middleware"
@@ -61,6 +63,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/web"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 
 	// Inherit memory limit if set from cgroup
 	_ "github.com/KimMachineGun/automemlimit"
@@ -121,12 +124,42 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	// Set the state storage driver
 	state.Storage = storage
 
+	// Parse outgoing HTTP proxy settings
+	var proxy *url.URL
+	if p := config.GetHTTPClientProxy(); p != "" {
+		proxy, err = httpclient.ParseProxy(p)
+		if err != nil {
+			return fmt.Errorf("error parsing %s: %w", config.HTTPClientProxyFlag(), err)
+		}
+	}
+
+	proxyRules, err := httpclient.ParseProxyRules(config.GetHTTPClientProxyRules())
+	if err != nil {
+		return fmt.Errorf("error parsing %s: %w", config.HTTPClientProxyRulesFlag(), err)
+	}
+
+	// Parse outgoing dialing settings
+	network, err := httpclient.ParseIPVersion(config.GetHTTPClientIPVersion())
+	if err != nil {
+		return fmt.Errorf("error parsing %s: %w", config.HTTPClientIPVersionFlag(), err)
+	}
+
+	resolver, err := httpclient.ParseResolver(config.GetHTTPClientResolver())
+	if err != nil {
+		return fmt.Errorf("error parsing %s: %w", config.HTTPClientResolverFlag(), err)
+	}
+
 	// Build HTTP client
 	client := httpclient.New(httpclient.Config{
 		AllowRanges:           config.MustParseIPPrefixes(config.GetHTTPClientAllowIPs()),
 		BlockRanges:           config.MustParseIPPrefixes(config.GetHTTPClientBlockIPs()),
+		Network:               network,
+		HappyEyeballsDelay:    config.GetHTTPClientHappyEyeballsDelay(),
+		Resolver:              resolver,
 		Timeout:               config.GetHTTPClientTimeout(),
 		TLSInsecureSkipVerify: config.GetHTTPClientTLSInsecureSkipVerify(),
+		Proxy:                 proxy,
+		ProxyRules:            proxyRules,
 	})
 
 	// Build handlers used in later initializations.
@@ -156,8 +189,33 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		}
 	}
 
+	// Ensure we have VAPID keys for signing
+	// Web Push requests, generating new ones
+	// if none were configured.
+	if config.GetWebPushVAPIDPublicKey() == "" &&
+		config.GetWebPushVAPIDPrivateKey() == "" {
+		publicKey, privateKey, err := webpush.GenerateVAPIDKeyPair()
+		if err != nil {
+			return fmt.Errorf("error generating vapid keys: %w", err)
+		}
+
+		config.SetWebPushVAPIDPublicKey(publicKey)
+		config.SetWebPushVAPIDPrivateKey(privateKey)
+
+		log.Warnf(ctx,
+			"no vapid keys configured, generated new ones; set %s to %s and %s to %s "+
+				"to keep existing web push subscriptions working across restarts",
+			config.WebPushVAPIDPublicKeyFlag(), publicKey,
+			config.WebPushVAPIDPrivateKeyFlag(), privateKey,
+		)
+	}
+
+	webPushSender := webpush.NewSender(client, &state)
+
 	// Initialize timelines.
-	state.Timelines.Home = timeline.NewManager(
+	state.Timelines.Home = timeline.NewConfiguredManager(
+		state.DB,
+		gtsmodel.TimelineTypeHome,
 		tlprocessor.HomeTimelineGrab(&state),
 		tlprocessor.HomeTimelineFilter(&state, visFilter),
 		tlprocessor.HomeTimelineStatusPrepare(&state, typeConverter),
@@ -167,7 +225,9 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		return fmt.Errorf("error starting home timeline: %s", err)
 	}
 
-	state.Timelines.List = timeline.NewManager(
+	state.Timelines.List = timeline.NewConfiguredManager(
+		state.DB,
+		gtsmodel.TimelineTypeList,
 		tlprocessor.ListTimelineGrab(&state),
 		tlprocessor.ListTimelineFilter(&state, visFilter),
 		tlprocessor.ListTimelineStatusPrepare(&state, typeConverter),
@@ -206,6 +266,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		mediaManager,
 		&state,
 		emailSender,
+		webPushSender,
 	)
 
 	// Initialize the specialized workers.
@@ -224,11 +285,78 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		return fmt.Errorf("error scheduling poll expiries: %w", err)
 	}
 
+	// Schedule tasks for all upcoming announcement starts / ends.
+	if err := processor.Admin().ScheduleAnnouncements(ctx); err != nil {
+		return fmt.Errorf("error scheduling announcements: %w", err)
+	}
+
+	// Schedule tasks for all existing block expiries.
+	if err := processor.Account().ScheduleBlockExpiries(ctx); err != nil {
+		return fmt.Errorf("error scheduling block expiries: %w", err)
+	}
+
+	// Schedule tasks for all existing mute expiries.
+	if err := processor.Account().ScheduleMuteExpiries(ctx); err != nil {
+		return fmt.Errorf("error scheduling mute expiries: %w", err)
+	}
+
+	// Schedule tasks for all existing account freezes.
+	if err := processor.Admin().ScheduleUnfreezes(ctx); err != nil {
+		return fmt.Errorf("error scheduling unfreezes: %w", err)
+	}
+
+	// Requeue generation of account exports
+	// interrupted by the last shutdown.
+	if err := processor.Exports().RequeueUnfinished(ctx); err != nil {
+		return fmt.Errorf("error requeueing account exports: %w", err)
+	}
+
+	// Requeue processing of account imports
+	// interrupted by the last shutdown.
+	if err := processor.Imports().RequeueUnfinished(ctx); err != nil {
+		return fmt.Errorf("error requeueing account imports: %w", err)
+	}
+
+	// Build or clear the status search
+	// index according to configuration.
+	if err := processor.Search().PrepareStatusIndex(ctx); err != nil {
+		return fmt.Errorf("error preparing status search index: %w", err)
+	}
+
+	// Schedule publishing of scheduled statuses as they fall due.
+	if err := processor.Workers().ScheduleStatusPublishing(); err != nil {
+		return fmt.Errorf("error scheduling status publishing: %w", err)
+	}
+
+	// Regularly persist outgoing delivery stats.
+	if err := processor.Admin().ScheduleDeliveryStatsFlush(); err != nil {
+		return fmt.Errorf("error scheduling delivery stats flush: %w", err)
+	}
+
+	// Regularly drop expired outgoing delivery log entries.
+	if err := processor.Admin().ScheduleDeliveryLogPrune(); err != nil {
+		return fmt.Errorf("error scheduling delivery log prune: %w", err)
+	}
+
+	// Clear out abandoned media upload files, and
+	// schedule regular sweeps of abandoned uploads.
+	if err := processor.Media().ScheduleUploadSweep(ctx); err != nil {
+		return fmt.Errorf("error scheduling upload sweep: %w", err)
+	}
+
 	// Initialize metrics.
 	if err := metrics.Initialize(state.DB); err != nil {
 		return fmt.Errorf("error initializing metrics: %w", err)
 	}
 
+	if err := metrics.InstrumentHTTPClient(client); err != nil {
+		return fmt.Errorf("error initializing http client metrics: %w", err)
+	}
+
+	if err := metrics.InstrumentStreams(processor.Stream().Stats); err != nil {
+		return fmt.Errorf("error initializing streaming metrics: %w", err)
+	}
+
 	/*
 		HTTP router initialization
 	*/
@@ -264,6 +392,11 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		middleware.ExtraHeaders(),
 	}...)
 
+	// Advertise onion service if configured.
+	if onionHost := config.GetOnionHost(); onionHost != "" {
+		middlewares = append(middlewares, middleware.OnionLocation(onionHost))
+	}
+
 	// Instantiate Content-Security-Policy
 	// middleware, with extra URIs.
 	cspExtraURIs := make([]string, 0)
@@ -0,0 +1,586 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package testrig
+
+import (
+	"bytes"
+	"context"
+	crand "crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// seedSpan is how far back in time
+// seeded accounts and statuses go.
+const seedSpan = 30 * 24 * time.Hour
+
+// seedWords are used to generate
+// display names and status content.
+var seedWords = []string{
+	"apple", "bicycle", "cloud", "dolphin", "ember", "fjord", "garden",
+	"harbor", "island", "jigsaw", "kettle", "lantern", "meadow", "nebula",
+	"orchard", "pebble", "quartz", "river", "saffron", "thistle", "umbrella",
+	"velvet", "willow", "xylophone", "yarrow", "zephyr",
+}
+
+// Seed fills the configured database (and storage) with synthetic
+// local accounts, follows between them, statuses, media attachments,
+// and fave notifications, for load testing timelines and caches.
+//
+// Generation is driven by a seeded random number generator, so the
+// same seed and counts always produce the same usernames, follow
+// graph, and status contents (though not the same IDs).
+var Seed action.GTSAction = func(ctx context.Context) error {
+	var state state.State
+
+	state.Caches.Init()
+	state.Caches.Start()
+	defer state.Caches.Stop()
+
+	dbService, err := bundb.NewBunDBService(ctx, &state)
+	if err != nil {
+		return fmt.Errorf("error creating dbservice: %w", err)
+	}
+	state.DB = dbService
+
+	defer func() {
+		if err := dbService.Close(); err != nil {
+			log.Error(ctx, err)
+		}
+	}()
+
+	//nolint:contextcheck
+	storage, err := gtsstorage.AutoConfig()
+	if err != nil {
+		return fmt.Errorf("error creating storage backend: %w", err)
+	}
+	state.Storage = storage
+
+	defer func() {
+		if err := storage.Close(); err != nil {
+			log.Error(ctx, err)
+		}
+	}()
+
+	randSeed := config.GetTestrigSeedRandom()
+
+	s := &seeder{
+		state:    &state,
+		manager:  media.NewManager(&state),
+		rand:     rand.New(rand.NewSource(int64(randSeed))), //nolint:gosec
+		randSeed: randSeed,
+		start:    time.Now().Add(-seedSpan),
+		faved:    make(map[string]struct{}),
+	}
+
+	return s.seed(ctx,
+		config.GetTestrigSeedAccounts(),
+		config.GetTestrigSeedFollows(),
+		config.GetTestrigSeedStatuses(),
+		config.GetTestrigSeedMedia(),
+		config.GetTestrigSeedNotifications(),
+		config.GetAdminAccountPassword(),
+	)
+}
+
+// seeder wraps state and
+// generation for Seed.
+type seeder struct {
+	state    *state.State
+	manager  *media.Manager
+	rand     *rand.Rand
+	randSeed int
+	start    time.Time
+
+	// keys of faves already created,
+	// in the form {accountID}{statusID}.
+	faved map[string]struct{}
+}
+
+func (s *seeder) seed(
+	ctx context.Context,
+	accounts int,
+	follows int,
+	statuses int,
+	attachments int,
+	notifications int,
+	password string,
+) error {
+	if accounts < 0 || follows < 0 || statuses < 0 ||
+		attachments < 0 || notifications < 0 {
+		return errors.New("counts must not be negative")
+	}
+
+	// Can't follow more accounts than there are
+	// (others), or attach more media than there
+	// are statuses to attach it to.
+	follows = min(follows, max(accounts-1, 0))
+	attachments = min(attachments, statuses)
+
+	// Check we haven't already seeded with
+	// this seed, since usernames would clash.
+	if accounts > 0 {
+		username := s.username(0)
+		_, err := s.state.DB.GetAccountByUsernameDomain(ctx, username, "")
+		if err == nil {
+			return fmt.Errorf("account %s already exists; use a different --%s", username, config.TestrigSeedRandomFlag())
+		}
+		if !errors.Is(err, db.ErrNoEntries) {
+			return fmt.Errorf("error checking for existing account: %w", err)
+		}
+	}
+
+	// Generating rsa keys and bcrypt hashes
+	// is slow, and seeded accounts don't need
+	// unique ones, so do it just once for all.
+	privKey, err := rsa.GenerateKey(crand.Reader, 2048)
+	if err != nil {
+		return fmt.Errorf("error creating rsa private key: %w", err)
+	}
+
+	encryptedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("error hashing password: %w", err)
+	}
+
+	seeded := make([]*gtsmodel.Account, accounts)
+	for i := range seeded {
+		seeded[i], err = s.account(ctx, i, privKey, string(encryptedPassword))
+		if err != nil {
+			return fmt.Errorf("error creating account: %w", err)
+		}
+	}
+	log.Infof(ctx, "created %d accounts", len(seeded))
+
+	var followCount int
+	for _, account := range seeded {
+		n, err := s.follows(ctx, account, seeded, follows)
+		if err != nil {
+			return fmt.Errorf("error creating follows: %w", err)
+		}
+		followCount += n
+	}
+	log.Infof(ctx, "created %d follows", followCount)
+
+	// Statuses of each account, by account
+	// index, for picking statuses to fave.
+	seededStatuses := make([][]*gtsmodel.Status, len(seeded))
+
+	var statusCount int
+	for i, account := range seeded {
+		seededStatuses[i] = make([]*gtsmodel.Status, statuses)
+		for j := range seededStatuses[i] {
+			// Attach media to the first
+			// statuses of each account.
+			withMedia := j < attachments
+
+			seededStatuses[i][j], err = s.status(ctx, account, withMedia)
+			if err != nil {
+				return fmt.Errorf("error creating status: %w", err)
+			}
+			statusCount++
+		}
+	}
+	log.Infof(ctx, "created %d statuses", statusCount)
+
+	var notifCount int
+	if statuses > 0 && accounts > 1 {
+		for i, account := range seeded {
+			for j := 0; j < notifications; j++ {
+				// Pick another account to fave
+				// one of this account's statuses.
+				faver := seeded[(i+1+s.rand.Intn(accounts-1))%accounts]
+				status := seededStatuses[i][s.rand.Intn(statuses)]
+
+				created, err := s.fave(ctx, faver, account, status)
+				if err != nil {
+					return fmt.Errorf("error creating fave: %w", err)
+				}
+				if created {
+					notifCount++
+				}
+			}
+		}
+	}
+	log.Infof(ctx, "created %d faves and notifications", notifCount)
+
+	return nil
+}
+
+// username returns the username of
+// the i'th seeded account for this seed.
+func (s *seeder) username(i int) string {
+	return "seed" + strconv.Itoa(s.randSeed) + "_" + strconv.Itoa(i)
+}
+
+// words returns n space-separated random seed words.
+func (s *seeder) words(n int) string {
+	words := make([]string, n)
+	for i := range words {
+		words[i] = seedWords[s.rand.Intn(len(seedWords))]
+	}
+	return strings.Join(words, " ")
+}
+
+// timeAfter returns a random time
+// between the given time and now.
+func (s *seeder) timeAfter(t time.Time) time.Time {
+	span := time.Since(t)
+	if span <= 0 {
+		return t
+	}
+
+	// Use a float rather than eg., Int63n, which
+	// may use a varying amount of random numbers
+	// depending on span, changing everything after.
+	return t.Add(time.Duration(s.rand.Float64() * float64(span)))
+}
+
+func (s *seeder) account(
+	ctx context.Context,
+	i int,
+	privKey *rsa.PrivateKey,
+	encryptedPassword string,
+) (*gtsmodel.Account, error) {
+	var (
+		username  = s.username(i)
+		uris      = uris.GenerateURIsForAccount(username)
+		createdAt = s.start.Add(time.Duration(i) * time.Second)
+	)
+
+	accountID, err := id.NewULIDFromTime(createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := &gtsmodel.AccountSettings{
+		AccountID:                  accountID,
+		CreatedAt:                  createdAt,
+		Privacy:                    gtsmodel.VisibilityDefault,
+		NotifPolicyNotFollowing:    gtsmodel.NotificationPolicyAccept,
+		NotifPolicyNotFollowers:    gtsmodel.NotificationPolicyAccept,
+		NotifPolicyNewAccounts:     gtsmodel.NotificationPolicyAccept,
+		NotifPolicyPrivateMentions: gtsmodel.NotificationPolicyAccept,
+	}
+
+	if err := s.state.DB.PutAccountSettings(ctx, settings); err != nil {
+		return nil, err
+	}
+
+	account := &gtsmodel.Account{
+		ID:                    accountID,
+		CreatedAt:             createdAt,
+		Username:              username,
+		DisplayName:           s.words(2),
+		Note:                  "<p>" + s.words(10) + "</p>",
+		NoteRaw:               s.words(10),
+		URI:                   uris.UserURI,
+		URL:                   uris.UserURL,
+		InboxURI:              uris.InboxURI,
+		OutboxURI:             uris.OutboxURI,
+		FollowingURI:          uris.FollowingURI,
+		FollowersURI:          uris.FollowersURI,
+		FeaturedCollectionURI: uris.FeaturedCollectionURI,
+		ActorType:             ap.ActorPerson,
+		PrivateKey:            privKey,
+		PublicKey:             &privKey.PublicKey,
+		PublicKeyURI:          uris.PublicKeyURI,
+		Locked:                util.Ptr(false),
+		Discoverable:          util.Ptr(true),
+		Settings:              settings,
+	}
+
+	if err := s.state.DB.PutAccount(ctx, account); err != nil {
+		return nil, err
+	}
+
+	userID, err := id.NewULIDFromTime(createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &gtsmodel.User{
+		ID:                userID,
+		CreatedAt:         createdAt,
+		AccountID:         accountID,
+		Account:           account,
+		EncryptedPassword: encryptedPassword,
+		Email:             username + "@example.org",
+		ConfirmedAt:       createdAt,
+		Locale:            "en",
+		Moderator:         util.Ptr(false),
+		Admin:             util.Ptr(false),
+		Disabled:          util.Ptr(false),
+		Approved:          util.Ptr(true),
+	}
+
+	if err := s.state.DB.PutUser(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// follows makes the given account follow n
+// distinct others from seeded, returning
+// the number of follows created.
+func (s *seeder) follows(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	seeded []*gtsmodel.Account,
+	n int,
+) (int, error) {
+	targets := make(map[string]struct{}, n)
+	for len(targets) < n {
+		target := seeded[s.rand.Intn(len(seeded))]
+		if target.ID == account.ID {
+			continue
+		}
+		if _, ok := targets[target.ID]; ok {
+			continue
+		}
+		targets[target.ID] = struct{}{}
+
+		createdAt := s.timeAfter(target.CreatedAt)
+		followID, err := id.NewULIDFromTime(createdAt)
+		if err != nil {
+			return 0, err
+		}
+
+		if err := s.state.DB.PutFollow(ctx, &gtsmodel.Follow{
+			ID:              followID,
+			CreatedAt:       createdAt,
+			URI:             uris.GenerateURIForFollow(account.Username, followID),
+			AccountID:       account.ID,
+			TargetAccountID: target.ID,
+			ShowReblogs:     util.Ptr(true),
+			Notify:          util.Ptr(false),
+		}); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(targets), nil
+}
+
+func (s *seeder) status(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	withMedia bool,
+) (*gtsmodel.Status, error) {
+	createdAt := s.timeAfter(account.CreatedAt)
+	statusID, err := id.NewULIDFromTime(createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	// Mostly public statuses,
+	// with some of the others.
+	visibility := gtsmodel.VisibilityPublic
+	switch s.rand.Intn(10) {
+	case 0:
+		visibility = gtsmodel.VisibilityUnlocked
+	case 1:
+		visibility = gtsmodel.VisibilityFollowersOnly
+	}
+
+	text := s.words(5 + s.rand.Intn(20))
+
+	// Generate the image even if it won't be
+	// used, to keep the sequence of random
+	// numbers independent of media counts.
+	img := s.image()
+
+	var attachmentIDs []string
+	if withMedia {
+		description := s.words(3)
+		attachment, err := s.manager.PreProcessMedia(
+			func(context.Context) (io.ReadCloser, int64, error) {
+				return io.NopCloser(bytes.NewReader(img)), int64(len(img)), nil
+			},
+			account.ID,
+			&media.AdditionalMediaInfo{
+				CreatedAt:   &createdAt,
+				StatusID:    &statusID,
+				Description: &description,
+			},
+		).LoadAttachment(ctx)
+		if err != nil {
+			return nil, gtserror.Newf("error processing media: %w", err)
+		}
+		attachmentIDs = []string{attachment.ID}
+	}
+
+	threadID, err := id.NewULIDFromTime(createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.state.DB.PutThread(ctx, &gtsmodel.Thread{
+		ID:        threadID,
+		StatusIDs: []string{statusID},
+	}); err != nil {
+		return nil, err
+	}
+
+	userURIs := uris.GenerateURIsForAccount(account.Username)
+	status := &gtsmodel.Status{
+		ID:                  statusID,
+		CreatedAt:           createdAt,
+		UpdatedAt:           createdAt,
+		URI:                 userURIs.StatusesURI + "/" + statusID,
+		URL:                 userURIs.StatusesURL + "/" + statusID,
+		Content:             "<p>" + text + "</p>",
+		Text:                text,
+		AttachmentIDs:       attachmentIDs,
+		Local:               util.Ptr(true),
+		AccountURI:          account.URI,
+		AccountID:           account.ID,
+		ThreadID:            threadID,
+		Visibility:          visibility,
+		Sensitive:           util.Ptr(false),
+		Language:            "en",
+		ActivityStreamsType: ap.ObjectNote,
+		Federated:           util.Ptr(true),
+		Boostable:           util.Ptr(true),
+		Replyable:           util.Ptr(true),
+		Likeable:            util.Ptr(true),
+	}
+
+	if err := s.state.DB.PutStatus(ctx, status); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// image returns a small generated png,
+// a randomly colored background with
+// a randomly colored block on top.
+func (s *seeder) image() []byte {
+	randColor := func() color.Color {
+		return color.RGBA{
+			R: uint8(s.rand.Intn(256)),
+			G: uint8(s.rand.Intn(256)),
+			B: uint8(s.rand.Intn(256)),
+			A: 255,
+		}
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, 320, 240))
+	draw.Draw(img, img.Bounds(), &image.Uniform{randColor()}, image.Point{}, draw.Src)
+
+	x, y := s.rand.Intn(240), s.rand.Intn(160)
+	block := image.Rect(x, y, x+80, y+80)
+	draw.Draw(img, block, &image.Uniform{randColor()}, image.Point{}, draw.Src)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		// Encoding to a buffer can't
+		// fail for a valid rgba image.
+		panic(err)
+	}
+
+	return buf.Bytes()
+}
+
+// fave makes faver fave the given status of
+// account, and notifies account of it, unless
+// faver already faved it. Returns whether the
+// fave and notification were created.
+func (s *seeder) fave(
+	ctx context.Context,
+	faver *gtsmodel.Account,
+	account *gtsmodel.Account,
+	status *gtsmodel.Status,
+) (bool, error) {
+	key := faver.ID + status.ID
+	if _, ok := s.faved[key]; ok {
+		return false, nil
+	}
+	s.faved[key] = struct{}{}
+
+	createdAt := s.timeAfter(status.CreatedAt)
+	faveID, err := id.NewULIDFromTime(createdAt)
+	if err != nil {
+		return false, err
+	}
+
+	if err := s.state.DB.PutStatusFave(ctx, &gtsmodel.StatusFave{
+		ID:              faveID,
+		CreatedAt:       createdAt,
+		AccountID:       faver.ID,
+		TargetAccountID: account.ID,
+		StatusID:        status.ID,
+		URI:             uris.GenerateURIForLike(faver.Username, faveID),
+	}); err != nil {
+		return false, err
+	}
+
+	notifID, err := id.NewULIDFromTime(createdAt)
+	if err != nil {
+		return false, err
+	}
+
+	// Group faves of the same status by the hour,
+	// roughly as the notification surface would.
+	hour := createdAt.Unix() / int64(time.Hour/time.Second)
+	groupKey := string(gtsmodel.NotificationFave) + "-" + status.ID + "-" + strconv.FormatInt(hour, 10)
+
+	if err := s.state.DB.PutNotification(ctx, &gtsmodel.Notification{
+		ID:               notifID,
+		CreatedAt:        createdAt,
+		NotificationType: gtsmodel.NotificationFave,
+		TargetAccountID:  account.ID,
+		OriginAccountID:  faver.ID,
+		StatusID:         status.ID,
+		Read:             util.Ptr(false),
+		GroupKey:         groupKey,
+		Filtered:         util.Ptr(false),
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
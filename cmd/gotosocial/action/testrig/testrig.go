@@ -37,6 +37,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/gotosocial"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/language"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/metrics"
@@ -120,7 +121,9 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	filter := visibility.NewFilter(&state)
 
 	// Initialize timelines.
-	state.Timelines.Home = timeline.NewManager(
+	state.Timelines.Home = timeline.NewConfiguredManager(
+		state.DB,
+		gtsmodel.TimelineTypeHome,
 		tlprocessor.HomeTimelineGrab(&state),
 		tlprocessor.HomeTimelineFilter(&state, filter),
 		tlprocessor.HomeTimelineStatusPrepare(&state, typeConverter),
@@ -130,7 +133,9 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		return fmt.Errorf("error starting home timeline: %s", err)
 	}
 
-	state.Timelines.List = timeline.NewManager(
+	state.Timelines.List = timeline.NewConfiguredManager(
+		state.DB,
+		gtsmodel.TimelineTypeList,
 		tlprocessor.ListTimelineGrab(&state),
 		tlprocessor.ListTimelineFilter(&state, filter),
 		tlprocessor.ListTimelineStatusPrepare(&state, typeConverter),
@@ -20,6 +20,8 @@ package main
 import (
 	"github.com/spf13/cobra"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/account"
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/database"
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/inspect"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/media"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/media/prune"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/trans"
@@ -55,6 +57,25 @@ func adminCommands() *cobra.Command {
 	config.AddAdminAccountCreate(adminAccountCreateCmd)
 	adminAccountCmd.AddCommand(adminAccountCreateCmd)
 
+	adminAccountBotCmd := &cobra.Command{
+		Use:   "bot",
+		Short: "admin commands related to local bot accounts",
+	}
+
+	adminAccountBotCreateCmd := &cobra.Command{
+		Use:   "create",
+		Short: "create a new local bot account, and print a long-lived access token for it",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), account.BotCreate)
+		},
+	}
+	config.AddAdminAccountBotCreate(adminAccountBotCreateCmd)
+	adminAccountBotCmd.AddCommand(adminAccountBotCreateCmd)
+	adminAccountCmd.AddCommand(adminAccountBotCmd)
+
 	adminAccountListCmd := &cobra.Command{
 		Use:   "list",
 		Short: "list all existing local accounts",
@@ -67,6 +88,19 @@ func adminCommands() *cobra.Command {
 	}
 	adminAccountCmd.AddCommand(adminAccountListCmd)
 
+	adminAccountRecountCmd := &cobra.Command{
+		Use:   "recount",
+		Short: "recount followers, following, and statuses stats of one local account (if username is set) or of all accounts",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), account.Recount)
+		},
+	}
+	config.AddAdminAccountRecount(adminAccountRecountCmd)
+	adminAccountCmd.AddCommand(adminAccountRecountCmd)
+
 	adminAccountConfirmCmd := &cobra.Command{
 		Use:   "confirm",
 		Short: "confirm an existing local account manually, thereby skipping email confirmation",
@@ -146,6 +180,33 @@ func adminCommands() *cobra.Command {
 	config.AddAdminAccountPassword(adminAccountPasswordCmd)
 	adminAccountCmd.AddCommand(adminAccountPasswordCmd)
 
+	adminAccountPasswordResetCmd := &cobra.Command{
+		Use:   "password-reset",
+		Short: "generate a one-time link which the owner of the given local account can use to set a new password",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), account.PasswordReset)
+		},
+	}
+	config.AddAdminAccount(adminAccountPasswordResetCmd)
+	adminAccountCmd.AddCommand(adminAccountPasswordResetCmd)
+
+	adminAccountEmailChangeCmd := &cobra.Command{
+		Use:   "email-change",
+		Short: "set a new email address for the given local account, and generate a one-time link to confirm it",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), account.EmailChange)
+		},
+	}
+	config.AddAdminAccount(adminAccountEmailChangeCmd)
+	config.AddAdminAccountEmail(adminAccountEmailChangeCmd)
+	adminAccountCmd.AddCommand(adminAccountEmailChangeCmd)
+
 	adminCmd.AddCommand(adminAccountCmd)
 
 	/*
@@ -251,6 +312,19 @@ func adminCommands() *cobra.Command {
 	config.AddAdminMediaPrune(adminMediaPruneRemoteCmd)
 	adminMediaPruneCmd.AddCommand(adminMediaPruneRemoteCmd)
 
+	adminMediaPruneDuplicatesCmd := &cobra.Command{
+		Use:   "duplicates",
+		Short: "hash media files and remove files duplicating the content of another",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), prune.Duplicates)
+		},
+	}
+	config.AddAdminMediaPrune(adminMediaPruneDuplicatesCmd)
+	adminMediaPruneCmd.AddCommand(adminMediaPruneDuplicatesCmd)
+
 	adminMediaPruneAllCmd := &cobra.Command{
 		Use:   "all",
 		Short: "perform all media and emoji prune / cleaning commands",
@@ -268,5 +342,64 @@ func adminCommands() *cobra.Command {
 
 	adminCmd.AddCommand(adminMediaCmd)
 
+	/*
+		ADMIN DATABASE COMMANDS
+	*/
+	adminDatabaseCmd := &cobra.Command{
+		Use:   "database",
+		Short: "admin commands related to database maintenance",
+	}
+
+	adminDatabaseOrphansCmd := &cobra.Command{
+		Use:   "orphans",
+		Short: "find orphaned database rows (attachments and mentions of missing statuses, notifications of missing accounts), and delete them if not a dry run",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), database.Orphans)
+		},
+	}
+	config.AddAdminDatabaseOrphans(adminDatabaseOrphansCmd)
+	adminDatabaseCmd.AddCommand(adminDatabaseOrphansCmd)
+
+	adminCmd.AddCommand(adminDatabaseCmd)
+
+	/*
+		ADMIN INSPECT COMMANDS
+	*/
+	adminInspectCmd := &cobra.Command{
+		Use:   "inspect",
+		Short: "admin commands for inspecting database records",
+	}
+
+	adminInspectStatusCmd := &cobra.Command{
+		Use:   "status [id|uri]",
+		Short: "print the database record of a status with the given ID, URI or URL, including its populated relations, as JSON",
+		Args:  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), inspect.Status(args[0]))
+		},
+	}
+	adminInspectCmd.AddCommand(adminInspectStatusCmd)
+
+	adminInspectAccountCmd := &cobra.Command{
+		Use:   "account [id|uri]",
+		Short: "print the database record of an account with the given ID, URI or URL, including its populated relations, as JSON",
+		Args:  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), inspect.Account(args[0]))
+		},
+	}
+	adminInspectCmd.AddCommand(adminInspectAccountCmd)
+
+	adminCmd.AddCommand(adminInspectCmd)
+
 	return adminCmd
 }
@@ -20,6 +20,7 @@ package main
 import (
 	"github.com/spf13/cobra"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/testrig"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 )
 
 func testrigCommands() *cobra.Command {
@@ -37,5 +38,19 @@ func testrigCommands() *cobra.Command {
 	}
 
 	testrigCmd.AddCommand(testrigStartCmd)
+
+	testrigSeedCmd := &cobra.Command{
+		Use:   "seed",
+		Short: "fill the configured database with synthetic accounts, follows, statuses, media, and notifications for load testing",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), testrig.Seed)
+		},
+	}
+	config.AddTestrigSeed(testrigSeedCmd)
+	testrigCmd.AddCommand(testrigSeedCmd)
+
 	return testrigCmd
 }
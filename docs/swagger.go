@@ -32,32 +32,45 @@
 //	    tokenUrl: https://example.org/oauth/token
 //	    scopes:
 //	      read: grants read access to everything
-//	      read:accounts: grants read access to accounts
-//	      read:blocks: grant read access to blocks
-//	      read:custom_emojis: grant read access to custom_emojis
-//	      read:favourites: grant read access to favourites
-//	      read:filters: grant read access to filters
-//	      read:follows: grant read access to follows
-//	      read:lists: grant read access to lists
-//	      read:media: grant read access to media
-//	      read:mutes: grant read access to mutes
-//	      read:search: grant read access to searches
-//	      read:statuses: grants read access to statuses
-//	      read:streaming: grants read access to streaming api
-//	      read:user: grants read access to user-level info
+//	      read:accounts: grants read access to accounts and account settings
+//	      read:blocks: grants read access to blocks
+//	      read:bookmarks: grants read access to bookmarks
+//	      read:favourites: grants read access to favourites
+//	      read:filters: grants read access to filters
+//	      read:follows: grants read access to follows
+//	      read:lists: grants read access to lists
+//	      read:mutes: grants read access to mutes
 //	      read:notifications: grants read access to notifications
+//	      read:reports: grants read access to reports
+//	      read:search: grants read access to searches
+//	      read:statuses: grants read access to statuses, timelines, and streaming
 //	      write: grants write access to everything
-//	      write:accounts: grants write access to accounts
+//	      write:accounts: grants write access to accounts and account settings
 //	      write:blocks: grants write access to blocks
+//	      write:bookmarks: grants write access to bookmarks
+//	      write:conversations: grants write access to conversations
+//	      write:favourites: grants write access to favourites
 //	      write:filters: grants write access to filters
 //	      write:follows: grants write access to follows
 //	      write:lists: grants write access to lists
 //	      write:media: grants write access to media
 //	      write:mutes: grants write access to mutes
+//	      write:notifications: grants write access to notifications
+//	      write:reports: grants write access to reports
 //	      write:statuses: grants write access to statuses
-//	      write:user: grants write access to user-level info
+//	      follow: grants read and write access to follows, blocks, and mutes
+//	      push: grants access to push notifications
 //	      admin: grants admin access to everything
-//	      admin:accounts: grants admin access to accounts
+//	      admin:read: grants admin read access to everything
+//	      admin:read:accounts: grants admin read access to accounts
+//	      admin:read:domain_allows: grants admin read access to domain allows
+//	      admin:read:domain_blocks: grants admin read access to domain blocks
+//	      admin:read:reports: grants admin read access to reports
+//	      admin:write: grants admin write access to everything
+//	      admin:write:accounts: grants admin write access to accounts
+//	      admin:write:domain_allows: grants admin write access to domain allows
+//	      admin:write:domain_blocks: grants admin write access to domain blocks
+//	      admin:write:reports: grants admin write access to reports
 //	  OAuth2 Application:
 //	    type: oauth2
 //	    flow: application
@@ -77,6 +77,25 @@ const (
 	// See https://www.w3.org/TR/activitystreams-vocabulary/#microsyntaxes
 	// and https://www.w3.org/TR/activitystreams-vocabulary/#dfn-tag
 	TagHashtag = "Hashtag"
+
+	// ObjectLinkMediaType is the media type set on FEP-e232 object
+	// links, ie., Links in the tag property that point to another
+	// AS object rather than to a web page. Used for quote posts.
+	//
+	// See https://codeberg.org/fediverse/fep/src/branch/main/fep/e232/fep-e232.md
+	ObjectLinkMediaType = `application/ld+json; profile="https://www.w3.org/ns/activitystreams"`
+
+	// QuoteLinkRel is the rel set on FEP-e232 object links
+	// to indicate that the linked object is being quoted.
+	QuoteLinkRel = "https://misskey-hub.net/ns#_misskey_quote"
+
+	// ActivityEmojiReact is the Pleroma / Litepub emoji reaction
+	// activity. It's not in the AS vocab, so incoming EmojiReacts
+	// are normalized into Likes with content (as Misskey sends them),
+	// and this name is used internally to route emoji reactions.
+	//
+	// See https://docs.pleroma.social/backend/development/ap_extensions/#emojireact
+	ActivityEmojiReact = "EmojiReact"
 )
 
 // isActivity returns whether AS type name is of an Activity (NOT IntransitiveActivity).
@@ -27,11 +27,13 @@ import (
 	"net/url"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/superseriousbusiness/activity/pub"
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/regexes"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
@@ -672,7 +674,7 @@ func ExtractAttachments(i WithAttachment) ([]*gtsmodel.MediaAttachment, error) {
 }
 
 // ExtractAttachment extracts a minimal gtsmodel.Attachment
-// (just remote URL, description, and blurhash) from the given
+// (just remote URL, description, blurhash, and sensitive) from the given
 // Attachmentable interface, or an error if no remote URL is set.
 func ExtractAttachment(i Attachmentable) (*gtsmodel.MediaAttachment, error) {
 	// Get the URL for the attachment file.
@@ -682,12 +684,22 @@ func ExtractAttachment(i Attachmentable) (*gtsmodel.MediaAttachment, error) {
 		return nil, gtserror.Newf("error extracting attachment URL: %w", err)
 	}
 
-	return &gtsmodel.MediaAttachment{
+	attachment := &gtsmodel.MediaAttachment{
 		RemoteURL:   remoteURL.String(),
 		Description: ExtractDescription(i),
 		Blurhash:    ExtractBlurhash(i),
 		Processing:  gtsmodel.ProcessingStatusReceived,
-	}, nil
+	}
+
+	// Not all attachment types have
+	// the sensitive property, but use
+	// it if set, to mark single pieces
+	// of media as sensitive by themselves.
+	if withSensitive, ok := i.(WithSensitive); ok {
+		attachment.Sensitive = util.Ptr(ExtractSensitive(withSensitive))
+	}
+
+	return attachment, nil
 }
 
 // ExtractDescription extracts the image description
@@ -891,6 +903,75 @@ func ExtractEmoji(i Emojiable) (*gtsmodel.Emoji, error) {
 	}, nil
 }
 
+// ExtractEmojiReaction extracts the emoji reaction carried in the content
+// of the given EmojiReactable, returning either a unicode emoji, or a custom
+// emoji shortcode (without colons) along with the matching (barebones)
+// custom emoji from the tags of the EmojiReactable.
+//
+// Returns an empty string if there's no usable reaction content,
+// in which case the EmojiReactable should be treated as a plain Like.
+func ExtractEmojiReaction(i EmojiReactable) (string, *gtsmodel.Emoji) {
+	content := strings.TrimSpace(ExtractContent(i).Content)
+	if content == "" {
+		return "", nil
+	}
+
+	if len(content) > 2 &&
+		content[0] == ':' &&
+		content[len(content)-1] == ':' {
+		// Looks like a custom emoji, find
+		// the matching emoji in the tags.
+		shortcode := content[1 : len(content)-1]
+		if !regexes.EmojiValidator.MatchString(shortcode) {
+			return "", nil
+		}
+
+		emojis, _ := ExtractEmojis(i)
+		for _, emoji := range emojis {
+			if emoji.Shortcode == shortcode {
+				return shortcode, emoji
+			}
+		}
+
+		// Custom emoji we
+		// can't do anything with.
+		return "", nil
+	}
+
+	if !IsUnicodeEmojiReaction(content) {
+		return "", nil
+	}
+
+	return content, nil
+}
+
+// IsUnicodeEmojiReaction returns whether the given string
+// looks like a single unicode emoji usable as a reaction.
+// This is deliberately lax, as there is no sensible way of
+// validating against the ever-growing set of emoji sequences:
+// it must be short, not contain whitespace or markup, and
+// contain at least one non-ASCII character.
+func IsUnicodeEmojiReaction(str string) bool {
+	const maxLen = 32 // bytes; long enough for ZWJ sequences.
+	if str == "" || len(str) > maxLen {
+		return false
+	}
+
+	var nonASCII bool
+	for _, r := range str {
+		switch {
+		case unicode.IsSpace(r),
+			r == '<', r == '>',
+			r == ':', r == '&':
+			return false
+		case r > unicode.MaxASCII:
+			nonASCII = true
+		}
+	}
+
+	return nonASCII
+}
+
 // ExtractMentions extracts a slice of minimal gtsmodel.Mentions
 // from a WithTag. If an entry in the WithTag is not a mention,
 // it will be quietly ignored.
@@ -959,6 +1040,58 @@ func ExtractMention(i Mentionable) (*gtsmodel.Mention, error) {
 	}, nil
 }
 
+// ExtractQuoteURI extracts the URI of the object quoted by
+// the given WithTag, taken from the href of the first FEP-e232
+// object link found in its tags. Returns nil if there's none.
+func ExtractQuoteURI(i WithTag) *url.URL {
+	tagsProp := i.GetActivityStreamsTag()
+	if tagsProp == nil {
+		return nil
+	}
+
+	for iter := tagsProp.Begin(); iter != tagsProp.End(); iter = iter.Next() {
+		if !iter.IsActivityStreamsLink() {
+			continue
+		}
+
+		link := iter.GetActivityStreamsLink()
+		if link == nil || !isObjectLink(link) {
+			continue
+		}
+
+		hrefProp := link.GetActivityStreamsHref()
+		if hrefProp == nil || !hrefProp.IsIRI() {
+			continue
+		}
+
+		if href := hrefProp.GetIRI(); href != nil {
+			return href
+		}
+	}
+
+	return nil
+}
+
+// isObjectLink returns whether the given Link is
+// an FEP-e232 object link, judging by its media type.
+func isObjectLink(link vocab.ActivityStreamsLink) bool {
+	mediaTypeProp := link.GetActivityStreamsMediaType()
+	if mediaTypeProp == nil {
+		return false
+	}
+
+	// Be lenient about whitespace
+	// around the profile parameter.
+	mediaType := strings.ReplaceAll(mediaTypeProp.Get(), " ", "")
+	switch mediaType {
+	case strings.ReplaceAll(ObjectLinkMediaType, " ", ""),
+		"application/activity+json":
+		return true
+	default:
+		return false
+	}
+}
+
 // ExtractActorURI extracts the first Actor URI
 // it can find from a WithActor interface.
 func ExtractActorURI(withActor WithActor) (*url.URL, error) {
@@ -1305,6 +1438,62 @@ func extractPollOption(t vocab.Type) (name string, votes *int, err error) {
 	return name, votes, nil
 }
 
+// ExtractLikes extracts the "likes" collection of the given
+// WithLikes. If the collection is embedded and has a totalItems
+// count, that count is returned. Otherwise the collection IRI,
+// if any, is returned so that it can be dereferenced separately.
+func ExtractLikes(i WithLikes) (count *int, iri *url.URL) {
+	likesProp := i.GetActivityStreamsLikes()
+	if likesProp == nil {
+		return nil, nil
+	}
+	return extractCollectionCount(likesProp)
+}
+
+// ExtractShares extracts the "shares" collection of the given
+// WithShares. If the collection is embedded and has a totalItems
+// count, that count is returned. Otherwise the collection IRI,
+// if any, is returned so that it can be dereferenced separately.
+func ExtractShares(i WithShares) (count *int, iri *url.URL) {
+	sharesProp := i.GetActivityStreamsShares()
+	if sharesProp == nil {
+		return nil, nil
+	}
+	return extractCollectionCount(sharesProp)
+}
+
+// collectionProp is the common subset of
+// collection-valued properties, eg., likes
+// and shares, used by extractCollectionCount.
+type collectionProp interface {
+	GetActivityStreamsCollection() vocab.ActivityStreamsCollection
+	GetActivityStreamsOrderedCollection() vocab.ActivityStreamsOrderedCollection
+	GetIRI() *url.URL
+}
+
+// extractCollectionCount extracts either the totalItems count
+// of the embedded collection in given prop, or else its IRI.
+func extractCollectionCount(prop collectionProp) (*int, *url.URL) {
+	var collection CollectionIterator
+	switch {
+	case prop.GetActivityStreamsCollection() != nil:
+		collection = WrapCollection(prop.GetActivityStreamsCollection())
+	case prop.GetActivityStreamsOrderedCollection() != nil:
+		collection = WrapOrderedCollection(prop.GetActivityStreamsOrderedCollection())
+	default:
+		// Not embedded, at most an IRI.
+		return nil, prop.GetIRI()
+	}
+
+	if count := collection.TotalItems(); count >= 0 {
+		return &count, nil
+	}
+
+	// Embedded but without a count,
+	// try the collection's own ID.
+	return nil, GetJSONLDId(collection)
+}
+
 // isPublic checks if at least one entry in the given
 // uris slice equals the activitystreams public uri.
 func isPublic(uris []*url.URL) bool {
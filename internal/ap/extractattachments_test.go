@@ -77,6 +77,39 @@ func (suite *ExtractAttachmentsTestSuite) TestExtractDescription() {
 	suite.Equal("A very large panel that is entirely twist switches", attachment.Description)
 }
 
+func (suite *ExtractAttachmentsTestSuite) TestExtractSensitive() {
+	attachmentableJSON := `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "mediaType": "image/jpeg",
+  "name": "a spider, up close",
+  "sensitive": true,
+  "type": "Document",
+  "url": "https://example.org/d/XzKw4M2Sc1pBxj3hY4.jpg"
+}`
+
+	raw := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(attachmentableJSON), &raw); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	t, err := streams.ToType(context.Background(), raw)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	attachmentable, ok := t.(ap.Attachmentable)
+	if !ok {
+		suite.FailNow("type was not Attachmentable")
+	}
+
+	attachment, err := ap.ExtractAttachment(attachmentable)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.True(*attachment.Sensitive)
+}
+
 func TestExtractAttachmentsTestSuite(t *testing.T) {
 	suite.Run(t, &ExtractAttachmentsTestSuite{})
 }
@@ -0,0 +1,98 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ap_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+)
+
+type ExtractCollectionCountTestSuite struct {
+	APTestSuite
+}
+
+func (suite *ExtractCollectionCountTestSuite) TestExtractEmbeddedCounts() {
+	t, _ := suite.jsonToType(`{
+		"@context": "https://www.w3.org/ns/activitystreams",
+		"id": "https://example.org/users/someone/statuses/01HZ0000000000000000000000",
+		"type": "Note",
+		"content": "hello",
+		"likes": {
+			"id": "https://example.org/users/someone/statuses/01HZ0000000000000000000000/likes",
+			"type": "Collection",
+			"totalItems": 7
+		},
+		"shares": {
+			"id": "https://example.org/users/someone/statuses/01HZ0000000000000000000000/shares",
+			"type": "OrderedCollection",
+			"totalItems": 2
+		}
+	}`)
+	statusable := t.(ap.Statusable)
+
+	likes, iri := ap.ExtractLikes(statusable)
+	suite.Nil(iri)
+	suite.Equal(7, *likes)
+
+	shares, iri := ap.ExtractShares(statusable)
+	suite.Nil(iri)
+	suite.Equal(2, *shares)
+}
+
+func (suite *ExtractCollectionCountTestSuite) TestExtractCollectionIRIs() {
+	t, _ := suite.jsonToType(`{
+		"@context": "https://www.w3.org/ns/activitystreams",
+		"id": "https://example.org/users/someone/statuses/01HZ0000000000000000000000",
+		"type": "Note",
+		"content": "hello",
+		"likes": "https://example.org/users/someone/statuses/01HZ0000000000000000000000/likes",
+		"shares": {
+			"id": "https://example.org/users/someone/statuses/01HZ0000000000000000000000/shares",
+			"type": "Collection"
+		}
+	}`)
+	statusable := t.(ap.Statusable)
+
+	likes, iri := ap.ExtractLikes(statusable)
+	suite.Nil(likes)
+	suite.Equal("https://example.org/users/someone/statuses/01HZ0000000000000000000000/likes", iri.String())
+
+	shares, iri := ap.ExtractShares(statusable)
+	suite.Nil(shares)
+	suite.Equal("https://example.org/users/someone/statuses/01HZ0000000000000000000000/shares", iri.String())
+}
+
+func (suite *ExtractCollectionCountTestSuite) TestExtractNoCollections() {
+	t, _ := suite.jsonToType(`{
+		"@context": "https://www.w3.org/ns/activitystreams",
+		"id": "https://example.org/users/someone/statuses/01HZ0000000000000000000000",
+		"type": "Note",
+		"content": "hello"
+	}`)
+	statusable := t.(ap.Statusable)
+
+	likes, iri := ap.ExtractLikes(statusable)
+	suite.Nil(likes)
+	suite.Nil(iri)
+}
+
+func TestExtractCollectionCountTestSuite(t *testing.T) {
+	suite.Run(t, &ExtractCollectionCountTestSuite{})
+}
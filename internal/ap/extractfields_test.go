@@ -0,0 +1,90 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ap_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+)
+
+type ExtractFieldsTestSuite struct {
+	APTestSuite
+}
+
+func (suite *ExtractFieldsTestSuite) TestExtractFieldsIgnoreVerified() {
+	t, _ := suite.jsonToType(`{
+		"@context": "https://www.w3.org/ns/activitystreams",
+		"id": "https://example.org/users/someone",
+		"attachment": [
+			{
+				"name": "website",
+				"type": "PropertyValue",
+				"value": "<a href=\"https://example.org\" rel=\"me\">example.org</a>",
+				"verified_at": "2024-04-29T14:12:51.000Z"
+			},
+			{
+				"name": "hello",
+				"type": "PropertyValue",
+				"value": "world",
+				"verified_at": "not a date lol"
+			}
+		],
+		"type": "Person"
+	}`)
+
+	fields := ap.ExtractFields(t.(vocab.ActivityStreamsPerson))
+	suite.Len(fields, 2)
+
+	// Verification by the remote
+	// instance shouldn't be trusted.
+	suite.Equal("website", fields[0].Name)
+	suite.True(fields[0].VerifiedAt.IsZero())
+
+	suite.Equal("hello", fields[1].Name)
+	suite.True(fields[1].VerifiedAt.IsZero())
+}
+
+func (suite *ExtractFieldsTestSuite) TestGetAttributionDomains() {
+	t, _ := suite.jsonToType(`{
+		"@context": "https://www.w3.org/ns/activitystreams",
+		"id": "https://example.org/users/someone",
+		"attributionDomains": [
+			"Example.org",
+			"blog.example.org",
+			12345
+		],
+		"type": "Person"
+	}`)
+
+	person := t.(vocab.ActivityStreamsPerson)
+	suite.Equal([]string{
+		"example.org",
+		"blog.example.org",
+	}, ap.GetAttributionDomains(person))
+
+	// Set domains should come out the same.
+	ap.SetAttributionDomains(person, []string{"example.com"})
+	suite.Equal([]string{"example.com"}, ap.GetAttributionDomains(person))
+}
+
+func TestExtractFieldsTestSuite(t *testing.T) {
+	suite.Run(t, &ExtractFieldsTestSuite{})
+}
@@ -0,0 +1,71 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ap_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+)
+
+type ExtractQuoteTestSuite struct {
+	APTestSuite
+}
+
+func (suite *ExtractQuoteTestSuite) TestExtractQuoteURI() {
+	t, _ := suite.jsonToType(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "http://example.org/users/someone/statuses/02",
+  "type": "Note",
+  "attributedTo": "http://example.org/users/someone",
+  "content": "<p>look at this!</p><p>RE: http://example.org/users/someone/statuses/01</p>",
+  "tag": [
+    {
+      "type": "Link",
+      "href": "https://example.com/this-is-not-a-quote"
+    },
+    {
+      "type": "Link",
+      "mediaType": "application/ld+json; profile=\"https://www.w3.org/ns/activitystreams\"",
+      "rel": "https://misskey-hub.net/ns#_misskey_quote",
+      "href": "http://example.org/users/someone/statuses/01",
+      "name": "RE: http://example.org/users/someone/statuses/01"
+    }
+  ]
+}`)
+
+	statusable, ok := t.(ap.Statusable)
+	if !ok {
+		suite.FailNow("type was not statusable")
+	}
+
+	quoteURI := ap.ExtractQuoteURI(statusable)
+	if quoteURI == nil {
+		suite.FailNow("expected quote uri")
+	}
+	suite.Equal("http://example.org/users/someone/statuses/01", quoteURI.String())
+}
+
+func (suite *ExtractQuoteTestSuite) TestExtractQuoteURINone() {
+	suite.Nil(ap.ExtractQuoteURI(suite.noteWithHashtags1()))
+}
+
+func TestExtractQuoteTestSuite(t *testing.T) {
+	suite.Run(t, &ExtractQuoteTestSuite{})
+}
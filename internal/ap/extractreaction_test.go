@@ -0,0 +1,155 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ap_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+)
+
+type ExtractEmojiReactionTestSuite struct {
+	APTestSuite
+}
+
+func (suite *ExtractEmojiReactionTestSuite) resolveReactable(rawJSON string) ap.EmojiReactable {
+	r := httptest.NewRequest(http.MethodPost, "http://localhost:8080/users/the_mighty_zork/inbox", strings.NewReader(rawJSON))
+	activity, _, errWithCode := ap.ResolveIncomingActivity(r)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	reactable, ok := activity.(ap.EmojiReactable)
+	if !ok {
+		suite.FailNow("", "%T was not emoji reactable", activity)
+	}
+
+	return reactable
+}
+
+func (suite *ExtractEmojiReactionTestSuite) TestExtractPleromaEmojiReact() {
+	reactable := suite.resolveReactable(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "http://example.org/activities/01",
+  "type": "EmojiReact",
+  "actor": "http://example.org/users/someone",
+  "object": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY",
+  "content": "👍"
+}`)
+
+	// EmojiReact should have been normalized to a Like.
+	suite.Equal(ap.ActivityLike, reactable.GetTypeName())
+
+	name, emoji := ap.ExtractEmojiReaction(reactable)
+	suite.Equal("👍", name)
+	suite.Nil(emoji)
+}
+
+func (suite *ExtractEmojiReactionTestSuite) TestExtractMisskeyCustomEmojiReaction() {
+	reactable := suite.resolveReactable(`{
+  "@context": [
+    "https://www.w3.org/ns/activitystreams",
+    {
+      "Emoji": "toot:Emoji",
+      "toot": "http://joinmastodon.org/ns#"
+    }
+  ],
+  "id": "http://example.org/likes/01",
+  "type": "Like",
+  "actor": "http://example.org/users/someone",
+  "object": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY",
+  "content": ":blobcat:",
+  "_misskey_reaction": ":blobcat:",
+  "tag": [
+    {
+      "id": "http://example.org/emojis/blobcat",
+      "type": "Emoji",
+      "name": ":blobcat:",
+      "icon": {
+        "type": "Image",
+        "mediaType": "image/png",
+        "url": "http://example.org/files/blobcat.png"
+      }
+    }
+  ]
+}`)
+
+	name, emoji := ap.ExtractEmojiReaction(reactable)
+	suite.Equal("blobcat", name)
+	if emoji == nil {
+		suite.FailNow("expected custom emoji")
+	}
+	suite.Equal("blobcat", emoji.Shortcode)
+	suite.Equal("example.org", emoji.Domain)
+	suite.Equal("http://example.org/files/blobcat.png", emoji.ImageRemoteURL)
+}
+
+func (suite *ExtractEmojiReactionTestSuite) TestExtractPlainLike() {
+	reactable := suite.resolveReactable(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "http://example.org/likes/02",
+  "type": "Like",
+  "actor": "http://example.org/users/someone",
+  "object": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY"
+}`)
+
+	name, emoji := ap.ExtractEmojiReaction(reactable)
+	suite.Empty(name)
+	suite.Nil(emoji)
+}
+
+func (suite *ExtractEmojiReactionTestSuite) TestExtractCustomEmojiReactionNoTag() {
+	reactable := suite.resolveReactable(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "http://example.org/likes/03",
+  "type": "Like",
+  "actor": "http://example.org/users/someone",
+  "object": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY",
+  "content": ":blobcat:"
+}`)
+
+	// Can't use a custom emoji we know nothing about.
+	name, emoji := ap.ExtractEmojiReaction(reactable)
+	suite.Empty(name)
+	suite.Nil(emoji)
+}
+
+func (suite *ExtractEmojiReactionTestSuite) TestIsUnicodeEmojiReaction() {
+	for str, expect := range map[string]bool{
+		"👍":                     true,
+		"❤️":                    true,
+		"👩‍👩‍👧":                 true,
+		"1️⃣":                   true,
+		"":                      false,
+		"hello":                 false,
+		":blobcat:":             false,
+		"👍 👍":                   false,
+		"<b>👍</b>":              false,
+		strings.Repeat("👍", 20): false,
+	} {
+		suite.Equal(expect, ap.IsUnicodeEmojiReaction(str), str)
+	}
+}
+
+func TestExtractEmojiReactionTestSuite(t *testing.T) {
+	suite.Run(t, &ExtractEmojiReactionTestSuite{})
+}
@@ -162,6 +162,7 @@ type Accountable interface {
 	WithFeatured
 	WithMovedTo
 	WithAlsoKnownAs
+	WithAttributionDomains
 	WithManuallyApprovesFollowers
 	WithEndpoints
 	WithTag
@@ -178,6 +179,7 @@ type Statusable interface {
 	WithName
 	WithInReplyTo
 	WithPublished
+	WithUpdated
 	WithURL
 	WithAttributedTo
 	WithTo
@@ -188,6 +190,8 @@ type Statusable interface {
 	WithAttachment
 	WithTag
 	WithReplies
+	WithLikes
+	WithShares
 }
 
 // Pollable represents the minimum activitypub interface for representing a 'poll' (it's a subset of a status).
@@ -268,6 +272,15 @@ type Likeable interface {
 	WithObject
 }
 
+// EmojiReactable represents the minimum interface for an emoji reaction,
+// ie., a 'like' activity carrying an emoji in its content (and tags).
+type EmojiReactable interface {
+	Likeable
+
+	WithContent
+	WithTag
+}
+
 // Blockable represents the minimum interface for an activitystreams 'block' activity.
 type Blockable interface {
 	WithJSONLDId
@@ -482,6 +495,13 @@ type WithAlsoKnownAs interface {
 	SetActivityStreamsAlsoKnownAs(vocab.ActivityStreamsAlsoKnownAsProperty)
 }
 
+// WithAttributionDomains represents an Object which may have Mastodon's
+// attributionDomains property. go-activity doesn't know this property,
+// so it's found among (and set on) the Object's unknown properties.
+type WithAttributionDomains interface {
+	GetUnknownProperties() map[string]interface{}
+}
+
 // WithAttributedTo represents an activity with ActivityStreamsAttributedToProperty
 type WithAttributedTo interface {
 	GetActivityStreamsAttributedTo() vocab.ActivityStreamsAttributedToProperty
@@ -552,6 +572,18 @@ type WithReplies interface {
 	SetActivityStreamsReplies(vocab.ActivityStreamsRepliesProperty)
 }
 
+// WithLikes represents an activity with ActivityStreamsLikesProperty
+type WithLikes interface {
+	GetActivityStreamsLikes() vocab.ActivityStreamsLikesProperty
+	SetActivityStreamsLikes(vocab.ActivityStreamsLikesProperty)
+}
+
+// WithShares represents an activity with ActivityStreamsSharesProperty
+type WithShares interface {
+	GetActivityStreamsShares() vocab.ActivityStreamsSharesProperty
+	SetActivityStreamsShares(vocab.ActivityStreamsSharesProperty)
+}
+
 // WithMediaType represents an activity with ActivityStreamsMediaTypeProperty
 type WithMediaType interface {
 	GetActivityStreamsMediaType() vocab.ActivityStreamsMediaTypeProperty
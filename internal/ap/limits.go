@@ -0,0 +1,159 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ap
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/superseriousbusiness/activity/pub"
+	"github.com/superseriousbusiness/activity/streams/vocab"
+)
+
+// ErrLimitExceeded is returned when an incoming activity
+// is too complex, ie. exceeds one of the given Limits.
+var ErrLimitExceeded = errors.New("limit exceeded")
+
+// Limits on the complexity of incoming activities,
+// and the objects embedded in them. A limit of 0 or
+// less means no limit.
+type Limits struct {
+	// MaxRecipients is the max number of entries in
+	// to, cc, bto, bcc and audience combined, of the
+	// activity or any of its objects.
+	MaxRecipients int
+
+	// MaxTags is the max number of hashtags
+	// and mentions on any of the objects.
+	MaxTags int
+
+	// MaxAttachments is the max number of
+	// attachments on any of the objects.
+	MaxAttachments int
+
+	// MaxEmojis is the max number of custom
+	// emojis on any of the objects.
+	MaxEmojis int
+}
+
+// CheckLimits checks the given activity, and the objects
+// embedded in it, against limits, returning an error
+// wrapping ErrLimitExceeded for the first limit exceeded.
+func CheckLimits(activity pub.Activity, limits Limits) error {
+	if err := limits.check(activity); err != nil {
+		return err
+	}
+
+	for _, obj := range ExtractObjects(activity) {
+		t := obj.GetType()
+		if t == nil {
+			// Just an IRI.
+			continue
+		}
+
+		if err := limits.check(t); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// check checks the fields of t against limits.
+func (l Limits) check(t vocab.Type) error {
+	if n := countRecipients(t); exceeds(n, l.MaxRecipients) {
+		return fmt.Errorf("%w: %s has %d recipients, max %d", ErrLimitExceeded, t.GetTypeName(), n, l.MaxRecipients)
+	}
+
+	if withTag, ok := t.(WithTag); ok {
+		tags, emojis := countTags(withTag)
+		if exceeds(tags, l.MaxTags) {
+			return fmt.Errorf("%w: %s has %d tags, max %d", ErrLimitExceeded, t.GetTypeName(), tags, l.MaxTags)
+		}
+		if exceeds(emojis, l.MaxEmojis) {
+			return fmt.Errorf("%w: %s has %d emojis, max %d", ErrLimitExceeded, t.GetTypeName(), emojis, l.MaxEmojis)
+		}
+	}
+
+	if withAttachment, ok := t.(WithAttachment); ok {
+		if prop := withAttachment.GetActivityStreamsAttachment(); prop != nil &&
+			exceeds(prop.Len(), l.MaxAttachments) {
+			return fmt.Errorf("%w: %s has %d attachments, max %d", ErrLimitExceeded, t.GetTypeName(), prop.Len(), l.MaxAttachments)
+		}
+	}
+
+	return nil
+}
+
+// exceeds returns whether n exceeds
+// limit, with limit <= 0 being none.
+func exceeds(n int, limit int) bool {
+	return limit > 0 && n > limit
+}
+
+// countRecipients returns the combined number of entries
+// in the to, cc, bto, bcc and audience properties of t.
+func countRecipients(t vocab.Type) int {
+	var n int
+
+	if i, ok := t.(WithTo); ok && i.GetActivityStreamsTo() != nil {
+		n += i.GetActivityStreamsTo().Len()
+	}
+
+	if i, ok := t.(WithCc); ok && i.GetActivityStreamsCc() != nil {
+		n += i.GetActivityStreamsCc().Len()
+	}
+
+	if i, ok := t.(interface {
+		GetActivityStreamsBto() vocab.ActivityStreamsBtoProperty
+	}); ok && i.GetActivityStreamsBto() != nil {
+		n += i.GetActivityStreamsBto().Len()
+	}
+
+	if i, ok := t.(WithBcc); ok && i.GetActivityStreamsBcc() != nil {
+		n += i.GetActivityStreamsBcc().Len()
+	}
+
+	if i, ok := t.(interface {
+		GetActivityStreamsAudience() vocab.ActivityStreamsAudienceProperty
+	}); ok && i.GetActivityStreamsAudience() != nil {
+		n += i.GetActivityStreamsAudience().Len()
+	}
+
+	return n
+}
+
+// countTags returns the number of custom emojis in the
+// tag property of i, and the number of other tags, ie.
+// hashtags and mentions (or anything else unknown).
+func countTags(i WithTag) (tags int, emojis int) {
+	prop := i.GetActivityStreamsTag()
+	if prop == nil {
+		return 0, 0
+	}
+
+	for iter := prop.Begin(); iter != prop.End(); iter = iter.Next() {
+		if iter.IsTootEmoji() {
+			emojis++
+		} else {
+			tags++
+		}
+	}
+
+	return tags, emojis
+}
@@ -0,0 +1,150 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ap_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/pub"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+)
+
+type LimitsTestSuite struct {
+	APTestSuite
+}
+
+func (suite *LimitsTestSuite) create() pub.Activity {
+	t, _ := suite.jsonToType(`{
+  "@context": [
+    "https://www.w3.org/ns/activitystreams",
+    {
+      "toot": "http://joinmastodon.org/ns#",
+      "Emoji": "toot:Emoji",
+      "Hashtag": "as:Hashtag"
+    }
+  ],
+  "id": "http://example.org/users/someone/statuses/01J5QVB9VC76NPPRQ207GG4DRZ/activity",
+  "type": "Create",
+  "actor": "http://example.org/users/someone",
+  "to": "https://www.w3.org/ns/activitystreams#Public",
+  "object": {
+    "id": "http://example.org/users/someone/statuses/01J5QVB9VC76NPPRQ207GG4DRZ",
+    "type": "Note",
+    "attributedTo": "http://example.org/users/someone",
+    "content": "hello @someone_else and @another_one, #howdy #partner :rainbow:",
+    "to": "https://www.w3.org/ns/activitystreams#Public",
+    "cc": [
+      "http://example.org/users/someone/followers",
+      "http://example.com/users/someone_else",
+      "http://example.net/users/another_one"
+    ],
+    "tag": [
+      {
+        "type": "Mention",
+        "href": "http://example.com/users/someone_else",
+        "name": "@someone_else@example.com"
+      },
+      {
+        "type": "Mention",
+        "href": "http://example.net/users/another_one",
+        "name": "@another_one@example.net"
+      },
+      {
+        "type": "Hashtag",
+        "href": "http://example.org/tags/howdy",
+        "name": "#howdy"
+      },
+      {
+        "type": "Hashtag",
+        "href": "http://example.org/tags/partner",
+        "name": "#partner"
+      },
+      {
+        "type": "Emoji",
+        "id": "http://example.org/emoji/01J5QVCQ4ZC4VSZQ8CSQGK7ZQV",
+        "name": ":rainbow:",
+        "icon": {
+          "type": "Image",
+          "mediaType": "image/png",
+          "url": "http://example.org/fileserver/rainbow.png"
+        }
+      }
+    ],
+    "attachment": [
+      {
+        "type": "Document",
+        "mediaType": "image/png",
+        "url": "http://example.org/fileserver/one.png"
+      },
+      {
+        "type": "Document",
+        "mediaType": "image/png",
+        "url": "http://example.org/fileserver/two.png"
+      }
+    ]
+  }
+}`)
+
+	return t.(pub.Activity)
+}
+
+func (suite *LimitsTestSuite) TestCheckLimits() {
+	for _, test := range []struct {
+		limits ap.Limits
+		expect string
+	}{
+		{
+			limits: ap.Limits{},
+			expect: "",
+		},
+		{
+			limits: ap.Limits{MaxRecipients: 4, MaxTags: 4, MaxAttachments: 2, MaxEmojis: 1},
+			expect: "",
+		},
+		{
+			limits: ap.Limits{MaxRecipients: 3},
+			expect: "limit exceeded: Note has 4 recipients, max 3",
+		},
+		{
+			limits: ap.Limits{MaxTags: 3},
+			expect: "limit exceeded: Note has 4 tags, max 3",
+		},
+		{
+			limits: ap.Limits{MaxAttachments: 1},
+			expect: "limit exceeded: Note has 2 attachments, max 1",
+		},
+		{
+			limits: ap.Limits{MaxEmojis: -1},
+			expect: "",
+		},
+	} {
+		err := ap.CheckLimits(suite.create(), test.limits)
+		if test.expect == "" {
+			suite.NoError(err)
+			continue
+		}
+
+		suite.ErrorIs(err, ap.ErrLimitExceeded)
+		suite.EqualError(err, test.expect)
+	}
+}
+
+func TestLimitsTestSuite(t *testing.T) {
+	suite.Run(t, &LimitsTestSuite{})
+}
@@ -20,6 +20,7 @@ package ap
 import (
 	"github.com/superseriousbusiness/activity/pub"
 	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
 )
@@ -665,3 +666,33 @@ func NormalizeOutgoingObjectProp(item WithObject, rawJSON map[string]interface{}
 
 	return nil
 }
+
+// NormalizeIncomingEmojiReact rewrites the type of a Pleroma
+// EmojiReact activity (or an EmojiReact wrapped as the object of
+// eg., an Undo) in the given raw json object map into Like, which
+// is the way Misskey federates reactions. The emoji reaction
+// itself is then carried in the 'content' of the Like.
+//
+// noop if there's no EmojiReact in the json object map.
+func NormalizeIncomingEmojiReact(rawJSON map[string]interface{}) {
+	if rawJSON["type"] == ActivityEmojiReact {
+		rawJSON["type"] = ActivityLike
+	}
+
+	rawObject, ok := rawJSON["object"].(map[string]interface{})
+	if ok && rawObject["type"] == ActivityEmojiReact {
+		rawObject["type"] = ActivityLike
+	}
+}
+
+// NormalizeOutgoingEmojiReact sets the '_misskey_reaction' property
+// of the given raw json object map to the content of the given Like,
+// for compatibility with implementations that only recognize emoji
+// reactions federated that way.
+//
+// noop if the Like has no content, ie., is a plain old fave.
+func NormalizeOutgoingEmojiReact(like vocab.ActivityStreamsLike, rawJSON map[string]interface{}) {
+	if content := ExtractContent(like).Content; content != "" {
+		rawJSON["_misskey_reaction"] = content
+	}
+}
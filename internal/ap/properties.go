@@ -20,6 +20,7 @@ package ap
 import (
 	"fmt"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/superseriousbusiness/activity/streams"
@@ -389,6 +390,48 @@ func SetAlsoKnownAs(with WithAlsoKnownAs, alsoKnownAs []*url.URL) {
 	}, alsoKnownAs...)
 }
 
+// GetAttributionDomains returns the (lowercased) domains contained in the
+// attributionDomains property of 'with', on which content may be credited
+// to the account as its author.
+func GetAttributionDomains(with WithAttributionDomains) []string {
+	var raw []interface{}
+	switch v := with.GetUnknownProperties()["attributionDomains"].(type) {
+	case string:
+		raw = []interface{}{v}
+	case []interface{}:
+		raw = v
+	default:
+		return nil
+	}
+
+	domains := make([]string, 0, len(raw))
+	for _, r := range raw {
+		domain, ok := r.(string)
+		if !ok {
+			continue
+		}
+
+		domain = strings.ToLower(strings.TrimSpace(domain))
+		if domain == "" {
+			continue
+		}
+
+		domains = append(domains, domain)
+	}
+
+	return domains
+}
+
+// SetAttributionDomains sets the given domains
+// on the attributionDomains property of 'with'.
+func SetAttributionDomains(with WithAttributionDomains, domains []string) {
+	raw := make([]interface{}, len(domains))
+	for i, domain := range domains {
+		raw[i] = domain
+	}
+	with.GetUnknownProperties()["attributionDomains"] = raw
+}
+
 // GetPublished returns the time contained in the Published property of 'with'.
 func GetPublished(with WithPublished) time.Time {
 	publishProp := with.GetActivityStreamsPublished()
@@ -408,6 +451,25 @@ func SetPublished(with WithPublished, published time.Time) {
 	publishProp.Set(published)
 }
 
+// GetUpdated returns the time contained in the Updated property of 'with'.
+func GetUpdated(with WithUpdated) time.Time {
+	updateProp := with.GetActivityStreamsUpdated()
+	if updateProp == nil || !updateProp.IsXMLSchemaDateTime() {
+		return time.Time{}
+	}
+	return updateProp.Get()
+}
+
+// SetUpdated sets the given time on the Updated property of 'with'.
+func SetUpdated(with WithUpdated, updated time.Time) {
+	updateProp := with.GetActivityStreamsUpdated()
+	if updateProp == nil {
+		updateProp = streams.NewActivityStreamsUpdatedProperty()
+		with.SetActivityStreamsUpdated(updateProp)
+	}
+	updateProp.Set(updated)
+}
+
 // GetEndTime returns the time contained in the EndTime property of 'with'.
 func GetEndTime(with WithEndTime) time.Time {
 	endTimeProp := with.GetActivityStreamsEndTime()
@@ -251,6 +251,10 @@ func decodeType(
 	// Done with body.
 	_ = body.Close()
 
+	// Rewrite any EmojiReacts to Likes,
+	// as the former isn't in our vocab.
+	NormalizeIncomingEmojiReact(raw)
+
 	// Resolve an ActivityStreams type.
 	t, err := streams.ToType(ctx, raw)
 	if err != nil {
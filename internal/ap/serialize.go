@@ -39,6 +39,7 @@ import (
 //   - Any Accountable type:    'attachment' property will always be made into an array.
 //   - Any Statusable type:     'attachment' property will always be made into an array; 'content' and 'contentMap' will be normalized.
 //   - Any Activityable type:   any 'object's set on an activity will be custom serialized as above.
+//   - Like with content:       '_misskey_reaction' will be set to the emoji reaction content.
 func Serialize(t vocab.Type) (m map[string]interface{}, e error) {
 	switch tn := t.GetTypeName(); {
 	case tn == ObjectOrderedCollection ||
@@ -182,5 +183,10 @@ func serializeActivityable(t vocab.Type, includeContext bool) (map[string]interf
 		return nil, err
 	}
 
+	if like, ok := t.(vocab.ActivityStreamsLike); ok {
+		// Likes may be emoji reactions.
+		NormalizeOutgoingEmojiReact(like, data)
+	}
+
 	return data, nil
 }
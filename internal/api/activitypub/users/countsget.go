@@ -0,0 +1,164 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package users
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// StatusLikesGETHandler swagger:operation GET /users/{username}/statuses/{status}/likes s2sLikesGet
+//
+// Get the likes collection for a status.
+//
+// The collection only gives the number of likes of the status, as `totalItems`; the likes themselves are not listed.
+//
+// HTTP signature is required on the request.
+//
+//	---
+//	tags:
+//	- s2s/federation
+//
+//	produces:
+//	- application/activity+json
+//
+//	parameters:
+//	-
+//		name: username
+//		type: string
+//		description: Username of the account.
+//		in: path
+//		required: true
+//	-
+//		name: status
+//		type: string
+//		description: ID of the status.
+//		in: path
+//		required: true
+//
+//	responses:
+//		'200':
+//			in: body
+//			schema:
+//				"$ref": "#/definitions/swaggerCollection"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+func (m *Module) StatusLikesGETHandler(c *gin.Context) {
+	m.statusCountCollectionGETHandler(c, m.processor.Fedi().StatusLikesGet)
+}
+
+// StatusSharesGETHandler swagger:operation GET /users/{username}/statuses/{status}/shares s2sSharesGet
+//
+// Get the shares collection for a status.
+//
+// The collection only gives the number of shares (boosts) of the status, as `totalItems`; the shares themselves are not listed.
+//
+// HTTP signature is required on the request.
+//
+//	---
+//	tags:
+//	- s2s/federation
+//
+//	produces:
+//	- application/activity+json
+//
+//	parameters:
+//	-
+//		name: username
+//		type: string
+//		description: Username of the account.
+//		in: path
+//		required: true
+//	-
+//		name: status
+//		type: string
+//		description: ID of the status.
+//		in: path
+//		required: true
+//
+//	responses:
+//		'200':
+//			in: body
+//			schema:
+//				"$ref": "#/definitions/swaggerCollection"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+func (m *Module) StatusSharesGETHandler(c *gin.Context) {
+	m.statusCountCollectionGETHandler(c, m.processor.Fedi().StatusSharesGet)
+}
+
+// statusCountCollectionGETHandler serves the
+// collection returned by given get function.
+func (m *Module) statusCountCollectionGETHandler(
+	c *gin.Context,
+	get func(ctx context.Context, requestedUser string, statusID string) (interface{}, gtserror.WithCode),
+) {
+	// usernames on our instance are always lowercase
+	requestedUsername := strings.ToLower(c.Param(UsernameKey))
+	if requestedUsername == "" {
+		err := errors.New("no username specified in request")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	// status IDs on our instance are always uppercase
+	requestedStatusID := strings.ToUpper(c.Param(StatusIDKey))
+	if requestedStatusID == "" {
+		err := errors.New("no status id specified in request")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	contentType, err := apiutil.NegotiateAccept(c, apiutil.ActivityPubOrHTMLHeaders...)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if contentType == string(apiutil.TextHTML) {
+		// redirect to the status
+		c.Redirect(http.StatusSeeOther, "/@"+requestedUsername+"/statuses/"+requestedStatusID)
+		return
+	}
+
+	resp, errWithCode := get(c.Request.Context(), requestedUsername, requestedStatusID)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSONType(c, http.StatusOK, contentType, resp)
+}
@@ -0,0 +1,111 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package users_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/activitypub/users"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type CountsGetTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *CountsGetTestSuite) getCollection(derefKey string, path string, handler gin.HandlerFunc) string {
+	derefRequests := testrig.NewTestDereferenceRequests(suite.testAccounts)
+	signedRequest := derefRequests[derefKey]
+	targetAccount := suite.testAccounts["local_account_1"]
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	// setup request
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Request = httptest.NewRequest(http.MethodGet, targetStatus.URI+path, nil)
+	ctx.Request.Header.Set("accept", "application/activity+json")
+	ctx.Request.Header.Set("Signature", signedRequest.SignatureHeader)
+	ctx.Request.Header.Set("Date", signedRequest.DateHeader)
+
+	// we need to pass the context through signature check first to set appropriate values on it
+	suite.signatureCheck(ctx)
+
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   users.UsernameKey,
+			Value: targetAccount.Username,
+		},
+		gin.Param{
+			Key:   users.StatusIDKey,
+			Value: targetStatus.ID,
+		},
+	}
+
+	handler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := io.ReadAll(result.Body)
+	suite.NoError(err)
+
+	return string(indentJSON(b))
+}
+
+func (suite *CountsGetTestSuite) TestGetLikes() {
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	b := suite.getCollection(
+		"foss_satan_dereference_local_account_1_status_1_likes",
+		"/likes",
+		suite.userModule.StatusLikesGETHandler,
+	)
+
+	suite.Equal(toJSON(map[string]any{
+		"@context":   "https://www.w3.org/ns/activitystreams",
+		"type":       "Collection",
+		"id":         targetStatus.URI + "/likes",
+		"totalItems": 1,
+	}), b)
+}
+
+func (suite *CountsGetTestSuite) TestGetShares() {
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	b := suite.getCollection(
+		"foss_satan_dereference_local_account_1_status_1_shares",
+		"/shares",
+		suite.userModule.StatusSharesGETHandler,
+	)
+
+	suite.Equal(toJSON(map[string]any{
+		"@context":   "https://www.w3.org/ns/activitystreams",
+		"type":       "Collection",
+		"id":         targetStatus.URI + "/shares",
+		"totalItems": 1,
+	}), b)
+}
+
+func TestCountsGetTestSuite(t *testing.T) {
+	suite.Run(t, new(CountsGetTestSuite))
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/api/activitypub/users"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -590,11 +591,67 @@ func (suite *InboxPostTestSuite) TestPostUnauthorized() {
 		requestingAccount,
 		targetAccount,
 		http.StatusUnauthorized,
-		`{"error":"Unauthorized: http request wasn't signed or http signature was invalid: (verifier)"}`,
+		`{"error":"Unauthorized: http request wasn't signed or http signature was invalid: (verifier)","error_code":"signature_missing"}`,
 		// Omit signature check middleware.
 	)
 }
 
+func (suite *InboxPostTestSuite) TestPostTooLarge() {
+	var (
+		requestingAccount = suite.testAccounts["remote_account_1"]
+		targetAccount     = suite.testAccounts["local_account_1"]
+		activityID        = requestingAccount.URI + "/some-new-activity/01FG9C441MCTW3R2W117V2PQK3"
+	)
+
+	// Set a limit smaller than any block could be.
+	config.SetInstanceInboxMaxSize(64)
+
+	block := suite.newBlock(activityID, requestingAccount, targetAccount)
+
+	suite.inboxPost(
+		block,
+		requestingAccount,
+		targetAccount,
+		http.StatusUnprocessableEntity,
+		"",
+		suite.signatureCheck,
+	)
+
+	// Block should not be in the database.
+	_, err := suite.state.DB.GetBlockByURI(context.Background(), activityID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *InboxPostTestSuite) TestPostTooManyRecipients() {
+	var (
+		requestingAccount = suite.testAccounts["remote_account_1"]
+		targetAccount     = suite.testAccounts["local_account_1"]
+		activityID        = requestingAccount.URI + "/some-new-activity/01FG9C441MCTW3R2W117V2PQK3"
+	)
+
+	config.SetInstanceInboxMaxRecipients(2)
+
+	// Address the block to more
+	// recipients than we allow.
+	block := suite.newBlock(activityID, requestingAccount, targetAccount)
+	to := block.GetActivityStreamsTo()
+	to.AppendIRI(testrig.URLMustParse(suite.testAccounts["local_account_2"].URI))
+	to.AppendIRI(testrig.URLMustParse(suite.testAccounts["admin_account"].URI))
+
+	suite.inboxPost(
+		block,
+		requestingAccount,
+		targetAccount,
+		http.StatusUnprocessableEntity,
+		`{"error":"Unprocessable Entity: limit exceeded: Block has 3 recipients, max 2"}`,
+		suite.signatureCheck,
+	)
+
+	// Block should not be in the database.
+	_, err := suite.state.DB.GetBlockByURI(context.Background(), activityID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
 func TestInboxPostTestSuite(t *testing.T) {
 	suite.Run(t, &InboxPostTestSuite{})
 }
@@ -63,5 +63,17 @@ func (m *Module) StatusGETHandler(c *gin.Context) {
 		return
 	}
 
-	apiutil.JSONType(c, http.StatusOK, contentType, resp)
+	// Visibility of the status depends on the
+	// requester, so only permit private caching,
+	// and ETag revalidation before each reuse.
+	c.Header("Cache-Control", "private, no-cache")
+	c.Header("Vary", "Accept")
+
+	apiutil.EncodeJSONResponseETag(
+		c.Writer,
+		c.Request,
+		http.StatusOK,
+		contentType,
+		resp,
+	)
 }
@@ -55,6 +55,10 @@ const (
 	StatusPath = BasePath + "/" + uris.StatusesPath + "/:" + StatusIDKey
 	// StatusRepliesPath is for serving the replies collection of a status.
 	StatusRepliesPath = StatusPath + "/replies"
+	// StatusLikesPath is for serving the likes collection of a status.
+	StatusLikesPath = StatusPath + "/likes"
+	// StatusSharesPath is for serving the shares collection of a status.
+	StatusSharesPath = StatusPath + "/shares"
 )
 
 type Module struct {
@@ -75,5 +79,7 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodGet, FeaturedCollectionPath, m.FeaturedCollectionGETHandler)
 	attachHandler(http.MethodGet, StatusPath, m.StatusGETHandler)
 	attachHandler(http.MethodGet, StatusRepliesPath, m.StatusRepliesGETHandler)
+	attachHandler(http.MethodGet, StatusLikesPath, m.StatusLikesGETHandler)
+	attachHandler(http.MethodGet, StatusSharesPath, m.StatusSharesGETHandler)
 	attachHandler(http.MethodGet, OutboxPath, m.OutboxGETHandler)
 }
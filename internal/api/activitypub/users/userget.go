@@ -63,5 +63,17 @@ func (m *Module) UsersGETHandler(c *gin.Context) {
 		return
 	}
 
-	apiutil.JSONType(c, http.StatusOK, contentType, resp)
+	// Allow the requester to store this response,
+	// but only for itself (it may differ per requester),
+	// and only if it revalidates it with us before reuse.
+	c.Header("Cache-Control", "private, no-cache")
+	c.Header("Vary", "Accept")
+
+	apiutil.EncodeJSONResponseETag(
+		c.Writer,
+		c.Request,
+		http.StatusOK,
+		contentType,
+		resp,
+	)
 }
@@ -0,0 +1,124 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auth
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// acceptTerms wraps the form submitted
+// from the accept terms page.
+type acceptTerms struct {
+	ID       string `form:"id"`
+	Accepted bool   `form:"accepted"`
+}
+
+// AcceptTermsGETHandler should be served at https://example.org/auth/accept_terms.
+// Users who are signing in, and who haven't yet accepted the current version of
+// the instance terms, land here, where they're shown the terms along with a form
+// which POSTs to AcceptTermsPOSTHandler.
+func (m *Module) AcceptTermsGETHandler(c *gin.Context) {
+	if _, err := apiutil.NegotiateAccept(c, apiutil.HTMLAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	user, errWithCode := m.sessionUser(c)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	terms, errWithCode := m.processor.User().TermsGet(c.Request.Context(), user)
+	if errWithCode != nil {
+		if errWithCode.Code() == http.StatusNotFound {
+			// No terms to accept, carry on.
+			c.Redirect(http.StatusSeeOther, "/oauth"+OauthAuthorizePath)
+			return
+		}
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if terms.Accepted {
+		// Nothing to accept, carry on.
+		c.Redirect(http.StatusSeeOther, "/oauth"+OauthAuthorizePath)
+		return
+	}
+
+	instance, errWithCode := m.processor.InstanceGetV1(c.Request.Context())
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	page := apiutil.WebPage{
+		Template: "accept_terms.tmpl",
+		Instance: instance,
+		Extra: map[string]any{
+			"terms": terms,
+		},
+	}
+
+	apiutil.TemplateWebPage(c, page)
+}
+
+// AcceptTermsPOSTHandler should be served at https://example.org/auth/accept_terms.
+// It stores the user's acceptance of the current version of the instance terms,
+// and sends them on their way to the authorize page.
+func (m *Module) AcceptTermsPOSTHandler(c *gin.Context) {
+	user, errWithCode := m.sessionUser(c)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &acceptTerms{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	if !form.Accepted {
+		err := errors.New("agreement to terms and conditions not given")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, errWithCode := m.processor.User().TermsAccept(c.Request.Context(), user, form.ID); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/oauth"+OauthAuthorizePath)
+}
+
+// termsAcceptanceRequired returns whether the given user
+// still needs to accept the current version of the terms
+// of the given instance, ie., whether they never accepted
+// any terms, or accepted an older version.
+func termsAcceptanceRequired(user *gtsmodel.User, instance *gtsmodel.Instance) bool {
+	return instance.TermsVersionID != "" && user.TermsAcceptedVersionID != instance.TermsVersionID
+}
@@ -41,6 +41,10 @@ const (
 	AuthWaitForApprovalPath = "/wait_for_approval"
 	// AuthAccountDisabledPath users land here when their account is suspended by an admin
 	AuthAccountDisabledPath = "/account_disabled"
+	// AuthConfirmAgePath users land here when they haven't yet confirmed being at least the instance's minimum age
+	AuthConfirmAgePath = "/confirm_age"
+	// AuthAcceptTermsPath users land here when they haven't yet accepted the current version of the instance terms
+	AuthAcceptTermsPath = "/accept_terms"
 	// AuthCallbackPath is the API path for receiving callback tokens from external OIDC providers
 	AuthCallbackPath = "/callback"
 
@@ -97,6 +101,10 @@ func (m *Module) RouteAuth(attachHandler func(method string, path string, f ...g
 	attachHandler(http.MethodGet, AuthSignInPath, m.SignInGETHandler)
 	attachHandler(http.MethodPost, AuthSignInPath, m.SignInPOSTHandler)
 	attachHandler(http.MethodGet, AuthCallbackPath, m.CallbackGETHandler)
+	attachHandler(http.MethodGet, AuthConfirmAgePath, m.ConfirmAgeGETHandler)
+	attachHandler(http.MethodPost, AuthConfirmAgePath, m.ConfirmAgePOSTHandler)
+	attachHandler(http.MethodGet, AuthAcceptTermsPath, m.AcceptTermsGETHandler)
+	attachHandler(http.MethodPost, AuthAcceptTermsPath, m.AcceptTermsPOSTHandler)
 }
 
 // RouteOauth routes all paths that should have an 'oauth' prefix
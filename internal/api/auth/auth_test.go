@@ -64,8 +64,10 @@ type AuthStandardTestSuite struct {
 }
 
 const (
-	sessionUserID   = "userid"
-	sessionClientID = "client_id"
+	sessionUserID      = "userid"
+	sessionClientID    = "client_id"
+	sessionRedirectURI = "redirect_uri"
+	sessionScope       = "scope"
 )
 
 func (suite *AuthStandardTestSuite) SetupSuite() {
@@ -28,6 +28,7 @@ import (
 	"github.com/google/uuid"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -117,7 +118,14 @@ func (m *Module) AuthorizeGETHandler(c *gin.Context) {
 		return
 	}
 
-	if ensureUserIsAuthorizedOrRedirect(c, user, acct) {
+	thisInstance, err := m.db.GetInstance(c.Request.Context(), config.GetHost())
+	if err != nil {
+		err := gtserror.Newf("db error getting instance: %w", err)
+		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	if ensureUserIsAuthorizedOrRedirect(c, user, acct, thisInstance) {
 		return
 	}
 
@@ -138,6 +146,26 @@ func (m *Module) AuthorizeGETHandler(c *gin.Context) {
 		return
 	}
 
+	// Make sure the app only asks
+	// for scopes it registered with.
+	scopes := oauth.ParseScopes(scope)
+	if !oauth.GrantedScopes(app.Scopes).PermitsAll(scopes) {
+		m.clearSession(s)
+		err := fmt.Errorf("scope %s is not within the scopes registered for application %s", scope, app.Name)
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error(), oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	// Describe the requested scopes, so
+	// users know what they're allowing.
+	scopeDescriptions := make([]map[string]string, 0, len(scopes))
+	for _, requested := range scopes {
+		scopeDescriptions = append(scopeDescriptions, map[string]string{
+			"scope":       string(requested),
+			"description": requested.Description(),
+		})
+	}
+
 	instance, errWithCode := m.processor.InstanceGetV1(c.Request.Context())
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
@@ -158,6 +186,7 @@ func (m *Module) AuthorizeGETHandler(c *gin.Context) {
 			"appwebsite": app.Website,
 			"redirect":   redirect,
 			"scope":      scope,
+			"scopes":     scopeDescriptions,
 			"user":       acct.Username,
 		},
 	}
@@ -244,7 +273,14 @@ func (m *Module) AuthorizePOSTHandler(c *gin.Context) {
 		return
 	}
 
-	if ensureUserIsAuthorizedOrRedirect(c, user, acct) {
+	thisInstance, err := m.db.GetInstance(c.Request.Context(), config.GetHost())
+	if err != nil {
+		err := gtserror.Newf("db error getting instance: %w", err)
+		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	if ensureUserIsAuthorizedOrRedirect(c, user, acct, thisInstance) {
 		return
 	}
 
@@ -318,7 +354,7 @@ func saveAuthFormToSession(s sessions.Session, form *apimodel.OAuthAuthorize) gt
 	return nil
 }
 
-func ensureUserIsAuthorizedOrRedirect(ctx *gin.Context, user *gtsmodel.User, account *gtsmodel.Account) (redirected bool) {
+func ensureUserIsAuthorizedOrRedirect(ctx *gin.Context, user *gtsmodel.User, account *gtsmodel.Account, instance *gtsmodel.Instance) (redirected bool) {
 	if user.ConfirmedAt.IsZero() {
 		ctx.Redirect(http.StatusSeeOther, "/auth"+AuthCheckYourEmailPath)
 		redirected = true
@@ -337,5 +373,17 @@ func ensureUserIsAuthorizedOrRedirect(ctx *gin.Context, user *gtsmodel.User, acc
 		return
 	}
 
+	if ageConfirmationRequired(user) {
+		ctx.Redirect(http.StatusSeeOther, "/auth"+AuthConfirmAgePath)
+		redirected = true
+		return
+	}
+
+	if termsAcceptanceRequired(user, instance) {
+		ctx.Redirect(http.StatusSeeOther, "/auth"+AuthAcceptTermsPath)
+		redirected = true
+		return
+	}
+
 	return
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/gin-contrib/sessions"
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/api/auth"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
@@ -113,6 +114,54 @@ func (suite *AuthAuthorizeTestSuite) TestAccountAuthorizeHandler() {
 	}
 }
 
+func (suite *AuthAuthorizeTestSuite) TestAccountAuthorizeHandlerConfirmAge() {
+	config.SetAccountsMinimumAge(16)
+	defer config.SetAccountsMinimumAge(0)
+
+	for _, test := range []struct {
+		confirmedMinimum       int
+		expectedLocationHeader string
+	}{
+		{
+			// Never confirmed.
+			confirmedMinimum:       0,
+			expectedLocationHeader: "/auth" + auth.AuthConfirmAgePath,
+		},
+		{
+			// Confirmed when the minimum was lower.
+			confirmedMinimum:       13,
+			expectedLocationHeader: "/auth" + auth.AuthConfirmAgePath,
+		},
+		{
+			// Confirmed current minimum, shown authorize page.
+			confirmedMinimum:       16,
+			expectedLocationHeader: "",
+		},
+	} {
+		ctx, recorder := suite.newContext(http.MethodGet, auth.OauthAuthorizePath, nil, "")
+
+		user := new(gtsmodel.User)
+		*user = *suite.testUsers["local_account_1"]
+
+		testSession := sessions.Default(ctx)
+		testSession.Set(sessionUserID, user.ID)
+		testSession.Set(sessionClientID, suite.testApplications["application_1"].ClientID)
+		testSession.Set(sessionRedirectURI, suite.testApplications["application_1"].RedirectURI)
+		testSession.Set(sessionScope, "read")
+		if err := testSession.Save(); err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		user.AgeConfirmedMinimum = test.confirmedMinimum
+		if err := suite.db.UpdateUser(context.Background(), user, "age_confirmed_minimum"); err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		suite.authModule.AuthorizeGETHandler(ctx)
+		suite.Equal(test.expectedLocationHeader, recorder.Header().Get("Location"))
+	}
+}
+
 func TestAccountUpdateTestSuite(t *testing.T) {
 	suite.Run(t, new(AuthAuthorizeTestSuite))
 }
@@ -207,7 +207,7 @@ func (m *Module) FinalizePOSTHandler(c *gin.Context) {
 	}
 
 	// check if the username conforms to the spec
-	if err := validate.Username(form.Username); err != nil {
+	if err := validate.NewUsername(form.Username); err != nil {
 		validationError(err)
 		return
 	}
@@ -0,0 +1,146 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// confirmAge wraps the form submitted
+// from the confirm age page.
+type confirmAge struct {
+	AgeConfirmed bool `form:"age_confirmed"`
+}
+
+// ConfirmAgeGETHandler should be served at https://example.org/auth/confirm_age.
+// Users who are signing in, and who haven't yet confirmed being at least the
+// instance's (current) minimum age, land here, where they're shown a form
+// which POSTs to ConfirmAgePOSTHandler.
+func (m *Module) ConfirmAgeGETHandler(c *gin.Context) {
+	if _, err := apiutil.NegotiateAccept(c, apiutil.HTMLAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	user, errWithCode := m.sessionUser(c)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if !ageConfirmationRequired(user) {
+		// Nothing to confirm, carry on.
+		c.Redirect(http.StatusSeeOther, "/oauth"+OauthAuthorizePath)
+		return
+	}
+
+	instance, errWithCode := m.processor.InstanceGetV1(c.Request.Context())
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	page := apiutil.WebPage{
+		Template: "confirm_age.tmpl",
+		Instance: instance,
+		Extra: map[string]any{
+			"minimumAge": config.GetAccountsMinimumAge(),
+		},
+	}
+
+	apiutil.TemplateWebPage(c, page)
+}
+
+// ConfirmAgePOSTHandler should be served at https://example.org/auth/confirm_age.
+// It stores the user's confirmation of being at least the instance's minimum
+// age, and sends them on their way to the authorize page.
+func (m *Module) ConfirmAgePOSTHandler(c *gin.Context) {
+	user, errWithCode := m.sessionUser(c)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &confirmAge{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	if !form.AgeConfirmed {
+		err := fmt.Errorf("confirmation of being at least %d years old not given", config.GetAccountsMinimumAge())
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	user.AgeConfirmedAt = time.Now()
+	user.AgeConfirmedMinimum = config.GetAccountsMinimumAge()
+	if err := m.db.UpdateUser(
+		c.Request.Context(),
+		user,
+		"age_confirmed_at",
+		"age_confirmed_minimum",
+	); err != nil {
+		err := gtserror.Newf("db error updating user: %w", err)
+		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/oauth"+OauthAuthorizePath)
+}
+
+// sessionUser gets the user who's signed in on the current session.
+func (m *Module) sessionUser(c *gin.Context) (*gtsmodel.User, gtserror.WithCode) {
+	userID, ok := sessions.Default(c).Get(sessionUserID).(string)
+	if !ok || userID == "" {
+		err := fmt.Errorf("key %s was not found in session", sessionUserID)
+		return nil, gtserror.NewErrorBadRequest(err, oauth.HelpfulAdvice)
+	}
+
+	user, err := m.db.GetUserByID(c.Request.Context(), userID)
+	if err != nil {
+		safe := fmt.Sprintf("user with id %s could not be retrieved", userID)
+		if errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.NewErrorBadRequest(err, safe, oauth.HelpfulAdvice)
+		}
+		return nil, gtserror.NewErrorInternalError(err, safe, oauth.HelpfulAdvice)
+	}
+
+	return user, nil
+}
+
+// ageConfirmationRequired returns whether the given user
+// still needs to confirm being at least the minimum age
+// set for the instance, ie., whether they never did, or
+// they did when the minimum age was lower than it is now.
+func ageConfirmationRequired(user *gtsmodel.User) bool {
+	minAge := config.GetAccountsMinimumAge()
+	return minAge != 0 && user.AgeConfirmedMinimum < minAge
+}
@@ -23,29 +23,42 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/accounts"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/announcements"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/apps"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/blocks"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/bookmarks"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/contentreveals"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/conversations"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/customemojis"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/exports"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/favourites"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/featuredtags"
 	filtersV1 "github.com/superseriousbusiness/gotosocial/internal/api/client/filters/v1"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/followedtags"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/followrequests"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/gotosocial"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/imports"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/instance"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/interactionrequests"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/lists"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/markers"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/media"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/mutes"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/notifications"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/oembed"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/polls"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/preferences"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/push"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/reports"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/scheduledstatuses"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/search"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/statuses"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/streaming"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/tags"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/timelines"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/trends"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/user"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/versions"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/middleware"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
@@ -56,31 +69,44 @@ type Client struct {
 	processor *processing.Processor
 	db        db.DB
 
-	accounts       *accounts.Module       // api/v1/accounts
-	admin          *admin.Module          // api/v1/admin
-	apps           *apps.Module           // api/v1/apps
-	blocks         *blocks.Module         // api/v1/blocks
-	bookmarks      *bookmarks.Module      // api/v1/bookmarks
-	conversations  *conversations.Module  // api/v1/conversations
-	customEmojis   *customemojis.Module   // api/v1/custom_emojis
-	favourites     *favourites.Module     // api/v1/favourites
-	featuredTags   *featuredtags.Module   // api/v1/featured_tags
-	filtersV1      *filtersV1.Module      // api/v1/filters
-	followRequests *followrequests.Module // api/v1/follow_requests
-	instance       *instance.Module       // api/v1/instance
-	lists          *lists.Module          // api/v1/lists
-	markers        *markers.Module        // api/v1/markers
-	media          *media.Module          // api/v1/media, api/v2/media
-	mutes          *mutes.Module          // api/v1/mutes
-	notifications  *notifications.Module  // api/v1/notifications
-	polls          *polls.Module          // api/v1/polls
-	preferences    *preferences.Module    // api/v1/preferences
-	reports        *reports.Module        // api/v1/reports
-	search         *search.Module         // api/v1/search, api/v2/search
-	statuses       *statuses.Module       // api/v1/statuses
-	streaming      *streaming.Module      // api/v1/streaming
-	timelines      *timelines.Module      // api/v1/timelines
-	user           *user.Module           // api/v1/user
+	accounts            *accounts.Module            // api/v1/accounts
+	admin               *admin.Module               // api/v1/admin
+	announcements       *announcements.Module       // api/v1/announcements
+	apps                *apps.Module                // api/v1/apps
+	blocks              *blocks.Module              // api/v1/blocks
+	bookmarks           *bookmarks.Module           // api/v1/bookmarks
+	contentReveals      *contentreveals.Module      // api/v1/content_reveals
+	conversations       *conversations.Module       // api/v1/conversations
+	customEmojis        *customemojis.Module        // api/v1/custom_emojis
+	exports             *exports.Module             // api/v1/exports
+	favourites          *favourites.Module          // api/v1/favourites
+	featuredTags        *featuredtags.Module        // api/v1/featured_tags
+	filtersV1           *filtersV1.Module           // api/v1/filters
+	followedTags        *followedtags.Module        // api/v1/followed_tags
+	followRequests      *followrequests.Module      // api/v1/follow_requests
+	imports             *imports.Module             // api/v1/import
+	gotosocial          *gotosocial.Module          // api/v1/gotosocial
+	instance            *instance.Module            // api/v1/instance
+	interactionRequests *interactionrequests.Module // api/v1/interaction_requests
+	lists               *lists.Module               // api/v1/lists
+	markers             *markers.Module             // api/v1/markers
+	media               *media.Module               // api/v1/media, api/v2/media
+	mutes               *mutes.Module               // api/v1/mutes
+	notifications       *notifications.Module       // api/v1/notifications, api/v2/notifications
+	oEmbed              *oembed.Module              // api/oembed
+	polls               *polls.Module               // api/v1/polls
+	preferences         *preferences.Module         // api/v1/preferences
+	push                *push.Module                // api/v1/push
+	reports             *reports.Module             // api/v1/reports
+	scheduledStatuses   *scheduledstatuses.Module   // api/v1/scheduled_statuses
+	search              *search.Module              // api/v1/search, api/v2/search
+	statuses            *statuses.Module            // api/v1/statuses
+	streaming           *streaming.Module           // api/v1/streaming
+	timelines           *timelines.Module           // api/v1/timelines
+	tags                *tags.Module                // api/v1/tags
+	trends              *trends.Module              // api/v1/trends
+	user                *user.Module                // api/v1/user
+	versions            *versions.Module            // api/versions
 }
 
 func (c *Client) Route(r *router.Router, m ...gin.HandlerFunc) {
@@ -91,40 +117,67 @@ func (c *Client) Route(r *router.Router, m ...gin.HandlerFunc) {
 	apiGroup.Use(m...)
 	apiGroup.Use(
 		middleware.TokenCheck(c.db, c.processor.OAuthValidateBearerToken),
+		middleware.ScopeCheck(clientRequiredScope),
+		middleware.TermsCheck(c.db,
+			// Let users see the instance (and its terms),
+			// check who they are, and accept the terms.
+			"/api"+instance.InstanceInformationPathV1,
+			"/api"+instance.InstanceInformationPathV2,
+			"/api"+accounts.VerifyPath,
+			"/api"+user.TermsPath,
+			"/api"+versions.BasePath,
+		),
+		middleware.DeprecationHeaders(clientDeprecation),
 		middleware.CacheControl(middleware.CacheControlConfig{
 			// Never cache client api responses.
 			Directives: []string{"no-store"},
 		}),
 	)
 
-	// for each client api module, pass it the Handle function
-	// so that the module can attach its routes to this group
-	h := apiGroup.Handle
+	c.routeModules(apiGroup.Handle)
+}
+
+// routeModules passes the given Handle function to each
+// client api module, so that the module can attach its routes.
+func (c *Client) routeModules(h func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	c.accounts.Route(h)
 	c.admin.Route(h)
+	c.announcements.Route(h)
 	c.apps.Route(h)
 	c.blocks.Route(h)
 	c.bookmarks.Route(h)
+	c.contentReveals.Route(h)
 	c.conversations.Route(h)
 	c.customEmojis.Route(h)
+	c.exports.Route(h)
 	c.favourites.Route(h)
 	c.featuredTags.Route(h)
 	c.filtersV1.Route(h)
+	c.followedTags.Route(h)
 	c.followRequests.Route(h)
+	c.imports.Route(h)
+	c.gotosocial.Route(h)
 	c.instance.Route(h)
+	c.interactionRequests.Route(h)
 	c.lists.Route(h)
 	c.markers.Route(h)
 	c.media.Route(h)
 	c.mutes.Route(h)
 	c.notifications.Route(h)
+	c.oEmbed.Route(h)
 	c.polls.Route(h)
 	c.preferences.Route(h)
+	c.push.Route(h)
 	c.reports.Route(h)
+	c.scheduledStatuses.Route(h)
 	c.search.Route(h)
 	c.statuses.Route(h)
 	c.streaming.Route(h)
 	c.timelines.Route(h)
+	c.tags.Route(h)
+	c.trends.Route(h)
 	c.user.Route(h)
+	c.versions.Route(h)
 }
 
 func NewClient(db db.DB, p *processing.Processor) *Client {
@@ -132,30 +185,43 @@ func NewClient(db db.DB, p *processing.Processor) *Client {
 		processor: p,
 		db:        db,
 
-		accounts:       accounts.New(p),
-		admin:          admin.New(p),
-		apps:           apps.New(p),
-		blocks:         blocks.New(p),
-		bookmarks:      bookmarks.New(p),
-		conversations:  conversations.New(p),
-		customEmojis:   customemojis.New(p),
-		favourites:     favourites.New(p),
-		featuredTags:   featuredtags.New(p),
-		filtersV1:      filtersV1.New(p),
-		followRequests: followrequests.New(p),
-		instance:       instance.New(p),
-		lists:          lists.New(p),
-		markers:        markers.New(p),
-		media:          media.New(p),
-		mutes:          mutes.New(p),
-		notifications:  notifications.New(p),
-		polls:          polls.New(p),
-		preferences:    preferences.New(p),
-		reports:        reports.New(p),
-		search:         search.New(p),
-		statuses:       statuses.New(p),
-		streaming:      streaming.New(p, time.Second*30, 4096),
-		timelines:      timelines.New(p),
-		user:           user.New(p),
+		accounts:            accounts.New(p),
+		admin:               admin.New(p),
+		announcements:       announcements.New(p),
+		apps:                apps.New(p),
+		blocks:              blocks.New(p),
+		bookmarks:           bookmarks.New(p),
+		contentReveals:      contentreveals.New(p),
+		conversations:       conversations.New(p),
+		customEmojis:        customemojis.New(p),
+		exports:             exports.New(p),
+		favourites:          favourites.New(p),
+		featuredTags:        featuredtags.New(p),
+		filtersV1:           filtersV1.New(p),
+		followedTags:        followedtags.New(p),
+		followRequests:      followrequests.New(p),
+		imports:             imports.New(p),
+		gotosocial:          gotosocial.New(p),
+		instance:            instance.New(p),
+		interactionRequests: interactionrequests.New(p),
+		lists:               lists.New(p),
+		markers:             markers.New(p),
+		media:               media.New(p),
+		mutes:               mutes.New(p),
+		notifications:       notifications.New(p),
+		oEmbed:              oembed.New(p),
+		polls:               polls.New(p),
+		preferences:         preferences.New(p),
+		push:                push.New(p),
+		reports:             reports.New(p),
+		scheduledStatuses:   scheduledstatuses.New(p),
+		search:              search.New(p),
+		statuses:            statuses.New(p),
+		streaming:           streaming.New(p, time.Second*30, 4096),
+		timelines:           timelines.New(p),
+		tags:                tags.New(p),
+		trends:              trends.New(p),
+		user:                user.New(p),
+		versions:            versions.New(p, clientAPIVersions()),
 	}
 }
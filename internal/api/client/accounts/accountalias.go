@@ -27,6 +27,66 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
+// AccountAliasGETHandler swagger:operation GET /api/v1/accounts/alias accountAliasesGet
+//
+// Get the alsoKnownAs aliases of your account, along with whether each has been verified.
+//
+// An alias is verified when the aliased account lists your account as an alias in return.
+// Aliased accounts are refreshed before responding, so that recent changes are picked up.
+//
+//	---
+//	tags:
+//	- accounts
+//
+//	produces:
+//	- application/json
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:accounts
+//
+//	responses:
+//		'200':
+//			description: "Aliases of your account, in the order they were set."
+//			schema:
+//				type: array
+//				items:
+//					"$ref": "#/definitions/accountAlias"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) AccountAliasGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	resp, errWithCode := m.processor.Account().Aliases(c.Request.Context(), authed.Account)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// Return an empty array
+	// rather than null.
+	if resp == nil {
+		resp = []apimodel.AccountAlias{}
+	}
+
+	apiutil.JSON(c, http.StatusOK, resp)
+}
+
 // AccountAliasPOSTHandler swagger:operation POST /api/v1/accounts/alias accountAlias
 //
 // Alias your account to another account by setting alsoKnownAs to the given URI.
@@ -97,3 +157,131 @@ func (m *Module) AccountAliasPOSTHandler(c *gin.Context) {
 
 	apiutil.JSON(c, http.StatusOK, resp)
 }
+
+// AccountAliasAddPOSTHandler swagger:operation POST /api/v1/accounts/alias/add accountAliasAdd
+//
+// Add an alias from your account to another account, leaving existing aliases in place.
+//
+// The target account will be dereferenced to make sure it exists and is not suspended.
+//
+//	---
+//	tags:
+//	- accounts
+//
+//	consumes:
+//	- multipart/form-data
+//
+//	parameters:
+//	-
+//		name: also_known_as_uri
+//		in: formData
+//		description: >-
+//			ActivityPub URI/ID of the target account to which this account
+//			is being aliased. Eg., `https://example.org/users/some_account`.
+//		type: string
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:accounts
+//
+//	responses:
+//		'200':
+//			description: "The newly updated account."
+//			schema:
+//				"$ref": "#/definitions/account"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'422':
+//			description: Unprocessable. Check the response body for more details.
+//		'500':
+//			description: internal server error
+func (m *Module) AccountAliasAddPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.AccountAliasChangeRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	resp, errWithCode := m.processor.Account().AliasAdd(c.Request.Context(), authed.Account, form.AlsoKnownAsURI)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, resp)
+}
+
+// AccountAliasRemovePOSTHandler swagger:operation POST /api/v1/accounts/alias/remove accountAliasRemove
+//
+// Remove one alias from your account to another account, leaving other aliases in place.
+//
+//	---
+//	tags:
+//	- accounts
+//
+//	consumes:
+//	- multipart/form-data
+//
+//	parameters:
+//	-
+//		name: also_known_as_uri
+//		in: formData
+//		description: >-
+//			ActivityPub URI/ID (or web URL) of the aliased account
+//			to remove. Eg., `https://example.org/users/some_account`.
+//		type: string
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:accounts
+//
+//	responses:
+//		'200':
+//			description: "The newly updated account."
+//			schema:
+//				"$ref": "#/definitions/account"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: not found, account is not aliased to the given URI
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) AccountAliasRemovePOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.AccountAliasChangeRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	resp, errWithCode := m.processor.Account().AliasRemove(c.Request.Context(), authed.Account, form.AlsoKnownAsURI)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, resp)
+}
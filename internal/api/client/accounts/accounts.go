@@ -44,6 +44,7 @@ const (
 	FollowingPath     = BasePathWithID + "/following"
 	FollowPath        = BasePathWithID + "/follow"
 	ListsPath         = BasePathWithID + "/lists"
+	MutePath          = BasePathWithID + "/mute"
 	LookupPath        = BasePath + "/lookup"
 	NotePath          = BasePathWithID + "/note"
 	RelationshipsPath = BasePath + "/relationships"
@@ -51,10 +52,14 @@ const (
 	StatusesPath      = BasePathWithID + "/statuses"
 	UnblockPath       = BasePathWithID + "/unblock"
 	UnfollowPath      = BasePathWithID + "/unfollow"
+	UnmutePath        = BasePathWithID + "/unmute"
 	UpdatePath        = BasePath + "/update_credentials"
 	VerifyPath        = BasePath + "/verify_credentials"
 	MovePath          = BasePath + "/move"
 	AliasPath         = BasePath + "/alias"
+	AliasAddPath      = AliasPath + "/add"
+	AliasRemovePath   = AliasPath + "/remove"
+	UsernamePath      = BasePath + "/username"
 	ThemesPath        = BasePath + "/themes"
 )
 
@@ -102,6 +107,10 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodPost, BlockPath, m.AccountBlockPOSTHandler)
 	attachHandler(http.MethodPost, UnblockPath, m.AccountUnblockPOSTHandler)
 
+	// mute or unmute account
+	attachHandler(http.MethodPost, MutePath, m.AccountMutePOSTHandler)
+	attachHandler(http.MethodPost, UnmutePath, m.AccountUnmutePOSTHandler)
+
 	// account lists
 	attachHandler(http.MethodGet, ListsPath, m.AccountListsGETHandler)
 
@@ -113,8 +122,12 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodGet, LookupPath, m.AccountLookupGETHandler)
 
 	// migration handlers
+	attachHandler(http.MethodGet, AliasPath, m.AccountAliasGETHandler)
 	attachHandler(http.MethodPost, AliasPath, m.AccountAliasPOSTHandler)
+	attachHandler(http.MethodPost, AliasAddPath, m.AccountAliasAddPOSTHandler)
+	attachHandler(http.MethodPost, AliasRemovePath, m.AccountAliasRemovePOSTHandler)
 	attachHandler(http.MethodPost, MovePath, m.AccountMovePOSTHandler)
+	attachHandler(http.MethodPost, UsernamePath, m.AccountUsernamePOSTHandler)
 
 	// account themes
 	attachHandler(http.MethodGet, ThemesPath, m.AccountThemesGETHandler)
@@ -21,12 +21,9 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"slices"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
-	"github.com/go-playground/form/v4"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
@@ -108,6 +105,59 @@ import (
 //		description: Default content type to use for authored statuses (text/plain or text/markdown).
 //		type: string
 //	-
+//		name: source[notify_new_from_days]
+//		in: formData
+//		description: >-
+//			Receive a `new_from` notification when an account you follow posts
+//			after being inactive for at least this many days. 0 disables this.
+//		type: integer
+//		minimum: 0
+//		maximum: 3650
+//	-
+//		name: source[email_when_away_hours]
+//		in: formData
+//		description: >-
+//			Also receive new mentions and direct messages by email when they
+//			arrive after you haven't been active for at least this many hours.
+//			Mentions arriving in quick succession are batched into one email.
+//			0 disables this.
+//		type: integer
+//		minimum: 0
+//		maximum: 8760
+//	-
+//		name: source[auto_approve_followed]
+//		in: formData
+//		description: >-
+//			When the account is locked, automatically approve follow
+//			requests from accounts that you already follow.
+//		type: boolean
+//	-
+//		name: source[auto_approve_local]
+//		in: formData
+//		description: >-
+//			When the account is locked, automatically approve follow
+//			requests from other accounts on this instance.
+//		type: boolean
+//	-
+//		name: source[auto_approve_min_age_days]
+//		in: formData
+//		description: >-
+//			When the account is locked, automatically approve follow
+//			requests from accounts known to this instance for at least
+//			this many days. Remote accounts are counted from when they
+//			were first seen by this instance. 0 disables this.
+//		type: integer
+//		minimum: 0
+//		maximum: 3650
+//	-
+//		name: source[record_follower_events]
+//		in: formData
+//		description: >-
+//			Keep a log of accounts following and unfollowing you, viewable
+//			at /api/v1/gotosocial/follower_events. Turning this off
+//			deletes the events recorded so far.
+//		type: boolean
+//	-
 //		name: theme
 //		in: formData
 //		description: >-
@@ -133,6 +183,26 @@ import (
 //		description: Hide the account's following/followers collections.
 //		type: boolean
 //	-
+//		name: hide_application
+//		in: formData
+//		description: Hide which application was used to post the account's statuses.
+//		type: boolean
+//	-
+//		name: enable_embeds
+//		in: formData
+//		description: Allow the account's public statuses to be embedded in other websites using oEmbed.
+//		type: boolean
+//	-
+//		name: attribution_domains[]
+//		in: formData
+//		description: >-
+//			Domains of websites which may credit the account as author of their content,
+//			using the fediverse:creator meta tag. Subdomains are allowed too.
+//			Submit a single empty value to clear.
+//		type: array
+//		items:
+//			type: string
+//	-
 //		name: fields_attributes[0][name]
 //		in: formData
 //		description: Name of 1st profile field to be added to this account's profile.
@@ -241,28 +311,6 @@ func (m *Module) AccountUpdateCredentialsPATCHHandler(c *gin.Context) {
 	apiutil.JSON(c, http.StatusOK, acctSensitive)
 }
 
-// fieldsAttributesFormBinding satisfies gin's binding.Binding interface.
-// Should only be used specifically for multipart/form-data MIME type.
-type fieldsAttributesFormBinding struct{}
-
-func (fieldsAttributesFormBinding) Name() string {
-	return "FieldsAttributes"
-}
-
-func (fieldsAttributesFormBinding) Bind(req *http.Request, obj any) error {
-	if err := req.ParseForm(); err != nil {
-		return err
-	}
-
-	// Change default namespace prefix and suffix to
-	// allow correct parsing of the field attributes.
-	decoder := form.NewDecoder()
-	decoder.SetNamespacePrefix("[")
-	decoder.SetNamespaceSuffix("]")
-
-	return decoder.Decode(obj, req.Form)
-}
-
 func parseUpdateAccountForm(c *gin.Context) (*apimodel.UpdateCredentialsRequest, error) {
 	form := &apimodel.UpdateCredentialsRequest{
 		Source: &apimodel.UpdateSource{},
@@ -278,7 +326,7 @@ func parseUpdateAccountForm(c *gin.Context) (*apimodel.UpdateCredentialsRequest,
 		// Now use custom form binding for
 		// field attributes in the json data.
 		var err error
-		form.FieldsAttributes, err = parseFieldsAttributesFromJSON(form.JSONFieldsAttributes)
+		form.FieldsAttributes, err = apiutil.ParseFieldsAttributesFromJSON(form.JSONFieldsAttributes)
 		if err != nil {
 			return nil, fmt.Errorf("custom json binding failed: %w", err)
 		}
@@ -290,7 +338,7 @@ func parseUpdateAccountForm(c *gin.Context) (*apimodel.UpdateCredentialsRequest,
 
 		// Now use custom form binding for
 		// field attributes in the form data.
-		if err := c.ShouldBindWith(form, fieldsAttributesFormBinding{}); err != nil {
+		if err := c.ShouldBindWith(form, apiutil.FieldsAttributesFormBinding{}); err != nil {
 			return nil, fmt.Errorf("custom form binding failed: %w", err)
 		}
 	case binding.MIMEMultipartPOSTForm:
@@ -301,7 +349,7 @@ func parseUpdateAccountForm(c *gin.Context) (*apimodel.UpdateCredentialsRequest,
 
 		// Now use custom form binding for
 		// field attributes in the form data.
-		if err := c.ShouldBindWith(form, fieldsAttributesFormBinding{}); err != nil {
+		if err := c.ShouldBindWith(form, apiutil.FieldsAttributesFormBinding{}); err != nil {
 			return nil, fmt.Errorf("custom form binding failed: %w", err)
 		}
 	default:
@@ -321,49 +369,22 @@ func parseUpdateAccountForm(c *gin.Context) (*apimodel.UpdateCredentialsRequest,
 			form.Source.Sensitive == nil &&
 			form.Source.Language == nil &&
 			form.Source.StatusContentType == nil &&
+			form.Source.NotifyNewFromDays == nil &&
+			form.Source.EmailWhenAwayHours == nil &&
+			form.Source.AutoApproveFollowed == nil &&
+			form.Source.AutoApproveLocal == nil &&
+			form.Source.AutoApproveMinAgeDays == nil &&
+			form.Source.RecordFollowerEvents == nil &&
 			form.FieldsAttributes == nil &&
 			form.Theme == nil &&
 			form.CustomCSS == nil &&
 			form.EnableRSS == nil &&
-			form.HideCollections == nil) {
+			form.HideCollections == nil &&
+			form.HideApplication == nil &&
+			form.EnableEmbeds == nil &&
+			form.AttributionDomains == nil) {
 		return nil, errors.New("empty form submitted")
 	}
 
 	return form, nil
 }
-
-func parseFieldsAttributesFromJSON(jsonFieldsAttributes *map[string]apimodel.UpdateField) (*[]apimodel.UpdateField, error) {
-	if jsonFieldsAttributes == nil {
-		// Nothing set, nothing to do.
-		return nil, nil
-	}
-
-	fieldsAttributes := make([]apimodel.UpdateField, 0, len(*jsonFieldsAttributes))
-	for keyStr, updateField := range *jsonFieldsAttributes {
-		key, err := strconv.Atoi(keyStr)
-		if err != nil {
-			return nil, fmt.Errorf("couldn't parse fieldAttributes key %s to int: %w", keyStr, err)
-		}
-
-		fieldsAttributes = append(fieldsAttributes, apimodel.UpdateField{
-			Key:   key,
-			Name:  updateField.Name,
-			Value: updateField.Value,
-		})
-	}
-
-	// Sort slice by the key each field was submitted with.
-	slices.SortFunc(fieldsAttributes, func(a, b apimodel.UpdateField) int {
-		const k = +1
-		switch {
-		case a.Key > b.Key:
-			return +k
-		case a.Key < b.Key:
-			return -k
-		default:
-			return 0
-		}
-	})
-
-	return &fieldsAttributes, nil
-}
@@ -22,6 +22,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
@@ -31,10 +32,18 @@ import (
 //
 // Block account with id.
 //
+// Optionally, a duration can be given in seconds, after which the block will be automatically undone.
+// If you already block the given account, then the duration of the block will be updated instead.
+//
 //	---
 //	tags:
 //	- accounts
 //
+//	consumes:
+//	- application/json
+//	- application/xml
+//	- application/x-www-form-urlencoded
+//
 //	produces:
 //	- application/json
 //
@@ -45,6 +54,15 @@ import (
 //		description: The id of the account to block.
 //		in: path
 //		required: true
+//	-
+//		name: duration
+//		type: integer
+//		minimum: 0
+//		maximum: 315360000
+//		description: >-
+//			Number of seconds after which the block will be automatically undone.
+//			If not set or 0, the block will not expire.
+//		in: formData
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -84,7 +102,14 @@ func (m *Module) AccountBlockPOSTHandler(c *gin.Context) {
 		return
 	}
 
-	relationship, errWithCode := m.processor.Account().BlockCreate(c.Request.Context(), authed.Account, targetAcctID)
+	form := &apimodel.AccountBlockRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+	form.ID = targetAcctID
+
+	relationship, errWithCode := m.processor.Account().BlockCreate(c.Request.Context(), authed.Account, form)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
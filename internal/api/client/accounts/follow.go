@@ -66,6 +66,14 @@ import (
 //		default: false
 //		description: Notify when this account posts.
 //		in: formData
+//	-
+//		name: note
+//		type: string
+//		maxLength: 500
+//		description: >-
+//			Optional plaintext message to send along with a new follow request to a locked account.
+//			Ignored if you already follow (request) the given account.
+//		in: formData
 //
 //	produces:
 //	- application/json
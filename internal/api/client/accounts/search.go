@@ -50,11 +50,9 @@ import (
 //		name: offset
 //		type: integer
 //		description: >-
-//			Page number of results to return (starts at 0).
-//			This parameter is currently not used, offsets
-//			over 0 will always return 0 results.
+//			Number of results to skip, to page through results.
 //		default: 0
-//		maximum: 10
+//		maximum: 400
 //		minimum: 0
 //		in: query
 //	-
@@ -131,7 +129,7 @@ func (m *Module) AccountSearchGETHandler(c *gin.Context) {
 		return
 	}
 
-	offset, errWithCode := apiutil.ParseSearchOffset(c.Query(apiutil.SearchOffsetKey), 0, 10, 0)
+	offset, errWithCode := apiutil.ParseSearchOffset(c.Query(apiutil.SearchOffsetKey), 0, 400, 0)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
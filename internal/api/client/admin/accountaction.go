@@ -53,14 +53,21 @@ import (
 //	-
 //		name: type
 //		in: formData
-//		description: Type of action to be taken, currently only supports `suspend`.
+//		description: Type of action to be taken, currently supports `suspend`, `regenerate-timelines`, `recount-stats`, and `freeze`. `regenerate-timelines` purges and rebuilds the home and list timelines of a local account from the database. `recount-stats` recounts the followers, following, and statuses counts of an account from the database. `freeze` disables sign-in and API access for a local account for the given `duration`, after which it is automatically reenabled; nothing is federated, and the user is notified by email.
 //		type: string
 //		required: true
 //	-
 //		name: text
 //		in: formData
-//		description: Optional text describing why this action was taken.
+//		description: Optional text describing why this action was taken. For `freeze`, this is included in the email sent to the user.
 //		type: string
+//	-
+//		name: duration
+//		in: formData
+//		description: Number of seconds for which to freeze the account. Required for `freeze`, ignored otherwise.
+//		type: integer
+//		minimum: 1
+//		maximum: 31536000
 //
 //	security:
 //	- OAuth2 Bearer:
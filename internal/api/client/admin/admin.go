@@ -45,6 +45,8 @@ const (
 	AccountsActionPath      = AccountsPathWithID + "/action"
 	AccountsApprovePath     = AccountsPathWithID + "/approve"
 	AccountsRejectPath      = AccountsPathWithID + "/reject"
+	AccountsDeliveriesPath  = AccountsPathWithID + "/deliveries"
+	AccountsProfilePath     = AccountsPathWithID + "/profile"
 	MediaCleanupPath        = BasePath + "/media_cleanup"
 	MediaRefetchPath        = BasePath + "/media_refetch"
 	ReportsPath             = BasePath + "/reports"
@@ -54,8 +56,15 @@ const (
 	EmailTestPath           = EmailPath + "/test"
 	InstanceRulesPath       = BasePath + "/instance/rules"
 	InstanceRulesPathWithID = InstanceRulesPath + "/:" + IDKey
+	AnnouncementsPath       = BasePath + "/announcements"
+	AnnouncementsPathWithID = AnnouncementsPath + "/:" + IDKey
+	InstancesPath           = BasePath + "/instances"
+	InstancesPathWithID     = InstancesPath + "/:" + IDKey
+	DirectMessagesPath      = BasePath + "/direct_messages"
+	LogLevelsPath           = BasePath + "/log_levels"
 	DebugPath               = BasePath + "/debug"
 	DebugAPUrlPath          = DebugPath + "/apurl"
+	DebugConversionsPath    = DebugPath + "/conversions"
 
 	IDKey                 = "id"
 	FilterQueryKey        = "filter"
@@ -122,6 +131,8 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodPost, AccountsActionPath, m.AccountActionPOSTHandler)
 	attachHandler(http.MethodPost, AccountsApprovePath, m.AccountApprovePOSTHandler)
 	attachHandler(http.MethodPost, AccountsRejectPath, m.AccountRejectPOSTHandler)
+	attachHandler(http.MethodGet, AccountsDeliveriesPath, m.AccountDeliveriesGETHandler)
+	attachHandler(http.MethodPost, AccountsProfilePath, m.AccountProfilePOSTHandler)
 
 	// media stuff
 	attachHandler(http.MethodPost, MediaCleanupPath, m.MediaCleanupPOSTHandler)
@@ -142,8 +153,27 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodPatch, InstanceRulesPathWithID, m.RulePATCHHandler)
 	attachHandler(http.MethodDelete, InstanceRulesPathWithID, m.RuleDELETEHandler)
 
+	// announcements stuff
+	attachHandler(http.MethodGet, AnnouncementsPath, m.AnnouncementsGETHandler)
+	attachHandler(http.MethodGet, AnnouncementsPathWithID, m.AnnouncementGETHandler)
+	attachHandler(http.MethodPost, AnnouncementsPath, m.AnnouncementPOSTHandler)
+	attachHandler(http.MethodPatch, AnnouncementsPathWithID, m.AnnouncementPATCHHandler)
+	attachHandler(http.MethodDelete, AnnouncementsPathWithID, m.AnnouncementDELETEHandler)
+
+	// instances stuff
+	attachHandler(http.MethodGet, InstancesPath, m.InstancesGETHandler)
+	attachHandler(http.MethodGet, InstancesPathWithID, m.InstanceGETHandler)
+
+	// direct messages stuff
+	attachHandler(http.MethodPost, DirectMessagesPath, m.DirectMessagePOSTHandler)
+
+	// log levels stuff
+	attachHandler(http.MethodGet, LogLevelsPath, m.LogLevelsGETHandler)
+	attachHandler(http.MethodPost, LogLevelsPath, m.LogLevelPOSTHandler)
+
 	// debug stuff
 	if debug.DEBUG {
 		attachHandler(http.MethodGet, DebugAPUrlPath, m.DebugAPUrlHandler)
+		attachHandler(http.MethodGet, DebugConversionsPath, m.DebugConversionsHandler)
 	}
 }
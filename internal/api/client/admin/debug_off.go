@@ -73,3 +73,69 @@ import (
 //		'500':
 //			description: internal server error
 func (m *Module) DebugAPUrlHandler(c *gin.Context) {}
+
+// DebugConversionsHandler swagger:operation GET /api/v1/admin/debug/conversions debugConversions
+//
+// Measure typical conversions of database models to their API representation.
+//
+// Conversions are measured against the live database and caches, using a status
+// made by the requesting admin account that isn't stored in the database, with up
+// to the given amount of existing media attachments and custom emojis.
+// This can help with tuning cache ratios and spotting performance regressions.
+//
+// Only enabled / exposed if GoToSocial was built and is running with flag DEBUG=1.
+//
+//	---
+//	tags:
+//	- debug
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: iterations
+//		type: integer
+//		description: Amount of times to run each conversion.
+//		default: 100
+//		minimum: 1
+//		maximum: 10000
+//		in: query
+//	-
+//		name: attachments
+//		type: integer
+//		description: >-
+//			Amount of media attachments to give the measured status.
+//			Capped to the maximum amount of attachments per status.
+//		default: 0
+//		minimum: 0
+//		in: query
+//	-
+//		name: emojis
+//		type: integer
+//		description: Amount of custom emojis to give the measured status.
+//		default: 0
+//		minimum: 0
+//		maximum: 100
+//		in: query
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			name: Debug response.
+//			schema:
+//				"$ref": "#/definitions/debugConversionsResponse"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) DebugConversionsHandler(c *gin.Context) {}
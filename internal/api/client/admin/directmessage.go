@@ -0,0 +1,166 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// DirectMessagePOSTHandler swagger:operation POST /api/v1/admin/direct_messages adminDirectMessage
+//
+// Send a direct message to local accounts, eg., to let them know about a moderation decision or incident that affects them.
+//
+// A separate direct status is created for each recipient, mentioning only them, so recipients
+// won't see who else received the message. Statuses are created one after the other in the
+// background, with a short pause between each, so it may take a while for all of them to be sent.
+//
+// Only one direct message action can run at a time per sending account.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	consumes:
+//	- multipart/form-data
+//	- application/json
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: status
+//		in: formData
+//		description: Plain text of the message.
+//		type: string
+//		required: true
+//	-
+//		name: spoiler_text
+//		in: formData
+//		description: Optional content warning for the message.
+//		type: string
+//	-
+//		name: language
+//		in: formData
+//		description: ISO 639 language code of the message. Defaults to the sending account's default language.
+//		type: string
+//	-
+//		name: account_id
+//		in: formData
+//		description: >-
+//			ID of the account to send the message from. This can only
+//			be the ID of the requesting admin's own account.
+//			If not set, the message is sent from the instance account.
+//		type: string
+//	-
+//		name: all
+//		in: formData
+//		description: >-
+//			Send the message to all active local accounts.
+//			Exactly one of `all` or `target_account_ids` must be set.
+//		type: boolean
+//	-
+//		name: target_account_ids[]
+//		in: formData
+//		description: IDs of local accounts to send the message to.
+//		type: array
+//		items:
+//			type: string
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			description: >-
+//				Request accepted and will be processed.
+//				Check the admin action for errors.
+//			schema:
+//				"$ref": "#/definitions/adminActionResponse"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: >-
+//				forbidden, or account_id is not the requesting admin's own account
+//		'404':
+//			description: sender or target account not found
+//		'406':
+//			description: not acceptable
+//		'409':
+//			description: >-
+//				Conflict: There is already an admin action running that conflicts with this action.
+//				Check the error message in the response body for more information. This is a temporary
+//				error; it should be possible to process this action if you try again in a bit.
+//		'422':
+//			description: sender account is suspended or has moved
+//		'500':
+//			description: internal server error
+func (m *Module) DirectMessagePOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if !*authed.User.Admin {
+		err := fmt.Errorf("user %s not an admin", authed.User.ID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if authed.Account.IsMoving() {
+		apiutil.ForbiddenAfterMove(c)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := new(apimodel.AdminDirectMessageRequest)
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	actionID, errWithCode := m.processor.Admin().DirectMessage(
+		c.Request.Context(),
+		authed.Account,
+		authed.Application,
+		form,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, &apimodel.AdminActionResponse{
+		ActionID: actionID,
+	})
+}
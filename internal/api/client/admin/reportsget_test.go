@@ -452,6 +452,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       {
         "id": "01FVW7JHQFSFK166WWKR8CBA6M",
         "created_at": "2021-09-20T10:40:37.000Z",
+        "edited_at": null,
         "in_reply_to_id": null,
         "in_reply_to_account_id": null,
         "sensitive": false,
@@ -467,9 +468,22 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
         "reblogged": false,
         "muted": false,
         "bookmarked": false,
+        "content_revealed": false,
         "pinned": false,
         "content": "dark souls status bot: \"thoughts of dog\"",
         "reblog": null,
+        "quote": null,
+        "interaction_policy": {
+          "can_reply": [
+            "public"
+          ],
+          "can_announce": [
+            "public"
+          ],
+          "can_like": [
+            "public"
+          ]
+        },
         "account": {
           "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
           "username": "foss_satan",
@@ -520,7 +534,8 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
               }
             },
             "description": "tweet from thoughts of dog: i drank. all the water. in my bowl. earlier. but just now. i returned. to the same bowl. and it was. full again.. the bowl. is haunted",
-            "blurhash": "LARysgM_IU_3~pD%M_Rj_39FIAt6"
+            "blurhash": "LARysgM_IU_3~pD%M_Rj_39FIAt6",
+            "sensitive": false
           }
         ],
         "mentions": [],
@@ -674,6 +689,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
       {
         "id": "01FVW7JHQFSFK166WWKR8CBA6M",
         "created_at": "2021-09-20T10:40:37.000Z",
+        "edited_at": null,
         "in_reply_to_id": null,
         "in_reply_to_account_id": null,
         "sensitive": false,
@@ -689,9 +705,22 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
         "reblogged": false,
         "muted": false,
         "bookmarked": false,
+        "content_revealed": false,
         "pinned": false,
         "content": "dark souls status bot: \"thoughts of dog\"",
         "reblog": null,
+        "quote": null,
+        "interaction_policy": {
+          "can_reply": [
+            "public"
+          ],
+          "can_announce": [
+            "public"
+          ],
+          "can_like": [
+            "public"
+          ]
+        },
         "account": {
           "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
           "username": "foss_satan",
@@ -742,7 +771,8 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
               }
             },
             "description": "tweet from thoughts of dog: i drank. all the water. in my bowl. earlier. but just now. i returned. to the same bowl. and it was. full again.. the bowl. is haunted",
-            "blurhash": "LARysgM_IU_3~pD%M_Rj_39FIAt6"
+            "blurhash": "LARysgM_IU_3~pD%M_Rj_39FIAt6",
+            "sensitive": false
           }
         ],
         "mentions": [],
@@ -896,6 +926,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
       {
         "id": "01FVW7JHQFSFK166WWKR8CBA6M",
         "created_at": "2021-09-20T10:40:37.000Z",
+        "edited_at": null,
         "in_reply_to_id": null,
         "in_reply_to_account_id": null,
         "sensitive": false,
@@ -911,9 +942,22 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
         "reblogged": false,
         "muted": false,
         "bookmarked": false,
+        "content_revealed": false,
         "pinned": false,
         "content": "dark souls status bot: \"thoughts of dog\"",
         "reblog": null,
+        "quote": null,
+        "interaction_policy": {
+          "can_reply": [
+            "public"
+          ],
+          "can_announce": [
+            "public"
+          ],
+          "can_like": [
+            "public"
+          ]
+        },
         "account": {
           "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
           "username": "foss_satan",
@@ -964,7 +1008,8 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
               }
             },
             "description": "tweet from thoughts of dog: i drank. all the water. in my bowl. earlier. but just now. i returned. to the same bowl. and it was. full again.. the bowl. is haunted",
-            "blurhash": "LARysgM_IU_3~pD%M_Rj_39FIAt6"
+            "blurhash": "LARysgM_IU_3~pD%M_Rj_39FIAt6",
+            "sensitive": false
           }
         ],
         "mentions": [],
@@ -18,14 +18,13 @@
 package bookmarks
 
 import (
-	"fmt"
 	"net/http"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
 const (
@@ -59,6 +58,8 @@ const (
 //		type: integer
 //		description: Number of statuses to return.
 //		default: 30
+//		minimum: 1
+//		maximum: 80
 //		in: query
 //	-
 //		name: max_id
@@ -86,6 +87,8 @@ const (
 //				Link:
 //					type: string
 //					description: Links to the next and previous queries.
+//		'400':
+//			description: bad request
 //		'401':
 //			description: unauthorized
 //		'406':
@@ -104,36 +107,17 @@ func (m *Module) BookmarksGETHandler(c *gin.Context) {
 		return
 	}
 
-	limit := 30
-	limitString := c.Query(LimitKey)
-	if limitString != "" {
-		i, err := strconv.ParseInt(limitString, 10, 64)
-		if err != nil {
-			err := fmt.Errorf("error parsing %s: %s", LimitKey, err)
-			apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
-			return
-		}
-		limit = int(i)
-	}
-
-	maxID := ""
-	maxIDString := c.Query(MaxIDKey)
-	if maxIDString != "" {
-		maxID = maxIDString
-	}
-
-	minID := ""
-	minIDString := c.Query(MinIDKey)
-	if minIDString != "" {
-		minID = minIDString
-	}
-
-	resp, errWithCode := m.processor.Account().BookmarksGet(c.Request.Context(), authed.Account, limit, maxID, minID)
+	page, errWithCode := paging.ParseIDPage(c,
+		1,  // min limit
+		80, // max limit
+		30, // default limit
+	)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
 
+	resp, errWithCode := m.processor.Account().BookmarksGet(c.Request.Context(), authed.Account, page)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
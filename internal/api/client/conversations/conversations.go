@@ -28,6 +28,16 @@ const (
 	// BasePath is the base URI path for serving
 	// conversations, minus the api prefix.
 	BasePath = "/v1/conversations"
+
+	// IDKey is for conversation IDs.
+	IDKey = "id"
+
+	// BasePathWithID is the base path with the ID key in it.
+	// Use this anywhere you need to know the ID of the conversation being queried.
+	BasePathWithID = BasePath + "/:" + IDKey
+
+	// ReadPath is for marking one conversation as read.
+	ReadPath = BasePathWithID + "/read"
 )
 
 type Module struct {
@@ -42,4 +52,6 @@ func New(processor *processing.Processor) *Module {
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	attachHandler(http.MethodGet, BasePath, m.ConversationsGETHandler)
+	attachHandler(http.MethodPost, ReadPath, m.ConversationReadPOSTHandler)
+	attachHandler(http.MethodDelete, BasePathWithID, m.ConversationDELETEHandler)
 }
@@ -24,13 +24,17 @@ import (
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
 // ConversationsGETHandler swagger:operation GET /api/v1/conversations conversationsGet
 //
 // Get an array of (direct message) conversations that requesting account is involved in.
 //
-// NOT IMPLEMENTED YET: Will currently always return an array of length 0.
+// Each conversation is a direct message thread, and includes every other
+// account addressed in it, so that group direct messages show up as one
+// conversation with several participants. Conversations are sorted by their
+// last status, newest first.
 //
 // The next and previous queries can be parsed from the returned Link header.
 // Example:
@@ -53,7 +57,7 @@ import (
 //		description: >-
 //			Return only conversations *OLDER* than the given max ID.
 //			The conversation with the specified ID will not be included in the response.
-//			NOTE: the ID is of the internal conversation, use the Link header for pagination.
+//			NOTE: the ID is of the last status of the conversation, use the Link header for pagination.
 //		in: query
 //		required: false
 //	-
@@ -62,7 +66,7 @@ import (
 //		description: >-
 //			Return only conversations *NEWER* than the given since ID.
 //			The conversation with the specified ID will not be included in the response.
-//			NOTE: the ID is of the internal conversation, use the Link header for pagination.
+//			NOTE: the ID is of the last status of the conversation, use the Link header for pagination.
 //		in: query
 //	-
 //		name: min_id
@@ -70,7 +74,7 @@ import (
 //		description: >-
 //			Return only conversations *IMMEDIATELY NEWER* than the given min ID.
 //			The conversation with the specified ID will not be included in the response.
-//			NOTE: the ID is of the internal conversation, use the Link header for pagination.
+//			NOTE: the ID is of the last status of the conversation, use the Link header for pagination.
 //		in: query
 //		required: false
 //	-
@@ -108,7 +112,8 @@ import (
 //		'500':
 //			description: internal server error
 func (m *Module) ConversationsGETHandler(c *gin.Context) {
-	if _, err := oauth.Authed(c, true, true, true, true); err != nil {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
 		return
 	}
@@ -118,5 +123,29 @@ func (m *Module) ConversationsGETHandler(c *gin.Context) {
 		return
 	}
 
-	apiutil.Data(c, http.StatusOK, apiutil.AppJSON, apiutil.EmptyJSONArray)
+	page, errWithCode := paging.ParseIDPage(c,
+		1,  // min limit
+		80, // max limit
+		40, // default limit
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	resp, errWithCode := m.processor.Conversations().GetPage(
+		c.Request.Context(),
+		authed.Account,
+		page,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if resp.LinkHeader != "" {
+		c.Header("Link", resp.LinkHeader)
+	}
+
+	apiutil.JSON(c, http.StatusOK, resp.Items)
 }
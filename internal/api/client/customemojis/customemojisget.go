@@ -39,7 +39,7 @@ import (
 //
 //	security:
 //	- OAuth2 Bearer:
-//		- read:custom_emojis
+//		- read:statuses
 //
 //	responses:
 //		'200':
@@ -0,0 +1,103 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package exports
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// ExportDownloadGETHandler swagger:operation GET /api/v1/exports/{id}/download exportDownload
+//
+// Download the archive of one export of the requesting account.
+//
+// The archive is a gzipped tarball, which is only available once the state of the export is `done`.
+//
+//	---
+//	tags:
+//	- exports
+//
+//	produces:
+//	- application/gzip
+//
+//	parameters:
+//	-
+//		name: id
+//		type: string
+//		description: ID of the export.
+//		in: path
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:accounts
+//
+//	responses:
+//		'200':
+//			description: The export archive.
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: not found
+//		'422':
+//			description: export archive is not ready for download
+//		'500':
+//			description: internal server error
+func (m *Module) ExportDownloadGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	exportID, errWithCode := apiutil.ParseID(c.Param(IDKey))
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	content, errWithCode := m.processor.Exports().Download(c.Request.Context(), authed.Account, exportID)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	defer func() {
+		// Close content when we're done, catch errors.
+		if err := content.Content.Close(); err != nil {
+			log.Errorf(c.Request.Context(), "error closing export archive: %v", err)
+		}
+	}()
+
+	c.DataFromReader(
+		http.StatusOK,
+		content.ContentLength,
+		content.ContentType,
+		content.Content,
+		map[string]string{
+			"Content-Disposition": `attachment; filename="archive-` + exportID + `.tar.gz"`,
+		},
+	)
+}
@@ -71,6 +71,45 @@ func (suite *FavouritesTestSuite) TestGetFavourites() {
 	assert.Equal(suite.T(), "01F8MH75CBF9JFX4ZAD54N0W0R", favs[len(favs)-1].ID)
 }
 
+func (suite *FavouritesTestSuite) TestGetFavouritesPageUp() {
+	t := suite.testTokens["local_account_1"]
+	oauthToken := oauth.DBTokenToToken(t)
+
+	// setup
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_2"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:8080%s?limit=2&min_id=01F8MHD2QCZSZ6WQS2ATVPEYJ9", favourites.BasePath), nil)
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.favModule.FavouritesGETHandler(ctx)
+
+	// check response
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	favs := []model.Status{}
+	err = json.Unmarshal(b, &favs)
+	suite.NoError(err)
+
+	// Should get the two faves immediately
+	// newer than min_id, still newest first.
+	suite.Len(favs, 2)
+	suite.Equal("01F8MHBQCBTDKN6X5VHGMMN4MA", favs[0].ID)
+	suite.Equal("01F8MHAAY43M6RJ473VQFCVH37", favs[1].ID)
+	suite.Equal(
+		`<http://localhost:8080/api/v1/favourites?limit=2&max_id=01GM435XERVPXXRK6NBAHK5HCZ>; rel="next", <http://localhost:8080/api/v1/favourites?limit=2&min_id=01GM43AKBMN4YNXQ1HZHVC1SGB>; rel="prev"`,
+		result.Header.Get("Link"),
+	)
+}
+
 func TestStatusGetTestSuite(t *testing.T) {
 	suite.Run(t, new(FavouritesTestSuite))
 }
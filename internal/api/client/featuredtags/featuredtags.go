@@ -25,7 +25,14 @@ import (
 )
 
 const (
+	// IDKey is the key to use for retrieving featured tag ID in requests.
+	IDKey = "id"
+	// BasePath is the base API path for this module, excluding the 'api' prefix.
 	BasePath = "/v1/featured_tags"
+	// BasePathWithID is the base path with the ID key in it, for operations on an existing featured tag.
+	BasePathWithID = BasePath + "/:" + IDKey
+	// SuggestionsPath is for getting hashtags suggested for featuring.
+	SuggestionsPath = BasePath + "/suggestions"
 )
 
 type Module struct {
@@ -40,4 +47,7 @@ func New(processor *processing.Processor) *Module {
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	attachHandler(http.MethodGet, BasePath, m.FeaturedTagsGETHandler)
+	attachHandler(http.MethodPost, BasePath, m.FeaturedTagCreatePOSTHandler)
+	attachHandler(http.MethodDelete, BasePathWithID, m.FeaturedTagDELETEHandler)
+	attachHandler(http.MethodGet, SuggestionsPath, m.FeaturedTagSuggestionsGETHandler)
 }
@@ -0,0 +1,258 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package featuredtags_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/featuredtags"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/federation"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type FeaturedTagsTestSuite struct {
+	// standard suite interfaces
+	suite.Suite
+	db           db.DB
+	storage      *storage.Driver
+	mediaManager *media.Manager
+	federator    *federation.Federator
+	processor    *processing.Processor
+	emailSender  email.Sender
+	state        state.State
+
+	// standard suite models
+	testTokens       map[string]*gtsmodel.Token
+	testApplications map[string]*gtsmodel.Application
+	testUsers        map[string]*gtsmodel.User
+	testAccounts     map[string]*gtsmodel.Account
+
+	// module being tested
+	featuredTagsModule *featuredtags.Module
+}
+
+func (suite *FeaturedTagsTestSuite) SetupSuite() {
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
+}
+
+func (suite *FeaturedTagsTestSuite) SetupTest() {
+	suite.state.Caches.Init()
+	suite.state.Caches.Start()
+	testrig.StartNoopWorkers(&suite.state)
+
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+
+	suite.db = testrig.NewTestDB(&suite.state)
+	suite.state.DB = suite.db
+	suite.storage = testrig.NewInMemoryStorage()
+	suite.state.Storage = suite.storage
+
+	testrig.StartTimelines(
+		&suite.state,
+		visibility.NewFilter(&suite.state),
+		typeutils.NewConverter(&suite.state),
+	)
+
+	suite.mediaManager = testrig.NewTestMediaManager(&suite.state)
+	suite.federator = testrig.NewTestFederator(&suite.state, testrig.NewTestTransportController(&suite.state, testrig.NewMockHTTPClient(nil, "../../../../testrig/media")), suite.mediaManager)
+	suite.emailSender = testrig.NewEmailSender("../../../../web/template/", nil)
+	suite.processor = testrig.NewTestProcessor(&suite.state, suite.federator, suite.emailSender, suite.mediaManager)
+	suite.featuredTagsModule = featuredtags.New(suite.processor)
+
+	testrig.StandardDBSetup(suite.db, nil)
+	testrig.StandardStorageSetup(suite.storage, "../../../../testrig/media")
+}
+
+func (suite *FeaturedTagsTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+	testrig.StandardStorageTeardown(suite.storage)
+	testrig.StopWorkers(&suite.state)
+}
+
+// request calls the given handler as the admin
+// account, and returns the response body.
+func (suite *FeaturedTagsTestSuite) request(
+	handler func(*gin.Context),
+	method string,
+	path string,
+	form string,
+	featuredTagID string,
+	expectedHTTPStatus int,
+) (string, error) {
+	var (
+		recorder = httptest.NewRecorder()
+		ctx, _   = testrig.CreateGinTestContext(recorder, nil)
+	)
+
+	// Prepare test context.
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["admin_account"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["admin_account"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["admin_account"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["admin_account"])
+
+	if featuredTagID != "" {
+		// Inject path parameters.
+		ctx.AddParam(featuredtags.IDKey, featuredTagID)
+	}
+
+	requestPath := config.GetProtocol() + "://" + config.GetHost() + "/api" + path
+	request := httptest.NewRequest(method, requestPath, strings.NewReader(form))
+	request.Header.Set("accept", "application/json")
+	if form != "" {
+		request.Header.Set("content-type", "application/x-www-form-urlencoded")
+	}
+	ctx.Request = request
+
+	// trigger the handler
+	handler(ctx)
+
+	// read the response
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		return "", err
+	}
+
+	// Check status code.
+	if status := recorder.Code; expectedHTTPStatus != status {
+		err = fmt.Errorf("expected %d got %d: %s", expectedHTTPStatus, status, string(b))
+	}
+
+	return string(b), err
+}
+
+func (suite *FeaturedTagsTestSuite) TestFeatureUnfeature() {
+	// Admin used #welcome in a status, so it's suggested.
+	resp, err := suite.request(
+		suite.featuredTagsModule.FeaturedTagSuggestionsGETHandler,
+		http.MethodGet, featuredtags.SuggestionsPath, "", "",
+		http.StatusOK,
+	)
+	suite.NoError(err)
+	suite.Contains(resp, `"name":"welcome"`)
+
+	// Feature it.
+	resp, err = suite.request(
+		suite.featuredTagsModule.FeaturedTagCreatePOSTHandler,
+		http.MethodPost, featuredtags.BasePath, "name=%23Welcome", "",
+		http.StatusOK,
+	)
+	suite.NoError(err)
+
+	// Pull the ID out of the response.
+	featuredTagID := resp[len(`{"id":"`) : len(`{"id":"`)+26]
+	suite.Equal(`{"id":"`+featuredTagID+`","name":"welcome","url":"http://localhost:8080/tags/welcome","statuses_count":1,"last_status_at":"2021-10-20T11:36:45.000Z"}`, resp)
+
+	// Featuring it again should fail.
+	resp, err = suite.request(
+		suite.featuredTagsModule.FeaturedTagCreatePOSTHandler,
+		http.MethodPost, featuredtags.BasePath, "name=welcome", "",
+		http.StatusUnprocessableEntity,
+	)
+	suite.NoError(err)
+	suite.Equal(`{"error":"Unprocessable Entity: hashtag is already featured"}`, resp)
+
+	// It should be listed now, and not suggested anymore.
+	resp, err = suite.request(
+		suite.featuredTagsModule.FeaturedTagsGETHandler,
+		http.MethodGet, featuredtags.BasePath, "", "",
+		http.StatusOK,
+	)
+	suite.NoError(err)
+	suite.Contains(resp, `"id":"`+featuredTagID+`"`)
+
+	resp, err = suite.request(
+		suite.featuredTagsModule.FeaturedTagSuggestionsGETHandler,
+		http.MethodGet, featuredtags.SuggestionsPath, "", "",
+		http.StatusOK,
+	)
+	suite.NoError(err)
+	suite.Equal(`[]`, resp)
+
+	// Unfeature it.
+	resp, err = suite.request(
+		suite.featuredTagsModule.FeaturedTagDELETEHandler,
+		http.MethodDelete, featuredtags.BasePath+"/"+featuredTagID, "", featuredTagID,
+		http.StatusOK,
+	)
+	suite.NoError(err)
+	suite.Equal(`{}`, resp)
+
+	resp, err = suite.request(
+		suite.featuredTagsModule.FeaturedTagsGETHandler,
+		http.MethodGet, featuredtags.BasePath, "", "",
+		http.StatusOK,
+	)
+	suite.NoError(err)
+	suite.Equal(`[]`, resp)
+
+	// Unfeaturing it again should 404.
+	_, err = suite.request(
+		suite.featuredTagsModule.FeaturedTagDELETEHandler,
+		http.MethodDelete, featuredtags.BasePath+"/"+featuredTagID, "", featuredTagID,
+		http.StatusNotFound,
+	)
+	suite.NoError(err)
+}
+
+func (suite *FeaturedTagsTestSuite) TestFeatureTooMany() {
+	for i := 0; i < gtsmodel.MaxFeaturedTags; i++ {
+		_, err := suite.request(
+			suite.featuredTagsModule.FeaturedTagCreatePOSTHandler,
+			http.MethodPost, featuredtags.BasePath, fmt.Sprintf("name=tag%d", i), "",
+			http.StatusOK,
+		)
+		suite.NoError(err)
+	}
+
+	resp, err := suite.request(
+		suite.featuredTagsModule.FeaturedTagCreatePOSTHandler,
+		http.MethodPost, featuredtags.BasePath, "name=onetoomany", "",
+		http.StatusUnprocessableEntity,
+	)
+	suite.NoError(err)
+	suite.Equal(`{"error":"Unprocessable Entity: cannot feature more than 10 hashtags"}`, resp)
+}
+
+func TestFeaturedTagsTestSuite(t *testing.T) {
+	suite.Run(t, new(FeaturedTagsTestSuite))
+}
@@ -31,6 +31,9 @@ import (
 //
 // Get an array of accounts that have requested to follow you.
 //
+// If a requesting account attached a message to its follow request,
+// this will be set as `follow_request_note` on the returned account.
+//
 // The next and previous queries can be parsed from the returned Link header.
 // Example:
 //
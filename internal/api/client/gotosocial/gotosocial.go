@@ -0,0 +1,59 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gotosocial
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+)
+
+const (
+	// BasePath is the base path for GoToSocial-specific API features, minus the 'api' prefix.
+	BasePath = "/v1/gotosocial"
+	// StatusesPathWithID is the path for GoToSocial-specific information about one status.
+	StatusesPathWithID = BasePath + "/statuses/:" + apiutil.IDKey
+	// DomainPermissionSubscriptionsPath is the path for viewing domain permission subscriptions.
+	DomainPermissionSubscriptionsPath = BasePath + "/admin/domain_permission_subscriptions"
+	// AccountStatsPath is the path for viewing statistics about the requesting account.
+	AccountStatsPath = BasePath + "/account_stats"
+	// FollowerEventsPath is the path for viewing the follower events of the requesting account.
+	FollowerEventsPath = BasePath + "/follower_events"
+)
+
+// Module implements APIs for features specific
+// to GoToSocial, which don't fit within (and so
+// shouldn't be squeezed into) the Mastodon API.
+type Module struct {
+	processor *processing.Processor
+}
+
+func New(processor *processing.Processor) *Module {
+	return &Module{
+		processor: processor,
+	}
+}
+
+func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
+	attachHandler(http.MethodGet, StatusesPathWithID, m.StatusGETHandler)
+	attachHandler(http.MethodGet, DomainPermissionSubscriptionsPath, m.DomainPermissionSubscriptionsGETHandler)
+	attachHandler(http.MethodGet, AccountStatsPath, m.AccountStatsGETHandler)
+	attachHandler(http.MethodGet, FollowerEventsPath, m.FollowerEventsGETHandler)
+}
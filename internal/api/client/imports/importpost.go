@@ -0,0 +1,152 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package imports
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// ImportPOSTHandler swagger:operation POST /api/v1/import importCreate
+//
+// Import follows, blocks, mutes, or lists from a CSV file, as exported by Mastodon.
+//
+// The expected columns of the file depend on the type of import:
+//
+//   - `following`: account address, and optionally whether to show boosts and whether to notify on new posts.
+//   - `blocking`: account address.
+//   - `muting`: account address, and optionally whether to hide notifications.
+//   - `lists`: list name, and account address. Lists are created if they don't exist yet,
+//     and accounts are followed if they aren't yet, as only followed accounts can be added to lists.
+//
+// Account addresses look like `someone@example.org`. A header line starting with `Account address` is skipped.
+//
+// The file is imported in the background: poll the returned import
+// until its state is `done`, then check `failures` for rows that could not be imported.
+// Only one import per account can be processed at a time.
+//
+//	---
+//	tags:
+//	- import
+//
+//	consumes:
+//	- multipart/form-data
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: type
+//		in: formData
+//		description: Kind of data being imported.
+//		type: string
+//		enum:
+//			- following
+//			- blocking
+//			- muting
+//			- lists
+//		required: true
+//	-
+//		name: data
+//		in: formData
+//		description: CSV file to import.
+//		type: file
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:accounts
+//
+//	responses:
+//		'200':
+//			description: The newly created import.
+//			schema:
+//				"$ref": "#/definitions/accountImport"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+//		'422':
+//			description: file could not be parsed, or an import is already being processed
+//		'500':
+//			description: internal server error
+func (m *Module) ImportPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if authed.Account.IsMoving() {
+		apiutil.ForbiddenAfterMove(c)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.AccountImportRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	importType := gtsmodel.AccountImportType(form.Type)
+	switch importType {
+	case gtsmodel.AccountImportTypeFollowing,
+		gtsmodel.AccountImportTypeBlocking,
+		gtsmodel.AccountImportTypeMuting,
+		gtsmodel.AccountImportTypeLists:
+		// No problem.
+	default:
+		err := errors.New("type must be one of following, blocking, muting, lists")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if form.Data == nil || form.Data.Size == 0 {
+		err := errors.New("no data file provided, or file was empty")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	imp, errWithCode := m.processor.Imports().Create(
+		c.Request.Context(),
+		authed.Account,
+		importType,
+		form.Data,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, imp)
+}
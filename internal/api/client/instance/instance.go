@@ -29,6 +29,7 @@ const (
 	InstanceInformationPathV2 = "/v2/instance"
 	InstancePeersPath         = InstanceInformationPathV1 + "/peers"
 	InstanceRulesPath         = InstanceInformationPathV1 + "/rules"
+	InstanceWebClientsPath    = InstanceInformationPathV1 + "/web_clients"
 	PeersFilterKey            = "filter" // PeersFilterKey is used to provide filters to /api/v1/instance/peers
 )
 
@@ -50,4 +51,6 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodGet, InstancePeersPath, m.InstancePeersGETHandler)
 
 	attachHandler(http.MethodGet, InstanceRulesPath, m.InstanceRulesGETHandler)
+
+	attachHandler(http.MethodGet, InstanceWebClientsPath, m.InstanceWebClientsGETHandler)
 }
@@ -65,7 +65,8 @@ import (
 //				If no filter parameter is provided, or filter is empty, then a legacy,
 //				Mastodon-API compatible response will be returned. This will consist of
 //				just a 'flat' array of strings like `["example.com", "example.org"]`,
-//				which corresponds to domains this instance peers with.
+//				which corresponds to domains this instance peers with. Instances that
+//				haven't sent this instance any federated traffic in a long time are omitted.
 //
 //
 //				If a filter parameter is provided, then an array of objects with at least
@@ -27,6 +27,8 @@ import (
 const (
 	// BasePath is the base path for serving the markers API, minus the 'api' prefix
 	BasePath = "/v1/markers"
+	// UnreadCountPath is for counting items newer than the markers.
+	UnreadCountPath = BasePath + "/unread_count"
 )
 
 type Module struct {
@@ -42,4 +44,5 @@ func New(processor *processing.Processor) *Module {
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	attachHandler(http.MethodGet, BasePath, m.MarkersGETHandler)
 	attachHandler(http.MethodPost, BasePath, m.MarkersPOSTHandler)
+	attachHandler(http.MethodGet, UnreadCountPath, m.MarkersUnreadCountGETHandler)
 }
@@ -0,0 +1,114 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package markers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// MarkersUnreadCountGETHandler swagger:operation GET /api/v1/markers/unread_count markersUnreadCountGet
+//
+// Get the number of items in timelines newer than their markers.
+//
+// Counts stop at the given limit, so clients can show eg., "99+" without
+// downloading pages of items. If a timeline marker hasn't been set yet, all
+// its items count as unread. Statuses counted for the home timeline aren't
+// checked for visibility, so the count may be slightly higher than the number
+// of statuses actually shown in the timeline.
+//
+//	---
+//	tags:
+//	- markers
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: timeline
+//		type: array
+//		items:
+//			type: string
+//			enum:
+//				- home
+//				- notifications
+//		description: Timelines to count unread items of. If not set, all are counted.
+//		in: query
+//	-
+//		name: limit
+//		type: integer
+//		description: Maximum number of unread items to count per timeline.
+//		default: 100
+//		minimum: 1
+//		maximum: 1000
+//		in: query
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:statuses
+//
+//	responses:
+//		'200':
+//			description: Unread counts of requested timelines.
+//			schema:
+//				"$ref": "#/definitions/markerUnreadCounts"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) MarkersUnreadCountGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	names, errWithCode := parseMarkerNames(c.QueryArray("timeline[]"))
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	limit, errWithCode := apiutil.ParseLimit(c.Query(apiutil.LimitKey), 100, 1000, 1)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	counts, errWithCode := m.processor.Markers().UnreadCounts(c.Request.Context(), authed.Account, names, limit)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, counts)
+}
@@ -29,6 +29,8 @@ const (
 	IDKey            = "id"                                    // IDKey is the key for media attachment IDs
 	BasePath         = "/:" + apiutil.APIVersionKey + "/media" // BasePath is the base API path for making media requests through v1 or v2 of the api (for mastodon API compatibility)
 	AttachmentWithID = BasePath + "/:" + IDKey                 // BasePathWithID corresponds to a media attachment with the given ID
+	UploadsPath      = BasePath + "/uploads"                   // UploadsPath is the path for creating resumable media uploads
+	UploadWithID     = UploadsPath + "/:" + IDKey              // UploadWithID corresponds to a resumable media upload with the given ID
 )
 
 type Module struct {
@@ -45,4 +47,7 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodPost, BasePath, m.MediaCreatePOSTHandler)
 	attachHandler(http.MethodGet, AttachmentWithID, m.MediaGETHandler)
 	attachHandler(http.MethodPut, AttachmentWithID, m.MediaPUTHandler)
+	attachHandler(http.MethodPost, UploadsPath, m.MediaUploadCreatePOSTHandler)
+	attachHandler(http.MethodGet, UploadWithID, m.MediaUploadGETHandler)
+	attachHandler(http.MethodPatch, UploadWithID, m.MediaUploadPATCHHandler)
 }
@@ -34,6 +34,11 @@ import (
 //
 // Upload a new media attachment.
 //
+// When using the v2 API, media is processed asynchronously. If processing
+// finishes quickly, the response code will be 200. Otherwise, the response
+// code will be 202, and the client should poll `/api/v1/media/{id}` until
+// processing has finished, at which point the attachment url will be set.
+//
 //	---
 //	tags:
 //	- media
@@ -69,6 +74,23 @@ import (
 //		type: string
 //		default: "0,0"
 //	-
+//		name: sensitive
+//		in: formData
+//		description: >-
+//			Mark the media as sensitive by itself, hiding it behind
+//			a warning even if the status it's attached to is not sensitive.
+//		type: boolean
+//		default: false
+//	-
+//		name: expires_in
+//		in: formData
+//		description: >-
+//			Number of seconds after which the media file should be deleted,
+//			leaving only a placeholder in any status it's attached to.
+//			Must be between 300 (5 minutes) and 31536000 (1 year).
+//			If not set, the media will not expire.
+//		type: integer
+//	-
 //		name: file
 //		in: formData
 //		description: The media attachment to upload.
@@ -84,6 +106,13 @@ import (
 //			description: The newly-created media attachment.
 //			schema:
 //				"$ref": "#/definitions/attachment"
+//		'202':
+//			description: >-
+//				The newly-created media attachment, which is
+//				still being processed (v2 API only). Its url
+//				will be null until processing has finished.
+//			schema:
+//				"$ref": "#/definitions/attachment"
 //		'400':
 //			description: bad request
 //		'401':
@@ -129,21 +158,41 @@ func (m *Module) MediaCreatePOSTHandler(c *gin.Context) {
 		return
 	}
 
-	apiAttachment, errWithCode := m.processor.Media().Create(c.Request.Context(), authed.Account, form)
+	ctx := apiutil.IdempotencyContext(c)
+
+	if apiVersion == apiutil.APIv1 {
+		// The v1 media API processes
+		// the upload synchronously.
+		apiAttachment, errWithCode := m.processor.Media().Create(ctx, authed.Account, form)
+		if errWithCode != nil {
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		apiutil.JSON(c, http.StatusOK, apiAttachment)
+		return
+	}
+
+	apiAttachment, errWithCode := m.processor.Media().CreateAsync(ctx, authed.Account, form)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
 
-	if apiVersion == apiutil.APIv2 {
-		// the mastodon v2 media API specifies that the URL should be null
-		// and that the client should call /api/v1/media/:id to get the URL
-		//
-		// so even though we have the URL already, remove it now to comply
-		// with the api
-		apiAttachment.URL = nil
+	if apiAttachment.URL == nil {
+		// Still being processed, client
+		// should poll /api/v1/media/:id.
+		apiutil.JSON(c, http.StatusAccepted, apiAttachment)
+		return
 	}
 
+	// the mastodon v2 media API specifies that the URL should be null
+	// and that the client should call /api/v1/media/:id to get the URL
+	//
+	// so even though we have the URL already, remove it now to comply
+	// with the api
+	apiAttachment.URL = nil
+
 	apiutil.JSON(c, http.StatusOK, apiAttachment)
 }
 
@@ -173,5 +222,27 @@ func validateCreateMedia(form *apimodel.AttachmentRequest) error {
 		return fmt.Errorf("image description length must be between %d and %d characters (inclusive), but provided image description was %d chars", minDescriptionChars, maxDescriptionChars, length)
 	}
 
+	if form.ExpiresIn != 0 {
+		if err := validateExpiresIn(form.ExpiresIn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateExpiresIn checks that the given
+// non-zero media expiry in seconds is neither
+// too soon nor too far away.
+func validateExpiresIn(expiresIn int) error {
+	const (
+		minExpiresIn = 5 * 60             // 5 minutes
+		maxExpiresIn = 365 * 24 * 60 * 60 // 1 year
+	)
+
+	if expiresIn < minExpiresIn || expiresIn > maxExpiresIn {
+		return fmt.Errorf("expires_in must be between %d and %d seconds (inclusive), but provided expires_in was %d", minExpiresIn, maxExpiresIn, expiresIn)
+	}
+
 	return nil
 }
@@ -82,6 +82,10 @@ func (suite *MediaCreateTestSuite) SetupSuite() {
 	suite.state.Caches.Init()
 	testrig.StartNoopWorkers(&suite.state)
 
+	// v2 uploads are processed
+	// by the media workers.
+	suite.state.Workers.Media.Start(1)
+
 	// setup standard items
 	testrig.InitTestConfig()
 	testrig.InitTestLog()
@@ -208,9 +212,9 @@ func (suite *MediaCreateTestSuite) TestMediaCreateSuccessful() {
 		},
 		Small: apimodel.MediaDimensions{
 			Width:  512,
-			Height: 288,
-			Size:   "512x288",
-			Aspect: 1.7777778,
+			Height: 512,
+			Size:   "512x512",
+			Aspect: 1,
 		},
 		Focus: &apimodel.MediaFocus{
 			X: -0.5,
@@ -293,9 +297,9 @@ func (suite *MediaCreateTestSuite) TestMediaCreateSuccessfulV2() {
 		},
 		Small: apimodel.MediaDimensions{
 			Width:  512,
-			Height: 288,
-			Size:   "512x288",
-			Aspect: 1.7777778,
+			Height: 512,
+			Size:   "512x512",
+			Aspect: 1,
 		},
 		Focus: &apimodel.MediaFocus{
 			X: -0.5,
@@ -388,6 +392,48 @@ func (suite *MediaCreateTestSuite) TestMediaCreateTooShortDescription() {
 	suite.EqualValues(http.StatusOK, recorder.Code)
 }
 
+func (suite *MediaCreateTestSuite) TestMediaCreateTypeNotAllowed() {
+	// only allow png uploads
+	config.SetMediaAllowedMIMETypes([]string{"image/png"})
+
+	// set up the context for the request
+	t := suite.testTokens["local_account_1"]
+	oauthToken := oauth.DBTokenToToken(t)
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+
+	// create the request, using a png file
+	// extension to try to sneak a jpeg through
+	buf, w, err := testrig.CreateMultipartFormData("file", "../../../../testrig/media/test-jpeg.jpg", map[string][]string{
+		"description": {"this is a test image -- a cool background from somewhere"},
+	})
+	if err != nil {
+		panic(err)
+	}
+	body := bytes.Replace(buf.Bytes(), []byte(`filename="test-jpeg.jpg"`), []byte(`filename="test-jpeg.png"`), 1)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "http://localhost:8080/api/v1/media", bytes.NewReader(body)) // the endpoint we're hitting
+	ctx.Request.Header.Set("Content-Type", w.FormDataContentType())
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.AddParam(apiutil.APIVersionKey, apiutil.APIv1)
+
+	// do the actual request
+	suite.mediaModule.MediaCreatePOSTHandler(ctx)
+
+	// check response
+	suite.EqualValues(http.StatusUnprocessableEntity, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	suite.Equal(`{"error":"Unprocessable Entity: uploading media of type image/jpeg is not allowed on this instance"}`, string(b))
+}
+
 func TestMediaCreateTestSuite(t *testing.T) {
 	suite.Run(t, new(MediaCreateTestSuite))
 }
@@ -31,6 +31,10 @@ import (
 //
 // Get a media attachment that you own.
 //
+// If the attachment is still being processed, the response code
+// will be 206 and the attachment url will be null. If processing
+// failed or timed out, the response code will be 422.
+//
 //	---
 //	tags:
 //	- media
@@ -48,13 +52,19 @@ import (
 //
 //	security:
 //	- OAuth2 Bearer:
-//		- read:media
+//		- write:media
 //
 //	responses:
 //		'200':
 //			description: The requested media attachment.
 //			schema:
 //				"$ref": "#/definitions/attachment"
+//		'206':
+//			description: >-
+//				The requested media attachment, which is
+//				still being processed. Its url will be null.
+//			schema:
+//				"$ref": "#/definitions/attachment"
 //		'400':
 //			description: bad request
 //		'401':
@@ -63,6 +73,8 @@ import (
 //			description: not found
 //		'406':
 //			description: not acceptable
+//		'422':
+//			description: media processing failed or timed out
 //		'500':
 //		   description: internal server error
 func (m *Module) MediaGETHandler(c *gin.Context) {
@@ -98,5 +110,11 @@ func (m *Module) MediaGETHandler(c *gin.Context) {
 		return
 	}
 
+	if attachment.URL == nil {
+		// Still being processed.
+		apiutil.JSON(c, http.StatusPartialContent, attachment)
+		return
+	}
+
 	apiutil.JSON(c, http.StatusOK, attachment)
 }
@@ -77,6 +77,22 @@ import (
 //		type: string
 //		allowEmptyValue: true
 //		default: "0,0"
+//	-
+//		name: sensitive
+//		in: formData
+//		description: >-
+//			Mark the media as sensitive by itself, hiding it behind
+//			a warning even if the status it's attached to is not sensitive.
+//		type: boolean
+//	-
+//		name: expires_in
+//		in: formData
+//		description: >-
+//			Number of seconds from now after which the media file should be deleted,
+//			leaving only a placeholder in any status it's attached to.
+//			Must be between 300 (5 minutes) and 31536000 (1 year),
+//			or 0 to stop the media from expiring.
+//		type: integer
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -95,6 +111,8 @@ import (
 //			description: not found
 //		'406':
 //			description: not acceptable
+//		'422':
+//			description: attachment has expired
 //		'500':
 //			description: internal server error
 func (m *Module) MediaPUTHandler(c *gin.Context) {
@@ -159,8 +177,14 @@ func validateUpdateMedia(form *apimodel.AttachmentUpdateRequest) error {
 		}
 	}
 
-	if form.Focus == nil && form.Description == nil {
-		return errors.New("focus and description were both nil, there's nothing to update")
+	if form.ExpiresIn != nil && *form.ExpiresIn != 0 {
+		if err := validateExpiresIn(*form.ExpiresIn); err != nil {
+			return err
+		}
+	}
+
+	if form.Focus == nil && form.Description == nil && form.Sensitive == nil && form.ExpiresIn == nil {
+		return errors.New("focus, description, sensitive, and expires_in were all nil, there's nothing to update")
 	}
 
 	return nil
@@ -24,6 +24,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -184,13 +185,17 @@ func (suite *MediaUpdateTestSuite) TestUpdateImage() {
 	suite.EqualValues("image", attachmentReply.Type)
 	suite.EqualValues(apimodel.MediaMeta{
 		Original: apimodel.MediaDimensions{Width: 800, Height: 450, FrameRate: "", Duration: 0, Bitrate: 0, Size: "800x450", Aspect: 1.7777778},
-		Small:    apimodel.MediaDimensions{Width: 256, Height: 144, FrameRate: "", Duration: 0, Bitrate: 0, Size: "256x144", Aspect: 1.7777778},
+		Small:    apimodel.MediaDimensions{Width: 450, Height: 450, FrameRate: "", Duration: 0, Bitrate: 0, Size: "450x450", Aspect: 1},
 		Focus:    &apimodel.MediaFocus{X: -0.1, Y: 0.3},
 	}, *attachmentReply.Meta)
 	suite.Equal(toUpdate.Blurhash, *attachmentReply.Blurhash)
 	suite.Equal(toUpdate.ID, attachmentReply.ID)
 	suite.Equal(toUpdate.URL, *attachmentReply.URL)
-	suite.NotEmpty(toUpdate.Thumbnail.URL, attachmentReply.PreviewURL)
+
+	// thumbnail should have been recropped around the new
+	// focus point, and given a new url to bust caches
+	suite.NotEqual(toUpdate.Thumbnail.URL, *attachmentReply.PreviewURL)
+	suite.True(strings.HasPrefix(*attachmentReply.PreviewURL, toUpdate.Thumbnail.URL+"?v="))
 }
 
 func (suite *MediaUpdateTestSuite) TestUpdateImageShortDescription() {
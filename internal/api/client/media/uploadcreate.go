@@ -0,0 +1,143 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package media
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// MediaUploadCreatePOSTHandler swagger:operation POST /api/v1/media/uploads mediaUploadCreate
+//
+// Start a new resumable media upload.
+//
+// This is intended for large files (such as videos) uploaded over unreliable connections.
+// Once the upload has been created, send the file data in one or more chunks using
+// `PATCH /api/v1/media/uploads/{id}`. If a chunk fails partway through, fetch the upload
+// with `GET /api/v1/media/uploads/{id}` to see how many bytes were received, and resume
+// from that offset.
+//
+// Uploads expire 24 hours after the last chunk was received.
+//
+//	---
+//	tags:
+//	- media
+//
+//	consumes:
+//	- application/json
+//	- application/x-www-form-urlencoded
+//	- multipart/form-data
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: size
+//		in: formData
+//		description: Total size of the media file in bytes.
+//		type: integer
+//		required: true
+//	-
+//		name: description
+//		in: formData
+//		description: >-
+//			Image or media description to use as alt-text on the attachment.
+//			This is very useful for users of screenreaders!
+//			May or may not be required, depending on your instance settings.
+//		type: string
+//	-
+//		name: focus
+//		in: formData
+//		description: >-
+//			Focus of the media file.
+//			If present, it should be in the form of two comma-separated floats between -1 and 1.
+//			For example: `-0.5,0.25`.
+//		type: string
+//		default: "0,0"
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:media
+//
+//	responses:
+//		'200':
+//			description: The newly-created upload.
+//			schema:
+//				"$ref": "#/definitions/mediaUpload"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+//		'422':
+//			description: unprocessable
+//		'500':
+//			description: internal server error
+func (m *Module) MediaUploadCreatePOSTHandler(c *gin.Context) {
+	if _, errWithCode := apiutil.ParseAPIVersion(
+		c.Param(apiutil.APIVersionKey),
+		[]string{apiutil.APIv1}...,
+	); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if authed.Account.IsMoving() {
+		apiutil.ForbiddenAfterMove(c)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.MediaUploadRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if length := len([]rune(form.Description)); length > config.GetMediaDescriptionMaxChars() {
+		err := fmt.Errorf("image description length must be between %d and %d characters (inclusive), but provided image description was %d chars", config.GetMediaDescriptionMinChars(), config.GetMediaDescriptionMaxChars(), length)
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	apiUpload, errWithCode := m.processor.Media().UploadCreate(c.Request.Context(), authed.Account, form)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, apiUpload)
+}
@@ -0,0 +1,181 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package media
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// MediaUploadPATCHHandler swagger:operation PATCH /api/v1/media/uploads/{id} mediaUploadPatch
+//
+// Send a chunk of file data for a resumable media upload that you own.
+//
+// The request body should contain the raw bytes of the chunk, and the `Content-Range`
+// header should give the position of the chunk within the file, for example
+// `Content-Range: bytes 0-1048575/5242880`. The start of the range must match the
+// current offset of the upload.
+//
+// Once all bytes of the file have been received, the upload is processed in the
+// same way as `POST /api/v2/media`, and the returned upload will contain the new
+// media attachment. If the attachment url is null, the client should poll
+// `GET /api/v1/media/{id}` until processing has finished.
+//
+//	---
+//	tags:
+//	- media
+//
+//	consumes:
+//	- application/octet-stream
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: id
+//		description: id of the upload
+//		type: string
+//		in: path
+//		required: true
+//	-
+//		name: Content-Range
+//		description: Position of the chunk within the file, in the form `bytes start-end/size`.
+//		type: string
+//		in: header
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:media
+//
+//	responses:
+//		'200':
+//			description: The updated upload.
+//			schema:
+//				"$ref": "#/definitions/mediaUpload"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'409':
+//			description: chunk does not start at the current upload offset
+//		'422':
+//			description: unprocessable
+//		'500':
+//			description: internal server error
+func (m *Module) MediaUploadPATCHHandler(c *gin.Context) {
+	if _, errWithCode := apiutil.ParseAPIVersion(
+		c.Param(apiutil.APIVersionKey),
+		[]string{apiutil.APIv1}...,
+	); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if authed.Account.IsMoving() {
+		apiutil.ForbiddenAfterMove(c)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	uploadID := c.Param(IDKey)
+	if uploadID == "" {
+		err := errors.New("no upload id specified")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	start, end, err := parseContentRange(c.GetHeader("Content-Range"))
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	// Only read as many bytes as the client said it would send.
+	data := io.LimitReader(c.Request.Body, end-start+1)
+
+	apiUpload, errWithCode := m.processor.Media().UploadAppend(c.Request.Context(), authed.Account, uploadID, start, data)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, apiUpload)
+}
+
+// parseContentRange parses the start and end positions from
+// a Content-Range header of the form "bytes start-end/size".
+func parseContentRange(header string) (int64, int64, error) {
+	if header == "" {
+		return 0, 0, errors.New("no Content-Range header specified")
+	}
+
+	invalid := fmt.Errorf("invalid Content-Range header %q", header)
+
+	rng, ok := strings.CutPrefix(header, "bytes ")
+	if !ok {
+		return 0, 0, invalid
+	}
+
+	// We don't need the size, as
+	// that's set on upload creation.
+	rng, _, ok = strings.Cut(rng, "/")
+	if !ok {
+		return 0, 0, invalid
+	}
+
+	startStr, endStr, ok := strings.Cut(rng, "-")
+	if !ok {
+		return 0, 0, invalid
+	}
+
+	start, err := strconv.ParseInt(startStr, 10, 64)
+	if err != nil || start < 0 {
+		return 0, 0, invalid
+	}
+
+	end, err := strconv.ParseInt(endStr, 10, 64)
+	if err != nil || end < start {
+		return 0, 0, invalid
+	}
+
+	return start, end, nil
+}
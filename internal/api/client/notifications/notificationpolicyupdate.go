@@ -0,0 +1,198 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package notifications
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// NotificationPolicyPATCHHandler swagger:operation PATCH /api/v1/notifications/policy notificationPolicyUpdateV1
+//
+// Update the notification policy of the requesting account.
+//
+// Fields that are not provided are left unchanged.
+//
+//	---
+//	tags:
+//	- notifications
+//
+//	consumes:
+//	- application/json
+//	- application/x-www-form-urlencoded
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: filter_not_following
+//		type: boolean
+//		description: Filter notifications from accounts you don't follow.
+//		in: formData
+//	-
+//		name: filter_not_followers
+//		type: boolean
+//		description: Filter notifications from accounts that don't follow you.
+//		in: formData
+//	-
+//		name: filter_new_accounts
+//		type: boolean
+//		description: Filter notifications from accounts created in the past 30 days.
+//		in: formData
+//	-
+//		name: filter_private_mentions
+//		type: boolean
+//		description: Filter private mentions from accounts you don't follow, unless in reply to you.
+//		in: formData
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:notifications
+//
+//	responses:
+//		'200':
+//			schema:
+//				"$ref": "#/definitions/notificationPolicyV1"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) NotificationPolicyPATCHHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.NotificationPolicyUpdateRequestV1{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	policy, errWithCode := m.processor.Timeline().NotificationPolicyUpdateV1(c.Request.Context(), authed.Account, form)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, policy)
+}
+
+// NotificationPolicyV2PATCHHandler swagger:operation PATCH /api/v2/notifications/policy notificationPolicyUpdate
+//
+// Update the notification policy of the requesting account.
+//
+// Each policy is one of `accept` (notify as usual), `filter` (set
+// notifications aside in notification requests), or `drop` (drop
+// notifications altogether). Fields that are not provided are left unchanged.
+//
+//	---
+//	tags:
+//	- notifications
+//
+//	consumes:
+//	- application/json
+//	- application/x-www-form-urlencoded
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: for_not_following
+//		type: string
+//		enum: [accept, filter, drop]
+//		description: Policy for notifications from accounts you don't follow.
+//		in: formData
+//	-
+//		name: for_not_followers
+//		type: string
+//		enum: [accept, filter, drop]
+//		description: Policy for notifications from accounts that don't follow you.
+//		in: formData
+//	-
+//		name: for_new_accounts
+//		type: string
+//		enum: [accept, filter, drop]
+//		description: Policy for notifications from accounts created in the past 30 days.
+//		in: formData
+//	-
+//		name: for_private_mentions
+//		type: string
+//		enum: [accept, filter, drop]
+//		description: Policy for private mentions from accounts you don't follow, unless in reply to you.
+//		in: formData
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:notifications
+//
+//	responses:
+//		'200':
+//			schema:
+//				"$ref": "#/definitions/notificationPolicy"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) NotificationPolicyV2PATCHHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.NotificationPolicyUpdateRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	policy, errWithCode := m.processor.Timeline().NotificationPolicyUpdate(c.Request.Context(), authed.Account, form)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, policy)
+}
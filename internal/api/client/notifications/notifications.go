@@ -34,6 +34,30 @@ const (
 	BasePathWithID    = BasePath + "/:" + IDKey
 	BasePathWithClear = BasePath + "/clear"
 
+	// GroupKeyKey is for notification group keys
+	GroupKeyKey = "group_key"
+	// BasePathV2 is the base path for serving the grouped notifications API, minus the 'api' prefix.
+	BasePathV2 = "/v2/notifications"
+	// BasePathV2WithGroupKey is the v2 base path with the group key in it.
+	// Use this anywhere you need to know the key of the notification group being queried.
+	BasePathV2WithGroupKey = BasePathV2 + "/:" + GroupKeyKey
+	GroupAccountsPath      = BasePathV2WithGroupKey + "/accounts"
+	GroupDismissPath       = BasePathV2WithGroupKey + "/dismiss"
+
+	// PolicyPath is the path for the notification policy of the authorized account.
+	PolicyPath   = BasePath + "/policy"
+	PolicyPathV2 = BasePathV2 + "/policy"
+
+	// RequestsPath is the path for the notification requests of the authorized account.
+	RequestsPath = BasePath + "/requests"
+	// RequestsPathWithID is the requests path with the ID key in it.
+	// Use this anywhere you need to know the ID of the notification request being queried.
+	RequestsPathWithID  = RequestsPath + "/:" + IDKey
+	RequestAcceptPath   = RequestsPathWithID + "/accept"
+	RequestDismissPath  = RequestsPathWithID + "/dismiss"
+	RequestsAcceptPath  = RequestsPath + "/accept"
+	RequestsDismissPath = RequestsPath + "/dismiss"
+
 	// ExcludeTypes is an array specifying notification types to exclude
 	ExcludeTypesKey = "exclude_types[]"
 	MaxIDKey        = "max_id"
@@ -56,4 +80,18 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodGet, BasePath, m.NotificationsGETHandler)
 	attachHandler(http.MethodGet, BasePathWithID, m.NotificationGETHandler)
 	attachHandler(http.MethodPost, BasePathWithClear, m.NotificationsClearPOSTHandler)
+	attachHandler(http.MethodGet, BasePathV2, m.GroupedNotificationsGETHandler)
+	attachHandler(http.MethodGet, BasePathV2WithGroupKey, m.NotificationGroupGETHandler)
+	attachHandler(http.MethodGet, GroupAccountsPath, m.NotificationGroupAccountsGETHandler)
+	attachHandler(http.MethodPost, GroupDismissPath, m.NotificationGroupDismissPOSTHandler)
+	attachHandler(http.MethodGet, PolicyPath, m.NotificationPolicyGETHandler)
+	attachHandler(http.MethodPatch, PolicyPath, m.NotificationPolicyPATCHHandler)
+	attachHandler(http.MethodGet, PolicyPathV2, m.NotificationPolicyV2GETHandler)
+	attachHandler(http.MethodPatch, PolicyPathV2, m.NotificationPolicyV2PATCHHandler)
+	attachHandler(http.MethodGet, RequestsPath, m.NotificationRequestsGETHandler)
+	attachHandler(http.MethodGet, RequestsPathWithID, m.NotificationRequestGETHandler)
+	attachHandler(http.MethodPost, RequestAcceptPath, m.NotificationRequestAcceptPOSTHandler)
+	attachHandler(http.MethodPost, RequestDismissPath, m.NotificationRequestDismissPOSTHandler)
+	attachHandler(http.MethodPost, RequestsAcceptPath, m.NotificationRequestsAcceptPOSTHandler)
+	attachHandler(http.MethodPost, RequestsDismissPath, m.NotificationRequestsDismissPOSTHandler)
 }
@@ -0,0 +1,209 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package push_test
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/push"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type PushSubscriptionTestSuite struct {
+	PushStandardTestSuite
+}
+
+func (suite *PushSubscriptionTestSuite) request(
+	method string,
+	form url.Values,
+	handler gin.HandlerFunc,
+	expectedHTTPStatus int,
+	target any,
+) {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Request = httptest.NewRequest(method, "http://localhost:8080/api"+push.BasePath, strings.NewReader(form.Encode()))
+	ctx.Request.Header.Set("accept", "application/json")
+	if form != nil {
+		ctx.Request.Header.Set("content-type", "application/x-www-form-urlencoded")
+	}
+
+	handler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	suite.Equal(expectedHTTPStatus, recorder.Code)
+	if expectedHTTPStatus != http.StatusOK || target == nil {
+		return
+	}
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := json.Unmarshal(b, target); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+// subscribeForm returns a form for creating a
+// subscription, with a freshly generated key.
+func (suite *PushSubscriptionTestSuite) subscribeForm(endpoint string) url.Values {
+	uaPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return url.Values{
+		"subscription[endpoint]":      {endpoint},
+		"subscription[keys][p256dh]":  {base64.RawURLEncoding.EncodeToString(uaPrivate.PublicKey().Bytes())},
+		"subscription[keys][auth]":    {"AAAAAAAAAAAAAAAAAAAAAA"},
+		"data[alerts][mention]":       {"true"},
+		"data[alerts][follow]":        {"true"},
+		"data[alerts][admin.sign_up]": {"true"},
+		"data[policy]":                {"followed"},
+	}
+}
+
+func (suite *PushSubscriptionTestSuite) TestSubscriptionLifecycle() {
+	// No subscription yet.
+	suite.request(http.MethodGet, nil,
+		suite.pushModule.PushSubscriptionGETHandler,
+		http.StatusNotFound, nil,
+	)
+
+	// Subscribe.
+	created := &apimodel.WebPushSubscription{}
+	suite.request(http.MethodPost,
+		suite.subscribeForm("https://push.example.org/send/some-id"),
+		suite.pushModule.PushSubscriptionPOSTHandler,
+		http.StatusOK, created,
+	)
+	suite.NotEmpty(created.ID)
+	suite.Equal("https://push.example.org/send/some-id", created.Endpoint)
+	suite.Equal(config.GetWebPushVAPIDPublicKey(), created.ServerKey)
+	suite.Equal("followed", created.Policy)
+	suite.Equal(apimodel.WebPushSubscriptionAlerts{
+		Follow:      true,
+		Mention:     true,
+		AdminSignup: true,
+	}, created.Alerts)
+
+	// Get it back.
+	got := &apimodel.WebPushSubscription{}
+	suite.request(http.MethodGet, nil,
+		suite.pushModule.PushSubscriptionGETHandler,
+		http.StatusOK, got,
+	)
+	suite.Equal(created, got)
+
+	// Update some of it, leaving the rest alone.
+	updated := &apimodel.WebPushSubscription{}
+	suite.request(http.MethodPut,
+		url.Values{
+			"data[alerts][mention]":   {"false"},
+			"data[alerts][favourite]": {"true"},
+			"data[policy]":            {"all"},
+		},
+		suite.pushModule.PushSubscriptionPUTHandler,
+		http.StatusOK, updated,
+	)
+	suite.Equal(created.ID, updated.ID)
+	suite.Equal("all", updated.Policy)
+	suite.Equal(apimodel.WebPushSubscriptionAlerts{
+		Follow:      true,
+		Favourite:   true,
+		AdminSignup: true,
+	}, updated.Alerts)
+
+	// Subscribing again replaces it.
+	replaced := &apimodel.WebPushSubscription{}
+	suite.request(http.MethodPost,
+		suite.subscribeForm("https://push.example.org/send/other-id"),
+		suite.pushModule.PushSubscriptionPOSTHandler,
+		http.StatusOK, replaced,
+	)
+	suite.NotEqual(created.ID, replaced.ID)
+	suite.Equal("https://push.example.org/send/other-id", replaced.Endpoint)
+
+	// Unsubscribe.
+	suite.request(http.MethodDelete, nil,
+		suite.pushModule.PushSubscriptionDELETEHandler,
+		http.StatusOK, nil,
+	)
+	suite.request(http.MethodGet, nil,
+		suite.pushModule.PushSubscriptionGETHandler,
+		http.StatusNotFound, nil,
+	)
+}
+
+func (suite *PushSubscriptionTestSuite) TestSubscribeInvalid() {
+	// Not https.
+	suite.request(http.MethodPost,
+		suite.subscribeForm("http://push.example.org/send/some-id"),
+		suite.pushModule.PushSubscriptionPOSTHandler,
+		http.StatusBadRequest, nil,
+	)
+
+	// Bad key.
+	form := suite.subscribeForm("https://push.example.org/send/some-id")
+	form.Set("subscription[keys][p256dh]", "AAAA")
+	suite.request(http.MethodPost, form,
+		suite.pushModule.PushSubscriptionPOSTHandler,
+		http.StatusBadRequest, nil,
+	)
+
+	// Unknown policy.
+	form = suite.subscribeForm("https://push.example.org/send/some-id")
+	form.Set("data[policy]", "everyone")
+	suite.request(http.MethodPost, form,
+		suite.pushModule.PushSubscriptionPOSTHandler,
+		http.StatusBadRequest, nil,
+	)
+
+	// Updating nothing is not found.
+	suite.request(http.MethodPut,
+		url.Values{"data[policy]": {"all"}},
+		suite.pushModule.PushSubscriptionPUTHandler,
+		http.StatusNotFound, nil,
+	)
+}
+
+func TestPushSubscriptionTestSuite(t *testing.T) {
+	suite.Run(t, new(PushSubscriptionTestSuite))
+}
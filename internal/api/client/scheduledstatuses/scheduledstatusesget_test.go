@@ -0,0 +1,158 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package scheduledstatuses_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/scheduledstatuses"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ScheduledStatusesGetTestSuite struct {
+	ScheduledStatusesStandardTestSuite
+}
+
+func (suite *ScheduledStatusesGetTestSuite) schedule(text string, at time.Time) *apimodel.ScheduledStatus {
+	scheduled, errWithCode := suite.processor.Status().ScheduledCreate(
+		context.Background(),
+		suite.testAccounts["local_account_1"],
+		suite.testApplications["application_1"],
+		&apimodel.AdvancedStatusCreateForm{
+			StatusCreateRequest: apimodel.StatusCreateRequest{
+				Status:      text,
+				Visibility:  apimodel.VisibilityPublic,
+				ScheduledAt: util.FormatISO8601(at),
+				ContentType: apimodel.StatusContentTypePlain,
+			},
+		},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	return scheduled
+}
+
+func (suite *ScheduledStatusesGetTestSuite) request(
+	method string,
+	path string,
+	id string,
+	handler gin.HandlerFunc,
+	expectedHTTPStatus int,
+	target any,
+) {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Request = httptest.NewRequest(method, "http://localhost:8080/api"+path, nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	if id != "" {
+		ctx.AddParam(apiutil.IDKey, id)
+	}
+
+	handler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	suite.Equal(expectedHTTPStatus, recorder.Code)
+	if expectedHTTPStatus != http.StatusOK || target == nil {
+		return
+	}
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := json.Unmarshal(b, target); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *ScheduledStatusesGetTestSuite) TestGetScheduledStatuses() {
+	var (
+		now    = time.Now().Truncate(time.Second)
+		first  = suite.schedule("first", now.Add(time.Hour))
+		second = suite.schedule("second", now.Add(2*time.Hour))
+	)
+
+	var list []*apimodel.ScheduledStatus
+	suite.request(http.MethodGet,
+		scheduledstatuses.BasePath, "",
+		suite.scheduledStatusesModule.ScheduledStatusesGETHandler,
+		http.StatusOK, &list,
+	)
+	if !suite.Len(list, 2) {
+		suite.FailNow("")
+	}
+	suite.ElementsMatch(
+		[]string{first.ID, second.ID},
+		[]string{list[0].ID, list[1].ID},
+	)
+
+	// Limit is respected.
+	list = nil
+	suite.request(http.MethodGet,
+		scheduledstatuses.BasePath+"?limit=1", "",
+		suite.scheduledStatusesModule.ScheduledStatusesGETHandler,
+		http.StatusOK, &list,
+	)
+	suite.Len(list, 1)
+
+	// Get just one.
+	var scheduled apimodel.ScheduledStatus
+	suite.request(http.MethodGet,
+		scheduledstatuses.BasePath+"/"+first.ID, first.ID,
+		suite.scheduledStatusesModule.ScheduledStatusGETHandler,
+		http.StatusOK, &scheduled,
+	)
+	suite.Equal("first", scheduled.Params.Text)
+	suite.Equal(util.FormatISO8601(now.Add(time.Hour)), scheduled.ScheduledAt)
+
+	// Delete it, it should be gone.
+	suite.request(http.MethodDelete,
+		scheduledstatuses.BasePath+"/"+first.ID, first.ID,
+		suite.scheduledStatusesModule.ScheduledStatusDELETEHandler,
+		http.StatusOK, nil,
+	)
+	suite.request(http.MethodGet,
+		scheduledstatuses.BasePath+"/"+first.ID, first.ID,
+		suite.scheduledStatusesModule.ScheduledStatusGETHandler,
+		http.StatusNotFound, nil,
+	)
+}
+
+func TestScheduledStatusesGetTestSuite(t *testing.T) {
+	suite.Run(t, &ScheduledStatusesGetTestSuite{})
+}
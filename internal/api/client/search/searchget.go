@@ -81,11 +81,11 @@ import (
 //		name: offset
 //		type: integer
 //		description: >-
-//			Page number of results to return (starts at 0).
-//			This parameter is currently not used, page by selecting
-//			a specific query type and using maxID and minID instead.
+//			Number of results of each type to skip, to page through results.
+//			Paging by selecting a specific query type and using max_id and
+//			min_id instead is faster, so prefer that where possible.
 //		default: 0
-//		maximum: 10
+//		maximum: 400
 //		minimum: 0
 //		in: query
 //		required: false
@@ -99,6 +99,11 @@ import (
 //			- `https://example.org/some/arbitrary/url` -- search for an account OR a status with the given URL. Will only ever return 1 result at most.
 //			- `#[hashtag_name]` -- search for a hashtag with the given hashtag name, or starting with the given hashtag name. Case insensitive. Can return multiple results.
 //			- any arbitrary string -- search for accounts or statuses containing the given string. Can return multiple results.
+//			  Statuses are searched among statuses created by, or replying to, the requesting account. If the instance has `statuses-search-index` enabled, statuses bookmarked or faved by the requesting account are searched too, and must contain all words of the given string.
+//			  The string may contain the following search operators, in which case only statuses are returned:
+//			  - `from:me`, `from:[username]`, or `from:[username]@[domain]` -- only statuses created by the given account.
+//			  - `has:media` -- only statuses with media attachments.
+//			  - `has:poll` -- only statuses with a poll.
 //		in: query
 //		required: true
 //	-
@@ -131,6 +136,12 @@ import (
 //		default: false
 //		in: query
 //	-
+//		name: account_id
+//		type: string
+//		description: >-
+//			If search type includes statuses, show only statuses created by the account with this ID.
+//		in: query
+//	-
 //		name: exclude_unreviewed
 //		type: boolean
 //		description: >-
@@ -198,7 +209,7 @@ func (m *Module) SearchGETHandler(c *gin.Context) {
 		return
 	}
 
-	offset, errWithCode := apiutil.ParseSearchOffset(c.Query(apiutil.SearchOffsetKey), 0, 10, 0)
+	offset, errWithCode := apiutil.ParseSearchOffset(c.Query(apiutil.SearchOffsetKey), 0, 400, 0)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
@@ -237,6 +248,7 @@ func (m *Module) SearchGETHandler(c *gin.Context) {
 		QueryType:         c.Query(apiutil.SearchTypeKey),
 		Resolve:           resolve,
 		Following:         following,
+		AccountID:         c.Query(apiutil.SearchAccountIDKey),
 		ExcludeUnreviewed: excludeUnreviewed,
 		APIv1:             apiVersion == apiutil.APIv1,
 	}
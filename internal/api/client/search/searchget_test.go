@@ -29,6 +29,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
@@ -47,6 +48,20 @@ type SearchGetTestSuite struct {
 	SearchStandardTestSuite
 }
 
+// unusedTagHistory returns the expected json
+// history of a tag not used in the last week.
+func unusedTagHistory() string {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	days := make([]string, 7)
+	for i := range days {
+		day := today.AddDate(0, 0, -i).Unix()
+		days[i] = `{"day":"` + strconv.FormatInt(day, 10) + `","uses":"0","accounts":"0"}`
+	}
+
+	return "[" + strings.Join(days, ",") + "]"
+}
+
 func (suite *SearchGetTestSuite) getSearch(
 	requestingAccount *gtsmodel.Account,
 	token *gtsmodel.Token,
@@ -1310,6 +1325,262 @@ func (suite *SearchGetTestSuite) TestSearchRemoteInstanceAccountPartial() {
 	suite.Len(searchResult.Hashtags, 0)
 }
 
+func (suite *SearchGetTestSuite) TestSearchAStatusesOffset() {
+	var (
+		requestingAccount          = suite.testAccounts["local_account_1"]
+		token                      = suite.testTokens["local_account_1"]
+		user                       = suite.testUsers["local_account_1"]
+		maxID              *string = nil
+		minID              *string = nil
+		limit              *int    = nil
+		offset             *int    = func() *int { i := 4; return &i }() // Skip first 4 results.
+		resolve            *bool   = nil
+		query                      = "a"
+		queryType          *string = func() *string { i := "statuses"; return &i }() // Only statuses.
+		following          *bool   = nil
+		expectedHTTPStatus         = http.StatusOK
+		expectedBody               = ""
+	)
+
+	searchResult, err := suite.getSearch(
+		requestingAccount,
+		token,
+		apiutil.APIv2,
+		user,
+		maxID,
+		minID,
+		limit,
+		offset,
+		query,
+		queryType,
+		resolve,
+		following,
+		expectedHTTPStatus,
+		expectedBody)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Len(searchResult.Accounts, 0)
+	suite.Len(searchResult.Statuses, 2)
+	suite.Len(searchResult.Hashtags, 0)
+}
+
+func (suite *SearchGetTestSuite) TestSearchAStatusesFromMe() {
+	var (
+		requestingAccount          = suite.testAccounts["local_account_1"]
+		token                      = suite.testTokens["local_account_1"]
+		user                       = suite.testUsers["local_account_1"]
+		maxID              *string = nil
+		minID              *string = nil
+		limit              *int    = nil
+		offset             *int    = nil
+		resolve            *bool   = nil
+		query                      = "a from:me"
+		queryType          *string = nil
+		following          *bool   = nil
+		expectedHTTPStatus         = http.StatusOK
+		expectedBody               = ""
+	)
+
+	searchResult, err := suite.getSearch(
+		requestingAccount,
+		token,
+		apiutil.APIv2,
+		user,
+		maxID,
+		minID,
+		limit,
+		offset,
+		query,
+		queryType,
+		resolve,
+		following,
+		expectedHTTPStatus,
+		expectedBody)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Operators mean only statuses are returned.
+	suite.Len(searchResult.Accounts, 0)
+	suite.Len(searchResult.Statuses, 5)
+	suite.Len(searchResult.Hashtags, 0)
+
+	for _, status := range searchResult.Statuses {
+		suite.Equal(requestingAccount.ID, status.Account.ID)
+	}
+}
+
+func (suite *SearchGetTestSuite) TestSearchAStatusesFromAdmin() {
+	var (
+		requestingAccount          = suite.testAccounts["local_account_1"]
+		token                      = suite.testTokens["local_account_1"]
+		user                       = suite.testUsers["local_account_1"]
+		maxID              *string = nil
+		minID              *string = nil
+		limit              *int    = nil
+		offset             *int    = nil
+		resolve            *bool   = nil
+		query                      = "a from:admin"
+		queryType          *string = func() *string { i := "statuses"; return &i }() // Only statuses.
+		following          *bool   = nil
+		expectedHTTPStatus         = http.StatusOK
+		expectedBody               = ""
+	)
+
+	searchResult, err := suite.getSearch(
+		requestingAccount,
+		token,
+		apiutil.APIv2,
+		user,
+		maxID,
+		minID,
+		limit,
+		offset,
+		query,
+		queryType,
+		resolve,
+		following,
+		expectedHTTPStatus,
+		expectedBody)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Len(searchResult.Accounts, 0)
+	if suite.Len(searchResult.Statuses, 1) {
+		suite.Equal(suite.testStatuses["admin_account_status_3"].ID, searchResult.Statuses[0].ID)
+	}
+	suite.Len(searchResult.Hashtags, 0)
+}
+
+func (suite *SearchGetTestSuite) TestSearchStatusesFromMeHasMedia() {
+	var (
+		requestingAccount          = suite.testAccounts["local_account_1"]
+		token                      = suite.testTokens["local_account_1"]
+		user                       = suite.testUsers["local_account_1"]
+		maxID              *string = nil
+		minID              *string = nil
+		limit              *int    = nil
+		offset             *int    = nil
+		resolve            *bool   = nil
+		query                      = "from:me has:media"
+		queryType          *string = func() *string { i := "statuses"; return &i }() // Only statuses.
+		following          *bool   = nil
+		expectedHTTPStatus         = http.StatusOK
+		expectedBody               = ""
+	)
+
+	searchResult, err := suite.getSearch(
+		requestingAccount,
+		token,
+		apiutil.APIv2,
+		user,
+		maxID,
+		minID,
+		limit,
+		offset,
+		query,
+		queryType,
+		resolve,
+		following,
+		expectedHTTPStatus,
+		expectedBody)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Len(searchResult.Accounts, 0)
+	suite.NotEmpty(searchResult.Statuses)
+	for _, status := range searchResult.Statuses {
+		suite.Equal(requestingAccount.ID, status.Account.ID)
+		suite.NotEmpty(status.MediaAttachments)
+	}
+	suite.Len(searchResult.Hashtags, 0)
+}
+
+func (suite *SearchGetTestSuite) TestSearchStatusesFromMeHasPoll() {
+	var (
+		requestingAccount          = suite.testAccounts["local_account_1"]
+		token                      = suite.testTokens["local_account_1"]
+		user                       = suite.testUsers["local_account_1"]
+		maxID              *string = nil
+		minID              *string = nil
+		limit              *int    = nil
+		offset             *int    = nil
+		resolve            *bool   = nil
+		query                      = "from:me has:poll"
+		queryType          *string = func() *string { i := "statuses"; return &i }() // Only statuses.
+		following          *bool   = nil
+		expectedHTTPStatus         = http.StatusOK
+		expectedBody               = ""
+	)
+
+	searchResult, err := suite.getSearch(
+		requestingAccount,
+		token,
+		apiutil.APIv2,
+		user,
+		maxID,
+		minID,
+		limit,
+		offset,
+		query,
+		queryType,
+		resolve,
+		following,
+		expectedHTTPStatus,
+		expectedBody)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Len(searchResult.Accounts, 0)
+	if suite.Len(searchResult.Statuses, 1) {
+		suite.Equal(suite.testStatuses["local_account_1_status_6"].ID, searchResult.Statuses[0].ID)
+		suite.NotNil(searchResult.Statuses[0].Poll)
+	}
+	suite.Len(searchResult.Hashtags, 0)
+}
+
+func (suite *SearchGetTestSuite) TestSearchStatusesBadOperator() {
+	var (
+		requestingAccount          = suite.testAccounts["local_account_1"]
+		token                      = suite.testTokens["local_account_1"]
+		user                       = suite.testUsers["local_account_1"]
+		maxID              *string = nil
+		minID              *string = nil
+		limit              *int    = nil
+		offset             *int    = nil
+		resolve            *bool   = nil
+		query                      = "a has:nonsense"
+		queryType          *string = func() *string { i := "statuses"; return &i }() // Only statuses.
+		following          *bool   = nil
+		expectedHTTPStatus         = http.StatusBadRequest
+		expectedBody               = `{"error":"Bad Request: search operator has:nonsense was not recognized, valid options are ['has:media', 'has:poll']"}`
+	)
+
+	_, err := suite.getSearch(
+		requestingAccount,
+		token,
+		apiutil.APIv2,
+		user,
+		maxID,
+		minID,
+		limit,
+		offset,
+		query,
+		queryType,
+		resolve,
+		following,
+		expectedHTTPStatus,
+		expectedBody)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
 func (suite *SearchGetTestSuite) TestSearchBadQueryType() {
 	var (
 		requestingAccount          = suite.testAccounts["local_account_1"]
@@ -1398,7 +1669,7 @@ func (suite *SearchGetTestSuite) TestSearchHashtagV1() {
 		queryType          *string = func() *string { i := "hashtags"; return &i }()
 		following          *bool   = nil
 		expectedHTTPStatus         = http.StatusOK
-		expectedBody               = `{"accounts":[],"statuses":[],"hashtags":[{"name":"welcome","url":"http://localhost:8080/tags/welcome","history":[]}]}`
+		expectedBody               = `{"accounts":[],"statuses":[],"hashtags":[{"name":"welcome","url":"http://localhost:8080/tags/welcome","history":` + unusedTagHistory() + `}]}`
 	)
 
 	searchResult, err := suite.getSearch(
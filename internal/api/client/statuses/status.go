@@ -70,6 +70,14 @@ const (
 
 	// SourcePath is used for fetching source of a post.
 	SourcePath = BasePathWithID + "/source"
+
+	// EmojiKey is for emoji reaction names.
+	EmojiKey = "emoji"
+	// ReactionsPath is for seeing the emoji reactions to a given status.
+	// It lives under the Pleroma API namespace, for client compatibility.
+	ReactionsPath = "/v1/pleroma/statuses/:" + IDKey + "/reactions"
+	// ReactionPath is for adding or removing an emoji reaction to/from a given status.
+	ReactionPath = ReactionsPath + "/:" + EmojiKey
 )
 
 type Module struct {
@@ -83,9 +91,10 @@ func New(processor *processing.Processor) *Module {
 }
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
-	// create / get / delete status
+	// create / get / edit / delete status
 	attachHandler(http.MethodPost, BasePath, m.StatusCreatePOSTHandler)
 	attachHandler(http.MethodGet, BasePathWithID, m.StatusGETHandler)
+	attachHandler(http.MethodPut, BasePathWithID, m.StatusEditPUTHandler)
 	attachHandler(http.MethodDelete, BasePathWithID, m.StatusDELETEHandler)
 
 	// fave stuff
@@ -111,6 +120,11 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	// context / status thread
 	attachHandler(http.MethodGet, ContextPath, m.StatusContextGETHandler)
 
+	// emoji reaction stuff
+	attachHandler(http.MethodGet, ReactionsPath, m.StatusReactionsGETHandler)
+	attachHandler(http.MethodPut, ReactionPath, m.StatusReactionPUTHandler)
+	attachHandler(http.MethodDelete, ReactionPath, m.StatusReactionDELETEHandler)
+
 	// history/edit stuff
 	attachHandler(http.MethodGet, HistoryPath, m.StatusHistoryGETHandler)
 	attachHandler(http.MethodGet, SourcePath, m.StatusSourceGETHandler)
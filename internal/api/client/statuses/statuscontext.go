@@ -19,10 +19,14 @@ package statuses
 
 import (
 	"errors"
+	"math"
 	"net/http"
+	"net/url"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
@@ -33,6 +37,12 @@ import (
 //
 // The returned statuses will be ordered in a thread structure, so they are suitable to be displayed in the order in which they were returned.
 //
+// Statuses hidden by the requester's filters in the `thread` context are left out of the results.
+//
+// For very large threads, descendants can be paged through using the `limit` and `offset` parameters.
+// When a limit is set and more descendants may be available, a `Link` header with `rel="next"`
+// will point to the next page. Ancestors are always returned in full.
+//
 //	---
 //	tags:
 //	- statuses
@@ -47,6 +57,25 @@ import (
 //		description: Target status ID.
 //		in: path
 //		required: true
+//	-
+//		name: limit
+//		type: integer
+//		description: >-
+//			Return at most this many descendants.
+//			0 or unset means return all descendants.
+//		default: 0
+//		minimum: 0
+//		maximum: 200
+//		in: query
+//	-
+//		name: offset
+//		type: integer
+//		description: >-
+//			Skip this many descendants (in thread order) before returning results.
+//			Only used when limit is set.
+//		default: 0
+//		minimum: 0
+//		in: query
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -89,11 +118,52 @@ func (m *Module) StatusContextGETHandler(c *gin.Context) {
 		return
 	}
 
-	statusContext, errWithCode := m.processor.Status().ContextGet(c.Request.Context(), authed.Account, targetStatusID)
+	limit, errWithCode := apiutil.ParseLimit(c.Query(apiutil.LimitKey), 0, 200, 0)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
 
+	offset, errWithCode := apiutil.ParseOffset(c.Query(apiutil.OffsetKey), 0, math.MaxInt32, 0)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	statusContext, errWithCode := m.processor.Status().ContextGet(
+		c.Request.Context(),
+		authed.Account,
+		targetStatusID,
+		limit,
+		offset,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if limit > 0 && len(statusContext.Descendants) == limit {
+		// A full page was returned, so there
+		// may be more descendants to fetch.
+		c.Header("Link", contextNextLink(c.Request.URL, limit, offset+limit))
+	}
+
 	c.JSON(http.StatusOK, statusContext)
 }
+
+// contextNextLink returns a Link header value pointing
+// to the next page of descendants for a status context.
+func contextNextLink(current *url.URL, limit int, offset int) string {
+	query := current.Query()
+	query.Set(apiutil.LimitKey, strconv.Itoa(limit))
+	query.Set(apiutil.OffsetKey, strconv.Itoa(offset))
+
+	next := &url.URL{
+		Scheme:   config.GetProtocol(),
+		Host:     config.GetHost(),
+		Path:     current.Path,
+		RawQuery: query.Encode(),
+	}
+
+	return `<` + next.String() + `>; rel="next"`
+}
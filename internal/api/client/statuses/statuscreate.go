@@ -111,6 +111,14 @@ import (
 //		type: string
 //		in: formData
 //	-
+//		name: quote_id
+//		x-go-name: QuoteID
+//		description: >-
+//			ID of the status being quoted, if status is a quote post.
+//			The quoted status must be a boostable public or unlisted status.
+//		type: string
+//		in: formData
+//	-
 //		name: sensitive
 //		x-go-name: Sensitive
 //		description: Status and attached media should be marked as sensitive.
@@ -143,8 +151,6 @@ import (
 //			ISO 8601 Datetime at which to schedule a status.
 //			Providing this parameter will cause ScheduledStatus to be returned instead of Status.
 //			Must be at least 5 minutes in the future.
-//
-//			This feature isn't implemented yet.
 //		type: string
 //		in: formData
 //	-
@@ -186,6 +192,39 @@ import (
 //		description: This status can be liked/faved.
 //		in: formData
 //		type: boolean
+//	-
+//		name: interaction_policy[can_reply][]
+//		x-go-name: InteractionPolicyCanReply
+//		description: |-
+//			Audiences permitted to reply to this status.
+//			Any of `public`, `followers`, `following`, `mentioned`, or `author`.
+//			Defaults to `public` if not set. Ignored for direct statuses.
+//		type: array
+//		items:
+//			type: string
+//		in: formData
+//	-
+//		name: interaction_policy[can_announce][]
+//		x-go-name: InteractionPolicyCanAnnounce
+//		description: |-
+//			Audiences permitted to boost this status.
+//			Any of `public`, `followers`, `following`, `mentioned`, or `author`.
+//			Defaults to `public` if not set. Ignored for direct statuses.
+//		type: array
+//		items:
+//			type: string
+//		in: formData
+//	-
+//		name: interaction_policy[can_like][]
+//		x-go-name: InteractionPolicyCanLike
+//		description: |-
+//			Audiences permitted to like/fave this status.
+//			Any of `public`, `followers`, `following`, `mentioned`, or `author`.
+//			Defaults to `public` if not set. Ignored for direct statuses.
+//		type: array
+//		items:
+//			type: string
+//		in: formData
 //
 //	produces:
 //	- application/json
@@ -196,7 +235,9 @@ import (
 //
 //	responses:
 //		'200':
-//			description: "The newly created status."
+//			description: |-
+//				The newly created status.
+//				If scheduled_at was provided, the newly scheduled status (see scheduledStatus model) is returned instead.
 //			schema:
 //				"$ref": "#/definitions/status"
 //		'400':
@@ -209,6 +250,8 @@ import (
 //			description: not found
 //		'406':
 //			description: not acceptable
+//		'422':
+//			description: unprocessable entity; scheduled_at is too soon, or too many statuses scheduled
 //		'500':
 //			description: internal server error
 func (m *Module) StatusCreatePOSTHandler(c *gin.Context) {
@@ -248,8 +291,26 @@ func (m *Module) StatusCreatePOSTHandler(c *gin.Context) {
 		return
 	}
 
+	if form.ScheduledAt != "" {
+		// Status should be published later,
+		// so schedule it instead of creating.
+		apiScheduled, errWithCode := m.processor.Status().ScheduledCreate(
+			apiutil.IdempotencyContext(c),
+			authed.Account,
+			authed.Application,
+			form,
+		)
+		if errWithCode != nil {
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		c.JSON(http.StatusOK, apiScheduled)
+		return
+	}
+
 	apiStatus, errWithCode := m.processor.Status().Create(
-		c.Request.Context(),
+		apiutil.IdempotencyContext(c),
 		authed.Account,
 		authed.Application,
 		form,
@@ -304,6 +365,41 @@ func validateNormalizeCreateStatus(form *apimodel.AdvancedStatusCreateForm) erro
 		form.Language = language
 	}
 
+	if form.InteractionPolicy != nil {
+		if err := validateCreateInteractionPolicy(form.InteractionPolicy); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateCreateInteractionPolicy(policy *apimodel.InteractionPolicyRequest) error {
+	if err := validatePolicyValues("can_reply", policy.CanReply); err != nil {
+		return err
+	}
+
+	if err := validatePolicyValues("can_announce", policy.CanAnnounce); err != nil {
+		return err
+	}
+
+	return validatePolicyValues("can_like", policy.CanLike)
+}
+
+func validatePolicyValues(name string, values []apimodel.PolicyValue) error {
+	for _, value := range values {
+		switch value {
+		case apimodel.PolicyValuePublic,
+			apimodel.PolicyValueFollowers,
+			apimodel.PolicyValueFollowing,
+			apimodel.PolicyValueMentioned,
+			apimodel.PolicyValueAuthor:
+			// Valid.
+		default:
+			return fmt.Errorf("interaction_policy %s value %q not recognized", name, value)
+		}
+	}
+
 	return nil
 }
 
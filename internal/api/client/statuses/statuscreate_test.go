@@ -28,6 +28,7 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/statuses"
@@ -35,6 +36,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -228,6 +230,48 @@ func (suite *StatusCreateTestSuite) TestPostAnotherNewStatus() {
 	suite.Equal("<p><a href=\"http://localhost:8080/tags/test\" class=\"mention hashtag\" rel=\"tag nofollow noreferrer noopener\" target=\"_blank\">#<span>test</span></a> alright, should be able to post <a href=\"http://localhost:8080/tags/links\" class=\"mention hashtag\" rel=\"tag nofollow noreferrer noopener\" target=\"_blank\">#<span>links</span></a> with fragments in them now, let's see........<br><br><a href=\"https://docs.gotosocial.org/en/latest/user_guide/posts/#links\" rel=\"nofollow noreferrer noopener\" target=\"_blank\">https://docs.gotosocial.org/en/latest/user_guide/posts/#links</a><br><br><a href=\"http://localhost:8080/tags/gotosocial\" class=\"mention hashtag\" rel=\"tag nofollow noreferrer noopener\" target=\"_blank\">#<span>gotosocial</span></a><br><br>(tobi remember to pull the docker image challenge)</p>", statusReply.Content)
 }
 
+func (suite *StatusCreateTestSuite) TestPostNewScheduledStatus() {
+	t := suite.testTokens["local_account_1"]
+	oauthToken := oauth.DBTokenToToken(t)
+	scheduledAt := util.FormatISO8601(time.Now().Add(time.Hour))
+
+	// setup
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:8080/%s", statuses.BasePath), nil) // the endpoint we're hitting
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Request.Form = url.Values{
+		"status":       {"see you in an hour!"},
+		"scheduled_at": {scheduledAt},
+	}
+	suite.statusModule.StatusCreatePOSTHandler(ctx)
+
+	// check response
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := io.ReadAll(result.Body)
+	suite.NoError(err)
+
+	// We should get a scheduled status back, not a status.
+	scheduledReply := &apimodel.ScheduledStatus{}
+	err = json.Unmarshal(b, scheduledReply)
+	suite.NoError(err)
+
+	suite.NotEmpty(scheduledReply.ID)
+	suite.Equal(scheduledAt, scheduledReply.ScheduledAt)
+	suite.Equal("see you in an hour!", scheduledReply.Params.Text)
+	suite.Equal(suite.testApplications["application_1"].ID, scheduledReply.Params.ApplicationID)
+
+	_, err = suite.db.GetScheduledStatusByID(context.Background(), scheduledReply.ID)
+	suite.NoError(err)
+}
+
 func (suite *StatusCreateTestSuite) TestPostNewStatusWithEmoji() {
 	t := suite.testTokens["local_account_1"]
 	oauthToken := oauth.DBTokenToToken(t)
@@ -503,6 +547,81 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusWithPollJSON() {
 	})
 }
 
+func (suite *StatusCreateTestSuite) TestPostNewStatusWithInteractionPolicy() {
+	t := suite.testTokens["local_account_1"]
+	oauthToken := oauth.DBTokenToToken(t)
+
+	// setup
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:8080/%s", statuses.BasePath), nil) // the endpoint we're hitting
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Request.Form = url.Values{
+		"status":                             {"only my followers may reply to this"},
+		"interaction_policy[can_reply][]":    {"followers", "mentioned"},
+		"interaction_policy[can_announce][]": {"author"},
+	}
+	suite.statusModule.StatusCreatePOSTHandler(ctx)
+
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	statusReply := &apimodel.Status{}
+	err = json.Unmarshal(b, statusReply)
+	suite.NoError(err)
+
+	if suite.NotNil(statusReply.InteractionPolicy) {
+		suite.Equal([]apimodel.PolicyValue{apimodel.PolicyValueFollowers, apimodel.PolicyValueMentioned}, statusReply.InteractionPolicy.CanReply)
+		suite.Equal([]apimodel.PolicyValue{apimodel.PolicyValueAuthor}, statusReply.InteractionPolicy.CanAnnounce)
+		suite.Equal([]apimodel.PolicyValue{apimodel.PolicyValuePublic}, statusReply.InteractionPolicy.CanLike)
+	}
+
+	// The policy should be stored on the status.
+	dbStatus, err := suite.db.GetStatusByID(context.Background(), statusReply.ID)
+	suite.NoError(err)
+	if suite.NotNil(dbStatus.InteractionPolicy) {
+		suite.Equal(gtsmodel.PolicyValues{gtsmodel.PolicyValueFollowers, gtsmodel.PolicyValueMentioned}, dbStatus.InteractionPolicy.CanReply)
+		suite.Equal(gtsmodel.PolicyValues{gtsmodel.PolicyValueAuthor}, dbStatus.InteractionPolicy.CanAnnounce)
+		suite.Empty(dbStatus.InteractionPolicy.CanLike)
+	}
+}
+
+func (suite *StatusCreateTestSuite) TestPostNewStatusWithInvalidInteractionPolicy() {
+	t := suite.testTokens["local_account_1"]
+	oauthToken := oauth.DBTokenToToken(t)
+
+	// setup
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:8080/%s", statuses.BasePath), nil) // the endpoint we're hitting
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Request.Form = url.Values{
+		"status":                         {"who may like this?"},
+		"interaction_policy[can_like][]": {"friends"},
+	}
+	suite.statusModule.StatusCreatePOSTHandler(ctx)
+
+	suite.EqualValues(http.StatusBadRequest, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+	suite.Equal(`{"error":"Bad Request: interaction_policy can_like value \"friends\" not recognized"}`, string(b))
+}
+
 func TestStatusCreateTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusCreateTestSuite))
 }
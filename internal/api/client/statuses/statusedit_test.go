@@ -0,0 +1,139 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package statuses_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/statuses"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type StatusEditTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusEditTestSuite) editStatus(
+	accountKey string,
+	targetStatusID string,
+	form url.Values,
+) (*apimodel.Status, int) {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(
+		http.MethodPut,
+		fmt.Sprintf("http://localhost:8080%s", strings.ReplaceAll(statuses.BasePathWithID, ":id", targetStatusID)),
+		strings.NewReader(form.Encode()),
+	)
+	request.Header.Set("accept", "application/json")
+	request.Header.Set("content-type", "application/x-www-form-urlencoded")
+	ctx, _ := testrig.CreateGinTestContext(recorder, request)
+
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens[accountKey]))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[accountKey])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[accountKey])
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   statuses.IDKey,
+			Value: targetStatusID,
+		},
+	}
+
+	suite.statusModule.StatusEditPUTHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	if recorder.Code != http.StatusOK {
+		return nil, recorder.Code
+	}
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	apiStatus := &apimodel.Status{}
+	if err := json.Unmarshal(b, apiStatus); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return apiStatus, recorder.Code
+}
+
+func (suite *StatusEditTestSuite) TestEditStatus() {
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	apiStatus, code := suite.editStatus("local_account_1", targetStatus.ID, url.Values{
+		"status":       {"hello everyone, edited!"},
+		"spoiler_text": {"introduction post (edited)"},
+		"sensitive":    {"true"},
+	})
+	suite.Equal(http.StatusOK, code)
+	suite.Equal("<p>hello everyone, edited!</p>", apiStatus.Content)
+	suite.Equal("introduction post (edited)", apiStatus.SpoilerText)
+	suite.NotNil(apiStatus.EditedAt)
+
+	// The previous revision should
+	// have been stored as an edit.
+	edits, err := suite.db.GetStatusEditsByStatusID(context.Background(), targetStatus.ID)
+	suite.NoError(err)
+	suite.Len(edits, 1)
+	suite.Equal(targetStatus.Content, edits[0].Content)
+	suite.Equal(targetStatus.ContentWarning, edits[0].ContentWarning)
+
+	// The source should now be the edited text.
+	dbStatus, err := suite.db.GetStatusByID(context.Background(), targetStatus.ID)
+	suite.NoError(err)
+	suite.Equal("hello everyone, edited!", dbStatus.Text)
+	suite.False(dbStatus.EditedAt.IsZero())
+}
+
+func (suite *StatusEditTestSuite) TestEditStatusNoContent() {
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	_, code := suite.editStatus("local_account_1", targetStatus.ID, url.Values{
+		"spoiler_text": {"nothing to see here"},
+	})
+	suite.Equal(http.StatusBadRequest, code)
+}
+
+func (suite *StatusEditTestSuite) TestEditStatusNotOwn() {
+	targetStatus := suite.testStatuses["local_account_2_status_1"]
+
+	_, code := suite.editStatus("local_account_1", targetStatus.ID, url.Values{
+		"status": {"this isn't mine to edit"},
+	})
+	suite.Equal(http.StatusNotFound, code)
+}
+
+func TestStatusEditTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusEditTestSuite))
+}
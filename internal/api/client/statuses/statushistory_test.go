@@ -19,6 +19,7 @@ package statuses_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -26,11 +27,15 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/statuses"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -128,6 +133,87 @@ func (suite *StatusHistoryTestSuite) TestGetHistory() {
 ]`, dst.String())
 }
 
+func (suite *StatusHistoryTestSuite) TestGetHistoryEdited() {
+	var (
+		testApplication = suite.testApplications["application_1"]
+		testAccount     = suite.testAccounts["local_account_1"]
+		testUser        = suite.testUsers["local_account_1"]
+		testToken       = oauth.DBTokenToToken(suite.testTokens["local_account_1"])
+		targetStatus    = suite.testStatuses["local_account_1_status_1"]
+		target          = fmt.Sprintf("http://localhost:8080%s", strings.ReplaceAll(statuses.HistoryPath, ":id", targetStatus.ID))
+		editedAt        = targetStatus.CreatedAt.Add(time.Hour)
+	)
+
+	// Store a previous revision of the
+	// status, and mark the status edited.
+	if err := suite.db.PutStatusEdit(context.Background(), &gtsmodel.StatusEdit{
+		ID:             "01HZHFVKG5A0V2MPXS7WJ6MZ3F",
+		CreatedAt:      editedAt,
+		StatusID:       targetStatus.ID,
+		Content:        "hello world!",
+		ContentWarning: "first post",
+		Text:           "hello world!",
+		Sensitive:      util.Ptr(false),
+		PollOptions:    []string{"yes", "no"},
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	edited := new(gtsmodel.Status)
+	*edited = *targetStatus
+	edited.EditedAt = editedAt
+	if err := suite.db.UpdateStatus(context.Background(), edited, "edited_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Setup request.
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, target, nil)
+	request.Header.Set("accept", "application/json")
+	ctx, _ := testrig.CreateGinTestContext(recorder, request)
+
+	// Set auth + path params.
+	ctx.Set(oauth.SessionAuthorizedApplication, testApplication)
+	ctx.Set(oauth.SessionAuthorizedToken, testToken)
+	ctx.Set(oauth.SessionAuthorizedUser, testUser)
+	ctx.Set(oauth.SessionAuthorizedAccount, testAccount)
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   statuses.IDKey,
+			Value: targetStatus.ID,
+		},
+	}
+
+	// Call the handler.
+	suite.statusModule.StatusHistoryGETHandler(ctx)
+
+	// Check code.
+	if code := recorder.Code; code != http.StatusOK {
+		suite.FailNow("", "unexpected http code: %d", code)
+	}
+
+	history := []*apimodel.StatusEdit{}
+	if err := json.NewDecoder(recorder.Body).Decode(&history); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Previous revision should come first,
+	// created when the status was created.
+	suite.Len(history, 2)
+	suite.Equal("hello world!", history[0].Content)
+	suite.Equal("first post", history[0].SpoilerText)
+	suite.Equal("2021-10-20T10:40:37.000Z", history[0].CreatedAt)
+	suite.NotNil(history[0].Poll)
+	suite.Len(history[0].Poll.Options, 2)
+	suite.Equal("yes", history[0].Poll.Options[0].Title)
+
+	// Latest revision should come last,
+	// created when the status was edited.
+	suite.Equal("hello everyone!", history[1].Content)
+	suite.Equal("2021-10-20T11:40:37.000Z", history[1].CreatedAt)
+	suite.Nil(history[1].Poll)
+}
+
 func TestStatusHistoryTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusHistoryTestSuite))
 }
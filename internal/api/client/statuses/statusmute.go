@@ -28,7 +28,7 @@ import (
 
 // StatusMutePOSTHandler swagger:operation POST /api/v1/statuses/{id}/mute statusMute
 //
-// Mute a status's thread. This prevents notifications from being created for future replies, likes, boosts etc in the thread of which the target status is a part.
+// Mute a status's thread. This prevents notifications from being created for future replies, likes, boosts, poll results etc in the thread of which the target status is a part.
 //
 // Target status must belong to you or mention you.
 //
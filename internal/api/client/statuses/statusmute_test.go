@@ -19,6 +19,7 @@ package statuses_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -30,6 +31,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/statuses"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/testrig"
@@ -91,6 +93,7 @@ func (suite *StatusMuteTestSuite) TestMuteUnmuteStatus() {
 	suite.Equal(`{
   "id": "01F8MHAMCHF6Y650WCRSCP4WMY",
   "created_at": "2021-10-20T10:40:37.000Z",
+  "edited_at": null,
   "in_reply_to_id": null,
   "in_reply_to_account_id": null,
   "sensitive": true,
@@ -106,9 +109,22 @@ func (suite *StatusMuteTestSuite) TestMuteUnmuteStatus() {
   "reblogged": false,
   "muted": true,
   "bookmarked": false,
+  "content_revealed": false,
   "pinned": false,
   "content": "hello everyone!",
   "reblog": null,
+  "quote": null,
+  "interaction_policy": {
+    "can_reply": [
+      "public"
+    ],
+    "can_announce": [
+      "public"
+    ],
+    "can_like": [
+      "public"
+    ]
+  },
   "application": {
     "name": "really cool gts application",
     "website": "https://reallycool.app"
@@ -154,6 +170,7 @@ func (suite *StatusMuteTestSuite) TestMuteUnmuteStatus() {
 	suite.Equal(`{
   "id": "01F8MHAMCHF6Y650WCRSCP4WMY",
   "created_at": "2021-10-20T10:40:37.000Z",
+  "edited_at": null,
   "in_reply_to_id": null,
   "in_reply_to_account_id": null,
   "sensitive": true,
@@ -169,9 +186,22 @@ func (suite *StatusMuteTestSuite) TestMuteUnmuteStatus() {
   "reblogged": false,
   "muted": false,
   "bookmarked": false,
+  "content_revealed": false,
   "pinned": false,
   "content": "hello everyone!",
   "reblog": null,
+  "quote": null,
+  "interaction_policy": {
+    "can_reply": [
+      "public"
+    ],
+    "can_announce": [
+      "public"
+    ],
+    "can_like": [
+      "public"
+    ]
+  },
   "application": {
     "name": "really cool gts application",
     "website": "https://reallycool.app"
@@ -212,6 +242,42 @@ func (suite *StatusMuteTestSuite) TestMuteUnmuteStatus() {
 }`, unmuted)
 }
 
+func (suite *StatusMuteTestSuite) TestMuteUnthreadedStatus() {
+	var (
+		ctx          = context.Background()
+		targetStatus = suite.testStatuses["local_account_1_status_6"]
+		path         = fmt.Sprintf("http://localhost:8080/api%s", strings.ReplaceAll(statuses.MutePath, ":id", targetStatus.ID))
+	)
+
+	// Sanity check: status
+	// shouldn't be threaded yet.
+	suite.Empty(targetStatus.ThreadID)
+
+	// Mute the status, ensure `muted` is `true`.
+	code, muted := suite.post(path, suite.statusModule.StatusMutePOSTHandler, targetStatus.ID)
+	suite.Equal(http.StatusOK, code)
+
+	apiStatus := new(apimodel.Status)
+	if err := json.Unmarshal([]byte(muted), apiStatus); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(apiStatus.Muted)
+
+	// Status should now have a thread,
+	// which is muted by the requester.
+	dbStatus, err := suite.db.GetStatusByID(ctx, targetStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotEmpty(dbStatus.ThreadID)
+
+	threadMuted, err := suite.db.IsThreadMutedByAccount(ctx, dbStatus.ThreadID, targetStatus.AccountID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(threadMuted)
+}
+
 func TestStatusMuteTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusMuteTestSuite))
 }
@@ -0,0 +1,127 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package statuses_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/statuses"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type StatusReactionTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusReactionTestSuite) reactionRequest(
+	method string,
+	handler gin.HandlerFunc,
+	statusID string,
+	emoji string,
+) (int, []byte) {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+
+	path := strings.Replace(statuses.ReactionsPath, ":id", statusID, 1)
+	params := gin.Params{{Key: statuses.IDKey, Value: statusID}}
+	if emoji != "" {
+		path += "/" + url.PathEscape(emoji)
+		params = append(params, gin.Param{Key: statuses.EmojiKey, Value: emoji})
+	}
+
+	ctx.Request = httptest.NewRequest(method, "http://localhost:8080/api"+path, nil)
+	ctx.Request.Header.Set("accept", "application/json")
+
+	// normally the router would populate these params from the path values,
+	// but because we're calling the function directly, we need to set them manually.
+	ctx.Params = params
+
+	handler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return recorder.Code, b
+}
+
+func (suite *StatusReactionTestSuite) TestPutGetDeleteReaction() {
+	targetStatus := suite.testStatuses["admin_account_status_2"]
+
+	// React.
+	code, b := suite.reactionRequest(http.MethodPut, suite.statusModule.StatusReactionPUTHandler, targetStatus.ID, "🦥")
+	suite.Equal(http.StatusOK, code, string(b))
+
+	apiStatus := &apimodel.Status{}
+	if err := json.Unmarshal(b, apiStatus); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal([]apimodel.EmojiReaction{{Name: "🦥", Count: 1, Me: true}}, apiStatus.EmojiReactions)
+
+	// Get reactions, with accounts.
+	code, b = suite.reactionRequest(http.MethodGet, suite.statusModule.StatusReactionsGETHandler, targetStatus.ID, "")
+	suite.Equal(http.StatusOK, code, string(b))
+
+	apiReactions := []apimodel.EmojiReaction{}
+	if err := json.Unmarshal(b, &apiReactions); err != nil {
+		suite.FailNow(err.Error())
+	}
+	if suite.Len(apiReactions, 1) && suite.Len(apiReactions[0].Accounts, 1) {
+		suite.Equal(suite.testAccounts["local_account_1"].ID, apiReactions[0].Accounts[0].ID)
+	}
+
+	// Unreact.
+	code, b = suite.reactionRequest(http.MethodDelete, suite.statusModule.StatusReactionDELETEHandler, targetStatus.ID, "🦥")
+	suite.Equal(http.StatusOK, code, string(b))
+
+	apiStatus = &apimodel.Status{}
+	if err := json.Unmarshal(b, apiStatus); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(apiStatus.EmojiReactions)
+}
+
+func (suite *StatusReactionTestSuite) TestPutReactionUnlikeable() {
+	targetStatus := suite.testStatuses["local_account_2_status_3"] // this one is unlikeable and unreplyable
+
+	code, b := suite.reactionRequest(http.MethodPut, suite.statusModule.StatusReactionPUTHandler, targetStatus.ID, "🦥")
+	suite.Equal(http.StatusForbidden, code)
+	suite.Equal(`{"error":"Forbidden: status is not reactable"}`, string(b))
+}
+
+func TestStatusReactionTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusReactionTestSuite))
+}
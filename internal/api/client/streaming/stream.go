@@ -19,6 +19,8 @@ package streaming
 
 import (
 	"context"
+	"errors"
+	"net"
 	"slices"
 	"time"
 
@@ -45,6 +47,7 @@ import (
 // As long as the connection is open, various message types will be streamed into it.
 //
 // GoToSocial will ping the connection every 30 seconds to check whether the client is still receiving.
+// Connections that don't answer pings (or send messages) for longer than the configured idle timeout are closed.
 //
 // If the ping fails, or something else goes wrong during transmission, then the connection will be dropped, and the client will be expected to start it again.
 //
@@ -99,7 +102,7 @@ import (
 //
 //	security:
 //	- OAuth2 Bearer:
-//		- read:streaming
+//		- read:statuses
 //
 //	responses:
 //		'101':
@@ -148,6 +151,10 @@ import (
 //			description: unauthorized
 //		'400':
 //			description: bad request
+//		'429':
+//			description: too many streaming connections open for the requesting account
+//		'503':
+//			description: too many streaming connections open on this instance
 func (m *Module) StreamGETHandler(c *gin.Context) {
 	var (
 		account     *gtsmodel.Account
@@ -255,6 +262,17 @@ func (m *Module) handleWSConn(l *log.Entry, wsConn *websocket.Conn, stream *stre
 	// Create new async context with cancel.
 	ctx, cncl := context.WithCancel(context.Background())
 
+	if m.idleTimeout > 0 {
+		// Close the connection if the client doesn't
+		// answer pings or send anything for too long;
+		// any pong extends the read deadline again.
+		m.extendReadDeadline(wsConn, l)
+		wsConn.SetPongHandler(func(string) error {
+			m.extendReadDeadline(wsConn, l)
+			return nil
+		})
+	}
+
 	go func() {
 		defer cncl()
 
@@ -307,13 +325,20 @@ func (m *Module) readFromWSConn(
 
 		// Read JSON objects from the client and act on them.
 		if err := wsConn.ReadJSON(&msg); err != nil {
+			var netErr net.Error
+			switch {
+			case errors.As(err, &netErr) && netErr.Timeout():
+				// Read deadline was reached,
+				// the client has gone quiet.
+				l.Info("closing idle websocket connection")
+
 			// Only log an error if something weird happened.
 			// See: https://www.rfc-editor.org/rfc/rfc6455.html#section-11.7
-			if !websocket.IsCloseError(err, []int{
+			case !websocket.IsCloseError(err, []int{
 				websocket.CloseNormalClosure,
 				websocket.CloseGoingAway,
 				websocket.CloseNoStatusReceived,
-			}...) {
+			}...):
 				l.Errorf("error during websocket read: %v", err)
 			}
 
@@ -326,6 +351,12 @@ func (m *Module) readFromWSConn(
 		// and usually interesting, so log this at info.
 		l.Infof("received websocket message: %+v", msg)
 
+		if m.idleTimeout > 0 {
+			// Client is evidently
+			// still there, extend.
+			m.extendReadDeadline(wsConn, l)
+		}
+
 		// Ignore if the updateStreamType is unknown (or missing),
 		// so a bad client can't cause extra memory allocations
 		if !slices.Contains(streampkg.AllStatusTimelines, msg.Stream) {
@@ -406,3 +437,11 @@ func (m *Module) writeToWSConn(
 
 	l.Debug("finished websocket write")
 }
+
+// extendReadDeadline pushes the read deadline of the given
+// websocket connection to the configured idle timeout from now.
+func (m *Module) extendReadDeadline(wsConn *websocket.Conn, l *log.Entry) {
+	if err := wsConn.SetReadDeadline(time.Now().Add(m.idleTimeout)); err != nil {
+		l.Debugf("error setting websocket read deadline: %v", err)
+	}
+}
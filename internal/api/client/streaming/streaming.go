@@ -23,6 +23,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 )
 
@@ -36,9 +37,10 @@ const (
 )
 
 type Module struct {
-	processor *processing.Processor
-	dTicker   time.Duration
-	wsUpgrade websocket.Upgrader
+	processor   *processing.Processor
+	dTicker     time.Duration
+	idleTimeout time.Duration
+	wsUpgrade   websocket.Upgrader
 }
 
 func New(processor *processing.Processor, dTicker time.Duration, wsBuf int) *Module {
@@ -48,8 +50,9 @@ func New(processor *processing.Processor, dTicker time.Duration, wsBuf int) *Mod
 	checkOrigin := func(r *http.Request) bool { return true }
 
 	return &Module{
-		processor: processor,
-		dTicker:   dTicker,
+		processor:   processor,
+		dTicker:     dTicker,
+		idleTimeout: config.GetAdvancedStreamingIdleTimeout(),
 		wsUpgrade: websocket.Upgrader{
 			ReadBufferSize:  wsBuf,
 			WriteBufferSize: wsBuf,
@@ -55,6 +55,36 @@ import (
 //		in: path
 //		required: true
 //	-
+//		name: any[]
+//		type: array
+//		items:
+//			type: string
+//		description: >-
+//			Also return statuses that use any of these additional tags. Maximum 4.
+//		in: query
+//		collectionFormat: multi
+//		required: false
+//	-
+//		name: all[]
+//		type: array
+//		items:
+//			type: string
+//		description: >-
+//			Return only statuses that also use all of these tags. Maximum 4.
+//		in: query
+//		collectionFormat: multi
+//		required: false
+//	-
+//		name: none[]
+//		type: array
+//		items:
+//			type: string
+//		description: >-
+//			Return only statuses that use none of these tags. Maximum 4.
+//		in: query
+//		collectionFormat: multi
+//		required: false
+//	-
 //		name: max_id
 //		type: string
 //		description: >-
@@ -86,6 +116,13 @@ import (
 //		maximum: 40
 //		in: query
 //		required: false
+//	-
+//		name: local
+//		type: boolean
+//		description: Show only statuses posted by local accounts.
+//		default: false
+//		in: query
+//		required: false
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -138,14 +175,24 @@ func (m *Module) TagTimelineGETHandler(c *gin.Context) {
 		return
 	}
 
+	local, errWithCode := apiutil.ParseLocal(c.Query(apiutil.LocalKey), false)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	resp, errWithCode := m.processor.Timeline().TagTimelineGet(
 		c.Request.Context(),
 		authed.Account,
 		tagName,
+		c.QueryArray(apiutil.TagAnyKey),
+		c.QueryArray(apiutil.TagAllKey),
+		c.QueryArray(apiutil.TagNoneKey),
 		c.Query(apiutil.MaxIDKey),
 		c.Query(apiutil.SinceIDKey),
 		c.Query(apiutil.MinIDKey),
 		limit,
+		local,
 	)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
@@ -0,0 +1,135 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/trends"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type TagsGetTestSuite struct {
+	TrendsStandardTestSuite
+}
+
+func (suite *TagsGetTestSuite) getTrendingTags(query string, expectedHTTPStatus int) []*apimodel.Tag {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Request = httptest.NewRequest(http.MethodGet, "http://localhost:8080/api"+trends.TagsPath+query, nil)
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.trendsModule.TrendsTagsGETHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	suite.Equal(expectedHTTPStatus, recorder.Code)
+	if expectedHTTPStatus != http.StatusOK {
+		return nil
+	}
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	var tags []*apimodel.Tag
+	if err := json.Unmarshal(b, &tags); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return tags
+}
+
+func (suite *TagsGetTestSuite) TestGetTrendingTags() {
+	var (
+		ctx     = context.Background()
+		welcome = suite.testTags["welcome"]
+		hashtag = suite.testTags["Hashtag"]
+	)
+
+	// Nothing has been used yet.
+	suite.Empty(suite.getTrendingTags("", http.StatusOK))
+
+	// Use #welcome from two accounts, and #hashtag from one.
+	for _, use := range []struct {
+		accountID string
+		tagIDs    []string
+	}{
+		{suite.testAccounts["local_account_1"].ID, []string{welcome.ID, hashtag.ID}},
+		{suite.testAccounts["admin_account"].ID, []string{welcome.ID}},
+	} {
+		status := &gtsmodel.Status{
+			ID:        id.NewULID(),
+			CreatedAt: time.Now(),
+			AccountID: use.accountID,
+		}
+
+		for _, tagID := range use.tagIDs {
+			if err := suite.db.IncrementTagHistory(ctx, tagID, status); err != nil {
+				suite.FailNow(err.Error())
+			}
+		}
+	}
+
+	tags := suite.getTrendingTags("", http.StatusOK)
+	if !suite.Len(tags, 2) {
+		suite.FailNow("")
+	}
+
+	suite.Equal("welcome", tags[0].Name)
+	suite.Equal("http://localhost:8080/tags/welcome", tags[0].URL)
+	suite.Equal("hashtag", tags[1].Name)
+
+	// History covers the last week, today first.
+	history := *tags[0].History
+	suite.Len(history, 7)
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	suite.Equal(strconv.FormatInt(today.Unix(), 10), history[0].Day)
+	suite.Equal("2", history[0].Uses)
+	suite.Equal("2", history[0].Accounts)
+	suite.Equal("0", history[1].Uses)
+
+	// Limit is respected.
+	tags = suite.getTrendingTags("?limit=1", http.StatusOK)
+	suite.Len(tags, 1)
+
+	// Limit isn't a number.
+	suite.getTrendingTags("?limit=lots", http.StatusBadRequest)
+}
+
+func TestTagsGetTestSuite(t *testing.T) {
+	suite.Run(t, &TagsGetTestSuite{})
+}
@@ -0,0 +1,204 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package user_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/user"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type FiltersTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *FiltersTestSuite) newContext(recorder *httptest.ResponseRecorder, method string, path string, body []byte, contentType string) *gin.Context {
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(method, "http://localhost:8080"+path, bytes.NewReader(body))
+	ctx.Request.Header.Set("accept", "application/json")
+	if contentType != "" {
+		ctx.Request.Header.Set("Content-Type", contentType)
+	}
+	return ctx
+}
+
+func (suite *FiltersTestSuite) exportFilters() []*apimodel.FilterExportV2 {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, user.FiltersExportPath, nil, "")
+	suite.userModule.FiltersExportGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	filters := []*apimodel.FilterExportV2{}
+	if err := json.Unmarshal(b, &filters); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return filters
+}
+
+func (suite *FiltersTestSuite) importFilters(filters []*apimodel.FilterExportV2) *apimodel.MultiStatus {
+	b, err := json.Marshal(filters)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	path := filepath.Join(suite.T().TempDir(), "filters.json")
+	if err := os.WriteFile(path, b, 0o600); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	body, w, err := testrig.CreateMultipartFormData("filters", path, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, user.FiltersImportPath, body.Bytes(), w.FormDataContentType())
+	suite.userModule.FiltersImportPOSTHandler(ctx)
+	suite.Equal(http.StatusMultiStatus, recorder.Code)
+
+	multiStatus := &apimodel.MultiStatus{}
+	if err := json.NewDecoder(recorder.Body).Decode(multiStatus); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return multiStatus
+}
+
+func (suite *FiltersTestSuite) TestFiltersExport() {
+	filters := suite.exportFilters()
+	if !suite.Len(filters, 2) {
+		suite.FailNow("")
+	}
+
+	b, err := json.MarshalIndent(filters, "", "  ")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(`[
+  {
+    "title": "fnord",
+    "context": [
+      "home",
+      "public"
+    ],
+    "expires_at": null,
+    "filter_action": "warn",
+    "keywords": [
+      {
+        "keyword": "fnord",
+        "whole_word": true
+      }
+    ]
+  },
+  {
+    "title": "metasyntactic variables",
+    "context": [
+      "home",
+      "public"
+    ],
+    "expires_at": null,
+    "filter_action": "warn",
+    "keywords": [
+      {
+        "keyword": "foo",
+        "whole_word": true
+      },
+      {
+        "keyword": "bar",
+        "whole_word": true
+      }
+    ]
+  }
+]`, string(b))
+}
+
+func (suite *FiltersTestSuite) TestFiltersImport() {
+	// Re-importing an export should
+	// skip the existing filters.
+	filters := suite.exportFilters()
+	multiStatus := suite.importFilters(filters)
+	suite.Equal(2, multiStatus.Metadata.Failure)
+	for _, entry := range multiStatus.Data {
+		suite.Equal(http.StatusConflict, entry.Status)
+	}
+
+	// Import a new filter, plus an invalid one.
+	expiresAt := "2099-01-01T00:00:00.000Z"
+	multiStatus = suite.importFilters([]*apimodel.FilterExportV2{
+		{
+			Title:        "shouting",
+			Context:      []apimodel.FilterContext{apimodel.FilterContextHome, apimodel.FilterContextThread},
+			ExpiresAt:    &expiresAt,
+			FilterAction: apimodel.FilterActionHide,
+			Keywords: []apimodel.FilterKeywordExportV2{
+				{Keyword: "AAAAA", WholeWord: false},
+				{Keyword: "HELP", WholeWord: true},
+			},
+		},
+		{
+			Title:   "no keywords",
+			Context: []apimodel.FilterContext{apimodel.FilterContextHome},
+		},
+	})
+	suite.Equal(1, multiStatus.Metadata.Success)
+	suite.Equal(1, multiStatus.Metadata.Failure)
+	suite.Equal(http.StatusOK, multiStatus.Data[0].Status)
+	suite.Equal(http.StatusUnprocessableEntity, multiStatus.Data[1].Status)
+	suite.Equal("Unprocessable Entity: at least one filter keyword is required", multiStatus.Data[1].Message)
+
+	// The new filter should be there.
+	dbFilters, err := suite.db.GetFiltersForAccountID(context.Background(), suite.testAccounts["local_account_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(dbFilters, 3)
+
+	filters = suite.exportFilters()
+	suite.Len(filters, 3)
+	suite.Equal("shouting", filters[2].Title)
+	suite.Equal(apimodel.FilterActionHide, filters[2].FilterAction)
+	suite.Equal(expiresAt, *filters[2].ExpiresAt)
+	suite.Len(filters[2].Keywords, 2)
+}
+
+func TestFiltersTestSuite(t *testing.T) {
+	suite.Run(t, new(FiltersTestSuite))
+}
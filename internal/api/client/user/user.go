@@ -29,6 +29,14 @@ const (
 	BasePath = "/v1/user"
 	// PasswordChangePath is the path for POSTing a password change request.
 	PasswordChangePath = BasePath + "/password_change"
+	// TermsPath is the path for getting the current version of the instance terms.
+	TermsPath = BasePath + "/terms"
+	// TermsAcceptPath is the path for POSTing acceptance of the instance terms.
+	TermsAcceptPath = TermsPath + "/accept"
+	// FiltersExportPath is the path for exporting the user's filters.
+	FiltersExportPath = BasePath + "/filters/export"
+	// FiltersImportPath is the path for POSTing a filters import.
+	FiltersImportPath = BasePath + "/filters/import"
 )
 
 type Module struct {
@@ -43,4 +51,8 @@ func New(processor *processing.Processor) *Module {
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	attachHandler(http.MethodPost, PasswordChangePath, m.PasswordChangePOSTHandler)
+	attachHandler(http.MethodGet, TermsPath, m.TermsGETHandler)
+	attachHandler(http.MethodPost, TermsAcceptPath, m.TermsAcceptPOSTHandler)
+	attachHandler(http.MethodGet, FiltersExportPath, m.FiltersExportGETHandler)
+	attachHandler(http.MethodPost, FiltersImportPath, m.FiltersImportPOSTHandler)
 }
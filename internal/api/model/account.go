@@ -99,12 +99,24 @@ type Account struct {
 	// Account has opted to hide their followers/following collections.
 	// Key/value omitted if false.
 	HideCollections bool `json:"hide_collections,omitempty"`
+	// Account has opted to hide which application was used to post their statuses.
+	// Key/value omitted if false.
+	HideApplication bool `json:"hide_application,omitempty"`
+	// Account allows their public statuses to be embedded in other websites.
+	// Key/value omitted if false.
+	EnableEmbeds bool `json:"enable_embeds,omitempty"`
 	// Role of the account on this instance.
 	// Key/value omitted for remote accounts.
 	Role *AccountRole `json:"role,omitempty"`
 	// If set, indicates that this account is currently inactive, and has migrated to the given account.
 	// Key/value omitted for accounts that haven't moved, and for suspended accounts.
 	Moved *Account `json:"moved,omitempty"`
+	// Accounts that this account is also known as, which alias back to this account.
+	// Key/value omitted if there are none, and for remote accounts.
+	AlsoKnownAs []*Account `json:"also_known_as,omitempty"`
+	// Message attached by this account to its follow request, if any.
+	// Key/value only set when viewing pending follow requests.
+	FollowRequestNote string `json:"follow_request_note,omitempty"`
 }
 
 // AccountCreateRequest models account creation parameters.
@@ -133,6 +145,14 @@ type AccountCreateRequest struct {
 	// swagger:parameters
 	// required: true
 	Agreement bool `form:"agreement"  json:"agreement" xml:"agreement" binding:"required"`
+	// The user confirms being at least the instance's minimum age.
+	// Required if the instance has a minimum age set.
+	// swagger:parameters
+	AgeConfirmed bool `form:"age_confirmed" json:"age_confirmed" xml:"age_confirmed"`
+	// IDs of the instance rules that the user explicitly acknowledges.
+	// Required to include every current rule, if the instance has any.
+	// swagger:parameters
+	Rules []string `form:"rules[]" json:"rules" xml:"rules"`
 	// The language of the confirmation email that will be sent.
 	// swagger:parameters
 	// example: en
@@ -178,6 +198,13 @@ type UpdateCredentialsRequest struct {
 	EnableRSS *bool `form:"enable_rss" json:"enable_rss"`
 	// Hide this account's following/followers collections.
 	HideCollections *bool `form:"hide_collections" json:"hide_collections"`
+	// Hide which application was used to post this account's statuses.
+	HideApplication *bool `form:"hide_application" json:"hide_application"`
+	// Allow this account's public statuses to be embedded in other websites.
+	EnableEmbeds *bool `form:"enable_embeds" json:"enable_embeds"`
+	// Domains of websites which may credit this account as author of
+	// their content. Submit a single empty string to clear.
+	AttributionDomains *[]string `form:"attribution_domains[]" json:"attribution_domains"`
 }
 
 // UpdateSource is to be used specifically in an UpdateCredentialsRequest.
@@ -192,6 +219,18 @@ type UpdateSource struct {
 	Language *string `form:"language" json:"language"`
 	// Default format for authored statuses (text/plain or text/markdown).
 	StatusContentType *string `form:"status_content_type" json:"status_content_type"`
+	// Days of inactivity after which a post from a followed account triggers a notification (0 to disable).
+	NotifyNewFromDays *int `form:"notify_new_from_days" json:"notify_new_from_days"`
+	// Hours of inactivity after which new mentions are also sent by email (0 to disable).
+	EmailWhenAwayHours *int `form:"email_when_away_hours" json:"email_when_away_hours"`
+	// Automatically approve follow requests from accounts you already follow.
+	AutoApproveFollowed *bool `form:"auto_approve_followed" json:"auto_approve_followed"`
+	// Automatically approve follow requests from accounts on this instance.
+	AutoApproveLocal *bool `form:"auto_approve_local" json:"auto_approve_local"`
+	// Automatically approve follow requests from accounts known to this instance for at least this many days (0 to disable).
+	AutoApproveMinAgeDays *int `form:"auto_approve_min_age_days" json:"auto_approve_min_age_days"`
+	// Keep a log of accounts following and unfollowing you.
+	RecordFollowerEvents *bool `form:"record_follower_events" json:"record_follower_events"`
 }
 
 // UpdateField is to be used specifically in an UpdateCredentialsRequest.
@@ -218,6 +257,33 @@ type AccountFollowRequest struct {
 	Reblogs *bool `form:"reblogs" json:"reblogs" xml:"reblogs"`
 	// Notify when this account posts.
 	Notify *bool `form:"notify" json:"notify" xml:"notify"`
+	// Optional short message to send along with
+	// a new follow request to a locked account.
+	Note string `form:"note" json:"note" xml:"note"`
+}
+
+// AccountBlockRequest models a request to block an account.
+//
+// swagger:ignore
+type AccountBlockRequest struct {
+	// The id of the account to block.
+	ID string `form:"-" json:"-" xml:"-"`
+	// Number of seconds after which the block
+	// should be automatically undone. 0 = never.
+	Duration *int `form:"duration" json:"duration" xml:"duration"`
+}
+
+// AccountMuteRequest models a request to mute an account.
+//
+// swagger:ignore
+type AccountMuteRequest struct {
+	// The id of the account to mute.
+	ID string `form:"-" json:"-" xml:"-"`
+	// Mute notifications from the account as well. Default true.
+	Notifications *bool `form:"notifications" json:"notifications" xml:"notifications"`
+	// Number of seconds after which the mute
+	// should be automatically undone. 0 = never.
+	Duration *int `form:"duration" json:"duration" xml:"duration"`
 }
 
 // AccountDeleteRequest models a request to delete an account.
@@ -238,6 +304,19 @@ type AccountMoveRequest struct {
 	MovedToURI string `form:"moved_to_uri" json:"moved_to_uri" xml:"moved_to_uri"`
 }
 
+// AccountUsernameChangeRequest models a
+// request to change an account's username.
+//
+// swagger:ignore
+type AccountUsernameChangeRequest struct {
+	// Password of the account's user, for confirmation.
+	Password string `form:"password" json:"password" xml:"password"`
+	// Username to change to.
+	Username string `form:"username" json:"username" xml:"username"`
+	// How to federate the change, "update" (default) or "move".
+	Strategy string `form:"strategy" json:"strategy" xml:"strategy"`
+}
+
 // AccountAliasRequest models a request
 // to set an account's alsoKnownAs URIs.
 type AccountAliasRequest struct {
@@ -245,6 +324,36 @@ type AccountAliasRequest struct {
 	AlsoKnownAsURIs []string `form:"also_known_as_uris" json:"also_known_as_uris" xml:"also_known_as_uris"`
 }
 
+// AccountAliasChangeRequest models a request to add
+// or remove a single alsoKnownAs URI of an account.
+type AccountAliasChangeRequest struct {
+	// ActivityPub URI of the account to add or remove as alias.
+	AlsoKnownAsURI string `form:"also_known_as_uri" json:"also_known_as_uri" xml:"also_known_as_uri"`
+}
+
+// AccountAlias models one alsoKnownAs alias of an account,
+// along with whether the alias has been verified.
+//
+// swagger:model accountAlias
+type AccountAlias struct {
+	// ActivityPub URI of the aliased account.
+	// example: https://example.org/users/some_account
+	URI string `json:"uri"`
+	// Web URL of the aliased account.
+	// Empty if the account couldn't be dereferenced.
+	// example: https://example.org/@some_account
+	URL string `json:"url"`
+	// Webfinger account URI of the aliased account.
+	// Empty if the account couldn't be dereferenced.
+	// example: some_account@example.org
+	Acct string `json:"acct"`
+	// The aliased account lists this account as an alias in
+	// return, so both accounts are known to be controlled by
+	// the same person. Unverified aliases are not shown on
+	// the public profile of this account.
+	Verified bool `json:"verified"`
+}
+
 // AccountRole models the role of an account.
 //
 // swagger:model accountRole
@@ -62,6 +62,10 @@ type AdminAccountInfo struct {
 	Approved bool `json:"approved"`
 	// Whether the account is currently disabled.
 	Disabled bool `json:"disabled"`
+	// If the account is temporarily frozen, time at which
+	// it will be automatically reenabled (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	FrozenUntil string `json:"frozen_until,omitempty"`
 	// Whether the account is currently silenced
 	Silenced bool `json:"silenced"`
 	// Whether the account is currently suspended.
@@ -72,6 +76,10 @@ type AdminAccountInfo struct {
 	CreatedByApplicationID string `json:"created_by_application_id,omitempty"`
 	// The ID of the account that invited this user
 	InvitedByAccountID string `json:"invited_by_account_id,omitempty"`
+	// Instance rules that the user explicitly acknowledged when signing up,
+	// as they were at the time. Omitted for remote accounts, and for users
+	// who signed up before rules had to be acknowledged.
+	AcknowledgedRules []AdminRuleAcknowledgement `json:"acknowledged_rules,omitempty"`
 }
 
 // AdminReport models the admin view of a report.
@@ -170,10 +178,13 @@ type AdminEmoji struct {
 type AdminActionRequest struct {
 	// Category of the target entity.
 	Category string `form:"-" json:"-" xml:"-"`
-	// Type of admin action to take. One of disable, silence, suspend.
+	// Type of admin action to take. One of disable, silence, suspend, freeze.
 	Type string `form:"type" json:"type" xml:"type"`
 	// Text describing why an action was taken.
 	Text string `form:"text" json:"text" xml:"text"`
+	// Number of seconds after which the action should
+	// be automatically undone. Only used by freeze.
+	Duration *int `form:"duration" json:"duration" xml:"duration"`
 	// ID of the target entity.
 	TargetID string `form:"-" json:"-" xml:"-"`
 }
@@ -198,6 +209,50 @@ type MediaCleanupRequest struct {
 	RemoteCacheDays *int `form:"remote_cache_days" json:"remote_cache_days" xml:"remote_cache_days"`
 }
 
+// AdminDirectMessageRequest models a request to send a
+// direct message to a set of local accounts, eg., to let
+// them know about a moderation decision that affects them.
+type AdminDirectMessageRequest struct {
+	// Text of the message, in plain text.
+	Status string `form:"status" json:"status" xml:"status"`
+	// Optional content warning for the message.
+	SpoilerText string `form:"spoiler_text" json:"spoiler_text" xml:"spoiler_text"`
+	// ISO 639 language code of the message.
+	Language string `form:"language" json:"language" xml:"language"`
+	// ID of the account to send the message from, which
+	// may only be the requesting admin's own account.
+	// If not set, the instance account will be used.
+	AccountID string `form:"account_id" json:"account_id" xml:"account_id"`
+	// Send the message to all active local accounts.
+	All bool `form:"all" json:"all" xml:"all"`
+	// IDs of local accounts to send the message to.
+	// Must not be set if All is true.
+	TargetAccountIDs []string `form:"target_account_ids[]" json:"target_account_ids" xml:"target_account_ids"`
+}
+
+// AdminAccountProfileRequest models a request to edit or
+// clear the profile of a local account on its behalf, eg.,
+// to remove abusive content pending a response from the user.
+//
+// swagger:ignore
+type AdminAccountProfileRequest struct {
+	// New display name for the account.
+	// Use empty string to clear.
+	DisplayName *string `form:"display_name" json:"display_name"`
+	// New bio for the account.
+	// Use empty string to clear.
+	Note *string `form:"note" json:"note"`
+	// New profile metadata names and values.
+	// Submit no (non-empty) fields to clear.
+	FieldsAttributes *[]UpdateField `form:"fields_attributes" json:"-"`
+	// Profile metadata names and values, parsed from JSON.
+	JSONFieldsAttributes *map[string]UpdateField `form:"-" json:"fields_attributes"`
+	// Text describing why the profile was edited.
+	Text string `form:"text" json:"text"`
+	// ID of the target account.
+	TargetID string `form:"-" json:"-"`
+}
+
 // AdminSendTestEmailRequest models a test email send request (woah).
 type AdminSendTestEmailRequest struct {
 	// Email address to send the test email to.
@@ -230,6 +285,50 @@ type DebugAPUrlResponse struct {
 	ResponseBody string `json:"response_body"`
 }
 
+// DebugConversionsResponse provides timings of
+// typical typeutils conversions, measured against
+// the live database and caches.
+//
+// swagger:model debugConversionsResponse
+type DebugConversionsResponse struct {
+	// Amount of media attachments on the measured status.
+	Attachments int `json:"attachments"`
+	// Amount of custom emojis on the measured status.
+	Emojis int `json:"emojis"`
+	// Timings of each measured conversion.
+	Results []DebugBenchmarkResult `json:"results"`
+}
+
+// DebugBenchmarkResult provides timings of
+// repeated runs of one measured operation.
+//
+// swagger:model debugBenchmarkResult
+type DebugBenchmarkResult struct {
+	// Name of the measured operation.
+	// example: StatusToAPIStatus
+	Name string `json:"name"`
+	// Amount of times the operation was run.
+	Iterations int `json:"iterations"`
+	// Amount of runs that returned an error.
+	Errors int `json:"errors"`
+	// Fastest run, in nanoseconds.
+	MinNS int64 `json:"min_ns"`
+	// Mean of all runs, in nanoseconds.
+	MeanNS int64 `json:"mean_ns"`
+	// Median of all runs, in nanoseconds.
+	P50NS int64 `json:"p50_ns"`
+	// 95th percentile of all runs, in nanoseconds.
+	P95NS int64 `json:"p95_ns"`
+	// Slowest run, in nanoseconds.
+	MaxNS int64 `json:"max_ns"`
+	// Mean heap allocations per run. This is approximate,
+	// as it includes allocations of concurrent requests.
+	AllocsPerOp uint64 `json:"allocs_per_op"`
+	// Mean heap bytes allocated per run. This is approximate,
+	// as it includes allocations of concurrent requests.
+	BytesPerOp uint64 `json:"bytes_per_op"`
+}
+
 // AdminGetAccountsRequest models a request
 // to get an admin view of one or more
 // accounts using given parameters.
@@ -278,3 +377,92 @@ type AdminAccountRejectRequest struct {
 	// them that their sign-up has been rejected.
 	SendEmail bool `form:"send_email" json:"send_email"`
 }
+
+// AdminInstance models an admin view of a remote
+// instance that this instance has federated with.
+//
+// swagger:model adminInstance
+type AdminInstance struct {
+	// The ID of the instance.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	ID string `json:"id"`
+	// The domain of the instance.
+	// example: example.org
+	Domain string `json:"domain"`
+	// Title of the instance, as reported by the instance.
+	// example: Example Instance
+	Title string `json:"title,omitempty"`
+	// Name of the software the instance runs, as reported by nodeinfo.
+	// example: mastodon
+	Software string `json:"software,omitempty"`
+	// Version of the software the instance runs.
+	// example: mastodon 4.2.8
+	Version string `json:"version,omitempty"`
+	// Time at which this instance was first seen (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Time at which the instance metadata was last fetched (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	FetchedAt string `json:"fetched_at,omitempty"`
+	// Time at which federated traffic was last received from this instance (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	LastSeenAt string `json:"last_seen_at,omitempty"`
+	// Time at which this instance was suspended, if at all (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	SuspendedAt string `json:"suspended_at,omitempty"`
+	// Outgoing delivery statistics for this instance.
+	Delivery AdminInstanceDelivery `json:"delivery"`
+}
+
+// AdminInstanceDelivery models outgoing
+// delivery statistics for one instance.
+//
+// swagger:model adminInstanceDelivery
+type AdminInstanceDelivery struct {
+	// Number of successful delivery attempts.
+	// example: 420
+	Delivered uint64 `json:"delivered"`
+	// Number of failed delivery attempts.
+	// example: 69
+	Failed uint64 `json:"failed"`
+	// Time of the last successful delivery (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	LastDeliveredAt string `json:"last_delivered_at,omitempty"`
+	// Time of the last failed delivery (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	LastFailedAt string `json:"last_failed_at,omitempty"`
+}
+
+// AdminAccountDelivery models one recent outgoing
+// delivery of an activity by a local account.
+//
+// swagger:model adminAccountDelivery
+type AdminAccountDelivery struct {
+	// ActivityStreams type of the delivered activity.
+	// example: Create
+	ActivityType string `json:"activity_type"`
+	// ActivityPub ID of the object of the delivered activity, if any.
+	// example: https://example.org/users/someone/statuses/01FBW21XJA09XYX51KV5JVBW0F
+	ObjectID string `json:"object_id,omitempty"`
+	// Inbox URL the activity was delivered to.
+	// example: https://remote.example.org/inbox
+	Inbox string `json:"inbox"`
+	// Domain of the inbox the activity was delivered to.
+	// example: remote.example.org
+	Domain string `json:"domain"`
+	// Status of the delivery: queued, retrying, delivered, or failed.
+	// example: delivered
+	Status string `json:"status"`
+	// Number of delivery attempts made so far.
+	// example: 1
+	Attempts int `json:"attempts"`
+	// Error returned by the last failed attempt, if any.
+	// example: POST request to https://remote.example.org/inbox failed: status="503 Service Unavailable"
+	Error string `json:"error,omitempty"`
+	// Time at which the delivery was queued (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Time of the last delivery attempt, or queue time if not yet attempted (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	UpdatedAt string `json:"updated_at"`
+}
@@ -19,7 +19,7 @@ package model
 
 // Announcement models an admin announcement for the instance.
 //
-// TODO: swagger:model announcement once announcement API is supported
+// swagger:model announcement
 type Announcement struct {
 	// The ID of the announcement.
 	// example: 01FC30T7X4TNCZK0TH90QYF3M4
@@ -29,13 +29,13 @@ type Announcement struct {
 	// example: <p>This is an announcement. No malarky.</p>
 	Content string `json:"content"`
 	// When the announcement should begin to be displayed (ISO 8601 Datetime).
-	// If the announcement has no start time, this will be omitted or empty.
+	// If the announcement has no start time, this will be null.
 	// example: 2021-07-30T09:20:25+00:00
-	StartsAt string `json:"starts_at"`
+	StartsAt *string `json:"starts_at"`
 	// When the announcement should stop being displayed (ISO 8601 Datetime).
-	// If the announcement has no end time, this will be omitted or empty.
+	// If the announcement has no end time, this will be null.
 	// example: 2021-07-30T09:20:25+00:00
-	EndsAt string `json:"ends_at"`
+	EndsAt *string `json:"ends_at"`
 	// Announcement doesn't have begin time and end time, but begin day and end day.
 	AllDay bool `json:"all_day"`
 	// When the announcement was first published (ISO 8601 Datetime).
@@ -56,7 +56,41 @@ type Announcement struct {
 	// Tags used in this announcement.
 	Tags []Tag `json:"tags"`
 	// Emojis used in this announcement.
-	Emojis []Emoji `json:"emoji"`
+	Emojis []Emoji `json:"emojis"`
 	// Reactions to this announcement.
 	Reactions []AnnouncementReaction `json:"reactions"`
 }
+
+// AnnouncementCreateRequest represents a request to create a new announcement, made through the admin API.
+//
+// swagger:ignore
+type AnnouncementCreateRequest struct {
+	// Text body of the announcement, markdown.
+	Text string `form:"text" json:"text"`
+	// When to start showing the announcement (ISO 8601 Datetime).
+	StartsAt string `form:"starts_at" json:"starts_at"`
+	// When to stop showing the announcement (ISO 8601 Datetime).
+	EndsAt string `form:"ends_at" json:"ends_at"`
+	// Only the dates of starts_at and ends_at are relevant.
+	AllDay bool `form:"all_day" json:"all_day"`
+	// Publish the announcement immediately. Defaults to true.
+	Published *bool `form:"published" json:"published"`
+}
+
+// AnnouncementUpdateRequest represents a request to update an existing announcement, made through the admin API.
+//
+// swagger:ignore
+type AnnouncementUpdateRequest struct {
+	// Text body of the announcement, markdown.
+	Text *string `form:"text" json:"text"`
+	// When to start showing the announcement (ISO 8601 Datetime).
+	// Set to empty string to remove the start time.
+	StartsAt *string `form:"starts_at" json:"starts_at"`
+	// When to stop showing the announcement (ISO 8601 Datetime).
+	// Set to empty string to remove the end time.
+	EndsAt *string `form:"ends_at" json:"ends_at"`
+	// Only the dates of starts_at and ends_at are relevant.
+	AllDay *bool `form:"all_day" json:"all_day"`
+	// Publish or unpublish the announcement.
+	Published *bool `form:"published" json:"published"`
+}
@@ -33,6 +33,12 @@ type AttachmentRequest struct {
 	// If present, it should be in the form of two comma-separated floats between -1 and 1.
 	// example: -0.5,0.565
 	Focus string `form:"focus"`
+	// Mark the media file as sensitive by itself, hiding it
+	// behind a warning even if its status is not sensitive. Optional.
+	Sensitive bool `form:"sensitive"`
+	// Number of seconds from now after which the media file
+	// should be deleted, leaving only a placeholder. Optional.
+	ExpiresIn int `form:"expires_in"`
 }
 
 // AttachmentUpdateRequest models an update request for an attachment.
@@ -47,6 +53,13 @@ type AttachmentUpdateRequest struct {
 	// If present, it should be in the form of two comma-separated floats between -1 and 1.
 	// allowEmptyValue: true
 	Focus *string `form:"focus" json:"focus" xml:"focus"`
+	// Mark the media file as sensitive by itself, hiding it
+	// behind a warning even if its status is not sensitive.
+	Sensitive *bool `form:"sensitive" json:"sensitive" xml:"sensitive"`
+	// Number of seconds from now after which the media file
+	// should be deleted, leaving only a placeholder.
+	// Set to 0 to stop the media from expiring.
+	ExpiresIn *int `form:"expires_in" json:"expires_in" xml:"expires_in"`
 }
 
 // Attachment models a media attachment.
@@ -90,12 +103,22 @@ type Attachment struct {
 	// A hash computed by the BlurHash algorithm, for generating colorful preview thumbnails when media has not been downloaded yet.
 	// See https://github.com/woltapp/blurhash
 	Blurhash *string `json:"blurhash"`
-
-	// Additional fields not exposed via JSON
-	// (used only internally for templating etc).
-
-	// Parent status of this media is sensitive.
-	Sensitive bool `json:"-"`
+	// Kinds of metadata which were stripped from the file when it was uploaded, for privacy.
+	// Only included when the owner of the attachment views it via the media API.
+	// example: ["exif","gps"]
+	StrippedMetadata []string `json:"stripped_metadata,omitempty"`
+	// This attachment should be hidden behind a warning,
+	// even if the status it's attached to is not sensitive.
+	// When rendering statuses for the web view, this is
+	// also set if the parent status is sensitive.
+	// example: false
+	Sensitive bool `json:"sensitive"`
+	// When the file of this attachment will be or was deleted (ISO 8601 Datetime).
+	// Once this time has passed, the attachment will have type `unknown`
+	// and no URLs, and will only be shown as a placeholder.
+	// Not set if the attachment doesn't expire.
+	// example: 2021-07-30T09:20:25+00:00
+	ExpiresAt *string `json:"expires_at,omitempty"`
 }
 
 // MediaMeta models media metadata.
@@ -44,6 +44,10 @@ type Card struct {
 	// A link to the author of the original resource.
 	// example: https://buzzfeed.com/authors/weewee
 	AuthorURL string `json:"author_url"`
+	// Authors of the original resource, including
+	// their fediverse account if the resource declared
+	// one with a fediverse:creator meta tag.
+	Authors []CardAuthor `json:"authors"`
 	// The provider of the original resource.
 	// example: Buzzfeed
 	ProviderName string `json:"provider_name"`
@@ -64,3 +68,19 @@ type Card struct {
 	// A hash computed by the BlurHash algorithm, for generating colorful preview thumbnails when media has not been downloaded yet.
 	Blurhash string `json:"blurhash"`
 }
+
+// CardAuthor represents one author of a resource linked in a preview card.
+//
+// swagger:model cardAuthor
+type CardAuthor struct {
+	// Name of the author.
+	// example: weewee
+	Name string `json:"name"`
+	// A link to the author.
+	// example: https://buzzfeed.com/authors/weewee
+	URL string `json:"url"`
+	// Fediverse account of the author, if the resource
+	// named one via fediverse:creator, and the resource
+	// is hosted on (a subdomain of) the account's domain.
+	Account *Account `json:"account"`
+}
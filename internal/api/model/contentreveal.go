@@ -0,0 +1,50 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// ContentReveal models a decision by the authorized account to always
+// show content behind content warnings, either for all statuses of one
+// author, or for all statuses of one thread.
+//
+// swagger:model contentReveal
+type ContentReveal struct {
+	// The ID of the content reveal.
+	// example: 01FBW9XGEP7G6K88VY4S9MPE1R
+	ID string `json:"id"`
+	// When the content reveal was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Author whose content is always shown.
+	// Only set for reveals of an author.
+	Account *Account `json:"account,omitempty"`
+	// ID of the status from which the thread was revealed.
+	// Only set for reveals of a thread.
+	// example: 01FBW9XGEP7G6K88VY4S9MPE1R
+	StatusID string `json:"status_id,omitempty"`
+}
+
+// ContentRevealCreateRequest models a request
+// to always show content for an author or thread.
+//
+// swagger:ignore
+type ContentRevealCreateRequest struct {
+	// ID of an author whose content should always be shown.
+	AccountID string `form:"account_id" json:"account_id"`
+	// ID of a status whose thread's content should always be shown.
+	StatusID string `form:"status_id" json:"status_id"`
+}
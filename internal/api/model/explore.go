@@ -0,0 +1,31 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// Explore contains the trending posts, trending hashtags
+// and suggested accounts shown on the web explore page.
+//
+// swagger:ignore
+type Explore struct {
+	// Trending posts, rendered for the web.
+	Statuses []*Status `json:"statuses"`
+	// Trending hashtags, with their usage history.
+	Tags []*Tag `json:"tags"`
+	// Suggested accounts to follow.
+	Accounts []*Account `json:"accounts"`
+}
@@ -0,0 +1,49 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// AccountExport models a request from the authorized
+// account for an archive of its data, and the archive
+// generated for it once it's ready.
+//
+// swagger:model accountExport
+type AccountExport struct {
+	// The ID of the export.
+	// example: 01FBW9XGEP7G6K88VY4S9MPE1R
+	ID string `json:"id"`
+	// When the export was requested (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Progress state of the export.
+	// enum:
+	//   - pending
+	//   - processing
+	//   - done
+	//   - failed
+	// example: done
+	State string `json:"state"`
+	// Size of the export archive in bytes,
+	// or 0 if the archive is not ready yet.
+	// example: 1048576
+	Size int `json:"size"`
+	// URL at which the export archive can be downloaded
+	// using the authorized account's access token.
+	// Only set once the archive is ready.
+	// example: https://example.org/api/v1/exports/01FBW9XGEP7G6K88VY4S9MPE1R/download
+	DownloadURL *string `json:"download_url"`
+}
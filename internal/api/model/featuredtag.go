@@ -18,15 +18,29 @@
 package model
 
 // FeaturedTag represents a hashtag that is featured on a profile.
+//
+// swagger:model featuredTag
 type FeaturedTag struct {
 	// The internal ID of the featured tag in the database.
+	// example: 01FBW9XGEP7G6K88VY4S9MPE1R
 	ID string `json:"id"`
-	// The name of the hashtag being featured.
+	// The name of the hashtag being featured, without the leading '#'.
+	// example: example
 	Name string `json:"name"`
-	// A link to all statuses by a user that contain this hashtag.
+	// A link to all statuses that contain this hashtag.
 	URL string `json:"url"`
 	// The number of authored statuses containing this hashtag.
 	StatusesCount int `json:"statuses_count"`
-	// The timestamp of the last authored status containing this hashtag. (ISO 8601 Datetime)
-	LastStatusAt string `json:"last_status_at"`
+	// The timestamp of the last authored status containing this hashtag (ISO 8601 Datetime).
+	// Null if there's no such status.
+	// example: 2021-07-30T09:20:25+00:00
+	LastStatusAt *string `json:"last_status_at"`
+}
+
+// FeaturedTagCreateRequest models a request to feature a hashtag.
+//
+// swagger:ignore
+type FeaturedTagCreateRequest struct {
+	// The name of the hashtag to feature, with or without the leading '#'.
+	Name string `form:"name" json:"name"`
 }
@@ -17,6 +17,8 @@
 
 package model
 
+import "mime/multipart"
+
 // FilterV2 represents a user-defined filter for determining which statuses should not be shown to the user.
 // v2 filters have names and can include multiple phrases and status IDs to filter.
 //
@@ -104,3 +106,70 @@ type FilterStatus struct {
 	// The status ID to be filtered.
 	StatusID string `json:"phrase"`
 }
+
+// FilterExportV2 represents a v2 filter in a portable format, suitable for
+// backing up, or for moving filters from one instance to another.
+// Unlike FilterV2, it contains no database IDs, and no statuses,
+// since those are only meaningful on the instance they came from.
+//
+// swagger:model filterExportV2
+//
+// ---
+// tags:
+// - filters
+type FilterExportV2 struct {
+	// The name of the filter.
+	//
+	// Example: Linux Words
+	Title string `json:"title"`
+	// The contexts in which the filter should be applied.
+	//
+	// Minimum items: 1
+	// Unique: true
+	// Enum:
+	//	- home
+	//	- notifications
+	//	- public
+	//	- thread
+	//	- account
+	// Example: ["home", "public"]
+	Context []FilterContext `json:"context"`
+	// When the filter should no longer be applied. Null if the filter does not expire.
+	//
+	// Example: 2024-02-01T02:57:49Z
+	ExpiresAt *string `json:"expires_at"`
+	// The action to be taken when a status matches this filter.
+	// Enum:
+	//	- warn
+	//	- hide
+	FilterAction FilterAction `json:"filter_action"`
+	// The keywords grouped under this filter.
+	Keywords []FilterKeywordExportV2 `json:"keywords"`
+}
+
+// FilterKeywordExportV2 represents text to filter
+// within a v2 filter, in a portable format.
+//
+// swagger:model filterKeywordExportV2
+//
+// ---
+// tags:
+// - filters
+type FilterKeywordExportV2 struct {
+	// The text to be filtered.
+	//
+	// Example: fnord
+	Keyword string `json:"keyword"`
+	// Should the filter consider word boundaries?
+	//
+	// Example: true
+	WholeWord bool `json:"whole_word"`
+}
+
+// FiltersImportRequestV2 captures params for importing v2 filters.
+//
+// swagger:ignore
+type FiltersImportRequestV2 struct {
+	// JSON-formatted list of filters, as produced by a filters export.
+	Filters *multipart.FileHeader `form:"filters" json:"-"`
+}
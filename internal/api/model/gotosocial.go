@@ -0,0 +1,123 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// GTSStatus models GoToSocial-specific information about
+// a status, served in the /api/v1/gotosocial namespace
+// rather than overloading the Mastodon status model.
+//
+// swagger:model gtsStatus
+type GTSStatus struct {
+	// ID of the status.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// This status is local-only: it's only
+	// visible to accounts on this instance,
+	// and is not federated to other instances.
+	// example: false
+	LocalOnly bool `json:"local_only"`
+	// Who may interact with this status, taking
+	// into account both its interaction policy and
+	// any replyable / boostable / likeable flags.
+	InteractionPolicy *InteractionPolicy `json:"interaction_policy"`
+}
+
+// GTSAccountStats models statistics about the
+// activity of the requesting account over time,
+// computed locally by this instance.
+//
+// swagger:model gtsAccountStats
+type GTSAccountStats struct {
+	// Per-week statistics, from the oldest week to the current week.
+	Weeks []GTSAccountStatsWeek `json:"weeks"`
+	// Accounts that most interacted with this account's
+	// statuses during these weeks, most interactions first.
+	TopInteractingAccounts []GTSAccountInteractions `json:"top_interacting_accounts"`
+}
+
+// GTSAccountStatsWeek models statistics
+// about one week of an account's activity.
+//
+// swagger:model gtsAccountStatsWeek
+type GTSAccountStatsWeek struct {
+	// Date (UTC) of the Monday starting this week.
+	// example: 2024-07-01
+	Week string `json:"week"`
+	// Number of statuses posted, excluding boosts.
+	// example: 12
+	Statuses int `json:"statuses"`
+	// Number of new followers gained.
+	// example: 3
+	FollowersGained int `json:"followers_gained"`
+	// Number of followers lost, if known.
+	// Null if follower events aren't recorded for the account.
+	// example: 1
+	// nullable: true
+	FollowersLost *int `json:"followers_lost"`
+}
+
+// GTSAccountInteractions models the number of times
+// an account interacted with another account's statuses.
+//
+// swagger:model gtsAccountInteractions
+type GTSAccountInteractions struct {
+	// The interacting account.
+	Account *Account `json:"account"`
+	// Number of faves, boosts, and replies by the account.
+	// example: 5
+	Interactions int `json:"interactions"`
+}
+
+// GTSDomainPermissionSubscription models a subscription
+// through which domain permissions (blocks or allows)
+// were created on this instance, as referenced by the
+// subscription_id of those domain permissions.
+//
+// swagger:model gtsDomainPermissionSubscription
+type GTSDomainPermissionSubscription struct {
+	// ID of the subscription.
+	// example: 01FBW25TF5J67JW3HFHZCSD23K
+	ID string `json:"id"`
+	// Type of domain permissions created by this subscription.
+	// example: block
+	PermissionType string `json:"permission_type"`
+	// Domains with a permission created by this subscription.
+	// example: ["example.org","example.com"]
+	Domains []string `json:"domains"`
+}
+
+// GTSFollowerEvent models an account starting or stopping
+// following the requesting account, as recorded by this
+// instance for accounts that opted in to it.
+//
+// swagger:model gtsFollowerEvent
+type GTSFollowerEvent struct {
+	// ID of the follower event.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// Type of the follower event.
+	// enum:
+	//	- follow
+	//	- unfollow
+	Type string `json:"type"`
+	// When the event happened (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// The account that followed or unfollowed.
+	Account *Account `json:"account"`
+}
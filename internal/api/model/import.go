@@ -0,0 +1,84 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import "mime/multipart"
+
+// AccountImport models a request from the authorized account
+// to import follows, blocks, mutes, or lists from a CSV file,
+// and the progress of processing that file.
+//
+// swagger:model accountImport
+type AccountImport struct {
+	// The ID of the import.
+	// example: 01FBW9XGEP7G6K88VY4S9MPE1R
+	ID string `json:"id"`
+	// When the import was requested (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Kind of data being imported.
+	// enum:
+	//   - following
+	//   - blocking
+	//   - muting
+	//   - lists
+	// example: following
+	Type string `json:"type"`
+	// Progress state of the import.
+	// enum:
+	//   - pending
+	//   - processing
+	//   - done
+	//   - failed
+	// example: processing
+	State string `json:"state"`
+	// Total number of rows in the imported file.
+	// example: 120
+	TotalRows int `json:"total_rows"`
+	// Number of rows processed so far, whether successfully or not.
+	// example: 80
+	ProcessedRows int `json:"processed_rows"`
+	// Rows that could not be imported, in file order.
+	Failures []AccountImportFailure `json:"failures"`
+}
+
+// AccountImportFailure models one row of
+// an account import that could not be imported.
+//
+// swagger:model accountImportFailure
+type AccountImportFailure struct {
+	// Line number of the row in the imported file.
+	// example: 12
+	Line int `json:"line"`
+	// Fields of the row in the imported file.
+	// example: ["someone@example.org"]
+	Record []string `json:"record"`
+	// Reason the row could not be imported.
+	// example: account could not be found
+	Error string `json:"error"`
+}
+
+// AccountImportRequest captures params for importing account data.
+//
+// swagger:ignore
+type AccountImportRequest struct {
+	// Kind of data being imported.
+	Type string `form:"type" json:"-"`
+	// CSV file, as exported by Mastodon.
+	Data *multipart.FileHeader `form:"data" json:"-"`
+}
@@ -146,3 +146,17 @@ type InstanceConfigurationEmojis struct {
 	// example: 51200
 	EmojiSizeLimit int `json:"emoji_size_limit"`
 }
+
+// InstanceWebClient represents a first-party
+// web client which may use this instance's API.
+//
+// swagger:model instanceWebClient
+type InstanceWebClient struct {
+	// URL at which the web client is hosted.
+	// example: https://elk.example.org
+	URL string `json:"url"`
+	// Origin (scheme + host) of the web client,
+	// allowed to make cross-origin requests.
+	// example: https://elk.example.org
+	Origin string `json:"origin"`
+}
@@ -143,6 +143,15 @@ type InstanceV2ConfigurationTranslation struct {
 	Enabled bool `json:"enabled"`
 }
 
+// Information about the VAPID key of this instance.
+//
+// swagger:model instanceV2ConfigurationVAPID
+type InstanceV2ConfigurationVAPID struct {
+	// The instance's VAPID public key, used by clients
+	// when subscribing to Web Push notifications.
+	PublicKey string `json:"public_key"`
+}
+
 // Configured values and limits for this instance.
 //
 // swagger:model instanceV2Configuration
@@ -161,6 +170,8 @@ type InstanceV2Configuration struct {
 	Translation InstanceV2ConfigurationTranslation `json:"translation"`
 	// Instance configuration pertaining to emojis.
 	Emojis InstanceConfigurationEmojis `json:"emojis"`
+	// Information about the VAPID key of this instance.
+	VAPID InstanceV2ConfigurationVAPID `json:"vapid"`
 }
 
 // Information about registering for this instance.
@@ -177,6 +188,31 @@ type InstanceV2Registrations struct {
 	// Value will be null if no message is set.
 	// example: <p>Registrations are currently closed on example.org because of spam bots!</p>
 	Message *string `json:"message"`
+	// Minimum age in years that users must confirm being at least to sign up.
+	// Value will be null if no minimum age is set.
+	// example: 16
+	MinAge *int `json:"min_age"`
+	// Rules that usernames of new accounts must follow.
+	Usernames InstanceV2RegistrationsUsernames `json:"usernames"`
+}
+
+// Rules that usernames of new accounts on this instance must follow.
+//
+// swagger:model instanceV2RegistrationsUsernames
+type InstanceV2RegistrationsUsernames struct {
+	// Minimum length of a username, in characters.
+	// example: 1
+	MinLength int `json:"min_length"`
+	// Maximum length of a username, in characters.
+	// example: 64
+	MaxLength int `json:"max_length"`
+	// Regular expression that usernames must match, on top of only containing
+	// lowercase letters, numbers and underscores. Empty string if not set.
+	// example: ^[a-z].*$
+	Pattern string `json:"pattern"`
+	// Usernames that can't be taken by new accounts.
+	// example: ["admin","webmaster","security"]
+	Reserved []string `json:"reserved"`
 }
 
 // Hints related to contacting a representative of the instance.
@@ -0,0 +1,44 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// InteractionRequest models a reply to, or boost of, one of the
+// authorized account's statuses, which is held pending approval.
+//
+// swagger:model interactionRequest
+type InteractionRequest struct {
+	// The ID of the interaction request in the database.
+	ID string `json:"id"`
+	// The type of interaction held pending approval.
+	// enum:
+	//	- reply
+	//	- reblog
+	Type string `json:"type"`
+	// When the interaction request was created (ISO 8601 Datetime).
+	CreatedAt string `json:"created_at"`
+	// The account that interacted with the status.
+	Account *Account `json:"account"`
+	// The status that was interacted with.
+	Status *Status `json:"status"`
+	// The reply held pending approval, if type is reply.
+	Reply *Status `json:"reply"`
+	// When the interaction request was accepted (ISO 8601 Datetime), if it was.
+	AcceptedAt string `json:"accepted_at,omitempty"`
+	// When the interaction request was rejected (ISO 8601 Datetime), if it was.
+	RejectedAt string `json:"rejected_at,omitempty"`
+}
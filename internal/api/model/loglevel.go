@@ -0,0 +1,53 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// LogLevels represents the logging levels currently in use by the instance.
+//
+// swagger:model logLevels
+type LogLevels struct {
+	// Global logging level, as set by the log-level config option.
+	// Applies to all components without a logging level of their own.
+	// example: info
+	Level string `json:"level"`
+
+	// Logging levels set at runtime for components (packages) of GoToSocial,
+	// keyed by component name. A component's logging level also applies
+	// to its subcomponents, unless they have a logging level of their own.
+	// example: {"transport":"debug","federation/dereferencing":"trace"}
+	Components map[string]string `json:"components"`
+}
+
+// LogLevelRequest is the form submitted as a POST to set or unset the logging level of a component.
+//
+// swagger:parameters logLevelSet
+type LogLevelRequest struct {
+	// Name of the component (package) to set the logging level of, given as
+	// its path within GoToSocial without 'internal/', eg. 'transport',
+	// 'federation/dereferencing' or 'processing/workers'.
+	// required: true
+	// in: formData
+	Component string `form:"component" json:"component" xml:"component"`
+
+	// Logging level to set for the component, one of
+	// 'trace', 'debug', 'info', 'warn', 'error', 'fatal'.
+	// Leave empty to unset the component's logging level,
+	// so it's inherited from its parent or the global level again.
+	// in: formData
+	Level string `form:"level" json:"level" xml:"level"`
+}
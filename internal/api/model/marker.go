@@ -37,6 +37,17 @@ type TimelineMarker struct {
 	Version int `json:"version"`
 }
 
+// MarkerUnreadCounts contains the number of items in a user's
+// timelines newer than their markers, up to the requested limit.
+//
+// swagger:model markerUnreadCounts
+type MarkerUnreadCounts struct {
+	// Number of statuses in the home timeline newer than its marker.
+	Home *int `json:"home,omitempty"`
+	// Number of notifications newer than their marker.
+	Notifications *int `json:"notifications,omitempty"`
+}
+
 // MarkerName is the name of one of the timelines we can store markers for.
 type MarkerName string
 
@@ -0,0 +1,56 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// MediaUploadRequest models resumable media upload creation parameters.
+//
+// swagger:ignore
+type MediaUploadRequest struct {
+	// Total size of the media file in bytes.
+	Size int64 `form:"size" json:"size" xml:"size"`
+	// Description of the media file. Optional.
+	// This will be used as alt-text for users of screenreaders etc.
+	Description string `form:"description" json:"description" xml:"description"`
+	// Focus of the media file. Optional.
+	// If present, it should be in the form of two comma-separated floats between -1 and 1.
+	Focus string `form:"focus" json:"focus" xml:"focus"`
+}
+
+// MediaUpload models an in-progress resumable media upload.
+//
+// swagger:model mediaUpload
+type MediaUpload struct {
+	// The ID of the upload.
+	// example: 01FC31DZT1AYWDZ8XTCRWRBYRK
+	ID string `json:"id"`
+	// Total size of the media file in bytes.
+	// example: 1048576
+	Size int64 `json:"size"`
+	// Number of bytes received so far. The next
+	// chunk of the file should start at this offset.
+	// example: 524288
+	Offset int64 `json:"offset"`
+	// Time at which the upload will expire if no more data is received (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	ExpiresAt string `json:"expires_at"`
+	// The media attachment created from the upload.
+	// Only set once all bytes of the file have been received.
+	// The attachment url will be null until processing has finished,
+	// so clients should poll `/api/v1/media/{id}` until it's set.
+	Attachment *Attachment `json:"attachment,omitempty"`
+}
@@ -34,6 +34,7 @@ type Notification struct {
 	// 	poll = A poll you have voted in or created has ended. `status` will be set. `account` will be set.
 	// 	status = Someone you enabled notifications for has posted a status. `status` will be set. `account` will be set.
 	// 	admin.sign_up = Someone has signed up for a new account on the instance. `account` will be set.
+	// 	new_from = Someone you follow has posted for the first time in a while. `status` will be set. `account` will be set.
 	Type string `json:"type"`
 	// The timestamp of the notification (ISO 8601 Datetime)
 	CreatedAt string `json:"created_at"`
@@ -66,3 +67,45 @@ func (n *Notification) GetBoostOfID() string {
 func (n *Notification) GetBoostOfAccountID() string {
 	return ""
 }
+
+// GroupedNotificationsResults represents a page of notifications, grouped
+// so that similar notifications (eg., several faves of one status) can be
+// shown together, along with the accounts and statuses the groups refer to.
+//
+// swagger:model groupedNotificationsResults
+type GroupedNotificationsResults struct {
+	// Accounts referenced by the notification groups.
+	Accounts []*Account `json:"accounts"`
+	// Statuses referenced by the notification groups.
+	Statuses []*Status `json:"statuses"`
+	// The notification groups, most recent first.
+	NotificationGroups []*NotificationGroup `json:"notification_groups"`
+}
+
+// NotificationGroup represents a group of notifications of the same
+// type about the same thing, such as several faves of one status.
+//
+// swagger:model notificationGroup
+type NotificationGroup struct {
+	// Key identifying the group. Notifications that
+	// aren't grouped have a key of their own.
+	GroupKey string `json:"group_key"`
+	// Number of notifications in the group.
+	NotificationsCount int `json:"notifications_count"`
+	// The type of event that resulted in the notifications. See notification.type.
+	Type string `json:"type"`
+	// The ID of the most recent notification in the group.
+	MostRecentNotificationID string `json:"most_recent_notification_id"`
+	// The ID of the oldest notification of the group in this page.
+	PageMinID string `json:"page_min_id,omitempty"`
+	// The ID of the newest notification of the group in this page.
+	PageMaxID string `json:"page_max_id,omitempty"`
+	// The timestamp of the newest notification of the group in this page (ISO 8601 Datetime).
+	LatestPageNotificationAt string `json:"latest_page_notification_at,omitempty"`
+	// IDs of some of the accounts that performed the actions that
+	// generated the notifications, most recent first. See accounts.
+	SampleAccountIDs []string `json:"sample_account_ids"`
+	// ID of the status that was the object of the notifications,
+	// if any, e.g. in mentions, reblogs, favourites, or polls. See statuses.
+	StatusID string `json:"status_id,omitempty"`
+}
@@ -0,0 +1,128 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// NotificationPolicy represents what to do with notifications
+// to the authorized account, from accounts that match each of
+// the conditions: "accept" to notify as usual, "filter" to
+// set the notifications aside in notification requests for
+// review, or "drop" to drop the notifications altogether.
+//
+// swagger:model notificationPolicy
+type NotificationPolicy struct {
+	// Policy for notifications from accounts you don't follow.
+	ForNotFollowing string `json:"for_not_following"`
+	// Policy for notifications from accounts that don't follow you.
+	ForNotFollowers string `json:"for_not_followers"`
+	// Policy for notifications from accounts created in the past 30 days.
+	ForNewAccounts string `json:"for_new_accounts"`
+	// Policy for private mentions from accounts you don't follow,
+	// unless they're in reply to one of your statuses.
+	ForPrivateMentions string `json:"for_private_mentions"`
+	// Summary of filtered notifications.
+	Summary NotificationPolicySummary `json:"summary"`
+}
+
+// NotificationPolicyV1 represents the notification policy of the
+// authorized account, in the format of v1 of the API: each field
+// is true if notifications from accounts matching the condition
+// are filtered (or dropped), false if they're accepted.
+//
+// swagger:model notificationPolicyV1
+type NotificationPolicyV1 struct {
+	// Filter notifications from accounts you don't follow.
+	FilterNotFollowing bool `json:"filter_not_following"`
+	// Filter notifications from accounts that don't follow you.
+	FilterNotFollowers bool `json:"filter_not_followers"`
+	// Filter notifications from accounts created in the past 30 days.
+	FilterNewAccounts bool `json:"filter_new_accounts"`
+	// Filter private mentions from accounts you don't follow,
+	// unless they're in reply to one of your statuses.
+	FilterPrivateMentions bool `json:"filter_private_mentions"`
+	// Summary of filtered notifications.
+	Summary NotificationPolicySummary `json:"summary"`
+}
+
+// NotificationPolicySummary summarizes the
+// notifications currently filtered by a policy.
+//
+// swagger:model notificationPolicySummary
+type NotificationPolicySummary struct {
+	// Number of pending notification requests.
+	PendingRequestsCount int `json:"pending_requests_count"`
+	// Number of filtered notifications in pending notification requests.
+	PendingNotificationsCount int `json:"pending_notifications_count"`
+}
+
+// NotificationPolicyUpdateRequest models a notification policy update request.
+//
+// swagger:ignore
+type NotificationPolicyUpdateRequest struct {
+	// Policy for notifications from accounts you don't follow.
+	ForNotFollowing *string `form:"for_not_following" json:"for_not_following"`
+	// Policy for notifications from accounts that don't follow you.
+	ForNotFollowers *string `form:"for_not_followers" json:"for_not_followers"`
+	// Policy for notifications from accounts created in the past 30 days.
+	ForNewAccounts *string `form:"for_new_accounts" json:"for_new_accounts"`
+	// Policy for unsolicited private mentions.
+	ForPrivateMentions *string `form:"for_private_mentions" json:"for_private_mentions"`
+}
+
+// NotificationPolicyUpdateRequestV1 models a notification
+// policy update request, in the format of v1 of the API.
+//
+// swagger:ignore
+type NotificationPolicyUpdateRequestV1 struct {
+	// Filter notifications from accounts you don't follow.
+	FilterNotFollowing *bool `form:"filter_not_following" json:"filter_not_following"`
+	// Filter notifications from accounts that don't follow you.
+	FilterNotFollowers *bool `form:"filter_not_followers" json:"filter_not_followers"`
+	// Filter notifications from accounts created in the past 30 days.
+	FilterNewAccounts *bool `form:"filter_new_accounts" json:"filter_new_accounts"`
+	// Filter unsolicited private mentions.
+	FilterPrivateMentions *bool `form:"filter_private_mentions" json:"filter_private_mentions"`
+}
+
+// NotificationRequest represents the notifications to the authorized
+// account from another account that were filtered by its notification
+// policy, pending being accepted or dismissed.
+//
+// swagger:model notificationRequest
+type NotificationRequest struct {
+	// The ID of the notification request in the database.
+	ID string `json:"id"`
+	// When the notification request was created (ISO 8601 Datetime).
+	CreatedAt string `json:"created_at"`
+	// When the notification request was last updated (ISO 8601 Datetime).
+	UpdatedAt string `json:"updated_at"`
+	// The account that the filtered notifications originate from.
+	Account *Account `json:"account"`
+	// The number of filtered notifications, as a string.
+	NotificationsCount string `json:"notifications_count"`
+	// The status of the most recent filtered notification, if any.
+	LastStatus *Status `json:"last_status,omitempty"`
+}
+
+// NotificationRequestsBulkRequest models a request to accept
+// or dismiss multiple notification requests at once.
+//
+// swagger:ignore
+type NotificationRequestsBulkRequest struct {
+	// IDs of the notification requests.
+	IDs []string `form:"id[]" json:"id"`
+}
@@ -0,0 +1,59 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// OEmbed represents an oEmbed response for a status, allowing
+// the status to be embedded in other websites via an iframe.
+//
+// See https://oembed.com/#section2.3
+//
+// swagger:model oEmbed
+type OEmbed struct {
+	// The resource type. Always "rich" for statuses.
+	// example: rich
+	Type string `json:"type"`
+	// The oEmbed version number. Always "1.0".
+	// example: 1.0
+	Version string `json:"version"`
+	// A text title describing the resource.
+	// example: Post by @some_user
+	Title string `json:"title"`
+	// The name of the author of the status.
+	// example: Some User
+	AuthorName string `json:"author_name"`
+	// A URL for the author of the status.
+	// example: https://example.org/@some_user
+	AuthorURL string `json:"author_url"`
+	// The name of the instance providing the status.
+	// example: GoToSocial Example Instance
+	ProviderName string `json:"provider_name"`
+	// The URL of the instance providing the status.
+	// example: https://example.org
+	ProviderURL string `json:"provider_url"`
+	// The suggested cache lifetime for this resource, in seconds.
+	// example: 86400
+	CacheAge int `json:"cache_age"`
+	// The HTML required to display the status: an iframe of the status embed page.
+	HTML string `json:"html"`
+	// The width in pixels required to display the HTML.
+	// example: 400
+	Width int `json:"width"`
+	// The height in pixels required to display the HTML.
+	// Null if the height depends on the content of the status.
+	Height *int `json:"height"`
+}
@@ -0,0 +1,59 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// Problem models an error response as RFC 7807 "problem details",
+// served with content-type application/problem+json to callers
+// who ask for it in their Accept header.
+//
+// See https://www.rfc-editor.org/rfc/rfc7807
+//
+// swagger:model problem
+type Problem struct {
+	// URI reference identifying the problem type.
+	// This is always "about:blank" for now; use
+	// error_code to tell error types apart instead.
+	// example: about:blank
+	Type string `json:"type"`
+	// Short summary of the problem type; the HTTP status text.
+	// example: Too Many Requests
+	Title string `json:"title"`
+	// HTTP status code of the response.
+	// example: 429
+	Status int `json:"status"`
+	// Explanation of this particular occurrence of the problem.
+	// example: Too Many Requests: rate limit reached
+	Detail string `json:"detail"`
+	// Path of the request that caused the problem.
+	// example: /api/v1/timelines/home
+	Instance string `json:"instance"`
+	// Same as detail. Included for compatibility
+	// with clients expecting Mastodon style errors.
+	// example: Too Many Requests: rate limit reached
+	Error string `json:"error"`
+	// Machine-readable code for this type of error, if any.
+	// Current codes: rate_limited, capacity_exceeded,
+	// domain_blocked, signature_missing, signature_invalid,
+	// terms_not_accepted.
+	// example: rate_limited
+	ErrorCode string `json:"error_code,omitempty"`
+	// ID of the request, which can be
+	// given to the admin to help debug.
+	// example: 01HY4C8VFRQ4E32G6J6Y6CSZAA
+	RequestID string `json:"request_id,omitempty"`
+}
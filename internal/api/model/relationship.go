@@ -34,12 +34,20 @@ type Relationship struct {
 	FollowedBy bool `json:"followed_by"`
 	// You are blocking this account.
 	Blocking bool `json:"blocking"`
+	// Seconds remaining until your block on this account is automatically undone.
+	// Key/value omitted if you're not blocking this account, or the block doesn't expire.
+	// example: 86400
+	BlockingExpiresIn *int `json:"blocking_expires_in,omitempty"`
 	// This account is blocking you.
 	BlockedBy bool `json:"blocked_by"`
 	// You are muting this account.
 	Muting bool `json:"muting"`
 	// You are muting notifications from this account.
 	MutingNotifications bool `json:"muting_notifications"`
+	// Seconds remaining until your mute on this account is automatically undone.
+	// Key/value omitted if you're not muting this account, or the mute doesn't expire.
+	// example: 86400
+	MutingExpiresIn *int `json:"muting_expires_in,omitempty"`
 	// You have requested to follow this account, and the request is pending.
 	Requested bool `json:"requested"`
 	// This account has requested to follow you, and the request is pending.
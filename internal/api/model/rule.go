@@ -25,6 +25,29 @@ type InstanceRule struct {
 	Text string `json:"text"`
 }
 
+// AdminRuleAcknowledgement models the admin view of a user's
+// acknowledgement of an instance rule when signing up.
+//
+// swagger:model adminRuleAcknowledgement
+type AdminRuleAcknowledgement struct {
+	// ID of the acknowledged rule.
+	// example: 01GP3AWY4CRDVRNZKW0TEAMB51
+	RuleID string `json:"rule_id"`
+	// Text of the rule as it was when acknowledged.
+	// example: Be gay
+	Text string `json:"text"`
+	// When the rule was last updated before it was acknowledged,
+	// ie., which version of the rule was acknowledged. (ISO 8601 Datetime)
+	// example: 2022-05-14T10:20:03.000Z
+	RuleUpdatedAt string `json:"rule_updated_at"`
+	// When the rule was acknowledged. (ISO 8601 Datetime)
+	// example: 2024-06-28T10:00:00.000Z
+	AcknowledgedAt string `json:"acknowledged_at"`
+	// Whether the acknowledged version of the rule is still in effect,
+	// ie., the rule hasn't since been changed or deleted.
+	Current bool `json:"current"`
+}
+
 // InstanceRuleCreateRequest represents a request to create a new instance rule, made through the admin API.
 //
 // swagger:parameters ruleCreate
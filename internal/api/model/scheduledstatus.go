@@ -18,21 +18,54 @@
 package model
 
 // ScheduledStatus represents a status that will be published at a future scheduled date.
+//
+// swagger:model scheduledStatus
 type ScheduledStatus struct {
-	ID               string        `json:"id"`
-	ScheduledAt      string        `json:"scheduled_at"`
-	Params           *StatusParams `json:"params"`
-	MediaAttachments []Attachment  `json:"media_attachments"`
+	// ID of the scheduled status.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// When the status will be published (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	ScheduledAt string `json:"scheduled_at"`
+	// Parameters the status will be published with.
+	Params *StatusParams `json:"params"`
+	// Media that will be attached to the status.
+	MediaAttachments []Attachment `json:"media_attachments"`
 }
 
 // StatusParams represents parameters for a scheduled status.
+//
+// swagger:model statusParams
 type StatusParams struct {
-	Text          string   `json:"text"`
-	InReplyToID   string   `json:"in_reply_to_id,omitempty"`
-	MediaIDs      []string `json:"media_ids,omitempty"`
-	Sensitive     bool     `json:"sensitive,omitempty"`
-	SpoilerText   string   `json:"spoiler_text,omitempty"`
-	Visibility    string   `json:"visibility"`
-	ScheduledAt   string   `json:"scheduled_at,omitempty"`
-	ApplicationID string   `json:"application_id"`
+	Text          string            `json:"text"`
+	InReplyToID   string            `json:"in_reply_to_id,omitempty"`
+	MediaIDs      []string          `json:"media_ids,omitempty"`
+	Sensitive     bool              `json:"sensitive,omitempty"`
+	SpoilerText   string            `json:"spoiler_text,omitempty"`
+	Visibility    string            `json:"visibility"`
+	ScheduledAt   string            `json:"scheduled_at,omitempty"`
+	ApplicationID string            `json:"application_id"`
+	Language      string            `json:"language,omitempty"`
+	Poll          *StatusParamsPoll `json:"poll,omitempty"`
+}
+
+// StatusParamsPoll represents the poll
+// parameters of a scheduled status.
+//
+// swagger:model statusParamsPoll
+type StatusParamsPoll struct {
+	Options    []string `json:"options"`
+	ExpiresIn  int      `json:"expires_in"`
+	Multiple   bool     `json:"multiple"`
+	HideTotals bool     `json:"hide_totals"`
+}
+
+// ScheduledStatusUpdateRequest models a
+// request to reschedule a scheduled status.
+//
+// swagger:ignore
+type ScheduledStatusUpdateRequest struct {
+	// ISO 8601 Datetime at which to publish the status.
+	// Must be at least 5 minutes in the future.
+	ScheduledAt string `form:"scheduled_at" json:"scheduled_at" xml:"scheduled_at"`
 }
@@ -27,6 +27,7 @@ type SearchRequest struct {
 	QueryType         string
 	Resolve           bool
 	Following         bool
+	AccountID         string
 	ExcludeUnreviewed bool
 	APIv1             bool // Set to 'true' if using version 1 of the search API.
 }
@@ -36,6 +36,29 @@ type Source struct {
 	Note string `json:"note"`
 	// Metadata about the account.
 	Fields []Field `json:"fields"`
+	// Post a `new_from` notification when an account
+	// you follow posts for the first time after being
+	// inactive for at least this many days. 0 = disabled.
+	NotifyNewFromDays int `json:"notify_new_from_days"`
+	// Email new mentions and direct messages
+	// if they arrive after you haven't been
+	// active for this many hours. 0 = disabled.
+	EmailWhenAwayHours int `json:"email_when_away_hours"`
+	// Automatically approve follow requests
+	// from accounts you already follow.
+	AutoApproveFollowed bool `json:"auto_approve_followed"`
+	// Automatically approve follow requests
+	// from accounts on this instance.
+	AutoApproveLocal bool `json:"auto_approve_local"`
+	// Automatically approve follow requests from
+	// accounts known to this instance for at least
+	// this many days (for remote accounts, counted
+	// from when they were first seen). 0 = disabled.
+	AutoApproveMinAgeDays int `json:"auto_approve_min_age_days"`
+	// Keep a log of accounts following
+	// and unfollowing you, viewable at
+	// `/api/v1/gotosocial/follower_events`.
+	RecordFollowerEvents bool `json:"record_follower_events"`
 	// The number of pending follow requests.
 	FollowRequestsCount int `json:"follow_requests_count"`
 	// This account is aliased to / also known as accounts at the
@@ -43,4 +66,13 @@ type Source struct {
 	//
 	// Omitted from json if empty / not set.
 	AlsoKnownAsURIs []string `json:"also_known_as_uris,omitempty"`
+	// Aliases in AlsoKnownAsURIs, in the same order,
+	// along with whether each alias has been verified.
+	//
+	// Omitted from json if empty / not set.
+	Aliases []AccountAlias `json:"aliases,omitempty"`
+	// Domains of websites which may credit this
+	// account as author of their content, eg.,
+	// using the fediverse:creator meta tag.
+	AttributionDomains []string `json:"attribution_domains"`
 }
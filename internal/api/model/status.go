@@ -29,6 +29,11 @@ type Status struct {
 	// The date when this status was created (ISO 8601 Datetime).
 	// example: 2021-07-30T09:20:25+00:00
 	CreatedAt string `json:"created_at"`
+	// The date when this status was last edited (ISO 8601 Datetime).
+	// Will be null if the status hasn't been edited.
+	// example: 2021-07-30T09:20:25+00:00
+	// nullable: true
+	EditedAt *string `json:"edited_at"`
 	// ID of the status being replied to.
 	// example: 01FBVD42CQ3ZEEVMW180SBX03B
 	// nullable: true
@@ -70,20 +75,38 @@ type Status struct {
 	Muted bool `json:"muted"`
 	// This status has been bookmarked by the account viewing it.
 	Bookmarked bool `json:"bookmarked"`
+	// The account viewing this status has chosen to always show content
+	// behind content warnings for its author or its thread.
+	ContentRevealed bool `json:"content_revealed"`
 	// This status has been pinned by the account viewing it (only relevant for your own statuses).
 	Pinned bool `json:"pinned"`
+	// Emoji reactions to this status, summarized per emoji, in order of first use.
+	// Omitted if the status has no reactions.
+	EmojiReactions []EmojiReaction `json:"emoji_reactions,omitempty"`
 	// The content of this status. Should be HTML, but might also be plaintext in some cases.
 	// example: <p>Hey this is a status!</p>
 	Content string `json:"content"`
 	// The status that this status reblogs/boosts.
 	// nullable: true
 	Reblog *StatusReblogged `json:"reblog"`
+	// The status that this status quotes, if visible to the account viewing it.
+	// nullable: true
+	Quote *StatusQuoted `json:"quote"`
+	// Audiences permitted to reply to, boost, and favourite this status.
+	InteractionPolicy *InteractionPolicy `json:"interaction_policy"`
 	// The application used to post this status, if visible.
 	Application *Application `json:"application,omitempty"`
 	// The account that authored this status.
 	Account *Account `json:"account"`
 	// Media that is attached to this status.
 	MediaAttachments []*Attachment `json:"media_attachments"`
+	// Media that is attached to this status, but which can't be shown,
+	// either because this instance couldn't process it (type `unknown`
+	// with a `remote_url`), or because it has expired (type `unknown`
+	// without a `remote_url`). These are left out of media_attachments,
+	// so that clients can show placeholders for them in their own way.
+	// Omitted if there are none.
+	UnknownMediaAttachments []*Attachment `json:"unknown_media_attachments,omitempty"`
 	// Mentions of users within the status content.
 	Mentions []Mention `json:"mentions"`
 	// Hashtags used within the status content.
@@ -124,6 +147,20 @@ type Status struct {
 	//
 	// swagger:ignore
 	Local bool `json:"-"`
+
+	// Number of words in the content
+	// warning and content of the status.
+	// Always 0 for non-web statuses.
+	//
+	// swagger:ignore
+	WordCount int `json:"-"`
+
+	// Estimated reading time of the status in
+	// minutes, only set for long statuses.
+	// Always 0 for non-web statuses.
+	//
+	// swagger:ignore
+	ReadingTime int `json:"-"`
 }
 
 /*
@@ -163,6 +200,13 @@ type StatusReblogged struct {
 	*Status
 }
 
+// StatusQuoted represents a quoted status.
+//
+// swagger:model statusQuoted
+type StatusQuoted struct {
+	*Status
+}
+
 // StatusCreateRequest models status creation parameters.
 //
 // swagger:ignore
@@ -178,6 +222,8 @@ type StatusCreateRequest struct {
 	Poll *PollRequest `form:"poll" json:"poll" xml:"poll"`
 	// ID of the status being replied to, if status is a reply.
 	InReplyToID string `form:"in_reply_to_id" json:"in_reply_to_id" xml:"in_reply_to_id"`
+	// ID of the status being quoted, if status is a quote post.
+	QuoteID string `form:"quote_id" json:"quote_id" xml:"quote_id"`
 	// Status and attached media should be marked as sensitive.
 	Sensitive bool `form:"sensitive" json:"sensitive" xml:"sensitive"`
 	// Text to be shown as a warning or subject before the actual content.
@@ -195,6 +241,27 @@ type StatusCreateRequest struct {
 	ContentType StatusContentType `form:"content_type" json:"content_type" xml:"content_type"`
 }
 
+// StatusEditRequest models status edit parameters.
+//
+// swagger:ignore
+type StatusEditRequest struct {
+	// Text content of the status.
+	// If media_ids is provided, this becomes optional.
+	Status string `form:"status" json:"status" xml:"status"`
+	// Array of Attachment ids to be attached as media.
+	// Media already attached to the status can be kept by including their ids.
+	MediaIDs []string `form:"media_ids[]" json:"media_ids" xml:"media_ids"`
+	// Status and attached media should be marked as sensitive.
+	Sensitive bool `form:"sensitive" json:"sensitive" xml:"sensitive"`
+	// Text to be shown as a warning or subject before the actual content.
+	// Statuses are generally collapsed behind this field.
+	SpoilerText string `form:"spoiler_text" json:"spoiler_text" xml:"spoiler_text"`
+	// ISO 639 language code for this status.
+	Language string `form:"language" json:"language" xml:"language"`
+	// Content type to use when parsing this status.
+	ContentType StatusContentType `form:"content_type" json:"content_type" xml:"content_type"`
+}
+
 // Visibility models the visibility of a status.
 //
 // swagger:enum statusVisibility
@@ -236,8 +303,59 @@ type AdvancedVisibilityFlagsForm struct {
 	Replyable *bool `form:"replyable" json:"replyable" xml:"replyable"`
 	// This status can be liked/faved.
 	Likeable *bool `form:"likeable" json:"likeable" xml:"likeable"`
+	// Audiences permitted to interact with this status.
+	InteractionPolicy *InteractionPolicyRequest `form:"interaction_policy" json:"interaction_policy" xml:"interaction_policy"`
+}
+
+// InteractionPolicyRequest models the audiences
+// permitted to interact with a new status.
+//
+// swagger:ignore
+type InteractionPolicyRequest struct {
+	// Audiences permitted to reply to the status.
+	CanReply []PolicyValue `form:"interaction_policy[can_reply][]" json:"can_reply" xml:"can_reply"`
+	// Audiences permitted to boost the status.
+	CanAnnounce []PolicyValue `form:"interaction_policy[can_announce][]" json:"can_announce" xml:"can_announce"`
+	// Audiences permitted to favourite the status.
+	CanLike []PolicyValue `form:"interaction_policy[can_like][]" json:"can_like" xml:"can_like"`
+}
+
+// InteractionPolicy models the audiences permitted to interact with a
+// status in various ways. The status author may always interact with it.
+//
+// swagger:model interactionPolicy
+type InteractionPolicy struct {
+	// Audiences permitted to reply to the status.
+	// example: ["public"]
+	CanReply []PolicyValue `json:"can_reply"`
+	// Audiences permitted to boost the status.
+	// example: ["followers","mentioned"]
+	CanAnnounce []PolicyValue `json:"can_announce"`
+	// Audiences permitted to favourite the status.
+	// example: ["author"]
+	CanLike []PolicyValue `json:"can_like"`
 }
 
+// PolicyValue is an audience permitted
+// by an interaction policy.
+//
+// swagger:enum policyValue
+// swagger:type string
+type PolicyValue string
+
+const (
+	// PolicyValuePublic means anyone who can see the status.
+	PolicyValuePublic PolicyValue = "public"
+	// PolicyValueFollowers means accounts following the status author.
+	PolicyValueFollowers PolicyValue = "followers"
+	// PolicyValueFollowing means accounts followed by the status author.
+	PolicyValueFollowing PolicyValue = "following"
+	// PolicyValueMentioned means accounts mentioned in the status.
+	PolicyValueMentioned PolicyValue = "mentioned"
+	// PolicyValueAuthor means the status author only.
+	PolicyValueAuthor PolicyValue = "author"
+)
+
 // StatusContentType is the content type with which to parse the submitted status.
 // Can be either text/plain or text/markdown. Empty will default to text/plain.
 //
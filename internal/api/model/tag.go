@@ -27,8 +27,28 @@ type Tag struct {
 	// Web link to the hashtag.
 	// example: https://example.org/tags/helloworld
 	URL string `json:"url"`
-	// History of this hashtag's usage.
-	// Currently just a stub, if provided will always be an empty array.
-	// example: []
-	History *[]any `json:"history,omitempty"`
+	// Daily usage history of this hashtag by local statuses,
+	// for the last week, newest first. Only provided where
+	// the Mastodon API does, eg., in search results and trends.
+	History *[]TagHistory `json:"history,omitempty"`
+	// Whether the requesting account follows this hashtag.
+	// Only provided when following or unfollowing a hashtag,
+	// and when listing followed hashtags.
+	// example: true
+	Following *bool `json:"following,omitempty"`
+}
+
+// TagHistory represents usage of a hashtag on one day.
+//
+// swagger:model tagHistory
+type TagHistory struct {
+	// UNIX timestamp of the start of the (UTC) day.
+	// example: 1574553600
+	Day string `json:"day"`
+	// Number of statuses using the hashtag on this day.
+	// example: 200
+	Uses string `json:"uses"`
+	// Number of accounts using the hashtag on this day.
+	// example: 31
+	Accounts string `json:"accounts"`
 }
@@ -0,0 +1,50 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// Terms models the current version of the instance terms,
+// and whether the requesting user has accepted that version.
+//
+// swagger:model terms
+type Terms struct {
+	// The ID of this version of the terms.
+	// example: 01FC30T7X4TNCZK0TH90QYF3M4
+	ID string `json:"id"`
+	// When this version of the terms took effect (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// The terms themselves.
+	// Should be HTML formatted.
+	// example: <p>Be nice to each other.</p>
+	Content string `json:"content"`
+	// Requesting user has accepted this version of the terms.
+	Accepted bool `json:"accepted"`
+	// When the requesting user last accepted the terms (ISO 8601 Datetime).
+	// If the user never accepted any version of the terms, this will be null.
+	// example: 2021-07-30T09:20:25+00:00
+	AcceptedAt *string `json:"accepted_at"`
+}
+
+// TermsAcceptRequest models a request to accept a version of the instance terms.
+//
+// swagger:ignore
+type TermsAcceptRequest struct {
+	// ID of the version of the terms being accepted.
+	// Must be the ID of the current version.
+	ID string `form:"id" json:"id" xml:"id"`
+}
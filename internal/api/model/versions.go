@@ -0,0 +1,66 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// APIVersions describes which versions of the client API
+// this instance supports, which GoToSocial-specific extensions
+// it offers, and which endpoints are deprecated, so that
+// clients can feature-detect without parsing version strings.
+//
+// swagger:model apiVersions
+type APIVersions struct {
+	// Version of the GoToSocial software running on this instance.
+	// example: 0.16.0+git-f16a7fd
+	Version string `json:"version"`
+	// Level of the Mastodon API supported by this instance, in the
+	// same format as Mastodon's `api_versions.mastodon` instance field.
+	// example: 1
+	Mastodon int `json:"mastodon"`
+	// Versioned API path prefixes served by this instance.
+	// example: ["v1","v2"]
+	Versions []string `json:"versions"`
+	// GoToSocial-specific API extensions offered by this instance.
+	// example: ["oembed","problem_json","terms_acceptance"]
+	Extensions []string `json:"extensions"`
+	// Endpoints which still work, but are deprecated and may be removed.
+	Deprecated []APIDeprecation `json:"deprecated"`
+}
+
+// APIDeprecation describes one deprecated client API endpoint.
+// Requests to the endpoint are served with `Deprecation` and,
+// if set, `Sunset` and `Link: <successor>; rel="successor-version"`
+// response headers.
+//
+// swagger:model apiDeprecation
+type APIDeprecation struct {
+	// HTTP method of the deprecated endpoint.
+	// example: GET
+	Method string `json:"method"`
+	// Path of the deprecated endpoint.
+	// example: /api/v1/instance
+	Path string `json:"path"`
+	// When the endpoint was deprecated (ISO 8601 Datetime).
+	// example: 2022-11-14T00:00:00.000Z
+	DeprecatedAt string `json:"deprecated_at"`
+	// When the endpoint will be removed (ISO 8601 Datetime), if planned.
+	// example: 2025-01-01T00:00:00.000Z
+	Sunset *string `json:"sunset"`
+	// Path of the endpoint to use instead, if any.
+	// example: /api/v2/instance
+	Successor string `json:"successor,omitempty"`
+}
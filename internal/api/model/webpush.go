@@ -0,0 +1,134 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// WebPushSubscription represents the Web Push subscription
+// of the OAuth token used to access the API.
+//
+// swagger:model webPushSubscription
+type WebPushSubscription struct {
+	// The id of the push subscription in the database.
+	ID string `json:"id"`
+	// Where push alerts will be sent to.
+	Endpoint string `json:"endpoint"`
+	// Which alerts should be delivered to the endpoint.
+	Alerts WebPushSubscriptionAlerts `json:"alerts"`
+	// The streaming server's VAPID key.
+	ServerKey string `json:"server_key"`
+	// From whom notifications should be delivered.
+	// 	all = Deliver notifications from anyone.
+	// 	followed = Deliver notifications from accounts the user follows.
+	// 	follower = Deliver notifications from accounts following the user.
+	// 	none = Deliver no notifications.
+	Policy string `json:"policy"`
+}
+
+// WebPushSubscriptionAlerts represents which alerts
+// should be delivered through a Web Push subscription.
+//
+// swagger:model webPushSubscriptionAlerts
+type WebPushSubscriptionAlerts struct {
+	// Receive a push notification when someone has followed you?
+	Follow bool `json:"follow"`
+	// Receive a push notification when someone has requested to follow you?
+	FollowRequest bool `json:"follow_request"`
+	// Receive a push notification when a status you created has been favourited by someone else?
+	Favourite bool `json:"favourite"`
+	// Receive a push notification when someone else has mentioned you in a status?
+	Mention bool `json:"mention"`
+	// Receive a push notification when a status you created has been boosted by someone else?
+	Reblog bool `json:"reblog"`
+	// Receive a push notification when a poll you voted in or created has ended?
+	Poll bool `json:"poll"`
+	// Receive a push notification when a subscribed account posts a status?
+	Status bool `json:"status"`
+	// Receive a push notification when a new user has signed up?
+	AdminSignup bool `json:"admin.sign_up"`
+}
+
+// WebPushSubscriptionCreateRequest models a
+// request to create a Web Push subscription.
+//
+// swagger:ignore
+type WebPushSubscriptionCreateRequest struct {
+	// Details of the subscription at the push service.
+	Subscription *WebPushSubscriptionRequestSubscription `form:"subscription" json:"subscription" xml:"subscription"`
+	// Alerts and policy of the subscription.
+	Data *WebPushSubscriptionRequestData `form:"data" json:"data" xml:"data"`
+}
+
+// WebPushSubscriptionUpdateRequest models a
+// request to update a Web Push subscription.
+//
+// swagger:ignore
+type WebPushSubscriptionUpdateRequest struct {
+	// Alerts and policy of the subscription.
+	Data *WebPushSubscriptionRequestData `form:"data" json:"data" xml:"data"`
+}
+
+// WebPushSubscriptionRequestSubscription models the
+// details of a subscription at a push service.
+//
+// swagger:ignore
+type WebPushSubscriptionRequestSubscription struct {
+	// The endpoint URL that is called when a notification event occurs.
+	Endpoint string `form:"subscription[endpoint]" json:"endpoint" xml:"endpoint"`
+	// Keys used to encrypt notifications.
+	Keys WebPushSubscriptionRequestKeys `form:"subscription[keys]" json:"keys" xml:"keys"`
+}
+
+// WebPushSubscriptionRequestKeys models the keys used
+// to encrypt notifications for a Web Push subscription.
+//
+// swagger:ignore
+type WebPushSubscriptionRequestKeys struct {
+	// User agent public key. Base64url encoded
+	// string of a public key from an ECDH
+	// keypair using the prime256v1 curve.
+	P256dh string `form:"subscription[keys][p256dh]" json:"p256dh" xml:"p256dh"`
+	// Auth secret. Base64url encoded string of 16 bytes of random data.
+	Auth string `form:"subscription[keys][auth]" json:"auth" xml:"auth"`
+}
+
+// WebPushSubscriptionRequestData models the
+// alerts and policy of a Web Push subscription.
+//
+// swagger:ignore
+type WebPushSubscriptionRequestData struct {
+	// Which alerts should be delivered.
+	Alerts *WebPushSubscriptionRequestAlerts `form:"data[alerts]" json:"alerts" xml:"alerts"`
+	// From whom notifications should be delivered.
+	Policy *string `form:"data[policy]" json:"policy" xml:"policy"`
+}
+
+// WebPushSubscriptionRequestAlerts models which alerts
+// should be delivered through a Web Push subscription.
+// Alerts not provided are left as they are, or disabled
+// for new subscriptions.
+//
+// swagger:ignore
+type WebPushSubscriptionRequestAlerts struct {
+	Follow        *bool `form:"data[alerts][follow]" json:"follow" xml:"follow"`
+	FollowRequest *bool `form:"data[alerts][follow_request]" json:"follow_request" xml:"follow_request"`
+	Favourite     *bool `form:"data[alerts][favourite]" json:"favourite" xml:"favourite"`
+	Mention       *bool `form:"data[alerts][mention]" json:"mention" xml:"mention"`
+	Reblog        *bool `form:"data[alerts][reblog]" json:"reblog" xml:"reblog"`
+	Poll          *bool `form:"data[alerts][poll]" json:"poll" xml:"poll"`
+	Status        *bool `form:"data[alerts][status]" json:"status" xml:"status"`
+	AdminSignup   *bool `form:"data[alerts][admin.sign_up]" json:"admin.sign_up" xml:"admin.sign_up"`
+}
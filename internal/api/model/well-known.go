@@ -29,6 +29,11 @@ type WellKnownResponse struct {
 	Subject string   `json:"subject,omitempty"`
 	Aliases []string `json:"aliases,omitempty"`
 	Links   []Link   `json:"links,omitempty"`
+
+	// Properties contains additional
+	// information about the subject,
+	// eg., whether the account has moved.
+	Properties map[string]string `json:"properties,omitempty"`
 }
 
 // Link represents one 'link' in a slice of links returned from a lookup request.
@@ -0,0 +1,141 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// scopeRule describes the scopes needed for requests
+// to routes with paths starting with the given prefix:
+// read is needed for GET (and HEAD) requests, and write
+// for all others. An empty scope means none is needed.
+type scopeRule struct {
+	prefix string
+	read   oauth.Scope
+	write  oauth.Scope
+}
+
+// clientScopeRules contains the scope rules for the client API,
+// in order of precedence: the first rule with a prefix matching
+// the route path applies, so more specific rules come first.
+var clientScopeRules = []scopeRule{
+	// Public or pre-login endpoints, and
+	// endpoints that only need any token.
+	{prefix: "/api/v1/apps"},
+	{prefix: "/api/oembed"},
+	{prefix: "/api/versions"},
+	{prefix: "/api/v1/instance", write: oauth.ScopeAdminWrite},
+	{prefix: "/api/v2/instance", write: oauth.ScopeAdminWrite},
+	{prefix: "/api/v1/announcements/:id/dismiss", write: oauth.ScopeWriteAccounts},
+	{prefix: "/api/v1/announcements/:id/reactions", write: oauth.ScopeWriteFavourites},
+	{prefix: "/api/v1/announcements"},
+	{prefix: "/api/v1/trends"},
+
+	// Admin.
+	{prefix: "/api/v1/admin/accounts", read: oauth.ScopeAdminReadAccounts, write: oauth.ScopeAdminWriteAccounts},
+	{prefix: "/api/v2/admin/accounts", read: oauth.ScopeAdminReadAccounts, write: oauth.ScopeAdminWriteAccounts},
+	{prefix: "/api/v1/admin/domain_allows", read: oauth.ScopeAdminReadDomainAllows, write: oauth.ScopeAdminWriteDomainAllows},
+	{prefix: "/api/v1/admin/domain_blocks", read: oauth.ScopeAdminReadDomainBlocks, write: oauth.ScopeAdminWriteDomainBlocks},
+	{prefix: "/api/v1/admin/reports", read: oauth.ScopeAdminReadReports, write: oauth.ScopeAdminWriteReports},
+	{prefix: "/api/v1/admin", read: oauth.ScopeAdminRead, write: oauth.ScopeAdminWrite},
+	{prefix: "/api/v1/gotosocial/admin", read: oauth.ScopeAdminRead, write: oauth.ScopeAdminWrite},
+
+	// Accounts.
+	{prefix: "/api/v1/accounts/:id/block", write: oauth.ScopeWriteBlocks},
+	{prefix: "/api/v1/accounts/:id/unblock", write: oauth.ScopeWriteBlocks},
+	{prefix: "/api/v1/accounts/:id/follow", write: oauth.ScopeWriteFollows},
+	{prefix: "/api/v1/accounts/:id/unfollow", write: oauth.ScopeWriteFollows},
+	{prefix: "/api/v1/accounts/:id/mute", write: oauth.ScopeWriteMutes},
+	{prefix: "/api/v1/accounts/:id/unmute", write: oauth.ScopeWriteMutes},
+	{prefix: "/api/v1/accounts/:id/lists", read: oauth.ScopeReadLists},
+	{prefix: "/api/v1/accounts/relationships", read: oauth.ScopeReadFollows},
+	{prefix: "/api/v1/accounts", read: oauth.ScopeReadAccounts, write: oauth.ScopeWriteAccounts},
+	{prefix: "/api/v1/exports", read: oauth.ScopeReadAccounts, write: oauth.ScopeWriteAccounts},
+	{prefix: "/api/v1/import", read: oauth.ScopeReadAccounts, write: oauth.ScopeWriteAccounts},
+	{prefix: "/api/v1/content_reveals", read: oauth.ScopeReadAccounts, write: oauth.ScopeWriteAccounts},
+	{prefix: "/api/v1/featured_tags", read: oauth.ScopeReadAccounts, write: oauth.ScopeWriteAccounts},
+	{prefix: "/api/v1/preferences", read: oauth.ScopeReadAccounts, write: oauth.ScopeWriteAccounts},
+	{prefix: "/api/v1/user/filters", read: oauth.ScopeReadFilters, write: oauth.ScopeWriteFilters},
+	{prefix: "/api/v1/user", read: oauth.ScopeReadAccounts, write: oauth.ScopeWriteAccounts},
+
+	// Statuses.
+	{prefix: "/api/v1/statuses/:id/bookmark", write: oauth.ScopeWriteBookmarks},
+	{prefix: "/api/v1/statuses/:id/unbookmark", write: oauth.ScopeWriteBookmarks},
+	{prefix: "/api/v1/statuses/:id/favourite", write: oauth.ScopeWriteFavourites},
+	{prefix: "/api/v1/statuses/:id/unfavourite", write: oauth.ScopeWriteFavourites},
+	{prefix: "/api/v1/statuses/:id/mute", write: oauth.ScopeWriteMutes},
+	{prefix: "/api/v1/statuses/:id/unmute", write: oauth.ScopeWriteMutes},
+	{prefix: "/api/v1/statuses/:id/pin", write: oauth.ScopeWriteAccounts},
+	{prefix: "/api/v1/statuses/:id/unpin", write: oauth.ScopeWriteAccounts},
+	{prefix: "/api/v1/statuses", read: oauth.ScopeReadStatuses, write: oauth.ScopeWriteStatuses},
+	{prefix: "/api/v1/gotosocial/statuses", read: oauth.ScopeReadStatuses, write: oauth.ScopeWriteStatuses},
+	{prefix: "/api/v1/scheduled_statuses", read: oauth.ScopeReadStatuses, write: oauth.ScopeWriteStatuses},
+	{prefix: "/api/v1/custom_emojis", read: oauth.ScopeReadStatuses},
+	{prefix: "/api/v1/conversations", read: oauth.ScopeReadStatuses, write: oauth.ScopeWriteConversations},
+	{prefix: "/api/v1/markers", read: oauth.ScopeReadStatuses, write: oauth.ScopeWriteStatuses},
+	{prefix: "/api/:api_version/polls", read: oauth.ScopeReadStatuses, write: oauth.ScopeWriteStatuses},
+	{prefix: "/api/:api_version/media", read: oauth.ScopeWriteMedia, write: oauth.ScopeWriteMedia},
+	{prefix: "/api/v1/streaming", read: oauth.ScopeReadStatuses},
+	{prefix: "/api/v1/timelines/list", read: oauth.ScopeReadLists},
+	{prefix: "/api/v1/timelines", read: oauth.ScopeReadStatuses},
+
+	// Everything else.
+	{prefix: "/api/v1/blocks", read: oauth.ScopeReadBlocks, write: oauth.ScopeWriteBlocks},
+	{prefix: "/api/v1/bookmarks", read: oauth.ScopeReadBookmarks, write: oauth.ScopeWriteBookmarks},
+	{prefix: "/api/v1/favourites", read: oauth.ScopeReadFavourites, write: oauth.ScopeWriteFavourites},
+	{prefix: "/api/v1/filters", read: oauth.ScopeReadFilters, write: oauth.ScopeWriteFilters},
+	{prefix: "/api/v1/follow_requests", read: oauth.ScopeReadFollows, write: oauth.ScopeWriteFollows},
+	{prefix: "/api/v1/interaction_requests", read: oauth.ScopeReadStatuses, write: oauth.ScopeWriteStatuses},
+	{prefix: "/api/v1/followed_tags", read: oauth.ScopeReadFollows, write: oauth.ScopeWriteFollows},
+	{prefix: "/api/v1/tags/:tag_name/follow", write: oauth.ScopeWriteFollows},
+	{prefix: "/api/v1/tags/:tag_name/unfollow", write: oauth.ScopeWriteFollows},
+	{prefix: "/api/v1/lists", read: oauth.ScopeReadLists, write: oauth.ScopeWriteLists},
+	{prefix: "/api/v1/mutes", read: oauth.ScopeReadMutes, write: oauth.ScopeWriteMutes},
+	{prefix: "/api/v1/notifications", read: oauth.ScopeReadNotifications, write: oauth.ScopeWriteNotifications},
+	{prefix: "/api/v2/notifications", read: oauth.ScopeReadNotifications, write: oauth.ScopeWriteNotifications},
+	{prefix: "/api/v1/push", read: oauth.ScopePush, write: oauth.ScopePush},
+	{prefix: "/api/v1/reports", read: oauth.ScopeReadReports, write: oauth.ScopeWriteReports},
+	{prefix: "/api/:api_version/search", read: oauth.ScopeReadSearch},
+}
+
+// clientRequiredScope returns the scope needed for a client API
+// request with the given method, to the route with the given path.
+// Routes not covered by any rule need full `read` or `write`.
+func clientRequiredScope(method string, path string) oauth.Scope {
+	read := method == http.MethodGet || method == http.MethodHead
+
+	for _, rule := range clientScopeRules {
+		if path != rule.prefix && !strings.HasPrefix(path, rule.prefix+"/") {
+			continue
+		}
+
+		if read {
+			return rule.read
+		}
+		return rule.write
+	}
+
+	if read {
+		return oauth.ScopeRead
+	}
+	return oauth.ScopeWrite
+}
@@ -0,0 +1,78 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+func TestClientRequiredScope(t *testing.T) {
+	for _, test := range []struct {
+		method string
+		path   string
+		expect oauth.Scope
+	}{
+		{http.MethodPost, "/api/v1/apps", ""},
+		{http.MethodGet, "/api/v1/instance", ""},
+		{http.MethodPatch, "/api/v1/instance", oauth.ScopeAdminWrite},
+		{http.MethodGet, "/api/v1/accounts/verify_credentials", oauth.ScopeReadAccounts},
+		{http.MethodPost, "/api/v1/accounts/:id/follow", oauth.ScopeWriteFollows},
+		{http.MethodPost, "/api/v1/accounts/:id/block", oauth.ScopeWriteBlocks},
+		{http.MethodPost, "/api/v1/accounts/:id/mute", oauth.ScopeWriteMutes},
+		{http.MethodPost, "/api/v1/accounts/:id/unmute", oauth.ScopeWriteMutes},
+		{http.MethodGet, "/api/v1/accounts/:id/followers", oauth.ScopeReadAccounts},
+		{http.MethodGet, "/api/v1/statuses/:id", oauth.ScopeReadStatuses},
+		{http.MethodPost, "/api/v1/statuses/:id/favourite", oauth.ScopeWriteFavourites},
+		{http.MethodPost, "/api/v1/statuses/:id/reblog", oauth.ScopeWriteStatuses},
+		{http.MethodGet, "/api/v1/timelines/list/:id", oauth.ScopeReadLists},
+		{http.MethodGet, "/api/v1/timelines/home", oauth.ScopeReadStatuses},
+		{http.MethodPost, "/api/v1/user/filters/import", oauth.ScopeWriteFilters},
+		{http.MethodPost, "/api/v1/user/password_change", oauth.ScopeWriteAccounts},
+		{http.MethodPost, "/api/v1/exports", oauth.ScopeWriteAccounts},
+		{http.MethodGet, "/api/v1/exports/:id/download", oauth.ScopeReadAccounts},
+		{http.MethodDelete, "/api/v1/content_reveals/:id", oauth.ScopeWriteAccounts},
+		{http.MethodPost, "/api/v1/import", oauth.ScopeWriteAccounts},
+		{http.MethodGet, "/api/v1/admin/reports/:id", oauth.ScopeAdminReadReports},
+		{http.MethodPost, "/api/v1/admin/media_cleanup", oauth.ScopeAdminWrite},
+		{http.MethodGet, "/api/v2/admin/accounts", oauth.ScopeAdminReadAccounts},
+		{http.MethodGet, "/api/v1/gotosocial/admin/domain_permission_subscriptions", oauth.ScopeAdminRead},
+		{http.MethodGet, "/api/v1/gotosocial/statuses/:id", oauth.ScopeReadStatuses},
+		{http.MethodDelete, "/api/v1/scheduled_statuses/:id", oauth.ScopeWriteStatuses},
+		{http.MethodPost, "/api/v2/notifications/:group_key/dismiss", oauth.ScopeWriteNotifications},
+		{http.MethodPost, "/api/v1/push/subscription", oauth.ScopePush},
+		{http.MethodPost, "/api/:api_version/media", oauth.ScopeWriteMedia},
+		{http.MethodGet, "/api/:api_version/search", oauth.ScopeReadSearch},
+		{http.MethodGet, "/api/v1/trends/tags", ""},
+		{http.MethodGet, "/api/v1/interaction_requests", oauth.ScopeReadStatuses},
+		{http.MethodGet, "/api/v1/interaction_requests/:id", oauth.ScopeReadStatuses},
+		{http.MethodPost, "/api/v1/interaction_requests/:id/authorize", oauth.ScopeWriteStatuses},
+		{http.MethodPost, "/api/v1/interaction_requests/:id/reject", oauth.ScopeWriteStatuses},
+		{http.MethodGet, "/api/v1/followed_tags", oauth.ScopeReadFollows},
+		{http.MethodPost, "/api/v1/tags/:tag_name/follow", oauth.ScopeWriteFollows},
+		{http.MethodPost, "/api/v1/tags/:tag_name/unfollow", oauth.ScopeWriteFollows},
+		{http.MethodGet, "/api/v1/unknown", oauth.ScopeRead},
+		{http.MethodDelete, "/api/v1/unknown", oauth.ScopeWrite},
+	} {
+		if scope := clientRequiredScope(test.method, test.path); scope != test.expect {
+			t.Errorf("%s %s: expected scope %q, got %q", test.method, test.path, test.expect, scope)
+		}
+	}
+}
@@ -34,7 +34,7 @@ import (
 
 // NotFoundHandler serves a 404 html page through the provided gin context,
 // if accept is 'text/html', or just returns a json error if 'accept' is empty
-// or application/json.
+// or application/json (or application/problem+json, see writeErrorJSON).
 //
 // When serving html, NotFoundHandler calls the provided InstanceGet function
 // to fetch the apimodel representation of the instance, for serving in the
@@ -55,9 +55,7 @@ func NotFoundHandler(c *gin.Context, instanceGet func(ctx context.Context) (*api
 			gtscontext.RequestID(ctx),
 		)
 	default:
-		JSON(c, http.StatusNotFound, map[string]string{
-			"error": errWithCode.Safe(),
-		})
+		writeErrorJSON(c, accept, http.StatusNotFound, errWithCode)
 	}
 }
 
@@ -80,10 +78,41 @@ func genericErrorHandler(c *gin.Context, instanceGet func(ctx context.Context) (
 			gtscontext.RequestID(ctx),
 		)
 	default:
-		JSON(c, errWithCode.Code(), map[string]string{
-			"error": errWithCode.Safe(),
+		writeErrorJSON(c, accept, errWithCode.Code(), errWithCode)
+	}
+}
+
+// writeErrorJSON writes the given error with code to the caller
+// as RFC 7807 problem details if accept is application/problem+json,
+// or as a Mastodon style {"error": "..."} object otherwise. In both
+// cases, the machine-readable error_code is included if the error
+// has a type set on it (see gtserror.Type()).
+func writeErrorJSON(c *gin.Context, accept string, code int, errWithCode gtserror.WithCode) {
+	errType := string(gtserror.Type(errWithCode))
+
+	if accept == AppProblemJSON {
+		JSONType(c, code, AppProblemJSON, &apimodel.Problem{
+			Type:      "about:blank",
+			Title:     http.StatusText(code),
+			Status:    code,
+			Detail:    errWithCode.Safe(),
+			Instance:  c.Request.URL.Path,
+			Error:     errWithCode.Safe(),
+			ErrorCode: errType,
+			RequestID: gtscontext.RequestID(c.Request.Context()),
 		})
+		return
+	}
+
+	obj := map[string]string{
+		"error": errWithCode.Safe(),
+	}
+
+	if errType != "" {
+		obj["error_code"] = errType
 	}
+
+	JSON(c, code, obj)
 }
 
 // ErrorHandler takes the provided gin context and errWithCode
@@ -141,8 +170,11 @@ func ErrorHandler(
 	// or if we should just use a json. Normally we would want to
 	// check for a returned error, but if an error occurs here we
 	// can just fall back to default behavior (serve json error).
-	// Prefer provided offers, fall back to JSON or HTML.
-	accept, _ := NegotiateAccept(c, append(offers, JSONOrHTMLAcceptHeaders...)...)
+	// Prefer provided offers, fall back to JSON or HTML,
+	// or problem+json if the caller specifically wants it.
+	offers = append(offers, JSONOrHTMLAcceptHeaders...)
+	offers = append(offers, AppProblemJSON)
+	accept, _ := NegotiateAccept(c, offers...)
 
 	if errWithCode.Code() == http.StatusNotFound {
 		// Use our special not found handler with useful status text.
@@ -152,6 +184,16 @@ func ErrorHandler(
 	}
 }
 
+// ErrorJSON is like ErrorHandler, but for use in middlewares
+// which reject requests before they reach a handler. It only
+// serves JSON or problem+json, doesn't set the error on the
+// gin context for logging, and aborts the handler chain.
+func ErrorJSON(c *gin.Context, errWithCode gtserror.WithCode) {
+	accept, _ := NegotiateAccept(c, AppJSON, AppProblemJSON)
+	writeErrorJSON(c, accept, errWithCode.Code(), errWithCode)
+	c.Abort()
+}
+
 // WebErrorHandler is like ErrorHandler, but will display HTML over JSON by default.
 func WebErrorHandler(c *gin.Context, errWithCode gtserror.WithCode, instanceGet func(ctx context.Context) (*apimodel.InstanceV1, gtserror.WithCode)) {
 	ErrorHandler(c, errWithCode, instanceGet, TextHTML, AppJSON)
@@ -0,0 +1,85 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package util_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+func TestErrorJSON(t *testing.T) {
+	err := gtserror.SetType(errors.New("rate limit reached"), gtserror.TypeRateLimited)
+	errWithCode := gtserror.NewErrorTooManyRequests(err, "rate limit reached")
+
+	tests := []struct {
+		accept      string
+		contentType string
+		body        string
+	}{
+		{
+			accept:      "",
+			contentType: apiutil.AppJSON,
+			body:        `{"error":"Too Many Requests: rate limit reached","error_code":"rate_limited"}`,
+		},
+		{
+			accept:      "application/json",
+			contentType: apiutil.AppJSON,
+			body:        `{"error":"Too Many Requests: rate limit reached","error_code":"rate_limited"}`,
+		},
+		{
+			accept:      "application/problem+json",
+			contentType: apiutil.AppProblemJSON,
+			body:        `{"type":"about:blank","title":"Too Many Requests","status":429,"detail":"Too Many Requests: rate limit reached","instance":"/api/v1/timelines/home","error":"Too Many Requests: rate limit reached","error_code":"rate_limited"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run("accept:"+tt.accept, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(recorder)
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/timelines/home", nil)
+			if tt.accept != "" {
+				c.Request.Header.Set("Accept", tt.accept)
+			}
+
+			apiutil.ErrorJSON(c, errWithCode)
+
+			if recorder.Code != http.StatusTooManyRequests {
+				t.Fatalf("expected code %d, got %d", http.StatusTooManyRequests, recorder.Code)
+			}
+
+			if ct := recorder.Header().Get("Content-Type"); ct != tt.contentType {
+				t.Fatalf("expected content-type '%s', got '%s'", tt.contentType, ct)
+			}
+
+			if body := recorder.Body.String(); body != tt.body {
+				t.Fatalf("expected body '%s', got '%s'", tt.body, body)
+			}
+
+			if !c.IsAborted() {
+				t.Fatal("expected handler chain to be aborted")
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package util
+
+import (
+	// nolint:gosec
+	"crypto/sha1"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// WriteResponseBytesETag is functionally similar to
+// WriteResponseBytes, except that for 200 OK responses
+// it also sets a strong ETag generated from data. If the
+// request's If-None-Match header already matches that ETag,
+// 304 Not Modified is written instead, without a body.
+func WriteResponseBytesETag(
+	rw http.ResponseWriter,
+	r *http.Request,
+	statusCode int,
+	contentType string,
+	data []byte,
+) {
+	if statusCode != http.StatusOK {
+		// Only tag successful
+		// full responses.
+		WriteResponseBytes(rw, r,
+			statusCode,
+			contentType,
+			data,
+		)
+		return
+	}
+
+	eTag := generateETag(data)
+	rw.Header().Set("ETag", eTag)
+
+	if eTagMatches(r.Header.Get("If-None-Match"), eTag) {
+		// Caller already has
+		// this representation.
+		rw.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	WriteResponseBytes(rw, r,
+		statusCode,
+		contentType,
+		data,
+	)
+}
+
+// EncodeJSONResponseETag is like EncodeJSONResponse,
+// but writes the encoded response via WriteResponseBytesETag.
+func EncodeJSONResponseETag(
+	rw http.ResponseWriter,
+	r *http.Request,
+	statusCode int,
+	contentType string,
+	data any,
+) {
+	encodeJSONResponse(rw, r,
+		statusCode,
+		contentType,
+		data,
+		WriteResponseBytesETag,
+	)
+}
+
+// EncodeXMLResponseETag is like EncodeXMLResponse,
+// but writes the encoded response via WriteResponseBytesETag.
+func EncodeXMLResponseETag(
+	rw http.ResponseWriter,
+	r *http.Request,
+	statusCode int,
+	contentType string,
+	data any,
+) {
+	encodeXMLResponse(rw, r,
+		statusCode,
+		contentType,
+		data,
+		WriteResponseBytesETag,
+	)
+}
+
+// generateETag generates a strong
+// (byte-for-byte) ETag from data.
+func generateETag(data []byte) string {
+	// nolint:gosec
+	sum := sha1.Sum(data)
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+// eTagMatches returns whether the given If-None-Match
+// header value matches eTag. As per RFC 9110, this uses
+// weak comparison, so a "W/" prefix is ignored.
+//
+// See: https://www.rfc-editor.org/rfc/rfc9110#field.if-none-match
+func eTagMatches(ifNoneMatch string, eTag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+
+		candidate = strings.TrimPrefix(candidate, "W/")
+		if candidate == eTag {
+			return true
+		}
+	}
+
+	return false
+}
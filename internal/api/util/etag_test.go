@@ -0,0 +1,88 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package util
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestETagMatches(t *testing.T) {
+	const eTag = `"abc123"`
+
+	for _, test := range []struct {
+		IfNoneMatch string
+		Expect      bool
+	}{
+		{IfNoneMatch: "", Expect: false},
+		{IfNoneMatch: `"abc123"`, Expect: true},
+		{IfNoneMatch: `W/"abc123"`, Expect: true},
+		{IfNoneMatch: `"def456", "abc123"`, Expect: true},
+		{IfNoneMatch: `"def456"`, Expect: false},
+		{IfNoneMatch: `abc123`, Expect: false},
+		{IfNoneMatch: `*`, Expect: true},
+	} {
+		if got := eTagMatches(test.IfNoneMatch, eTag); got != test.Expect {
+			t.Errorf("If-None-Match %q: expected %v, got %v", test.IfNoneMatch, test.Expect, got)
+		}
+	}
+}
+
+func TestWriteResponseBytesETag(t *testing.T) {
+	data := []byte(`{"hello":"world"}`)
+
+	// No If-None-Match, expect full response.
+	rw := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	WriteResponseBytesETag(rw, r, http.StatusOK, AppJSON, data)
+	if rw.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rw.Code)
+	}
+	eTag := rw.Header().Get("ETag")
+	if eTag == "" {
+		t.Fatal("expected ETag to be set")
+	}
+	if rw.Body.String() != string(data) {
+		t.Fatalf("unexpected body %q", rw.Body.String())
+	}
+
+	// Matching If-None-Match, expect 304 with no body.
+	rw = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("If-None-Match", eTag)
+	WriteResponseBytesETag(rw, r, http.StatusOK, AppJSON, data)
+	if rw.Code != http.StatusNotModified {
+		t.Fatalf("expected 304, got %d", rw.Code)
+	}
+	if rw.Body.Len() != 0 {
+		t.Fatalf("expected empty body, got %q", rw.Body.String())
+	}
+
+	// Non-200 responses are not tagged.
+	rw = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("If-None-Match", eTag)
+	WriteResponseBytesETag(rw, r, http.StatusNotFound, AppJSON, data)
+	if rw.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rw.Code)
+	}
+	if rw.Header().Get("ETag") != "" {
+		t.Fatal("expected no ETag on error response")
+	}
+}
@@ -0,0 +1,42 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package util
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+)
+
+// IdempotencyKeyHeader is the request header used by
+// clients to mark retries of the same request, see:
+// https://docs.joinmastodon.org/methods/statuses/#headers
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotencyContext returns the request context of
+// the given gin context, wrapped with the value of the
+// Idempotency-Key header (if set), so that processor
+// functions can deduplicate retries of the request.
+func IdempotencyContext(c *gin.Context) context.Context {
+	ctx := c.Request.Context()
+	if key := c.GetHeader(IdempotencyKeyHeader); key != "" {
+		ctx = gtscontext.SetIdempotencyKey(ctx, key)
+	}
+	return ctx
+}
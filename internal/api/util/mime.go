@@ -29,12 +29,15 @@ const (
 	AppActivityJSON   = `application/activity+json`
 	appActivityLDJSON = `application/ld+json` // without profile
 	AppActivityLDJSON = appActivityLDJSON + `; profile="https://www.w3.org/ns/activitystreams"`
-	AppJRDJSON        = `application/jrd+json` // https://www.rfc-editor.org/rfc/rfc7033#section-10.2
+	AppJRDJSON        = `application/jrd+json`     // https://www.rfc-editor.org/rfc/rfc7033#section-10.2
+	AppProblemJSON    = `application/problem+json` // https://www.rfc-editor.org/rfc/rfc7807#section-6.1
 	AppForm           = `application/x-www-form-urlencoded`
 	MultipartForm     = `multipart/form-data`
 	TextXML           = `text/xml`
 	TextHTML          = `text/html`
 	TextCSS           = `text/css`
+	TextPlain         = `text/plain`
+	TextGemini        = `text/gemini` // https://geminiprotocol.net/docs/gemtext-specification.gmi
 )
 
 // JSONContentType returns whether is application/json(;charset=utf-8)? content-type.
@@ -63,6 +63,17 @@ var HTMLOrActivityPubHeaders = []string{
 	AppActivityJSON,
 }
 
+// HTMLOrActivityPubOrTextHeaders is like HTMLOrActivityPubHeaders,
+// but also matches plaintext and gemtext, for URLs which can also
+// be rendered for text-based browsers and gemini proxies.
+var HTMLOrActivityPubOrTextHeaders = []string{
+	TextHTML,
+	AppActivityLDJSON,
+	AppActivityJSON,
+	TextGemini,
+	TextPlain,
+}
+
 // ActivityPubOrHTMLHeaders matches activitypub types first, then text/html.
 // This is useful for URLs that should serve ActivityPub by default, but
 // which a user might also go to in their browser sometimes.
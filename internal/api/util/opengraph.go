@@ -19,15 +19,16 @@ package util
 
 import (
 	"html"
+	"mime"
+	"path"
 	"strconv"
 	"strings"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
 )
 
-const maxOGDescriptionLength = 300
-
 // OGMeta represents supported OpenGraph Meta tags
 //
 // see eg https://ogp.me/
@@ -46,6 +47,12 @@ type OGMeta struct {
 	ImageHeight string // og:image:height
 	ImageAlt    string // og:image:alt
 
+	// video tags
+	Video       string // og:video
+	VideoType   string // og:video:type
+	VideoWidth  string // og:video:width
+	VideoHeight string // og:video:height
+
 	// article tags
 	ArticlePublisher     string // article:publisher
 	ArticleAuthor        string // article:author
@@ -54,6 +61,10 @@ type OGMeta struct {
 
 	// profile tags
 	ProfileUsername string // profile:username
+
+	// attribution / card tags
+	Creator     string // fediverse:creator
+	TwitterCard string // twitter:card
 }
 
 // OGBase returns an *ogMeta suitable for serving at
@@ -76,6 +87,8 @@ func OGBase(instance *apimodel.InstanceV1) *OGMeta {
 
 		Image:    instance.Thumbnail,
 		ImageAlt: instance.ThumbnailDescription,
+
+		TwitterCard: "summary",
 	}
 
 	return og
@@ -98,6 +111,7 @@ func (og *OGMeta) WithAccount(account *apimodel.Account) *OGMeta {
 	og.ImageAlt = "Avatar for " + account.Username
 
 	og.ProfileUsername = account.Username
+	og.Creator = "@" + account.Acct + "@" + og.SiteName
 
 	return og
 }
@@ -112,37 +126,81 @@ func (og *OGMeta) WithStatus(status *apimodel.Status) *OGMeta {
 		og.Locale = *status.Language
 	}
 	og.URL = status.URL
-	switch {
-	case status.SpoilerText != "":
+	if status.SpoilerText != "" {
+		// Don't reveal what
+		// the CW is hiding.
 		og.Description = ParseDescription("CW: " + status.SpoilerText)
-	case status.Text != "":
-		og.Description = ParseDescription(status.Text)
-	default:
-		og.Description = og.Title
+	} else {
+		og.Description = ParseDescription(status.Content)
 	}
 
-	if !status.Sensitive && len(status.MediaAttachments) > 0 {
-		a := status.MediaAttachments[0]
-
-		og.ImageWidth = strconv.Itoa(a.Meta.Small.Width)
-		og.ImageHeight = strconv.Itoa(a.Meta.Small.Height)
+	if og.Description == `content=""` {
+		// Nothing to describe
+		// (eg., media only).
+		og.Description = ParseDescription(og.Title)
+	}
 
-		if a.PreviewURL != nil {
-			og.Image = *a.PreviewURL
+	// Use the first attachment with a
+	// thumbnail as the preview image.
+	var attachment *apimodel.Attachment
+	for _, a := range status.MediaAttachments {
+		if a.PreviewURL != nil && a.Meta != nil {
+			attachment = a
+			break
 		}
+	}
 
-		if a.Description != nil {
-			og.ImageAlt = *a.Description
-		}
-	} else {
+	switch {
+	case attachment == nil:
+		// Nothing to show,
+		// fall back to avatar.
 		og.Image = status.Account.Avatar
 		og.ImageAlt = "Avatar for " + status.Account.Username
+
+	case status.Sensitive:
+		if attachment.Blurhash == nil {
+			// Can't blur this,
+			// fall back to avatar.
+			og.Image = status.Account.Avatar
+			og.ImageAlt = "Avatar for " + status.Account.Username
+			break
+		}
+
+		// Serve a blurred preview of the same size
+		// as the thumbnail, so as not to reveal it.
+		og.Image = status.URL + "/media/" + attachment.ID + "/preview"
+		og.ImageWidth = strconv.Itoa(attachment.Meta.Small.Width)
+		og.ImageHeight = strconv.Itoa(attachment.Meta.Small.Height)
+		og.ImageAlt = "Blurred preview of media marked as sensitive"
+		og.TwitterCard = "summary_large_image"
+
+	default:
+		og.Image = *attachment.PreviewURL
+		og.ImageWidth = strconv.Itoa(attachment.Meta.Small.Width)
+		og.ImageHeight = strconv.Itoa(attachment.Meta.Small.Height)
+		if attachment.Description != nil {
+			og.ImageAlt = *attachment.Description
+		}
+		og.TwitterCard = "summary_large_image"
+
+		// Link videos so they can be played
+		// inline, with the image as poster.
+		if (attachment.Type == "video" || attachment.Type == "gifv") && attachment.URL != nil {
+			og.Video = *attachment.URL
+			og.VideoType = mime.TypeByExtension(path.Ext(og.Video))
+			og.VideoWidth = strconv.Itoa(attachment.Meta.Original.Width)
+			og.VideoHeight = strconv.Itoa(attachment.Meta.Original.Height)
+		}
 	}
 
 	og.ArticlePublisher = status.Account.URL
 	og.ArticleAuthor = status.Account.URL
 	og.ArticlePublishedTime = status.CreatedAt
 	og.ArticleModifiedTime = status.CreatedAt
+	if status.EditedAt != nil {
+		og.ArticleModifiedTime = *status.EditedAt
+	}
+	og.Creator = "@" + status.Account.Acct + "@" + og.SiteName
 
 	return og
 }
@@ -160,25 +218,13 @@ func AccountTitle(account *apimodel.Account, accountDomain string) string {
 
 // ParseDescription returns a string description which is
 // safe to use as a template.HTMLAttr inside templates.
+//
+// The description is converted to plaintext and truncated
+// to the configured length before being escaped, so that
+// truncation never cuts through the middle of an entity.
 func ParseDescription(in string) string {
-	i := text.SanitizeToPlaintext(in)
-	i = strings.ReplaceAll(i, "\n", " ")
-	i = strings.Join(strings.Fields(i), " ")
+	i := text.Summarize(in, config.GetWebOpenGraphDescriptionLength())
 	i = html.EscapeString(i)
 	i = strings.ReplaceAll(i, `\`, "&bsol;")
-	i = truncate(i, maxOGDescriptionLength)
 	return `content="` + i + `"`
 }
-
-// truncate trims given string to
-// specified length (in runes).
-func truncate(s string, l int) string {
-	r := []rune(s)
-	if len(r) < l {
-		// No need
-		// to trim.
-		return s
-	}
-
-	return string(r[:l]) + "..."
-}
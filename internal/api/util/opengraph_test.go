@@ -19,22 +19,31 @@ package util
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 type OpenGraphTestSuite struct {
 	suite.Suite
 }
 
+func (suite *OpenGraphTestSuite) SetupTest() {
+	config.SetWebOpenGraphDescriptionLength(config.Defaults.WebOpenGraphDescriptionLength)
+}
+
 func (suite *OpenGraphTestSuite) TestParseDescription() {
 	tests := []struct {
 		name, in, exp string
 	}{
 		{name: "shellcmd", in: `echo '\e]8;;http://example.com\e\This is a link\e]8;;\e'`, exp: `echo &#39;&bsol;e]8;;http://example.com&bsol;e&bsol;This is a link&bsol;e]8;;&bsol;e&#39;`},
 		{name: "newlines", in: "test\n\ntest\ntest", exp: "test test test"},
+		{name: "html", in: "<p>hello <a href=\"https://example.org\">world</a></p><p>&amp; goodbye</p>", exp: "hello world &amp; goodbye"},
+		{name: "truncate", in: strings.Repeat("a&b ", 100), exp: strings.Repeat("a&amp;b ", 73) + "a&amp;b..."},
 	}
 
 	for _, tt := range tests {
@@ -75,6 +84,8 @@ func (suite *OpenGraphTestSuite) TestWithAccountWithNote() {
 		ArticleModifiedTime:  "",
 		ArticlePublishedTime: "",
 		ProfileUsername:      "example_account",
+		Creator:              "@example_account@example.org",
+		TwitterCard:          "summary",
 	}, *accountMeta)
 }
 
@@ -108,9 +119,127 @@ func (suite *OpenGraphTestSuite) TestWithAccountNoNote() {
 		ArticleModifiedTime:  "",
 		ArticlePublishedTime: "",
 		ProfileUsername:      "example_account",
+		Creator:              "@example_account@example.org",
+		TwitterCard:          "summary",
 	}, *accountMeta)
 }
 
+func (suite *OpenGraphTestSuite) TestWithStatusCW() {
+	config.SetWebOpenGraphDescriptionLength(16)
+
+	baseMeta := OGBase(&apimodel.InstanceV1{
+		AccountDomain: "example.org",
+		Languages:     []string{"en"},
+	})
+
+	statusMeta := baseMeta.WithStatus(&apimodel.Status{
+		Account: &apimodel.Account{
+			Acct:     "example_account",
+			Username: "example_account",
+		},
+		SpoilerText: "spoilers for the & film",
+		Content:     "<p>the butler did it</p>",
+	})
+
+	suite.Equal(`content="CW: spoilers..."`, statusMeta.Description)
+}
+
+func (suite *OpenGraphTestSuite) TestWithStatusNoContent() {
+	baseMeta := OGBase(&apimodel.InstanceV1{
+		AccountDomain: "example.org",
+		Languages:     []string{"en"},
+	})
+
+	statusMeta := baseMeta.WithStatus(&apimodel.Status{
+		Account: &apimodel.Account{
+			Acct:     "example_account",
+			Username: "example_account",
+		},
+	})
+
+	suite.Equal(`content="Post by @example_account@example.org"`, statusMeta.Description)
+}
+
+func (suite *OpenGraphTestSuite) TestWithStatusVideo() {
+	baseMeta := OGBase(&apimodel.InstanceV1{
+		AccountDomain: "example.org",
+		Languages:     []string{"en"},
+	})
+
+	statusMeta := baseMeta.WithStatus(&apimodel.Status{
+		Account: &apimodel.Account{
+			Acct:     "example_account",
+			Username: "example_account",
+			URL:      "https://example.org/@example_account",
+		},
+		URL:       "https://example.org/@example_account/statuses/01J1D9ZEWFJ4ZCMEAXP5YCV3D5",
+		CreatedAt: "2024-06-27T10:00:00.000Z",
+		Content:   "<p>look at this</p>",
+		MediaAttachments: []*apimodel.Attachment{{
+			ID:          "01J1D9ZEWFJ4ZCMEAXP5YCV3D6",
+			Type:        "video",
+			URL:         util.Ptr("https://example.org/fileserver/01J1D9ZEWFJ4ZCMEAXP5YCV3D7/attachment/original/01J1D9ZEWFJ4ZCMEAXP5YCV3D6.mp4"),
+			PreviewURL:  util.Ptr("https://example.org/fileserver/01J1D9ZEWFJ4ZCMEAXP5YCV3D7/attachment/small/01J1D9ZEWFJ4ZCMEAXP5YCV3D6.jpg"),
+			Description: util.Ptr("a cat falling over"),
+			Blurhash:    util.Ptr("LjCZqS%MjbWBlzt7RjRk00ofj[WB"),
+			Meta: &apimodel.MediaMeta{
+				Original: apimodel.MediaDimensions{Width: 1280, Height: 720},
+				Small:    apimodel.MediaDimensions{Width: 512, Height: 288},
+			},
+		}},
+	})
+
+	suite.Equal("https://example.org/fileserver/01J1D9ZEWFJ4ZCMEAXP5YCV3D7/attachment/small/01J1D9ZEWFJ4ZCMEAXP5YCV3D6.jpg", statusMeta.Image)
+	suite.Equal("512", statusMeta.ImageWidth)
+	suite.Equal("288", statusMeta.ImageHeight)
+	suite.Equal("a cat falling over", statusMeta.ImageAlt)
+	suite.Equal("https://example.org/fileserver/01J1D9ZEWFJ4ZCMEAXP5YCV3D7/attachment/original/01J1D9ZEWFJ4ZCMEAXP5YCV3D6.mp4", statusMeta.Video)
+	suite.Equal("video/mp4", statusMeta.VideoType)
+	suite.Equal("1280", statusMeta.VideoWidth)
+	suite.Equal("720", statusMeta.VideoHeight)
+	suite.Equal("@example_account@example.org", statusMeta.Creator)
+	suite.Equal("summary_large_image", statusMeta.TwitterCard)
+}
+
+func (suite *OpenGraphTestSuite) TestWithStatusSensitiveMedia() {
+	baseMeta := OGBase(&apimodel.InstanceV1{
+		AccountDomain: "example.org",
+		Languages:     []string{"en"},
+	})
+
+	statusMeta := baseMeta.WithStatus(&apimodel.Status{
+		Account: &apimodel.Account{
+			Acct:     "example_account",
+			Username: "example_account",
+			URL:      "https://example.org/@example_account",
+		},
+		URL:         "https://example.org/@example_account/statuses/01J1D9ZEWFJ4ZCMEAXP5YCV3D5",
+		Sensitive:   true,
+		SpoilerText: "spooky",
+		MediaAttachments: []*apimodel.Attachment{{
+			ID:          "01J1D9ZEWFJ4ZCMEAXP5YCV3D6",
+			Type:        "video",
+			URL:         util.Ptr("https://example.org/fileserver/01J1D9ZEWFJ4ZCMEAXP5YCV3D7/attachment/original/01J1D9ZEWFJ4ZCMEAXP5YCV3D6.mp4"),
+			PreviewURL:  util.Ptr("https://example.org/fileserver/01J1D9ZEWFJ4ZCMEAXP5YCV3D7/attachment/small/01J1D9ZEWFJ4ZCMEAXP5YCV3D6.jpg"),
+			Description: util.Ptr("a ghost"),
+			Blurhash:    util.Ptr("LjCZqS%MjbWBlzt7RjRk00ofj[WB"),
+			Meta: &apimodel.MediaMeta{
+				Original: apimodel.MediaDimensions{Width: 1280, Height: 720},
+				Small:    apimodel.MediaDimensions{Width: 512, Height: 288},
+			},
+		}},
+	})
+
+	// Media should be replaced by a blurred
+	// preview, and the video not linked at all.
+	suite.Equal("https://example.org/@example_account/statuses/01J1D9ZEWFJ4ZCMEAXP5YCV3D5/media/01J1D9ZEWFJ4ZCMEAXP5YCV3D6/preview", statusMeta.Image)
+	suite.Equal("512", statusMeta.ImageWidth)
+	suite.Equal("288", statusMeta.ImageHeight)
+	suite.Equal("Blurred preview of media marked as sensitive", statusMeta.ImageAlt)
+	suite.Empty(statusMeta.Video)
+	suite.Equal(`content="CW: spooky"`, statusMeta.Description)
+}
+
 func TestOpenGraphTestSuite(t *testing.T) {
 	suite.Run(t, &OpenGraphTestSuite{})
 }
@@ -40,6 +40,7 @@ const (
 	MaxIDKey    = "max_id"
 	SinceIDKey  = "since_id"
 	MinIDKey    = "min_id"
+	OffsetKey   = "offset"
 	UsernameKey = "username"
 
 	/* AP endpoint keys */
@@ -48,6 +49,7 @@ const (
 
 	/* Search keys */
 
+	SearchAccountIDKey         = "account_id"
 	SearchExcludeUnreviewedKey = "exclude_unreviewed"
 	SearchFollowingKey         = "following"
 	SearchLookupKey            = "acct"
@@ -56,13 +58,29 @@ const (
 	SearchResolveKey           = "resolve"
 	SearchTypeKey              = "type"
 
+	/* Announcement keys */
+
+	AnnouncementWithDismissedKey = "with_dismissed"
+	AnnouncementReactionNameKey  = "name"
+
+	/* oEmbed keys */
+
+	OEmbedURLKey       = "url"
+	OEmbedMaxWidthKey  = "maxwidth"
+	OEmbedMaxHeightKey = "maxheight"
+	OEmbedFormatKey    = "format"
+
 	/* Tag keys */
 
 	TagNameKey = "tag_name"
+	TagAnyKey  = "any[]"
+	TagAllKey  = "all[]"
+	TagNoneKey = "none[]"
 
 	/* Web endpoint keys */
 
-	WebStatusIDKey = "status"
+	WebStatusIDKey     = "status"
+	WebAttachmentIDKey = "attachment"
 
 	/* Domain permission keys */
 
@@ -88,6 +106,16 @@ const (
 	AdminPermissionsKey = "permissions"
 	AdminRoleIDsKey     = "role_ids[]"
 	AdminInvitedByKey   = "invited_by"
+
+	/* Account stats keys */
+
+	AccountStatsWeeksKey = "weeks"
+
+	/* Debug keys */
+
+	DebugIterationsKey  = "iterations"
+	DebugAttachmentsKey = "attachments"
+	DebugEmojisKey      = "emojis"
 )
 
 /*
@@ -139,10 +167,38 @@ func ParseSearchFollowing(value string, defaultValue bool) (bool, gtserror.WithC
 	return parseBool(value, defaultValue, SearchFollowingKey)
 }
 
+func ParseOffset(value string, defaultValue int, max, min int) (int, gtserror.WithCode) {
+	return parseInt(value, defaultValue, max, min, OffsetKey)
+}
+
 func ParseSearchOffset(value string, defaultValue int, max, min int) (int, gtserror.WithCode) {
 	return parseInt(value, defaultValue, max, min, SearchOffsetKey)
 }
 
+func ParseOEmbedMaxWidth(value string, defaultValue int, max, min int) (int, gtserror.WithCode) {
+	return parseInt(value, defaultValue, max, min, OEmbedMaxWidthKey)
+}
+
+func ParseOEmbedMaxHeight(value string, defaultValue int, max, min int) (int, gtserror.WithCode) {
+	return parseInt(value, defaultValue, max, min, OEmbedMaxHeightKey)
+}
+
+func ParseAccountStatsWeeks(value string, defaultValue int, max, min int) (int, gtserror.WithCode) {
+	return parseInt(value, defaultValue, max, min, AccountStatsWeeksKey)
+}
+
+func ParseDebugIterations(value string, defaultValue int, max, min int) (int, gtserror.WithCode) {
+	return parseInt(value, defaultValue, max, min, DebugIterationsKey)
+}
+
+func ParseDebugAttachments(value string, defaultValue int, max, min int) (int, gtserror.WithCode) {
+	return parseInt(value, defaultValue, max, min, DebugAttachmentsKey)
+}
+
+func ParseDebugEmojis(value string, defaultValue int, max, min int) (int, gtserror.WithCode) {
+	return parseInt(value, defaultValue, max, min, DebugEmojisKey)
+}
+
 func ParseSearchResolve(value string, defaultValue bool) (bool, gtserror.WithCode) {
 	return parseBool(value, defaultValue, SearchResolveKey)
 }
@@ -155,6 +211,10 @@ func ParseDomainPermissionImport(value string, defaultValue bool) (bool, gtserro
 	return parseBool(value, defaultValue, DomainPermissionImportKey)
 }
 
+func ParseAnnouncementWithDismissed(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	return parseBool(value, defaultValue, AnnouncementWithDismissedKey)
+}
+
 func ParseOnlyOtherAccounts(value string, defaultValue bool) (bool, gtserror.WithCode) {
 	return parseBool(value, defaultValue, OnlyOtherAccountsKey)
 }
@@ -221,6 +281,26 @@ func ParseID(value string) (string, gtserror.WithCode) {
 	return value, nil
 }
 
+func ParseAnnouncementReactionName(value string) (string, gtserror.WithCode) {
+	key := AnnouncementReactionNameKey
+
+	if value == "" {
+		return "", requiredError(key)
+	}
+
+	return value, nil
+}
+
+func ParseOEmbedURL(value string) (string, gtserror.WithCode) {
+	key := OEmbedURLKey
+
+	if value == "" {
+		return "", requiredError(key)
+	}
+
+	return value, nil
+}
+
 func ParseSearchLookup(value string) (string, gtserror.WithCode) {
 	key := SearchLookupKey
 
@@ -271,6 +351,16 @@ func ParseWebStatusID(value string) (string, gtserror.WithCode) {
 	return value, nil
 }
 
+func ParseWebAttachmentID(value string) (string, gtserror.WithCode) {
+	key := WebAttachmentIDKey
+
+	if value == "" {
+		return "", requiredError(key)
+	}
+
+	return value, nil
+}
+
 /*
 	Internal functions
 */
@@ -45,12 +45,6 @@ var (
 	StatusInternalServerErrorJSON = mustJSON(map[string]string{
 		"status": http.StatusText(http.StatusInternalServerError),
 	})
-	ErrorCapacityExceeded = mustJSON(map[string]string{
-		"error": "server capacity exceeded",
-	})
-	ErrorRateLimited = mustJSON(map[string]string{
-		"error": "rate limit reached",
-	})
 	EmptyJSONObject = json.RawMessage(`{}`)
 	EmptyJSONArray  = json.RawMessage(`[]`)
 
@@ -126,6 +120,27 @@ func EncodeJSONResponse(
 	statusCode int,
 	contentType string,
 	data any,
+) {
+	encodeJSONResponse(rw, r,
+		statusCode,
+		contentType,
+		data,
+		WriteResponseBytes,
+	)
+}
+
+// writeBytesFunc matches the signature of WriteResponseBytes().
+type writeBytesFunc func(http.ResponseWriter, *http.Request, int, string, []byte)
+
+// encodeJSONResponse encodes 'data' as JSON and
+// passes the encoded bytes on to the given write func.
+func encodeJSONResponse(
+	rw http.ResponseWriter,
+	r *http.Request,
+	statusCode int,
+	contentType string,
+	data any,
+	write writeBytesFunc,
 ) {
 	// Acquire buffer.
 	buf := getBuf()
@@ -144,7 +159,7 @@ func EncodeJSONResponse(
 
 		// Respond with the now-known
 		// size byte slice within buf.
-		WriteResponseBytes(rw, r,
+		write(rw, r,
 			statusCode,
 			contentType,
 			buf.B,
@@ -175,6 +190,24 @@ func EncodeXMLResponse(
 	statusCode int,
 	contentType string,
 	data any,
+) {
+	encodeXMLResponse(rw, r,
+		statusCode,
+		contentType,
+		data,
+		WriteResponseBytes,
+	)
+}
+
+// encodeXMLResponse encodes 'data' as XML and
+// passes the encoded bytes on to the given write func.
+func encodeXMLResponse(
+	rw http.ResponseWriter,
+	r *http.Request,
+	statusCode int,
+	contentType string,
+	data any,
+	write writeBytesFunc,
 ) {
 	// Acquire buffer.
 	buf := getBuf()
@@ -190,7 +223,7 @@ func EncodeXMLResponse(
 
 		// Respond with the now-known
 		// size byte slice within buf.
-		WriteResponseBytes(rw, r,
+		write(rw, r,
 			statusCode,
 			contentType,
 			buf.B,
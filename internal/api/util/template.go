@@ -81,6 +81,30 @@ func TemplateWebPage(
 	templatePage(c, page.Template, http.StatusOK, obj)
 }
 
+// TemplateEmbedPage renders the given HTML template and
+// page params within the standalone "embed" template, for
+// serving pages that will be shown inside an iframe on
+// other websites. OGMeta is not used by this template.
+func TemplateEmbedPage(
+	c *gin.Context,
+	page WebPage,
+) {
+	const embedTmpl = "embed.tmpl"
+
+	obj := map[string]any{
+		"instance":    page.Instance,
+		"stylesheets": page.Stylesheets,
+		"javascript":  page.Javascript,
+		"pageContent": page.Template,
+	}
+
+	for k, v := range page.Extra {
+		obj[k] = v
+	}
+
+	c.HTML(http.StatusOK, embedTmpl, obj)
+}
+
 // templateErrorPage renders the given
 // HTTP code, error, and request ID
 // within the standard error template.
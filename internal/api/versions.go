@@ -0,0 +1,129 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"net/http"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// clientMastodonAPIVersion is the level of the Mastodon
+// client API that we support, as served to clients in
+// the same format as Mastodon's `api_versions.mastodon`.
+// Only bump this once everything in the level is supported,
+// as clients use it to decide which endpoints they can call.
+const clientMastodonAPIVersion = 1
+
+// clientExtensions are the GoToSocial-specific features
+// of the client API, for clients to feature-detect by.
+// These must not be changed or removed once published!
+var clientExtensions = []string{
+	"account_aliases",         // POST /api/v1/accounts/alias{,/add,/remove}
+	"account_themes",          // GET /api/v1/accounts/themes
+	"account_username_change", // POST /api/v1/accounts/username
+	"filters_export",          // GET /api/v1/user/filters/export, POST /api/v1/user/filters/import
+	"gotosocial_namespace",    // /api/v1/gotosocial/*
+	"instance_web_clients",    // GET /api/v1/instance/web_clients
+	"oembed",                  // GET /api/oembed
+	"problem_json",            // Accept: application/problem+json error responses
+	"terms_acceptance",        // GET /api/v1/user/terms, POST /api/v1/user/terms/accept
+}
+
+// deprecationRule marks the route with the given
+// method and path (with api version filled in) as
+// deprecated. Paths must match exactly.
+type deprecationRule struct {
+	method string
+	path   string
+	middleware.Deprecation
+}
+
+// clientDeprecationRules contains deprecated client API routes.
+// Since GoToSocial follows the Mastodon API, routes deprecated
+// by Mastodon are marked as deprecated from the date of the
+// Mastodon release that deprecated them.
+var clientDeprecationRules = []deprecationRule{
+	{
+		// Mastodon 3.1.3.
+		method:      http.MethodPost,
+		path:        "/api/v1/media",
+		Deprecation: middleware.Deprecation{Since: date(2020, 4, 5), Successor: "/api/v2/media"},
+	},
+	{
+		// Mastodon 3.0.0.
+		method:      http.MethodGet,
+		path:        "/api/v1/search",
+		Deprecation: middleware.Deprecation{Since: date(2019, 10, 3), Successor: "/api/v2/search"},
+	},
+	{
+		// Mastodon 4.0.0.
+		method:      http.MethodGet,
+		path:        "/api/v1/instance",
+		Deprecation: middleware.Deprecation{Since: date(2022, 11, 14), Successor: "/api/v2/instance"},
+	},
+}
+
+// clientDeprecation returns the deprecation of the client API
+// route with the given method and path, or nil if not deprecated.
+func clientDeprecation(method string, path string) *middleware.Deprecation {
+	for i := range clientDeprecationRules {
+		rule := &clientDeprecationRules[i]
+		if rule.method == method && rule.path == path {
+			return &rule.Deprecation
+		}
+	}
+	return nil
+}
+
+// clientAPIVersions returns the apimodel representation
+// of supported client API versions, extensions and deprecations.
+func clientAPIVersions() *apimodel.APIVersions {
+	deprecated := make([]apimodel.APIDeprecation, 0, len(clientDeprecationRules))
+	for _, rule := range clientDeprecationRules {
+		apiDeprecation := apimodel.APIDeprecation{
+			Method:       rule.method,
+			Path:         rule.path,
+			DeprecatedAt: util.FormatISO8601(rule.Since),
+			Successor:    rule.Successor,
+		}
+
+		if !rule.Sunset.IsZero() {
+			sunset := util.FormatISO8601(rule.Sunset)
+			apiDeprecation.Sunset = &sunset
+		}
+
+		deprecated = append(deprecated, apiDeprecation)
+	}
+
+	return &apimodel.APIVersions{
+		Version:    config.GetSoftwareVersion(),
+		Mastodon:   clientMastodonAPIVersion,
+		Versions:   []string{"v1", "v2"},
+		Extensions: clientExtensions,
+		Deprecated: deprecated,
+	}
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
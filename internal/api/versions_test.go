@@ -0,0 +1,50 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestClientDeprecation(t *testing.T) {
+	for _, test := range []struct {
+		method    string
+		path      string
+		successor string
+	}{
+		{http.MethodGet, "/api/v1/instance", "/api/v2/instance"},
+		{http.MethodPatch, "/api/v1/instance", ""},
+		{http.MethodGet, "/api/v2/instance", ""},
+		{http.MethodGet, "/api/v1/search", "/api/v2/search"},
+		{http.MethodGet, "/api/v2/search", ""},
+		{http.MethodPost, "/api/v1/media", "/api/v2/media"},
+		{http.MethodPost, "/api/v2/media", ""},
+		{http.MethodGet, "/api/v1/instance/peers", ""},
+	} {
+		d := clientDeprecation(test.method, test.path)
+		switch {
+		case test.successor == "" && d != nil:
+			t.Errorf("%s %s: expected not deprecated", test.method, test.path)
+		case test.successor != "" && d == nil:
+			t.Errorf("%s %s: expected deprecated", test.method, test.path)
+		case d != nil && d.Successor != test.successor:
+			t.Errorf("%s %s: expected successor %q, got %q", test.method, test.path, test.successor, d.Successor)
+		}
+	}
+}
@@ -42,6 +42,8 @@ import (
 //		'200':
 //			schema:
 //				"$ref": "#/definitions/hostmeta"
+//		'304':
+//			description: Not modified; the ETag given in If-None-Match is still current.
 func (m *Module) HostMetaGETHandler(c *gin.Context) {
 	if _, err := apiutil.NegotiateAccept(c, apiutil.HostMetaHeaders...); err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
@@ -50,8 +52,8 @@ func (m *Module) HostMetaGETHandler(c *gin.Context) {
 
 	hostMeta := m.processor.Fedi().HostMetaGet()
 
-	// Encode XML HTTP response.
-	apiutil.EncodeXMLResponse(
+	// Encode XML HTTP response with ETag.
+	apiutil.EncodeXMLResponseETag(
 		c.Writer,
 		c.Request,
 		http.StatusOK,
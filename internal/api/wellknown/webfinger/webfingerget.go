@@ -54,6 +54,8 @@ import (
 //		'200':
 //			schema:
 //				"$ref": "#/definitions/wellKnownResponse"
+//		'304':
+//			description: Not modified; the ETag given in If-None-Match is still current.
 func (m *Module) WebfingerGETRequest(c *gin.Context) {
 	if _, err := apiutil.NegotiateAccept(c, apiutil.WebfingerJSONAcceptHeaders...); err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
@@ -86,8 +88,10 @@ func (m *Module) WebfingerGETRequest(c *gin.Context) {
 		return
 	}
 
-	// Encode JSON HTTP response.
-	apiutil.EncodeJSONResponse(
+	// Encode JSON HTTP response, tagged
+	// so that callers who already have
+	// this response can revalidate it.
+	apiutil.EncodeJSONResponseETag(
 		c.Writer,
 		c.Request,
 		http.StatusOK,
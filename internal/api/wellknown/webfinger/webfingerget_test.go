@@ -37,6 +37,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -85,7 +86,7 @@ func (suite *WebfingerGetTestSuite) funkifyAccountDomain(host string, accountDom
 	config.SetAccountDomain(accountDomain)
 	testrig.StopWorkers(&suite.state)
 	testrig.StartNoopWorkers(&suite.state)
-	suite.processor = processing.NewProcessor(cleaner.New(&suite.state), suite.tc, suite.federator, testrig.NewTestOauthServer(suite.db), testrig.NewTestMediaManager(&suite.state), &suite.state, suite.emailSender)
+	suite.processor = processing.NewProcessor(cleaner.New(&suite.state), suite.tc, suite.federator, testrig.NewTestOauthServer(suite.db), testrig.NewTestMediaManager(&suite.state), &suite.state, suite.emailSender, webpush.NewNoopSender(nil))
 	suite.webfingerModule = webfinger.New(suite.processor)
 	testrig.StartNoopWorkers(&suite.state)
 
@@ -269,6 +270,51 @@ func (suite *WebfingerGetTestSuite) TestFingerUserWithoutAcct() {
 }`, resp)
 }
 
+func (suite *WebfingerGetTestSuite) TestFingerUserNotModified() {
+	targetAccount := suite.testAccounts["local_account_1"]
+	requestPath := fmt.Sprintf("/%s?resource=acct:%s@%s", webfinger.WebfingerBasePath, targetAccount.Username, config.GetHost())
+
+	fingerWithETag := func(eTag string) *http.Response {
+		recorder := httptest.NewRecorder()
+		ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+		ctx.Request = httptest.NewRequest(http.MethodGet, requestPath, nil)
+		ctx.Request.Header.Set("accept", "application/jrd+json")
+		if eTag != "" {
+			ctx.Request.Header.Set("If-None-Match", eTag)
+		}
+		suite.webfingerModule.WebfingerGETRequest(ctx)
+
+		// Flush header as gin would after handling,
+		// since a 304 response has no body to write.
+		ctx.Writer.WriteHeaderNow()
+		return recorder.Result()
+	}
+
+	// First request should be a full response with an ETag.
+	result := fingerWithETag("")
+	result.Body.Close()
+	suite.Equal(http.StatusOK, result.StatusCode)
+	eTag := result.Header.Get("ETag")
+	suite.NotEmpty(eTag)
+
+	// Revalidating with that ETag should give 304 and no body.
+	result = fingerWithETag(eTag)
+	defer result.Body.Close()
+	suite.Equal(http.StatusNotModified, result.StatusCode)
+	suite.Equal(eTag, result.Header.Get("ETag"))
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(b)
+
+	// A stale ETag should give a full response again.
+	result = fingerWithETag(`"stale"`)
+	defer result.Body.Close()
+	suite.Equal(http.StatusOK, result.StatusCode)
+}
+
 func TestWebfingerGetTestSuite(t *testing.T) {
 	suite.Run(t, new(WebfingerGetTestSuite))
 }
@@ -20,6 +20,7 @@ package cache
 import (
 	"time"
 
+	"codeberg.org/gruf/go-cache/v3/ttl"
 	"github.com/superseriousbusiness/gotosocial/internal/cache/headerfilter"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
@@ -42,6 +43,11 @@ type Caches struct {
 	// cache. (used by the visibility filter).
 	Visibility VisibilityCache
 
+	// Idempotency provides access to the idempotency key
+	// cache, mapping keys to the IDs of items created by
+	// client API requests. (used by the processor).
+	Idempotency *ttl.Cache[string, string] // TTL=1hr, sweep=5min
+
 	// prevent pass-by-value.
 	_ nocopy
 }
@@ -59,6 +65,7 @@ func (c *Caches) Init() {
 	c.initBlock()
 	c.initBlockIDs()
 	c.initBoostOfIDs()
+	c.initCard()
 	c.initClient()
 	c.initDomainAllow()
 	c.initDomainBlock()
@@ -71,6 +78,8 @@ func (c *Caches) Init() {
 	c.initFollowIDs()
 	c.initFollowRequest()
 	c.initFollowRequestIDs()
+	c.initFeaturedTag()
+	c.initFollowedTag()
 	c.initInReplyToIDs()
 	c.initInstance()
 	c.initList()
@@ -87,6 +96,8 @@ func (c *Caches) Init() {
 	c.initStatus()
 	c.initStatusFave()
 	c.initStatusFaveIDs()
+	c.initStatusReaction()
+	c.initStatusReactionIDs()
 	c.initTag()
 	c.initThreadMute()
 	c.initToken()
@@ -94,6 +105,7 @@ func (c *Caches) Init() {
 	c.initUser()
 	c.initWebfinger()
 	c.initVisibility()
+	c.initIdempotency()
 }
 
 // Start will start any caches that require a background
@@ -104,6 +116,10 @@ func (c *Caches) Start() {
 	tryUntil("starting *gtsmodel.Webfinger cache", 5, func() bool {
 		return c.GTS.Webfinger.Start(5 * time.Minute)
 	})
+
+	tryUntil("starting idempotency cache", 5, func() bool {
+		return c.Idempotency.Start(5 * time.Minute)
+	})
 }
 
 // Stop will stop any caches that require a background
@@ -112,6 +128,7 @@ func (c *Caches) Stop() {
 	log.Infof(nil, "stop: %p", c)
 
 	tryUntil("stopping *gtsmodel.Webfinger cache", 5, c.GTS.Webfinger.Stop)
+	tryUntil("stopping idempotency cache", 5, c.Idempotency.Stop)
 }
 
 // Sweep will sweep all the available caches to ensure none
@@ -129,6 +146,7 @@ func (c *Caches) Sweep(threshold float64) {
 	c.GTS.Block.Trim(threshold)
 	c.GTS.BlockIDs.Trim(threshold)
 	c.GTS.BoostOfIDs.Trim(threshold)
+	c.GTS.Card.Trim(threshold)
 	c.GTS.Client.Trim(threshold)
 	c.GTS.Emoji.Trim(threshold)
 	c.GTS.EmojiCategory.Trim(threshold)
@@ -139,6 +157,8 @@ func (c *Caches) Sweep(threshold float64) {
 	c.GTS.FollowIDs.Trim(threshold)
 	c.GTS.FollowRequest.Trim(threshold)
 	c.GTS.FollowRequestIDs.Trim(threshold)
+	c.GTS.FeaturedTag.Trim(threshold)
+	c.GTS.FollowedTag.Trim(threshold)
 	c.GTS.InReplyToIDs.Trim(threshold)
 	c.GTS.Instance.Trim(threshold)
 	c.GTS.List.Trim(threshold)
@@ -155,6 +175,8 @@ func (c *Caches) Sweep(threshold float64) {
 	c.GTS.Status.Trim(threshold)
 	c.GTS.StatusFave.Trim(threshold)
 	c.GTS.StatusFaveIDs.Trim(threshold)
+	c.GTS.StatusReaction.Trim(threshold)
+	c.GTS.StatusReactionIDs.Trim(threshold)
 	c.GTS.Tag.Trim(threshold)
 	c.GTS.ThreadMute.Trim(threshold)
 	c.GTS.Token.Trim(threshold)
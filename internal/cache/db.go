@@ -53,6 +53,9 @@ type GTSCaches struct {
 	// BoostOfIDs provides access to the boost of IDs list database cache.
 	BoostOfIDs SliceCache[string]
 
+	// Card provides access to the gtsmodel Card database cache.
+	Card StructCache[*gtsmodel.Card]
+
 	// Client provides access to the gtsmodel Client database cache.
 	Client StructCache[*gtsmodel.Client]
 
@@ -97,6 +100,12 @@ type GTSCaches struct {
 	// - '<'  for follower IDs
 	FollowRequestIDs SliceCache[string]
 
+	// FeaturedTag provides access to the gtsmodel FeaturedTag database cache.
+	FeaturedTag StructCache[*gtsmodel.FeaturedTag]
+
+	// FollowedTag provides access to the gtsmodel FollowedTag database cache.
+	FollowedTag StructCache[*gtsmodel.FollowedTag]
+
 	// Instance provides access to the gtsmodel Instance database cache.
 	Instance StructCache[*gtsmodel.Instance]
 
@@ -145,6 +154,12 @@ type GTSCaches struct {
 	// StatusFaveIDs provides access to the status fave IDs list database cache.
 	StatusFaveIDs SliceCache[string]
 
+	// StatusReaction provides access to the gtsmodel StatusReaction database cache.
+	StatusReaction StructCache[*gtsmodel.StatusReaction]
+
+	// StatusReactionIDs provides access to the status reaction IDs list database cache.
+	StatusReactionIDs SliceCache[string]
+
 	// Tag provides access to the gtsmodel Tag database cache.
 	Tag StructCache[*gtsmodel.Tag]
 
@@ -384,6 +399,38 @@ func (c *Caches) initBoostOfIDs() {
 	c.GTS.BoostOfIDs.Init(0, cap)
 }
 
+func (c *Caches) initCard() {
+	// Calculate maximum cache size.
+	cap := calculateResultCacheMax(
+		sizeofCard(), // model in-mem size.
+		config.GetCacheCardMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(c1 *gtsmodel.Card) *gtsmodel.Card {
+		c2 := new(gtsmodel.Card)
+		*c2 = *c1
+
+		// Don't include ptr fields that
+		// will be populated separately.
+		// See internal/db/bundb/card.go.
+		c2.CreatorAccount = nil
+
+		return c2
+	}
+
+	c.GTS.Card.Init(structr.CacheConfig[*gtsmodel.Card]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+			{Fields: "URL"},
+		},
+		MaxSize:   cap,
+		IgnoreErr: ignoreErrors,
+		Copy:      copyF,
+	})
+}
+
 func (c *Caches) initClient() {
 	// Calculate maximum cache size.
 	cap := calculateResultCacheMax(
@@ -1070,7 +1117,9 @@ func (c *Caches) initStatus() {
 		s2.InReplyToAccount = nil
 		s2.BoostOf = nil
 		s2.BoostOfAccount = nil
+		s2.QuoteOf = nil
 		s2.Poll = nil
+		s2.Card = nil
 		s2.Attachments = nil
 		s2.Tags = nil
 		s2.Mentions = nil
@@ -1143,6 +1192,55 @@ func (c *Caches) initStatusFaveIDs() {
 	c.GTS.StatusFaveIDs.Init(0, cap)
 }
 
+func (c *Caches) initStatusReaction() {
+	// Calculate maximum cache size.
+	cap := calculateResultCacheMax(
+		sizeofStatusReaction(), // model in-mem size.
+		config.GetCacheStatusReactionMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(r1 *gtsmodel.StatusReaction) *gtsmodel.StatusReaction {
+		r2 := new(gtsmodel.StatusReaction)
+		*r2 = *r1
+
+		// Don't include ptr fields that
+		// will be populated separately.
+		// See internal/db/bundb/statusreaction.go.
+		r2.Account = nil
+		r2.TargetAccount = nil
+		r2.Status = nil
+		r2.Emoji = nil
+
+		return r2
+	}
+
+	c.GTS.StatusReaction.Init(structr.CacheConfig[*gtsmodel.StatusReaction]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+			{Fields: "URI"},
+			{Fields: "AccountID,StatusID,Name"},
+			{Fields: "StatusID", Multiple: true},
+		},
+		MaxSize:    cap,
+		IgnoreErr:  ignoreErrors,
+		Copy:       copyF,
+		Invalidate: c.OnInvalidateStatusReaction,
+	})
+}
+
+func (c *Caches) initStatusReactionIDs() {
+	// Calculate maximum cache size.
+	cap := calculateSliceCacheMax(
+		config.GetCacheStatusReactionIDsMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	c.GTS.StatusReactionIDs.Init(0, cap)
+}
+
 func (c *Caches) initTag() {
 	// Calculate maximum cache size.
 	cap := calculateResultCacheMax(
@@ -1169,6 +1267,72 @@ func (c *Caches) initTag() {
 	})
 }
 
+func (c *Caches) initFeaturedTag() {
+	// Calculate maximum cache size.
+	cap := calculateResultCacheMax(
+		sizeofFeaturedTag(), // model in-mem size.
+		config.GetCacheFeaturedTagMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(f1 *gtsmodel.FeaturedTag) *gtsmodel.FeaturedTag {
+		f2 := new(gtsmodel.FeaturedTag)
+		*f2 = *f1
+
+		// Don't include ptr fields that
+		// will be populated separately.
+		// See internal/db/bundb/tag.go.
+		f2.Tag = nil
+
+		return f2
+	}
+
+	c.GTS.FeaturedTag.Init(structr.CacheConfig[*gtsmodel.FeaturedTag]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+			{Fields: "AccountID,TagID"},
+			{Fields: "AccountID", Multiple: true},
+		},
+		MaxSize:   cap,
+		IgnoreErr: ignoreErrors,
+		Copy:      copyF,
+	})
+}
+
+func (c *Caches) initFollowedTag() {
+	// Calculate maximum cache size.
+	cap := calculateResultCacheMax(
+		sizeofFollowedTag(), // model in-mem size.
+		config.GetCacheFollowedTagMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(f1 *gtsmodel.FollowedTag) *gtsmodel.FollowedTag {
+		f2 := new(gtsmodel.FollowedTag)
+		*f2 = *f1
+
+		// Don't include ptr fields that
+		// will be populated separately.
+		// See internal/db/bundb/tag.go.
+		f2.Tag = nil
+
+		return f2
+	}
+
+	c.GTS.FollowedTag.Init(structr.CacheConfig[*gtsmodel.FollowedTag]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+			{Fields: "AccountID,TagID"},
+			{Fields: "AccountID", Multiple: true},
+		},
+		MaxSize:   cap,
+		IgnoreErr: ignoreErrors,
+		Copy:      copyF,
+	})
+}
+
 func (c *Caches) initThreadMute() {
 	cap := calculateResultCacheMax(
 		sizeofThreadMute(), // model in-mem size.
@@ -1278,6 +1442,7 @@ func (c *Caches) initUser() {
 			{Fields: "AccountID"},
 			{Fields: "Email"},
 			{Fields: "ConfirmationToken"},
+			{Fields: "ResetPasswordToken"},
 			{Fields: "ExternalID"},
 		},
 		MaxSize:    cap,
@@ -0,0 +1,42 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cache
+
+import (
+	"time"
+
+	"codeberg.org/gruf/go-cache/v3/ttl"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// idempotencyCacheMax is the maximum number
+// of idempotency keys to keep track of. Keys
+// and IDs are tiny so this doesn't need to be
+// tied to the configured cache memory ratios.
+const idempotencyCacheMax = 10000
+
+func (c *Caches) initIdempotency() {
+	log.Infof(nil, "Idempotency cache size = %d", idempotencyCacheMax)
+
+	c.Idempotency = new(ttl.Cache[string, string])
+	c.Idempotency.Init(
+		0,
+		idempotencyCacheMax,
+		time.Hour,
+	)
+}
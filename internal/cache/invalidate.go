@@ -203,8 +203,19 @@ func (c *Caches) OnInvalidateStatusFave(fave *gtsmodel.StatusFave) {
 	c.GTS.StatusFaveIDs.Invalidate(fave.StatusID)
 }
 
+func (c *Caches) OnInvalidateStatusReaction(reaction *gtsmodel.StatusReaction) {
+	// Invalidate status reaction ID list for this status.
+	c.GTS.StatusReactionIDs.Invalidate(reaction.StatusID)
+}
+
 func (c *Caches) OnInvalidateUser(user *gtsmodel.User) {
 	// Invalidate local account ID cached visibility.
 	c.Visibility.Invalidate("ItemID", user.AccountID)
 	c.Visibility.Invalidate("RequesterID", user.AccountID)
 }
+
+func (c *Caches) OnInvalidateUserMute(mute *gtsmodel.UserMute) {
+	// Invalidate mute origin account ID cached visibility,
+	// as muted accounts are hidden from their timelines.
+	c.Visibility.Invalidate("RequesterID", mute.AccountID)
+}
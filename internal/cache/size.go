@@ -178,6 +178,7 @@ func totalOfRatios() float64 {
 		config.GetCacheBlockMemRatio() +
 		config.GetCacheBlockIDsMemRatio() +
 		config.GetCacheBoostOfIDsMemRatio() +
+		config.GetCacheCardMemRatio() +
 		config.GetCacheClientMemRatio() +
 		config.GetCacheEmojiMemRatio() +
 		config.GetCacheEmojiCategoryMemRatio() +
@@ -188,6 +189,8 @@ func totalOfRatios() float64 {
 		config.GetCacheFollowIDsMemRatio() +
 		config.GetCacheFollowRequestMemRatio() +
 		config.GetCacheFollowRequestIDsMemRatio() +
+		config.GetCacheFeaturedTagMemRatio() +
+		config.GetCacheFollowedTagMemRatio() +
 		config.GetCacheInstanceMemRatio() +
 		config.GetCacheInReplyToIDsMemRatio() +
 		config.GetCacheListMemRatio() +
@@ -203,6 +206,8 @@ func totalOfRatios() float64 {
 		config.GetCacheStatusMemRatio() +
 		config.GetCacheStatusFaveMemRatio() +
 		config.GetCacheStatusFaveIDsMemRatio() +
+		config.GetCacheStatusReactionMemRatio() +
+		config.GetCacheStatusReactionIDsMemRatio() +
 		config.GetCacheTagMemRatio() +
 		config.GetCacheThreadMuteMemRatio() +
 		config.GetCacheTokenMemRatio() +
@@ -257,16 +262,25 @@ func sizeofAccountNote() uintptr {
 
 func sizeofAccountSettings() uintptr {
 	return uintptr(size.Of(&gtsmodel.AccountSettings{
-		AccountID:         exampleID,
-		CreatedAt:         exampleTime,
-		UpdatedAt:         exampleTime,
-		Privacy:           gtsmodel.VisibilityFollowersOnly,
-		Sensitive:         util.Ptr(true),
-		Language:          "fr",
-		StatusContentType: "text/plain",
-		CustomCSS:         exampleText,
-		EnableRSS:         util.Ptr(true),
-		HideCollections:   util.Ptr(false),
+		AccountID:             exampleID,
+		CreatedAt:             exampleTime,
+		UpdatedAt:             exampleTime,
+		Privacy:               gtsmodel.VisibilityFollowersOnly,
+		Sensitive:             util.Ptr(true),
+		Language:              "fr",
+		StatusContentType:     "text/plain",
+		CustomCSS:             exampleText,
+		EnableRSS:             util.Ptr(true),
+		HideCollections:       util.Ptr(false),
+		HideApplication:       util.Ptr(false),
+		EnableEmbeds:          util.Ptr(false),
+		NotifyNewFromDays:     30,
+		StatusRateLimit:       10,
+		EmailWhenAwayHours:    24,
+		AutoApproveFollowed:   util.Ptr(false),
+		AutoApproveLocal:      util.Ptr(false),
+		AutoApproveMinAgeDays: 30,
+		RecordFollowerEvents:  util.Ptr(false),
 	}))
 }
 
@@ -303,6 +317,27 @@ func sizeofBlock() uintptr {
 		URI:             exampleURI,
 		AccountID:       exampleID,
 		TargetAccountID: exampleID,
+		ExpiresAt:       exampleTime,
+	}))
+}
+
+func sizeofCard() uintptr {
+	return uintptr(size.Of(&gtsmodel.Card{
+		ID:               exampleID,
+		CreatedAt:        exampleTime,
+		UpdatedAt:        exampleTime,
+		FetchedAt:        exampleTime,
+		URL:              exampleURI,
+		Title:            exampleUsername, // similar length
+		Description:      exampleTextSmall,
+		Type:             gtsmodel.CardTypeLink,
+		AuthorName:       exampleUsername,
+		AuthorURL:        exampleURI,
+		Creator:          exampleUsername,
+		CreatorAccountID: exampleID,
+		ProviderName:     exampleUsername,
+		ProviderURL:      exampleURI,
+		ImageURL:         exampleURI,
 	}))
 }
 
@@ -405,6 +440,7 @@ func sizeofFollowRequest() uintptr {
 		ShowReblogs:     func() *bool { ok := true; return &ok }(),
 		URI:             exampleURI,
 		Notify:          func() *bool { ok := false; return &ok }(),
+		Note:            exampleTextSmall,
 	}))
 }
 
@@ -421,6 +457,14 @@ func sizeofInstance() uintptr {
 		ContactEmail:           exampleUsername,
 		ContactAccountUsername: exampleUsername,
 		ContactAccountID:       exampleID,
+		Version:                exampleTextSmall,
+		Software:               exampleTextSmall,
+		FetchedAt:              exampleTime,
+		LastSeenAt:             exampleTime,
+		DeliveredCount:         1000,
+		FailedCount:            10,
+		LastDeliveredAt:        exampleTime,
+		LastFailedAt:           exampleTime,
 	}))
 }
 
@@ -432,6 +476,7 @@ func sizeofList() uintptr {
 		Title:         exampleTextSmall,
 		AccountID:     exampleID,
 		RepliesPolicy: gtsmodel.RepliesPolicyFollowed,
+		Exclusive:     util.Ptr(false),
 	}))
 }
 
@@ -480,9 +525,10 @@ func sizeofMedia() uintptr {
 			URL:         exampleURI,
 			RemoteURL:   exampleURI,
 		},
-		Avatar: func() *bool { ok := false; return &ok }(),
-		Header: func() *bool { ok := false; return &ok }(),
-		Cached: func() *bool { ok := true; return &ok }(),
+		Avatar:           func() *bool { ok := false; return &ok }(),
+		Header:           func() *bool { ok := false; return &ok }(),
+		Cached:           func() *bool { ok := true; return &ok }(),
+		StrippedMetadata: []string{"exif", "gps"},
 	}))
 }
 
@@ -523,6 +569,7 @@ func sizeofNotification() uintptr {
 		OriginAccountID:  exampleID,
 		StatusID:         exampleID,
 		Read:             func() *bool { ok := false; return &ok }(),
+		GroupKey:         "favourite-" + exampleID + "-475000",
 	}))
 }
 
@@ -586,6 +633,8 @@ func sizeofStatus() uintptr {
 		InReplyToAccountID:       exampleID,
 		BoostOfID:                exampleID,
 		BoostOfAccountID:         exampleID,
+		QuoteOfID:                exampleID,
+		QuoteOfURI:               exampleURI,
 		ContentWarning:           exampleUsername, // similar length
 		Visibility:               gtsmodel.VisibilityPublic,
 		Sensitive:                func() *bool { ok := false; return &ok }(),
@@ -610,6 +659,20 @@ func sizeofStatusFave() uintptr {
 	}))
 }
 
+func sizeofStatusReaction() uintptr {
+	return uintptr(size.Of(&gtsmodel.StatusReaction{
+		ID:              exampleID,
+		CreatedAt:       exampleTime,
+		UpdatedAt:       exampleTime,
+		AccountID:       exampleID,
+		TargetAccountID: exampleID,
+		StatusID:        exampleID,
+		Name:            exampleUsername,
+		EmojiID:         exampleID,
+		URI:             exampleURI,
+	}))
+}
+
 func sizeofTag() uintptr {
 	return uintptr(size.Of(&gtsmodel.Tag{
 		ID:        exampleID,
@@ -621,6 +684,24 @@ func sizeofTag() uintptr {
 	}))
 }
 
+func sizeofFeaturedTag() uintptr {
+	return uintptr(size.Of(&gtsmodel.FeaturedTag{
+		ID:        exampleID,
+		CreatedAt: exampleTime,
+		AccountID: exampleID,
+		TagID:     exampleID,
+	}))
+}
+
+func sizeofFollowedTag() uintptr {
+	return uintptr(size.Of(&gtsmodel.FollowedTag{
+		ID:        exampleID,
+		CreatedAt: exampleTime,
+		AccountID: exampleID,
+		TagID:     exampleID,
+	}))
+}
+
 func sizeofThreadMute() uintptr {
 	return uintptr(size.Of(&gtsmodel.ThreadMute{
 		ID:        exampleID,
@@ -697,5 +778,6 @@ func sizeofUser() uintptr {
 		ResetPasswordToken:     exampleTextSmall,
 		ResetPasswordSentAt:    exampleTime,
 		ExternalID:             exampleID,
+		LastActiveAt:           exampleTime,
 	}))
 }
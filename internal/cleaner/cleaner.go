@@ -27,24 +27,35 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 )
 
 const (
 	selectLimit = 50
+
+	// expireEvery is how often to check for and
+	// delete expired media, separately from the
+	// main media clean, so that media expires
+	// reasonably close to its expiry time.
+	expireEvery = 5 * time.Minute
 )
 
 type Cleaner struct {
-	state *state.State
-	emoji Emoji
-	media Media
+	state   *state.State
+	manager *media.Manager
+	emoji   Emoji
+	media   Media
+	orphans Orphans
 }
 
 func New(state *state.State) *Cleaner {
 	c := new(Cleaner)
 	c.state = state
+	c.manager = media.NewManager(state)
 	c.emoji.Cleaner = c
 	c.media.Cleaner = c
+	c.orphans.Cleaner = c
 	return c
 }
 
@@ -58,6 +69,11 @@ func (c *Cleaner) Media() *Media {
 	return &c.media
 }
 
+// Orphans returns the orphaned database rows set of cleaner utilities.
+func (c *Cleaner) Orphans() *Orphans {
+	return &c.orphans
+}
+
 // haveFiles returns whether all of the provided files exist within current storage.
 func (c *Cleaner) haveFiles(ctx context.Context, files ...string) (bool, error) {
 	for _, file := range files {
@@ -170,5 +186,25 @@ func (c *Cleaner) ScheduleJobs() error {
 		panic("failed to schedule @mediacleanup")
 	}
 
+	expireFn := func(ctx context.Context, start time.Time) {
+		// Runs often, so only log when
+		// there was anything to expire.
+		if n, err := c.Media().Expire(ctx); err != nil {
+			log.Error(ctx, err)
+		} else if n > 0 {
+			log.Infof(ctx, "expired media: %d", n)
+		}
+	}
+
+	// Schedule expiring media to run on its own, more often.
+	if !c.state.Workers.Scheduler.AddRecurring(
+		"@mediaexpiry",
+		now.Add(expireEvery),
+		expireEvery,
+		expireFn,
+	) {
+		panic("failed to schedule @mediaexpiry")
+	}
+
 	return nil
 }
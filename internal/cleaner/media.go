@@ -20,6 +20,7 @@ package cleaner
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -30,7 +31,9 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/regexes"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // Media encompasses a set of
@@ -44,9 +47,11 @@ type Media struct {
 func (m *Media) All(ctx context.Context, maxRemoteDays int) {
 	t := time.Now().Add(-24 * time.Hour * time.Duration(maxRemoteDays))
 	m.LogUncacheRemote(ctx, t)
+	m.LogExpire(ctx)
 	m.LogPruneOrphaned(ctx)
 	m.LogPruneUnused(ctx)
 	m.LogFixCacheStates(ctx)
+	m.LogDedupe(ctx)
 	_ = m.state.Storage.Storage.Clean(ctx)
 }
 
@@ -60,6 +65,16 @@ func (m *Media) LogUncacheRemote(ctx context.Context, olderThan time.Time) {
 	}
 }
 
+// LogExpire performs Media.Expire(...), logging the start and outcome.
+func (m *Media) LogExpire(ctx context.Context) {
+	log.Info(ctx, "start")
+	if n, err := m.Expire(ctx); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "expired: %d", n)
+	}
+}
+
 // LogPruneOrphaned performs Media.PruneOrphaned(...), logging the start and outcome.
 func (m *Media) LogPruneOrphaned(ctx context.Context) {
 	log.Info(ctx, "start")
@@ -90,6 +105,16 @@ func (m *Media) LogFixCacheStates(ctx context.Context) {
 	}
 }
 
+// LogDedupe performs Media.Dedupe(...), logging the start and outcome.
+func (m *Media) LogDedupe(ctx context.Context) {
+	log.Info(ctx, "start")
+	if n, err := m.Dedupe(ctx); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "deduplicated: %d", n)
+	}
+}
+
 // PruneOrphaned will delete orphaned files from storage (i.e. media missing a database entry).
 // Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
 func (m *Media) PruneOrphaned(ctx context.Context) (int, error) {
@@ -97,6 +122,12 @@ func (m *Media) PruneOrphaned(ctx context.Context) (int, error) {
 
 	// All media files in storage will have path fitting: {$account}/{$type}/{$size}/{$id}.{$ext}
 	if err := m.state.Storage.WalkKeys(ctx, func(ctx context.Context, path string) error {
+		if strings.HasPrefix(path, storage.UploadsDir+"/") {
+			// Spooled upload,
+			// not stored media.
+			return nil
+		}
+
 		// Check for our expected fileserver path format.
 		if !regexes.FilePath.MatchString(path) {
 			log.Warn(ctx, "unexpected storage item: %s", path)
@@ -218,6 +249,50 @@ func (m *Media) UncacheRemote(ctx context.Context, olderThan time.Time) (int, er
 	return total, nil
 }
 
+// Expire will delete the files of all media attachments whose expiry time has passed,
+// leaving the attachments in the database as tombstones of type unknown.
+// Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
+func (m *Media) Expire(ctx context.Context) (int, error) {
+	var total int
+
+	// Only consider media
+	// expired as of now.
+	now := time.Now()
+
+	for {
+		// Fetch the next batch of cached attachments that expired before now.
+		attachments, err := m.state.DB.GetCachedAttachmentsExpiredBefore(ctx, now, selectLimit)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return total, gtserror.Newf("error getting expired attachments: %w", err)
+		}
+
+		if len(attachments) == 0 {
+			// Reached the end.
+			break
+		}
+
+		for _, media := range attachments {
+			// Delete each expired media attachment's files.
+			if err := m.expire(ctx, media); err != nil {
+				return total, err
+			}
+
+			// Update
+			// count.
+			total++
+		}
+
+		if gtscontext.DryRun(ctx) {
+			// Nothing is updated in a dry
+			// run, so the same attachments
+			// would be returned again.
+			break
+		}
+	}
+
+	return total, nil
+}
+
 // FixCacheStatus will check all media for up-to-date cache status (i.e. in storage driver).
 // Media marked as cached, with any required files missing, will be automatically uncached.
 // Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
@@ -266,6 +341,58 @@ func (m *Media) FixCacheStates(ctx context.Context) (int, error) {
 	return total, nil
 }
 
+// Dedupe will hash the files of all cached media attachments stored before
+// files were hashed on ingest, deduplicating identical files in storage.
+// Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
+func (m *Media) Dedupe(ctx context.Context) (int, error) {
+	var (
+		total int
+		page  paging.Page
+
+		// File hashes seen, for dry runs.
+		seen = make(map[string]struct{})
+	)
+
+	// Set page select limit.
+	page.Limit = selectLimit
+
+	for {
+		// Fetch the next batch of media attachments up to next max ID.
+		attachments, err := m.state.DB.GetAttachments(ctx, &page)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return total, gtserror.Newf("error getting attachments: %w", err)
+		}
+
+		// Get current max ID.
+		maxID := page.Max.Value
+
+		// If no attachments or the same group is returned, we reached the end.
+		if len(attachments) == 0 || maxID == attachments[len(attachments)-1].ID {
+			break
+		}
+
+		// Use last ID as the next 'maxID' value.
+		maxID = attachments[len(attachments)-1].ID
+		page.Max = paging.MaxID(maxID)
+
+		for _, media := range attachments {
+			// Hash / deduplicate media attachment file.
+			deduped, err := m.dedupe(ctx, media, seen)
+			if err != nil {
+				return total, err
+			}
+
+			if deduped {
+				// Update
+				// count.
+				total++
+			}
+		}
+	}
+
+	return total, nil
+}
+
 func (m *Media) isOrphaned(ctx context.Context, path string) (bool, error) {
 	pathParts := regexes.FilePath.FindStringSubmatch(path)
 	if len(pathParts) != 6 {
@@ -281,6 +408,10 @@ func (m *Media) isOrphaned(ctx context.Context, path string) (bool, error) {
 		// 3rd -> media sub-type (e.g. small, static)
 		mediaID = pathParts[4]
 		// 5th -> file extension
+
+		// Whether this is an original file,
+		// which may be shared by deduplicated attachments.
+		original = media.Size(pathParts[3]) == media.SizeOriginal
 	)
 
 	// Start a log entry for media.
@@ -298,6 +429,33 @@ func (m *Media) isOrphaned(ctx context.Context, path string) (bool, error) {
 			return false, gtserror.Newf("error fetching media by id %s: %w", mediaID, err)
 		}
 
+		if media == nil && original {
+			// Deduplicated files are shared by attachments,
+			// and outlive the attachment that stored them.
+			ids, err := m.state.DB.GetAttachmentIDsByFilePath(ctx, path)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				return false, gtserror.Newf("error fetching media by path %s: %w", path, err)
+			}
+
+			if len(ids) > 0 {
+				l.Debug("skipping as file shared with other media")
+				return false, nil
+			}
+		}
+
+		if media == nil && !original {
+			// Regenerated thumbnails are stored
+			// under a new ID, so check by path.
+			_, err := m.state.DB.GetAttachmentIDByThumbnailPath(ctx, path)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				return false, gtserror.Newf("error fetching media by thumbnail path %s: %w", path, err)
+			}
+
+			if err == nil {
+				return false, nil
+			}
+		}
+
 		if media == nil {
 			l.Debug("missing db entry for media")
 			return true, nil
@@ -328,6 +486,18 @@ func (m *Media) isOrphaned(ctx context.Context, path string) (bool, error) {
 			l.Debug("missing db entry for emoji")
 			return true, nil
 		}
+
+	case media.TypeExport:
+		// Look for account export in database stored by ID.
+		export, err := m.state.DB.GetAccountExportByID(ctx, mediaID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return false, gtserror.Newf("error fetching export by id %s: %w", mediaID, err)
+		}
+
+		if export == nil {
+			l.Debug("missing db entry for export")
+			return true, nil
+		}
 	}
 
 	return false, nil
@@ -376,6 +546,16 @@ func (m *Media) pruneUnused(ctx context.Context, media *gtsmodel.MediaAttachment
 		}
 	}
 
+	// Check whether media is waiting to be
+	// published along with a scheduled status.
+	scheduled, err := m.getScheduledStatus(ctx, media)
+	if err != nil {
+		return false, err
+	} else if scheduled != nil {
+		l.Debug("skipping as attached to scheduled status")
+		return false, nil
+	}
+
 	// Media totally unused, delete it.
 	l.Debug("deleting unused media")
 	return true, m.delete(ctx, media)
@@ -422,10 +602,13 @@ func (m *Media) fixCacheState(ctx context.Context, media *gtsmodel.MediaAttachme
 	case !*media.Cached && exist:
 		// Remove files if we don't expect them to exist.
 		l.Debug("cached=false exists=true => deleting")
-		_, err := m.removeFiles(ctx,
-			media.Thumbnail.Path,
-			media.File.Path,
-		)
+		unlock := m.manager.LockFile(media)
+		defer unlock()
+		files, err := m.manager.RemovablePaths(ctx, media)
+		if err != nil {
+			return false, err
+		}
+		_, err = m.removeFiles(ctx, files...)
 		return true, err
 
 	default:
@@ -481,6 +664,16 @@ func (m *Media) uncacheRemote(ctx context.Context, after time.Time, media *gtsmo
 		}
 	}
 
+	// Uncaching media whose file is shared with
+	// other cached media wouldn't free up space.
+	shared, err := m.manager.FileShared(ctx, media)
+	if err != nil {
+		return false, err
+	} else if shared {
+		l.Debug("skipping as file shared with other media")
+		return false, nil
+	}
+
 	// This media is too old, uncache it.
 	l.Debug("uncaching old remote media")
 	return true, m.uncache(ctx, media)
@@ -532,18 +725,43 @@ func (m *Media) getRelatedStatus(ctx context.Context, media *gtsmodel.MediaAttac
 	return status, false, nil
 }
 
+func (m *Media) getScheduledStatus(ctx context.Context, media *gtsmodel.MediaAttachment) (*gtsmodel.ScheduledStatus, error) {
+	if media.ScheduledStatusID == "" {
+		// no scheduled status.
+		return nil, nil
+	}
+
+	// Load the scheduled status this media is waiting for.
+	scheduled, err := m.state.DB.GetScheduledStatusByID(
+		gtscontext.SetBarebones(ctx),
+		media.ScheduledStatusID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("error fetching scheduled status by id %s: %w", media.ScheduledStatusID, err)
+	}
+
+	return scheduled, nil
+}
+
 func (m *Media) uncache(ctx context.Context, media *gtsmodel.MediaAttachment) error {
 	if gtscontext.DryRun(ctx) {
 		// Dry run, do nothing.
 		return nil
 	}
 
-	// Remove media and thumbnail.
-	_, err := m.removeFiles(ctx,
-		media.File.Path,
-		media.Thumbnail.Path,
-	)
+	// Lock the file so it can't be
+	// deduplicated onto meanwhile.
+	unlock := m.manager.LockFile(media)
+	defer unlock()
+
+	// Remove media and thumbnail, keeping
+	// any files still used elsewhere.
+	files, err := m.manager.RemovablePaths(ctx, media)
 	if err != nil {
+		return err
+	}
+
+	if _, err := m.removeFiles(ctx, files...); err != nil {
 		return gtserror.Newf("error removing media files: %w", err)
 	}
 
@@ -557,18 +775,67 @@ func (m *Media) uncache(ctx context.Context, media *gtsmodel.MediaAttachment) er
 	return nil
 }
 
+func (m *Media) expire(ctx context.Context, media *gtsmodel.MediaAttachment) error {
+	if gtscontext.DryRun(ctx) {
+		// Dry run, do nothing.
+		return nil
+	}
+
+	// Lock the file so it can't be
+	// deduplicated onto meanwhile.
+	unlock := m.manager.LockFile(media)
+	defer unlock()
+
+	// Remove media and thumbnail, keeping
+	// any files still used elsewhere.
+	files, err := m.manager.RemovablePaths(ctx, media)
+	if err != nil {
+		return err
+	}
+
+	if _, err := m.removeFiles(ctx, files...); err != nil {
+		return gtserror.Newf("error removing media files: %w", err)
+	}
+
+	// Update attachment to a tombstone, pointing to nothing.
+	log.Debugf(ctx, "marking media attachment as expired: %s", media.ID)
+	media.Type = gtsmodel.FileTypeUnknown
+	media.Cached = util.Ptr(false)
+	media.URL = ""
+	media.Thumbnail.URL = ""
+	media.Blurhash = ""
+	if err := m.state.DB.UpdateAttachment(ctx, media,
+		"type",
+		"cached",
+		"url",
+		"thumbnail_url",
+		"blurhash",
+	); err != nil {
+		return gtserror.Newf("error updating media: %w", err)
+	}
+
+	return nil
+}
+
 func (m *Media) delete(ctx context.Context, media *gtsmodel.MediaAttachment) error {
 	if gtscontext.DryRun(ctx) {
 		// Dry run, do nothing.
 		return nil
 	}
 
-	// Remove media and thumbnail.
-	_, err := m.removeFiles(ctx,
-		media.File.Path,
-		media.Thumbnail.Path,
-	)
+	// Lock the file so it can't be
+	// deduplicated onto meanwhile.
+	unlock := m.manager.LockFile(media)
+	defer unlock()
+
+	// Remove media and thumbnail, keeping
+	// any files still used elsewhere.
+	files, err := m.manager.RemovablePaths(ctx, media)
 	if err != nil {
+		return err
+	}
+
+	if _, err := m.removeFiles(ctx, files...); err != nil {
 		return gtserror.Newf("error removing media files: %w", err)
 	}
 
@@ -580,3 +847,58 @@ func (m *Media) delete(ctx context.Context, media *gtsmodel.MediaAttachment) err
 
 	return nil
 }
+
+func (m *Media) dedupe(ctx context.Context, media *gtsmodel.MediaAttachment, seen map[string]struct{}) (bool, error) {
+	if !*media.Cached || media.File.Hash != "" ||
+		media.Type == gtsmodel.FileTypeUnknown {
+		// Not stored, or
+		// already hashed.
+		return false, nil
+	}
+
+	// Start a log entry for media.
+	l := log.WithContext(ctx).
+		WithField("media", media.ID)
+
+	hash, err := m.manager.HashFile(ctx, media)
+	if err != nil {
+		// FixCacheStates will
+		// take care of this.
+		l.Debugf("skipping as file not readable: %v", err)
+		return false, nil
+	}
+
+	if gtscontext.DryRun(ctx) {
+		// Dry run, hashes aren't stored, so check
+		// for identical files hashed in this run,
+		// or already hashed files in the database.
+		if _, ok := seen[hash]; ok {
+			return true, nil
+		}
+		seen[hash] = struct{}{}
+
+		others, err := m.state.DB.GetAttachmentsByFileHash(ctx, hash)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return false, gtserror.Newf("error fetching media by hash: %w", err)
+		}
+		return len(others) > 0, nil
+	}
+
+	// Update attachment with its file hash,
+	// before deduplicating by that hash.
+	media.File.Hash = hash
+	if err := m.state.DB.UpdateAttachment(ctx, media, "file_hash"); err != nil {
+		return false, gtserror.Newf("error updating media: %w", err)
+	}
+
+	deduped, err := m.manager.DedupeFile(ctx, media)
+	if err != nil {
+		return false, err
+	}
+
+	if deduped {
+		l.Debug("deduplicated media file")
+	}
+
+	return deduped, nil
+}
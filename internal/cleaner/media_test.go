@@ -352,6 +352,49 @@ func (suite *MediaTestSuite) TestUncacheRemoteTwice() {
 	suite.Equal(0, totalUncachedAgain)
 }
 
+func (suite *MediaTestSuite) TestExpire() {
+	ctx := context.Background()
+
+	// Nothing expires to begin with.
+	totalExpired, err := suite.cleaner.Media().Expire(ctx)
+	suite.NoError(err)
+	suite.Equal(0, totalExpired)
+
+	// Set an expiry in the past on a local attachment.
+	testAttachment := suite.testAttachments["local_account_1_status_4_attachment_1"]
+	testAttachment.ExpiresAt = time.Now().Add(-time.Minute)
+	if err := suite.db.UpdateAttachment(ctx, testAttachment, "expires_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	totalExpired, err = suite.cleaner.Media().Expire(ctx)
+	suite.NoError(err)
+	suite.Equal(1, totalExpired)
+
+	// Attachment should now be a tombstone.
+	expiredAttachment, err := suite.db.GetAttachmentByID(ctx, testAttachment.ID)
+	suite.NoError(err)
+	suite.Equal(gtsmodel.FileTypeUnknown, expiredAttachment.Type)
+	suite.False(*expiredAttachment.Cached)
+	suite.Empty(expiredAttachment.URL)
+	suite.True(expiredAttachment.IsExpired())
+
+	// Its files should be gone from storage.
+	for _, path := range []string{
+		testAttachment.File.Path,
+		testAttachment.Thumbnail.Path,
+	} {
+		has, err := suite.storage.Has(ctx, path)
+		suite.NoError(err)
+		suite.False(has)
+	}
+
+	// Expiring again should do nothing.
+	totalExpired, err = suite.cleaner.Media().Expire(ctx)
+	suite.NoError(err)
+	suite.Equal(0, totalExpired)
+}
+
 func (suite *MediaTestSuite) TestUncacheAndRecache() {
 	ctx := context.Background()
 	testStatusAttachment := suite.testAttachments["remote_account_1_status_1_attachment_1"]
@@ -382,7 +425,7 @@ func (suite *MediaTestSuite) TestUncacheAndRecache() {
 		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
 	}
 
-	for _, original := range []*gtsmodel.MediaAttachment{
+	for i, original := range []*gtsmodel.MediaAttachment{
 		testStatusAttachment,
 		testHeader,
 	} {
@@ -397,7 +440,12 @@ func (suite *MediaTestSuite) TestUncacheAndRecache() {
 		// recachedAttachment should be basically the same as the old attachment
 		suite.True(*recachedAttachment.Cached)
 		suite.Equal(original.ID, recachedAttachment.ID)
-		suite.Equal(original.File.Path, recachedAttachment.File.Path)           // file should be stored in the same place
+		if i == 0 {
+			suite.Equal(original.File.Path, recachedAttachment.File.Path) // file should be stored in the same place
+		} else {
+			// same image as the first, so it should be deduplicated onto its file
+			suite.Equal(testStatusAttachment.File.Path, recachedAttachment.File.Path)
+		}
 		suite.Equal(original.Thumbnail.Path, recachedAttachment.Thumbnail.Path) // as should the thumbnail
 		suite.EqualValues(original.FileMeta, recachedAttachment.FileMeta)       // and the filemeta should be the same
 
@@ -426,3 +474,66 @@ func (suite *MediaTestSuite) TestUncacheOneNonExistent() {
 	suite.NoError(err)
 	suite.Equal(3, totalUncached)
 }
+
+func (suite *MediaTestSuite) TestDedupe() {
+	ctx := context.Background()
+	testAttachment := suite.testAttachments["local_account_1_status_4_attachment_1"]
+
+	// Store a copy of an attachment with another ID,
+	// as though it had been uploaded again before
+	// files were hashed on ingest.
+	b, err := suite.storage.Get(ctx, testAttachment.File.Path)
+	suite.NoError(err)
+
+	duplicate := new(gtsmodel.MediaAttachment)
+	*duplicate = *testAttachment
+	duplicate.ID = "01J0G7ZDYQ7N5PEZEB5EW3TR8G"
+	duplicate.StatusID = ""
+	duplicate.File.Path = "01F8MH1H7YV1Z7D2C8K2730QBF/attachment/original/01J0G7ZDYQ7N5PEZEB5EW3TR8G.gif"
+	duplicate.Thumbnail.Path = "01F8MH1H7YV1Z7D2C8K2730QBF/attachment/small/01J0G7ZDYQ7N5PEZEB5EW3TR8G.jpg"
+	_, err = suite.storage.Put(ctx, duplicate.File.Path, b)
+	suite.NoError(err)
+	suite.NoError(suite.db.PutAttachment(ctx, duplicate))
+
+	// Dry run should only count the duplicate.
+	totalDeduped, err := suite.cleaner.Media().Dedupe(gtscontext.SetDryRun(ctx))
+	suite.NoError(err)
+	suite.Equal(1, totalDeduped)
+
+	totalDeduped, err = suite.cleaner.Media().Dedupe(ctx)
+	suite.NoError(err)
+	suite.Equal(1, totalDeduped)
+
+	original, err := suite.db.GetAttachmentByID(ctx, testAttachment.ID)
+	suite.NoError(err)
+	suite.NotEmpty(original.File.Hash)
+
+	deduped, err := suite.db.GetAttachmentByID(ctx, duplicate.ID)
+	suite.NoError(err)
+	suite.Equal(original.File.Hash, deduped.File.Hash)
+
+	// Both attachments now share one file; newest media
+	// is hashed first, so the original was deduplicated
+	// onto the file of the duplicate, and removed.
+	suite.Equal(duplicate.File.Path, original.File.Path)
+	suite.Equal(duplicate.File.Path, deduped.File.Path)
+	have, err := suite.storage.Has(ctx, testAttachment.File.Path)
+	suite.NoError(err)
+	suite.False(have)
+
+	// Running again should find nothing left to do.
+	totalDeduped, err = suite.cleaner.Media().Dedupe(ctx)
+	suite.NoError(err)
+	suite.Zero(totalDeduped)
+
+	// The shared file mustn't be pruned as orphaned,
+	// even once the attachment that stored it is gone.
+	suite.NoError(suite.db.DeleteAttachment(ctx, duplicate.ID))
+	totalPruned, err := suite.cleaner.Media().PruneOrphaned(ctx)
+	suite.NoError(err)
+	suite.Zero(totalPruned)
+
+	have, err = suite.storage.Has(ctx, original.File.Path)
+	suite.NoError(err)
+	suite.True(have)
+}
@@ -0,0 +1,173 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cleaner
+
+import (
+	"context"
+	"errors"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// Orphans encompasses a set of utils for
+// finding and cleaning up database rows
+// that point at entries that no longer exist.
+type Orphans struct {
+	*Cleaner
+}
+
+// OrphanCounts contains the number
+// of orphaned rows found, by category.
+type OrphanCounts struct {
+	// Attachments is the number of media
+	// attachments whose status is missing.
+	Attachments int
+
+	// Mentions is the number of mentions
+	// whose originating status is missing.
+	Mentions int
+
+	// Notifications is the number of notifications
+	// whose target or origin account is missing.
+	Notifications int
+}
+
+// Total returns the total number of orphaned rows.
+func (c OrphanCounts) Total() int {
+	return c.Attachments + c.Mentions + c.Notifications
+}
+
+// LogPrune performs Orphans.Prune(...), logging the start and outcome.
+func (o *Orphans) LogPrune(ctx context.Context) {
+	log.Info(ctx, "start")
+	counts, err := o.Prune(ctx)
+	if err != nil {
+		log.Error(ctx, err)
+	}
+	log.Infof(ctx, "pruned: attachments=%d mentions=%d notifications=%d",
+		counts.Attachments, counts.Mentions, counts.Notifications)
+}
+
+// Prune finds all orphaned database rows (media attachments, mentions, notifications)
+// and deletes them, returning the number found in each category. Media attachment
+// files are removed from storage alongside their database entries.
+// Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
+func (o *Orphans) Prune(ctx context.Context) (OrphanCounts, error) {
+	var (
+		counts OrphanCounts
+		errs   gtserror.MultiError
+	)
+
+	// Prune attachments of missing statuses.
+	n, err := o.pruneAttachments(ctx)
+	counts.Attachments = n
+	if err != nil {
+		errs.Append(err)
+	}
+
+	// Prune mentions of missing statuses.
+	n, err = o.pruneMentions(ctx)
+	counts.Mentions = n
+	if err != nil {
+		errs.Append(err)
+	}
+
+	// Prune notifications of missing accounts.
+	n, err = o.pruneNotifications(ctx)
+	counts.Notifications = n
+	if err != nil {
+		errs.Append(err)
+	}
+
+	return counts, errs.Combine()
+}
+
+func (o *Orphans) pruneAttachments(ctx context.Context) (int, error) {
+	ids, err := o.state.DB.GetOrphanedAttachmentIDs(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return 0, gtserror.Newf("error getting orphaned attachments: %w", err)
+	}
+
+	if gtscontext.DryRun(ctx) {
+		// Dry run, do nothing.
+		return len(ids), nil
+	}
+
+	for _, id := range ids {
+		media, err := o.state.DB.GetAttachmentByID(gtscontext.SetBarebones(ctx), id)
+		if err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				// Already gone.
+				continue
+			}
+			return 0, gtserror.Newf("error getting attachment %s: %w", id, err)
+		}
+
+		// Remove files and database entry.
+		if err := o.media.delete(ctx, media); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(ids), nil
+}
+
+func (o *Orphans) pruneMentions(ctx context.Context) (int, error) {
+	ids, err := o.state.DB.GetOrphanedMentionIDs(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return 0, gtserror.Newf("error getting orphaned mentions: %w", err)
+	}
+
+	if gtscontext.DryRun(ctx) {
+		// Dry run, do nothing.
+		return len(ids), nil
+	}
+
+	for _, id := range ids {
+		log.Debugf(ctx, "deleting mention: %s", id)
+		if err := o.state.DB.DeleteMentionByID(ctx, id); err != nil {
+			return 0, gtserror.Newf("error deleting mention %s: %w", id, err)
+		}
+	}
+
+	return len(ids), nil
+}
+
+func (o *Orphans) pruneNotifications(ctx context.Context) (int, error) {
+	ids, err := o.state.DB.GetOrphanedNotificationIDs(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return 0, gtserror.Newf("error getting orphaned notifications: %w", err)
+	}
+
+	if gtscontext.DryRun(ctx) {
+		// Dry run, do nothing.
+		return len(ids), nil
+	}
+
+	for _, id := range ids {
+		log.Debugf(ctx, "deleting notification: %s", id)
+		if err := o.state.DB.DeleteNotificationByID(ctx, id); err != nil {
+			return 0, gtserror.Newf("error deleting notification %s: %w", id, err)
+		}
+	}
+
+	return len(ids), nil
+}
@@ -0,0 +1,96 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cleaner_test
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/cleaner"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+func (suite *CleanerTestSuite) TestPruneOrphans() {
+	ctx := context.Background()
+
+	// There should be no orphans
+	// in the standard test data.
+	counts, err := suite.cleaner.Orphans().Prune(gtscontext.SetDryRun(ctx))
+	suite.NoError(err)
+	suite.Equal(cleaner.OrphanCounts{}, counts)
+
+	var (
+		missingStatusID  = id.NewULID()
+		missingAccountID = id.NewULID()
+		testAccount      = testrig.NewTestAccounts()["local_account_1"]
+	)
+
+	// Attachment of a status that doesn't exist.
+	attachment := testrig.NewTestAttachments()["local_account_1_status_4_attachment_1"]
+	attachment.ID = id.NewULID()
+	attachment.StatusID = missingStatusID
+	if err := suite.state.DB.PutAttachment(ctx, attachment); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Mention from a status that doesn't exist.
+	mention := &gtsmodel.Mention{
+		ID:               id.NewULID(),
+		StatusID:         missingStatusID,
+		OriginAccountID:  testAccount.ID,
+		OriginAccountURI: testAccount.URI,
+		TargetAccountID:  testAccount.ID,
+	}
+	if err := suite.state.DB.PutMention(ctx, mention); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Notification from an account that doesn't exist.
+	notif := &gtsmodel.Notification{
+		ID:               id.NewULID(),
+		NotificationType: gtsmodel.NotificationFollow,
+		TargetAccountID:  testAccount.ID,
+		OriginAccountID:  missingAccountID,
+	}
+	if err := suite.state.DB.PutNotification(ctx, notif); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	expect := cleaner.OrphanCounts{
+		Attachments:   1,
+		Mentions:      1,
+		Notifications: 1,
+	}
+
+	// Dry run should find them, without deleting.
+	counts, err = suite.cleaner.Orphans().Prune(gtscontext.SetDryRun(ctx))
+	suite.NoError(err)
+	suite.Equal(expect, counts)
+
+	// Real run should find and delete them.
+	counts, err = suite.cleaner.Orphans().Prune(ctx)
+	suite.NoError(err)
+	suite.Equal(expect, counts)
+
+	// Nothing left to find.
+	counts, err = suite.cleaner.Orphans().Prune(gtscontext.SetDryRun(ctx))
+	suite.NoError(err)
+	suite.Equal(cleaner.OrphanCounts{}, counts)
+}
@@ -55,6 +55,7 @@ type Configuration struct {
 	Host               string   `name:"host" usage:"Hostname to use for the server (eg., example.org, gotosocial.whatever.com). DO NOT change this on a server that's already run!"`
 	AccountDomain      string   `name:"account-domain" usage:"Domain to use in account names (eg., example.org, whatever.com). If not set, will default to the setting for host. DO NOT change this on a server that's already run!"`
 	Protocol           string   `name:"protocol" usage:"Protocol to use for the REST api of the server (only use http if you are debugging or behind a reverse proxy!)"`
+	OnionHost          string   `name:"onion-host" usage:"Hostname of a Tor onion service serving this instance as well as host (eg., xyz.onion), which will be advertised to web browsers and federating instances."`
 	BindAddress        string   `name:"bind-address" usage:"Bind address to use for the GoToSocial server (eg., 0.0.0.0, 172.138.0.9, [::], localhost). For ipv6, enclose the address in square brackets, eg [2001:db8::fed1]. Default binds to all interfaces."`
 	Port               int      `name:"port" usage:"Port to use for GoToSocial. Change this to 443 if you're running the binary directly on the host machine."`
 	TrustedProxies     []string `name:"trusted-proxies" usage:"Proxies to trust when parsing x-forwarded headers into real IPs."`
@@ -74,29 +75,50 @@ type Configuration struct {
 	DbSqliteCacheSize        bytesize.Size `name:"db-sqlite-cache-size" usage:"Sqlite only: see https://www.sqlite.org/pragma.html#pragma_cache_size"`
 	DbSqliteBusyTimeout      time.Duration `name:"db-sqlite-busy-timeout" usage:"Sqlite only: see https://www.sqlite.org/pragma.html#pragma_busy_timeout"`
 
-	WebTemplateBaseDir string `name:"web-template-base-dir" usage:"Basedir for html templating files for rendering pages and composing emails."`
-	WebAssetBaseDir    string `name:"web-asset-base-dir" usage:"Directory to serve static assets from, accessible at example.org/assets/"`
+	WebTemplateBaseDir            string `name:"web-template-base-dir" usage:"Basedir for html templating files for rendering pages and composing emails."`
+	WebAssetBaseDir               string `name:"web-asset-base-dir" usage:"Directory to serve static assets from, accessible at example.org/assets/"`
+	WebRSSTitleLength             int    `name:"web-rss-title-length" usage:"Maximum length (characters) of the titles of items in account RSS feeds."`
+	WebRSSDescriptionLength       int    `name:"web-rss-description-length" usage:"Maximum length (characters) of the descriptions of items in account RSS feeds."`
+	WebOpenGraphDescriptionLength int    `name:"web-opengraph-description-length" usage:"Maximum length (characters) of the og:description meta tag of web pages, used for link previews."`
 
-	InstanceFederationMode         string             `name:"instance-federation-mode" usage:"Set instance federation mode."`
-	InstanceFederationSpamFilter   bool               `name:"instance-federation-spam-filter" usage:"Enable basic spam filter heuristics for messages coming from other instances, and drop messages identified as spam"`
-	InstanceExposePeers            bool               `name:"instance-expose-peers" usage:"Allow unauthenticated users to query /api/v1/instance/peers?filter=open"`
-	InstanceExposeSuspended        bool               `name:"instance-expose-suspended" usage:"Expose suspended instances via web UI, and allow unauthenticated users to query /api/v1/instance/peers?filter=suspended"`
-	InstanceExposeSuspendedWeb     bool               `name:"instance-expose-suspended-web" usage:"Expose list of suspended instances as webpage on /about/suspended"`
-	InstanceExposePublicTimeline   bool               `name:"instance-expose-public-timeline" usage:"Allow unauthenticated users to query /api/v1/timelines/public"`
-	InstanceDeliverToSharedInboxes bool               `name:"instance-deliver-to-shared-inboxes" usage:"Deliver federated messages to shared inboxes, if they're available."`
-	InstanceInjectMastodonVersion  bool               `name:"instance-inject-mastodon-version" usage:"This injects a Mastodon compatible version in /api/v1/instance to help Mastodon clients that use that version for feature detection"`
-	InstanceLanguages              language.Languages `name:"instance-languages" usage:"BCP47 language tags for the instance. Used to indicate the preferred languages of instance residents (in order from most-preferred to least-preferred)."`
+	InstanceFederationMode               string             `name:"instance-federation-mode" usage:"Set instance federation mode."`
+	InstanceFederationSpamFilter         bool               `name:"instance-federation-spam-filter" usage:"Enable basic spam filter heuristics for messages coming from other instances, and drop messages identified as spam"`
+	InstanceExposePeers                  bool               `name:"instance-expose-peers" usage:"Allow unauthenticated users to query /api/v1/instance/peers?filter=open"`
+	InstanceExposeSuspended              bool               `name:"instance-expose-suspended" usage:"Expose suspended instances via web UI, and allow unauthenticated users to query /api/v1/instance/peers?filter=suspended"`
+	InstanceExposeSuspendedWeb           bool               `name:"instance-expose-suspended-web" usage:"Expose list of suspended instances as webpage on /about/suspended"`
+	InstanceExposeExplore                bool               `name:"instance-expose-explore" usage:"Expose trending posts, trending hashtags and suggested accounts as webpage on /explore"`
+	InstanceExposePublicTimeline         bool               `name:"instance-expose-public-timeline" usage:"Allow unauthenticated users to query /api/v1/timelines/public"`
+	InstanceDeliverToSharedInboxes       bool               `name:"instance-deliver-to-shared-inboxes" usage:"Deliver federated messages to shared inboxes, if they're available."`
+	InstanceInjectMastodonVersion        bool               `name:"instance-inject-mastodon-version" usage:"This injects a Mastodon compatible version in /api/v1/instance to help Mastodon clients that use that version for feature detection"`
+	InstanceLanguages                    language.Languages `name:"instance-languages" usage:"BCP47 language tags for the instance. Used to indicate the preferred languages of instance residents (in order from most-preferred to least-preferred)."`
+	InstanceBlockedInteractionsThreshold int                `name:"instance-blocked-interactions-threshold" usage:"Number of attempts by a blocked remote account to interact with the local account blocking it, within instance-blocked-interactions-window, after which a report will be opened automatically. 0 to disable."`
+	InstanceBlockedInteractionsWindow    time.Duration      `name:"instance-blocked-interactions-window" usage:"Window of time within which attempts to interact by blocked remote accounts are counted towards instance-blocked-interactions-threshold."`
+	InstanceInboxMaxSize                 bytesize.Size      `name:"instance-inbox-max-size" usage:"Max size in bytes of activities posted to inboxes by other instances. 0 for no limit."`
+	InstanceInboxMaxRecipients           int                `name:"instance-inbox-max-recipients" usage:"Max number of recipients (to, cc, bto, bcc, audience) of activities, and objects in them, posted to inboxes by other instances. 0 for no limit."`
+	InstanceInboxMaxTags                 int                `name:"instance-inbox-max-tags" usage:"Max number of hashtags and mentions on objects in activities posted to inboxes by other instances. 0 for no limit."`
+	InstanceInboxMaxAttachments          int                `name:"instance-inbox-max-attachments" usage:"Max number of attachments on objects in activities posted to inboxes by other instances. 0 for no limit."`
+	InstanceInboxMaxEmojis               int                `name:"instance-inbox-max-emojis" usage:"Max number of custom emojis on objects in activities posted to inboxes by other instances. 0 for no limit."`
 
 	AccountsRegistrationOpen bool `name:"accounts-registration-open" usage:"Allow anyone to submit an account signup request. If false, server will be invite-only."`
 	AccountsReasonRequired   bool `name:"accounts-reason-required" usage:"Do new account signups require a reason to be submitted on registration?"`
 	AccountsAllowCustomCSS   bool `name:"accounts-allow-custom-css" usage:"Allow accounts to enable custom CSS for their profile pages and statuses."`
 	AccountsCustomCSSLength  int  `name:"accounts-custom-css-length" usage:"Maximum permitted length (characters) of custom CSS for accounts."`
 
+	AccountsReservedUsernames []string `name:"accounts-reserved-usernames" usage:"Usernames that can't be taken by new accounts on this instance."`
+	AccountsUsernameMinLength int      `name:"accounts-username-min-length" usage:"Minimum length (characters) of usernames of new accounts on this instance."`
+	AccountsUsernameMaxLength int      `name:"accounts-username-max-length" usage:"Maximum length (characters) of usernames of new accounts on this instance. Can't be more than 64."`
+	AccountsUsernamePattern   string   `name:"accounts-username-pattern" usage:"Regular expression that usernames of new accounts on this instance must match, on top of only containing lowercase letters, numbers and underscores. Leave empty to allow any such username."`
+	AccountsMinimumAge        int      `name:"accounts-minimum-age" usage:"Minimum age in years that users must confirm being at least, when signing up and when the value changes. 0 to not ask users to confirm their age."`
+
 	MediaImageMaxSize        bytesize.Size `name:"media-image-max-size" usage:"Max size of accepted images in bytes"`
 	MediaVideoMaxSize        bytesize.Size `name:"media-video-max-size" usage:"Max size of accepted videos in bytes"`
+	MediaAllowedMIMETypes    []string      `name:"media-allowed-mime-types" usage:"MIME types that local users are allowed to upload as media, eg., 'image/jpeg'. Types are detected from file contents rather than extension or Content-Type. If empty, all supported types are allowed."`
 	MediaDescriptionMinChars int           `name:"media-description-min-chars" usage:"Min required chars for an image description"`
 	MediaDescriptionMaxChars int           `name:"media-description-max-chars" usage:"Max permitted chars for an image description"`
+	MediaPreserveOrientation bool          `name:"media-preserve-orientation" usage:"Keep the orientation tag when stripping EXIF metadata from uploaded JPEG images, so that they're displayed the right way up."`
 	MediaRemoteCacheDays     int           `name:"media-remote-cache-days" usage:"Number of days to locally cache media from remote instances. If set to 0, remote media will be kept indefinitely."`
+	MediaAccountLazyFetch    bool          `name:"media-account-lazy-fetch" usage:"Don't fetch avatars and headers of remote accounts when the accounts are discovered, only when they're first viewed by a local client. Saves storage and bandwidth on instances that know many accounts."`
+	MediaUnknownPlaceholder  string        `name:"media-unknown-placeholder" usage:"What to append to the content of statuses with attachments that can't be shown, for clients that don't understand the unknown_media_attachments field: 'note', 'links', or 'none'."`
 	MediaEmojiLocalMaxSize   bytesize.Size `name:"media-emoji-local-max-size" usage:"Max size in bytes of emojis uploaded to this instance via the admin API."`
 	MediaEmojiRemoteMaxSize  bytesize.Size `name:"media-emoji-remote-max-size" usage:"Max size in bytes of emojis to download from other instances."`
 	MediaCleanupFrom         string        `name:"media-cleanup-from" usage:"Time of day from which to start running media cleanup/prune jobs. Should be in the format 'hh:mm:ss', eg., '15:04:05'."`
@@ -111,10 +133,11 @@ type Configuration struct {
 	StorageS3BucketName  string `name:"storage-s3-bucket" usage:"Place blobs in this bucket"`
 	StorageS3Proxy       bool   `name:"storage-s3-proxy" usage:"Proxy S3 contents through GoToSocial instead of redirecting to a presigned URL"`
 
-	StatusesMaxChars           int `name:"statuses-max-chars" usage:"Max permitted characters for posted statuses, including content warning"`
-	StatusesPollMaxOptions     int `name:"statuses-poll-max-options" usage:"Max amount of options permitted on a poll"`
-	StatusesPollOptionMaxChars int `name:"statuses-poll-option-max-chars" usage:"Max amount of characters for a poll option"`
-	StatusesMediaMaxFiles      int `name:"statuses-media-max-files" usage:"Maximum number of media files/attachments per status"`
+	StatusesMaxChars           int  `name:"statuses-max-chars" usage:"Max permitted characters for posted statuses, including content warning"`
+	StatusesPollMaxOptions     int  `name:"statuses-poll-max-options" usage:"Max amount of options permitted on a poll"`
+	StatusesPollOptionMaxChars int  `name:"statuses-poll-option-max-chars" usage:"Max amount of characters for a poll option"`
+	StatusesMediaMaxFiles      int  `name:"statuses-media-max-files" usage:"Maximum number of media files/attachments per status"`
+	StatusesSearchIndex        bool `name:"statuses-search-index" usage:"Maintain a full-text search index of statuses, so that users can search the text of their own statuses, bookmarks and favourites"`
 
 	LetsEncryptEnabled      bool   `name:"letsencrypt-enabled" usage:"Enable letsencrypt TLS certs for this server. If set to true, then cert dir also needs to be set (or take the default)."`
 	LetsEncryptPort         int    `name:"letsencrypt-port" usage:"Port to listen on for letsencrypt certificate challenges. Must not be the same as the GtS webserver/API port."`
@@ -152,18 +175,32 @@ type Configuration struct {
 	SMTPFrom               string `name:"smtp-from" usage:"Address to use as the 'from' field of the email. Eg., 'gotosocial@example.org'"`
 	SMTPDiscloseRecipients bool   `name:"smtp-disclose-recipients" usage:"If true, email notifications sent to multiple recipients will be To'd to every recipient at once. If false, recipients will not be disclosed"`
 
+	WebPushVAPIDPublicKey  string `name:"web-push-vapid-public-key" usage:"Base64url encoded P-256 public key used to identify this instance to Web Push services. If this and web-push-vapid-private-key are empty, a key pair is generated on startup."`
+	WebPushVAPIDPrivateKey string `name:"web-push-vapid-private-key" usage:"Base64url encoded P-256 private key used to sign requests to Web Push services. If this and web-push-vapid-public-key are empty, a key pair is generated on startup."`
+
 	SyslogEnabled  bool   `name:"syslog-enabled" usage:"Enable the syslog logging hook. Logs will be mirrored to the configured destination."`
 	SyslogProtocol string `name:"syslog-protocol" usage:"Protocol to use when directing logs to syslog. Leave empty to connect to local syslog."`
 	SyslogAddress  string `name:"syslog-address" usage:"Address:port to send syslog logs to. Leave empty to connect to local syslog."`
 
-	AdvancedCookiesSamesite      string        `name:"advanced-cookies-samesite" usage:"'strict' or 'lax', see https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Set-Cookie/SameSite"`
-	AdvancedRateLimitRequests    int           `name:"advanced-rate-limit-requests" usage:"Amount of HTTP requests to permit within a 5 minute window. 0 or less turns rate limiting off."`
-	AdvancedRateLimitExceptions  []string      `name:"advanced-rate-limit-exceptions" usage:"Slice of CIDRs to exclude from rate limit restrictions."`
-	AdvancedThrottlingMultiplier int           `name:"advanced-throttling-multiplier" usage:"Multiplier to use per cpu for http request throttling. 0 or less turns throttling off."`
-	AdvancedThrottlingRetryAfter time.Duration `name:"advanced-throttling-retry-after" usage:"Retry-After duration response to send for throttled requests."`
-	AdvancedSenderMultiplier     int           `name:"advanced-sender-multiplier" usage:"Multiplier to use per cpu for batching outgoing fedi messages. 0 or less turns batching off (not recommended)."`
-	AdvancedCSPExtraURIs         []string      `name:"advanced-csp-extra-uris" usage:"Additional URIs to allow when building content-security-policy for media + images."`
-	AdvancedHeaderFilterMode     string        `name:"advanced-header-filter-mode" usage:"Set incoming request header filtering mode."`
+	AdvancedCookiesSamesite                string        `name:"advanced-cookies-samesite" usage:"'strict' or 'lax', see https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Set-Cookie/SameSite"`
+	AdvancedRateLimitRequests              int           `name:"advanced-rate-limit-requests" usage:"Amount of HTTP requests to permit within a 5 minute window. 0 or less turns rate limiting off."`
+	AdvancedRateLimitExceptions            []string      `name:"advanced-rate-limit-exceptions" usage:"Slice of CIDRs to exclude from rate limit restrictions."`
+	AdvancedThrottlingMultiplier           int           `name:"advanced-throttling-multiplier" usage:"Multiplier to use per cpu for http request throttling. 0 or less turns throttling off."`
+	AdvancedThrottlingRetryAfter           time.Duration `name:"advanced-throttling-retry-after" usage:"Retry-After duration response to send for throttled requests."`
+	AdvancedSenderMultiplier               int           `name:"advanced-sender-multiplier" usage:"Multiplier to use per cpu for batching outgoing fedi messages. 0 or less turns batching off (not recommended)."`
+	AdvancedCSPExtraURIs                   []string      `name:"advanced-csp-extra-uris" usage:"Additional URIs to allow when building content-security-policy for media + images."`
+	AdvancedCORSAllowOrigins               []string      `name:"advanced-cors-allow-origins" usage:"Origins to allow cross-origin requests from. If empty, all origins are allowed."`
+	AdvancedCORSWebClients                 []string      `name:"advanced-cors-web-clients" usage:"URLs of first-party web clients to allow cross-origin requests from, and advertise at /api/v1/instance/web_clients."`
+	AdvancedHeaderFilterMode               string        `name:"advanced-header-filter-mode" usage:"Set incoming request header filtering mode."`
+	AdvancedThreadMaxAncestors             int           `name:"advanced-thread-max-ancestors" usage:"Maximum number of parent statuses to dereference upwards from a remote status. 0 or less is normalized to 1."`
+	AdvancedThreadMaxDepth                 int           `name:"advanced-thread-max-depth" usage:"Maximum depth of replies to dereference downwards from a remote status. 0 or less is normalized to 1."`
+	AdvancedThreadMaxDescendants           int           `name:"advanced-thread-max-descendants" usage:"Maximum number of replies to dereference downwards from a remote status, each time its thread is dereferenced. 0 or less is normalized to 1."`
+	AdvancedDereferenceBudget              int           `name:"advanced-dereference-budget" usage:"Maximum number of remote fetches (parent statuses, mentioned accounts, emojis, media) one incoming activity may trigger before the rest are deferred to the background. 0 or less turns the budget off."`
+	AdvancedTimelineStorage                string        `name:"advanced-timeline-storage" usage:"Where to store home and list timelines: 'memory' or 'database'."`
+	AdvancedDeliveryLogRetention           time.Duration `name:"advanced-delivery-log-retention" usage:"How long to keep entries in the per-account log of outgoing federated deliveries. 0 turns the log off."`
+	AdvancedStreamingMaxConnections        int           `name:"advanced-streaming-max-connections" usage:"Maximum number of streaming API connections open at a time in total. 0 or less turns the limit off."`
+	AdvancedStreamingMaxAccountConnections int           `name:"advanced-streaming-max-account-connections" usage:"Maximum number of streaming API connections open at a time per account. 0 or less turns the limit off."`
+	AdvancedStreamingIdleTimeout           time.Duration `name:"advanced-streaming-idle-timeout" usage:"Close streaming API connections that haven't answered a ping or sent a message for this long. 0 turns the timeout off."`
 
 	// HTTPClient configuration vars.
 	HTTPClient HTTPClientConfiguration `name:"http-client"`
@@ -175,11 +212,20 @@ type Configuration struct {
 	AdminAccountUsername     string `name:"username" usage:"the username to create/delete/etc"`
 	AdminAccountEmail        string `name:"email" usage:"the email address of this account"`
 	AdminAccountPassword     string `name:"password" usage:"the password to set for this account"`
+	AdminAccountScopes       string `name:"scopes" usage:"space-separated OAuth scopes to grant to a bot account's access token"`
+	AdminAccountRateLimit    int    `name:"status-rate-limit" usage:"maximum number of statuses a bot account may post per hour; 0 means no limit"`
 	AdminTransPath           string `name:"path" usage:"the path of the file to import from/export to"`
 	AdminMediaPruneDryRun    bool   `name:"dry-run" usage:"perform a dry run and only log number of items eligible for pruning"`
 	AdminMediaListLocalOnly  bool   `name:"local-only" usage:"list only local attachments/emojis; if specified then remote-only cannot also be true"`
 	AdminMediaListRemoteOnly bool   `name:"remote-only" usage:"list only remote attachments/emojis; if specified then local-only cannot also be true"`
 
+	TestrigSeedAccounts      int `name:"seed-accounts" usage:"number of synthetic local accounts to create"`
+	TestrigSeedFollows       int `name:"seed-follows" usage:"number of other synthetic accounts that each synthetic account follows"`
+	TestrigSeedStatuses      int `name:"seed-statuses" usage:"number of statuses to create for each synthetic account"`
+	TestrigSeedMedia         int `name:"seed-media" usage:"number of each synthetic account's statuses to attach a generated image to"`
+	TestrigSeedNotifications int `name:"seed-notifications" usage:"number of faves (and fave notifications) to create for each synthetic account"`
+	TestrigSeedRandom        int `name:"seed" usage:"seed for the random number generator; the same seed always generates the same accounts, follows, and status contents"`
+
 	RequestIDHeader string `name:"request-id-header" usage:"Header to extract the Request ID from. Eg.,'X-Request-Id'."`
 }
 
@@ -188,51 +234,61 @@ type HTTPClientConfiguration struct {
 	BlockIPs              []string      `name:"block-ips"`
 	Timeout               time.Duration `name:"timeout"`
 	TLSInsecureSkipVerify bool          `name:"tls-insecure-skip-verify"`
+	Proxy                 string        `name:"proxy"`
+	ProxyRules            []string      `name:"proxy-rules"`
+	IPVersion             string        `name:"ip-version"`
+	HappyEyeballsDelay    time.Duration `name:"happy-eyeballs-delay"`
+	Resolver              string        `name:"resolver"`
 }
 
 type CacheConfiguration struct {
-	MemoryTarget             bytesize.Size `name:"memory-target"`
-	AccountMemRatio          float64       `name:"account-mem-ratio"`
-	AccountNoteMemRatio      float64       `name:"account-note-mem-ratio"`
-	AccountSettingsMemRatio  float64       `name:"account-settings-mem-ratio"`
-	AccountStatsMemRatio     float64       `name:"account-stats-mem-ratio"`
-	ApplicationMemRatio      float64       `name:"application-mem-ratio"`
-	BlockMemRatio            float64       `name:"block-mem-ratio"`
-	BlockIDsMemRatio         float64       `name:"block-mem-ratio"`
-	BoostOfIDsMemRatio       float64       `name:"boost-of-ids-mem-ratio"`
-	ClientMemRatio           float64       `name:"client-mem-ratio"`
-	EmojiMemRatio            float64       `name:"emoji-mem-ratio"`
-	EmojiCategoryMemRatio    float64       `name:"emoji-category-mem-ratio"`
-	FilterMemRatio           float64       `name:"filter-mem-ratio"`
-	FilterKeywordMemRatio    float64       `name:"filter-keyword-mem-ratio"`
-	FilterStatusMemRatio     float64       `name:"filter-status-mem-ratio"`
-	FollowMemRatio           float64       `name:"follow-mem-ratio"`
-	FollowIDsMemRatio        float64       `name:"follow-ids-mem-ratio"`
-	FollowRequestMemRatio    float64       `name:"follow-request-mem-ratio"`
-	FollowRequestIDsMemRatio float64       `name:"follow-request-ids-mem-ratio"`
-	InReplyToIDsMemRatio     float64       `name:"in-reply-to-ids-mem-ratio"`
-	InstanceMemRatio         float64       `name:"instance-mem-ratio"`
-	ListMemRatio             float64       `name:"list-mem-ratio"`
-	ListEntryMemRatio        float64       `name:"list-entry-mem-ratio"`
-	MarkerMemRatio           float64       `name:"marker-mem-ratio"`
-	MediaMemRatio            float64       `name:"media-mem-ratio"`
-	MentionMemRatio          float64       `name:"mention-mem-ratio"`
-	MoveMemRatio             float64       `name:"move-mem-ratio"`
-	NotificationMemRatio     float64       `name:"notification-mem-ratio"`
-	PollMemRatio             float64       `name:"poll-mem-ratio"`
-	PollVoteMemRatio         float64       `name:"poll-vote-mem-ratio"`
-	PollVoteIDsMemRatio      float64       `name:"poll-vote-ids-mem-ratio"`
-	ReportMemRatio           float64       `name:"report-mem-ratio"`
-	StatusMemRatio           float64       `name:"status-mem-ratio"`
-	StatusFaveMemRatio       float64       `name:"status-fave-mem-ratio"`
-	StatusFaveIDsMemRatio    float64       `name:"status-fave-ids-mem-ratio"`
-	TagMemRatio              float64       `name:"tag-mem-ratio"`
-	ThreadMuteMemRatio       float64       `name:"thread-mute-mem-ratio"`
-	TokenMemRatio            float64       `name:"token-mem-ratio"`
-	TombstoneMemRatio        float64       `name:"tombstone-mem-ratio"`
-	UserMemRatio             float64       `name:"user-mem-ratio"`
-	WebfingerMemRatio        float64       `name:"webfinger-mem-ratio"`
-	VisibilityMemRatio       float64       `name:"visibility-mem-ratio"`
+	MemoryTarget              bytesize.Size `name:"memory-target"`
+	AccountMemRatio           float64       `name:"account-mem-ratio"`
+	AccountNoteMemRatio       float64       `name:"account-note-mem-ratio"`
+	AccountSettingsMemRatio   float64       `name:"account-settings-mem-ratio"`
+	AccountStatsMemRatio      float64       `name:"account-stats-mem-ratio"`
+	ApplicationMemRatio       float64       `name:"application-mem-ratio"`
+	BlockMemRatio             float64       `name:"block-mem-ratio"`
+	BlockIDsMemRatio          float64       `name:"block-mem-ratio"`
+	BoostOfIDsMemRatio        float64       `name:"boost-of-ids-mem-ratio"`
+	CardMemRatio              float64       `name:"card-mem-ratio"`
+	ClientMemRatio            float64       `name:"client-mem-ratio"`
+	EmojiMemRatio             float64       `name:"emoji-mem-ratio"`
+	EmojiCategoryMemRatio     float64       `name:"emoji-category-mem-ratio"`
+	FilterMemRatio            float64       `name:"filter-mem-ratio"`
+	FilterKeywordMemRatio     float64       `name:"filter-keyword-mem-ratio"`
+	FilterStatusMemRatio      float64       `name:"filter-status-mem-ratio"`
+	FollowMemRatio            float64       `name:"follow-mem-ratio"`
+	FollowIDsMemRatio         float64       `name:"follow-ids-mem-ratio"`
+	FollowRequestMemRatio     float64       `name:"follow-request-mem-ratio"`
+	FollowRequestIDsMemRatio  float64       `name:"follow-request-ids-mem-ratio"`
+	FeaturedTagMemRatio       float64       `name:"featured-tag-mem-ratio"`
+	FollowedTagMemRatio       float64       `name:"followed-tag-mem-ratio"`
+	InReplyToIDsMemRatio      float64       `name:"in-reply-to-ids-mem-ratio"`
+	InstanceMemRatio          float64       `name:"instance-mem-ratio"`
+	ListMemRatio              float64       `name:"list-mem-ratio"`
+	ListEntryMemRatio         float64       `name:"list-entry-mem-ratio"`
+	MarkerMemRatio            float64       `name:"marker-mem-ratio"`
+	MediaMemRatio             float64       `name:"media-mem-ratio"`
+	MentionMemRatio           float64       `name:"mention-mem-ratio"`
+	MoveMemRatio              float64       `name:"move-mem-ratio"`
+	NotificationMemRatio      float64       `name:"notification-mem-ratio"`
+	PollMemRatio              float64       `name:"poll-mem-ratio"`
+	PollVoteMemRatio          float64       `name:"poll-vote-mem-ratio"`
+	PollVoteIDsMemRatio       float64       `name:"poll-vote-ids-mem-ratio"`
+	ReportMemRatio            float64       `name:"report-mem-ratio"`
+	StatusMemRatio            float64       `name:"status-mem-ratio"`
+	StatusFaveMemRatio        float64       `name:"status-fave-mem-ratio"`
+	StatusFaveIDsMemRatio     float64       `name:"status-fave-ids-mem-ratio"`
+	StatusReactionMemRatio    float64       `name:"status-reaction-mem-ratio"`
+	StatusReactionIDsMemRatio float64       `name:"status-reaction-ids-mem-ratio"`
+	TagMemRatio               float64       `name:"tag-mem-ratio"`
+	ThreadMuteMemRatio        float64       `name:"thread-mute-mem-ratio"`
+	TokenMemRatio             float64       `name:"token-mem-ratio"`
+	TombstoneMemRatio         float64       `name:"tombstone-mem-ratio"`
+	UserMemRatio              float64       `name:"user-mem-ratio"`
+	WebfingerMemRatio         float64       `name:"webfinger-mem-ratio"`
+	VisibilityMemRatio        float64       `name:"visibility-mem-ratio"`
 }
 
 // MarshalMap will marshal current Configuration into a map structure (useful for JSON/TOML/YAML).
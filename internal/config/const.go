@@ -29,4 +29,16 @@ const (
 	RequestHeaderFilterModeAllow    = "allow"
 	RequestHeaderFilterModeBlock    = "block"
 	RequestHeaderFilterModeDisabled = ""
+
+	// Unknown media placeholder determines what
+	// is appended to the content of statuses
+	// with attachments that can't be shown.
+	MediaUnknownPlaceholderNote  = "note"
+	MediaUnknownPlaceholderLinks = "links"
+	MediaUnknownPlaceholderNone  = "none"
+
+	// Timeline storage determines where
+	// home and list timelines are kept.
+	TimelineStorageMemory   = "memory"
+	TimelineStorageDatabase = "database"
 )
@@ -55,27 +55,62 @@ var Defaults = Configuration{
 	DbSqliteCacheSize:        8 * bytesize.MiB,
 	DbSqliteBusyTimeout:      time.Minute * 30,
 
-	WebTemplateBaseDir: "./web/template/",
-	WebAssetBaseDir:    "./web/assets/",
+	WebTemplateBaseDir:            "./web/template/",
+	WebAssetBaseDir:               "./web/assets/",
+	WebRSSTitleLength:             128,
+	WebRSSDescriptionLength:       256,
+	WebOpenGraphDescriptionLength: 300,
 
-	InstanceFederationMode:         InstanceFederationModeDefault,
-	InstanceFederationSpamFilter:   false,
-	InstanceExposePeers:            false,
-	InstanceExposeSuspended:        false,
-	InstanceExposeSuspendedWeb:     false,
-	InstanceDeliverToSharedInboxes: true,
-	InstanceLanguages:              make(language.Languages, 0),
+	InstanceFederationMode:               InstanceFederationModeDefault,
+	InstanceFederationSpamFilter:         false,
+	InstanceExposePeers:                  false,
+	InstanceExposeSuspended:              false,
+	InstanceExposeSuspendedWeb:           false,
+	InstanceExposeExplore:                false,
+	InstanceDeliverToSharedInboxes:       true,
+	InstanceLanguages:                    make(language.Languages, 0),
+	InstanceBlockedInteractionsThreshold: 0,
+	InstanceBlockedInteractionsWindow:    24 * time.Hour,
+	InstanceInboxMaxSize:                 1 * bytesize.MiB,
+	InstanceInboxMaxRecipients:           1024,
+	InstanceInboxMaxTags:                 256,
+	InstanceInboxMaxAttachments:          32,
+	InstanceInboxMaxEmojis:               256,
 
 	AccountsRegistrationOpen: false,
 	AccountsReasonRequired:   true,
 	AccountsAllowCustomCSS:   false,
 	AccountsCustomCSSLength:  10000,
 
+	AccountsReservedUsernames: []string{
+		"admin",
+		"administrator",
+		"root",
+		"webmaster",
+		"hostmaster",
+		"postmaster",
+		"abuse",
+		"security",
+		"support",
+		"moderator",
+		"staff",
+		"noreply",
+		"no_reply",
+	},
+	AccountsUsernameMinLength: 1,
+	AccountsUsernameMaxLength: 64,
+	AccountsUsernamePattern:   "",
+	AccountsMinimumAge:        0,
+
 	MediaImageMaxSize:        10 * bytesize.MiB,
 	MediaVideoMaxSize:        40 * bytesize.MiB,
+	MediaAllowedMIMETypes:    []string{},
 	MediaDescriptionMinChars: 0,
 	MediaDescriptionMaxChars: 1500,
+	MediaPreserveOrientation: true,
 	MediaRemoteCacheDays:     7,
+	MediaAccountLazyFetch:    false,
+	MediaUnknownPlaceholder:  MediaUnknownPlaceholderNote,
 	MediaEmojiLocalMaxSize:   50 * bytesize.KiB,
 	MediaEmojiRemoteMaxSize:  100 * bytesize.KiB,
 	MediaCleanupFrom:         "00:00",        // Midnight.
@@ -90,6 +125,7 @@ var Defaults = Configuration{
 	StatusesPollMaxOptions:     6,
 	StatusesPollOptionMaxChars: 50,
 	StatusesMediaMaxFiles:      6,
+	StatusesSearchIndex:        false,
 
 	LetsEncryptEnabled:      false,
 	LetsEncryptPort:         80,
@@ -115,6 +151,9 @@ var Defaults = Configuration{
 	SMTPFrom:               "",
 	SMTPDiscloseRecipients: false,
 
+	WebPushVAPIDPublicKey:  "",
+	WebPushVAPIDPrivateKey: "",
+
 	TracingEnabled:           false,
 	TracingTransport:         "grpc",
 	TracingEndpoint:          "",
@@ -127,14 +166,25 @@ var Defaults = Configuration{
 	SyslogProtocol: "udp",
 	SyslogAddress:  "localhost:514",
 
-	AdvancedCookiesSamesite:      "lax",
-	AdvancedRateLimitRequests:    300, // 1 per second per 5 minutes
-	AdvancedRateLimitExceptions:  []string{},
-	AdvancedThrottlingMultiplier: 8, // 8 open requests per CPU
-	AdvancedThrottlingRetryAfter: time.Second * 30,
-	AdvancedSenderMultiplier:     2, // 2 senders per CPU
-	AdvancedCSPExtraURIs:         []string{},
-	AdvancedHeaderFilterMode:     RequestHeaderFilterModeDisabled,
+	AdvancedCookiesSamesite:                "lax",
+	AdvancedRateLimitRequests:              300, // 1 per second per 5 minutes
+	AdvancedRateLimitExceptions:            []string{},
+	AdvancedThrottlingMultiplier:           8, // 8 open requests per CPU
+	AdvancedThrottlingRetryAfter:           time.Second * 30,
+	AdvancedSenderMultiplier:               2, // 2 senders per CPU
+	AdvancedCSPExtraURIs:                   []string{},
+	AdvancedCORSAllowOrigins:               []string{},
+	AdvancedCORSWebClients:                 []string{},
+	AdvancedHeaderFilterMode:               RequestHeaderFilterModeDisabled,
+	AdvancedThreadMaxAncestors:             256,
+	AdvancedThreadMaxDepth:                 64,
+	AdvancedThreadMaxDescendants:           256,
+	AdvancedDereferenceBudget:              32,
+	AdvancedTimelineStorage:                TimelineStorageMemory,
+	AdvancedDeliveryLogRetention:           24 * time.Hour,
+	AdvancedStreamingMaxConnections:        0,
+	AdvancedStreamingMaxAccountConnections: 20,
+	AdvancedStreamingIdleTimeout:           5 * time.Minute,
 
 	Cache: CacheConfiguration{
 		// Rough memory target that the total
@@ -156,47 +206,52 @@ var Defaults = Configuration{
 		// when TODO items in the size.go source
 		// file have been addressed, these should
 		// be able to make some more sense :D
-		AccountMemRatio:          5,
-		AccountNoteMemRatio:      1,
-		AccountSettingsMemRatio:  0.1,
-		AccountStatsMemRatio:     2,
-		ApplicationMemRatio:      0.1,
-		BlockMemRatio:            2,
-		BlockIDsMemRatio:         3,
-		BoostOfIDsMemRatio:       3,
-		ClientMemRatio:           0.1,
-		EmojiMemRatio:            3,
-		EmojiCategoryMemRatio:    0.1,
-		FilterMemRatio:           0.5,
-		FilterKeywordMemRatio:    0.5,
-		FilterStatusMemRatio:     0.5,
-		FollowMemRatio:           2,
-		FollowIDsMemRatio:        4,
-		FollowRequestMemRatio:    2,
-		FollowRequestIDsMemRatio: 2,
-		InReplyToIDsMemRatio:     3,
-		InstanceMemRatio:         1,
-		ListMemRatio:             1,
-		ListEntryMemRatio:        2,
-		MarkerMemRatio:           0.5,
-		MediaMemRatio:            4,
-		MentionMemRatio:          2,
-		MoveMemRatio:             0.1,
-		NotificationMemRatio:     2,
-		PollMemRatio:             1,
-		PollVoteMemRatio:         2,
-		PollVoteIDsMemRatio:      2,
-		ReportMemRatio:           1,
-		StatusMemRatio:           5,
-		StatusFaveMemRatio:       2,
-		StatusFaveIDsMemRatio:    3,
-		TagMemRatio:              2,
-		ThreadMuteMemRatio:       0.2,
-		TokenMemRatio:            0.75,
-		TombstoneMemRatio:        0.5,
-		UserMemRatio:             0.25,
-		WebfingerMemRatio:        0.1,
-		VisibilityMemRatio:       2,
+		AccountMemRatio:           5,
+		AccountNoteMemRatio:       1,
+		AccountSettingsMemRatio:   0.1,
+		AccountStatsMemRatio:      2,
+		ApplicationMemRatio:       0.1,
+		BlockMemRatio:             2,
+		BlockIDsMemRatio:          3,
+		BoostOfIDsMemRatio:        3,
+		CardMemRatio:              1,
+		ClientMemRatio:            0.1,
+		EmojiMemRatio:             3,
+		EmojiCategoryMemRatio:     0.1,
+		FilterMemRatio:            0.5,
+		FilterKeywordMemRatio:     0.5,
+		FilterStatusMemRatio:      0.5,
+		FollowMemRatio:            2,
+		FollowIDsMemRatio:         4,
+		FollowRequestMemRatio:     2,
+		FollowRequestIDsMemRatio:  2,
+		FeaturedTagMemRatio:       1,
+		FollowedTagMemRatio:       1,
+		InReplyToIDsMemRatio:      3,
+		InstanceMemRatio:          1,
+		ListMemRatio:              1,
+		ListEntryMemRatio:         2,
+		MarkerMemRatio:            0.5,
+		MediaMemRatio:             4,
+		MentionMemRatio:           2,
+		MoveMemRatio:              0.1,
+		NotificationMemRatio:      2,
+		PollMemRatio:              1,
+		PollVoteMemRatio:          2,
+		PollVoteIDsMemRatio:       2,
+		ReportMemRatio:            1,
+		StatusMemRatio:            5,
+		StatusFaveMemRatio:        2,
+		StatusFaveIDsMemRatio:     3,
+		StatusReactionMemRatio:    1,
+		StatusReactionIDsMemRatio: 2,
+		TagMemRatio:               2,
+		ThreadMuteMemRatio:        0.2,
+		TokenMemRatio:             0.75,
+		TombstoneMemRatio:         0.5,
+		UserMemRatio:              0.25,
+		WebfingerMemRatio:         0.1,
+		VisibilityMemRatio:        2,
 	},
 
 	HTTPClient: HTTPClientConfiguration{
@@ -204,10 +259,23 @@ var Defaults = Configuration{
 		BlockIPs:              make([]string, 0),
 		Timeout:               10 * time.Second,
 		TLSInsecureSkipVerify: false,
+		Proxy:                 "",
+		ProxyRules:            make([]string, 0),
+		IPVersion:             "",
+		HappyEyeballsDelay:    300 * time.Millisecond,
+		Resolver:              "",
 	},
 
+	AdminAccountScopes:    "read write",
 	AdminMediaPruneDryRun: true,
 
+	TestrigSeedAccounts:      100,
+	TestrigSeedFollows:       20,
+	TestrigSeedStatuses:      50,
+	TestrigSeedMedia:         2,
+	TestrigSeedNotifications: 20,
+	TestrigSeedRandom:        1,
+
 	RequestIDHeader: "X-Request-Id",
 
 	LogClientIP: true,
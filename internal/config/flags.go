@@ -37,6 +37,7 @@ func (s *ConfigState) AddGlobalFlags(cmd *cobra.Command) {
 		cmd.PersistentFlags().String(HostFlag(), cfg.Host, fieldtag("Host", "usage"))
 		cmd.PersistentFlags().String(AccountDomainFlag(), cfg.AccountDomain, fieldtag("AccountDomain", "usage"))
 		cmd.PersistentFlags().String(ProtocolFlag(), cfg.Protocol, fieldtag("Protocol", "usage"))
+		cmd.PersistentFlags().String(OnionHostFlag(), cfg.OnionHost, fieldtag("OnionHost", "usage"))
 		cmd.PersistentFlags().String(LogLevelFlag(), cfg.LogLevel, fieldtag("LogLevel", "usage"))
 		cmd.PersistentFlags().String(LogTimestampFormatFlag(), cfg.LogTimestampFormat, fieldtag("LogTimestampFormat", "usage"))
 		cmd.PersistentFlags().Bool(LogDbQueriesFlag(), cfg.LogDbQueries, fieldtag("LogDbQueries", "usage"))
@@ -62,6 +63,11 @@ func (s *ConfigState) AddGlobalFlags(cmd *cobra.Command) {
 		cmd.PersistentFlags().StringSlice(HTTPClientBlockIPsFlag(), cfg.HTTPClient.BlockIPs, "no usage string")
 		cmd.PersistentFlags().Duration(HTTPClientTimeoutFlag(), cfg.HTTPClient.Timeout, "no usage string")
 		cmd.PersistentFlags().Bool(HTTPClientTLSInsecureSkipVerifyFlag(), cfg.HTTPClient.TLSInsecureSkipVerify, "no usage string")
+		cmd.PersistentFlags().String(HTTPClientProxyFlag(), cfg.HTTPClient.Proxy, "no usage string")
+		cmd.PersistentFlags().StringSlice(HTTPClientProxyRulesFlag(), cfg.HTTPClient.ProxyRules, "no usage string")
+		cmd.PersistentFlags().String(HTTPClientIPVersionFlag(), cfg.HTTPClient.IPVersion, "no usage string")
+		cmd.PersistentFlags().Duration(HTTPClientHappyEyeballsDelayFlag(), cfg.HTTPClient.HappyEyeballsDelay, "no usage string")
+		cmd.PersistentFlags().String(HTTPClientResolverFlag(), cfg.HTTPClient.Resolver, "no usage string")
 	})
 }
 
@@ -81,6 +87,9 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		// Template
 		cmd.Flags().String(WebTemplateBaseDirFlag(), cfg.WebTemplateBaseDir, fieldtag("WebTemplateBaseDir", "usage"))
 		cmd.Flags().String(WebAssetBaseDirFlag(), cfg.WebAssetBaseDir, fieldtag("WebAssetBaseDir", "usage"))
+		cmd.Flags().Int(WebRSSTitleLengthFlag(), cfg.WebRSSTitleLength, fieldtag("WebRSSTitleLength", "usage"))
+		cmd.Flags().Int(WebRSSDescriptionLengthFlag(), cfg.WebRSSDescriptionLength, fieldtag("WebRSSDescriptionLength", "usage"))
+		cmd.Flags().Int(WebOpenGraphDescriptionLengthFlag(), cfg.WebOpenGraphDescriptionLength, fieldtag("WebOpenGraphDescriptionLength", "usage"))
 
 		// Instance
 		cmd.Flags().String(InstanceFederationModeFlag(), cfg.InstanceFederationMode, fieldtag("InstanceFederationMode", "usage"))
@@ -88,20 +97,37 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		cmd.Flags().Bool(InstanceExposePeersFlag(), cfg.InstanceExposePeers, fieldtag("InstanceExposePeers", "usage"))
 		cmd.Flags().Bool(InstanceExposeSuspendedFlag(), cfg.InstanceExposeSuspended, fieldtag("InstanceExposeSuspended", "usage"))
 		cmd.Flags().Bool(InstanceExposeSuspendedWebFlag(), cfg.InstanceExposeSuspendedWeb, fieldtag("InstanceExposeSuspendedWeb", "usage"))
+		cmd.Flags().Bool(InstanceExposeExploreFlag(), cfg.InstanceExposeExplore, fieldtag("InstanceExposeExplore", "usage"))
 		cmd.Flags().Bool(InstanceDeliverToSharedInboxesFlag(), cfg.InstanceDeliverToSharedInboxes, fieldtag("InstanceDeliverToSharedInboxes", "usage"))
 		cmd.Flags().StringSlice(InstanceLanguagesFlag(), cfg.InstanceLanguages.TagStrs(), fieldtag("InstanceLanguages", "usage"))
+		cmd.Flags().Int(InstanceBlockedInteractionsThresholdFlag(), cfg.InstanceBlockedInteractionsThreshold, fieldtag("InstanceBlockedInteractionsThreshold", "usage"))
+		cmd.Flags().Duration(InstanceBlockedInteractionsWindowFlag(), cfg.InstanceBlockedInteractionsWindow, fieldtag("InstanceBlockedInteractionsWindow", "usage"))
+		cmd.Flags().Uint64(InstanceInboxMaxSizeFlag(), uint64(cfg.InstanceInboxMaxSize), fieldtag("InstanceInboxMaxSize", "usage"))
+		cmd.Flags().Int(InstanceInboxMaxRecipientsFlag(), cfg.InstanceInboxMaxRecipients, fieldtag("InstanceInboxMaxRecipients", "usage"))
+		cmd.Flags().Int(InstanceInboxMaxTagsFlag(), cfg.InstanceInboxMaxTags, fieldtag("InstanceInboxMaxTags", "usage"))
+		cmd.Flags().Int(InstanceInboxMaxAttachmentsFlag(), cfg.InstanceInboxMaxAttachments, fieldtag("InstanceInboxMaxAttachments", "usage"))
+		cmd.Flags().Int(InstanceInboxMaxEmojisFlag(), cfg.InstanceInboxMaxEmojis, fieldtag("InstanceInboxMaxEmojis", "usage"))
 
 		// Accounts
 		cmd.Flags().Bool(AccountsRegistrationOpenFlag(), cfg.AccountsRegistrationOpen, fieldtag("AccountsRegistrationOpen", "usage"))
 		cmd.Flags().Bool(AccountsReasonRequiredFlag(), cfg.AccountsReasonRequired, fieldtag("AccountsReasonRequired", "usage"))
 		cmd.Flags().Bool(AccountsAllowCustomCSSFlag(), cfg.AccountsAllowCustomCSS, fieldtag("AccountsAllowCustomCSS", "usage"))
+		cmd.Flags().StringSlice(AccountsReservedUsernamesFlag(), cfg.AccountsReservedUsernames, fieldtag("AccountsReservedUsernames", "usage"))
+		cmd.Flags().Int(AccountsUsernameMinLengthFlag(), cfg.AccountsUsernameMinLength, fieldtag("AccountsUsernameMinLength", "usage"))
+		cmd.Flags().Int(AccountsUsernameMaxLengthFlag(), cfg.AccountsUsernameMaxLength, fieldtag("AccountsUsernameMaxLength", "usage"))
+		cmd.Flags().String(AccountsUsernamePatternFlag(), cfg.AccountsUsernamePattern, fieldtag("AccountsUsernamePattern", "usage"))
+		cmd.Flags().Int(AccountsMinimumAgeFlag(), cfg.AccountsMinimumAge, fieldtag("AccountsMinimumAge", "usage"))
 
 		// Media
 		cmd.Flags().Uint64(MediaImageMaxSizeFlag(), uint64(cfg.MediaImageMaxSize), fieldtag("MediaImageMaxSize", "usage"))
 		cmd.Flags().Uint64(MediaVideoMaxSizeFlag(), uint64(cfg.MediaVideoMaxSize), fieldtag("MediaVideoMaxSize", "usage"))
+		cmd.Flags().StringSlice(MediaAllowedMIMETypesFlag(), cfg.MediaAllowedMIMETypes, fieldtag("MediaAllowedMIMETypes", "usage"))
 		cmd.Flags().Int(MediaDescriptionMinCharsFlag(), cfg.MediaDescriptionMinChars, fieldtag("MediaDescriptionMinChars", "usage"))
 		cmd.Flags().Int(MediaDescriptionMaxCharsFlag(), cfg.MediaDescriptionMaxChars, fieldtag("MediaDescriptionMaxChars", "usage"))
+		cmd.Flags().Bool(MediaPreserveOrientationFlag(), cfg.MediaPreserveOrientation, fieldtag("MediaPreserveOrientation", "usage"))
 		cmd.Flags().Int(MediaRemoteCacheDaysFlag(), cfg.MediaRemoteCacheDays, fieldtag("MediaRemoteCacheDays", "usage"))
+		cmd.Flags().Bool(MediaAccountLazyFetchFlag(), cfg.MediaAccountLazyFetch, fieldtag("MediaAccountLazyFetch", "usage"))
+		cmd.Flags().String(MediaUnknownPlaceholderFlag(), cfg.MediaUnknownPlaceholder, fieldtag("MediaUnknownPlaceholder", "usage"))
 		cmd.Flags().Uint64(MediaEmojiLocalMaxSizeFlag(), uint64(cfg.MediaEmojiLocalMaxSize), fieldtag("MediaEmojiLocalMaxSize", "usage"))
 		cmd.Flags().Uint64(MediaEmojiRemoteMaxSizeFlag(), uint64(cfg.MediaEmojiRemoteMaxSize), fieldtag("MediaEmojiRemoteMaxSize", "usage"))
 		cmd.Flags().String(MediaCleanupFromFlag(), cfg.MediaCleanupFrom, fieldtag("MediaCleanupFrom", "usage"))
@@ -116,6 +142,7 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		cmd.Flags().Int(StatusesPollMaxOptionsFlag(), cfg.StatusesPollMaxOptions, fieldtag("StatusesPollMaxOptions", "usage"))
 		cmd.Flags().Int(StatusesPollOptionMaxCharsFlag(), cfg.StatusesPollOptionMaxChars, fieldtag("StatusesPollOptionMaxChars", "usage"))
 		cmd.Flags().Int(StatusesMediaMaxFilesFlag(), cfg.StatusesMediaMaxFiles, fieldtag("StatusesMediaMaxFiles", "usage"))
+		cmd.Flags().Bool(StatusesSearchIndexFlag(), cfg.StatusesSearchIndex, fieldtag("StatusesSearchIndex", "usage"))
 
 		// LetsEncrypt
 		cmd.Flags().Bool(LetsEncryptEnabledFlag(), cfg.LetsEncryptEnabled, fieldtag("LetsEncryptEnabled", "usage"))
@@ -144,6 +171,10 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		cmd.Flags().String(SMTPFromFlag(), cfg.SMTPFrom, fieldtag("SMTPFrom", "usage"))
 		cmd.Flags().Bool(SMTPDiscloseRecipientsFlag(), cfg.SMTPDiscloseRecipients, fieldtag("SMTPDiscloseRecipients", "usage"))
 
+		// Web Push
+		cmd.Flags().String(WebPushVAPIDPublicKeyFlag(), cfg.WebPushVAPIDPublicKey, fieldtag("WebPushVAPIDPublicKey", "usage"))
+		cmd.Flags().String(WebPushVAPIDPrivateKeyFlag(), cfg.WebPushVAPIDPrivateKey, fieldtag("WebPushVAPIDPrivateKey", "usage"))
+
 		// Syslog
 		cmd.Flags().Bool(SyslogEnabledFlag(), cfg.SyslogEnabled, fieldtag("SyslogEnabled", "usage"))
 		cmd.Flags().String(SyslogProtocolFlag(), cfg.SyslogProtocol, fieldtag("SyslogProtocol", "usage"))
@@ -157,7 +188,18 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		cmd.Flags().Duration(AdvancedThrottlingRetryAfterFlag(), cfg.AdvancedThrottlingRetryAfter, fieldtag("AdvancedThrottlingRetryAfter", "usage"))
 		cmd.Flags().Int(AdvancedSenderMultiplierFlag(), cfg.AdvancedSenderMultiplier, fieldtag("AdvancedSenderMultiplier", "usage"))
 		cmd.Flags().StringSlice(AdvancedCSPExtraURIsFlag(), cfg.AdvancedCSPExtraURIs, fieldtag("AdvancedCSPExtraURIs", "usage"))
+		cmd.Flags().StringSlice(AdvancedCORSAllowOriginsFlag(), cfg.AdvancedCORSAllowOrigins, fieldtag("AdvancedCORSAllowOrigins", "usage"))
+		cmd.Flags().StringSlice(AdvancedCORSWebClientsFlag(), cfg.AdvancedCORSWebClients, fieldtag("AdvancedCORSWebClients", "usage"))
 		cmd.Flags().String(AdvancedHeaderFilterModeFlag(), cfg.AdvancedHeaderFilterMode, fieldtag("AdvancedHeaderFilterMode", "usage"))
+		cmd.Flags().Int(AdvancedThreadMaxAncestorsFlag(), cfg.AdvancedThreadMaxAncestors, fieldtag("AdvancedThreadMaxAncestors", "usage"))
+		cmd.Flags().Int(AdvancedThreadMaxDepthFlag(), cfg.AdvancedThreadMaxDepth, fieldtag("AdvancedThreadMaxDepth", "usage"))
+		cmd.Flags().Int(AdvancedThreadMaxDescendantsFlag(), cfg.AdvancedThreadMaxDescendants, fieldtag("AdvancedThreadMaxDescendants", "usage"))
+		cmd.Flags().Int(AdvancedDereferenceBudgetFlag(), cfg.AdvancedDereferenceBudget, fieldtag("AdvancedDereferenceBudget", "usage"))
+		cmd.Flags().String(AdvancedTimelineStorageFlag(), cfg.AdvancedTimelineStorage, fieldtag("AdvancedTimelineStorage", "usage"))
+		cmd.Flags().Duration(AdvancedDeliveryLogRetentionFlag(), cfg.AdvancedDeliveryLogRetention, fieldtag("AdvancedDeliveryLogRetention", "usage"))
+		cmd.Flags().Int(AdvancedStreamingMaxConnectionsFlag(), cfg.AdvancedStreamingMaxConnections, fieldtag("AdvancedStreamingMaxConnections", "usage"))
+		cmd.Flags().Int(AdvancedStreamingMaxAccountConnectionsFlag(), cfg.AdvancedStreamingMaxAccountConnections, fieldtag("AdvancedStreamingMaxAccountConnections", "usage"))
+		cmd.Flags().Duration(AdvancedStreamingIdleTimeoutFlag(), cfg.AdvancedStreamingIdleTimeout, fieldtag("AdvancedStreamingIdleTimeout", "usage"))
 
 		cmd.Flags().String(RequestIDHeaderFlag(), cfg.RequestIDHeader, fieldtag("RequestIDHeader", "usage"))
 	})
@@ -183,6 +225,16 @@ func AddAdminAccountPassword(cmd *cobra.Command) {
 	}
 }
 
+// AddAdminAccountEmail attaches flags pertaining to admin account email change.
+func AddAdminAccountEmail(cmd *cobra.Command) {
+	name := AdminAccountEmailFlag()
+	usage := fieldtag("AdminAccountEmail", "usage")
+	cmd.Flags().String(name, "", usage) // REQUIRED
+	if err := cmd.MarkFlagRequired(name); err != nil {
+		panic(err)
+	}
+}
+
 // AddAdminAccountCreate attaches flags pertaining to admin account creation.
 func AddAdminAccountCreate(cmd *cobra.Command) {
 	// Requires both account and password
@@ -197,6 +249,47 @@ func AddAdminAccountCreate(cmd *cobra.Command) {
 	}
 }
 
+// AddAdminAccountBotCreate attaches flags pertaining to admin bot account creation.
+func AddAdminAccountBotCreate(cmd *cobra.Command) {
+	// Requires account, password is optional.
+	AddAdminAccount(cmd)
+
+	name := AdminAccountEmailFlag()
+	usage := fieldtag("AdminAccountEmail", "usage")
+	cmd.Flags().String(name, "", usage) // REQUIRED
+	if err := cmd.MarkFlagRequired(name); err != nil {
+		panic(err)
+	}
+
+	name = AdminAccountPasswordFlag()
+	usage = fieldtag("AdminAccountPassword", "usage")
+	cmd.Flags().String(name, "", usage)
+
+	name = AdminAccountScopesFlag()
+	usage = fieldtag("AdminAccountScopes", "usage")
+	cmd.Flags().String(name, Defaults.AdminAccountScopes, usage)
+
+	name = AdminAccountRateLimitFlag()
+	usage = fieldtag("AdminAccountRateLimit", "usage")
+	cmd.Flags().Int(name, Defaults.AdminAccountRateLimit, usage)
+}
+
+// AddAdminAccountRecount attaches flags pertaining to admin account stats recount.
+func AddAdminAccountRecount(cmd *cobra.Command) {
+	// Username is optional; if not
+	// set, all accounts are recounted.
+	name := AdminAccountUsernameFlag()
+	usage := fieldtag("AdminAccountUsername", "usage")
+	cmd.Flags().String(name, "", usage)
+}
+
+// AddAdminDatabaseOrphans attaches flags pertaining to database orphan pruning commands.
+func AddAdminDatabaseOrphans(cmd *cobra.Command) {
+	name := AdminMediaPruneDryRunFlag()
+	usage := fieldtag("AdminMediaPruneDryRun", "usage")
+	cmd.Flags().Bool(name, true, usage)
+}
+
 // AddAdminTrans attaches flags pertaining to import/export commands.
 func AddAdminTrans(cmd *cobra.Command) {
 	name := AdminTransPathFlag()
@@ -224,3 +317,34 @@ func AddAdminMediaPrune(cmd *cobra.Command) {
 	usage := fieldtag("AdminMediaPruneDryRun", "usage")
 	cmd.Flags().Bool(name, true, usage)
 }
+
+// AddTestrigSeed attaches flags pertaining to the testrig seed command.
+func AddTestrigSeed(cmd *cobra.Command) {
+	// Seeded accounts all share this
+	// password, so they can log in.
+	AddAdminAccountPassword(cmd)
+
+	name := TestrigSeedAccountsFlag()
+	usage := fieldtag("TestrigSeedAccounts", "usage")
+	cmd.Flags().Int(name, Defaults.TestrigSeedAccounts, usage)
+
+	name = TestrigSeedFollowsFlag()
+	usage = fieldtag("TestrigSeedFollows", "usage")
+	cmd.Flags().Int(name, Defaults.TestrigSeedFollows, usage)
+
+	name = TestrigSeedStatusesFlag()
+	usage = fieldtag("TestrigSeedStatuses", "usage")
+	cmd.Flags().Int(name, Defaults.TestrigSeedStatuses, usage)
+
+	name = TestrigSeedMediaFlag()
+	usage = fieldtag("TestrigSeedMedia", "usage")
+	cmd.Flags().Int(name, Defaults.TestrigSeedMedia, usage)
+
+	name = TestrigSeedNotificationsFlag()
+	usage = fieldtag("TestrigSeedNotifications", "usage")
+	cmd.Flags().Int(name, Defaults.TestrigSeedNotifications, usage)
+
+	name = TestrigSeedRandomFlag()
+	usage = fieldtag("TestrigSeedRandom", "usage")
+	cmd.Flags().Int(name, Defaults.TestrigSeedRandom, usage)
+}
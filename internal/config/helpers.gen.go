@@ -275,6 +275,31 @@ func GetProtocol() string { return global.GetProtocol() }
 // SetProtocol safely sets the value for global configuration 'Protocol' field
 func SetProtocol(v string) { global.SetProtocol(v) }
 
+// GetOnionHost safely fetches the Configuration value for state's 'OnionHost' field
+func (st *ConfigState) GetOnionHost() (v string) {
+	st.mutex.RLock()
+	v = st.config.OnionHost
+	st.mutex.RUnlock()
+	return
+}
+
+// SetOnionHost safely sets the Configuration value for state's 'OnionHost' field
+func (st *ConfigState) SetOnionHost(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.OnionHost = v
+	st.reloadToViper()
+}
+
+// OnionHostFlag returns the flag name for the 'OnionHost' field
+func OnionHostFlag() string { return "onion-host" }
+
+// GetOnionHost safely fetches the value for global configuration 'OnionHost' field
+func GetOnionHost() string { return global.GetOnionHost() }
+
+// SetOnionHost safely sets the value for global configuration 'OnionHost' field
+func SetOnionHost(v string) { global.SetOnionHost(v) }
+
 // GetBindAddress safely fetches the Configuration value for state's 'BindAddress' field
 func (st *ConfigState) GetBindAddress() (v string) {
 	st.mutex.RLock()
@@ -750,6 +775,81 @@ func GetWebAssetBaseDir() string { return global.GetWebAssetBaseDir() }
 // SetWebAssetBaseDir safely sets the value for global configuration 'WebAssetBaseDir' field
 func SetWebAssetBaseDir(v string) { global.SetWebAssetBaseDir(v) }
 
+// GetWebRSSTitleLength safely fetches the Configuration value for state's 'WebRSSTitleLength' field
+func (st *ConfigState) GetWebRSSTitleLength() (v int) {
+	st.mutex.RLock()
+	v = st.config.WebRSSTitleLength
+	st.mutex.RUnlock()
+	return
+}
+
+// SetWebRSSTitleLength safely sets the Configuration value for state's 'WebRSSTitleLength' field
+func (st *ConfigState) SetWebRSSTitleLength(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.WebRSSTitleLength = v
+	st.reloadToViper()
+}
+
+// WebRSSTitleLengthFlag returns the flag name for the 'WebRSSTitleLength' field
+func WebRSSTitleLengthFlag() string { return "web-rss-title-length" }
+
+// GetWebRSSTitleLength safely fetches the value for global configuration 'WebRSSTitleLength' field
+func GetWebRSSTitleLength() int { return global.GetWebRSSTitleLength() }
+
+// SetWebRSSTitleLength safely sets the value for global configuration 'WebRSSTitleLength' field
+func SetWebRSSTitleLength(v int) { global.SetWebRSSTitleLength(v) }
+
+// GetWebRSSDescriptionLength safely fetches the Configuration value for state's 'WebRSSDescriptionLength' field
+func (st *ConfigState) GetWebRSSDescriptionLength() (v int) {
+	st.mutex.RLock()
+	v = st.config.WebRSSDescriptionLength
+	st.mutex.RUnlock()
+	return
+}
+
+// SetWebRSSDescriptionLength safely sets the Configuration value for state's 'WebRSSDescriptionLength' field
+func (st *ConfigState) SetWebRSSDescriptionLength(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.WebRSSDescriptionLength = v
+	st.reloadToViper()
+}
+
+// WebRSSDescriptionLengthFlag returns the flag name for the 'WebRSSDescriptionLength' field
+func WebRSSDescriptionLengthFlag() string { return "web-rss-description-length" }
+
+// GetWebRSSDescriptionLength safely fetches the value for global configuration 'WebRSSDescriptionLength' field
+func GetWebRSSDescriptionLength() int { return global.GetWebRSSDescriptionLength() }
+
+// SetWebRSSDescriptionLength safely sets the value for global configuration 'WebRSSDescriptionLength' field
+func SetWebRSSDescriptionLength(v int) { global.SetWebRSSDescriptionLength(v) }
+
+// GetWebOpenGraphDescriptionLength safely fetches the Configuration value for state's 'WebOpenGraphDescriptionLength' field
+func (st *ConfigState) GetWebOpenGraphDescriptionLength() (v int) {
+	st.mutex.RLock()
+	v = st.config.WebOpenGraphDescriptionLength
+	st.mutex.RUnlock()
+	return
+}
+
+// SetWebOpenGraphDescriptionLength safely sets the Configuration value for state's 'WebOpenGraphDescriptionLength' field
+func (st *ConfigState) SetWebOpenGraphDescriptionLength(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.WebOpenGraphDescriptionLength = v
+	st.reloadToViper()
+}
+
+// WebOpenGraphDescriptionLengthFlag returns the flag name for the 'WebOpenGraphDescriptionLength' field
+func WebOpenGraphDescriptionLengthFlag() string { return "web-opengraph-description-length" }
+
+// GetWebOpenGraphDescriptionLength safely fetches the value for global configuration 'WebOpenGraphDescriptionLength' field
+func GetWebOpenGraphDescriptionLength() int { return global.GetWebOpenGraphDescriptionLength() }
+
+// SetWebOpenGraphDescriptionLength safely sets the value for global configuration 'WebOpenGraphDescriptionLength' field
+func SetWebOpenGraphDescriptionLength(v int) { global.SetWebOpenGraphDescriptionLength(v) }
+
 // GetInstanceFederationMode safely fetches the Configuration value for state's 'InstanceFederationMode' field
 func (st *ConfigState) GetInstanceFederationMode() (v string) {
 	st.mutex.RLock()
@@ -875,6 +975,31 @@ func GetInstanceExposeSuspendedWeb() bool { return global.GetInstanceExposeSuspe
 // SetInstanceExposeSuspendedWeb safely sets the value for global configuration 'InstanceExposeSuspendedWeb' field
 func SetInstanceExposeSuspendedWeb(v bool) { global.SetInstanceExposeSuspendedWeb(v) }
 
+// GetInstanceExposeExplore safely fetches the Configuration value for state's 'InstanceExposeExplore' field
+func (st *ConfigState) GetInstanceExposeExplore() (v bool) {
+	st.mutex.RLock()
+	v = st.config.InstanceExposeExplore
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceExposeExplore safely sets the Configuration value for state's 'InstanceExposeExplore' field
+func (st *ConfigState) SetInstanceExposeExplore(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceExposeExplore = v
+	st.reloadToViper()
+}
+
+// InstanceExposeExploreFlag returns the flag name for the 'InstanceExposeExplore' field
+func InstanceExposeExploreFlag() string { return "instance-expose-explore" }
+
+// GetInstanceExposeExplore safely fetches the value for global configuration 'InstanceExposeExplore' field
+func GetInstanceExposeExplore() bool { return global.GetInstanceExposeExplore() }
+
+// SetInstanceExposeExplore safely sets the value for global configuration 'InstanceExposeExplore' field
+func SetInstanceExposeExplore(v bool) { global.SetInstanceExposeExplore(v) }
+
 // GetInstanceExposePublicTimeline safely fetches the Configuration value for state's 'InstanceExposePublicTimeline' field
 func (st *ConfigState) GetInstanceExposePublicTimeline() (v bool) {
 	st.mutex.RLock()
@@ -975,6 +1100,215 @@ func GetInstanceLanguages() language.Languages { return global.GetInstanceLangua
 // SetInstanceLanguages safely sets the value for global configuration 'InstanceLanguages' field
 func SetInstanceLanguages(v language.Languages) { global.SetInstanceLanguages(v) }
 
+// GetInstanceBlockedInteractionsThreshold safely fetches the Configuration value for state's 'InstanceBlockedInteractionsThreshold' field
+func (st *ConfigState) GetInstanceBlockedInteractionsThreshold() (v int) {
+	st.mutex.RLock()
+	v = st.config.InstanceBlockedInteractionsThreshold
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceBlockedInteractionsThreshold safely sets the Configuration value for state's 'InstanceBlockedInteractionsThreshold' field
+func (st *ConfigState) SetInstanceBlockedInteractionsThreshold(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceBlockedInteractionsThreshold = v
+	st.reloadToViper()
+}
+
+// InstanceBlockedInteractionsThresholdFlag returns the flag name for the 'InstanceBlockedInteractionsThreshold' field
+func InstanceBlockedInteractionsThresholdFlag() string {
+	return "instance-blocked-interactions-threshold"
+}
+
+// GetInstanceBlockedInteractionsThreshold safely fetches the value for global configuration 'InstanceBlockedInteractionsThreshold' field
+func GetInstanceBlockedInteractionsThreshold() int {
+	return global.GetInstanceBlockedInteractionsThreshold()
+}
+
+// SetInstanceBlockedInteractionsThreshold safely sets the value for global configuration 'InstanceBlockedInteractionsThreshold' field
+func SetInstanceBlockedInteractionsThreshold(v int) {
+	global.SetInstanceBlockedInteractionsThreshold(v)
+}
+
+// GetInstanceBlockedInteractionsWindow safely fetches the Configuration value for state's 'InstanceBlockedInteractionsWindow' field
+func (st *ConfigState) GetInstanceBlockedInteractionsWindow() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.InstanceBlockedInteractionsWindow
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceBlockedInteractionsWindow safely sets the Configuration value for state's 'InstanceBlockedInteractionsWindow' field
+func (st *ConfigState) SetInstanceBlockedInteractionsWindow(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceBlockedInteractionsWindow = v
+	st.reloadToViper()
+}
+
+// InstanceBlockedInteractionsWindowFlag returns the flag name for the 'InstanceBlockedInteractionsWindow' field
+func InstanceBlockedInteractionsWindowFlag() string { return "instance-blocked-interactions-window" }
+
+// GetInstanceBlockedInteractionsWindow safely fetches the value for global configuration 'InstanceBlockedInteractionsWindow' field
+func GetInstanceBlockedInteractionsWindow() time.Duration {
+	return global.GetInstanceBlockedInteractionsWindow()
+}
+
+// SetInstanceBlockedInteractionsWindow safely sets the value for global configuration 'InstanceBlockedInteractionsWindow' field
+func SetInstanceBlockedInteractionsWindow(v time.Duration) {
+	global.SetInstanceBlockedInteractionsWindow(v)
+}
+
+// GetInstanceInboxMaxSize safely fetches the Configuration value for state's 'InstanceInboxMaxSize' field
+func (st *ConfigState) GetInstanceInboxMaxSize() (v bytesize.Size) {
+	st.mutex.RLock()
+	v = st.config.InstanceInboxMaxSize
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceInboxMaxSize safely sets the Configuration value for state's 'InstanceInboxMaxSize' field
+func (st *ConfigState) SetInstanceInboxMaxSize(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceInboxMaxSize = v
+	st.reloadToViper()
+}
+
+// InstanceInboxMaxSizeFlag returns the flag name for the 'InstanceInboxMaxSize' field
+func InstanceInboxMaxSizeFlag() string { return "instance-inbox-max-size" }
+
+// GetInstanceInboxMaxSize safely fetches the value for global configuration 'InstanceInboxMaxSize' field
+func GetInstanceInboxMaxSize() bytesize.Size { return global.GetInstanceInboxMaxSize() }
+
+// SetInstanceInboxMaxSize safely sets the value for global configuration 'InstanceInboxMaxSize' field
+func SetInstanceInboxMaxSize(v bytesize.Size) { global.SetInstanceInboxMaxSize(v) }
+
+// GetInstanceInboxMaxRecipients safely fetches the Configuration value for state's 'InstanceInboxMaxRecipients' field
+func (st *ConfigState) GetInstanceInboxMaxRecipients() (v int) {
+	st.mutex.RLock()
+	v = st.config.InstanceInboxMaxRecipients
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceInboxMaxRecipients safely sets the Configuration value for state's 'InstanceInboxMaxRecipients' field
+func (st *ConfigState) SetInstanceInboxMaxRecipients(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceInboxMaxRecipients = v
+	st.reloadToViper()
+}
+
+// InstanceInboxMaxRecipientsFlag returns the flag name for the 'InstanceInboxMaxRecipients' field
+func InstanceInboxMaxRecipientsFlag() string {
+	return "instance-inbox-max-recipients"
+}
+
+// GetInstanceInboxMaxRecipients safely fetches the value for global configuration 'InstanceInboxMaxRecipients' field
+func GetInstanceInboxMaxRecipients() int {
+	return global.GetInstanceInboxMaxRecipients()
+}
+
+// SetInstanceInboxMaxRecipients safely sets the value for global configuration 'InstanceInboxMaxRecipients' field
+func SetInstanceInboxMaxRecipients(v int) {
+	global.SetInstanceInboxMaxRecipients(v)
+}
+
+// GetInstanceInboxMaxTags safely fetches the Configuration value for state's 'InstanceInboxMaxTags' field
+func (st *ConfigState) GetInstanceInboxMaxTags() (v int) {
+	st.mutex.RLock()
+	v = st.config.InstanceInboxMaxTags
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceInboxMaxTags safely sets the Configuration value for state's 'InstanceInboxMaxTags' field
+func (st *ConfigState) SetInstanceInboxMaxTags(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceInboxMaxTags = v
+	st.reloadToViper()
+}
+
+// InstanceInboxMaxTagsFlag returns the flag name for the 'InstanceInboxMaxTags' field
+func InstanceInboxMaxTagsFlag() string {
+	return "instance-inbox-max-tags"
+}
+
+// GetInstanceInboxMaxTags safely fetches the value for global configuration 'InstanceInboxMaxTags' field
+func GetInstanceInboxMaxTags() int {
+	return global.GetInstanceInboxMaxTags()
+}
+
+// SetInstanceInboxMaxTags safely sets the value for global configuration 'InstanceInboxMaxTags' field
+func SetInstanceInboxMaxTags(v int) {
+	global.SetInstanceInboxMaxTags(v)
+}
+
+// GetInstanceInboxMaxAttachments safely fetches the Configuration value for state's 'InstanceInboxMaxAttachments' field
+func (st *ConfigState) GetInstanceInboxMaxAttachments() (v int) {
+	st.mutex.RLock()
+	v = st.config.InstanceInboxMaxAttachments
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceInboxMaxAttachments safely sets the Configuration value for state's 'InstanceInboxMaxAttachments' field
+func (st *ConfigState) SetInstanceInboxMaxAttachments(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceInboxMaxAttachments = v
+	st.reloadToViper()
+}
+
+// InstanceInboxMaxAttachmentsFlag returns the flag name for the 'InstanceInboxMaxAttachments' field
+func InstanceInboxMaxAttachmentsFlag() string {
+	return "instance-inbox-max-attachments"
+}
+
+// GetInstanceInboxMaxAttachments safely fetches the value for global configuration 'InstanceInboxMaxAttachments' field
+func GetInstanceInboxMaxAttachments() int {
+	return global.GetInstanceInboxMaxAttachments()
+}
+
+// SetInstanceInboxMaxAttachments safely sets the value for global configuration 'InstanceInboxMaxAttachments' field
+func SetInstanceInboxMaxAttachments(v int) {
+	global.SetInstanceInboxMaxAttachments(v)
+}
+
+// GetInstanceInboxMaxEmojis safely fetches the Configuration value for state's 'InstanceInboxMaxEmojis' field
+func (st *ConfigState) GetInstanceInboxMaxEmojis() (v int) {
+	st.mutex.RLock()
+	v = st.config.InstanceInboxMaxEmojis
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceInboxMaxEmojis safely sets the Configuration value for state's 'InstanceInboxMaxEmojis' field
+func (st *ConfigState) SetInstanceInboxMaxEmojis(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceInboxMaxEmojis = v
+	st.reloadToViper()
+}
+
+// InstanceInboxMaxEmojisFlag returns the flag name for the 'InstanceInboxMaxEmojis' field
+func InstanceInboxMaxEmojisFlag() string {
+	return "instance-inbox-max-emojis"
+}
+
+// GetInstanceInboxMaxEmojis safely fetches the value for global configuration 'InstanceInboxMaxEmojis' field
+func GetInstanceInboxMaxEmojis() int {
+	return global.GetInstanceInboxMaxEmojis()
+}
+
+// SetInstanceInboxMaxEmojis safely sets the value for global configuration 'InstanceInboxMaxEmojis' field
+func SetInstanceInboxMaxEmojis(v int) {
+	global.SetInstanceInboxMaxEmojis(v)
+}
+
 // GetAccountsRegistrationOpen safely fetches the Configuration value for state's 'AccountsRegistrationOpen' field
 func (st *ConfigState) GetAccountsRegistrationOpen() (v bool) {
 	st.mutex.RLock()
@@ -1075,6 +1409,131 @@ func GetAccountsCustomCSSLength() int { return global.GetAccountsCustomCSSLength
 // SetAccountsCustomCSSLength safely sets the value for global configuration 'AccountsCustomCSSLength' field
 func SetAccountsCustomCSSLength(v int) { global.SetAccountsCustomCSSLength(v) }
 
+// GetAccountsReservedUsernames safely fetches the Configuration value for state's 'AccountsReservedUsernames' field
+func (st *ConfigState) GetAccountsReservedUsernames() (v []string) {
+	st.mutex.RLock()
+	v = st.config.AccountsReservedUsernames
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsReservedUsernames safely sets the Configuration value for state's 'AccountsReservedUsernames' field
+func (st *ConfigState) SetAccountsReservedUsernames(v []string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsReservedUsernames = v
+	st.reloadToViper()
+}
+
+// AccountsReservedUsernamesFlag returns the flag name for the 'AccountsReservedUsernames' field
+func AccountsReservedUsernamesFlag() string { return "accounts-reserved-usernames" }
+
+// GetAccountsReservedUsernames safely fetches the value for global configuration 'AccountsReservedUsernames' field
+func GetAccountsReservedUsernames() []string { return global.GetAccountsReservedUsernames() }
+
+// SetAccountsReservedUsernames safely sets the value for global configuration 'AccountsReservedUsernames' field
+func SetAccountsReservedUsernames(v []string) { global.SetAccountsReservedUsernames(v) }
+
+// GetAccountsUsernameMinLength safely fetches the Configuration value for state's 'AccountsUsernameMinLength' field
+func (st *ConfigState) GetAccountsUsernameMinLength() (v int) {
+	st.mutex.RLock()
+	v = st.config.AccountsUsernameMinLength
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsUsernameMinLength safely sets the Configuration value for state's 'AccountsUsernameMinLength' field
+func (st *ConfigState) SetAccountsUsernameMinLength(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsUsernameMinLength = v
+	st.reloadToViper()
+}
+
+// AccountsUsernameMinLengthFlag returns the flag name for the 'AccountsUsernameMinLength' field
+func AccountsUsernameMinLengthFlag() string { return "accounts-username-min-length" }
+
+// GetAccountsUsernameMinLength safely fetches the value for global configuration 'AccountsUsernameMinLength' field
+func GetAccountsUsernameMinLength() int { return global.GetAccountsUsernameMinLength() }
+
+// SetAccountsUsernameMinLength safely sets the value for global configuration 'AccountsUsernameMinLength' field
+func SetAccountsUsernameMinLength(v int) { global.SetAccountsUsernameMinLength(v) }
+
+// GetAccountsUsernameMaxLength safely fetches the Configuration value for state's 'AccountsUsernameMaxLength' field
+func (st *ConfigState) GetAccountsUsernameMaxLength() (v int) {
+	st.mutex.RLock()
+	v = st.config.AccountsUsernameMaxLength
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsUsernameMaxLength safely sets the Configuration value for state's 'AccountsUsernameMaxLength' field
+func (st *ConfigState) SetAccountsUsernameMaxLength(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsUsernameMaxLength = v
+	st.reloadToViper()
+}
+
+// AccountsUsernameMaxLengthFlag returns the flag name for the 'AccountsUsernameMaxLength' field
+func AccountsUsernameMaxLengthFlag() string { return "accounts-username-max-length" }
+
+// GetAccountsUsernameMaxLength safely fetches the value for global configuration 'AccountsUsernameMaxLength' field
+func GetAccountsUsernameMaxLength() int { return global.GetAccountsUsernameMaxLength() }
+
+// SetAccountsUsernameMaxLength safely sets the value for global configuration 'AccountsUsernameMaxLength' field
+func SetAccountsUsernameMaxLength(v int) { global.SetAccountsUsernameMaxLength(v) }
+
+// GetAccountsUsernamePattern safely fetches the Configuration value for state's 'AccountsUsernamePattern' field
+func (st *ConfigState) GetAccountsUsernamePattern() (v string) {
+	st.mutex.RLock()
+	v = st.config.AccountsUsernamePattern
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsUsernamePattern safely sets the Configuration value for state's 'AccountsUsernamePattern' field
+func (st *ConfigState) SetAccountsUsernamePattern(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsUsernamePattern = v
+	st.reloadToViper()
+}
+
+// AccountsUsernamePatternFlag returns the flag name for the 'AccountsUsernamePattern' field
+func AccountsUsernamePatternFlag() string { return "accounts-username-pattern" }
+
+// GetAccountsUsernamePattern safely fetches the value for global configuration 'AccountsUsernamePattern' field
+func GetAccountsUsernamePattern() string { return global.GetAccountsUsernamePattern() }
+
+// SetAccountsUsernamePattern safely sets the value for global configuration 'AccountsUsernamePattern' field
+func SetAccountsUsernamePattern(v string) { global.SetAccountsUsernamePattern(v) }
+
+// GetAccountsMinimumAge safely fetches the Configuration value for state's 'AccountsMinimumAge' field
+func (st *ConfigState) GetAccountsMinimumAge() (v int) {
+	st.mutex.RLock()
+	v = st.config.AccountsMinimumAge
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsMinimumAge safely sets the Configuration value for state's 'AccountsMinimumAge' field
+func (st *ConfigState) SetAccountsMinimumAge(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsMinimumAge = v
+	st.reloadToViper()
+}
+
+// AccountsMinimumAgeFlag returns the flag name for the 'AccountsMinimumAge' field
+func AccountsMinimumAgeFlag() string { return "accounts-minimum-age" }
+
+// GetAccountsMinimumAge safely fetches the value for global configuration 'AccountsMinimumAge' field
+func GetAccountsMinimumAge() int { return global.GetAccountsMinimumAge() }
+
+// SetAccountsMinimumAge safely sets the value for global configuration 'AccountsMinimumAge' field
+func SetAccountsMinimumAge(v int) { global.SetAccountsMinimumAge(v) }
+
 // GetMediaImageMaxSize safely fetches the Configuration value for state's 'MediaImageMaxSize' field
 func (st *ConfigState) GetMediaImageMaxSize() (v bytesize.Size) {
 	st.mutex.RLock()
@@ -1125,6 +1584,31 @@ func GetMediaVideoMaxSize() bytesize.Size { return global.GetMediaVideoMaxSize()
 // SetMediaVideoMaxSize safely sets the value for global configuration 'MediaVideoMaxSize' field
 func SetMediaVideoMaxSize(v bytesize.Size) { global.SetMediaVideoMaxSize(v) }
 
+// GetMediaAllowedMIMETypes safely fetches the Configuration value for state's 'MediaAllowedMIMETypes' field
+func (st *ConfigState) GetMediaAllowedMIMETypes() (v []string) {
+	st.mutex.RLock()
+	v = st.config.MediaAllowedMIMETypes
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaAllowedMIMETypes safely sets the Configuration value for state's 'MediaAllowedMIMETypes' field
+func (st *ConfigState) SetMediaAllowedMIMETypes(v []string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaAllowedMIMETypes = v
+	st.reloadToViper()
+}
+
+// MediaAllowedMIMETypesFlag returns the flag name for the 'MediaAllowedMIMETypes' field
+func MediaAllowedMIMETypesFlag() string { return "media-allowed-mime-types" }
+
+// GetMediaAllowedMIMETypes safely fetches the value for global configuration 'MediaAllowedMIMETypes' field
+func GetMediaAllowedMIMETypes() []string { return global.GetMediaAllowedMIMETypes() }
+
+// SetMediaAllowedMIMETypes safely sets the value for global configuration 'MediaAllowedMIMETypes' field
+func SetMediaAllowedMIMETypes(v []string) { global.SetMediaAllowedMIMETypes(v) }
+
 // GetMediaDescriptionMinChars safely fetches the Configuration value for state's 'MediaDescriptionMinChars' field
 func (st *ConfigState) GetMediaDescriptionMinChars() (v int) {
 	st.mutex.RLock()
@@ -1175,6 +1659,31 @@ func GetMediaDescriptionMaxChars() int { return global.GetMediaDescriptionMaxCha
 // SetMediaDescriptionMaxChars safely sets the value for global configuration 'MediaDescriptionMaxChars' field
 func SetMediaDescriptionMaxChars(v int) { global.SetMediaDescriptionMaxChars(v) }
 
+// GetMediaPreserveOrientation safely fetches the Configuration value for state's 'MediaPreserveOrientation' field
+func (st *ConfigState) GetMediaPreserveOrientation() (v bool) {
+	st.mutex.RLock()
+	v = st.config.MediaPreserveOrientation
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaPreserveOrientation safely sets the Configuration value for state's 'MediaPreserveOrientation' field
+func (st *ConfigState) SetMediaPreserveOrientation(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaPreserveOrientation = v
+	st.reloadToViper()
+}
+
+// MediaPreserveOrientationFlag returns the flag name for the 'MediaPreserveOrientation' field
+func MediaPreserveOrientationFlag() string { return "media-preserve-orientation" }
+
+// GetMediaPreserveOrientation safely fetches the value for global configuration 'MediaPreserveOrientation' field
+func GetMediaPreserveOrientation() bool { return global.GetMediaPreserveOrientation() }
+
+// SetMediaPreserveOrientation safely sets the value for global configuration 'MediaPreserveOrientation' field
+func SetMediaPreserveOrientation(v bool) { global.SetMediaPreserveOrientation(v) }
+
 // GetMediaRemoteCacheDays safely fetches the Configuration value for state's 'MediaRemoteCacheDays' field
 func (st *ConfigState) GetMediaRemoteCacheDays() (v int) {
 	st.mutex.RLock()
@@ -1200,6 +1709,56 @@ func GetMediaRemoteCacheDays() int { return global.GetMediaRemoteCacheDays() }
 // SetMediaRemoteCacheDays safely sets the value for global configuration 'MediaRemoteCacheDays' field
 func SetMediaRemoteCacheDays(v int) { global.SetMediaRemoteCacheDays(v) }
 
+// GetMediaAccountLazyFetch safely fetches the Configuration value for state's 'MediaAccountLazyFetch' field
+func (st *ConfigState) GetMediaAccountLazyFetch() (v bool) {
+	st.mutex.RLock()
+	v = st.config.MediaAccountLazyFetch
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaAccountLazyFetch safely sets the Configuration value for state's 'MediaAccountLazyFetch' field
+func (st *ConfigState) SetMediaAccountLazyFetch(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaAccountLazyFetch = v
+	st.reloadToViper()
+}
+
+// MediaAccountLazyFetchFlag returns the flag name for the 'MediaAccountLazyFetch' field
+func MediaAccountLazyFetchFlag() string { return "media-account-lazy-fetch" }
+
+// GetMediaAccountLazyFetch safely fetches the value for global configuration 'MediaAccountLazyFetch' field
+func GetMediaAccountLazyFetch() bool { return global.GetMediaAccountLazyFetch() }
+
+// SetMediaAccountLazyFetch safely sets the value for global configuration 'MediaAccountLazyFetch' field
+func SetMediaAccountLazyFetch(v bool) { global.SetMediaAccountLazyFetch(v) }
+
+// GetMediaUnknownPlaceholder safely fetches the Configuration value for state's 'MediaUnknownPlaceholder' field
+func (st *ConfigState) GetMediaUnknownPlaceholder() (v string) {
+	st.mutex.RLock()
+	v = st.config.MediaUnknownPlaceholder
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaUnknownPlaceholder safely sets the Configuration value for state's 'MediaUnknownPlaceholder' field
+func (st *ConfigState) SetMediaUnknownPlaceholder(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaUnknownPlaceholder = v
+	st.reloadToViper()
+}
+
+// MediaUnknownPlaceholderFlag returns the flag name for the 'MediaUnknownPlaceholder' field
+func MediaUnknownPlaceholderFlag() string { return "media-unknown-placeholder" }
+
+// GetMediaUnknownPlaceholder safely fetches the value for global configuration 'MediaUnknownPlaceholder' field
+func GetMediaUnknownPlaceholder() string { return global.GetMediaUnknownPlaceholder() }
+
+// SetMediaUnknownPlaceholder safely sets the value for global configuration 'MediaUnknownPlaceholder' field
+func SetMediaUnknownPlaceholder(v string) { global.SetMediaUnknownPlaceholder(v) }
+
 // GetMediaEmojiLocalMaxSize safely fetches the Configuration value for state's 'MediaEmojiLocalMaxSize' field
 func (st *ConfigState) GetMediaEmojiLocalMaxSize() (v bytesize.Size) {
 	st.mutex.RLock()
@@ -1600,6 +2159,31 @@ func GetStatusesMediaMaxFiles() int { return global.GetStatusesMediaMaxFiles() }
 // SetStatusesMediaMaxFiles safely sets the value for global configuration 'StatusesMediaMaxFiles' field
 func SetStatusesMediaMaxFiles(v int) { global.SetStatusesMediaMaxFiles(v) }
 
+// GetStatusesSearchIndex safely fetches the Configuration value for state's 'StatusesSearchIndex' field
+func (st *ConfigState) GetStatusesSearchIndex() (v bool) {
+	st.mutex.RLock()
+	v = st.config.StatusesSearchIndex
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStatusesSearchIndex safely sets the Configuration value for state's 'StatusesSearchIndex' field
+func (st *ConfigState) SetStatusesSearchIndex(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StatusesSearchIndex = v
+	st.reloadToViper()
+}
+
+// StatusesSearchIndexFlag returns the flag name for the 'StatusesSearchIndex' field
+func StatusesSearchIndexFlag() string { return "statuses-search-index" }
+
+// GetStatusesSearchIndex safely fetches the value for global configuration 'StatusesSearchIndex' field
+func GetStatusesSearchIndex() bool { return global.GetStatusesSearchIndex() }
+
+// SetStatusesSearchIndex safely sets the value for global configuration 'StatusesSearchIndex' field
+func SetStatusesSearchIndex(v bool) { global.SetStatusesSearchIndex(v) }
+
 // GetLetsEncryptEnabled safely fetches the Configuration value for state's 'LetsEncryptEnabled' field
 func (st *ConfigState) GetLetsEncryptEnabled() (v bool) {
 	st.mutex.RLock()
@@ -2350,6 +2934,56 @@ func GetSMTPDiscloseRecipients() bool { return global.GetSMTPDiscloseRecipients(
 // SetSMTPDiscloseRecipients safely sets the value for global configuration 'SMTPDiscloseRecipients' field
 func SetSMTPDiscloseRecipients(v bool) { global.SetSMTPDiscloseRecipients(v) }
 
+// GetWebPushVAPIDPublicKey safely fetches the Configuration value for state's 'WebPushVAPIDPublicKey' field
+func (st *ConfigState) GetWebPushVAPIDPublicKey() (v string) {
+	st.mutex.RLock()
+	v = st.config.WebPushVAPIDPublicKey
+	st.mutex.RUnlock()
+	return
+}
+
+// SetWebPushVAPIDPublicKey safely sets the Configuration value for state's 'WebPushVAPIDPublicKey' field
+func (st *ConfigState) SetWebPushVAPIDPublicKey(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.WebPushVAPIDPublicKey = v
+	st.reloadToViper()
+}
+
+// WebPushVAPIDPublicKeyFlag returns the flag name for the 'WebPushVAPIDPublicKey' field
+func WebPushVAPIDPublicKeyFlag() string { return "web-push-vapid-public-key" }
+
+// GetWebPushVAPIDPublicKey safely fetches the value for global configuration 'WebPushVAPIDPublicKey' field
+func GetWebPushVAPIDPublicKey() string { return global.GetWebPushVAPIDPublicKey() }
+
+// SetWebPushVAPIDPublicKey safely sets the value for global configuration 'WebPushVAPIDPublicKey' field
+func SetWebPushVAPIDPublicKey(v string) { global.SetWebPushVAPIDPublicKey(v) }
+
+// GetWebPushVAPIDPrivateKey safely fetches the Configuration value for state's 'WebPushVAPIDPrivateKey' field
+func (st *ConfigState) GetWebPushVAPIDPrivateKey() (v string) {
+	st.mutex.RLock()
+	v = st.config.WebPushVAPIDPrivateKey
+	st.mutex.RUnlock()
+	return
+}
+
+// SetWebPushVAPIDPrivateKey safely sets the Configuration value for state's 'WebPushVAPIDPrivateKey' field
+func (st *ConfigState) SetWebPushVAPIDPrivateKey(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.WebPushVAPIDPrivateKey = v
+	st.reloadToViper()
+}
+
+// WebPushVAPIDPrivateKeyFlag returns the flag name for the 'WebPushVAPIDPrivateKey' field
+func WebPushVAPIDPrivateKeyFlag() string { return "web-push-vapid-private-key" }
+
+// GetWebPushVAPIDPrivateKey safely fetches the value for global configuration 'WebPushVAPIDPrivateKey' field
+func GetWebPushVAPIDPrivateKey() string { return global.GetWebPushVAPIDPrivateKey() }
+
+// SetWebPushVAPIDPrivateKey safely sets the value for global configuration 'WebPushVAPIDPrivateKey' field
+func SetWebPushVAPIDPrivateKey(v string) { global.SetWebPushVAPIDPrivateKey(v) }
+
 // GetSyslogEnabled safely fetches the Configuration value for state's 'SyslogEnabled' field
 func (st *ConfigState) GetSyslogEnabled() (v bool) {
 	st.mutex.RLock()
@@ -2587,43 +3221,324 @@ func (st *ConfigState) GetAdvancedCSPExtraURIs() (v []string) {
 func (st *ConfigState) SetAdvancedCSPExtraURIs(v []string) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.AdvancedCSPExtraURIs = v
+	st.config.AdvancedCSPExtraURIs = v
+	st.reloadToViper()
+}
+
+// AdvancedCSPExtraURIsFlag returns the flag name for the 'AdvancedCSPExtraURIs' field
+func AdvancedCSPExtraURIsFlag() string { return "advanced-csp-extra-uris" }
+
+// GetAdvancedCSPExtraURIs safely fetches the value for global configuration 'AdvancedCSPExtraURIs' field
+func GetAdvancedCSPExtraURIs() []string { return global.GetAdvancedCSPExtraURIs() }
+
+// SetAdvancedCSPExtraURIs safely sets the value for global configuration 'AdvancedCSPExtraURIs' field
+func SetAdvancedCSPExtraURIs(v []string) { global.SetAdvancedCSPExtraURIs(v) }
+
+// GetAdvancedCORSAllowOrigins safely fetches the Configuration value for state's 'AdvancedCORSAllowOrigins' field
+func (st *ConfigState) GetAdvancedCORSAllowOrigins() (v []string) {
+	st.mutex.RLock()
+	v = st.config.AdvancedCORSAllowOrigins
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedCORSAllowOrigins safely sets the Configuration value for state's 'AdvancedCORSAllowOrigins' field
+func (st *ConfigState) SetAdvancedCORSAllowOrigins(v []string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedCORSAllowOrigins = v
+	st.reloadToViper()
+}
+
+// AdvancedCORSAllowOriginsFlag returns the flag name for the 'AdvancedCORSAllowOrigins' field
+func AdvancedCORSAllowOriginsFlag() string { return "advanced-cors-allow-origins" }
+
+// GetAdvancedCORSAllowOrigins safely fetches the value for global configuration 'AdvancedCORSAllowOrigins' field
+func GetAdvancedCORSAllowOrigins() []string { return global.GetAdvancedCORSAllowOrigins() }
+
+// SetAdvancedCORSAllowOrigins safely sets the value for global configuration 'AdvancedCORSAllowOrigins' field
+func SetAdvancedCORSAllowOrigins(v []string) { global.SetAdvancedCORSAllowOrigins(v) }
+
+// GetAdvancedCORSWebClients safely fetches the Configuration value for state's 'AdvancedCORSWebClients' field
+func (st *ConfigState) GetAdvancedCORSWebClients() (v []string) {
+	st.mutex.RLock()
+	v = st.config.AdvancedCORSWebClients
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedCORSWebClients safely sets the Configuration value for state's 'AdvancedCORSWebClients' field
+func (st *ConfigState) SetAdvancedCORSWebClients(v []string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedCORSWebClients = v
+	st.reloadToViper()
+}
+
+// AdvancedCORSWebClientsFlag returns the flag name for the 'AdvancedCORSWebClients' field
+func AdvancedCORSWebClientsFlag() string { return "advanced-cors-web-clients" }
+
+// GetAdvancedCORSWebClients safely fetches the value for global configuration 'AdvancedCORSWebClients' field
+func GetAdvancedCORSWebClients() []string { return global.GetAdvancedCORSWebClients() }
+
+// SetAdvancedCORSWebClients safely sets the value for global configuration 'AdvancedCORSWebClients' field
+func SetAdvancedCORSWebClients(v []string) { global.SetAdvancedCORSWebClients(v) }
+
+// GetAdvancedHeaderFilterMode safely fetches the Configuration value for state's 'AdvancedHeaderFilterMode' field
+func (st *ConfigState) GetAdvancedHeaderFilterMode() (v string) {
+	st.mutex.RLock()
+	v = st.config.AdvancedHeaderFilterMode
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedHeaderFilterMode safely sets the Configuration value for state's 'AdvancedHeaderFilterMode' field
+func (st *ConfigState) SetAdvancedHeaderFilterMode(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedHeaderFilterMode = v
+	st.reloadToViper()
+}
+
+// AdvancedHeaderFilterModeFlag returns the flag name for the 'AdvancedHeaderFilterMode' field
+func AdvancedHeaderFilterModeFlag() string { return "advanced-header-filter-mode" }
+
+// GetAdvancedHeaderFilterMode safely fetches the value for global configuration 'AdvancedHeaderFilterMode' field
+func GetAdvancedHeaderFilterMode() string { return global.GetAdvancedHeaderFilterMode() }
+
+// SetAdvancedHeaderFilterMode safely sets the value for global configuration 'AdvancedHeaderFilterMode' field
+func SetAdvancedHeaderFilterMode(v string) { global.SetAdvancedHeaderFilterMode(v) }
+
+// GetAdvancedThreadMaxAncestors safely fetches the Configuration value for state's 'AdvancedThreadMaxAncestors' field
+func (st *ConfigState) GetAdvancedThreadMaxAncestors() (v int) {
+	st.mutex.RLock()
+	v = st.config.AdvancedThreadMaxAncestors
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedThreadMaxAncestors safely sets the Configuration value for state's 'AdvancedThreadMaxAncestors' field
+func (st *ConfigState) SetAdvancedThreadMaxAncestors(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedThreadMaxAncestors = v
+	st.reloadToViper()
+}
+
+// AdvancedThreadMaxAncestorsFlag returns the flag name for the 'AdvancedThreadMaxAncestors' field
+func AdvancedThreadMaxAncestorsFlag() string { return "advanced-thread-max-ancestors" }
+
+// GetAdvancedThreadMaxAncestors safely fetches the value for global configuration 'AdvancedThreadMaxAncestors' field
+func GetAdvancedThreadMaxAncestors() int { return global.GetAdvancedThreadMaxAncestors() }
+
+// SetAdvancedThreadMaxAncestors safely sets the value for global configuration 'AdvancedThreadMaxAncestors' field
+func SetAdvancedThreadMaxAncestors(v int) { global.SetAdvancedThreadMaxAncestors(v) }
+
+// GetAdvancedThreadMaxDepth safely fetches the Configuration value for state's 'AdvancedThreadMaxDepth' field
+func (st *ConfigState) GetAdvancedThreadMaxDepth() (v int) {
+	st.mutex.RLock()
+	v = st.config.AdvancedThreadMaxDepth
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedThreadMaxDepth safely sets the Configuration value for state's 'AdvancedThreadMaxDepth' field
+func (st *ConfigState) SetAdvancedThreadMaxDepth(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedThreadMaxDepth = v
+	st.reloadToViper()
+}
+
+// AdvancedThreadMaxDepthFlag returns the flag name for the 'AdvancedThreadMaxDepth' field
+func AdvancedThreadMaxDepthFlag() string { return "advanced-thread-max-depth" }
+
+// GetAdvancedThreadMaxDepth safely fetches the value for global configuration 'AdvancedThreadMaxDepth' field
+func GetAdvancedThreadMaxDepth() int { return global.GetAdvancedThreadMaxDepth() }
+
+// SetAdvancedThreadMaxDepth safely sets the value for global configuration 'AdvancedThreadMaxDepth' field
+func SetAdvancedThreadMaxDepth(v int) { global.SetAdvancedThreadMaxDepth(v) }
+
+// GetAdvancedThreadMaxDescendants safely fetches the Configuration value for state's 'AdvancedThreadMaxDescendants' field
+func (st *ConfigState) GetAdvancedThreadMaxDescendants() (v int) {
+	st.mutex.RLock()
+	v = st.config.AdvancedThreadMaxDescendants
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedThreadMaxDescendants safely sets the Configuration value for state's 'AdvancedThreadMaxDescendants' field
+func (st *ConfigState) SetAdvancedThreadMaxDescendants(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedThreadMaxDescendants = v
+	st.reloadToViper()
+}
+
+// AdvancedThreadMaxDescendantsFlag returns the flag name for the 'AdvancedThreadMaxDescendants' field
+func AdvancedThreadMaxDescendantsFlag() string { return "advanced-thread-max-descendants" }
+
+// GetAdvancedThreadMaxDescendants safely fetches the value for global configuration 'AdvancedThreadMaxDescendants' field
+func GetAdvancedThreadMaxDescendants() int { return global.GetAdvancedThreadMaxDescendants() }
+
+// SetAdvancedThreadMaxDescendants safely sets the value for global configuration 'AdvancedThreadMaxDescendants' field
+func SetAdvancedThreadMaxDescendants(v int) { global.SetAdvancedThreadMaxDescendants(v) }
+
+// GetAdvancedDereferenceBudget safely fetches the Configuration value for state's 'AdvancedDereferenceBudget' field
+func (st *ConfigState) GetAdvancedDereferenceBudget() (v int) {
+	st.mutex.RLock()
+	v = st.config.AdvancedDereferenceBudget
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedDereferenceBudget safely sets the Configuration value for state's 'AdvancedDereferenceBudget' field
+func (st *ConfigState) SetAdvancedDereferenceBudget(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedDereferenceBudget = v
+	st.reloadToViper()
+}
+
+// AdvancedDereferenceBudgetFlag returns the flag name for the 'AdvancedDereferenceBudget' field
+func AdvancedDereferenceBudgetFlag() string { return "advanced-dereference-budget" }
+
+// GetAdvancedDereferenceBudget safely fetches the value for global configuration 'AdvancedDereferenceBudget' field
+func GetAdvancedDereferenceBudget() int { return global.GetAdvancedDereferenceBudget() }
+
+// SetAdvancedDereferenceBudget safely sets the value for global configuration 'AdvancedDereferenceBudget' field
+func SetAdvancedDereferenceBudget(v int) { global.SetAdvancedDereferenceBudget(v) }
+
+// GetAdvancedTimelineStorage safely fetches the Configuration value for state's 'AdvancedTimelineStorage' field
+func (st *ConfigState) GetAdvancedTimelineStorage() (v string) {
+	st.mutex.RLock()
+	v = st.config.AdvancedTimelineStorage
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedTimelineStorage safely sets the Configuration value for state's 'AdvancedTimelineStorage' field
+func (st *ConfigState) SetAdvancedTimelineStorage(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedTimelineStorage = v
+	st.reloadToViper()
+}
+
+// AdvancedTimelineStorageFlag returns the flag name for the 'AdvancedTimelineStorage' field
+func AdvancedTimelineStorageFlag() string { return "advanced-timeline-storage" }
+
+// GetAdvancedTimelineStorage safely fetches the value for global configuration 'AdvancedTimelineStorage' field
+func GetAdvancedTimelineStorage() string { return global.GetAdvancedTimelineStorage() }
+
+// SetAdvancedTimelineStorage safely sets the value for global configuration 'AdvancedTimelineStorage' field
+func SetAdvancedTimelineStorage(v string) { global.SetAdvancedTimelineStorage(v) }
+
+// GetAdvancedDeliveryLogRetention safely fetches the Configuration value for state's 'AdvancedDeliveryLogRetention' field
+func (st *ConfigState) GetAdvancedDeliveryLogRetention() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.AdvancedDeliveryLogRetention
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedDeliveryLogRetention safely sets the Configuration value for state's 'AdvancedDeliveryLogRetention' field
+func (st *ConfigState) SetAdvancedDeliveryLogRetention(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedDeliveryLogRetention = v
+	st.reloadToViper()
+}
+
+// AdvancedDeliveryLogRetentionFlag returns the flag name for the 'AdvancedDeliveryLogRetention' field
+func AdvancedDeliveryLogRetentionFlag() string { return "advanced-delivery-log-retention" }
+
+// GetAdvancedDeliveryLogRetention safely fetches the value for global configuration 'AdvancedDeliveryLogRetention' field
+func GetAdvancedDeliveryLogRetention() time.Duration { return global.GetAdvancedDeliveryLogRetention() }
+
+// SetAdvancedDeliveryLogRetention safely sets the value for global configuration 'AdvancedDeliveryLogRetention' field
+func SetAdvancedDeliveryLogRetention(v time.Duration) { global.SetAdvancedDeliveryLogRetention(v) }
+
+// GetAdvancedStreamingMaxConnections safely fetches the Configuration value for state's 'AdvancedStreamingMaxConnections' field
+func (st *ConfigState) GetAdvancedStreamingMaxConnections() (v int) {
+	st.mutex.RLock()
+	v = st.config.AdvancedStreamingMaxConnections
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedStreamingMaxConnections safely sets the Configuration value for state's 'AdvancedStreamingMaxConnections' field
+func (st *ConfigState) SetAdvancedStreamingMaxConnections(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedStreamingMaxConnections = v
+	st.reloadToViper()
+}
+
+// AdvancedStreamingMaxConnectionsFlag returns the flag name for the 'AdvancedStreamingMaxConnections' field
+func AdvancedStreamingMaxConnectionsFlag() string { return "advanced-streaming-max-connections" }
+
+// GetAdvancedStreamingMaxConnections safely fetches the value for global configuration 'AdvancedStreamingMaxConnections' field
+func GetAdvancedStreamingMaxConnections() int { return global.GetAdvancedStreamingMaxConnections() }
+
+// SetAdvancedStreamingMaxConnections safely sets the value for global configuration 'AdvancedStreamingMaxConnections' field
+func SetAdvancedStreamingMaxConnections(v int) { global.SetAdvancedStreamingMaxConnections(v) }
+
+// GetAdvancedStreamingMaxAccountConnections safely fetches the Configuration value for state's 'AdvancedStreamingMaxAccountConnections' field
+func (st *ConfigState) GetAdvancedStreamingMaxAccountConnections() (v int) {
+	st.mutex.RLock()
+	v = st.config.AdvancedStreamingMaxAccountConnections
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedStreamingMaxAccountConnections safely sets the Configuration value for state's 'AdvancedStreamingMaxAccountConnections' field
+func (st *ConfigState) SetAdvancedStreamingMaxAccountConnections(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedStreamingMaxAccountConnections = v
 	st.reloadToViper()
 }
 
-// AdvancedCSPExtraURIsFlag returns the flag name for the 'AdvancedCSPExtraURIs' field
-func AdvancedCSPExtraURIsFlag() string { return "advanced-csp-extra-uris" }
+// AdvancedStreamingMaxAccountConnectionsFlag returns the flag name for the 'AdvancedStreamingMaxAccountConnections' field
+func AdvancedStreamingMaxAccountConnectionsFlag() string {
+	return "advanced-streaming-max-account-connections"
+}
 
-// GetAdvancedCSPExtraURIs safely fetches the value for global configuration 'AdvancedCSPExtraURIs' field
-func GetAdvancedCSPExtraURIs() []string { return global.GetAdvancedCSPExtraURIs() }
+// GetAdvancedStreamingMaxAccountConnections safely fetches the value for global configuration 'AdvancedStreamingMaxAccountConnections' field
+func GetAdvancedStreamingMaxAccountConnections() int {
+	return global.GetAdvancedStreamingMaxAccountConnections()
+}
 
-// SetAdvancedCSPExtraURIs safely sets the value for global configuration 'AdvancedCSPExtraURIs' field
-func SetAdvancedCSPExtraURIs(v []string) { global.SetAdvancedCSPExtraURIs(v) }
+// SetAdvancedStreamingMaxAccountConnections safely sets the value for global configuration 'AdvancedStreamingMaxAccountConnections' field
+func SetAdvancedStreamingMaxAccountConnections(v int) {
+	global.SetAdvancedStreamingMaxAccountConnections(v)
+}
 
-// GetAdvancedHeaderFilterMode safely fetches the Configuration value for state's 'AdvancedHeaderFilterMode' field
-func (st *ConfigState) GetAdvancedHeaderFilterMode() (v string) {
+// GetAdvancedStreamingIdleTimeout safely fetches the Configuration value for state's 'AdvancedStreamingIdleTimeout' field
+func (st *ConfigState) GetAdvancedStreamingIdleTimeout() (v time.Duration) {
 	st.mutex.RLock()
-	v = st.config.AdvancedHeaderFilterMode
+	v = st.config.AdvancedStreamingIdleTimeout
 	st.mutex.RUnlock()
 	return
 }
 
-// SetAdvancedHeaderFilterMode safely sets the Configuration value for state's 'AdvancedHeaderFilterMode' field
-func (st *ConfigState) SetAdvancedHeaderFilterMode(v string) {
+// SetAdvancedStreamingIdleTimeout safely sets the Configuration value for state's 'AdvancedStreamingIdleTimeout' field
+func (st *ConfigState) SetAdvancedStreamingIdleTimeout(v time.Duration) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.AdvancedHeaderFilterMode = v
+	st.config.AdvancedStreamingIdleTimeout = v
 	st.reloadToViper()
 }
 
-// AdvancedHeaderFilterModeFlag returns the flag name for the 'AdvancedHeaderFilterMode' field
-func AdvancedHeaderFilterModeFlag() string { return "advanced-header-filter-mode" }
+// AdvancedStreamingIdleTimeoutFlag returns the flag name for the 'AdvancedStreamingIdleTimeout' field
+func AdvancedStreamingIdleTimeoutFlag() string { return "advanced-streaming-idle-timeout" }
 
-// GetAdvancedHeaderFilterMode safely fetches the value for global configuration 'AdvancedHeaderFilterMode' field
-func GetAdvancedHeaderFilterMode() string { return global.GetAdvancedHeaderFilterMode() }
+// GetAdvancedStreamingIdleTimeout safely fetches the value for global configuration 'AdvancedStreamingIdleTimeout' field
+func GetAdvancedStreamingIdleTimeout() time.Duration { return global.GetAdvancedStreamingIdleTimeout() }
 
-// SetAdvancedHeaderFilterMode safely sets the value for global configuration 'AdvancedHeaderFilterMode' field
-func SetAdvancedHeaderFilterMode(v string) { global.SetAdvancedHeaderFilterMode(v) }
+// SetAdvancedStreamingIdleTimeout safely sets the value for global configuration 'AdvancedStreamingIdleTimeout' field
+func SetAdvancedStreamingIdleTimeout(v time.Duration) { global.SetAdvancedStreamingIdleTimeout(v) }
 
 // GetHTTPClientAllowIPs safely fetches the Configuration value for state's 'HTTPClient.AllowIPs' field
 func (st *ConfigState) GetHTTPClientAllowIPs() (v []string) {
@@ -2725,6 +3640,131 @@ func GetHTTPClientTLSInsecureSkipVerify() bool { return global.GetHTTPClientTLSI
 // SetHTTPClientTLSInsecureSkipVerify safely sets the value for global configuration 'HTTPClient.TLSInsecureSkipVerify' field
 func SetHTTPClientTLSInsecureSkipVerify(v bool) { global.SetHTTPClientTLSInsecureSkipVerify(v) }
 
+// GetHTTPClientProxy safely fetches the Configuration value for state's 'HTTPClient.Proxy' field
+func (st *ConfigState) GetHTTPClientProxy() (v string) {
+	st.mutex.RLock()
+	v = st.config.HTTPClient.Proxy
+	st.mutex.RUnlock()
+	return
+}
+
+// SetHTTPClientProxy safely sets the Configuration value for state's 'HTTPClient.Proxy' field
+func (st *ConfigState) SetHTTPClientProxy(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.HTTPClient.Proxy = v
+	st.reloadToViper()
+}
+
+// HTTPClientProxyFlag returns the flag name for the 'HTTPClient.Proxy' field
+func HTTPClientProxyFlag() string { return "httpclient-proxy" }
+
+// GetHTTPClientProxy safely fetches the value for global configuration 'HTTPClient.Proxy' field
+func GetHTTPClientProxy() string { return global.GetHTTPClientProxy() }
+
+// SetHTTPClientProxy safely sets the value for global configuration 'HTTPClient.Proxy' field
+func SetHTTPClientProxy(v string) { global.SetHTTPClientProxy(v) }
+
+// GetHTTPClientProxyRules safely fetches the Configuration value for state's 'HTTPClient.ProxyRules' field
+func (st *ConfigState) GetHTTPClientProxyRules() (v []string) {
+	st.mutex.RLock()
+	v = st.config.HTTPClient.ProxyRules
+	st.mutex.RUnlock()
+	return
+}
+
+// SetHTTPClientProxyRules safely sets the Configuration value for state's 'HTTPClient.ProxyRules' field
+func (st *ConfigState) SetHTTPClientProxyRules(v []string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.HTTPClient.ProxyRules = v
+	st.reloadToViper()
+}
+
+// HTTPClientProxyRulesFlag returns the flag name for the 'HTTPClient.ProxyRules' field
+func HTTPClientProxyRulesFlag() string { return "httpclient-proxy-rules" }
+
+// GetHTTPClientProxyRules safely fetches the value for global configuration 'HTTPClient.ProxyRules' field
+func GetHTTPClientProxyRules() []string { return global.GetHTTPClientProxyRules() }
+
+// SetHTTPClientProxyRules safely sets the value for global configuration 'HTTPClient.ProxyRules' field
+func SetHTTPClientProxyRules(v []string) { global.SetHTTPClientProxyRules(v) }
+
+// GetHTTPClientIPVersion safely fetches the Configuration value for state's 'HTTPClient.IPVersion' field
+func (st *ConfigState) GetHTTPClientIPVersion() (v string) {
+	st.mutex.RLock()
+	v = st.config.HTTPClient.IPVersion
+	st.mutex.RUnlock()
+	return
+}
+
+// SetHTTPClientIPVersion safely sets the Configuration value for state's 'HTTPClient.IPVersion' field
+func (st *ConfigState) SetHTTPClientIPVersion(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.HTTPClient.IPVersion = v
+	st.reloadToViper()
+}
+
+// HTTPClientIPVersionFlag returns the flag name for the 'HTTPClient.IPVersion' field
+func HTTPClientIPVersionFlag() string { return "httpclient-ip-version" }
+
+// GetHTTPClientIPVersion safely fetches the value for global configuration 'HTTPClient.IPVersion' field
+func GetHTTPClientIPVersion() string { return global.GetHTTPClientIPVersion() }
+
+// SetHTTPClientIPVersion safely sets the value for global configuration 'HTTPClient.IPVersion' field
+func SetHTTPClientIPVersion(v string) { global.SetHTTPClientIPVersion(v) }
+
+// GetHTTPClientHappyEyeballsDelay safely fetches the Configuration value for state's 'HTTPClient.HappyEyeballsDelay' field
+func (st *ConfigState) GetHTTPClientHappyEyeballsDelay() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.HTTPClient.HappyEyeballsDelay
+	st.mutex.RUnlock()
+	return
+}
+
+// SetHTTPClientHappyEyeballsDelay safely sets the Configuration value for state's 'HTTPClient.HappyEyeballsDelay' field
+func (st *ConfigState) SetHTTPClientHappyEyeballsDelay(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.HTTPClient.HappyEyeballsDelay = v
+	st.reloadToViper()
+}
+
+// HTTPClientHappyEyeballsDelayFlag returns the flag name for the 'HTTPClient.HappyEyeballsDelay' field
+func HTTPClientHappyEyeballsDelayFlag() string { return "httpclient-happy-eyeballs-delay" }
+
+// GetHTTPClientHappyEyeballsDelay safely fetches the value for global configuration 'HTTPClient.HappyEyeballsDelay' field
+func GetHTTPClientHappyEyeballsDelay() time.Duration { return global.GetHTTPClientHappyEyeballsDelay() }
+
+// SetHTTPClientHappyEyeballsDelay safely sets the value for global configuration 'HTTPClient.HappyEyeballsDelay' field
+func SetHTTPClientHappyEyeballsDelay(v time.Duration) { global.SetHTTPClientHappyEyeballsDelay(v) }
+
+// GetHTTPClientResolver safely fetches the Configuration value for state's 'HTTPClient.Resolver' field
+func (st *ConfigState) GetHTTPClientResolver() (v string) {
+	st.mutex.RLock()
+	v = st.config.HTTPClient.Resolver
+	st.mutex.RUnlock()
+	return
+}
+
+// SetHTTPClientResolver safely sets the Configuration value for state's 'HTTPClient.Resolver' field
+func (st *ConfigState) SetHTTPClientResolver(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.HTTPClient.Resolver = v
+	st.reloadToViper()
+}
+
+// HTTPClientResolverFlag returns the flag name for the 'HTTPClient.Resolver' field
+func HTTPClientResolverFlag() string { return "httpclient-resolver" }
+
+// GetHTTPClientResolver safely fetches the value for global configuration 'HTTPClient.Resolver' field
+func GetHTTPClientResolver() string { return global.GetHTTPClientResolver() }
+
+// SetHTTPClientResolver safely sets the value for global configuration 'HTTPClient.Resolver' field
+func SetHTTPClientResolver(v string) { global.SetHTTPClientResolver(v) }
+
 // GetCacheMemoryTarget safely fetches the Configuration value for state's 'Cache.MemoryTarget' field
 func (st *ConfigState) GetCacheMemoryTarget() (v bytesize.Size) {
 	st.mutex.RLock()
@@ -2950,6 +3990,31 @@ func GetCacheBoostOfIDsMemRatio() float64 { return global.GetCacheBoostOfIDsMemR
 // SetCacheBoostOfIDsMemRatio safely sets the value for global configuration 'Cache.BoostOfIDsMemRatio' field
 func SetCacheBoostOfIDsMemRatio(v float64) { global.SetCacheBoostOfIDsMemRatio(v) }
 
+// GetCacheCardMemRatio safely fetches the Configuration value for state's 'Cache.CardMemRatio' field
+func (st *ConfigState) GetCacheCardMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.CardMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheCardMemRatio safely sets the Configuration value for state's 'Cache.CardMemRatio' field
+func (st *ConfigState) SetCacheCardMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.CardMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheCardMemRatioFlag returns the flag name for the 'Cache.CardMemRatio' field
+func CacheCardMemRatioFlag() string { return "cache-card-mem-ratio" }
+
+// GetCacheCardMemRatio safely fetches the value for global configuration 'Cache.CardMemRatio' field
+func GetCacheCardMemRatio() float64 { return global.GetCacheCardMemRatio() }
+
+// SetCacheCardMemRatio safely sets the value for global configuration 'Cache.CardMemRatio' field
+func SetCacheCardMemRatio(v float64) { global.SetCacheCardMemRatio(v) }
+
 // GetCacheClientMemRatio safely fetches the Configuration value for state's 'Cache.ClientMemRatio' field
 func (st *ConfigState) GetCacheClientMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -3200,6 +4265,56 @@ func GetCacheFollowRequestIDsMemRatio() float64 { return global.GetCacheFollowRe
 // SetCacheFollowRequestIDsMemRatio safely sets the value for global configuration 'Cache.FollowRequestIDsMemRatio' field
 func SetCacheFollowRequestIDsMemRatio(v float64) { global.SetCacheFollowRequestIDsMemRatio(v) }
 
+// GetCacheFeaturedTagMemRatio safely fetches the Configuration value for state's 'Cache.FeaturedTagMemRatio' field
+func (st *ConfigState) GetCacheFeaturedTagMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.FeaturedTagMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheFeaturedTagMemRatio safely sets the Configuration value for state's 'Cache.FeaturedTagMemRatio' field
+func (st *ConfigState) SetCacheFeaturedTagMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.FeaturedTagMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheFeaturedTagMemRatioFlag returns the flag name for the 'Cache.FeaturedTagMemRatio' field
+func CacheFeaturedTagMemRatioFlag() string { return "cache-featured-tag-mem-ratio" }
+
+// GetCacheFeaturedTagMemRatio safely fetches the value for global configuration 'Cache.FeaturedTagMemRatio' field
+func GetCacheFeaturedTagMemRatio() float64 { return global.GetCacheFeaturedTagMemRatio() }
+
+// SetCacheFeaturedTagMemRatio safely sets the value for global configuration 'Cache.FeaturedTagMemRatio' field
+func SetCacheFeaturedTagMemRatio(v float64) { global.SetCacheFeaturedTagMemRatio(v) }
+
+// GetCacheFollowedTagMemRatio safely fetches the Configuration value for state's 'Cache.FollowedTagMemRatio' field
+func (st *ConfigState) GetCacheFollowedTagMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.FollowedTagMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheFollowedTagMemRatio safely sets the Configuration value for state's 'Cache.FollowedTagMemRatio' field
+func (st *ConfigState) SetCacheFollowedTagMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.FollowedTagMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheFollowedTagMemRatioFlag returns the flag name for the 'Cache.FollowedTagMemRatio' field
+func CacheFollowedTagMemRatioFlag() string { return "cache-followed-tag-mem-ratio" }
+
+// GetCacheFollowedTagMemRatio safely fetches the value for global configuration 'Cache.FollowedTagMemRatio' field
+func GetCacheFollowedTagMemRatio() float64 { return global.GetCacheFollowedTagMemRatio() }
+
+// SetCacheFollowedTagMemRatio safely sets the value for global configuration 'Cache.FollowedTagMemRatio' field
+func SetCacheFollowedTagMemRatio(v float64) { global.SetCacheFollowedTagMemRatio(v) }
+
 // GetCacheInReplyToIDsMemRatio safely fetches the Configuration value for state's 'Cache.InReplyToIDsMemRatio' field
 func (st *ConfigState) GetCacheInReplyToIDsMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -3600,6 +4715,56 @@ func GetCacheStatusFaveIDsMemRatio() float64 { return global.GetCacheStatusFaveI
 // SetCacheStatusFaveIDsMemRatio safely sets the value for global configuration 'Cache.StatusFaveIDsMemRatio' field
 func SetCacheStatusFaveIDsMemRatio(v float64) { global.SetCacheStatusFaveIDsMemRatio(v) }
 
+// GetCacheStatusReactionMemRatio safely fetches the Configuration value for state's 'Cache.StatusReactionMemRatio' field
+func (st *ConfigState) GetCacheStatusReactionMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.StatusReactionMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheStatusReactionMemRatio safely sets the Configuration value for state's 'Cache.StatusReactionMemRatio' field
+func (st *ConfigState) SetCacheStatusReactionMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.StatusReactionMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheStatusReactionMemRatioFlag returns the flag name for the 'Cache.StatusReactionMemRatio' field
+func CacheStatusReactionMemRatioFlag() string { return "cache-status-reaction-mem-ratio" }
+
+// GetCacheStatusReactionMemRatio safely fetches the value for global configuration 'Cache.StatusReactionMemRatio' field
+func GetCacheStatusReactionMemRatio() float64 { return global.GetCacheStatusReactionMemRatio() }
+
+// SetCacheStatusReactionMemRatio safely sets the value for global configuration 'Cache.StatusReactionMemRatio' field
+func SetCacheStatusReactionMemRatio(v float64) { global.SetCacheStatusReactionMemRatio(v) }
+
+// GetCacheStatusReactionIDsMemRatio safely fetches the Configuration value for state's 'Cache.StatusReactionIDsMemRatio' field
+func (st *ConfigState) GetCacheStatusReactionIDsMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.StatusReactionIDsMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheStatusReactionIDsMemRatio safely sets the Configuration value for state's 'Cache.StatusReactionIDsMemRatio' field
+func (st *ConfigState) SetCacheStatusReactionIDsMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.StatusReactionIDsMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheStatusReactionIDsMemRatioFlag returns the flag name for the 'Cache.StatusReactionIDsMemRatio' field
+func CacheStatusReactionIDsMemRatioFlag() string { return "cache-status-reaction-ids-mem-ratio" }
+
+// GetCacheStatusReactionIDsMemRatio safely fetches the value for global configuration 'Cache.StatusReactionIDsMemRatio' field
+func GetCacheStatusReactionIDsMemRatio() float64 { return global.GetCacheStatusReactionIDsMemRatio() }
+
+// SetCacheStatusReactionIDsMemRatio safely sets the value for global configuration 'Cache.StatusReactionIDsMemRatio' field
+func SetCacheStatusReactionIDsMemRatio(v float64) { global.SetCacheStatusReactionIDsMemRatio(v) }
+
 // GetCacheTagMemRatio safely fetches the Configuration value for state's 'Cache.TagMemRatio' field
 func (st *ConfigState) GetCacheTagMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -3850,6 +5015,56 @@ func GetAdminAccountPassword() string { return global.GetAdminAccountPassword()
 // SetAdminAccountPassword safely sets the value for global configuration 'AdminAccountPassword' field
 func SetAdminAccountPassword(v string) { global.SetAdminAccountPassword(v) }
 
+// GetAdminAccountScopes safely fetches the Configuration value for state's 'AdminAccountScopes' field
+func (st *ConfigState) GetAdminAccountScopes() (v string) {
+	st.mutex.RLock()
+	v = st.config.AdminAccountScopes
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdminAccountScopes safely sets the Configuration value for state's 'AdminAccountScopes' field
+func (st *ConfigState) SetAdminAccountScopes(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminAccountScopes = v
+	st.reloadToViper()
+}
+
+// AdminAccountScopesFlag returns the flag name for the 'AdminAccountScopes' field
+func AdminAccountScopesFlag() string { return "scopes" }
+
+// GetAdminAccountScopes safely fetches the value for global configuration 'AdminAccountScopes' field
+func GetAdminAccountScopes() string { return global.GetAdminAccountScopes() }
+
+// SetAdminAccountScopes safely sets the value for global configuration 'AdminAccountScopes' field
+func SetAdminAccountScopes(v string) { global.SetAdminAccountScopes(v) }
+
+// GetAdminAccountRateLimit safely fetches the Configuration value for state's 'AdminAccountRateLimit' field
+func (st *ConfigState) GetAdminAccountRateLimit() (v int) {
+	st.mutex.RLock()
+	v = st.config.AdminAccountRateLimit
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdminAccountRateLimit safely sets the Configuration value for state's 'AdminAccountRateLimit' field
+func (st *ConfigState) SetAdminAccountRateLimit(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminAccountRateLimit = v
+	st.reloadToViper()
+}
+
+// AdminAccountRateLimitFlag returns the flag name for the 'AdminAccountRateLimit' field
+func AdminAccountRateLimitFlag() string { return "status-rate-limit" }
+
+// GetAdminAccountRateLimit safely fetches the value for global configuration 'AdminAccountRateLimit' field
+func GetAdminAccountRateLimit() int { return global.GetAdminAccountRateLimit() }
+
+// SetAdminAccountRateLimit safely sets the value for global configuration 'AdminAccountRateLimit' field
+func SetAdminAccountRateLimit(v int) { global.SetAdminAccountRateLimit(v) }
+
 // GetAdminTransPath safely fetches the Configuration value for state's 'AdminTransPath' field
 func (st *ConfigState) GetAdminTransPath() (v string) {
 	st.mutex.RLock()
@@ -3950,6 +5165,156 @@ func GetAdminMediaListRemoteOnly() bool { return global.GetAdminMediaListRemoteO
 // SetAdminMediaListRemoteOnly safely sets the value for global configuration 'AdminMediaListRemoteOnly' field
 func SetAdminMediaListRemoteOnly(v bool) { global.SetAdminMediaListRemoteOnly(v) }
 
+// GetTestrigSeedAccounts safely fetches the Configuration value for state's 'TestrigSeedAccounts' field
+func (st *ConfigState) GetTestrigSeedAccounts() (v int) {
+	st.mutex.RLock()
+	v = st.config.TestrigSeedAccounts
+	st.mutex.RUnlock()
+	return
+}
+
+// SetTestrigSeedAccounts safely sets the Configuration value for state's 'TestrigSeedAccounts' field
+func (st *ConfigState) SetTestrigSeedAccounts(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.TestrigSeedAccounts = v
+	st.reloadToViper()
+}
+
+// TestrigSeedAccountsFlag returns the flag name for the 'TestrigSeedAccounts' field
+func TestrigSeedAccountsFlag() string { return "seed-accounts" }
+
+// GetTestrigSeedAccounts safely fetches the value for global configuration 'TestrigSeedAccounts' field
+func GetTestrigSeedAccounts() int { return global.GetTestrigSeedAccounts() }
+
+// SetTestrigSeedAccounts safely sets the value for global configuration 'TestrigSeedAccounts' field
+func SetTestrigSeedAccounts(v int) { global.SetTestrigSeedAccounts(v) }
+
+// GetTestrigSeedFollows safely fetches the Configuration value for state's 'TestrigSeedFollows' field
+func (st *ConfigState) GetTestrigSeedFollows() (v int) {
+	st.mutex.RLock()
+	v = st.config.TestrigSeedFollows
+	st.mutex.RUnlock()
+	return
+}
+
+// SetTestrigSeedFollows safely sets the Configuration value for state's 'TestrigSeedFollows' field
+func (st *ConfigState) SetTestrigSeedFollows(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.TestrigSeedFollows = v
+	st.reloadToViper()
+}
+
+// TestrigSeedFollowsFlag returns the flag name for the 'TestrigSeedFollows' field
+func TestrigSeedFollowsFlag() string { return "seed-follows" }
+
+// GetTestrigSeedFollows safely fetches the value for global configuration 'TestrigSeedFollows' field
+func GetTestrigSeedFollows() int { return global.GetTestrigSeedFollows() }
+
+// SetTestrigSeedFollows safely sets the value for global configuration 'TestrigSeedFollows' field
+func SetTestrigSeedFollows(v int) { global.SetTestrigSeedFollows(v) }
+
+// GetTestrigSeedStatuses safely fetches the Configuration value for state's 'TestrigSeedStatuses' field
+func (st *ConfigState) GetTestrigSeedStatuses() (v int) {
+	st.mutex.RLock()
+	v = st.config.TestrigSeedStatuses
+	st.mutex.RUnlock()
+	return
+}
+
+// SetTestrigSeedStatuses safely sets the Configuration value for state's 'TestrigSeedStatuses' field
+func (st *ConfigState) SetTestrigSeedStatuses(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.TestrigSeedStatuses = v
+	st.reloadToViper()
+}
+
+// TestrigSeedStatusesFlag returns the flag name for the 'TestrigSeedStatuses' field
+func TestrigSeedStatusesFlag() string { return "seed-statuses" }
+
+// GetTestrigSeedStatuses safely fetches the value for global configuration 'TestrigSeedStatuses' field
+func GetTestrigSeedStatuses() int { return global.GetTestrigSeedStatuses() }
+
+// SetTestrigSeedStatuses safely sets the value for global configuration 'TestrigSeedStatuses' field
+func SetTestrigSeedStatuses(v int) { global.SetTestrigSeedStatuses(v) }
+
+// GetTestrigSeedMedia safely fetches the Configuration value for state's 'TestrigSeedMedia' field
+func (st *ConfigState) GetTestrigSeedMedia() (v int) {
+	st.mutex.RLock()
+	v = st.config.TestrigSeedMedia
+	st.mutex.RUnlock()
+	return
+}
+
+// SetTestrigSeedMedia safely sets the Configuration value for state's 'TestrigSeedMedia' field
+func (st *ConfigState) SetTestrigSeedMedia(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.TestrigSeedMedia = v
+	st.reloadToViper()
+}
+
+// TestrigSeedMediaFlag returns the flag name for the 'TestrigSeedMedia' field
+func TestrigSeedMediaFlag() string { return "seed-media" }
+
+// GetTestrigSeedMedia safely fetches the value for global configuration 'TestrigSeedMedia' field
+func GetTestrigSeedMedia() int { return global.GetTestrigSeedMedia() }
+
+// SetTestrigSeedMedia safely sets the value for global configuration 'TestrigSeedMedia' field
+func SetTestrigSeedMedia(v int) { global.SetTestrigSeedMedia(v) }
+
+// GetTestrigSeedNotifications safely fetches the Configuration value for state's 'TestrigSeedNotifications' field
+func (st *ConfigState) GetTestrigSeedNotifications() (v int) {
+	st.mutex.RLock()
+	v = st.config.TestrigSeedNotifications
+	st.mutex.RUnlock()
+	return
+}
+
+// SetTestrigSeedNotifications safely sets the Configuration value for state's 'TestrigSeedNotifications' field
+func (st *ConfigState) SetTestrigSeedNotifications(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.TestrigSeedNotifications = v
+	st.reloadToViper()
+}
+
+// TestrigSeedNotificationsFlag returns the flag name for the 'TestrigSeedNotifications' field
+func TestrigSeedNotificationsFlag() string { return "seed-notifications" }
+
+// GetTestrigSeedNotifications safely fetches the value for global configuration 'TestrigSeedNotifications' field
+func GetTestrigSeedNotifications() int { return global.GetTestrigSeedNotifications() }
+
+// SetTestrigSeedNotifications safely sets the value for global configuration 'TestrigSeedNotifications' field
+func SetTestrigSeedNotifications(v int) { global.SetTestrigSeedNotifications(v) }
+
+// GetTestrigSeedRandom safely fetches the Configuration value for state's 'TestrigSeedRandom' field
+func (st *ConfigState) GetTestrigSeedRandom() (v int) {
+	st.mutex.RLock()
+	v = st.config.TestrigSeedRandom
+	st.mutex.RUnlock()
+	return
+}
+
+// SetTestrigSeedRandom safely sets the Configuration value for state's 'TestrigSeedRandom' field
+func (st *ConfigState) SetTestrigSeedRandom(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.TestrigSeedRandom = v
+	st.reloadToViper()
+}
+
+// TestrigSeedRandomFlag returns the flag name for the 'TestrigSeedRandom' field
+func TestrigSeedRandomFlag() string { return "seed" }
+
+// GetTestrigSeedRandom safely fetches the value for global configuration 'TestrigSeedRandom' field
+func GetTestrigSeedRandom() int { return global.GetTestrigSeedRandom() }
+
+// SetTestrigSeedRandom safely sets the value for global configuration 'TestrigSeedRandom' field
+func SetTestrigSeedRandom(v int) { global.SetTestrigSeedRandom(v) }
+
 // GetRequestIDHeader safely fetches the Configuration value for state's 'RequestIDHeader' field
 func (st *ConfigState) GetRequestIDHeader() (v string) {
 	st.mutex.RLock()
@@ -19,6 +19,7 @@ package config
 
 import (
 	"net/netip"
+	"net/url"
 
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
@@ -37,3 +38,16 @@ func MustParseIPPrefixes(in []string) []netip.Prefix {
 
 	return prefs
 }
+
+// WebClientOrigin returns the origin (scheme + host)
+// of the given web client URL, or an empty string if
+// the URL is not an absolute http or https URL.
+func WebClientOrigin(webClient string) string {
+	u, err := url.Parse(webClient)
+	if err != nil || u.Host == "" ||
+		(u.Scheme != "http" && u.Scheme != "https") {
+		return ""
+	}
+
+	return u.Scheme + "://" + u.Host
+}
@@ -19,6 +19,8 @@ package config
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/miekg/dns"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
@@ -60,6 +62,16 @@ func Validate() error {
 		}
 	}
 
+	// `onion-host`, if set, should be
+	// an onion hostname, not host itself.
+	if onionHost := GetOnionHost(); onionHost != "" {
+		if !strings.HasSuffix(onionHost, ".onion") {
+			errf("%s %s must be a .onion hostname", OnionHostFlag(), onionHost)
+		} else if onionHost == host {
+			errf("%s must not be the same as %s", OnionHostFlag(), HostFlag())
+		}
+	}
+
 	// Ensure `protocol` sensibly set.
 	switch proto := GetProtocol(); proto {
 	case "https":
@@ -112,12 +124,164 @@ func Validate() error {
 		SetInstanceLanguages(parsedLangs)
 	}
 
+	// `accounts-username-min-length` and
+	// `accounts-username-max-length` should
+	// be within what usernames can be at all.
+	var (
+		usernameMinLen = GetAccountsUsernameMinLength()
+		usernameMaxLen = GetAccountsUsernameMaxLength()
+	)
+
+	if usernameMinLen < 1 {
+		errf("%s must be at least 1", AccountsUsernameMinLengthFlag())
+	}
+
+	if usernameMaxLen > 64 {
+		errf("%s must be at most 64", AccountsUsernameMaxLengthFlag())
+	}
+
+	if usernameMinLen > usernameMaxLen {
+		errf(
+			"%s must not be more than %s",
+			AccountsUsernameMinLengthFlag(), AccountsUsernameMaxLengthFlag(),
+		)
+	}
+
+	// `accounts-username-pattern`
+	// should be a valid regex.
+	if pattern := GetAccountsUsernamePattern(); pattern != "" {
+		if _, err := regexp.Compile(pattern); err != nil {
+			errf(
+				"%s could not be compiled as a regular expression: %v",
+				AccountsUsernamePatternFlag(), err,
+			)
+		}
+	}
+
+	// `instance-blocked-interactions-threshold` can't
+	// be negative, and if it's set then the window
+	// to count blocked interactions in must be too.
+	if threshold := GetInstanceBlockedInteractionsThreshold(); threshold < 0 {
+		errf("%s must not be negative", InstanceBlockedInteractionsThresholdFlag())
+	} else if threshold > 0 && GetInstanceBlockedInteractionsWindow() <= 0 {
+		errf(
+			"%s must be greater than 0 when %s is set",
+			InstanceBlockedInteractionsWindowFlag(), InstanceBlockedInteractionsThresholdFlag(),
+		)
+	}
+
+	// `accounts-minimum-age` can't be negative.
+	if GetAccountsMinimumAge() < 0 {
+		errf("%s must not be negative", AccountsMinimumAgeFlag())
+	}
+
+	// `media-allowed-mime-types` should be
+	// type/subtype pairs; normalize them
+	// to lowercase to ease comparison.
+	if allowedMIMETypes := GetMediaAllowedMIMETypes(); len(allowedMIMETypes) > 0 {
+		normalized := make([]string, 0, len(allowedMIMETypes))
+		for _, mimeType := range allowedMIMETypes {
+			mimeType = strings.ToLower(strings.TrimSpace(mimeType))
+			typ, subtype, ok := strings.Cut(mimeType, "/")
+			if !ok || typ == "" || subtype == "" || strings.Contains(subtype, "/") {
+				errf(
+					"%s entry %s must be a MIME type like image/jpeg",
+					MediaAllowedMIMETypesFlag(), mimeType,
+				)
+				continue
+			}
+			normalized = append(normalized, mimeType)
+		}
+		SetMediaAllowedMIMETypes(normalized)
+	}
+
 	// `web-assets-base-dir`.
 	webAssetsBaseDir := GetWebAssetBaseDir()
 	if webAssetsBaseDir == "" {
 		errf("%s must be set", WebAssetBaseDirFlag())
 	}
 
+	// Truncated summaries need room
+	// for some text plus an ellipsis.
+	for flag, length := range map[string]int{
+		WebRSSTitleLengthFlag():             GetWebRSSTitleLength(),
+		WebRSSDescriptionLengthFlag():       GetWebRSSDescriptionLength(),
+		WebOpenGraphDescriptionLengthFlag(): GetWebOpenGraphDescriptionLength(),
+	} {
+		if length < 16 {
+			errf("%s must be at least 16", flag)
+		}
+	}
+
+	// `advanced-cors-allow-origins` should be http(s)
+	// origins, or patterns with at most one wildcard,
+	// which is all that the CORS middleware supports.
+	for _, origin := range GetAdvancedCORSAllowOrigins() {
+		if strings.Count(origin, "*") > 1 {
+			errf(
+				"%s origin %s must not contain more than one '*'",
+				AdvancedCORSAllowOriginsFlag(), origin,
+			)
+			continue
+		}
+
+		if !strings.Contains(origin, "*") &&
+			!strings.HasPrefix(origin, "http://") &&
+			!strings.HasPrefix(origin, "https://") {
+			errf(
+				"%s origin %s must contain '*' or start with http:// or https://",
+				AdvancedCORSAllowOriginsFlag(), origin,
+			)
+		}
+	}
+
+	// `advanced-cors-web-clients`
+	// should be absolute http(s) URLs.
+	for _, webClient := range GetAdvancedCORSWebClients() {
+		if WebClientOrigin(webClient) == "" {
+			errf(
+				"%s url %s must be an absolute http or https url",
+				AdvancedCORSWebClientsFlag(), webClient,
+			)
+		}
+	}
+
+	// `media-unknown-placeholder` should
+	// be "note", "links", or "none".
+	switch placeholder := GetMediaUnknownPlaceholder(); placeholder {
+	case MediaUnknownPlaceholderNote, MediaUnknownPlaceholderLinks, MediaUnknownPlaceholderNone:
+		// No problem.
+
+	default:
+		errf(
+			"%s must be set to %s, %s, or %s, provided value was %s",
+			MediaUnknownPlaceholderFlag(), MediaUnknownPlaceholderNote,
+			MediaUnknownPlaceholderLinks, MediaUnknownPlaceholderNone, placeholder,
+		)
+	}
+
+	// `advanced-timeline-storage` should
+	// be "memory" or "database".
+	switch storage := GetAdvancedTimelineStorage(); storage {
+	case TimelineStorageMemory, TimelineStorageDatabase:
+		// No problem.
+
+	default:
+		errf(
+			"%s must be set to either %s or %s, provided value was %s",
+			AdvancedTimelineStorageFlag(), TimelineStorageMemory, TimelineStorageDatabase, storage,
+		)
+	}
+
+	// `advanced-delivery-log-retention`
+	// should not be negative.
+	if retention := GetAdvancedDeliveryLogRetention(); retention < 0 {
+		errf(
+			"%s must be 0 or greater, provided value was %s",
+			AdvancedDeliveryLogRetentionFlag(), retention,
+		)
+	}
+
 	// Custom / LE TLS settings.
 	//
 	// Only one of custom certs or LE can be set,
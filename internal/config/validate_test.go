@@ -45,6 +45,24 @@ func (suite *ConfigValidateTestSuite) TestValidateConfigNoHost() {
 	suite.EqualError(err, "host must be set")
 }
 
+func (suite *ConfigValidateTestSuite) TestValidateOnionHostOK() {
+	testrig.InitTestConfig()
+
+	config.SetOnionHost("gotosocialxyz.onion")
+
+	err := config.Validate()
+	suite.NoError(err)
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateOnionHostNotOnion() {
+	testrig.InitTestConfig()
+
+	config.SetOnionHost("example.org")
+
+	err := config.Validate()
+	suite.EqualError(err, "onion-host example.org must be a .onion hostname")
+}
+
 func (suite *ConfigValidateTestSuite) TestValidateAccountDomainOK1() {
 	testrig.InitTestConfig()
 
@@ -111,6 +129,15 @@ func (suite *ConfigValidateTestSuite) TestValidateConfigNoWebAssetBaseDir() {
 	suite.EqualError(err, "web-asset-base-dir must be set")
 }
 
+func (suite *ConfigValidateTestSuite) TestValidateConfigWebRSSTitleLengthTooShort() {
+	testrig.InitTestConfig()
+
+	config.SetWebRSSTitleLength(3)
+
+	err := config.Validate()
+	suite.EqualError(err, "web-rss-title-length must be at least 16")
+}
+
 func (suite *ConfigValidateTestSuite) TestValidateConfigNoProtocolOrHost() {
 	testrig.InitTestConfig()
 
@@ -140,6 +167,44 @@ func (suite *ConfigValidateTestSuite) TestValidateConfigBadProtocolNoHost() {
 	suite.EqualError(err, "host must be set\nprotocol must be set to either http or https, provided value was foo")
 }
 
+func (suite *ConfigValidateTestSuite) TestValidateConfigBadCORS() {
+	testrig.InitTestConfig()
+
+	config.SetAdvancedCORSAllowOrigins([]string{"https://elk.zone", "*.example.org", "elk.zone", "https://*.*.example.org"})
+	config.SetAdvancedCORSWebClients([]string{"https://pinafore.example.org/", "pinafore.example.org"})
+
+	err := config.Validate()
+	suite.EqualError(err, "advanced-cors-allow-origins origin elk.zone must contain '*' or start with http:// or https://\nadvanced-cors-allow-origins origin https://*.*.example.org must not contain more than one '*'\nadvanced-cors-web-clients url pinafore.example.org must be an absolute http or https url")
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateConfigMediaAllowedMIMETypes() {
+	testrig.InitTestConfig()
+
+	config.SetMediaAllowedMIMETypes([]string{" Image/JPEG", "image/png"})
+
+	err := config.Validate()
+	suite.NoError(err)
+	suite.Equal([]string{"image/jpeg", "image/png"}, config.GetMediaAllowedMIMETypes())
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateConfigBadMediaAllowedMIMETypes() {
+	testrig.InitTestConfig()
+
+	config.SetMediaAllowedMIMETypes([]string{"image/jpeg", "png", "image/"})
+
+	err := config.Validate()
+	suite.EqualError(err, "media-allowed-mime-types entry png must be a MIME type like image/jpeg\nmedia-allowed-mime-types entry image/ must be a MIME type like image/jpeg")
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateConfigBadMediaUnknownPlaceholder() {
+	testrig.InitTestConfig()
+
+	config.SetMediaUnknownPlaceholder("html")
+
+	err := config.Validate()
+	suite.EqualError(err, "media-unknown-placeholder must be set to note, links, or none, provided value was html")
+}
+
 func TestConfigValidateTestSuite(t *testing.T) {
 	suite.Run(t, &ConfigValidateTestSuite{})
 }
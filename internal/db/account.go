@@ -20,6 +20,7 @@ package db
 import (
 	"context"
 	"net/netip"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/paging"
@@ -40,6 +41,7 @@ type Account interface {
 	GetAccountByURL(ctx context.Context, uri string) (*gtsmodel.Account, error)
 
 	// GetAccountByUsernameDomain returns one account with the given username and domain, or an error if something goes wrong.
+	// For local accounts (empty domain), a username that was changed away from still returns the account that changed it.
 	GetAccountByUsernameDomain(ctx context.Context, username string, domain string) (*gtsmodel.Account, error)
 
 	// GetAccountByPubkeyID returns one account with the given public key URI (ID), or an error if something goes wrong.
@@ -121,6 +123,28 @@ type Account interface {
 	// In the case of no statuses, this function will return db.ErrNoEntries.
 	GetAccountWebStatuses(ctx context.Context, accountID string, limit int, maxID string) ([]*gtsmodel.Status, error)
 
+	// GetSuggestedAccounts gets up to limit discoverable local accounts to suggest
+	// following, with the most followed first. Accounts that are suspended, moved,
+	// or whose user isn't approved or is disabled are not included.
+	GetSuggestedAccounts(ctx context.Context, limit int) ([]*gtsmodel.Account, error)
+
+	// CountAccountStatusesSince returns the number of statuses (including boosts)
+	// created by the given account at or after the given time.
+	CountAccountStatusesSince(ctx context.Context, accountID string, since time.Time) (int, error)
+
+	// GetAccountStatusTimes returns the creation times of statuses (excluding boosts)
+	// created by the given account at or after the given time, in no particular order.
+	GetAccountStatusTimes(ctx context.Context, accountID string, since time.Time) ([]time.Time, error)
+
+	// GetAccountFollowerTimes returns the creation times of follows targeting
+	// the given account created at or after the given time, in no particular order.
+	GetAccountFollowerTimes(ctx context.Context, accountID string, since time.Time) ([]time.Time, error)
+
+	// CountAccountInteractionsSince returns the number of faves, boosts, and replies
+	// targeting statuses of the given account, created at or after the given time,
+	// keyed by the ID of the interacting account. The account's own are excluded.
+	CountAccountInteractionsSince(ctx context.Context, accountID string, since time.Time) (map[string]int, error)
+
 	// SetAccountHeaderOrAvatar sets the header or avatar for the given accountID to the given media attachment.
 	SetAccountHeaderOrAvatar(ctx context.Context, mediaAttachment *gtsmodel.MediaAttachment, accountID string) error
 
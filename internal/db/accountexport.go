@@ -0,0 +1,50 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// AccountExport contains functions for getting/creating/deleting
+// data export requests of local accounts, and their archives.
+type AccountExport interface {
+	// GetAccountExportByID gets one account export by its db id.
+	GetAccountExportByID(ctx context.Context, id string) (*gtsmodel.AccountExport, error)
+
+	// GetAccountExports gets all exports
+	// of the given account, newest first.
+	GetAccountExports(ctx context.Context, accountID string) ([]*gtsmodel.AccountExport, error)
+
+	// GetUnfinishedAccountExports gets all account exports,
+	// of any account, that are pending or still processing.
+	GetUnfinishedAccountExports(ctx context.Context) ([]*gtsmodel.AccountExport, error)
+
+	// PutAccountExport puts the given account export in the database.
+	PutAccountExport(ctx context.Context, export *gtsmodel.AccountExport) error
+
+	// UpdateAccountExport updates the given account export by ID,
+	// updating only the given columns, or all if none are given.
+	UpdateAccountExport(ctx context.Context, export *gtsmodel.AccountExport, columns ...string) error
+
+	// DeleteAccountExportByID deletes one account export by its db id.
+	// Note that this does not delete the export archive from storage.
+	DeleteAccountExportByID(ctx context.Context, id string) error
+}
@@ -0,0 +1,63 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// AccountImport contains functions for getting/creating/deleting
+// data import requests of local accounts, and their rows.
+type AccountImport interface {
+	// GetAccountImportByID gets one account import by its db id.
+	GetAccountImportByID(ctx context.Context, id string) (*gtsmodel.AccountImport, error)
+
+	// GetAccountImports gets all imports
+	// of the given account, newest first.
+	GetAccountImports(ctx context.Context, accountID string) ([]*gtsmodel.AccountImport, error)
+
+	// GetUnfinishedAccountImports gets all account imports,
+	// of any account, that are pending or still processing.
+	GetUnfinishedAccountImports(ctx context.Context) ([]*gtsmodel.AccountImport, error)
+
+	// PutAccountImport puts the given account
+	// import and all its rows in the database.
+	PutAccountImport(ctx context.Context, imp *gtsmodel.AccountImport, rows []*gtsmodel.AccountImportRow) error
+
+	// UpdateAccountImport updates the given account import by ID,
+	// updating only the given columns, or all if none are given.
+	UpdateAccountImport(ctx context.Context, imp *gtsmodel.AccountImport, columns ...string) error
+
+	// GetAccountImportRows gets rows of the given import in the
+	// given state, in file order. If limit is > 0, at most limit
+	// rows are returned.
+	GetAccountImportRows(ctx context.Context, importID string, state gtsmodel.AccountImportRowState, limit int) ([]*gtsmodel.AccountImportRow, error)
+
+	// CountAccountImportRows counts rows of the given import in the given state.
+	CountAccountImportRows(ctx context.Context, importID string, state gtsmodel.AccountImportRowState) (int, error)
+
+	// UpdateAccountImportRow updates the given account import row by ID,
+	// updating only the given columns, or all if none are given.
+	UpdateAccountImportRow(ctx context.Context, row *gtsmodel.AccountImportRow, columns ...string) error
+
+	// DeleteAccountImportsByAccountID deletes all
+	// imports of the given account, and their rows.
+	DeleteAccountImportsByAccountID(ctx context.Context, accountID string) error
+}
@@ -0,0 +1,81 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Announcement contains functions for getting/creating/updating/deleting
+// instance announcements, and reads + reactions of announcements.
+type Announcement interface {
+	// GetAnnouncementByID gets one announcement by its db id.
+	GetAnnouncementByID(ctx context.Context, id string) (*gtsmodel.Announcement, error)
+
+	// GetAnnouncements gets all announcements,
+	// including unpublished ones, newest first.
+	GetAnnouncements(ctx context.Context) ([]*gtsmodel.Announcement, error)
+
+	// GetActiveAnnouncements gets all published announcements
+	// that should be shown at the given time, newest first.
+	GetActiveAnnouncements(ctx context.Context, now time.Time) ([]*gtsmodel.Announcement, error)
+
+	// GetScheduledAnnouncements gets all published announcements
+	// that have yet to start or end as of the given time.
+	GetScheduledAnnouncements(ctx context.Context, now time.Time) ([]*gtsmodel.Announcement, error)
+
+	// PopulateAnnouncement ensures that all sub-models
+	// of the given announcement are populated.
+	PopulateAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement) error
+
+	// PutAnnouncement puts the given announcement in the database.
+	PutAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement) error
+
+	// UpdateAnnouncement updates the given announcement. If
+	// columns is empty, all columns will be updated.
+	UpdateAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement, columns ...string) error
+
+	// DeleteAnnouncementByID deletes one announcement by its
+	// db id, along with any reads and reactions pertaining to it.
+	DeleteAnnouncementByID(ctx context.Context, id string) error
+
+	// IsAnnouncementReadByAccount returns whether the
+	// given account has read (dismissed) the given announcement.
+	IsAnnouncementReadByAccount(ctx context.Context, announcementID string, accountID string) (bool, error)
+
+	// PutAnnouncementRead puts the given announcement read in the database.
+	// Marking an already-read announcement as read again is not an error.
+	PutAnnouncementRead(ctx context.Context, read *gtsmodel.AnnouncementRead) error
+
+	// GetAnnouncementReactions gets all reactions to the given
+	// announcement, oldest first, with custom emojis populated.
+	GetAnnouncementReactions(ctx context.Context, announcementID string) ([]*gtsmodel.AnnouncementReaction, error)
+
+	// GetAnnouncementReaction gets the reaction with the given
+	// name, created by the given account, to the given announcement.
+	GetAnnouncementReaction(ctx context.Context, announcementID string, accountID string, name string) (*gtsmodel.AnnouncementReaction, error)
+
+	// PutAnnouncementReaction puts the given announcement reaction in the database.
+	PutAnnouncementReaction(ctx context.Context, reaction *gtsmodel.AnnouncementReaction) error
+
+	// DeleteAnnouncementReactionByID deletes one announcement reaction by its db id.
+	DeleteAnnouncementReactionByID(ctx context.Context, id string) error
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/dialect"
@@ -107,6 +108,10 @@ func (a *accountDB) GetAccountsByIDs(ctx context.Context, ids []string) ([]*gtsm
 }
 
 func (a *accountDB) GetAccountByURI(ctx context.Context, uri string) (*gtsmodel.Account, error) {
+	// Stored URIs are normalized,
+	// so do the same for lookups.
+	uri = uris.Normalize(uri)
+
 	return a.getAccount(
 		ctx,
 		"URI",
@@ -161,7 +166,23 @@ func (a *accountDB) GetAccountByUsernameDomain(ctx context.Context, username str
 					Where("? IS NULL", bun.Ident("account.domain"))
 			}
 
-			return q.Scan(ctx)
+			err := q.Scan(ctx)
+			if domain != "" || !errors.Is(err, db.ErrNoEntries) {
+				return err
+			}
+
+			// No local account currently has this username,
+			// but one might have had it before changing it,
+			// in which case lookups should still resolve.
+			return a.db.NewSelect().
+				Model(account).
+				Where("? = (?)", bun.Ident("account.id"), a.db.
+					NewSelect().
+					Table("username_changes").
+					Column("account_id").
+					Where("? = ?", bun.Ident("old_username"), strings.ToLower(username)),
+				).
+				Scan(ctx)
 		},
 		username,
 		domain,
@@ -702,6 +723,8 @@ func (a *accountDB) PopulateAccount(ctx context.Context, account *gtsmodel.Accou
 }
 
 func (a *accountDB) PutAccount(ctx context.Context, account *gtsmodel.Account) error {
+	account.URI = uris.Normalize(account.URI)
+
 	return a.state.Caches.GTS.Account.Store(account, func() error {
 		// It is safe to run this database transaction within cache.Store
 		// as the cache does not attempt a mutex lock until AFTER hook.
@@ -731,6 +754,8 @@ func (a *accountDB) UpdateAccount(ctx context.Context, account *gtsmodel.Account
 		columns = append(columns, "updated_at")
 	}
 
+	account.URI = uris.Normalize(account.URI)
+
 	return a.state.Caches.GTS.Account.Store(account, func() error {
 		// It is safe to run this database transaction within cache.Store
 		// as the cache does not attempt a mutex lock until AFTER hook.
@@ -999,6 +1024,45 @@ func (a *accountDB) GetAccountStatuses(ctx context.Context, accountID string, li
 	return a.state.DB.GetStatusesByIDs(ctx, statusIDs)
 }
 
+func (a *accountDB) GetSuggestedAccounts(ctx context.Context, limit int) ([]*gtsmodel.Account, error) {
+	var accountIDs []string
+
+	// Count of followers of each account.
+	followers := a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("follows"), bun.Ident("follow")).
+		ColumnExpr("COUNT(*)").
+		Where("? = ?", bun.Ident("follow.target_account_id"), bun.Ident("account.id"))
+
+	if err := a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("accounts"), bun.Ident("account")).
+		Column("account.id").
+		Join(
+			"JOIN ? AS ? ON ? = ?",
+			bun.Ident("users"), bun.Ident("user"),
+			bun.Ident("account.id"), bun.Ident("user.account_id"),
+		).
+		Where("? IS NULL", bun.Ident("account.domain")).
+		Where("? = ?", bun.Ident("account.discoverable"), true).
+		Where("? IS NULL", bun.Ident("account.suspended_at")).
+		Where("? IS NULL", bun.Ident("account.moved_to_uri")).
+		Where("? = ?", bun.Ident("user.approved"), true).
+		Where("? = ?", bun.Ident("user.disabled"), false).
+		OrderExpr("(?) DESC", followers).
+		Order("account.id DESC").
+		Limit(limit).
+		Scan(ctx, &accountIDs); err != nil {
+		return nil, err
+	}
+
+	if len(accountIDs) == 0 {
+		return nil, nil
+	}
+
+	return a.state.DB.GetAccountsByIDs(ctx, accountIDs)
+}
+
 func (a *accountDB) GetAccountPinnedStatuses(ctx context.Context, accountID string) ([]*gtsmodel.Status, error) {
 	statusIDs := []string{}
 
@@ -1073,6 +1137,95 @@ func (a *accountDB) GetAccountWebStatuses(ctx context.Context, accountID string,
 	return a.state.DB.GetStatusesByIDs(ctx, statusIDs)
 }
 
+func (a *accountDB) CountAccountStatusesSince(ctx context.Context, accountID string, since time.Time) (int, error) {
+	return a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		Where("? = ?", bun.Ident("status.account_id"), accountID).
+		Where("? >= ?", bun.Ident("status.created_at"), since).
+		Count(ctx)
+}
+
+func (a *accountDB) GetAccountStatusTimes(ctx context.Context, accountID string, since time.Time) ([]time.Time, error) {
+	var times []time.Time
+	if err := a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		Column("status.created_at").
+		Where("? = ?", bun.Ident("status.account_id"), accountID).
+		Where("? IS NULL", bun.Ident("status.boost_of_id")).
+		Where("? >= ?", bun.Ident("status.created_at"), since).
+		Scan(ctx, &times); err != nil {
+		return nil, err
+	}
+	return times, nil
+}
+
+func (a *accountDB) GetAccountFollowerTimes(ctx context.Context, accountID string, since time.Time) ([]time.Time, error) {
+	var times []time.Time
+	if err := a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("follows"), bun.Ident("follow")).
+		Column("follow.created_at").
+		Where("? = ?", bun.Ident("follow.target_account_id"), accountID).
+		Where("? >= ?", bun.Ident("follow.created_at"), since).
+		Scan(ctx, &times); err != nil {
+		return nil, err
+	}
+	return times, nil
+}
+
+func (a *accountDB) CountAccountInteractionsSince(ctx context.Context, accountID string, since time.Time) (map[string]int, error) {
+	// interactionCount is a row of
+	// interactions by one account.
+	type interactionCount struct {
+		AccountID string `bun:"account_id"`
+		Count     int    `bun:"count"`
+	}
+
+	// countInteractions scans the interaction
+	// counts in table, where targetColumn
+	// matches the given account ID.
+	counts := make(map[string]int)
+	countInteractions := func(table, alias, targetColumn string) error {
+		var rows []interactionCount
+		if err := a.db.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident(table), bun.Ident(alias)).
+			ColumnExpr("? AS ?", bun.Ident(alias+".account_id"), bun.Ident("account_id")).
+			ColumnExpr("COUNT(*) AS ?", bun.Ident("count")).
+			Where("? = ?", bun.Ident(alias+"."+targetColumn), accountID).
+			Where("? != ?", bun.Ident(alias+".account_id"), accountID).
+			Where("? >= ?", bun.Ident(alias+".created_at"), since).
+			GroupExpr("?", bun.Ident(alias+".account_id")).
+			Scan(ctx, &rows); err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			counts[row.AccountID] += row.Count
+		}
+		return nil
+	}
+
+	// Faves of the account's statuses.
+	if err := countInteractions("status_faves", "status_fave", "target_account_id"); err != nil {
+		return nil, err
+	}
+
+	// Boosts of the account's statuses.
+	if err := countInteractions("statuses", "status", "boost_of_account_id"); err != nil {
+		return nil, err
+	}
+
+	// Replies to the account's statuses.
+	if err := countInteractions("statuses", "status", "in_reply_to_account_id"); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
 func (a *accountDB) GetAccountSettings(
 	ctx context.Context,
 	accountID string,
@@ -440,6 +440,34 @@ func (suite *AccountTestSuite) TestInsertAccountWithDefaults() {
 	suite.False(*newAccount.Discoverable)
 }
 
+func (suite *AccountTestSuite) TestPutAccountNormalizedURI() {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	suite.NoError(err)
+
+	newAccount := &gtsmodel.Account{
+		ID:           "01HXMZ5DYC3VQF0Q2X4S8QXW1B",
+		Username:     "test_service",
+		Domain:       "example.org",
+		URI:          "https://Example.ORG:443/users/test_service",
+		URL:          "https://example.org/@test_service",
+		ActorType:    ap.ActorService,
+		PublicKey:    &key.PublicKey,
+		PublicKeyURI: "https://example.org/users/test_service#main-key",
+	}
+
+	if err := suite.db.PutAccount(context.Background(), newAccount); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("https://example.org/users/test_service", newAccount.URI)
+
+	// Lookups should be normalized in the same way.
+	account, err := suite.db.GetAccountByURI(context.Background(), "HTTPS://EXAMPLE.org/users/test_service")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(newAccount.ID, account.ID)
+}
+
 func (suite *AccountTestSuite) TestGetAccountPinnedStatusesSomeResults() {
 	testAccount := suite.testAccounts["admin_account"]
 
@@ -773,6 +801,31 @@ func (suite *AccountTestSuite) TestAccountStatsAll() {
 	}
 }
 
+func (suite *AccountTestSuite) TestGetSuggestedAccounts() {
+	ctx := context.Background()
+
+	accounts, err := suite.db.GetSuggestedAccounts(ctx, 10)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ids := make([]string, 0, len(accounts))
+	for _, account := range accounts {
+		suite.True(account.IsLocal())
+		suite.True(*account.Discoverable)
+		suite.True(account.SuspendedAt.IsZero())
+		ids = append(ids, account.ID)
+	}
+
+	// Only approved, discoverable, local
+	// users, most followed first. The instance
+	// account has no user so it's left out.
+	suite.Equal([]string{
+		suite.testAccounts["local_account_1"].ID,
+		suite.testAccounts["admin_account"].ID,
+	}, ids)
+}
+
 func TestAccountTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountTestSuite))
 }
@@ -0,0 +1,112 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type accountExportDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (a *accountExportDB) GetAccountExportByID(ctx context.Context, id string) (*gtsmodel.AccountExport, error) {
+	var export gtsmodel.AccountExport
+
+	if err := a.db.
+		NewSelect().
+		Model(&export).
+		Where("? = ?", bun.Ident("account_export.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &export, nil
+}
+
+func (a *accountExportDB) GetAccountExports(ctx context.Context, accountID string) ([]*gtsmodel.AccountExport, error) {
+	var exports []*gtsmodel.AccountExport
+
+	if err := a.db.
+		NewSelect().
+		Model(&exports).
+		Where("? = ?", bun.Ident("account_export.account_id"), accountID).
+		OrderExpr("? DESC", bun.Ident("account_export.id")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return exports, nil
+}
+
+func (a *accountExportDB) GetUnfinishedAccountExports(ctx context.Context) ([]*gtsmodel.AccountExport, error) {
+	var exports []*gtsmodel.AccountExport
+
+	if err := a.db.
+		NewSelect().
+		Model(&exports).
+		Where("? IN (?)", bun.Ident("account_export.state"), bun.In([]gtsmodel.AccountExportState{
+			gtsmodel.AccountExportStatePending,
+			gtsmodel.AccountExportStateProcessing,
+		})).
+		OrderExpr("? ASC", bun.Ident("account_export.id")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return exports, nil
+}
+
+func (a *accountExportDB) PutAccountExport(ctx context.Context, export *gtsmodel.AccountExport) error {
+	_, err := a.db.
+		NewInsert().
+		Model(export).
+		Exec(ctx)
+	return err
+}
+
+func (a *accountExportDB) UpdateAccountExport(ctx context.Context, export *gtsmodel.AccountExport, columns ...string) error {
+	export.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column, ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := a.db.
+		NewUpdate().
+		Model(export).
+		Column(columns...).
+		Where("? = ?", bun.Ident("account_export.id"), export.ID).
+		Exec(ctx)
+	return err
+}
+
+func (a *accountExportDB) DeleteAccountExportByID(ctx context.Context, id string) error {
+	_, err := a.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("account_exports"), bun.Ident("account_export")).
+		Where("? = ?", bun.Ident("account_export.id"), id).
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,97 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+type AccountExportTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *AccountExportTestSuite) TestPutUpdateDelete() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+
+	export := &gtsmodel.AccountExport{
+		ID:        id.NewULID(),
+		AccountID: account.ID,
+		State:     gtsmodel.AccountExportStatePending,
+	}
+	if err := suite.db.PutAccountExport(ctx, export); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Pending export should be unfinished.
+	unfinished, err := suite.db.GetUnfinishedAccountExports(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(unfinished, 1)
+	suite.Equal(export.ID, unfinished[0].ID)
+
+	// Mark it as done.
+	export.State = gtsmodel.AccountExportStateDone
+	export.FilePath = account.ID + "/export/original/" + export.ID + ".tgz"
+	export.FileSize = 1024
+	if err := suite.db.UpdateAccountExport(ctx, export,
+		"state",
+		"file_path",
+		"file_size",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Should no longer be unfinished.
+	unfinished, err = suite.db.GetUnfinishedAccountExports(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(unfinished)
+
+	// Should be returned with the updated fields.
+	exports, err := suite.db.GetAccountExports(ctx, account.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(exports, 1)
+	suite.Equal(gtsmodel.AccountExportStateDone, exports[0].State)
+	suite.Equal(export.FilePath, exports[0].FilePath)
+	suite.Equal(1024, exports[0].FileSize)
+	suite.True(exports[0].Finished())
+
+	// Delete it.
+	if err := suite.db.DeleteAccountExportByID(ctx, export.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.db.GetAccountExportByID(ctx, export.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func TestAccountExportTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountExportTestSuite))
+}
@@ -0,0 +1,198 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type accountImportDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (a *accountImportDB) GetAccountImportByID(ctx context.Context, id string) (*gtsmodel.AccountImport, error) {
+	var imp gtsmodel.AccountImport
+
+	if err := a.db.
+		NewSelect().
+		Model(&imp).
+		Where("? = ?", bun.Ident("account_import.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &imp, nil
+}
+
+func (a *accountImportDB) GetAccountImports(ctx context.Context, accountID string) ([]*gtsmodel.AccountImport, error) {
+	var imps []*gtsmodel.AccountImport
+
+	if err := a.db.
+		NewSelect().
+		Model(&imps).
+		Where("? = ?", bun.Ident("account_import.account_id"), accountID).
+		OrderExpr("? DESC", bun.Ident("account_import.id")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return imps, nil
+}
+
+func (a *accountImportDB) GetUnfinishedAccountImports(ctx context.Context) ([]*gtsmodel.AccountImport, error) {
+	var imps []*gtsmodel.AccountImport
+
+	if err := a.db.
+		NewSelect().
+		Model(&imps).
+		Where("? IN (?)", bun.Ident("account_import.state"), bun.In([]gtsmodel.AccountImportState{
+			gtsmodel.AccountImportStatePending,
+			gtsmodel.AccountImportStateProcessing,
+		})).
+		OrderExpr("? ASC", bun.Ident("account_import.id")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return imps, nil
+}
+
+func (a *accountImportDB) PutAccountImport(ctx context.Context, imp *gtsmodel.AccountImport, rows []*gtsmodel.AccountImportRow) error {
+	return a.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.
+			NewInsert().
+			Model(imp).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		// Insert rows in batches, to stay
+		// within query parameter limits.
+		const batchSize = 500
+		for len(rows) > 0 {
+			batch := rows[:min(batchSize, len(rows))]
+			rows = rows[len(batch):]
+
+			if _, err := tx.
+				NewInsert().
+				Model(&batch).
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (a *accountImportDB) UpdateAccountImport(ctx context.Context, imp *gtsmodel.AccountImport, columns ...string) error {
+	imp.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column, ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := a.db.
+		NewUpdate().
+		Model(imp).
+		Column(columns...).
+		Where("? = ?", bun.Ident("account_import.id"), imp.ID).
+		Exec(ctx)
+	return err
+}
+
+func (a *accountImportDB) GetAccountImportRows(ctx context.Context, importID string, state gtsmodel.AccountImportRowState, limit int) ([]*gtsmodel.AccountImportRow, error) {
+	var rows []*gtsmodel.AccountImportRow
+
+	q := a.db.
+		NewSelect().
+		Model(&rows).
+		Where("? = ?", bun.Ident("account_import_row.import_id"), importID).
+		Where("? = ?", bun.Ident("account_import_row.state"), state).
+		OrderExpr("? ASC", bun.Ident("account_import_row.line"))
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (a *accountImportDB) CountAccountImportRows(ctx context.Context, importID string, state gtsmodel.AccountImportRowState) (int, error) {
+	return a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("account_import_rows"), bun.Ident("account_import_row")).
+		Where("? = ?", bun.Ident("account_import_row.import_id"), importID).
+		Where("? = ?", bun.Ident("account_import_row.state"), state).
+		Count(ctx)
+}
+
+func (a *accountImportDB) UpdateAccountImportRow(ctx context.Context, row *gtsmodel.AccountImportRow, columns ...string) error {
+	_, err := a.db.
+		NewUpdate().
+		Model(row).
+		Column(columns...).
+		Where("? = ?", bun.Ident("account_import_row.id"), row.ID).
+		Exec(ctx)
+	return err
+}
+
+func (a *accountImportDB) DeleteAccountImportsByAccountID(ctx context.Context, accountID string) error {
+	return a.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Select IDs of all
+		// imports of account.
+		var importIDs []string
+		if err := tx.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("account_imports"), bun.Ident("account_import")).
+			Column("account_import.id").
+			Where("? = ?", bun.Ident("account_import.account_id"), accountID).
+			Scan(ctx, &importIDs); err != nil {
+			return err
+		}
+
+		if len(importIDs) == 0 {
+			return nil
+		}
+
+		if _, err := tx.
+			NewDelete().
+			TableExpr("? AS ?", bun.Ident("account_import_rows"), bun.Ident("account_import_row")).
+			Where("? IN (?)", bun.Ident("account_import_row.import_id"), bun.In(importIDs)).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		_, err := tx.
+			NewDelete().
+			TableExpr("? AS ?", bun.Ident("account_imports"), bun.Ident("account_import")).
+			Where("? IN (?)", bun.Ident("account_import.id"), bun.In(importIDs)).
+			Exec(ctx)
+		return err
+	})
+}
@@ -57,6 +57,19 @@ func (a *adminDB) IsUsernameAvailable(ctx context.Context, username string) (boo
 		Column("account.id").
 		Where("? = ?", bun.Ident("account.username"), username).
 		Where("? IS NULL", bun.Ident("account.domain"))
+
+	available, err := notExists(ctx, q)
+	if err != nil || !available {
+		return available, err
+	}
+
+	// Usernames that accounts have changed away from
+	// still redirect to those accounts, so can't be reused.
+	q = a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("username_changes"), bun.Ident("username_change")).
+		Column("username_change.id").
+		Where("? = ?", bun.Ident("username_change.old_username"), username)
 	return notExists(ctx, q)
 }
 
@@ -121,8 +134,12 @@ func (a *adminDB) NewSignup(ctx context.Context, newSignup gtsmodel.NewSignup) (
 		}
 
 		settings := &gtsmodel.AccountSettings{
-			AccountID: accountID,
-			Privacy:   gtsmodel.VisibilityDefault,
+			AccountID:                  accountID,
+			Privacy:                    gtsmodel.VisibilityDefault,
+			NotifPolicyNotFollowing:    gtsmodel.NotificationPolicyAccept,
+			NotifPolicyNotFollowers:    gtsmodel.NotificationPolicyAccept,
+			NotifPolicyNewAccounts:     gtsmodel.NotificationPolicyAccept,
+			NotifPolicyPrivateMentions: gtsmodel.NotificationPolicyAccept,
 		}
 
 		// Insert the settings!
@@ -221,6 +238,18 @@ func (a *adminDB) NewSignup(ctx context.Context, newSignup gtsmodel.NewSignup) (
 		user.Approved = util.Ptr(true)
 	}
 
+	if newSignup.AgeConfirmed != 0 {
+		// Store that user confirmed their age.
+		user.AgeConfirmedAt = time.Now()
+		user.AgeConfirmedMinimum = newSignup.AgeConfirmed
+	}
+
+	if newSignup.TermsVersionID != "" {
+		// Store that user accepted the terms.
+		user.TermsAcceptedVersionID = newSignup.TermsVersionID
+		user.TermsAcceptedAt = time.Now()
+	}
+
 	// Insert the user!
 	if err := a.state.DB.PutUser(ctx, user); err != nil {
 		err := gtserror.Newf("db error inserting user: %w", err)
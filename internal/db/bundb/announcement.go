@@ -0,0 +1,323 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type announcementDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (a *announcementDB) GetAnnouncementByID(ctx context.Context, id string) (*gtsmodel.Announcement, error) {
+	var announcement gtsmodel.Announcement
+
+	if err := a.db.
+		NewSelect().
+		Model(&announcement).
+		Where("? = ?", bun.Ident("announcement.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return &announcement, nil
+	}
+
+	// Further populate the announcement fields where applicable.
+	if err := a.PopulateAnnouncement(ctx, &announcement); err != nil {
+		return nil, err
+	}
+
+	return &announcement, nil
+}
+
+func (a *announcementDB) GetAnnouncements(ctx context.Context) ([]*gtsmodel.Announcement, error) {
+	return a.getAnnouncements(ctx, false)
+}
+
+func (a *announcementDB) GetActiveAnnouncements(ctx context.Context, now time.Time) ([]*gtsmodel.Announcement, error) {
+	announcements, err := a.getAnnouncements(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start and end times are checked here rather
+	// than in the query, as there will only ever be
+	// a handful of published announcements at once.
+	active := announcements[:0]
+	for _, announcement := range announcements {
+		if announcement.Active(now) {
+			active = append(active, announcement)
+		}
+	}
+
+	return active, nil
+}
+
+func (a *announcementDB) GetScheduledAnnouncements(ctx context.Context, now time.Time) ([]*gtsmodel.Announcement, error) {
+	announcements, err := a.getAnnouncements(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduled := announcements[:0]
+	for _, announcement := range announcements {
+		if announcement.StartsAt.After(now) ||
+			announcement.EndsAt.After(now) {
+			scheduled = append(scheduled, announcement)
+		}
+	}
+
+	return scheduled, nil
+}
+
+func (a *announcementDB) getAnnouncements(ctx context.Context, publishedOnly bool) ([]*gtsmodel.Announcement, error) {
+	var announcementIDs []string
+
+	q := a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("announcements"), bun.Ident("announcement")).
+		Column("announcement.id").
+		Order("announcement.id DESC")
+
+	if publishedOnly {
+		q = q.Where("? = ?", bun.Ident("announcement.published"), true)
+	}
+
+	if err := q.Scan(ctx, &announcementIDs); err != nil {
+		return nil, err
+	}
+
+	announcements := make([]*gtsmodel.Announcement, 0, len(announcementIDs))
+	for _, id := range announcementIDs {
+		// Attempt to fetch announcement from DB.
+		announcement, err := a.GetAnnouncementByID(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error getting announcement %s: %v", id, err)
+			continue
+		}
+
+		// Append announcement to return slice.
+		announcements = append(announcements, announcement)
+	}
+
+	return announcements, nil
+}
+
+func (a *announcementDB) PopulateAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement) error {
+	var (
+		err  error
+		errs = gtserror.NewMultiError(3)
+	)
+
+	if len(announcement.MentionedAccounts) != len(announcement.MentionedAccountIDs) {
+		// Mentioned accounts are out-of-date with IDs, repopulate.
+		announcement.MentionedAccounts, err = a.state.DB.GetAccountsByIDs(
+			gtscontext.SetBarebones(ctx),
+			announcement.MentionedAccountIDs,
+		)
+		if err != nil {
+			errs.Appendf("error populating announcement mentioned accounts: %w", err)
+		}
+	}
+
+	if len(announcement.Tags) != len(announcement.TagIDs) {
+		// Tags are out-of-date with IDs, repopulate.
+		announcement.Tags, err = a.state.DB.GetTags(
+			ctx,
+			announcement.TagIDs,
+		)
+		if err != nil {
+			errs.Appendf("error populating announcement tags: %w", err)
+		}
+	}
+
+	if len(announcement.Emojis) != len(announcement.EmojiIDs) {
+		// Emojis are out-of-date with IDs, repopulate.
+		announcement.Emojis, err = a.state.DB.GetEmojisByIDs(
+			ctx,
+			announcement.EmojiIDs,
+		)
+		if err != nil {
+			errs.Appendf("error populating announcement emojis: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (a *announcementDB) PutAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement) error {
+	_, err := a.db.
+		NewInsert().
+		Model(announcement).
+		Exec(ctx)
+	return err
+}
+
+func (a *announcementDB) UpdateAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement, columns ...string) error {
+	announcement.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := a.db.
+		NewUpdate().
+		Model(announcement).
+		Column(columns...).
+		Where("? = ?", bun.Ident("announcement.id"), announcement.ID).
+		Exec(ctx)
+	return err
+}
+
+func (a *announcementDB) DeleteAnnouncementByID(ctx context.Context, id string) error {
+	return a.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Delete reads of the announcement.
+		if _, err := tx.
+			NewDelete().
+			TableExpr("? AS ?", bun.Ident("announcement_reads"), bun.Ident("announcement_read")).
+			Where("? = ?", bun.Ident("announcement_read.announcement_id"), id).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		// Delete reactions to the announcement.
+		if _, err := tx.
+			NewDelete().
+			TableExpr("? AS ?", bun.Ident("announcement_reactions"), bun.Ident("announcement_reaction")).
+			Where("? = ?", bun.Ident("announcement_reaction.announcement_id"), id).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		// Finally delete the announcement itself.
+		_, err := tx.
+			NewDelete().
+			TableExpr("? AS ?", bun.Ident("announcements"), bun.Ident("announcement")).
+			Where("? = ?", bun.Ident("announcement.id"), id).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (a *announcementDB) IsAnnouncementReadByAccount(ctx context.Context, announcementID string, accountID string) (bool, error) {
+	return a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("announcement_reads"), bun.Ident("announcement_read")).
+		Column("announcement_read.id").
+		Where("? = ?", bun.Ident("announcement_read.announcement_id"), announcementID).
+		Where("? = ?", bun.Ident("announcement_read.account_id"), accountID).
+		Exists(ctx)
+}
+
+func (a *announcementDB) PutAnnouncementRead(ctx context.Context, read *gtsmodel.AnnouncementRead) error {
+	_, err := a.db.
+		NewInsert().
+		Model(read).
+		On("CONFLICT (?, ?) DO NOTHING", bun.Ident("announcement_id"), bun.Ident("account_id")).
+		Exec(ctx)
+	return err
+}
+
+func (a *announcementDB) GetAnnouncementReactions(ctx context.Context, announcementID string) ([]*gtsmodel.AnnouncementReaction, error) {
+	reactions := make([]*gtsmodel.AnnouncementReaction, 0)
+
+	if err := a.db.
+		NewSelect().
+		Model(&reactions).
+		Where("? = ?", bun.Ident("announcement_reaction.announcement_id"), announcementID).
+		Order("announcement_reaction.id ASC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	for _, reaction := range reactions {
+		if err := a.populateAnnouncementReaction(ctx, reaction); err != nil {
+			log.Errorf(ctx, "error populating announcement reaction %s: %v", reaction.ID, err)
+		}
+	}
+
+	return reactions, nil
+}
+
+func (a *announcementDB) GetAnnouncementReaction(ctx context.Context, announcementID string, accountID string, name string) (*gtsmodel.AnnouncementReaction, error) {
+	var reaction gtsmodel.AnnouncementReaction
+
+	if err := a.db.
+		NewSelect().
+		Model(&reaction).
+		Where("? = ?", bun.Ident("announcement_reaction.announcement_id"), announcementID).
+		Where("? = ?", bun.Ident("announcement_reaction.account_id"), accountID).
+		Where("? = ?", bun.Ident("announcement_reaction.name"), name).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := a.populateAnnouncementReaction(ctx, &reaction); err != nil {
+		return nil, err
+	}
+
+	return &reaction, nil
+}
+
+func (a *announcementDB) populateAnnouncementReaction(ctx context.Context, reaction *gtsmodel.AnnouncementReaction) error {
+	if reaction.EmojiID == "" || reaction.Emoji != nil {
+		// Nothing to do.
+		return nil
+	}
+
+	emoji, err := a.state.DB.GetEmojiByID(ctx, reaction.EmojiID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error populating announcement reaction emoji: %w", err)
+	}
+
+	reaction.Emoji = emoji
+	return nil
+}
+
+func (a *announcementDB) PutAnnouncementReaction(ctx context.Context, reaction *gtsmodel.AnnouncementReaction) error {
+	_, err := a.db.
+		NewInsert().
+		Model(reaction).
+		Exec(ctx)
+	return err
+}
+
+func (a *announcementDB) DeleteAnnouncementReactionByID(ctx context.Context, id string) error {
+	_, err := a.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("announcement_reactions"), bun.Ident("announcement_reaction")).
+		Where("? = ?", bun.Ident("announcement_reaction.id"), id).
+		Exec(ctx)
+	return err
+}
@@ -55,13 +55,21 @@ import (
 // DBService satisfies the DB interface
 type DBService struct {
 	db.Account
+	db.AccountExport
+	db.AccountImport
 	db.Admin
+	db.Announcement
 	db.Application
 	db.Basic
+	db.Card
+	db.ContentReveal
+	db.Conversation
 	db.Domain
 	db.Emoji
+	db.FollowerEvent
 	db.HeaderFilter
 	db.Instance
+	db.InteractionRequest
 	db.Filter
 	db.List
 	db.Marker
@@ -69,20 +77,28 @@ type DBService struct {
 	db.Mention
 	db.Move
 	db.Notification
+	db.NotificationRequest
 	db.Poll
 	db.Relationship
 	db.Report
 	db.Rule
+	db.ScheduledStatus
 	db.Search
 	db.Session
 	db.Status
 	db.StatusBookmark
+	db.StatusEdit
 	db.StatusFave
+	db.StatusReaction
 	db.Tag
+	db.TermsVersion
 	db.Thread
 	db.Timeline
+	db.TimelineEntry
 	db.User
+	db.UsernameChange
 	db.Tombstone
+	db.WebPush
 	db *bun.DB
 }
 
@@ -182,6 +198,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		Announcement: &announcementDB{
+			db:    db,
+			state: state,
+		},
 		Application: &applicationDB{
 			db:    db,
 			state: state,
@@ -189,6 +209,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 		Basic: &basicDB{
 			db: db,
 		},
+		Card: &cardDB{
+			db:    db,
+			state: state,
+		},
 		Domain: &domainDB{
 			db:    db,
 			state: state,
@@ -229,10 +253,38 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		Conversation: &conversationDB{
+			db:    db,
+			state: state,
+		},
+		AccountExport: &accountExportDB{
+			db:    db,
+			state: state,
+		},
+		AccountImport: &accountImportDB{
+			db:    db,
+			state: state,
+		},
+		ContentReveal: &contentRevealDB{
+			db:    db,
+			state: state,
+		},
+		FollowerEvent: &followerEventDB{
+			db:    db,
+			state: state,
+		},
+		InteractionRequest: &interactionRequestDB{
+			db:    db,
+			state: state,
+		},
 		Notification: &notificationDB{
 			db:    db,
 			state: state,
 		},
+		NotificationRequest: &notificationRequestDB{
+			db:    db,
+			state: state,
+		},
 		Poll: &pollDB{
 			db:    db,
 			state: state,
@@ -249,6 +301,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		ScheduledStatus: &scheduledStatusDB{
+			db:    db,
+			state: state,
+		},
 		Search: &searchDB{
 			db:    db,
 			state: state,
@@ -264,14 +320,26 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		StatusEdit: &statusEditDB{
+			db:    db,
+			state: state,
+		},
 		StatusFave: &statusFaveDB{
 			db:    db,
 			state: state,
 		},
+		StatusReaction: &statusReactionDB{
+			db:    db,
+			state: state,
+		},
 		Tag: &tagDB{
 			db:    db,
 			state: state,
 		},
+		TermsVersion: &termsVersionDB{
+			db:    db,
+			state: state,
+		},
 		Thread: &threadDB{
 			db:    db,
 			state: state,
@@ -280,14 +348,26 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		TimelineEntry: &timelineEntryDB{
+			db:    db,
+			state: state,
+		},
 		User: &userDB{
 			db:    db,
 			state: state,
 		},
+		UsernameChange: &usernameChangeDB{
+			db:    db,
+			state: state,
+		},
 		Tombstone: &tombstoneDB{
 			db:    db,
 			state: state,
 		},
+		WebPush: &webPushDB{
+			db:    db,
+			state: state,
+		},
 		db: db,
 	}
 
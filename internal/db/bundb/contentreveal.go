@@ -0,0 +1,131 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type contentRevealDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (c *contentRevealDB) GetContentRevealByID(ctx context.Context, id string) (*gtsmodel.ContentReveal, error) {
+	return c.getContentReveal(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("? = ?", bun.Ident("content_reveal.id"), id)
+	})
+}
+
+func (c *contentRevealDB) GetContentReveals(ctx context.Context, accountID string) ([]*gtsmodel.ContentReveal, error) {
+	var reveals []*gtsmodel.ContentReveal
+
+	if err := c.db.
+		NewSelect().
+		Model(&reveals).
+		Where("? = ?", bun.Ident("content_reveal.account_id"), accountID).
+		OrderExpr("? DESC", bun.Ident("content_reveal.id")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return reveals, nil
+}
+
+func (c *contentRevealDB) GetContentRevealForAccount(ctx context.Context, accountID string, targetAccountID string) (*gtsmodel.ContentReveal, error) {
+	return c.getContentReveal(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.
+			Where("? = ?", bun.Ident("content_reveal.account_id"), accountID).
+			Where("? = ?", bun.Ident("content_reveal.target_account_id"), targetAccountID)
+	})
+}
+
+func (c *contentRevealDB) GetContentRevealForThread(ctx context.Context, accountID string, threadID string) (*gtsmodel.ContentReveal, error) {
+	return c.getContentReveal(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.
+			Where("? = ?", bun.Ident("content_reveal.account_id"), accountID).
+			Where("? = ?", bun.Ident("content_reveal.thread_id"), threadID)
+	})
+}
+
+func (c *contentRevealDB) getContentReveal(ctx context.Context, where func(*bun.SelectQuery) *bun.SelectQuery) (*gtsmodel.ContentReveal, error) {
+	var reveal gtsmodel.ContentReveal
+
+	q := c.db.
+		NewSelect().
+		Model(&reveal)
+
+	if err := where(q).Limit(1).Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &reveal, nil
+}
+
+func (c *contentRevealDB) IsContentRevealed(ctx context.Context, accountID string, targetAccountID string, threadID string) (bool, error) {
+	if targetAccountID == "" && threadID == "" {
+		return false, nil
+	}
+
+	return c.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("content_reveals"), bun.Ident("content_reveal")).
+		Column("content_reveal.id").
+		Where("? = ?", bun.Ident("content_reveal.account_id"), accountID).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			if targetAccountID != "" {
+				q = q.WhereOr("? = ?", bun.Ident("content_reveal.target_account_id"), targetAccountID)
+			}
+			if threadID != "" {
+				q = q.WhereOr("? = ?", bun.Ident("content_reveal.thread_id"), threadID)
+			}
+			return q
+		}).
+		Exists(ctx)
+}
+
+func (c *contentRevealDB) PutContentReveal(ctx context.Context, reveal *gtsmodel.ContentReveal) error {
+	_, err := c.db.
+		NewInsert().
+		Model(reveal).
+		Exec(ctx)
+	return err
+}
+
+func (c *contentRevealDB) DeleteContentRevealByID(ctx context.Context, id string) error {
+	_, err := c.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("content_reveals"), bun.Ident("content_reveal")).
+		Where("? = ?", bun.Ident("content_reveal.id"), id).
+		Exec(ctx)
+	return err
+}
+
+func (c *contentRevealDB) DeleteContentRevealsByAccountID(ctx context.Context, accountID string) error {
+	_, err := c.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("content_reveals"), bun.Ident("content_reveal")).
+		WhereOr("? = ?", bun.Ident("content_reveal.account_id"), accountID).
+		WhereOr("? = ?", bun.Ident("content_reveal.target_account_id"), accountID).
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,119 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+type ContentRevealTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *ContentRevealTestSuite) TestIsContentRevealed() {
+	var (
+		ctx       = context.Background()
+		account   = suite.testAccounts["local_account_1"]
+		author    = suite.testAccounts["remote_account_1"]
+		otherAuth = suite.testAccounts["local_account_2"]
+		threadID  = id.NewULID()
+	)
+
+	// Nothing revealed yet.
+	revealed, err := suite.db.IsContentRevealed(ctx, account.ID, author.ID, threadID)
+	suite.NoError(err)
+	suite.False(revealed)
+
+	// Reveal the author.
+	if err := suite.db.PutContentReveal(ctx, &gtsmodel.ContentReveal{
+		ID:              id.NewULID(),
+		AccountID:       account.ID,
+		TargetAccountID: author.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	revealed, err = suite.db.IsContentRevealed(ctx, account.ID, author.ID, "")
+	suite.NoError(err)
+	suite.True(revealed)
+
+	// Other authors + threads are still hidden.
+	revealed, err = suite.db.IsContentRevealed(ctx, account.ID, otherAuth.ID, threadID)
+	suite.NoError(err)
+	suite.False(revealed)
+
+	// Reveal the thread.
+	if err := suite.db.PutContentReveal(ctx, &gtsmodel.ContentReveal{
+		ID:        id.NewULID(),
+		AccountID: account.ID,
+		ThreadID:  threadID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	revealed, err = suite.db.IsContentRevealed(ctx, account.ID, otherAuth.ID, threadID)
+	suite.NoError(err)
+	suite.True(revealed)
+
+	// Reveals only apply to the account that made them.
+	revealed, err = suite.db.IsContentRevealed(ctx, otherAuth.ID, author.ID, threadID)
+	suite.NoError(err)
+	suite.False(revealed)
+
+	reveals, err := suite.db.GetContentReveals(ctx, account.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(reveals, 2)
+
+	// Deleting the revealed author's
+	// account removes reveals targeting it.
+	if err := suite.db.DeleteContentRevealsByAccountID(ctx, author.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.db.GetContentRevealForAccount(ctx, account.ID, author.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	reveal, err := suite.db.GetContentRevealForThread(ctx, account.ID, threadID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Delete the thread reveal too.
+	if err := suite.db.DeleteContentRevealByID(ctx, reveal.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	reveals, err = suite.db.GetContentReveals(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(reveals)
+}
+
+func TestContentRevealTestSuite(t *testing.T) {
+	suite.Run(t, new(ContentRevealTestSuite))
+}
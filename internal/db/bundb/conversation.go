@@ -0,0 +1,282 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type conversationDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (c *conversationDB) GetConversationByID(ctx context.Context, id string) (*gtsmodel.Conversation, error) {
+	return c.getConversation(ctx, func(conversation *gtsmodel.Conversation) error {
+		return c.db.
+			NewSelect().
+			Model(conversation).
+			Where("? = ?", bun.Ident("conversation.id"), id).
+			Scan(ctx)
+	})
+}
+
+func (c *conversationDB) GetConversationByThreadID(ctx context.Context, accountID string, threadID string) (*gtsmodel.Conversation, error) {
+	return c.getConversation(ctx, func(conversation *gtsmodel.Conversation) error {
+		return c.db.
+			NewSelect().
+			Model(conversation).
+			Where("? = ?", bun.Ident("conversation.account_id"), accountID).
+			Where("? = ?", bun.Ident("conversation.thread_id"), threadID).
+			Scan(ctx)
+	})
+}
+
+func (c *conversationDB) getConversation(ctx context.Context, dbQuery func(*gtsmodel.Conversation) error) (*gtsmodel.Conversation, error) {
+	var conversation gtsmodel.Conversation
+
+	// Not cached! Perform database query.
+	if err := dbQuery(&conversation); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// Only a barebones model was requested.
+		return &conversation, nil
+	}
+
+	// Further populate the conversation fields where applicable.
+	if err := c.PopulateConversation(ctx, &conversation); err != nil {
+		return nil, err
+	}
+
+	return &conversation, nil
+}
+
+func (c *conversationDB) GetConversationsByAccountID(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.Conversation, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		conversationIDs = make([]string, 0, limit)
+	)
+
+	q := c.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("conversations"), bun.Ident("conversation")).
+		// Select just the IDs of each conversation.
+		Column("conversation.id").
+		Where("? = ?", bun.Ident("conversation.account_id"), accountID)
+
+	if maxID != "" {
+		// Return only conversations with a last status *OLDER* than given max ID.
+		q = q.Where("? < ?", bun.Ident("conversation.last_status_id"), maxID)
+	}
+
+	if minID != "" {
+		// Return only conversations with a last status *NEWER* than given min ID.
+		q = q.Where("? > ?", bun.Ident("conversation.last_status_id"), minID)
+	}
+
+	if limit > 0 {
+		// Limit amount of conversations returned.
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("conversation.last_status_id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("conversation.last_status_id"))
+	}
+
+	if err := q.Scan(ctx, &conversationIDs); err != nil {
+		return nil, err
+	}
+
+	if len(conversationIDs) == 0 {
+		return nil, nil
+	}
+
+	// If we're paging up, we still want conversations
+	// to be sorted by last status desc, so reverse.
+	if order == paging.OrderAscending {
+		slices.Reverse(conversationIDs)
+	}
+
+	conversations := make([]*gtsmodel.Conversation, 0, len(conversationIDs))
+	for _, id := range conversationIDs {
+		// Attempt to fetch conversation from DB.
+		conversation, err := c.GetConversationByID(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error getting conversation %s: %v", id, err)
+			continue
+		}
+
+		// Append conversation to return slice.
+		conversations = append(conversations, conversation)
+	}
+
+	return conversations, nil
+}
+
+func (c *conversationDB) GetConversationsByLastStatusID(ctx context.Context, statusID string) ([]*gtsmodel.Conversation, error) {
+	var conversations []*gtsmodel.Conversation
+
+	if err := c.db.
+		NewSelect().
+		Model(&conversations).
+		Where("? = ?", bun.Ident("conversation.last_status_id"), statusID).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return conversations, nil
+	}
+
+	// Populate all loaded conversations, removing those we fail
+	// to populate (removes needing so many nil checks everywhere).
+	conversations = slices.DeleteFunc(conversations, func(conversation *gtsmodel.Conversation) bool {
+		if err := c.PopulateConversation(ctx, conversation); err != nil {
+			log.Errorf(ctx, "error populating conversation %s: %v", conversation.ID, err)
+			return true
+		}
+		return false
+	})
+
+	return conversations, nil
+}
+
+func (c *conversationDB) GetThreadDirectStatusIDs(ctx context.Context, threadID string) ([]string, error) {
+	var statusIDs []string
+
+	if err := c.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		Column("status.id").
+		Where("? = ?", bun.Ident("status.thread_id"), threadID).
+		Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityDirect).
+		OrderExpr("? DESC", bun.Ident("status.id")).
+		Scan(ctx, &statusIDs); err != nil {
+		return nil, err
+	}
+
+	return statusIDs, nil
+}
+
+func (c *conversationDB) PopulateConversation(ctx context.Context, conversation *gtsmodel.Conversation) error {
+	var (
+		errs = gtserror.NewMultiError(3)
+		err  error
+	)
+
+	if conversation.Account == nil {
+		// Conversation owner account is not set, fetch from database.
+		conversation.Account, err = c.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			conversation.AccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating conversation account: %w", err)
+		}
+	}
+
+	if conversation.OtherAccounts == nil {
+		// Conversation other accounts are not set, fetch from database.
+		// Accounts which have since been deleted are simply skipped.
+		conversation.OtherAccounts, err = c.state.DB.GetAccountsByIDs(
+			gtscontext.SetBarebones(ctx),
+			conversation.OtherAccountIDs,
+		)
+		if err != nil {
+			errs.Appendf("error populating conversation other accounts: %w", err)
+		}
+	}
+
+	if conversation.LastStatus == nil {
+		// Conversation last status is not set, fetch from database.
+		conversation.LastStatus, err = c.state.DB.GetStatusByID(
+			gtscontext.SetBarebones(ctx),
+			conversation.LastStatusID,
+		)
+		if err != nil {
+			errs.Appendf("error populating conversation last status: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (c *conversationDB) PutConversation(ctx context.Context, conversation *gtsmodel.Conversation) error {
+	_, err := c.db.
+		NewInsert().
+		Model(conversation).
+		Exec(ctx)
+	return err
+}
+
+func (c *conversationDB) UpdateConversation(ctx context.Context, conversation *gtsmodel.Conversation, columns ...string) error {
+	conversation.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column, ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := c.db.
+		NewUpdate().
+		Model(conversation).
+		Column(columns...).
+		Where("? = ?", bun.Ident("conversation.id"), conversation.ID).
+		Exec(ctx)
+	return err
+}
+
+func (c *conversationDB) DeleteConversationByID(ctx context.Context, id string) error {
+	_, err := c.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("conversations"), bun.Ident("conversation")).
+		Where("? = ?", bun.Ident("conversation.id"), id).
+		Exec(ctx)
+	return err
+}
+
+func (c *conversationDB) DeleteConversationsByAccountID(ctx context.Context, accountID string) error {
+	_, err := c.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("conversations"), bun.Ident("conversation")).
+		Where("? = ?", bun.Ident("conversation.account_id"), accountID).
+		Exec(ctx)
+	return err
+}
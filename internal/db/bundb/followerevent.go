@@ -0,0 +1,199 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type followerEventDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (f *followerEventDB) GetFollowerEventByID(ctx context.Context, id string) (*gtsmodel.FollowerEvent, error) {
+	var event gtsmodel.FollowerEvent
+
+	if err := f.db.
+		NewSelect().
+		Model(&event).
+		Where("? = ?", bun.Ident("follower_event.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return &event, nil
+	}
+
+	// Further populate the follower event fields where applicable.
+	if err := f.PopulateFollowerEvent(ctx, &event); err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+func (f *followerEventDB) GetAccountFollowerEvents(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.FollowerEvent, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		eventIDs = make([]string, 0, limit)
+	)
+
+	q := f.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("follower_events"), bun.Ident("follower_event")).
+		// Select just the IDs of each follower event.
+		Column("follower_event.id").
+		Where("? = ?", bun.Ident("follower_event.account_id"), accountID)
+
+	if maxID != "" {
+		// Return only follower events *OLDER* than given max ID.
+		q = q.Where("? < ?", bun.Ident("follower_event.id"), maxID)
+	}
+
+	if minID != "" {
+		// Return only follower events *NEWER* than given min ID.
+		q = q.Where("? > ?", bun.Ident("follower_event.id"), minID)
+	}
+
+	if limit > 0 {
+		// Limit amount of follower events returned.
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("follower_event.id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("follower_event.id"))
+	}
+
+	if err := q.Scan(ctx, &eventIDs); err != nil {
+		return nil, err
+	}
+
+	if len(eventIDs) == 0 {
+		return nil, nil
+	}
+
+	// If we're paging up, we still want follower
+	// events to be sorted by ID desc, so reverse.
+	if order == paging.OrderAscending {
+		slices.Reverse(eventIDs)
+	}
+
+	events := make([]*gtsmodel.FollowerEvent, 0, len(eventIDs))
+	for _, id := range eventIDs {
+		// Attempt to fetch follower event from DB.
+		event, err := f.GetFollowerEventByID(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error getting follower event %s: %v", id, err)
+			continue
+		}
+
+		// Append follower event to return slice.
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func (f *followerEventDB) GetAccountFollowerEventTimes(
+	ctx context.Context,
+	accountID string,
+	eventType gtsmodel.FollowerEventType,
+	since time.Time,
+) ([]time.Time, error) {
+	var times []time.Time
+
+	if err := f.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("follower_events"), bun.Ident("follower_event")).
+		Column("follower_event.created_at").
+		Where("? = ?", bun.Ident("follower_event.account_id"), accountID).
+		Where("? = ?", bun.Ident("follower_event.type"), eventType).
+		Where("? >= ?", bun.Ident("follower_event.created_at"), since).
+		Scan(ctx, &times); err != nil {
+		return nil, err
+	}
+
+	return times, nil
+}
+
+func (f *followerEventDB) PopulateFollowerEvent(ctx context.Context, event *gtsmodel.FollowerEvent) error {
+	var err error
+
+	if event.FollowerAccount == nil {
+		// Follower event follower account is not set, fetch from database.
+		event.FollowerAccount, err = f.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			event.FollowerAccountID,
+		)
+		if err != nil {
+			return gtserror.Newf("error populating follower event follower account: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (f *followerEventDB) PutFollowerEvent(ctx context.Context, event *gtsmodel.FollowerEvent) error {
+	_, err := f.db.
+		NewInsert().
+		Model(event).
+		Exec(ctx)
+	return err
+}
+
+func (f *followerEventDB) DeleteAccountFollowerEvents(ctx context.Context, accountID string) error {
+	_, err := f.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("follower_events"), bun.Ident("follower_event")).
+		Where("? = ?", bun.Ident("follower_event.account_id"), accountID).
+		Exec(ctx)
+	return err
+}
+
+func (f *followerEventDB) DeleteFollowerEventsByAccountID(ctx context.Context, accountID string) error {
+	_, err := f.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("follower_events"), bun.Ident("follower_event")).
+		WhereOr("? = ?", bun.Ident("follower_event.account_id"), accountID).
+		WhereOr("? = ?", bun.Ident("follower_event.follower_account_id"), accountID).
+		Exec(ctx)
+	return err
+}
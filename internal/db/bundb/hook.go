@@ -50,7 +50,7 @@ func (queryHook) AfterQuery(ctx context.Context, event *bun.QueryEvent) {
 
 	// On trace, we log query information,
 	// manually crafting so DB query not escaped.
-	case log.Level() >= level.TRACE:
+	case log.CallerLevel() >= level.TRACE:
 		log.Printf("level=TRACE duration=%s query=%s", dur, event.Query)
 	}
 }
@@ -19,6 +19,7 @@ package bundb
 
 import (
 	"context"
+	"slices"
 	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/config"
@@ -28,11 +29,18 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/uptrace/bun"
 )
 
+// peerSeenWindow is the duration after which an instance
+// we haven't received any traffic from is no longer listed
+// as a peer. Instances never seen (e.g. only known through
+// dereferenced accounts) are still listed, as before.
+const peerSeenWindow = 90 * 24 * time.Hour
+
 type instanceDB struct {
 	db    *bun.DB
 	state *state.State
@@ -250,6 +258,79 @@ func (i *instanceDB) UpdateInstance(ctx context.Context, instance *gtsmodel.Inst
 	})
 }
 
+func (i *instanceDB) GetInstances(ctx context.Context, page *paging.Page) ([]*gtsmodel.Instance, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		instanceIDs = make([]string, 0, limit)
+	)
+
+	q := i.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("instances"), bun.Ident("instance")).
+		// Select just the IDs of each instance.
+		Column("instance.id").
+		// Exclude our own instance.
+		Where("? != ?", bun.Ident("instance.domain"), config.GetHost())
+
+	if maxID != "" {
+		// Return only instances *OLDER* than given max ID.
+		q = q.Where("? < ?", bun.Ident("instance.id"), maxID)
+	}
+
+	if minID != "" {
+		// Return only instances *NEWER* than given min ID.
+		q = q.Where("? > ?", bun.Ident("instance.id"), minID)
+	}
+
+	if limit > 0 {
+		// Limit amount of instances returned.
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("instance.id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("instance.id"))
+	}
+
+	if err := q.Scan(ctx, &instanceIDs); err != nil {
+		return nil, err
+	}
+
+	if len(instanceIDs) == 0 {
+		return nil, nil
+	}
+
+	// If we're paging up, we still want instances
+	// to be sorted by ID desc, so reverse ids slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(instanceIDs)
+	}
+
+	instances := make([]*gtsmodel.Instance, 0, len(instanceIDs))
+	for _, id := range instanceIDs {
+		// Select each instance by its ID.
+		instance, err := i.GetInstanceByID(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error getting instance %q: %v", id, err)
+			continue
+		}
+
+		// Append to return slice.
+		instances = append(instances, instance)
+	}
+
+	return instances, nil
+}
+
 func (i *instanceDB) GetInstancePeers(ctx context.Context, includeSuspended bool) ([]*gtsmodel.Instance, error) {
 	instanceIDs := []string{}
 
@@ -265,6 +346,14 @@ func (i *instanceDB) GetInstancePeers(ctx context.Context, includeSuspended bool
 		q = q.Where("? IS NULL", bun.Ident("instance.suspended_at"))
 	}
 
+	// Exclude instances we've
+	// not heard from in a while.
+	q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.
+			Where("? IS NULL", bun.Ident("instance.last_seen_at")).
+			WhereOr("? > ?", bun.Ident("instance.last_seen_at"), time.Now().Add(-peerSeenWindow))
+	})
+
 	if err := q.Scan(ctx, &instanceIDs); err != nil {
 		return nil, err
 	}
@@ -20,11 +20,13 @@ package bundb_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
@@ -86,6 +88,61 @@ func (suite *InstanceTestSuite) TestGetInstancePeersIncludeSuspended() {
 	suite.Len(peers, 2)
 }
 
+func (suite *InstanceTestSuite) TestGetInstancePeersExcludeStale() {
+	ctx := context.Background()
+
+	peers, err := suite.db.GetInstancePeers(ctx, false)
+	suite.NoError(err)
+	suite.NotEmpty(peers)
+
+	// Mark one peer as last seen a long time ago.
+	stale := peers[0]
+	stale.LastSeenAt = time.Now().Add(-365 * 24 * time.Hour)
+	err = suite.db.UpdateInstance(ctx, stale, "last_seen_at")
+	suite.NoError(err)
+
+	// It should no longer be listed.
+	latest, err := suite.db.GetInstancePeers(ctx, false)
+	suite.NoError(err)
+	suite.Len(latest, len(peers)-1)
+	for _, peer := range latest {
+		suite.NotEqual(stale.ID, peer.ID)
+	}
+}
+
+func (suite *InstanceTestSuite) TestGetInstances() {
+	instances, err := suite.db.GetInstances(context.Background(), &paging.Page{
+		Limit: 10,
+	})
+	suite.NoError(err)
+	suite.Len(instances, 2)
+
+	// Our own instance should be excluded.
+	for _, instance := range instances {
+		suite.NotEqual(config.GetHost(), instance.Domain)
+	}
+
+	// Instances should be sorted newest first.
+	suite.Greater(instances[0].ID, instances[1].ID)
+}
+
+func (suite *InstanceTestSuite) TestGetInstancesPaged() {
+	instances, err := suite.db.GetInstances(context.Background(), &paging.Page{
+		Limit: 1,
+	})
+	suite.NoError(err)
+	suite.Len(instances, 1)
+
+	// Get the next page down.
+	next, err := suite.db.GetInstances(context.Background(), &paging.Page{
+		Max:   paging.MaxID(instances[0].ID),
+		Limit: 1,
+	})
+	suite.NoError(err)
+	suite.Len(next, 1)
+	suite.Less(next[0].ID, instances[0].ID)
+}
+
 func (suite *InstanceTestSuite) TestGetInstanceAccounts() {
 	accounts, err := suite.db.GetInstanceAccounts(context.Background(), "fossbros-anonymous.io", "", 10)
 	suite.NoError(err)
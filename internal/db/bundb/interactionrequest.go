@@ -0,0 +1,256 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type interactionRequestDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (i *interactionRequestDB) GetInteractionRequestByID(ctx context.Context, id string) (*gtsmodel.InteractionRequest, error) {
+	return i.getInteractionRequest(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("? = ?", bun.Ident("interaction_request.id"), id)
+	})
+}
+
+func (i *interactionRequestDB) GetInteractionRequestByInteractionID(ctx context.Context, interactionID string) (*gtsmodel.InteractionRequest, error) {
+	return i.getInteractionRequest(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("? = ?", bun.Ident("interaction_request.interaction_id"), interactionID)
+	})
+}
+
+func (i *interactionRequestDB) getInteractionRequest(ctx context.Context, where func(*bun.SelectQuery) *bun.SelectQuery) (*gtsmodel.InteractionRequest, error) {
+	var req gtsmodel.InteractionRequest
+
+	if err := where(i.db.
+		NewSelect().
+		Model(&req)).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return &req, nil
+	}
+
+	// Further populate the interaction request fields where applicable.
+	if err := i.PopulateInteractionRequest(ctx, &req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (i *interactionRequestDB) GetAccountInteractionRequests(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.InteractionRequest, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		reqIDs = make([]string, 0, limit)
+	)
+
+	q := i.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("interaction_requests"), bun.Ident("interaction_request")).
+		// Select just the IDs of each interaction request.
+		Column("interaction_request.id").
+		Where("? = ?", bun.Ident("interaction_request.target_account_id"), accountID).
+		Where("? IS NULL", bun.Ident("interaction_request.accepted_at")).
+		Where("? IS NULL", bun.Ident("interaction_request.rejected_at"))
+
+	if maxID != "" {
+		// Return only interaction requests *OLDER* than given max ID.
+		q = q.Where("? < ?", bun.Ident("interaction_request.id"), maxID)
+	}
+
+	if minID != "" {
+		// Return only interaction requests *NEWER* than given min ID.
+		q = q.Where("? > ?", bun.Ident("interaction_request.id"), minID)
+	}
+
+	if limit > 0 {
+		// Limit amount of interaction requests returned.
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("interaction_request.id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("interaction_request.id"))
+	}
+
+	if err := q.Scan(ctx, &reqIDs); err != nil {
+		return nil, err
+	}
+
+	if len(reqIDs) == 0 {
+		return nil, nil
+	}
+
+	// If we're paging up, we still want interaction
+	// requests to be sorted by ID desc, so reverse.
+	if order == paging.OrderAscending {
+		slices.Reverse(reqIDs)
+	}
+
+	reqs := make([]*gtsmodel.InteractionRequest, 0, len(reqIDs))
+	for _, id := range reqIDs {
+		// Attempt to fetch interaction request from DB.
+		req, err := i.GetInteractionRequestByID(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error getting interaction request %s: %v", id, err)
+			continue
+		}
+
+		// Append interaction request to return slice.
+		reqs = append(reqs, req)
+	}
+
+	return reqs, nil
+}
+
+func (i *interactionRequestDB) PopulateInteractionRequest(ctx context.Context, req *gtsmodel.InteractionRequest) error {
+	var (
+		err  error
+		errs = gtserror.NewMultiError(4)
+	)
+
+	if req.Status == nil {
+		// Interaction request status is not set, fetch from database.
+		req.Status, err = i.state.DB.GetStatusByID(
+			gtscontext.SetBarebones(ctx),
+			req.StatusID,
+		)
+		if err != nil {
+			errs.Appendf("error populating interaction request status: %w", err)
+		}
+	}
+
+	if req.TargetAccount == nil {
+		// Interaction request target account is not set, fetch from database.
+		req.TargetAccount, err = i.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			req.TargetAccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating interaction request target account: %w", err)
+		}
+	}
+
+	if req.InteractingAccount == nil {
+		// Interaction request interacting account is not set, fetch from database.
+		req.InteractingAccount, err = i.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			req.InteractingAccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating interaction request interacting account: %w", err)
+		}
+	}
+
+	if req.Interaction == nil {
+		// Interaction request interaction is not set, fetch from database.
+		req.Interaction, err = i.state.DB.GetStatusByID(
+			gtscontext.SetBarebones(ctx),
+			req.InteractionID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			// A since deleted (eg., rejected) interaction is fine.
+			errs.Appendf("error populating interaction request interaction: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (i *interactionRequestDB) PutInteractionRequest(ctx context.Context, req *gtsmodel.InteractionRequest) error {
+	_, err := i.db.
+		NewInsert().
+		Model(req).
+		Exec(ctx)
+	return err
+}
+
+func (i *interactionRequestDB) UpdateInteractionRequest(ctx context.Context, req *gtsmodel.InteractionRequest, columns ...string) error {
+	req.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := i.db.
+		NewUpdate().
+		Model(req).
+		Column(columns...).
+		Where("? = ?", bun.Ident("interaction_request.id"), req.ID).
+		Exec(ctx)
+	return err
+}
+
+func (i *interactionRequestDB) DeleteInteractionRequestByID(ctx context.Context, id string) error {
+	_, err := i.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("interaction_requests"), bun.Ident("interaction_request")).
+		Where("? = ?", bun.Ident("interaction_request.id"), id).
+		Exec(ctx)
+	return err
+}
+
+func (i *interactionRequestDB) DeleteInteractionRequestsByAccountID(ctx context.Context, accountID string) error {
+	_, err := i.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("interaction_requests"), bun.Ident("interaction_request")).
+		WhereOr("? = ?", bun.Ident("interaction_request.target_account_id"), accountID).
+		WhereOr("? = ?", bun.Ident("interaction_request.interacting_account_id"), accountID).
+		Exec(ctx)
+	return err
+}
+
+func (i *interactionRequestDB) DeleteInteractionRequestsByStatusID(ctx context.Context, statusID string) error {
+	_, err := i.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("interaction_requests"), bun.Ident("interaction_request")).
+		WhereOr("? = ?", bun.Ident("interaction_request.status_id"), statusID).
+		WhereOr("? = ?", bun.Ident("interaction_request.interaction_id"), statusID).
+		Exec(ctx)
+	return err
+}
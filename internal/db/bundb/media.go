@@ -295,3 +295,99 @@ func (m *mediaDB) GetCachedAttachmentsOlderThan(ctx context.Context, olderThan t
 
 	return m.GetAttachmentsByIDs(ctx, attachmentIDs)
 }
+
+func (m *mediaDB) GetCachedAttachmentsExpiredBefore(ctx context.Context, expiredBefore time.Time, limit int) ([]*gtsmodel.MediaAttachment, error) {
+	attachmentIDs := make([]string, 0, limit)
+
+	q := m.db.
+		NewSelect().
+		Table("media_attachments").
+		Column("id").
+		Where("cached = true").
+		Where("expires_at IS NOT NULL").
+		Where("expires_at < ?", expiredBefore).
+		Order("expires_at ASC")
+
+	if limit != 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &attachmentIDs); err != nil {
+		return nil, err
+	}
+
+	return m.GetAttachmentsByIDs(ctx, attachmentIDs)
+}
+
+func (m *mediaDB) GetAttachmentsByFileHash(ctx context.Context, hash string) ([]*gtsmodel.MediaAttachment, error) {
+	var attachmentIDs []string
+
+	if err := m.db.
+		NewSelect().
+		Table("media_attachments").
+		Column("id").
+		Where("? = ?", bun.Ident("file_hash"), hash).
+		Where("cached = true").
+		Order("id ASC").
+		Scan(ctx, &attachmentIDs); err != nil {
+		return nil, err
+	}
+
+	return m.GetAttachmentsByIDs(ctx, attachmentIDs)
+}
+
+func (m *mediaDB) GetAttachmentIDsByFilePath(ctx context.Context, filePath string) ([]string, error) {
+	var attachmentIDs []string
+
+	if err := m.db.
+		NewSelect().
+		Table("media_attachments").
+		Column("id").
+		Where("? = ?", bun.Ident("file_path"), filePath).
+		Where("cached = true").
+		Order("id ASC").
+		Scan(ctx, &attachmentIDs); err != nil {
+		return nil, err
+	}
+
+	return attachmentIDs, nil
+}
+
+func (m *mediaDB) GetAttachmentIDByThumbnailPath(ctx context.Context, thumbnailPath string) (string, error) {
+	var attachmentID string
+
+	if err := m.db.
+		NewSelect().
+		Table("media_attachments").
+		Column("id").
+		Where("? = ?", bun.Ident("thumbnail_path"), thumbnailPath).
+		Where("cached = true").
+		Limit(1).
+		Scan(ctx, &attachmentID); err != nil {
+		return "", err
+	}
+
+	return attachmentID, nil
+}
+
+func (m *mediaDB) GetOrphanedAttachmentIDs(ctx context.Context) ([]string, error) {
+	var attachmentIDs []string
+
+	if err := m.db.NewSelect().
+		TableExpr("? AS ?", bun.Ident("media_attachments"), bun.Ident("media_attachment")).
+		Column("media_attachment.id").
+		Join(
+			"LEFT JOIN ? AS ? ON ? = ?",
+			bun.Ident("statuses"), bun.Ident("status"),
+			bun.Ident("status.id"), bun.Ident("media_attachment.status_id"),
+		).
+		Where("? IS NOT NULL", bun.Ident("media_attachment.status_id")).
+		Where("? IS NULL", bun.Ident("status.id")).
+		Where("? IS NULL", bun.Ident("media_attachment.scheduled_status_id")).
+		Order("media_attachment.id DESC").
+		Scan(ctx, &attachmentIDs); err != nil {
+		return nil, err
+	}
+
+	return attachmentIDs, nil
+}
@@ -50,6 +50,32 @@ func (suite *MediaTestSuite) TestGetCachedAttachmentsOlderThan() {
 	suite.Len(attachments, 3)
 }
 
+func (suite *MediaTestSuite) TestGetCachedAttachmentsExpiredBefore() {
+	ctx := context.Background()
+
+	// No test attachments expire.
+	attachments, err := suite.db.GetCachedAttachmentsExpiredBefore(ctx, time.Now(), 20)
+	suite.NoError(err)
+	suite.Empty(attachments)
+
+	// Set an expiry on one of them.
+	testAttachment := suite.testAttachments["local_account_1_status_4_attachment_1"]
+	testAttachment.ExpiresAt = time.Now().Add(-time.Minute)
+	if err := suite.db.UpdateAttachment(ctx, testAttachment, "expires_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	attachments, err = suite.db.GetCachedAttachmentsExpiredBefore(ctx, time.Now(), 20)
+	suite.NoError(err)
+	suite.Len(attachments, 1)
+	suite.Equal(testAttachment.ID, attachments[0].ID)
+
+	// Not expired yet at an earlier time.
+	attachments, err = suite.db.GetCachedAttachmentsExpiredBefore(ctx, time.Now().Add(-time.Hour), 20)
+	suite.NoError(err)
+	suite.Empty(attachments)
+}
+
 func TestMediaTestSuite(t *testing.T) {
 	suite.Run(t, new(MediaTestSuite))
 }
@@ -38,7 +38,23 @@ type mentionDB struct {
 }
 
 func (m *mentionDB) GetMention(ctx context.Context, id string) (*gtsmodel.Mention, error) {
-	mention, err := m.state.Caches.GTS.Mention.LoadOne("ID", func() (*gtsmodel.Mention, error) {
+	mention, err := m.getMention(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Further populate the mention fields where applicable.
+	if err := m.PopulateMention(ctx, mention); err != nil {
+		return nil, err
+	}
+
+	return mention, nil
+}
+
+// getMention loads the mention with given ID
+// from cache / database, without populating it.
+func (m *mentionDB) getMention(ctx context.Context, id string) (*gtsmodel.Mention, error) {
+	return m.state.Caches.GTS.Mention.LoadOne("ID", func() (*gtsmodel.Mention, error) {
 		var mention gtsmodel.Mention
 
 		q := m.db.
@@ -52,16 +68,6 @@ func (m *mentionDB) GetMention(ctx context.Context, id string) (*gtsmodel.Mentio
 
 		return &mention, nil
 	}, id)
-	if err != nil {
-		return nil, err
-	}
-
-	// Further populate the mention fields where applicable.
-	if err := m.PopulateMention(ctx, mention); err != nil {
-		return nil, err
-	}
-
-	return mention, nil
 }
 
 func (m *mentionDB) GetMentions(ctx context.Context, ids []string) ([]*gtsmodel.Mention, error) {
@@ -163,8 +169,9 @@ func (m *mentionDB) DeleteMentionByID(ctx context.Context, id string) error {
 
 	// Load mention into cache before attempting a delete,
 	// as we need it cached in order to trigger the invalidate
-	// callback. This in turn invalidates others.
-	_, err := m.GetMention(gtscontext.SetBarebones(ctx), id)
+	// callback. This in turn invalidates others. Don't
+	// populate it, as its status may already be gone.
+	_, err := m.getMention(ctx, id)
 	if err != nil {
 		if errors.Is(err, db.ErrNoEntries) {
 			// not an issue.
@@ -180,3 +187,23 @@ func (m *mentionDB) DeleteMentionByID(ctx context.Context, id string) error {
 		Exec(ctx)
 	return err
 }
+
+func (m *mentionDB) GetOrphanedMentionIDs(ctx context.Context) ([]string, error) {
+	var mentionIDs []string
+
+	if err := m.db.NewSelect().
+		TableExpr("? AS ?", bun.Ident("mentions"), bun.Ident("mention")).
+		Column("mention.id").
+		Join(
+			"LEFT JOIN ? AS ? ON ? = ?",
+			bun.Ident("statuses"), bun.Ident("status"),
+			bun.Ident("status.id"), bun.Ident("mention.status_id"),
+		).
+		Where("? IS NULL", bun.Ident("status.id")).
+		Order("mention.id DESC").
+		Scan(ctx, &mentionIDs); err != nil {
+		return nil, err
+	}
+
+	return mentionIDs, nil
+}
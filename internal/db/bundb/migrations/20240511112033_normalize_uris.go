@@ -0,0 +1,459 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+)
+
+func init() {
+	const batchSize = 500
+
+	// ref is a column referencing the ID of a row that
+	// may need to be repointed from a duplicate row to
+	// the row being kept. If unique is set, the column
+	// is part of a unique constraint together with the
+	// unique column, and rows that would conflict after
+	// repointing are deleted instead.
+	type ref struct {
+		table  string
+		column string
+		unique string
+	}
+
+	// Columns referencing statuses.
+	statusRefs := []ref{
+		{"statuses", "in_reply_to_id", ""},
+		{"statuses", "boost_of_id", ""},
+		{"status_faves", "status_id", "account_id"},
+		{"status_bookmarks", "status_id", ""},
+		{"notifications", "status_id", ""},
+		{"filter_statuses", "status_id", "filter_id"},
+	}
+
+	// Columns referencing accounts.
+	accountRefs := []ref{
+		{"statuses", "account_id", ""},
+		{"statuses", "in_reply_to_account_id", ""},
+		{"statuses", "boost_of_account_id", ""},
+		{"mentions", "origin_account_id", ""},
+		{"mentions", "target_account_id", ""},
+		{"notifications", "origin_account_id", ""},
+		{"notifications", "target_account_id", ""},
+		{"status_faves", "account_id", "status_id"},
+		{"status_faves", "target_account_id", ""},
+		{"status_bookmarks", "target_account_id", ""},
+		{"media_attachments", "account_id", ""},
+		{"poll_votes", "account_id", "poll_id"},
+		{"timeline_entries", "account_id", ""},
+		{"timeline_entries", "boost_of_account_id", ""},
+		{"follows", "account_id", "target_account_id"},
+		{"follows", "target_account_id", "account_id"},
+		{"follow_requests", "account_id", "target_account_id"},
+		{"follow_requests", "target_account_id", "account_id"},
+		{"blocks", "account_id", "target_account_id"},
+		{"blocks", "target_account_id", "account_id"},
+		{"account_notes", "account_id", "target_account_id"},
+		{"account_notes", "target_account_id", "account_id"},
+		{"thread_mutes", "account_id", "thread_id"},
+		{"reports", "account_id", ""},
+		{"reports", "target_account_id", ""},
+		{"admin_actions", "account_id", ""},
+	}
+
+	// report is the subset of a report
+	// needed to repoint its status IDs.
+	type report struct {
+		bun.BaseModel `bun:"table:reports"`
+		ID            string   `bun:",pk"`
+		StatusIDs     []string `bun:"statuses,array"`
+	}
+
+	repoint := func(ctx context.Context, tx bun.Tx, r ref, dupID string, keepID string) error {
+		if r.unique != "" {
+			// Select rows of the duplicate
+			// that would conflict with rows
+			// already present for the keeper.
+			conflicts := tx.
+				NewSelect().
+				Table(r.table).
+				Column("id").
+				Where("? = ?", bun.Ident(r.column), dupID).
+				Where("? IN (?)", bun.Ident(r.unique), tx.
+					NewSelect().
+					Table(r.table).
+					Column(r.unique).
+					Where("? = ?", bun.Ident(r.column), keepID),
+				)
+
+			if r.table == "follows" {
+				// List entries point to follows,
+				// so remove entries for any follows
+				// that are about to be deleted.
+				if _, err := tx.
+					NewDelete().
+					Table("list_entries").
+					Where("? IN (?)", bun.Ident("follow_id"), conflicts).
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			if _, err := tx.
+				NewDelete().
+				Table(r.table).
+				Where("? IN (?)", bun.Ident("id"), conflicts).
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		_, err := tx.
+			NewUpdate().
+			Table(r.table).
+			Set("? = ?", bun.Ident(r.column), keepID).
+			Where("? = ?", bun.Ident(r.column), dupID).
+			Exec(ctx)
+		return err
+	}
+
+	deleteWhere := func(ctx context.Context, tx bun.Tx, table string, column string, value string) error {
+		_, err := tx.
+			NewDelete().
+			Table(table).
+			Where("? = ?", bun.Ident(column), value).
+			Exec(ctx)
+		return err
+	}
+
+	mergeStatus := func(ctx context.Context, tx bun.Tx, dupID string, keepID string) error {
+		for _, r := range statusRefs {
+			if err := repoint(ctx, tx, r, dupID, keepID); err != nil {
+				return err
+			}
+		}
+
+		// Reports store the IDs of the statuses they
+		// cite in an array column, so they can't be
+		// repointed with a plain update. Only reports
+		// targeting the status author can cite it.
+		var reports []*report
+		if err := tx.
+			NewSelect().
+			Model(&reports).
+			Where("? IN (?)", bun.Ident("target_account_id"), tx.
+				NewSelect().
+				Table("statuses").
+				Column("account_id").
+				Where("? = ?", bun.Ident("id"), dupID),
+			).
+			Scan(ctx); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		for _, report := range reports {
+			if !slices.Contains(report.StatusIDs, dupID) {
+				continue
+			}
+
+			statusIDs := make([]string, 0, len(report.StatusIDs))
+			for _, id := range report.StatusIDs {
+				if id == dupID {
+					id = keepID
+				}
+				if !slices.Contains(statusIDs, id) {
+					statusIDs = append(statusIDs, id)
+				}
+			}
+			report.StatusIDs = statusIDs
+
+			if _, err := tx.
+				NewUpdate().
+				Model(report).
+				Column("statuses").
+				WherePK().
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		// Drop the duplicate's poll and any votes in it,
+		// the kept status has its own copy of the poll.
+		if _, err := tx.
+			NewDelete().
+			Table("poll_votes").
+			Where("? IN (?)", bun.Ident("poll_id"), tx.
+				NewSelect().
+				Table("polls").
+				Column("id").
+				Where("? = ?", bun.Ident("status_id"), dupID),
+			).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		// Likewise drop the duplicate's own links
+		// to mentions, tags, emojis, threads etc.
+		for _, table := range []string{
+			"polls",
+			"mentions",
+			"status_to_tags",
+			"status_to_emojis",
+			"thread_to_statuses",
+			"timeline_entries",
+		} {
+			if err := deleteWhere(ctx, tx, table, "status_id", dupID); err != nil {
+				return err
+			}
+		}
+
+		// Unattach the duplicate's media, so that
+		// it gets picked up by the media cleaner.
+		if _, err := tx.
+			NewUpdate().
+			Table("media_attachments").
+			Set("? = NULL", bun.Ident("status_id")).
+			Where("? = ?", bun.Ident("status_id"), dupID).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		return deleteWhere(ctx, tx, "statuses", "id", dupID)
+	}
+
+	mergeAccount := func(ctx context.Context, tx bun.Tx, dupID string, keepID string) error {
+		for _, r := range accountRefs {
+			if err := repoint(ctx, tx, r, dupID, keepID); err != nil {
+				return err
+			}
+		}
+
+		// Admin actions store the ID of their target
+		// in a column shared with domain targets, so
+		// only repoint actions that target accounts.
+		if _, err := tx.
+			NewUpdate().
+			Table("admin_actions").
+			Set("? = ?", bun.Ident("target_id"), keepID).
+			Where("? = ?", bun.Ident("target_category"), gtsmodel.AdminActionCategoryAccount).
+			Where("? = ?", bun.Ident("target_id"), dupID).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		if err := deleteWhere(ctx, tx, "account_to_emojis", "account_id", dupID); err != nil {
+			return err
+		}
+
+		// Drop stats for both accounts; they'll
+		// be regenerated the next time they're needed.
+		for _, id := range []string{dupID, keepID} {
+			if err := deleteWhere(ctx, tx, "account_stats", "account_id", id); err != nil {
+				return err
+			}
+		}
+
+		return deleteWhere(ctx, tx, "accounts", "id", dupID)
+	}
+
+	// normalize normalizes the URIs of rows selected by
+	// the given query, calling merge for each row whose
+	// normalized URI is already in use by another row.
+	// It returns the URIs that were changed, mapped to
+	// their new, normalized form.
+	normalize := func(
+		ctx context.Context,
+		tx bun.Tx,
+		table string,
+		where func(*bun.SelectQuery) *bun.SelectQuery,
+		merge func(ctx context.Context, tx bun.Tx, dupID string, keepID string) error,
+	) (map[string]string, error) {
+		changed := make(map[string]string)
+
+		// Page through rows with URIs that might
+		// need normalizing, oldest first, so that
+		// the oldest of any duplicates is kept.
+		for lastID := ""; ; {
+			var (
+				ids     []string
+				uriStrs []string
+			)
+
+			// Only the scheme and host are normalized, so only
+			// look at those; paths may well contain uppercase
+			// characters (eg., ULIDs) and are left untouched.
+			prefix := uriPrefix(tx.Dialect().Name())
+
+			if err := where(tx.
+				NewSelect().
+				Table(table).
+				Column("id", "uri").
+				Where("? > ?", bun.Ident("id"), lastID).
+				WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+					return q.
+						Where("? != LOWER(?)", prefix, prefix).
+						WhereOr("? LIKE ?", prefix, "https://%:443").
+						WhereOr("? LIKE ?", prefix, "http://%:80")
+				}).
+				Order("id ASC").
+				Limit(batchSize),
+			).Scan(ctx, &ids, &uriStrs); err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return nil, err
+			}
+
+			if len(ids) == 0 {
+				// Done.
+				break
+			}
+			lastID = ids[len(ids)-1]
+
+			for i, uriStr := range uriStrs {
+				normalized := uris.Normalize(uriStr)
+				if normalized == uriStr {
+					// Nothing to do.
+					continue
+				}
+				changed[uriStr] = normalized
+
+				var keepID string
+				err := tx.
+					NewSelect().
+					Table(table).
+					Column("id").
+					Where("? = ?", bun.Ident("uri"), normalized).
+					Limit(1).
+					Scan(ctx, &keepID)
+
+				switch {
+				case errors.Is(err, sql.ErrNoRows):
+					// No duplicate, just
+					// update the URI in place.
+					if _, err := tx.
+						NewUpdate().
+						Table(table).
+						Set("? = ?", bun.Ident("uri"), normalized).
+						Where("? = ?", bun.Ident("id"), ids[i]).
+						Exec(ctx); err != nil {
+						return nil, err
+					}
+
+				case err != nil:
+					return nil, err
+
+				default:
+					log.Infof(ctx, "merging %s %s into %s", table, uriStr, normalized)
+					if err := merge(ctx, tx, ids[i], keepID); err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+
+		return changed, nil
+	}
+
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			log.Info(ctx, "normalizing remote account and status URIs, please wait...")
+
+			changedAccounts, err := normalize(ctx, tx, "accounts",
+				func(q *bun.SelectQuery) *bun.SelectQuery {
+					return q.Where("? IS NOT NULL", bun.Ident("domain"))
+				},
+				mergeAccount,
+			)
+			if err != nil {
+				return err
+			}
+
+			// Statuses store their author's URI too.
+			for oldURI, newURI := range changedAccounts {
+				if _, err := tx.
+					NewUpdate().
+					Table("statuses").
+					Set("? = ?", bun.Ident("account_uri"), newURI).
+					Where("? = ?", bun.Ident("account_uri"), oldURI).
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			changedStatuses, err := normalize(ctx, tx, "statuses",
+				func(q *bun.SelectQuery) *bun.SelectQuery {
+					return q.Where("? = ?", bun.Ident("local"), false)
+				},
+				mergeStatus,
+			)
+			if err != nil {
+				return err
+			}
+
+			// As well as the URI of the status they reply to.
+			for oldURI, newURI := range changedStatuses {
+				if _, err := tx.
+					NewUpdate().
+					Table("statuses").
+					Set("? = ?", bun.Ident("in_reply_to_uri"), newURI).
+					Where("? = ?", bun.Ident("in_reply_to_uri"), oldURI).
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
+
+// uriPrefix returns an expression selecting the scheme
+// and authority of the uri column, eg., "https://example.org"
+// of "https://example.org/users/someone", for the given dialect.
+func uriPrefix(name dialect.Name) bun.Safe {
+	switch name {
+	case dialect.SQLite:
+		// Everything up to the first slash after "://",
+		// or the whole uri if there's no path.
+		return bun.Safe(`(CASE WHEN INSTR(SUBSTR("uri", INSTR("uri", '://') + 3), '/') > 0 ` +
+			`THEN SUBSTR("uri", 1, INSTR("uri", '://') + 1 + INSTR(SUBSTR("uri", INSTR("uri", '://') + 3), '/')) ` +
+			`ELSE "uri" END)`)
+	case dialect.PG:
+		// First and third parts of "scheme://authority/path".
+		return bun.Safe(`(SPLIT_PART("uri", '/', 1) || '//' || SPLIT_PART("uri", '/', 3))`)
+	default:
+		panic("db conn was neither pg not sqlite")
+	}
+}
@@ -0,0 +1,98 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"strings"
+
+	gtsmodel "github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		// Add notification policy columns to account
+		// settings, and filtered flag to notifications.
+		for _, column := range []struct {
+			table string
+			name  string
+			typ   string
+		}{
+			{table: "account_settings", name: "notif_policy_not_following", typ: "VARCHAR NOT NULL DEFAULT 'accept'"},
+			{table: "account_settings", name: "notif_policy_not_followers", typ: "VARCHAR NOT NULL DEFAULT 'accept'"},
+			{table: "account_settings", name: "notif_policy_new_accounts", typ: "VARCHAR NOT NULL DEFAULT 'accept'"},
+			{table: "account_settings", name: "notif_policy_private_mentions", typ: "VARCHAR NOT NULL DEFAULT 'accept'"},
+			{table: "notifications", name: "filtered", typ: "BOOLEAN NOT NULL DEFAULT false"},
+		} {
+			_, err := db.ExecContext(ctx,
+				"ALTER TABLE ? ADD COLUMN ? "+column.typ,
+				bun.Ident(column.table), bun.Ident(column.name),
+			)
+			if err != nil {
+				e := err.Error()
+				if !(strings.Contains(e, "already exists") ||
+					strings.Contains(e, "duplicate column name") ||
+					strings.Contains(e, "SQLSTATE 42701")) {
+					return err
+				}
+			}
+		}
+
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Create table for notification requests.
+			if _, err := tx.
+				NewCreateTable().
+				Model(&gtsmodel.NotificationRequest{}).
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// Index new table properly.
+			for index, columns := range map[string][]string{
+				// Eg., select page of an account's notification requests.
+				"notification_requests_account_id_id_idx": {"account_id", "id"},
+				// Eg., delete notification requests from an account.
+				"notification_requests_from_account_id_idx": {"from_account_id"},
+			} {
+				if _, err := tx.
+					NewCreateIndex().
+					Table("notification_requests").
+					Index(index).
+					Column(columns...).
+					IfNotExists().
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,81 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+
+	gtsmodel "github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Create tables for account imports and their rows.
+			for _, model := range []interface{}{
+				&gtsmodel.AccountImport{},
+				&gtsmodel.AccountImportRow{},
+			} {
+				if _, err := tx.
+					NewCreateTable().
+					Model(model).
+					IfNotExists().
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			// Index new tables properly.
+			for _, index := range []struct {
+				table   string
+				name    string
+				columns []string
+			}{
+				// Eg., select an account's imports.
+				{"account_imports", "account_imports_account_id_id_idx", []string{"account_id", "id"}},
+				// Eg., select unfinished imports on startup.
+				{"account_imports", "account_imports_state_idx", []string{"state"}},
+				// Eg., select next pending rows of an import.
+				{"account_import_rows", "account_import_rows_import_id_state_line_idx", []string{"import_id", "state", "line"}},
+			} {
+				if _, err := tx.
+					NewCreateIndex().
+					Table(index.table).
+					Index(index.name).
+					Column(index.columns...).
+					IfNotExists().
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
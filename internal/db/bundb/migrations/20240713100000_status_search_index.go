@@ -0,0 +1,98 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+
+	gtsmodel "github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Create table holding the plaintext
+			// of statuses in the search index.
+			if _, err := tx.
+				NewCreateTable().
+				Model(&gtsmodel.StatusSearchText{}).
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// Full-text index the plaintext; each
+			// database does this its own way.
+			var queries []string
+			switch tx.Dialect().Name() {
+
+			case dialect.SQLite:
+				queries = []string{
+					// FTS5 table using status_search_texts as external
+					// content, so the plaintext isn't stored twice.
+					`CREATE VIRTUAL TABLE IF NOT EXISTS "status_search_texts_fts" USING fts5(
+						"text",
+						content = 'status_search_texts',
+						content_rowid = 'id'
+					)`,
+
+					// Triggers to keep the FTS5 table in
+					// sync with the external content table.
+					`CREATE TRIGGER IF NOT EXISTS "status_search_texts_ai" AFTER INSERT ON "status_search_texts" BEGIN
+						INSERT INTO "status_search_texts_fts" ("rowid", "text") VALUES (new."id", new."text");
+					END`,
+					`CREATE TRIGGER IF NOT EXISTS "status_search_texts_ad" AFTER DELETE ON "status_search_texts" BEGIN
+						INSERT INTO "status_search_texts_fts" ("status_search_texts_fts", "rowid", "text") VALUES ('delete', old."id", old."text");
+					END`,
+					`CREATE TRIGGER IF NOT EXISTS "status_search_texts_au" AFTER UPDATE ON "status_search_texts" BEGIN
+						INSERT INTO "status_search_texts_fts" ("status_search_texts_fts", "rowid", "text") VALUES ('delete', old."id", old."text");
+						INSERT INTO "status_search_texts_fts" ("rowid", "text") VALUES (new."id", new."text");
+					END`,
+				}
+
+			case dialect.PG:
+				queries = []string{
+					// GIN index on tsvector of the plaintext, using
+					// the 'simple' configuration as statuses may
+					// be written in any language.
+					`CREATE INDEX IF NOT EXISTS "status_search_texts_text_idx" ON "status_search_texts" USING GIN (to_tsvector('simple', "text"))`,
+				}
+			}
+
+			for _, query := range queries {
+				if _, err := tx.ExecContext(ctx, query); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,317 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb/migrations"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+type MigrationsTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+// runMigration runs the up func of the named
+// migration against the already-migrated test db.
+func (suite *MigrationsTestSuite) runMigration(ctx context.Context, bunDB *bun.DB, name string) {
+	var up migrate.MigrationFunc
+	for _, m := range migrations.Migrations.Sorted() {
+		if m.String() == name {
+			up = m.Up
+		}
+	}
+
+	if up == nil {
+		suite.FailNow("migration not found: " + name)
+	}
+
+	if err := up(ctx, bunDB); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *MigrationsTestSuite) TestNormalizeURIsMergeDuplicates() {
+	var (
+		ctx          = context.Background()
+		keepAccount  = suite.testAccounts["remote_account_1"]
+		keepStatus   = suite.testStatuses["remote_account_1_status_1"]
+		localAccount = suite.testAccounts["local_account_1"]
+		adminAccount = suite.testAccounts["admin_account"]
+	)
+
+	// Duplicate of remote_account_1,
+	// differing only in URI case + port.
+	dupAccount := new(gtsmodel.Account)
+	*dupAccount = *keepAccount
+	dupAccount.ID = id.NewULID()
+	dupAccount.Username = "foss_satan_dup"
+	dupAccount.URI = "http://FossBros-Anonymous.io:80/users/foss_satan"
+	dupAccount.URL = ""
+	dupAccount.InboxURI = ""
+	dupAccount.OutboxURI = ""
+	dupAccount.FollowingURI = ""
+	dupAccount.FollowersURI = ""
+	dupAccount.FeaturedCollectionURI = ""
+	dupAccount.PublicKeyURI = dupAccount.URI + "#main-key"
+
+	// Duplicate of remote_account_1_status_1,
+	// authored by the duplicate account.
+	dupStatus := new(gtsmodel.Status)
+	*dupStatus = *keepStatus
+	dupStatus.ID = id.NewULID()
+	dupStatus.URI = "http://FOSSBROS-ANONYMOUS.IO/users/foss_satan/statuses/01FVW7JHQFSFK166WWKR8CBA6M"
+	dupStatus.URL = ""
+	dupStatus.AttachmentIDs = nil
+	dupStatus.AccountID = dupAccount.ID
+	dupStatus.AccountURI = dupAccount.URI
+
+	// Local reply to the duplicate status.
+	reply := new(gtsmodel.Status)
+	*reply = *suite.testStatuses["local_account_1_status_1"]
+	reply.ID = id.NewULID()
+	reply.URI = "http://localhost:8080/users/the_mighty_zork/statuses/" + reply.ID
+	reply.URL = ""
+	reply.InReplyToID = dupStatus.ID
+	reply.InReplyToAccountID = dupAccount.ID
+	reply.InReplyToURI = dupStatus.URI
+
+	fave := &gtsmodel.StatusFave{
+		ID:              id.NewULID(),
+		AccountID:       localAccount.ID,
+		TargetAccountID: dupAccount.ID,
+		StatusID:        dupStatus.ID,
+		URI:             "http://localhost:8080/users/the_mighty_zork/fave/" + id.NewULID(),
+	}
+
+	bookmark := &gtsmodel.StatusBookmark{
+		ID:              id.NewULID(),
+		AccountID:       localAccount.ID,
+		TargetAccountID: dupAccount.ID,
+		StatusID:        dupStatus.ID,
+	}
+
+	notif := &gtsmodel.Notification{
+		ID:               id.NewULID(),
+		NotificationType: gtsmodel.NotificationMention,
+		TargetAccountID:  localAccount.ID,
+		OriginAccountID:  dupAccount.ID,
+		StatusID:         dupStatus.ID,
+	}
+
+	follow := &gtsmodel.Follow{
+		ID:              id.NewULID(),
+		URI:             "http://fossbros-anonymous.io/follows/" + id.NewULID(),
+		AccountID:       dupAccount.ID,
+		TargetAccountID: localAccount.ID,
+	}
+
+	report := &gtsmodel.Report{
+		ID:              id.NewULID(),
+		URI:             "http://localhost:8080/reports/" + id.NewULID(),
+		AccountID:       localAccount.ID,
+		TargetAccountID: dupAccount.ID,
+		StatusIDs:       []string{dupStatus.ID},
+	}
+
+	note := &gtsmodel.AccountNote{
+		ID:              id.NewULID(),
+		AccountID:       localAccount.ID,
+		TargetAccountID: dupAccount.ID,
+		Comment:         "this is the same guy twice",
+	}
+
+	threadMute := &gtsmodel.ThreadMute{
+		ID:        id.NewULID(),
+		ThreadID:  id.NewULID(),
+		AccountID: dupAccount.ID,
+	}
+
+	adminAction := &gtsmodel.AdminAction{
+		ID:             id.NewULID(),
+		TargetCategory: gtsmodel.AdminActionCategoryAccount,
+		TargetID:       dupAccount.ID,
+		Type:           gtsmodel.AdminActionSuspend,
+		AccountID:      adminAccount.ID,
+	}
+
+	filterStatus := &gtsmodel.FilterStatus{
+		ID:        id.NewULID(),
+		AccountID: localAccount.ID,
+		FilterID:  "01HN26VM6KZTW1ANNRVSBMA461",
+		StatusID:  dupStatus.ID,
+	}
+
+	dbService, ok := suite.db.(*bundb.DBService)
+	if !ok {
+		panic("db was not *bundb.DBService")
+	}
+	bunDB := dbService.DB()
+
+	for _, model := range []any{
+		dupAccount,
+		dupStatus,
+		reply,
+		fave,
+		bookmark,
+		notif,
+		follow,
+		report,
+		note,
+		threadMute,
+		adminAction,
+		filterStatus,
+	} {
+		if _, err := bunDB.
+			NewInsert().
+			Model(model).
+			Exec(ctx); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	suite.runMigration(ctx, bunDB, "20240511112033_normalize_uris")
+
+	// count returns the number of rows in
+	// table where column is set to value.
+	count := func(table string, column string, value string) int {
+		n, err := bunDB.
+			NewSelect().
+			Table(table).
+			Where("? = ?", bun.Ident(column), value).
+			Count(ctx)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		return n
+	}
+
+	// Duplicates should be gone.
+	suite.Zero(count("accounts", "id", dupAccount.ID))
+	suite.Zero(count("statuses", "id", dupStatus.ID))
+
+	// Nothing should reference them anymore.
+	for _, c := range [][2]string{
+		{"statuses", "account_id"},
+		{"statuses", "in_reply_to_account_id"},
+		{"statuses", "boost_of_account_id"},
+		{"mentions", "origin_account_id"},
+		{"mentions", "target_account_id"},
+		{"notifications", "origin_account_id"},
+		{"notifications", "target_account_id"},
+		{"status_faves", "account_id"},
+		{"status_faves", "target_account_id"},
+		{"status_bookmarks", "target_account_id"},
+		{"media_attachments", "account_id"},
+		{"poll_votes", "account_id"},
+		{"timeline_entries", "account_id"},
+		{"timeline_entries", "boost_of_account_id"},
+		{"follows", "account_id"},
+		{"follows", "target_account_id"},
+		{"follow_requests", "account_id"},
+		{"follow_requests", "target_account_id"},
+		{"blocks", "account_id"},
+		{"blocks", "target_account_id"},
+		{"account_notes", "account_id"},
+		{"account_notes", "target_account_id"},
+		{"thread_mutes", "account_id"},
+		{"reports", "account_id"},
+		{"reports", "target_account_id"},
+		{"admin_actions", "account_id"},
+		{"admin_actions", "target_id"},
+		{"account_to_emojis", "account_id"},
+		{"account_stats", "account_id"},
+	} {
+		suite.Zero(count(c[0], c[1], dupAccount.ID), c[0]+"."+c[1])
+	}
+
+	for _, c := range [][2]string{
+		{"statuses", "in_reply_to_id"},
+		{"statuses", "boost_of_id"},
+		{"status_faves", "status_id"},
+		{"status_bookmarks", "status_id"},
+		{"notifications", "status_id"},
+		{"filter_statuses", "status_id"},
+		{"polls", "status_id"},
+		{"mentions", "status_id"},
+		{"status_to_tags", "status_id"},
+		{"status_to_emojis", "status_id"},
+		{"thread_to_statuses", "status_id"},
+		{"timeline_entries", "status_id"},
+		{"media_attachments", "status_id"},
+	} {
+		suite.Zero(count(c[0], c[1], dupStatus.ID), c[0]+"."+c[1])
+	}
+
+	// Referencing rows should now
+	// point to the kept account/status.
+	for _, c := range []struct {
+		table  string
+		id     string
+		column string
+		value  string
+	}{
+		{"statuses", reply.ID, "in_reply_to_id", keepStatus.ID},
+		{"statuses", reply.ID, "in_reply_to_account_id", keepAccount.ID},
+		{"statuses", reply.ID, "in_reply_to_uri", keepStatus.URI},
+		{"status_faves", fave.ID, "status_id", keepStatus.ID},
+		{"status_faves", fave.ID, "target_account_id", keepAccount.ID},
+		{"status_bookmarks", bookmark.ID, "status_id", keepStatus.ID},
+		{"notifications", notif.ID, "status_id", keepStatus.ID},
+		{"notifications", notif.ID, "origin_account_id", keepAccount.ID},
+		{"follows", follow.ID, "account_id", keepAccount.ID},
+		{"reports", report.ID, "target_account_id", keepAccount.ID},
+		{"account_notes", note.ID, "target_account_id", keepAccount.ID},
+		{"thread_mutes", threadMute.ID, "account_id", keepAccount.ID},
+		{"admin_actions", adminAction.ID, "target_id", keepAccount.ID},
+		{"filter_statuses", filterStatus.ID, "status_id", keepStatus.ID},
+	} {
+		var value string
+		if err := bunDB.
+			NewSelect().
+			Table(c.table).
+			Column(c.column).
+			Where("? = ?", bun.Ident("id"), c.id).
+			Scan(ctx, &value); err != nil {
+			suite.FailNow(err.Error(), c.table)
+		}
+		suite.Equal(c.value, value, c.table+"."+c.column)
+	}
+
+	// Report should cite the kept status.
+	dbReport := new(gtsmodel.Report)
+	if err := bunDB.
+		NewSelect().
+		Model(dbReport).
+		Where("? = ?", bun.Ident("id"), report.ID).
+		Scan(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal([]string{keepStatus.ID}, dbReport.StatusIDs)
+}
+
+func TestMigrationsTestSuite(t *testing.T) {
+	suite.Run(t, new(MigrationsTestSuite))
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"slices"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
@@ -233,6 +234,10 @@ func (n *notificationDB) GetAccountNotifications(
 		frontToBack = false // page up
 	}
 
+	// Return only notifs not filtered by
+	// the account's notification policy.
+	q = q.Where("? = ?", bun.Ident("notification.filtered"), false)
+
 	for _, excludeType := range excludeTypes {
 		// Filter out unwanted notif types.
 		q = q.Where("? != ?", bun.Ident("notification.notification_type"), excludeType)
@@ -274,6 +279,144 @@ func (n *notificationDB) GetAccountNotifications(
 	return n.GetNotificationsByIDs(ctx, notifIDs)
 }
 
+func (n *notificationDB) GetAccountNotificationsByGroupKey(
+	ctx context.Context,
+	accountID string,
+	groupKey string,
+) ([]*gtsmodel.Notification, error) {
+	var notifIDs []string
+
+	if err := n.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("notifications"), bun.Ident("notification")).
+		Column("notification.id").
+		Where("? = ?", bun.Ident("notification.target_account_id"), accountID).
+		Where("? = ?", bun.Ident("notification.group_key"), groupKey).
+		Order("notification.id DESC").
+		Scan(ctx, &notifIDs); err != nil {
+		return nil, err
+	}
+
+	if len(notifIDs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	// Fetch notification models by their IDs.
+	return n.GetNotificationsByIDs(ctx, notifIDs)
+}
+
+func (n *notificationDB) GetLatestNotificationGroupKey(
+	ctx context.Context,
+	targetAccountID string,
+	prefix string,
+) (string, error) {
+	var groupKeys []string
+
+	if err := n.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("notifications"), bun.Ident("notification")).
+		Column("notification.group_key").
+		Where("? = ?", bun.Ident("notification.target_account_id"), targetAccountID).
+		Where("? LIKE ?", bun.Ident("notification.group_key"), prefix+"%").
+		Order("notification.id DESC").
+		Limit(1).
+		Scan(ctx, &groupKeys); err != nil {
+		return "", err
+	}
+
+	if len(groupKeys) == 0 {
+		return "", db.ErrNoEntries
+	}
+
+	return groupKeys[0], nil
+}
+
+func (n *notificationDB) GetFilteredNotifications(
+	ctx context.Context,
+	accountID string,
+	fromAccountID string,
+) ([]*gtsmodel.Notification, error) {
+	var notifIDs []string
+
+	q := n.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("notifications"), bun.Ident("notification")).
+		Column("notification.id").
+		Where("? = ?", bun.Ident("notification.target_account_id"), accountID).
+		Where("? = ?", bun.Ident("notification.filtered"), true).
+		Order("notification.id DESC")
+
+	if fromAccountID != "" {
+		q = q.Where("? = ?", bun.Ident("notification.origin_account_id"), fromAccountID)
+	}
+
+	if err := q.Scan(ctx, &notifIDs); err != nil {
+		return nil, err
+	}
+
+	if len(notifIDs) == 0 {
+		return nil, nil
+	}
+
+	// Fetch notification models by their IDs.
+	return n.GetNotificationsByIDs(ctx, notifIDs)
+}
+
+func (n *notificationDB) CountAccountNotifications(
+	ctx context.Context,
+	accountID string,
+	sinceID string,
+	limit int,
+) (int, error) {
+	// Select IDs of notifications newer than
+	// sinceID, up to the given limit, so the
+	// count is bounded however far behind the
+	// account is.
+	subQuery := n.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("notifications"), bun.Ident("notification")).
+		Column("notification.id").
+		Where("? = ?", bun.Ident("notification.target_account_id"), accountID).
+		Where("? = ?", bun.Ident("notification.filtered"), false)
+
+	if sinceID != "" {
+		subQuery = subQuery.Where("? > ?", bun.Ident("notification.id"), sinceID)
+	}
+
+	if limit > 0 {
+		subQuery = subQuery.Limit(limit)
+	}
+
+	var count int
+	if err := n.db.
+		NewSelect().
+		ColumnExpr("COUNT(*)").
+		TableExpr("(?) AS ?", subQuery, bun.Ident("subquery")).
+		Scan(ctx, &count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (n *notificationDB) CountFilteredNotifications(
+	ctx context.Context,
+	accountID string,
+	fromAccountID string,
+) (int, error) {
+	q := n.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("notifications"), bun.Ident("notification")).
+		Where("? = ?", bun.Ident("notification.target_account_id"), accountID).
+		Where("? = ?", bun.Ident("notification.filtered"), true)
+
+	if fromAccountID != "" {
+		q = q.Where("? = ?", bun.Ident("notification.origin_account_id"), fromAccountID)
+	}
+
+	return q.Count(ctx)
+}
+
 func (n *notificationDB) PutNotification(ctx context.Context, notif *gtsmodel.Notification) error {
 	return n.state.Caches.GTS.Notification.Store(notif, func() error {
 		_, err := n.db.NewInsert().Model(notif).Exec(ctx)
@@ -281,6 +424,23 @@ func (n *notificationDB) PutNotification(ctx context.Context, notif *gtsmodel.No
 	})
 }
 
+func (n *notificationDB) UpdateNotification(ctx context.Context, notif *gtsmodel.Notification, columns ...string) error {
+	notif.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column, ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	return n.state.Caches.GTS.Notification.Store(notif, func() error {
+		_, err := n.db.NewUpdate().
+			Model(notif).
+			Where("? = ?", bun.Ident("notification.id"), notif.ID).
+			Column(columns...).
+			Exec(ctx)
+		return err
+	})
+}
+
 func (n *notificationDB) DeleteNotificationByID(ctx context.Context, id string) error {
 	defer n.state.Caches.GTS.Notification.Invalidate("ID", id)
 
@@ -386,3 +546,29 @@ func (n *notificationDB) DeleteNotificationsForStatus(ctx context.Context, statu
 		Exec(ctx)
 	return err
 }
+
+func (n *notificationDB) GetOrphanedNotificationIDs(ctx context.Context) ([]string, error) {
+	var notifIDs []string
+
+	if err := n.db.NewSelect().
+		TableExpr("? AS ?", bun.Ident("notifications"), bun.Ident("notification")).
+		Column("notification.id").
+		Join(
+			"LEFT JOIN ? AS ? ON ? = ?",
+			bun.Ident("accounts"), bun.Ident("target_account"),
+			bun.Ident("target_account.id"), bun.Ident("notification.target_account_id"),
+		).
+		Join(
+			"LEFT JOIN ? AS ? ON ? = ?",
+			bun.Ident("accounts"), bun.Ident("origin_account"),
+			bun.Ident("origin_account.id"), bun.Ident("notification.origin_account_id"),
+		).
+		WhereOr("? IS NULL", bun.Ident("target_account.id")).
+		WhereOr("? IS NULL", bun.Ident("origin_account.id")).
+		Order("notification.id DESC").
+		Scan(ctx, &notifIDs); err != nil {
+		return nil, err
+	}
+
+	return notifIDs, nil
+}
@@ -114,6 +114,28 @@ func (suite *NotificationTestSuite) TestGetAccountNotificationsWithoutSpam() {
 	}
 }
 
+func (suite *NotificationTestSuite) TestCountAccountNotificationsWithSpam() {
+	// All test notifications are older than a minute.
+	sinceID, err := id.NewULIDFromTime(time.Now().Add(-time.Minute))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.spamNotifs()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	// Only notifications newer than since ID count,
+	// ie., the half of the spam targeting zork.
+	count, err := suite.db.CountAccountNotifications(context.Background(), testAccount.ID, sinceID, 0)
+	suite.NoError(err)
+	suite.Equal(5000, count)
+
+	// Counting stops at the limit.
+	count, err = suite.db.CountAccountNotifications(context.Background(), testAccount.ID, sinceID, 100)
+	suite.NoError(err)
+	suite.Equal(100, count)
+}
+
 func (suite *NotificationTestSuite) TestDeleteNotificationsWithSpam() {
 	suite.spamNotifs()
 	testAccount := suite.testAccounts["local_account_1"]
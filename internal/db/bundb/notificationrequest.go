@@ -0,0 +1,246 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type notificationRequestDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (n *notificationRequestDB) GetNotificationRequestByID(ctx context.Context, id string) (*gtsmodel.NotificationRequest, error) {
+	return n.getNotificationRequest(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("? = ?", bun.Ident("notification_request.id"), id)
+	})
+}
+
+func (n *notificationRequestDB) GetNotificationRequest(ctx context.Context, accountID string, fromAccountID string) (*gtsmodel.NotificationRequest, error) {
+	return n.getNotificationRequest(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.
+			Where("? = ?", bun.Ident("notification_request.account_id"), accountID).
+			Where("? = ?", bun.Ident("notification_request.from_account_id"), fromAccountID)
+	})
+}
+
+func (n *notificationRequestDB) getNotificationRequest(ctx context.Context, where func(*bun.SelectQuery) *bun.SelectQuery) (*gtsmodel.NotificationRequest, error) {
+	var req gtsmodel.NotificationRequest
+
+	if err := where(n.db.
+		NewSelect().
+		Model(&req)).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return &req, nil
+	}
+
+	// Further populate the notification request fields where applicable.
+	if err := n.PopulateNotificationRequest(ctx, &req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (n *notificationRequestDB) GetAccountNotificationRequests(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.NotificationRequest, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		reqIDs = make([]string, 0, limit)
+	)
+
+	q := n.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("notification_requests"), bun.Ident("notification_request")).
+		// Select just the IDs of each notification request.
+		Column("notification_request.id").
+		Where("? = ?", bun.Ident("notification_request.account_id"), accountID).
+		Where("? = ?", bun.Ident("notification_request.accepted"), false)
+
+	if maxID != "" {
+		// Return only notification requests *OLDER* than given max ID.
+		q = q.Where("? < ?", bun.Ident("notification_request.id"), maxID)
+	}
+
+	if minID != "" {
+		// Return only notification requests *NEWER* than given min ID.
+		q = q.Where("? > ?", bun.Ident("notification_request.id"), minID)
+	}
+
+	if limit > 0 {
+		// Limit amount of notification requests returned.
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("notification_request.id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("notification_request.id"))
+	}
+
+	if err := q.Scan(ctx, &reqIDs); err != nil {
+		return nil, err
+	}
+
+	if len(reqIDs) == 0 {
+		return nil, nil
+	}
+
+	// If we're paging up, we still want notification
+	// requests to be sorted by ID desc, so reverse.
+	if order == paging.OrderAscending {
+		slices.Reverse(reqIDs)
+	}
+
+	reqs := make([]*gtsmodel.NotificationRequest, 0, len(reqIDs))
+	for _, id := range reqIDs {
+		// Attempt to fetch notification request from DB.
+		req, err := n.GetNotificationRequestByID(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error getting notification request %s: %v", id, err)
+			continue
+		}
+
+		// Append notification request to return slice.
+		reqs = append(reqs, req)
+	}
+
+	return reqs, nil
+}
+
+func (n *notificationRequestDB) CountAccountNotificationRequests(ctx context.Context, accountID string) (int, error) {
+	return n.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("notification_requests"), bun.Ident("notification_request")).
+		Where("? = ?", bun.Ident("notification_request.account_id"), accountID).
+		Where("? = ?", bun.Ident("notification_request.accepted"), false).
+		Count(ctx)
+}
+
+func (n *notificationRequestDB) PopulateNotificationRequest(ctx context.Context, req *gtsmodel.NotificationRequest) error {
+	var (
+		err  error
+		errs = gtserror.NewMultiError(3)
+	)
+
+	if req.Account == nil {
+		// Notification request account is not set, fetch from database.
+		req.Account, err = n.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			req.AccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating notification request account: %w", err)
+		}
+	}
+
+	if req.FromAccount == nil {
+		// Notification request from account is not set, fetch from database.
+		req.FromAccount, err = n.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			req.FromAccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating notification request from account: %w", err)
+		}
+	}
+
+	if req.LastStatus == nil &&
+		req.LastStatusID != "" {
+		// Notification request last status is not set, fetch from database.
+		req.LastStatus, err = n.state.DB.GetStatusByID(
+			gtscontext.SetBarebones(ctx),
+			req.LastStatusID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			// A since deleted status is fine.
+			errs.Appendf("error populating notification request last status: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (n *notificationRequestDB) PutNotificationRequest(ctx context.Context, req *gtsmodel.NotificationRequest) error {
+	_, err := n.db.
+		NewInsert().
+		Model(req).
+		Exec(ctx)
+	return err
+}
+
+func (n *notificationRequestDB) UpdateNotificationRequest(ctx context.Context, req *gtsmodel.NotificationRequest, columns ...string) error {
+	req.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := n.db.
+		NewUpdate().
+		Model(req).
+		Column(columns...).
+		Where("? = ?", bun.Ident("notification_request.id"), req.ID).
+		Exec(ctx)
+	return err
+}
+
+func (n *notificationRequestDB) DeleteNotificationRequestByID(ctx context.Context, id string) error {
+	_, err := n.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("notification_requests"), bun.Ident("notification_request")).
+		Where("? = ?", bun.Ident("notification_request.id"), id).
+		Exec(ctx)
+	return err
+}
+
+func (n *notificationRequestDB) DeleteNotificationRequestsByAccountID(ctx context.Context, accountID string) error {
+	_, err := n.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("notification_requests"), bun.Ident("notification_request")).
+		WhereOr("? = ?", bun.Ident("notification_request.account_id"), accountID).
+		WhereOr("? = ?", bun.Ident("notification_request.from_account_id"), accountID).
+		Exec(ctx)
+	return err
+}
@@ -20,6 +20,7 @@ package bundb
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
@@ -84,17 +85,44 @@ func (r *relationshipDB) GetRelationship(ctx context.Context, requestingAccount
 	}
 
 	// check if the requesting account is blocking the target account
-	rel.Blocking, err = r.IsBlocked(ctx, requestingAccount, targetAccount)
-	if err != nil {
+	block, err := r.GetBlock(
+		gtscontext.SetBarebones(ctx),
+		requestingAccount,
+		targetAccount,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
 		return nil, gtserror.Newf("error checking blocking: %w", err)
 	}
 
+	if block != nil {
+		// block exists so we can fill these fields out...
+		rel.Blocking = true
+		rel.BlockingExpiresAt = block.ExpiresAt
+	}
+
 	// check if the requesting account is blocked by the target account
 	rel.BlockedBy, err = r.IsBlocked(ctx, targetAccount, requestingAccount)
 	if err != nil {
 		return nil, gtserror.Newf("error checking blockedBy: %w", err)
 	}
 
+	// check if the requesting account is muting the target account
+	mute, err := r.GetMute(
+		gtscontext.SetBarebones(ctx),
+		requestingAccount,
+		targetAccount,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("error checking muting: %w", err)
+	}
+
+	if mute != nil && !mute.Expired(time.Now()) {
+		// mute exists so we can fill these fields out...
+		rel.Muting = true
+		rel.MutingNotifications = *mute.Notifications
+		rel.MutingExpiresAt = mute.ExpiresAt
+	}
+
 	// retrieve a note by the requesting account on the target account, if there is one
 	note, err := r.GetNote(
 		gtscontext.SetBarebones(ctx),
@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"slices"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
@@ -214,6 +215,41 @@ func (r *relationshipDB) PutBlock(ctx context.Context, block *gtsmodel.Block) er
 	})
 }
 
+func (r *relationshipDB) UpdateBlock(ctx context.Context, block *gtsmodel.Block, columns ...string) error {
+	block.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column, ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	return r.state.Caches.GTS.Block.Store(block, func() error {
+		if _, err := r.db.NewUpdate().
+			Model(block).
+			Where("? = ?", bun.Ident("block.id"), block.ID).
+			Column(columns...).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (r *relationshipDB) GetExpiringBlocks(ctx context.Context) ([]*gtsmodel.Block, error) {
+	var blockIDs []string
+
+	// Select all blocks with a set `expires_at` time.
+	if err := r.db.NewSelect().
+		Table("blocks").
+		Column("blocks.id").
+		Where("? IS NOT NULL", bun.Ident("blocks.expires_at")).
+		Scan(ctx, &blockIDs); err != nil {
+		return nil, err
+	}
+
+	return r.GetBlocksByIDs(ctx, blockIDs)
+}
+
 func (r *relationshipDB) DeleteBlockByID(ctx context.Context, id string) error {
 	// Load block into cache before attempting a delete,
 	// as we need it cached in order to trigger the invalidate
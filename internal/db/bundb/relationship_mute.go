@@ -0,0 +1,302 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/uptrace/bun"
+)
+
+func (r *relationshipDB) GetMuteByID(ctx context.Context, id string) (*gtsmodel.UserMute, error) {
+	return r.getMute(ctx, func(mute *gtsmodel.UserMute) error {
+		return r.db.NewSelect().
+			Model(mute).
+			Where("? = ?", bun.Ident("user_mute.id"), id).
+			Scan(ctx)
+	})
+}
+
+func (r *relationshipDB) GetMute(ctx context.Context, sourceAccountID string, targetAccountID string) (*gtsmodel.UserMute, error) {
+	return r.getMute(ctx, func(mute *gtsmodel.UserMute) error {
+		return r.db.NewSelect().
+			Model(mute).
+			Where("? = ?", bun.Ident("user_mute.account_id"), sourceAccountID).
+			Where("? = ?", bun.Ident("user_mute.target_account_id"), targetAccountID).
+			Scan(ctx)
+	})
+}
+
+func (r *relationshipDB) getMute(ctx context.Context, dbQuery func(*gtsmodel.UserMute) error) (*gtsmodel.UserMute, error) {
+	var mute gtsmodel.UserMute
+
+	// Not cached! Perform database query.
+	if err := dbQuery(&mute); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// Only a barebones model was requested.
+		return &mute, nil
+	}
+
+	// Further populate the mute fields where applicable.
+	if err := r.PopulateMute(ctx, &mute); err != nil {
+		return nil, err
+	}
+
+	return &mute, nil
+}
+
+func (r *relationshipDB) PopulateMute(ctx context.Context, mute *gtsmodel.UserMute) error {
+	var (
+		errs = gtserror.NewMultiError(2)
+		err  error
+	)
+
+	// Ensure mute source account set.
+	if mute.Account == nil {
+		mute.Account, err = r.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			mute.AccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating mute source account: %w", err)
+		}
+	}
+
+	// Ensure mute target account set.
+	if mute.TargetAccount == nil {
+		mute.TargetAccount, err = r.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			mute.TargetAccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating mute target account: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (r *relationshipDB) PutMute(ctx context.Context, mute *gtsmodel.UserMute) error {
+	if _, err := r.db.NewInsert().
+		Model(mute).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Muter's timeline visibility has changed.
+	r.state.Caches.OnInvalidateUserMute(mute)
+	return nil
+}
+
+func (r *relationshipDB) UpdateMute(ctx context.Context, mute *gtsmodel.UserMute, columns ...string) error {
+	mute.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column, ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	if _, err := r.db.NewUpdate().
+		Model(mute).
+		Where("? = ?", bun.Ident("user_mute.id"), mute.ID).
+		Column(columns...).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Muter's timeline visibility may have changed.
+	r.state.Caches.OnInvalidateUserMute(mute)
+	return nil
+}
+
+func (r *relationshipDB) GetExpiringMutes(ctx context.Context) ([]*gtsmodel.UserMute, error) {
+	var mutes []*gtsmodel.UserMute
+
+	// Select all mutes with a set `expires_at` time.
+	if err := r.db.NewSelect().
+		Model(&mutes).
+		Where("? IS NOT NULL", bun.Ident("user_mute.expires_at")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return mutes, nil
+	}
+
+	// Populate all loaded mutes, removing those we fail to
+	// populate (removes needing so many nil checks everywhere).
+	mutes = slices.DeleteFunc(mutes, func(mute *gtsmodel.UserMute) bool {
+		if err := r.PopulateMute(ctx, mute); err != nil {
+			log.Errorf(ctx, "error populating mute %s: %v", mute.ID, err)
+			return true
+		}
+		return false
+	})
+
+	return mutes, nil
+}
+
+func (r *relationshipDB) GetAccountMutes(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.UserMute, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		muteIDs = make([]string, 0, limit)
+	)
+
+	q := r.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("user_mutes"), bun.Ident("user_mute")).
+		// Select just the IDs of each mute.
+		Column("user_mute.id").
+		Where("? = ?", bun.Ident("user_mute.account_id"), accountID)
+
+	if maxID != "" {
+		// Return only mutes *OLDER* than given max ID.
+		q = q.Where("? < ?", bun.Ident("user_mute.id"), maxID)
+	}
+
+	if minID != "" {
+		// Return only mutes *NEWER* than given min ID.
+		q = q.Where("? > ?", bun.Ident("user_mute.id"), minID)
+	}
+
+	if limit > 0 {
+		// Limit amount of mutes returned.
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("user_mute.id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("user_mute.id"))
+	}
+
+	if err := q.Scan(ctx, &muteIDs); err != nil {
+		return nil, err
+	}
+
+	if len(muteIDs) == 0 {
+		return nil, nil
+	}
+
+	// If we're paging up, we still want mutes
+	// to be sorted by ID desc, so reverse ids slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(muteIDs)
+	}
+
+	mutes := make([]*gtsmodel.UserMute, 0, len(muteIDs))
+	for _, id := range muteIDs {
+		// Attempt to fetch mute from DB.
+		mute, err := r.GetMuteByID(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error getting mute %s: %v", id, err)
+			continue
+		}
+
+		// Append mute to return slice.
+		mutes = append(mutes, mute)
+	}
+
+	return mutes, nil
+}
+
+func (r *relationshipDB) DeleteMuteByID(ctx context.Context, id string) error {
+	// Load mute before attempting a delete,
+	// as we need it in order to invalidate
+	// the muting account's cached visibility.
+	mute, err := r.GetMuteByID(gtscontext.SetBarebones(ctx), id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// not an issue.
+			err = nil
+		}
+		return err
+	}
+
+	// Invalidate muting account's cached visibility on return after delete.
+	defer r.state.Caches.OnInvalidateUserMute(mute)
+
+	// Finally delete mute from DB.
+	_, err = r.db.NewDelete().
+		Table("user_mutes").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx)
+	return err
+}
+
+func (r *relationshipDB) DeleteAccountMutes(ctx context.Context, accountID string) error {
+	var mutes []*gtsmodel.UserMute
+
+	// Get full list of mutes to / from account.
+	if err := r.db.NewSelect().
+		Model(&mutes).
+		WhereOr("? = ? OR ? = ?",
+			bun.Ident("user_mute.account_id"),
+			accountID,
+			bun.Ident("user_mute.target_account_id"),
+			accountID,
+		).
+		Scan(ctx); err != nil {
+		return err
+	}
+
+	if len(mutes) == 0 {
+		// Nothing to do.
+		return nil
+	}
+
+	defer func() {
+		// Invalidate all muting accounts' cached visibility on return.
+		for _, mute := range mutes {
+			r.state.Caches.OnInvalidateUserMute(mute)
+		}
+	}()
+
+	// Finally delete all from DB.
+	_, err := r.db.NewDelete().
+		Table("user_mutes").
+		WhereOr("? = ? OR ? = ?",
+			bun.Ident("account_id"),
+			accountID,
+			bun.Ident("target_account_id"),
+			accountID,
+		).
+		Exec(ctx)
+	return err
+}
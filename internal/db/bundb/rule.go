@@ -147,3 +147,41 @@ func (r *ruleDB) UpdateRule(ctx context.Context, rule *gtsmodel.Rule) (*gtsmodel
 
 	return rule, nil
 }
+
+func (r *ruleDB) GetRuleAcknowledgementsByUserID(ctx context.Context, userID string) ([]*gtsmodel.RuleAcknowledgement, error) {
+	acks := []*gtsmodel.RuleAcknowledgement{}
+
+	if err := r.db.
+		NewSelect().
+		Model(&acks).
+		Where("? = ?", bun.Ident("rule_acknowledgement.user_id"), userID).
+		Order("rule_acknowledgement.rule_id ASC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return acks, nil
+}
+
+func (r *ruleDB) PutRuleAcknowledgements(ctx context.Context, acks []*gtsmodel.RuleAcknowledgement) error {
+	if len(acks) == 0 {
+		// Nothing
+		// to do.
+		return nil
+	}
+
+	_, err := r.db.
+		NewInsert().
+		Model(&acks).
+		Exec(ctx)
+	return err
+}
+
+func (r *ruleDB) DeleteRuleAcknowledgementsByUserID(ctx context.Context, userID string) error {
+	_, err := r.db.
+		NewDelete().
+		Table("rule_acknowledgements").
+		Where("? = ?", bun.Ident("user_id"), userID).
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,249 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type scheduledStatusDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (s *scheduledStatusDB) GetScheduledStatusByID(ctx context.Context, id string) (*gtsmodel.ScheduledStatus, error) {
+	var scheduledStatus gtsmodel.ScheduledStatus
+
+	if err := s.db.
+		NewSelect().
+		Model(&scheduledStatus).
+		Where("? = ?", bun.Ident("scheduled_status.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return &scheduledStatus, nil
+	}
+
+	// Further populate the scheduled status fields where applicable.
+	if err := s.PopulateScheduledStatus(ctx, &scheduledStatus); err != nil {
+		return nil, err
+	}
+
+	return &scheduledStatus, nil
+}
+
+func (s *scheduledStatusDB) GetAccountScheduledStatuses(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.ScheduledStatus, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		scheduledStatusIDs = make([]string, 0, limit)
+	)
+
+	q := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("scheduled_statuses"), bun.Ident("scheduled_status")).
+		// Select just the IDs of each scheduled status.
+		Column("scheduled_status.id").
+		Where("? = ?", bun.Ident("scheduled_status.account_id"), accountID)
+
+	if maxID != "" {
+		// Return only scheduled statuses *OLDER* than given max ID.
+		q = q.Where("? < ?", bun.Ident("scheduled_status.id"), maxID)
+	}
+
+	if minID != "" {
+		// Return only scheduled statuses *NEWER* than given min ID.
+		q = q.Where("? > ?", bun.Ident("scheduled_status.id"), minID)
+	}
+
+	if limit > 0 {
+		// Limit amount of scheduled statuses returned.
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("scheduled_status.id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("scheduled_status.id"))
+	}
+
+	if err := q.Scan(ctx, &scheduledStatusIDs); err != nil {
+		return nil, err
+	}
+
+	if len(scheduledStatusIDs) == 0 {
+		return nil, nil
+	}
+
+	// If we're paging up, we still want scheduled
+	// statuses to be sorted by ID desc, so reverse.
+	if order == paging.OrderAscending {
+		slices.Reverse(scheduledStatusIDs)
+	}
+
+	return s.getScheduledStatuses(ctx, scheduledStatusIDs)
+}
+
+func (s *scheduledStatusDB) CountAccountScheduledStatuses(ctx context.Context, accountID string) (int, error) {
+	return s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("scheduled_statuses"), bun.Ident("scheduled_status")).
+		Where("? = ?", bun.Ident("scheduled_status.account_id"), accountID).
+		Count(ctx)
+}
+
+func (s *scheduledStatusDB) GetDueScheduledStatuses(ctx context.Context, now time.Time) ([]*gtsmodel.ScheduledStatus, error) {
+	var scheduledStatusIDs []string
+
+	if err := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("scheduled_statuses"), bun.Ident("scheduled_status")).
+		Column("scheduled_status.id").
+		Where("? <= ?", bun.Ident("scheduled_status.scheduled_at"), now).
+		OrderExpr("? ASC", bun.Ident("scheduled_status.scheduled_at")).
+		Scan(ctx, &scheduledStatusIDs); err != nil {
+		return nil, err
+	}
+
+	return s.getScheduledStatuses(ctx, scheduledStatusIDs)
+}
+
+func (s *scheduledStatusDB) getScheduledStatuses(ctx context.Context, ids []string) ([]*gtsmodel.ScheduledStatus, error) {
+	scheduledStatuses := make([]*gtsmodel.ScheduledStatus, 0, len(ids))
+	for _, id := range ids {
+		// Attempt to fetch scheduled status from DB.
+		scheduledStatus, err := s.GetScheduledStatusByID(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error getting scheduled status %s: %v", id, err)
+			continue
+		}
+
+		// Append scheduled status to return slice.
+		scheduledStatuses = append(scheduledStatuses, scheduledStatus)
+	}
+
+	return scheduledStatuses, nil
+}
+
+func (s *scheduledStatusDB) PopulateScheduledStatus(ctx context.Context, scheduledStatus *gtsmodel.ScheduledStatus) error {
+	var (
+		err  error
+		errs = gtserror.NewMultiError(3)
+	)
+
+	if scheduledStatus.Account == nil {
+		// Scheduled status author is not set, fetch from database.
+		scheduledStatus.Account, err = s.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			scheduledStatus.AccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating scheduled status account: %w", err)
+		}
+	}
+
+	if scheduledStatus.Application == nil &&
+		scheduledStatus.ApplicationID != "" {
+		// Scheduled status application is not set, fetch from database.
+		scheduledStatus.Application, err = s.state.DB.GetApplicationByID(
+			ctx,
+			scheduledStatus.ApplicationID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			errs.Appendf("error populating scheduled status application: %w", err)
+		}
+	}
+
+	if len(scheduledStatus.Attachments) != len(scheduledStatus.AttachmentIDs) {
+		// Attachments are out-of-date with IDs, repopulate.
+		scheduledStatus.Attachments, err = s.state.DB.GetAttachmentsByIDs(
+			ctx,
+			scheduledStatus.AttachmentIDs,
+		)
+		if err != nil {
+			errs.Appendf("error populating scheduled status attachments: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (s *scheduledStatusDB) PutScheduledStatus(ctx context.Context, scheduledStatus *gtsmodel.ScheduledStatus) error {
+	_, err := s.db.
+		NewInsert().
+		Model(scheduledStatus).
+		Exec(ctx)
+	return err
+}
+
+func (s *scheduledStatusDB) UpdateScheduledStatus(ctx context.Context, scheduledStatus *gtsmodel.ScheduledStatus, columns ...string) error {
+	scheduledStatus.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := s.db.
+		NewUpdate().
+		Model(scheduledStatus).
+		Column(columns...).
+		Where("? = ?", bun.Ident("scheduled_status.id"), scheduledStatus.ID).
+		Exec(ctx)
+	return err
+}
+
+func (s *scheduledStatusDB) DeleteScheduledStatusByID(ctx context.Context, id string) error {
+	_, err := s.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("scheduled_statuses"), bun.Ident("scheduled_status")).
+		Where("? = ?", bun.Ident("scheduled_status.id"), id).
+		Exec(ctx)
+	return err
+}
+
+func (s *scheduledStatusDB) DeleteScheduledStatusesByAccountID(ctx context.Context, accountID string) error {
+	_, err := s.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("scheduled_statuses"), bun.Ident("scheduled_status")).
+		Where("? = ?", bun.Ident("scheduled_status.account_id"), accountID).
+		Exec(ctx)
+	return err
+}
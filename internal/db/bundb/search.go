@@ -19,7 +19,9 @@ package bundb
 
 import (
 	"context"
+	"slices"
 	"strings"
+	"unicode"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
@@ -29,33 +31,17 @@ import (
 	"github.com/uptrace/bun/dialect"
 )
 
-// todo: currently we pass an 'offset' parameter into functions owned by this struct,
-// which is ignored.
+// Functions owned by this struct take an 'offset' parameter, which
+// allows callers to page through results without supplying maxID
+// or minID params, by skipping the given number of results.
 //
-// The idea of 'offset' is to allow callers to page through results without supplying
-// maxID or minID params; they simply use the offset as more or less a 'page number'.
-// This works fine when you're dealing with something like Elasticsearch, but for
-// SQLite or Postgres 'LIKE' queries it doesn't really, because for each higher offset
-// you have to calculate the value of all the previous offsets as well *within the
-// execution time of the query*. It's MUCH more efficient to page using maxID and
-// minID for queries like this. For now, then, we just ignore the offset and hope that
-// the caller will page using maxID and minID instead.
-//
-// In future, however, it would be good to support offset in a way that doesn't totally
-// destroy database queries. One option would be to cache previous offsets when paging
-// down (which is the most common use case).
-//
-// For example, say a caller makes a call with offset 0: we run the query as normal,
-// and in a 10 minute cache or something, store the next maxID value as it would be for
-// offset 1, for the supplied query, limit, following, etc. Then when they call for
-// offset 1, instead of supplying 'offset' in the query and causing slowdown, we check
-// the cache to see if we have the next maxID value stored for that query, and use that
-// instead. If a caller out of the blue requests offset 4 or something, on an empty cache,
-// we could run the previous 4 queries and store the offsets for those before making the
-// 5th call for page 4.
-//
-// This isn't ideal, of course, but at least we could cover the most common use case of
-// a caller paging down through results.
+// Offset is applied using SQL OFFSET. This works fine for small
+// offsets, but for each higher offset, SQLite or Postgres have
+// to calculate all of the previous results as well *within the
+// execution time of the query*, as 'LIKE' queries can't use an
+// index to skip them. It's MUCH more efficient to page using
+// maxID and minID for queries like this, so callers should
+// keep offsets small, and prefer maxID and minID where possible.
 type searchDB struct {
 	db    *bun.DB
 	state *state.State
@@ -147,6 +133,11 @@ func (s *searchDB) SearchForAccounts(
 		q = q.Limit(limit)
 	}
 
+	if offset > 0 {
+		// Skip already seen accounts.
+		q = q.Offset(offset)
+	}
+
 	if frontToBack {
 		// Page down.
 		q = q.Order("account.id DESC")
@@ -268,22 +259,48 @@ func (s *searchDB) SearchForStatuses(
 	ctx context.Context,
 	accountID string,
 	query string,
+	fromAccountID string,
+	hasMedia bool,
+	hasPoll bool,
 	maxID string,
 	minID string,
 	limit int,
 	offset int,
 ) ([]*gtsmodel.Status, error) {
-	// Ensure reasonable
-	if limit < 0 {
-		limit = 0
+	q := s.statusSearch(
+		accountID,
+		fromAccountID,
+		hasMedia,
+		hasPoll,
+		false, // no bookmarks + faves
+	)
+
+	if query != "" {
+		// Search using LIKE for matches of query
+		// string within statusText subquery.
+		q = whereLike(q, s.statusText(), query)
 	}
 
-	// Make educated guess for slice size
-	var (
-		statusIDs   = make([]string, 0, limit)
-		frontToBack = true
-	)
+	return s.pageStatuses(ctx, q, maxID, minID, limit, offset)
+}
 
+// statusSearch returns a query that selects IDs of statuses,
+// excluding boosts, which accountID is allowed to search:
+// statuses created by accountID or replying to accountID,
+// and if bookmarksAndFaves is set, statuses bookmarked or
+// faved by accountID.
+//
+// Results are narrowed down to statuses created by
+// fromAccountID, with media attachments, or with a
+// poll, depending on which of the other parameters
+// are set.
+func (s *searchDB) statusSearch(
+	accountID string,
+	fromAccountID string,
+	hasMedia bool,
+	hasPoll bool,
+	bookmarksAndFaves bool,
+) *bun.SelectQuery {
 	q := s.db.
 		NewSelect().
 		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
@@ -292,13 +309,77 @@ func (s *searchDB) SearchForStatuses(
 		// Ignore boosts.
 		Where("? IS NULL", bun.Ident("status.boost_of_id")).
 		// Select only statuses created by
-		// accountID or replying to accountID.
+		// accountID or replying to accountID,
+		// or bookmarked or faved by accountID.
 		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
-			return q.
+			q = q.
 				Where("? = ?", bun.Ident("status.account_id"), accountID).
 				WhereOr("? = ?", bun.Ident("status.in_reply_to_account_id"), accountID)
+
+			if !bookmarksAndFaves {
+				return q
+			}
+
+			return q.
+				WhereOr("EXISTS (?)", s.db.
+					NewSelect().
+					TableExpr("? AS ?", bun.Ident("status_bookmarks"), bun.Ident("status_bookmark")).
+					Column("status_bookmark.id").
+					Where("? = ?", bun.Ident("status_bookmark.status_id"), bun.Ident("status.id")).
+					Where("? = ?", bun.Ident("status_bookmark.account_id"), accountID),
+				).
+				WhereOr("EXISTS (?)", s.db.
+					NewSelect().
+					TableExpr("? AS ?", bun.Ident("status_faves"), bun.Ident("status_fave")).
+					Column("status_fave.id").
+					Where("? = ?", bun.Ident("status_fave.status_id"), bun.Ident("status.id")).
+					Where("? = ?", bun.Ident("status_fave.account_id"), accountID),
+				)
 		})
 
+	if fromAccountID != "" {
+		// Select only statuses
+		// created by fromAccountID.
+		q = q.Where("? = ?", bun.Ident("status.account_id"), fromAccountID)
+	}
+
+	if hasMedia {
+		// Select only statuses
+		// with media attachments.
+		q = whereArrayIsNotEmpty(q, bun.Ident("status.attachment_ids"))
+	}
+
+	if hasPoll {
+		// Select only statuses with a poll.
+		q = q.Where("? IS NOT NULL", bun.Ident("status.poll_id"))
+	}
+
+	return q
+}
+
+// pageStatuses pages through the results of the given
+// status search query using maxID, minID, limit and
+// offset, and returns the resulting statuses, sorted
+// by ID descending.
+func (s *searchDB) pageStatuses(
+	ctx context.Context,
+	q *bun.SelectQuery,
+	maxID string,
+	minID string,
+	limit int,
+	offset int,
+) ([]*gtsmodel.Status, error) {
+	// Ensure reasonable
+	if limit < 0 {
+		limit = 0
+	}
+
+	// Make educated guess for slice size
+	var (
+		statusIDs   = make([]string, 0, limit)
+		frontToBack = true
+	)
+
 	// Return only items with a LOWER id than maxID.
 	if maxID == "" {
 		maxID = id.Highest
@@ -313,18 +394,16 @@ func (s *searchDB) SearchForStatuses(
 		frontToBack = false
 	}
 
-	// Select status text as subquery.
-	statusTextSubq := s.statusText()
-
-	// Search using LIKE for matches of query
-	// string within statusText subquery.
-	q = whereLike(q, statusTextSubq, query)
-
 	if limit > 0 {
 		// Limit amount of statuses returned.
 		q = q.Limit(limit)
 	}
 
+	if offset > 0 {
+		// Skip already seen statuses.
+		q = q.Offset(offset)
+	}
+
 	if frontToBack {
 		// Page down.
 		q = q.Order("status.id DESC")
@@ -451,6 +530,11 @@ func (s *searchDB) SearchForTags(
 		q = q.Limit(limit)
 	}
 
+	if offset > 0 {
+		// Skip already seen tags.
+		q = q.Offset(offset)
+	}
+
 	if frontToBack {
 		// Page down.
 		q = q.Order("tag.id DESC")
@@ -491,3 +575,171 @@ func (s *searchDB) SearchForTags(
 
 	return tags, nil
 }
+
+// Query example (SQLite):
+//
+//	SELECT "status"."id"
+//	FROM "statuses" AS "status"
+//	WHERE ("status"."boost_of_id" IS NULL)
+//	AND (("status"."account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF') OR ("status"."in_reply_to_account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF')
+//	OR (EXISTS (SELECT "status_bookmark"."id" FROM "status_bookmarks" AS "status_bookmark" WHERE ("status_bookmark"."status_id" = "status"."id") AND ("status_bookmark"."account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF')))
+//	OR (EXISTS (SELECT "status_fave"."id" FROM "status_faves" AS "status_fave" WHERE ("status_fave"."status_id" = "status"."id") AND ("status_fave"."account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF'))))
+//	AND ("status"."id" < 'ZZZZZZZZZZZZZZZZZZZZZZZZZZ')
+//	AND ("status"."id" IN (SELECT "status_search_text"."status_id" FROM "status_search_texts" AS "status_search_text" JOIN "status_search_texts_fts" ON "status_search_texts_fts"."rowid" = "status_search_text"."id" WHERE ("status_search_texts_fts" MATCH '"hello"')))
+//	ORDER BY "status"."id" DESC LIMIT 10
+func (s *searchDB) SearchForIndexedStatuses(
+	ctx context.Context,
+	accountID string,
+	query string,
+	fromAccountID string,
+	hasMedia bool,
+	hasPoll bool,
+	maxID string,
+	minID string,
+	limit int,
+	offset int,
+) ([]*gtsmodel.Status, error) {
+	q := s.statusSearch(
+		accountID,
+		fromAccountID,
+		hasMedia,
+		hasPoll,
+		true, // include bookmarks + faves
+	)
+
+	if query != "" {
+		// Select IDs of indexed statuses
+		// matching the query text.
+		matchSubq := s.matchStatusText(query)
+		if matchSubq == nil {
+			// Nothing searchable
+			// in query text.
+			return nil, nil
+		}
+
+		// Select only statuses whose
+		// indexed text matches query.
+		q = q.Where("? IN (?)", bun.Ident("status.id"), matchSubq)
+	}
+
+	return s.pageStatuses(ctx, q, maxID, minID, limit, offset)
+}
+
+// matchStatusText returns a subquery that selects the IDs of
+// statuses in the search index whose text matches all words
+// of the given query, or nil if query contains no words.
+func (s *searchDB) matchStatusText(query string) *bun.SelectQuery {
+	// Only keep words with at least one letter
+	// or number in them, as anything else can't
+	// match a term in either full-text index.
+	words := strings.FieldsFunc(query, unicode.IsSpace)
+	words = slices.DeleteFunc(words, func(word string) bool {
+		return strings.IndexFunc(word, func(r rune) bool {
+			return unicode.IsLetter(r) || unicode.IsNumber(r)
+		}) == -1
+	})
+	if len(words) == 0 {
+		return nil
+	}
+
+	q := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_search_texts"), bun.Ident("status_search_text")).
+		Column("status_search_text.status_id")
+
+	// SQLite and Postgres have
+	// different full-text search.
+	switch d := s.db.Dialect().Name(); d {
+
+	case dialect.SQLite:
+		// Quote each word as an FTS5 string, so that
+		// any FTS5 query syntax in it is taken literally.
+		// Space-separated strings must all match.
+		for i, word := range words {
+			words[i] = `"` + strings.ReplaceAll(word, `"`, `""`) + `"`
+		}
+
+		q = q.
+			Join("JOIN ? ON ? = ?",
+				bun.Ident("status_search_texts_fts"),
+				bun.Ident("status_search_texts_fts.rowid"),
+				bun.Ident("status_search_text.id"),
+			).
+			Where("? MATCH ?", bun.Ident("status_search_texts_fts"), strings.Join(words, " "))
+
+	case dialect.PG:
+		// This expression must match the
+		// one used by the text GIN index.
+		q = q.Where("to_tsvector('simple', ?) @@ plainto_tsquery('simple', ?)",
+			bun.Ident("status_search_text.text"), strings.Join(words, " "),
+		)
+
+	default:
+		log.Panicf(nil, "db conn %s was neither pg nor sqlite", d)
+	}
+
+	return q
+}
+
+func (s *searchDB) PutStatusSearchText(ctx context.Context, statusID string, text string) error {
+	_, err := s.db.
+		NewInsert().
+		Model(&gtsmodel.StatusSearchText{
+			StatusID: statusID,
+			Text:     text,
+		}).
+		On("CONFLICT (?) DO UPDATE", bun.Ident("status_id")).
+		Set("? = EXCLUDED.?", bun.Ident("text"), bun.Ident("text")).
+		Exec(ctx)
+	return err
+}
+
+func (s *searchDB) DeleteStatusSearchText(ctx context.Context, statusID string) error {
+	_, err := s.db.
+		NewDelete().
+		Table("status_search_texts").
+		Where("? = ?", bun.Ident("status_id"), statusID).
+		Exec(ctx)
+	return err
+}
+
+func (s *searchDB) CountStatusSearchTexts(ctx context.Context) (int, error) {
+	return s.db.
+		NewSelect().
+		Table("status_search_texts").
+		Count(ctx)
+}
+
+func (s *searchDB) DeleteAllStatusSearchTexts(ctx context.Context) error {
+	_, err := s.db.
+		NewDelete().
+		Table("status_search_texts").
+		Where("TRUE"). // bun gets angry performing delete over all rows
+		Exec(ctx)
+	return err
+}
+
+func (s *searchDB) GetStatusesToIndex(ctx context.Context, maxID string, limit int) ([]*gtsmodel.Status, error) {
+	if maxID == "" {
+		maxID = id.Highest
+	}
+
+	var statusIDs []string
+	if err := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		Column("status.id").
+		Where("? IS NULL", bun.Ident("status.boost_of_id")).
+		Where("? < ?", bun.Ident("status.id"), maxID).
+		Order("status.id DESC").
+		Limit(limit).
+		Scan(ctx, &statusIDs); err != nil {
+		return nil, err
+	}
+
+	if len(statusIDs) == 0 {
+		return nil, nil
+	}
+
+	return s.state.DB.GetStatusesByIDs(ctx, statusIDs)
+}
@@ -107,11 +107,111 @@ func (suite *SearchTestSuite) TestSearchAccountsFossAny() {
 func (suite *SearchTestSuite) TestSearchStatuses() {
 	testAccount := suite.testAccounts["local_account_1"]
 
-	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, "hello", "", "", 10, 0)
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, "hello", "", false, false, "", "", 10, 0)
 	suite.NoError(err)
 	suite.Len(statuses, 1)
 }
 
+func (suite *SearchTestSuite) TestSearchStatusesFiltered() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+	adminAccount := suite.testAccounts["admin_account"]
+
+	// Only statuses created by admin account.
+	statuses, err := suite.db.SearchForStatuses(ctx, testAccount.ID, "a", adminAccount.ID, false, false, "", "", 10, 0)
+	suite.NoError(err)
+	for _, status := range statuses {
+		suite.Equal(adminAccount.ID, status.AccountID)
+	}
+
+	// Only statuses with a poll, empty query matches all text.
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, "", "", false, true, "", "", 10, 0)
+	suite.NoError(err)
+	suite.NotEmpty(statuses)
+	for _, status := range statuses {
+		suite.NotEmpty(status.PollID)
+	}
+
+	// Only statuses with media.
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, "", "", true, false, "", "", 10, 0)
+	suite.NoError(err)
+	suite.NotEmpty(statuses)
+	for _, status := range statuses {
+		suite.NotEmpty(status.AttachmentIDs)
+	}
+
+	// Offset skips the first results.
+	all, err := suite.db.SearchForStatuses(ctx, testAccount.ID, "a", "", false, false, "", "", 10, 0)
+	suite.NoError(err)
+	skipped, err := suite.db.SearchForStatuses(ctx, testAccount.ID, "a", "", false, false, "", "", 10, 2)
+	suite.NoError(err)
+	if suite.Len(skipped, len(all)-2) {
+		for i, status := range skipped {
+			suite.Equal(all[i+2].ID, status.ID)
+		}
+	}
+}
+
+func (suite *SearchTestSuite) TestSearchIndexedStatuses() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+	ownStatus := suite.testStatuses["local_account_1_status_1"]
+	favedStatus := suite.testStatuses["admin_account_status_1"]
+	otherStatus := suite.testStatuses["remote_account_1_status_1"]
+
+	// Index an own status, a status faved by
+	// the test account, and an unrelated status.
+	for statusID, text := range map[string]string{
+		ownStatus.ID:   "hello everyone!",
+		favedStatus.ID: "hello world! #welcome ! first post on the instance :rainbow: !",
+		otherStatus.ID: "hello everyone, this is my first status",
+	} {
+		err := suite.db.PutStatusSearchText(ctx, statusID, text)
+		suite.NoError(err)
+	}
+	defer func() {
+		err := suite.db.DeleteAllStatusSearchTexts(ctx)
+		suite.NoError(err)
+	}()
+
+	count, err := suite.db.CountStatusSearchTexts(ctx)
+	suite.NoError(err)
+	suite.Equal(3, count)
+
+	// Own and faved status match, unrelated one doesn't.
+	statuses, err := suite.db.SearchForIndexedStatuses(ctx, testAccount.ID, "hello", "", false, false, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 2)
+
+	// All words must match, in any order and case.
+	statuses, err = suite.db.SearchForIndexedStatuses(ctx, testAccount.ID, "POST hello", "", false, false, "", "", 10, 0)
+	suite.NoError(err)
+	if suite.Len(statuses, 1) {
+		suite.Equal(favedStatus.ID, statuses[0].ID)
+	}
+
+	// FTS syntax is taken literally.
+	statuses, err = suite.db.SearchForIndexedStatuses(ctx, testAccount.ID, `hello OR "first`, "", false, false, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+
+	// Updated text replaces the old text.
+	err = suite.db.PutStatusSearchText(ctx, ownStatus.ID, "goodbye everyone!")
+	suite.NoError(err)
+
+	statuses, err = suite.db.SearchForIndexedStatuses(ctx, testAccount.ID, "hello", "", false, false, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+
+	// Deleted text no longer matches.
+	err = suite.db.DeleteStatusSearchText(ctx, favedStatus.ID)
+	suite.NoError(err)
+
+	statuses, err = suite.db.SearchForIndexedStatuses(ctx, testAccount.ID, "hello", "", false, false, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+}
+
 func (suite *SearchTestSuite) TestSearchTags() {
 	// Search with full tag string.
 	tags, err := suite.db.SearchForTags(context.Background(), "welcome", "", "", 10, 0)
@@ -29,6 +29,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/uptrace/bun"
 )
@@ -97,6 +98,10 @@ func (s *statusDB) GetStatusesByIDs(ctx context.Context, ids []string) ([]*gtsmo
 }
 
 func (s *statusDB) GetStatusByURI(ctx context.Context, uri string) (*gtsmodel.Status, error) {
+	// Stored URIs are normalized,
+	// so do the same for lookups.
+	uri = uris.Normalize(uri)
+
 	return s.getStatus(
 		ctx,
 		"URI",
@@ -219,6 +224,19 @@ func (s *statusDB) PopulateStatus(ctx context.Context, status *gtsmodel.Status)
 		}
 	}
 
+	if status.QuoteOfID != "" && status.QuoteOf == nil {
+		// Quoted status is not set, fetch from database.
+		status.QuoteOf, err = s.GetStatusByID(
+			gtscontext.SetBarebones(ctx),
+			status.QuoteOfID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			// Quoted status may have been deleted
+			// since, in which case just leave it unset.
+			errs.Appendf("error populating quoted status: %w", err)
+		}
+	}
+
 	if status.BoostOfID != "" {
 		if status.BoostOf == nil {
 			// Status boost is not set, fetch from database.
@@ -254,6 +272,17 @@ func (s *statusDB) PopulateStatus(ctx context.Context, status *gtsmodel.Status)
 		}
 	}
 
+	if status.CardID != "" && status.Card == nil {
+		// Status card is not set, fetch from database.
+		status.Card, err = s.state.DB.GetCardByID(
+			ctx, // these are already barebones
+			status.CardID,
+		)
+		if err != nil {
+			errs.Appendf("error populating status card: %w", err)
+		}
+	}
+
 	if !status.AttachmentsPopulated() {
 		// Status attachments are out-of-date with IDs, repopulate.
 		status.Attachments, err = s.state.DB.GetAttachmentsByIDs(
@@ -313,6 +342,8 @@ func (s *statusDB) PopulateStatus(ctx context.Context, status *gtsmodel.Status)
 }
 
 func (s *statusDB) PutStatus(ctx context.Context, status *gtsmodel.Status) error {
+	normalizeStatusURIs(status)
+
 	return s.state.Caches.GTS.Status.Store(status, func() error {
 		// It is safe to run this database transaction within cache.Store
 		// as the cache does not attempt a mutex lock until AFTER hook.
@@ -397,6 +428,8 @@ func (s *statusDB) UpdateStatus(ctx context.Context, status *gtsmodel.Status, co
 		columns = append(columns, "updated_at")
 	}
 
+	normalizeStatusURIs(status)
+
 	return s.state.Caches.GTS.Status.Store(status, func() error {
 		// It is safe to run this database transaction within cache.Store
 		// as the cache does not attempt a mutex lock until AFTER hook.
@@ -686,3 +719,65 @@ func (s *statusDB) IsStatusBookmarkedBy(ctx context.Context, status *gtsmodel.St
 		Where("? = ?", bun.Ident("status_bookmark.account_id"), accountID)
 	return exists(ctx, q)
 }
+
+func (s *statusDB) GetTrendingStatuses(ctx context.Context, since time.Time, limit int) ([]*gtsmodel.Status, error) {
+	var statusIDs []string
+
+	// Count of faves + boosts of each status.
+	faves := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_faves"), bun.Ident("status_fave")).
+		ColumnExpr("COUNT(*)").
+		Where("? = ?", bun.Ident("status_fave.status_id"), bun.Ident("status.id"))
+	boosts := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("boost")).
+		ColumnExpr("COUNT(*)").
+		Where("? = ?", bun.Ident("boost.boost_of_id"), bun.Ident("status.id"))
+
+	if err := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		Column("status.id").
+		Join(
+			"JOIN ? AS ? ON ? = ?",
+			bun.Ident("accounts"), bun.Ident("account"),
+			bun.Ident("status.account_id"), bun.Ident("account.id"),
+		).
+		// Only local statuses, from accounts
+		// that have opted in to being discovered.
+		Where("? = ?", bun.Ident("status.local"), true).
+		Where("? = ?", bun.Ident("account.discoverable"), true).
+		Where("? IS NULL", bun.Ident("account.suspended_at")).
+		// Same as web statuses: no replies, boosts,
+		// or statuses that aren't public + federated.
+		Where("? IS NULL", bun.Ident("status.in_reply_to_uri")).
+		Where("? IS NULL", bun.Ident("status.boost_of_id")).
+		Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic).
+		Where("? = ?", bun.Ident("status.federated"), true).
+		Where("? >= ?", bun.Ident("status.created_at"), since).
+		Where("(?) + (?) > 0", faves, boosts).
+		OrderExpr("(?) + (?) DESC", faves, boosts).
+		Order("status.id DESC").
+		Limit(limit).
+		Scan(ctx, &statusIDs); err != nil {
+		return nil, err
+	}
+
+	if len(statusIDs) == 0 {
+		return nil, nil
+	}
+
+	return s.GetStatusesByIDs(ctx, statusIDs)
+}
+
+// normalizeStatusURIs normalizes the URIs on the
+// given status that are used to look up (or link
+// to) other statuses and accounts, so that these
+// match the normalized URIs stored in the database.
+func normalizeStatusURIs(status *gtsmodel.Status) {
+	status.URI = uris.Normalize(status.URI)
+	status.AccountURI = uris.Normalize(status.AccountURI)
+	status.InReplyToURI = uris.Normalize(status.InReplyToURI)
+	status.BoostOfURI = uris.Normalize(status.BoostOfURI)
+}
@@ -19,6 +19,7 @@ package bundb_test
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -109,6 +110,21 @@ func (suite *StatusTestSuite) TestGetStatusByURI() {
 	suite.False(*status.Likeable)
 }
 
+func (suite *StatusTestSuite) TestGetStatusByURINormalized() {
+	testStatus := suite.testStatuses["remote_account_1_status_1"]
+
+	// Host case and default port
+	// shouldn't matter for lookups.
+	uri := strings.Replace(testStatus.URI, "http://fossbros-anonymous.io/", "HTTP://Fossbros-Anonymous.IO:80/", 1)
+	suite.NotEqual(testStatus.URI, uri)
+
+	status, err := suite.db.GetStatusByURI(context.Background(), uri)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(testStatus.ID, status.ID)
+}
+
 func (suite *StatusTestSuite) TestGetStatusWithExtras() {
 	status, err := suite.db.GetStatusByID(context.Background(), suite.testStatuses["admin_account_status_1"].ID)
 	if err != nil {
@@ -277,6 +293,43 @@ func (suite *StatusTestSuite) TestPutPopulatedStatus() {
 	)
 }
 
+func (suite *StatusTestSuite) TestGetTrendingStatuses() {
+	ctx := context.Background()
+
+	statuses, err := suite.db.GetTrendingStatuses(ctx, time.Time{}, 20)
+	suite.NoError(err)
+	suite.NotEmpty(statuses)
+
+	lastScore := -1
+	for _, status := range statuses {
+		suite.True(status.IsLocal())
+		suite.Equal(gtsmodel.VisibilityPublic, status.Visibility)
+		suite.True(*status.Federated)
+		suite.Empty(status.InReplyToID)
+		suite.Empty(status.BoostOfID)
+		suite.True(*status.Account.Discoverable)
+
+		faves, err := suite.db.CountStatusFaves(ctx, status.ID)
+		suite.NoError(err)
+		boosts, err := suite.db.CountStatusBoosts(ctx, status.ID)
+		suite.NoError(err)
+
+		// Statuses should be ordered
+		// by engagement, highest first.
+		score := faves + boosts
+		suite.Positive(score)
+		if lastScore != -1 {
+			suite.LessOrEqual(score, lastScore)
+		}
+		lastScore = score
+	}
+
+	// Nothing can have been posted in the future.
+	statuses, err = suite.db.GetTrendingStatuses(ctx, time.Now().Add(time.Hour), 20)
+	suite.NoError(err)
+	suite.Empty(statuses)
+}
+
 func TestStatusTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusTestSuite))
 }
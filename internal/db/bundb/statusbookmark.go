@@ -21,9 +21,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/uptrace/bun"
 )
@@ -81,54 +83,92 @@ func (s *statusBookmarkDB) GetStatusBookmarkID(ctx context.Context, accountID st
 	return id, nil
 }
 
-func (s *statusBookmarkDB) GetStatusBookmarks(ctx context.Context, accountID string, limit int, maxID string, minID string) ([]*gtsmodel.StatusBookmark, error) {
-	// Ensure reasonable
-	if limit < 0 {
-		limit = 0
+func (s *statusBookmarkDB) GetStatusBookmarks(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.StatusBookmark, error) {
+	if accountID == "" {
+		return nil, errors.New("must provide an account")
 	}
 
-	// Guess size of IDs based on limit.
-	ids := make([]string, 0, limit)
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		bookmarks = make([]*gtsmodel.StatusBookmark, 0, limit)
+	)
 
 	q := s.db.
 		NewSelect().
-		TableExpr("? AS ?", bun.Ident("status_bookmarks"), bun.Ident("status_bookmark")).
-		Column("status_bookmark.id").
-		Where("? = ?", bun.Ident("status_bookmark.account_id"), accountID).
-		Order("status_bookmark.id DESC")
-
-	if accountID == "" {
-		return nil, errors.New("must provide an account")
-	}
+		Model(&bookmarks).
+		// Select just the IDs of each bookmark and bookmarked status.
+		Column("status_bookmark.id", "status_bookmark.status_id").
+		Where("? = ?", bun.Ident("status_bookmark.account_id"), accountID)
 
 	if maxID != "" {
+		// Return only bookmarks *OLDER* than given max ID.
 		q = q.Where("? < ?", bun.Ident("status_bookmark.id"), maxID)
 	}
 
 	if minID != "" {
+		// Return only bookmarks *NEWER* than given min ID.
 		q = q.Where("? > ?", bun.Ident("status_bookmark.id"), minID)
 	}
 
-	if limit != 0 {
+	if limit > 0 {
+		// Limit amount of bookmarks returned.
 		q = q.Limit(limit)
 	}
 
-	if err := q.Scan(ctx, &ids); err != nil {
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("status_bookmark.id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("status_bookmark.id"))
+	}
+
+	if err := q.Scan(ctx); err != nil {
 		return nil, err
 	}
 
-	bookmarks := make([]*gtsmodel.StatusBookmark, 0, len(ids))
+	if len(bookmarks) == 0 {
+		return nil, nil
+	}
 
-	for _, id := range ids {
-		bookmark, err := s.GetStatusBookmark(ctx, id)
-		if err != nil {
-			log.Errorf(ctx, "error getting bookmark %q: %v", id, err)
-			continue
-		}
+	// If we're paging up, we still want bookmarks
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(bookmarks)
+	}
 
-		bookmarks = append(bookmarks, bookmark)
+	// Load all the bookmarked statuses in one go.
+	statusIDs := make([]string, len(bookmarks))
+	for i, bookmark := range bookmarks {
+		statusIDs[i] = bookmark.StatusID
 	}
 
+	statuses, err := s.state.DB.GetStatusesByIDs(ctx, statusIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set each bookmark's status, dropping
+	// bookmarks of statuses we don't have.
+	bookmarks = slices.DeleteFunc(bookmarks, func(bookmark *gtsmodel.StatusBookmark) bool {
+		i := slices.IndexFunc(statuses, func(status *gtsmodel.Status) bool {
+			return status.ID == bookmark.StatusID
+		})
+		if i == -1 {
+			log.Debugf(ctx, "bookmarked status %s not found", bookmark.StatusID)
+			return true
+		}
+
+		bookmark.Status = statuses[i]
+		return false
+	})
+
 	return bookmarks, nil
 }
 
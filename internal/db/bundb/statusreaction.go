@@ -0,0 +1,378 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/uptrace/bun"
+)
+
+type statusReactionDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (s *statusReactionDB) GetStatusReaction(ctx context.Context, accountID string, statusID string, name string) (*gtsmodel.StatusReaction, error) {
+	return s.getStatusReaction(
+		ctx,
+		"AccountID,StatusID,Name",
+		func(reaction *gtsmodel.StatusReaction) error {
+			return s.db.
+				NewSelect().
+				Model(reaction).
+				Where("? = ?", bun.Ident("status_reaction.account_id"), accountID).
+				Where("? = ?", bun.Ident("status_reaction.status_id"), statusID).
+				Where("? = ?", bun.Ident("status_reaction.name"), name).
+				Scan(ctx)
+		},
+		accountID,
+		statusID,
+		name,
+	)
+}
+
+func (s *statusReactionDB) GetStatusReactionByID(ctx context.Context, id string) (*gtsmodel.StatusReaction, error) {
+	return s.getStatusReaction(
+		ctx,
+		"ID",
+		func(reaction *gtsmodel.StatusReaction) error {
+			return s.db.
+				NewSelect().
+				Model(reaction).
+				Where("? = ?", bun.Ident("status_reaction.id"), id).
+				Scan(ctx)
+		},
+		id,
+	)
+}
+
+func (s *statusReactionDB) GetStatusReactionByURI(ctx context.Context, uri string) (*gtsmodel.StatusReaction, error) {
+	return s.getStatusReaction(
+		ctx,
+		"URI",
+		func(reaction *gtsmodel.StatusReaction) error {
+			return s.db.
+				NewSelect().
+				Model(reaction).
+				Where("? = ?", bun.Ident("status_reaction.uri"), uri).
+				Scan(ctx)
+		},
+		uri,
+	)
+}
+
+func (s *statusReactionDB) getStatusReaction(ctx context.Context, lookup string, dbQuery func(*gtsmodel.StatusReaction) error, keyParts ...any) (*gtsmodel.StatusReaction, error) {
+	// Fetch status reaction from database cache with loader callback
+	reaction, err := s.state.Caches.GTS.StatusReaction.LoadOne(lookup, func() (*gtsmodel.StatusReaction, error) {
+		var reaction gtsmodel.StatusReaction
+
+		// Not cached! Perform database query.
+		if err := dbQuery(&reaction); err != nil {
+			return nil, err
+		}
+
+		return &reaction, nil
+	}, keyParts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return reaction, nil
+	}
+
+	// Populate the status reaction model.
+	if err := s.PopulateStatusReaction(ctx, reaction); err != nil {
+		return nil, fmt.Errorf("error(s) populating status reaction: %w", err)
+	}
+
+	return reaction, nil
+}
+
+func (s *statusReactionDB) GetStatusReactions(ctx context.Context, statusID string) ([]*gtsmodel.StatusReaction, error) {
+	// Fetch the status reaction IDs for status.
+	reactionIDs, err := s.getStatusReactionIDs(ctx, statusID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load all reaction IDs via cache loader callbacks.
+	reactions, err := s.state.Caches.GTS.StatusReaction.LoadIDs("ID",
+		reactionIDs,
+		func(uncached []string) ([]*gtsmodel.StatusReaction, error) {
+			// Preallocate expected length of uncached reactions.
+			reactions := make([]*gtsmodel.StatusReaction, 0, len(uncached))
+
+			// Perform database query scanning
+			// the remaining (uncached) reaction IDs.
+			if err := s.db.NewSelect().
+				Model(&reactions).
+				Where("? IN (?)", bun.Ident("id"), bun.In(uncached)).
+				Scan(ctx); err != nil {
+				return nil, err
+			}
+
+			return reactions, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reorder the reactions by their
+	// IDs to ensure in correct order.
+	getID := func(r *gtsmodel.StatusReaction) string { return r.ID }
+	util.OrderBy(reactions, reactionIDs, getID)
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return reactions, nil
+	}
+
+	// Populate all loaded reactions, removing those we fail to
+	// populate (removes needing so many nil checks everywhere).
+	reactions = slices.DeleteFunc(reactions, func(reaction *gtsmodel.StatusReaction) bool {
+		if err := s.PopulateStatusReaction(ctx, reaction); err != nil {
+			log.Errorf(ctx, "error populating reaction %s: %v", reaction.ID, err)
+			return true
+		}
+		return false
+	})
+
+	return reactions, nil
+}
+
+func (s *statusReactionDB) getStatusReactionIDs(ctx context.Context, statusID string) ([]string, error) {
+	return s.state.Caches.GTS.StatusReactionIDs.Load(statusID, func() ([]string, error) {
+		var reactionIDs []string
+
+		// Status reaction IDs not in cache, perform DB query!
+		if err := s.db.
+			NewSelect().
+			Table("status_reactions").
+			Column("id").
+			Where("? = ?", bun.Ident("status_id"), statusID).
+			Order("id ASC").
+			Scan(ctx, &reactionIDs); err != nil {
+			return nil, err
+		}
+
+		return reactionIDs, nil
+	})
+}
+
+func (s *statusReactionDB) PopulateStatusReaction(ctx context.Context, reaction *gtsmodel.StatusReaction) error {
+	var (
+		err  error
+		errs = gtserror.NewMultiError(4)
+	)
+
+	if reaction.Account == nil {
+		// StatusReaction author is not set, fetch from database.
+		reaction.Account, err = s.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			reaction.AccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating status reaction author: %w", err)
+		}
+	}
+
+	if reaction.TargetAccount == nil {
+		// StatusReaction target account is not set, fetch from database.
+		reaction.TargetAccount, err = s.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			reaction.TargetAccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating status reaction target account: %w", err)
+		}
+	}
+
+	if reaction.Status == nil {
+		// StatusReaction status is not set, fetch from database.
+		reaction.Status, err = s.state.DB.GetStatusByID(
+			gtscontext.SetBarebones(ctx),
+			reaction.StatusID,
+		)
+		if err != nil {
+			errs.Appendf("error populating status reaction status: %w", err)
+		}
+	}
+
+	if reaction.EmojiID != "" && reaction.Emoji == nil {
+		// StatusReaction custom emoji is not set, fetch from database.
+		reaction.Emoji, err = s.state.DB.GetEmojiByID(
+			gtscontext.SetBarebones(ctx),
+			reaction.EmojiID,
+		)
+		if err != nil {
+			errs.Appendf("error populating status reaction emoji: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (s *statusReactionDB) PutStatusReaction(ctx context.Context, reaction *gtsmodel.StatusReaction) error {
+	return s.state.Caches.GTS.StatusReaction.Store(reaction, func() error {
+		_, err := s.db.
+			NewInsert().
+			Model(reaction).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (s *statusReactionDB) UpdateStatusReaction(ctx context.Context, reaction *gtsmodel.StatusReaction, columns ...string) error {
+	reaction.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column, ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	return s.state.Caches.GTS.StatusReaction.Store(reaction, func() error {
+		_, err := s.db.NewUpdate().
+			Model(reaction).
+			Where("? = ?", bun.Ident("status_reaction.id"), reaction.ID).
+			Column(columns...).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (s *statusReactionDB) DeleteStatusReactionByID(ctx context.Context, id string) error {
+	var statusID string
+
+	// Perform DELETE on status reaction,
+	// returning the status ID it was for.
+	if _, err := s.db.NewDelete().
+		Table("status_reactions").
+		Where("? = ?", bun.Ident("id"), id).
+		Returning("status_id").
+		Exec(ctx, &statusID); err != nil {
+		if err == sql.ErrNoRows {
+			// Not an issue, only due
+			// to us doing a RETURNING.
+			err = nil
+		}
+		return err
+	}
+
+	// Invalidate any cached status reaction with this ID.
+	s.state.Caches.GTS.StatusReaction.Invalidate("ID", id)
+
+	if statusID != "" {
+		// Invalidate any cached status reaction IDs for this status.
+		s.state.Caches.GTS.StatusReactionIDs.Invalidate(statusID)
+	}
+
+	return nil
+}
+
+func (s *statusReactionDB) DeleteStatusReactions(ctx context.Context, targetAccountID string, originAccountID string) error {
+	if targetAccountID == "" && originAccountID == "" {
+		return errors.New("DeleteStatusReactions: one of targetAccountID or originAccountID must be set")
+	}
+
+	var deleted []*gtsmodel.StatusReaction
+
+	// Prepare DELETE query returning
+	// the deleted reactions' IDs + status IDs.
+	q := s.db.NewDelete().
+		Table("status_reactions").
+		Returning("?, ?", bun.Ident("id"), bun.Ident("status_id"))
+
+	if targetAccountID != "" {
+		q = q.Where("? = ?", bun.Ident("target_account_id"), targetAccountID)
+	}
+
+	if originAccountID != "" {
+		q = q.Where("? = ?", bun.Ident("account_id"), originAccountID)
+	}
+
+	// Execute query, store deleted reactions.
+	if _, err := q.Exec(ctx, &deleted); err != nil {
+		if err == sql.ErrNoRows {
+			// Not an issue, only due
+			// to us doing a RETURNING.
+			err = nil
+		}
+		return err
+	}
+
+	s.invalidateStatusReactions(deleted)
+	return nil
+}
+
+func (s *statusReactionDB) DeleteStatusReactionsForStatus(ctx context.Context, statusID string) error {
+	var deleted []*gtsmodel.StatusReaction
+
+	// Delete all status reactions for status,
+	// returning the deleted reactions' IDs.
+	if _, err := s.db.NewDelete().
+		Table("status_reactions").
+		Where("? = ?", bun.Ident("status_id"), statusID).
+		Returning("?, ?", bun.Ident("id"), bun.Ident("status_id")).
+		Exec(ctx, &deleted); err != nil {
+		if err == sql.ErrNoRows {
+			// Not an issue, only due
+			// to us doing a RETURNING.
+			err = nil
+		}
+		return err
+	}
+
+	s.invalidateStatusReactions(deleted)
+
+	// Invalidate any cached status reaction IDs for this status.
+	s.state.Caches.GTS.StatusReactionIDs.Invalidate(statusID)
+
+	return nil
+}
+
+// invalidateStatusReactions invalidates the given (deleted) status
+// reactions by ID, rather than by status ID, as invalidating the
+// latter "Multiple" index may leave some of its entries behind.
+func (s *statusReactionDB) invalidateStatusReactions(deleted []*gtsmodel.StatusReaction) {
+	ids := make([]string, 0, len(deleted))
+	statusIDs := make([]string, 0, len(deleted))
+	for _, reaction := range deleted {
+		ids = append(ids, reaction.ID)
+		statusIDs = append(statusIDs, reaction.StatusID)
+	}
+
+	// Invalidate any cached status reactions by ID.
+	s.state.Caches.GTS.StatusReaction.InvalidateIDs("ID", ids)
+
+	// Invalidate any cached status reaction IDs for these status IDs.
+	s.state.Caches.GTS.StatusReactionIDs.Invalidate(util.Deduplicate(statusIDs)...)
+}
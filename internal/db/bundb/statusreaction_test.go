@@ -0,0 +1,187 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+type StatusReactionTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *StatusReactionTestSuite) putReaction(account *gtsmodel.Account, status *gtsmodel.Status, name string, emoji *gtsmodel.Emoji) *gtsmodel.StatusReaction {
+	reactionID := id.NewULID()
+	reaction := &gtsmodel.StatusReaction{
+		ID:              reactionID,
+		AccountID:       account.ID,
+		TargetAccountID: status.AccountID,
+		StatusID:        status.ID,
+		Name:            name,
+		URI:             account.URI + "/liked/" + reactionID,
+	}
+
+	if emoji != nil {
+		reaction.EmojiID = emoji.ID
+	}
+
+	if err := suite.db.PutStatusReaction(context.Background(), reaction); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return reaction
+}
+
+func (suite *StatusReactionTestSuite) TestPutGetStatusReactions() {
+	var (
+		ctx        = context.Background()
+		testStatus = suite.testStatuses["admin_account_status_1"]
+		account1   = suite.testAccounts["local_account_1"]
+		account2   = suite.testAccounts["local_account_2"]
+		rainbow    = suite.testEmojis["rainbow"]
+	)
+
+	r1 := suite.putReaction(account1, testStatus, "👍", nil)
+	suite.putReaction(account2, testStatus, "rainbow", rainbow)
+	r3 := suite.putReaction(account1, testStatus, "rainbow", rainbow)
+
+	reactions, err := suite.db.GetStatusReactions(ctx, testStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Should be ordered by ID, and populated.
+	if !suite.Len(reactions, 3) {
+		suite.FailNow("")
+	}
+	suite.True(slices.IsSortedFunc(reactions, func(a, b *gtsmodel.StatusReaction) int {
+		return strings.Compare(a.ID, b.ID)
+	}))
+	for _, reaction := range reactions {
+		suite.NotNil(reaction.Account)
+		suite.NotNil(reaction.TargetAccount)
+		suite.NotNil(reaction.Status)
+		suite.Equal(reaction.EmojiID != "", reaction.Emoji != nil)
+	}
+
+	// Get by account + status + name.
+	reaction, err := suite.db.GetStatusReaction(ctx, account1.ID, testStatus.ID, "rainbow")
+	suite.NoError(err)
+	suite.Equal(r3.ID, reaction.ID)
+
+	// Get by URI.
+	reaction, err = suite.db.GetStatusReactionByURI(ctx, r1.URI)
+	suite.NoError(err)
+	suite.Equal(r1.ID, reaction.ID)
+
+	// The same reaction again isn't allowed.
+	err = suite.db.PutStatusReaction(ctx, &gtsmodel.StatusReaction{
+		ID:              id.NewULID(),
+		AccountID:       account1.ID,
+		TargetAccountID: testStatus.AccountID,
+		StatusID:        testStatus.ID,
+		Name:            "👍",
+		URI:             account1.URI + "/liked/duplicate",
+	})
+	suite.ErrorIs(err, db.ErrAlreadyExists)
+}
+
+func (suite *StatusReactionTestSuite) TestDeleteStatusReaction() {
+	var (
+		ctx        = context.Background()
+		testStatus = suite.testStatuses["admin_account_status_1"]
+		account1   = suite.testAccounts["local_account_1"]
+	)
+
+	r1 := suite.putReaction(account1, testStatus, "👍", nil)
+	r2 := suite.putReaction(account1, testStatus, "🎉", nil)
+
+	if err := suite.db.DeleteStatusReactionByID(ctx, r1.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err := suite.db.GetStatusReactionByID(ctx, r1.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	reactions, err := suite.db.GetStatusReactions(ctx, testStatus.ID)
+	suite.NoError(err)
+	if suite.Len(reactions, 1) {
+		suite.Equal(r2.ID, reactions[0].ID)
+	}
+}
+
+func (suite *StatusReactionTestSuite) TestDeleteStatusReactionsOriginatingFromAccount() {
+	var (
+		ctx        = context.Background()
+		testStatus = suite.testStatuses["admin_account_status_1"]
+		account1   = suite.testAccounts["local_account_1"]
+		account2   = suite.testAccounts["local_account_2"]
+	)
+
+	suite.putReaction(account1, testStatus, "👍", nil)
+	suite.putReaction(account2, testStatus, "👍", nil)
+
+	// Populate the cache.
+	if _, err := suite.db.GetStatusReactions(ctx, testStatus.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.db.DeleteStatusReactions(ctx, "", account1.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	reactions, err := suite.db.GetStatusReactions(ctx, testStatus.ID)
+	suite.NoError(err)
+	if suite.Len(reactions, 1) {
+		suite.Equal(account2.ID, reactions[0].AccountID)
+	}
+
+	_, err = suite.db.GetStatusReaction(ctx, account1.ID, testStatus.ID, "👍")
+	suite.True(errors.Is(err, db.ErrNoEntries))
+}
+
+func (suite *StatusReactionTestSuite) TestDeleteStatusReactionsForStatus() {
+	var (
+		ctx        = context.Background()
+		testStatus = suite.testStatuses["admin_account_status_1"]
+		account1   = suite.testAccounts["local_account_1"]
+	)
+
+	suite.putReaction(account1, testStatus, "👍", nil)
+
+	if err := suite.db.DeleteStatusReactionsForStatus(ctx, testStatus.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	reactions, err := suite.db.GetStatusReactions(ctx, testStatus.ID)
+	suite.NoError(err)
+	suite.Empty(reactions)
+}
+
+func TestStatusReactionTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusReactionTestSuite))
+}
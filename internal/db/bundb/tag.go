@@ -19,9 +19,18 @@ package bundb
 
 import (
 	"context"
+	"errors"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/uptrace/bun"
@@ -131,3 +140,491 @@ func (t *tagDB) PutTag(ctx context.Context, tag *gtsmodel.Tag) error {
 
 	return nil
 }
+
+func (t *tagDB) IncrementTagHistory(ctx context.Context, tagID string, status *gtsmodel.Status) error {
+	// Start of the (UTC) day
+	// the status was created.
+	day := status.CreatedAt.UTC().Truncate(24 * time.Hour)
+
+	// Check whether the status account already used this
+	// tag earlier in the day, in which case it's not new.
+	used, err := exists(ctx, t.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_to_tags"), bun.Ident("status_to_tag")).
+		Join(
+			"JOIN ? AS ? ON ? = ?",
+			bun.Ident("statuses"), bun.Ident("status"),
+			bun.Ident("status_to_tag.status_id"), bun.Ident("status.id"),
+		).
+		Where("? = ?", bun.Ident("status_to_tag.tag_id"), tagID).
+		Where("? = ?", bun.Ident("status.account_id"), status.AccountID).
+		Where("? >= ?", bun.Ident("status.created_at"), day).
+		Where("? < ?", bun.Ident("status.id"), status.ID),
+	)
+	if err != nil {
+		return err
+	}
+
+	var accounts int
+	if !used {
+		accounts = 1
+	}
+
+	history := &gtsmodel.TagHistory{
+		ID:       id.NewULID(),
+		TagID:    tagID,
+		Day:      day,
+		Uses:     1,
+		Accounts: accounts,
+	}
+
+	// Insert new history for the day,
+	// or add to the existing one.
+	_, err = t.db.
+		NewInsert().
+		Model(history).
+		On("CONFLICT (?, ?) DO UPDATE", bun.Ident("tag_id"), bun.Ident("day")).
+		Set("? = ? + 1", bun.Ident("uses"), bun.Ident("tag_history.uses")).
+		Set("? = ? + ?", bun.Ident("accounts"), bun.Ident("tag_history.accounts"), accounts).
+		Set("? = ?", bun.Ident("updated_at"), time.Now()).
+		Exec(ctx)
+	return err
+}
+
+func (t *tagDB) GetTagHistory(ctx context.Context, tagID string, since time.Time) ([]*gtsmodel.TagHistory, error) {
+	var history []*gtsmodel.TagHistory
+
+	if err := t.db.
+		NewSelect().
+		Model(&history).
+		Where("? = ?", bun.Ident("tag_history.tag_id"), tagID).
+		Where("? >= ?", bun.Ident("tag_history.day"), since).
+		Order("tag_history.day DESC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+func (t *tagDB) GetTrendingTags(ctx context.Context, since time.Time, limit int) ([]*gtsmodel.Tag, error) {
+	var tagIDs []string
+
+	if err := t.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("tag_histories"), bun.Ident("tag_history")).
+		Column("tag_history.tag_id").
+		Join(
+			"JOIN ? AS ? ON ? = ?",
+			bun.Ident("tags"), bun.Ident("tag"),
+			bun.Ident("tag_history.tag_id"), bun.Ident("tag.id"),
+		).
+		Where("? >= ?", bun.Ident("tag_history.day"), since).
+		Where("? = ?", bun.Ident("tag.useable"), true).
+		Where("? = ?", bun.Ident("tag.listable"), true).
+		Group("tag_history.tag_id").
+		OrderExpr("SUM(?) DESC", bun.Ident("tag_history.accounts")).
+		OrderExpr("SUM(?) DESC", bun.Ident("tag_history.uses")).
+		Limit(limit).
+		Scan(ctx, &tagIDs); err != nil {
+		return nil, err
+	}
+
+	if len(tagIDs) == 0 {
+		return nil, nil
+	}
+
+	return t.GetTags(ctx, tagIDs)
+}
+
+func (t *tagDB) GetFollowedTag(ctx context.Context, accountID string, tagID string) (*gtsmodel.FollowedTag, error) {
+	return t.getFollowedTag(
+		ctx,
+		"AccountID,TagID",
+		func(followedTag *gtsmodel.FollowedTag) error {
+			return t.db.NewSelect().
+				Model(followedTag).
+				Where("? = ?", bun.Ident("followed_tag.account_id"), accountID).
+				Where("? = ?", bun.Ident("followed_tag.tag_id"), tagID).
+				Scan(ctx)
+		},
+		accountID, tagID,
+	)
+}
+
+func (t *tagDB) getFollowedTagByID(ctx context.Context, id string) (*gtsmodel.FollowedTag, error) {
+	return t.getFollowedTag(
+		ctx,
+		"ID",
+		func(followedTag *gtsmodel.FollowedTag) error {
+			return t.db.NewSelect().
+				Model(followedTag).
+				Where("? = ?", bun.Ident("followed_tag.id"), id).
+				Scan(ctx)
+		},
+		id,
+	)
+}
+
+func (t *tagDB) getFollowedTag(ctx context.Context, lookup string, dbQuery func(*gtsmodel.FollowedTag) error, keyParts ...any) (*gtsmodel.FollowedTag, error) {
+	// Fetch followed tag from database cache with loader callback.
+	followedTag, err := t.state.Caches.GTS.FollowedTag.LoadOne(lookup, func() (*gtsmodel.FollowedTag, error) {
+		var followedTag gtsmodel.FollowedTag
+
+		// Not cached! perform database query.
+		if err := dbQuery(&followedTag); err != nil {
+			return nil, err
+		}
+
+		return &followedTag, nil
+	}, keyParts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return followedTag, nil
+	}
+
+	// Populate the followed tag itself.
+	followedTag.Tag, err = t.GetTag(ctx, followedTag.TagID)
+	if err != nil {
+		return nil, gtserror.Newf("error populating followed tag %s: %w", followedTag.TagID, err)
+	}
+
+	return followedTag, nil
+}
+
+func (t *tagDB) GetAccountFollowedTags(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.FollowedTag, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		followedTagIDs = make([]string, 0, limit)
+	)
+
+	q := t.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("followed_tags"), bun.Ident("followed_tag")).
+		// Select just the IDs of each followed tag.
+		Column("followed_tag.id").
+		Where("? = ?", bun.Ident("followed_tag.account_id"), accountID)
+
+	if maxID != "" {
+		// Return only followed tags *OLDER* than given max ID.
+		q = q.Where("? < ?", bun.Ident("followed_tag.id"), maxID)
+	}
+
+	if minID != "" {
+		// Return only followed tags *NEWER* than given min ID.
+		q = q.Where("? > ?", bun.Ident("followed_tag.id"), minID)
+	}
+
+	if limit > 0 {
+		// Limit amount of followed tags returned.
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("followed_tag.id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("followed_tag.id"))
+	}
+
+	if err := q.Scan(ctx, &followedTagIDs); err != nil {
+		return nil, err
+	}
+
+	if len(followedTagIDs) == 0 {
+		return nil, nil
+	}
+
+	// If we're paging up, we still want followed
+	// tags to be sorted by ID desc, so reverse.
+	if order == paging.OrderAscending {
+		slices.Reverse(followedTagIDs)
+	}
+
+	followedTags := make([]*gtsmodel.FollowedTag, 0, len(followedTagIDs))
+	for _, id := range followedTagIDs {
+		// Attempt to fetch followed tag from DB.
+		followedTag, err := t.getFollowedTagByID(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error getting followed tag %s: %v", id, err)
+			continue
+		}
+
+		// Append followed tag to return slice.
+		followedTags = append(followedTags, followedTag)
+	}
+
+	return followedTags, nil
+}
+
+func (t *tagDB) GetTagFollowerAccountIDs(ctx context.Context, tagIDs []string) ([]string, error) {
+	if len(tagIDs) == 0 {
+		return nil, nil
+	}
+
+	var accountIDs []string
+
+	if err := t.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("followed_tags"), bun.Ident("followed_tag")).
+		ColumnExpr("DISTINCT ?", bun.Ident("followed_tag.account_id")).
+		Where("? IN (?)", bun.Ident("followed_tag.tag_id"), bun.In(tagIDs)).
+		Scan(ctx, &accountIDs); err != nil {
+		return nil, err
+	}
+
+	return accountIDs, nil
+}
+
+func (t *tagDB) PutFollowedTag(ctx context.Context, followedTag *gtsmodel.FollowedTag) error {
+	return t.state.Caches.GTS.FollowedTag.Store(followedTag, func() error {
+		_, err := t.db.NewInsert().Model(followedTag).Exec(ctx)
+		return err
+	})
+}
+
+func (t *tagDB) DeleteFollowedTag(ctx context.Context, accountID string, tagID string) error {
+	defer t.state.Caches.GTS.FollowedTag.Invalidate("AccountID,TagID", accountID, tagID)
+
+	_, err := t.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("followed_tags"), bun.Ident("followed_tag")).
+		Where("? = ?", bun.Ident("followed_tag.account_id"), accountID).
+		Where("? = ?", bun.Ident("followed_tag.tag_id"), tagID).
+		Exec(ctx)
+	return err
+}
+
+func (t *tagDB) DeleteFollowedTagsByAccountID(ctx context.Context, accountID string) error {
+	defer t.state.Caches.GTS.FollowedTag.Invalidate("AccountID", accountID)
+
+	_, err := t.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("followed_tags"), bun.Ident("followed_tag")).
+		Where("? = ?", bun.Ident("followed_tag.account_id"), accountID).
+		Exec(ctx)
+	return err
+}
+
+func (t *tagDB) GetFeaturedTagByID(ctx context.Context, id string) (*gtsmodel.FeaturedTag, error) {
+	return t.getFeaturedTag(
+		ctx,
+		"ID",
+		func(featuredTag *gtsmodel.FeaturedTag) error {
+			return t.db.NewSelect().
+				Model(featuredTag).
+				Where("? = ?", bun.Ident("featured_tag.id"), id).
+				Scan(ctx)
+		},
+		id,
+	)
+}
+
+func (t *tagDB) GetFeaturedTag(ctx context.Context, accountID string, tagID string) (*gtsmodel.FeaturedTag, error) {
+	return t.getFeaturedTag(
+		ctx,
+		"AccountID,TagID",
+		func(featuredTag *gtsmodel.FeaturedTag) error {
+			return t.db.NewSelect().
+				Model(featuredTag).
+				Where("? = ?", bun.Ident("featured_tag.account_id"), accountID).
+				Where("? = ?", bun.Ident("featured_tag.tag_id"), tagID).
+				Scan(ctx)
+		},
+		accountID, tagID,
+	)
+}
+
+func (t *tagDB) getFeaturedTag(ctx context.Context, lookup string, dbQuery func(*gtsmodel.FeaturedTag) error, keyParts ...any) (*gtsmodel.FeaturedTag, error) {
+	// Fetch featured tag from database cache with loader callback.
+	featuredTag, err := t.state.Caches.GTS.FeaturedTag.LoadOne(lookup, func() (*gtsmodel.FeaturedTag, error) {
+		var featuredTag gtsmodel.FeaturedTag
+
+		// Not cached! perform database query.
+		if err := dbQuery(&featuredTag); err != nil {
+			return nil, err
+		}
+
+		return &featuredTag, nil
+	}, keyParts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return featuredTag, nil
+	}
+
+	// Populate the featured tag itself.
+	featuredTag.Tag, err = t.GetTag(ctx, featuredTag.TagID)
+	if err != nil {
+		return nil, gtserror.Newf("error populating featured tag %s: %w", featuredTag.TagID, err)
+	}
+
+	return featuredTag, nil
+}
+
+func (t *tagDB) GetAccountFeaturedTags(ctx context.Context, accountID string) ([]*gtsmodel.FeaturedTag, error) {
+	var featuredTagIDs []string
+
+	if err := t.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("featured_tags"), bun.Ident("featured_tag")).
+		// Select just the IDs of each featured tag.
+		Column("featured_tag.id").
+		Where("? = ?", bun.Ident("featured_tag.account_id"), accountID).
+		OrderExpr("? ASC", bun.Ident("featured_tag.id")).
+		Scan(ctx, &featuredTagIDs); err != nil {
+		return nil, err
+	}
+
+	if len(featuredTagIDs) == 0 {
+		return nil, nil
+	}
+
+	featuredTags := make([]*gtsmodel.FeaturedTag, 0, len(featuredTagIDs))
+	for _, id := range featuredTagIDs {
+		// Attempt to fetch featured tag from DB.
+		featuredTag, err := t.GetFeaturedTagByID(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error getting featured tag %s: %v", id, err)
+			continue
+		}
+
+		// Append featured tag to return slice.
+		featuredTags = append(featuredTags, featuredTag)
+	}
+
+	return featuredTags, nil
+}
+
+// accountTaggedStatusesQ returns a query selecting from the status to tag
+// mappings of the public and unlisted, non-boost statuses of the given account.
+func (t *tagDB) accountTaggedStatusesQ(accountID string) *bun.SelectQuery {
+	return t.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_to_tags"), bun.Ident("status_to_tag")).
+		Join(
+			"JOIN ? AS ? ON ? = ?",
+			bun.Ident("statuses"), bun.Ident("status"),
+			bun.Ident("status_to_tag.status_id"), bun.Ident("status.id"),
+		).
+		Where("? = ?", bun.Ident("status.account_id"), accountID).
+		Where("? IN (?)", bun.Ident("status.visibility"), bun.In([]gtsmodel.Visibility{
+			gtsmodel.VisibilityPublic,
+			gtsmodel.VisibilityUnlocked,
+		})).
+		Where("? IS NULL", bun.Ident("status.boost_of_id"))
+}
+
+func (t *tagDB) GetAccountTagStats(ctx context.Context, accountID string, tagID string) (int, time.Time, error) {
+	var statuses []struct {
+		CreatedAt time.Time `bun:"created_at"`
+	}
+
+	// Count all the statuses, and select
+	// the creation time of the latest one.
+	count, err := t.accountTaggedStatusesQ(accountID).
+		Column("status.created_at").
+		Where("? = ?", bun.Ident("status_to_tag.tag_id"), tagID).
+		OrderExpr("? DESC", bun.Ident("status.id")).
+		Limit(1).
+		ScanAndCount(ctx, &statuses)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	if len(statuses) == 0 {
+		return 0, time.Time{}, nil
+	}
+
+	return count, statuses[0].CreatedAt, nil
+}
+
+func (t *tagDB) GetAccountMostUsedTags(ctx context.Context, accountID string, limit int) ([]*gtsmodel.Tag, error) {
+	var tagIDs []string
+
+	// Select the tags already featured by the account.
+	featuredQ := t.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("featured_tags"), bun.Ident("featured_tag")).
+		Column("featured_tag.tag_id").
+		Where("? = ?", bun.Ident("featured_tag.account_id"), accountID)
+
+	if err := t.accountTaggedStatusesQ(accountID).
+		Column("status_to_tag.tag_id").
+		Join(
+			"JOIN ? AS ? ON ? = ?",
+			bun.Ident("tags"), bun.Ident("tag"),
+			bun.Ident("status_to_tag.tag_id"), bun.Ident("tag.id"),
+		).
+		Where("? = ?", bun.Ident("tag.useable"), true).
+		Where("? NOT IN (?)", bun.Ident("status_to_tag.tag_id"), featuredQ).
+		Group("status_to_tag.tag_id").
+		OrderExpr("COUNT(*) DESC").
+		OrderExpr("? DESC", bun.Ident("status_to_tag.tag_id")).
+		Limit(limit).
+		Scan(ctx, &tagIDs); err != nil {
+		return nil, err
+	}
+
+	if len(tagIDs) == 0 {
+		return nil, nil
+	}
+
+	return t.GetTags(ctx, tagIDs)
+}
+
+func (t *tagDB) PutFeaturedTag(ctx context.Context, featuredTag *gtsmodel.FeaturedTag) error {
+	return t.state.Caches.GTS.FeaturedTag.Store(featuredTag, func() error {
+		_, err := t.db.NewInsert().Model(featuredTag).Exec(ctx)
+		return err
+	})
+}
+
+func (t *tagDB) DeleteFeaturedTagByID(ctx context.Context, id string) error {
+	// Load featured tag into cache before attempting a delete,
+	// as we need it cached in order to trigger the invalidate
+	// callback. This in turn invalidates others.
+	featuredTag, err := t.GetFeaturedTagByID(gtscontext.SetBarebones(ctx), id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// not an issue.
+			err = nil
+		}
+		return err
+	}
+
+	// Drop this now-cached featured tag on return after delete.
+	defer t.state.Caches.GTS.FeaturedTag.Invalidate("ID", featuredTag.ID)
+
+	_, err = t.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("featured_tags"), bun.Ident("featured_tag")).
+		Where("? = ?", bun.Ident("featured_tag.id"), featuredTag.ID).
+		Exec(ctx)
+	return err
+}
+
+func (t *tagDB) DeleteFeaturedTagsByAccountID(ctx context.Context, accountID string) error {
+	defer t.state.Caches.GTS.FeaturedTag.Invalidate("AccountID", accountID)
+
+	_, err := t.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("featured_tags"), bun.Ident("featured_tag")).
+		Where("? = ?", bun.Ident("featured_tag.account_id"), accountID).
+		Exec(ctx)
+	return err
+}
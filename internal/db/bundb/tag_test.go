@@ -20,11 +20,13 @@ package bundb_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
 type TagTestSuite struct {
@@ -86,6 +88,228 @@ func (suite *TagTestSuite) TestPutTag() {
 	}
 }
 
+func (suite *TagTestSuite) TestTagHistory() {
+	var (
+		ctx     = context.Background()
+		welcome = suite.testTags["welcome"]
+		hashtag = suite.testTags["Hashtag"]
+		today   = time.Now().UTC().Truncate(24 * time.Hour)
+	)
+
+	// newStatus puts a new status using
+	// the given tags, from the given account.
+	newStatus := func(accountID string, tagIDs ...string) *gtsmodel.Status {
+		status := new(gtsmodel.Status)
+		*status = *suite.testStatuses["local_account_1_status_1"]
+		status.ID = id.NewULID()
+		status.URI = "http://localhost:8080/users/the_mighty_zork/statuses/" + status.ID
+		status.URL = ""
+		status.CreatedAt = time.Now()
+		status.AccountID = accountID
+		status.TagIDs = tagIDs
+		status.Tags = nil
+
+		if err := suite.db.PutStatus(ctx, status); err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		return status
+	}
+
+	var (
+		zorkID  = suite.testAccounts["local_account_1"].ID
+		adminID = suite.testAccounts["admin_account"].ID
+	)
+
+	for _, status := range []*gtsmodel.Status{
+		// Two uses by one account, then one by another.
+		newStatus(zorkID, welcome.ID, hashtag.ID),
+		newStatus(zorkID, welcome.ID),
+		newStatus(adminID, welcome.ID),
+	} {
+		for _, tagID := range status.TagIDs {
+			if err := suite.db.IncrementTagHistory(ctx, tagID, status); err != nil {
+				suite.FailNow(err.Error())
+			}
+		}
+	}
+
+	history, err := suite.db.GetTagHistory(ctx, welcome.ID, today)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(history, 1)
+	suite.True(history[0].Day.Equal(today))
+	suite.Equal(3, history[0].Uses)
+	suite.Equal(2, history[0].Accounts)
+
+	history, err = suite.db.GetTagHistory(ctx, hashtag.ID, today)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(history, 1)
+	suite.Equal(1, history[0].Uses)
+	suite.Equal(1, history[0].Accounts)
+
+	// Most used tag should come first.
+	trending, err := suite.db.GetTrendingTags(ctx, today, 10)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(trending, 2)
+	suite.Equal(welcome.ID, trending[0].ID)
+	suite.Equal(hashtag.ID, trending[1].ID)
+
+	// Nothing trends tomorrow.
+	trending, err = suite.db.GetTrendingTags(ctx, today.AddDate(0, 0, 1), 10)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(trending)
+}
+
+func (suite *TagTestSuite) TestFollowedTags() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_1"]
+		welcome = suite.testTags["welcome"]
+		hashtag = suite.testTags["Hashtag"]
+	)
+
+	// Follow both tags, #welcome a minute earlier.
+	for i, tag := range []*gtsmodel.Tag{welcome, hashtag} {
+		followedTagID, err := id.NewULIDFromTime(time.Now().Add(time.Duration(i-1) * time.Minute))
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		if err := suite.db.PutFollowedTag(ctx, &gtsmodel.FollowedTag{
+			ID:        followedTagID,
+			AccountID: account.ID,
+			TagID:     tag.ID,
+		}); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// Get one follow, it should have its tag populated.
+	followedTag, err := suite.db.GetFollowedTag(ctx, account.ID, welcome.ID)
+	suite.NoError(err)
+	suite.Equal(welcome.ID, followedTag.Tag.ID)
+
+	// Get all followed tags, newest first.
+	followedTags, err := suite.db.GetAccountFollowedTags(ctx, account.ID, &paging.Page{Limit: 10})
+	suite.NoError(err)
+	if suite.Len(followedTags, 2) {
+		suite.Equal(hashtag.ID, followedTags[0].TagID)
+		suite.Equal(welcome.ID, followedTags[1].TagID)
+	}
+
+	// Account should be listed once as a
+	// follower, even when following both tags.
+	accountIDs, err := suite.db.GetTagFollowerAccountIDs(ctx, []string{welcome.ID, hashtag.ID})
+	suite.NoError(err)
+	suite.Equal([]string{account.ID}, accountIDs)
+
+	// Unfollow #welcome.
+	err = suite.db.DeleteFollowedTag(ctx, account.ID, welcome.ID)
+	suite.NoError(err)
+
+	_, err = suite.db.GetFollowedTag(ctx, account.ID, welcome.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	accountIDs, err = suite.db.GetTagFollowerAccountIDs(ctx, []string{welcome.ID})
+	suite.NoError(err)
+	suite.Empty(accountIDs)
+
+	// Delete all remaining follows.
+	err = suite.db.DeleteFollowedTagsByAccountID(ctx, account.ID)
+	suite.NoError(err)
+
+	_, err = suite.db.GetFollowedTag(ctx, account.ID, hashtag.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *TagTestSuite) TestFeaturedTags() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["admin_account"]
+		welcome = suite.testTags["welcome"]
+		hashtag = suite.testTags["Hashtag"]
+	)
+
+	// Admin used #welcome once, in a public status.
+	count, lastStatusAt, err := suite.db.GetAccountTagStats(ctx, account.ID, welcome.ID)
+	suite.NoError(err)
+	suite.Equal(1, count)
+	suite.Equal("2021-10-20T11:36:45Z", lastStatusAt.UTC().Format(time.RFC3339))
+
+	// Admin never used #hashtag.
+	count, lastStatusAt, err = suite.db.GetAccountTagStats(ctx, account.ID, hashtag.ID)
+	suite.NoError(err)
+	suite.Zero(count)
+	suite.True(lastStatusAt.IsZero())
+
+	// #welcome should be suggested.
+	suggested, err := suite.db.GetAccountMostUsedTags(ctx, account.ID, 10)
+	suite.NoError(err)
+	if suite.Len(suggested, 1) {
+		suite.Equal(welcome.ID, suggested[0].ID)
+	}
+
+	// Feature both tags, #welcome first. IDs created within
+	// the same millisecond aren't strictly ordered, so space
+	// them out to make sure ordering by ID is deterministic.
+	now := time.Now()
+	for i, tag := range []*gtsmodel.Tag{welcome, hashtag} {
+		featuredTagID, err := id.NewULIDFromTime(now.Add(time.Duration(i) * time.Second))
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		if err := suite.db.PutFeaturedTag(ctx, &gtsmodel.FeaturedTag{
+			ID:        featuredTagID,
+			AccountID: account.ID,
+			TagID:     tag.ID,
+		}); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// Get one feature, it should have its tag populated.
+	featuredTag, err := suite.db.GetFeaturedTag(ctx, account.ID, welcome.ID)
+	suite.NoError(err)
+	suite.Equal(welcome.ID, featuredTag.Tag.ID)
+
+	// Get all featured tags, oldest first.
+	featuredTags, err := suite.db.GetAccountFeaturedTags(ctx, account.ID)
+	suite.NoError(err)
+	if suite.Len(featuredTags, 2) {
+		suite.Equal(welcome.ID, featuredTags[0].TagID)
+		suite.Equal(hashtag.ID, featuredTags[1].TagID)
+	}
+
+	// Featured #welcome shouldn't be suggested anymore.
+	suggested, err = suite.db.GetAccountMostUsedTags(ctx, account.ID, 10)
+	suite.NoError(err)
+	suite.Empty(suggested)
+
+	// Unfeature #welcome.
+	err = suite.db.DeleteFeaturedTagByID(ctx, featuredTag.ID)
+	suite.NoError(err)
+
+	_, err = suite.db.GetFeaturedTag(ctx, account.ID, welcome.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Delete all remaining features.
+	err = suite.db.DeleteFeaturedTagsByAccountID(ctx, account.ID)
+	suite.NoError(err)
+
+	featuredTags, err = suite.db.GetAccountFeaturedTags(ctx, account.ID)
+	suite.NoError(err)
+	suite.Empty(featuredTags)
+}
+
 func TestTagTestSuite(t *testing.T) {
 	suite.Run(t, new(TagTestSuite))
 }
@@ -0,0 +1,71 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type termsVersionDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (t *termsVersionDB) GetTermsVersionByID(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.TermsVersion, error) {
+	version := new(gtsmodel.TermsVersion)
+	if err := t.db.
+		NewSelect().
+		Model(version).
+		Where("? = ?", bun.Ident("terms_version.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+func (t *termsVersionDB) GetTermsVersions(
+	ctx context.Context,
+) ([]*gtsmodel.TermsVersion, error) {
+	versions := []*gtsmodel.TermsVersion{}
+	if err := t.db.
+		NewSelect().
+		Model(&versions).
+		Order("terms_version.created_at DESC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+func (t *termsVersionDB) PutTermsVersion(
+	ctx context.Context,
+	version *gtsmodel.TermsVersion,
+) error {
+	_, err := t.db.
+		NewInsert().
+		Model(version).
+		Exec(ctx)
+	return err
+}
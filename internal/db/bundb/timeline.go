@@ -29,6 +29,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/uptrace/bun"
 )
@@ -105,30 +106,11 @@ func (t *timelineDB) GetHomeTimeline(ctx context.Context, accountID string, maxI
 	// As this is the home timeline, it should be
 	// populated by statuses from accounts followed
 	// by accountID, and posts from accountID itself.
-	//
-	// So, begin by seeing who accountID follows.
-	// It should be a little cheaper to do this in
-	// a separate query like this, rather than using
-	// a join, since followIDs are cached in memory.
-	follows, err := t.state.DB.GetAccountFollows(
-		gtscontext.SetBarebones(ctx),
-		accountID,
-		nil, // select all
-	)
-	if err != nil && !errors.Is(err, db.ErrNoEntries) {
-		return nil, gtserror.Newf("db error getting follows for account %s: %w", accountID, err)
-	}
-
-	// Extract just the accountID from each follow.
-	targetAccountIDs := make([]string, len(follows)+1)
-	for i, f := range follows {
-		targetAccountIDs[i] = f.TargetAccountID
+	targetAccountIDs, noBoostAccountIDs, err := t.homeTimelineAccountIDs(ctx, accountID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Add accountID itself as a pseudo follow so that
-	// accountID can see its own posts in the timeline.
-	targetAccountIDs[len(targetAccountIDs)-1] = accountID
-
 	// Select only statuses authored by
 	// accounts with IDs in the slice.
 	q = q.Where(
@@ -137,6 +119,12 @@ func (t *timelineDB) GetHomeTimeline(ctx context.Context, accountID string, maxI
 		bun.In(targetAccountIDs),
 	)
 
+	if len(noBoostAccountIDs) > 0 {
+		// Exclude boosts by accounts
+		// followed with reblogs off.
+		q = whereNotBoostedBy(q, noBoostAccountIDs)
+	}
+
 	if err := q.Scan(ctx, &statusIDs); err != nil {
 		return nil, err
 	}
@@ -158,6 +146,98 @@ func (t *timelineDB) GetHomeTimeline(ctx context.Context, accountID string, maxI
 	return t.state.DB.GetStatusesByIDs(ctx, statusIDs)
 }
 
+func (t *timelineDB) CountHomeTimeline(ctx context.Context, accountID string, sinceID string, limit int) (int, error) {
+	targetAccountIDs, noBoostAccountIDs, err := t.homeTimelineAccountIDs(ctx, accountID)
+	if err != nil {
+		return 0, err
+	}
+
+	// Select IDs of statuses in the home timeline
+	// newer than sinceID, up to the given limit,
+	// so the count is bounded however far behind
+	// the account is. As for the timeline itself,
+	// statuses more than 24hr in the future don't count.
+	maxID, err := id.NewULIDFromTime(time.Now().Add(24 * time.Hour))
+	if err != nil {
+		return 0, err
+	}
+
+	subQuery := t.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		Column("status.id").
+		Where("? < ?", bun.Ident("status.id"), maxID).
+		Where("? IN (?)", bun.Ident("status.account_id"), bun.In(targetAccountIDs))
+
+	if sinceID != "" {
+		subQuery = subQuery.Where("? > ?", bun.Ident("status.id"), sinceID)
+	}
+
+	if len(noBoostAccountIDs) > 0 {
+		subQuery = whereNotBoostedBy(subQuery, noBoostAccountIDs)
+	}
+
+	if limit > 0 {
+		subQuery = subQuery.Limit(limit)
+	}
+
+	var count int
+	if err := t.db.
+		NewSelect().
+		ColumnExpr("COUNT(*)").
+		TableExpr("(?) AS ?", subQuery, bun.Ident("subquery")).
+		Scan(ctx, &count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// homeTimelineAccountIDs returns the IDs of accounts whose statuses
+// belong in the home timeline of the given account: those it follows,
+// and the account itself. It also returns the IDs of those followed
+// accounts whose boosts should not be shown, per the follow settings.
+func (t *timelineDB) homeTimelineAccountIDs(ctx context.Context, accountID string) ([]string, []string, error) {
+	// It should be a little cheaper to do this in
+	// a separate query like this, rather than using
+	// a join, since followIDs are cached in memory.
+	follows, err := t.state.DB.GetAccountFollows(
+		gtscontext.SetBarebones(ctx),
+		accountID,
+		nil, // select all
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, nil, gtserror.Newf("db error getting follows for account %s: %w", accountID, err)
+	}
+
+	// Extract just the accountID from each follow,
+	// noting those followed with reblogs turned off.
+	targetAccountIDs := make([]string, len(follows)+1)
+	var noBoostAccountIDs []string
+	for i, f := range follows {
+		targetAccountIDs[i] = f.TargetAccountID
+		if f.ShowReblogs != nil && !*f.ShowReblogs {
+			noBoostAccountIDs = append(noBoostAccountIDs, f.TargetAccountID)
+		}
+	}
+
+	// Add accountID itself as a pseudo follow so that
+	// accountID can see its own posts in the timeline.
+	targetAccountIDs[len(targetAccountIDs)-1] = accountID
+
+	return targetAccountIDs, noBoostAccountIDs, nil
+}
+
+// whereNotBoostedBy adds a clause to the given status select
+// query excluding boosts authored by any of the given accounts.
+func whereNotBoostedBy(q *bun.SelectQuery, accountIDs []string) *bun.SelectQuery {
+	return q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.
+			Where("? IS NULL", bun.Ident("status.boost_of_id")).
+			WhereOr("? NOT IN (?)", bun.Ident("status.account_id"), bun.In(accountIDs))
+	})
+}
+
 func (t *timelineDB) GetPublicTimeline(ctx context.Context, maxID string, sinceID string, minID string, limit int, local bool) ([]*gtsmodel.Status, error) {
 	// Ensure reasonable
 	if limit < 0 {
@@ -247,37 +327,49 @@ func (t *timelineDB) GetPublicTimeline(ctx context.Context, maxID string, sinceI
 	return t.state.DB.GetStatusesByIDs(ctx, statusIDs)
 }
 
-// TODO optimize this query and the logic here, because it's slow as balls -- it takes like a literal second to return with a limit of 20!
-// It might be worth serving it through a timeline instead of raw DB queries, like we do for Home feeds.
-func (t *timelineDB) GetFavedTimeline(ctx context.Context, accountID string, maxID string, minID string, limit int) ([]*gtsmodel.Status, string, string, error) {
-	// Ensure reasonable
-	if limit < 0 {
-		limit = 0
-	}
-
-	// Make educated guess for slice size
-	faves := make([]*gtsmodel.StatusFave, 0, limit)
+func (t *timelineDB) GetFavedTimeline(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.Status, string, string, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		faves = make([]*gtsmodel.StatusFave, 0, limit)
+	)
 
-	fq := t.db.
+	q := t.db.
 		NewSelect().
 		Model(&faves).
-		Where("? = ?", bun.Ident("status_fave.account_id"), accountID).
-		Order("status_fave.id DESC")
+		// Select just the IDs of each fave and faved status.
+		Column("status_fave.id", "status_fave.status_id").
+		Where("? = ?", bun.Ident("status_fave.account_id"), accountID)
 
 	if maxID != "" {
-		fq = fq.Where("? < ?", bun.Ident("status_fave.id"), maxID)
+		// Return only faves *OLDER* than given max ID.
+		q = q.Where("? < ?", bun.Ident("status_fave.id"), maxID)
 	}
 
 	if minID != "" {
-		fq = fq.Where("? > ?", bun.Ident("status_fave.id"), minID)
+		// Return only faves *NEWER* than given min ID.
+		q = q.Where("? > ?", bun.Ident("status_fave.id"), minID)
 	}
 
 	if limit > 0 {
-		fq = fq.Limit(limit)
+		// Limit amount of faves returned.
+		q = q.Limit(limit)
 	}
 
-	err := fq.Scan(ctx)
-	if err != nil {
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("status_fave.id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("status_fave.id"))
+	}
+
+	if err := q.Scan(ctx); err != nil {
 		return nil, "", "", err
 	}
 
@@ -285,18 +377,11 @@ func (t *timelineDB) GetFavedTimeline(ctx context.Context, accountID string, max
 		return nil, "", "", db.ErrNoEntries
 	}
 
-	// Sort by favourite ID rather than status ID
-	slices.SortFunc(faves, func(a, b *gtsmodel.StatusFave) int {
-		const k = -1
-		switch {
-		case a.ID > b.ID:
-			return +k
-		case a.ID < b.ID:
-			return -k
-		default:
-			return 0
-		}
-	})
+	// If we're paging up, we still want faves
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(faves)
+	}
 
 	// Convert fave IDs to status IDs.
 	statusIDs := make([]string, len(faves))
@@ -309,9 +394,9 @@ func (t *timelineDB) GetFavedTimeline(ctx context.Context, accountID string, max
 		return nil, "", "", err
 	}
 
-	nextMaxID := faves[len(faves)-1].ID
-	prevMinID := faves[0].ID
-	return statuses, nextMaxID, prevMinID, nil
+	lo := faves[len(faves)-1].ID
+	hi := faves[0].ID
+	return statuses, lo, hi, nil
 }
 
 func (t *timelineDB) GetListTimeline(
@@ -431,11 +516,14 @@ func (t *timelineDB) GetListTimeline(
 
 func (t *timelineDB) GetTagTimeline(
 	ctx context.Context,
-	tagID string,
+	anyTagIDs []string,
+	allTagIDs []string,
+	noneTagIDs []string,
 	maxID string,
 	sinceID string,
 	minID string,
 	limit int,
+	local bool,
 ) ([]*gtsmodel.Status, error) {
 	// Ensure reasonable
 	if limit < 0 {
@@ -451,6 +539,9 @@ func (t *timelineDB) GetTagTimeline(
 	q := t.db.
 		NewSelect().
 		TableExpr("? AS ?", bun.Ident("status_to_tags"), bun.Ident("status_to_tag")).
+		// Select distinct, since a status
+		// may use more than one of anyTagIDs.
+		Distinct().
 		Column("status_to_tag.status_id").
 		// Join with statuses for filtering.
 		Join(
@@ -460,8 +551,35 @@ func (t *timelineDB) GetTagTimeline(
 		).
 		// Public only.
 		Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic).
-		// This tag only.
-		Where("? = ?", bun.Ident("status_to_tag.tag_id"), tagID)
+		// Any of these tags.
+		Where("? IN (?)", bun.Ident("status_to_tag.tag_id"), bun.In(anyTagIDs))
+
+	for _, tagID := range allTagIDs {
+		// Status must use each of these tags.
+		q = q.Where("EXISTS (?)", t.db.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("status_to_tags"), bun.Ident("all_tag")).
+			Column("all_tag.status_id").
+			Where("? = ?", bun.Ident("all_tag.status_id"), bun.Ident("status_to_tag.status_id")).
+			Where("? = ?", bun.Ident("all_tag.tag_id"), tagID),
+		)
+	}
+
+	if len(noneTagIDs) != 0 {
+		// Status must use none of these tags.
+		q = q.Where("NOT EXISTS (?)", t.db.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("status_to_tags"), bun.Ident("none_tag")).
+			Column("none_tag.status_id").
+			Where("? = ?", bun.Ident("none_tag.status_id"), bun.Ident("status_to_tag.status_id")).
+			Where("? IN (?)", bun.Ident("none_tag.tag_id"), bun.In(noneTagIDs)),
+		)
+	}
+
+	if local {
+		// return only statuses posted by local account havers
+		q = q.Where("? = ?", bun.Ident("status.local"), local)
+	}
 
 	if maxID == "" || maxID >= id.Highest {
 		const future = 24 * time.Hour
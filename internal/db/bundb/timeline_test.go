@@ -246,6 +246,74 @@ func (suite *TimelineTestSuite) TestGetHomeTimelineFromHighest() {
 	suite.Equal("01G20ZM733MGN8J344T4ZDDFY1", s[len(s)-1].ID)
 }
 
+func (suite *TimelineTestSuite) TestGetHomeTimelineNoReblogs() {
+	var (
+		ctx            = context.Background()
+		viewingAccount = suite.testAccounts["local_account_1"]
+		boost          = suite.testStatuses["admin_account_status_4"]
+		follow         = suite.testFollows["local_account_1_admin_account"]
+	)
+
+	// Turn off boosts from admin,
+	// who boosted the status above.
+	follow.ShowReblogs = util.Ptr(false)
+	if err := suite.db.UpdateFollow(ctx, follow, "show_reblogs"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	s, err := suite.db.GetHomeTimeline(ctx, viewingAccount.ID, "", "", "", 20, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// The boost should be missing, while
+	// admin's other statuses still show.
+	suite.checkStatuses(s, id.Highest, id.Lowest, 18)
+	for _, status := range s {
+		suite.NotEqual(boost.ID, status.ID)
+	}
+
+	// Counts should also skip the boost.
+	count, err := suite.db.CountHomeTimeline(ctx, viewingAccount.ID, "", 0)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(18, count)
+}
+
+func (suite *TimelineTestSuite) TestCountHomeTimeline() {
+	var (
+		ctx            = context.Background()
+		viewingAccount = suite.testAccounts["local_account_1"]
+	)
+
+	s, err := suite.db.GetHomeTimeline(ctx, viewingAccount.ID, "", "", "", 100, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Without since ID or limit, all statuses count.
+	count, err := suite.db.CountHomeTimeline(ctx, viewingAccount.ID, "", 0)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(len(s), count)
+
+	// Only statuses newer than since ID count.
+	count, err = suite.db.CountHomeTimeline(ctx, viewingAccount.ID, s[4].ID, 0)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(4, count)
+
+	// Counting stops at the limit.
+	count, err = suite.db.CountHomeTimeline(ctx, viewingAccount.ID, "", 5)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(5, count)
+}
+
 func (suite *TimelineTestSuite) TestGetListTimelineNoParams() {
 	var (
 		ctx  = context.Background()
@@ -314,7 +382,7 @@ func (suite *TimelineTestSuite) TestGetTagTimelineNoParams() {
 		tag = suite.testTags["welcome"]
 	)
 
-	s, err := suite.db.GetTagTimeline(ctx, tag.ID, "", "", "", 1)
+	s, err := suite.db.GetTagTimeline(ctx, []string{tag.ID}, nil, nil, "", "", "", 1, false)
 	if err != nil {
 		suite.FailNow(err.Error())
 	}
@@ -323,6 +391,51 @@ func (suite *TimelineTestSuite) TestGetTagTimelineNoParams() {
 	suite.Equal("01F8MH75CBF9JFX4ZAD54N0W0R", s[0].ID)
 }
 
+func (suite *TimelineTestSuite) TestGetTagTimelineAnyAllNone() {
+	var (
+		ctx     = context.Background()
+		welcome = suite.testTags["welcome"]
+		hashtag = suite.testTags["Hashtag"]
+	)
+
+	// Status using either tag should
+	// be returned, only once.
+	s, err := suite.db.GetTagTimeline(ctx, []string{hashtag.ID, welcome.ID}, nil, nil, "", "", "", 20, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(s, 1)
+	suite.Equal("01F8MH75CBF9JFX4ZAD54N0W0R", s[0].ID)
+
+	// Status doesn't use all tags.
+	s, err = suite.db.GetTagTimeline(ctx, []string{welcome.ID}, []string{hashtag.ID}, nil, "", "", "", 20, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(s)
+
+	// Status uses all tags.
+	s, err = suite.db.GetTagTimeline(ctx, []string{welcome.ID}, []string{welcome.ID}, nil, "", "", "", 20, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(s, 1)
+
+	// Status uses excluded tag.
+	s, err = suite.db.GetTagTimeline(ctx, []string{welcome.ID, hashtag.ID}, nil, []string{welcome.ID}, "", "", "", 20, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(s)
+
+	// Status doesn't use excluded tag.
+	s, err = suite.db.GetTagTimeline(ctx, []string{welcome.ID}, nil, []string{hashtag.ID}, "", "", "", 20, true)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(s, 1)
+}
+
 func TestTimelineTestSuite(t *testing.T) {
 	suite.Run(t, new(TimelineTestSuite))
 }
@@ -0,0 +1,258 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type timelineEntryDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (t *timelineEntryDB) GetTimelineEntries(
+	ctx context.Context,
+	timelineType gtsmodel.TimelineType,
+	timelineID string,
+	maxID string,
+	sinceID string,
+	minID string,
+	limit int,
+) ([]*gtsmodel.TimelineEntry, error) {
+	// Ensure reasonable
+	if limit < 0 {
+		limit = 0
+	}
+
+	var (
+		entries     = make([]*gtsmodel.TimelineEntry, 0, limit)
+		frontToBack = true
+	)
+
+	q := t.db.
+		NewSelect().
+		Model(&entries).
+		Where("? = ?", bun.Ident("timeline_type"), timelineType).
+		Where("? = ?", bun.Ident("timeline_id"), timelineID)
+
+	if maxID == "" {
+		maxID = id.Highest
+	}
+
+	// return only entries LOWER (ie., older) than maxID
+	q = q.Where("? < ?", bun.Ident("status_id"), maxID)
+
+	if sinceID != "" {
+		// return only entries HIGHER (ie., newer) than sinceID
+		q = q.Where("? > ?", bun.Ident("status_id"), sinceID)
+	}
+
+	if minID != "" {
+		// return only entries HIGHER (ie., newer) than minID
+		q = q.Where("? > ?", bun.Ident("status_id"), minID)
+
+		// page up
+		frontToBack = false
+	}
+
+	if limit > 0 {
+		// limit amount of entries returned
+		q = q.Limit(limit)
+	}
+
+	if frontToBack {
+		// Page down.
+		q = q.Order("status_id DESC")
+	} else {
+		// Page up.
+		q = q.Order("status_id ASC")
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	// If we're paging up, we still want entries
+	// to be sorted by ID desc, so reverse slice.
+	if !frontToBack {
+		slices.Reverse(entries)
+	}
+
+	return entries, nil
+}
+
+func (t *timelineEntryDB) CountTimelineEntries(ctx context.Context, timelineType gtsmodel.TimelineType, timelineID string) (int, error) {
+	return t.db.
+		NewSelect().
+		Table("timeline_entries").
+		Where("? = ?", bun.Ident("timeline_type"), timelineType).
+		Where("? = ?", bun.Ident("timeline_id"), timelineID).
+		Count(ctx)
+}
+
+func (t *timelineEntryDB) GetTimelineIDs(ctx context.Context, timelineType gtsmodel.TimelineType) ([]string, error) {
+	var timelineIDs []string
+	if err := t.db.
+		NewSelect().
+		Table("timeline_entries").
+		Distinct().
+		Column("timeline_id").
+		Where("? = ?", bun.Ident("timeline_type"), timelineType).
+		Scan(ctx, &timelineIDs); err != nil {
+		return nil, err
+	}
+	return timelineIDs, nil
+}
+
+func (t *timelineEntryDB) PutTimelineEntry(ctx context.Context, entry *gtsmodel.TimelineEntry) error {
+	_, err := t.db.
+		NewInsert().
+		Model(entry).
+		On("CONFLICT (?, ?, ?) DO NOTHING",
+			bun.Ident("timeline_type"),
+			bun.Ident("timeline_id"),
+			bun.Ident("status_id"),
+		).
+		Exec(ctx)
+	return err
+}
+
+func (t *timelineEntryDB) DeleteTimelineEntry(ctx context.Context, timelineType gtsmodel.TimelineType, timelineID string, statusID string) (int, error) {
+	return t.delete(ctx, func(q *bun.DeleteQuery) *bun.DeleteQuery {
+		return q.
+			Where("? = ?", bun.Ident("timeline_type"), timelineType).
+			Where("? = ?", bun.Ident("timeline_id"), timelineID).
+			Where("? = ?", bun.Ident("status_id"), statusID)
+	})
+}
+
+func (t *timelineEntryDB) DeleteTimelineEntries(ctx context.Context, timelineType gtsmodel.TimelineType, timelineID string) error {
+	_, err := t.delete(ctx, func(q *bun.DeleteQuery) *bun.DeleteQuery {
+		return q.
+			Where("? = ?", bun.Ident("timeline_type"), timelineType).
+			Where("? = ?", bun.Ident("timeline_id"), timelineID)
+	})
+	return err
+}
+
+func (t *timelineEntryDB) DeleteTimelineEntriesByStatusID(ctx context.Context, timelineType gtsmodel.TimelineType, statusID string) error {
+	_, err := t.delete(ctx, func(q *bun.DeleteQuery) *bun.DeleteQuery {
+		return q.
+			Where("? = ?", bun.Ident("timeline_type"), timelineType).
+			Where("? = ?", bun.Ident("status_id"), statusID)
+	})
+	return err
+}
+
+func (t *timelineEntryDB) DeleteTimelineEntriesByAccountID(ctx context.Context, timelineType gtsmodel.TimelineType, timelineID string, accountID string) (int, error) {
+	return t.delete(ctx, func(q *bun.DeleteQuery) *bun.DeleteQuery {
+		return q.
+			Where("? = ?", bun.Ident("timeline_type"), timelineType).
+			Where("? = ?", bun.Ident("timeline_id"), timelineID).
+			WhereGroup(" AND ", func(q *bun.DeleteQuery) *bun.DeleteQuery {
+				return q.
+					Where("? = ?", bun.Ident("account_id"), accountID).
+					WhereOr("? = ?", bun.Ident("boost_of_account_id"), accountID)
+			})
+	})
+}
+
+func (t *timelineEntryDB) DeleteTimelineBoostsByAccountID(ctx context.Context, timelineType gtsmodel.TimelineType, timelineID string, accountID string) (int, error) {
+	return t.delete(ctx, func(q *bun.DeleteQuery) *bun.DeleteQuery {
+		return q.
+			Where("? = ?", bun.Ident("timeline_type"), timelineType).
+			Where("? = ?", bun.Ident("timeline_id"), timelineID).
+			Where("? = ?", bun.Ident("account_id"), accountID).
+			Where("? IS NOT NULL", bun.Ident("boost_of_id"))
+	})
+}
+
+func (t *timelineEntryDB) PruneTimelineEntries(ctx context.Context, timelineType gtsmodel.TimelineType, timelineID string, keep int) (int, error) {
+	if keep < 0 {
+		keep = 0
+	}
+
+	// Find the oldest status ID that we want to
+	// keep, ie., the keep'th newest entry.
+	var oldestKeptID string
+	if keep > 0 {
+		err := t.db.
+			NewSelect().
+			Table("timeline_entries").
+			Column("status_id").
+			Where("? = ?", bun.Ident("timeline_type"), timelineType).
+			Where("? = ?", bun.Ident("timeline_id"), timelineID).
+			Order("status_id DESC").
+			Offset(keep-1).
+			Limit(1).
+			Scan(ctx, &oldestKeptID)
+		if err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				// Fewer than keep
+				// entries, nothing
+				// to prune.
+				return 0, nil
+			}
+			return 0, err
+		}
+	}
+
+	return t.delete(ctx, func(q *bun.DeleteQuery) *bun.DeleteQuery {
+		q = q.
+			Where("? = ?", bun.Ident("timeline_type"), timelineType).
+			Where("? = ?", bun.Ident("timeline_id"), timelineID)
+
+		if oldestKeptID != "" {
+			q = q.Where("? < ?", bun.Ident("status_id"), oldestKeptID)
+		}
+
+		return q
+	})
+}
+
+// delete deletes timeline entries
+// selected by the given where func,
+// returning the number deleted.
+func (t *timelineEntryDB) delete(
+	ctx context.Context,
+	where func(*bun.DeleteQuery) *bun.DeleteQuery,
+) (int, error) {
+	res, err := where(t.db.
+		NewDelete().
+		Table("timeline_entries"),
+	).Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rows), nil
+}
@@ -114,6 +114,17 @@ func (u *userDB) GetUserByConfirmationToken(ctx context.Context, token string) (
 	)
 }
 
+func (u *userDB) GetUserByResetPasswordToken(ctx context.Context, token string) (*gtsmodel.User, error) {
+	return u.getUser(
+		ctx,
+		"ResetPasswordToken",
+		func(user *gtsmodel.User) error {
+			return u.db.NewSelect().Model(user).Where("? = ?", bun.Ident("reset_password_token"), token).Scan(ctx)
+		},
+		token,
+	)
+}
+
 func (u *userDB) getUser(ctx context.Context, lookup string, dbQuery func(*gtsmodel.User) error, keyParts ...any) (*gtsmodel.User, error) {
 	// Fetch user from database cache with loader callback.
 	user, err := u.state.Caches.GTS.User.LoadOne(lookup, func() (*gtsmodel.User, error) {
@@ -178,6 +189,22 @@ func (u *userDB) GetAllUsers(ctx context.Context) ([]*gtsmodel.User, error) {
 	return u.GetUsersByIDs(ctx, userIDs)
 }
 
+func (u *userDB) GetFrozenUsers(ctx context.Context) ([]*gtsmodel.User, error) {
+	var userIDs []string
+
+	// Select all users with a set `frozen_until` time.
+	if err := u.db.NewSelect().
+		Table("users").
+		Column("users.id").
+		Where("? IS NOT NULL", bun.Ident("users.frozen_until")).
+		Scan(ctx, &userIDs); err != nil {
+		return nil, err
+	}
+
+	// Transform user IDs into user slice.
+	return u.GetUsersByIDs(ctx, userIDs)
+}
+
 func (u *userDB) PutUser(ctx context.Context, user *gtsmodel.User) error {
 	return u.state.Caches.GTS.User.Store(user, func() error {
 		_, err := u.db.
@@ -0,0 +1,89 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type usernameChangeDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (u *usernameChangeDB) GetUsernameChangeByOldUsername(
+	ctx context.Context,
+	username string,
+) (*gtsmodel.UsernameChange, error) {
+	change := new(gtsmodel.UsernameChange)
+	if err := u.db.
+		NewSelect().
+		Model(change).
+		Where("? = ?", bun.Ident("username_change.old_username"), username).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+func (u *usernameChangeDB) GetUsernameChangesByAccountID(
+	ctx context.Context,
+	accountID string,
+) ([]*gtsmodel.UsernameChange, error) {
+	changes := []*gtsmodel.UsernameChange{}
+	if err := u.db.
+		NewSelect().
+		Model(&changes).
+		Where("? = ?", bun.Ident("username_change.account_id"), accountID).
+		Order("username_change.created_at DESC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func (u *usernameChangeDB) PutUsernameChange(
+	ctx context.Context,
+	change *gtsmodel.UsernameChange,
+) error {
+	// The account may be cached under
+	// the old username, invalidate it.
+	defer u.state.Caches.GTS.Account.Invalidate("ID", change.AccountID)
+
+	_, err := u.db.
+		NewInsert().
+		Model(change).
+		Exec(ctx)
+	return err
+}
+
+func (u *usernameChangeDB) DeleteUsernameChangeByID(
+	ctx context.Context,
+	id string,
+) error {
+	_, err := u.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("username_changes"), bun.Ident("username_change")).
+		Where("? = ?", bun.Ident("username_change.id"), id).
+		Exec(ctx)
+	return err
+}
@@ -226,3 +226,21 @@ func whereArrayIsNullOrEmpty(query *bun.SelectQuery, subject interface{}) *bun.S
 			WhereOr(arrayEmptySQL, subject)
 	})
 }
+
+// whereArrayIsNotEmpty extends a query with a where clause requiring an array to be neither null nor empty.
+// (The empty check varies by dialect; only PG has direct support for SQL array types.)
+func whereArrayIsNotEmpty(query *bun.SelectQuery, subject interface{}) *bun.SelectQuery {
+	var arrayNotEmptySQL string
+	switch d := query.Dialect().Name(); d {
+	case dialect.SQLite:
+		arrayNotEmptySQL = "json_array_length(?) > 0"
+	case dialect.PG:
+		arrayNotEmptySQL = "CARDINALITY(?) > 0"
+	default:
+		log.Panicf(nil, "db conn %s was neither pg nor sqlite", d)
+	}
+
+	return query.
+		Where("? IS NOT NULL", subject).
+		Where(arrayNotEmptySQL, subject)
+}
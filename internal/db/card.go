@@ -0,0 +1,43 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Card contains functions for getting/creating link preview cards in the database.
+type Card interface {
+	// GetCardByID gets one preview card with the given ID.
+	GetCardByID(ctx context.Context, id string) (*gtsmodel.Card, error)
+
+	// GetCardByURL gets one preview card for the given link URL.
+	GetCardByURL(ctx context.Context, url string) (*gtsmodel.Card, error)
+
+	// PopulateCard populates the struct pointers on the given preview card.
+	PopulateCard(ctx context.Context, card *gtsmodel.Card) error
+
+	// PutCard inserts the given preview card in the database.
+	PutCard(ctx context.Context, card *gtsmodel.Card) error
+
+	// UpdateCard updates the given preview card in the database,
+	// updating only the given columns, or all columns if none given.
+	UpdateCard(ctx context.Context, card *gtsmodel.Card, columns ...string) error
+}
@@ -0,0 +1,59 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// ContentReveal contains functions for getting/creating/deleting
+// decisions of local accounts to always show content behind
+// content warnings for an author or thread.
+type ContentReveal interface {
+	// GetContentRevealByID gets one content reveal by its db id.
+	GetContentRevealByID(ctx context.Context, id string) (*gtsmodel.ContentReveal, error)
+
+	// GetContentReveals gets all content reveals
+	// of the given account, newest first.
+	GetContentReveals(ctx context.Context, accountID string) ([]*gtsmodel.ContentReveal, error)
+
+	// GetContentRevealForAccount gets the content reveal
+	// of accountID targeting the author targetAccountID.
+	GetContentRevealForAccount(ctx context.Context, accountID string, targetAccountID string) (*gtsmodel.ContentReveal, error)
+
+	// GetContentRevealForThread gets the content
+	// reveal of accountID targeting threadID.
+	GetContentRevealForThread(ctx context.Context, accountID string, threadID string) (*gtsmodel.ContentReveal, error)
+
+	// IsContentRevealed returns true if accountID has chosen to always show
+	// content of statuses by targetAccountID, or of statuses in threadID.
+	// Either of targetAccountID or threadID may be empty.
+	IsContentRevealed(ctx context.Context, accountID string, targetAccountID string, threadID string) (bool, error)
+
+	// PutContentReveal puts the given content reveal in the database.
+	PutContentReveal(ctx context.Context, reveal *gtsmodel.ContentReveal) error
+
+	// DeleteContentRevealByID deletes one content reveal by its db id.
+	DeleteContentRevealByID(ctx context.Context, id string) error
+
+	// DeleteContentRevealsByAccountID deletes all content
+	// reveals made by or targeting the given account.
+	DeleteContentRevealsByAccountID(ctx context.Context, accountID string) error
+}
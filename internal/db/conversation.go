@@ -0,0 +1,69 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// Conversation contains functions for getting/creating/deleting
+// the direct message conversations of local accounts.
+type Conversation interface {
+	// GetConversationByID gets one conversation by its db id.
+	GetConversationByID(ctx context.Context, id string) (*gtsmodel.Conversation, error)
+
+	// GetConversationByThreadID gets the conversation of the
+	// given account that tracks the given thread, if it exists.
+	GetConversationByThreadID(ctx context.Context, accountID string, threadID string) (*gtsmodel.Conversation, error)
+
+	// GetConversationsByAccountID gets a page of the conversations
+	// of the given account, most recently active first.
+	//
+	// Conversations are paged by their last status ID,
+	// not by the ID of the conversation itself.
+	GetConversationsByAccountID(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.Conversation, error)
+
+	// GetConversationsByLastStatusID gets all
+	// conversations with the given last status.
+	GetConversationsByLastStatusID(ctx context.Context, statusID string) ([]*gtsmodel.Conversation, error)
+
+	// GetThreadDirectStatusIDs gets the IDs of all
+	// direct statuses in the given thread, newest first.
+	GetThreadDirectStatusIDs(ctx context.Context, threadID string) ([]string, error)
+
+	// PopulateConversation ensures that all sub-models
+	// of the given conversation are populated.
+	PopulateConversation(ctx context.Context, conversation *gtsmodel.Conversation) error
+
+	// PutConversation puts the given conversation in the database.
+	PutConversation(ctx context.Context, conversation *gtsmodel.Conversation) error
+
+	// UpdateConversation updates the given conversation by ID,
+	// updating only the given columns, or all if none are given.
+	UpdateConversation(ctx context.Context, conversation *gtsmodel.Conversation, columns ...string) error
+
+	// DeleteConversationByID deletes one conversation by its db id.
+	DeleteConversationByID(ctx context.Context, id string) error
+
+	// DeleteConversationsByAccountID deletes
+	// all conversations owned by the given account.
+	DeleteConversationsByAccountID(ctx context.Context, accountID string) error
+}
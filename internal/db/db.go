@@ -25,13 +25,21 @@ const (
 // DB provides methods for interacting with an underlying database or other storage mechanism.
 type DB interface {
 	Account
+	AccountExport
+	AccountImport
 	Admin
+	Announcement
 	Application
 	Basic
+	Card
+	ContentReveal
+	Conversation
 	Domain
 	Emoji
+	FollowerEvent
 	HeaderFilter
 	Instance
+	InteractionRequest
 	Filter
 	List
 	Marker
@@ -39,18 +47,26 @@ type DB interface {
 	Mention
 	Move
 	Notification
+	NotificationRequest
 	Poll
 	Relationship
 	Report
 	Rule
+	ScheduledStatus
 	Search
 	Session
 	Status
 	StatusBookmark
+	StatusEdit
 	StatusFave
+	StatusReaction
 	Tag
+	TermsVersion
 	Thread
 	Timeline
+	TimelineEntry
 	User
+	UsernameChange
 	Tombstone
+	WebPush
 }
@@ -0,0 +1,56 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// FollowerEvent contains functions for getting/creating/deleting
+// the recorded follow / unfollow events of local accounts.
+type FollowerEvent interface {
+	// GetFollowerEventByID gets one follower event by its db id.
+	GetFollowerEventByID(ctx context.Context, id string) (*gtsmodel.FollowerEvent, error)
+
+	// GetAccountFollowerEvents gets a page of the
+	// follower events of the given account, newest first.
+	GetAccountFollowerEvents(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.FollowerEvent, error)
+
+	// GetAccountFollowerEventTimes returns the creation times of the given
+	// account's follower events of the given type, created after since.
+	GetAccountFollowerEventTimes(ctx context.Context, accountID string, eventType gtsmodel.FollowerEventType, since time.Time) ([]time.Time, error)
+
+	// PopulateFollowerEvent ensures that all sub-models
+	// of the given follower event are populated.
+	PopulateFollowerEvent(ctx context.Context, event *gtsmodel.FollowerEvent) error
+
+	// PutFollowerEvent puts the given follower event in the database.
+	PutFollowerEvent(ctx context.Context, event *gtsmodel.FollowerEvent) error
+
+	// DeleteAccountFollowerEvents deletes the
+	// recorded follower events of the given account.
+	DeleteAccountFollowerEvents(ctx context.Context, accountID string) error
+
+	// DeleteFollowerEventsByAccountID deletes all follower
+	// events of, or by, the given account.
+	DeleteFollowerEventsByAccountID(ctx context.Context, accountID string) error
+}
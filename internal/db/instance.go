@@ -21,6 +21,7 @@ import (
 	"context"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
 // Instance contains functions for instance-level actions (counting instance users etc.).
@@ -52,7 +53,11 @@ type Instance interface {
 	// GetInstanceAccounts returns a slice of accounts from the given instance, arranged by ID.
 	GetInstanceAccounts(ctx context.Context, domain string, maxID string, limit int) ([]*gtsmodel.Account, error)
 
-	// GetInstancePeers returns a slice of instances that the host instance knows about.
+	// GetInstances returns a page of known instances, arranged by ID.
+	GetInstances(ctx context.Context, page *paging.Page) ([]*gtsmodel.Instance, error)
+
+	// GetInstancePeers returns a slice of instances that the host instance knows about,
+	// excluding instances we haven't received federated traffic from for a long time.
 	GetInstancePeers(ctx context.Context, includeSuspended bool) ([]*gtsmodel.Instance, error)
 
 	// GetInstanceModeratorAddresses returns a slice of email addresses belonging to active
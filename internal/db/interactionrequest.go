@@ -0,0 +1,62 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// InteractionRequest contains functions for getting/creating/updating/deleting
+// requests of interactions held pending approval by a locked account.
+type InteractionRequest interface {
+	// GetInteractionRequestByID gets one interaction request by its db id.
+	GetInteractionRequestByID(ctx context.Context, id string) (*gtsmodel.InteractionRequest, error)
+
+	// GetInteractionRequestByInteractionID gets the interaction
+	// request of the reply or boost status with the given ID.
+	GetInteractionRequestByInteractionID(ctx context.Context, interactionID string) (*gtsmodel.InteractionRequest, error)
+
+	// GetAccountInteractionRequests gets a page of the pending (ie., neither
+	// accepted nor rejected) interaction requests targeting the given account, newest first.
+	GetAccountInteractionRequests(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.InteractionRequest, error)
+
+	// PopulateInteractionRequest ensures that all sub-models
+	// of the given interaction request are populated.
+	PopulateInteractionRequest(ctx context.Context, req *gtsmodel.InteractionRequest) error
+
+	// PutInteractionRequest puts the given interaction request in the database.
+	PutInteractionRequest(ctx context.Context, req *gtsmodel.InteractionRequest) error
+
+	// UpdateInteractionRequest updates the given interaction request.
+	// If columns is empty, all columns will be updated.
+	UpdateInteractionRequest(ctx context.Context, req *gtsmodel.InteractionRequest, columns ...string) error
+
+	// DeleteInteractionRequestByID deletes one interaction request by its db id.
+	DeleteInteractionRequestByID(ctx context.Context, id string) error
+
+	// DeleteInteractionRequestsByAccountID deletes all interaction
+	// requests targeting, or by, the given account.
+	DeleteInteractionRequestsByAccountID(ctx context.Context, accountID string) error
+
+	// DeleteInteractionRequestsByStatusID deletes all interaction
+	// requests of, or for the interaction of, the given status.
+	DeleteInteractionRequestsByStatusID(ctx context.Context, statusID string) error
+}
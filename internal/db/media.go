@@ -51,4 +51,26 @@ type Media interface {
 	// GetCachedAttachmentsOlderThan gets limit n remote attachments (including avatars and headers) older than
 	// the given time. These will be returned in order of attachment.created_at descending (i.e. newest to oldest).
 	GetCachedAttachmentsOlderThan(ctx context.Context, olderThan time.Time, limit int) ([]*gtsmodel.MediaAttachment, error)
+
+	// GetCachedAttachmentsExpiredBefore gets limit n cached attachments whose expiry time is before the
+	// given time. These will be returned in order of attachment.expires_at ascending (i.e. soonest expired first).
+	GetCachedAttachmentsExpiredBefore(ctx context.Context, expiredBefore time.Time, limit int) ([]*gtsmodel.MediaAttachment, error)
+
+	// GetAttachmentsByFileHash gets the cached media attachments
+	// whose files have the given hash, in order of attachment ID
+	// ascending (i.e. oldest to newest).
+	GetAttachmentsByFileHash(ctx context.Context, hash string) ([]*gtsmodel.MediaAttachment, error)
+
+	// GetAttachmentIDsByFilePath returns the IDs of the cached media
+	// attachments whose files are stored at the given storage path.
+	GetAttachmentIDsByFilePath(ctx context.Context, path string) ([]string, error)
+
+	// GetAttachmentIDByThumbnailPath returns the ID of the cached
+	// media attachment whose thumbnail is stored at the given path.
+	GetAttachmentIDByThumbnailPath(ctx context.Context, path string) (string, error)
+
+	// GetOrphanedAttachmentIDs returns the IDs of all media attachments
+	// with a status ID set, where that status no longer exists, and
+	// which aren't waiting to be published with a scheduled status.
+	GetOrphanedAttachmentIDs(ctx context.Context) ([]string, error)
 }
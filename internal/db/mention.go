@@ -39,4 +39,8 @@ type Mention interface {
 
 	// DeleteMentionByID will delete mention with given ID from the database.
 	DeleteMentionByID(ctx context.Context, id string) error
+
+	// GetOrphanedMentionIDs returns the IDs of all mentions
+	// whose originating status no longer exists.
+	GetOrphanedMentionIDs(ctx context.Context) ([]string, error)
 }
@@ -25,7 +25,8 @@ import (
 
 // Notification contains functions for creating and getting notifications.
 type Notification interface {
-	// GetNotifications returns a slice of notifications that pertain to the given accountID.
+	// GetNotifications returns a slice of notifications that pertain to the given accountID,
+	// excluding those filtered by the account's notification policy.
 	//
 	// Returned notifications will be ordered ID descending (ie., highest/newest to lowest/oldest).
 	GetAccountNotifications(ctx context.Context, accountID string, maxID string, sinceID string, minID string, limit int, excludeTypes []string) ([]*gtsmodel.Notification, error)
@@ -40,12 +41,40 @@ type Notification interface {
 	// Since not all notifications are about a status, statusID can be an empty string.
 	GetNotification(ctx context.Context, notificationType gtsmodel.NotificationType, targetAccountID string, originAccountID string, statusID string) (*gtsmodel.Notification, error)
 
+	// GetAccountNotificationsByGroupKey returns the notifications targeting
+	// the given account with the given group key, ordered ID descending.
+	GetAccountNotificationsByGroupKey(ctx context.Context, accountID string, groupKey string) ([]*gtsmodel.Notification, error)
+
+	// GetLatestNotificationGroupKey returns the group key of the most
+	// recent notification targeting the given account, whose group key
+	// starts with the given prefix, or db.ErrNoEntries if there's none.
+	GetLatestNotificationGroupKey(ctx context.Context, targetAccountID string, prefix string) (string, error)
+
+	// CountAccountNotifications counts the notifications targeting the given account
+	// newer than sinceID (or all of them, if sinceID is not set), up to the given
+	// limit, excluding those filtered by the account's notification policy.
+	CountAccountNotifications(ctx context.Context, accountID string, sinceID string, limit int) (int, error)
+
+	// GetFilteredNotifications returns the notifications targeting the given
+	// account that were filtered by its notification policy, ordered ID descending.
+	// If fromAccountID is set, only those originating from that account are returned.
+	GetFilteredNotifications(ctx context.Context, accountID string, fromAccountID string) ([]*gtsmodel.Notification, error)
+
+	// CountFilteredNotifications counts the notifications targeting the given
+	// account that were filtered by its notification policy. If fromAccountID
+	// is set, only those originating from that account are counted.
+	CountFilteredNotifications(ctx context.Context, accountID string, fromAccountID string) (int, error)
+
 	// PopulateNotification ensures that the notification's struct fields are populated.
 	PopulateNotification(ctx context.Context, notif *gtsmodel.Notification) error
 
 	// PutNotification will insert the given notification into the database.
 	PutNotification(ctx context.Context, notif *gtsmodel.Notification) error
 
+	// UpdateNotification updates the given notification.
+	// If columns is empty, all columns will be updated.
+	UpdateNotification(ctx context.Context, notif *gtsmodel.Notification, columns ...string) error
+
 	// DeleteNotificationByID deletes one notification according to its id,
 	// and removes that notification from the in-memory cache.
 	DeleteNotificationByID(ctx context.Context, id string) error
@@ -69,4 +98,8 @@ type Notification interface {
 	// the given statusID. This function is useful when a status has been deleted,
 	// and so notifications relating to that status must also be deleted.
 	DeleteNotificationsForStatus(ctx context.Context, statusID string) error
+
+	// GetOrphanedNotificationIDs returns the IDs of all notifications whose
+	// target account or origin account no longer exists.
+	GetOrphanedNotificationIDs(ctx context.Context) ([]string, error)
 }
@@ -0,0 +1,62 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// NotificationRequest contains functions for getting/creating/updating/deleting
+// requests of notifications filtered by accounts' notification policies.
+type NotificationRequest interface {
+	// GetNotificationRequestByID gets one notification request by its db id.
+	GetNotificationRequestByID(ctx context.Context, id string) (*gtsmodel.NotificationRequest, error)
+
+	// GetNotificationRequest gets the notification request of
+	// notifications to accountID from fromAccountID, if any.
+	GetNotificationRequest(ctx context.Context, accountID string, fromAccountID string) (*gtsmodel.NotificationRequest, error)
+
+	// GetAccountNotificationRequests gets a page of the pending (ie.,
+	// not accepted) notification requests of the given account, newest first.
+	GetAccountNotificationRequests(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.NotificationRequest, error)
+
+	// CountAccountNotificationRequests counts the pending
+	// (ie., not accepted) notification requests of the given account.
+	CountAccountNotificationRequests(ctx context.Context, accountID string) (int, error)
+
+	// PopulateNotificationRequest ensures that all sub-models
+	// of the given notification request are populated.
+	PopulateNotificationRequest(ctx context.Context, req *gtsmodel.NotificationRequest) error
+
+	// PutNotificationRequest puts the given notification request in the database.
+	PutNotificationRequest(ctx context.Context, req *gtsmodel.NotificationRequest) error
+
+	// UpdateNotificationRequest updates the given notification request.
+	// If columns is empty, all columns will be updated.
+	UpdateNotificationRequest(ctx context.Context, req *gtsmodel.NotificationRequest, columns ...string) error
+
+	// DeleteNotificationRequestByID deletes one notification request by its db id.
+	DeleteNotificationRequestByID(ctx context.Context, id string) error
+
+	// DeleteNotificationRequestsByAccountID deletes all notification
+	// requests of, or from, the given account.
+	DeleteNotificationRequestsByAccountID(ctx context.Context, accountID string) error
+}
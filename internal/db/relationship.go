@@ -47,6 +47,12 @@ type Relationship interface {
 	// PutBlock attempts to place the given account block in the database.
 	PutBlock(ctx context.Context, block *gtsmodel.Block) error
 
+	// UpdateBlock updates one block by ID.
+	UpdateBlock(ctx context.Context, block *gtsmodel.Block, columns ...string) error
+
+	// GetExpiringBlocks fetches all blocks in the database with a set `expires_at` column.
+	GetExpiringBlocks(ctx context.Context) ([]*gtsmodel.Block, error)
+
 	// DeleteBlockByID removes block with given ID from the database.
 	DeleteBlockByID(ctx context.Context, id string) error
 
@@ -56,6 +62,32 @@ type Relationship interface {
 	// DeleteAccountBlocks will delete all database blocks to / from the given account ID.
 	DeleteAccountBlocks(ctx context.Context, accountID string) error
 
+	// GetMuteByID fetches user mute with given ID from the database.
+	GetMuteByID(ctx context.Context, id string) (*gtsmodel.UserMute, error)
+
+	// GetMute returns the user mute from account1 targeting account2, if it exists, or an error if it doesn't.
+	//
+	// Note that the returned mute may have expired, see gtsmodel.UserMute{}.Expired().
+	GetMute(ctx context.Context, account1 string, account2 string) (*gtsmodel.UserMute, error)
+
+	// PopulateMute populates the struct pointers on the given user mute.
+	PopulateMute(ctx context.Context, mute *gtsmodel.UserMute) error
+
+	// PutMute attempts to place the given user mute in the database.
+	PutMute(ctx context.Context, mute *gtsmodel.UserMute) error
+
+	// UpdateMute updates one user mute by ID.
+	UpdateMute(ctx context.Context, mute *gtsmodel.UserMute, columns ...string) error
+
+	// GetExpiringMutes fetches all user mutes in the database with a set `expires_at` column.
+	GetExpiringMutes(ctx context.Context) ([]*gtsmodel.UserMute, error)
+
+	// DeleteMuteByID removes user mute with given ID from the database.
+	DeleteMuteByID(ctx context.Context, id string) error
+
+	// DeleteAccountMutes will delete all database user mutes to / from the given account ID.
+	DeleteAccountMutes(ctx context.Context, accountID string) error
+
 	// GetRelationship retrieves the relationship of the targetAccount to the requestingAccount.
 	GetRelationship(ctx context.Context, requestingAccount string, targetAccount string) (*gtsmodel.Relationship, error)
 
@@ -179,6 +211,9 @@ type Relationship interface {
 	// GetAccountBlockIDs is like GetAccountBlocks, but returns just IDs.
 	GetAccountBlockIDs(ctx context.Context, accountID string, page *paging.Page) ([]string, error)
 
+	// GetAccountMutes returns all user mutes originating from the given account, with given optional paging parameters.
+	GetAccountMutes(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.UserMute, error)
+
 	// GetNote gets a private note from a source account on a target account, if it exists.
 	GetNote(ctx context.Context, sourceAccountID string, targetAccountID string) (*gtsmodel.AccountNote, error)
 
@@ -39,4 +39,14 @@ type Rule interface {
 
 	// UpdateRule updates one rule by its db id.
 	UpdateRule(ctx context.Context, rule *gtsmodel.Rule) (*gtsmodel.Rule, error)
+
+	// GetRuleAcknowledgementsByUserID gets all rule acknowledgements
+	// of the given user, in the order the rules were acknowledged.
+	GetRuleAcknowledgementsByUserID(ctx context.Context, userID string) ([]*gtsmodel.RuleAcknowledgement, error)
+
+	// PutRuleAcknowledgements puts the given rule acknowledgements in the database.
+	PutRuleAcknowledgements(ctx context.Context, acks []*gtsmodel.RuleAcknowledgement) error
+
+	// DeleteRuleAcknowledgementsByUserID deletes all rule acknowledgements of the given user.
+	DeleteRuleAcknowledgementsByUserID(ctx context.Context, userID string) error
 }
@@ -0,0 +1,63 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// ScheduledStatus contains functions for getting/creating/updating/deleting
+// statuses scheduled by local accounts to be published at a later time.
+type ScheduledStatus interface {
+	// GetScheduledStatusByID gets one scheduled status by its db id.
+	GetScheduledStatusByID(ctx context.Context, id string) (*gtsmodel.ScheduledStatus, error)
+
+	// GetAccountScheduledStatuses gets a page of scheduled
+	// statuses created by the given account, newest first.
+	GetAccountScheduledStatuses(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.ScheduledStatus, error)
+
+	// CountAccountScheduledStatuses counts the scheduled
+	// statuses currently pending for the given account.
+	CountAccountScheduledStatuses(ctx context.Context, accountID string) (int, error)
+
+	// GetDueScheduledStatuses gets all scheduled statuses that are due
+	// to be published as of the given time, earliest scheduled first.
+	GetDueScheduledStatuses(ctx context.Context, now time.Time) ([]*gtsmodel.ScheduledStatus, error)
+
+	// PopulateScheduledStatus ensures that all sub-models
+	// of the given scheduled status are populated.
+	PopulateScheduledStatus(ctx context.Context, scheduledStatus *gtsmodel.ScheduledStatus) error
+
+	// PutScheduledStatus puts the given scheduled status in the database.
+	PutScheduledStatus(ctx context.Context, scheduledStatus *gtsmodel.ScheduledStatus) error
+
+	// UpdateScheduledStatus updates the given scheduled status.
+	// If columns is empty, all columns will be updated.
+	UpdateScheduledStatus(ctx context.Context, scheduledStatus *gtsmodel.ScheduledStatus, columns ...string) error
+
+	// DeleteScheduledStatusByID deletes one scheduled status by its db id.
+	DeleteScheduledStatusByID(ctx context.Context, id string) error
+
+	// DeleteScheduledStatusesByAccountID deletes all
+	// scheduled statuses created by the given account.
+	DeleteScheduledStatusesByAccountID(ctx context.Context, accountID string) error
+}
@@ -28,8 +28,32 @@ type Search interface {
 	SearchForAccounts(ctx context.Context, accountID string, query string, maxID string, minID string, limit int, following bool, offset int) ([]*gtsmodel.Account, error)
 
 	// SearchForStatuses uses the given query text to search for statuses created by accountID, or in reply to accountID.
-	SearchForStatuses(ctx context.Context, accountID string, query string, maxID string, minID string, limit int, offset int) ([]*gtsmodel.Status, error)
+	// If set, fromAccountID, hasMedia and hasPoll narrow down results to statuses created by fromAccountID,
+	// with media attachments, and with a poll, respectively.
+	// An empty query matches all statuses.
+	SearchForStatuses(ctx context.Context, accountID string, query string, fromAccountID string, hasMedia bool, hasPoll bool, maxID string, minID string, limit int, offset int) ([]*gtsmodel.Status, error)
 
 	// SearchForTags searches for tags that start with the given query text (case insensitive).
 	SearchForTags(ctx context.Context, query string, maxID string, minID string, limit int, offset int) ([]*gtsmodel.Tag, error)
+
+	// SearchForIndexedStatuses uses the full-text status search index to search for statuses
+	// created by accountID, in reply to accountID, or bookmarked or faved by accountID.
+	// Results are narrowed down the same way as SearchForStatuses.
+	SearchForIndexedStatuses(ctx context.Context, accountID string, query string, fromAccountID string, hasMedia bool, hasPoll bool, maxID string, minID string, limit int, offset int) ([]*gtsmodel.Status, error)
+
+	// PutStatusSearchText inserts or updates the plaintext of a status in the full-text status search index.
+	PutStatusSearchText(ctx context.Context, statusID string, text string) error
+
+	// DeleteStatusSearchText removes the plaintext of a status from the full-text status search index.
+	DeleteStatusSearchText(ctx context.Context, statusID string) error
+
+	// CountStatusSearchTexts returns the number of statuses in the full-text status search index.
+	CountStatusSearchTexts(ctx context.Context) (int, error)
+
+	// DeleteAllStatusSearchTexts empties the full-text status search index.
+	DeleteAllStatusSearchTexts(ctx context.Context) error
+
+	// GetStatusesToIndex returns up to limit statuses with an ID lower than maxID,
+	// excluding boosts, in descending ID order. Used to build the status search index.
+	GetStatusesToIndex(ctx context.Context, maxID string, limit int) ([]*gtsmodel.Status, error)
 }
@@ -19,6 +19,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
@@ -81,4 +82,9 @@ type Status interface {
 
 	// IsStatusBookmarkedBy checks if a given status has been bookmarked by a given account ID
 	IsStatusBookmarkedBy(ctx context.Context, status *gtsmodel.Status, accountID string) (bool, error)
+
+	// GetTrendingStatuses gets up to limit public, federated, top-level statuses created
+	// by discoverable local accounts from the given time onwards, with the most faves
+	// and boosts first. Statuses without any faves or boosts are not included.
+	GetTrendingStatuses(ctx context.Context, since time.Time, limit int) ([]*gtsmodel.Status, error)
 }
@@ -21,6 +21,7 @@ import (
 	"context"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
 type StatusBookmark interface {
@@ -31,12 +32,12 @@ type StatusBookmark interface {
 	// of a status bookmark created by the given accountID, targeting the given statusID.
 	GetStatusBookmarkID(ctx context.Context, accountID string, statusID string) (string, error)
 
-	// GetStatusBookmarks retrieves status bookmarks created by the given accountID,
-	// and using the provided parameters. If limit is < 0 then no limit will be set.
+	// GetStatusBookmarks retrieves a page of status bookmarks created by the given accountID.
 	//
-	// This function is primarily useful for paging through bookmarks in a sort of
-	// timeline view.
-	GetStatusBookmarks(ctx context.Context, accountID string, limit int, maxID string, minID string) ([]*gtsmodel.StatusBookmark, error)
+	// This function is primarily useful for paging through bookmarks in a sort of timeline
+	// view, so only the Status of each returned bookmark is populated. Bookmarks of statuses
+	// that could not be found are left out.
+	GetStatusBookmarks(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.StatusBookmark, error)
 
 	// PutStatusBookmark inserts the given statusBookmark into the database.
 	PutStatusBookmark(ctx context.Context, statusBookmark *gtsmodel.StatusBookmark) error
@@ -19,8 +19,10 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
 // Tag contains functions for getting/creating tags in the database.
@@ -36,4 +38,62 @@ type Tag interface {
 
 	// GetTags gets multiple tags.
 	GetTags(ctx context.Context, ids []string) ([]*gtsmodel.Tag, error)
+
+	// IncrementTagHistory records one use of the tag with the given ID by the
+	// given status, on the (UTC) day the status was created. The status account
+	// is counted as a new account for the day if it hasn't used the tag already.
+	IncrementTagHistory(ctx context.Context, tagID string, status *gtsmodel.Status) error
+
+	// GetTagHistory gets the daily usage history of the tag with the given ID,
+	// newest first, for days starting from the given time. Days without use are omitted.
+	GetTagHistory(ctx context.Context, tagID string, since time.Time) ([]*gtsmodel.TagHistory, error)
+
+	// GetTrendingTags gets up to limit usable, listable tags used by the most
+	// accounts (then by the most statuses) on days starting from the given time.
+	GetTrendingTags(ctx context.Context, since time.Time, limit int) ([]*gtsmodel.Tag, error)
+
+	// GetFollowedTag gets the follow of the tag with the given ID by the given account.
+	GetFollowedTag(ctx context.Context, accountID string, tagID string) (*gtsmodel.FollowedTag, error)
+
+	// GetAccountFollowedTags gets a page of the tags followed by the given account, newest follows first.
+	GetAccountFollowedTags(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.FollowedTag, error)
+
+	// GetTagFollowerAccountIDs gets the IDs of all accounts following any of the tags with the given IDs.
+	GetTagFollowerAccountIDs(ctx context.Context, tagIDs []string) ([]string, error)
+
+	// PutFollowedTag inserts the given tag follow in the database.
+	PutFollowedTag(ctx context.Context, followedTag *gtsmodel.FollowedTag) error
+
+	// DeleteFollowedTag deletes the follow of the tag with the given ID by the given account, if it exists.
+	DeleteFollowedTag(ctx context.Context, accountID string, tagID string) error
+
+	// DeleteFollowedTagsByAccountID deletes all tag follows by the given account.
+	DeleteFollowedTagsByAccountID(ctx context.Context, accountID string) error
+
+	// GetFeaturedTagByID gets the featured tag with the given ID.
+	GetFeaturedTagByID(ctx context.Context, id string) (*gtsmodel.FeaturedTag, error)
+
+	// GetFeaturedTag gets the featuring of the tag with the given ID by the given account.
+	GetFeaturedTag(ctx context.Context, accountID string, tagID string) (*gtsmodel.FeaturedTag, error)
+
+	// GetAccountFeaturedTags gets all tags featured by the given account, oldest features first.
+	GetAccountFeaturedTags(ctx context.Context, accountID string) ([]*gtsmodel.FeaturedTag, error)
+
+	// GetAccountTagStats gets the amount of public and unlisted statuses by the given
+	// account using the tag with the given ID, and when the latest of them was created.
+	GetAccountTagStats(ctx context.Context, accountID string, tagID string) (count int, lastStatusAt time.Time, err error)
+
+	// GetAccountMostUsedTags gets up to limit usable tags used in the most
+	// public and unlisted statuses by the given account, excluding tags
+	// already featured by the account.
+	GetAccountMostUsedTags(ctx context.Context, accountID string, limit int) ([]*gtsmodel.Tag, error)
+
+	// PutFeaturedTag inserts the given featured tag in the database.
+	PutFeaturedTag(ctx context.Context, featuredTag *gtsmodel.FeaturedTag) error
+
+	// DeleteFeaturedTagByID deletes the featured tag with the given ID.
+	DeleteFeaturedTagByID(ctx context.Context, id string) error
+
+	// DeleteFeaturedTagsByAccountID deletes all tags featured by the given account.
+	DeleteFeaturedTagsByAccountID(ctx context.Context, accountID string) error
 }
@@ -0,0 +1,36 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type TermsVersion interface {
+	// GetTermsVersionByID gets one version of the instance terms by its ID.
+	GetTermsVersionByID(ctx context.Context, id string) (*gtsmodel.TermsVersion, error)
+
+	// GetTermsVersions gets all stored versions
+	// of the instance terms, newest first.
+	GetTermsVersions(ctx context.Context) ([]*gtsmodel.TermsVersion, error)
+
+	// PutTermsVersion puts the given TermsVersion in the database.
+	PutTermsVersion(ctx context.Context, version *gtsmodel.TermsVersion) error
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
 // Timeline contains functionality for retrieving home/public/faved etc timelines for an account.
@@ -30,6 +31,11 @@ type Timeline interface {
 	// Statuses should be returned in descending order of when they were created (newest first).
 	GetHomeTimeline(ctx context.Context, accountID string, maxID string, sinceID string, minID string, limit int, local bool) ([]*gtsmodel.Status, error)
 
+	// CountHomeTimeline counts the statuses in the home timeline of the given account
+	// newer than sinceID (or all of them, if sinceID is not set), up to the given limit.
+	// Unlike GetHomeTimeline, no visibility filtering is involved, so the count is an upper bound.
+	CountHomeTimeline(ctx context.Context, accountID string, sinceID string, limit int) (int, error)
+
 	// GetPublicTimeline fetches the account's PUBLIC timeline -- ie., posts and replies that are public.
 	// It will use the given filters and try to return as many statuses as possible up to the limit.
 	//
@@ -37,19 +43,20 @@ type Timeline interface {
 	GetPublicTimeline(ctx context.Context, maxID string, sinceID string, minID string, limit int, local bool) ([]*gtsmodel.Status, error)
 
 	// GetFavedTimeline fetches the account's FAVED timeline -- ie., posts and replies that the requesting account has faved.
-	// It will use the given filters and try to return as many statuses as possible up to the limit.
+	// It will use the given page and try to return as many statuses as possible up to the page limit.
 	//
 	// Note that unlike the other GetTimeline functions, the returned statuses will be arranged by their FAVE id, not the STATUS id.
 	// In other words, they'll be returned in descending order of when they were faved by the requesting user, not when they were created.
 	//
-	// Also note the extra return values, which correspond to the nextMaxID and prevMinID for building Link headers.
-	GetFavedTimeline(ctx context.Context, accountID string, maxID string, minID string, limit int) ([]*gtsmodel.Status, string, string, error)
+	// Also note the extra return values, which correspond to the lowest and highest fave IDs for building Link headers.
+	GetFavedTimeline(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.Status, string, string, error)
 
 	// GetListTimeline returns a slice of statuses from followed accounts collected within the list with the given listID.
 	// Statuses should be returned in descending order of when they were created (newest first).
 	GetListTimeline(ctx context.Context, listID string, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.Status, error)
 
-	// GetTagTimeline returns a slice of public-visibility statuses that use the given tagID.
+	// GetTagTimeline returns a slice of public-visibility statuses that use at least one of anyTagIDs,
+	// every one of allTagIDs, and none of noneTagIDs. allTagIDs and noneTagIDs may be empty.
 	// Statuses should be returned in descending order of when they were created (newest first).
-	GetTagTimeline(ctx context.Context, tagID string, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.Status, error)
+	GetTagTimeline(ctx context.Context, anyTagIDs []string, allTagIDs []string, noneTagIDs []string, maxID string, sinceID string, minID string, limit int, local bool) ([]*gtsmodel.Status, error)
 }
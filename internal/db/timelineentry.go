@@ -0,0 +1,63 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// TimelineEntry contains functions for storing and retrieving
+// home and list timelines that are materialized in the database.
+type TimelineEntry interface {
+	// GetTimelineEntries returns entries of the given timeline between the given IDs,
+	// in descending order of status ID (newest first). If minID is set, entries
+	// immediately newer than minID are selected, else those immediately older than maxID.
+	GetTimelineEntries(ctx context.Context, timelineType gtsmodel.TimelineType, timelineID string, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.TimelineEntry, error)
+
+	// CountTimelineEntries returns the number of entries in the given timeline.
+	CountTimelineEntries(ctx context.Context, timelineType gtsmodel.TimelineType, timelineID string) (int, error)
+
+	// GetTimelineIDs returns the IDs of all timelines of the given type that have entries.
+	GetTimelineIDs(ctx context.Context, timelineType gtsmodel.TimelineType) ([]string, error)
+
+	// PutTimelineEntry inserts the given entry, doing nothing if the status is already in the timeline.
+	PutTimelineEntry(ctx context.Context, entry *gtsmodel.TimelineEntry) error
+
+	// DeleteTimelineEntry deletes the given status from the given timeline, returning the number of entries removed.
+	DeleteTimelineEntry(ctx context.Context, timelineType gtsmodel.TimelineType, timelineID string, statusID string) (int, error)
+
+	// DeleteTimelineEntries deletes all entries of the given timeline.
+	DeleteTimelineEntries(ctx context.Context, timelineType gtsmodel.TimelineType, timelineID string) error
+
+	// DeleteTimelineEntriesByStatusID deletes the given status from all timelines of the given type.
+	DeleteTimelineEntriesByStatusID(ctx context.Context, timelineType gtsmodel.TimelineType, statusID string) error
+
+	// DeleteTimelineEntriesByAccountID deletes all entries in the given timeline created
+	// by, or boosting statuses by, the given account, returning the number of entries removed.
+	DeleteTimelineEntriesByAccountID(ctx context.Context, timelineType gtsmodel.TimelineType, timelineID string, accountID string) (int, error)
+
+	// DeleteTimelineBoostsByAccountID deletes all entries in the given timeline which are
+	// boosts created by the given account, returning the number of entries removed.
+	DeleteTimelineBoostsByAccountID(ctx context.Context, timelineType gtsmodel.TimelineType, timelineID string, accountID string) (int, error)
+
+	// PruneTimelineEntries deletes all but the newest keep entries of the
+	// given timeline, returning the number of entries removed.
+	PruneTimelineEntries(ctx context.Context, timelineType gtsmodel.TimelineType, timelineID string, keep int) (int, error)
+}
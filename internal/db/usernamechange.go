@@ -0,0 +1,42 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type UsernameChange interface {
+	// GetUsernameChangeByOldUsername gets the change away from the
+	// given (local) username, ie., the change that retired it.
+	GetUsernameChangeByOldUsername(ctx context.Context, username string) (*gtsmodel.UsernameChange, error)
+
+	// GetUsernameChangesByAccountID gets all username
+	// changes of the given local account, newest first.
+	GetUsernameChangesByAccountID(ctx context.Context, accountID string) ([]*gtsmodel.UsernameChange, error)
+
+	// PutUsernameChange puts the given UsernameChange in the database,
+	// and invalidates any cached copy of the changed account. It should
+	// be called before updating the account with its new username.
+	PutUsernameChange(ctx context.Context, change *gtsmodel.UsernameChange) error
+
+	// DeleteUsernameChangeByID deletes the UsernameChange with the given ID.
+	DeleteUsernameChangeByID(ctx context.Context, id string) error
+}
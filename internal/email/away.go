@@ -0,0 +1,54 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package email
+
+var (
+	awayNotificationsTemplate = "email_away_notifications.tmpl"
+	awayNotificationsSubject  = "GoToSocial New Mentions"
+)
+
+type AwayNotificationsData struct {
+	// Username to be addressed.
+	Username string
+	// URL of the instance to present to the receiver.
+	InstanceURL string
+	// Name of the instance to present to the receiver.
+	InstanceName string
+	// Mentions received while
+	// the receiver was away.
+	Mentions []AwayMention
+	// More is true if there were more
+	// mentions than those listed.
+	More bool
+}
+
+type AwayMention struct {
+	// Account that mentioned the receiver,
+	// as @username or @username@domain.
+	From string
+	// True if the mention was in a
+	// direct message to the receiver.
+	Direct bool
+	// URL of the status containing
+	// the mention.
+	StatusURL string
+}
+
+func (s *sender) SendAwayNotificationsEmail(toAddress string, data AwayNotificationsData) error {
+	return s.sendTemplate(awayNotificationsTemplate, awayNotificationsSubject, data, toAddress)
+}
@@ -185,6 +185,47 @@ func (suite *EmailTestSuite) TestTemplateReportClosedLocalAccountNoComment() {
 	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Report Closed\r\nMIME-Version: 1.0\r\nContent-Transfer-Encoding: 8bit\r\nContent-Type: text/plain; charset=\"UTF-8\"\r\n\r\nHello !\r\n\r\nYou recently reported the account @1happyturtle to the moderator(s) of Test Instance (https://example.org).\r\n\r\nThe report you submitted has now been closed.\r\n\r\nThe moderator who closed the report did not leave a comment.\r\n\r\n---\r\n\r\nIf you believe you've been sent this email in error, feel free to ignore it, or contact the administrator of https://example.org.\r\n\r\n", suite.sentEmails["user@example.org"])
 }
 
+func (suite *EmailTestSuite) TestTemplateAwayNotifications() {
+	awayData := email.AwayNotificationsData{
+		Username:     "test",
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+		Mentions: []email.AwayMention{
+			{
+				From:      "@foss_satan@fossbros-anonymous.io",
+				StatusURL: "http://fossbros-anonymous.io/@foss_satan/01FVW7JHQFSFK166WWKR8CBA6M",
+			},
+			{
+				From:      "@1happyturtle",
+				Direct:    true,
+				StatusURL: "https://example.org/@1happyturtle/statuses/01FN3VJGFH10KR7S2PB0GFJZYG",
+			},
+		},
+	}
+
+	if err := suite.sender.SendAwayNotificationsEmail("user@example.org", awayData); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial New Mentions\r\nMIME-Version: 1.0\r\nContent-Transfer-Encoding: 8bit\r\nContent-Type: text/plain; charset=\"UTF-8\"\r\n\r\nHello test!\r\n\r\nYou are receiving this mail because you were mentioned on Test Instance while you were away, and you asked to be told about this by email.\r\n\r\nMention from @foss_satan@fossbros-anonymous.io: http://fossbros-anonymous.io/@foss_satan/01FVW7JHQFSFK166WWKR8CBA6M\r\nDirect message from @1happyturtle: https://example.org/@1happyturtle/statuses/01FN3VJGFH10KR7S2PB0GFJZYG\r\n\r\nTo stop receiving these emails, change your account settings at https://example.org/settings/user/settings.\r\n\r\n---\r\n\r\nIf you believe you've been sent this email in error, feel free to ignore it, or contact the administrator of https://example.org.\r\n\r\n", suite.sentEmails["user@example.org"])
+}
+
+func (suite *EmailTestSuite) TestTemplateAccountFrozen() {
+	frozenData := email.AccountFrozenData{
+		Username:     "test",
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+		FrozenUntil:  "Jul 30 2021 09:20:25 UTC",
+		Reason:       "Please calm down a bit.",
+	}
+
+	if err := suite.sender.SendAccountFrozenEmail("user@example.org", frozenData); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Account Frozen\r\nMIME-Version: 1.0\r\nContent-Transfer-Encoding: 8bit\r\nContent-Type: text/plain; charset=\"UTF-8\"\r\n\r\nHello test!\r\n\r\nYou are receiving this mail because your account on Test Instance has been temporarily frozen by a moderator.\r\n\r\nWhile your account is frozen, you will not be able to log in or use it through any client application. Your posts and profile remain visible, and nothing has been removed.\r\n\r\nYour account will be unfrozen automatically at Jul 30 2021 09:20:25 UTC.\r\n\r\nThe moderator who froze your account included the following message: \"Please calm down a bit.\"\r\n\r\n---\r\n\r\nIf you believe you've been sent this email in error, feel free to ignore it, or contact the administrator of https://example.org.\r\n\r\n", suite.sentEmails["user@example.org"])
+}
+
 func TestEmailTestSuite(t *testing.T) {
 	suite.Run(t, new(EmailTestSuite))
 }
@@ -0,0 +1,41 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package email
+
+var (
+	accountFrozenTemplate = "email_account_frozen.tmpl"
+	accountFrozenSubject  = "GoToSocial Account Frozen"
+)
+
+type AccountFrozenData struct {
+	// Username to be addressed.
+	Username string
+	// URL of the instance to present to the receiver.
+	InstanceURL string
+	// Name of the instance to present to the receiver.
+	InstanceName string
+	// Human-readable time at which
+	// the account will be unfrozen.
+	FrozenUntil string
+	// Reason given by the moderator, if any.
+	Reason string
+}
+
+func (s *sender) SendAccountFrozenEmail(toAddress string, data AccountFrozenData) error {
+	return s.sendTemplate(accountFrozenTemplate, accountFrozenSubject, data, toAddress)
+}
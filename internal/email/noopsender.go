@@ -80,6 +80,14 @@ func (s *noopSender) SendSignupRejectedEmail(toAddress string, data SignupReject
 	return s.sendTemplate(signupRejectedTemplate, signupRejectedSubject, data, toAddress)
 }
 
+func (s *noopSender) SendAwayNotificationsEmail(toAddress string, data AwayNotificationsData) error {
+	return s.sendTemplate(awayNotificationsTemplate, awayNotificationsSubject, data, toAddress)
+}
+
+func (s *noopSender) SendAccountFrozenEmail(toAddress string, data AccountFrozenData) error {
+	return s.sendTemplate(accountFrozenTemplate, accountFrozenSubject, data, toAddress)
+}
+
 func (s *noopSender) sendTemplate(template string, subject string, data any, toAddresses ...string) error {
 	buf := &bytes.Buffer{}
 	if err := s.template.ExecuteTemplate(buf, template, data); err != nil {
@@ -61,6 +61,14 @@ type Sender interface {
 	// SendSignupRejectedEmail sends an email to the given address
 	// that their sign-up request has been rejected by a moderator.
 	SendSignupRejectedEmail(toAddress string, data SignupRejectedData) error
+
+	// SendAwayNotificationsEmail sends an email to the given address listing
+	// mentions and direct messages received while the user was away.
+	SendAwayNotificationsEmail(toAddress string, data AwayNotificationsData) error
+
+	// SendAccountFrozenEmail sends an email to the given address that
+	// their account has been temporarily frozen by a moderator.
+	SendAccountFrozenEmail(toAddress string, data AccountFrozenData) error
 }
 
 // NewSender returns a new email Sender interface with the given configuration, or an error if something goes wrong.
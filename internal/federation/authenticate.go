@@ -45,6 +45,16 @@ var (
 	errUnsigned = errors.New("http request wasn't signed or http signature was invalid")
 )
 
+const (
+	// instanceSeenFreq is the minimum duration
+	// between updates of an instance's last seen.
+	instanceSeenFreq = time.Hour
+
+	// instanceFetchFreq is the maximum duration after
+	// which we consider instance metadata to be stale.
+	instanceFetchFreq = 7 * 24 * time.Hour
+)
+
 // PubKeyAuth models authorization information for a remote
 // Actor making a signed HTTP request to this GtS instance
 // using a public key.
@@ -110,7 +120,7 @@ func (f *Federator) AuthenticateFederatedRequest(ctx context.Context, requestedU
 	// this is an unsigned request.
 	verifier := gtscontext.HTTPSignatureVerifier(ctx)
 	if verifier == nil {
-		err := gtserror.Newf("%w", errUnsigned)
+		err := gtserror.SetType(gtserror.Newf("%w", errUnsigned), gtserror.TypeSignatureMissing)
 		errWithCode := gtserror.NewErrorUnauthorized(err, errUnsigned.Error(), "(verifier)")
 		return nil, errWithCode
 	}
@@ -118,7 +128,7 @@ func (f *Federator) AuthenticateFederatedRequest(ctx context.Context, requestedU
 	// We should have the signature itself set too.
 	signature := gtscontext.HTTPSignature(ctx)
 	if signature == "" {
-		err := gtserror.Newf("%w", errUnsigned)
+		err := gtserror.SetType(gtserror.Newf("%w", errUnsigned), gtserror.TypeSignatureMissing)
 		errWithCode := gtserror.NewErrorUnauthorized(err, errUnsigned.Error(), "(signature)")
 		return nil, errWithCode
 	}
@@ -126,7 +136,7 @@ func (f *Federator) AuthenticateFederatedRequest(ctx context.Context, requestedU
 	// And finally the public key ID URI.
 	pubKeyID := gtscontext.HTTPSignaturePubKeyID(ctx)
 	if pubKeyID == nil {
-		err := gtserror.Newf("%w", errUnsigned)
+		err := gtserror.SetType(gtserror.Newf("%w", errUnsigned), gtserror.TypeSignatureMissing)
 		errWithCode := gtserror.NewErrorUnauthorized(err, errUnsigned.Error(), "(pubKeyID)")
 		return nil, errWithCode
 	}
@@ -175,7 +185,8 @@ func (f *Federator) AuthenticateFederatedRequest(ctx context.Context, requestedU
 
 		const format = "authentication NOT PASSED for public key %s; tried algorithms %+v; signature value was '%s'"
 		text := fmt.Sprintf(format, pubKeyIDStr, signingAlgorithms, signature)
-		return nil, gtserror.NewErrorUnauthorized(errors.New(text), text)
+		err := gtserror.SetType(errors.New(text), gtserror.TypeSignatureInvalid)
+		return nil, gtserror.NewErrorUnauthorized(err, text)
 	}
 
 	if pubKeyAuth.Owner == nil {
@@ -225,6 +236,7 @@ func (f *Federator) AuthenticateFederatedRequest(ctx context.Context, requestedU
 				"key mismatch: fetched key %s does not match pubkey of fetched Actor %s",
 				pubKeyID, pubKeyAuth.Owner.URI,
 			)
+			err = gtserror.SetType(err, gtserror.TypeSignatureInvalid)
 			return nil, gtserror.NewErrorUnauthorized(err)
 		}
 	}
@@ -234,6 +246,17 @@ func (f *Federator) AuthenticateFederatedRequest(ctx context.Context, requestedU
 		return nil, gtserror.NewErrorForbidden(errors.New(text))
 	}
 
+	if !isLocal {
+		// Mark the instance of the requester as
+		// seen, this error isn't fatal to the req.
+		if err := f.markInstanceSeen(ctx,
+			requestedUsername,
+			pubKeyAuth.OwnerURI,
+		); err != nil {
+			l.Errorf("error marking instance seen: %v", err)
+		}
+	}
+
 	return pubKeyAuth, nil
 }
 
@@ -482,6 +505,10 @@ func (f *Federator) fetchAccountInstance(
 		return gtserror.Newf("error dereferencing instance %s: %w", accountURI.Host, err)
 	}
 
+	// We've only just seen this instance,
+	// as it has sent us a signed request.
+	instance.LastSeenAt = time.Now()
+
 	// Insert new instance into the datbase.
 	err = f.db.PutInstance(ctx, instance)
 	if err != nil && !errors.Is(err, db.ErrAlreadyExists) {
@@ -491,6 +518,137 @@ func (f *Federator) fetchAccountInstance(
 	return nil
 }
 
+// markInstanceSeen updates the last seen time of the instance
+// stored for the given account URI, and schedules a background
+// refresh of the instance's metadata if it has become stale.
+func (f *Federator) markInstanceSeen(
+	ctx context.Context,
+	requestedUsername string,
+	accountURI *url.URL,
+) error {
+	instance, err := f.db.GetInstance(
+		gtscontext.SetBarebones(ctx),
+		accountURI.Host,
+	)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// Not stored (yet),
+			// nothing to mark.
+			return nil
+		}
+		return gtserror.Newf("error getting instance from database: %w", err)
+	}
+
+	now := time.Now()
+
+	if now.Sub(instance.FetchedAt) > instanceFetchFreq {
+		// Instance metadata is stale, refresh
+		// it asynchronously to the request.
+		if err := f.scheduleInstanceRefresh(ctx,
+			requestedUsername,
+			instance.Domain,
+		); err != nil {
+			return err
+		}
+	}
+
+	if now.Sub(instance.LastSeenAt) < instanceSeenFreq {
+		// Seen recently enough, avoid
+		// excess database writes.
+		return nil
+	}
+
+	instance.LastSeenAt = now
+	if err := f.db.UpdateInstance(ctx, instance, "last_seen_at"); err != nil {
+		return gtserror.Newf("error updating instance %s: %w", instance.Domain, err)
+	}
+
+	return nil
+}
+
+// scheduleInstanceRefresh records a refresh attempt for the
+// instance with given domain and queues the refresh itself.
+// The attempt time is stored before dereferencing, so that
+// neither concurrent requests nor a failing remote cause the
+// refresh to be queued again before instanceFetchFreq passes.
+func (f *Federator) scheduleInstanceRefresh(
+	ctx context.Context,
+	requestedUsername string,
+	domain string,
+) error {
+	// Acquire per-instance lock, to
+	// avoid concurrent duplicate refreshes.
+	unlock := f.state.FedLocks.Lock("instance:" + domain)
+	defer unlock()
+
+	instance, err := f.db.GetInstance(
+		gtscontext.SetBarebones(ctx),
+		domain,
+	)
+	if err != nil {
+		return gtserror.Newf("error getting instance from database: %w", err)
+	}
+
+	if time.Since(instance.FetchedAt) < instanceFetchFreq {
+		// Already attempted
+		// in the meantime.
+		return nil
+	}
+
+	instance.FetchedAt = time.Now()
+	if err := f.db.UpdateInstance(ctx, instance, "fetched_at"); err != nil {
+		return gtserror.Newf("error updating instance %s: %w", domain, err)
+	}
+
+	f.state.Workers.Dereference.Queue.Push(func(ctx context.Context) {
+		if err := f.refreshInstance(ctx, requestedUsername, domain); err != nil {
+			log.Errorf(ctx, "error refreshing instance %s: %v", domain, err)
+		}
+	})
+
+	return nil
+}
+
+// refreshInstance re-dereferences the instance with given
+// domain, updating the stored software / version details.
+func (f *Federator) refreshInstance(
+	ctx context.Context,
+	requestedUsername string,
+	domain string,
+) error {
+	instance, err := f.db.GetInstance(
+		gtscontext.SetBarebones(ctx),
+		domain,
+	)
+	if err != nil {
+		return gtserror.Newf("error getting instance from database: %w", err)
+	}
+
+	instanceURI, err := url.Parse(instance.URI)
+	if err != nil {
+		return gtserror.Newf("invalid instance uri %s: %w", instance.URI, err)
+	}
+
+	latest, err := f.GetRemoteInstance(
+		gtscontext.SetFastFail(ctx),
+		requestedUsername,
+		instanceURI,
+	)
+	if err != nil {
+		return gtserror.Newf("error dereferencing instance: %w", err)
+	}
+
+	if latest.Version == "" {
+		// Only update when a version
+		// could actually be determined.
+		return nil
+	}
+
+	instance.Software = latest.Software
+	instance.Version = latest.Version
+	return f.db.UpdateInstance(ctx, instance, "software", "version")
+}
+
 // parsePubKeyBytes extracts an rsa public key from the
 // given pubKeyBytes by trying to parse the pubKeyBytes
 // as an ActivityPub type. It will return the public key
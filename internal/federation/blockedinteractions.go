@@ -0,0 +1,217 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// blockedInteractions counts attempts by blocked
+// remote accounts to interact with the local
+// accounts blocking them, keyed by blocker and
+// blocked account ID, within the configured window.
+type blockedInteractions struct {
+	m         map[string][]time.Time
+	lastPrune time.Time
+	mu        sync.Mutex
+}
+
+// record records an interaction attempt by blocked
+// account towards blocker at given time, returning
+// the number of attempts within the window. If the
+// threshold is reached, the count is reset.
+func (b *blockedInteractions) record(
+	blockerID string,
+	blockedID string,
+	now time.Time,
+	threshold int,
+	window time.Duration,
+) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.m == nil {
+		b.m = make(map[string][]time.Time)
+	}
+
+	cutoff := now.Add(-window)
+	if b.lastPrune.Before(cutoff) {
+		// Periodically drop counts that have
+		// expired entirely, so that pairs of
+		// accounts that aren't heard from
+		// again don't stick around forever.
+		for key, times := range b.m {
+			if times[len(times)-1].Before(cutoff) {
+				delete(b.m, key)
+			}
+		}
+		b.lastPrune = now
+	}
+
+	key := blockerID + blockedID
+	times := b.m[key]
+
+	// Times are appended in order,
+	// so find first one in window.
+	var i int
+	for i < len(times) && times[i].Before(cutoff) {
+		i++
+	}
+
+	times = append(times[i:], now)
+	count := len(times)
+
+	if count >= threshold {
+		delete(b.m, key)
+	} else {
+		b.m[key] = times
+	}
+
+	return count
+}
+
+// recordBlockedInteraction records an attempt by the (remote) requesting
+// account to interact with the local receiving account that blocks it.
+// If enabled, and the configured threshold of attempts is reached within
+// the configured window, a report about the requesting account is opened
+// on behalf of the instance, so admins + moderators can take a look.
+func (f *Federator) recordBlockedInteraction(
+	ctx context.Context,
+	receivingAccount *gtsmodel.Account,
+	requestingAccount *gtsmodel.Account,
+) {
+	threshold := config.GetInstanceBlockedInteractionsThreshold()
+	if threshold <= 0 || requestingAccount.IsLocal() {
+		// Disabled, or nothing to do.
+		return
+	}
+
+	window := config.GetInstanceBlockedInteractionsWindow()
+	count := f.blockedInteractions.record(
+		receivingAccount.ID,
+		requestingAccount.ID,
+		time.Now(),
+		threshold,
+		window,
+	)
+
+	if count < threshold {
+		// Not (yet) worth a report.
+		return
+	}
+
+	if err := f.openBlockedInteractionsReport(
+		ctx,
+		receivingAccount,
+		requestingAccount,
+		count,
+		window,
+	); err != nil {
+		log.Errorf(ctx, "error opening report: %v", err)
+	}
+}
+
+// openBlockedInteractionsReport opens a report about requesting account
+// on behalf of the instance, or, if the instance already has an unresolved
+// report open about that account, adds the new attempts to that one instead,
+// so that moderators aren't flooded with reports about the same account.
+func (f *Federator) openBlockedInteractionsReport(
+	ctx context.Context,
+	receivingAccount *gtsmodel.Account,
+	requestingAccount *gtsmodel.Account,
+	count int,
+	window time.Duration,
+) error {
+	instanceAcct, err := f.db.GetInstanceAccount(ctx, "")
+	if err != nil {
+		return gtserror.Newf("db error getting instance account: %w", err)
+	}
+
+	comment := fmt.Sprintf(
+		"Automatically opened: %d attempts within %s to interact with @%s, who blocks this account.",
+		count, window, receivingAccount.Username,
+	)
+
+	// Check for an existing unresolved
+	// report about this account by us.
+	reports, err := f.db.GetReports(
+		ctx,
+		util.Ptr(false),
+		instanceAcct.ID,
+		requestingAccount.ID,
+		"", "", "", 1,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting reports: %w", err)
+	}
+
+	if len(reports) != 0 {
+		// Add these attempts to the
+		// report that's already open.
+		report := reports[0]
+		report.Comment += "\n" + comment
+		if _, err := f.db.UpdateReport(ctx, report, "comment"); err != nil {
+			return gtserror.Newf("db error updating report: %w", err)
+		}
+
+		return nil
+	}
+
+	reportID := id.NewULID()
+	report := &gtsmodel.Report{
+		ID:              reportID,
+		URI:             uris.GenerateURIForReport(reportID),
+		AccountID:       instanceAcct.ID,
+		Account:         instanceAcct,
+		TargetAccountID: requestingAccount.ID,
+		TargetAccount:   requestingAccount,
+		Comment:         comment,
+		Forwarded:       util.Ptr(false),
+	}
+
+	if err := f.db.PutReport(ctx, report); err != nil {
+		return gtserror.Newf("db error storing report: %w", err)
+	}
+
+	// Process report side effects
+	// (eg., emailing moderators).
+	f.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   ap.ObjectProfile,
+		APActivityType: ap.ActivityFlag,
+		GTSModel:       report,
+		Origin:         instanceAcct,
+		Target:         requestingAccount,
+	})
+
+	return nil
+}
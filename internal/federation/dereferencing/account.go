@@ -35,6 +35,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
@@ -681,9 +682,10 @@ func (d *Dereferencer) enrichAccount(
 	}
 
 	// Ensure the final parsed account URI / URL matches
-	// the input URI we fetched (or received) it as.
-	if expect := uri.String(); latestAcc.URI != expect &&
-		latestAcc.URL != expect {
+	// the input URI we fetched (or received) it as,
+	// ignoring differences in host case / default port.
+	if expect := uris.Normalize(uri.String()); uris.Normalize(latestAcc.URI) != expect &&
+		uris.Normalize(latestAcc.URL) != expect {
 		return nil, nil, gtserror.Newf(
 			"dereferenced account uri %s does not match %s",
 			latestAcc.URI, expect,
@@ -714,7 +716,8 @@ func (d *Dereferencer) enrichAccount(
 	}
 
 	// Fetch the latest remote account emoji IDs used in account display name/bio.
-	if _, err = d.fetchRemoteAccountEmojis(ctx, latestAcc, requestUser); err != nil {
+	_, emojisDeferred, err := d.fetchRemoteAccountEmojis(ctx, latestAcc, requestUser)
+	if err != nil {
 		log.Errorf(ctx, "error fetching remote emojis for account %s: %v", uri, err)
 	}
 
@@ -723,6 +726,10 @@ func (d *Dereferencer) enrichAccount(
 		log.Errorf(ctx, "error fetching remote stats for account %s: %v", uri, err)
 	}
 
+	// Keep verification of unchanged profile fields,
+	// checking whether any new field links need it.
+	verifyFields := carryFieldVerifications(account, latestAcc)
+
 	if account.IsNew() {
 		// Prefer published/created time from
 		// apubAcc, fall back to FetchedAt value.
@@ -754,6 +761,33 @@ func (d *Dereferencer) enrichAccount(
 		}
 	}
 
+	if verifyFields {
+		// Verifying fields means fetching each linked
+		// page, so do this asynchronously to the deref.
+		accountID := latestAcc.ID
+		d.state.Workers.Dereference.Queue.Push(func(ctx context.Context) {
+			if err := d.verifyAccountFields(ctx, requestUser, accountID); err != nil {
+				log.Errorf(ctx, "error verifying fields of account %s: %v", uri, err)
+			}
+		})
+	}
+
+	if emojisDeferred {
+		// Some emojis were left out as the dereference budget
+		// is spent. Enrich the account again in the background,
+		// where there's no budget, to fetch and link them.
+		d.state.Workers.Dereference.Queue.Push(func(ctx context.Context) {
+			if _, _, err := d.enrichAccountSafely(ctx,
+				requestUser,
+				uri,
+				latestAcc,
+				apubAcc,
+			); err != nil {
+				log.Errorf(ctx, "error enriching account %s with deferred emojis: %v", uri, err)
+			}
+		})
+	}
+
 	return latestAcc, apubAcc, nil
 }
 
@@ -798,6 +832,21 @@ func (d *Dereferencer) fetchRemoteAccountAvatar(ctx context.Context, tsport tran
 		return gtserror.Newf("error parsing url %s: %w", latestAcc.AvatarRemoteURL, err)
 	}
 
+	if config.GetMediaAccountLazyFetch() {
+		// Only store a placeholder for now, the avatar
+		// will be fetched when it's first viewed.
+		placeholder, err := d.mediaManager.PlaceholderMedia(ctx, latestAcc.ID, &media.AdditionalMediaInfo{
+			Avatar:    util.Ptr(true),
+			RemoteURL: &latestAcc.AvatarRemoteURL,
+		})
+		if err != nil {
+			return gtserror.Newf("error storing placeholder %s: %w", latestAcc.AvatarRemoteURL, err)
+		}
+
+		latestAcc.AvatarMediaAttachmentID = placeholder.ID
+		return nil
+	}
+
 	// Acquire lock for derefs map.
 	unlock := d.state.FedLocks.Lock(latestAcc.AvatarRemoteURL)
 	unlock = util.DoOnce(unlock)
@@ -884,6 +933,21 @@ func (d *Dereferencer) fetchRemoteAccountHeader(ctx context.Context, tsport tran
 		return gtserror.Newf("error parsing url %s: %w", latestAcc.HeaderRemoteURL, err)
 	}
 
+	if config.GetMediaAccountLazyFetch() {
+		// Only store a placeholder for now, the header
+		// will be fetched when it's first viewed.
+		placeholder, err := d.mediaManager.PlaceholderMedia(ctx, latestAcc.ID, &media.AdditionalMediaInfo{
+			Header:    util.Ptr(true),
+			RemoteURL: &latestAcc.HeaderRemoteURL,
+		})
+		if err != nil {
+			return gtserror.Newf("error storing placeholder %s: %w", latestAcc.HeaderRemoteURL, err)
+		}
+
+		latestAcc.HeaderMediaAttachmentID = placeholder.ID
+		return nil
+	}
+
 	// Acquire lock for derefs map.
 	unlock := d.state.FedLocks.Lock(latestAcc.HeaderRemoteURL)
 	unlock = util.DoOnce(unlock)
@@ -929,7 +993,9 @@ func (d *Dereferencer) fetchRemoteAccountHeader(ctx context.Context, tsport tran
 	return nil
 }
 
-func (d *Dereferencer) fetchRemoteAccountEmojis(ctx context.Context, targetAccount *gtsmodel.Account, requestingUsername string) (bool, error) {
+// fetchRemoteAccountEmojis populates the emojis of targetAccount, returning whether
+// they changed, and whether any were left out as the dereference budget is spent.
+func (d *Dereferencer) fetchRemoteAccountEmojis(ctx context.Context, targetAccount *gtsmodel.Account, requestingUsername string) (bool, bool, error) {
 	maybeEmojis := targetAccount.Emojis
 	maybeEmojiIDs := targetAccount.EmojiIDs
 
@@ -943,7 +1009,7 @@ func (d *Dereferencer) fetchRemoteAccountEmojis(ctx context.Context, targetAccou
 		for _, emojiID := range maybeEmojiIDs {
 			maybeEmoji, err := d.state.DB.GetEmojiByID(ctx, emojiID)
 			if err != nil {
-				return false, err
+				return false, false, err
 			}
 			maybeEmojis = append(maybeEmojis, maybeEmoji)
 		}
@@ -951,9 +1017,9 @@ func (d *Dereferencer) fetchRemoteAccountEmojis(ctx context.Context, targetAccou
 
 	// For all the maybe emojis we have, we either fetch them from the database
 	// (if we haven't already), or dereference them from the remote instance.
-	gotEmojis, err := d.populateEmojis(ctx, maybeEmojis, requestingUsername)
+	gotEmojis, deferred, err := d.populateEmojis(ctx, maybeEmojis, requestingUsername)
 	if err != nil {
-		return false, err
+		return false, false, err
 	}
 
 	// Extract the ID of each fetched or dereferenced emoji, so we can attach
@@ -972,7 +1038,7 @@ func (d *Dereferencer) fetchRemoteAccountEmojis(ctx context.Context, targetAccou
 	// if the length of everything is zero, this is simple:
 	// nothing has changed and there's nothing to do
 	if maybeLen == 0 && gotLen == 0 {
-		return changed, nil
+		return changed, deferred, nil
 	}
 
 	// if the *amount* of emojis on the account has changed, then the got emojis
@@ -983,7 +1049,7 @@ func (d *Dereferencer) fetchRemoteAccountEmojis(ctx context.Context, targetAccou
 		changed = true
 		targetAccount.Emojis = gotEmojis
 		targetAccount.EmojiIDs = gotEmojiIDs
-		return changed, nil
+		return changed, deferred, nil
 	}
 
 	// if the lengths are the same but not all of the slices are
@@ -1008,7 +1074,7 @@ func (d *Dereferencer) fetchRemoteAccountEmojis(ctx context.Context, targetAccou
 			changed = true
 			targetAccount.Emojis = gotEmojis
 			targetAccount.EmojiIDs = gotEmojiIDs
-			return changed, nil
+			return changed, deferred, nil
 		}
 	}
 
@@ -1034,11 +1100,11 @@ func (d *Dereferencer) fetchRemoteAccountEmojis(ctx context.Context, targetAccou
 			changed = true
 			targetAccount.Emojis = gotEmojis
 			targetAccount.EmojiIDs = gotEmojiIDs
-			return changed, nil
+			return changed, deferred, nil
 		}
 	}
 
-	return changed, nil
+	return changed, deferred, nil
 }
 
 func (d *Dereferencer) fetchRemoteAccountStats(ctx context.Context, account *gtsmodel.Account, requestUser string) error {
@@ -1176,6 +1242,13 @@ func (d *Dereferencer) dereferenceAccountFeatured(ctx context.Context, requestUs
 			break
 		}
 
+		if t := item.GetType(); t != nil &&
+			t.GetTypeName() == ap.TagHashtag {
+			// Featured hashtags are also
+			// listed here, skip them.
+			continue
+		}
+
 		// Check for available IRI.
 		itemIRI, _ := pub.ToId(item)
 		if itemIRI == nil {
@@ -1213,7 +1286,7 @@ func (d *Dereferencer) dereferenceAccountFeatured(ctx context.Context, requestUs
 			continue
 		}
 
-		if status.AccountURI != account.URI {
+		if uris.Normalize(status.AccountURI) != uris.Normalize(account.URI) {
 			// Someone's pinned a status that doesn't
 			// belong to them, this doesn't work for us.
 			continue
@@ -1241,7 +1314,7 @@ func (d *Dereferencer) dereferenceAccountFeatured(ctx context.Context, requestUs
 outerLoop:
 	for _, status := range wasPinned {
 		for _, statusURI := range statusURIs {
-			if status.URI == statusURI.String() {
+			if status.URI == uris.Normalize(statusURI.String()) {
 				// This status is included in most recent
 				// pinned uris. No need to keep checking.
 				continue outerLoop
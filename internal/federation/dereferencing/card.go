@@ -0,0 +1,303 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// FetchStatusCard ensures that the given status has an up-to-date
+// preview card for the first link in its content (or no card, if
+// there's no such link), updating the status in the database when
+// its card has changed. Returns whether the card of the status changed.
+func (d *Dereferencer) FetchStatusCard(
+	ctx context.Context,
+	requestUser string,
+	status *gtsmodel.Status,
+) (bool, error) {
+	var card *gtsmodel.Card
+
+	if link := statusCardLink(status); link != nil {
+		var err error
+
+		// Get (possibly cached) card for link.
+		card, err = d.GetCard(ctx, requestUser, link)
+		if err != nil {
+			// Not being able to make a card for
+			// a link is very normal, so just
+			// treat this as there being no card.
+			log.Debugf(ctx, "couldn't get card for %s: %v", link, err)
+		}
+	}
+
+	var cardID string
+	if card != nil {
+		cardID = card.ID
+	}
+
+	if cardID == status.CardID {
+		// Nothing changed.
+		status.Card = card
+		return false, nil
+	}
+
+	// Update the status card.
+	status.CardID = cardID
+	status.Card = card
+
+	if err := d.state.DB.UpdateStatus(ctx,
+		status,
+		"card_id",
+	); err != nil {
+		return false, gtserror.Newf("error updating status: %w", err)
+	}
+
+	return true, nil
+}
+
+// GetCard fetches the preview card for the given link, first checking
+// the database. Stored cards are refetched after DefaultCardFreshness,
+// though the stored card is returned unchanged if that refetch fails.
+func (d *Dereferencer) GetCard(
+	ctx context.Context,
+	requestUser string,
+	link *url.URL,
+) (*gtsmodel.Card, error) {
+	linkStr := link.String()
+
+	// Acquire per-URL deref lock, so we
+	// don't fetch (and store) any card twice.
+	unlock := d.state.FedLocks.Lock(linkStr)
+	defer unlock()
+
+	// Look for an existing card for this link.
+	card, err := d.state.DB.GetCardByURL(ctx, linkStr)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting card %s: %w", linkStr, err)
+	}
+
+	if card != nil && time.Since(card.FetchedAt) < time.Duration(*DefaultCardFreshness) {
+		// Card is still fresh.
+		return card, nil
+	}
+
+	latest, err := d.dereferenceCard(ctx, requestUser, link)
+	if err != nil {
+		if card != nil {
+			// Stale is better than nothing.
+			log.Debugf(ctx, "couldn't refresh card %s: %v", linkStr, err)
+			return card, nil
+		}
+		return nil, err
+	}
+
+	if card == nil {
+		// This is a new card, insert it.
+		latest.ID = id.NewULID()
+
+		if err := d.state.DB.PutCard(ctx, latest); err != nil {
+			return nil, gtserror.Newf("error putting card %s: %w", linkStr, err)
+		}
+
+		return latest, nil
+	}
+
+	// Update existing card.
+	latest.ID = card.ID
+	latest.CreatedAt = card.CreatedAt
+
+	if err := d.state.DB.UpdateCard(ctx, latest); err != nil {
+		return nil, gtserror.Newf("error updating card %s: %w", linkStr, err)
+	}
+
+	return latest, nil
+}
+
+// dereferenceCard fetches and sanitizes the preview card for the given link.
+func (d *Dereferencer) dereferenceCard(
+	ctx context.Context,
+	requestUser string,
+	link *url.URL,
+) (*gtsmodel.Card, error) {
+	blocked, err := d.state.DB.IsDomainBlocked(ctx, link.Host)
+	if err != nil {
+		return nil, gtserror.Newf("db error checking domain block: %w", err)
+	}
+
+	if blocked {
+		return nil, gtserror.Newf("%s is blocked", link.Host)
+	}
+
+	tsport, err := d.transportController.NewTransportForUsername(ctx, requestUser)
+	if err != nil {
+		return nil, gtserror.Newf("couldn't create transport: %w", err)
+	}
+
+	card, err := tsport.DereferenceCard(ctx, link)
+	if err != nil {
+		return nil, gtserror.Newf("error dereferencing card %s: %w", link, err)
+	}
+
+	// Card content comes straight from
+	// some web page, so clean it all up.
+	card.Title = text.SanitizeToPlaintext(card.Title)
+	card.Description = text.SanitizeToPlaintext(card.Description)
+	card.AuthorName = text.SanitizeToPlaintext(card.AuthorName)
+	card.ProviderName = text.SanitizeToPlaintext(card.ProviderName)
+	card.HTML = text.SanitizeToHTML(card.HTML)
+
+	if card.HTML == "" &&
+		(card.Type == gtsmodel.CardTypeVideo ||
+			card.Type == gtsmodel.CardTypeRich) {
+		// Nothing left to embed,
+		// fall back to a link card.
+		card.Type = gtsmodel.CardTypeLink
+	}
+
+	if card.Creator != "" {
+		// The page names a fediverse account
+		// as its creator, try to resolve it.
+		account, err := d.getCardCreator(ctx,
+			requestUser,
+			link,
+			card.Creator,
+		)
+		if err != nil {
+			log.Debugf(ctx, "couldn't get creator %s of %s: %v", card.Creator, link, err)
+		} else {
+			card.CreatorAccountID = account.ID
+			card.CreatorAccount = account
+		}
+	}
+
+	return card, nil
+}
+
+// getCardCreator resolves the fediverse:creator handle declared
+// by the page at the given link to an account. So that pages
+// can't claim to be by just anyone, the page has to be hosted
+// on one of the account's attribution domains (or a subdomain).
+func (d *Dereferencer) getCardCreator(
+	ctx context.Context,
+	requestUser string,
+	link *url.URL,
+	creator string,
+) (*gtsmodel.Account, error) {
+	if !strings.HasPrefix(creator, "@") {
+		// Handles are commonly
+		// given without leading @.
+		creator = "@" + creator
+	}
+
+	username, domain, err := util.ExtractNamestringParts(creator)
+	if err != nil {
+		return nil, gtserror.SetMalformed(err)
+	}
+
+	if domain == "" {
+		err := gtserror.Newf("no domain in creator %s", creator)
+		return nil, gtserror.SetMalformed(err)
+	}
+
+	account, _, err := d.getAccountByUsernameDomain(ctx,
+		requestUser,
+		username,
+		domain,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if account.IsSuspended() {
+		return nil, gtserror.Newf("creator %s is suspended", creator)
+	}
+
+	if !account.CanBeAttributedFrom(link.Hostname()) {
+		return nil, gtserror.Newf("creator %s can't be attributed from %s", creator, link)
+	}
+
+	return account, nil
+}
+
+// statusCardLink returns the first link in the given status content
+// which is suitable for a preview card, ie., an http(s) link that
+// isn't a mention or hashtag. Boosts don't get cards of their own.
+func statusCardLink(status *gtsmodel.Status) *url.URL {
+	if status.BoostOfID != "" || status.Content == "" {
+		return nil
+	}
+
+	z := html.NewTokenizer(strings.NewReader(status.Content))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			// End of content.
+			return nil
+
+		case html.StartTagToken:
+			t := z.Token()
+			if t.DataAtom != atom.A {
+				continue
+			}
+
+			var href, class, rel string
+			for _, a := range t.Attr {
+				switch a.Key {
+				case "href":
+					href = a.Val
+				case "class":
+					class = a.Val
+				case "rel":
+					rel = a.Val
+				}
+			}
+
+			classes := strings.Fields(class)
+			if slices.Contains(classes, "mention") ||
+				slices.Contains(classes, "hashtag") ||
+				slices.Contains(strings.Fields(rel), "tag") {
+				// Mentions + hashtags
+				// aren't worth a card.
+				continue
+			}
+
+			u, err := url.Parse(href)
+			if err != nil || u.Host == "" ||
+				(u.Scheme != "http" && u.Scheme != "https") {
+				continue
+			}
+
+			return u
+		}
+	}
+}
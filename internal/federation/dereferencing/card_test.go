@@ -0,0 +1,207 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/federation/dereferencing"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type CardTestSuite struct {
+	DereferencerStandardTestSuite
+}
+
+// cardDereferencer returns a dereferencer whose
+// http client serves the given page at any URL,
+// counting how many requests were made.
+func (suite *CardTestSuite) cardDereferencer(page string, requests *int) dereferencing.Dereferencer {
+	client := testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		*requests++
+		return &http.Response{
+			Request:       req,
+			StatusCode:    http.StatusOK,
+			Body:          io.NopCloser(bytes.NewReader([]byte(page))),
+			ContentLength: int64(len(page)),
+			Header:        http.Header{"Content-Type": {"text/html"}},
+		}, nil
+	}, "")
+
+	return dereferencing.NewDereferencer(
+		&suite.state,
+		typeutils.NewConverter(&suite.state),
+		testrig.NewTestTransportController(&suite.state, client),
+		visibility.NewFilter(&suite.state),
+		testrig.NewTestMediaManager(&suite.state),
+	)
+}
+
+func (suite *CardTestSuite) TestFetchStatusCard() {
+	var (
+		ctx      = context.Background()
+		requests = 0
+		d        = suite.cardDereferencer(`<html><head>
+<meta property="og:title" content="Some &lt;b&gt;article&lt;/b&gt;">
+<meta property="og:description" content="An article.">
+</head></html>`, &requests)
+	)
+
+	status, err := suite.db.GetStatusByID(ctx, "01F8MHAMCHF6Y650WCRSCP4WMY")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Link to an article after a mention + hashtag,
+	// which shouldn't be picked up for the card.
+	status.Content = `<p><span class="h-card"><a href="http://localhost:8080/@admin" class="u-url mention">@<span>admin</span></a></span> ` +
+		`<a href="http://localhost:8080/tags/welcome" class="mention hashtag" rel="tag">#<span>welcome</span></a> ` +
+		`read this: <a href="https://example.org/article" rel="nofollow noreferrer noopener" target="_blank">https://example.org/article</a></p>`
+
+	changed, err := d.FetchStatusCard(ctx, "", status)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(changed)
+	suite.Equal(1, requests)
+
+	card := status.Card
+	suite.NotNil(card)
+	suite.Equal(card.ID, status.CardID)
+	suite.Equal("https://example.org/article", card.URL)
+	suite.Equal("Some article", card.Title)
+	suite.Equal("An article.", card.Description)
+	suite.Equal(gtsmodel.CardTypeLink, card.Type)
+
+	// Card should be set on the stored status.
+	dbStatus, err := suite.db.GetStatusByID(ctx, status.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(card.ID, dbStatus.CardID)
+	suite.Equal(card.URL, dbStatus.Card.URL)
+
+	// Fetching again should use the fresh stored card.
+	changed, err = d.FetchStatusCard(ctx, "", status)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(changed)
+	suite.Equal(1, requests)
+
+	// Removing the link should remove the card.
+	status.Content = `<p>nothing to see here</p>`
+	changed, err = d.FetchStatusCard(ctx, "", status)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(changed)
+	suite.Empty(status.CardID)
+	suite.Nil(status.Card)
+}
+
+func (suite *CardTestSuite) TestFetchStatusCardNoLink() {
+	var (
+		ctx      = context.Background()
+		requests = 0
+		d        = suite.cardDereferencer(`<html><head><title>Profile</title></head></html>`, &requests)
+	)
+
+	// This status only contains a mention.
+	status, err := suite.db.GetStatusByID(ctx, "01HE7XJ1CG84TBKH5V9XKBVGF5")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	changed, err := d.FetchStatusCard(ctx, "", status)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(changed)
+	suite.Empty(status.CardID)
+	suite.Zero(requests)
+}
+
+func (suite *CardTestSuite) TestGetCardCreator() {
+	var (
+		ctx      = context.Background()
+		requests = 0
+		creator  = suite.testAccounts["remote_account_1"]
+		d        = suite.cardDereferencer(`<html><head>
+<title>A blog post</title>
+<meta name="fediverse:creator" content="@foss_satan@fossbros-anonymous.io">
+</head></html>`, &requests)
+	)
+
+	// Without attribution domains set
+	// the creator can't be verified.
+	card, err := d.GetCard(ctx, "", testrig.URLMustParse("https://blog.fossbros-anonymous.io/post"))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("@foss_satan@fossbros-anonymous.io", card.Creator)
+	suite.Empty(card.CreatorAccountID)
+
+	// Allow the creator to be
+	// credited from their blog.
+	creator.AttributionDomains = []string{"fossbros-anonymous.io"}
+	if err := suite.db.UpdateAccount(ctx, creator, "attribution_domains"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Page is hosted on a subdomain of
+	// one of the creator's attribution
+	// domains, so creator should be set.
+	card, err = d.GetCard(ctx, "", testrig.URLMustParse("https://blog.fossbros-anonymous.io/other-post"))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("@foss_satan@fossbros-anonymous.io", card.Creator)
+	suite.Equal(creator.ID, card.CreatorAccountID)
+
+	// Creator account should be
+	// populated on the stored card.
+	dbCard, err := suite.db.GetCardByID(ctx, card.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotNil(dbCard.CreatorAccount)
+	suite.Equal(creator.ID, dbCard.CreatorAccount.ID)
+
+	// The same claim from a page on
+	// some other domain is ignored.
+	card, err = d.GetCard(ctx, "", testrig.URLMustParse("https://example.org/post"))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("@foss_satan@fossbros-anonymous.io", card.Creator)
+	suite.Empty(card.CreatorAccountID)
+	suite.Nil(card.CreatorAccount)
+}
+
+func TestCardTestSuite(t *testing.T) {
+	suite.Run(t, &CardTestSuite{})
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
 )
 
 // dereferenceCollectionPage returns the activitystreams Collection at the specified IRI, or an error if something goes wrong.
@@ -55,6 +56,35 @@ func (d *Dereferencer) dereferenceCollection(ctx context.Context, username strin
 	return collect, nil
 }
 
+// dereferenceCollectionCount returns the totalItems count of the activitystreams
+// Collection at the specified IRI, or an error if it has no count or something goes wrong.
+func (d *Dereferencer) dereferenceCollectionCount(ctx context.Context, tsport transport.Transport, iri *url.URL) (int, error) {
+	if blocked, err := d.state.DB.IsDomainBlocked(ctx, iri.Host); blocked || err != nil {
+		return 0, gtserror.Newf("domain %s is blocked", iri.Host)
+	}
+
+	rsp, err := tsport.Dereference(ctx, iri)
+	if err != nil {
+		return 0, gtserror.Newf("error dereferencing %s: %w", iri.String(), err)
+	}
+
+	collect, err := ap.ResolveCollection(ctx, rsp.Body)
+
+	// Tidy up rsp body.
+	_ = rsp.Body.Close()
+
+	if err != nil {
+		return 0, gtserror.Newf("error resolving collection %s: %w", iri.String(), err)
+	}
+
+	count := collect.TotalItems()
+	if count < 0 {
+		return 0, gtserror.Newf("collection %s has no totalItems", iri.String())
+	}
+
+	return count, nil
+}
+
 // dereferenceCollectionPage returns the activitystreams CollectionPage at the specified IRI, or an error if something goes wrong.
 func (d *Dereferencer) dereferenceCollectionPage(ctx context.Context, username string, pageIRI *url.URL) (ap.CollectionPageIterator, error) {
 	if blocked, err := d.state.DB.IsDomainBlocked(ctx, pageIRI.Host); blocked || err != nil {
@@ -24,6 +24,7 @@ import (
 	"net/url"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -91,7 +92,11 @@ func (d *Dereferencer) GetRemoteEmoji(ctx context.Context, requestingUsername st
 	return processingEmoji, nil
 }
 
-func (d *Dereferencer) populateEmojis(ctx context.Context, rawEmojis []*gtsmodel.Emoji, requestingUsername string) ([]*gtsmodel.Emoji, error) {
+// populateEmojis returns the stored emojis corresponding to the given raw
+// emojis, fetching any we don't have yet. Emojis which couldn't be fetched
+// are left out. If any new emojis were left out only because the dereference
+// budget is spent, true is also returned, so the caller can try again later.
+func (d *Dereferencer) populateEmojis(ctx context.Context, rawEmojis []*gtsmodel.Emoji, requestingUsername string) ([]*gtsmodel.Emoji, bool, error) {
 	// At this point we should know:
 	// * the AP uri of the emoji
 	// * the domain of the emoji
@@ -100,6 +105,10 @@ func (d *Dereferencer) populateEmojis(ctx context.Context, rawEmojis []*gtsmodel
 	// This should be enough to dereference the emoji
 	gotEmojis := make([]*gtsmodel.Emoji, 0, len(rawEmojis))
 
+	// Set if any new emojis
+	// had to be left out for now.
+	var deferred bool
+
 	for _, e := range rawEmojis {
 		var gotEmoji *gtsmodel.Emoji
 		var err error
@@ -137,6 +146,9 @@ func (d *Dereferencer) populateEmojis(ctx context.Context, rawEmojis []*gtsmodel
 
 			if !refresh {
 				log.Tracef(ctx, "emoji %s is up to date, will not refresh", shortcodeDomain)
+			} else if !gtscontext.TakeDerefBudget(ctx) {
+				log.Debugf(ctx, "dereference budget spent, deferring refresh of emoji %s", shortcodeDomain)
+				d.deferEmoji(requestingUsername, e)
 			} else {
 				log.Tracef(ctx, "refreshing emoji %s", shortcodeDomain)
 				emojiID := gotEmoji.ID // use existing ID
@@ -157,6 +169,11 @@ func (d *Dereferencer) populateEmojis(ctx context.Context, rawEmojis []*gtsmodel
 					continue
 				}
 			}
+		} else if !gtscontext.TakeDerefBudget(ctx) {
+			// it's new, but we can't get it right now.
+			log.Debugf(ctx, "dereference budget spent, deferring emoji %s", shortcodeDomain)
+			deferred = true
+			continue
 		} else {
 			// it's new! go get it!
 			newEmojiID, err := id.NewRandomULID()
@@ -187,5 +204,16 @@ func (d *Dereferencer) populateEmojis(ctx context.Context, rawEmojis []*gtsmodel
 		gotEmojis = append(gotEmojis, gotEmoji)
 	}
 
-	return gotEmojis, nil
+	return gotEmojis, deferred, nil
+}
+
+// deferEmoji enqueues refreshing the given emoji in the background,
+// for when it can't be refreshed right now as the dereference budget
+// is spent. Until then, the existing stored emoji is used as-is.
+func (d *Dereferencer) deferEmoji(requestingUsername string, e *gtsmodel.Emoji) {
+	d.state.Workers.Dereference.Queue.Push(func(ctx context.Context) {
+		if _, _, err := d.populateEmojis(ctx, []*gtsmodel.Emoji{e}, requestingUsername); err != nil {
+			log.Errorf(ctx, "error refreshing deferred emoji: %v", err)
+		}
+	})
 }
@@ -0,0 +1,207 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// carryFieldVerifications copies the verification time of each
+// unchanged field of the existing account onto the latest account,
+// returning whether the latest account has any new or changed field
+// linking to a page, which should then be verified by us. We don't
+// trust a remote instance to tell us whether a field was verified.
+func carryFieldVerifications(existing, latest *gtsmodel.Account) bool {
+	var verify bool
+
+	for _, field := range latest.Fields {
+		i := slices.IndexFunc(existing.Fields, func(f *gtsmodel.Field) bool {
+			return f.Name == field.Name && f.Value == field.Value
+		})
+
+		if i >= 0 {
+			// Unchanged field.
+			field.VerifiedAt = existing.Fields[i].VerifiedAt
+			continue
+		}
+
+		field.VerifiedAt = time.Time{}
+		if fieldLink(field.Value) != nil {
+			verify = true
+		}
+	}
+
+	return verify
+}
+
+// verifyAccountFields checks each unverified field of the remote
+// account with given ID that links to a page, marking the field as
+// verified if that page links back to the account with rel="me".
+func (d *Dereferencer) verifyAccountFields(
+	ctx context.Context,
+	requestUser string,
+	accountID string,
+) error {
+	account, err := d.state.DB.GetAccountByID(
+		gtscontext.SetBarebones(ctx),
+		accountID,
+	)
+	if err != nil {
+		return gtserror.Newf("db error getting account %s: %w", accountID, err)
+	}
+
+	tsport, err := d.transportController.NewTransportForUsername(ctx, requestUser)
+	if err != nil {
+		return gtserror.Newf("couldn't create transport: %w", err)
+	}
+
+	var verified bool
+
+	for _, field := range account.Fields {
+		if !field.VerifiedAt.IsZero() {
+			// Already verified.
+			continue
+		}
+
+		link := fieldLink(field.Value)
+		if link == nil {
+			// Nothing to verify.
+			continue
+		}
+
+		ok, err := d.linksBackTo(ctx, tsport, link, account)
+		if err != nil {
+			log.Debugf(ctx, "couldn't verify field link %s: %v", link, err)
+			continue
+		}
+
+		if ok {
+			field.VerifiedAt = time.Now()
+			verified = true
+		}
+	}
+
+	if !verified {
+		// Nothing to update.
+		return nil
+	}
+
+	if err := d.state.DB.UpdateAccount(ctx, account, "fields"); err != nil {
+		return gtserror.Newf("db error updating account %s: %w", accountID, err)
+	}
+
+	return nil
+}
+
+// linksBackTo returns whether the page at the given link has
+// a rel="me" link to the given account's URI or web profile.
+func (d *Dereferencer) linksBackTo(
+	ctx context.Context,
+	tsport transport.Transport,
+	link *url.URL,
+	account *gtsmodel.Account,
+) (bool, error) {
+	blocked, err := d.state.DB.IsDomainBlocked(ctx, link.Host)
+	if err != nil {
+		return false, gtserror.Newf("db error checking domain block: %w", err)
+	}
+
+	if blocked {
+		return false, gtserror.Newf("%s is blocked", link.Host)
+	}
+
+	relMe, err := tsport.DereferenceRelMe(ctx, link)
+	if err != nil {
+		return false, err
+	}
+
+	var (
+		accountURI = uris.Normalize(account.URI)
+		accountURL = uris.Normalize(account.URL)
+	)
+
+	for _, u := range relMe {
+		switch uris.Normalize(u.String()) {
+		case accountURI, accountURL:
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// fieldLink returns the http(s) link that the given field
+// value consists of, either as an html <a> tag or as plain
+// text, or nil if the value isn't a link.
+func fieldLink(value string) *url.URL {
+	value = strings.TrimSpace(value)
+
+	if strings.HasPrefix(value, "<") {
+		// Value is html, take
+		// the first <a> tag.
+		nodes, err := html.ParseFragment(
+			strings.NewReader(value),
+			&html.Node{
+				Type:     html.ElementNode,
+				Data:     "div",
+				DataAtom: atom.Div,
+			},
+		)
+		if err != nil {
+			return nil
+		}
+
+		value = ""
+		for _, n := range nodes {
+			if n.Type == html.ElementNode && n.DataAtom == atom.A {
+				for _, a := range n.Attr {
+					if a.Key == "href" {
+						value = a.Val
+						break
+					}
+				}
+				break
+			}
+		}
+	}
+
+	if value == "" || strings.ContainsAny(value, " \t\n") {
+		return nil
+	}
+
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" ||
+		(u.Scheme != "http" && u.Scheme != "https") {
+		return nil
+	}
+
+	return u
+}
@@ -0,0 +1,104 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// DereferenceStatusQuote dereferences the status quoted by the
+// given status, if it's not yet known, and links the two in the
+// database if the quoting status' author is allowed to quote it.
+func (d *Dereferencer) DereferenceStatusQuote(ctx context.Context, requestUser string, status *gtsmodel.Status) error {
+	if status.QuoteOfURI == "" || status.QuoteOfID != "" {
+		// Not a quote, or
+		// already linked.
+		return nil
+	}
+
+	uri, err := url.Parse(status.QuoteOfURI)
+	if err != nil {
+		return gtserror.Newf("invalid quote uri %q: %w", status.QuoteOfURI, err)
+	}
+
+	// Fetch the quoted status without dereferencing its
+	// thread, or any further quotes, to avoid recursion.
+	quoteOf, _, _, err := d.getStatusByURI(ctx, requestUser, uri)
+	if err != nil && quoteOf == nil {
+		return gtserror.Newf("error dereferencing quoted status %s: %w", status.QuoteOfURI, err)
+	}
+
+	status.QuoteOfID = quoteOf.ID
+	status.QuoteOf = quoteOf
+
+	if err := d.checkStatusQuote(ctx, status); err != nil {
+		return err
+	}
+
+	if status.QuoteOfID == "" {
+		// Not permitted,
+		// nothing to store.
+		return nil
+	}
+
+	if err := d.state.DB.UpdateStatus(ctx, status, "quote_of_id"); err != nil {
+		return gtserror.Newf("error updating status %s: %w", status.URI, err)
+	}
+
+	return nil
+}
+
+// checkStatusQuote unsets the quoted status of the given
+// status if its author isn't allowed to quote it, ie., if
+// it's a boost wrapper, or a local status they can't see.
+func (d *Dereferencer) checkStatusQuote(ctx context.Context, status *gtsmodel.Status) error {
+	if status.QuoteOf == nil {
+		// Nothing
+		// to check.
+		return nil
+	}
+
+	permitted := status.QuoteOf.BoostOfID == "" &&
+		status.QuoteOf.ID != status.ID
+
+	if permitted && status.QuoteOf.IsLocal() {
+		var err error
+
+		// Check visibility of quoted status to status author.
+		permitted, err = d.visibility.StatusVisible(ctx,
+			status.Account,
+			status.QuoteOf,
+		)
+		if err != nil {
+			return gtserror.Newf("error checking quoted status visibility: %w", err)
+		}
+	}
+
+	if !permitted {
+		// Keep the URI, but
+		// don't link to it.
+		status.QuoteOfID = ""
+		status.QuoteOf = nil
+	}
+
+	return nil
+}
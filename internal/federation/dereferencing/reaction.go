@@ -0,0 +1,65 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// DereferenceReactionEmoji fetches the remote custom emoji
+// of the given status reaction if we didn't have it yet (as
+// set by typeutils.ASLikeToStatusReaction), and updates the
+// reaction in the database to point to the fetched emoji.
+//
+// Noop for unicode reactions or already fetched emojis.
+func (d *Dereferencer) DereferenceReactionEmoji(
+	ctx context.Context,
+	requestUser string,
+	reaction *gtsmodel.StatusReaction,
+) error {
+	if reaction.Emoji == nil || reaction.EmojiID != "" {
+		// Nothing to do.
+		return nil
+	}
+
+	emojis, _, err := d.populateEmojis(ctx,
+		[]*gtsmodel.Emoji{reaction.Emoji},
+		requestUser,
+	)
+	if err != nil {
+		return gtserror.Newf("error populating emoji: %w", err)
+	}
+
+	if len(emojis) == 0 {
+		// Either failed (logged), or deferred.
+		return gtserror.Newf("emoji %s@%s not (yet) fetched",
+			reaction.Emoji.Shortcode, reaction.Emoji.Domain)
+	}
+
+	reaction.Emoji = emojis[0]
+	reaction.EmojiID = emojis[0].ID
+
+	if err := d.state.DB.UpdateStatusReaction(ctx, reaction, "emoji_id"); err != nil {
+		return gtserror.Newf("db error updating reaction: %w", err)
+	}
+
+	return nil
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
@@ -273,6 +274,9 @@ func (d *Dereferencer) RefreshStatusAsync(
 			if err := d.DereferenceStatusDescendants(ctx, requestUser, uri, statusable); err != nil {
 				log.Error(ctx, err)
 			}
+			if err := d.DereferenceStatusQuote(ctx, requestUser, latest); err != nil {
+				log.Error(ctx, err)
+			}
 		}
 	})
 }
@@ -470,9 +474,10 @@ func (d *Dereferencer) enrichStatus(
 	}
 
 	// Ensure the final parsed status URI / URL matches
-	// the input URI we fetched (or received) it as.
-	if expect := uri.String(); latestStatus.URI != expect &&
-		latestStatus.URL != expect {
+	// the input URI we fetched (or received) it as,
+	// ignoring differences in host case / default port.
+	if expect := uris.Normalize(uri.String()); uris.Normalize(latestStatus.URI) != expect &&
+		uris.Normalize(latestStatus.URL) != expect {
 		return nil, nil, gtserror.Newf(
 			"dereferenced status uri %s does not match %s",
 			latestStatus.URI, expect,
@@ -516,7 +521,8 @@ func (d *Dereferencer) enrichStatus(
 	}
 
 	// Ensure the status' mentions are populated, and pass in existing to check for changes.
-	if err := d.fetchStatusMentions(ctx, requestUser, status, latestStatus); err != nil {
+	mentionsDeferred, err := d.fetchStatusMentions(ctx, requestUser, status, latestStatus)
+	if err != nil {
 		return nil, nil, gtserror.Newf("error populating mentions for status %s: %w", uri, err)
 	}
 
@@ -542,10 +548,19 @@ func (d *Dereferencer) enrichStatus(
 	}
 
 	// Ensure the status' emoji attachments are populated, (changes are expected / okay).
-	if err := d.fetchStatusEmojis(ctx, requestUser, latestStatus); err != nil {
+	emojisDeferred, err := d.fetchStatusEmojis(ctx, requestUser, latestStatus)
+	if err != nil {
 		return nil, nil, gtserror.Newf("error populating emojis for status %s: %w", uri, err)
 	}
 
+	// Ensure the status' author is allowed to quote the quoted status, if known.
+	if err := d.checkStatusQuote(ctx, latestStatus); err != nil {
+		return nil, nil, gtserror.Newf("error checking quote for status %s: %w", uri, err)
+	}
+
+	// Ensure the status' faves / boosts counts are up-to-date, (failures are okay).
+	d.fetchStatusInteractionCounts(ctx, tsport, uri, status, latestStatus, apubStatus)
+
 	if isNew {
 		// This is new, put the status in the database.
 		err := d.state.DB.PutStatus(ctx, latestStatus)
@@ -553,15 +568,63 @@ func (d *Dereferencer) enrichStatus(
 			return nil, nil, gtserror.Newf("error putting in database: %w", err)
 		}
 	} else {
+		// If the status has been edited since we last
+		// saw it, keep the existing revision as an edit.
+		if latestStatus.EditedAt.After(status.EditedAt) {
+			d.storeStatusEdit(ctx, status, latestStatus.EditedAt)
+		}
+
 		// This is an existing status, update the model in the database.
 		if err := d.state.DB.UpdateStatus(ctx, latestStatus); err != nil {
 			return nil, nil, gtserror.Newf("error updating database: %w", err)
 		}
 	}
 
+	if mentionsDeferred || emojisDeferred {
+		// Some mentions / emojis were left out as the dereference
+		// budget is spent. Enrich the status again in the background,
+		// where there's no budget, to fetch and link them.
+		d.state.Workers.Dereference.Queue.Push(func(ctx context.Context) {
+			if _, _, _, err := d.enrichStatusSafely(ctx,
+				requestUser,
+				uri,
+				latestStatus,
+				apubStatus,
+			); err != nil {
+				log.Errorf(ctx, "error enriching status %s with deferred items: %v", uri, err)
+			}
+		})
+	}
+
 	return latestStatus, apubStatus, nil
 }
 
+// storeStatusEdit stores the existing revision of a status
+// as a status edit, superseded by an edit at editedAt. Only
+// errors are logged, as the edit history is nice-to-have.
+func (d *Dereferencer) storeStatusEdit(ctx context.Context, existing *gtsmodel.Status, editedAt time.Time) {
+	edit := &gtsmodel.StatusEdit{
+		ID:             id.NewULID(),
+		CreatedAt:      editedAt,
+		StatusID:       existing.ID,
+		Content:        existing.Content,
+		ContentWarning: existing.ContentWarning,
+		Text:           existing.Text,
+		Language:       existing.Language,
+		Sensitive:      existing.Sensitive,
+		AttachmentIDs:  existing.AttachmentIDs,
+		EmojiIDs:       existing.EmojiIDs,
+	}
+
+	if existing.Poll != nil {
+		edit.PollOptions = existing.Poll.Options
+	}
+
+	if err := d.state.DB.PutStatusEdit(ctx, edit); err != nil {
+		log.Errorf(ctx, "error putting status edit for %s: %v", existing.URI, err)
+	}
+}
+
 // isPermittedStatus returns whether the given status
 // is permitted to be stored on this instance, checking
 // whether the author is suspended, and passes visibility
@@ -683,6 +746,16 @@ func (d *Dereferencer) populateMentionTarget(
 			return nil, false, err
 		}
 
+		// Accounts we don't have yet need fetching, which counts
+		// against the dereference budget. If that's spent, leave
+		// the mention out for now; the status will be refreshed
+		// in the background to add it (see enrichStatus).
+		if !d.accountStored(ctx, accountURI.Host, func(ctx context.Context) (*gtsmodel.Account, error) {
+			return d.state.DB.GetAccountByURI(ctx, mention.TargetAccountURI)
+		}) && !gtscontext.TakeDerefBudget(ctx) {
+			return nil, false, gtserror.Newf("deferred account %s: %w", accountURI, errDerefBudgetSpent)
+		}
+
 		// Ensure we have the account of the mention target dereferenced.
 		mention.TargetAccount, _, err = d.getAccountByURI(ctx, requestUser, accountURI)
 		if err != nil {
@@ -697,6 +770,14 @@ func (d *Dereferencer) populateMentionTarget(
 			return nil, false, err
 		}
 
+		// As above, defer fetching accounts we don't
+		// have yet if the dereference budget is spent.
+		if !d.accountStored(ctx, domain, func(ctx context.Context) (*gtsmodel.Account, error) {
+			return d.state.DB.GetAccountByUsernameDomain(ctx, username, domain)
+		}) && !gtscontext.TakeDerefBudget(ctx) {
+			return nil, false, gtserror.Newf("deferred account %s: %w", mention.NameString, errDerefBudgetSpent)
+		}
+
 		mention.TargetAccount, _, err = d.getAccountByUsernameDomain(ctx, requestUser, username, domain)
 		if err != nil {
 			err = gtserror.Newf("failed to dereference account %s: %w", mention.NameString, err)
@@ -716,10 +797,38 @@ func (d *Dereferencer) populateMentionTarget(
 	return mention, false, nil
 }
 
-func (d *Dereferencer) fetchStatusMentions(ctx context.Context, requestUser string, existing, status *gtsmodel.Status) error {
+// accountStored returns whether the account on domain,
+// as got from the database using given get function,
+// is local or already stored, ie. needs no remote fetch.
+func (d *Dereferencer) accountStored(
+	ctx context.Context,
+	domain string,
+	get func(context.Context) (*gtsmodel.Account, error),
+) bool {
+	if domain == "" ||
+		domain == config.GetHost() ||
+		domain == config.GetAccountDomain() {
+		return true
+	}
+
+	account, err := get(gtscontext.SetBarebones(ctx))
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		log.Errorf(ctx, "error checking database for account: %v", err)
+	}
+
+	return account != nil
+}
+
+// fetchStatusMentions populates the mentions of status, returning
+// true if any were left out as the dereference budget is spent.
+func (d *Dereferencer) fetchStatusMentions(ctx context.Context, requestUser string, existing, status *gtsmodel.Status) (bool, error) {
 	// Allocate new slice to take the yet-to-be created mention IDs.
 	status.MentionIDs = make([]string, len(status.Mentions))
 
+	// Set if any mentions
+	// had to be left out for now.
+	var deferred bool
+
 	for i := range status.Mentions {
 		var (
 			mention       = status.Mentions[i]
@@ -734,7 +843,11 @@ func (d *Dereferencer) fetchStatusMentions(ctx context.Context, requestUser stri
 			existing,
 			status,
 		)
-		if err != nil {
+		if errors.Is(err, errDerefBudgetSpent) {
+			log.Debugf(ctx, "skipping mention: %v", err)
+			deferred = true
+			continue
+		} else if err != nil {
 			log.Errorf(ctx, "failed to derive mention: %v", err)
 			continue
 		}
@@ -771,7 +884,7 @@ func (d *Dereferencer) fetchStatusMentions(ctx context.Context, requestUser stri
 
 		// Place the new mention into the database.
 		if err := d.state.DB.PutMention(ctx, mention); err != nil {
-			return gtserror.Newf("error putting mention in database: %w", err)
+			return false, gtserror.Newf("error putting mention in database: %w", err)
 		}
 
 		// Set the *new* mention and ID.
@@ -792,7 +905,7 @@ func (d *Dereferencer) fetchStatusMentions(ctx context.Context, requestUser stri
 		i++
 	}
 
-	return nil
+	return deferred, nil
 }
 
 func (d *Dereferencer) threadStatus(ctx context.Context, status *gtsmodel.Status) error {
@@ -907,10 +1020,14 @@ func (d *Dereferencer) fetchStatusPoll(ctx context.Context, existing, status *gt
 		insertStatusPoll = func(ctx context.Context, status *gtsmodel.Status) error {
 			var err error
 
-			// Generate new ID for poll from the status CreatedAt.
-			// TODO: update this to use "edited_at" when we add
-			//       support for edited status revision history.
-			status.Poll.ID, err = id.NewULIDFromTime(status.CreatedAt)
+			// Generate new ID for poll from the status EditedAt,
+			// as changing a poll is an edit, else CreatedAt.
+			pollAt := status.CreatedAt
+			if !status.EditedAt.IsZero() {
+				pollAt = status.EditedAt
+			}
+
+			status.Poll.ID, err = id.NewULIDFromTime(pollAt)
 			if err != nil {
 				log.Errorf(ctx, "invalid created at date (falling back to 'now'): %v", err)
 				status.Poll.ID = id.NewULID() // just use "now"
@@ -1022,11 +1139,22 @@ func (d *Dereferencer) fetchStatusAttachments(ctx context.Context, tsport transp
 			RemoteURL:   &attachment.RemoteURL,
 			Description: &attachment.Description,
 			Blurhash:    &attachment.Blurhash,
+			Sensitive:   attachment.Sensitive,
 		}
 
 		// Start pre-processing remote media at remote URL.
 		processing := d.mediaManager.PreProcessMedia(data, status.AccountID, ai)
 
+		if !gtscontext.TakeDerefBudget(ctx) {
+			// Dereference budget is spent, load the attachment in
+			// the background and use a placeholder in the meantime.
+			log.Debugf(ctx, "dereference budget spent, deferring media %s", remoteURL)
+			attachment = processing.LoadAttachmentAsync()
+			status.Attachments[i] = attachment
+			status.AttachmentIDs[i] = attachment.ID
+			continue
+		}
+
 		// Force attachment loading *right now*.
 		attachment, err = processing.LoadAttachment(ctx)
 		if err != nil {
@@ -1062,11 +1190,59 @@ func (d *Dereferencer) fetchStatusAttachments(ctx context.Context, tsport transp
 	return nil
 }
 
-func (d *Dereferencer) fetchStatusEmojis(ctx context.Context, requestUser string, status *gtsmodel.Status) error {
+// fetchStatusInteractionCounts populates the remote faves and boosts
+// counts of status from the likes and shares collections of apubStatus.
+// Collections not embedded with a count are dereferenced if they're on
+// the same host as the status (at uri); counts that can't be fetched at all are
+// carried over from the existing status model.
+func (d *Dereferencer) fetchStatusInteractionCounts(
+	ctx context.Context,
+	tsport transport.Transport,
+	uri *url.URL,
+	existing *gtsmodel.Status,
+	status *gtsmodel.Status,
+	apubStatus ap.Statusable,
+) {
+	// fetchCount returns the count of the
+	// given collection, or ok=false if unknown.
+	fetchCount := func(count *int, iri *url.URL) (int, bool) {
+		if count != nil {
+			return *count, true
+		}
+
+		if iri == nil || iri.Host != uri.Host {
+			return 0, false
+		}
+
+		n, err := d.dereferenceCollectionCount(ctx, tsport, iri)
+		if err != nil {
+			log.Debugf(ctx, "error fetching collection count: %v", err)
+			return 0, false
+		}
+
+		return n, true
+	}
+
+	if n, ok := fetchCount(ap.ExtractLikes(apubStatus)); ok {
+		status.RemoteFavesCount = n
+	} else {
+		status.RemoteFavesCount = existing.RemoteFavesCount
+	}
+
+	if n, ok := fetchCount(ap.ExtractShares(apubStatus)); ok {
+		status.RemoteBoostsCount = n
+	} else {
+		status.RemoteBoostsCount = existing.RemoteBoostsCount
+	}
+}
+
+// fetchStatusEmojis populates the emojis of status, returning
+// true if any were left out as the dereference budget is spent.
+func (d *Dereferencer) fetchStatusEmojis(ctx context.Context, requestUser string, status *gtsmodel.Status) (bool, error) {
 	// Fetch the full-fleshed-out emoji objects for our status.
-	emojis, err := d.populateEmojis(ctx, status.Emojis, requestUser)
+	emojis, deferred, err := d.populateEmojis(ctx, status.Emojis, requestUser)
 	if err != nil {
-		return gtserror.Newf("failed to populate emojis: %w", err)
+		return false, gtserror.Newf("failed to populate emojis: %w", err)
 	}
 
 	// Iterate over and get their IDs.
@@ -1079,5 +1255,5 @@ func (d *Dereferencer) fetchStatusEmojis(ctx context.Context, requestUser string
 	status.Emojis = emojis
 	status.EmojiIDs = emojiIDs
 
-	return nil
+	return deferred, nil
 }
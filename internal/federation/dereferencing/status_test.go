@@ -20,11 +20,16 @@ package dereferencing_test
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/pub"
+	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
@@ -124,6 +129,69 @@ func (suite *StatusTestSuite) TestDereferenceStatusWithMention() {
 	suite.False(*m.Silent)
 }
 
+func (suite *StatusTestSuite) TestDereferenceStatusWithMentionBudgetSpent() {
+	fetchingAccount := suite.testAccounts["local_account_1"]
+
+	// Spend the whole dereference budget up front.
+	ctx := gtscontext.SetDerefBudget(context.Background(), 1)
+	gtscontext.TakeDerefBudget(ctx)
+
+	// Status mentioning an account we don't have yet.
+	mention := streams.NewActivityStreamsMention()
+	hrefProp := streams.NewActivityStreamsHrefProperty()
+	hrefProp.SetIRI(testrig.URLMustParse("https://turnip.farm/users/turniplover6969"))
+	mention.SetActivityStreamsHref(hrefProp)
+	nameProp := streams.NewActivityStreamsNameProperty()
+	nameProp.AppendXMLSchemaString("@turniplover6969@turnip.farm")
+	mention.SetActivityStreamsName(nameProp)
+
+	statusURI := "https://unknown-instance.com/users/brand_new_person/statuses/01HXYZ0TKQ3V6GBFJ4HY1TJB6F"
+	note := testrig.NewAPNote(
+		testrig.URLMustParse(statusURI),
+		testrig.URLMustParse("https://unknown-instance.com/users/@brand_new_person/01HXYZ0TKQ3V6GBFJ4HY1TJB6F"),
+		testrig.TimeMustParse("2024-05-12T12:13:12+02:00"),
+		"Hey @turniplover6969@turnip.farm how's it going?",
+		"",
+		testrig.URLMustParse("https://unknown-instance.com/users/brand_new_person"),
+		[]*url.URL{testrig.URLMustParse(pub.PublicActivityPubIRI)},
+		[]*url.URL{},
+		false,
+		[]vocab.ActivityStreamsMention{mention},
+		[]vocab.TootHashtag{},
+		nil,
+	)
+
+	status, _, err := suite.dereferencer.RefreshStatus(ctx,
+		fetchingAccount.Username,
+		&gtsmodel.Status{URI: statusURI},
+		note,
+		nil,
+	)
+	suite.NoError(err)
+
+	// Mention should have been left out for now.
+	suite.Empty(status.MentionIDs)
+
+	// Run the work deferred to the background,
+	// which should fetch the account and add it.
+	for {
+		fn, ok := suite.state.Workers.Dereference.Queue.Pop()
+		if !ok {
+			break
+		}
+		fn(context.Background())
+	}
+
+	status, err = suite.db.GetStatusByURI(context.Background(), statusURI)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if suite.Len(status.Mentions, 1) {
+		suite.Equal("https://turnip.farm/users/turniplover6969", status.Mentions[0].TargetAccountURI)
+	}
+}
+
 func (suite *StatusTestSuite) TestDereferenceStatusWithTag() {
 	fetchingAccount := suite.testAccounts["local_account_1"]
 
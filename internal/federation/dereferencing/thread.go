@@ -26,15 +26,13 @@ import (
 	"github.com/superseriousbusiness/activity/pub"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
-// maxIter defines how many iterations of descendants or
-// ancesters we are willing to follow before returning error.
-const maxIter = 512
-
 // dereferenceThread handles dereferencing status thread after
 // fetch. Passing off appropriate parts to be enqueued for async
 // processing, or handling some parts synchronously when required.
@@ -60,6 +58,9 @@ func (d *Dereferencer) dereferenceThread(
 			if err := d.DereferenceStatusDescendants(ctx, requestUser, uri, statusable); err != nil {
 				log.Error(ctx, err)
 			}
+			if err := d.DereferenceStatusQuote(ctx, requestUser, status); err != nil {
+				log.Error(ctx, err)
+			}
 		})
 	} else {
 		// This is an existing status, dereference the WHOLE thread asynchronously.
@@ -70,6 +71,9 @@ func (d *Dereferencer) dereferenceThread(
 			if err := d.DereferenceStatusDescendants(ctx, requestUser, uri, statusable); err != nil {
 				log.Error(ctx, err)
 			}
+			if err := d.DereferenceStatusQuote(ctx, requestUser, status); err != nil {
+				log.Error(ctx, err)
+			}
 		})
 	}
 }
@@ -87,11 +91,15 @@ func (d *Dereferencer) DereferenceStatusAncestors(ctx context.Context, username
 	// for this ancestor thread to prevent recursion.
 	derefdStatuses := make(map[string]struct{}, 10)
 
+	// Maximum number of ancestors
+	// we're willing to dereference.
+	maxAncestors := max(config.GetAdvancedThreadMaxAncestors(), 1)
+
 	// Mark given status as the one
 	// we're currently working on.
 	current := status
 
-	for i := 0; i < maxIter; i++ {
+	for i := 0; i < maxAncestors; i++ {
 		if current.InReplyToURI == "" {
 			// Status has no parent, we've
 			// reached the top of the chain.
@@ -118,6 +126,20 @@ func (d *Dereferencer) DereferenceStatusAncestors(ctx context.Context, username
 			return nil
 		}
 
+		// Parents we don't have yet need fetching, which counts
+		// against the dereference budget. If that's spent, defer
+		// dereferencing the rest of the ancestors to background.
+		if current.InReplyToID == "" && !gtscontext.TakeDerefBudget(ctx) {
+			l.Debug("dereference budget spent, deferring ancestors")
+			deferred := current
+			d.state.Workers.Dereference.Queue.Push(func(ctx context.Context) {
+				if err := d.DereferenceStatusAncestors(ctx, username, deferred); err != nil {
+					log.Error(ctx, err)
+				}
+			})
+			return nil
+		}
+
 		// Fetch parent status by current's reply URI, this handles
 		// case of existing (updating if necessary) or a new status.
 		parent, _, _, err := d.getStatusByURI(ctx, username, uri)
@@ -190,7 +212,7 @@ func (d *Dereferencer) DereferenceStatusAncestors(ctx context.Context, username
 		// weird and broken... Leave the URI in place but
 		// don't link the statuses via database IDs as it
 		// could cause all sorts of unexpected situations.
-		case current.InReplyToURI != parent.URI:
+		case uris.Normalize(current.InReplyToURI) != parent.URI:
 			l.Errorf("indirect in_reply_to_uri => %s", parent.URI)
 
 		// The ID has changed for currently stored parent ID
@@ -215,7 +237,7 @@ func (d *Dereferencer) DereferenceStatusAncestors(ctx context.Context, username
 		current = current.InReplyTo
 	}
 
-	return gtserror.Newf("reached %d ancestor iterations for %q", maxIter, status.URI)
+	return gtserror.Newf("reached %d ancestors for %q", maxAncestors, status.URI)
 }
 
 // DereferenceStatusDescendents iterates downwards from the given status, using its replies, to ensure that as many children statuses as possible are dereferenced.
@@ -239,6 +261,14 @@ func (d *Dereferencer) DereferenceStatusDescendants(ctx context.Context, usernam
 	// pages for this thread to prevent recursion.
 	derefdPages := make(map[string]struct{}, 10)
 
+	// Maximum depth of replies, and number of
+	// replies, we're willing to dereference.
+	maxDepth := max(config.GetAdvancedThreadMaxDepth(), 1)
+	maxDescendants := max(config.GetAdvancedThreadMaxDescendants(), 1)
+
+	// Number of replies dereferenced so far.
+	var derefdCount int
+
 	// frame represents a single stack frame when
 	// iteratively derefencing status descendants.
 	type frame struct {
@@ -291,8 +321,11 @@ func (d *Dereferencer) DereferenceStatusDescendants(ctx context.Context, usernam
 		}
 	)
 
+	// NOTE: every frame pushed to the stack follows
+	// a reply dereference, so this loop is bounded
+	// by maxDescendants, checked in the item loop.
 stackLoop:
-	for i := 0; i < maxIter; i++ {
+	for {
 		// Pop next frame, nil means we are at end
 		if current = popStack(); current == nil {
 			return nil
@@ -321,6 +354,14 @@ stackLoop:
 					continue itemLoop
 				}
 
+				if derefdCount >= maxDescendants {
+					return gtserror.Newf("reached %d descendants for %q", maxDescendants, statusIRIStr)
+				}
+
+				// Count this towards
+				// the descendant budget.
+				derefdCount++
+
 				// Dereference the remote status and store in the database.
 				// getStatusByURI guards against the following conditions:
 				//   - refetching recently fetched statuses (recursion!)
@@ -341,6 +382,14 @@ stackLoop:
 					continue itemLoop
 				}
 
+				// This status is one deeper than the current frame's
+				// depth, which is the number of frames shelved below
+				// it plus itself. Don't go beyond the maximum depth.
+				if len(stack)+1 >= maxDepth {
+					l.Debugf("reached max depth %d at %s", maxDepth, itemIRI)
+					continue itemLoop
+				}
+
 				// Extract any attached collection + ID URI from status.
 				page, pageURI := getAttachedStatusCollectionPage(statusable)
 				if page == nil {
@@ -392,8 +441,6 @@ stackLoop:
 			continue pageLoop
 		}
 	}
-
-	return gtserror.Newf("reached %d descendant iterations for %q", maxIter, statusIRIStr)
 }
 
 // updateStatusParent updates the given status' parent
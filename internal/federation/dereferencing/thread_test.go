@@ -0,0 +1,158 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/pub"
+	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ThreadTestSuite struct {
+	DereferencerStandardTestSuite
+}
+
+// putThread puts a thread of remote statuses of the given
+// length in the mock http client, each one replying to the
+// previous, and returns the URIs of the statuses in order.
+func (suite *ThreadTestSuite) putThread(length int) []string {
+	var (
+		uris      []string
+		inReplyTo *url.URL
+	)
+
+	for i := 0; i < length; i++ {
+		uri := testrig.URLMustParse("https://unknown-instance.com/users/brand_new_person/statuses/thread-" + strconv.Itoa(i))
+		note := testrig.NewAPNote(
+			uri,
+			uri,
+			time.Now(),
+			"reply number "+strconv.Itoa(i),
+			"",
+			testrig.URLMustParse("https://unknown-instance.com/users/brand_new_person"),
+			[]*url.URL{testrig.URLMustParse(pub.PublicActivityPubIRI)},
+			nil,
+			false,
+			nil,
+			nil,
+			nil,
+		)
+
+		if inReplyTo != nil {
+			prop := streams.NewActivityStreamsInReplyToProperty()
+			prop.AppendIRI(inReplyTo)
+			note.SetActivityStreamsInReplyTo(prop)
+		}
+
+		suite.client.TestRemoteStatuses[uri.String()] = note
+		uris = append(uris, uri.String())
+		inReplyTo = uri
+	}
+
+	return uris
+}
+
+func (suite *ThreadTestSuite) TestDereferenceStatusAncestorsMax() {
+	ctx := context.Background()
+	fetchingAccount := suite.testAccounts["local_account_1"]
+
+	config.SetAdvancedThreadMaxAncestors(2)
+	uris := suite.putThread(5)
+
+	// Fetch the last status in the thread,
+	// which dereferences its ancestors.
+	status, _, err := suite.dereferencer.GetStatusByURI(ctx,
+		fetchingAccount.Username,
+		testrig.URLMustParse(uris[4]),
+	)
+	suite.NoError(err)
+	suite.NotNil(status)
+
+	// Only the two nearest ancestors
+	// should have been dereferenced.
+	for i, uri := range uris {
+		_, err := suite.db.GetStatusByURI(ctx, uri)
+		if i >= 2 {
+			suite.NoError(err, uri)
+		} else {
+			suite.True(errors.Is(err, db.ErrNoEntries), uri)
+		}
+	}
+
+	// Trying again should fail on hitting the limit.
+	err = suite.dereferencer.DereferenceStatusAncestors(ctx, fetchingAccount.Username, status)
+	suite.ErrorContains(err, "reached 2 ancestors")
+}
+
+func (suite *ThreadTestSuite) TestDereferenceStatusAncestorsBudget() {
+	// Only allow two remote fetches.
+	ctx := gtscontext.SetDerefBudget(context.Background(), 2)
+	fetchingAccount := suite.testAccounts["local_account_1"]
+
+	uris := suite.putThread(5)
+
+	// Fetch the last status in the thread,
+	// which dereferences its ancestors.
+	status, _, err := suite.dereferencer.GetStatusByURI(ctx,
+		fetchingAccount.Username,
+		testrig.URLMustParse(uris[4]),
+	)
+	suite.NoError(err)
+	suite.NotNil(status)
+
+	// Only the two nearest ancestors should
+	// have been dereferenced synchronously.
+	for i, uri := range uris {
+		_, err := suite.db.GetStatusByURI(ctx, uri)
+		if i >= 2 {
+			suite.NoError(err, uri)
+		} else {
+			suite.True(errors.Is(err, db.ErrNoEntries), uri)
+		}
+	}
+
+	// Run the work deferred to the background,
+	// which should dereference the rest of them.
+	for {
+		fn, ok := suite.state.Workers.Dereference.Queue.Pop()
+		if !ok {
+			break
+		}
+		fn(context.Background())
+	}
+
+	for _, uri := range uris {
+		_, err := suite.db.GetStatusByURI(ctx, uri)
+		suite.NoError(err, uri)
+	}
+}
+
+func TestThreadTestSuite(t *testing.T) {
+	suite.Run(t, new(ThreadTestSuite))
+}
@@ -18,11 +18,17 @@
 package dereferencing
 
 import (
+	"errors"
 	"slices"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
+// errDerefBudgetSpent is returned when a remote fetch
+// was deferred to the background instead, as the
+// dereference budget of the context was spent.
+var errDerefBudgetSpent = errors.New("dereference budget spent")
+
 // pollChanged returns whether a poll has changed in way that
 // indicates that this should be an entirely new poll. i.e. if
 // the available options have changed, or the expiry has increased.
@@ -18,9 +18,11 @@
 package federation
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 
@@ -30,7 +32,9 @@ import (
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
@@ -106,6 +110,21 @@ func (f *federatingActor) PostInboxScheme(ctx context.Context, w http.ResponseWr
 		return false, gtserror.NewErrorUnauthorized(errors.New(text), text)
 	}
 
+	// Log the sender of any activities
+	// rejected for exceeding the limits.
+	if requester := gtscontext.RequestingAccount(ctx); requester != nil {
+		l = l.WithField("requester", requester.URI)
+	}
+
+	// Ensure the activity isn't too large
+	// before we go to the trouble of parsing it.
+	if err := readInboxBody(r); errors.Is(err, errInboxBodyTooLarge) {
+		l.Warnf("rejecting incoming activity: %v", err)
+		return false, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	} else if err != nil {
+		return false, gtserror.NewErrorBadRequest(err)
+	}
+
 	/*
 		Begin processing the request, but note that we
 		have not yet applied authorization (ie., blocks).
@@ -119,6 +138,13 @@ func (f *federatingActor) PostInboxScheme(ctx context.Context, w http.ResponseWr
 		return false, nil
 	}
 
+	// Ensure the activity isn't too complex, to protect
+	// against it causing us lots of work, or fetches.
+	if err := ap.CheckLimits(activity, inboxLimits()); err != nil {
+		l.Warnf("rejecting incoming activity: %v", err)
+		return false, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
 	// Set additional context data. Primarily this means
 	// looking at the Activity and seeing which IRIs are
 	// involved in it tangentially.
@@ -161,6 +187,15 @@ func (f *federatingActor) PostInboxScheme(ctx context.Context, w http.ResponseWr
 		*u = *r.URL
 		u.Host = r.Host
 		u.Scheme = scheme
+
+		if onionHost := config.GetOnionHost(); //
+		onionHost != "" && u.Host == onionHost {
+			// Delivered to our onion service,
+			// use the canonical inbox URI.
+			u.Host = config.GetHost()
+			u.Scheme = config.GetProtocol()
+		}
+
 		return u
 	}()
 
@@ -254,3 +289,50 @@ func (f *federatingActor) PostOutboxScheme(c context.Context, w http.ResponseWri
 func (f *federatingActor) GetOutbox(c context.Context, w http.ResponseWriter, r *http.Request) (bool, error) {
 	return f.wrapped.GetOutbox(c, w, r)
 }
+
+// errInboxBodyTooLarge is returned when the body of
+// a request to an inbox exceeds the configured limit.
+var errInboxBodyTooLarge = errors.New("body too large")
+
+// readInboxBody reads the body of the given request
+// to an inbox into memory, returning an error if it
+// exceeds the configured limit on inbox body size.
+func readInboxBody(r *http.Request) error {
+	limit := int64(config.GetInstanceInboxMaxSize())
+	if limit <= 0 {
+		// No limit.
+		return nil
+	}
+
+	if r.ContentLength > limit {
+		// Don't bother reading it.
+		_ = r.Body.Close()
+		return fmt.Errorf("%w: %d bytes, max %d", errInboxBodyTooLarge, r.ContentLength, limit)
+	}
+
+	// Read up to one byte more than the limit,
+	// to tell whether the body exceeds it or not.
+	b, err := io.ReadAll(io.LimitReader(r.Body, limit+1))
+	_ = r.Body.Close()
+	if err != nil {
+		return gtserror.Newf("error reading body: %w", err)
+	}
+
+	if int64(len(b)) > limit {
+		return fmt.Errorf("%w: over %d bytes, max %d", errInboxBodyTooLarge, limit, limit)
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(b))
+	return nil
+}
+
+// inboxLimits returns the configured limits
+// on the complexity of incoming activities.
+func inboxLimits() ap.Limits {
+	return ap.Limits{
+		MaxRecipients:  config.GetInstanceInboxMaxRecipients(),
+		MaxTags:        config.GetInstanceInboxMaxTags(),
+		MaxAttachments: config.GetInstanceInboxMaxAttachments(),
+		MaxEmojis:      config.GetInstanceInboxMaxEmojis(),
+	}
+}
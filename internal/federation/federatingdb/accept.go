@@ -31,7 +31,7 @@ import (
 )
 
 func (f *federatingDB) Accept(ctx context.Context, accept vocab.ActivityStreamsAccept) error {
-	if log.Level() >= level.DEBUG {
+	if log.CallerLevel() >= level.DEBUG {
 		i, err := marshalItem(accept)
 		if err != nil {
 			return err
@@ -31,7 +31,7 @@ import (
 )
 
 func (f *federatingDB) Announce(ctx context.Context, announce vocab.ActivityStreamsAnnounce) error {
-	if log.Level() >= level.DEBUG {
+	if log.CallerLevel() >= level.DEBUG {
 		i, err := marshalItem(announce)
 		if err != nil {
 			return err
@@ -33,6 +33,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
 // Create adds a new entry to the database which must be able to be
@@ -48,7 +49,7 @@ import (
 // Under certain conditions and network activities, Create may be called
 // multiple times for the same ActivityStreams object.
 func (f *federatingDB) Create(ctx context.Context, asType vocab.Type) error {
-	if log.Level() >= level.TRACE {
+	if log.CallerLevel() >= level.TRACE {
 		i, err := marshalItem(asType)
 		if err != nil {
 			return err
@@ -278,7 +279,7 @@ func (f *federatingDB) createPollOptionables(
 			}
 		}
 
-		if statusURI != inReplyTo.URI {
+		if uris.Normalize(statusURI) != uris.Normalize(inReplyTo.URI) {
 			// All activity votes should be to the same poll per activity.
 			return gtserror.New("votes to multiple polls in single activity")
 		}
@@ -460,6 +461,17 @@ func (f *federatingDB) activityLike(ctx context.Context, asType vocab.Type, rece
 		return errors.New("activityLike: could not convert type to like")
 	}
 
+	// Likes carrying an emoji are reactions
+	// (Misskey, or Pleroma EmojiReact), the
+	// rest we just treat as a plain fave.
+	reaction, err := f.converter.ASLikeToStatusReaction(ctx, like)
+	switch {
+	case err == nil:
+		return f.activityEmojiReact(ctx, reaction, receivingAccount, requestingAccount)
+	case !gtserror.IsWrongType(err):
+		return fmt.Errorf("activityLike: could not convert Like to reaction: %w", err)
+	}
+
 	fave, err := f.converter.ASLikeToFave(ctx, like)
 	if err != nil {
 		return fmt.Errorf("activityLike: could not convert Like to fave: %w", err)
@@ -495,6 +507,37 @@ func (f *federatingDB) activityLike(ctx context.Context, asType vocab.Type, rece
 	return nil
 }
 
+func (f *federatingDB) activityEmojiReact(ctx context.Context, reaction *gtsmodel.StatusReaction, receivingAccount *gtsmodel.Account, requestingAccount *gtsmodel.Account) error {
+	if reaction.AccountID != requestingAccount.ID {
+		return fmt.Errorf(
+			"activityEmojiReact: requestingAccount %s is not Like actor account %s",
+			requestingAccount.URI, reaction.Account.URI,
+		)
+	}
+
+	reaction.ID = id.NewULID()
+
+	if err := f.state.DB.PutStatusReaction(ctx, reaction); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			// The reaction already exists in the database, which
+			// means we've already handled side effects. We can
+			// just return nil here and be done with it.
+			return nil
+		}
+		return fmt.Errorf("activityEmojiReact: database error inserting reaction: %w", err)
+	}
+
+	f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+		APObjectType:   ap.ActivityEmojiReact,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       reaction,
+		Receiving:      receivingAccount,
+		Requesting:     requestingAccount,
+	})
+
+	return nil
+}
+
 /*
 	FLAG HANDLERS
 */
@@ -91,6 +91,57 @@ func (suite *CreateTestSuite) TestCreateNoteForward() {
 	suite.Equal("http://example.org/users/Some_User/statuses/afaba698-5740-4e32-a702-af61aa543bc1", msg.APIRI.String())
 }
 
+func (suite *CreateTestSuite) TestCreateEmojiReact() {
+	reactedAccount := suite.testAccounts["local_account_1"]
+	reactingAccount := suite.testAccounts["remote_account_1"]
+	reactedStatus := suite.testStatuses["local_account_1_status_1"]
+
+	raw := `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "actor": "` + reactingAccount.URI + `",
+  "content": "🦊",
+  "id": "http://fossbros-anonymous.io/activities/01HZ8Q8X1F7M7M4T7W3X9PVA6B",
+  "object": "` + reactedStatus.URI + `",
+  "type": "EmojiReact"
+}`
+
+	m := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(raw), &m); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ap.NormalizeIncomingEmojiReact(m)
+
+	t, err := streams.ToType(context.Background(), m)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ctx := createTestContext(reactedAccount, reactingAccount)
+	if err := suite.federatingDB.Create(ctx, t); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// should be a message heading to the processor now, which we can intercept here
+	msg, _ := suite.getFederatorMsg(5 * time.Second)
+	suite.Equal(ap.ActivityEmojiReact, msg.APObjectType)
+	suite.Equal(ap.ActivityCreate, msg.APActivityType)
+
+	// shiny new reaction should be in the database
+	reaction, err := suite.db.GetStatusReaction(ctx, reactingAccount.ID, reactedStatus.ID, "🦊")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("http://fossbros-anonymous.io/activities/01HZ8Q8X1F7M7M4T7W3X9PVA6B", reaction.URI)
+	suite.Equal(reactedAccount.ID, reaction.TargetAccountID)
+	suite.Equal(reaction.ID, msg.GTSModel.(*gtsmodel.StatusReaction).ID)
+
+	// and it should not be a fave
+	faved, err := suite.db.IsStatusFavedBy(ctx, reactedStatus.ID, reactingAccount.ID)
+	suite.NoError(err)
+	suite.False(faved)
+}
+
 func (suite *CreateTestSuite) TestCreateFlag1() {
 	reportedAccount := suite.testAccounts["local_account_1"]
 	reportingAccount := suite.testAccounts["remote_account_1"]
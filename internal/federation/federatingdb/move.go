@@ -34,10 +34,11 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
 func (f *federatingDB) Move(ctx context.Context, move vocab.ActivityStreamsMove) error {
-	if log.Level() >= level.DEBUG {
+	if log.CallerLevel() >= level.DEBUG {
 		i, err := marshalItem(move)
 		if err != nil {
 			return err
@@ -99,7 +100,7 @@ func (f *federatingDB) Move(ctx context.Context, move vocab.ActivityStreamsMove)
 	object := objects[0]
 	objectStr := object.String()
 
-	if objectStr != requestingAcct.URI {
+	if uris.Normalize(objectStr) != uris.Normalize(requestingAcct.URI) {
 		err := fmt.Errorf(
 			"Move was signed by %s but object was %s",
 			requestingAcct.URI, objectStr,
@@ -116,7 +117,7 @@ func (f *federatingDB) Move(ctx context.Context, move vocab.ActivityStreamsMove)
 	actor := actors[0]
 	actorStr := actor.String()
 
-	if actorStr != requestingAcct.URI {
+	if uris.Normalize(actorStr) != uris.Normalize(requestingAcct.URI) {
 		err := fmt.Errorf(
 			"Move was signed by %s but actor was %s",
 			requestingAcct.URI, actorStr,
@@ -133,7 +134,7 @@ func (f *federatingDB) Move(ctx context.Context, move vocab.ActivityStreamsMove)
 	target := targets[0]
 	targetStr := target.String()
 
-	if targetStr == requestingAcct.URI {
+	if uris.Normalize(targetStr) == uris.Normalize(requestingAcct.URI) {
 		err := fmt.Errorf(
 			"Move target and origin were the same (%s)",
 			targetStr,
@@ -149,7 +150,7 @@ func (f *federatingDB) Move(ctx context.Context, move vocab.ActivityStreamsMove)
 	// Move was really sent to us by requestingAcct.
 	movedToURI := receivingAcct.MovedToURI
 	if movedToURI != "" &&
-		movedToURI != targetStr {
+		uris.Normalize(movedToURI) != uris.Normalize(targetStr) {
 		err := fmt.Errorf(
 			"origin account movedTo is set to %s, which differs from Move target; will not process Move",
 			movedToURI,
@@ -161,9 +162,9 @@ func (f *federatingDB) Move(ctx context.Context, move vocab.ActivityStreamsMove)
 	// values. This will be updated / stored by the
 	// fedi api worker as necessary.
 	stubMove := &gtsmodel.Move{
-		OriginURI: objectStr,
+		OriginURI: uris.Normalize(objectStr),
 		Origin:    object,
-		TargetURI: targetStr,
+		TargetURI: uris.Normalize(targetStr),
 		Target:    target,
 		URI:       moveURIStr,
 	}
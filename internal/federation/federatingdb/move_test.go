@@ -119,6 +119,36 @@ func (suite *MoveTestSuite) TestMove() {
 	suite.Equal(ap.ActivityMove, msg.APActivityType)
 }
 
+func (suite *MoveTestSuite) TestMoveNonNormalizedActor() {
+	var (
+		receivingAcct  = suite.testAccounts["local_account_1"]
+		requestingAcct = suite.testAccounts["remote_account_1"]
+
+		// Actor and object point to the requesting
+		// account, but with a mixed-case host and
+		// the default port included explicitly.
+		moveStr = `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "http://fossbros-anonymous.io/users/foss_satan/moves/01J3A8W2Q5N4RZ7HB2M6KXVD9S",
+  "actor": "http://Fossbros-Anonymous.io:80/users/foss_satan",
+  "type": "Move",
+  "object": "HTTP://FOSSBROS-ANONYMOUS.IO/users/foss_satan",
+  "target": "https://turnip.farm/users/turniplover6969",
+  "to": "http://fossbros-anonymous.io/users/foss_satan/followers"
+}`
+	)
+
+	// Trigger the move.
+	if err := suite.move(receivingAcct, requestingAcct, moveStr); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Should be a message heading to the processor.
+	msg, _ := suite.getFederatorMsg(5 * time.Second)
+	suite.Equal(ap.ObjectProfile, msg.APObjectType)
+	suite.Equal(ap.ActivityMove, msg.APActivityType)
+}
+
 func (suite *MoveTestSuite) TestBadMoves() {
 	var (
 		receivingAcct  = suite.testAccounts["local_account_1"]
@@ -30,7 +30,7 @@ import (
 )
 
 func (f *federatingDB) Reject(ctx context.Context, reject vocab.ActivityStreamsReject) error {
-	if log.Level() >= level.DEBUG {
+	if log.CallerLevel() >= level.DEBUG {
 		i, err := marshalItem(reject)
 		if err != nil {
 			return err
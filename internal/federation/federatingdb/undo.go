@@ -30,12 +30,13 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
 )
 
 func (f *federatingDB) Undo(ctx context.Context, undo vocab.ActivityStreamsUndo) error {
 	l := log.WithContext(ctx)
 
-	if log.Level() >= level.DEBUG {
+	if log.CallerLevel() >= level.DEBUG {
 		i, err := marshalItem(undo)
 		if err != nil {
 			return err
@@ -118,9 +119,25 @@ func (f *federatingDB) undoFollow(
 		return nil
 	}
 
-	// Delete any existing follow with this URI.
-	if err := f.state.DB.DeleteFollowByURI(ctx, follow.URI); err != nil && !errors.Is(err, db.ErrNoEntries) {
-		return fmt.Errorf("undoFollow: db error removing follow: %w", err)
+	// Get any existing follow with this URI.
+	existing, err := f.state.DB.GetFollowByURI(gtscontext.SetBarebones(ctx), follow.URI)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return fmt.Errorf("undoFollow: db error getting follow: %w", err)
+	}
+
+	if existing != nil {
+		// Delete the existing follow.
+		if err := f.state.DB.DeleteFollowByURI(ctx, follow.URI); err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return fmt.Errorf("undoFollow: db error removing follow: %w", err)
+		}
+
+		f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+			APObjectType:   ap.ActivityFollow,
+			APActivityType: ap.ActivityUndo,
+			GTSModel:       existing,
+			Receiving:      receivingAccount,
+			Requesting:     requestingAccount,
+		})
 	}
 
 	// Delete any existing follow request with this URI.
@@ -150,6 +167,16 @@ func (f *federatingDB) undoLike(
 		return nil
 	}
 
+	// Likes carrying an emoji are reactions,
+	// the rest we just treat as a plain fave.
+	reaction, err := f.converter.ASLikeToStatusReaction(ctx, Like)
+	switch {
+	case err == nil:
+		return f.undoEmojiReact(ctx, receivingAccount, requestingAccount, reaction)
+	case !gtserror.IsWrongType(err):
+		return fmt.Errorf("undoLike: error converting ActivityStreams Like to reaction: %w", err)
+	}
+
 	fave, err := f.converter.ASLikeToFave(ctx, Like)
 	if err != nil {
 		return fmt.Errorf("undoLike: error converting ActivityStreams Like to fave: %w", err)
@@ -192,6 +219,59 @@ func (f *federatingDB) undoLike(
 	return nil
 }
 
+func (f *federatingDB) undoEmojiReact(
+	ctx context.Context,
+	receivingAccount *gtsmodel.Account,
+	requestingAccount *gtsmodel.Account,
+	reaction *gtsmodel.StatusReaction,
+) error {
+	// Ensure addressee is reaction target.
+	if reaction.TargetAccountID != receivingAccount.ID {
+		// Ignore this Activity.
+		return nil
+	}
+
+	// Ensure requester is reaction origin.
+	if reaction.AccountID != requestingAccount.ID {
+		// Ignore this Activity.
+		return nil
+	}
+
+	// Select using account, target status and emoji
+	// rather than URI, for the same reasons as with Likes.
+	reaction, err := f.state.DB.GetStatusReaction(
+		gtscontext.SetBarebones(ctx),
+		reaction.AccountID,
+		reaction.StatusID,
+		reaction.Name,
+	)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// We didn't have a reaction
+			// for this combo anyway, ignore.
+			return nil
+		}
+		// Real error.
+		return fmt.Errorf("undoEmojiReact: db error getting reaction: %w", err)
+	}
+
+	// Delete the status reaction.
+	if err := f.state.DB.DeleteStatusReactionByID(ctx, reaction.ID); err != nil {
+		return fmt.Errorf("undoEmojiReact: db error deleting reaction %s: %w", reaction.ID, err)
+	}
+
+	f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+		APObjectType:   ap.ActivityEmojiReact,
+		APActivityType: ap.ActivityUndo,
+		GTSModel:       reaction,
+		Receiving:      receivingAccount,
+		Requesting:     requestingAccount,
+	})
+
+	log.Debug(ctx, "EmojiReact undone")
+	return nil
+}
+
 func (f *federatingDB) undoBlock(
 	ctx context.Context,
 	receivingAccount *gtsmodel.Account,
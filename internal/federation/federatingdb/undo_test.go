@@ -0,0 +1,106 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type UndoTestSuite struct {
+	FederatingDBTestSuite
+}
+
+func (suite *UndoTestSuite) TestUndoEmojiReact() {
+	var (
+		ctx             = context.Background()
+		reactedAccount  = suite.testAccounts["local_account_1"]
+		reactingAccount = suite.testAccounts["remote_account_1"]
+		reactedStatus   = suite.testStatuses["local_account_1_status_1"]
+		reactionURI     = "http://fossbros-anonymous.io/activities/01HZ8Q8X1F7M7M4T7W3X9PVA6B"
+	)
+
+	if err := suite.db.PutStatusReaction(ctx, &gtsmodel.StatusReaction{
+		ID:              "01HZ8QB0K3DZ6R9RS0T0D7W1YH",
+		AccountID:       reactingAccount.ID,
+		TargetAccountID: reactedAccount.ID,
+		StatusID:        reactedStatus.ID,
+		Name:            "🦊",
+		URI:             reactionURI,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	raw := `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "actor": "` + reactingAccount.URI + `",
+  "id": "http://fossbros-anonymous.io/activities/01HZ8QC2Y2V4P6H1Y8Q2J5M0ZN",
+  "object": {
+    "actor": "` + reactingAccount.URI + `",
+    "content": "🦊",
+    "id": "` + reactionURI + `",
+    "object": "` + reactedStatus.URI + `",
+    "type": "EmojiReact"
+  },
+  "type": "Undo"
+}`
+
+	m := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(raw), &m); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ap.NormalizeIncomingEmojiReact(m)
+
+	t, err := streams.ToType(context.Background(), m)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	undo, ok := t.(vocab.ActivityStreamsUndo)
+	if !ok {
+		suite.FailNow("", "%T was not an undo", t)
+	}
+
+	if err := suite.federatingDB.Undo(createTestContext(reactedAccount, reactingAccount), undo); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// should be a message heading to the processor now, which we can intercept here
+	msg, _ := suite.getFederatorMsg(5 * time.Second)
+	suite.Equal(ap.ActivityEmojiReact, msg.APObjectType)
+	suite.Equal(ap.ActivityUndo, msg.APActivityType)
+
+	// reaction should be gone from the database
+	_, err = suite.db.GetStatusReactionByURI(ctx, reactionURI)
+	suite.True(errors.Is(err, db.ErrNoEntries))
+}
+
+func TestUndoTestSuite(t *testing.T) {
+	suite.Run(t, &UndoTestSuite{})
+}
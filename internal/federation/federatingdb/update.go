@@ -30,6 +30,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
 // Update sets an existing entry to the database based on the value's
@@ -44,7 +45,7 @@ import (
 func (f *federatingDB) Update(ctx context.Context, asType vocab.Type) error {
 	l := log.WithContext(ctx)
 
-	if log.Level() >= level.DEBUG {
+	if log.CallerLevel() >= level.DEBUG {
 		i, err := marshalItem(asType)
 		if err != nil {
 			return err
@@ -89,7 +90,7 @@ func (f *federatingDB) updateAccountable(ctx context.Context, receivingAcct *gts
 	}
 
 	// Check that update was by the account themselves.
-	if accountURIStr != requestingAcct.URI {
+	if uris.Normalize(accountURIStr) != uris.Normalize(requestingAcct.URI) {
 		return gtserror.Newf("update for %s was not requested by owner", accountURIStr)
 	}
 
@@ -303,6 +303,7 @@ func (f *Federator) Blocked(ctx context.Context, actorIRIs []*url.URL) (bool, er
 
 	if blocked {
 		l.Trace("receiving account blocks requesting account")
+		f.recordBlockedInteraction(ctx, receivingAccount, requestingAccount)
 		return blocked, nil
 	}
 
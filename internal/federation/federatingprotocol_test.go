@@ -21,6 +21,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -30,6 +31,8 @@ import (
 	errorsv2 "codeberg.org/gruf/go-errors/v2"
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -411,6 +414,82 @@ func (suite *FederatingProtocolTestSuite) TestBlockedReceiverBlocksRequester() {
 	suite.True(blocked)
 }
 
+func (suite *FederatingProtocolTestSuite) TestBlockedReceiverBlocksRequesterReport() {
+	var (
+		ctx               = context.Background()
+		receivingAccount  = suite.testAccounts["local_account_1"]
+		requestingAccount = suite.testAccounts["remote_account_1"]
+		otherIRIs         = []*url.URL{}
+		actorIRIs         = []*url.URL{
+			testrig.URLMustParse(requestingAccount.URI),
+		}
+	)
+
+	// Open a report after 3 attempts.
+	config.SetInstanceBlockedInteractionsThreshold(3)
+
+	// Insert a block from receivingAccount targeting requestingAccount.
+	if err := suite.state.DB.PutBlock(ctx, &gtsmodel.Block{
+		ID:              "01G3KBEMJD4VQ2D615MPV7KTRD",
+		URI:             "whatever",
+		AccountID:       receivingAccount.ID,
+		TargetAccountID: requestingAccount.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	instanceAcct, err := suite.state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	getReports := func() []*gtsmodel.Report {
+		reports, err := suite.state.DB.GetReports(ctx, nil, instanceAcct.ID, requestingAccount.ID, "", "", "", 0)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			suite.FailNow(err.Error())
+		}
+		return reports
+	}
+
+	attempt := func() {
+		blocked, err := suite.blocked(
+			ctx,
+			receivingAccount,
+			requestingAccount,
+			otherIRIs,
+			actorIRIs,
+		)
+		suite.NoError(err)
+		suite.True(blocked)
+	}
+
+	for i := 0; i < 3; i++ {
+		// No report should be opened
+		// before the threshold is hit.
+		suite.Empty(getReports())
+		attempt()
+	}
+
+	// There should now be a report.
+	reports := getReports()
+	if suite.Len(reports, 1) {
+		suite.Equal("Automatically opened: 3 attempts within 24h0m0s to interact with @the_mighty_zork, who blocks this account.", reports[0].Comment)
+		suite.False(*reports[0].Forwarded)
+	}
+
+	for i := 0; i < 3; i++ {
+		attempt()
+	}
+
+	// The same report should have been
+	// updated, rather than a new one opened.
+	reports = getReports()
+	if suite.Len(reports, 1) {
+		suite.Equal("Automatically opened: 3 attempts within 24h0m0s to interact with @the_mighty_zork, who blocks this account.\n"+
+			"Automatically opened: 3 attempts within 24h0m0s to interact with @the_mighty_zork, who blocks this account.", reports[0].Comment)
+	}
+}
+
 func (suite *FederatingProtocolTestSuite) TestBlockedCCd() {
 	var (
 		receivingAccount  = suite.testAccounts["local_account_1"]
@@ -35,6 +35,7 @@ var _ interface {
 } = &Federator{}
 
 type Federator struct {
+	state               *state.State
 	db                  db.DB
 	federatingDB        federatingdb.DB
 	clock               pub.Clock
@@ -42,6 +43,7 @@ type Federator struct {
 	transportController transport.Controller
 	mediaManager        *media.Manager
 	actor               pub.FederatingActor
+	blockedInteractions blockedInteractions
 	dereferencing.Dereferencer
 }
 
@@ -56,6 +58,7 @@ func NewFederator(
 ) *Federator {
 	clock := &Clock{}
 	f := &Federator{
+		state:               state,
 		db:                  state.DB,
 		federatingDB:        federatingDB,
 		clock:               clock,
@@ -93,6 +93,17 @@ func (f *Filter) isStatusHomeTimelineable(ctx context.Context, owner *gtsmodel.A
 		return true, nil
 	}
 
+	// Check whether owner has muted the author.
+	muted, err := f.isStatusMuted(ctx, owner, status)
+	if err != nil {
+		return false, err
+	}
+
+	if muted {
+		log.Trace(ctx, "ignoring status from muted account")
+		return false, nil
+	}
+
 	if status.MentionsAccount(owner.ID) {
 		// Can always see when you are mentioned.
 		return true, nil
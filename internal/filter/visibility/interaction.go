@@ -0,0 +1,125 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package visibility
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// StatusInteractable checks whether the given status' interaction policy permits requester
+// to perform the given type of interaction with it. This does NOT check visibility of the status.
+func (f *Filter) StatusInteractable(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	status *gtsmodel.Status,
+	interaction gtsmodel.InteractionType,
+) (bool, error) {
+	if requester == nil {
+		// Interactions always require an account.
+		return false, nil
+	}
+
+	if requester.ID == status.AccountID {
+		// Status author can always
+		// interact with their own status.
+		return true, nil
+	}
+
+	// Statuses with the older interaction flags
+	// unset are restricted to their author only.
+	var flag *bool
+	switch interaction {
+	case gtsmodel.InteractionReply:
+		flag = status.Replyable
+	case gtsmodel.InteractionAnnounce:
+		flag = status.Boostable
+	case gtsmodel.InteractionLike:
+		flag = status.Likeable
+	}
+
+	if !util.PtrValueOr(flag, true) {
+		log.Trace(ctx, "status interaction flag unset")
+		return false, nil
+	}
+
+	for _, value := range status.InteractionPolicy.Values(interaction) {
+		permitted, err := f.policyValuePermits(ctx, requester, status, value)
+		if err != nil {
+			return false, err
+		}
+
+		if permitted {
+			return true, nil
+		}
+	}
+
+	log.Trace(ctx, "status interaction policy does not permit requester")
+	return false, nil
+}
+
+// policyValuePermits returns whether the given interaction policy value
+// of status matches requester, expecting requester to not be the author.
+func (f *Filter) policyValuePermits(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	status *gtsmodel.Status,
+	value gtsmodel.PolicyValue,
+) (bool, error) {
+	switch value {
+	case gtsmodel.PolicyValuePublic:
+		return true, nil
+
+	case gtsmodel.PolicyValueFollowers:
+		// Requester must follow the status author.
+		following, err := f.state.DB.IsFollowing(ctx, requester.ID, status.AccountID)
+		if err != nil {
+			return false, gtserror.Newf("error checking follow: %w", err)
+		}
+		return following, nil
+
+	case gtsmodel.PolicyValueFollowing:
+		// Status author must follow the requester.
+		following, err := f.state.DB.IsFollowing(ctx, status.AccountID, requester.ID)
+		if err != nil {
+			return false, gtserror.Newf("error checking follow: %w", err)
+		}
+		return following, nil
+
+	case gtsmodel.PolicyValueMentioned:
+		if !status.MentionsPopulated() {
+			var err error
+
+			// Status needs its mentions populating, fetch these from database.
+			status.Mentions, err = f.state.DB.GetMentions(ctx, status.MentionIDs)
+			if err != nil {
+				return false, gtserror.Newf("error populating status %s mentions: %w", status.ID, err)
+			}
+		}
+		return status.MentionsAccount(requester.ID), nil
+
+	default:
+		// Includes PolicyValueAuthor,
+		// which only the author matches.
+		return false, nil
+	}
+}
@@ -0,0 +1,148 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package visibility_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type StatusInteractableTestSuite struct {
+	FilterStandardTestSuite
+}
+
+// withPolicy returns a copy of the given
+// status with the given interaction policy.
+func withPolicy(status *gtsmodel.Status, policy *gtsmodel.InteractionPolicy) *gtsmodel.Status {
+	status2 := new(gtsmodel.Status)
+	*status2 = *status
+	status2.InteractionPolicy = policy
+	return status2
+}
+
+func (suite *StatusInteractableTestSuite) TestNoPolicyInteractable() {
+	testStatus := suite.testStatuses["admin_account_status_1"]
+	testAccount := suite.testAccounts["local_account_2"]
+	ctx := context.Background()
+
+	for _, interaction := range []gtsmodel.InteractionType{
+		gtsmodel.InteractionReply,
+		gtsmodel.InteractionAnnounce,
+		gtsmodel.InteractionLike,
+	} {
+		interactable, err := suite.filter.StatusInteractable(ctx, testAccount, testStatus, interaction)
+		suite.NoError(err)
+		suite.True(interactable)
+	}
+}
+
+func (suite *StatusInteractableTestSuite) TestFlagUnsetNotInteractable() {
+	testStatus := withPolicy(suite.testStatuses["admin_account_status_1"], nil)
+	testStatus.Likeable = util.Ptr(false)
+	testAccount := suite.testAccounts["local_account_1"]
+	ctx := context.Background()
+
+	interactable, err := suite.filter.StatusInteractable(ctx, testAccount, testStatus, gtsmodel.InteractionLike)
+	suite.NoError(err)
+	suite.False(interactable)
+
+	// Replies are governed by their own flag.
+	interactable, err = suite.filter.StatusInteractable(ctx, testAccount, testStatus, gtsmodel.InteractionReply)
+	suite.NoError(err)
+	suite.True(interactable)
+}
+
+func (suite *StatusInteractableTestSuite) TestFollowersPolicy() {
+	testStatus := withPolicy(suite.testStatuses["admin_account_status_1"], &gtsmodel.InteractionPolicy{
+		CanReply: gtsmodel.PolicyValues{gtsmodel.PolicyValueFollowers},
+	})
+	ctx := context.Background()
+
+	// local_account_1 follows admin_account.
+	interactable, err := suite.filter.StatusInteractable(ctx, suite.testAccounts["local_account_1"], testStatus, gtsmodel.InteractionReply)
+	suite.NoError(err)
+	suite.True(interactable)
+
+	// local_account_2 does not.
+	interactable, err = suite.filter.StatusInteractable(ctx, suite.testAccounts["local_account_2"], testStatus, gtsmodel.InteractionReply)
+	suite.NoError(err)
+	suite.False(interactable)
+
+	// Other interactions remain public.
+	interactable, err = suite.filter.StatusInteractable(ctx, suite.testAccounts["local_account_2"], testStatus, gtsmodel.InteractionLike)
+	suite.NoError(err)
+	suite.True(interactable)
+}
+
+func (suite *StatusInteractableTestSuite) TestFollowingPolicy() {
+	testStatus := withPolicy(suite.testStatuses["admin_account_status_1"], &gtsmodel.InteractionPolicy{
+		CanAnnounce: gtsmodel.PolicyValues{gtsmodel.PolicyValueFollowing},
+	})
+	ctx := context.Background()
+
+	// admin_account follows local_account_1.
+	interactable, err := suite.filter.StatusInteractable(ctx, suite.testAccounts["local_account_1"], testStatus, gtsmodel.InteractionAnnounce)
+	suite.NoError(err)
+	suite.True(interactable)
+
+	// admin_account doesn't follow local_account_2.
+	interactable, err = suite.filter.StatusInteractable(ctx, suite.testAccounts["local_account_2"], testStatus, gtsmodel.InteractionAnnounce)
+	suite.NoError(err)
+	suite.False(interactable)
+}
+
+func (suite *StatusInteractableTestSuite) TestMentionedPolicy() {
+	testStatus := withPolicy(suite.testStatuses["local_account_2_status_5"], &gtsmodel.InteractionPolicy{
+		CanLike: gtsmodel.PolicyValues{gtsmodel.PolicyValueMentioned},
+	})
+	ctx := context.Background()
+
+	// local_account_1 is mentioned.
+	interactable, err := suite.filter.StatusInteractable(ctx, suite.testAccounts["local_account_1"], testStatus, gtsmodel.InteractionLike)
+	suite.NoError(err)
+	suite.True(interactable)
+
+	// admin_account isn't.
+	interactable, err = suite.filter.StatusInteractable(ctx, suite.testAccounts["admin_account"], testStatus, gtsmodel.InteractionLike)
+	suite.NoError(err)
+	suite.False(interactable)
+}
+
+func (suite *StatusInteractableTestSuite) TestAuthorPolicy() {
+	testStatus := withPolicy(suite.testStatuses["admin_account_status_1"], &gtsmodel.InteractionPolicy{
+		CanReply: gtsmodel.PolicyValues{gtsmodel.PolicyValueAuthor},
+	})
+	ctx := context.Background()
+
+	interactable, err := suite.filter.StatusInteractable(ctx, suite.testAccounts["local_account_1"], testStatus, gtsmodel.InteractionReply)
+	suite.NoError(err)
+	suite.False(interactable)
+
+	// The author can always interact.
+	interactable, err = suite.filter.StatusInteractable(ctx, suite.testAccounts["admin_account"], testStatus, gtsmodel.InteractionReply)
+	suite.NoError(err)
+	suite.True(interactable)
+}
+
+func TestStatusInteractableTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusInteractableTestSuite))
+}
@@ -0,0 +1,68 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package visibility
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// isStatusMuted checks whether the given status was authored or
+// boosted by an account the requester has (unexpired) muted.
+func (f *Filter) isStatusMuted(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status) (bool, error) {
+	if requester == nil {
+		// Without auth
+		// nothing is muted.
+		return false, nil
+	}
+
+	accountIDs := []string{status.AccountID}
+	if status.BoostOfAccountID != "" {
+		// Also check boosted status author.
+		accountIDs = append(accountIDs, status.BoostOfAccountID)
+	}
+
+	now := time.Now()
+	for _, accountID := range accountIDs {
+		if accountID == requester.ID {
+			// Can't mute yourself.
+			continue
+		}
+
+		mute, err := f.state.DB.GetMute(
+			gtscontext.SetBarebones(ctx),
+			requester.ID,
+			accountID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return false, gtserror.Newf("error checking mute %s->%s: %w", requester.ID, accountID, err)
+		}
+
+		if mute != nil && !mute.Expired(now) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
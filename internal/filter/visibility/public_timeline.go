@@ -91,6 +91,17 @@ func (f *Filter) isStatusPublicTimelineable(ctx context.Context, requester *gtsm
 		return false, nil
 	}
 
+	// Check whether requester has muted the author.
+	muted, err := f.isStatusMuted(ctx, requester, status)
+	if err != nil {
+		return false, err
+	}
+
+	if muted {
+		log.Trace(ctx, "ignoring status from muted account")
+		return false, nil
+	}
+
 	for parent := status; parent.InReplyToURI != ""; {
 		// Fetch next parent to lookup.
 		parentID := parent.InReplyToID
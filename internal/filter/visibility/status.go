@@ -25,6 +25,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // StatusesVisible calls StatusVisible for each status in the statuses slice, and returns a slice of only statuses which are visible to the requester.
@@ -90,6 +91,16 @@ func (f *Filter) isStatusVisible(ctx context.Context, requester *gtsmodel.Accoun
 		return false, nil
 	}
 
+	if util.PtrValueOr(status.PendingApproval, false) {
+		// Interactions held pending approval are
+		// only visible to their author, and to the
+		// author of the status they interact with.
+		return requester != nil &&
+			(requester.ID == status.AccountID ||
+				requester.ID == status.InReplyToAccountID ||
+				requester.ID == status.BoostOfAccountID), nil
+	}
+
 	if status.Visibility == gtsmodel.VisibilityPublic {
 		// This status will be visible to all.
 		return true, nil
@@ -55,6 +55,17 @@ func (f *Filter) StatusTagTimelineable(
 		return false, nil
 	}
 
+	// Check whether requester has muted the author.
+	muted, err := f.isStatusMuted(ctx, requester, status)
+	if err != nil {
+		return false, err
+	}
+
+	if muted {
+		log.Trace(ctx, "ignoring status from muted account")
+		return false, nil
+	}
+
 	// Looks good!
 	return true, nil
 }
@@ -21,6 +21,7 @@ import (
 	"context"
 	"net/http"
 	"net/url"
+	"sync/atomic"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/httpsig"
@@ -44,6 +45,8 @@ const (
 	httpSigPubKeyIDKey
 	dryRunKey
 	httpClientSignFnKey
+	idempotencyKey
+	derefBudgetKey
 )
 
 // DryRun returns whether the "dryrun" context key has been set. This can be
@@ -75,6 +78,21 @@ func SetRequestID(ctx context.Context, id string) context.Context {
 	return context.WithValue(ctx, requestIDKey, id)
 }
 
+// IdempotencyKey returns the client-supplied idempotency key associated with
+// context. This value will usually be set by client API handlers from the
+// 'Idempotency-Key' request header, and can be used to deduplicate retried
+// requests that would otherwise create the same item more than once.
+func IdempotencyKey(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKey).(string)
+	return key
+}
+
+// SetIdempotencyKey stores the given idempotency key and returns the wrapped
+// context. See IdempotencyKey() for further information on the idempotency key.
+func SetIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKey, key)
+}
+
 // OutgoingPublicKeyID returns the public key ID (URI) associated with context. This
 // value is useful for logging situations in which a given public key URI is
 // relevant, e.g. for outgoing requests being signed by the given key.
@@ -208,3 +226,27 @@ func Barebones(ctx context.Context) bool {
 func SetBarebones(ctx context.Context) context.Context {
 	return context.WithValue(ctx, barebonesKey, struct{}{})
 }
+
+// TakeDerefBudget takes one remote fetch from the dereference budget set
+// on the context, returning false if it's already spent. This can be used
+// to limit how many remote fetches processing one incoming activity may
+// trigger synchronously, deferring the rest. With no budget set, always true.
+func TakeDerefBudget(ctx context.Context) bool {
+	budget, ok := ctx.Value(derefBudgetKey).(*atomic.Int64)
+	if !ok {
+		return true
+	}
+	return budget.Add(-1) >= 0
+}
+
+// SetDerefBudget sets a dereference budget of n remote fetches on the context
+// and returns this wrapped context, n <= 0 meaning no budget. The budget is
+// shared by all contexts derived from the returned one. See TakeDerefBudget().
+func SetDerefBudget(ctx context.Context, n int) context.Context {
+	if n <= 0 {
+		return ctx
+	}
+	budget := new(atomic.Int64)
+	budget.Store(int64(n))
+	return context.WithValue(ctx, derefBudgetKey, budget)
+}
@@ -25,8 +25,23 @@ import (
 type errkey int
 
 // ErrorType denotes the type of an error, if set.
+//
+// Types are short, machine-readable codes which are
+// served to API / federation callers as error_code,
+// so they can handle the error programmatically.
 type ErrorType string
 
+// Error types served to callers. These
+// must not be changed once published!
+const (
+	TypeRateLimited      ErrorType = "rate_limited"
+	TypeCapacityExceeded ErrorType = "capacity_exceeded"
+	TypeDomainBlocked    ErrorType = "domain_blocked"
+	TypeSignatureMissing ErrorType = "signature_missing"
+	TypeSignatureInvalid ErrorType = "signature_invalid"
+	TypeTermsNotAccepted ErrorType = "terms_not_accepted"
+)
+
 const (
 	// error value keys.
 	_ errkey = iota
@@ -42,6 +57,20 @@ const (
 	notPermittedKey
 )
 
+// Type checks error for a stored "type" value,
+// returning empty string if none is set. For
+// example the reason a request was rejected.
+func Type(err error) ErrorType {
+	t, _ := errors.Value(err, errorTypeKey).(ErrorType)
+	return t
+}
+
+// SetType will wrap the given error to store the given type,
+// returning wrapped error. See Type() for example use-cases.
+func SetType(err error, errType ErrorType) error {
+	return errors.WithValue(err, errorTypeKey, errType)
+}
+
 // IsUnretrievable indicates that a call to retrieve a resource
 // (account, status, attachment, etc) could not be fulfilled, either
 // because it was not found locally, or because some prerequisite
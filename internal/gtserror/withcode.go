@@ -191,6 +191,45 @@ func NewErrorGone(original error, helpText ...string) WithCode {
 	}
 }
 
+// NewErrorNotImplemented returns an ErrorWithCode 501 with the given original error and optional help text.
+func NewErrorNotImplemented(original error, helpText ...string) WithCode {
+	safe := http.StatusText(http.StatusNotImplemented)
+	if helpText != nil {
+		safe = safe + ": " + strings.Join(helpText, ": ")
+	}
+	return withCode{
+		original: original,
+		safe:     errors.New(safe),
+		code:     http.StatusNotImplemented,
+	}
+}
+
+// NewErrorTooManyRequests returns an ErrorWithCode 429 with the given original error and optional help text.
+func NewErrorTooManyRequests(original error, helpText ...string) WithCode {
+	safe := http.StatusText(http.StatusTooManyRequests)
+	if helpText != nil {
+		safe = safe + ": " + strings.Join(helpText, ": ")
+	}
+	return withCode{
+		original: original,
+		safe:     errors.New(safe),
+		code:     http.StatusTooManyRequests,
+	}
+}
+
+// NewErrorServiceUnavailable returns an ErrorWithCode 503 with the given original error and optional help text.
+func NewErrorServiceUnavailable(original error, helpText ...string) WithCode {
+	safe := http.StatusText(http.StatusServiceUnavailable)
+	if helpText != nil {
+		safe = safe + ": " + strings.Join(helpText, ": ")
+	}
+	return withCode{
+		original: original,
+		safe:     errors.New(safe),
+		code:     http.StatusServiceUnavailable,
+	}
+}
+
 // NewErrorClientClosedRequest returns an ErrorWithCode 499 with the given original error.
 // This error type should only be used when an http caller has already hung up their request.
 // See: https://en.wikipedia.org/wiki/List_of_HTTP_status_codes#nginx
@@ -54,6 +54,7 @@ type Account struct {
 	NoteRaw                 string           `bun:""`                                                            // The raw contents of .Note without conversion to HTML, only available when requester = target
 	Memorial                *bool            `bun:",default:false"`                                              // Is this a memorial account, ie., has the user passed away?
 	AlsoKnownAsURIs         []string         `bun:"also_known_as_uris,array"`                                    // This account is associated with these account URIs.
+	AttributionDomains      []string         `bun:"attribution_domains,array"`                                   // This account may be credited as author of content hosted on these domains (and their subdomains).
 	AlsoKnownAs             []*Account       `bun:"-"`                                                           // This account is associated with these accounts (field not stored in the db).
 	MovedToURI              string           `bun:",nullzero"`                                                   // This account has (or claims to have) moved to this account URI. Even if this field is set the move may not yet have been processed. Check `move` for this.
 	MovedTo                 *Account         `bun:"-"`                                                           // This account has moved to this account (field not stored in the db).
@@ -175,6 +176,20 @@ func (a *Account) IsAliasedTo(uri string) bool {
 	return slices.Contains(a.AlsoKnownAsURIs, uri)
 }
 
+// CanBeAttributedFrom returns true if account allows
+// itself to be credited as author of content hosted
+// on the given host, ie., host is one of its attribution
+// domains, or a subdomain of one of them.
+func (a *Account) CanBeAttributedFrom(host string) bool {
+	host = strings.ToLower(host)
+	for _, domain := range a.AttributionDomains {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
 // IsSuspended returns true if account
 // has been suspended from this instance.
 func (a *Account) IsSuspended() bool {
@@ -206,20 +221,22 @@ type Field struct {
 
 // Relationship describes a requester's relationship with another account.
 type Relationship struct {
-	ID                  string // The account id.
-	Following           bool   // Are you following this user?
-	ShowingReblogs      bool   // Are you receiving this user's boosts in your home timeline?
-	Notifying           bool   // Have you enabled notifications for this user?
-	FollowedBy          bool   // Are you followed by this user?
-	Blocking            bool   // Are you blocking this user?
-	BlockedBy           bool   // Is this user blocking you?
-	Muting              bool   // Are you muting this user?
-	MutingNotifications bool   // Are you muting notifications from this user?
-	Requested           bool   // Do you have a pending follow request targeting this user?
-	RequestedBy         bool   // Does the user have a pending follow request targeting you?
-	DomainBlocking      bool   // Are you blocking this user's domain?
-	Endorsed            bool   // Are you featuring this user on your profile?
-	Note                string // Your note on this account.
+	ID                  string    // The account id.
+	Following           bool      // Are you following this user?
+	ShowingReblogs      bool      // Are you receiving this user's boosts in your home timeline?
+	Notifying           bool      // Have you enabled notifications for this user?
+	FollowedBy          bool      // Are you followed by this user?
+	Blocking            bool      // Are you blocking this user?
+	BlockingExpiresAt   time.Time // When does your block on this user expire, if ever?
+	BlockedBy           bool      // Is this user blocking you?
+	Muting              bool      // Are you muting this user?
+	MutingNotifications bool      // Are you muting notifications from this user?
+	MutingExpiresAt     time.Time // When does your mute on this user expire, if ever?
+	Requested           bool      // Do you have a pending follow request targeting this user?
+	RequestedBy         bool      // Does the user have a pending follow request targeting you?
+	DomainBlocking      bool      // Are you blocking this user's domain?
+	Endorsed            bool      // Are you featuring this user on your profile?
+	Note                string    // Your note on this account.
 }
 
 // Theme represents a user-selected
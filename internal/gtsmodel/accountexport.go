@@ -0,0 +1,50 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// AccountExportState is the progress state of an account export.
+type AccountExportState string
+
+const (
+	AccountExportStatePending    AccountExportState = "pending"    // AccountExportStatePending -- export is queued, and will be generated soon.
+	AccountExportStateProcessing AccountExportState = "processing" // AccountExportStateProcessing -- export archive is currently being generated.
+	AccountExportStateDone       AccountExportState = "done"       // AccountExportStateDone -- export archive is ready for download.
+	AccountExportStateFailed     AccountExportState = "failed"     // AccountExportStateFailed -- export archive could not be generated.
+)
+
+// AccountExport represents a request from a local account to export
+// its data as an archive, and the archive generated for it, if any.
+type AccountExport struct {
+	ID        string             `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID string             `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the local account that requested the export.
+	Account   *Account           `bun:"-"`                                                           // Account corresponding to AccountID.
+	State     AccountExportState `bun:",nullzero,notnull"`                                           // Progress state of the export.
+	FilePath  string             `bun:",nullzero"`                                                   // Path of the export archive in storage, once generated.
+	FileSize  int                `bun:",notnull,default:0"`                                          // Size of the export archive in bytes, once generated.
+}
+
+// Finished returns whether this export is done
+// being processed, whether successfully or not.
+func (e *AccountExport) Finished() bool {
+	return e.State == AccountExportStateDone ||
+		e.State == AccountExportStateFailed
+}
@@ -0,0 +1,79 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// AccountImportType is the kind of data imported by an account import.
+type AccountImportType string
+
+const (
+	AccountImportTypeFollowing AccountImportType = "following" // AccountImportTypeFollowing -- accounts to follow.
+	AccountImportTypeBlocking  AccountImportType = "blocking"  // AccountImportTypeBlocking -- accounts to block.
+	AccountImportTypeMuting    AccountImportType = "muting"    // AccountImportTypeMuting -- accounts to mute.
+	AccountImportTypeLists     AccountImportType = "lists"     // AccountImportTypeLists -- accounts to add to lists.
+)
+
+// AccountImportState is the progress state of an account import.
+type AccountImportState string
+
+const (
+	AccountImportStatePending    AccountImportState = "pending"    // AccountImportStatePending -- import is queued, and will be processed soon.
+	AccountImportStateProcessing AccountImportState = "processing" // AccountImportStateProcessing -- rows of the import are currently being processed.
+	AccountImportStateDone       AccountImportState = "done"       // AccountImportStateDone -- all rows of the import have been processed.
+	AccountImportStateFailed     AccountImportState = "failed"     // AccountImportStateFailed -- import could not be processed, and was stopped.
+)
+
+// AccountImport represents a request from a local account to import
+// relationships or lists from a Mastodon-compatible CSV export.
+type AccountImport struct {
+	ID        string             `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID string             `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the local account that requested the import.
+	Account   *Account           `bun:"-"`                                                           // Account corresponding to AccountID.
+	Type      AccountImportType  `bun:",nullzero,notnull"`                                           // Kind of data being imported.
+	State     AccountImportState `bun:",nullzero,notnull"`                                           // Progress state of the import.
+	TotalRows int                `bun:",notnull,default:0"`                                          // Total number of rows to import.
+}
+
+// Finished returns whether this import is done
+// being processed, whether successfully or not.
+func (i *AccountImport) Finished() bool {
+	return i.State == AccountImportStateDone ||
+		i.State == AccountImportStateFailed
+}
+
+// AccountImportRowState is the progress state of one row of an account import.
+type AccountImportRowState string
+
+const (
+	AccountImportRowStatePending AccountImportRowState = "pending" // AccountImportRowStatePending -- row has not been processed yet.
+	AccountImportRowStateDone    AccountImportRowState = "done"    // AccountImportRowStateDone -- row was imported successfully.
+	AccountImportRowStateFailed  AccountImportRowState = "failed"  // AccountImportRowStateFailed -- row could not be imported, see Error.
+)
+
+// AccountImportRow represents one row of the CSV file of an account import.
+type AccountImportRow struct {
+	ID       string                `bun:"type:CHAR(26),pk,nullzero,notnull,unique"` // id of this item in the database
+	ImportID string                `bun:"type:CHAR(26),nullzero,notnull"`           // ID of the account import this row belongs to.
+	Line     int                   `bun:",notnull"`                                 // Line number of this row in the CSV file.
+	Record   []string              `bun:"record,array"`                             // Fields of this row in the CSV file.
+	State    AccountImportRowState `bun:",nullzero,notnull"`                        // Progress state of this row.
+	Error    string                `bun:",nullzero"`                                // Reason this row could not be imported, if it failed.
+}
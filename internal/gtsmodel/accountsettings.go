@@ -21,15 +21,31 @@ import "time"
 
 // AccountSettings models settings / preferences for a local, non-instance account.
 type AccountSettings struct {
-	AccountID         string     `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // AccountID that owns this settings.
-	CreatedAt         time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created.
-	UpdatedAt         time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item was last updated.
-	Privacy           Visibility `bun:",nullzero"`                                                   // Default post privacy for this account
-	Sensitive         *bool      `bun:",nullzero,notnull,default:false"`                             // Set posts from this account to sensitive by default?
-	Language          string     `bun:",nullzero,notnull,default:'en'"`                              // What language does this account post in?
-	StatusContentType string     `bun:",nullzero"`                                                   // What is the default format for statuses posted by this account (only for local accounts).
-	Theme             string     `bun:",nullzero"`                                                   // Preset CSS theme filename selected by this Account (empty string if nothing set).
-	CustomCSS         string     `bun:",nullzero"`                                                   // Custom CSS that should be displayed for this Account's profile and statuses.
-	EnableRSS         *bool      `bun:",nullzero,notnull,default:false"`                             // enable RSS feed subscription for this account's public posts at [URL]/feed
-	HideCollections   *bool      `bun:",nullzero,notnull,default:false"`                             // Hide this account's followers/following collections.
+	AccountID             string     `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // AccountID that owns this settings.
+	CreatedAt             time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created.
+	UpdatedAt             time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item was last updated.
+	Privacy               Visibility `bun:",nullzero"`                                                   // Default post privacy for this account
+	Sensitive             *bool      `bun:",nullzero,notnull,default:false"`                             // Set posts from this account to sensitive by default?
+	Language              string     `bun:",nullzero,notnull,default:'en'"`                              // What language does this account post in?
+	StatusContentType     string     `bun:",nullzero"`                                                   // What is the default format for statuses posted by this account (only for local accounts).
+	Theme                 string     `bun:",nullzero"`                                                   // Preset CSS theme filename selected by this Account (empty string if nothing set).
+	CustomCSS             string     `bun:",nullzero"`                                                   // Custom CSS that should be displayed for this Account's profile and statuses.
+	EnableRSS             *bool      `bun:",nullzero,notnull,default:false"`                             // enable RSS feed subscription for this account's public posts at [URL]/feed
+	HideCollections       *bool      `bun:",nullzero,notnull,default:false"`                             // Hide this account's followers/following collections.
+	HideApplication       *bool      `bun:",nullzero,notnull,default:false"`                             // Hide which application was used to create this account's statuses.
+	EnableEmbeds          *bool      `bun:",nullzero,notnull,default:false"`                             // Allow this account's public statuses to be embedded in other websites via oEmbed.
+	NotifyNewFromDays     int        `bun:",notnull,default:0"`                                          // Notify of posts from followed accounts after this many days of inactivity (0 = disabled).
+	StatusRateLimit       int        `bun:",notnull,default:0"`                                          // Maximum number of statuses this account may create per hour (0 = no limit).
+	EmailWhenAwayHours    int        `bun:",notnull,default:0"`                                          // Email about new mentions after this many hours without activity (0 = disabled).
+	AutoApproveFollowed   *bool      `bun:",nullzero,notnull,default:false"`                             // Automatically approve follow requests from accounts this account already follows.
+	AutoApproveLocal      *bool      `bun:",nullzero,notnull,default:false"`                             // Automatically approve follow requests from local accounts.
+	AutoApproveMinAgeDays int        `bun:",notnull,default:0"`                                          // Automatically approve follow requests from accounts at least this many days old (0 = disabled).
+	RecordFollowerEvents  *bool      `bun:",nullzero,notnull,default:false"`                             // Keep a log of accounts following / unfollowing this account.
+
+	// Notification policy: what to do with notifications from accounts
+	// matching each condition. The strictest matching policy applies.
+	NotifPolicyNotFollowing    NotificationPolicy `bun:",nullzero,notnull,default:'accept'"` // From accounts this account doesn't follow.
+	NotifPolicyNotFollowers    NotificationPolicy `bun:",nullzero,notnull,default:'accept'"` // From accounts that don't follow this account.
+	NotifPolicyNewAccounts     NotificationPolicy `bun:",nullzero,notnull,default:'accept'"` // From accounts created within the last 30 days.
+	NotifPolicyPrivateMentions NotificationPolicy `bun:",nullzero,notnull,default:'accept'"` // Unsolicited private mentions, from accounts this account doesn't follow.
 }
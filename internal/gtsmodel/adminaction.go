@@ -73,6 +73,11 @@ const (
 	AdminActionSuspend
 	AdminActionUnsuspend
 	AdminActionExpireKeys
+	AdminActionRegenerateTimelines
+	AdminActionRecountStats
+	AdminActionDirectMessage
+	AdminActionProfileEdit
+	AdminActionFreeze
 )
 
 func (t AdminActionType) String() string {
@@ -91,6 +96,16 @@ func (t AdminActionType) String() string {
 		return "unsuspend"
 	case AdminActionExpireKeys:
 		return "expire-keys"
+	case AdminActionRegenerateTimelines:
+		return "regenerate-timelines"
+	case AdminActionRecountStats:
+		return "recount-stats"
+	case AdminActionDirectMessage:
+		return "direct-message"
+	case AdminActionProfileEdit:
+		return "profile-edit"
+	case AdminActionFreeze:
+		return "freeze"
 	default:
 		return "unknown"
 	}
@@ -112,6 +127,16 @@ func NewAdminActionType(in string) AdminActionType {
 		return AdminActionUnsuspend
 	case "expire-keys":
 		return AdminActionExpireKeys
+	case "regenerate-timelines":
+		return AdminActionRegenerateTimelines
+	case "recount-stats":
+		return AdminActionRecountStats
+	case "direct-message":
+		return AdminActionDirectMessage
+	case "profile-edit":
+		return AdminActionProfileEdit
+	case "freeze":
+		return AdminActionFreeze
 	default:
 		return AdminActionUnknown
 	}
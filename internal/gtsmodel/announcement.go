@@ -0,0 +1,88 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Announcement models an instance-wide
+// announcement made by an admin.
+type Announcement struct {
+	ID                  string     `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt           time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt           time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Text                string     `bun:",nullzero,notnull"`                                           // text of the announcement, as written by the admin
+	Content             string     `bun:",nullzero,notnull"`                                           // html content of the announcement, formatted from text
+	StartsAt            time.Time  `bun:"type:timestamptz,nullzero"`                                   // announcement should not be shown before this time (zero = no start)
+	EndsAt              time.Time  `bun:"type:timestamptz,nullzero"`                                   // announcement should not be shown after this time (zero = no end)
+	AllDay              *bool      `bun:",nullzero,notnull,default:false"`                             // starts / ends times only pertain to the date, not the time of day
+	Published           *bool      `bun:",nullzero,notnull,default:false"`                             // announcement is visible to non-admin users
+	PublishedAt         time.Time  `bun:"type:timestamptz,nullzero"`                                   // when was the announcement (last) published
+	MentionedAccountIDs []string   `bun:"mentioned_accounts,array"`                                    // Database IDs of any accounts mentioned in the announcement
+	MentionedAccounts   []*Account `bun:"-"`                                                           // Accounts corresponding to MentionedAccountIDs
+	TagIDs              []string   `bun:"tags,array"`                                                  // Database IDs of any tags used in the announcement
+	Tags                []*Tag     `bun:"-"`                                                           // Tags corresponding to TagIDs
+	EmojiIDs            []string   `bun:"emojis,array"`                                                // Database IDs of any emojis used in the announcement
+	Emojis              []*Emoji   `bun:"-"`                                                           // Emojis corresponding to EmojiIDs
+}
+
+// IsPublished returns whether the
+// announcement has been published.
+func (a *Announcement) IsPublished() bool {
+	return a.Published != nil && *a.Published
+}
+
+// Active returns whether the announcement is
+// published, and should be shown at given time.
+func (a *Announcement) Active(now time.Time) bool {
+	if !a.IsPublished() {
+		return false
+	}
+
+	if !a.StartsAt.IsZero() && now.Before(a.StartsAt) {
+		// Not started yet.
+		return false
+	}
+
+	if !a.EndsAt.IsZero() && !now.Before(a.EndsAt) {
+		// Already ended.
+		return false
+	}
+
+	return true
+}
+
+// AnnouncementRead marks an announcement as
+// read (dismissed) by the given local account.
+type AnnouncementRead struct {
+	ID             string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                                 // id of this item in the database
+	CreatedAt      time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                              // when was item created
+	AnnouncementID string    `bun:"type:CHAR(26),nullzero,notnull,unique:announcement_reads_announcement_id_account_id_uniq"` // ID of the read announcement
+	AccountID      string    `bun:"type:CHAR(26),nullzero,notnull,unique:announcement_reads_announcement_id_account_id_uniq"` // ID of the account that read the announcement
+}
+
+// AnnouncementReaction represents an emoji
+// reaction by a local account to an announcement.
+type AnnouncementReaction struct {
+	ID             string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                                          // id of this item in the database
+	CreatedAt      time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                                       // when was item created
+	AnnouncementID string    `bun:"type:CHAR(26),nullzero,notnull,unique:announcement_reactions_announcement_id_account_id_name_uniq"` // ID of the announcement reacted to
+	AccountID      string    `bun:"type:CHAR(26),nullzero,notnull,unique:announcement_reactions_announcement_id_account_id_name_uniq"` // ID of the account that reacted
+	Name           string    `bun:",nullzero,notnull,unique:announcement_reactions_announcement_id_account_id_name_uniq"`              // unicode emoji, or shortcode of a local custom emoji
+	EmojiID        string    `bun:"type:CHAR(26),nullzero"`                                                                            // ID of the custom emoji, if Name is a shortcode
+	Emoji          *Emoji    `bun:"-"`                                                                                                 // Emoji corresponding to EmojiID
+}
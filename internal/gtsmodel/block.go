@@ -29,4 +29,5 @@ type Block struct {
 	Account         *Account  `bun:"rel:belongs-to"`                                              // Account corresponding to accountID
 	TargetAccountID string    `bun:"type:CHAR(26),unique:blocksrctarget,notnull,nullzero"`        // Who is the target of this block ?
 	TargetAccount   *Account  `bun:"rel:belongs-to"`                                              // Account corresponding to targetAccountID
+	ExpiresAt       time.Time `bun:"type:timestamptz,nullzero"`                                   // When should this block be automatically undone? Zero means never.
 }
@@ -0,0 +1,57 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Card represents a preview card for a link, generated
+// from OpenGraph tags and / or oEmbed data served at a URL.
+// Cards are shared between all statuses that link the URL.
+type Card struct {
+	ID               string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt        time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt        time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	FetchedAt        time.Time `bun:"type:timestamptz,nullzero"`                                   // when was the linked resource last fetched
+	URL              string    `bun:",unique,nullzero,notnull"`                                    // URL of the linked resource, as it appeared in the status
+	Title            string    `bun:""`                                                            // title of the linked resource
+	Description      string    `bun:""`                                                            // description of the linked resource
+	Type             CardType  `bun:",nullzero,notnull"`                                           // type of preview card
+	AuthorName       string    `bun:",nullzero"`                                                   // name of the author of the linked resource
+	AuthorURL        string    `bun:",nullzero"`                                                   // link to the author of the linked resource
+	Creator          string    `bun:",nullzero"`                                                   // fediverse:creator handle declared by the linked resource, eg., @someone@example.org
+	CreatorAccountID string    `bun:"type:CHAR(26),nullzero"`                                      // id of the account corresponding to Creator, if it was resolved and verified
+	CreatorAccount   *Account  `bun:"-"`                                                           // account corresponding to CreatorAccountID
+	ProviderName     string    `bun:",nullzero"`                                                   // name of the provider of the linked resource
+	ProviderURL      string    `bun:",nullzero"`                                                   // link to the provider of the linked resource
+	HTML             string    `bun:""`                                                            // sanitized oEmbed html for rich / video cards
+	Width            int       `bun:",nullzero"`                                                   // width of the preview, in pixels
+	Height           int       `bun:",nullzero"`                                                   // height of the preview, in pixels
+	ImageURL         string    `bun:",nullzero"`                                                   // remote URL of the preview thumbnail
+	EmbedURL         string    `bun:",nullzero"`                                                   // URL to embed directly for photo cards
+}
+
+// CardType describes the kind of content a preview card links to.
+type CardType string
+
+// Possible card types, as used by the Mastodon API.
+const (
+	CardTypeLink  CardType = "link"
+	CardTypePhoto CardType = "photo"
+	CardTypeVideo CardType = "video"
+	CardTypeRich  CardType = "rich"
+)
@@ -0,0 +1,36 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// ContentReveal represents a decision by a local account to always
+// show the content of statuses behind a content warning, either for
+// all statuses of one author, or for all statuses of one thread.
+//
+// Exactly one of TargetAccountID or ThreadID will be set.
+type ContentReveal struct {
+	ID              string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                                                                                 // id of this item in the database
+	CreatedAt       time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                                                                              // when was item created
+	AccountID       string    `bun:"type:CHAR(26),nullzero,notnull,unique:content_reveals_account_id_target_account_id_uniq,unique:content_reveals_account_id_thread_id_uniq"` // ID of the local account that made this decision.
+	Account         *Account  `bun:"-"`                                                                                                                                        // Account corresponding to AccountID.
+	TargetAccountID string    `bun:"type:CHAR(26),nullzero,unique:content_reveals_account_id_target_account_id_uniq"`                                                          // ID of the author whose content should always be shown, if set.
+	TargetAccount   *Account  `bun:"-"`                                                                                                                                        // Account corresponding to TargetAccountID.
+	ThreadID        string    `bun:"type:CHAR(26),nullzero,unique:content_reveals_account_id_thread_id_uniq"`                                                                  // ID of the thread whose content should always be shown, if set.
+	StatusID        string    `bun:"type:CHAR(26),nullzero"`                                                                                                                   // ID of the status from which the thread was revealed, if ThreadID is set.
+}
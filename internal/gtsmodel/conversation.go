@@ -0,0 +1,42 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Conversation represents one local account's view
+// of a direct message thread: the other accounts
+// taking part, and the latest status in the thread.
+//
+// Each local participant of a thread gets their own
+// Conversation, keyed on account ID and thread ID, so
+// a direct status addressed to several accounts acts
+// as a lightweight group conversation between them.
+type Conversation struct {
+	ID              string     `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                 // id of this item in the database
+	CreatedAt       time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`              // when was item created
+	UpdatedAt       time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`              // when was item last updated
+	AccountID       string     `bun:"type:CHAR(26),unique:conversations_account_id_thread_id,nullzero,notnull"` // ID of the local account that owns this conversation.
+	Account         *Account   `bun:"-"`                                                                        // Account corresponding to AccountID.
+	ThreadID        string     `bun:"type:CHAR(26),unique:conversations_account_id_thread_id,nullzero,notnull"` // ID of the thread this conversation tracks.
+	OtherAccountIDs []string   `bun:"other_account_ids,array"`                                                  // IDs of the other accounts taking part in the conversation.
+	OtherAccounts   []*Account `bun:"-"`                                                                        // Accounts corresponding to OtherAccountIDs.
+	LastStatusID    string     `bun:"type:CHAR(26),nullzero,notnull"`                                           // ID of the latest direct status in the thread that the owner can see.
+	LastStatus      *Status    `bun:"-"`                                                                        // Status corresponding to LastStatusID.
+	Read            *bool      `bun:",nullzero,notnull,default:false"`                                          // Has the owner read the latest status in the conversation?
+}
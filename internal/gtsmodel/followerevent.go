@@ -0,0 +1,39 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// FollowerEventType is the type of a follower event.
+type FollowerEventType string
+
+const (
+	FollowerEventFollow   FollowerEventType = "follow"   // FollowerEventFollow -- someone started following the account.
+	FollowerEventUnfollow FollowerEventType = "unfollow" // FollowerEventUnfollow -- someone stopped following the account.
+)
+
+// FollowerEvent records a local account gaining or losing a follower.
+// Follower events are only recorded for accounts that opted in to it.
+type FollowerEvent struct {
+	ID                string            `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt         time.Time         `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	AccountID         string            `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the local account that gained or lost a follower.
+	FollowerAccountID string            `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the account that followed or unfollowed.
+	FollowerAccount   *Account          `bun:"-"`                                                           // Account corresponding to FollowerAccountID.
+	Type              FollowerEventType `bun:",nullzero,notnull"`                                           // Type of the event, follow or unfollow.
+}
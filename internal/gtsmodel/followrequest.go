@@ -31,4 +31,5 @@ type FollowRequest struct {
 	TargetAccount   *Account  `bun:"rel:belongs-to"`                                              // Account corresponding to targetAccountID
 	ShowReblogs     *bool     `bun:",nullzero,notnull,default:true"`                              // Does this follow also want to see reblogs and not just posts?
 	Notify          *bool     `bun:",nullzero,notnull,default:false"`                             // does the following account want to be notified when the followed account posts?
+	Note            string    `bun:",nullzero"`                                                   // Optional short plaintext message from the requester to the target.
 }
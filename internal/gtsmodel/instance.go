@@ -27,6 +27,7 @@ type Instance struct {
 	Domain                 string       `bun:",nullzero,notnull,unique"`                                    // Instance domain eg example.org
 	Title                  string       `bun:""`                                                            // Title of this instance as it would like to be displayed.
 	URI                    string       `bun:",nullzero,notnull,unique"`                                    // base URI of this instance eg https://example.org
+	OnionURI               string       `bun:",nullzero"`                                                   // base URI of this instance's Tor onion service, if it advertises one, eg http://example.onion
 	SuspendedAt            time.Time    `bun:"type:timestamptz,nullzero"`                                   // When was this instance suspended, if at all?
 	DomainBlockID          string       `bun:"type:CHAR(26),nullzero"`                                      // ID of any existing domain block for this instance in the database
 	DomainBlock            *DomainBlock `bun:"rel:belongs-to"`                                              // Domain block corresponding to domainBlockID
@@ -36,11 +37,19 @@ type Instance struct {
 	DescriptionText        string       `bun:""`                                                            // Raw text version of long description (before parsing).
 	Terms                  string       `bun:""`                                                            // Terms and conditions of this instance.
 	TermsText              string       `bun:""`                                                            // Raw text version of terms (before parsing).
+	TermsVersionID         string       `bun:"type:CHAR(26),nullzero"`                                      // ID of the current TermsVersion of this instance's terms, if any. Only set for our own instance.
 	ContactEmail           string       `bun:""`                                                            // Contact email address for this instance
 	ContactAccountUsername string       `bun:",nullzero"`                                                   // Username of the contact account for this instance
 	ContactAccountID       string       `bun:"type:CHAR(26),nullzero"`                                      // Contact account ID in the database for this instance
 	ContactAccount         *Account     `bun:"rel:belongs-to"`                                              // account corresponding to contactAccountID
 	Reputation             int64        `bun:",notnull,default:0"`                                          // Reputation score of this instance
 	Version                string       `bun:",nullzero"`                                                   // Version of the software used on this instance
+	Software               string       `bun:",nullzero"`                                                   // Name of the software used on this instance, as reported by nodeinfo
+	FetchedAt              time.Time    `bun:"type:timestamptz,nullzero"`                                   // When did we last attempt to dereference this instance's metadata?
+	LastSeenAt             time.Time    `bun:"type:timestamptz,nullzero"`                                   // When did we last receive federated traffic from this instance?
+	DeliveredCount         int64        `bun:",notnull,default:0"`                                          // Number of successful outgoing deliveries to this instance.
+	FailedCount            int64        `bun:",notnull,default:0"`                                          // Number of failed outgoing delivery attempts to this instance.
+	LastDeliveredAt        time.Time    `bun:"type:timestamptz,nullzero"`                                   // When did we last successfully deliver to this instance?
+	LastFailedAt           time.Time    `bun:"type:timestamptz,nullzero"`                                   // When did a delivery to this instance last fail?
 	Rules                  []Rule       `bun:"-"`                                                           // List of instance rules
 }
@@ -0,0 +1,83 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+// InteractionType is a type of interaction
+// that an account may have with a status.
+type InteractionType int
+
+const (
+	InteractionReply    InteractionType = iota // Replying to the status.
+	InteractionAnnounce                        // Announcing (boosting) the status.
+	InteractionLike                            // Liking (faving) the status.
+)
+
+// PolicyValue is an audience that an interaction
+// policy may permit to interact with a status.
+type PolicyValue string
+
+const (
+	// PolicyValuePublic means anyone who can see the status.
+	PolicyValuePublic PolicyValue = "public"
+	// PolicyValueFollowers means accounts following the status author.
+	PolicyValueFollowers PolicyValue = "followers"
+	// PolicyValueFollowing means accounts followed by the status author.
+	PolicyValueFollowing PolicyValue = "following"
+	// PolicyValueMentioned means accounts mentioned in the status.
+	PolicyValueMentioned PolicyValue = "mentioned"
+	// PolicyValueAuthor means the status author only.
+	PolicyValueAuthor PolicyValue = "author"
+)
+
+// PolicyValues is a set of audiences permitted
+// to perform one type of interaction with a status.
+// Empty means the same as PolicyValuePublic.
+type PolicyValues []PolicyValue
+
+// InteractionPolicy describes which audiences are
+// permitted to interact with a status in which ways.
+// The status author is always permitted to interact
+// with their own status, whatever the policy says.
+type InteractionPolicy struct {
+	CanReply    PolicyValues `json:"canReply,omitempty"`    // Audiences permitted to reply to the status.
+	CanAnnounce PolicyValues `json:"canAnnounce,omitempty"` // Audiences permitted to announce the status.
+	CanLike     PolicyValues `json:"canLike,omitempty"`     // Audiences permitted to like the status.
+}
+
+// Values returns the audiences permitted by the policy to
+// perform the given type of interaction. A nil policy
+// permits everyone, and so returns PolicyValuePublic.
+func (p *InteractionPolicy) Values(interaction InteractionType) PolicyValues {
+	var values PolicyValues
+	if p != nil {
+		switch interaction {
+		case InteractionReply:
+			values = p.CanReply
+		case InteractionAnnounce:
+			values = p.CanAnnounce
+		case InteractionLike:
+			values = p.CanLike
+		}
+	}
+
+	if len(values) == 0 {
+		return PolicyValues{PolicyValuePublic}
+	}
+
+	return values
+}
@@ -0,0 +1,49 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// InteractionRequest represents an interaction (reply or boost) with
+// a status of a locked local account, which the status' interaction
+// policy doesn't permit, by an account not following the status author.
+// Rather than being rejected outright, the interaction is held pending
+// approval (or rejection) by the status author.
+type InteractionRequest struct {
+	ID                   string          `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt            time.Time       `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt            time.Time       `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	StatusID             string          `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the local status interacted with.
+	Status               *Status         `bun:"-"`                                                           // Status corresponding to StatusID.
+	TargetAccountID      string          `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the account that authored the status, who may approve the interaction.
+	TargetAccount        *Account        `bun:"-"`                                                           // Account corresponding to TargetAccountID.
+	InteractingAccountID string          `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the account that interacted with the status.
+	InteractingAccount   *Account        `bun:"-"`                                                           // Account corresponding to InteractingAccountID.
+	InteractionType      InteractionType `bun:",notnull"`                                                    // Type of the interaction, reply or announce.
+	InteractionID        string          `bun:"type:CHAR(26),nullzero,notnull,unique"`                       // ID of the reply status or boost wrapper status.
+	Interaction          *Status         `bun:"-"`                                                           // Status corresponding to InteractionID.
+	InteractionURI       string          `bun:",nullzero,notnull"`                                           // URI of the reply status or Announce activity.
+	AcceptedAt           time.Time       `bun:"type:timestamptz,nullzero"`                                   // When the interaction was approved, if it was.
+	RejectedAt           time.Time       `bun:"type:timestamptz,nullzero"`                                   // When the interaction was rejected, if it was.
+}
+
+// IsPending returns whether the interaction request
+// is yet to be either accepted or rejected.
+func (r *InteractionRequest) IsPending() bool {
+	return r.AcceptedAt.IsZero() && r.RejectedAt.IsZero()
+}
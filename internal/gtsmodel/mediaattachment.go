@@ -42,6 +42,24 @@ type MediaAttachment struct {
 	Avatar            *bool            `bun:",nullzero,notnull,default:false"`                             // Is this attachment being used as an avatar?
 	Header            *bool            `bun:",nullzero,notnull,default:false"`                             // Is this attachment being used as a header?
 	Cached            *bool            `bun:",nullzero,notnull,default:false"`                             // Is this attachment currently cached by our instance?
+	Sensitive         *bool            `bun:",nullzero,notnull,default:false"`                             // Should this attachment be hidden behind a warning, even if its status isn't marked sensitive?
+	StrippedMetadata  []string         `bun:",array"`                                                      // Kinds of metadata (eg., exif, gps) stripped from this attachment when it was uploaded.
+	ExpiresAt         time.Time        `bun:"type:timestamptz,nullzero"`                                   // When should the file of this attachment be deleted, leaving only a tombstone (zero for never).
+}
+
+// IsExpired returns whether this attachment has passed
+// its expiry time, if set. The attachment's files may
+// not have been deleted from storage yet, but should
+// no longer be served or shown either way.
+func (m *MediaAttachment) IsExpired() bool {
+	return !m.ExpiresAt.IsZero() && !m.ExpiresAt.After(time.Now())
+}
+
+// IsPlaceholder returns whether this is a placeholder for remote
+// media that hasn't been fetched yet, and should be fetched when
+// it's first requested.
+func (m *MediaAttachment) IsPlaceholder() bool {
+	return m.Processing == ProcessingStatusPending
 }
 
 // File refers to the metadata for the whole file
@@ -50,6 +68,7 @@ type File struct {
 	ContentType string    `bun:",nullzero,notnull"`                                           // MIME content type of the file.
 	FileSize    int       `bun:",notnull"`                                                    // File size in bytes
 	UpdatedAt   time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // When was the file last updated.
+	Hash        string    `bun:",nullzero"`                                                   // Hex encoded SHA-256 hash of the file, used to deduplicate identical files in storage.
 }
 
 // Thumbnail refers to a small image thumbnail derived from a larger image, video, or audio file.
@@ -70,6 +89,7 @@ const (
 	ProcessingStatusReceived   ProcessingStatus = 0   // ProcessingStatusReceived indicates the attachment has been received and is awaiting processing. No thumbnail available yet.
 	ProcessingStatusProcessing ProcessingStatus = 1   // ProcessingStatusProcessing indicates the attachment is currently being processed. Thumbnail is available but full media is not.
 	ProcessingStatusProcessed  ProcessingStatus = 2   // ProcessingStatusProcessed indicates the attachment has been fully processed and is ready to be served.
+	ProcessingStatusPending    ProcessingStatus = 3   // ProcessingStatusPending indicates the attachment is remote media that hasn't been fetched yet, and will be fetched when first requested.
 	ProcessingStatusError      ProcessingStatus = 666 // ProcessingStatusError indicates something went wrong processing the attachment and it won't be tried again--these can be deleted.
 )
 
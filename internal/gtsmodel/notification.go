@@ -32,8 +32,15 @@ type Notification struct {
 	StatusID         string           `bun:"type:CHAR(26),nullzero"`                                      // If the notification pertains to a status, what is the database ID of that status?
 	Status           *Status          `bun:"-"`                                                           // Status corresponding to StatusID. Can be nil, always check first + select using ID if necessary.
 	Read             *bool            `bun:",nullzero,notnull,default:false"`                             // Notification has been seen/read
+	GroupKey         string           `bun:",nullzero"`                                                   // Key shared by similar notifications (eg., faves of one status) to be grouped together. Empty if not grouped.
+	Filtered         *bool            `bun:",nullzero,notnull,default:false"`                             // Notification was filtered by the target's notification policy, pending a notification request.
 }
 
+// NotificationUngroupedPrefix prefixes the group key reported
+// in the grouped notifications API for a notification that's
+// not grouped, followed by the notification ID.
+const NotificationUngroupedPrefix = "ungrouped-"
+
 // NotificationType describes the reason/type of this notification.
 type NotificationType string
 
@@ -47,4 +54,32 @@ const (
 	NotificationPoll          NotificationType = "poll"           // NotificationPoll -- a poll you voted in or created has ended
 	NotificationStatus        NotificationType = "status"         // NotificationStatus -- someone you enabled notifications for has posted a status.
 	NotificationSignup        NotificationType = "admin.sign_up"  // NotificationSignup -- someone has submitted a new account sign-up to the instance.
+	NotificationNewFrom       NotificationType = "new_from"       // NotificationNewFrom -- someone you follow has posted for the first time in a while.
 )
+
+// NotificationPolicy describes what to do with notifications
+// that match a condition of an account's notification policy.
+type NotificationPolicy string
+
+// Notification Policies
+const (
+	NotificationPolicyAccept NotificationPolicy = "accept" // NotificationPolicyAccept -- notify as usual
+	NotificationPolicyFilter NotificationPolicy = "filter" // NotificationPolicyFilter -- set notification aside in a notification request
+	NotificationPolicyDrop   NotificationPolicy = "drop"   // NotificationPolicyDrop -- drop notification altogether
+)
+
+// NotificationRequest models notifications to an account from
+// another account that were filtered by the receiving account's
+// notification policy, pending being accepted or dismissed.
+type NotificationRequest struct {
+	ID            string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                                    // id of this item in the database
+	CreatedAt     time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                                 // when was item created
+	UpdatedAt     time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                                 // when was item last updated
+	AccountID     string    `bun:"type:CHAR(26),nullzero,notnull,unique:notification_requests_account_id_from_account_id_uniq"` // ID of the account that received the filtered notifications.
+	Account       *Account  `bun:"-"`                                                                                           // Account corresponding to AccountID.
+	FromAccountID string    `bun:"type:CHAR(26),nullzero,notnull,unique:notification_requests_account_id_from_account_id_uniq"` // ID of the account that the filtered notifications originate from.
+	FromAccount   *Account  `bun:"-"`                                                                                           // Account corresponding to FromAccountID.
+	LastStatusID  string    `bun:"type:CHAR(26),nullzero"`                                                                      // ID of the status of the most recent filtered notification, if any.
+	LastStatus    *Status   `bun:"-"`                                                                                           // Status corresponding to LastStatusID.
+	Accepted      *bool     `bun:",nullzero,notnull,default:false"`                                                             // Request was accepted, so further notifications from FromAccount aren't filtered.
+}
@@ -0,0 +1,33 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// RuleAcknowledgement records that a user explicitly acknowledged
+// one instance rule when signing up. Rules may be edited later, so
+// the rule's last-updated time (ie., its version) and text at the
+// time of acknowledgement are stored too, for moderation disputes.
+type RuleAcknowledgement struct {
+	ID            string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                         // id of this item in the database
+	CreatedAt     time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                      // when was item created, ie., when was the rule acknowledged
+	UserID        string    `bun:"type:CHAR(26),nullzero,notnull,unique:rule_acknowledgements_user_id_rule_id_uniq"` // ID of the user who acknowledged the rule
+	RuleID        string    `bun:"type:CHAR(26),nullzero,notnull,unique:rule_acknowledgements_user_id_rule_id_uniq"` // ID of the acknowledged rule
+	RuleUpdatedAt time.Time `bun:"type:timestamptz,nullzero,notnull"`                                                // when was the rule last updated before it was acknowledged, ie., the version of the rule
+	RuleText      string    `bun:",nullzero"`                                                                        // text of the rule as it was acknowledged
+}
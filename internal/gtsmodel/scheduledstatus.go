@@ -0,0 +1,59 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// ScheduledStatus represents a status created by a local
+// account to be published at a later time. It stores the
+// parameters the status was created with, which are then
+// used to create the status when it's due to be published.
+type ScheduledStatus struct {
+	ID                string             `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt         time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt         time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	ScheduledAt       time.Time          `bun:"type:timestamptz,nullzero,notnull"`                           // when should the status be published
+	AccountID         string             `bun:"type:CHAR(26),nullzero,notnull"`                              // which account scheduled this status?
+	Account           *Account           `bun:"-"`                                                           // account corresponding to accountID
+	ApplicationID     string             `bun:"type:CHAR(26),nullzero"`                                      // which application was used to schedule this status?
+	Application       *Application       `bun:"-"`                                                           // application corresponding to applicationID
+	Text              string             `bun:""`                                                            // text of the status, as submitted
+	ContentType       string             `bun:",nullzero"`                                                   // content type to use when parsing text
+	ContentWarning    string             `bun:",nullzero"`                                                   // cw string for the status
+	Sensitive         *bool              `bun:",nullzero,notnull,default:false"`                             // mark the status as sensitive?
+	Visibility        Visibility         `bun:",nullzero,notnull"`                                           // visibility entry for the status
+	Federated         *bool              `bun:",nullzero"`                                                   // advanced visibility flag, nil for default
+	Boostable         *bool              `bun:",nullzero"`                                                   // advanced visibility flag, nil for default
+	Replyable         *bool              `bun:",nullzero"`                                                   // advanced visibility flag, nil for default
+	Likeable          *bool              `bun:",nullzero"`                                                   // advanced visibility flag, nil for default
+	InteractionPolicy *InteractionPolicy `bun:""`                                                            // interaction policy for the status, nil for default
+	Language          string             `bun:",nullzero"`                                                   // what language is the status written in?
+	InReplyToID       string             `bun:"type:CHAR(26),nullzero"`                                      // id of the status the status replies to
+	AttachmentIDs     []string           `bun:"attachments,array"`                                           // Database IDs of any media attachments to attach to the status
+	Attachments       []*MediaAttachment `bun:"-"`                                                           // Attachments corresponding to attachmentIDs
+	PollOptions       []string           `bun:",array"`                                                      // options of the poll to attach to the status, if any
+	PollExpiresIn     int                `bun:",nullzero"`                                                   // duration in seconds the poll should be open, from publishing
+	PollMultiple      *bool              `bun:",nullzero"`                                                   // poll allows multiple choices
+	PollHideCounts    *bool              `bun:",nullzero"`                                                   // poll hides vote counts until it ends
+}
+
+// HasPoll returns whether the scheduled
+// status will be published with a poll.
+func (s *ScheduledStatus) HasPoll() bool {
+	return len(s.PollOptions) > 0
+}
@@ -28,6 +28,7 @@ type Status struct {
 	CreatedAt                time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
 	UpdatedAt                time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
 	FetchedAt                time.Time          `bun:"type:timestamptz,nullzero"`                                   // when was item (remote) last fetched.
+	EditedAt                 time.Time          `bun:"type:timestamptz,nullzero"`                                   // when was item last edited; zero if never edited.
 	PinnedAt                 time.Time          `bun:"type:timestamptz,nullzero"`                                   // Status was pinned by owning account at this time.
 	URI                      string             `bun:",unique,nullzero,notnull"`                                    // activitypub URI of this status
 	URL                      string             `bun:",nullzero"`                                                   // web url for viewing this status
@@ -49,6 +50,9 @@ type Status struct {
 	InReplyToAccountID       string             `bun:"type:CHAR(26),nullzero"`                                      // id of the account that this status replies to
 	InReplyTo                *Status            `bun:"-"`                                                           // status corresponding to inReplyToID
 	InReplyToAccount         *Account           `bun:"rel:belongs-to"`                                              // account corresponding to inReplyToAccountID
+	QuoteOfID                string             `bun:"type:CHAR(26),nullzero"`                                      // id of the status this status quotes
+	QuoteOfURI               string             `bun:",nullzero"`                                                   // activitypub uri of the status this status quotes
+	QuoteOf                  *Status            `bun:"-"`                                                           // status corresponding to quoteOfID
 	BoostOfID                string             `bun:"type:CHAR(26),nullzero"`                                      // id of the status this status is a boost of
 	BoostOfURI               string             `bun:"-"`                                                           // URI of the status this status is a boost of; field not inserted in the db, just for dereferencing purposes.
 	BoostOfAccountID         string             `bun:"type:CHAR(26),nullzero"`                                      // id of the account that owns the boosted status
@@ -57,6 +61,8 @@ type Status struct {
 	ThreadID                 string             `bun:"type:CHAR(26),nullzero"`                                      // id of the thread to which this status belongs; only set for remote statuses if a local account is involved at some point in the thread, otherwise null
 	PollID                   string             `bun:"type:CHAR(26),nullzero"`                                      //
 	Poll                     *Poll              `bun:"-"`                                                           //
+	CardID                   string             `bun:"type:CHAR(26),nullzero"`                                      // id of the preview card for the first link in this status, if any
+	Card                     *Card              `bun:"-"`                                                           // preview card corresponding to cardID
 	ContentWarning           string             `bun:",nullzero"`                                                   // cw string for this status
 	Visibility               Visibility         `bun:",nullzero,notnull"`                                           // visibility entry for this status
 	Sensitive                *bool              `bun:",nullzero,notnull,default:false"`                             // mark the status as sensitive?
@@ -69,6 +75,10 @@ type Status struct {
 	Boostable                *bool              `bun:",notnull"`                                                    // This status can be boosted/reblogged
 	Replyable                *bool              `bun:",notnull"`                                                    // This status can be replied to
 	Likeable                 *bool              `bun:",notnull"`                                                    // This status can be liked/faved
+	InteractionPolicy        *InteractionPolicy `bun:""`                                                            // Audiences permitted to reply to, boost and like this status; nil permits everyone.
+	PendingApproval          *bool              `bun:",nullzero,notnull,default:false"`                             // Interaction held pending approval by the author of the status it replies to or boosts.
+	RemoteFavesCount         int                `bun:",nullzero"`                                                   // Faves count of this (remote) status, as last reported in its likes collection.
+	RemoteBoostsCount        int                `bun:",nullzero"`                                                   // Boosts count of this (remote) status, as last reported in its shares collection.
 }
 
 // GetID implements timeline.Timelineable{}.
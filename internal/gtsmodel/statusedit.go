@@ -0,0 +1,38 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// StatusEdit represents a previous revision of a
+// status, ie., the status as it was before an edit.
+// Together with the current version of the status,
+// stored edits make up the status' edit history.
+type StatusEdit struct {
+	ID             string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // ID of this item in the database.
+	CreatedAt      time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // When was item created, ie., when was this revision superseded by an edit.
+	StatusID       string    `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the status this is a revision of.
+	Content        string    `bun:""`                                                            // Content of the status at this revision.
+	ContentWarning string    `bun:",nullzero"`                                                   // Content warning of the status at this revision.
+	Text           string    `bun:""`                                                            // Original text of the status at this revision, without formatting.
+	Language       string    `bun:",nullzero"`                                                   // Language of the status at this revision.
+	Sensitive      *bool     `bun:",nullzero,notnull,default:false"`                             // Was the status marked as sensitive at this revision?
+	AttachmentIDs  []string  `bun:"attachments,array"`                                           // Database IDs of media attachments of the status at this revision.
+	EmojiIDs       []string  `bun:"emojis,array"`                                                // Database IDs of emojis used in the status at this revision.
+	PollOptions    []string  `bun:",array"`                                                      // Options of the status' poll at this revision, if any.
+}
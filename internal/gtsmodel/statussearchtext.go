@@ -0,0 +1,29 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+// StatusSearchText is the plaintext of one status, as stored in the
+// full-text status search index when statuses-search-index is enabled.
+//
+// The integer ID is required by SQLite FTS5, which can only reference
+// rows of an external content table by a stable integer rowid.
+type StatusSearchText struct {
+	ID       int64  `bun:",pk,autoincrement"`                     // integer id of this item in the database
+	StatusID string `bun:"type:CHAR(26),nullzero,notnull,unique"` // database id of the indexed status
+	Text     string `bun:",notnull"`                              // plaintext of content warning, content, media descriptions and poll options
+}
@@ -29,3 +29,15 @@ type Tag struct {
 	Listable  *bool     `bun:",nullzero,notnull,default:true"`                              // Tagged statuses can be listed on this instance.
 	Href      string    `bun:"-"`                                                           // Href of the hashtag. Will only be set on freshly-extracted hashtags from remote AP messages. Not stored in the database.
 }
+
+// TagHistory records how much a tag
+// was used by local statuses on one day.
+type TagHistory struct {
+	ID        string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                               // id of this item in the database
+	CreatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`            // when was item created
+	UpdatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`            // when was item last updated
+	TagID     string    `bun:"type:CHAR(26),unique:tag_histories_tag_id_day_uniq,nullzero,notnull"`    // id of the tag this history is for
+	Day       time.Time `bun:"type:timestamptz,unique:tag_histories_tag_id_day_uniq,nullzero,notnull"` // start of the (UTC) day this history covers
+	Uses      int       `bun:",notnull,default:0"`                                                     // number of statuses using the tag on this day
+	Accounts  int       `bun:",notnull,default:0"`                                                     // number of distinct accounts using the tag on this day
+}
@@ -0,0 +1,41 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// TimelineEntry is one status materialized into a
+// home or list timeline, when timelines are stored
+// in the database rather than in memory.
+type TimelineEntry struct {
+	TimelineType     TimelineType `bun:",pk,nullzero,notnull"`                                        // Type of timeline this entry belongs to.
+	TimelineID       string       `bun:"type:CHAR(26),pk,nullzero,notnull"`                           // ID of the timeline: account ID for home timelines, list ID for list timelines.
+	StatusID         string       `bun:"type:CHAR(26),pk,nullzero,notnull"`                           // ID of the status in the timeline.
+	AccountID        string       `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the account that created the status.
+	BoostOfID        string       `bun:"type:CHAR(26),nullzero"`                                      // ID of the boosted status, if status is a boost.
+	BoostOfAccountID string       `bun:"type:CHAR(26),nullzero"`                                      // ID of the account that created the boosted status, if status is a boost.
+	CreatedAt        time.Time    `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // When was this entry put in the timeline.
+}
+
+// TimelineType is the type of a database-stored timeline.
+type TimelineType string
+
+const (
+	TimelineTypeHome TimelineType = "home"
+	TimelineTypeList TimelineType = "list"
+)
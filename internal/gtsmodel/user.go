@@ -52,10 +52,16 @@ type User struct {
 	Moderator              *bool        `bun:",nullzero,notnull,default:false"`                             // Is this user a moderator?
 	Admin                  *bool        `bun:",nullzero,notnull,default:false"`                             // Is this user an admin?
 	Disabled               *bool        `bun:",nullzero,notnull,default:false"`                             // Is this user disabled from posting?
+	FrozenUntil            time.Time    `bun:"type:timestamptz,nullzero"`                                   // If set, user is disabled until this time, after which they will be automatically reenabled.
 	Approved               *bool        `bun:",nullzero,notnull,default:false"`                             // Has this user been approved by a moderator?
 	ResetPasswordToken     string       `bun:",nullzero"`                                                   // The generated token that the user can use to reset their password
 	ResetPasswordSentAt    time.Time    `bun:"type:timestamptz,nullzero"`                                   // When did we email the user their reset-password email?
 	ExternalID             string       `bun:",nullzero,unique"`                                            // If the login for the user is managed externally (e.g OIDC), we need to keep a stable reference to the external object (e.g OIDC sub claim)
+	AgeConfirmedAt         time.Time    `bun:"type:timestamptz,nullzero"`                                   // When did the user last confirm that they're at least AgeConfirmedMinimum years old?
+	AgeConfirmedMinimum    int          `bun:",notnull,default:0"`                                          // Minimum age (in years) that the user last confirmed being at least; 0 if never confirmed.
+	TermsAcceptedVersionID string       `bun:"type:CHAR(26),nullzero"`                                      // ID of the TermsVersion of the instance terms that the user last accepted.
+	TermsAcceptedAt        time.Time    `bun:"type:timestamptz,nullzero"`                                   // When did the user last accept the instance terms?
+	LastActiveAt           time.Time    `bun:"type:timestamptz,nullzero"`                                   // When was this user last seen making an authenticated request? Only updated every few minutes.
 }
 
 // DeniedUser represents one user sign-up that
@@ -89,12 +95,14 @@ type NewSignup struct {
 	Email    string // Email address of the user (required).
 	Password string // Plaintext (not yet hashed) password for the user (required).
 
-	Reason        string // Reason given by the user when submitting a sign up request (optional).
-	PreApproved   bool   // Mark the new user/account as preapproved (optional)
-	SignUpIP      net.IP // IP address from which the sign up request occurred (optional).
-	Locale        string // Locale code for the new account/user (optional).
-	AppID         string // ID of the application used to create this account (optional).
-	EmailVerified bool   // Mark submitted email address as already verified (optional).
-	ExternalID    string // ID of this user in external OIDC system (optional).
-	Admin         bool   // Mark new user as an admin user (optional).
+	Reason         string // Reason given by the user when submitting a sign up request (optional).
+	PreApproved    bool   // Mark the new user/account as preapproved (optional)
+	SignUpIP       net.IP // IP address from which the sign up request occurred (optional).
+	Locale         string // Locale code for the new account/user (optional).
+	AppID          string // ID of the application used to create this account (optional).
+	EmailVerified  bool   // Mark submitted email address as already verified (optional).
+	ExternalID     string // ID of this user in external OIDC system (optional).
+	Admin          bool   // Mark new user as an admin user (optional).
+	AgeConfirmed   int    // Minimum age in years that the user confirmed being at least (optional).
+	TermsVersionID string // ID of the version of the instance terms that the user accepted (optional).
 }
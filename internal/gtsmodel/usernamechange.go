@@ -0,0 +1,54 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// UsernameChange represents a local account's
+// username having been changed from OldUsername
+// to NewUsername. Stored changes are used to
+// redirect lookups of the old username to the
+// account, and to enforce a cooldown between
+// username changes.
+type UsernameChange struct {
+	ID          string                 `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // ID of this item in the database.
+	CreatedAt   time.Time              `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // When was item created.
+	AccountID   string                 `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the local account whose username was changed.
+	Account     *Account               `bun:"-"`                                                           // Account corresponding to AccountID.
+	OldUsername string                 `bun:",nullzero,notnull,unique"`                                    // Username of the account before the change.
+	NewUsername string                 `bun:",nullzero,notnull"`                                           // Username of the account after the change.
+	OldURI      string                 `bun:",nullzero,notnull"`                                           // ActivityPub URI of the account before the change.
+	Strategy    UsernameChangeStrategy `bun:",nullzero,notnull"`                                           // How the change was federated.
+}
+
+// UsernameChangeStrategy describes how a
+// username change is presented to remotes.
+type UsernameChangeStrategy string
+
+const (
+	// UsernameChangeStrategyUpdate keeps the
+	// account's ActivityPub URIs as they are,
+	// and just federates an Update of the
+	// account with its new username.
+	UsernameChangeStrategyUpdate UsernameChangeStrategy = "update"
+
+	// UsernameChangeStrategyMove gives the account
+	// new ActivityPub URIs based on its new username,
+	// and federates a Move from the old URI to the new.
+	UsernameChangeStrategyMove UsernameChangeStrategy = "move"
+)
@@ -0,0 +1,85 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// WebPushSubscription represents a subscription of a client
+// (or rather, its OAuth token) to receive Web Push notifications
+// for the account it's authorized for. There's at most one
+// subscription per token.
+type WebPushSubscription struct {
+	ID                 string                    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time                 `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt          time.Time                 `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID          string                    `bun:"type:CHAR(26),nullzero,notnull"`                              // which account receives notifications through this subscription?
+	TokenID            string                    `bun:"type:CHAR(26),nullzero,notnull,unique"`                       // which token does this subscription belong to?
+	Endpoint           string                    `bun:",nullzero,notnull"`                                           // push service URL to deliver notifications to
+	P256dh             string                    `bun:",nullzero,notnull"`                                           // base64url encoded P-256 public key of the client, used for encryption
+	Auth               string                    `bun:",nullzero,notnull"`                                           // base64url encoded auth secret of the client, used for encryption
+	AlertFollow        *bool                     `bun:",nullzero,notnull,default:false"`                             // deliver follow notifications?
+	AlertFollowRequest *bool                     `bun:",nullzero,notnull,default:false"`                             // deliver follow request notifications?
+	AlertFavourite     *bool                     `bun:",nullzero,notnull,default:false"`                             // deliver favourite notifications?
+	AlertMention       *bool                     `bun:",nullzero,notnull,default:false"`                             // deliver mention notifications?
+	AlertReblog        *bool                     `bun:",nullzero,notnull,default:false"`                             // deliver reblog notifications?
+	AlertPoll          *bool                     `bun:",nullzero,notnull,default:false"`                             // deliver poll notifications?
+	AlertStatus        *bool                     `bun:",nullzero,notnull,default:false"`                             // deliver status (and new_from) notifications?
+	AlertSignup        *bool                     `bun:",nullzero,notnull,default:false"`                             // deliver admin sign-up notifications?
+	Policy             WebPushSubscriptionPolicy `bun:",nullzero,notnull,default:'all'"`                             // from whom should notifications be delivered?
+}
+
+// Alerts returns whether notifications of
+// the given type should be delivered through
+// this subscription, going by its alert flags.
+func (w *WebPushSubscription) Alerts(notificationType NotificationType) bool {
+	var alert *bool
+
+	switch notificationType {
+	case NotificationFollow:
+		alert = w.AlertFollow
+	case NotificationFollowRequest:
+		alert = w.AlertFollowRequest
+	case NotificationFave:
+		alert = w.AlertFavourite
+	case NotificationMention:
+		alert = w.AlertMention
+	case NotificationReblog:
+		alert = w.AlertReblog
+	case NotificationPoll:
+		alert = w.AlertPoll
+	case NotificationStatus, NotificationNewFrom:
+		alert = w.AlertStatus
+	case NotificationSignup:
+		alert = w.AlertSignup
+	}
+
+	return alert != nil && *alert
+}
+
+// WebPushSubscriptionPolicy describes from whom
+// notifications should be delivered through a
+// web push subscription.
+type WebPushSubscriptionPolicy string
+
+// WebPushSubscriptionPolicy values.
+const (
+	WebPushSubscriptionPolicyAll      WebPushSubscriptionPolicy = "all"      // deliver notifications from anyone
+	WebPushSubscriptionPolicyFollowed WebPushSubscriptionPolicy = "followed" // deliver notifications from accounts the user follows
+	WebPushSubscriptionPolicyFollower WebPushSubscriptionPolicy = "follower" // deliver notifications from accounts following the user
+	WebPushSubscriptionPolicyNone     WebPushSubscriptionPolicy = "none"     // deliver no notifications
+)
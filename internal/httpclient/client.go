@@ -26,6 +26,7 @@ import (
 	"net"
 	"net/http"
 	"net/netip"
+	"net/url"
 	"runtime"
 	"strconv"
 	"strings"
@@ -72,6 +73,20 @@ type Config struct {
 	// communiciations to given IP nets.
 	BlockRanges []netip.Prefix
 
+	// Network is the TCP network to dial remotes
+	// over, "tcp4" or "tcp6" to only dial remotes
+	// over IPv4 or IPv6, default "tcp" for either.
+	Network string
+
+	// HappyEyeballsDelay: see net.Dialer{}.FallbackDelay,
+	// ie. how long to wait for a connection over IPv6 to
+	// succeed before racing one over IPv4 (RFC 6555).
+	HappyEyeballsDelay time.Duration
+
+	// Resolver is the DNS resolver to look up
+	// remote hosts with, nil for system default.
+	Resolver *net.Resolver
+
 	// TLSInsecureSkipVerify can be set to true to
 	// skip validation of remote TLS certificates.
 	//
@@ -97,6 +112,17 @@ type Config struct {
 
 	// DisableCompression: see http.Transport{}.DisableCompression.
 	DisableCompression bool
+
+	// Proxy is the proxy to send requests through
+	// when they don't match any of ProxyRules. If
+	// nil, the proxy is taken from the environment
+	// (see http.ProxyFromEnvironment).
+	Proxy *url.URL
+
+	// ProxyRules route requests to matching hosts
+	// through a different proxy than Proxy. The
+	// first rule matching the request host is used.
+	ProxyRules []ProxyRule
 }
 
 // Client wraps an underlying http.Client{} to provide the following:
@@ -105,11 +131,16 @@ type Config struct {
 //     cases to protect against forged / unknown content-lengths
 //   - protection from server side request forgery (SSRF) by only dialing
 //     out to known public IP prefixes, configurable with allows/blocks
+//   - dialing over IPv4 and / or IPv6 with happy eyeballs, resolving
+//     hosts with the system or a configured DNS (over TLS / HTTPS) resolver
+//   - routing requests through proxies per destination host, each
+//     proxy having its own connection pool and request / failure stats
 //   - retry-backoff logic for error temporary HTTP error responses
 //   - optional request signing
 //   - request logging
 type Client struct {
 	client   http.Client
+	egress   egresstransport
 	badHosts cache.TTLCache[string, struct{}]
 	bodyMax  int64
 	retries  uint
@@ -120,10 +151,16 @@ func New(cfg Config) *Client {
 	var c Client
 	c.retries = 5
 
+	if cfg.Resolver == nil {
+		// Use system default resolver.
+		cfg.Resolver = &net.Resolver{}
+	}
+
 	d := &net.Dialer{
-		Timeout:   15 * time.Second,
-		KeepAlive: 30 * time.Second,
-		Resolver:  &net.Resolver{},
+		Timeout:       15 * time.Second,
+		KeepAlive:     30 * time.Second,
+		FallbackDelay: cfg.HappyEyeballsDelay,
+		Resolver:      cfg.Resolver,
 	}
 
 	if cfg.MaxOpenConnsPerHost <= 0 {
@@ -142,12 +179,18 @@ func New(cfg Config) *Client {
 		cfg.MaxBodySize = int64(40 * bytesize.MiB)
 	}
 
+	// Proxies are set by the admin so they
+	// are dialed without sanitizing the IP.
+	pd := new(net.Dialer)
+	*pd = *d
+
 	// Protect the dialer
 	// with IP range sanitizer.
-	d.Control = (&Sanitizer{
+	sanitizer := &Sanitizer{
 		Allow: cfg.AllowRanges,
 		Block: cfg.BlockRanges,
-	}).Sanitize
+	}
+	d.Control = sanitizer.Sanitize
 
 	// Prepare client fields.
 	c.client.Timeout = cfg.Timeout
@@ -167,20 +210,84 @@ func New(cfg Config) *Client {
 		)
 	}
 
+	// Remotes are only dialed over the configured
+	// network, configured proxies are dialed over
+	// whichever is available as they're likely local.
+	dial := d.DialContext
+	if cfg.Network != "" && cfg.Network != "tcp" {
+		dial = func(ctx context.Context, _ string, addr string) (net.Conn, error) {
+			return d.DialContext(ctx, cfg.Network, addr)
+		}
+	}
+
+	// newEgress returns a new egress route with its own
+	// connection pool, using given proxy func and dial func.
+	newEgress := func(
+		name string,
+		proxy func(*http.Request) (*url.URL, error),
+		dial func(context.Context, string, string) (net.Conn, error),
+	) *egress {
+		return &egress{
+			name:    name,
+			proxied: proxy != nil,
+			transport: &http.Transport{
+				Proxy:                 proxy,
+				ForceAttemptHTTP2:     true,
+				DialContext:           dial,
+				TLSClientConfig:       tlsClientConfig,
+				MaxIdleConns:          cfg.MaxIdleConns,
+				MaxConnsPerHost:       cfg.MaxOpenConnsPerHost,
+				IdleConnTimeout:       90 * time.Second,
+				TLSHandshakeTimeout:   10 * time.Second,
+				ExpectContinueTimeout: 1 * time.Second,
+				ReadBufferSize:        cfg.ReadBufferSize,
+				WriteBufferSize:       cfg.WriteBufferSize,
+				DisableCompression:    cfg.DisableCompression,
+			},
+		}
+	}
+
+	// Get egress route for given proxy,
+	// reusing an existing route if possible.
+	routes := make(map[string]*egress)
+	getEgress := func(proxy *url.URL) *egress {
+		name := egressName(proxy)
+		if route, ok := routes[name]; ok {
+			return route
+		}
+
+		var route *egress
+		if proxy == nil {
+			route = newEgress(name, nil, dial)
+		} else {
+			route = newEgress(name, http.ProxyURL(proxy), pd.DialContext)
+		}
+
+		routes[name] = route
+		c.egress.all = append(c.egress.all, route)
+		return route
+	}
+
+	// Prepare egress routes for each of the proxy rules.
+	c.egress.rules = cfg.ProxyRules
+	c.egress.sanitize = sanitizer
+	c.egress.resolver = cfg.Resolver
+	for _, rule := range cfg.ProxyRules {
+		c.egress.routes = append(c.egress.routes, getEgress(rule.Proxy))
+	}
+
+	if cfg.Proxy != nil {
+		// Requests not matching any rules go via proxy.
+		c.egress.fallback = getEgress(cfg.Proxy)
+	} else {
+		// Requests not matching any rules go
+		// via proxy set in env, if any, else direct.
+		c.egress.fallback = newEgress("default", http.ProxyFromEnvironment, dial)
+		c.egress.all = append(c.egress.all, c.egress.fallback)
+	}
+
 	// Set underlying HTTP client roundtripper.
-	c.client.Transport = &signingtransport{http.Transport{
-		Proxy:                 http.ProxyFromEnvironment,
-		ForceAttemptHTTP2:     true,
-		DialContext:           d.DialContext,
-		TLSClientConfig:       tlsClientConfig,
-		MaxIdleConns:          cfg.MaxIdleConns,
-		IdleConnTimeout:       90 * time.Second,
-		TLSHandshakeTimeout:   10 * time.Second,
-		ExpectContinueTimeout: 1 * time.Second,
-		ReadBufferSize:        cfg.ReadBufferSize,
-		WriteBufferSize:       cfg.WriteBufferSize,
-		DisableCompression:    cfg.DisableCompression,
-	}}
+	c.client.Transport = &signingtransport{&c.egress}
 
 	// Initiate outgoing bad hosts lookup cache.
 	c.badHosts = cache.NewTTL[string, struct{}](0, 512, 0)
@@ -192,6 +299,12 @@ func New(cfg Config) *Client {
 	return &c
 }
 
+// EgressStats returns the current request and failure
+// counts of each egress route, ie., direct or proxied.
+func (c *Client) EgressStats() []EgressStats {
+	return c.egress.stats()
+}
+
 // Do will essentially perform http.Client{}.Do() with retry-backoff functionality.
 func (c *Client) Do(r *http.Request) (rsp *http.Response, err error) {
 
@@ -0,0 +1,237 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package httpclient
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"sync/atomic"
+)
+
+// ErrInvalidProxy is returned when a configured proxy URL or rule cannot be parsed.
+var ErrInvalidProxy = errors.New("invalid proxy")
+
+// ProxyRule routes outgoing requests to hosts
+// matching a pattern through a particular proxy.
+type ProxyRule struct {
+	// Pattern is either an exact hostname, "*.suffix"
+	// to match any subdomain of suffix (eg., "*.onion"),
+	// or "*" to match all hosts.
+	Pattern string
+
+	// Proxy is the URL of the proxy to route matching
+	// requests through, or nil to dial matching hosts
+	// directly, bypassing any other configured proxy.
+	Proxy *url.URL
+}
+
+// Matches returns whether given host matches the rule pattern.
+func (r ProxyRule) Matches(host string) bool {
+	host = strings.ToLower(host)
+	switch {
+	case r.Pattern == "*":
+		return true
+
+	case strings.HasPrefix(r.Pattern, "*."):
+		return strings.HasSuffix(host, r.Pattern[1:])
+
+	default:
+		return host == r.Pattern
+	}
+}
+
+// ParseProxy parses the given string as the URL of an
+// HTTP(S) or SOCKS5 proxy, eg., "socks5://127.0.0.1:9050".
+func ParseProxy(str string) (*url.URL, error) {
+	u, err := url.Parse(str)
+	if err != nil {
+		return nil, fmt.Errorf("%w %q: %v", ErrInvalidProxy, str, err)
+	}
+
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+		// Supported by http.Transport{}.
+	default:
+		return nil, fmt.Errorf("%w %q: unsupported scheme %q", ErrInvalidProxy, str, u.Scheme)
+	}
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("%w %q: no host", ErrInvalidProxy, str)
+	}
+
+	return u, nil
+}
+
+// ParseProxyRules parses the given strings as proxy rules
+// in the form "pattern=proxy", where proxy is either a URL
+// accepted by ParseProxy, or "direct" to not use a proxy.
+func ParseProxyRules(in []string) ([]ProxyRule, error) {
+	rules := make([]ProxyRule, 0, len(in))
+
+	for _, str := range in {
+		pattern, proxy, ok := strings.Cut(str, "=")
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		proxy = strings.TrimSpace(proxy)
+		if !ok || pattern == "" || proxy == "" {
+			return nil, fmt.Errorf("%w rule %q: should be in the form pattern=proxy", ErrInvalidProxy, str)
+		}
+
+		rule := ProxyRule{Pattern: pattern}
+
+		if proxy != "direct" {
+			u, err := ParseProxy(proxy)
+			if err != nil {
+				return nil, err
+			}
+			rule.Proxy = u
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// EgressStats provides counts of the outgoing
+// requests made over a single egress route, ie.,
+// directly or through a particular proxy.
+type EgressStats struct {
+	// Name of the egress route: "direct",
+	// or the scheme and host of the proxy.
+	Name string
+
+	// Requests is the number of requests made.
+	Requests uint64
+
+	// Failures is the number of requests that
+	// failed to get any response, eg. because
+	// the proxy or remote could not be reached.
+	Failures uint64
+}
+
+// egress is a single route out for requests,
+// with its own pool of connections and stats.
+type egress struct {
+	name      string
+	proxied   bool
+	transport *http.Transport
+	requests  atomic.Uint64
+	failures  atomic.Uint64
+}
+
+// egressName returns the name of the egress route for proxy.
+func egressName(proxy *url.URL) string {
+	if proxy == nil {
+		return "direct"
+	}
+	return proxy.Scheme + "://" + proxy.Host
+}
+
+// egresstransport is an http.RoundTripper{} that sends each
+// request over the egress route of the first matching proxy
+// rule, or over the fallback route if no rules match.
+type egresstransport struct {
+	rules    []ProxyRule
+	routes   []*egress // routes[i] is used for rules[i]
+	fallback *egress
+	all      []*egress
+	sanitize *Sanitizer
+	resolver *net.Resolver
+}
+
+func (t *egresstransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	route := t.route(r.URL.Hostname())
+
+	if route.proxied {
+		// Proxied requests are dialed by the proxy so our
+		// dialer never sees the destination, check it here.
+		if err := t.checkProxied(route, r); err != nil {
+			return nil, err
+		}
+	}
+
+	route.requests.Add(1)
+	rsp, err := route.transport.RoundTrip(r)
+	if err != nil {
+		route.failures.Add(1)
+	}
+
+	return rsp, err
+}
+
+// checkProxied checks the destination IP(s) of a request to be sent
+// over the given proxied route, resolving the host with the configured
+// resolver. Tor onion services can't be resolved (and don't live at
+// any IP we could check), so they're left to the proxy.
+func (t *egresstransport) checkProxied(route *egress, r *http.Request) error {
+	if proxy, _ := route.transport.Proxy(r); proxy == nil {
+		// Not proxied after all (eg., no proxy
+		// set in env), so our dialer checks it.
+		return nil
+	}
+
+	host := r.URL.Hostname()
+	if ip, err := netip.ParseAddr(host); err == nil {
+		return t.sanitize.CheckIP(ip)
+	}
+
+	if strings.HasSuffix(strings.ToLower(host), ".onion") {
+		return nil
+	}
+
+	ips, err := t.resolver.LookupNetIP(r.Context(), "ip", host)
+	if err != nil {
+		return err
+	}
+
+	for _, ip := range ips {
+		if err := t.sanitize.CheckIP(ip.Unmap()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// route returns the egress route to use for host.
+func (t *egresstransport) route(host string) *egress {
+	for i, rule := range t.rules {
+		if rule.Matches(host) {
+			return t.routes[i]
+		}
+	}
+	return t.fallback
+}
+
+// stats returns the current stats of all egress routes.
+func (t *egresstransport) stats() []EgressStats {
+	stats := make([]EgressStats, 0, len(t.all))
+	for _, route := range t.all {
+		stats = append(stats, EgressStats{
+			Name:     route.name,
+			Requests: route.requests.Load(),
+			Failures: route.failures.Load(),
+		})
+	}
+	return stats
+}
@@ -0,0 +1,228 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package httpclient_test
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"testing"
+
+	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+func TestParseProxyRules(t *testing.T) {
+	rules, err := httpclient.ParseProxyRules([]string{
+		"*.onion=socks5h://127.0.0.1:9050",
+		"example.org = direct",
+		"*=http://proxy.example.com:3128",
+	})
+	if err != nil {
+		t.Fatalf("error parsing proxy rules: %v", err)
+	}
+
+	for _, test := range []struct {
+		host  string
+		proxy string // "" == direct
+	}{
+		{"somewhere.onion", "socks5h://127.0.0.1:9050"},
+		{"SOMEWHERE.ONION", "socks5h://127.0.0.1:9050"},
+		{"example.org", ""},
+		{"sub.example.org", "http://proxy.example.com:3128"},
+		{"onion", "http://proxy.example.com:3128"},
+	} {
+		var proxy string
+		for _, rule := range rules {
+			if rule.Matches(test.host) {
+				if rule.Proxy != nil {
+					proxy = rule.Proxy.String()
+				}
+				break
+			}
+		}
+
+		if proxy != test.proxy {
+			t.Errorf("%s: expected proxy %q, got %q", test.host, test.proxy, proxy)
+		}
+	}
+
+	for _, invalid := range []string{
+		"*.onion",
+		"=socks5://127.0.0.1:9050",
+		"*.onion=ftp://127.0.0.1:21",
+		"*.onion=socks5://",
+	} {
+		if _, err := httpclient.ParseProxyRules([]string{invalid}); !errors.Is(err, httpclient.ErrInvalidProxy) {
+			t.Errorf("%s: expected invalid proxy error, got %v", invalid, err)
+		}
+	}
+}
+
+func TestHTTPClientProxyRules(t *testing.T) {
+	// Start a test HTTP proxy that just
+	// responds with the host it was asked for.
+	proxy := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(rw, r.URL.Host)
+	}))
+	defer proxy.Close()
+
+	proxyURL, _ := url.Parse(proxy.URL)
+
+	// Proxied hostnames are looked up and
+	// checked before being handed to the proxy.
+	resolver := testResolver(t, map[string]netip.Addr{
+		"remote.example.org.":   netip.MustParseAddr("93.184.216.34"),
+		"internal.example.org.": netip.MustParseAddr("127.0.0.1"),
+	})
+
+	// The proxy is on loopback, which would be
+	// blocked for direct dials but is fine here.
+	client := httpclient.New(httpclient.Config{
+		Resolver: resolver,
+		ProxyRules: []httpclient.ProxyRule{
+			{Pattern: "*.example.org", Proxy: proxyURL},
+			{Pattern: "*.onion", Proxy: proxyURL},
+		},
+	})
+
+	req, _ := http.NewRequest("GET", "http://remote.example.org/users/someone", nil)
+	rsp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("error performing proxied request: %v", err)
+	}
+	defer rsp.Body.Close()
+
+	body, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		t.Fatalf("error reading response body: %v", err)
+	}
+
+	if string(body) != "remote.example.org" {
+		t.Errorf("unexpected proxied host %q", string(body))
+	}
+
+	// Hostnames resolving to reserved IPs should be refused.
+	req, _ = http.NewRequest("GET", "http://internal.example.org/", nil)
+	if _, err := client.Do(req); !errors.Is(err, httpclient.ErrReservedAddr) {
+		t.Errorf("expected reserved addr error, got %v", err)
+	}
+
+	// Onion hosts can't be resolved, so go straight to the proxy.
+	req, _ = http.NewRequest("GET", "http://someone.onion/", nil)
+	rsp, err = client.Do(req)
+	if err != nil {
+		t.Fatalf("error performing proxied onion request: %v", err)
+	}
+	rsp.Body.Close()
+
+	// IP literals should still be sanitized when proxied.
+	req, _ = http.NewRequest("GET", "http://127.0.0.1/", nil)
+	req.Host = "127.0.0.1"
+	client = httpclient.New(httpclient.Config{
+		ProxyRules: []httpclient.ProxyRule{
+			{Pattern: "*", Proxy: proxyURL},
+		},
+	})
+	if _, err := client.Do(req); !errors.Is(err, httpclient.ErrReservedAddr) {
+		t.Errorf("expected reserved addr error, got %v", err)
+	}
+
+	// Check stats were recorded per egress route.
+	stats := client.EgressStats()
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 egress routes, got %d", len(stats))
+	}
+
+	if stats[0].Name != "http://"+proxyURL.Host {
+		t.Errorf("unexpected egress name %q", stats[0].Name)
+	}
+
+	if stats[0].Requests != 0 || stats[0].Failures != 0 {
+		t.Errorf("unexpected stats for rejected request: %+v", stats[0])
+	}
+}
+
+// testResolver returns a resolver which looks up hosts
+// from the given A records, via a local test DNS server.
+func testResolver(t *testing.T, records map[string]netip.Addr) *net.Resolver {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		b := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(b)
+			if err != nil {
+				return
+			}
+
+			var query dnsmessage.Message
+			if err := query.Unpack(b[:n]); err != nil || len(query.Questions) == 0 {
+				continue
+			}
+
+			answer := dnsmessage.Message{
+				Header: dnsmessage.Header{
+					ID:                 query.Header.ID,
+					Response:           true,
+					RecursionAvailable: true,
+				},
+				Questions: query.Questions,
+			}
+
+			q := query.Questions[0]
+			ip, ok := records[q.Name.String()]
+			switch {
+			case !ok:
+				answer.Header.RCode = dnsmessage.RCodeNameError
+			case q.Type == dnsmessage.TypeA:
+				answer.Answers = []dnsmessage.Resource{{
+					Header: dnsmessage.ResourceHeader{
+						Name:  q.Name,
+						Type:  dnsmessage.TypeA,
+						Class: dnsmessage.ClassINET,
+						TTL:   60,
+					},
+					Body: &dnsmessage.AResource{A: ip.As4()},
+				}}
+			}
+
+			rsp, err := answer.Pack()
+			if err != nil {
+				continue
+			}
+
+			_, _ = conn.WriteTo(rsp, addr)
+		}
+	}()
+
+	resolver, err := httpclient.ParseResolver("udp://" + conn.LocalAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return resolver
+}
@@ -0,0 +1,255 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidResolver is returned when a configured DNS resolver cannot be parsed.
+	ErrInvalidResolver = errors.New("invalid resolver")
+
+	// ErrInvalidIPVersion is returned when a configured IP version is not recognized.
+	ErrInvalidIPVersion = errors.New("invalid ip version")
+)
+
+// ParseIPVersion parses the given IP version, one of "" (either),
+// "ipv4" or "ipv6", returning the TCP network to dial remotes over.
+func ParseIPVersion(str string) (string, error) {
+	switch strings.ToLower(str) {
+	case "", "any":
+		return "tcp", nil
+	case "ipv4":
+		return "tcp4", nil
+	case "ipv6":
+		return "tcp6", nil
+	default:
+		return "", fmt.Errorf("%w %q: should be one of ipv4 or ipv6", ErrInvalidIPVersion, str)
+	}
+}
+
+// ParseResolver parses the given string as a DNS resolver to use instead of
+// the system default, returning nil (ie., system default) if str is empty.
+//
+// Supported are plain DNS over UDP ("udp://1.1.1.1:53"), over TCP
+// ("tcp://1.1.1.1:53"), DNS over TLS ("tls://1.1.1.1:853") and DNS
+// over HTTPS ("https://cloudflare-dns.com/dns-query"). The hostname
+// of DoT and DoH resolvers is looked up with the system resolver.
+func ParseResolver(str string) (*net.Resolver, error) {
+	if str == "" {
+		return nil, nil
+	}
+
+	u, err := url.Parse(str)
+	if err != nil {
+		return nil, fmt.Errorf("%w %q: %v", ErrInvalidResolver, str, err)
+	}
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("%w %q: no host", ErrInvalidResolver, str)
+	}
+
+	// Resolvers are set by the
+	// admin, so they're dialed
+	// without sanitizing the IP.
+	d := &net.Dialer{
+		Timeout:   5 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+
+	var dial func(ctx context.Context) (net.Conn, error)
+
+	switch u.Scheme {
+	case "udp", "tcp":
+		addr := withDefaultPort(u.Host, "53")
+		dial = func(ctx context.Context) (net.Conn, error) {
+			return d.DialContext(ctx, u.Scheme, addr)
+		}
+
+	case "tls":
+		addr := withDefaultPort(u.Host, "853")
+		td := &tls.Dialer{
+			NetDialer: d,
+			Config:    &tls.Config{ServerName: u.Hostname()},
+		}
+		dial = func(ctx context.Context) (net.Conn, error) {
+			return td.DialContext(ctx, "tcp", addr)
+		}
+
+	case "https":
+		client := &http.Client{
+			Transport: &http.Transport{
+				DialContext:         d.DialContext,
+				ForceAttemptHTTP2:   true,
+				IdleConnTimeout:     90 * time.Second,
+				TLSHandshakeTimeout: 10 * time.Second,
+			},
+		}
+		return newDoHResolver(u.String(), client), nil
+
+	default:
+		return nil, fmt.Errorf("%w %q: unsupported scheme %q", ErrInvalidResolver, str, u.Scheme)
+	}
+
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			// Always dial the configured resolver,
+			// instead of the system nameservers.
+			return dial(ctx)
+		},
+	}, nil
+}
+
+// withDefaultPort returns host with given port appended if it has none.
+func withDefaultPort(host string, port string) string {
+	if _, _, err := net.SplitHostPort(host); err == nil {
+		return host
+	}
+	return net.JoinHostPort(strings.Trim(host, "[]"), port)
+}
+
+// newDoHResolver returns a new resolver sending
+// DNS queries to endpoint using given HTTP client.
+func newDoHResolver(endpoint string, client *http.Client) *net.Resolver {
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return &dohConn{
+				ctx:      ctx,
+				endpoint: endpoint,
+				client:   client,
+			}, nil
+		},
+	}
+}
+
+// dohConn is a net.Conn{} that sends each DNS query written to it as a
+// DNS over HTTPS request (RFC 8484), buffering the answer to be read.
+//
+// As it isn't a net.PacketConn{}, the Go resolver frames written
+// queries and read answers as over TCP, with a 2-byte length prefix.
+type dohConn struct {
+	ctx      context.Context
+	endpoint string
+	client   *http.Client
+	deadline time.Time
+	wbuf     bytes.Buffer
+	rbuf     bytes.Buffer
+}
+
+func (c *dohConn) Write(b []byte) (int, error) {
+	c.wbuf.Write(b)
+
+	for c.wbuf.Len() >= 2 {
+		n := int(binary.BigEndian.Uint16(c.wbuf.Bytes()))
+		if c.wbuf.Len() < 2+n {
+			// Wait for rest of query.
+			break
+		}
+
+		c.wbuf.Next(2)
+		if err := c.exchange(c.wbuf.Next(n)); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(b), nil
+}
+
+// exchange performs DoH request for
+// query, buffering the framed answer.
+func (c *dohConn) exchange(query []byte) error {
+	ctx := c.ctx
+	if !c.deadline.IsZero() {
+		var cncl context.CancelFunc
+		ctx, cncl = context.WithDeadline(ctx, c.deadline)
+		defer cncl()
+	}
+
+	req, err := http.NewRequestWithContext(ctx,
+		http.MethodPost,
+		c.endpoint,
+		bytes.NewReader(query),
+	)
+	if err != nil {
+		return err
+	}
+
+	const dnsMessage = "application/dns-message"
+	req.Header.Set("Content-Type", dnsMessage)
+	req.Header.Set("Accept", dnsMessage)
+
+	rsp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		return fmt.Errorf("doh resolver returned status %s", rsp.Status)
+	}
+
+	// DNS messages are at most 64KiB.
+	answer, err := io.ReadAll(io.LimitReader(rsp.Body, 1<<16))
+	if err != nil {
+		return err
+	}
+
+	var n [2]byte
+	binary.BigEndian.PutUint16(n[:], uint16(len(answer))) //nolint:gosec
+	c.rbuf.Write(n[:])
+	c.rbuf.Write(answer)
+	return nil
+}
+
+func (c *dohConn) Read(b []byte) (int, error) {
+	if c.rbuf.Len() == 0 {
+		return 0, io.EOF
+	}
+	return c.rbuf.Read(b)
+}
+
+func (c *dohConn) SetDeadline(t time.Time) error {
+	c.deadline = t
+	return nil
+}
+
+func (c *dohConn) SetReadDeadline(t time.Time) error  { return nil }
+func (c *dohConn) SetWriteDeadline(t time.Time) error { return c.SetDeadline(t) }
+func (c *dohConn) Close() error                       { return nil }
+func (c *dohConn) LocalAddr() net.Addr                { return dohAddr(c.endpoint) }
+func (c *dohConn) RemoteAddr() net.Addr               { return dohAddr(c.endpoint) }
+
+// dohAddr is the net.Addr{} of a DoH endpoint.
+type dohAddr string
+
+func (a dohAddr) Network() string { return "https" }
+func (a dohAddr) String() string  { return string(a) }
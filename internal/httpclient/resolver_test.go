@@ -0,0 +1,138 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+func TestParseResolver(t *testing.T) {
+	for _, test := range []struct {
+		in  string
+		err error
+	}{
+		{"", nil},
+		{"udp://9.9.9.9:53", nil},
+		{"tcp://9.9.9.9", nil},
+		{"tls://dns.quad9.net", nil},
+		{"https://dns.quad9.net/dns-query", nil},
+		{"9.9.9.9", ErrInvalidResolver},
+		{"quic://dns.quad9.net", ErrInvalidResolver},
+	} {
+		if _, err := ParseResolver(test.in); !errors.Is(err, test.err) {
+			t.Errorf("%q: expected error %v, got %v", test.in, test.err, err)
+		}
+	}
+}
+
+func TestParseIPVersion(t *testing.T) {
+	for _, test := range []struct {
+		in      string
+		network string
+		err     error
+	}{
+		{"", "tcp", nil},
+		{"ipv4", "tcp4", nil},
+		{"IPv6", "tcp6", nil},
+		{"ipv5", "", ErrInvalidIPVersion},
+	} {
+		network, err := ParseIPVersion(test.in)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%q: expected error %v, got %v", test.in, test.err, err)
+		}
+		if network != test.network {
+			t.Errorf("%q: expected network %q, got %q", test.in, test.network, network)
+		}
+	}
+}
+
+func TestDoHResolver(t *testing.T) {
+	addr := netip.MustParseAddr("93.184.216.34")
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/dns-message" {
+			http.Error(rw, "bad request", http.StatusBadRequest)
+			return
+		}
+
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var query dnsmessage.Message
+		if err := query.Unpack(b); err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// Answer A queries for example.org,
+		// with no records for anything else.
+		answer := dnsmessage.Message{
+			Header: dnsmessage.Header{
+				ID:                 query.Header.ID,
+				Response:           true,
+				RecursionAvailable: true,
+			},
+			Questions: query.Questions,
+		}
+
+		q := query.Questions[0]
+		if q.Name.String() == "example.org." && q.Type == dnsmessage.TypeA {
+			answer.Answers = []dnsmessage.Resource{{
+				Header: dnsmessage.ResourceHeader{
+					Name:  q.Name,
+					Type:  dnsmessage.TypeA,
+					Class: dnsmessage.ClassINET,
+					TTL:   60,
+				},
+				Body: &dnsmessage.AResource{A: addr.As4()},
+			}}
+		}
+
+		b, err = answer.Pack()
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		rw.Header().Set("Content-Type", "application/dns-message")
+		_, _ = rw.Write(b)
+	}))
+	defer srv.Close()
+
+	resolver := newDoHResolver(srv.URL+"/dns-query", srv.Client())
+
+	addrs, err := resolver.LookupNetIP(context.Background(), "ip4", "example.org")
+	if err != nil {
+		t.Fatalf("error looking up example.org: %v", err)
+	}
+
+	if len(addrs) != 1 || addrs[0] != addr {
+		t.Fatalf("expected addrs [%s], got %v", addr, addrs)
+	}
+}
@@ -36,6 +36,11 @@ var (
 		netip.MustParsePrefix("2620:4f:8000::/48"), // Direct Delegation AS112 Service (RFC 7534)
 	}
 
+	// ipv6NAT64 is the well-known NAT64 prefix, with the IPv4
+	// address of the remote embedded in the last 32 bits (RFC 6052).
+	// IPv6-only hosts reach IPv4-only remotes via DNS64 through it.
+	ipv6NAT64 = netip.MustParsePrefix("64:ff9b::/96")
+
 	// ipv4Reserved contains IPv4 reserved IP prefixes.
 	// https://www.iana.org/assignments/iana-ipv4-special-registry/iana-ipv4-special-registry.xhtml
 	ipv4Reserved = [...]netip.Prefix{
@@ -83,19 +88,33 @@ func (s *Sanitizer) Sanitize(ntwrk, addr string, _ syscall.RawConn) error {
 		return ErrInvalidNetwork
 	}
 
-	// Separate the IP.
-	ip := ipport.Addr()
+	return s.CheckIP(ipport.Addr())
+}
+
+// CheckIP returns ErrReservedAddr if the given IP
+// is not permitted to be dialed, taking explicitly
+// allowed and blocked IP ranges into account.
+//
+// IPv4-mapped and NAT64 IPv6 addresses are also checked
+// against the ranges by the IPv4 address they embed, so
+// blocked IPv4 ranges can't be dialed via IPv6 instead.
+func (s *Sanitizer) CheckIP(ip netip.Addr) error {
+	ip4, embeds := embeddedIPv4(ip)
+	contains := func(prefix netip.Prefix) bool {
+		return prefix.Contains(ip) ||
+			(embeds && prefix.Contains(ip4))
+	}
 
 	// Check if this IP is explicitly allowed.
 	for i := 0; i < len(s.Allow); i++ {
-		if s.Allow[i].Contains(ip) {
+		if contains(s.Allow[i]) {
 			return nil
 		}
 	}
 
 	// Check if this IP is explicitly blocked.
 	for i := 0; i < len(s.Block); i++ {
-		if s.Block[i].Contains(ip) {
+		if contains(s.Block[i]) {
 			return ErrReservedAddr
 		}
 	}
@@ -121,6 +140,12 @@ func SafeIP(ip netip.Addr) bool {
 		}
 		return true
 
+	// IPv6 NAT64: check the embedded IPv4,
+	// the NAT64 gateway will dial on our behalf.
+	case ipv6NAT64.Contains(ip):
+		ip4, _ := embeddedIPv4(ip)
+		return SafeIP(ip4)
+
 	// IPv6: check if IP in IPv6 reserved nets
 	case ip.Is6():
 		if !ipv6GlobalUnicast.Contains(ip) {
@@ -143,3 +168,19 @@ func SafeIP(ip netip.Addr) bool {
 		return false
 	}
 }
+
+// embeddedIPv4 returns the IPv4 address embedded in
+// an IPv4-mapped or NAT64 IPv6 address, if any.
+func embeddedIPv4(ip netip.Addr) (netip.Addr, bool) {
+	switch {
+	case ip.Is4In6():
+		return ip.Unmap(), true
+
+	case ipv6NAT64.Contains(ip):
+		b := ip.As16()
+		return netip.AddrFrom4([4]byte(b[12:])), true
+
+	default:
+		return netip.Addr{}, false
+	}
+}
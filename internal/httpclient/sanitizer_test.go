@@ -60,6 +60,10 @@ func TestSafeIP(t *testing.T) {
 			name: "IPv4-mapped address",
 			ip:   netip.MustParseAddr("::ffff:169.254.169.254"),
 		},
+		{
+			name: "NAT64 address of IPv4 link-local",
+			ip:   netip.MustParseAddr("64:ff9b::a9fe:a9fe"),
+		},
 	}
 
 	for _, tc := range tests {
@@ -140,6 +144,30 @@ func TestSanitizer(t *testing.T) {
 			addr:     "[::ffff:169.254.169.254]:80",
 			expected: nil, // We allowed this explicitly.
 		},
+		{
+			name:     "NAT64 address",
+			ntwrk:    "tcp6",
+			addr:     "[64:ff9b::808:808]:80", // 8.8.8.8
+			expected: nil,
+		},
+		{
+			name:     "NAT64 address of IPv4 private",
+			ntwrk:    "tcp6",
+			addr:     "[64:ff9b::a00:1]:80", // 10.0.0.1
+			expected: httpclient.ErrReservedAddr,
+		},
+		{
+			name:     "NAT64 address of example.org",
+			ntwrk:    "tcp6",
+			addr:     "[64:ff9b::5db8:d822]:80",
+			expected: httpclient.ErrReservedAddr, // We blocked this explicitly.
+		},
+		{
+			name:     "IPv4-mapped address of example.org",
+			ntwrk:    "tcp6",
+			addr:     "[::ffff:93.184.216.34]:80",
+			expected: httpclient.ErrReservedAddr, // We blocked this explicitly.
+		},
 	}
 
 	for _, tc := range tests {
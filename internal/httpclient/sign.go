@@ -28,11 +28,10 @@ import (
 // SignFunc is a function signature that provides request signing.
 type SignFunc func(r *http.Request) error
 
-// signingtransport wraps an http.Transport{}
-// (RoundTripper implementer) to check request
-// context for a signing function and using for
-// all subsequent trips through RoundTrip().
-type signingtransport struct{ http.Transport }
+// signingtransport wraps an http.RoundTripper{}
+// to check request context for a signing function
+// and using for all subsequent trips through RoundTrip().
+type signingtransport struct{ http.RoundTripper }
 
 func (t *signingtransport) RoundTrip(r *http.Request) (*http.Response, error) {
 	// Ensure updated host always set.
@@ -58,5 +57,5 @@ func (t *signingtransport) RoundTrip(r *http.Request) (*http.Response, error) {
 	}
 
 	// Pass to underlying transport.
-	return t.Transport.RoundTrip(r)
+	return t.RoundTripper.RoundTrip(r)
 }
@@ -54,6 +54,15 @@ func NewULIDFromTime(t time.Time) (string, error) {
 	return newUlid.String(), nil
 }
 
+// TimeFromULID returns the time encoded in the given ULID string, or an error if it's not a valid ULID.
+func TimeFromULID(id string) (time.Time, error) {
+	parsed, err := ulid.ParseStrict(id)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return ulid.Time(parsed.Time()), nil
+}
+
 // NewRandomULID returns a new ULID string using a random time in an ~80 year range around the current datetime, or an error if something goes wrong.
 func NewRandomULID() (string, error) {
 	b1, err := rand.Int(rand.Reader, big.NewInt(randomRange))
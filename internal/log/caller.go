@@ -24,12 +24,22 @@ import (
 
 // Caller fetches the calling function name, skipping 'depth'.
 func Caller(depth int) string {
+	return funcName(callerFunc(depth + 1))
+}
+
+// callerFunc fetches the calling function, skipping 'depth'.
+func callerFunc(depth int) *runtime.Func {
 	var pcs [1]uintptr
 
 	// Fetch calling function using calldepth
 	_ = runtime.Callers(depth, pcs[:])
-	fn := runtime.FuncForPC(pcs[0])
+	return runtime.FuncForPC(pcs[0])
+}
 
+// funcName returns the name of given function,
+// formatted for logging as its package name
+// and function name, without module path.
+func funcName(fn *runtime.Func) string {
 	if fn == nil {
 		return ""
 	}
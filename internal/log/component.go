@@ -0,0 +1,193 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package log
+
+import (
+	"maps"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"codeberg.org/gruf/go-logger/v2/level"
+)
+
+// modulePath is stripped from package
+// paths to get their component name.
+const modulePath = "github.com/superseriousbusiness/gotosocial/"
+
+var (
+	// complvls is the currently set component
+	// logging levels, nil when none are set.
+	complvls atomic.Pointer[componentLevels]
+
+	// complvlsMu serializes component
+	// logging level updates.
+	complvlsMu sync.Mutex
+
+	// funccomps caches the component name
+	// of logging funcs, by func entry PC.
+	funccomps sync.Map
+)
+
+// componentLevels is an immutable set of
+// logging levels for named components.
+type componentLevels struct {
+	// levels by component name.
+	levels map[string]level.LEVEL
+
+	// max is the highest of levels.
+	max level.LEVEL
+}
+
+// ComponentLevels returns a copy of the currently
+// set logging levels of named components.
+func ComponentLevels() map[string]level.LEVEL {
+	comps := complvls.Load()
+	if comps == nil {
+		return map[string]level.LEVEL{}
+	}
+	return maps.Clone(comps.levels)
+}
+
+// CallerLevel returns the max logging level for
+// the component of the calling function, i.e. the
+// component level if set, else the global Level.
+func CallerLevel() level.LEVEL {
+	comps := complvls.Load()
+	if comps == nil {
+		return Level()
+	}
+	return comps.level(callerFunc(3), Level())
+}
+
+// SetComponentLevel sets the max logging level for
+// the named component, overriding the max logging
+// level set by SetLevel.
+//
+// A component is a package of GoToSocial, named by
+// its path without module path or 'internal/', for
+// example 'transport', 'federation/dereferencing'
+// or 'cmd/gotosocial/action/server'. The logging
+// level of a component also applies to all its
+// subpackages, unless they have their own level.
+func SetComponentLevel(component string, lvl level.LEVEL) {
+	updateComponentLevels(func(levels map[string]level.LEVEL) {
+		levels[component] = lvl
+	})
+}
+
+// UnsetComponentLevel unsets the max logging level
+// of the named component, after which its logging
+// level is inherited again.
+func UnsetComponentLevel(component string) {
+	updateComponentLevels(func(levels map[string]level.LEVEL) {
+		delete(levels, component)
+	})
+}
+
+// updateComponentLevels applies the given update
+// to a copy of the current component levels,
+// and atomically sets that as current.
+func updateComponentLevels(update func(map[string]level.LEVEL)) {
+	complvlsMu.Lock()
+	defer complvlsMu.Unlock()
+
+	levels := ComponentLevels()
+	update(levels)
+
+	if len(levels) == 0 {
+		// Nothing set, use
+		// fast path in logf.
+		complvls.Store(nil)
+		return
+	}
+
+	comps := &componentLevels{levels: levels}
+	for _, lvl := range levels {
+		comps.max = max(comps.max, lvl)
+	}
+
+	complvls.Store(comps)
+}
+
+// maxLevel returns the highest of the given global
+// logging level and all component logging levels,
+// which any logging must be at or below.
+func (c *componentLevels) maxLevel(global level.LEVEL) level.LEVEL {
+	if c == nil {
+		return global
+	}
+	return max(global, c.max)
+}
+
+// level returns the logging level for the component
+// of the given func, falling back to the given global
+// logging level if no component level applies.
+func (c *componentLevels) level(fn *runtime.Func, global level.LEVEL) level.LEVEL {
+	if c == nil || fn == nil {
+		return global
+	}
+
+	// Check levels from most to least specific
+	// component, eg. 'federation/dereferencing'
+	// then 'federation'.
+	component := funcComponent(fn)
+	for component != "" {
+		if lvl, ok := c.levels[component]; ok {
+			return lvl
+		}
+
+		idx := strings.LastIndexByte(component, '/')
+		if idx < 0 {
+			break
+		}
+
+		component = component[:idx]
+	}
+
+	return global
+}
+
+// funcComponent returns the component
+// name of the package of the given func.
+func funcComponent(fn *runtime.Func) string {
+	entry := fn.Entry()
+
+	if v, ok := funccomps.Load(entry); ok {
+		return v.(string)
+	}
+
+	// Func names are package path followed by
+	// func name, eg. 'example.org/pkg.(*T).Func',
+	// so package path ends at first '.' after
+	// the last '/'.
+	name := fn.Name()
+	slash := strings.LastIndexByte(name, '/')
+	if dot := strings.IndexByte(name[slash+1:], '.'); dot >= 0 {
+		name = name[:slash+1+dot]
+	}
+
+	// Trim module path and 'internal/'
+	// to get just the component name.
+	name = strings.TrimPrefix(name, modulePath)
+	name = strings.TrimPrefix(name, "internal/")
+
+	funccomps.Store(entry, name)
+	return name
+}
@@ -0,0 +1,59 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package log_test
+
+import (
+	"regexp"
+
+	"codeberg.org/gruf/go-logger/v2/level"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+func (suite *SyslogTestSuite) TestSyslogComponentLevel() {
+	// Only log info and below globally.
+	lvl := log.Level()
+	log.SetLevel(level.INFO)
+	defer log.SetLevel(lvl)
+
+	// Debug shouldn't be logged, so
+	// first entry should be the error.
+	log.Debug(nil, "debug before component level")
+	log.Error(nil, "error before component level")
+	entry := <-suite.syslogChannel
+	suite.Regexp(regexp.MustCompile(`level=ERROR msg="error before component level"`), entry["content"])
+
+	// Set debug for this test package.
+	log.SetComponentLevel("log_test", level.DEBUG)
+	suite.Equal(map[string]level.LEVEL{"log_test": level.DEBUG}, log.ComponentLevels())
+	suite.Equal(level.DEBUG, log.CallerLevel())
+
+	log.Debug(nil, "debug with component level")
+	entry = <-suite.syslogChannel
+	suite.Regexp(regexp.MustCompile(`level=DEBUG msg="debug with component level"`), entry["content"])
+
+	// Levels for other components shouldn't apply here.
+	log.UnsetComponentLevel("log_test")
+	log.SetComponentLevel("transport", level.TRACE)
+	defer log.UnsetComponentLevel("transport")
+	suite.Equal(level.INFO, log.CallerLevel())
+
+	log.Debug(nil, "debug after component level")
+	log.Error(nil, "error after component level")
+	entry = <-suite.syslogChannel
+	suite.Regexp(regexp.MustCompile(`level=ERROR msg="error after component level"`), entry["content"])
+}
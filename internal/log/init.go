@@ -27,23 +27,54 @@ import (
 
 // ParseLevel will parse the log level from given string and set to appropriate level.
 func ParseLevel(str string) error {
+	lvl, err := LevelFromString(str)
+	if err != nil {
+		return err
+	}
+	SetLevel(lvl)
+	return nil
+}
+
+// LevelFromString parses the log level from given string,
+// where an empty string is treated as the default 'info'.
+func LevelFromString(str string) (level.LEVEL, error) {
 	switch strings.ToLower(str) {
 	case "trace":
-		SetLevel(level.TRACE)
+		return level.TRACE, nil
 	case "debug":
-		SetLevel(level.DEBUG)
+		return level.DEBUG, nil
 	case "", "info":
-		SetLevel(level.INFO)
+		return level.INFO, nil
 	case "warn":
-		SetLevel(level.WARN)
+		return level.WARN, nil
 	case "error":
-		SetLevel(level.ERROR)
+		return level.ERROR, nil
 	case "fatal":
-		SetLevel(level.FATAL)
+		return level.FATAL, nil
 	default:
-		return fmt.Errorf("unknown log level: %q", str)
+		return 0, fmt.Errorf("unknown log level: %q", str)
+	}
+}
+
+// LevelString returns the lowercase name of given log level,
+// as accepted by LevelFromString, or empty if unknown.
+func LevelString(lvl level.LEVEL) string {
+	switch lvl {
+	case level.TRACE:
+		return "trace"
+	case level.DEBUG:
+		return "debug"
+	case level.INFO:
+		return "info"
+	case level.WARN:
+		return "warn"
+	case level.ERROR:
+		return "error"
+	case level.FATAL:
+		return "fatal"
+	default:
+		return ""
 	}
-	return nil
 }
 
 // EnableSyslog will enabling logging to the syslog at given address.
@@ -213,8 +213,18 @@ func printf(depth int, fields []kv.Field, s string, a ...interface{}) {
 func logf(ctx context.Context, depth int, lvl level.LEVEL, fields []kv.Field, s string, a ...interface{}) {
 	var out *os.File
 
-	// Check if enabled.
-	if lvl > Level() {
+	// Check if enabled
+	// for any component.
+	comps := complvls.Load()
+	if lvl > comps.maxLevel(Level()) {
+		return
+	}
+
+	// Fetch calling function using calldepth.
+	fn := callerFunc(depth + 1)
+
+	// Check if enabled for caller's component.
+	if comps != nil && lvl > comps.level(fn, Level()) {
 		return
 	}
 
@@ -234,7 +244,7 @@ func logf(ctx context.Context, depth int, lvl level.LEVEL, fields []kv.Field, s
 
 	// Append formatted caller func
 	buf.B = append(buf.B, `func=`...)
-	buf.B = append(buf.B, Caller(depth+1)...)
+	buf.B = append(buf.B, funcName(fn)...)
 	buf.B = append(buf.B, ' ')
 
 	// Append formatted level string
@@ -0,0 +1,222 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+
+	"codeberg.org/gruf/go-store/v2/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// Identical attachment files are deduplicated in
+// storage: when a newly stored file has the same
+// hash as the file of another cached attachment,
+// the new attachment is pointed at the other file
+// instead, after which its own copy is removed.
+// Files are then only removed from storage once no
+// cached attachments use them anymore, which is
+// checked on demand by path (see FileShared),
+// rather than keeping counts. Deduplication and
+// removal of files with the same hash are done
+// under a lock on the hash (see LockFile), so a
+// file can't be removed while being shared.
+
+// newFileHash returns a new hash
+// for hashing attachment files.
+func newFileHash() hash.Hash {
+	return sha256.New()
+}
+
+// fileHashString returns the
+// hex encoded sum of given hash.
+func fileHashString(h hash.Hash) string {
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// HashFile hashes the file of the given attachment
+// in storage, as is done for new files on ingest,
+// returning the result as for File.Hash.
+func (m *Manager) HashFile(ctx context.Context, attachment *gtsmodel.MediaAttachment) (string, error) {
+	rc, err := m.state.Storage.GetStream(ctx, attachment.File.Path)
+	if err != nil {
+		return "", gtserror.Newf("error opening file: %w", err)
+	}
+	defer rc.Close()
+
+	h := newFileHash()
+	if _, err := io.Copy(h, rc); err != nil {
+		return "", gtserror.Newf("error reading file: %w", err)
+	}
+
+	return fileHashString(h), nil
+}
+
+// LockFile locks the file of the given attachment against
+// concurrent deduplication, or removal of the file through
+// other attachments with the same file hash, returning the
+// func to unlock it again. This should be held from checking
+// which files of the attachment are removable until they're
+// removed, and the attachment updated in the db to match.
+func (m *Manager) LockFile(attachment *gtsmodel.MediaAttachment) func() {
+	if attachment.File.Hash == "" {
+		// Only hashed files are
+		// deduplicated, or shared.
+		return func() {}
+	}
+	return m.state.ProcessingLocks.Lock("media_file:" + attachment.File.Hash)
+}
+
+// FileShared returns whether the file of the given
+// attachment is shared with other cached attachments,
+// after deduplication, and so must be kept in storage.
+func (m *Manager) FileShared(ctx context.Context, attachment *gtsmodel.MediaAttachment) (bool, error) {
+	if attachment.File.Path == "" {
+		// No file.
+		return false, nil
+	}
+
+	ids, err := m.state.DB.GetAttachmentIDsByFilePath(ctx, attachment.File.Path)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, gtserror.Newf("db error getting attachments by path: %w", err)
+	}
+
+	for _, id := range ids {
+		if id != attachment.ID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// RemovablePaths returns the storage paths of the files
+// of the given attachment which can be removed along with
+// it, or when uncaching it: its thumbnail, and its file
+// unless that's shared with other cached attachments
+// (see FileShared).
+func (m *Manager) RemovablePaths(ctx context.Context, attachment *gtsmodel.MediaAttachment) ([]string, error) {
+	shared, err := m.FileShared(ctx, attachment)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, 2)
+
+	if attachment.Thumbnail.Path != "" {
+		paths = append(paths, attachment.Thumbnail.Path)
+	}
+
+	if attachment.File.Path != "" && !shared {
+		paths = append(paths, attachment.File.Path)
+	}
+
+	return paths, nil
+}
+
+// DedupeFile deduplicates the stored file of the given hashed,
+// cached attachment: if another cached attachment has an identical
+// file, the given attachment's file path is set to that of the other
+// file and updated in the db, after which the attachment's own file
+// is removed from storage.
+//
+// It returns whether the file was deduplicated. The attachment must
+// already be stored in the db, and its file hash set there.
+func (m *Manager) DedupeFile(ctx context.Context, attachment *gtsmodel.MediaAttachment) (bool, error) {
+	if attachment.File.Hash == "" || !*attachment.Cached {
+		// Nothing to go by.
+		return false, nil
+	}
+
+	// Lock so that the file we dedupe onto
+	// can't be removed while we're at it.
+	unlock := m.LockFile(attachment)
+	defer unlock()
+
+	// Make sure nothing uses this file already,
+	// as then it must be kept in storage anyway.
+	shared, err := m.FileShared(ctx, attachment)
+	if err != nil {
+		return false, err
+	} else if shared {
+		return false, nil
+	}
+
+	others, err := m.state.DB.GetAttachmentsByFileHash(
+		gtscontext.SetBarebones(ctx),
+		attachment.File.Hash,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, gtserror.Newf("db error getting attachments by hash: %w", err)
+	}
+
+	for _, other := range others {
+		if other.ID == attachment.ID ||
+			other.File.Path == attachment.File.Path {
+			// Not another file.
+			continue
+		}
+
+		if other.File.ContentType != attachment.File.ContentType {
+			// Was processed differently, so
+			// it's not a drop-in replacement.
+			continue
+		}
+
+		// Check the other file is (still) there.
+		have, err := m.state.Storage.Has(ctx, other.File.Path)
+		if err != nil {
+			return false, gtserror.Newf("error checking storage for %s: %w", other.File.Path, err)
+		} else if !have {
+			continue
+		}
+
+		// Point the attachment at the other file in the
+		// db first, so that it never points at a removed
+		// file, even if we fail or stop part way through.
+		ownPath := attachment.File.Path
+		attachment.File.Path = other.File.Path
+		if err := m.state.DB.UpdateAttachment(ctx, attachment, "file_path"); err != nil {
+			attachment.File.Path = ownPath
+			return false, gtserror.Newf("db error updating file path: %w", err)
+		}
+
+		// Now remove the attachment's own copy.
+		err = m.state.Storage.Delete(ctx, ownPath)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			// Nothing points at this file
+			// anymore, so it'll be pruned
+			// as orphaned if left behind.
+			log.Errorf(ctx, "error removing duplicate %s: %v", ownPath, err)
+		}
+
+		log.Debugf(ctx, "deduplicated file of media %s with that of %s", attachment.ID, other.ID)
+		return true, nil
+	}
+
+	return false, nil
+}
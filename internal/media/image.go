@@ -29,6 +29,7 @@ import (
 
 	"github.com/buckket/go-blurhash"
 	"github.com/disintegration/imaging"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/iotools"
 
 	// import to init webp encode/decoding.
@@ -120,6 +121,41 @@ func (m *gtsImage) Thumbnail() *gtsImage {
 	return &gtsImage{image: img}
 }
 
+// FocusCrop returns a copy of gtsImage{} cropped to the largest square
+// containing the given focus point, placed so that the focus point keeps
+// the same relative position within the crop as within the full image.
+// Clients apply the focus point to thumbnails themselves, so this ensures
+// it still lands on the same spot rather than being shifted a second time.
+// The focus point is given as in gtsmodel.Focus{}, ie., with both x and y
+// between -1 and 1, and y increasing upwards. If the focus point is at the
+// centre of the image (the default), the image is returned as-is, uncropped.
+func (m *gtsImage) FocusCrop(x, y float32) *gtsImage {
+	if x == 0 && y == 0 {
+		return m
+	}
+
+	width := int(m.Width())
+	height := int(m.Height())
+	side := min(width, height)
+
+	// Convert focus point to a fraction
+	// of the image width and height.
+	fracX := (x + 1) / 2
+	fracY := (1 - y) / 2
+
+	// Offset crop area by the same fraction of
+	// the cropped-out space, which also keeps
+	// it within the image bounds.
+	minX := int(fracX * float32(width-side))
+	minY := int(fracY * float32(height-side))
+
+	img := imaging.Crop(m.image, image.Rect(
+		minX, minY,
+		minX+side, minY+side,
+	))
+	return &gtsImage{image: img}
+}
+
 // Blurhash calculates the blurhash for the receiving image data.
 func (m *gtsImage) Blurhash() (string, error) {
 	// for generating blurhashes, it's more cost effective to
@@ -130,6 +166,34 @@ func (m *gtsImage) Blurhash() (string, error) {
 	return blurhash.Encode(4, 3, tiny)
 }
 
+// BlurhashPreview decodes the given blurhash into a blurred JPEG
+// image of the given dimensions, suitable for standing in for
+// sensitive media in link previews without revealing it. If the
+// dimensions are unknown (zero), a max size thumbnail is used.
+func BlurhashPreview(hash string, width int, height int) (io.Reader, error) {
+	if width <= 0 || height <= 0 {
+		width, height = 512, 512
+	}
+
+	// Decoding is expensive per pixel, and detail gets
+	// lost in the blur anyway, so decode a tiny version
+	// (32px on the longest side) and scale that up.
+	tinyW, tinyH := 32, 32
+	if width > height {
+		tinyH = max(1, 32*height/width)
+	} else {
+		tinyW = max(1, 32*width/height)
+	}
+
+	tiny, err := blurhash.Decode(hash, tinyW, tinyH, 1)
+	if err != nil {
+		return nil, gtserror.Newf("error decoding blurhash: %w", err)
+	}
+
+	img := &gtsImage{image: imaging.Resize(tiny, width, height, imaging.Linear)}
+	return img.ToJPEG(&jpeg.Options{Quality: 70}), nil
+}
+
 // ToJPEG creates a new streaming JPEG encoder from receiving image, and a size ptr
 // which stores the number of bytes written during the image encoding process.
 func (m *gtsImage) ToJPEG(opts *jpeg.Options) io.Reader {
@@ -20,11 +20,14 @@ package media
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
+	"slices"
 	"time"
 
 	"codeberg.org/gruf/go-iotools"
 	"codeberg.org/gruf/go-store/v2/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
@@ -42,6 +45,40 @@ var SupportedMIMETypes = []string{
 	mimeVideoMp4,
 }
 
+// AllowedMIMETypes returns the MIME types that local
+// users are allowed to upload as media. This is
+// SupportedMIMETypes, limited to those set in
+// media-allowed-mime-types if that isn't empty.
+func AllowedMIMETypes() []string {
+	mimeTypes := make([]string, 0, len(SupportedMIMETypes))
+	for _, mimeType := range SupportedMIMETypes {
+		if IsAllowedMIMEType(mimeType) {
+			mimeTypes = append(mimeTypes, mimeType)
+		}
+	}
+	return mimeTypes
+}
+
+// IsAllowedMIMEType returns whether the instance admin
+// allows local users to upload media of the given MIME
+// type. Note this doesn't check whether the type is one
+// that we support; unsupported types are rejected anyway.
+func IsAllowedMIMEType(mimeType string) bool {
+	allowed := config.GetMediaAllowedMIMETypes()
+	return len(allowed) == 0 || slices.Contains(allowed, mimeType)
+}
+
+// UnprocessableUploadError returns an error explaining
+// why the given local upload was processed as unknown type.
+func UnprocessableUploadError(attachment *gtsmodel.MediaAttachment) error {
+	contentType := attachment.File.ContentType
+	if !IsAllowedMIMEType(contentType) {
+		return fmt.Errorf("uploading media of type %s is not allowed on this instance", contentType)
+	}
+
+	return gtserror.Newf("could not process uploaded file with extension %s", contentType)
+}
+
 var SupportedEmojiMIMETypes = []string{
 	mimeImageGif,
 	mimeImagePng,
@@ -54,6 +91,18 @@ type Manager struct {
 
 // NewManager returns a media manager with given state.
 func NewManager(state *state.State) *Manager {
+	// Let admins know about any allowed types
+	// that won't be accepted regardless, as we
+	// don't support uploading media of that type.
+	for _, mimeType := range config.GetMediaAllowedMIMETypes() {
+		if !slices.Contains(SupportedMIMETypes, mimeType) {
+			log.Warnf(nil,
+				"%s entry %s is not a supported media type, and will be ignored",
+				config.MediaAllowedMIMETypesFlag(), mimeType,
+			)
+		}
+	}
+
 	return &Manager{state: state}
 }
 
@@ -97,6 +146,7 @@ func (m *Manager) PreProcessMedia(
 		Avatar:    util.Ptr(false),
 		Header:    util.Ptr(false),
 		Cached:    util.Ptr(false),
+		Sensitive: util.Ptr(false),
 	}
 
 	attachment.URL = uris.URIForAttachment(
@@ -151,6 +201,14 @@ func (m *Manager) PreProcessMedia(
 			attachment.Header = ai.Header
 		}
 
+		if ai.Sensitive != nil {
+			attachment.Sensitive = ai.Sensitive
+		}
+
+		if ai.ExpiresAt != nil {
+			attachment.ExpiresAt = *ai.ExpiresAt
+		}
+
 		if ai.FocusX != nil {
 			attachment.FileMeta.Focus.X = *ai.FocusX
 		}
@@ -169,6 +227,53 @@ func (m *Manager) PreProcessMedia(
 	return processingMedia
 }
 
+// PlaceholderMedia stores a placeholder attachment for
+// remote media that should only be fetched when it's
+// first requested, instead of right away. The returned
+// attachment is uncached and has not been processed;
+// it will be fetched and processed as a recache on
+// first request (see PreProcessMediaRecache).
+//
+//   - accountID: the account that the media belongs to.
+//   - ai: optional and can be nil. Any additional information
+//     about the attachment provided will be put in the database.
+//     This should include at least a RemoteURL to fetch from.
+func (m *Manager) PlaceholderMedia(
+	ctx context.Context,
+	accountID string,
+	ai *AdditionalMediaInfo,
+) (*gtsmodel.MediaAttachment, error) {
+	// Prepare attachment as usual,
+	// but don't ever load it.
+	attachment := m.PreProcessMedia(nil, accountID, ai).media
+	attachment.Processing = gtsmodel.ProcessingStatusPending
+
+	// Set thumbnail details as they'd be set after
+	// processing, so the placeholder thumbnail URL
+	// can already be given out, and requested.
+	attachment.Thumbnail.ContentType = mimeImageJpeg
+	attachment.Thumbnail.Path = uris.StoragePathForAttachment(
+		accountID,
+		string(TypeAttachment),
+		string(SizeSmall),
+		attachment.ID,
+		"jpg",
+	)
+	attachment.Thumbnail.URL = uris.URIForAttachment(
+		accountID,
+		string(TypeAttachment),
+		string(SizeSmall),
+		attachment.ID,
+		"jpg",
+	)
+
+	if err := m.state.DB.PutAttachment(ctx, attachment); err != nil {
+		return nil, gtserror.Newf("error putting placeholder attachment: %w", err)
+	}
+
+	return attachment, nil
+}
+
 // PreProcessMediaRecache refetches, reprocesses,
 // and recaches an existing attachment that has
 // been uncached via cleaner pruning.
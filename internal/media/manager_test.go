@@ -28,7 +28,9 @@ import (
 	"time"
 
 	"codeberg.org/gruf/go-store/v2/storage"
+	exif "github.com/dsoprea/go-exif/v3"
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	gtsmodel "github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
@@ -436,6 +438,193 @@ func (suite *ManagerTestSuite) TestSimpleJpegProcessBlocking() {
 	suite.Equal(processedThumbnailBytesExpected, processedThumbnailBytes)
 }
 
+func (suite *ManagerTestSuite) TestJpegProcessStripsMetadata() {
+	ctx := context.Background()
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test image with exif + gps data
+		b, err := os.ReadFile("./test/test-jpeg-1x1px-exif-gps.jpg")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with no additional info provided
+	processingMedia := suite.manager.PreProcessMedia(data, accountID, nil)
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// we should be told what was stripped
+	suite.Equal([]string{media.MetadataEXIF, media.MetadataGPS}, attachment.StrippedMetadata)
+
+	// and it should be stored in the database
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+	suite.NoError(err)
+	suite.Equal(attachment.StrippedMetadata, dbAttachment.StrippedMetadata)
+
+	// the processed file should only have orientation left
+	processedFullBytes, err := suite.storage.Get(ctx, attachment.File.Path)
+	suite.NoError(err)
+
+	rawExif, err := exif.SearchAndExtractExif(processedFullBytes)
+	suite.NoError(err)
+
+	tags, _, err := exif.GetFlatExifData(rawExif, nil)
+	suite.NoError(err)
+	suite.Len(tags, 1)
+	suite.Equal("Orientation", tags[0].TagName)
+}
+
+func (suite *ManagerTestSuite) TestJpegProcessStripsOrientation() {
+	ctx := context.Background()
+
+	config.SetMediaPreserveOrientation(false)
+	defer config.SetMediaPreserveOrientation(true)
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test image with exif + gps data
+		b, err := os.ReadFile("./test/test-jpeg-1x1px-exif-gps.jpg")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with no additional info provided
+	processingMedia := suite.manager.PreProcessMedia(data, accountID, nil)
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+	suite.Equal([]string{media.MetadataEXIF, media.MetadataGPS}, attachment.StrippedMetadata)
+
+	// the processed file should have no exif at all
+	processedFullBytes, err := suite.storage.Get(ctx, attachment.File.Path)
+	suite.NoError(err)
+
+	_, err = exif.SearchAndExtractExif(processedFullBytes)
+	suite.ErrorIs(err, exif.ErrNoExif)
+}
+
+func (suite *ManagerTestSuite) TestSimpleJpegProcessNoMetadata() {
+	ctx := context.Background()
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test image without exif data
+		b, err := os.ReadFile("./test/test-jpeg-1x1px-white.jpg")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with no additional info provided
+	processingMedia := suite.manager.PreProcessMedia(data, accountID, nil)
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// nothing to strip
+	suite.Empty(attachment.StrippedMetadata)
+}
+
+func (suite *ManagerTestSuite) TestJpegProcessNotAllowed() {
+	ctx := context.Background()
+
+	// Only allow PNGs to be uploaded.
+	config.SetMediaAllowedMIMETypes([]string{"image/png"})
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test image
+		b, err := os.ReadFile("./test/test-jpeg.jpg")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with no additional info provided
+	processingMedia := suite.manager.PreProcessMedia(data, accountID, nil)
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// Attachment should have type unknown,
+	// with the type as sniffed from contents.
+	suite.Equal(gtsmodel.FileTypeUnknown, attachment.Type)
+	suite.Equal("image/jpeg", attachment.File.ContentType)
+	suite.EqualError(
+		media.UnprocessableUploadError(attachment),
+		"uploading media of type image/jpeg is not allowed on this instance",
+	)
+
+	// Nothing should be in storage for this attachment.
+	stored, err := suite.storage.Has(ctx, attachment.File.Path)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(stored)
+}
+
+func (suite *ManagerTestSuite) TestJpegProcessNotAllowedRemote() {
+	ctx := context.Background()
+
+	// Only allow PNGs to be uploaded.
+	config.SetMediaAllowedMIMETypes([]string{"image/png"})
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test image
+		b, err := os.ReadFile("./test/test-jpeg.jpg")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+	remoteURL := "http://fossbros-anonymous.io/some/image/path.jpg"
+
+	// process the media as if fetched from a remote instance
+	processingMedia := suite.manager.PreProcessMedia(data, accountID, &media.AdditionalMediaInfo{
+		RemoteURL: &remoteURL,
+	})
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// The allowlist only applies to uploads,
+	// so remote media should be processed fine.
+	suite.Equal(gtsmodel.FileTypeImage, attachment.Type)
+	suite.Equal("image/jpeg", attachment.File.ContentType)
+}
+
+func (suite *ManagerTestSuite) TestAllowedMIMETypes() {
+	suite.Equal(media.SupportedMIMETypes, media.AllowedMIMETypes())
+
+	// Entries that aren't supported anyway are left out.
+	config.SetMediaAllowedMIMETypes([]string{"image/webp", "image/jpeg", "video/webm"})
+	suite.Equal([]string{"image/jpeg", "image/webp"}, media.AllowedMIMETypes())
+}
+
 func (suite *ManagerTestSuite) TestSimpleJpegProcessPartial() {
 	ctx := context.Background()
 
@@ -1391,6 +1580,59 @@ func (suite *ManagerTestSuite) TestNoReportedSizeSmallMedia() {
 	suite.Equal(actualSize, attachment.File.FileSize)
 }
 
+func (suite *ManagerTestSuite) TestDedupeIdenticalFiles() {
+	ctx := context.Background()
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test image
+		b, err := os.ReadFile("./test/test-jpeg.jpg")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the same media twice
+	processingMedia := suite.manager.PreProcessMedia(data, accountID, nil)
+	attachment1, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+
+	processingMedia = suite.manager.PreProcessMedia(data, accountID, nil)
+	attachment2, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+
+	// both should be hashed identically
+	suite.Len(attachment1.File.Hash, 64)
+	suite.Equal(attachment1.File.Hash, attachment2.File.Hash)
+
+	// the second should reuse the file of the first,
+	// but keep its own thumbnail
+	suite.Equal(attachment1.File.Path, attachment2.File.Path)
+	suite.NotEqual(attachment1.Thumbnail.Path, attachment2.Thumbnail.Path)
+
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment2.ID)
+	suite.NoError(err)
+	suite.Equal(attachment1.File.Path, dbAttachment.File.Path)
+
+	// the shared file can't be removed with either attachment
+	shared, err := suite.manager.FileShared(ctx, attachment1)
+	suite.NoError(err)
+	suite.True(shared)
+
+	paths, err := suite.manager.RemovablePaths(ctx, attachment1)
+	suite.NoError(err)
+	suite.Equal([]string{attachment1.Thumbnail.Path}, paths)
+
+	// once one is gone, the other owns the file alone
+	suite.NoError(suite.db.DeleteAttachment(ctx, attachment1.ID))
+
+	paths, err = suite.manager.RemovablePaths(ctx, attachment2)
+	suite.NoError(err)
+	suite.Equal([]string{attachment2.Thumbnail.Path, attachment2.File.Path}, paths)
+}
+
 func TestManagerTestSuite(t *testing.T) {
 	suite.Run(t, &ManagerTestSuite{})
 }
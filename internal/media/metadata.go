@@ -0,0 +1,315 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	exif "github.com/dsoprea/go-exif/v3"
+)
+
+const (
+	// MetadataEXIF indicates that EXIF
+	// metadata (camera details, capture
+	// time, etc.) was stripped from media.
+	MetadataEXIF = "exif"
+
+	// MetadataGPS indicates that GPS
+	// location metadata was stripped
+	// from media.
+	MetadataGPS = "gps"
+
+	// maxExifSize is the largest EXIF block
+	// that will be kept in memory to check
+	// for GPS data. This is the most that
+	// fits in a single JPEG APP1 segment.
+	maxExifSize = 0xFFFF
+)
+
+// metadataReader wraps an uploaded image stream, passing it through
+// while walking the image's segments / chunks to find any EXIF block,
+// so that the kinds of metadata stripped from it can be reported once
+// the stream has been read. Only a single segment or chunk is ever
+// held in memory at a time; the rest of the image is streamed as-is.
+//
+// For JPEGs, EXIF segments can also be cleared as they're streamed,
+// as exif-terminator would otherwise keep the orientation tag.
+type metadataReader struct {
+	r         io.Reader
+	next      func() error // reads next segment / chunk
+	clearExif bool         // clear JPEG EXIF segments
+	hasExif   bool         // EXIF block found
+	exif      []byte       // EXIF block, if small enough
+	buf       []byte       // pending bytes to return
+	skip      int64        // bytes to pass through as-is
+	done      bool         // header walk finished
+}
+
+// newMetadataReader returns a new metadataReader for
+// image data of the given extension read from r.
+func newMetadataReader(r io.Reader, ext string, clearExif bool) *metadataReader {
+	m := &metadataReader{r: r, clearExif: clearExif}
+	switch ext {
+	case "jpg", "jpeg":
+		m.next = m.startJPEG
+	case "png":
+		m.next = m.startPNG
+	case "webp":
+		m.next = m.startWebP
+	default:
+		m.done = true
+	}
+	return m
+}
+
+// Read implements io.Reader.
+func (m *metadataReader) Read(p []byte) (int, error) {
+	for len(m.buf) == 0 && m.skip == 0 && !m.done {
+		if err := m.next(); err != nil {
+			return 0, err
+		}
+	}
+
+	switch {
+	case len(m.buf) > 0:
+		n := copy(p, m.buf)
+		m.buf = m.buf[n:]
+		return n, nil
+
+	case m.skip > 0:
+		if int64(len(p)) > m.skip {
+			p = p[:m.skip]
+		}
+		n, err := m.r.Read(p)
+		m.skip -= int64(n)
+		return n, err
+
+	default:
+		return m.r.Read(p)
+	}
+}
+
+// read reads exactly n bytes from the underlying reader
+// into m.buf. If the stream ends early, any bytes read are
+// still returned and the header walk is marked done.
+func (m *metadataReader) read(n int) (bool, error) {
+	b := make([]byte, n)
+	n, err := io.ReadFull(m.r, b)
+	m.buf = append(m.buf, b[:n]...)
+	switch err {
+	case nil:
+		return true, nil
+	case io.EOF, io.ErrUnexpectedEOF:
+		m.done = true
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// foundExif records an EXIF block found in
+// the image, keeping it if it's small enough.
+func (m *metadataReader) foundExif(data []byte) {
+	if m.hasExif {
+		return
+	}
+	m.hasExif = true
+	if len(data) <= maxExifSize {
+		m.exif = bytes.Clone(data)
+	}
+}
+
+func (m *metadataReader) startJPEG() error {
+	// Start-of-image marker.
+	if ok, err := m.read(2); !ok {
+		return err
+	}
+	m.next = m.nextJPEG
+	return nil
+}
+
+func (m *metadataReader) nextJPEG() error {
+	// Segment marker and length.
+	if ok, err := m.read(4); !ok {
+		return err
+	}
+
+	hdr := m.buf[len(m.buf)-4:]
+	if hdr[0] != 0xFF || hdr[1] == 0xDA {
+		// Malformed, or start of scan:
+		// no more header segments.
+		m.done = true
+		return nil
+	}
+
+	// Segment length includes the two length
+	// bytes, but not the two marker bytes.
+	length := int(binary.BigEndian.Uint16(hdr[2:]))
+	if length < 2 {
+		// Malformed.
+		m.done = true
+		return nil
+	}
+
+	marker := hdr[1]
+	if ok, err := m.read(length - 2); !ok {
+		return err
+	}
+
+	seg := m.buf[len(m.buf)-(length-2):]
+	if marker == 0xE1 && bytes.HasPrefix(seg, []byte("Exif\x00\x00")) {
+		m.foundExif(seg)
+		if m.clearExif {
+			// Lengths are left as-is, so
+			// the image is still valid.
+			clear(seg)
+		}
+	}
+
+	return nil
+}
+
+func (m *metadataReader) startPNG() error {
+	// PNG signature.
+	if ok, err := m.read(8); !ok {
+		return err
+	}
+	m.next = m.nextPNG
+	return nil
+}
+
+func (m *metadataReader) nextPNG() error {
+	// Chunk length and type.
+	if ok, err := m.read(8); !ok {
+		return err
+	}
+
+	hdr := m.buf[len(m.buf)-8:]
+	length := int64(binary.BigEndian.Uint32(hdr))
+	chunkType := string(hdr[4:])
+
+	if chunkType == "IEND" {
+		// Nothing
+		// follows.
+		m.done = true
+		return nil
+	}
+
+	return m.chunk(chunkType == "eXIf", length, 4)
+}
+
+func (m *metadataReader) startWebP() error {
+	// RIFF header.
+	if ok, err := m.read(12); !ok {
+		return err
+	}
+	m.next = m.nextWebP
+	return nil
+}
+
+func (m *metadataReader) nextWebP() error {
+	// Chunk type and length.
+	if ok, err := m.read(8); !ok {
+		return err
+	}
+
+	hdr := m.buf[len(m.buf)-8:]
+	length := int64(binary.LittleEndian.Uint32(hdr[4:]))
+	chunkType := string(hdr[:4])
+
+	// Chunks are padded
+	// to an even length.
+	return m.chunk(chunkType == "EXIF", length, length&1)
+}
+
+// chunk handles a PNG or WebP chunk of given data length, followed by
+// trailing bytes (CRC or padding). EXIF chunks small enough are read
+// into memory, everything else is passed through as-is.
+func (m *metadataReader) chunk(isExif bool, length, trailing int64) error {
+	if !isExif || length > maxExifSize {
+		if isExif {
+			m.foundExif(nil)
+		}
+		m.skip = length + trailing
+		return nil
+	}
+
+	if ok, err := m.read(int(length + trailing)); !ok {
+		return err
+	}
+
+	data := m.buf[len(m.buf)-int(length+trailing):]
+	m.foundExif(data[:length])
+	return nil
+}
+
+// stripped returns the kinds of metadata found in the
+// image data read so far which will be stripped from it.
+// If keepOrientation is set, an orientation tag on its own
+// will not be counted as EXIF metadata to be stripped.
+func (m *metadataReader) stripped(keepOrientation bool) []string {
+	if !m.hasExif {
+		return nil
+	}
+
+	if m.exif == nil {
+		// Too large to check
+		// what's in it.
+		return []string{MetadataEXIF}
+	}
+
+	rawExif, err := exif.SearchAndExtractExif(m.exif)
+	if err != nil {
+		// No (readable)
+		// exif data.
+		return nil
+	}
+
+	tags, _, err := exif.GetFlatExifData(rawExif, nil)
+	if err != nil {
+		// Found something that looked
+		// like exif, but couldn't parse
+		// it, so it's likely not exif.
+		return nil
+	}
+
+	var hasEXIF, hasGPS bool
+	for _, tag := range tags {
+		if tag.IfdPath == "IFD/GPSInfo" {
+			hasGPS = true
+		}
+
+		if keepOrientation && tag.TagName == "Orientation" {
+			// This will be kept.
+			continue
+		}
+
+		hasEXIF = true
+	}
+
+	var stripped []string
+	if hasEXIF {
+		stripped = append(stripped, MetadataEXIF)
+	}
+	if hasGPS {
+		stripped = append(stripped, MetadataGPS)
+	}
+	return stripped
+}
@@ -21,7 +21,6 @@ import (
 	"bytes"
 	"context"
 	"errors"
-	"image/jpeg"
 	"io"
 	"time"
 
@@ -30,6 +29,7 @@ import (
 	terminator "codeberg.org/superseriousbusiness/exif-terminator"
 	"github.com/disintegration/imaging"
 	"github.com/h2non/filetype"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -90,6 +90,28 @@ func (p *ProcessingMedia) LoadAttachment(ctx context.Context) (*gtsmodel.MediaAt
 	return media, err
 }
 
+// LoadAttachmentNoRetry is like LoadAttachment, except that
+// if the provided context is canceled before the attachment
+// could be fully processed, the media will NOT be enqueued
+// for asynchronous reprocessing. This should be used when
+// the caller is already processing asynchronously, and is
+// enforcing its own time limit on processing.
+func (p *ProcessingMedia) LoadAttachmentNoRetry(ctx context.Context) (*gtsmodel.MediaAttachment, error) {
+	media, _, err := p.load(ctx)
+	return media, err
+}
+
+// LoadAttachmentAsync enqueues the media for asynchronous
+// processing, instead of blocking until it's processed. A
+// copy of the attachment as it is before processing is
+// returned, which should be treated as a placeholder.
+func (p *ProcessingMedia) LoadAttachmentAsync() *gtsmodel.MediaAttachment {
+	media := new(gtsmodel.MediaAttachment)
+	*media = *p.media
+	p.mgr.state.Workers.Media.Queue.Push(p.Process)
+	return media
+}
+
 // Process allows the receiving object to fit the
 // runners.WorkerFunc signature. It performs a
 // (blocking) load and logs on error.
@@ -179,6 +201,16 @@ func (p *ProcessingMedia) load(ctx context.Context) (*gtsmodel.MediaAttachment,
 			errs.Append(dbErr)
 		}
 
+		// Once fully processed and stored, replace
+		// the stored file with an identical one of
+		// another attachment, if any.
+		if len(errs) == 0 && *p.media.Cached &&
+			p.media.Type != gtsmodel.FileTypeUnknown {
+			if _, err := p.mgr.DedupeFile(ctx, p.media); err != nil {
+				log.Errorf(ctx, "error deduplicating media file: %v", err)
+			}
+		}
+
 		err = errs.Combine()
 		return err
 	})
@@ -249,6 +281,25 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 		return gtserror.Newf("error parsing file type: %w", err)
 	}
 
+	if p.media.RemoteURL == "" {
+		// This is a local upload, so check that its type,
+		// as sniffed from its contents, is allowed here.
+		uploadMIME := info.MIME.Value
+		if !IsAllowedMIMEType(uploadMIME) {
+			log.Infof(ctx,
+				"media type '%s' not allowed for upload, will be processed as type '%s'",
+				uploadMIME, gtsmodel.FileTypeUnknown,
+			)
+
+			// Don't store it, just note the type so
+			// callers can explain why it was rejected.
+			if uploadMIME != "" {
+				p.media.File.ContentType = uploadMIME
+			}
+			return nil
+		}
+	}
+
 	// Recombine header bytes with remaining stream
 	r := io.MultiReader(bytes.NewReader(hdrBuf), rc)
 
@@ -256,6 +307,13 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 	// this file in storage.
 	store := true
 
+	// Set for local image uploads,
+	// to report stripped metadata.
+	var (
+		metadata        *metadataReader
+		keepOrientation bool
+	)
+
 	switch info.Extension {
 	case "mp4":
 		// No problem.
@@ -264,6 +322,19 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 		// No problem
 
 	case "jpg", "jpeg", "png", "webp":
+		if p.media.RemoteURL == "" {
+			// This is a local upload, so look for metadata as it's
+			// streamed, to report what was stripped from it.
+			// Orientation is only ever kept for JPEGs.
+			isJPEG := info.Extension == "jpg" || info.Extension == "jpeg"
+			keepOrientation = isJPEG && config.GetMediaPreserveOrientation()
+
+			// Terminator keeps JPEG orientation, so
+			// clear exif entirely if it's not wanted.
+			metadata = newMetadataReader(r, info.Extension, isJPEG && !keepOrientation)
+			r = metadata
+		}
+
 		if fileSize > 0 {
 			// A file size was provided so we can clean
 			// exif data from image as we're streaming it.
@@ -332,15 +403,26 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 		}
 	}
 
+	// Hash the file as it's written,
+	// for deduplicating it later.
+	h := newFileHash()
+	r = io.TeeReader(r, h)
+
 	// Write the final reader stream to our storage.
 	wroteSize, err := p.mgr.state.Storage.PutStream(ctx, p.media.File.Path, r)
 	if err != nil {
 		return gtserror.Newf("error writing media to storage: %w", err)
 	}
 
+	if metadata != nil {
+		// Whole image has now been read.
+		p.media.StrippedMetadata = metadata.stripped(keepOrientation)
+	}
+
 	// Set actual written size
 	// as authoritative file size.
 	p.media.File.FileSize = int(wroteSize)
+	p.media.File.Hash = fileHashString(h)
 
 	// We can now consider this cached.
 	p.media.Cached = util.Ptr(true)
@@ -457,17 +539,11 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 	p.media.FileMeta.Original.Size = int(fullImg.Size())
 	p.media.FileMeta.Original.Aspect = fullImg.AspectRatio()
 
-	// Get smaller thumbnail image
-	thumbImg := fullImg.Thumbnail()
-
-	// Garbage collector, you may
-	// now take our large son.
-	fullImg = nil
-
-	// Only generate blurhash
-	// from thumb if necessary.
+	// Only generate blurhash if necessary. This is
+	// generated from an uncropped thumbnail, as clients
+	// draw it as placeholder for the full-size image.
 	if p.media.Blurhash == "" {
-		hash, err := thumbImg.Blurhash()
+		hash, err := fullImg.Thumbnail().Blurhash()
 		if err != nil {
 			return gtserror.Newf("error generating blurhash: %w", err)
 		}
@@ -476,6 +552,17 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 		p.media.Blurhash = hash
 	}
 
+	// Get smaller thumbnail image, cropped
+	// around the focus point if one is set.
+	thumbImg := fullImg.FocusCrop(
+		p.media.FileMeta.Focus.X,
+		p.media.FileMeta.Focus.Y,
+	).Thumbnail()
+
+	// Garbage collector, you may
+	// now take our large son.
+	fullImg = nil
+
 	// Thumbnail shouldn't already exist in storage at this point,
 	// but we do a check as it's worth logging / cleaning up.
 	if have, _ := p.mgr.state.Storage.Has(ctx, p.media.Thumbnail.Path); have {
@@ -487,30 +574,12 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 		}
 	}
 
-	// Create a thumbnail JPEG encoder stream.
-	enc := thumbImg.ToJPEG(&jpeg.Options{
-		// Good enough for
-		// a thumbnail.
-		Quality: 70,
-	})
-
-	// Stream-encode the JPEG thumbnail image into storage.
-	sz, err := p.mgr.state.Storage.PutStream(ctx, p.media.Thumbnail.Path, enc)
-	if err != nil {
-		return gtserror.Newf("error stream-encoding thumbnail to storage: %w", err)
-	}
-
-	// Set thumbnail dimensions in attachment info.
-	p.media.FileMeta.Small = gtsmodel.Small{
-		Width:  int(thumbImg.Width()),
-		Height: int(thumbImg.Height()),
-		Size:   int(thumbImg.Size()),
-		Aspect: thumbImg.AspectRatio(),
+	// Encode thumbnail into storage, setting
+	// thumbnail details on the attachment.
+	if err := p.mgr.storeThumbnail(ctx, p.media, thumbImg); err != nil {
+		return err
 	}
 
-	// Set written image size.
-	p.media.Thumbnail.FileSize = int(sz)
-
 	// Finally set the attachment as processed and update time.
 	p.media.Processing = gtsmodel.ProcessingStatusProcessed
 	p.media.File.UpdatedAt = time.Now()
@@ -0,0 +1,175 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"image/jpeg"
+	"strconv"
+	"time"
+
+	"github.com/disintegration/imaging"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+// RegenerateThumbnail regenerates the small thumbnail of the given locally
+// cached image or video attachment, cropped around its current focus point.
+// The new thumbnail is stored under a new storage path, and the updated
+// thumbnail path, details and dimensions are set on the attachment. The
+// blurhash is left as-is, as it's of the full-size, uncropped image. The
+// thumbnail URL is also given a new version query, so that clients and
+// proxies holding the old, immutably cached thumbnail will fetch the new one.
+//
+// Note that the updated attachment is NOT stored in the database, and the old
+// thumbnail is NOT removed from storage, that's up to the caller once the
+// attachment is updated. The old thumbnail path is returned for this purpose.
+// If the attachment is not a cached image or video, this is a no-op and an
+// empty path is returned.
+func (m *Manager) RegenerateThumbnail(ctx context.Context, attachment *gtsmodel.MediaAttachment) (string, error) {
+	if attachment.Cached == nil || !*attachment.Cached {
+		// Nothing
+		// to crop.
+		return "", nil
+	}
+
+	if attachment.Type != gtsmodel.FileTypeImage &&
+		attachment.Type != gtsmodel.FileTypeVideo &&
+		attachment.Type != gtsmodel.FileTypeGifv {
+		// Thumbnail not
+		// derived from file.
+		return "", nil
+	}
+
+	// Get a stream to the original file for decoding.
+	rc, err := m.state.Storage.GetStream(ctx, attachment.File.Path)
+	if err != nil {
+		return "", gtserror.Newf("error loading file from storage: %w", err)
+	}
+	defer rc.Close()
+
+	var fullImg *gtsImage
+
+	switch attachment.File.ContentType {
+
+	// .jpeg, .gif, .webp image type
+	case mimeImageJpeg, mimeImageGif, mimeImageWebp:
+		fullImg, err = decodeImage(
+			rc,
+			imaging.AutoOrientation(true),
+		)
+
+	// .png image (requires ancillary chunk stripping)
+	case mimeImagePng:
+		fullImg, err = decodeImage(
+			&pngAncillaryChunkStripper{Reader: rc},
+			imaging.AutoOrientation(true),
+		)
+
+	// .mp4 video type
+	case mimeVideoMp4:
+		var video *gtsVideo
+		video, err = decodeVideoFrame(rc)
+		if err == nil {
+			fullImg = video.frame
+		}
+
+	default:
+		// Can't
+		// decode.
+		return "", nil
+	}
+
+	if err != nil {
+		return "", gtserror.Newf("error decoding %s: %w", attachment.File.ContentType, err)
+	}
+
+	// fullImg should be in-memory by
+	// now so we're done with storage.
+	if err := rc.Close(); err != nil {
+		return "", gtserror.Newf("error closing file: %w", err)
+	}
+
+	thumbImg := fullImg.FocusCrop(
+		attachment.FileMeta.Focus.X,
+		attachment.FileMeta.Focus.Y,
+	).Thumbnail()
+
+	// Store the new thumbnail under a new path, leaving
+	// the old one in place until the attachment's updated.
+	oldPath := attachment.Thumbnail.Path
+	attachment.Thumbnail.Path = uris.StoragePathForAttachment(
+		attachment.AccountID,
+		string(TypeAttachment),
+		string(SizeSmall),
+		id.NewULID(),
+		"jpg",
+	)
+
+	// Encode new thumbnail into storage,
+	// and set the new thumbnail details.
+	if err := m.storeThumbnail(ctx, attachment, thumbImg); err != nil {
+		attachment.Thumbnail.Path = oldPath
+		return "", err
+	}
+
+	// Set a new thumbnail URL version, to bust caches.
+	attachment.Thumbnail.UpdatedAt = time.Now()
+	attachment.Thumbnail.URL = uris.URIForAttachment(
+		attachment.AccountID,
+		string(TypeAttachment),
+		string(SizeSmall),
+		attachment.ID,
+		"jpg",
+	) + "?v=" + strconv.FormatInt(attachment.Thumbnail.UpdatedAt.Unix(), 10)
+
+	return oldPath, nil
+}
+
+// storeThumbnail encodes the given thumbnail image as JPEG into storage at
+// the attachment's thumbnail path, and sets the resulting thumbnail file size
+// and dimensions on the attachment.
+func (m *Manager) storeThumbnail(ctx context.Context, attachment *gtsmodel.MediaAttachment, thumbImg *gtsImage) error {
+	// Create a thumbnail JPEG encoder stream.
+	enc := thumbImg.ToJPEG(&jpeg.Options{
+		// Good enough for
+		// a thumbnail.
+		Quality: 70,
+	})
+
+	// Stream-encode the JPEG thumbnail image into storage.
+	sz, err := m.state.Storage.PutStream(ctx, attachment.Thumbnail.Path, enc)
+	if err != nil {
+		return gtserror.Newf("error stream-encoding thumbnail to storage: %w", err)
+	}
+
+	// Set thumbnail dimensions in attachment info.
+	attachment.FileMeta.Small = gtsmodel.Small{
+		Width:  int(thumbImg.Width()),
+		Height: int(thumbImg.Height()),
+		Size:   int(thumbImg.Size()),
+		Aspect: thumbImg.AspectRatio(),
+	}
+
+	// Set written image size.
+	attachment.Thumbnail.FileSize = int(sz)
+
+	return nil
+}
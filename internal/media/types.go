@@ -59,6 +59,7 @@ const (
 	TypeHeader     Type = "header"     // TypeHeader is the key for profile header requests
 	TypeAvatar     Type = "avatar"     // TypeAvatar is the key for profile avatar requests
 	TypeEmoji      Type = "emoji"      // TypeEmoji is the key for emoji type requests
+	TypeExport     Type = "export"     // TypeExport is the key for account export archives (never served by the fileserver)
 )
 
 // AdditionalMediaInfo represents additional information that should be added to an attachment
@@ -80,6 +81,10 @@ type AdditionalMediaInfo struct {
 	Avatar *bool
 	// Mark this media as in-use as a header; defaults to false.
 	Header *bool
+	// Mark this media as sensitive by itself; defaults to false.
+	Sensitive *bool
+	// Time after which this media's file should be deleted; defaults to never.
+	ExpiresAt *time.Time
 	// X focus coordinate for this media; defaults to 0.
 	FocusX *float32
 	// Y focus coordinate for this media; defaults to 0.
@@ -26,10 +26,13 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
 	"github.com/technologize/otel-go-contrib/otelginmetrics"
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/extra/bunotel"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/metric"
 	sdk "go.opentelemetry.io/otel/sdk/metric"
@@ -127,6 +130,80 @@ func Initialize(db db.DB) error {
 	return nil
 }
 
+// InstrumentHTTPClient registers metrics for the
+// outgoing requests made by the given client, per
+// egress route (ie., direct or through a proxy).
+func InstrumentHTTPClient(client *httpclient.Client) error {
+	if !config.GetMetricsEnabled() {
+		return nil
+	}
+
+	meter := otel.GetMeterProvider().Meter(serviceName)
+
+	requests, err := meter.Int64ObservableCounter(
+		"gotosocial.httpclient.requests",
+		metric.WithDescription("Total number of outgoing http requests, per egress route"),
+	)
+	if err != nil {
+		return err
+	}
+
+	failures, err := meter.Int64ObservableCounter(
+		"gotosocial.httpclient.failures",
+		metric.WithDescription("Total number of outgoing http requests that got no response, per egress route"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		for _, stats := range client.EgressStats() {
+			egress := metric.WithAttributes(attribute.String("egress", stats.Name))
+			o.ObserveInt64(requests, int64(stats.Requests), egress)
+			o.ObserveInt64(failures, int64(stats.Failures), egress)
+		}
+		return nil
+	}, requests, failures)
+
+	return err
+}
+
+// InstrumentStreams registers metrics for the
+// streaming API connections, using the given
+// func to get current stats about open streams.
+func InstrumentStreams(stats func() stream.Stats) error {
+	if !config.GetMetricsEnabled() {
+		return nil
+	}
+
+	meter := otel.GetMeterProvider().Meter(serviceName)
+
+	open, err := meter.Int64ObservableGauge(
+		"gotosocial.streaming.connections",
+		metric.WithDescription("Current number of open streaming API connections"),
+	)
+	if err != nil {
+		return err
+	}
+
+	rejected, err := meter.Int64ObservableCounter(
+		"gotosocial.streaming.rejected",
+		metric.WithDescription("Total number of streaming API connections rejected because of connection limits"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		stats := stats()
+		o.ObserveInt64(open, int64(stats.Open))
+		o.ObserveInt64(rejected, int64(stats.Rejected))
+		return nil
+	}, open, rejected)
+
+	return err
+}
+
 func InstrumentGin() gin.HandlerFunc {
 	return otelginmetrics.Middleware(serviceName)
 }
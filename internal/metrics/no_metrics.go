@@ -25,6 +25,8 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
 	"github.com/uptrace/bun"
 )
 
@@ -35,6 +37,14 @@ func Initialize(db db.DB) error {
 	return nil
 }
 
+func InstrumentHTTPClient(client *httpclient.Client) error {
+	return nil
+}
+
+func InstrumentStreams(stats func() stream.Stats) error {
+	return nil
+}
+
 func InstrumentGin() gin.HandlerFunc {
 	return func(c *gin.Context) {}
 }
@@ -22,13 +22,15 @@ import (
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 )
 
 // CORS returns a new gin middleware which allows CORS requests to be processed.
 // This is necessary in order for web/browser-based clients like Semaphore to work.
 func CORS() gin.HandlerFunc {
 	cfg := cors.Config{
-		// todo: use config to customize this
+		// Allow all origins unless
+		// configured otherwise, see below.
 		AllowAllOrigins: true,
 
 		// adds the following:
@@ -59,6 +61,9 @@ func CORS() gin.HandlerFunc {
 			//   - https://github.com/superseriousbusiness/gotosocial/issues/1664
 			"Idempotency-Key",
 
+			// needed for resumable media uploads
+			"Content-Range",
+
 			// needed for websocket upgrade requests
 			"Upgrade",
 			"Sec-WebSocket-Extensions",
@@ -78,6 +83,10 @@ func CORS() gin.HandlerFunc {
 			"X-RateLimit-Remaining",
 			"X-Request-Id",
 
+			// needed so clients can detect deprecated endpoints
+			"Deprecation",
+			"Sunset",
+
 			// websocket stuff
 			"Connection",
 			"Sec-WebSocket-Accept",
@@ -86,5 +95,19 @@ func CORS() gin.HandlerFunc {
 		MaxAge: 2 * time.Minute,
 	}
 
+	if allowOrigins := config.GetAdvancedCORSAllowOrigins(); len(allowOrigins) != 0 {
+		// Admin has restricted allowed
+		// origins, so only allow those,
+		// plus any first-party web clients.
+		cfg.AllowAllOrigins = false
+		cfg.AllowWildcard = true
+		cfg.AllowOrigins = append(cfg.AllowOrigins, allowOrigins...)
+		for _, webClient := range config.GetAdvancedCORSWebClients() {
+			if origin := config.WebClientOrigin(webClient); origin != "" {
+				cfg.AllowOrigins = append(cfg.AllowOrigins, origin)
+			}
+		}
+	}
+
 	return cors.New(cfg)
 }
@@ -0,0 +1,79 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+)
+
+// Deprecation describes the deprecation of an API route.
+type Deprecation struct {
+	// When the route was deprecated.
+	Since time.Time
+
+	// When the route will be removed.
+	// Zero if no removal is planned yet.
+	Sunset time.Time
+
+	// Path of the route that
+	// should be used instead, if any.
+	Successor string
+}
+
+// DeprecationHeaders returns a new gin middleware which informs callers
+// of deprecated routes about the deprecation, by setting the headers:
+//
+//   - Deprecation: https://www.rfc-editor.org/rfc/rfc9745
+//   - Sunset: https://www.rfc-editor.org/rfc/rfc8594
+//   - Link, with rel="successor-version": https://www.rfc-editor.org/rfc/rfc5829
+//
+// The deprecation is looked up with the given deprecation function, from
+// the request method and the path of the matched route, with the api version
+// param (if any) filled in (eg., `/api/v1/search`). Nil means not deprecated.
+func DeprecationHeaders(deprecation func(method string, path string) *Deprecation) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		path := c.FullPath()
+		if version := c.Param(apiutil.APIVersionKey); version != "" {
+			path = strings.Replace(path, ":"+apiutil.APIVersionKey, version, 1)
+		}
+
+		d := deprecation(c.Request.Method, path)
+		if d == nil {
+			// Not deprecated.
+			return
+		}
+
+		c.Header("Deprecation", "@"+strconv.FormatInt(d.Since.Unix(), 10))
+
+		if !d.Sunset.IsZero() {
+			c.Header("Sunset", d.Sunset.UTC().Format(http.TimeFormat))
+		}
+
+		if d.Successor != "" {
+			// Add rather than set, so as
+			// not to clobber any other links.
+			c.Writer.Header().Add("Link", "<"+d.Successor+`>; rel="successor-version"`)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// OnionLocation returns a new gin middleware which advertises the given
+// onion host, where this instance is also served as a Tor onion service,
+// by setting the Onion-Location header on responses to requests that
+// weren't made to the onion host. Tor Browser uses this header to offer
+// the onion version of a page, and other instances use it to prefer
+// delivering activities to us over Tor.
+//
+// See: https://community.torproject.org/onion-services/advanced/onion-location/
+func OnionLocation(onionHost string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Host == onionHost {
+			// Already onion.
+			return
+		}
+
+		// Onion services are end-to-end encrypted
+		// already, so they're generally served over
+		// plain http, without needing TLS certs.
+		c.Header("Onion-Location", "http://"+onionHost+c.Request.URL.RequestURI())
+	}
+}
@@ -0,0 +1,56 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+)
+
+func TestOnionLocation(t *testing.T) {
+	const onionHost = "gotosocialxyz.onion"
+
+	for _, test := range []struct {
+		target   string
+		expected string
+	}{
+		{
+			target:   "https://example.org/@someone/statuses/01HZJ3A6J0X4W4R8K0FBY1E9MB?foo=bar",
+			expected: "http://gotosocialxyz.onion/@someone/statuses/01HZJ3A6J0X4W4R8K0FBY1E9MB?foo=bar",
+		},
+		{
+			target:   "http://gotosocialxyz.onion/@someone",
+			expected: "",
+		},
+	} {
+		engine := gin.New()
+		engine.Use(middleware.OnionLocation(onionHost))
+		engine.GET("/*path", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+		recorder := httptest.NewRecorder()
+		engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, test.target, nil))
+
+		if location := recorder.Header().Get("Onion-Location"); location != test.expected {
+			t.Errorf("%s: expected Onion-Location %q, got %q", test.target, test.expected, location)
+		}
+	}
+}
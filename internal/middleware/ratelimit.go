@@ -18,8 +18,8 @@
 package middleware
 
 import (
+	"errors"
 	"net"
-	"net/http"
 	"net/netip"
 	"strconv"
 	"time"
@@ -120,10 +120,7 @@ func RateLimit(limit int, exceptions []string) gin.HandlerFunc {
 			c.Error(errWithCode) //nolint:errcheck
 
 			// Bail with 500.
-			c.AbortWithStatusJSON(
-				errWithCode.Code(),
-				gin.H{"error": errWithCode.Safe()},
-			)
+			apiutil.ErrorJSON(c, errWithCode)
 			return
 		}
 
@@ -143,12 +140,9 @@ func RateLimit(limit int, exceptions []string) gin.HandlerFunc {
 		if context.Reached {
 			// Return JSON error message for
 			// consistency with other endpoints.
-			apiutil.Data(c,
-				http.StatusTooManyRequests,
-				apiutil.AppJSON,
-				apiutil.ErrorRateLimited,
-			)
-			c.Abort()
+			const text = "rate limit reached"
+			err := gtserror.SetType(errors.New(text), gtserror.TypeRateLimited)
+			apiutil.ErrorJSON(c, gtserror.NewErrorTooManyRequests(err, text))
 			return
 		}
 
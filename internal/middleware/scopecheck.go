@@ -0,0 +1,73 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/oauth2/v4"
+)
+
+// ScopeCheck returns a new gin middleware which checks whether the
+// oauth token set on the gin context by TokenCheck (so it must run
+// after that) has been granted the scope needed for the request.
+//
+// The needed scope is looked up with the given requiredScope function,
+// from the request method and the path of the matched route (eg.,
+// `/api/v1/statuses/:id`). An empty scope means none is needed.
+//
+// If the token lacks the needed scope, the request is aborted with 403.
+// Requests without a token are always let through, as it's up to the
+// handler to decide whether an unauthorized request is acceptable.
+func ScopeCheck(requiredScope func(method string, path string) oauth.Scope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		i, ok := c.Get(oauth.SessionAuthorizedToken)
+		if !ok {
+			// No token, nothing to check.
+			return
+		}
+
+		ti, ok := i.(oauth2.TokenInfo)
+		if !ok || ti == nil {
+			return
+		}
+
+		required := requiredScope(c.Request.Method, c.FullPath())
+		if required == "" {
+			// No scope needed.
+			return
+		}
+
+		if oauth.GrantedScopes(ti.GetScope()).Permits(required) {
+			return
+		}
+
+		text := fmt.Sprintf("this action requires the %s scope, which was not granted to this token", required)
+		errWithCode := gtserror.NewErrorForbidden(gtserror.New(text), text)
+
+		// Set error on gin context so it'll
+		// be picked up by logging middleware.
+		c.Error(errWithCode) //nolint:errcheck
+
+		apiutil.ErrorJSON(c, errWithCode)
+	}
+}
@@ -44,9 +44,17 @@ func SessionOptions() sessions.Options {
 		samesite = http.SameSiteLaxMode
 	}
 
+	// If also served as an onion service, session
+	// cookies should work for whichever host they
+	// were set by, so leave the domain unset.
+	domain := config.GetHost()
+	if config.GetOnionHost() != "" {
+		domain = ""
+	}
+
 	return sessions.Options{
 		Path:   "/",
-		Domain: config.GetHost(),
+		Domain: domain,
 		// 2 minutes
 		MaxAge: 120,
 		// only set secure over https
@@ -19,10 +19,12 @@ package middleware
 
 import (
 	"context"
-	"net/http"
+	"errors"
 	"net/url"
 
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 
 	"github.com/gin-gonic/gin"
@@ -61,7 +63,9 @@ func SignatureCheck(uriBlocked func(context.Context, *url.URL) (bool, error)) fu
 			// it's up to other functions to reject this.
 			if err.Error() != noSigError {
 				log.Debugf(ctx, "http signature was present but invalid: %s", err)
-				c.AbortWithStatus(http.StatusUnauthorized)
+				const text = "http signature was present but invalid"
+				err := gtserror.SetType(errors.New(text), gtserror.TypeSignatureInvalid)
+				apiutil.ErrorJSON(c, gtserror.NewErrorUnauthorized(err, text))
 			}
 
 			return
@@ -80,7 +84,9 @@ func SignatureCheck(uriBlocked func(context.Context, *url.URL) (bool, error)) fu
 		pubKeyID, err := url.Parse(pubKeyIDStr)
 		if err != nil || pubKeyID == nil {
 			log.Warnf(ctx, "pubkey id %s could not be parsed as a url", pubKeyIDStr)
-			c.AbortWithStatus(http.StatusUnauthorized)
+			const text = "http signature key id could not be parsed as a url"
+			err := gtserror.SetType(errors.New(text), gtserror.TypeSignatureInvalid)
+			apiutil.ErrorJSON(c, gtserror.NewErrorUnauthorized(err, text))
 			return
 		}
 
@@ -89,13 +95,15 @@ func SignatureCheck(uriBlocked func(context.Context, *url.URL) (bool, error)) fu
 		blocked, err := uriBlocked(ctx, pubKeyID)
 		if err != nil {
 			log.Errorf(ctx, "error checking block for domain %s: %s", pubKeyID.Host, err)
-			c.AbortWithStatus(http.StatusInternalServerError)
+			apiutil.ErrorJSON(c, gtserror.NewErrorInternalError(err))
 			return
 		}
 
 		if blocked {
 			log.Infof(ctx, "domain %s is blocked", pubKeyID.Host)
-			c.AbortWithStatus(http.StatusForbidden)
+			const text = "domain is blocked"
+			err := gtserror.SetType(errors.New(text), gtserror.TypeDomainBlocked)
+			apiutil.ErrorJSON(c, gtserror.NewErrorForbidden(err, text))
 			return
 		}
 
@@ -0,0 +1,89 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// TermsCheck returns a new gin middleware which checks whether the
+// user authorized by TokenCheck (so it must run after that) has
+// accepted the current version of the instance terms.
+//
+// If they haven't, the request is aborted with 403 and an error_code
+// of gtserror.TypeTermsNotAccepted, which clients can handle by
+// showing the user the terms to accept. Requests to paths starting
+// with any of the given allowed prefixes are let through regardless,
+// so that clients can still get and accept the terms.
+//
+// Requests without an authorized user are always let through.
+func TermsCheck(dbConn db.DB, allowed ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		i, ok := c.Get(oauth.SessionAuthorizedUser)
+		if !ok {
+			// No user, nothing to check.
+			return
+		}
+
+		user, ok := i.(*gtsmodel.User)
+		if !ok || user == nil {
+			return
+		}
+
+		for _, prefix := range allowed {
+			if strings.HasPrefix(c.Request.URL.Path, prefix) {
+				return
+			}
+		}
+
+		instance, err := dbConn.GetInstance(c.Request.Context(), config.GetHost())
+		if err != nil {
+			errWithCode := gtserror.NewErrorInternalError(
+				gtserror.Newf("db error getting instance: %w", err),
+			)
+
+			// Set error on gin context so it'll
+			// be picked up by logging middleware.
+			c.Error(errWithCode) //nolint:errcheck
+
+			apiutil.ErrorJSON(c, errWithCode)
+			return
+		}
+
+		if instance.TermsVersionID == "" ||
+			instance.TermsVersionID == user.TermsAcceptedVersionID {
+			// No terms, or current
+			// terms already accepted.
+			return
+		}
+
+		const text = "the terms of this instance have changed; " +
+			"you must accept the new terms to continue using your account"
+		err = gtserror.SetType(errors.New(text), gtserror.TypeTermsNotAccepted)
+		apiutil.ErrorJSON(c, gtserror.NewErrorForbidden(err, text))
+	}
+}
@@ -26,7 +26,7 @@
 package middleware
 
 import (
-	"net/http"
+	"errors"
 	"runtime"
 	"strconv"
 	"sync/atomic"
@@ -35,6 +35,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 )
 
 // token represents a request that is being processed.
@@ -106,12 +107,9 @@ func Throttle(cpuMultiplier int, retryAfter time.Duration) gin.HandlerFunc {
 		// count is over queue limit.
 		if n > int64(queueLimit) {
 			c.Header("Retry-After", retryAfterStr)
-			apiutil.Data(c,
-				http.StatusTooManyRequests,
-				apiutil.AppJSON,
-				apiutil.ErrorCapacityExceeded,
-			)
-			c.Abort()
+			const text = "server capacity exceeded"
+			err := gtserror.SetType(errors.New(text), gtserror.TypeCapacityExceeded)
+			apiutil.ErrorJSON(c, gtserror.NewErrorTooManyRequests(err, text))
 			return
 		}
 
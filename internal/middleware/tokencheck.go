@@ -19,6 +19,7 @@ package middleware
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -27,6 +28,11 @@ import (
 	"github.com/superseriousbusiness/oauth2/v4"
 )
 
+// userActiveFreq is the minimum time between updates
+// of a user's last active time, to avoid writing to
+// the database on every single authenticated request.
+const userActiveFreq = 5 * time.Minute
+
 // TokenCheck returns a new gin middleware for validating oauth tokens in requests.
 //
 // The middleware checks the request Authorization header for a valid oauth Bearer token.
@@ -40,7 +46,8 @@ import (
 // gin context for further processing by other functions.
 //
 // Next, it will look up the *gtsmodel.Account for the User. If the Account has been suspended, then the
-// middleware will return early. Otherwise, it will set the Account on the gin context too.
+// middleware will return early. Otherwise, it will set the Account on the gin context too, and update
+// the User's last active time (at most once every few minutes).
 //
 // Finally, it will check the client ID of the token to see if a *gtsmodel.Application can be retrieved
 // for that client ID. This will also be set on the gin context.
@@ -117,6 +124,17 @@ func TokenCheck(dbConn db.DB, validateBearerToken func(r *http.Request) (oauth2.
 			}
 
 			c.Set(oauth.SessionAuthorizedAccount, user.Account)
+
+			if time.Since(user.LastActiveAt) >= userActiveFreq {
+				// Mark the user as recently active; this is
+				// used to decide whether they're away when
+				// something happens that they may want to
+				// hear about by email instead.
+				user.LastActiveAt = time.Now()
+				if err := dbConn.UpdateUser(ctx, user, "last_active_at"); err != nil {
+					log.Errorf(ctx, "database error updating last active time of user %s: %s", userID, err)
+				}
+			}
 		}
 
 		// check for application token
@@ -0,0 +1,228 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oauth
+
+import (
+	"strings"
+)
+
+// Scope represents one OAuth scope that
+// can be granted to an application / token.
+//
+// Scopes are hierarchical: a scope grants
+// every scope nested below it, so `read`
+// grants `read:statuses`, and `admin:read`
+// grants `admin:read:accounts`.
+type Scope string
+
+const (
+	ScopeRead   Scope = "read"
+	ScopeWrite  Scope = "write"
+	ScopeFollow Scope = "follow"
+	ScopePush   Scope = "push"
+	ScopeAdmin  Scope = "admin"
+
+	// ScopeUser is the legacy scope
+	// requested by older versions of
+	// the settings panel, equivalent
+	// to `read write`.
+	ScopeUser Scope = "user"
+
+	ScopeReadAccounts      Scope = "read:accounts"
+	ScopeReadBlocks        Scope = "read:blocks"
+	ScopeReadBookmarks     Scope = "read:bookmarks"
+	ScopeReadFavourites    Scope = "read:favourites"
+	ScopeReadFilters       Scope = "read:filters"
+	ScopeReadFollows       Scope = "read:follows"
+	ScopeReadLists         Scope = "read:lists"
+	ScopeReadMutes         Scope = "read:mutes"
+	ScopeReadNotifications Scope = "read:notifications"
+	ScopeReadReports       Scope = "read:reports"
+	ScopeReadSearch        Scope = "read:search"
+	ScopeReadStatuses      Scope = "read:statuses"
+
+	ScopeWriteAccounts      Scope = "write:accounts"
+	ScopeWriteBlocks        Scope = "write:blocks"
+	ScopeWriteBookmarks     Scope = "write:bookmarks"
+	ScopeWriteConversations Scope = "write:conversations"
+	ScopeWriteFavourites    Scope = "write:favourites"
+	ScopeWriteFilters       Scope = "write:filters"
+	ScopeWriteFollows       Scope = "write:follows"
+	ScopeWriteLists         Scope = "write:lists"
+	ScopeWriteMedia         Scope = "write:media"
+	ScopeWriteMutes         Scope = "write:mutes"
+	ScopeWriteNotifications Scope = "write:notifications"
+	ScopeWriteReports       Scope = "write:reports"
+	ScopeWriteStatuses      Scope = "write:statuses"
+
+	ScopeAdminRead              Scope = "admin:read"
+	ScopeAdminReadAccounts      Scope = "admin:read:accounts"
+	ScopeAdminReadDomainAllows  Scope = "admin:read:domain_allows"
+	ScopeAdminReadDomainBlocks  Scope = "admin:read:domain_blocks"
+	ScopeAdminReadReports       Scope = "admin:read:reports"
+	ScopeAdminWrite             Scope = "admin:write"
+	ScopeAdminWriteAccounts     Scope = "admin:write:accounts"
+	ScopeAdminWriteDomainAllows Scope = "admin:write:domain_allows"
+	ScopeAdminWriteDomainBlocks Scope = "admin:write:domain_blocks"
+	ScopeAdminWriteReports      Scope = "admin:write:reports"
+)
+
+// scopeDescriptions contains a human-readable
+// description of every scope known to GoToSocial,
+// for showing to users when they authorize an app.
+var scopeDescriptions = map[Scope]string{
+	ScopeRead:   "read all your account data",
+	ScopeWrite:  "modify all your account data",
+	ScopeFollow: "see and modify who you follow, block, and mute",
+	ScopePush:   "receive push notifications",
+	ScopeAdmin:  "read and modify all data on this instance, if you're an admin",
+	ScopeUser:   "read and modify all your account data",
+
+	ScopeReadAccounts:      "see your account info and preferences, and other accounts",
+	ScopeReadBlocks:        "see who you block",
+	ScopeReadBookmarks:     "see your bookmarks",
+	ScopeReadFavourites:    "see your favourites",
+	ScopeReadFilters:       "see your filters",
+	ScopeReadFollows:       "see who you follow, and who follows you",
+	ScopeReadLists:         "see your lists",
+	ScopeReadMutes:         "see who you mute",
+	ScopeReadNotifications: "see your notifications",
+	ScopeReadReports:       "see reports you've made",
+	ScopeReadSearch:        "search on your behalf",
+	ScopeReadStatuses:      "see posts, including your timelines",
+
+	ScopeWriteAccounts:      "modify your profile, account settings, and preferences",
+	ScopeWriteBlocks:        "block and unblock accounts",
+	ScopeWriteBookmarks:     "bookmark and unbookmark posts",
+	ScopeWriteConversations: "modify your conversations",
+	ScopeWriteFavourites:    "favourite and unfavourite posts",
+	ScopeWriteFilters:       "create, modify, and delete filters",
+	ScopeWriteFollows:       "follow and unfollow accounts, and handle follow requests",
+	ScopeWriteLists:         "create, modify, and delete lists",
+	ScopeWriteMedia:         "upload media files",
+	ScopeWriteMutes:         "mute and unmute accounts and conversations",
+	ScopeWriteNotifications: "clear your notifications",
+	ScopeWriteReports:       "report other accounts",
+	ScopeWriteStatuses:      "publish, boost, and delete posts, and vote in polls",
+
+	ScopeAdminRead:              "read all data on this instance, if you're an admin",
+	ScopeAdminReadAccounts:      "see sensitive info of all accounts on this instance, if you're an admin",
+	ScopeAdminReadDomainAllows:  "see domain allows of this instance, if you're an admin",
+	ScopeAdminReadDomainBlocks:  "see domain blocks of this instance, if you're an admin",
+	ScopeAdminReadReports:       "see all reports on this instance, if you're an admin",
+	ScopeAdminWrite:             "modify all data on this instance, if you're an admin",
+	ScopeAdminWriteAccounts:     "take moderation actions on accounts, if you're an admin",
+	ScopeAdminWriteDomainAllows: "create and remove domain allows, if you're an admin",
+	ScopeAdminWriteDomainBlocks: "create and remove domain blocks, if you're an admin",
+	ScopeAdminWriteReports:      "resolve reports, if you're an admin",
+}
+
+// Description returns a human-readable description
+// of what the scope allows, or an empty string if
+// the scope isn't known.
+func (s Scope) Description() string {
+	return scopeDescriptions[s]
+}
+
+// Permits returns whether having this scope
+// grants the given scope, ie., whether they're
+// the same, or the given scope is nested below
+// this one. As in the Mastodon API, the legacy
+// `follow` scope grants the read and write
+// scopes for follows, blocks, and mutes. The
+// legacy `user` scope grants `read` and `write`.
+func (s Scope) Permits(scope Scope) bool {
+	if s == scope || strings.HasPrefix(string(scope), string(s)+":") {
+		return true
+	}
+
+	switch s {
+	case ScopeFollow:
+		switch scope {
+		case ScopeReadFollows, ScopeWriteFollows,
+			ScopeReadBlocks, ScopeWriteBlocks,
+			ScopeReadMutes, ScopeWriteMutes:
+			return true
+		}
+
+	case ScopeUser:
+		return ScopeRead.Permits(scope) ||
+			ScopeWrite.Permits(scope)
+	}
+
+	return false
+}
+
+// Scopes is a set of scopes, as
+// granted to an application / token.
+type Scopes []Scope
+
+// ParseScopes parses the given space-separated
+// scopes string, as used in the OAuth flow,
+// returning the scopes known to GoToSocial.
+// Unknown scopes are ignored, as clients may
+// ask for scopes that only other servers know.
+func ParseScopes(str string) Scopes {
+	fields := strings.Fields(str)
+	scopes := make(Scopes, 0, len(fields))
+	for _, field := range fields {
+		scope := Scope(field)
+		if _, ok := scopeDescriptions[scope]; !ok {
+			continue
+		}
+		scopes = append(scopes, scope)
+	}
+	return scopes
+}
+
+// GrantedScopes returns the scopes in the given space-separated
+// scopes string, as stored on applications and tokens. Unlike
+// ParseScopes, it doesn't drop scopes that aren't known, as
+// older applications and tokens may have been registered with
+// scopes that aren't (or are no longer) known: those just don't
+// grant anything.
+func GrantedScopes(str string) Scopes {
+	fields := strings.Fields(str)
+	scopes := make(Scopes, 0, len(fields))
+	for _, field := range fields {
+		scopes = append(scopes, Scope(field))
+	}
+	return scopes
+}
+
+// Permits returns whether any of the
+// scopes in the set grant the given scope.
+func (s Scopes) Permits(scope Scope) bool {
+	for _, granted := range s {
+		if granted.Permits(scope) {
+			return true
+		}
+	}
+	return false
+}
+
+// PermitsAll returns whether all of the given
+// scopes are granted by the scopes in the set.
+func (s Scopes) PermitsAll(scopes Scopes) bool {
+	for _, scope := range scopes {
+		if !s.Permits(scope) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,80 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oauth_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type ScopesTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ScopesTestSuite) TestParseScopes() {
+	scopes := oauth.ParseScopes("read write:statuses  admin:read:accounts")
+	suite.Equal(oauth.Scopes{
+		oauth.ScopeRead,
+		oauth.ScopeWriteStatuses,
+		oauth.ScopeAdminReadAccounts,
+	}, scopes)
+
+	// Unknown scopes should be ignored.
+	scopes = oauth.ParseScopes("read profile user")
+	suite.Equal(oauth.Scopes{
+		oauth.ScopeRead,
+		oauth.ScopeUser,
+	}, scopes)
+}
+
+func (suite *ScopesTestSuite) TestPermits() {
+	for _, test := range []struct {
+		granted  string
+		required oauth.Scope
+		expect   bool
+	}{
+		{"read", oauth.ScopeReadStatuses, true},
+		{"read", oauth.ScopeWriteStatuses, false},
+		{"write:statuses", oauth.ScopeWriteStatuses, true},
+		{"write:statuses", oauth.ScopeWriteMedia, false},
+		{"write:statuses", oauth.ScopeWrite, false},
+		{"admin", oauth.ScopeAdminWriteReports, true},
+		{"admin:read", oauth.ScopeAdminReadAccounts, true},
+		{"admin:read", oauth.ScopeAdminWriteAccounts, false},
+		{"follow", oauth.ScopeWriteBlocks, true},
+		{"follow", oauth.ScopeWriteStatuses, false},
+		{"read write follow push", oauth.ScopeAdminRead, false},
+		{"user admin", oauth.ScopeReadAccounts, true},
+		{"user admin", oauth.ScopeWriteStatuses, true},
+		{"user admin", oauth.ScopeAdminReadReports, true},
+		{"user", oauth.ScopeWriteMedia, true},
+		{"user", oauth.ScopeAdminRead, false},
+	} {
+		suite.Equal(
+			test.expect,
+			oauth.GrantedScopes(test.granted).Permits(test.required),
+			"%q permits %s", test.granted, test.required,
+		)
+	}
+}
+
+func TestScopesTestSuite(t *testing.T) {
+	suite.Run(t, &ScopesTestSuite{})
+}
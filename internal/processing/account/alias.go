@@ -25,11 +25,65 @@ import (
 	"slices"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/federation/dereferencing"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
+// Aliases returns the alsoKnownAs aliases of the given
+// account, along with whether each has been verified, ie.,
+// whether the aliased account lists this one in return.
+// Aliased accounts are refreshed first, so that changes
+// on their end are picked up.
+func (p *Processor) Aliases(
+	ctx context.Context,
+	account *gtsmodel.Account,
+) ([]apimodel.AccountAlias, gtserror.WithCode) {
+	akas := make([]*gtsmodel.Account, 0, len(account.AlsoKnownAsURIs))
+	for _, akaURIStr := range account.AlsoKnownAsURIs {
+		akaURI, err := url.Parse(akaURIStr)
+		if err != nil {
+			log.Warnf(ctx, "invalid alias %s: %v", akaURIStr, err)
+			continue
+		}
+
+		// Ensure we have a valid representation of the aliased account.
+		aka, akaable, err := p.federator.GetAccountByURI(ctx,
+			account.Username,
+			akaURI,
+		)
+		if err != nil {
+			// Leave it out so it's shown as unverified.
+			log.Warnf(ctx, "error dereferencing alias %s: %v", akaURIStr, err)
+			continue
+		}
+
+		if aka.IsRemote() {
+			// Refresh aliased account to ensure
+			// we see any recent alsoKnownAs changes.
+			refreshed, _, err := p.federator.RefreshAccount(ctx,
+				account.Username,
+				aka,
+				akaable,
+				dereferencing.Fresh,
+			)
+			if err != nil {
+				log.Warnf(ctx, "error refreshing alias %s: %v", akaURIStr, err)
+			} else {
+				aka = refreshed
+			}
+		}
+
+		akas = append(akas, aka)
+	}
+
+	account.AlsoKnownAs = akas
+	return p.converter.AccountToAPIAliases(account), nil
+}
+
 func (p *Processor) Alias(
 	ctx context.Context,
 	account *gtsmodel.Account,
@@ -62,71 +116,29 @@ func (p *Processor) Alias(
 
 	// We need to set new AKA URIs!
 	//
-	// First parse them to URI ptrs and
-	// normalized string representations.
-	//
-	// Use this cheeky type to avoid
-	// repeatedly calling uri.String().
-	type uri struct {
-		uri *url.URL // Parsed URI.
-		str string   // uri.String().
-	}
-
-	newAKAs := make([]uri, newLen)
+	// First parse them all, so that
+	// we bail early on any bad ones.
+	newAKAURIs := make([]*url.URL, newLen)
 	for i, newAKAURIStr := range newAKAURIStrs {
-		newAKAURI, err := url.Parse(newAKAURIStr)
-		if err != nil {
-			err := fmt.Errorf(
-				"invalid also_known_as_uri (%s) provided in account alias request: %w",
-				newAKAURIStr, err,
-			)
-			return nil, gtserror.NewErrorBadRequest(err, err.Error())
-		}
-
-		// We only deref http or https, so check this.
-		if newAKAURI.Scheme != "https" && newAKAURI.Scheme != "http" {
-			err := fmt.Errorf(
-				"invalid also_known_as_uri (%s) provided in account alias request: %w",
-				newAKAURIStr, errors.New("uri must not be empty and scheme must be http or https"),
-			)
-			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		newAKAURI, errWithCode := parseAKAURI(newAKAURIStr)
+		if errWithCode != nil {
+			return nil, errWithCode
 		}
 
-		newAKAs[i].uri = newAKAURI
-		newAKAs[i].str = newAKAURI.String()
+		newAKAURIs[i] = newAKAURI
 	}
 
-	// For each deduped entry, get and
-	// check the target account, and set.
-	for _, newAKA := range newAKAs {
-		// Don't let account do anything
-		// daft by aliasing to itself.
-		if newAKA.str == account.URI ||
-			newAKA.str == account.URL {
-			continue
+	// For each entry, get and check
+	// the target account, and set.
+	for _, newAKAURI := range newAKAURIs {
+		targetAccount, errWithCode := p.aliasTarget(ctx, account, newAKAURI)
+		if errWithCode != nil {
+			return nil, errWithCode
 		}
 
-		// Ensure we have account dereferenced.
-		targetAccount, _, err := p.federator.GetAccountByURI(ctx,
-			account.Username,
-			newAKA.uri,
-		)
-		if err != nil {
-			err := fmt.Errorf(
-				"error dereferencing also_known_as_uri (%s) account: %w",
-				newAKA.str, err,
-			)
-			return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
-		}
-
-		// Target must not be suspended.
-		if !targetAccount.SuspendedAt.IsZero() {
-			err := fmt.Errorf(
-				"target account %s is suspended from this instance; "+
-					"you will not be able to set alsoKnownAs to that account",
-				newAKA.str,
-			)
-			return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		if targetAccount == nil {
+			// Alias to self.
+			continue
 		}
 
 		// Alrighty-roo, looks good, add this one.
@@ -153,3 +165,168 @@ func (p *Processor) Alias(
 
 	return p.c.GetAPIAccountSensitive(ctx, account)
 }
+
+// AliasAdd adds the account at the given URI
+// to the alsoKnownAs aliases of the account,
+// leaving any existing aliases in place.
+func (p *Processor) AliasAdd(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	akaURIStr string,
+) (*apimodel.Account, gtserror.WithCode) {
+	akaURI, errWithCode := parseAKAURI(akaURIStr)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	targetAccount, errWithCode := p.aliasTarget(ctx, account, akaURI)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if targetAccount == nil {
+		err := errors.New("cannot alias account to itself")
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	if account.IsAliasedTo(targetAccount.URI) {
+		// Already aliased,
+		// nothing to do.
+		return p.c.GetAPIAccountSensitive(ctx, account)
+	}
+
+	account.AlsoKnownAsURIs = append(account.AlsoKnownAsURIs, targetAccount.URI)
+	account.AlsoKnownAs = nil
+
+	if err := p.state.DB.UpdateAccount(ctx, account, "also_known_as_uris"); err != nil {
+		err := gtserror.Newf("db error updating also_known_as_uri: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.c.GetAPIAccountSensitive(ctx, account)
+}
+
+// AliasRemove removes the account at the given
+// URI from the alsoKnownAs aliases of the account.
+// The URI may also be the URL of the aliased account.
+func (p *Processor) AliasRemove(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	akaURIStr string,
+) (*apimodel.Account, gtserror.WithCode) {
+	akaURI, errWithCode := parseAKAURI(akaURIStr)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+	akaURIStr = uris.Normalize(akaURI.String())
+
+	// Check against aliased account URLs too,
+	// since that's what's shown to the user.
+	if err := p.state.DB.PopulateAccount(ctx, account); err != nil {
+		log.Warnf(ctx, "error(s) populating account: %v", err)
+	}
+
+	aliasURIs := slices.DeleteFunc(
+		slices.Clone(account.AlsoKnownAsURIs),
+		func(aliasURI string) bool {
+			if uris.Normalize(aliasURI) == akaURIStr {
+				return true
+			}
+
+			for _, aka := range account.AlsoKnownAs {
+				if aka.URI == aliasURI && uris.Normalize(aka.URL) == akaURIStr {
+					return true
+				}
+			}
+
+			return false
+		},
+	)
+
+	if len(aliasURIs) == len(account.AlsoKnownAsURIs) {
+		err := fmt.Errorf("account is not aliased to %s", akaURIStr)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	if len(aliasURIs) == 0 {
+		aliasURIs = nil
+	}
+
+	account.AlsoKnownAsURIs = aliasURIs
+	account.AlsoKnownAs = nil
+
+	if err := p.state.DB.UpdateAccount(ctx, account, "also_known_as_uris"); err != nil {
+		err := gtserror.Newf("db error updating also_known_as_uri: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.c.GetAPIAccountSensitive(ctx, account)
+}
+
+// parseAKAURI parses the given alsoKnownAs URI
+// string, checking that it's dereferenceable.
+func parseAKAURI(akaURIStr string) (*url.URL, gtserror.WithCode) {
+	akaURI, err := url.Parse(akaURIStr)
+	if err != nil {
+		err := fmt.Errorf(
+			"invalid also_known_as_uri (%s) provided in account alias request: %w",
+			akaURIStr, err,
+		)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// We only deref http or https, so check this.
+	if akaURI.Scheme != "https" && akaURI.Scheme != "http" {
+		err := fmt.Errorf(
+			"invalid also_known_as_uri (%s) provided in account alias request: %w",
+			akaURIStr, errors.New("uri must not be empty and scheme must be http or https"),
+		)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	return akaURI, nil
+}
+
+// aliasTarget dereferences and checks the account at
+// the given alsoKnownAs URI, returning it if the given
+// account may be aliased to it. If the URI points to
+// the given account itself, nil is returned.
+func (p *Processor) aliasTarget(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	akaURI *url.URL,
+) (*gtsmodel.Account, gtserror.WithCode) {
+	akaURIStr := akaURI.String()
+
+	// Don't let account do anything
+	// daft by aliasing to itself.
+	if akaURIStr == account.URI ||
+		akaURIStr == account.URL {
+		return nil, nil
+	}
+
+	// Ensure we have account dereferenced.
+	targetAccount, _, err := p.federator.GetAccountByURI(ctx,
+		account.Username,
+		akaURI,
+	)
+	if err != nil {
+		err := fmt.Errorf(
+			"error dereferencing also_known_as_uri (%s) account: %w",
+			akaURIStr, err,
+		)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	// Target must not be suspended.
+	if !targetAccount.SuspendedAt.IsZero() {
+		err := fmt.Errorf(
+			"target account %s is suspended from this instance; "+
+				"you will not be able to set alsoKnownAs to that account",
+			akaURIStr,
+		)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	return targetAccount, nil
+}
@@ -23,6 +23,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
@@ -167,6 +168,179 @@ func (suite *AliasTestSuite) TestAliasAccount() {
 	}
 }
 
+func (suite *AliasTestSuite) TestAliasAddRemove() {
+	var (
+		ctx      = context.Background()
+		testAcct = new(gtsmodel.Account)
+		turtle   = suite.testAccounts["local_account_2"]
+		admin    = suite.testAccounts["admin_account"]
+	)
+
+	// Copy zork test account.
+	*testAcct = *suite.testAccounts["local_account_1"]
+
+	for _, test := range []struct {
+		add             string
+		remove          string
+		expectedAliases []string
+		expectedErr     string
+	}{
+		// Add turtle.
+		{
+			add:             turtle.URI,
+			expectedAliases: []string{turtle.URI},
+		},
+		// Add turtle again (noop).
+		{
+			add:             turtle.URI,
+			expectedAliases: []string{turtle.URI},
+		},
+		// Add admin, turtle stays.
+		{
+			add:             admin.URI,
+			expectedAliases: []string{turtle.URI, admin.URI},
+		},
+		// Add self.
+		{
+			add:         testAcct.URI,
+			expectedErr: "cannot alias account to itself",
+		},
+		// Add bad alias.
+		{
+			add:         "oh no",
+			expectedErr: "invalid also_known_as_uri (oh no) provided in account alias request: uri must not be empty and scheme must be http or https",
+		},
+		// Remove turtle by URL.
+		{
+			remove:          turtle.URL,
+			expectedAliases: []string{admin.URI},
+		},
+		// Remove turtle again.
+		{
+			remove:      turtle.URI,
+			expectedErr: "account is not aliased to " + turtle.URI,
+		},
+		// Remove admin.
+		{
+			remove:          admin.URI,
+			expectedAliases: []string{},
+		},
+	} {
+		var (
+			apiAcct *apimodel.Account
+			err     error
+		)
+
+		if test.add != "" {
+			apiAcct, err = suite.accountProcessor.AliasAdd(ctx, testAcct, test.add)
+		} else {
+			apiAcct, err = suite.accountProcessor.AliasRemove(ctx, testAcct, test.remove)
+		}
+
+		if err != nil {
+			if err.Error() != test.expectedErr {
+				suite.FailNow("", "unexpected error: %s", err)
+			} else {
+				continue
+			}
+		}
+
+		if !slices.Equal(apiAcct.Source.AlsoKnownAsURIs, test.expectedAliases) {
+			suite.FailNow("", "unexpected aliases: %+v", apiAcct.Source.AlsoKnownAsURIs)
+		}
+	}
+}
+
+func (suite *AliasTestSuite) TestAliasShownWhenAliasedBack() {
+	var (
+		ctx      = context.Background()
+		testAcct = new(gtsmodel.Account)
+		turtle   = new(gtsmodel.Account)
+	)
+
+	// Copy zork and turtle test accounts.
+	*testAcct = *suite.testAccounts["local_account_1"]
+	*turtle = *suite.testAccounts["local_account_2"]
+
+	// Alias zork to turtle; turtle
+	// doesn't alias back so it's
+	// not shown as also known as.
+	apiAcct, errWithCode := suite.accountProcessor.AliasAdd(ctx, testAcct, turtle.URI)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(apiAcct.AlsoKnownAs)
+
+	// Alias turtle back to zork.
+	turtle.AlsoKnownAsURIs = []string{testAcct.URI}
+	if err := suite.state.DB.UpdateAccount(ctx, turtle, "also_known_as_uris"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Now zork should show turtle.
+	dbAcct, err := suite.state.DB.GetAccountByID(ctx, testAcct.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	apiAcct, err = suite.tc.AccountToAPIAccountPublic(ctx, dbAcct)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if suite.Len(apiAcct.AlsoKnownAs, 1) {
+		suite.Equal(turtle.ID, apiAcct.AlsoKnownAs[0].ID)
+		suite.Empty(apiAcct.AlsoKnownAs[0].AlsoKnownAs)
+	}
+}
+
+func (suite *AliasTestSuite) TestAliasesVerification() {
+	var (
+		ctx      = context.Background()
+		testAcct = new(gtsmodel.Account)
+		turtle   = new(gtsmodel.Account)
+		admin    = suite.testAccounts["admin_account"]
+	)
+
+	// Copy zork and turtle test accounts.
+	*testAcct = *suite.testAccounts["local_account_1"]
+	*turtle = *suite.testAccounts["local_account_2"]
+
+	// Alias zork to turtle and admin.
+	if _, errWithCode := suite.accountProcessor.Alias(ctx, testAcct, []string{turtle.URI, admin.URI}); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Alias turtle back to zork.
+	turtle.AlsoKnownAsURIs = []string{testAcct.URI}
+	if err := suite.state.DB.UpdateAccount(ctx, turtle, "also_known_as_uris"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	aliases, errWithCode := suite.accountProcessor.Aliases(ctx, testAcct)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Only turtle aliases
+	// back, so only turtle
+	// should be verified.
+	suite.Equal([]apimodel.AccountAlias{
+		{
+			URI:      turtle.URI,
+			URL:      turtle.URL,
+			Acct:     turtle.Username,
+			Verified: true,
+		},
+		{
+			URI:      admin.URI,
+			URL:      admin.URL,
+			Acct:     admin.Username,
+			Verified: false,
+		},
+	}, aliases)
+}
+
 func TestAliasTestSuite(t *testing.T) {
 	suite.Run(t, new(AliasTestSuite))
 }
@@ -21,10 +21,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
@@ -35,15 +37,53 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
+// maxBlockDuration is the maximum duration
+// after which a block can be set to expire.
+const maxBlockDuration = 10 * 365 * 24 * time.Hour
+
 // BlockCreate handles the creation of a block from requestingAccount to targetAccountID, either remote or local.
-func (p *Processor) BlockCreate(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode) {
+//
+// If a duration is given in the form, the block will be automatically undone once it elapses.
+// If the block exists already, then its expiry will be updated instead if a duration is given.
+func (p *Processor) BlockCreate(ctx context.Context, requestingAccount *gtsmodel.Account, form *apimodel.AccountBlockRequest) (*apimodel.Relationship, gtserror.WithCode) {
+	targetAccountID := form.ID
 	targetAccount, existingBlock, errWithCode := p.getBlockTarget(ctx, requestingAccount, targetAccountID)
 	if errWithCode != nil {
 		return nil, errWithCode
 	}
 
+	// Work out when the block should expire, if ever.
+	var expiresAt time.Time
+	if form.Duration != nil {
+		duration := time.Duration(*form.Duration) * time.Second
+		if *form.Duration < 0 || duration > maxBlockDuration {
+			err := fmt.Errorf("duration must be between 0 and %d seconds", int(maxBlockDuration.Seconds()))
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		if duration > 0 {
+			expiresAt = time.Now().Add(duration)
+		}
+	}
+
 	if existingBlock != nil {
-		// Block already exists, nothing to do.
+		if form.Duration == nil ||
+			existingBlock.ExpiresAt.Equal(expiresAt) {
+			// Block already exists, nothing to do.
+			return p.RelationshipGet(ctx, requestingAccount, targetAccountID)
+		}
+
+		// Update expiry of the existing block.
+		existingBlock.ExpiresAt = expiresAt
+		if err := p.state.DB.UpdateBlock(ctx, existingBlock, "expires_at"); err != nil {
+			err = fmt.Errorf("BlockCreate: error updating block in db: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		// Replace any previously scheduled expiry.
+		p.unscheduleBlockExpiry(existingBlock.ID)
+		p.scheduleBlockExpiry(ctx, existingBlock)
+
 		return p.RelationshipGet(ctx, requestingAccount, targetAccountID)
 	}
 
@@ -57,6 +97,7 @@ func (p *Processor) BlockCreate(ctx context.Context, requestingAccount *gtsmodel
 		Account:         requestingAccount,
 		TargetAccountID: targetAccountID,
 		TargetAccount:   targetAccount,
+		ExpiresAt:       expiresAt,
 	}
 
 	if err := p.state.DB.PutBlock(ctx, block); err != nil {
@@ -64,6 +105,9 @@ func (p *Processor) BlockCreate(ctx context.Context, requestingAccount *gtsmodel
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	// Schedule undoing the block, if it expires.
+	p.scheduleBlockExpiry(ctx, block)
+
 	// Ensure each account unfollows the other.
 	// We only care about processing unfollow side
 	// effects from requesting account -> target
@@ -115,6 +159,9 @@ func (p *Processor) BlockRemove(ctx context.Context, requestingAccount *gtsmodel
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	// Drop any scheduled expiry.
+	p.unscheduleBlockExpiry(existingBlock.ID)
+
 	// Populate account fields for convenience.
 	existingBlock.Account = requestingAccount
 	existingBlock.TargetAccount = targetAccount
@@ -178,6 +225,81 @@ func (p *Processor) BlocksGet(
 	}), nil
 }
 
+// ScheduleBlockExpiries schedules undoing
+// all blocks that have an expiry set.
+func (p *Processor) ScheduleBlockExpiries(ctx context.Context) error {
+	// Fetch all expiring blocks from the database (barebones models are enough).
+	blocks, err := p.state.DB.GetExpiringBlocks(gtscontext.SetBarebones(ctx))
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting expiring blocks from db: %w", err)
+	}
+
+	for _, block := range blocks {
+		p.scheduleBlockExpiry(ctx, block)
+	}
+
+	return nil
+}
+
+func (p *Processor) scheduleBlockExpiry(ctx context.Context, block *gtsmodel.Block) {
+	if block.ExpiresAt.IsZero() {
+		// Nothing to schedule.
+		return
+	}
+
+	if !p.state.Workers.Scheduler.AddOnce(
+		blockExpiryID(block.ID),
+		block.ExpiresAt,
+		p.onBlockExpiry(block.ID),
+	) {
+		log.Warnf(ctx, "failed adding block %s expiry to scheduler", block.ID)
+		return
+	}
+
+	atStr := block.ExpiresAt.Local().Format("Jan _2 2006 15:04:05")
+	log.Infof(ctx, "scheduled block expiry for %s at '%s'", block.ID, atStr)
+}
+
+func (p *Processor) unscheduleBlockExpiry(blockID string) {
+	_ = p.state.Workers.Scheduler.Cancel(blockExpiryID(blockID))
+}
+
+// onBlockExpiry returns a callback function to be
+// used by the scheduler when the given block expires.
+func (p *Processor) onBlockExpiry(blockID string) func(context.Context, time.Time) {
+	return func(ctx context.Context, now time.Time) {
+		// Get the latest version of block from database.
+		block, err := p.state.DB.GetBlockByID(ctx, blockID)
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				log.Errorf(ctx, "error getting block %s from db: %v", blockID, err)
+			}
+
+			// Block was removed in
+			// the meantime, all good.
+			return
+		}
+
+		if block.ExpiresAt.IsZero() || block.ExpiresAt.After(now) {
+			// Expiry was changed in
+			// the meantime, all good.
+			return
+		}
+
+		// Remove the block as if the blocker had done it
+		// themselves, so that the Undo gets federated out.
+		if _, errWithCode := p.BlockRemove(ctx, block.Account, block.TargetAccountID); errWithCode != nil {
+			log.Errorf(ctx, "error removing expired block %s: %v", blockID, errWithCode)
+		}
+	}
+}
+
+// blockExpiryID returns the scheduler
+// task ID for the given block's expiry.
+func blockExpiryID(blockID string) string {
+	return "block-expiry-" + blockID
+}
+
 func (p *Processor) getBlockTarget(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*gtsmodel.Account, *gtsmodel.Block, gtserror.WithCode) {
 	// Account should not block or unblock itself.
 	if requestingAccount.ID == targetAccountID {
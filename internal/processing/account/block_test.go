@@ -0,0 +1,112 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type BlockTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *BlockTestSuite) TestBlockWithDuration() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["local_account_1"]
+	targetAccount := suite.testAccounts["remote_account_1"]
+
+	// Negative duration should be rejected.
+	_, errWithCode := suite.accountProcessor.BlockCreate(
+		ctx,
+		requestingAccount,
+		&apimodel.AccountBlockRequest{
+			ID:       targetAccount.ID,
+			Duration: util.Ptr(-1),
+		})
+	suite.EqualError(errWithCode, "duration must be between 0 and 315360000 seconds")
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	// Block with an expiry an hour from now.
+	relationship, errWithCode := suite.accountProcessor.BlockCreate(
+		ctx,
+		requestingAccount,
+		&apimodel.AccountBlockRequest{
+			ID:       targetAccount.ID,
+			Duration: util.Ptr(3600),
+		})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.True(relationship.Blocking)
+	if suite.NotNil(relationship.BlockingExpiresIn) {
+		suite.InDelta(3600, *relationship.BlockingExpiresIn, 5)
+	}
+
+	// Blocking again with 0 should
+	// make the block indefinite.
+	relationship, errWithCode = suite.accountProcessor.BlockCreate(
+		ctx,
+		requestingAccount,
+		&apimodel.AccountBlockRequest{
+			ID:       targetAccount.ID,
+			Duration: util.Ptr(0),
+		})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.True(relationship.Blocking)
+	suite.Nil(relationship.BlockingExpiresIn)
+
+	block, err := suite.state.DB.GetBlock(ctx, requestingAccount.ID, targetAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Zero(block.ExpiresAt)
+
+	// Update the block to expire a second from now.
+	if _, errWithCode := suite.accountProcessor.BlockCreate(
+		ctx,
+		requestingAccount,
+		&apimodel.AccountBlockRequest{
+			ID:       targetAccount.ID,
+			Duration: util.Ptr(1),
+		}); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Block should be removed by the scheduler.
+	if !testrig.WaitFor(func() bool {
+		_, err := suite.state.DB.GetBlock(ctx, requestingAccount.ID, targetAccount.ID)
+		return errors.Is(err, db.ErrNoEntries)
+	}) {
+		suite.FailNow("timed out waiting for block to expire")
+	}
+}
+
+func TestBlockTestSuite(t *testing.T) {
+	suite.Run(t, new(BlockTestSuite))
+}
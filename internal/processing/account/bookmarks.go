@@ -27,44 +27,34 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
-	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
 // BookmarksGet returns a pageable response of statuses that are bookmarked by requestingAccount.
 // Paging for this response is done based on bookmark ID rather than status ID.
-func (p *Processor) BookmarksGet(ctx context.Context, requestingAccount *gtsmodel.Account, limit int, maxID string, minID string) (*apimodel.PageableResponse, gtserror.WithCode) {
-	bookmarks, err := p.state.DB.GetStatusBookmarks(ctx, requestingAccount.ID, limit, maxID, minID)
+func (p *Processor) BookmarksGet(ctx context.Context, requestingAccount *gtsmodel.Account, page *paging.Page) (*apimodel.PageableResponse, gtserror.WithCode) {
+	bookmarks, err := p.state.DB.GetStatusBookmarks(ctx, requestingAccount.ID, page)
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
 	count := len(bookmarks)
 	if count == 0 {
-		return util.EmptyPageableResponse(), nil
+		return paging.EmptyResponse(), nil
 	}
 
 	var (
 		items = make([]interface{}, 0, count)
 
-		// Set next + prev values before filtering and API
+		// Set lo + hi values before filtering and API
 		// converting, so caller can still page properly.
 		// Page based on bookmark ID, not status ID.
-		nextMaxIDValue = bookmarks[count-1].ID
-		prevMinIDValue = bookmarks[0].ID
+		lo = bookmarks[count-1].ID
+		hi = bookmarks[0].ID
 	)
 
 	for _, bookmark := range bookmarks {
-		status, err := p.state.DB.GetStatusByID(ctx, bookmark.StatusID)
-		if err != nil {
-			if errors.Is(err, db.ErrNoEntries) {
-				// We just don't have the status for some reason.
-				// Skip this one.
-				continue
-			}
-			return nil, gtserror.NewErrorInternalError(err) // A real error has occurred.
-		}
-
-		visible, err := p.filter.StatusVisible(ctx, requestingAccount, status)
+		visible, err := p.filter.StatusVisible(ctx, requestingAccount, bookmark.Status)
 		if err != nil {
 			log.Errorf(ctx, "error checking bookmarked status visibility: %s", err)
 			continue
@@ -75,7 +65,7 @@ func (p *Processor) BookmarksGet(ctx context.Context, requestingAccount *gtsmode
 		}
 
 		// Convert the status.
-		item, err := p.converter.StatusToAPIStatus(ctx, status, requestingAccount, statusfilter.FilterContextNone, nil)
+		item, err := p.converter.StatusToAPIStatus(ctx, bookmark.Status, requestingAccount, statusfilter.FilterContextNone, nil)
 		if err != nil {
 			log.Errorf(ctx, "error converting bookmarked status to api: %s", err)
 			continue
@@ -83,11 +73,10 @@ func (p *Processor) BookmarksGet(ctx context.Context, requestingAccount *gtsmode
 		items = append(items, item)
 	}
 
-	return util.PackagePageableResponse(util.PageableResponseParams{
-		Items:          items,
-		Path:           "/api/v1/bookmarks",
-		NextMaxIDValue: nextMaxIDValue,
-		PrevMinIDValue: prevMinIDValue,
-		Limit:          limit,
-	})
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/bookmarks",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
 }
@@ -20,6 +20,7 @@ package account
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
@@ -27,6 +28,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/oauth2/v4"
@@ -101,6 +103,21 @@ func (p *Processor) Create(
 		reason = form.Reason
 	}
 
+	// Ensure each current instance rule
+	// has been explicitly acknowledged.
+	rules, err := p.state.DB.GetActiveRules(ctx)
+	if err != nil {
+		err := fmt.Errorf("db error getting instance rules: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	for _, rule := range rules {
+		if !slices.Contains(form.Rules, rule.ID) {
+			err := fmt.Errorf("instance rule %s was not acknowledged; you must acknowledge each instance rule to sign up", rule.ID)
+			return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
+	}
+
 	// Use instance app if no app provided.
 	if app == nil {
 		app, err = p.state.DB.GetInstanceApplication(ctx)
@@ -110,6 +127,14 @@ func (p *Processor) Create(
 		}
 	}
 
+	// The form was validated, so the user agreed
+	// to the current version of the instance terms.
+	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		err := fmt.Errorf("db error getting instance: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
 	user, err := p.state.DB.NewSignup(ctx, gtsmodel.NewSignup{
 		Username: form.Username,
 		Email:    form.Email,
@@ -118,12 +143,36 @@ func (p *Processor) Create(
 		SignUpIP: form.IP,
 		Locale:   form.Locale,
 		AppID:    app.ID,
+
+		// Form was validated, so if the instance
+		// has a minimum age, it's been confirmed.
+		AgeConfirmed: config.GetAccountsMinimumAge(),
+
+		TermsVersionID: instance.TermsVersionID,
 	})
 	if err != nil {
 		err := fmt.Errorf("db error creating new signup: %w", err)
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	// Store which version of each
+	// rule the user acknowledged.
+	acks := make([]*gtsmodel.RuleAcknowledgement, len(rules))
+	for i, rule := range rules {
+		acks[i] = &gtsmodel.RuleAcknowledgement{
+			ID:            id.NewULID(),
+			UserID:        user.ID,
+			RuleID:        rule.ID,
+			RuleUpdatedAt: rule.UpdatedAt,
+			RuleText:      rule.Text,
+		}
+	}
+
+	if err := p.state.DB.PutRuleAcknowledgements(ctx, acks); err != nil {
+		err := fmt.Errorf("db error storing rule acknowledgements: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
 	// There are side effects for creating a new account
 	// (confirmation emails etc), perform these async.
 	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
@@ -0,0 +1,113 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type CreateTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *CreateTestSuite) form(rules ...string) *apimodel.AccountCreateRequest {
+	return &apimodel.AccountCreateRequest{
+		Username:  "new_user",
+		Email:     "new_user@example.org",
+		Password:  "a very long password indeed",
+		Agreement: true,
+		Locale:    "en",
+		Rules:     rules,
+		IP:        net.ParseIP("192.0.2.1"),
+	}
+}
+
+func (suite *CreateTestSuite) TestCreateMissingRule() {
+	var (
+		ctx   = context.Background()
+		rules = testrig.NewTestRules()
+	)
+
+	// Only acknowledge one of the two active rules.
+	_, errWithCode := suite.accountProcessor.Create(ctx, nil, suite.form(rules["rule1"].ID))
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+	suite.Equal(
+		"Unprocessable Entity: instance rule "+rules["rule2"].ID+" was not acknowledged; you must acknowledge each instance rule to sign up",
+		errWithCode.Safe(),
+	)
+}
+
+func (suite *CreateTestSuite) TestCreateAcknowledgeRules() {
+	var (
+		ctx   = context.Background()
+		rules = testrig.NewTestRules()
+	)
+
+	user, errWithCode := suite.accountProcessor.Create(ctx, nil, suite.form(
+		rules["rule1"].ID,
+		rules["rule2"].ID,
+	))
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Both rules should be shown as
+	// acknowledged in the admin view.
+	adminAcct, err := suite.tc.AccountToAdminAPIAccount(ctx, user.Account)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if !suite.Len(adminAcct.AcknowledgedRules, 2) {
+		suite.FailNow("")
+	}
+	suite.Equal(rules["rule1"].ID, adminAcct.AcknowledgedRules[0].RuleID)
+	suite.Equal("Be gay", adminAcct.AcknowledgedRules[0].Text)
+	suite.Equal("2022-05-14T10:20:03.000Z", adminAcct.AcknowledgedRules[0].RuleUpdatedAt)
+	suite.True(adminAcct.AcknowledgedRules[0].Current)
+	suite.Equal(rules["rule2"].ID, adminAcct.AcknowledgedRules[1].RuleID)
+	suite.True(adminAcct.AcknowledgedRules[1].Current)
+
+	// Change the first rule; the acknowledged
+	// version should no longer be current.
+	rule1 := rules["rule1"]
+	rule1.Text = "Be very gay"
+	if _, err := suite.state.DB.UpdateRule(ctx, rule1); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	adminAcct, err = suite.tc.AccountToAdminAPIAccount(ctx, user.Account)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal("Be gay", adminAcct.AcknowledgedRules[0].Text)
+	suite.False(adminAcct.AcknowledgedRules[0].Current)
+	suite.True(adminAcct.AcknowledgedRules[1].Current)
+}
+
+func TestCreateTestSuite(t *testing.T) {
+	suite.Run(t, new(CreateTestSuite))
+}
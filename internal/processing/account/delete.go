@@ -32,6 +32,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -65,6 +66,10 @@ func (p *Processor) Delete(
 		l.Errorf("continuing after error during account delete: %v", err)
 	}
 
+	if err := p.deleteAccountMutes(ctx, account); err != nil {
+		l.Errorf("continuing after error during account delete: %v", err)
+	}
+
 	if err := p.deleteAccountNotifications(ctx, account); err != nil {
 		l.Errorf("continuing after error during account delete: %v", err)
 	}
@@ -146,6 +151,16 @@ func (p *Processor) deleteUserAndTokensForAccount(ctx context.Context, account *
 		}
 	}
 
+	// Delete any Web Push subscriptions of the tokens.
+	if err := p.state.DB.DeleteWebPushSubscriptionsByAccountID(ctx, account.ID); err != nil {
+		return gtserror.Newf("db error deleting web push subscriptions: %w", err)
+	}
+
+	// Delete any rules the user acknowledged.
+	if err := p.state.DB.DeleteRuleAcknowledgementsByUserID(ctx, user.ID); err != nil {
+		return gtserror.Newf("db error deleting rule acknowledgements: %w", err)
+	}
+
 	columns, err := stubbifyUser(user)
 	if err != nil {
 		return gtserror.Newf("error stubbifying user: %w", err)
@@ -317,6 +332,13 @@ func (p *Processor) deleteAccountBlocks(ctx context.Context, account *gtsmodel.A
 	return nil
 }
 
+func (p *Processor) deleteAccountMutes(ctx context.Context, account *gtsmodel.Account) error {
+	if err := p.state.DB.DeleteAccountMutes(ctx, account.ID); err != nil {
+		return gtserror.Newf("db error deleting account mutes for %s: %w", account.ID, err)
+	}
+	return nil
+}
+
 // deleteAccountStatuses iterates through all statuses owned by
 // the given account, passing each discovered status (and boosts
 // thereof) to the processor workers for further processing.
@@ -447,6 +469,21 @@ func (p *Processor) deleteAccountNotifications(ctx context.Context, account *gts
 		return gtserror.Newf("error deleting notifications by account: %w", err)
 	}
 
+	// Delete all notification requests of, or from, given account.
+	if err := p.state.DB.DeleteNotificationRequestsByAccountID(ctx, account.ID); err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting notification requests: %w", err)
+	}
+
+	// Delete all interaction requests targeting, or by, given account.
+	if err := p.state.DB.DeleteInteractionRequestsByAccountID(ctx, account.ID); err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting interaction requests: %w", err)
+	}
+
+	// Delete all follower events of, or by, given account.
+	if err := p.state.DB.DeleteFollowerEventsByAccountID(ctx, account.ID); err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting follower events: %w", err)
+	}
+
 	return nil
 }
 
@@ -475,6 +512,18 @@ func (p *Processor) deleteAccountPeripheral(ctx context.Context, account *gtsmod
 		return gtserror.Newf("error deleting faves targeting account: %w", err)
 	}
 
+	// Delete all emoji reactions owned by given account.
+	if err := p.state.DB.DeleteStatusReactions(ctx, "", account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting reactions by account: %w", err)
+	}
+
+	// Delete all emoji reactions targeting given account.
+	if err := p.state.DB.DeleteStatusReactions(ctx, account.ID, ""); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting reactions targeting account: %w", err)
+	}
+
 	// TODO: add status mutes here when they're implemented.
 
 	// Delete all poll votes owned by given account.
@@ -483,6 +532,47 @@ func (p *Processor) deleteAccountPeripheral(ctx context.Context, account *gtsmod
 		return gtserror.Newf("error deleting poll votes by account: %w", err)
 	}
 
+	// Delete all statuses scheduled by given account.
+	if err := p.state.DB.DeleteScheduledStatusesByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting scheduled statuses by account: %w", err)
+	}
+
+	// Delete all hashtags followed by given account.
+	if err := p.state.DB.DeleteFollowedTagsByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting followed tags by account: %w", err)
+	}
+
+	// Delete all hashtags featured by given account.
+	if err := p.state.DB.DeleteFeaturedTagsByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting featured tags by account: %w", err)
+	}
+
+	// Delete all direct conversations owned by given account.
+	if err := p.state.DB.DeleteConversationsByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting conversations by account: %w", err)
+	}
+
+	// Delete all content reveals by or targeting given account.
+	if err := p.state.DB.DeleteContentRevealsByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting content reveals by account: %w", err)
+	}
+
+	// Delete all data imports requested by given account.
+	if err := p.state.DB.DeleteAccountImportsByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting imports by account: %w", err)
+	}
+
+	// Delete all data exports requested by given account.
+	if err := p.deleteAccountExports(ctx, account.ID); err != nil {
+		return err
+	}
+
 	// Delete account stats model.
 	if err := p.state.DB.DeleteAccountStats(ctx, account.ID); err != nil {
 		return gtserror.Newf("error deleting stats for account: %w", err)
@@ -491,6 +581,30 @@ func (p *Processor) deleteAccountPeripheral(ctx context.Context, account *gtsmod
 	return nil
 }
 
+// deleteAccountExports deletes all data exports
+// requested by the given account, and their archives.
+func (p *Processor) deleteAccountExports(ctx context.Context, accountID string) error {
+	exports, err := p.state.DB.GetAccountExports(ctx, accountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting exports by account: %w", err)
+	}
+
+	for _, export := range exports {
+		if export.FilePath != "" {
+			err := p.state.Storage.Delete(ctx, export.FilePath)
+			if err != nil && !errors.Is(err, storage.ErrNotFound) {
+				return gtserror.Newf("error deleting export archive %s: %w", export.FilePath, err)
+			}
+		}
+
+		if err := p.state.DB.DeleteAccountExportByID(ctx, export.ID); err != nil {
+			return gtserror.Newf("error deleting export %s: %w", export.ID, err)
+		}
+	}
+
+	return nil
+}
+
 // stubbifyAccount renders the given account as a stub,
 // removing most information from it and marking it as
 // suspended.
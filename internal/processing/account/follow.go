@@ -20,6 +20,8 @@ package account
 import (
 	"context"
 	"errors"
+	"strings"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
@@ -28,8 +30,11 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
 )
 
 // FollowCreate handles a follow request to an account, either remote or local.
@@ -39,6 +44,13 @@ func (p *Processor) FollowCreate(ctx context.Context, requestingAccount *gtsmode
 		return nil, errWithCode
 	}
 
+	if targetAccount.IsLocal() && targetAccount.IsMoving() {
+		// Local accounts that have Moved
+		// away can't receive new follows.
+		const text = "target account has moved"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
 	// Check if a follow exists already.
 	if follow, err := p.state.DB.GetFollow(
 		gtscontext.SetBarebones(ctx),
@@ -80,7 +92,13 @@ func (p *Processor) FollowCreate(ctx context.Context, requestingAccount *gtsmode
 	}
 
 	// Neither follows nor follow requests, so
-	// create and store a new follow request.
+	// create and store a new follow request,
+	// including any short message for target.
+	note := strings.TrimSpace(form.Note)
+	if err := validate.FollowRequestNote(note); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
 	followID, err := id.NewRandomULID()
 	if err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
@@ -96,6 +114,7 @@ func (p *Processor) FollowCreate(ctx context.Context, requestingAccount *gtsmode
 		TargetAccount:   targetAccount,
 		ShowReblogs:     form.Reblogs,
 		Notify:          form.Notify,
+		Note:            note,
 	}
 
 	if err := p.state.DB.PutFollowRequest(ctx, fr); err != nil {
@@ -103,10 +122,21 @@ func (p *Processor) FollowCreate(ctx context.Context, requestingAccount *gtsmode
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	if targetAccount.IsLocal() && !*targetAccount.Locked {
+	// Local locked accounts may still have the
+	// request approved by one of their rules.
+	autoApprove := false
+	if targetAccount.IsLocal() && *targetAccount.Locked {
+		autoApprove, err = p.AutoApproveFollowRequest(ctx, fr)
+		if err != nil {
+			log.Errorf(ctx, "error checking follow request auto-approval: %v", err)
+		}
+	}
+
+	if targetAccount.IsLocal() && (!*targetAccount.Locked || autoApprove) {
 		// If the target account is local and not locked,
-		// we can already accept the follow request and
-		// skip any further processing.
+		// (or auto-approves this request), we can already
+		// accept the follow request and skip any further
+		// processing.
 		//
 		// Because we know the requestingAccount is also
 		// local, we don't need to federate the accept out.
@@ -114,6 +144,14 @@ func (p *Processor) FollowCreate(ctx context.Context, requestingAccount *gtsmode
 			err = gtserror.Newf("error accepting follow request for local unlocked account: %w", err)
 			return nil, gtserror.NewErrorInternalError(err)
 		}
+
+		if err := p.RecordFollowerEvent(ctx,
+			targetAccount,
+			requestingAccount.ID,
+			gtsmodel.FollowerEventFollow,
+		); err != nil {
+			log.Errorf(ctx, "error recording follower event: %v", err)
+		}
 	} else {
 		// Otherwise we leave the follow request as it is,
 		// and we handle the rest of the process async.
@@ -129,6 +167,76 @@ func (p *Processor) FollowCreate(ctx context.Context, requestingAccount *gtsmode
 	return p.RelationshipGet(ctx, requestingAccount, form.ID)
 }
 
+// AutoApproveFollowRequest returns whether the given
+// follow request, targeting a locked local account,
+// matches any of the follow request auto-approval
+// rules set by the target account, and so should
+// be approved without asking the target account.
+//
+// The follow request should be fully populated.
+func (p *Processor) AutoApproveFollowRequest(
+	ctx context.Context,
+	followReq *gtsmodel.FollowRequest,
+) (bool, error) {
+	target := followReq.TargetAccount
+	if target.Settings == nil {
+		var err error
+		target.Settings, err = p.state.DB.GetAccountSettings(ctx, target.ID)
+		if err != nil {
+			return false, gtserror.Newf("db error getting account settings: %w", err)
+		}
+	}
+	settings := target.Settings
+
+	if util.PtrValueOr(settings.AutoApproveLocal, false) &&
+		followReq.Account.IsLocal() {
+		// Approve local accounts.
+		return true, nil
+	}
+
+	if days := settings.AutoApproveMinAgeDays; days > 0 {
+		firstSeen, ok := accountFirstSeen(followReq.Account)
+		if ok && time.Since(firstSeen) >= time.Duration(days)*24*time.Hour {
+			// Approve accounts that are old enough.
+			return true, nil
+		}
+	}
+
+	if util.PtrValueOr(settings.AutoApproveFollowed, false) {
+		// Approve accounts that target already follows.
+		following, err := p.state.DB.IsFollowing(ctx,
+			target.ID,
+			followReq.AccountID,
+		)
+		if err != nil {
+			return false, gtserror.Newf("db error checking follow: %w", err)
+		}
+
+		if following {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// accountFirstSeen returns when the given account was
+// first known to this instance. Remote accounts can claim
+// any creation date, so for those, go by the time of the
+// ID generated for them when they were first fetched.
+func accountFirstSeen(account *gtsmodel.Account) (time.Time, bool) {
+	if account.IsLocal() {
+		return account.CreatedAt, !account.CreatedAt.IsZero()
+	}
+
+	firstSeen, err := id.TimeFromULID(account.ID)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return firstSeen, true
+}
+
 // FollowRemove handles the removal of a follow/follow request to an account, either remote or local.
 func (p *Processor) FollowRemove(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode) {
 	targetAccount, errWithCode := p.getFollowTarget(ctx, requestingAccount, targetAccountID)
@@ -174,9 +282,11 @@ func (p *Processor) updateFollow(
 	columns := make([]string, 0, 3)
 
 	// Check what we need to update (if anything).
+	var hideReblogs bool
 	if newReblogs := form.Reblogs; newReblogs != nil && *newReblogs != *currentShowReblogs {
 		*currentShowReblogs = *newReblogs
 		columns = append(columns, "show_reblogs")
+		hideReblogs = !*newReblogs
 	}
 
 	if newNotify := form.Notify; newNotify != nil && *newNotify != *currentNotify {
@@ -194,9 +304,66 @@ func (p *Processor) updateFollow(
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	if hideReblogs {
+		// Boosts by target are no longer wanted,
+		// remove any already in requester's timelines.
+		p.wipeBoostsFromTimelines(ctx, requestingAccount, form.ID)
+	}
+
 	return p.RelationshipGet(ctx, requestingAccount, form.ID)
 }
 
+// wipeBoostsFromTimelines removes all boosts created by the
+// target account from the requester's home timeline, and
+// from the timelines of any of the requester's lists that
+// include the target account.
+func (p *Processor) wipeBoostsFromTimelines(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	targetAccountID string,
+) {
+	if err := p.state.Timelines.Home.WipeBoostsFromAccountID(
+		ctx,
+		requestingAccount.ID,
+		targetAccountID,
+	); err != nil {
+		log.Errorf(ctx, "error wiping boosts from home timeline: %v", err)
+	}
+
+	follow, err := p.state.DB.GetFollow(
+		gtscontext.SetBarebones(ctx),
+		requestingAccount.ID,
+		targetAccountID,
+	)
+	if err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			log.Errorf(ctx, "db error getting follow: %v", err)
+		}
+		// Only a follow request,
+		// so no list entries.
+		return
+	}
+
+	listEntries, err := p.state.DB.GetListEntriesForFollowID(
+		gtscontext.SetBarebones(ctx),
+		follow.ID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		log.Errorf(ctx, "db error getting list entries: %v", err)
+		return
+	}
+
+	for _, listEntry := range listEntries {
+		if err := p.state.Timelines.List.WipeBoostsFromAccountID(
+			ctx,
+			listEntry.ListID,
+			targetAccountID,
+		); err != nil {
+			log.Errorf(ctx, "error wiping boosts from list timeline %s: %v", listEntry.ListID, err)
+		}
+	}
+}
+
 // getFollowTarget is a convenience function which:
 //   - Checks if account is trying to follow/unfollow itself.
 //   - Returns not found if target should not be visible to requester.
@@ -255,6 +422,7 @@ func (p *Processor) unfollow(ctx context.Context, requestingAccount *gtsmodel.Ac
 			APObjectType:   ap.ActivityFollow,
 			APActivityType: ap.ActivityUndo,
 			GTSModel: &gtsmodel.Follow{
+				ID:              follow.ID,
 				AccountID:       requestingAccount.ID,
 				TargetAccountID: targetAccount.ID,
 				URI:             follow.URI,
@@ -110,6 +110,21 @@ func (p *Processor) FollowRequestsGet(ctx context.Context, requestingAccount *gt
 		count,
 	)
 
+	// Surface any notes attached to the
+	// follow requests on returned accounts.
+	notes := make(map[string]string, count)
+	for _, fr := range followRequests {
+		if fr.Note != "" {
+			notes[fr.AccountID] = fr.Note
+		}
+	}
+
+	for _, item := range items {
+		if account, ok := item.(*apimodel.Account); ok {
+			account.FollowRequestNote = notes[account.ID]
+		}
+	}
+
 	return paging.PackageResponse(paging.ResponseParams{
 		Items: items,
 		Path:  "/api/v1/follow_requests",
@@ -19,12 +19,15 @@ package account_test
 
 import (
 	"context"
+	"net/http"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
@@ -158,6 +161,111 @@ func (suite *FollowTestSuite) TestFollowRequestLocal() {
 	suite.Equal(targetAccount.ID, cMsg.Target.ID)
 }
 
+func (suite *FollowTestSuite) TestFollowRequestLocalWithNote() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["admin_account"]
+	targetAccount := suite.testAccounts["local_account_2"]
+
+	// Note that's too long should be rejected.
+	_, errWithCode := suite.accountProcessor.FollowCreate(
+		ctx,
+		requestingAccount,
+		&apimodel.AccountFollowRequest{
+			ID:   targetAccount.ID,
+			Note: strings.Repeat("a", 501),
+		})
+	suite.EqualError(errWithCode, "follow request note should be no more than 500 chars but given note was 501")
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	// Have admin follow request turtle with a note.
+	_, errWithCode = suite.accountProcessor.FollowCreate(
+		ctx,
+		requestingAccount,
+		&apimodel.AccountFollowRequest{
+			ID:   targetAccount.ID,
+			Note: "  hi turtle, it's me from the pond  ",
+		})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Note should be stored trimmed.
+	fr, err := suite.state.DB.GetFollowRequest(ctx, requestingAccount.ID, targetAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("hi turtle, it's me from the pond", fr.Note)
+
+	// Note should be shown to turtle on the requesting account.
+	resp, errWithCode := suite.accountProcessor.FollowRequestsGet(ctx, targetAccount, &paging.Page{Limit: 10})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(resp.Items, 1)
+	suite.Equal("hi turtle, it's me from the pond", resp.Items[0].(*apimodel.Account).FollowRequestNote)
+}
+
+func (suite *FollowTestSuite) TestFollowRequestLocalAutoApproved() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["admin_account"]
+	targetAccount := suite.testAccounts["local_account_2"]
+
+	// Have turtle auto-approve
+	// requests from local accounts.
+	settings, err := suite.state.DB.GetAccountSettings(ctx, targetAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	settings.AutoApproveLocal = util.Ptr(true)
+	if err := suite.state.DB.UpdateAccountSettings(ctx, settings, "auto_approve_local"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Have admin follow request turtle.
+	relationship, errWithCode := suite.accountProcessor.FollowCreate(
+		ctx,
+		requestingAccount,
+		&apimodel.AccountFollowRequest{
+			ID: targetAccount.ID,
+		})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Request should have been
+	// accepted straight away.
+	suite.True(relationship.Following)
+	suite.False(relationship.Requested)
+
+	following, err := suite.state.DB.IsFollowing(ctx, requestingAccount.ID, targetAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(following)
+}
+
+func (suite *FollowTestSuite) TestFollowMovedLocal() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["admin_account"]
+	targetAccount := suite.testAccounts["local_account_2"]
+
+	// Mark turtle as having Moved.
+	targetAccount.MovedToURI = "http://example.org/users/turtle"
+	if err := suite.state.DB.UpdateAccount(ctx, targetAccount, "moved_to_uri"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Have admin try to follow turtle.
+	_, errWithCode := suite.accountProcessor.FollowCreate(
+		ctx,
+		requestingAccount,
+		&apimodel.AccountFollowRequest{
+			ID: targetAccount.ID,
+		})
+	suite.EqualError(errWithCode, "target account has moved")
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
 func TestFollowTestS(t *testing.T) {
 	suite.Run(t, new(FollowTestSuite))
 }
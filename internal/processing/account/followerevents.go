@@ -0,0 +1,118 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// RecordFollowerEvent records account gaining or losing the
+// given follower, if account is a local account that opted
+// in to recording follower events. Otherwise it's a no-op.
+func (p *Processor) RecordFollowerEvent(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	followerAccountID string,
+	eventType gtsmodel.FollowerEventType,
+) error {
+	if !account.IsLocal() || account.IsInstance() {
+		// Only local accounts
+		// have account settings.
+		return nil
+	}
+
+	if account.Settings == nil {
+		// Ensure account settings populated.
+		var err error
+		account.Settings, err = p.state.DB.GetAccountSettings(ctx, account.ID)
+		if err != nil {
+			return gtserror.Newf("db error getting account settings: %w", err)
+		}
+	}
+
+	if !util.PtrValueOr(account.Settings.RecordFollowerEvents, false) {
+		// Account didn't opt in.
+		return nil
+	}
+
+	event := &gtsmodel.FollowerEvent{
+		ID:                id.NewULID(),
+		AccountID:         account.ID,
+		FollowerAccountID: followerAccountID,
+		Type:              eventType,
+	}
+
+	if err := p.state.DB.PutFollowerEvent(ctx, event); err != nil {
+		return gtserror.Newf("db error putting follower event: %w", err)
+	}
+
+	return nil
+}
+
+// FollowerEventsGet gets a page of the recorded follower
+// events of requestingAccount, newest first.
+func (p *Processor) FollowerEventsGet(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	events, err := p.state.DB.GetAccountFollowerEvents(ctx, requestingAccount.ID, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting follower events: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Check for empty response.
+	count := len(events)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo := events[count-1].ID
+	hi := events[0].ID
+
+	items := make([]interface{}, 0, count)
+	for _, event := range events {
+		apiEvent, err := p.converter.FollowerEventToAPIFollowerEvent(ctx, event)
+		if err != nil {
+			log.Errorf(ctx, "error converting follower event to api: %v", err)
+			continue
+		}
+
+		items = append(items, apiEvent)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/gotosocial/follower_events",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
@@ -0,0 +1,121 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type FollowerEventsTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *FollowerEventsTestSuite) TestRecordFollowerEventNotOptedIn() {
+	var (
+		ctx       = context.Background()
+		requester = suite.testAccounts["local_account_1"]
+		follower  = suite.testAccounts["remote_account_1"]
+	)
+
+	// Follower events are off by default,
+	// so nothing should be recorded.
+	err := suite.accountProcessor.RecordFollowerEvent(ctx,
+		requester,
+		follower.ID,
+		gtsmodel.FollowerEventFollow,
+	)
+	suite.NoError(err)
+
+	resp, errWithCode := suite.accountProcessor.FollowerEventsGet(ctx, requester, &paging.Page{Limit: 10})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(resp.Items)
+}
+
+func (suite *FollowerEventsTestSuite) TestRecordFollowerEvents() {
+	var (
+		ctx       = context.Background()
+		requester = new(gtsmodel.Account)
+		follower  = suite.testAccounts["remote_account_1"]
+	)
+
+	// Opt in to follower events.
+	*requester = *suite.testAccounts["local_account_1"]
+	requester.Settings = new(gtsmodel.AccountSettings)
+	*requester.Settings = *suite.testAccounts["local_account_1"].Settings
+	requester.Settings.RecordFollowerEvents = util.Ptr(true)
+	if err := suite.db.UpdateAccountSettings(ctx, requester.Settings); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	for _, eventType := range []gtsmodel.FollowerEventType{
+		gtsmodel.FollowerEventFollow,
+		gtsmodel.FollowerEventUnfollow,
+	} {
+		// IDs created within the same millisecond
+		// aren't strictly ordered, so space them out.
+		time.Sleep(2 * time.Millisecond)
+
+		if err := suite.accountProcessor.RecordFollowerEvent(ctx,
+			requester,
+			follower.ID,
+			eventType,
+		); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	resp, errWithCode := suite.accountProcessor.FollowerEventsGet(ctx, requester, &paging.Page{Limit: 10})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Events should be newest first.
+	if suite.Len(resp.Items, 2) {
+		unfollow := resp.Items[0].(*apimodel.GTSFollowerEvent)
+		suite.Equal("unfollow", unfollow.Type)
+		suite.Equal(follower.ID, unfollow.Account.ID)
+
+		follow := resp.Items[1].(*apimodel.GTSFollowerEvent)
+		suite.Equal("follow", follow.Type)
+		suite.Equal(follower.ID, follow.Account.ID)
+	}
+
+	// The unfollow should count as a follower lost this week.
+	stats, errWithCode := suite.accountProcessor.StatsGet(ctx, requester, 1)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	if suite.Len(stats.Weeks, 1) && suite.NotNil(stats.Weeks[0].FollowersLost) {
+		suite.Equal(1, *stats.Weeks[0].FollowersLost)
+	}
+}
+
+func TestFollowerEventsTestSuite(t *testing.T) {
+	suite.Run(t, new(FollowerEventsTestSuite))
+}
@@ -0,0 +1,333 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// maxMuteDuration is the maximum duration
+// after which a mute can be set to expire.
+const maxMuteDuration = maxBlockDuration
+
+// MuteCreate handles the muting of targetAccountID by requestingAccount.
+//
+// If a duration is given in the form, the mute will be automatically undone once it elapses.
+// If the mute exists already, then its expiry and notifications setting will be updated instead.
+func (p *Processor) MuteCreate(ctx context.Context, requestingAccount *gtsmodel.Account, form *apimodel.AccountMuteRequest) (*apimodel.Relationship, gtserror.WithCode) {
+	targetAccountID := form.ID
+	targetAccount, existingMute, errWithCode := p.getMuteTarget(ctx, requestingAccount, targetAccountID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Work out when the mute should expire, if ever.
+	var expiresAt time.Time
+	if form.Duration != nil {
+		duration := time.Duration(*form.Duration) * time.Second
+		if *form.Duration < 0 || duration > maxMuteDuration {
+			err := fmt.Errorf("duration must be between 0 and %d seconds", int(maxMuteDuration.Seconds()))
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		if duration > 0 {
+			expiresAt = time.Now().Add(duration)
+		}
+	}
+
+	if existingMute != nil {
+		var columns []string
+
+		if form.Duration != nil &&
+			!existingMute.ExpiresAt.Equal(expiresAt) {
+			existingMute.ExpiresAt = expiresAt
+			columns = append(columns, "expires_at")
+		}
+
+		if form.Notifications != nil &&
+			*form.Notifications != *existingMute.Notifications {
+			existingMute.Notifications = form.Notifications
+			columns = append(columns, "notifications")
+		}
+
+		if len(columns) == 0 {
+			// Mute already exists, nothing to do.
+			return p.RelationshipGet(ctx, requestingAccount, targetAccountID)
+		}
+
+		// Update the existing mute.
+		if err := p.state.DB.UpdateMute(ctx, existingMute, columns...); err != nil {
+			err = gtserror.Newf("error updating mute in db: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		// Replace any previously scheduled expiry.
+		p.unscheduleMuteExpiry(existingMute.ID)
+		p.scheduleMuteExpiry(ctx, existingMute)
+
+		return p.RelationshipGet(ctx, requestingAccount, targetAccountID)
+	}
+
+	// Create and store a new mute,
+	// muting notifications by default.
+	mute := &gtsmodel.UserMute{
+		ID:              id.NewULID(),
+		AccountID:       requestingAccount.ID,
+		Account:         requestingAccount,
+		TargetAccountID: targetAccountID,
+		TargetAccount:   targetAccount,
+		ExpiresAt:       expiresAt,
+		Notifications:   util.Ptr(true),
+	}
+
+	if form.Notifications != nil {
+		mute.Notifications = form.Notifications
+	}
+
+	if err := p.state.DB.PutMute(ctx, mute); err != nil {
+		err = gtserror.Newf("error creating mute in db: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Schedule undoing the mute, if it expires.
+	p.scheduleMuteExpiry(ctx, mute)
+
+	// Remove mutee's statuses from muter's timelines.
+	if err := p.state.Timelines.Home.WipeItemsFromAccountID(
+		ctx,
+		mute.AccountID,
+		mute.TargetAccountID,
+	); err != nil {
+		log.Errorf(ctx, "error wiping items from muter's home timeline: %v", err)
+	}
+
+	if err := p.state.Timelines.List.WipeItemsFromAccountID(
+		ctx,
+		mute.AccountID,
+		mute.TargetAccountID,
+	); err != nil {
+		log.Errorf(ctx, "error wiping items from muter's list timeline(s): %v", err)
+	}
+
+	return p.RelationshipGet(ctx, requestingAccount, targetAccountID)
+}
+
+// MuteRemove handles the unmuting of targetAccountID by requestingAccount.
+func (p *Processor) MuteRemove(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode) {
+	_, existingMute, errWithCode := p.getMuteTarget(ctx, requestingAccount, targetAccountID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if existingMute == nil {
+		// Already not muted, nothing to do.
+		return p.RelationshipGet(ctx, requestingAccount, targetAccountID)
+	}
+
+	// We got a mute, remove it from the db.
+	if err := p.state.DB.DeleteMuteByID(ctx, existingMute.ID); err != nil {
+		err = gtserror.Newf("error removing mute from db: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Drop any scheduled expiry.
+	p.unscheduleMuteExpiry(existingMute.ID)
+
+	return p.RelationshipGet(ctx, requestingAccount, targetAccountID)
+}
+
+// MutesGet returns a page of accounts muted by requestingAccount,
+// each with `mute_expires_at` set if the mute will expire.
+func (p *Processor) MutesGet(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	mutes, err := p.state.DB.GetAccountMutes(ctx,
+		requestingAccount.ID,
+		page,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Check for empty response.
+	count := len(mutes)
+	if len(mutes) == 0 {
+		return util.EmptyPageableResponse(), nil
+	}
+
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo := mutes[count-1].ID
+	hi := mutes[0].ID
+
+	items := make([]interface{}, 0, count)
+
+	now := time.Now()
+	for _, mute := range mutes {
+		if mute.Expired(now) {
+			// Due to be removed
+			// by the scheduler.
+			continue
+		}
+
+		// Convert target account to frontend API model. (target will never be nil)
+		account, err := p.converter.AccountToAPIAccountPublic(ctx, mute.TargetAccount)
+		if err != nil {
+			log.Errorf(ctx, "error converting account to public api account: %v", err)
+			continue
+		}
+
+		if !mute.ExpiresAt.IsZero() {
+			account.MuteExpiresAt = util.FormatISO8601(mute.ExpiresAt)
+		}
+
+		// Append target to return items.
+		items = append(items, account)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/mutes",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// ScheduleMuteExpiries schedules undoing
+// all mutes that have an expiry set.
+func (p *Processor) ScheduleMuteExpiries(ctx context.Context) error {
+	// Fetch all expiring mutes from the database (barebones models are enough).
+	mutes, err := p.state.DB.GetExpiringMutes(gtscontext.SetBarebones(ctx))
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting expiring mutes from db: %w", err)
+	}
+
+	for _, mute := range mutes {
+		p.scheduleMuteExpiry(ctx, mute)
+	}
+
+	return nil
+}
+
+func (p *Processor) scheduleMuteExpiry(ctx context.Context, mute *gtsmodel.UserMute) {
+	if mute.ExpiresAt.IsZero() {
+		// Nothing to schedule.
+		return
+	}
+
+	if !p.state.Workers.Scheduler.AddOnce(
+		muteExpiryID(mute.ID),
+		mute.ExpiresAt,
+		p.onMuteExpiry(mute.ID),
+	) {
+		log.Warnf(ctx, "failed adding mute %s expiry to scheduler", mute.ID)
+		return
+	}
+
+	atStr := mute.ExpiresAt.Local().Format("Jan _2 2006 15:04:05")
+	log.Infof(ctx, "scheduled mute expiry for %s at '%s'", mute.ID, atStr)
+}
+
+func (p *Processor) unscheduleMuteExpiry(muteID string) {
+	_ = p.state.Workers.Scheduler.Cancel(muteExpiryID(muteID))
+}
+
+// onMuteExpiry returns a callback function to be
+// used by the scheduler when the given mute expires.
+func (p *Processor) onMuteExpiry(muteID string) func(context.Context, time.Time) {
+	return func(ctx context.Context, now time.Time) {
+		// Get the latest version of mute from database.
+		mute, err := p.state.DB.GetMuteByID(gtscontext.SetBarebones(ctx), muteID)
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				log.Errorf(ctx, "error getting mute %s from db: %v", muteID, err)
+			}
+
+			// Mute was removed in
+			// the meantime, all good.
+			return
+		}
+
+		if !mute.Expired(now) {
+			// Expiry was changed in
+			// the meantime, all good.
+			return
+		}
+
+		// Mutes aren't federated, so just drop it;
+		// this also invalidates the muter's caches.
+		if err := p.state.DB.DeleteMuteByID(ctx, muteID); err != nil {
+			log.Errorf(ctx, "error removing expired mute %s: %v", muteID, err)
+		}
+	}
+}
+
+// muteExpiryID returns the scheduler
+// task ID for the given mute's expiry.
+func muteExpiryID(muteID string) string {
+	return "mute-expiry-" + muteID
+}
+
+func (p *Processor) getMuteTarget(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*gtsmodel.Account, *gtsmodel.UserMute, gtserror.WithCode) {
+	// Account should not mute or unmute itself.
+	if requestingAccount.ID == targetAccountID {
+		err := gtserror.Newf("account %s cannot mute or unmute itself", requestingAccount.ID)
+		return nil, nil, gtserror.NewErrorNotAcceptable(err, err.Error())
+	}
+
+	// Ensure target account retrievable.
+	targetAccount, err := p.state.DB.GetAccountByID(ctx, targetAccountID)
+	if err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			// Real db error.
+			err = gtserror.Newf("db error looking for target account %s: %w", targetAccountID, err)
+			return nil, nil, gtserror.NewErrorInternalError(err)
+		}
+		// Account not found.
+		err = gtserror.Newf("target account %s not found in the db", targetAccountID)
+		return nil, nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	// Check if currently muted.
+	mute, err := p.state.DB.GetMute(
+		gtscontext.SetBarebones(ctx),
+		requestingAccount.ID,
+		targetAccountID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error checking existing mute: %w", err)
+		return nil, nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return targetAccount, mute, nil
+}
@@ -0,0 +1,153 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type MuteTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *MuteTestSuite) TestMuteWithDuration() {
+	var (
+		ctx               = context.Background()
+		requestingAccount = suite.testAccounts["local_account_1"]
+		targetAccount     = suite.testAccounts["local_account_2"]
+		status            = suite.testStatuses["local_account_2_status_1"]
+		filter            = visibility.NewFilter(&suite.state)
+	)
+
+	// Status from followed account should be timelineable.
+	timelineable, err := filter.StatusHomeTimelineable(ctx, requestingAccount, status)
+	suite.NoError(err)
+	suite.True(timelineable)
+
+	// Negative duration should be rejected.
+	_, errWithCode := suite.accountProcessor.MuteCreate(
+		ctx,
+		requestingAccount,
+		&apimodel.AccountMuteRequest{
+			ID:       targetAccount.ID,
+			Duration: util.Ptr(-1),
+		})
+	suite.EqualError(errWithCode, "duration must be between 0 and 315360000 seconds")
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	// Mute (but not notifications) with an expiry an hour from now.
+	relationship, errWithCode := suite.accountProcessor.MuteCreate(
+		ctx,
+		requestingAccount,
+		&apimodel.AccountMuteRequest{
+			ID:            targetAccount.ID,
+			Notifications: util.Ptr(false),
+			Duration:      util.Ptr(3600),
+		})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.True(relationship.Muting)
+	suite.False(relationship.MutingNotifications)
+	if suite.NotNil(relationship.MutingExpiresIn) {
+		suite.InDelta(3600, *relationship.MutingExpiresIn, 5)
+	}
+
+	// Status should no longer be timelineable.
+	timelineable, err = filter.StatusHomeTimelineable(ctx, requestingAccount, status)
+	suite.NoError(err)
+	suite.False(timelineable)
+
+	// Muted account should be listed with its expiry.
+	resp, errWithCode := suite.accountProcessor.MutesGet(ctx, requestingAccount, &paging.Page{Limit: 10})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	if suite.Len(resp.Items, 1) {
+		account := resp.Items[0].(*apimodel.Account)
+		suite.Equal(targetAccount.ID, account.ID)
+		suite.NotEmpty(account.MuteExpiresAt)
+	}
+
+	// Muting again should update notifications
+	// and leave the expiry as it was.
+	relationship, errWithCode = suite.accountProcessor.MuteCreate(
+		ctx,
+		requestingAccount,
+		&apimodel.AccountMuteRequest{
+			ID:            targetAccount.ID,
+			Notifications: util.Ptr(true),
+		})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.True(relationship.MutingNotifications)
+	suite.NotNil(relationship.MutingExpiresIn)
+
+	// Update the mute to expire a second from now.
+	if _, errWithCode := suite.accountProcessor.MuteCreate(
+		ctx,
+		requestingAccount,
+		&apimodel.AccountMuteRequest{
+			ID:       targetAccount.ID,
+			Duration: util.Ptr(1),
+		}); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Mute should be removed by the scheduler.
+	if !testrig.WaitFor(func() bool {
+		_, err := suite.state.DB.GetMute(ctx, requestingAccount.ID, targetAccount.ID)
+		return errors.Is(err, db.ErrNoEntries)
+	}) {
+		suite.FailNow("timed out waiting for mute to expire")
+	}
+
+	// Status should be timelineable again.
+	timelineable, err = filter.StatusHomeTimelineable(ctx, requestingAccount, status)
+	suite.NoError(err)
+	suite.True(timelineable)
+}
+
+func (suite *MuteTestSuite) TestMuteSelf() {
+	requestingAccount := suite.testAccounts["local_account_1"]
+
+	_, errWithCode := suite.accountProcessor.MuteCreate(
+		context.Background(),
+		requestingAccount,
+		&apimodel.AccountMuteRequest{ID: requestingAccount.ID},
+	)
+	if suite.NotNil(errWithCode) {
+		suite.Equal(http.StatusNotAcceptable, errWithCode.Code())
+	}
+}
+
+func TestMuteTestSuite(t *testing.T) {
+	suite.Run(t, new(MuteTestSuite))
+}
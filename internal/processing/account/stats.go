@@ -0,0 +1,181 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// statsTopInteractingAccounts is the maximum number
+// of top interacting accounts returned in stats.
+const statsTopInteractingAccounts = 10
+
+// StatsGet returns statistics about the activity of
+// requestingAccount over the given number of weeks,
+// up to and including the current week.
+func (p *Processor) StatsGet(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	weeks int,
+) (*apimodel.GTSAccountStats, gtserror.WithCode) {
+	// Weeks start on Monday, UTC, with
+	// the oldest week starting at since.
+	current := weekStart(time.Now())
+	since := current.AddDate(0, 0, -7*(weeks-1))
+
+	stats := &apimodel.GTSAccountStats{
+		Weeks:                  make([]apimodel.GTSAccountStatsWeek, weeks),
+		TopInteractingAccounts: []apimodel.GTSAccountInteractions{},
+	}
+
+	for i := range stats.Weeks {
+		week := since.AddDate(0, 0, 7*i)
+		stats.Weeks[i].Week = week.Format(time.DateOnly)
+	}
+
+	// weekIndex returns the index of the
+	// stats week the given time falls in.
+	weekIndex := func(t time.Time) (int, bool) {
+		i := int(weekStart(t).Sub(since) / (7 * 24 * time.Hour))
+		return i, i >= 0 && i < weeks
+	}
+
+	statusTimes, err := p.state.DB.GetAccountStatusTimes(ctx, requestingAccount.ID, since)
+	if err != nil {
+		err := gtserror.Newf("error getting status times: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	for _, t := range statusTimes {
+		if i, ok := weekIndex(t); ok {
+			stats.Weeks[i].Statuses++
+		}
+	}
+
+	followerTimes, err := p.state.DB.GetAccountFollowerTimes(ctx, requestingAccount.ID, since)
+	if err != nil {
+		err := gtserror.Newf("error getting follower times: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	for _, t := range followerTimes {
+		if i, ok := weekIndex(t); ok {
+			stats.Weeks[i].FollowersGained++
+		}
+	}
+
+	// Followers lost are only known
+	// when follower events are recorded.
+	if requestingAccount.Settings != nil &&
+		util.PtrValueOr(requestingAccount.Settings.RecordFollowerEvents, false) {
+		unfollowTimes, err := p.state.DB.GetAccountFollowerEventTimes(ctx,
+			requestingAccount.ID,
+			gtsmodel.FollowerEventUnfollow,
+			since,
+		)
+		if err != nil {
+			err := gtserror.Newf("error getting unfollow times: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		for i := range stats.Weeks {
+			stats.Weeks[i].FollowersLost = util.Ptr(0)
+		}
+
+		for _, t := range unfollowTimes {
+			if i, ok := weekIndex(t); ok {
+				*stats.Weeks[i].FollowersLost++
+			}
+		}
+	}
+
+	counts, err := p.state.DB.CountAccountInteractionsSince(ctx, requestingAccount.ID, since)
+	if err != nil {
+		err := gtserror.Newf("error counting interactions: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Sort interacting account IDs by most
+	// interactions first, then by account ID
+	// so that the order is deterministic.
+	accountIDs := make([]string, 0, len(counts))
+	for accountID := range counts {
+		accountIDs = append(accountIDs, accountID)
+	}
+
+	slices.SortFunc(accountIDs, func(a, b string) int {
+		if c := cmp.Compare(counts[b], counts[a]); c != 0 {
+			return c
+		}
+		return cmp.Compare(a, b)
+	})
+
+	for _, accountID := range accountIDs {
+		if len(stats.TopInteractingAccounts) == statsTopInteractingAccounts {
+			break
+		}
+
+		account, err := p.state.DB.GetAccountByID(ctx, accountID)
+		if err != nil {
+			log.Errorf(ctx, "error getting interacting account %s: %v", accountID, err)
+			continue
+		}
+
+		visible, err := p.filter.AccountVisible(ctx, requestingAccount, account)
+		if err != nil {
+			log.Errorf(ctx, "error checking interacting account visibility: %v", err)
+			continue
+		}
+
+		if !visible {
+			continue
+		}
+
+		apiAccount, err := p.converter.AccountToAPIAccountPublic(ctx, account)
+		if err != nil {
+			log.Errorf(ctx, "error converting interacting account: %v", err)
+			continue
+		}
+
+		stats.TopInteractingAccounts = append(stats.TopInteractingAccounts,
+			apimodel.GTSAccountInteractions{
+				Account:      apiAccount,
+				Interactions: counts[accountID],
+			},
+		)
+	}
+
+	return stats, nil
+}
+
+// weekStart returns the start of the
+// week (Monday, UTC) containing t.
+func weekStart(t time.Time) time.Time {
+	t = t.UTC()
+	sinceMonday := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-sinceMonday, 0, 0, 0, 0, time.UTC)
+}
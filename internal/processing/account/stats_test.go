@@ -0,0 +1,115 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+type StatsTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *StatsTestSuite) TestStatsGet() {
+	var (
+		ctx           = context.Background()
+		requester     = suite.testAccounts["local_account_1"]
+		adminAccount  = suite.testAccounts["admin_account"]
+		remoteAccount = suite.testAccounts["remote_account_1"]
+		targetStatus  = suite.testStatuses["local_account_1_status_1"]
+		now           = time.Now()
+		lastWeek      = now.AddDate(0, 0, -7)
+		lastYear      = now.AddDate(-1, 0, 0)
+	)
+
+	// Post a new status this week, copying an existing one.
+	status := new(gtsmodel.Status)
+	*status = *suite.testStatuses["local_account_1_status_2"]
+	status.ID = id.NewULID()
+	status.URI = "http://localhost:8080/users/the_mighty_zork/statuses/" + status.ID
+	status.CreatedAt = now
+	if err := suite.db.PutStatus(ctx, status); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Gain a new follower last week.
+	if err := suite.db.PutFollow(ctx, &gtsmodel.Follow{
+		ID:              id.NewULID(),
+		CreatedAt:       lastWeek,
+		UpdatedAt:       lastWeek,
+		AccountID:       remoteAccount.ID,
+		TargetAccountID: requester.ID,
+		URI:             "http://fossbros-anonymous.io/users/foss_satan/follow/" + id.NewULID(),
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Receive faves: two recent ones from different
+	// accounts, and one too long ago to be counted.
+	for _, fave := range []*gtsmodel.StatusFave{
+		{AccountID: adminAccount.ID, CreatedAt: now},
+		{AccountID: remoteAccount.ID, CreatedAt: lastWeek},
+		{AccountID: suite.testAccounts["local_account_2"].ID, CreatedAt: lastYear},
+	} {
+		fave.ID = id.NewULID()
+		fave.TargetAccountID = requester.ID
+		fave.StatusID = targetStatus.ID
+		fave.URI = "http://example.org/liked/" + fave.ID
+		if err := suite.db.PutStatusFave(ctx, fave); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	stats, errWithCode := suite.accountProcessor.StatsGet(ctx, requester, 2)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	if suite.Len(stats.Weeks, 2) {
+		// Weeks are labelled with the date of their Monday.
+		monday, err := time.Parse(time.DateOnly, stats.Weeks[0].Week)
+		suite.NoError(err)
+		suite.Equal(time.Monday, monday.Weekday())
+		suite.Equal(monday.AddDate(0, 0, 7).Format(time.DateOnly), stats.Weeks[1].Week)
+
+		suite.Equal(0, stats.Weeks[0].Statuses)
+		suite.Equal(1, stats.Weeks[0].FollowersGained)
+		suite.Nil(stats.Weeks[0].FollowersLost)
+
+		suite.Equal(1, stats.Weeks[1].Statuses)
+		suite.Equal(0, stats.Weeks[1].FollowersGained)
+	}
+
+	// The fave from too long ago isn't counted.
+	if suite.Len(stats.TopInteractingAccounts, 2) {
+		for _, interactions := range stats.TopInteractingAccounts {
+			suite.NotEqual(suite.testAccounts["local_account_2"].ID, interactions.Account.ID)
+			suite.Equal(1, interactions.Interactions)
+		}
+	}
+}
+
+func TestStatsTestSuite(t *testing.T) {
+	suite.Run(t, new(StatsTestSuite))
+}
@@ -37,6 +37,20 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/validate"
 )
 
+// maxNotifyNewFromDays is the longest period of inactivity
+// (roughly ten years) that can be set for new_from notifs.
+const maxNotifyNewFromDays = 3650
+
+// maxEmailWhenAwayHours is the longest period
+// of inactivity (one year) that can be set
+// before new mentions are sent by email.
+const maxEmailWhenAwayHours = 8760
+
+// maxAutoApproveMinAgeDays is the highest minimum
+// account age (roughly ten years) that can be set
+// for automatically approving follow requests.
+const maxAutoApproveMinAgeDays = 3650
+
 func (p *Processor) selectNoteFormatter(contentType string) text.FormatFunc {
 	if contentType == "text/markdown" {
 		return p.formatter.FromMarkdown
@@ -254,6 +268,48 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, form
 
 			account.Settings.StatusContentType = *form.Source.StatusContentType
 		}
+
+		if form.Source.NotifyNewFromDays != nil {
+			days := *form.Source.NotifyNewFromDays
+			if days < 0 || days > maxNotifyNewFromDays {
+				err := fmt.Errorf("notify_new_from_days must be between 0 and %d", maxNotifyNewFromDays)
+				return nil, gtserror.NewErrorBadRequest(err, err.Error())
+			}
+
+			account.Settings.NotifyNewFromDays = days
+		}
+
+		if form.Source.EmailWhenAwayHours != nil {
+			hours := *form.Source.EmailWhenAwayHours
+			if hours < 0 || hours > maxEmailWhenAwayHours {
+				err := fmt.Errorf("email_when_away_hours must be between 0 and %d", maxEmailWhenAwayHours)
+				return nil, gtserror.NewErrorBadRequest(err, err.Error())
+			}
+
+			account.Settings.EmailWhenAwayHours = hours
+		}
+
+		if form.Source.AutoApproveFollowed != nil {
+			account.Settings.AutoApproveFollowed = form.Source.AutoApproveFollowed
+		}
+
+		if form.Source.AutoApproveLocal != nil {
+			account.Settings.AutoApproveLocal = form.Source.AutoApproveLocal
+		}
+
+		if form.Source.AutoApproveMinAgeDays != nil {
+			days := *form.Source.AutoApproveMinAgeDays
+			if days < 0 || days > maxAutoApproveMinAgeDays {
+				err := fmt.Errorf("auto_approve_min_age_days must be between 0 and %d", maxAutoApproveMinAgeDays)
+				return nil, gtserror.NewErrorBadRequest(err, err.Error())
+			}
+
+			account.Settings.AutoApproveMinAgeDays = days
+		}
+
+		if form.Source.RecordFollowerEvents != nil {
+			account.Settings.RecordFollowerEvents = form.Source.RecordFollowerEvents
+		}
 	}
 
 	if form.Theme != nil {
@@ -288,6 +344,22 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, form
 		account.Settings.HideCollections = form.HideCollections
 	}
 
+	if form.HideApplication != nil {
+		account.Settings.HideApplication = form.HideApplication
+	}
+
+	if form.EnableEmbeds != nil {
+		account.Settings.EnableEmbeds = form.EnableEmbeds
+	}
+
+	if form.AttributionDomains != nil {
+		domains, err := validate.AttributionDomains(*form.AttributionDomains)
+		if err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		account.AttributionDomains = domains
+	}
+
 	if err := p.state.DB.UpdateAccount(ctx, account); err != nil {
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("could not update account %s: %s", account.ID, err))
 	}
@@ -296,6 +368,14 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, form
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("could not update account settings %s: %s", account.ID, err))
 	}
 
+	if !util.PtrValueOr(account.Settings.RecordFollowerEvents, false) {
+		// Don't keep any previously recorded
+		// follower events after opting out.
+		if err := p.state.DB.DeleteAccountFollowerEvents(ctx, account.ID); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("could not delete follower events %s: %s", account.ID, err))
+		}
+	}
+
 	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
 		APObjectType:   ap.ObjectProfile,
 		APActivityType: ap.ActivityUpdate,
@@ -331,16 +411,16 @@ func (p *Processor) UpdateAvatar(
 	}
 
 	// Process the media attachment and load it immediately.
-	media := p.mediaManager.PreProcessMedia(data, accountID, &media.AdditionalMediaInfo{
+	processing := p.mediaManager.PreProcessMedia(data, accountID, &media.AdditionalMediaInfo{
 		Avatar:      util.Ptr(true),
 		Description: description,
 	})
 
-	attachment, err := media.LoadAttachment(ctx)
+	attachment, err := processing.LoadAttachment(ctx)
 	if err != nil {
 		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
 	} else if attachment.Type == gtsmodel.FileTypeUnknown {
-		err = gtserror.Newf("could not process uploaded file with extension %s", attachment.File.ContentType)
+		err := media.UnprocessableUploadError(attachment)
 		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
 	}
 
@@ -368,16 +448,16 @@ func (p *Processor) UpdateHeader(
 	}
 
 	// Process the media attachment and load it immediately.
-	media := p.mediaManager.PreProcessMedia(data, accountID, &media.AdditionalMediaInfo{
+	processing := p.mediaManager.PreProcessMedia(data, accountID, &media.AdditionalMediaInfo{
 		Header:      util.Ptr(true),
 		Description: description,
 	})
 
-	attachment, err := media.LoadAttachment(ctx)
+	attachment, err := processing.LoadAttachment(ctx)
 	if err != nil {
 		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
 	} else if attachment.Type == gtsmodel.FileTypeUnknown {
-		err = gtserror.Newf("could not process uploaded file with extension %s", attachment.File.ContentType)
+		err := media.UnprocessableUploadError(attachment)
 		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
 	}
 
@@ -19,6 +19,7 @@ package account_test
 
 import (
 	"context"
+	"net/http"
 	"testing"
 	"time"
 
@@ -331,6 +332,52 @@ func (suite *AccountUpdateTestSuite) TestAccountUpdateNoteNotFields() {
 	suite.Equal(fieldsBefore, len(dbAccount.Fields))
 }
 
+func (suite *AccountUpdateTestSuite) TestAccountUpdateAttributionDomains() {
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"]
+
+	var (
+		ctx     = context.Background()
+		domains = []string{"Blog.Example.org", "example.com", "example.com"}
+	)
+
+	apiAccount, errWithCode := suite.accountProcessor.Update(ctx, testAccount, &apimodel.UpdateCredentialsRequest{
+		AttributionDomains: &domains,
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Domains should be normalized.
+	expect := []string{"blog.example.org", "example.com"}
+	suite.Equal(expect, apiAccount.Source.AttributionDomains)
+
+	dbAccount, err := suite.db.GetAccountByID(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(expect, dbAccount.AttributionDomains)
+	suite.True(dbAccount.CanBeAttributedFrom("www.blog.example.org"))
+	suite.False(dbAccount.CanBeAttributedFrom("example.org"))
+
+	// Invalid domain should be rejected.
+	domains = []string{"https://example.org/blog"}
+	_, errWithCode = suite.accountProcessor.Update(ctx, testAccount, &apimodel.UpdateCredentialsRequest{
+		AttributionDomains: &domains,
+	})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	// A single empty value clears.
+	domains = []string{""}
+	apiAccount, errWithCode = suite.accountProcessor.Update(ctx, testAccount, &apimodel.UpdateCredentialsRequest{
+		AttributionDomains: &domains,
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(apiAccount.Source.AttributionDomains)
+}
+
 func TestAccountUpdateTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountUpdateTestSuite))
 }
@@ -0,0 +1,219 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// usernameChangeCooldown is how long an account
+// must wait between changes of its username.
+const usernameChangeCooldown = 30 * 24 * time.Hour
+
+// ChangeUsername changes the username of the requesting account,
+// keeping its old username around as a redirect to the account.
+func (p *Processor) ChangeUsername(
+	ctx context.Context,
+	authed *oauth.Auth,
+	form *apimodel.AccountUsernameChangeRequest,
+) (*apimodel.Account, gtserror.WithCode) {
+	// Username change requires password to ensure it's for real.
+	if form.Password == "" {
+		err := errors.New("no password provided in username change request")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if err := bcrypt.CompareHashAndPassword(
+		[]byte(authed.User.EncryptedPassword),
+		[]byte(form.Password),
+	); err != nil {
+		err := errors.New("invalid password provided in username change request")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if err := validate.NewUsername(form.Username); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	strategy := gtsmodel.UsernameChangeStrategy(form.Strategy)
+	switch strategy {
+	case "":
+		strategy = gtsmodel.UsernameChangeStrategyUpdate
+	case gtsmodel.UsernameChangeStrategyUpdate,
+		gtsmodel.UsernameChangeStrategyMove:
+		// Fine.
+	default:
+		err := fmt.Errorf("invalid strategy %s, must be update or move", form.Strategy)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	account := authed.Account
+	if form.Username == account.Username {
+		err := errors.New("new username is the same as current username")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if account.IsMoving() {
+		err := fmt.Errorf(
+			"your account is Moving or has Moved to %s; you cannot change its username",
+			account.MovedToURI,
+		)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	// Get a lock on this account, so
+	// that two changes can't race
+	// each other past the checks below.
+	unlock := p.state.ProcessingLocks.Lock(account.URI)
+	defer unlock()
+
+	changes, err := p.state.DB.GetUsernameChangesByAccountID(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting username changes: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if len(changes) != 0 {
+		if next := changes[0].CreatedAt.Add(usernameChangeCooldown); time.Now().Before(next) {
+			err := fmt.Errorf(
+				"your username was changed within the last %d days; please try again after %s",
+				int(usernameChangeCooldown/(24*time.Hour)), next,
+			)
+			return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
+	}
+
+	available, err := p.state.DB.IsUsernameAvailable(ctx, form.Username)
+	if err != nil {
+		err := gtserror.Newf("db error checking username availability: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// An account may take back one of
+	// its own previous usernames, in which
+	// case the old redirect is dropped.
+	var reclaimed *gtsmodel.UsernameChange
+	if !available {
+		reclaimed, err = p.state.DB.GetUsernameChangeByOldUsername(ctx, form.Username)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting username change: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if reclaimed == nil || reclaimed.AccountID != account.ID {
+			err := fmt.Errorf("username %s is not available", form.Username)
+			return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
+	}
+
+	newURIs := uris.GenerateURIsForAccount(form.Username)
+	if newURIs.UserURI == account.URI {
+		// Taking back the username that the account's
+		// URIs are still based on, nothing to Move.
+		strategy = gtsmodel.UsernameChangeStrategyUpdate
+	}
+
+	change := &gtsmodel.UsernameChange{
+		ID:          id.NewULID(),
+		AccountID:   account.ID,
+		OldUsername: account.Username,
+		NewUsername: form.Username,
+		OldURI:      account.URI,
+		Strategy:    strategy,
+	}
+
+	if reclaimed != nil {
+		if err := p.state.DB.DeleteUsernameChangeByID(ctx, reclaimed.ID); err != nil {
+			err := gtserror.Newf("db error deleting username change: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	if err := p.state.DB.PutUsernameChange(ctx, change); err != nil {
+		err := gtserror.Newf("db error storing username change: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	account.Username = change.NewUsername
+	account.URL = newURIs.UserURL
+	columns := []string{"username", "url"}
+
+	if strategy == gtsmodel.UsernameChangeStrategyMove {
+		// Switch the account over to URIs
+		// based on the new username. The
+		// old URI is served as an alias
+		// of the account from the change.
+		account.URI = newURIs.UserURI
+		account.InboxURI = newURIs.InboxURI
+		account.OutboxURI = newURIs.OutboxURI
+		account.FollowersURI = newURIs.FollowersURI
+		account.FollowingURI = newURIs.FollowingURI
+		account.FeaturedCollectionURI = newURIs.FeaturedCollectionURI
+		account.PublicKeyURI = newURIs.PublicKeyURI
+
+		columns = append(columns,
+			"uri",
+			"inbox_uri",
+			"outbox_uri",
+			"followers_uri",
+			"following_uri",
+			"featured_collection_uri",
+			"public_key_uri",
+		)
+	}
+
+	if err := p.state.DB.UpdateAccount(ctx, account, columns...); err != nil {
+		err := gtserror.Newf("db error updating account: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Process side effects async.
+	if strategy == gtsmodel.UsernameChangeStrategyMove {
+		p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+			APObjectType:   ap.ActorPerson,
+			APActivityType: ap.ActivityMove,
+			GTSModel:       change,
+			Origin:         account,
+			Target:         account,
+		})
+	} else {
+		p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+			APObjectType:   ap.ObjectProfile,
+			APActivityType: ap.ActivityUpdate,
+			GTSModel:       account,
+			Origin:         account,
+		})
+	}
+
+	return p.c.GetAPIAccountSensitive(ctx, account)
+}
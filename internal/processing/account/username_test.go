@@ -0,0 +1,218 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type UsernameTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *UsernameTestSuite) authed(accountKey string) (*oauth.Auth, *gtsmodel.Account) {
+	// Copy the account, it gets modified.
+	account := new(gtsmodel.Account)
+	*account = *suite.testAccounts[accountKey]
+
+	return &oauth.Auth{
+		Token:       oauth.DBTokenToToken(suite.testTokens[accountKey]),
+		Application: suite.testApplications[accountKey],
+		User:        suite.testUsers[accountKey],
+		Account:     account,
+	}, account
+}
+
+func (suite *UsernameTestSuite) TestChangeUsernameUpdate() {
+	var (
+		ctx           = context.Background()
+		authed, zork  = suite.authed("local_account_1")
+		oldUsername   = zork.Username
+		oldURI        = zork.URI
+		oldPubKeyURI  = zork.PublicKeyURI
+		newUsername   = "zork_the_renamed"
+		expectURL     = "http://localhost:8080/@zork_the_renamed"
+		expectMsgType = "Update"
+	)
+
+	apiAccount, errWithCode := suite.accountProcessor.ChangeUsername(ctx, authed,
+		&apimodel.AccountUsernameChangeRequest{
+			Password: "password",
+			Username: newUsername,
+		},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(newUsername, apiAccount.Username)
+	suite.Equal(expectURL, apiAccount.URL)
+
+	// Account should keep its AP URIs.
+	dbAccount, err := suite.state.DB.GetAccountByID(ctx, zork.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(newUsername, dbAccount.Username)
+	suite.Equal(oldURI, dbAccount.URI)
+	suite.Equal(oldPubKeyURI, dbAccount.PublicKeyURI)
+
+	// Old username should still lead to the account...
+	dbAccount, err = suite.state.DB.GetAccountByUsernameDomain(ctx, oldUsername, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(zork.ID, dbAccount.ID)
+	suite.Equal(newUsername, dbAccount.Username)
+
+	// ...and so not be available to others.
+	available, err := suite.state.DB.IsUsernameAvailable(ctx, oldUsername)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(available)
+
+	// Update should be going to the worker.
+	cMsg, _ := suite.getClientMsg(5 * time.Second)
+	suite.Equal(expectMsgType, cMsg.APActivityType)
+	suite.Equal(zork.ID, cMsg.GTSModel.(*gtsmodel.Account).ID)
+}
+
+func (suite *UsernameTestSuite) TestChangeUsernameMove() {
+	var (
+		ctx          = context.Background()
+		authed, zork = suite.authed("local_account_1")
+		oldURI       = zork.URI
+		newUsername  = "zork_the_moved"
+		expectURI    = "http://localhost:8080/users/zork_the_moved"
+	)
+
+	if _, errWithCode := suite.accountProcessor.ChangeUsername(ctx, authed,
+		&apimodel.AccountUsernameChangeRequest{
+			Password: "password",
+			Username: newUsername,
+			Strategy: "move",
+		},
+	); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Account should have new URIs.
+	dbAccount, err := suite.state.DB.GetAccountByID(ctx, zork.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(expectURI, dbAccount.URI)
+	suite.Equal(expectURI+"/inbox", dbAccount.InboxURI)
+	suite.Equal(expectURI+"/main-key", dbAccount.PublicKeyURI)
+	suite.Empty(dbAccount.MovedToURI)
+
+	// And be known as the old URI for the Move.
+	person, err := suite.tc.AccountToAS(ctx, dbAccount)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	alsoKnownAs := ap.GetAlsoKnownAs(person)
+	if suite.Len(alsoKnownAs, 1) {
+		suite.Equal(oldURI, alsoKnownAs[0].String())
+	}
+
+	// Move should be going to the worker.
+	cMsg, _ := suite.getClientMsg(5 * time.Second)
+	change, ok := cMsg.GTSModel.(*gtsmodel.UsernameChange)
+	if !ok {
+		suite.FailNow("", "could not cast %T to *gtsmodel.UsernameChange", cMsg.GTSModel)
+	}
+	suite.Equal(oldURI, change.OldURI)
+	suite.Equal(gtsmodel.UsernameChangeStrategyMove, change.Strategy)
+}
+
+func (suite *UsernameTestSuite) TestChangeUsernameCooldown() {
+	ctx := context.Background()
+	authed, _ := suite.authed("local_account_1")
+
+	if _, errWithCode := suite.accountProcessor.ChangeUsername(ctx, authed,
+		&apimodel.AccountUsernameChangeRequest{
+			Password: "password",
+			Username: "zork_once",
+		},
+	); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.getClientMsg(5 * time.Second)
+
+	// Changing again right away isn't allowed.
+	_, errWithCode := suite.accountProcessor.ChangeUsername(ctx, authed,
+		&apimodel.AccountUsernameChangeRequest{
+			Password: "password",
+			Username: "zork_twice",
+		},
+	)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func (suite *UsernameTestSuite) TestChangeUsernameErrors() {
+	ctx := context.Background()
+
+	for _, test := range []struct {
+		form   *apimodel.AccountUsernameChangeRequest
+		expect string
+	}{
+		{
+			form:   &apimodel.AccountUsernameChangeRequest{Password: "wrong", Username: "zork_new"},
+			expect: "invalid password provided in username change request",
+		},
+		{
+			form:   &apimodel.AccountUsernameChangeRequest{Password: "password", Username: "Zork New"},
+			expect: "given username Zork New was invalid: must contain only lowercase letters, numbers, and underscores, max 64 characters",
+		},
+		{
+			form:   &apimodel.AccountUsernameChangeRequest{Password: "password", Username: "zork_new", Strategy: "teleport"},
+			expect: "invalid strategy teleport, must be update or move",
+		},
+		{
+			form:   &apimodel.AccountUsernameChangeRequest{Password: "password", Username: "the_mighty_zork"},
+			expect: "new username is the same as current username",
+		},
+		{
+			form:   &apimodel.AccountUsernameChangeRequest{Password: "password", Username: "1happyturtle"},
+			expect: "username 1happyturtle is not available",
+		},
+		{
+			form:   &apimodel.AccountUsernameChangeRequest{Password: "password", Username: "webmaster"},
+			expect: "username webmaster is reserved",
+		},
+	} {
+		authed, _ := suite.authed("local_account_1")
+
+		_, errWithCode := suite.accountProcessor.ChangeUsername(ctx, authed, test.form)
+		suite.EqualError(errWithCode, test.expect)
+	}
+}
+
+func TestUsernameTestSuite(t *testing.T) {
+	suite.Run(t, new(UsernameTestSuite))
+}
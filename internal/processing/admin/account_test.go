@@ -20,10 +20,12 @@ package admin_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -77,6 +79,278 @@ func (suite *AccountTestSuite) TestAccountActionSuspend() {
 	suite.NotZero(targetAcct.SuspendedAt)
 }
 
+func (suite *AccountTestSuite) TestAccountActionRegenerateTimelines() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		targetID  = suite.testAccounts["local_account_1"].ID
+		request   = &apimodel.AdminActionRequest{
+			Category: gtsmodel.AdminActionCategoryAccount.String(),
+			Type:     gtsmodel.AdminActionRegenerateTimelines.String(),
+			TargetID: targetID,
+		}
+	)
+
+	// Put every test status in the home
+	// timeline, including ones that don't
+	// belong there, to make it stale.
+	for _, status := range suite.testStatuses {
+		if _, err := suite.state.Timelines.Home.IngestOne(ctx, targetID, status); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+	suite.Greater(suite.state.Timelines.Home.GetIndexedLength(ctx, targetID), 19)
+
+	actionID, errWithCode := suite.adminProcessor.AccountAction(
+		ctx,
+		adminAcct,
+		request,
+	)
+	suite.NoError(errWithCode)
+	suite.NotEmpty(actionID)
+
+	// Wait for action to finish.
+	if !testrig.WaitFor(func() bool {
+		return suite.adminProcessor.Actions().TotalRunning() == 0
+	}) {
+		suite.FailNow("timed out waiting for admin action(s) to finish")
+	}
+
+	adminAction, err := suite.db.GetAdminAction(ctx, actionID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.NotZero(adminAction.CompletedAt)
+	suite.Empty(adminAction.Errors)
+
+	// Only statuses that belong in the
+	// home timeline should be there now.
+	suite.Equal(19, suite.state.Timelines.Home.GetIndexedLength(ctx, targetID))
+}
+
+func (suite *AccountTestSuite) TestAccountActionRegenerateTimelinesRemote() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		targetID  = suite.testAccounts["remote_account_1"].ID
+		request   = &apimodel.AdminActionRequest{
+			Category: gtsmodel.AdminActionCategoryAccount.String(),
+			Type:     gtsmodel.AdminActionRegenerateTimelines.String(),
+			TargetID: targetID,
+		}
+	)
+
+	actionID, errWithCode := suite.adminProcessor.AccountAction(
+		ctx,
+		adminAcct,
+		request,
+	)
+	suite.EqualError(errWithCode, "account "+targetID+" is not a local account, so has no timelines to regenerate")
+	suite.Empty(actionID)
+}
+
+func (suite *AccountTestSuite) TestAccountActionRecountStats() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		targetID  = suite.testAccounts["local_account_1"].ID
+		request   = &apimodel.AdminActionRequest{
+			Category: gtsmodel.AdminActionCategoryAccount.String(),
+			Type:     gtsmodel.AdminActionRecountStats.String(),
+			TargetID: targetID,
+		}
+	)
+
+	targetAcct, err := suite.db.GetAccountByID(ctx, targetID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.db.PopulateAccountStats(ctx, targetAcct); err != nil {
+		suite.FailNow(err.Error())
+	}
+	expect := *targetAcct.Stats
+
+	// Make the stats drift.
+	drifted := expect
+	drifted.FollowersCount = util.Ptr(666)
+	drifted.FollowingCount = util.Ptr(666)
+	drifted.StatusesCount = util.Ptr(666)
+	if err := suite.db.UpdateAccountStats(ctx,
+		&drifted,
+		"followers_count",
+		"following_count",
+		"statuses_count",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	actionID, errWithCode := suite.adminProcessor.AccountAction(
+		ctx,
+		adminAcct,
+		request,
+	)
+	suite.NoError(errWithCode)
+	suite.NotEmpty(actionID)
+
+	// Wait for action to finish.
+	if !testrig.WaitFor(func() bool {
+		return suite.adminProcessor.Actions().TotalRunning() == 0
+	}) {
+		suite.FailNow("timed out waiting for admin action(s) to finish")
+	}
+
+	adminAction, err := suite.db.GetAdminAction(ctx, actionID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.NotZero(adminAction.CompletedAt)
+	suite.Empty(adminAction.Errors)
+
+	// Stats should be back to what they were.
+	targetAcct.Stats = nil
+	if err := suite.db.PopulateAccountStats(ctx, targetAcct); err != nil {
+		suite.FailNow(err.Error())
+	}
+	stats := targetAcct.Stats
+	suite.Equal(*expect.FollowersCount, *stats.FollowersCount)
+	suite.Equal(*expect.FollowingCount, *stats.FollowingCount)
+	suite.Equal(*expect.StatusesCount, *stats.StatusesCount)
+}
+
+func (suite *AccountTestSuite) TestAccountActionFreeze() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		request   = &apimodel.AdminActionRequest{
+			Category: gtsmodel.AdminActionCategoryAccount.String(),
+			Type:     gtsmodel.AdminActionFreeze.String(),
+			Text:     "take a breather",
+			Duration: util.Ptr(3600),
+			TargetID: suite.testAccounts["local_account_1"].ID,
+		}
+	)
+
+	actionID, errWithCode := suite.adminProcessor.AccountAction(
+		ctx,
+		adminAcct,
+		request,
+	)
+	suite.NoError(errWithCode)
+	suite.NotEmpty(actionID)
+
+	// Wait for action to finish.
+	if !testrig.WaitFor(func() bool {
+		return suite.adminProcessor.Actions().TotalRunning() == 0
+	}) {
+		suite.FailNow("timed out waiting for admin action(s) to finish")
+	}
+
+	adminAction, err := suite.db.GetAdminAction(ctx, actionID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.NotZero(adminAction.CompletedAt)
+	suite.Empty(adminAction.Errors)
+
+	// Ensure user disabled until about an hour from now.
+	user, err := suite.db.GetUserByAccountID(ctx, request.TargetID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.True(*user.Disabled)
+	suite.WithinDuration(time.Now().Add(time.Hour), user.FrozenUntil, time.Minute)
+
+	// Ensure user was emailed.
+	suite.Contains(suite.sentEmails[user.Email], "Subject: GoToSocial Account Frozen")
+	suite.Contains(suite.sentEmails[user.Email], `included the following message: "take a breather"`)
+
+	// Account should not be suspended.
+	targetAcct, err := suite.db.GetAccountByID(ctx, request.TargetID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Zero(targetAcct.SuspendedAt)
+}
+
+func (suite *AccountTestSuite) TestAccountActionFreezeElapses() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		request   = &apimodel.AdminActionRequest{
+			Category: gtsmodel.AdminActionCategoryAccount.String(),
+			Type:     gtsmodel.AdminActionFreeze.String(),
+			Duration: util.Ptr(1),
+			TargetID: suite.testAccounts["local_account_1"].ID,
+		}
+	)
+
+	_, errWithCode := suite.adminProcessor.AccountAction(
+		ctx,
+		adminAcct,
+		request,
+	)
+	suite.NoError(errWithCode)
+
+	// Scheduler should reenable
+	// the user once freeze elapses.
+	if !testrig.WaitFor(func() bool {
+		user, err := suite.db.GetUserByAccountID(ctx, request.TargetID)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		return !*user.Disabled && user.FrozenUntil.IsZero()
+	}) {
+		suite.FailNow("timed out waiting for user to be unfrozen")
+	}
+}
+
+func (suite *AccountTestSuite) TestAccountActionFreezeNoDuration() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		request   = &apimodel.AdminActionRequest{
+			Category: gtsmodel.AdminActionCategoryAccount.String(),
+			Type:     gtsmodel.AdminActionFreeze.String(),
+			TargetID: suite.testAccounts["local_account_1"].ID,
+		}
+	)
+
+	actionID, errWithCode := suite.adminProcessor.AccountAction(
+		ctx,
+		adminAcct,
+		request,
+	)
+	suite.EqualError(errWithCode, "freeze requires a duration")
+	suite.Empty(actionID)
+}
+
+func (suite *AccountTestSuite) TestAccountActionFreezeRemote() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		request   = &apimodel.AdminActionRequest{
+			Category: gtsmodel.AdminActionCategoryAccount.String(),
+			Type:     gtsmodel.AdminActionFreeze.String(),
+			Duration: util.Ptr(3600),
+			TargetID: suite.testAccounts["remote_account_1"].ID,
+		}
+	)
+
+	actionID, errWithCode := suite.adminProcessor.AccountAction(
+		ctx,
+		adminAcct,
+		request,
+	)
+	suite.EqualError(errWithCode, "account "+request.TargetID+" is not a local account, so cannot be frozen")
+	suite.Empty(actionID)
+}
+
 func (suite *AccountTestSuite) TestAccountActionUnsupported() {
 	var (
 		ctx       = context.Background()
@@ -94,7 +368,7 @@ func (suite *AccountTestSuite) TestAccountActionUnsupported() {
 		adminAcct,
 		request,
 	)
-	suite.EqualError(errWithCode, "admin action type pee pee poo poo is not supported for this endpoint, currently supported types are: [\"suspend\"]")
+	suite.EqualError(errWithCode, "admin action type pee pee poo poo is not supported for this endpoint, currently supported types are: [\"suspend\" \"regenerate-timelines\" \"recount-stats\" \"freeze\"]")
 	suite.Empty(actionID)
 }
 
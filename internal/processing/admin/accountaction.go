@@ -19,16 +19,24 @@ package admin
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/timeline"
 )
 
+// regenerateTimelineLength is the number of items
+// to prepare for each timeline that is regenerated.
+const regenerateTimelineLength = 400
+
 func (p *Processor) AccountAction(
 	ctx context.Context,
 	adminAcct *gtsmodel.Account,
@@ -44,11 +52,23 @@ func (p *Processor) AccountAction(
 	case gtsmodel.AdminActionSuspend:
 		return p.accountActionSuspend(ctx, adminAcct, targetAcct, request.Text)
 
+	case gtsmodel.AdminActionRegenerateTimelines:
+		return p.accountActionRegenerateTimelines(ctx, adminAcct, targetAcct, request.Text)
+
+	case gtsmodel.AdminActionRecountStats:
+		return p.accountActionRecountStats(ctx, adminAcct, targetAcct, request.Text)
+
+	case gtsmodel.AdminActionFreeze:
+		return p.accountActionFreeze(ctx, adminAcct, targetAcct, request)
+
 	default:
 		// TODO: add more types to this slice when adding
 		//       more types to the switch statement above.
 		supportedTypes := []string{
 			gtsmodel.AdminActionSuspend.String(),
+			gtsmodel.AdminActionRegenerateTimelines.String(),
+			gtsmodel.AdminActionRecountStats.String(),
+			gtsmodel.AdminActionFreeze.String(),
 		}
 
 		err := fmt.Errorf(
@@ -100,3 +120,115 @@ func (p *Processor) accountActionSuspend(
 
 	return actionID, errWithCode
 }
+
+func (p *Processor) accountActionRegenerateTimelines(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+	text string,
+) (string, gtserror.WithCode) {
+	if !targetAcct.IsLocal() {
+		err := fmt.Errorf("account %s is not a local account, so has no timelines to regenerate", targetAcct.ID)
+		return "", gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	actionID := id.NewULID()
+
+	errWithCode := p.actions.Run(
+		ctx,
+		&gtsmodel.AdminAction{
+			ID:             actionID,
+			TargetCategory: gtsmodel.AdminActionCategoryAccount,
+			TargetID:       targetAcct.ID,
+			Target:         targetAcct,
+			Type:           gtsmodel.AdminActionRegenerateTimelines,
+			AccountID:      adminAcct.ID,
+			Text:           text,
+		},
+		func(ctx context.Context) gtserror.MultiError {
+			return p.regenerateTimelines(ctx, targetAcct)
+		},
+	)
+
+	return actionID, errWithCode
+}
+
+func (p *Processor) accountActionRecountStats(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+	text string,
+) (string, gtserror.WithCode) {
+	actionID := id.NewULID()
+
+	errWithCode := p.actions.Run(
+		ctx,
+		&gtsmodel.AdminAction{
+			ID:             actionID,
+			TargetCategory: gtsmodel.AdminActionCategoryAccount,
+			TargetID:       targetAcct.ID,
+			Target:         targetAcct,
+			Type:           gtsmodel.AdminActionRecountStats,
+			AccountID:      adminAcct.ID,
+			Text:           text,
+		},
+		func(ctx context.Context) gtserror.MultiError {
+			// Recount stats from the source-of-truth
+			// tables, overwriting any drifted counts.
+			if err := p.state.DB.RegenerateAccountStats(ctx, targetAcct); err != nil {
+				errs := gtserror.NewMultiError(1)
+				errs.Appendf("db error recounting stats for account %s: %w", targetAcct.ID, err)
+				return errs
+			}
+
+			return nil
+		},
+	)
+
+	return actionID, errWithCode
+}
+
+// regenerateTimelines purges and rebuilds the home
+// timeline and list timelines of the given account,
+// logging progress as it goes.
+func (p *Processor) regenerateTimelines(ctx context.Context, account *gtsmodel.Account) gtserror.MultiError {
+	var errs gtserror.MultiError
+
+	regenerate := func(name string, manager timeline.Manager, timelineID string) {
+		l := log.
+			WithContext(ctx).
+			WithField("timeline", name).
+			WithField("timelineID", timelineID)
+		l.Info("regenerating timeline")
+
+		prepared, err := timeline.Regenerate(ctx,
+			manager,
+			timelineID,
+			regenerateTimelineLength,
+			func(prepared int) {
+				l.Infof("prepared %d/%d items", prepared, regenerateTimelineLength)
+			},
+		)
+		if err != nil {
+			errs.Appendf("error regenerating %s timeline %s: %w", name, timelineID, err)
+			return
+		}
+
+		l.Infof("regenerated timeline with %d items", prepared)
+	}
+
+	// Home timeline ID is the account's ID.
+	regenerate("home", p.state.Timelines.Home, account.ID)
+
+	lists, err := p.state.DB.GetListsForAccountID(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		errs.Appendf("db error getting lists for account %s: %w", account.ID, err)
+		return errs
+	}
+
+	for _, list := range lists {
+		regenerate("list", p.state.Timelines.List, list.ID)
+	}
+
+	return errs
+}
@@ -0,0 +1,102 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// ScheduleDeliveryLogPrune schedules regular pruning of
+// expired entries from the outgoing delivery log, so that
+// entries of actors that stop delivering are still freed.
+func (p *Processor) ScheduleDeliveryLogPrune() error {
+	if !p.state.Workers.Scheduler.AddRecurring(
+		"@deliverylogprune",
+		time.Now().Add(10*time.Minute),
+		10*time.Minute,
+		func(_ context.Context, now time.Time) {
+			p.state.Workers.Delivery.Log.Prune(now)
+		},
+	) {
+		return gtserror.New("failed to schedule @deliverylogprune")
+	}
+
+	return nil
+}
+
+// AccountDeliveriesGet returns recent outgoing deliveries of
+// activities by the given local account, newest first. If domain
+// is set, only deliveries to inboxes on that domain are returned.
+func (p *Processor) AccountDeliveriesGet(
+	ctx context.Context,
+	accountID string,
+	domain string,
+) ([]*apimodel.AdminAccountDelivery, gtserror.WithCode) {
+	account, err := p.state.DB.GetAccountByID(ctx, accountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting account %s: %w", accountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if account == nil {
+		err := fmt.Errorf("account %s not found", accountID)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	if !account.IsLocal() {
+		err := fmt.Errorf("account %s is not a local account", accountID)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	entries := p.state.Workers.Delivery.Log.Get(account.URI)
+	deliveries := make([]*apimodel.AdminAccountDelivery, 0, len(entries))
+
+	for _, entry := range entries {
+		var host string
+		if u, err := url.Parse(entry.Inbox); err == nil {
+			host = u.Host
+		}
+
+		if domain != "" && host != domain {
+			continue
+		}
+
+		deliveries = append(deliveries, &apimodel.AdminAccountDelivery{
+			ActivityType: entry.ActivityType,
+			ObjectID:     entry.ObjectID,
+			Inbox:        entry.Inbox,
+			Domain:       host,
+			Status:       string(entry.Status),
+			Attempts:     entry.Attempts,
+			Error:        entry.Error,
+			CreatedAt:    util.FormatISO8601(entry.CreatedAt),
+			UpdatedAt:    util.FormatISO8601(entry.UpdatedAt),
+		})
+	}
+
+	return deliveries, nil
+}
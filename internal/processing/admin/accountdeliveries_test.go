@@ -0,0 +1,93 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
+	"github.com/superseriousbusiness/gotosocial/internal/transport/delivery"
+)
+
+type AccountDeliveriesTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *AccountDeliveriesTestSuite) TestAccountDeliveriesGet() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_1"]
+	)
+
+	var dlvs []*delivery.Delivery
+	for _, inbox := range []string{
+		"http://fossbros-anonymous.io/users/foss_satan/inbox",
+		"http://example.org/users/Some_User/inbox",
+	} {
+		req, err := http.NewRequest(http.MethodPost, inbox, nil)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		dlvs = append(dlvs, &delivery.Delivery{
+			ActorID:      account.URI,
+			ObjectID:     account.URI + "/statuses/01F8MHAMCHF6Y650WCRSCP4WMY",
+			ActivityType: "Create",
+			Request:      httpclient.WrapRequest(req),
+		})
+	}
+	suite.state.Workers.Delivery.Log.Queued(dlvs...)
+
+	// Get all deliveries.
+	deliveries, errWithCode := suite.adminProcessor.AccountDeliveriesGet(ctx, account.ID, "")
+	suite.NoError(errWithCode)
+	suite.Len(deliveries, 2)
+
+	// Newest first.
+	suite.Equal("example.org", deliveries[0].Domain)
+	suite.Equal("http://example.org/users/Some_User/inbox", deliveries[0].Inbox)
+	suite.Equal("Create", deliveries[0].ActivityType)
+	suite.Equal("queued", deliveries[0].Status)
+	suite.Zero(deliveries[0].Attempts)
+	suite.Equal("fossbros-anonymous.io", deliveries[1].Domain)
+
+	// Get deliveries to one domain.
+	deliveries, errWithCode = suite.adminProcessor.AccountDeliveriesGet(ctx, account.ID, "fossbros-anonymous.io")
+	suite.NoError(errWithCode)
+	suite.Len(deliveries, 1)
+	suite.Equal("http://fossbros-anonymous.io/users/foss_satan/inbox", deliveries[0].Inbox)
+}
+
+func (suite *AccountDeliveriesTestSuite) TestAccountDeliveriesGetRemote() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["remote_account_1"]
+	)
+
+	deliveries, errWithCode := suite.adminProcessor.AccountDeliveriesGet(ctx, account.ID, "")
+	suite.Nil(deliveries)
+	suite.EqualError(errWithCode, "account "+account.ID+" is not a local account")
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func TestAccountDeliveriesTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountDeliveriesTestSuite))
+}
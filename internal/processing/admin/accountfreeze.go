@@ -0,0 +1,226 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// maxFreezeDuration is the maximum duration
+// for which an account can be frozen.
+const maxFreezeDuration = 365 * 24 * time.Hour
+
+func (p *Processor) accountActionFreeze(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+	request *apimodel.AdminActionRequest,
+) (string, gtserror.WithCode) {
+	if !targetAcct.IsLocal() {
+		err := fmt.Errorf("account %s is not a local account, so cannot be frozen", targetAcct.ID)
+		return "", gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if request.Duration == nil {
+		const text = "freeze requires a duration"
+		return "", gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	duration := time.Duration(*request.Duration) * time.Second
+	if duration <= 0 || duration > maxFreezeDuration {
+		err := fmt.Errorf("duration must be between 1 and %d seconds", int(maxFreezeDuration.Seconds()))
+		return "", gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	user, err := p.state.DB.GetUserByAccountID(ctx, targetAcct.ID)
+	if err != nil {
+		err := gtserror.Newf("db error getting user for account %s: %w", targetAcct.ID, err)
+		return "", gtserror.NewErrorInternalError(err)
+	}
+
+	if *user.Disabled && user.FrozenUntil.IsZero() {
+		// Don't allow a freeze to end up
+		// reenabling a disabled account.
+		err := fmt.Errorf("account %s is already disabled", targetAcct.ID)
+		return "", gtserror.NewErrorConflict(err, err.Error())
+	}
+
+	actionID := id.NewULID()
+	frozenUntil := time.Now().Add(duration)
+
+	errWithCode := p.actions.Run(
+		ctx,
+		&gtsmodel.AdminAction{
+			ID:             actionID,
+			TargetCategory: gtsmodel.AdminActionCategoryAccount,
+			TargetID:       targetAcct.ID,
+			Target:         targetAcct,
+			Type:           gtsmodel.AdminActionFreeze,
+			AccountID:      adminAcct.ID,
+			Text:           request.Text,
+		},
+		func(ctx context.Context) gtserror.MultiError {
+			var errs gtserror.MultiError
+
+			// Disable the user until the freeze elapses. Token
+			// and sign-in checks already refuse disabled users,
+			// so nothing more is needed to lock them out.
+			user.Disabled = util.Ptr(true)
+			user.FrozenUntil = frozenUntil
+			if err := p.state.DB.UpdateUser(ctx, user, "disabled", "frozen_until"); err != nil {
+				errs.Appendf("db error freezing user %s: %w", user.ID, err)
+				return errs
+			}
+
+			// Replace any previously scheduled unfreeze.
+			p.unscheduleUnfreeze(user.ID)
+			p.scheduleUnfreeze(ctx, user)
+
+			if err := p.emailUserFrozen(ctx, targetAcct, user, request.Text); err != nil {
+				errs.Appendf("error emailing user %s: %w", user.ID, err)
+			}
+
+			return errs
+		},
+	)
+
+	return actionID, errWithCode
+}
+
+// emailUserFrozen lets the given user know that
+// their account has been temporarily frozen.
+func (p *Processor) emailUserFrozen(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	user *gtsmodel.User,
+	reason string,
+) error {
+	if user.Email == "" {
+		// Nowhere to
+		// send email.
+		return nil
+	}
+
+	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return gtserror.Newf("db error getting instance: %w", err)
+	}
+
+	return p.emailSender.SendAccountFrozenEmail(
+		user.Email,
+		email.AccountFrozenData{
+			Username:     account.Username,
+			InstanceURL:  instance.URI,
+			InstanceName: instance.Title,
+			FrozenUntil:  user.FrozenUntil.UTC().Format("Jan _2 2006 15:04:05 MST"),
+			Reason:       reason,
+		},
+	)
+}
+
+// ScheduleUnfreezes schedules reenabling
+// all users that are currently frozen.
+func (p *Processor) ScheduleUnfreezes(ctx context.Context) error {
+	// Fetch all frozen users from the database (barebones models are enough).
+	users, err := p.state.DB.GetFrozenUsers(gtscontext.SetBarebones(ctx))
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting frozen users from db: %w", err)
+	}
+
+	for _, user := range users {
+		p.scheduleUnfreeze(ctx, user)
+	}
+
+	return nil
+}
+
+func (p *Processor) scheduleUnfreeze(ctx context.Context, user *gtsmodel.User) {
+	if user.FrozenUntil.IsZero() {
+		// Nothing to schedule.
+		return
+	}
+
+	if !p.state.Workers.Scheduler.AddOnce(
+		unfreezeID(user.ID),
+		user.FrozenUntil,
+		p.onUnfreeze(user.ID),
+	) {
+		log.Warnf(ctx, "failed adding user %s unfreeze to scheduler", user.ID)
+		return
+	}
+
+	atStr := user.FrozenUntil.Local().Format("Jan _2 2006 15:04:05")
+	log.Infof(ctx, "scheduled unfreeze for user %s at '%s'", user.ID, atStr)
+}
+
+func (p *Processor) unscheduleUnfreeze(userID string) {
+	_ = p.state.Workers.Scheduler.Cancel(unfreezeID(userID))
+}
+
+// onUnfreeze returns a callback function to be used
+// by the scheduler when the given user's freeze elapses.
+func (p *Processor) onUnfreeze(userID string) func(context.Context, time.Time) {
+	return func(ctx context.Context, now time.Time) {
+		// Get the latest version of user from database.
+		user, err := p.state.DB.GetUserByID(ctx, userID)
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				log.Errorf(ctx, "error getting user %s from db: %v", userID, err)
+			}
+
+			// User was removed in
+			// the meantime, all good.
+			return
+		}
+
+		if user.FrozenUntil.IsZero() || user.FrozenUntil.After(now) {
+			// Freeze was changed in
+			// the meantime, all good.
+			return
+		}
+
+		user.Disabled = util.Ptr(false)
+		user.FrozenUntil = time.Time{}
+		if err := p.state.DB.UpdateUser(ctx, user, "disabled", "frozen_until"); err != nil {
+			log.Errorf(ctx, "db error unfreezing user %s: %v", userID, err)
+			return
+		}
+
+		log.Infof(ctx, "unfroze user %s", userID)
+	}
+}
+
+// unfreezeID returns the scheduler
+// task ID for the given user's unfreeze.
+func unfreezeID(userID string) string {
+	return "unfreeze-" + userID
+}
@@ -0,0 +1,144 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// AccountProfileEdit edits or clears the display name, bio,
+// and/or profile fields of the given local account on its
+// behalf, eg., to remove abusive content from a profile
+// while waiting for the account owner to respond.
+//
+// The edit is recorded as an admin action, and the
+// updated profile is federated out as usual.
+func (p *Processor) AccountProfileEdit(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	form *apimodel.AdminAccountProfileRequest,
+) (string, gtserror.WithCode) {
+	if form.DisplayName == nil &&
+		form.Note == nil &&
+		form.FieldsAttributes == nil {
+		const errText = "at least one of display_name, note, or fields_attributes must be set"
+		return "", gtserror.NewErrorBadRequest(errors.New(errText), errText)
+	}
+
+	targetAcct, err := p.state.DB.GetAccountByID(ctx, form.TargetID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting account %s: %w", form.TargetID, err)
+		return "", gtserror.NewErrorInternalError(err)
+	}
+
+	if targetAcct == nil || targetAcct.IsInstance() {
+		err := fmt.Errorf("account %s not found", form.TargetID)
+		return "", gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	if !targetAcct.IsLocal() {
+		err := fmt.Errorf("account %s is not a local account", form.TargetID)
+		return "", gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if targetAcct.IsSuspended() {
+		err := fmt.Errorf("account %s is suspended", form.TargetID)
+		return "", gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	// Validate the new values up front, so
+	// the caller gets a useful error instead
+	// of it only being stored on the action.
+	if form.DisplayName != nil {
+		if err := validate.DisplayName(*form.DisplayName); err != nil {
+			return "", gtserror.NewErrorBadRequest(err, err.Error())
+		}
+	}
+
+	if form.Note != nil {
+		if err := validate.Note(*form.Note); err != nil {
+			return "", gtserror.NewErrorBadRequest(err, err.Error())
+		}
+	}
+
+	if form.FieldsAttributes != nil {
+		fields := make([]*gtsmodel.Field, 0, len(*form.FieldsAttributes))
+		for _, field := range *form.FieldsAttributes {
+			if field.Name == nil || field.Value == nil ||
+				*field.Name == "" || *field.Value == "" {
+				continue
+			}
+
+			fields = append(fields, &gtsmodel.Field{
+				Name:  text.SanitizeToPlaintext(*field.Name),
+				Value: text.SanitizeToPlaintext(*field.Value),
+			})
+		}
+
+		if err := validate.ProfileFields(fields); err != nil {
+			return "", gtserror.NewErrorBadRequest(err, err.Error())
+		}
+	}
+
+	actionID := id.NewULID()
+
+	// Update the profile asynchronously, via
+	// the usual account update logic, so that
+	// formatting, emojis, and federating the
+	// Update are handled exactly as if the
+	// account owner had made the change.
+	errWithCode := p.actions.Run(
+		ctx,
+		&gtsmodel.AdminAction{
+			ID:             actionID,
+			TargetCategory: gtsmodel.AdminActionCategoryAccount,
+			TargetID:       targetAcct.ID,
+			Target:         targetAcct,
+			Type:           gtsmodel.AdminActionProfileEdit,
+			AccountID:      adminAcct.ID,
+			Text:           form.Text,
+		},
+		func(ctx context.Context) gtserror.MultiError {
+			if _, errWithCode := p.account.Update(
+				ctx,
+				targetAcct,
+				&apimodel.UpdateCredentialsRequest{
+					DisplayName:      form.DisplayName,
+					Note:             form.Note,
+					FieldsAttributes: form.FieldsAttributes,
+				},
+			); errWithCode != nil {
+				return gtserror.MultiError{errWithCode}
+			}
+
+			return nil
+		},
+	)
+
+	return actionID, errWithCode
+}
@@ -0,0 +1,126 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type AccountProfileTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *AccountProfileTestSuite) TestAccountProfileClear() {
+	var (
+		ctx        = context.Background()
+		adminAcct  = suite.testAccounts["admin_account"]
+		targetAcct = suite.testAccounts["local_account_1"]
+	)
+
+	actionID, errWithCode := suite.adminProcessor.AccountProfileEdit(
+		ctx,
+		adminAcct,
+		&apimodel.AdminAccountProfileRequest{
+			DisplayName:      util.Ptr(""),
+			Note:             util.Ptr("this bio was removed by a moderator"),
+			FieldsAttributes: &[]apimodel.UpdateField{},
+			Text:             "slurs in bio",
+			TargetID:         targetAcct.ID,
+		},
+	)
+	suite.NoError(errWithCode)
+	suite.NotEmpty(actionID)
+
+	// Wait for action to finish.
+	if !testrig.WaitFor(func() bool {
+		return suite.adminProcessor.Actions().TotalRunning() == 0
+	}) {
+		suite.FailNow("timed out waiting for admin action(s) to finish")
+	}
+
+	adminAction, err := suite.db.GetAdminAction(ctx, actionID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(targetAcct.ID, adminAction.TargetID)
+	suite.Equal(gtsmodel.AdminActionProfileEdit, adminAction.Type)
+	suite.Equal("slurs in bio", adminAction.Text)
+	suite.Empty(adminAction.Errors)
+
+	// Profile should be updated.
+	dbAcct, err := suite.db.GetAccountByID(ctx, targetAcct.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(dbAcct.DisplayName)
+	suite.Equal("this bio was removed by a moderator", dbAcct.NoteRaw)
+	suite.Equal("<p>this bio was removed by a moderator</p>", dbAcct.Note)
+	suite.Empty(dbAcct.Fields)
+	suite.Empty(dbAcct.FieldsRaw)
+
+}
+
+func (suite *AccountProfileTestSuite) TestAccountProfileEmpty() {
+	var (
+		ctx        = context.Background()
+		adminAcct  = suite.testAccounts["admin_account"]
+		targetAcct = suite.testAccounts["local_account_1"]
+	)
+
+	actionID, errWithCode := suite.adminProcessor.AccountProfileEdit(
+		ctx,
+		adminAcct,
+		&apimodel.AdminAccountProfileRequest{
+			TargetID: targetAcct.ID,
+		},
+	)
+	suite.Empty(actionID)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+	suite.Equal("Bad Request: at least one of display_name, note, or fields_attributes must be set", errWithCode.Safe())
+}
+
+func (suite *AccountProfileTestSuite) TestAccountProfileRemote() {
+	var (
+		ctx        = context.Background()
+		adminAcct  = suite.testAccounts["admin_account"]
+		targetAcct = suite.testAccounts["remote_account_1"]
+	)
+
+	actionID, errWithCode := suite.adminProcessor.AccountProfileEdit(
+		ctx,
+		adminAcct,
+		&apimodel.AdminAccountProfileRequest{
+			DisplayName: util.Ptr(""),
+			TargetID:    targetAcct.ID,
+		},
+	)
+	suite.Empty(actionID)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func TestAccountProfileTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountProfileTestSuite))
+}
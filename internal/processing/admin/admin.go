@@ -22,7 +22,11 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/account"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/status"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/stream"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 )
@@ -34,6 +38,11 @@ type Processor struct {
 	mediaManager        *media.Manager
 	transportController transport.Controller
 	emailSender         email.Sender
+	formatter           *text.Formatter
+	parseMentionFunc    gtsmodel.ParseMentionFunc
+	account             *account.Processor
+	stream              *stream.Processor
+	status              *status.Processor
 
 	// admin Actions currently
 	// undergoing processing
@@ -52,6 +61,10 @@ func New(
 	mediaManager *media.Manager,
 	transportController transport.Controller,
 	emailSender email.Sender,
+	parseMentionFunc gtsmodel.ParseMentionFunc,
+	account *account.Processor,
+	stream *stream.Processor,
+	status *status.Processor,
 ) Processor {
 	return Processor{
 		state:               state,
@@ -60,6 +73,11 @@ func New(
 		mediaManager:        mediaManager,
 		transportController: transportController,
 		emailSender:         emailSender,
+		formatter:           text.NewFormatter(state.DB),
+		parseMentionFunc:    parseMentionFunc,
+		account:             account,
+		stream:              stream,
+		status:              status,
 
 		actions: &Actions{
 			r:     make(map[string]*gtsmodel.AdminAction),
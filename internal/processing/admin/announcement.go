@@ -0,0 +1,444 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// AnnouncementsGet returns all announcements stored on
+// this instance, including unpublished ones, newest first.
+func (p *Processor) AnnouncementsGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) ([]*apimodel.Announcement, gtserror.WithCode) {
+	announcements, err := p.state.DB.GetAnnouncements(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting announcements: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiAnnouncements := make([]*apimodel.Announcement, 0, len(announcements))
+	for _, announcement := range announcements {
+		apiAnnouncement, err := p.converter.AnnouncementToAPIAnnouncement(ctx, requester, announcement)
+		if err != nil {
+			log.Errorf(ctx, "error converting announcement %s: %v", announcement.ID, err)
+			continue
+		}
+		apiAnnouncements = append(apiAnnouncements, apiAnnouncement)
+	}
+
+	return apiAnnouncements, nil
+}
+
+// AnnouncementGet returns one announcement, with the given ID.
+func (p *Processor) AnnouncementGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	id string,
+) (*apimodel.Announcement, gtserror.WithCode) {
+	announcement, errWithCode := p.getAnnouncement(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiAnnouncement(ctx, requester, announcement)
+}
+
+// AnnouncementCreate creates a new announcement from the given
+// form, scheduling its start and end if they're in the future.
+func (p *Processor) AnnouncementCreate(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	form *apimodel.AnnouncementCreateRequest,
+) (*apimodel.Announcement, gtserror.WithCode) {
+	announcement := &gtsmodel.Announcement{
+		ID:        id.NewULID(),
+		AllDay:    &form.AllDay,
+		Published: util.Ptr(util.PtrValueOr(form.Published, true)),
+	}
+
+	if errWithCode := p.setAnnouncementText(ctx, announcement, form.Text); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	var err error
+
+	if announcement.StartsAt, err = parseAnnouncementTime(form.StartsAt); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if announcement.EndsAt, err = parseAnnouncementTime(form.EndsAt); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if errWithCode := validateAnnouncementTimes(announcement); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if announcement.IsPublished() {
+		announcement.PublishedAt = time.Now()
+	}
+
+	if err := p.state.DB.PutAnnouncement(ctx, announcement); err != nil {
+		err := gtserror.Newf("db error putting announcement: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Stream or schedule the announcement.
+	p.announcementChanged(ctx, announcement)
+
+	return p.apiAnnouncement(ctx, requester, announcement)
+}
+
+// AnnouncementUpdate updates an existing announcement with any fields set
+// in the given form, then (re)streams and (re)schedules it as appropriate.
+func (p *Processor) AnnouncementUpdate(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	id string,
+	form *apimodel.AnnouncementUpdateRequest,
+) (*apimodel.Announcement, gtserror.WithCode) {
+	announcement, errWithCode := p.getAnnouncement(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Track whether the announcement was
+	// visible to users before this update.
+	wasActive := announcement.Active(time.Now())
+
+	if form.Text != nil {
+		if errWithCode := p.setAnnouncementText(ctx, announcement, *form.Text); errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	var err error
+
+	if form.StartsAt != nil {
+		if announcement.StartsAt, err = parseAnnouncementTime(*form.StartsAt); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+	}
+
+	if form.EndsAt != nil {
+		if announcement.EndsAt, err = parseAnnouncementTime(*form.EndsAt); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+	}
+
+	if errWithCode := validateAnnouncementTimes(announcement); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if form.AllDay != nil {
+		announcement.AllDay = form.AllDay
+	}
+
+	if form.Published != nil {
+		if *form.Published && !announcement.IsPublished() {
+			// Being (re)published now.
+			announcement.PublishedAt = time.Now()
+		}
+		announcement.Published = form.Published
+	}
+
+	if err := p.state.DB.UpdateAnnouncement(ctx, announcement); err != nil {
+		err := gtserror.Newf("db error updating announcement: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Cancel any previously scheduled
+	// start / end, then stream or
+	// reschedule with updated times.
+	p.unscheduleAnnouncement(announcement.ID)
+	if wasActive && !announcement.Active(time.Now()) {
+		// No longer visible, remove it from clients.
+		p.stream.AnnouncementDelete(ctx, announcement.ID)
+	}
+	p.announcementChanged(ctx, announcement)
+
+	return p.apiAnnouncement(ctx, requester, announcement)
+}
+
+// AnnouncementDelete deletes an existing announcement,
+// along with any reads and reactions pertaining to it.
+func (p *Processor) AnnouncementDelete(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	id string,
+) (*apimodel.Announcement, gtserror.WithCode) {
+	announcement, errWithCode := p.getAnnouncement(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Convert before deletion, so reactions are still included.
+	apiAnnouncement, errWithCode := p.apiAnnouncement(ctx, requester, announcement)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteAnnouncementByID(ctx, announcement.ID); err != nil {
+		err := gtserror.Newf("db error deleting announcement: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.unscheduleAnnouncement(announcement.ID)
+	p.stream.AnnouncementDelete(ctx, announcement.ID)
+
+	return apiAnnouncement, nil
+}
+
+// ScheduleAnnouncements schedules stream events for the start
+// and end of all published announcements that are yet to occur.
+func (p *Processor) ScheduleAnnouncements(ctx context.Context) error {
+	announcements, err := p.state.DB.GetScheduledAnnouncements(
+		gtscontext.SetBarebones(ctx),
+		time.Now(),
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting scheduled announcements from db: %w", err)
+	}
+
+	for _, announcement := range announcements {
+		p.scheduleAnnouncement(ctx, announcement)
+	}
+
+	return nil
+}
+
+func (p *Processor) getAnnouncement(ctx context.Context, id string) (*gtsmodel.Announcement, gtserror.WithCode) {
+	announcement, err := p.state.DB.GetAnnouncementByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting announcement %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if announcement == nil {
+		err := fmt.Errorf("announcement %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	return announcement, nil
+}
+
+func (p *Processor) apiAnnouncement(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	announcement *gtsmodel.Announcement,
+) (*apimodel.Announcement, gtserror.WithCode) {
+	apiAnnouncement, err := p.converter.AnnouncementToAPIAnnouncement(ctx, requester, announcement)
+	if err != nil {
+		err := gtserror.Newf("error converting announcement: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiAnnouncement, nil
+}
+
+// setAnnouncementText sets the text of the given announcement, and
+// formats it into html content with mentions, tags, and emojis.
+func (p *Processor) setAnnouncementText(
+	ctx context.Context,
+	announcement *gtsmodel.Announcement,
+	text string,
+) gtserror.WithCode {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		const errText = "announcement text must not be empty"
+		return gtserror.NewErrorBadRequest(errors.New(errText), errText)
+	}
+
+	instanceAcc, err := p.state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		err := gtserror.Newf("db error getting instance account: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// Format announcement text as markdown,
+	// the same way as instance descriptions.
+	result := p.formatter.FromMarkdown(ctx,
+		p.parseMentionFunc,
+		instanceAcc.ID,
+		"",
+		text,
+	)
+
+	announcement.Text = text
+	announcement.Content = result.HTML
+
+	announcement.MentionedAccountIDs = make([]string, 0, len(result.Mentions))
+	announcement.MentionedAccounts = make([]*gtsmodel.Account, 0, len(result.Mentions))
+	for _, mention := range result.Mentions {
+		announcement.MentionedAccountIDs = append(announcement.MentionedAccountIDs, mention.TargetAccountID)
+		announcement.MentionedAccounts = append(announcement.MentionedAccounts, mention.TargetAccount)
+	}
+
+	announcement.TagIDs = make([]string, len(result.Tags))
+	for i, tag := range result.Tags {
+		announcement.TagIDs[i] = tag.ID
+	}
+	announcement.Tags = result.Tags
+
+	announcement.EmojiIDs = make([]string, len(result.Emojis))
+	for i, emoji := range result.Emojis {
+		announcement.EmojiIDs[i] = emoji.ID
+	}
+	announcement.Emojis = result.Emojis
+
+	return nil
+}
+
+// announcementChanged streams the given announcement to
+// users if it's currently active, and schedules stream
+// events for its start and end if they're yet to occur.
+func (p *Processor) announcementChanged(ctx context.Context, announcement *gtsmodel.Announcement) {
+	if announcement.Active(time.Now()) {
+		p.streamAnnouncement(ctx, announcement)
+	}
+	p.scheduleAnnouncement(ctx, announcement)
+}
+
+func (p *Processor) streamAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement) {
+	// Convert without a requester, as the
+	// streamed announcement goes to everyone.
+	apiAnnouncement, err := p.converter.AnnouncementToAPIAnnouncement(ctx, nil, announcement)
+	if err != nil {
+		log.Errorf(ctx, "error converting announcement %s: %v", announcement.ID, err)
+		return
+	}
+	p.stream.Announcement(ctx, apiAnnouncement)
+}
+
+func (p *Processor) scheduleAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement) {
+	if !announcement.IsPublished() {
+		// Nothing to schedule.
+		return
+	}
+
+	now := time.Now()
+
+	if announcement.StartsAt.After(now) {
+		_ = p.state.Workers.Scheduler.AddOnce(
+			announcementStartID(announcement.ID),
+			announcement.StartsAt,
+			p.onAnnouncementStart(announcement.ID),
+		)
+
+		atStr := announcement.StartsAt.Local().Format("Jan _2 2006 15:04:05")
+		log.Infof(ctx, "scheduled announcement start for %s at '%s'", announcement.ID, atStr)
+	}
+
+	if announcement.EndsAt.After(now) {
+		_ = p.state.Workers.Scheduler.AddOnce(
+			announcementEndID(announcement.ID),
+			announcement.EndsAt,
+			p.onAnnouncementEnd(announcement.ID),
+		)
+
+		atStr := announcement.EndsAt.Local().Format("Jan _2 2006 15:04:05")
+		log.Infof(ctx, "scheduled announcement end for %s at '%s'", announcement.ID, atStr)
+	}
+}
+
+func (p *Processor) unscheduleAnnouncement(announcementID string) {
+	_ = p.state.Workers.Scheduler.Cancel(announcementStartID(announcementID))
+	_ = p.state.Workers.Scheduler.Cancel(announcementEndID(announcementID))
+}
+
+// onAnnouncementStart returns a callback function to be used
+// by the scheduler when the given announcement should start.
+func (p *Processor) onAnnouncementStart(announcementID string) func(context.Context, time.Time) {
+	return func(ctx context.Context, now time.Time) {
+		// Get the latest version of announcement from database.
+		announcement, err := p.state.DB.GetAnnouncementByID(ctx, announcementID)
+		if err != nil {
+			log.Errorf(ctx, "error getting announcement %s from db: %v", announcementID, err)
+			return
+		}
+
+		if announcement.Active(now) {
+			p.streamAnnouncement(ctx, announcement)
+		}
+	}
+}
+
+// onAnnouncementEnd returns a callback function to be used
+// by the scheduler when the given announcement should end.
+func (p *Processor) onAnnouncementEnd(announcementID string) func(context.Context, time.Time) {
+	return func(ctx context.Context, _ time.Time) {
+		p.stream.AnnouncementDelete(ctx, announcementID)
+	}
+}
+
+// announcementStartID returns the scheduler task ID for the start
+// of an announcement, prefixed so it can't clash with poll tasks.
+func announcementStartID(announcementID string) string {
+	return "announcement-start-" + announcementID
+}
+
+// announcementEndID returns the scheduler
+// task ID for the end of an announcement.
+func announcementEndID(announcementID string) string {
+	return "announcement-end-" + announcementID
+}
+
+// parseAnnouncementTime parses the given announcement
+// start / end time string, where empty means no time.
+func parseAnnouncementTime(in string) (time.Time, error) {
+	if in == "" {
+		return time.Time{}, nil
+	}
+
+	for _, layout := range []string{
+		time.RFC3339,
+		util.ISO8601,
+		time.DateOnly,
+	} {
+		if t, err := time.Parse(layout, in); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("could not parse announcement time %q as ISO 8601 datetime", in)
+}
+
+func validateAnnouncementTimes(announcement *gtsmodel.Announcement) gtserror.WithCode {
+	if !announcement.StartsAt.IsZero() &&
+		!announcement.EndsAt.IsZero() &&
+		!announcement.EndsAt.After(announcement.StartsAt) {
+		const errText = "announcement ends_at must be after starts_at"
+		return gtserror.NewErrorBadRequest(errors.New(errText), errText)
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type AnnouncementTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *AnnouncementTestSuite) TestAnnouncementLifecycle() {
+	var (
+		ctx           = context.Background()
+		admin         = suite.testAccounts["admin_account"]
+		requester     = suite.testAccounts["local_account_1"]
+		announcements = suite.processor.Announcements()
+	)
+
+	// Create a published announcement with an end in the future.
+	announcement, errWithCode := suite.adminProcessor.AnnouncementCreate(ctx, admin, &apimodel.AnnouncementCreateRequest{
+		Text:   "hello **everyone**, have a :rainbow: #welcome",
+		EndsAt: util.FormatISO8601(time.Now().Add(time.Hour)),
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.True(announcement.Published)
+	suite.Nil(announcement.StartsAt)
+	suite.NotNil(announcement.EndsAt)
+	suite.Contains(announcement.Content, "<strong>everyone</strong>")
+	suite.Len(announcement.Emojis, 1)
+	suite.Len(announcement.Tags, 1)
+
+	// It should be visible to users.
+	active, errWithCode := announcements.Get(ctx, requester, false)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(active, 1)
+	suite.False(active[0].Read)
+
+	// React with a unicode emoji and a local custom emoji.
+	for _, name := range []string{"🎉", "rainbow"} {
+		if errWithCode := announcements.ReactionPut(ctx, requester, announcement.ID, name); errWithCode != nil {
+			suite.FailNow(errWithCode.Error())
+		}
+	}
+
+	// Reacting twice with the same emoji is a no-op.
+	if errWithCode := announcements.ReactionPut(ctx, requester, announcement.ID, "🎉"); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Words and remote emojis aren't valid reactions.
+	for _, name := range []string{"hello", "yell"} {
+		errWithCode := announcements.ReactionPut(ctx, requester, announcement.ID, name)
+		suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+	}
+
+	// Dismiss the announcement.
+	if errWithCode := announcements.Dismiss(ctx, requester, announcement.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	active, errWithCode = announcements.Get(ctx, requester, false)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(active)
+
+	// Still returned when asking for dismissed announcements.
+	active, errWithCode = announcements.Get(ctx, requester, true)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(active, 1)
+	suite.True(active[0].Read)
+
+	reactions := make(map[string]apimodel.AnnouncementReaction)
+	for _, reaction := range active[0].Reactions {
+		reactions[reaction.Name] = reaction
+	}
+	suite.Len(reactions, 2)
+	suite.Equal(1, reactions["🎉"].Count)
+	suite.True(reactions["🎉"].Me)
+	suite.Empty(reactions["🎉"].URL)
+	suite.Equal(1, reactions["rainbow"].Count)
+	suite.NotEmpty(reactions["rainbow"].URL)
+
+	// Remove a reaction.
+	if errWithCode := announcements.ReactionRemove(ctx, requester, announcement.ID, "🎉"); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	errWithCode = announcements.ReactionRemove(ctx, requester, announcement.ID, "🎉")
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	// Unpublish the announcement, it should no longer be visible.
+	announcement, errWithCode = suite.adminProcessor.AnnouncementUpdate(ctx, admin, announcement.ID, &apimodel.AnnouncementUpdateRequest{
+		Published: util.Ptr(false),
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.False(announcement.Published)
+
+	active, errWithCode = announcements.Get(ctx, requester, true)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(active)
+
+	errWithCode = announcements.Dismiss(ctx, requester, announcement.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	// Admins can still see it.
+	all, errWithCode := suite.adminProcessor.AnnouncementsGet(ctx, admin)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(all, 1)
+
+	// Delete it.
+	if _, errWithCode := suite.adminProcessor.AnnouncementDelete(ctx, admin, announcement.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	_, errWithCode = suite.adminProcessor.AnnouncementGet(ctx, admin, announcement.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *AnnouncementTestSuite) TestAnnouncementScheduled() {
+	var (
+		ctx       = context.Background()
+		admin     = suite.testAccounts["admin_account"]
+		requester = suite.testAccounts["local_account_1"]
+	)
+
+	// Announcement that only starts in the future.
+	_, errWithCode := suite.adminProcessor.AnnouncementCreate(ctx, admin, &apimodel.AnnouncementCreateRequest{
+		Text:     "coming soon",
+		StartsAt: time.Now().Add(time.Hour).Format(time.RFC3339),
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	active, errWithCode := suite.processor.Announcements().Get(ctx, requester, true)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(active)
+}
+
+func (suite *AnnouncementTestSuite) TestAnnouncementCreateInvalid() {
+	var (
+		ctx   = context.Background()
+		admin = suite.testAccounts["admin_account"]
+		now   = time.Now()
+	)
+
+	for _, form := range []*apimodel.AnnouncementCreateRequest{
+		{Text: ""},
+		{Text: "bad time", StartsAt: "not a time"},
+		{
+			Text:     "backwards",
+			StartsAt: now.Add(time.Hour).Format(time.RFC3339),
+			EndsAt:   now.Format(time.RFC3339),
+		},
+	} {
+		_, errWithCode := suite.adminProcessor.AnnouncementCreate(ctx, admin, form)
+		suite.Equal(http.StatusBadRequest, errWithCode.Code())
+	}
+}
+
+func TestAnnouncementTestSuite(t *testing.T) {
+	suite.Run(t, new(AnnouncementTestSuite))
+}
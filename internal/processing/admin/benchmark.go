@@ -0,0 +1,103 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"runtime"
+	"slices"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// benchmark runs fn the given amount of times, or until ctx
+// is cancelled, and returns timings of the runs under name.
+//
+// This is a rough micro-benchmark harness for use against a
+// running instance: unlike testing.B it doesn't try to reach
+// a stable measurement, and allocation counts include those
+// of anything else the process was doing meanwhile.
+func benchmark(
+	ctx context.Context,
+	name string,
+	iterations int,
+	fn func(context.Context) error,
+) apimodel.DebugBenchmarkResult {
+	var (
+		durations     = make([]time.Duration, 0, iterations)
+		errs          int
+		before, after runtime.MemStats
+	)
+
+	runtime.ReadMemStats(&before)
+
+	for i := 0; i < iterations && ctx.Err() == nil; i++ {
+		start := time.Now()
+		err := fn(ctx)
+		durations = append(durations, time.Since(start))
+
+		if err != nil {
+			log.Warnf(ctx, "error running %s: %v", name, err)
+			errs++
+		}
+	}
+
+	runtime.ReadMemStats(&after)
+
+	result := apimodel.DebugBenchmarkResult{
+		Name:       name,
+		Iterations: len(durations),
+		Errors:     errs,
+	}
+
+	if len(durations) == 0 {
+		// Cancelled before
+		// the first run.
+		return result
+	}
+
+	var total time.Duration
+	for _, d := range durations {
+		total += d
+	}
+
+	slices.Sort(durations)
+	n := uint64(len(durations))
+
+	result.MinNS = int64(durations[0])
+	result.MeanNS = int64(total) / int64(n)
+	result.P50NS = int64(percentile(durations, 50))
+	result.P95NS = int64(percentile(durations, 95))
+	result.MaxNS = int64(durations[n-1])
+	result.AllocsPerOp = (after.Mallocs - before.Mallocs) / n
+	result.BytesPerOp = (after.TotalAlloc - before.TotalAlloc) / n
+
+	return result
+}
+
+// percentile returns the nearest-rank
+// p-th percentile of sorted durations.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	i := (len(sorted)*p + 99) / 100
+	if i > 0 {
+		i--
+	}
+	return sorted[i]
+}
@@ -0,0 +1,141 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// DebugConversions measures typical typeutils conversions
+// against the live database and caches, with the given admin
+// account as the requesting account, to help with tuning
+// cache ratios and spotting performance regressions.
+//
+// The measured status is never stored in the database. It's
+// made by the admin account, and uses up to the given amount
+// of existing media attachments and custom emojis, so it
+// may have fewer of them than asked for on a fresh instance.
+//
+// Every run fetches the admin account or populates the
+// status again, so the timings include database / cache
+// lookups, like they would when serving an API request.
+func (p *Processor) DebugConversions(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	iterations int,
+	attachments int,
+	emojis int,
+) (*apimodel.DebugConversionsResponse, gtserror.WithCode) {
+	var attachmentIDs []string
+	if attachments > 0 {
+		mediaAttachments, err := p.state.DB.GetAttachments(ctx, &paging.Page{Limit: attachments})
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting attachments: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		for _, attachment := range mediaAttachments {
+			attachmentIDs = append(attachmentIDs, attachment.ID)
+		}
+	}
+
+	var (
+		emojiIDs []string
+		content  strings.Builder
+	)
+
+	content.WriteString("<p>Measuring conversions")
+	if emojis > 0 {
+		customEmojis, err := p.state.DB.GetEmojis(ctx, &paging.Page{Limit: emojis})
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting emojis: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		for _, emoji := range customEmojis {
+			emojiIDs = append(emojiIDs, emoji.ID)
+			content.WriteString(" :" + emoji.Shortcode + ":")
+		}
+	}
+	content.WriteString("</p>")
+
+	statusID := id.NewULID()
+	statusURIs := uris.GenerateURIsForAccount(adminAcct.Username)
+	template := gtsmodel.Status{
+		ID:                  statusID,
+		URI:                 statusURIs.StatusesURI + "/" + statusID,
+		URL:                 statusURIs.StatusesURL + "/" + statusID,
+		Content:             content.String(),
+		AttachmentIDs:       attachmentIDs,
+		EmojiIDs:            emojiIDs,
+		CreatedAt:           time.Now(),
+		UpdatedAt:           time.Now(),
+		Local:               util.Ptr(true),
+		AccountURI:          adminAcct.URI,
+		AccountID:           adminAcct.ID,
+		Visibility:          gtsmodel.VisibilityPublic,
+		Sensitive:           util.Ptr(false),
+		Federated:           util.Ptr(true),
+		ActivityStreamsType: ap.ObjectNote,
+	}
+
+	results := []apimodel.DebugBenchmarkResult{
+		benchmark(ctx, "StatusToAPIStatus", iterations, func(ctx context.Context) error {
+			// Copy the unpopulated template,
+			// so that it's populated anew.
+			status := template
+			_, err := p.converter.StatusToAPIStatus(ctx, &status, adminAcct, "", nil)
+			return err
+		}),
+		benchmark(ctx, "AccountToAPIAccountPublic", iterations, func(ctx context.Context) error {
+			account, err := p.state.DB.GetAccountByID(ctx, adminAcct.ID)
+			if err != nil {
+				return err
+			}
+			_, err = p.converter.AccountToAPIAccountPublic(ctx, account)
+			return err
+		}),
+		benchmark(ctx, "AccountToAPIAccountSensitive", iterations, func(ctx context.Context) error {
+			account, err := p.state.DB.GetAccountByID(ctx, adminAcct.ID)
+			if err != nil {
+				return err
+			}
+			_, err = p.converter.AccountToAPIAccountSensitive(ctx, account)
+			return err
+		}),
+	}
+
+	return &apimodel.DebugConversionsResponse{
+		Attachments: len(attachmentIDs),
+		Emojis:      len(emojiIDs),
+		Results:     results,
+	}, nil
+}
@@ -0,0 +1,84 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DebugConversionsTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *DebugConversionsTestSuite) TestDebugConversions() {
+	var (
+		ctx          = context.Background()
+		adminAccount = suite.testAccounts["admin_account"]
+	)
+
+	resp, errWithCode := suite.adminProcessor.DebugConversions(ctx, adminAccount, 10, 2, 1)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Equal(2, resp.Attachments)
+	suite.Equal(1, resp.Emojis)
+
+	names := make([]string, 0, len(resp.Results))
+	for _, result := range resp.Results {
+		names = append(names, result.Name)
+
+		suite.Equal(10, result.Iterations)
+		suite.Zero(result.Errors)
+		suite.Positive(result.MinNS)
+		suite.LessOrEqual(result.MinNS, result.P50NS)
+		suite.LessOrEqual(result.P50NS, result.P95NS)
+		suite.LessOrEqual(result.P95NS, result.MaxNS)
+		suite.LessOrEqual(result.MinNS, result.MeanNS)
+		suite.LessOrEqual(result.MeanNS, result.MaxNS)
+	}
+
+	suite.Equal([]string{
+		"StatusToAPIStatus",
+		"AccountToAPIAccountPublic",
+		"AccountToAPIAccountSensitive",
+	}, names)
+}
+
+func (suite *DebugConversionsTestSuite) TestDebugConversionsCancelled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	resp, errWithCode := suite.adminProcessor.DebugConversions(ctx, suite.testAccounts["admin_account"], 10, 0, 0)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Nothing should have been run.
+	for _, result := range resp.Results {
+		suite.Zero(result.Iterations)
+		suite.Zero(result.MaxNS)
+	}
+}
+
+func TestDebugConversionsTestSuite(t *testing.T) {
+	suite.Run(t, new(DebugConversionsTestSuite))
+}
@@ -0,0 +1,310 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// directMessageInterval is the time to wait between
+// creating each status of an admin direct message,
+// so that messaging every user on a big instance
+// doesn't swamp the client API worker queue.
+const directMessageInterval = time.Second
+
+// DirectMessage sends the given message as a separate
+// direct status to each of the targeted local accounts,
+// from either the instance account or the admin account.
+//
+// Statuses are created asynchronously and one at a time,
+// via the usual status creation logic, so this returns
+// as soon as the request has been validated.
+func (p *Processor) DirectMessage(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	app *gtsmodel.Application,
+	form *apimodel.AdminDirectMessageRequest,
+) (string, gtserror.WithCode) {
+	text := strings.TrimSpace(form.Status)
+	if text == "" {
+		const errText = "status must not be empty"
+		return "", gtserror.NewErrorBadRequest(errors.New(errText), errText)
+	}
+
+	lang := form.Language
+	if lang != "" {
+		var err error
+		lang, err = validate.Language(lang)
+		if err != nil {
+			return "", gtserror.NewErrorBadRequest(err, err.Error())
+		}
+	}
+
+	if form.All == (len(form.TargetAccountIDs) != 0) {
+		const errText = "exactly one of all or target_account_ids must be set"
+		return "", gtserror.NewErrorBadRequest(errors.New(errText), errText)
+	}
+
+	sender, errWithCode := p.directMessageSender(ctx, adminAcct, form.AccountID)
+	if errWithCode != nil {
+		return "", errWithCode
+	}
+
+	// Resolve targeted accounts now, so we
+	// can error out on bad IDs, and check the
+	// length of each status that will be sent.
+	var targets []*gtsmodel.Account
+	if form.All {
+		var err error
+		targets, err = p.directMessageAllTargets(ctx)
+		if err != nil {
+			return "", gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	for _, targetID := range form.TargetAccountIDs {
+		target, err := p.state.DB.GetAccountByID(ctx, targetID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting account %s: %w", targetID, err)
+			return "", gtserror.NewErrorInternalError(err)
+		}
+
+		if target == nil || !target.IsLocal() || target.IsInstance() {
+			err := fmt.Errorf("target account %s not found on this instance", targetID)
+			return "", gtserror.NewErrorNotFound(err, err.Error())
+		}
+
+		targets = append(targets, target)
+	}
+
+	// The target is mentioned at the start of each
+	// status, so make sure the text still fits after
+	// prepending the mention of the longest username.
+	maxChars := config.GetStatusesMaxChars()
+	textChars := len([]rune(text))
+	for _, target := range targets {
+		if target.ID == sender.ID {
+			// Not messaged.
+			continue
+		}
+
+		if textChars+len([]rune(directMessagePrefix(target))) > maxChars {
+			errText := fmt.Sprintf(
+				"status must not be longer than %d characters, including the mention of @%s",
+				maxChars, target.Username,
+			)
+			return "", gtserror.NewErrorBadRequest(errors.New(errText), errText)
+		}
+	}
+
+	actionID := id.NewULID()
+
+	// Send the messages asynchronously.
+	errWithCode = p.actions.Run(
+		ctx,
+		&gtsmodel.AdminAction{
+			ID:             actionID,
+			TargetCategory: gtsmodel.AdminActionCategoryAccount,
+			TargetID:       sender.ID,
+			Target:         sender,
+			Type:           gtsmodel.AdminActionDirectMessage,
+			AccountID:      adminAcct.ID,
+			Text:           text,
+		},
+		func(ctx context.Context) gtserror.MultiError {
+			return p.directMessageSideEffects(ctx,
+				sender,
+				app,
+				targets,
+				&apimodel.AdvancedStatusCreateForm{
+					StatusCreateRequest: apimodel.StatusCreateRequest{
+						Status:      text,
+						SpoilerText: form.SpoilerText,
+						Sensitive:   form.SpoilerText != "",
+						Visibility:  apimodel.VisibilityDirect,
+						Language:    lang,
+						ContentType: apimodel.StatusContentTypePlain,
+					},
+				},
+			)
+		},
+	)
+
+	return actionID, errWithCode
+}
+
+// directMessageSender returns the instance account if
+// ID is empty, or the admin account if ID is its own,
+// making sure it's fit for sending direct messages.
+//
+// Other accounts can't be used, as admins shouldn't
+// be able to post as any user they like.
+func (p *Processor) directMessageSender(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	accountID string,
+) (*gtsmodel.Account, gtserror.WithCode) {
+	var sender *gtsmodel.Account
+
+	switch accountID {
+	case "":
+		var err error
+		sender, err = p.state.DB.GetInstanceAccount(ctx, "")
+		if err != nil {
+			err := gtserror.Newf("db error getting instance account: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+	case adminAcct.ID:
+		sender = adminAcct
+
+	default:
+		const errText = "messages can only be sent from the instance account or your own account"
+		return nil, gtserror.NewErrorForbidden(errors.New(errText), errText)
+	}
+
+	if sender.IsSuspended() || sender.IsMoving() {
+		err := fmt.Errorf("sender account %s is suspended or has moved", sender.ID)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	if sender.Settings == nil {
+		// The instance account has no settings
+		// stored, but creating a status needs
+		// some, so just give it the defaults.
+		sender.Settings = &gtsmodel.AccountSettings{
+			AccountID:         sender.ID,
+			Privacy:           gtsmodel.VisibilityDirect,
+			Language:          "en",
+			StatusContentType: string(apimodel.StatusContentTypePlain),
+			Sensitive:         util.Ptr(false),
+			EnableRSS:         util.Ptr(false),
+			HideCollections:   util.Ptr(false),
+			HideApplication:   util.Ptr(false),
+			EnableEmbeds:      util.Ptr(false),
+		}
+
+		if langs := config.GetInstanceLanguages(); len(langs) != 0 {
+			sender.Settings.Language = langs[0].TagStr
+		}
+	}
+
+	return sender, nil
+}
+
+// directMessageAllTargets returns the accounts of all
+// local users that are approved, confirmed, and neither
+// disabled nor suspended, ie., all the active users.
+func (p *Processor) directMessageAllTargets(ctx context.Context) ([]*gtsmodel.Account, error) {
+	users, err := p.state.DB.GetAllUsers(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting users: %w", err)
+	}
+
+	targets := make([]*gtsmodel.Account, 0, len(users))
+	for _, user := range users {
+		if user.ConfirmedAt.IsZero() ||
+			!*user.Approved ||
+			*user.Disabled {
+			continue
+		}
+
+		account := user.Account
+		if account == nil {
+			account, err = p.state.DB.GetAccountByID(ctx, user.AccountID)
+			if err != nil {
+				log.Errorf(ctx, "db error getting account of user %s: %v", user.ID, err)
+				continue
+			}
+		}
+
+		if account.IsSuspended() {
+			continue
+		}
+
+		targets = append(targets, account)
+	}
+
+	return targets, nil
+}
+
+// directMessageSideEffects creates a direct status with
+// given form from sender to each of the target accounts
+// in turn, waiting directMessageInterval between each.
+func (p *Processor) directMessageSideEffects(
+	ctx context.Context,
+	sender *gtsmodel.Account,
+	app *gtsmodel.Application,
+	targets []*gtsmodel.Account,
+	form *apimodel.AdvancedStatusCreateForm,
+) gtserror.MultiError {
+	var errs gtserror.MultiError
+
+	// Each status is distinct, so make sure an idempotency
+	// key on the admin's request doesn't get reused for them.
+	ctx = gtscontext.SetIdempotencyKey(ctx, "")
+
+	text := form.Status
+	for i, target := range targets {
+		if target.ID == sender.ID {
+			// Don't message
+			// sender itself.
+			continue
+		}
+
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				errs.Appendf("stopped before messaging %d of %d accounts: %w", len(targets)-i, len(targets), ctx.Err())
+				return errs
+			case <-time.After(directMessageInterval):
+			}
+		}
+
+		// Mention target at the start of the
+		// status so it's delivered to them.
+		form.Status = directMessagePrefix(target) + text
+
+		if _, errWithCode := p.status.Create(ctx, sender, app, form); errWithCode != nil {
+			errs.Appendf("error messaging account %s: %w", target.ID, errWithCode)
+		}
+	}
+
+	return errs
+}
+
+// directMessagePrefix returns the mention of target
+// prepended to the text of the status sent to it.
+func directMessagePrefix(target *gtsmodel.Account) string {
+	return "@" + target.Username + " "
+}
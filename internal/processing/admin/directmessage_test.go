@@ -0,0 +1,197 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type DirectMessageTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *DirectMessageTestSuite) TestDirectMessageFromInstanceAccount() {
+	var (
+		ctx             = context.Background()
+		adminAcct       = suite.testAccounts["admin_account"]
+		app             = suite.testApplications["admin_account"]
+		instanceAccount = suite.testAccounts["instance_account"]
+		targets         = []*gtsmodel.Account{
+			suite.testAccounts["local_account_1"],
+			suite.testAccounts["local_account_2"],
+		}
+	)
+
+	actionID, errWithCode := suite.adminProcessor.DirectMessage(
+		ctx,
+		adminAcct,
+		app,
+		&apimodel.AdminDirectMessageRequest{
+			Status:           "Your posts have been affected by an incident, sorry!",
+			TargetAccountIDs: []string{targets[0].ID, targets[1].ID},
+		},
+	)
+	suite.NoError(errWithCode)
+	suite.NotEmpty(actionID)
+
+	// Wait for action to finish.
+	if !testrig.WaitFor(func() bool {
+		return suite.adminProcessor.Actions().TotalRunning() == 0
+	}) {
+		suite.FailNow("timed out waiting for admin action(s) to finish")
+	}
+
+	adminAction, err := suite.db.GetAdminAction(ctx, actionID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(instanceAccount.ID, adminAction.TargetID)
+	suite.Equal(gtsmodel.AdminActionDirectMessage, adminAction.Type)
+	suite.Empty(adminAction.Errors)
+
+	for _, target := range targets {
+		// Each target should have been
+		// mentioned in a direct message.
+		var notif *gtsmodel.Notification
+		if !testrig.WaitFor(func() bool {
+			notifs, err := suite.db.GetAccountNotifications(ctx, target.ID, "", "", "", 0, nil)
+			if err != nil {
+				suite.FailNow(err.Error())
+			}
+
+			for _, n := range notifs {
+				if n.NotificationType == gtsmodel.NotificationMention &&
+					n.OriginAccountID == instanceAccount.ID {
+					notif = n
+					return true
+				}
+			}
+
+			return false
+		}) {
+			suite.FailNow("timed out waiting for mention notification")
+		}
+
+		suite.Equal(gtsmodel.VisibilityDirect, notif.Status.Visibility)
+		suite.Len(notif.Status.MentionIDs, 1)
+		suite.Contains(notif.Status.Text, "@"+target.Username+" Your posts have been affected")
+	}
+}
+
+func (suite *DirectMessageTestSuite) TestDirectMessageNoTargets() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		app       = suite.testApplications["admin_account"]
+	)
+
+	actionID, errWithCode := suite.adminProcessor.DirectMessage(
+		ctx,
+		adminAcct,
+		app,
+		&apimodel.AdminDirectMessageRequest{
+			Status: "Hello?",
+		},
+	)
+	suite.Empty(actionID)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+	suite.Equal("Bad Request: exactly one of all or target_account_ids must be set", errWithCode.Safe())
+}
+
+func (suite *DirectMessageTestSuite) TestDirectMessageRemoteTarget() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		app       = suite.testApplications["admin_account"]
+		target    = suite.testAccounts["remote_account_1"]
+	)
+
+	actionID, errWithCode := suite.adminProcessor.DirectMessage(
+		ctx,
+		adminAcct,
+		app,
+		&apimodel.AdminDirectMessageRequest{
+			Status:           "Hello!",
+			TargetAccountIDs: []string{target.ID},
+		},
+	)
+	suite.Empty(actionID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *DirectMessageTestSuite) TestDirectMessageOtherSender() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		app       = suite.testApplications["admin_account"]
+		sender    = suite.testAccounts["local_account_2"]
+		target    = suite.testAccounts["local_account_1"]
+	)
+
+	actionID, errWithCode := suite.adminProcessor.DirectMessage(
+		ctx,
+		adminAcct,
+		app,
+		&apimodel.AdminDirectMessageRequest{
+			Status:           "Hello!",
+			AccountID:        sender.ID,
+			TargetAccountIDs: []string{target.ID},
+		},
+	)
+	suite.Empty(actionID)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+}
+
+func (suite *DirectMessageTestSuite) TestDirectMessageTooLongWithMention() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		app       = suite.testApplications["admin_account"]
+		target    = suite.testAccounts["local_account_1"]
+	)
+
+	// Text fits on its own, but not
+	// once the mention is prepended.
+	status := strings.Repeat("a", config.GetStatusesMaxChars())
+
+	actionID, errWithCode := suite.adminProcessor.DirectMessage(
+		ctx,
+		adminAcct,
+		app,
+		&apimodel.AdminDirectMessageRequest{
+			Status:           status,
+			TargetAccountIDs: []string{target.ID},
+		},
+	)
+	suite.Empty(actionID)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+	suite.Contains(errWithCode.Safe(), "including the mention of @"+target.Username)
+}
+
+func TestDirectMessageTestSuite(t *testing.T) {
+	suite.Run(t, new(DirectMessageTestSuite))
+}
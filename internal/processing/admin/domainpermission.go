@@ -296,6 +296,40 @@ func (p *Processor) DomainPermissionsGet(
 	return apiDomainPerms, nil
 }
 
+// DomainPermissionSubscriptionsGet returns the subscriptions
+// through which existing domain permissions were created.
+func (p *Processor) DomainPermissionSubscriptionsGet(
+	ctx context.Context,
+) ([]*apimodel.GTSDomainPermissionSubscription, gtserror.WithCode) {
+	blocks, err := p.state.DB.GetDomainBlocks(ctx)
+	if err != nil {
+		err := gtserror.Newf("error getting domain blocks: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	allows, err := p.state.DB.GetDomainAllows(ctx)
+	if err != nil {
+		err := gtserror.Newf("error getting domain allows: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	domainPerms := make([]gtsmodel.DomainPermission, 0, len(blocks)+len(allows))
+	for _, block := range blocks {
+		domainPerms = append(domainPerms, block)
+	}
+	for _, allow := range allows {
+		domainPerms = append(domainPerms, allow)
+	}
+
+	subs, err := p.converter.DomainPermsToGTSDomainPermSubscriptions(ctx, domainPerms)
+	if err != nil {
+		err := gtserror.Newf("error converting domain permission subscriptions: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return subs, nil
+}
+
 // DomainPermissionGet returns one domain
 // permission with the given id and type.
 //
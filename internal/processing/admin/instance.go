@@ -0,0 +1,155 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// InstancesGet returns a page of admin view
+// instances known to this instance.
+func (p *Processor) InstancesGet(
+	ctx context.Context,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	instances, err := p.state.DB.GetInstances(ctx, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting instances: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(instances)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	var (
+		// Get the lowest and highest
+		// ID values, used for paging.
+		lo = instances[count-1].ID
+		hi = instances[0].ID
+
+		// Best-guess items length.
+		items = make([]interface{}, 0, count)
+	)
+
+	for _, instance := range instances {
+		apiInstance, err := p.converter.InstanceToAdminAPIInstance(ctx, instance)
+		if err != nil {
+			log.Errorf(ctx, "error converting instance to api instance: %v", err)
+			continue
+		}
+		items = append(items, apiInstance)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/admin/instances",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// InstanceGet returns an admin view of the
+// instance with the given ID, if it exists.
+func (p *Processor) InstanceGet(
+	ctx context.Context,
+	id string,
+) (*apimodel.AdminInstance, gtserror.WithCode) {
+	instance, err := p.state.DB.GetInstanceByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting instance %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if instance == nil {
+		err := fmt.Errorf("instance %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	apiInstance, err := p.converter.InstanceToAdminAPIInstance(ctx, instance)
+	if err != nil {
+		err = gtserror.Newf("error converting instance to api instance: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiInstance, nil
+}
+
+// ScheduleDeliveryStatsFlush adds a job to the scheduler
+// which periodically persists outgoing delivery stats
+// recorded by the delivery workers to the instances table.
+func (p *Processor) ScheduleDeliveryStatsFlush() error {
+	if !p.state.Workers.Scheduler.AddRecurring(
+		"@deliverystatsflush",
+		time.Now().Add(time.Minute),
+		time.Minute,
+		p.FlushDeliveryStats,
+	) {
+		return gtserror.New("failed to schedule @deliverystatsflush")
+	}
+
+	return nil
+}
+
+// FlushDeliveryStats adds the outgoing delivery stats recorded
+// since the last flush to the stored stats of each instance.
+func (p *Processor) FlushDeliveryStats(ctx context.Context, _ time.Time) {
+	for domain, stats := range p.state.Workers.Delivery.Stats.Flush() {
+		instance, err := p.state.DB.GetInstance(
+			gtscontext.SetBarebones(ctx),
+			domain,
+		)
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				log.Errorf(ctx, "db error getting instance %s: %v", domain, err)
+			}
+			continue
+		}
+
+		instance.DeliveredCount += int64(stats.Delivered)
+		instance.FailedCount += int64(stats.Failed)
+
+		if stats.LastDeliveredAt.After(instance.LastDeliveredAt) {
+			instance.LastDeliveredAt = stats.LastDeliveredAt
+		}
+
+		if stats.LastFailedAt.After(instance.LastFailedAt) {
+			instance.LastFailedAt = stats.LastFailedAt
+		}
+
+		if err := p.state.DB.UpdateInstance(ctx, instance,
+			"delivered_count",
+			"failed_count",
+			"last_delivered_at",
+			"last_failed_at",
+		); err != nil {
+			log.Errorf(ctx, "db error updating instance %s: %v", domain, err)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// logComponent matches component names of
+// GoToSocial packages, eg. 'transport' or
+// 'federation/dereferencing'.
+var logComponent = regexp.MustCompile(`^[a-z0-9_]+(/[a-z0-9_]+)*$`)
+
+// LogLevelsGet returns the global logging level,
+// and logging levels set at runtime for components.
+func (p *Processor) LogLevelsGet(ctx context.Context) *apimodel.LogLevels {
+	components := log.ComponentLevels()
+
+	apiLevels := &apimodel.LogLevels{
+		Level:      log.LevelString(log.Level()),
+		Components: make(map[string]string, len(components)),
+	}
+
+	for component, lvl := range components {
+		apiLevels.Components[component] = log.LevelString(lvl)
+	}
+
+	return apiLevels
+}
+
+// LogLevelSet sets the logging level of the given component
+// at runtime, or unsets it if the given level is empty.
+// Component logging levels are not persisted, and
+// will be reset when the instance is restarted.
+func (p *Processor) LogLevelSet(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	component string,
+	level string,
+) (*apimodel.LogLevels, gtserror.WithCode) {
+	if !logComponent.MatchString(component) {
+		const text = "component must be a package path like 'transport' or 'federation/dereferencing'"
+		err := fmt.Errorf("invalid log component %q", component)
+		return nil, gtserror.NewErrorBadRequest(err, text)
+	}
+
+	if level == "" {
+		log.UnsetComponentLevel(component)
+		log.Infof(ctx, "%s unset log level of component %s", adminAcct.Username, component)
+		return p.LogLevelsGet(ctx), nil
+	}
+
+	lvl, err := log.LevelFromString(level)
+	if err != nil {
+		const text = "level must be one of 'trace', 'debug', 'info', 'warn', 'error', 'fatal'"
+		return nil, gtserror.NewErrorBadRequest(err, text)
+	}
+
+	log.SetComponentLevel(component, lvl)
+	log.Infof(ctx, "%s set log level of component %s to %s", adminAcct.Username, component, log.LevelString(lvl))
+	return p.LogLevelsGet(ctx), nil
+}
@@ -0,0 +1,107 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package announcements
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// Get returns all currently active announcements, newest first. Unless
+// withDismissed is true, announcements dismissed by account are left out.
+func (p *Processor) Get(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	withDismissed bool,
+) ([]*apimodel.Announcement, gtserror.WithCode) {
+	announcements, err := p.state.DB.GetActiveAnnouncements(ctx, time.Now())
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting announcements: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiAnnouncements := make([]*apimodel.Announcement, 0, len(announcements))
+	for _, announcement := range announcements {
+		apiAnnouncement, err := p.converter.AnnouncementToAPIAnnouncement(ctx, account, announcement)
+		if err != nil {
+			log.Errorf(ctx, "error converting announcement %s: %v", announcement.ID, err)
+			continue
+		}
+
+		if apiAnnouncement.Read && !withDismissed {
+			// Already dismissed.
+			continue
+		}
+
+		apiAnnouncements = append(apiAnnouncements, apiAnnouncement)
+	}
+
+	return apiAnnouncements, nil
+}
+
+// Dismiss marks the given announcement as read by account.
+func (p *Processor) Dismiss(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	announcementID string,
+) gtserror.WithCode {
+	announcement, errWithCode := p.getActiveAnnouncement(ctx, announcementID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := p.state.DB.PutAnnouncementRead(ctx, &gtsmodel.AnnouncementRead{
+		ID:             id.NewULID(),
+		AnnouncementID: announcement.ID,
+		AccountID:      account.ID,
+	}); err != nil {
+		err := gtserror.Newf("db error putting announcement read: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// getActiveAnnouncement gets the announcement with the given ID, returning
+// a 404 if it doesn't exist, or isn't currently visible to non-admins.
+func (p *Processor) getActiveAnnouncement(
+	ctx context.Context,
+	announcementID string,
+) (*gtsmodel.Announcement, gtserror.WithCode) {
+	announcement, err := p.state.DB.GetAnnouncementByID(ctx, announcementID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting announcement %s: %w", announcementID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if announcement == nil || !announcement.Active(time.Now()) {
+		err := fmt.Errorf("announcement %s not found", announcementID)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	return announcement, nil
+}
@@ -0,0 +1,173 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package announcements
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// maxUnicodeReactionLength is the maximum number of runes
+// in a unicode emoji reaction, allowing for ZWJ sequences.
+const maxUnicodeReactionLength = 16
+
+// ReactionPut adds a reaction with the given name to the announcement, on behalf of account.
+// Name must be either a unicode emoji, or the shortcode of an enabled local custom emoji.
+func (p *Processor) ReactionPut(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	announcementID string,
+	name string,
+) gtserror.WithCode {
+	announcement, errWithCode := p.getActiveAnnouncement(ctx, announcementID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	existing, err := p.state.DB.GetAnnouncementReaction(ctx, announcement.ID, account.ID, name)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting announcement reaction: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if existing != nil {
+		// Already reacted,
+		// nothing to do.
+		return nil
+	}
+
+	reaction := &gtsmodel.AnnouncementReaction{
+		ID:             id.NewULID(),
+		AnnouncementID: announcement.ID,
+		AccountID:      account.ID,
+		Name:           name,
+	}
+
+	if !isUnicodeEmoji(name) {
+		// Not a unicode emoji, so
+		// must be a local custom emoji.
+		emoji, err := p.state.DB.GetEmojiByShortcodeDomain(ctx, name, "")
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting emoji %s: %w", name, err)
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		if emoji == nil || util.PtrValueOr(emoji.Disabled, false) {
+			err := fmt.Errorf("%s is not a valid emoji for reactions", name)
+			return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
+
+		reaction.EmojiID = emoji.ID
+		reaction.Emoji = emoji
+	}
+
+	if err := p.state.DB.PutAnnouncementReaction(ctx, reaction); err != nil {
+		err := gtserror.Newf("db error putting announcement reaction: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	p.streamReaction(ctx, announcement.ID, name)
+	return nil
+}
+
+// ReactionRemove removes the reaction with the given
+// name from the announcement, on behalf of account.
+func (p *Processor) ReactionRemove(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	announcementID string,
+	name string,
+) gtserror.WithCode {
+	announcement, errWithCode := p.getActiveAnnouncement(ctx, announcementID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	reaction, err := p.state.DB.GetAnnouncementReaction(ctx, announcement.ID, account.ID, name)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting announcement reaction: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if reaction == nil {
+		err := fmt.Errorf("no %s reaction to announcement %s", name, announcement.ID)
+		return gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	if err := p.state.DB.DeleteAnnouncementReactionByID(ctx, reaction.ID); err != nil {
+		err := gtserror.Newf("db error deleting announcement reaction: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	p.streamReaction(ctx, announcement.ID, name)
+	return nil
+}
+
+// streamReaction streams the current
+// count of the given reaction name.
+func (p *Processor) streamReaction(ctx context.Context, announcementID string, name string) {
+	reactions, err := p.converter.AnnouncementReactionsToAPIReactions(ctx, nil, announcementID)
+	if err != nil {
+		log.Errorf(ctx, "error converting announcement reactions: %v", err)
+		return
+	}
+
+	reaction := &apimodel.AnnouncementReaction{Name: name}
+	for i := range reactions {
+		if reactions[i].Name == name {
+			reaction = &reactions[i]
+			break
+		}
+	}
+
+	p.stream.AnnouncementReaction(ctx, announcementID, reaction)
+}
+
+// isUnicodeEmoji returns whether the given name looks like a
+// unicode emoji (sequence), rather than a custom emoji shortcode.
+func isUnicodeEmoji(name string) bool {
+	if name == "" || utf8.RuneCountInString(name) > maxUnicodeReactionLength {
+		return false
+	}
+
+	var symbol bool
+	for _, r := range name {
+		switch {
+		case unicode.IsLetter(r),
+			unicode.IsSpace(r),
+			unicode.IsControl(r),
+			r == ':', r == '_':
+			return false
+		case unicode.IsSymbol(r):
+			symbol = true
+		}
+	}
+
+	return symbol
+}
@@ -0,0 +1,41 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package common
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+)
+
+// IdempotencyKey returns a cache key for the client-supplied
+// idempotency key stored in context, scoped to the given item
+// kind (eg., "status") and account ID. The client key is hashed,
+// so the returned cache key is of fixed length. Returns an empty
+// string if no idempotency key was set on the context.
+func IdempotencyKey(ctx context.Context, kind string, accountID string) string {
+	key := gtscontext.IdempotencyKey(ctx)
+	if key == "" {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(kind + "\x00" + accountID + "\x00" + key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,167 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package contentreveals
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+// Create stores the decision of the given account to always show
+// content behind content warnings, either for the author given by
+// form.AccountID, or for the thread of the status given by form.StatusID.
+//
+// If a matching content reveal already exists, it is returned as-is.
+func (p *Processor) Create(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	form *apimodel.ContentRevealCreateRequest,
+) (*apimodel.ContentReveal, gtserror.WithCode) {
+	var (
+		reveal      *gtsmodel.ContentReveal
+		errWithCode gtserror.WithCode
+	)
+
+	switch {
+	case form.AccountID != "" && form.StatusID != "":
+		const text = "only one of account_id or status_id should be set"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+
+	case form.AccountID != "":
+		reveal, errWithCode = p.revealAccount(ctx, account, form.AccountID)
+
+	case form.StatusID != "":
+		reveal, errWithCode = p.revealThread(ctx, account, form.StatusID)
+
+	default:
+		const text = "one of account_id or status_id must be set"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiReveal, err := p.converter.ContentRevealToAPIContentReveal(ctx, reveal)
+	if err != nil {
+		err := gtserror.Newf("error converting content reveal to api: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiReveal, nil
+}
+
+// revealAccount gets or creates a content
+// reveal of the given account for targetAccountID.
+func (p *Processor) revealAccount(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	targetAccountID string,
+) (*gtsmodel.ContentReveal, gtserror.WithCode) {
+	targetAccount, errWithCode := p.c.GetVisibleTargetAccount(ctx, account, targetAccountID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	reveal, err := p.state.DB.GetContentRevealForAccount(ctx, account.ID, targetAccount.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting content reveal: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if reveal != nil {
+		// Already revealed,
+		// nothing to do.
+		return reveal, nil
+	}
+
+	reveal = &gtsmodel.ContentReveal{
+		ID:              id.NewULID(),
+		AccountID:       account.ID,
+		Account:         account,
+		TargetAccountID: targetAccount.ID,
+		TargetAccount:   targetAccount,
+	}
+
+	if err := p.state.DB.PutContentReveal(ctx, reveal); err != nil {
+		err := gtserror.Newf("db error putting content reveal: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return reveal, nil
+}
+
+// revealThread gets or creates a content reveal of
+// the given account for the thread of targetStatusID.
+func (p *Processor) revealThread(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	targetStatusID string,
+) (*gtsmodel.ContentReveal, gtserror.WithCode) {
+	targetStatus, errWithCode := p.c.GetVisibleTargetStatus(ctx,
+		account,
+		targetStatusID,
+		nil, // default freshness
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if targetStatus.BoostOfID != "" {
+		const text = "cannot reveal the thread of a boost, use the boosted status instead"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if targetStatus.ThreadID == "" {
+		const text = "status is not part of a thread known to this instance"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	reveal, err := p.state.DB.GetContentRevealForThread(ctx, account.ID, targetStatus.ThreadID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting content reveal: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if reveal != nil {
+		// Already revealed,
+		// nothing to do.
+		return reveal, nil
+	}
+
+	reveal = &gtsmodel.ContentReveal{
+		ID:        id.NewULID(),
+		AccountID: account.ID,
+		Account:   account,
+		ThreadID:  targetStatus.ThreadID,
+		StatusID:  targetStatus.ID,
+	}
+
+	if err := p.state.DB.PutContentReveal(ctx, reveal); err != nil {
+		err := gtserror.Newf("db error putting content reveal: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return reveal, nil
+}
@@ -0,0 +1,45 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package contentreveals
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Delete deletes one content reveal of the given account, so
+// that content warnings of the author or thread apply again.
+func (p *Processor) Delete(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	revealID string,
+) gtserror.WithCode {
+	reveal, errWithCode := p.getContentReveal(ctx, account, revealID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := p.state.DB.DeleteContentRevealByID(ctx, reveal.ID); err != nil {
+		err := gtserror.Newf("db error deleting content reveal: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
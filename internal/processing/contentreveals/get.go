@@ -0,0 +1,76 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package contentreveals
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// GetAll gets all content reveals of the given account, newest first.
+func (p *Processor) GetAll(
+	ctx context.Context,
+	account *gtsmodel.Account,
+) ([]*apimodel.ContentReveal, gtserror.WithCode) {
+	reveals, err := p.state.DB.GetContentReveals(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting content reveals: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiReveals := make([]*apimodel.ContentReveal, 0, len(reveals))
+	for _, reveal := range reveals {
+		apiReveal, err := p.converter.ContentRevealToAPIContentReveal(ctx, reveal)
+		if err != nil {
+			log.Errorf(ctx, "error converting content reveal to api: %v", err)
+			continue
+		}
+
+		apiReveals = append(apiReveals, apiReveal)
+	}
+
+	return apiReveals, nil
+}
+
+// getContentReveal gets one of the account's content reveals,
+// returning 404 if it doesn't exist or is someone else's.
+func (p *Processor) getContentReveal(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	revealID string,
+) (*gtsmodel.ContentReveal, gtserror.WithCode) {
+	reveal, err := p.state.DB.GetContentRevealByID(ctx, revealID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting content reveal: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if reveal == nil ||
+		reveal.AccountID != account.ID {
+		const text = "content reveal not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	return reveal, nil
+}
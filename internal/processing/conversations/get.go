@@ -0,0 +1,95 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package conversations
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// GetPage gets a page of the account's direct
+// conversations, most recently active first.
+func (p *Processor) GetPage(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	conversations, err := p.state.DB.GetConversationsByAccountID(ctx, account.ID, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting conversations: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Check for empty response.
+	count := len(conversations)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	// Get the lowest and highest last
+	// status ID values, used for paging.
+	lo := conversations[count-1].LastStatusID
+	hi := conversations[0].LastStatusID
+
+	items := make([]interface{}, 0, count)
+	for _, conversation := range conversations {
+		apiConversation, err := p.converter.ConversationToAPIConversation(ctx, conversation, account)
+		if err != nil {
+			log.Errorf(ctx, "error converting conversation to api: %v", err)
+			continue
+		}
+
+		items = append(items, apiConversation)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/conversations",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// getConversation gets one of the account's conversations,
+// returning 404 if it doesn't exist or is someone else's.
+func (p *Processor) getConversation(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	conversationID string,
+) (*gtsmodel.Conversation, gtserror.WithCode) {
+	conversation, err := p.state.DB.GetConversationByID(ctx, conversationID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting conversation: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if conversation == nil ||
+		conversation.AccountID != account.ID {
+		const text = "conversation not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	return conversation, nil
+}
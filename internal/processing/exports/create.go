@@ -0,0 +1,101 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package exports
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+// exportInterval is the minimum amount of time an account
+// must wait after requesting an export before requesting
+// another one, unless the previous one failed.
+const exportInterval = 24 * time.Hour
+
+// Create requests a new export archive for the given account. The
+// archive is generated asynchronously; callers can check progress
+// of the returned export using Get, and download it once done.
+func (p *Processor) Create(
+	ctx context.Context,
+	account *gtsmodel.Account,
+) (*apimodel.AccountExport, gtserror.WithCode) {
+	exports, err := p.state.DB.GetAccountExports(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting account exports: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	for _, export := range exports {
+		if !export.Finished() {
+			const text = "an export is already being generated for this account"
+			return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+
+		if export.State != gtsmodel.AccountExportStateFailed &&
+			time.Since(export.CreatedAt) < exportInterval {
+			const text = "an export was already generated for this account recently, try again later"
+			return nil, gtserror.NewErrorTooManyRequests(errors.New(text), text)
+		}
+	}
+
+	export := &gtsmodel.AccountExport{
+		ID:        id.NewULID(),
+		AccountID: account.ID,
+		Account:   account,
+		State:     gtsmodel.AccountExportStatePending,
+	}
+
+	if err := p.state.DB.PutAccountExport(ctx, export); err != nil {
+		err := gtserror.Newf("db error putting account export: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.enqueue(export)
+
+	return p.converter.AccountExportToAPIAccountExport(ctx, export), nil
+}
+
+// RequeueUnfinished queues generation of all exports that were
+// pending or processing when the instance was last shut down.
+func (p *Processor) RequeueUnfinished(ctx context.Context) error {
+	exports, err := p.state.DB.GetUnfinishedAccountExports(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting unfinished account exports: %w", err)
+	}
+
+	for _, export := range exports {
+		p.enqueue(export)
+	}
+
+	return nil
+}
+
+// enqueue pushes generation of the given
+// export onto the processing worker queue.
+func (p *Processor) enqueue(export *gtsmodel.AccountExport) {
+	p.state.Workers.Processing.Queue.Push(func(ctx context.Context) {
+		p.generate(ctx, export)
+	})
+}
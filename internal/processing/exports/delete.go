@@ -0,0 +1,44 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package exports
+
+import (
+	"context"
+	"errors"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+)
+
+// deleteExport deletes the archive of
+// the given export, then the export itself.
+func (p *Processor) deleteExport(ctx context.Context, export *gtsmodel.AccountExport) error {
+	if export.FilePath != "" {
+		err := p.state.Storage.Delete(ctx, export.FilePath)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return gtserror.Newf("storage error deleting export archive %s: %w", export.FilePath, err)
+		}
+	}
+
+	if err := p.state.DB.DeleteAccountExportByID(ctx, export.ID); err != nil {
+		return gtserror.Newf("db error deleting account export %s: %w", export.ID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package exports
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Download returns the content of the given
+// export's archive, if it's done generating.
+func (p *Processor) Download(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	exportID string,
+) (*apimodel.Content, gtserror.WithCode) {
+	export, errWithCode := p.getExport(ctx, account, exportID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if export.State != gtsmodel.AccountExportStateDone {
+		const text = "export archive is not ready for download"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	rc, err := p.state.Storage.GetStream(ctx, export.FilePath)
+	if err != nil {
+		err := gtserror.Newf("storage error getting export archive %s: %w", export.FilePath, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apimodel.Content{
+		ContentType:    "application/gzip",
+		ContentLength:  int64(export.FileSize),
+		ContentUpdated: export.UpdatedAt,
+		Content:        rc,
+	}, nil
+}
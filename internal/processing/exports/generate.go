@@ -0,0 +1,382 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package exports
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+// statusesPageSize is the number of statuses
+// fetched from the database at once when
+// building an account's outbox.
+const statusesPageSize = 100
+
+// generate builds and stores the archive for the given export,
+// updating the state of the export in the database as it goes.
+// Once the archive is ready, older exports of the account are
+// deleted, so only the latest archive is kept in storage.
+func (p *Processor) generate(ctx context.Context, export *gtsmodel.AccountExport) {
+	export.State = gtsmodel.AccountExportStateProcessing
+	if err := p.state.DB.UpdateAccountExport(ctx, export, "state"); err != nil {
+		log.Errorf(ctx, "db error updating account export %s: %v", export.ID, err)
+		return
+	}
+
+	if err := p.writeArchive(ctx, export); err != nil {
+		log.Errorf(ctx, "error generating account export %s: %v", export.ID, err)
+
+		export.State = gtsmodel.AccountExportStateFailed
+		if err := p.state.DB.UpdateAccountExport(ctx, export, "state"); err != nil {
+			log.Errorf(ctx, "db error updating account export %s: %v", export.ID, err)
+		}
+		return
+	}
+
+	export.State = gtsmodel.AccountExportStateDone
+	if err := p.state.DB.UpdateAccountExport(ctx, export,
+		"state",
+		"file_path",
+		"file_size",
+	); err != nil {
+		log.Errorf(ctx, "db error updating account export %s: %v", export.ID, err)
+		return
+	}
+
+	exports, err := p.state.DB.GetAccountExports(ctx, export.AccountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		log.Errorf(ctx, "db error getting account exports: %v", err)
+		return
+	}
+
+	for _, older := range exports {
+		if older.ID >= export.ID || !older.Finished() {
+			continue
+		}
+
+		if err := p.deleteExport(ctx, older); err != nil {
+			log.Errorf(ctx, "error deleting older account export: %v", err)
+		}
+	}
+}
+
+// writeArchive writes a Mastodon-compatible gzipped tar archive
+// for the given export to storage, setting the path and size of
+// the stored archive on the export (without updating the database).
+func (p *Processor) writeArchive(ctx context.Context, export *gtsmodel.AccountExport) error {
+	account, err := p.state.DB.GetAccountByID(ctx, export.AccountID)
+	if err != nil {
+		return gtserror.Newf("db error getting account %s: %w", export.AccountID, err)
+	}
+
+	// Build archive in a temporary file first,
+	// so we don't have to hold it in memory.
+	tmp, err := os.CreateTemp("", "gotosocial-export-*.tar.gz")
+	if err != nil {
+		return gtserror.Newf("error creating temporary file: %w", err)
+	}
+
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	gzw := gzip.NewWriter(tmp)
+	a := &archive{
+		tw:      tar.NewWriter(gzw),
+		modTime: time.Now(),
+	}
+
+	if err := p.writeActor(ctx, a, account); err != nil {
+		return err
+	}
+
+	if err := p.writeOutbox(ctx, a, account); err != nil {
+		return err
+	}
+
+	if err := a.tw.Close(); err != nil {
+		return gtserror.Newf("error closing tar writer: %w", err)
+	}
+
+	if err := gzw.Close(); err != nil {
+		return gtserror.Newf("error closing gzip writer: %w", err)
+	}
+
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return gtserror.Newf("error seeking temporary file: %w", err)
+	}
+
+	filePath := uris.StoragePathForAttachment(
+		account.ID,
+		string(media.TypeExport),
+		string(media.SizeOriginal),
+		export.ID,
+		"tgz",
+	)
+
+	size, err := p.state.Storage.PutStream(ctx, filePath, tmp)
+	if err != nil {
+		return gtserror.Newf("storage error putting export archive %s: %w", filePath, err)
+	}
+
+	export.FilePath = filePath
+	export.FileSize = int(size)
+	return nil
+}
+
+// writeActor writes the account's actor to
+// actor.json, along with its avatar and header.
+func (p *Processor) writeActor(ctx context.Context, a *archive, account *gtsmodel.Account) error {
+	person, err := p.converter.AccountToAS(ctx, account)
+	if err != nil {
+		return gtserror.Newf("error converting account to AS: %w", err)
+	}
+
+	actor, err := ap.Serialize(person)
+	if err != nil {
+		return gtserror.Newf("error serializing account: %w", err)
+	}
+
+	// Include avatar + header in the archive,
+	// pointing the actor at the archived files.
+	for _, image := range []struct {
+		property   string
+		name       string
+		attachment *gtsmodel.MediaAttachment
+	}{
+		{"icon", "avatar", account.AvatarMediaAttachment},
+		{"image", "header", account.HeaderMediaAttachment},
+	} {
+		if image.attachment == nil {
+			continue
+		}
+
+		name := image.name + path.Ext(image.attachment.File.Path)
+		ok, err := p.writeMedia(ctx, a, image.attachment, name)
+		if err != nil {
+			return err
+		}
+
+		if obj, isMap := actor[image.property].(map[string]interface{}); ok && isMap {
+			obj["url"] = name
+		}
+	}
+
+	return a.writeJSON("actor.json", actor)
+}
+
+// writeOutbox writes all of the account's statuses and
+// boosts to outbox.json, oldest first, along with the
+// media attached to them.
+func (p *Processor) writeOutbox(ctx context.Context, a *archive, account *gtsmodel.Account) error {
+	var (
+		items []interface{}
+		maxID string
+	)
+
+	for {
+		statuses, err := p.state.DB.GetAccountStatuses(ctx,
+			account.ID,
+			statusesPageSize,
+			false, // excludeReplies
+			false, // excludeReblogs
+			maxID,
+			"",    // minID
+			false, // mediaOnly
+			false, // publicOnly
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error getting statuses: %w", err)
+		}
+
+		if len(statuses) == 0 {
+			break
+		}
+
+		maxID = statuses[len(statuses)-1].ID
+
+		for _, status := range statuses {
+			item, err := p.outboxItem(ctx, a, account, status)
+			if err != nil {
+				log.Warnf(ctx, "skipping status %s in account export: %v", status.ID, err)
+				continue
+			}
+
+			items = append(items, item)
+		}
+	}
+
+	// Statuses were fetched newest
+	// first, outbox is oldest first.
+	slices.Reverse(items)
+
+	return a.writeJSON("outbox.json", map[string]interface{}{
+		"@context":     "https://www.w3.org/ns/activitystreams",
+		"id":           account.OutboxURI,
+		"type":         ap.ObjectOrderedCollection,
+		"totalItems":   len(items),
+		"orderedItems": items,
+	})
+}
+
+// outboxItem serializes the given status as a Create activity,
+// or as an Announce if it's a boost, writing any attachments
+// to the archive under media_attachments/files/.
+func (p *Processor) outboxItem(
+	ctx context.Context,
+	a *archive,
+	account *gtsmodel.Account,
+	status *gtsmodel.Status,
+) (map[string]interface{}, error) {
+	if status.BoostOfID != "" {
+		announce, err := p.converter.BoostToAS(ctx, status, account, status.BoostOfAccount)
+		if err != nil {
+			return nil, gtserror.Newf("error converting boost to AS: %w", err)
+		}
+
+		return ap.Serialize(announce)
+	}
+
+	statusable, err := p.converter.StatusToAS(ctx, status)
+	if err != nil {
+		return nil, gtserror.Newf("error converting status to AS: %w", err)
+	}
+
+	item, err := ap.Serialize(typeutils.WrapStatusableInCreate(statusable, false))
+	if err != nil {
+		return nil, gtserror.Newf("error serializing status: %w", err)
+	}
+
+	if len(status.Attachments) == 0 {
+		return item, nil
+	}
+
+	// Map of remote attachment URLs
+	// to their location in the archive.
+	archived := make(map[string]string, len(status.Attachments))
+	for _, attachment := range status.Attachments {
+		name := "media_attachments/files/" + attachment.ID + path.Ext(attachment.File.Path)
+
+		ok, err := p.writeMedia(ctx, a, attachment, name)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			archived[attachment.URL] = "/" + name
+		}
+	}
+
+	object, _ := item["object"].(map[string]interface{})
+	attachments, _ := object["attachment"].([]interface{})
+	for _, attachment := range attachments {
+		attachment, ok := attachment.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		url, _ := attachment["url"].(string)
+		if name, ok := archived[url]; ok {
+			attachment["url"] = name
+		}
+	}
+
+	return item, nil
+}
+
+// writeMedia writes the original file of the given attachment to the
+// archive under name, returning false if the file isn't stored locally.
+func (p *Processor) writeMedia(
+	ctx context.Context,
+	a *archive,
+	attachment *gtsmodel.MediaAttachment,
+	name string,
+) (bool, error) {
+	if attachment.Cached == nil || !*attachment.Cached {
+		return false, nil
+	}
+
+	data, err := p.state.Storage.Get(ctx, attachment.File.Path)
+	if errors.Is(err, storage.ErrNotFound) {
+		log.Warnf(ctx, "media %s missing from storage, skipping", attachment.File.Path)
+		return false, nil
+	} else if err != nil {
+		return false, gtserror.Newf("storage error getting media %s: %w", attachment.File.Path, err)
+	}
+
+	if err := a.writeFile(name, data); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// archive wraps a tar writer
+// with some convenience functions.
+type archive struct {
+	tw      *tar.Writer
+	modTime time.Time
+}
+
+// writeFile writes a file with the given name and data to the archive.
+func (a *archive) writeFile(name string, data []byte) error {
+	if err := a.tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0o644,
+		Size:     int64(len(data)),
+		ModTime:  a.modTime,
+	}); err != nil {
+		return gtserror.Newf("error writing tar header for %s: %w", name, err)
+	}
+
+	if _, err := a.tw.Write(data); err != nil {
+		return gtserror.Newf("error writing %s to tar: %w", name, err)
+	}
+
+	return nil
+}
+
+// writeJSON writes v as JSON to a file with the given name in the archive.
+func (a *archive) writeJSON(name string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return gtserror.Newf("error marshaling %s: %w", name, err)
+	}
+
+	return a.writeFile(name, data)
+}
@@ -0,0 +1,83 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package exports
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// GetAll gets all exports of the given account, newest first.
+func (p *Processor) GetAll(
+	ctx context.Context,
+	account *gtsmodel.Account,
+) ([]*apimodel.AccountExport, gtserror.WithCode) {
+	exports, err := p.state.DB.GetAccountExports(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting account exports: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiExports := make([]*apimodel.AccountExport, 0, len(exports))
+	for _, export := range exports {
+		apiExports = append(apiExports, p.converter.AccountExportToAPIAccountExport(ctx, export))
+	}
+
+	return apiExports, nil
+}
+
+// Get gets one export of the given account.
+func (p *Processor) Get(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	exportID string,
+) (*apimodel.AccountExport, gtserror.WithCode) {
+	export, errWithCode := p.getExport(ctx, account, exportID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.converter.AccountExportToAPIAccountExport(ctx, export), nil
+}
+
+// getExport gets one of the account's exports, returning
+// 404 if it doesn't exist or belongs to someone else.
+func (p *Processor) getExport(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	exportID string,
+) (*gtsmodel.AccountExport, gtserror.WithCode) {
+	export, err := p.state.DB.GetAccountExportByID(ctx, exportID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting account export: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if export == nil ||
+		export.AccountID != account.ID {
+		const text = "export not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	return export, nil
+}
@@ -329,8 +329,8 @@ func (p *Processor) FollowingGet(ctx context.Context, requestedUser string, page
 	return data, nil
 }
 
-// FeaturedCollectionGet returns an ordered collection of the requested username's Pinned posts.
-// The returned collection have an `items` property which contains an ordered list of status URIs.
+// FeaturedCollectionGet returns an ordered collection of the requested username's Pinned posts and featured hashtags.
+// The returned collection have an `items` property which contains an ordered list of status URIs, followed by Hashtag objects.
 func (p *Processor) FeaturedCollectionGet(ctx context.Context, requestedUser string) (interface{}, gtserror.WithCode) {
 	// Authenticate the incoming request, getting related user accounts.
 	_, receiver, errWithCode := p.authenticate(ctx, requestedUser)
@@ -345,7 +345,14 @@ func (p *Processor) FeaturedCollectionGet(ctx context.Context, requestedUser str
 		}
 	}
 
-	collection, err := p.converter.StatusesToASFeaturedCollection(ctx, receiver.FeaturedCollectionURI, statuses)
+	featuredTags, err := p.state.DB.GetAccountFeaturedTags(ctx, receiver.ID)
+	if err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	collection, err := p.converter.StatusesToASFeaturedCollection(ctx, receiver.FeaturedCollectionURI, statuses, featuredTags)
 	if err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
@@ -220,3 +220,68 @@ func (p *Processor) StatusRepliesGet(
 
 	return data, nil
 }
+
+// StatusLikesGet handles the getting of a fedi/activitypub representation of the likes
+// collection of a local status, which only gives the number of likes, performing
+// appropriate authentication before returning a JSON serializable interface.
+func (p *Processor) StatusLikesGet(ctx context.Context, requestedUser string, statusID string) (interface{}, gtserror.WithCode) {
+	return p.statusCountCollectionGet(ctx, requestedUser, statusID, p.converter.StatusToASLikesCollection)
+}
+
+// StatusSharesGet handles the getting of a fedi/activitypub representation of the shares
+// collection of a local status, which only gives the number of shares, performing
+// appropriate authentication before returning a JSON serializable interface.
+func (p *Processor) StatusSharesGet(ctx context.Context, requestedUser string, statusID string) (interface{}, gtserror.WithCode) {
+	return p.statusCountCollectionGet(ctx, requestedUser, statusID, p.converter.StatusToASSharesCollection)
+}
+
+// statusCountCollectionGet gets the status with given ID, authenticating
+// the request and checking the status visibility, and returns the collection
+// produced by the given toCollection func, serialized.
+func (p *Processor) statusCountCollectionGet(
+	ctx context.Context,
+	requestedUser string,
+	statusID string,
+	toCollection func(context.Context, *gtsmodel.Status) (vocab.ActivityStreamsCollection, error),
+) (interface{}, gtserror.WithCode) {
+	// Authenticate the incoming request, getting related user accounts.
+	requester, receiver, errWithCode := p.authenticate(ctx, requestedUser)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Get target status and ensure visible to requester.
+	status, errWithCode := p.c.GetVisibleTargetStatus(ctx,
+		requester,
+		statusID,
+		nil, // default freshness
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Ensure status is by receiving account.
+	if status.AccountID != receiver.ID {
+		const text = "status does not belong to receiving account"
+		return nil, gtserror.NewErrorNotFound(errors.New(text))
+	}
+
+	if status.BoostOfID != "" {
+		const text = "status is a boost wrapper"
+		return nil, gtserror.NewErrorNotFound(errors.New(text))
+	}
+
+	collection, err := toCollection(ctx, status)
+	if err != nil {
+		err := gtserror.Newf("error converting collection: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	data, err := ap.Serialize(collection)
+	if err != nil {
+		err := gtserror.Newf("error serializing collection: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return data, nil
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
@@ -47,6 +48,24 @@ func (p *Processor) UserGet(ctx context.Context, requestedUsername string, reque
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	// If the account moved away from the requested actor
+	// by changing its username, serve a stand-in for the
+	// old actor that points to the new one, so remotes
+	// can still fetch it (and its key) to verify the Move.
+	if requestedUsername != receiver.Username {
+		change, err := p.state.DB.GetUsernameChangeByOldUsername(ctx, requestedUsername)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting username change: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if change != nil &&
+			change.Strategy == gtsmodel.UsernameChangeStrategyMove &&
+			change.OldURI != receiver.URI {
+			receiver = movedActor(receiver, requestedUsername)
+		}
+	}
+
 	if uris.IsPublicKeyPath(requestURL) {
 		// If request is on a public key path, we don't need to
 		// authenticate this request. However, we'll only serve
@@ -110,6 +129,31 @@ func (p *Processor) UserGet(ctx context.Context, requestedUsername string, reque
 	return data(person)
 }
 
+// movedActor returns a copy of the given local account
+// as it was when it had the given username, marked as
+// having Moved to the account as it is now.
+func movedActor(account *gtsmodel.Account, username string) *gtsmodel.Account {
+	oldURIs := uris.GenerateURIsForAccount(username)
+
+	actor := new(gtsmodel.Account)
+	*actor = *account
+	actor.Username = username
+	actor.URI = oldURIs.UserURI
+	actor.URL = oldURIs.UserURL
+	actor.InboxURI = oldURIs.InboxURI
+	actor.OutboxURI = oldURIs.OutboxURI
+	actor.FollowersURI = oldURIs.FollowersURI
+	actor.FollowingURI = oldURIs.FollowingURI
+	actor.FeaturedCollectionURI = oldURIs.FeaturedCollectionURI
+	actor.PublicKeyURI = oldURIs.PublicKeyURI
+	actor.AlsoKnownAsURIs = nil
+	actor.AlsoKnownAs = nil
+	actor.MovedToURI = account.URI
+	actor.MovedTo = account
+
+	return actor
+}
+
 func data(requestedPerson vocab.ActivityStreamsPerson) (interface{}, gtserror.WithCode) {
 	data, err := ap.Serialize(requestedPerson)
 	if err != nil {
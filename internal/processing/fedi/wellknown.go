@@ -39,6 +39,7 @@ const (
 	webfingerSelf                   = "self"
 	webFingerSelfContentType        = "application/activity+json"
 	webfingerAccount                = "acct"
+	webfingerMovedTo                = "https://www.w3.org/ns/activitystreams#movedTo"
 )
 
 var (
@@ -123,6 +124,15 @@ func (p *Processor) WebfingerGet(ctx context.Context, requestedUsername string)
 		return nil, gtserror.NewErrorNotFound(fmt.Errorf("database error getting account with username %s: %s", requestedUsername, err))
 	}
 
+	// If the account has Moved, indicate
+	// this by including the target URI.
+	var properties map[string]string
+	if requestedAccount.MovedToURI != "" {
+		properties = map[string]string{
+			webfingerMovedTo: requestedAccount.MovedToURI,
+		}
+	}
+
 	return &apimodel.WellKnownResponse{
 		Subject: webfingerAccount + ":" + requestedAccount.Username + "@" + config.GetAccountDomain(),
 		Aliases: []string{
@@ -141,5 +151,6 @@ func (p *Processor) WebfingerGet(ctx context.Context, requestedUsername string)
 				Href: requestedAccount.URI,
 			},
 		},
+		Properties: properties,
 	}, nil
 }
@@ -0,0 +1,58 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package v2
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Export returns all filters of the given account in a portable
+// format, suitable for backing up, or for importing elsewhere.
+func (p *Processor) Export(ctx context.Context, account *gtsmodel.Account) ([]*apimodel.FilterExportV2, gtserror.WithCode) {
+	filters, err := p.state.DB.GetFiltersForAccountID(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting filters: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiFilters := make([]*apimodel.FilterExportV2, 0, len(filters))
+	for _, filter := range filters {
+		apiFilter, err := p.converter.FilterToAPIFilterExportV2(ctx, filter)
+		if err != nil {
+			err := gtserror.Newf("error converting filter %s: %w", filter.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		apiFilters = append(apiFilters, apiFilter)
+	}
+
+	// Sort them by title so that exports
+	// are in a stable, human-friendly order.
+	slices.SortFunc(apiFilters, func(lhs *apimodel.FilterExportV2, rhs *apimodel.FilterExportV2) int {
+		return strings.Compare(lhs.Title, rhs.Title)
+	})
+
+	return apiFilters, nil
+}
@@ -0,0 +1,211 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// Import creates filters for the given account from the provided
+// file, which should contain a JSON list of filters as produced by
+// Export. Filters are imported one by one, and the result of each
+// import is returned as an entry in a multistatus, so that callers
+// can see which filters (if any) couldn't be imported, and why.
+func (p *Processor) Import(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	filtersF *multipart.FileHeader,
+) (*apimodel.MultiStatus, gtserror.WithCode) {
+	// Open the provided file.
+	file, err := filtersF.Open()
+	if err != nil {
+		err = gtserror.Newf("error opening attachment: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+	defer file.Close()
+
+	// Parse file as slice of filters.
+	apiFilters := make([]*apimodel.FilterExportV2, 0)
+	if err := json.NewDecoder(file).Decode(&apiFilters); err != nil {
+		err = gtserror.Newf("error parsing attachment as filters: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	count := len(apiFilters)
+	if count == 0 {
+		err = gtserror.New("error importing filters: 0 entries provided")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Get titles of existing filters, so we
+	// can skip those instead of overwriting.
+	existing, err := p.state.DB.GetFiltersForAccountID(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting filters: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	titles := make(map[string]struct{}, len(existing))
+	for _, filter := range existing {
+		titles[filter.Title] = struct{}{}
+	}
+
+	multiStatusEntries := make([]apimodel.MultiStatusEntry, 0, count)
+	for _, apiFilter := range apiFilters {
+		var entry apimodel.MultiStatusEntry
+
+		if apiFilter == nil {
+			entry = apimodel.MultiStatusEntry{
+				Resource: apiFilter,
+				Message:  "filter must not be null",
+				Status:   http.StatusUnprocessableEntity,
+			}
+		} else if _, ok := titles[apiFilter.Title]; ok {
+			entry = apimodel.MultiStatusEntry{
+				Resource: apiFilter.Title,
+				Message:  "you already have a filter with this title",
+				Status:   http.StatusConflict,
+			}
+		} else if errWithCode := p.importFilter(ctx, account, apiFilter); errWithCode != nil {
+			entry = apimodel.MultiStatusEntry{
+				Resource: apiFilter.Title,
+				Message:  errWithCode.Safe(),
+				Status:   errWithCode.Code(),
+			}
+		} else {
+			titles[apiFilter.Title] = struct{}{}
+			entry = apimodel.MultiStatusEntry{
+				Resource: apiFilter.Title,
+				Message:  http.StatusText(http.StatusOK),
+				Status:   http.StatusOK,
+			}
+		}
+
+		multiStatusEntries = append(multiStatusEntries, entry)
+	}
+
+	return apimodel.NewMultiStatus(multiStatusEntries), nil
+}
+
+// importFilter validates the given
+// filter, and creates it for account.
+func (p *Processor) importFilter(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	apiFilter *apimodel.FilterExportV2,
+) gtserror.WithCode {
+	if apiFilter.Title == "" {
+		err := errors.New("filter title must be provided")
+		return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	if err := validate.FilterContexts(apiFilter.Context); err != nil {
+		return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	if len(apiFilter.Keywords) == 0 {
+		err := errors.New("at least one filter keyword is required")
+		return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	filter := &gtsmodel.Filter{
+		ID:        id.NewULID(),
+		AccountID: account.ID,
+		Title:     apiFilter.Title,
+	}
+
+	switch apiFilter.FilterAction {
+	case apimodel.FilterActionWarn, apimodel.FilterActionNone:
+		// Warn is the default.
+		filter.Action = gtsmodel.FilterActionWarn
+	case apimodel.FilterActionHide:
+		filter.Action = gtsmodel.FilterActionHide
+	default:
+		err := fmt.Errorf("unsupported filter action '%s'", apiFilter.FilterAction)
+		return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	if apiFilter.ExpiresAt != nil {
+		expiresAt, err := util.ParseISO8601(*apiFilter.ExpiresAt)
+		if err != nil {
+			err := fmt.Errorf("could not parse expires_at value %s: %w", *apiFilter.ExpiresAt, err)
+			return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
+		filter.ExpiresAt = expiresAt
+	}
+
+	for _, context := range apiFilter.Context {
+		switch context {
+		case apimodel.FilterContextHome:
+			filter.ContextHome = util.Ptr(true)
+		case apimodel.FilterContextNotifications:
+			filter.ContextNotifications = util.Ptr(true)
+		case apimodel.FilterContextPublic:
+			filter.ContextPublic = util.Ptr(true)
+		case apimodel.FilterContextThread:
+			filter.ContextThread = util.Ptr(true)
+		case apimodel.FilterContextAccount:
+			filter.ContextAccount = util.Ptr(true)
+		}
+	}
+
+	// Dedupe keywords, since the same keyword
+	// can only be used once within a filter.
+	seen := make(map[string]struct{}, len(apiFilter.Keywords))
+	for _, apiKeyword := range apiFilter.Keywords {
+		if err := validate.FilterKeyword(apiKeyword.Keyword); err != nil {
+			return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
+
+		if _, ok := seen[apiKeyword.Keyword]; ok {
+			continue
+		}
+		seen[apiKeyword.Keyword] = struct{}{}
+
+		filter.Keywords = append(filter.Keywords, &gtsmodel.FilterKeyword{
+			ID:        id.NewULID(),
+			AccountID: account.ID,
+			FilterID:  filter.ID,
+			Filter:    filter,
+			Keyword:   apiKeyword.Keyword,
+			WholeWord: util.Ptr(apiKeyword.WholeWord),
+		})
+	}
+
+	if err := p.state.DB.PutFilter(ctx, filter); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			err = errors.New("a filter with this title already exists")
+			return gtserror.NewErrorConflict(err, err.Error())
+		}
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
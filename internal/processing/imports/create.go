@@ -0,0 +1,181 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package imports
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+// maxRows is the maximum number of
+// rows accepted in one imported file.
+const maxRows = 20000
+
+// Create parses the given Mastodon-compatible CSV file, and stores
+// its rows as a new import of the given type for the given account.
+// The rows are processed asynchronously; callers can check progress
+// of the returned import using Get.
+func (p *Processor) Create(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	importType gtsmodel.AccountImportType,
+	data *multipart.FileHeader,
+) (*apimodel.AccountImport, gtserror.WithCode) {
+	imports, err := p.state.DB.GetAccountImports(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting account imports: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	for _, imp := range imports {
+		if !imp.Finished() {
+			const text = "an import is already being processed for this account"
+			return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+	}
+
+	file, err := data.Open()
+	if err != nil {
+		err = gtserror.Newf("error opening data: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+	defer file.Close()
+
+	imp := &gtsmodel.AccountImport{
+		ID:        id.NewULID(),
+		AccountID: account.ID,
+		Account:   account,
+		Type:      importType,
+		State:     gtsmodel.AccountImportStatePending,
+	}
+
+	rows, err := parseRows(file, imp)
+	if err != nil {
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	imp.TotalRows = len(rows)
+	if err := p.state.DB.PutAccountImport(ctx, imp, rows); err != nil {
+		err := gtserror.Newf("db error putting account import: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.enqueue(imp)
+
+	return p.apiImport(ctx, imp)
+}
+
+// RequeueUnfinished queues processing of all imports that were
+// pending or processing when the instance was last shut down.
+func (p *Processor) RequeueUnfinished(ctx context.Context) error {
+	imports, err := p.state.DB.GetUnfinishedAccountImports(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting unfinished account imports: %w", err)
+	}
+
+	for _, imp := range imports {
+		p.enqueue(imp)
+	}
+
+	return nil
+}
+
+// enqueue pushes processing of the next chunk of the
+// given import onto the processing worker queue.
+func (p *Processor) enqueue(imp *gtsmodel.AccountImport) {
+	p.state.Workers.Processing.Queue.Push(func(ctx context.Context) {
+		p.process(ctx, imp)
+	})
+}
+
+// parseRows parses the rows of a CSV file for the given import,
+// skipping blank lines and the header line, if there is one.
+func parseRows(r io.Reader, imp *gtsmodel.AccountImport) ([]*gtsmodel.AccountImportRow, error) {
+	// Rows of lists exports are "list name,account
+	// address", all other exports have an account
+	// address in the first column.
+	minFields := 1
+	if imp.Type == gtsmodel.AccountImportTypeLists {
+		minFields = 2
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var rows []*gtsmodel.AccountImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("error parsing csv: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		// Trim all fields.
+		for i := range record {
+			record[i] = strings.TrimSpace(record[i])
+		}
+
+		if len(record) == 0 ||
+			(len(record) == 1 && record[0] == "") {
+			// Blank line.
+			continue
+		}
+
+		if len(rows) == 0 && strings.EqualFold(record[0], "account address") {
+			// Header line.
+			continue
+		}
+
+		if len(record) < minFields {
+			return nil, fmt.Errorf("line %d: expected at least %d fields, got %d", line, minFields, len(record))
+		}
+
+		if len(rows) == maxRows {
+			return nil, fmt.Errorf("file contains more than %d rows", maxRows)
+		}
+
+		rows = append(rows, &gtsmodel.AccountImportRow{
+			ID:       id.NewULID(),
+			ImportID: imp.ID,
+			Line:     line,
+			Record:   record,
+			State:    gtsmodel.AccountImportRowStatePending,
+		})
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.New("file contains no rows to import")
+	}
+
+	return rows, nil
+}
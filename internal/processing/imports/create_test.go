@@ -0,0 +1,117 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package imports
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func TestParseRows(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		importType gtsmodel.AccountImportType
+		data       string
+		expectErr  bool
+		expectRows [][]string
+		expectLine []int
+	}{
+		{
+			name:       "following with header",
+			importType: gtsmodel.AccountImportTypeFollowing,
+			data: "Account address,Show boosts,Notify on new posts,Languages\n" +
+				"someone@example.org,true,false,\n" +
+				"\n" +
+				" someone_else@example.org ,false,false,\n",
+			expectRows: [][]string{
+				{"someone@example.org", "true", "false", ""},
+				{"someone_else@example.org", "false", "false", ""},
+			},
+			expectLine: []int{2, 4},
+		},
+		{
+			name:       "blocking without header",
+			importType: gtsmodel.AccountImportTypeBlocking,
+			data:       "someone@example.org\nsomeone_else@example.org\n",
+			expectRows: [][]string{
+				{"someone@example.org"},
+				{"someone_else@example.org"},
+			},
+			expectLine: []int{1, 2},
+		},
+		{
+			name:       "lists",
+			importType: gtsmodel.AccountImportTypeLists,
+			data:       "Friends,someone@example.org\n",
+			expectRows: [][]string{
+				{"Friends", "someone@example.org"},
+			},
+			expectLine: []int{1},
+		},
+		{
+			name:       "lists missing account",
+			importType: gtsmodel.AccountImportTypeLists,
+			data:       "Friends\n",
+			expectErr:  true,
+		},
+		{
+			name:       "empty",
+			importType: gtsmodel.AccountImportTypeMuting,
+			data:       "Account address,Hide notifications\n",
+			expectErr:  true,
+		},
+	} {
+		imp := &gtsmodel.AccountImport{
+			ID:   "01J2M0Q4E0B5ZAXTC6TDVX5QX4",
+			Type: test.importType,
+		}
+
+		rows, err := parseRows(strings.NewReader(test.data), imp)
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("%s: expected error, got none", test.name)
+			}
+			continue
+		} else if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+
+		if len(rows) != len(test.expectRows) {
+			t.Errorf("%s: expected %d rows, got %d", test.name, len(test.expectRows), len(rows))
+			continue
+		}
+
+		for i, row := range rows {
+			if !slices.Equal(row.Record, test.expectRows[i]) {
+				t.Errorf("%s: row %d: expected record %q, got %q", test.name, i, test.expectRows[i], row.Record)
+			}
+
+			if row.Line != test.expectLine[i] {
+				t.Errorf("%s: row %d: expected line %d, got %d", test.name, i, test.expectLine[i], row.Line)
+			}
+
+			if row.ImportID != imp.ID || row.State != gtsmodel.AccountImportRowStatePending {
+				t.Errorf("%s: row %d: unexpected import id or state", test.name, i)
+			}
+		}
+	}
+}
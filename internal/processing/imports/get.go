@@ -0,0 +1,97 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package imports
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// GetAll gets all imports of the given account, newest first.
+func (p *Processor) GetAll(
+	ctx context.Context,
+	account *gtsmodel.Account,
+) ([]*apimodel.AccountImport, gtserror.WithCode) {
+	imports, err := p.state.DB.GetAccountImports(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting account imports: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiImports := make([]*apimodel.AccountImport, 0, len(imports))
+	for _, imp := range imports {
+		apiImport, errWithCode := p.apiImport(ctx, imp)
+		if errWithCode != nil {
+			log.Errorf(ctx, "error converting account import to api: %v", errWithCode)
+			continue
+		}
+
+		apiImports = append(apiImports, apiImport)
+	}
+
+	return apiImports, nil
+}
+
+// Get gets one import of the given account,
+// including the rows that failed to import.
+func (p *Processor) Get(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	importID string,
+) (*apimodel.AccountImport, gtserror.WithCode) {
+	imp, err := p.state.DB.GetAccountImportByID(ctx, importID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting account import: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if imp == nil ||
+		imp.AccountID != account.ID {
+		const text = "import not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	return p.apiImport(ctx, imp)
+}
+
+// apiImport converts the given import to its api
+// model, counting processed rows and getting failures.
+func (p *Processor) apiImport(
+	ctx context.Context,
+	imp *gtsmodel.AccountImport,
+) (*apimodel.AccountImport, gtserror.WithCode) {
+	pending, err := p.state.DB.CountAccountImportRows(ctx, imp.ID, gtsmodel.AccountImportRowStatePending)
+	if err != nil {
+		err := gtserror.Newf("db error counting pending rows: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	failed, err := p.state.DB.GetAccountImportRows(ctx, imp.ID, gtsmodel.AccountImportRowStateFailed, 0)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting failed rows: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.converter.AccountImportToAPIAccountImport(ctx, imp, imp.TotalRows-pending, failed), nil
+}
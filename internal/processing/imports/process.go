@@ -0,0 +1,305 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package imports
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// rowsChunkSize is the number of rows of an
+// import processed at a time, in one worker job.
+const rowsChunkSize = 50
+
+// process imports the next chunk of pending rows of the given
+// import, marking each as done or failed, then queues the next
+// chunk as a new job, so that one large import doesn't hold up
+// a worker for the whole time. Once there are no pending rows
+// left, the import itself is marked as done. If processing hits
+// an error, the import is marked as failed and stops there.
+func (p *Processor) process(ctx context.Context, imp *gtsmodel.AccountImport) {
+	account, err := p.state.DB.GetAccountByID(ctx, imp.AccountID)
+	if err != nil {
+		log.Errorf(ctx, "db error getting account %s for import %s: %v", imp.AccountID, imp.ID, err)
+		p.fail(ctx, imp)
+		return
+	}
+
+	if imp.State == gtsmodel.AccountImportStatePending {
+		imp.State = gtsmodel.AccountImportStateProcessing
+		if err := p.state.DB.UpdateAccountImport(ctx, imp, "state"); err != nil {
+			log.Errorf(ctx, "db error updating account import %s: %v", imp.ID, err)
+			p.fail(ctx, imp)
+			return
+		}
+	}
+
+	rows, err := p.state.DB.GetAccountImportRows(ctx, imp.ID, gtsmodel.AccountImportRowStatePending, rowsChunkSize)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		log.Errorf(ctx, "db error getting rows of account import %s: %v", imp.ID, err)
+		p.fail(ctx, imp)
+		return
+	}
+
+	if len(rows) == 0 {
+		// All rows processed.
+		imp.State = gtsmodel.AccountImportStateDone
+		if err := p.state.DB.UpdateAccountImport(ctx, imp, "state"); err != nil {
+			log.Errorf(ctx, "db error updating account import %s: %v", imp.ID, err)
+		}
+		return
+	}
+
+	// Cache of list titles to IDs,
+	// used when importing lists.
+	lists := make(map[string]string)
+
+	for _, row := range rows {
+		if errWithCode := p.importRow(ctx, account, imp.Type, row, lists); errWithCode != nil {
+			log.Debugf(ctx, "error importing line %d of account import %s: %v", row.Line, imp.ID, errWithCode)
+			row.State = gtsmodel.AccountImportRowStateFailed
+			row.Error = errWithCode.Safe()
+		} else {
+			row.State = gtsmodel.AccountImportRowStateDone
+		}
+
+		if err := p.state.DB.UpdateAccountImportRow(ctx, row, "state", "error"); err != nil {
+			log.Errorf(ctx, "db error updating row of account import %s: %v", imp.ID, err)
+			p.fail(ctx, imp)
+			return
+		}
+	}
+
+	// Queue up
+	// next chunk.
+	p.enqueue(imp)
+}
+
+// fail marks the given import as failed.
+func (p *Processor) fail(ctx context.Context, imp *gtsmodel.AccountImport) {
+	imp.State = gtsmodel.AccountImportStateFailed
+	if err := p.state.DB.UpdateAccountImport(ctx, imp, "state"); err != nil {
+		log.Errorf(ctx, "db error updating account import %s: %v", imp.ID, err)
+	}
+}
+
+// importRow imports one row of an import of the given type,
+// using the account processor to create follows, blocks and
+// mutes, so the usual side effects (federation etc) apply.
+func (p *Processor) importRow(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	importType gtsmodel.AccountImportType,
+	row *gtsmodel.AccountImportRow,
+	lists map[string]string,
+) gtserror.WithCode {
+	switch importType {
+
+	// Following: "Account address,Show boosts,Notify on new posts,Languages".
+	case gtsmodel.AccountImportTypeFollowing:
+		target, errWithCode := p.resolveAccount(ctx, account, row.Record[0])
+		if errWithCode != nil {
+			return errWithCode
+		}
+
+		_, errWithCode = p.account.FollowCreate(ctx, account, &apimodel.AccountFollowRequest{
+			ID:      target.ID,
+			Reblogs: parseBoolField(row.Record, 1),
+			Notify:  parseBoolField(row.Record, 2),
+		})
+		return errWithCode
+
+	// Blocking: "Account address".
+	case gtsmodel.AccountImportTypeBlocking:
+		target, errWithCode := p.resolveAccount(ctx, account, row.Record[0])
+		if errWithCode != nil {
+			return errWithCode
+		}
+
+		_, errWithCode = p.account.BlockCreate(ctx, account, &apimodel.AccountBlockRequest{
+			ID: target.ID,
+		})
+		return errWithCode
+
+	// Muting: "Account address,Hide notifications".
+	case gtsmodel.AccountImportTypeMuting:
+		target, errWithCode := p.resolveAccount(ctx, account, row.Record[0])
+		if errWithCode != nil {
+			return errWithCode
+		}
+
+		_, errWithCode = p.account.MuteCreate(ctx, account, &apimodel.AccountMuteRequest{
+			ID:            target.ID,
+			Notifications: parseBoolField(row.Record, 1),
+		})
+		return errWithCode
+
+	// Lists: "List name,Account address".
+	case gtsmodel.AccountImportTypeLists:
+		target, errWithCode := p.resolveAccount(ctx, account, row.Record[1])
+		if errWithCode != nil {
+			return errWithCode
+		}
+
+		return p.importListEntry(ctx, account, row.Record[0], target, lists)
+
+	default:
+		err := gtserror.Newf("unknown import type %s", importType)
+		return gtserror.NewErrorInternalError(err)
+	}
+}
+
+// importListEntry adds target to the account's list with the given
+// title, creating the list if necessary. As only followed accounts
+// can be added to lists, target is followed first if necessary.
+func (p *Processor) importListEntry(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	title string,
+	target *gtsmodel.Account,
+	lists map[string]string,
+) gtserror.WithCode {
+	listID, errWithCode := p.getOrCreateList(ctx, account, title, lists)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	relationship, errWithCode := p.account.FollowCreate(ctx, account, &apimodel.AccountFollowRequest{
+		ID: target.ID,
+	})
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if !relationship.Following {
+		const text = "account was sent a follow request, but can only be added to the list once it's accepted"
+		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	if errWithCode := p.list.AddToList(ctx, account, listID, []string{target.ID}); errWithCode != nil &&
+		errWithCode.Code() != http.StatusUnprocessableEntity {
+		// 422 means already in list, which is fine.
+		return errWithCode
+	}
+
+	return nil
+}
+
+// getOrCreateList returns the ID of the account's list
+// with the given title, creating it if it doesn't exist.
+func (p *Processor) getOrCreateList(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	title string,
+	lists map[string]string,
+) (string, gtserror.WithCode) {
+	if len(lists) == 0 {
+		existing, err := p.state.DB.GetListsForAccountID(ctx, account.ID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting lists: %w", err)
+			return "", gtserror.NewErrorInternalError(err)
+		}
+
+		for _, list := range existing {
+			lists[list.Title] = list.ID
+		}
+	}
+
+	if listID, ok := lists[title]; ok {
+		return listID, nil
+	}
+
+	list, errWithCode := p.list.Create(ctx, account, title, gtsmodel.RepliesPolicyList, false)
+	if errWithCode != nil {
+		return "", errWithCode
+	}
+
+	lists[title] = list.ID
+	return list.ID, nil
+}
+
+// resolveAccount gets the account with the given
+// address (eg., "someone@example.org"), resolving
+// it via webfinger if it's not yet known to us.
+func (p *Processor) resolveAccount(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	address string,
+) (*gtsmodel.Account, gtserror.WithCode) {
+	username, domain, err := util.ExtractNamestringParts("@" + strings.TrimPrefix(address, "@"))
+	if err != nil {
+		text := "invalid account address " + address
+		return nil, gtserror.NewErrorBadRequest(err, text)
+	}
+
+	if domain == config.GetHost() || domain == config.GetAccountDomain() {
+		// Local account, normalize domain.
+		domain = ""
+	}
+
+	if domain != "" {
+		blocked, err := p.state.DB.IsDomainBlocked(ctx, domain)
+		if err != nil {
+			err := gtserror.Newf("db error checking domain block: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if blocked {
+			const text = "account is on a blocked domain"
+			return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+	}
+
+	account, _, err := p.federator.GetAccountByUsernameDomain(ctx,
+		requester.Username,
+		username,
+		domain,
+	)
+	if err != nil {
+		text := "account " + address + " could not be found"
+		return nil, gtserror.NewErrorNotFound(err, text)
+	}
+
+	return account, nil
+}
+
+// parseBoolField parses the field at index i of record as a
+// bool, returning nil if it's missing, empty, or not a bool.
+func parseBoolField(record []string, i int) *bool {
+	if i >= len(record) || record[i] == "" {
+		return nil
+	}
+
+	b, err := strconv.ParseBool(record[i])
+	if err != nil {
+		return nil
+	}
+
+	return &b
+}
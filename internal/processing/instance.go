@@ -27,6 +27,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
@@ -136,6 +137,29 @@ func (p *Processor) InstanceGetRules(ctx context.Context) ([]apimodel.InstanceRu
 	return p.converter.InstanceRulesToAPIRules(i.Rules), nil
 }
 
+// InstanceGetWebClients returns the first-party web
+// clients configured for this instance (if any).
+func (p *Processor) InstanceGetWebClients() []apimodel.InstanceWebClient {
+	webClients := config.GetAdvancedCORSWebClients()
+	apiWebClients := make([]apimodel.InstanceWebClient, 0, len(webClients))
+
+	for _, webClient := range webClients {
+		origin := config.WebClientOrigin(webClient)
+		if origin == "" {
+			// Invalid,
+			// skip it.
+			continue
+		}
+
+		apiWebClients = append(apiWebClients, apimodel.InstanceWebClient{
+			URL:    webClient,
+			Origin: origin,
+		})
+	}
+
+	return apiWebClients
+}
+
 func (p *Processor) InstancePatch(ctx context.Context, form *apimodel.InstanceSettingsUpdateRequest) (*apimodel.InstanceV1, gtserror.WithCode) {
 	// Fetch this instance from the db for processing.
 	instance, err := p.getThisInstance(ctx)
@@ -235,11 +259,36 @@ func (p *Processor) InstancePatch(ctx context.Context, form *apimodel.InstanceSe
 			return nil, gtserror.NewErrorBadRequest(err, err.Error())
 		}
 
-		// Parse terms as Markdown, keep
-		// the raw version for later editing.
-		instance.TermsText = terms
-		instance.Terms = p.formatter.FromMarkdown(ctx, p.parseMentionFunc, "", "", terms).HTML
-		columns = append(columns, []string{"terms", "terms_text"}...)
+		// Only bother if the terms actually
+		// changed, else users would be asked
+		// to accept the same terms again.
+		if terms != instance.TermsText {
+			// Parse terms as Markdown, keep
+			// the raw version for later editing.
+			instance.TermsText = terms
+			instance.Terms = p.formatter.FromMarkdown(ctx, p.parseMentionFunc, "", "", terms).HTML
+
+			// Store the new terms as a new version, which
+			// users will be asked to accept. Empty terms
+			// have nothing to accept, so just unset it.
+			instance.TermsVersionID = ""
+			if terms != "" {
+				version := &gtsmodel.TermsVersion{
+					ID:        id.NewULID(),
+					Terms:     instance.Terms,
+					TermsText: instance.TermsText,
+				}
+
+				if err := p.state.DB.PutTermsVersion(ctx, version); err != nil {
+					err = fmt.Errorf("db error putting terms version: %w", err)
+					return nil, gtserror.NewErrorInternalError(err)
+				}
+
+				instance.TermsVersionID = version.ID
+			}
+
+			columns = append(columns, []string{"terms", "terms_text", "terms_version_id"}...)
+		}
 	}
 
 	var updateInstanceAccount bool
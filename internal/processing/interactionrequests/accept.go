@@ -0,0 +1,109 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package interactionrequests
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// Accept approves the given interaction request of the account,
+// making the held reply or boost visible as usual, and letting
+// the interacting account know it was approved.
+func (p *Processor) Accept(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	requestID string,
+) (*apimodel.InteractionRequest, gtserror.WithCode) {
+	req, errWithCode := p.getInteractionRequest(ctx, account, requestID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	req.AcceptedAt = time.Now()
+	if err := p.state.DB.UpdateInteractionRequest(ctx, req, "accepted_at"); err != nil {
+		err := gtserror.Newf("db error updating interaction request: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Process side effects asynchronously.
+	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   interactionObjectType(req),
+		APActivityType: ap.ActivityAccept,
+		GTSModel:       req,
+		Origin:         account,
+		Target:         req.InteractingAccount,
+	})
+
+	return p.apiInteractionRequest(ctx, req, account)
+}
+
+// Reject rejects the given interaction request of the account,
+// deleting the held reply or boost, and letting the interacting
+// account know it was rejected.
+func (p *Processor) Reject(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	requestID string,
+) (*apimodel.InteractionRequest, gtserror.WithCode) {
+	req, errWithCode := p.getInteractionRequest(ctx, account, requestID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Convert before the worker
+	// wipes the held interaction.
+	apiReq, errWithCode := p.apiInteractionRequest(ctx, req, account)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	req.RejectedAt = time.Now()
+	if err := p.state.DB.UpdateInteractionRequest(ctx, req, "rejected_at"); err != nil {
+		err := gtserror.Newf("db error updating interaction request: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	apiReq.RejectedAt = util.FormatISO8601(req.RejectedAt)
+
+	// Process side effects asynchronously.
+	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   interactionObjectType(req),
+		APActivityType: ap.ActivityReject,
+		GTSModel:       req,
+		Origin:         account,
+		Target:         req.InteractingAccount,
+	})
+
+	return apiReq, nil
+}
+
+// interactionObjectType returns the AP object type
+// of the interaction held by the given request.
+func interactionObjectType(req *gtsmodel.InteractionRequest) string {
+	if req.InteractionType == gtsmodel.InteractionAnnounce {
+		return ap.ActivityAnnounce
+	}
+	return ap.ObjectNote
+}
@@ -0,0 +1,125 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package interactionrequests
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// GetPage gets a page of the account's
+// interaction requests pending approval.
+func (p *Processor) GetPage(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	reqs, err := p.state.DB.GetAccountInteractionRequests(ctx, account.ID, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting interaction requests: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Check for empty response.
+	count := len(reqs)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo := reqs[count-1].ID
+	hi := reqs[0].ID
+
+	items := make([]interface{}, 0, count)
+	for _, req := range reqs {
+		apiReq, err := p.converter.InteractionRequestToAPIInteractionRequest(ctx, req, account)
+		if err != nil {
+			log.Errorf(ctx, "error converting interaction request to api: %v", err)
+			continue
+		}
+
+		items = append(items, apiReq)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/interaction_requests",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// Get gets one of the account's interaction requests pending approval.
+func (p *Processor) Get(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	requestID string,
+) (*apimodel.InteractionRequest, gtserror.WithCode) {
+	req, errWithCode := p.getInteractionRequest(ctx, account, requestID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiInteractionRequest(ctx, req, account)
+}
+
+// getInteractionRequest gets one of the account's interaction
+// requests, returning 404 if it doesn't exist, is someone
+// else's, or was already accepted or rejected.
+func (p *Processor) getInteractionRequest(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	requestID string,
+) (*gtsmodel.InteractionRequest, gtserror.WithCode) {
+	req, err := p.state.DB.GetInteractionRequestByID(ctx, requestID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting interaction request: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if req == nil ||
+		req.TargetAccountID != account.ID ||
+		!req.IsPending() {
+		const text = "interaction request not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	return req, nil
+}
+
+func (p *Processor) apiInteractionRequest(
+	ctx context.Context,
+	req *gtsmodel.InteractionRequest,
+	account *gtsmodel.Account,
+) (*apimodel.InteractionRequest, gtserror.WithCode) {
+	apiReq, err := p.converter.InteractionRequestToAPIInteractionRequest(ctx, req, account)
+	if err != nil {
+		err := gtserror.Newf("error converting interaction request to api: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiReq, nil
+}
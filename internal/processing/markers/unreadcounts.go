@@ -0,0 +1,82 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package markers
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+)
+
+// UnreadCounts returns the number of items in the requested timelines
+// newer than their markers, counting up to limit items per timeline.
+// If a timeline marker hasn't been set yet, all its items are unread.
+// If no timelines are requested, counts for all of them are returned.
+func (p *Processor) UnreadCounts(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	names []apimodel.MarkerName,
+	limit int,
+) (*apimodel.MarkerUnreadCounts, gtserror.WithCode) {
+	if len(names) == 0 {
+		names = []apimodel.MarkerName{
+			apimodel.MarkerNameHome,
+			apimodel.MarkerNameNotifications,
+		}
+	}
+
+	counts := new(apimodel.MarkerUnreadCounts)
+	for _, name := range names {
+		var lastReadID string
+
+		marker, err := p.state.DB.GetMarker(ctx, account.ID, typeutils.APIMarkerNameToMarkerName(name))
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting %s marker: %w", name, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if marker != nil {
+			lastReadID = marker.LastReadID
+		}
+
+		switch name {
+		case apimodel.MarkerNameHome:
+			count, err := p.state.DB.CountHomeTimeline(ctx, account.ID, lastReadID, limit)
+			if err != nil {
+				err := gtserror.Newf("db error counting home timeline: %w", err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+			counts.Home = &count
+
+		case apimodel.MarkerNameNotifications:
+			count, err := p.state.DB.CountAccountNotifications(ctx, account.ID, lastReadID, limit)
+			if err != nil {
+				err := gtserror.Newf("db error counting notifications: %w", err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+			counts.Notifications = &count
+		}
+	}
+
+	return counts, nil
+}
@@ -26,10 +26,42 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/common"
 )
 
 // Create creates a new media attachment belonging to the given account, using the request form.
+//
+// If an idempotency key is set on the context, and an attachment was already created by account
+// with that key within the last hour, then the existing attachment will be returned instead.
 func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, form *apimodel.AttachmentRequest) (*apimodel.Attachment, gtserror.WithCode) {
+	key := common.IdempotencyKey(ctx, "media", account.ID)
+	if key == "" {
+		// No idempotency
+		// key, just create.
+		return p.create(ctx, account, form)
+	}
+
+	// Lock on key so that concurrent
+	// retries wait for the first one.
+	unlock := p.state.ProcessingLocks.Lock(key)
+	defer unlock()
+
+	if attachmentID, ok := p.state.Caches.Idempotency.Get(key); ok {
+		// Attachment already created
+		// with this key, return it.
+		return p.Get(ctx, account, attachmentID)
+	}
+
+	apiAttachment, errWithCode := p.create(ctx, account, form)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	p.state.Caches.Idempotency.Set(key, apiAttachment.ID)
+	return apiAttachment, nil
+}
+
+func (p *Processor) create(ctx context.Context, account *gtsmodel.Account, form *apimodel.AttachmentRequest) (*apimodel.Attachment, gtserror.WithCode) {
 	data := func(innerCtx context.Context) (io.ReadCloser, int64, error) {
 		f, err := form.File.Open()
 		return f, form.File.Size, err
@@ -42,17 +74,19 @@ func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, form
 	}
 
 	// process the media attachment and load it immediately
-	media := p.mediaManager.PreProcessMedia(data, account.ID, &media.AdditionalMediaInfo{
+	processing := p.mediaManager.PreProcessMedia(data, account.ID, &media.AdditionalMediaInfo{
 		Description: &form.Description,
 		FocusX:      &focusX,
 		FocusY:      &focusY,
+		Sensitive:   &form.Sensitive,
+		ExpiresAt:   expiresAt(form.ExpiresIn),
 	})
 
-	attachment, err := media.LoadAttachment(ctx)
+	attachment, err := processing.LoadAttachment(ctx)
 	if err != nil {
 		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
 	} else if attachment.Type == gtsmodel.FileTypeUnknown {
-		err = gtserror.Newf("could not process uploaded file with extension %s", attachment.File.ContentType)
+		err := media.UnprocessableUploadError(attachment)
 		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
 	}
 
@@ -62,5 +96,7 @@ func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, form
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	apiAttachment.StrippedMetadata = attachment.StrippedMetadata
+
 	return &apiAttachment, nil
 }
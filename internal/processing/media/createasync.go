@@ -0,0 +1,285 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"os"
+	"sync"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/common"
+)
+
+const (
+	// asyncWaitTime is how long to wait for asynchronously
+	// processed media before returning a placeholder to the
+	// caller. Small images will usually be done by then.
+	asyncWaitTime = 5 * time.Second
+
+	// asyncProcessingTimeout is the maximum amount of time
+	// that asynchronous processing of one media attachment
+	// may take, before it will be marked as failed.
+	asyncProcessingTimeout = 10 * time.Minute
+)
+
+// CreateAsync creates a new media attachment belonging to the given account, using the request form.
+//
+// Unlike Create, this will not block until the attachment has been fully processed. If processing
+// finishes quickly, the processed attachment is returned. Otherwise, a placeholder attachment with
+// nil URL is returned, and the caller should poll Get until processing has completed.
+//
+// If an idempotency key is set on the context, and an attachment was already created by account
+// with that key within the last hour, then the existing attachment will be returned instead.
+func (p *Processor) CreateAsync(ctx context.Context, account *gtsmodel.Account, form *apimodel.AttachmentRequest) (*apimodel.Attachment, gtserror.WithCode) {
+	key := common.IdempotencyKey(ctx, "media", account.ID)
+	if key == "" {
+		// No idempotency
+		// key, just create.
+		return p.createAsync(ctx, account, form)
+	}
+
+	// Lock on key so that concurrent
+	// retries wait for the first one.
+	unlock := p.state.ProcessingLocks.Lock(key)
+	defer unlock()
+
+	if attachmentID, ok := p.state.Caches.Idempotency.Get(key); ok {
+		// Attachment already created
+		// with this key, return it.
+		return p.Get(ctx, account, attachmentID)
+	}
+
+	apiAttachment, errWithCode := p.createAsync(ctx, account, form)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	p.state.Caches.Idempotency.Set(key, apiAttachment.ID)
+	return apiAttachment, nil
+}
+
+func (p *Processor) createAsync(ctx context.Context, account *gtsmodel.Account, form *apimodel.AttachmentRequest) (*apimodel.Attachment, gtserror.WithCode) {
+	focusX, focusY, err := parseFocus(form.Focus)
+	if err != nil {
+		err := fmt.Errorf("could not parse focus value %s: %s", form.Focus, err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// The uploaded form file will be removed
+	// once the request has finished, so spool
+	// it somewhere we control until processed.
+	path, err := spoolUpload(form.File)
+	if err != nil {
+		err := gtserror.Newf("error spooling upload: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.processAsync(ctx, account, path, &media.AdditionalMediaInfo{
+		Description: &form.Description,
+		FocusX:      &focusX,
+		FocusY:      &focusY,
+		Sensitive:   &form.Sensitive,
+		ExpiresAt:   expiresAt(form.ExpiresIn),
+	})
+}
+
+// processAsync enqueues processing of the media file at the
+// given path, which will be removed once processing is done.
+// It waits a short time for processing to finish, returning
+// a placeholder attachment with nil URL if it has not.
+func (p *Processor) processAsync(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	path string,
+	info *media.AdditionalMediaInfo,
+) (*apimodel.Attachment, gtserror.WithCode) {
+	data := func(context.Context) (io.ReadCloser, int64, error) {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		stat, err := f.Stat()
+		if err != nil {
+			f.Close()
+			return nil, 0, err
+		}
+
+		return f, stat.Size(), nil
+	}
+
+	processing := p.mediaManager.PreProcessMedia(data, account.ID, info)
+	attachmentID := processing.AttachmentID()
+
+	// Track the attachment while it's being
+	// processed, so it can be found by Get.
+	p.processing.put(&processingAttachment{
+		processing:  processing,
+		accountID:   account.ID,
+		description: info.Description,
+	})
+
+	done := make(chan struct{})
+	p.state.Workers.Media.Queue.Push(func(ctx context.Context) {
+		defer close(done)
+		defer p.processing.delete(attachmentID)
+		defer func() {
+			if err := os.Remove(path); err != nil {
+				log.Errorf(ctx, "error removing spooled upload %s: %v", path, err)
+			}
+		}()
+
+		// Limit processing time, to ensure that
+		// uploads can't tie up the media workers.
+		pctx, cancel := context.WithTimeout(ctx, asyncProcessingTimeout)
+		defer cancel()
+
+		attachment, err := processing.LoadAttachmentNoRetry(pctx)
+		if err == nil && attachment.Type != gtsmodel.FileTypeUnknown {
+			// All good.
+			return
+		}
+
+		log.Errorf(ctx, "error processing media %s: %v", attachmentID, err)
+		p.markProcessingError(ctx, attachment)
+	})
+
+	select {
+	case <-done:
+		// Finished processing,
+		// return the attachment.
+		return p.Get(ctx, account, attachmentID)
+
+	case <-time.After(asyncWaitTime):
+	case <-ctx.Done():
+	}
+
+	// Still processing,
+	// return placeholder.
+	return (&processingAttachment{
+		processing:  processing,
+		description: info.Description,
+	}).placeholder(), nil
+}
+
+// markProcessingError stores the given attachment with
+// processing status set to error, inserting it into the
+// database if processing did not already get that far.
+func (p *Processor) markProcessingError(ctx context.Context, attachment *gtsmodel.MediaAttachment) {
+	attachment.Processing = gtsmodel.ProcessingStatusError
+
+	_, err := p.state.DB.GetAttachmentByID(gtscontext.SetBarebones(ctx), attachment.ID)
+	switch {
+	case err == nil:
+		err = p.state.DB.UpdateAttachment(ctx, attachment, "processing")
+	case errors.Is(err, db.ErrNoEntries):
+		err = p.state.DB.PutAttachment(ctx, attachment)
+	}
+
+	if err != nil {
+		log.Errorf(ctx, "error marking media %s as failed: %v", attachment.ID, err)
+	}
+}
+
+// spoolUpload copies the given multipart form file
+// to a temporary file, and returns the file's path.
+func spoolUpload(fh *multipart.FileHeader) (string, error) {
+	src, err := fh.Open()
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	dst, err := createUploadFile()
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		os.Remove(dst.Name())
+		return "", err
+	}
+
+	if err := dst.Close(); err != nil {
+		os.Remove(dst.Name())
+		return "", err
+	}
+
+	return dst.Name(), nil
+}
+
+// processingAttachment wraps a media attachment
+// which is still being processed asynchronously.
+type processingAttachment struct {
+	processing  *media.ProcessingMedia
+	accountID   string
+	description *string
+}
+
+// placeholder returns an API attachment for the
+// processing attachment, with nil URL to indicate
+// to the caller that processing is not yet done.
+func (p *processingAttachment) placeholder() *apimodel.Attachment {
+	return &apimodel.Attachment{
+		ID:          p.processing.AttachmentID(),
+		Type:        "unknown",
+		Description: p.description,
+	}
+}
+
+// processingAttachments tracks media attachments
+// which are still being processed asynchronously,
+// keyed by attachment ID.
+type processingAttachments struct {
+	m  map[string]*processingAttachment
+	mu sync.Mutex
+}
+
+func (p *processingAttachments) get(id string) *processingAttachment {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.m[id]
+}
+
+func (p *processingAttachments) put(a *processingAttachment) {
+	p.mu.Lock()
+	if p.m == nil {
+		p.m = make(map[string]*processingAttachment)
+	}
+	p.m[a.processing.AttachmentID()] = a
+	p.mu.Unlock()
+}
+
+func (p *processingAttachments) delete(id string) {
+	p.mu.Lock()
+	delete(p.m, id)
+	p.mu.Unlock()
+}
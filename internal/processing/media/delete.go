@@ -42,17 +42,21 @@ func (p *Processor) Delete(ctx context.Context, mediaAttachmentID string) gtserr
 
 	errs := []string{}
 
-	// delete the thumbnail from storage
-	if attachment.Thumbnail.Path != "" {
-		if err := p.state.Storage.Delete(ctx, attachment.Thumbnail.Path); err != nil && !errors.Is(err, storage.ErrNotFound) {
-			errs = append(errs, fmt.Sprintf("remove thumbnail at path %s: %s", attachment.Thumbnail.Path, err))
-		}
+	// lock the file so it can't be deduplicated onto
+	// by another attachment while we're deleting it
+	unlock := p.mediaManager.LockFile(attachment)
+	defer unlock()
+
+	// delete the thumbnail and file from storage,
+	// except files shared with other attachments
+	paths, err := p.mediaManager.RemovablePaths(ctx, attachment)
+	if err != nil {
+		return gtserror.NewErrorInternalError(err)
 	}
 
-	// delete the file from storage
-	if attachment.File.Path != "" {
-		if err := p.state.Storage.Delete(ctx, attachment.File.Path); err != nil && !errors.Is(err, storage.ErrNotFound) {
-			errs = append(errs, fmt.Sprintf("remove file at path %s: %s", attachment.File.Path, err))
+	for _, path := range paths {
+		if err := p.state.Storage.Delete(ctx, path); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			errs = append(errs, fmt.Sprintf("remove file at path %s: %s", path, err))
 		}
 	}
 
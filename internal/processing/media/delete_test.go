@@ -0,0 +1,101 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+)
+
+type DeleteTestSuite struct {
+	MediaStandardTestSuite
+}
+
+func (suite *DeleteTestSuite) TestDeleteDeduplicated() {
+	ctx := context.Background()
+
+	account := suite.testAccounts["local_account_1"]
+	b, err := suite.storage.Get(ctx, suite.testAttachments["local_account_1_unattached_1"].File.Path)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		return io.NopCloser(bytes.NewReader(b)), int64(len(b)), nil
+	}
+
+	// Upload the same file twice, so
+	// the second shares the first's file.
+	attachment1, err := suite.mediaManager.PreProcessMedia(data, account.ID, nil).LoadAttachment(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	attachment2, err := suite.mediaManager.PreProcessMedia(data, account.ID, nil).LoadAttachment(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(attachment1.File.Path, attachment2.File.Path)
+
+	stored, err := suite.storage.Get(ctx, attachment2.File.Path)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Delete the attachment that stored the file.
+	if errWithCode := suite.mediaProcessor.Delete(ctx, attachment1.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// The other should still serve the file.
+	content, errWithCode := suite.mediaProcessor.GetFile(ctx, account, &apimodel.GetContentRequestForm{
+		AccountID: account.ID,
+		MediaType: string(media.TypeAttachment),
+		MediaSize: string(media.SizeOriginal),
+		FileName:  path.Base(attachment2.URL),
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	served, err := io.ReadAll(content.Content)
+	suite.NoError(err)
+	suite.NoError(content.Content.Close())
+	suite.Equal(stored, served)
+
+	// Once the other is deleted
+	// too, the file should be gone.
+	if errWithCode := suite.mediaProcessor.Delete(ctx, attachment2.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	have, err := suite.storage.Has(ctx, attachment2.File.Path)
+	suite.NoError(err)
+	suite.False(have)
+}
+
+func TestDeleteTestSuite(t *testing.T) {
+	suite.Run(t, &DeleteTestSuite{})
+}
@@ -134,12 +134,22 @@ func (p *Processor) getAttachmentContent(ctx context.Context, requestingAccount
 		return nil, gtserror.NewErrorNotFound(err)
 	}
 
+	if a.IsExpired() {
+		// Expired media shouldn't be served, even
+		// if its files haven't been deleted yet.
+		err = gtserror.Newf("attachment %s has expired", wantedMediaID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
 	// If this is an "Unknown" file type, ie., one we
 	// tried to process and couldn't, or one we refused
 	// to process because it wasn't supported, then we
 	// can skip a lot of steps here by simply forwarding
 	// the request to the remote URL.
-	if a.Type == gtsmodel.FileTypeUnknown {
+	//
+	// Placeholders are also "Unknown", but they've
+	// just not been fetched yet, so leave them be.
+	if a.Type == gtsmodel.FileTypeUnknown && !a.IsPlaceholder() {
 		remoteURL, err := url.Parse(a.RemoteURL)
 		if err != nil {
 			err = gtserror.Newf("error parsing remote URL of 'Unknown'-type attachment for redirection: %w", err)
@@ -160,6 +170,8 @@ func (p *Processor) getAttachmentContent(ctx context.Context, requestingAccount
 		// if we don't have it cached, then we can assume two things:
 		// 1. this is remote media, since local media should never be uncached
 		// 2. we need to fetch it again using a transport and the media manager
+		//
+		// (or, if it's a placeholder, fetch it for the first time).
 		remoteMediaIRI, err := url.Parse(a.RemoteURL)
 		if err != nil {
 			return nil, gtserror.NewErrorNotFound(fmt.Errorf("error parsing remote media iri %s: %w", a.RemoteURL, err))
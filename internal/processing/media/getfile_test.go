@@ -208,6 +208,56 @@ func (suite *GetFileTestSuite) TestGetRemoteFileThumbnailUncached() {
 	suite.EqualValues(testAttachment.Thumbnail.FileSize, content.ContentLength)
 }
 
+func (suite *GetFileTestSuite) TestGetRemoteFilePlaceholder() {
+	ctx := context.Background()
+
+	// store a placeholder for a remote avatar that hasn't been fetched yet
+	remoteAccount := suite.testAccounts["remote_account_1"]
+	remoteURL := suite.testAttachments["remote_account_1_status_1_attachment_1"].RemoteURL
+	placeholder, err := suite.mediaManager.PlaceholderMedia(ctx, remoteAccount.ID, &media.AdditionalMediaInfo{
+		Avatar:    util.Ptr(true),
+		RemoteURL: &remoteURL,
+	})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(placeholder.IsPlaceholder())
+	suite.False(*placeholder.Cached)
+
+	// nothing should be in storage yet
+	has, err := suite.storage.Has(ctx, placeholder.Thumbnail.Path)
+	suite.NoError(err)
+	suite.False(has)
+
+	// now view it, it should be fetched
+	content, errWithCode := suite.mediaProcessor.GetFile(ctx, suite.testAccounts["local_account_1"], &apimodel.GetContentRequestForm{
+		AccountID: remoteAccount.ID,
+		MediaType: string(media.TypeAttachment),
+		MediaSize: string(media.SizeOriginal),
+		FileName:  path.Base(placeholder.File.Path),
+	})
+
+	suite.NoError(errWithCode)
+	suite.Nil(content.URL)
+	b, err := io.ReadAll(content.Content)
+	suite.NoError(err)
+	suite.NoError(content.Content.Close())
+
+	suite.Equal(suite.testRemoteAttachments[remoteURL].Data, b)
+	suite.Equal(suite.testRemoteAttachments[remoteURL].ContentType, content.ContentType)
+
+	// the attachment should now be cached and processed
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, placeholder.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(dbAttachment.IsPlaceholder())
+	suite.True(*dbAttachment.Cached)
+	suite.True(*dbAttachment.Avatar)
+	suite.Equal(gtsmodel.FileTypeImage, dbAttachment.Type)
+	suite.Equal(placeholder.Thumbnail.URL, dbAttachment.Thumbnail.URL)
+}
+
 func TestGetFileTestSuite(t *testing.T) {
 	suite.Run(t, &GetFileTestSuite{})
 }
@@ -29,6 +29,16 @@ import (
 )
 
 func (p *Processor) Get(ctx context.Context, account *gtsmodel.Account, mediaAttachmentID string) (*apimodel.Attachment, gtserror.WithCode) {
+	if processing := p.processing.get(mediaAttachmentID); processing != nil {
+		if processing.accountID != account.ID {
+			return nil, gtserror.NewErrorNotFound(errors.New("attachment not owned by requesting account"))
+		}
+
+		// Attachment is still being processed
+		// asynchronously, return placeholder.
+		return processing.placeholder(), nil
+	}
+
 	attachment, err := p.state.DB.GetAttachmentByID(ctx, mediaAttachmentID)
 	if err != nil {
 		if err == db.ErrNoEntries {
@@ -42,10 +52,17 @@ func (p *Processor) Get(ctx context.Context, account *gtsmodel.Account, mediaAtt
 		return nil, gtserror.NewErrorNotFound(errors.New("attachment not owned by requesting account"))
 	}
 
+	if attachment.Processing == gtsmodel.ProcessingStatusError {
+		const text = "media processing failed or timed out"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
 	a, err := p.converter.AttachmentToAPIAttachment(ctx, attachment)
 	if err != nil {
 		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error converting attachment: %s", err))
 	}
 
+	a.StrippedMetadata = attachment.StrippedMetadata
+
 	return &a, nil
 }
@@ -0,0 +1,66 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type GetMediaTestSuite struct {
+	MediaStandardTestSuite
+}
+
+func (suite *GetMediaTestSuite) TestGetMedia() {
+	ctx := context.Background()
+
+	testAttachment := suite.testAttachments["local_account_1_unattached_1"]
+	testAccount := suite.testAccounts["local_account_1"]
+
+	a, errWithCode := suite.mediaProcessor.Get(ctx, testAccount, testAttachment.ID)
+	suite.NoError(errWithCode)
+	suite.Equal(testAttachment.ID, a.ID)
+	suite.NotNil(a.URL)
+}
+
+func (suite *GetMediaTestSuite) TestGetMediaProcessingError() {
+	ctx := context.Background()
+
+	testAttachment := new(gtsmodel.MediaAttachment)
+	*testAttachment = *suite.testAttachments["local_account_1_unattached_1"]
+	testAccount := suite.testAccounts["local_account_1"]
+
+	// Mark the attachment as failed.
+	testAttachment.Processing = gtsmodel.ProcessingStatusError
+	if err := suite.db.UpdateAttachment(ctx, testAttachment, "processing"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	a, errWithCode := suite.mediaProcessor.Get(ctx, testAccount, testAttachment.ID)
+	suite.Nil(a)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+	suite.Equal("Unprocessable Entity: media processing failed or timed out", errWithCode.Safe())
+}
+
+func TestGetMediaTestSuite(t *testing.T) {
+	suite.Run(t, &GetMediaTestSuite{})
+}
@@ -29,6 +29,14 @@ type Processor struct {
 	converter           *typeutils.Converter
 	mediaManager        *media.Manager
 	transportController transport.Controller
+
+	// processing tracks attachments
+	// being processed asynchronously.
+	processing *processingAttachments
+
+	// uploads tracks in-progress
+	// resumable media uploads.
+	uploads *mediaUploads
 }
 
 // New returns a new media processor.
@@ -38,5 +46,7 @@ func New(state *state.State, converter *typeutils.Converter, mediaManager *media
 		converter:           converter,
 		mediaManager:        mediaManager,
 		transportController: transportController,
+		processing:          new(processingAttachments),
+		uploads:             new(mediaUploads),
 	}
 }
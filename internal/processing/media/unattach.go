@@ -54,5 +54,7 @@ func (p *Processor) Unattach(ctx context.Context, account *gtsmodel.Account, med
 		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error converting attachment: %s", err))
 	}
 
+	a.StrippedMetadata = attachment.StrippedMetadata
+
 	return &a, nil
 }
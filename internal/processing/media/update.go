@@ -21,11 +21,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
 )
 
@@ -44,31 +47,86 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, media
 		return nil, gtserror.NewErrorNotFound(errors.New("attachment not owned by requesting account"))
 	}
 
-	var updatingColumns []string
+	if attachment.IsExpired() {
+		err := errors.New("attachment has expired")
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	var (
+		updatingColumns []string
+		oldThumbPath    string
+	)
 
 	if form.Description != nil {
 		attachment.Description = text.SanitizeToPlaintext(*form.Description)
 		updatingColumns = append(updatingColumns, "description")
 	}
 
+	if form.Sensitive != nil {
+		attachment.Sensitive = form.Sensitive
+		updatingColumns = append(updatingColumns, "sensitive")
+	}
+
+	if form.ExpiresIn != nil {
+		if t := expiresAt(*form.ExpiresIn); t != nil {
+			attachment.ExpiresAt = *t
+		} else {
+			attachment.ExpiresAt = time.Time{}
+		}
+		updatingColumns = append(updatingColumns, "expires_at")
+	}
+
 	if form.Focus != nil {
 		focusx, focusy, err := parseFocus(*form.Focus)
 		if err != nil {
 			return nil, gtserror.NewErrorBadRequest(err)
 		}
+		focusChanged := focusx != attachment.FileMeta.Focus.X ||
+			focusy != attachment.FileMeta.Focus.Y
 		attachment.FileMeta.Focus.X = focusx
 		attachment.FileMeta.Focus.Y = focusy
 		updatingColumns = append(updatingColumns, "focus_x", "focus_y")
+
+		if focusChanged {
+			// Recrop the thumbnail around the new focus
+			// point so that previews in timelines use it.
+			oldThumbPath, err = p.mediaManager.RegenerateThumbnail(ctx, attachment)
+			if err != nil {
+				err := gtserror.Newf("error regenerating thumbnail: %w", err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+			updatingColumns = append(updatingColumns,
+				"thumbnail_path",
+				"thumbnail_file_size",
+				"thumbnail_updated_at",
+				"thumbnail_url",
+				"small_width",
+				"small_height",
+				"small_size",
+				"small_aspect",
+			)
+		}
 	}
 
 	if err := p.state.DB.UpdateAttachment(ctx, attachment, updatingColumns...); err != nil {
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("database error updating media: %s", err))
 	}
 
+	if oldThumbPath != "" {
+		// Attachment now points at the regenerated
+		// thumbnail, so the old one can be removed.
+		if err := p.state.Storage.Delete(ctx, oldThumbPath); err != nil &&
+			!errors.Is(err, storage.ErrNotFound) {
+			log.Errorf(ctx, "error removing old thumbnail %s: %v", oldThumbPath, err)
+		}
+	}
+
 	a, err := p.converter.AttachmentToAPIAttachment(ctx, attachment)
 	if err != nil {
 		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error converting attachment: %s", err))
 	}
 
+	a.StrippedMetadata = attachment.StrippedMetadata
+
 	return &a, nil
 }
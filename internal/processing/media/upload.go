@@ -0,0 +1,564 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+const (
+	// uploadExpiry is how long a resumable upload
+	// is kept around after the last received chunk.
+	uploadExpiry = 24 * time.Hour
+
+	// maxUploadsPerAccount is the maximum number of
+	// resumable uploads an account may have in progress.
+	maxUploadsPerAccount = 5
+
+	// uploadSweepEvery is how often abandoned
+	// upload files are looked for and removed.
+	uploadSweepEvery = time.Hour
+
+	// uploadFilePattern is the pattern used
+	// for files spooling uploads to disk.
+	uploadFilePattern = "gotosocial-upload-*"
+
+	// uploadStateSuffix is appended to the path of a
+	// resumable upload's file to get its state file.
+	uploadStateSuffix = ".json"
+)
+
+// UploadCreate starts a new resumable media upload for the given account,
+// using the request form. The file data should then be sent in one or more
+// chunks using UploadAppend, starting from the returned offset.
+func (p *Processor) UploadCreate(ctx context.Context, account *gtsmodel.Account, form *apimodel.MediaUploadRequest) (*apimodel.MediaUpload, gtserror.WithCode) {
+	maxSize := config.GetMediaVideoMaxSize()
+	if maxImageSize := config.GetMediaImageMaxSize(); maxImageSize > maxSize {
+		maxSize = maxImageSize
+	}
+
+	if form.Size <= 0 {
+		const text = "upload size must be greater than 0"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if form.Size > int64(maxSize) {
+		err := fmt.Errorf("file size limit exceeded: limit is %d bytes but upload was %d bytes", maxSize, form.Size)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	focusX, focusY, err := parseFocus(form.Focus)
+	if err != nil {
+		err := fmt.Errorf("could not parse focus value %s: %s", form.Focus, err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Drop any uploads which
+	// have since been abandoned.
+	p.uploads.sweep(ctx)
+
+	if p.uploads.count(account.ID) >= maxUploadsPerAccount {
+		err := fmt.Errorf("too many uploads in progress: limit is %d", maxUploadsPerAccount)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	file, err := createUploadFile()
+	if err != nil {
+		err := gtserror.Newf("error creating upload file: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	upload := &mediaUpload{
+		id:        id.NewULID(),
+		accountID: account.ID,
+		path:      file.Name(),
+		file:      file,
+		size:      form.Size,
+		expiresAt: time.Now().Add(uploadExpiry),
+		info: media.AdditionalMediaInfo{
+			Description: &form.Description,
+			FocusX:      &focusX,
+			FocusY:      &focusY,
+		},
+	}
+
+	// Write out upload state so
+	// it can survive a restart.
+	if err := upload.saveState(); err != nil {
+		file.Close()
+		os.Remove(upload.path)
+		err := gtserror.Newf("error storing upload state: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.uploads.put(upload)
+
+	return upload.toAPI(), nil
+}
+
+// UploadGet returns the current state of a
+// resumable media upload owned by account.
+func (p *Processor) UploadGet(ctx context.Context, account *gtsmodel.Account, uploadID string) (*apimodel.MediaUpload, gtserror.WithCode) {
+	upload, errWithCode := p.getUpload(account, uploadID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	upload.mu.Lock()
+	defer upload.mu.Unlock()
+
+	return upload.toAPI(), nil
+}
+
+// UploadAppend appends a chunk of file data, starting at offset, to a resumable media
+// upload owned by account. Offset must match the number of bytes received so far.
+//
+// If the connection drops partway through the chunk, any bytes received up until
+// then are kept, so the client can resume from the new offset returned by UploadGet.
+//
+// Once all bytes have been received, the upload is handed over to the media workers
+// to be processed, and the returned upload will contain the new media attachment.
+func (p *Processor) UploadAppend(ctx context.Context, account *gtsmodel.Account, uploadID string, offset int64, data io.Reader) (*apimodel.MediaUpload, gtserror.WithCode) {
+	upload, errWithCode := p.getUpload(account, uploadID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	upload.mu.Lock()
+	defer upload.mu.Unlock()
+
+	if upload.file == nil {
+		// Completed or expired while
+		// we were waiting on the lock.
+		err := fmt.Errorf("upload %s not found", uploadID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if offset != upload.offset {
+		err := fmt.Errorf("chunk offset %d does not match upload offset %d", offset, upload.offset)
+		return nil, gtserror.NewErrorConflict(err, err.Error())
+	}
+
+	// Read at most one byte more than the
+	// remaining size, so overflow is caught.
+	remaining := upload.size - upload.offset
+	n, err := io.Copy(upload.file, io.LimitReader(data, remaining+1))
+	upload.offset += n
+	upload.expiresAt = time.Now().Add(uploadExpiry)
+
+	if upload.offset > upload.size {
+		// Client sent more than it said
+		// it would, this upload is invalid.
+		p.uploads.delete(ctx, upload)
+		err := fmt.Errorf("upload exceeded declared size of %d bytes", upload.size)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if err != nil {
+		// Keep whatever was received so far,
+		// the client can resume from there.
+		err := gtserror.Newf("error receiving chunk for upload %s: %w", uploadID, err)
+		return nil, gtserror.NewErrorBadRequest(err, "error receiving chunk")
+	}
+
+	apiUpload := upload.toAPI()
+	if upload.offset < upload.size {
+		// Still more
+		// to come.
+		return apiUpload, nil
+	}
+
+	// All bytes received, close the file and
+	// stop tracking it as an upload; it will be
+	// removed once processed by the media workers.
+	path := upload.path
+	if err := upload.file.Close(); err != nil {
+		p.uploads.delete(ctx, upload)
+		err := gtserror.Newf("error closing upload file: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	upload.file = nil
+	upload.removeState(ctx)
+	p.uploads.remove(upload.id)
+
+	apiAttachment, errWithCode := p.processAsync(ctx, account, path, &upload.info)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiUpload.Attachment = apiAttachment
+	return apiUpload, nil
+}
+
+// ScheduleUploadSweep restores resumable uploads from before the
+// last shutdown, removes any other upload files left over, then adds
+// a job to the scheduler which periodically removes abandoned uploads.
+func (p *Processor) ScheduleUploadSweep(ctx context.Context) error {
+	if n := p.uploads.load(ctx); n > 0 {
+		log.Infof(ctx, "restored resumable uploads: %d", n)
+	}
+
+	// Any other upload files on disk were
+	// abandoned, or not yet processed, when
+	// the server last stopped (or crashed).
+	if n := p.uploads.sweepFiles(ctx, time.Now()); n > 0 {
+		log.Infof(ctx, "removed leftover upload files: %d", n)
+	}
+
+	if !p.state.Workers.Scheduler.AddRecurring(
+		"@mediauploadsweep",
+		time.Now().Add(uploadSweepEvery),
+		uploadSweepEvery,
+		p.SweepUploads,
+	) {
+		return gtserror.New("failed to schedule @mediauploadsweep")
+	}
+
+	return nil
+}
+
+// SweepUploads removes resumable uploads which have expired,
+// as well as any untracked upload files on disk which haven't
+// been touched for longer than the upload expiry time.
+func (p *Processor) SweepUploads(ctx context.Context, now time.Time) {
+	p.uploads.sweep(ctx)
+	if n := p.uploads.sweepFiles(ctx, now.Add(-uploadExpiry)); n > 0 {
+		log.Infof(ctx, "removed abandoned upload files: %d", n)
+	}
+}
+
+// getUpload fetches the resumable upload with
+// given ID, checking that it's owned by account.
+func (p *Processor) getUpload(account *gtsmodel.Account, uploadID string) (*mediaUpload, gtserror.WithCode) {
+	upload := p.uploads.get(uploadID)
+	if upload == nil || upload.accountID != account.ID {
+		err := fmt.Errorf("upload %s not found", uploadID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+	return upload, nil
+}
+
+// uploadDir returns the directory in which uploads
+// are spooled, beneath the local storage base path.
+func uploadDir() string {
+	return filepath.Join(config.GetStorageLocalBasePath(), storage.UploadsDir)
+}
+
+// createUploadFile creates a new
+// file in which to spool an upload.
+func createUploadFile() (*os.File, error) {
+	dir := uploadDir()
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+	return os.CreateTemp(dir, uploadFilePattern)
+}
+
+// mediaUpload is an in-progress resumable
+// media upload, spooled to a file on disk.
+type mediaUpload struct {
+	id        string
+	accountID string
+	path      string
+	file      *os.File
+	size      int64
+	offset    int64
+	expiresAt time.Time
+	info      media.AdditionalMediaInfo
+	mu        sync.Mutex
+}
+
+// toAPI converts the upload to its API model
+// representation. This MUST be called under lock.
+func (u *mediaUpload) toAPI() *apimodel.MediaUpload {
+	return &apimodel.MediaUpload{
+		ID:        u.id,
+		Size:      u.size,
+		Offset:    u.offset,
+		ExpiresAt: util.FormatISO8601(u.expiresAt),
+	}
+}
+
+// uploadState is the state of a resumable upload
+// stored next to its file, allowing it to be restored
+// after a restart. The offset and expiry time are
+// taken from the size and modtime of the file.
+type uploadState struct {
+	ID          string  `json:"id"`
+	AccountID   string  `json:"account_id"`
+	Size        int64   `json:"size"`
+	Description string  `json:"description"`
+	FocusX      float32 `json:"focus_x"`
+	FocusY      float32 `json:"focus_y"`
+}
+
+// saveState writes the upload
+// state file for this upload.
+func (u *mediaUpload) saveState() error {
+	b, err := json.Marshal(uploadState{
+		ID:          u.id,
+		AccountID:   u.accountID,
+		Size:        u.size,
+		Description: util.PtrValueOr(u.info.Description, ""),
+		FocusX:      util.PtrValueOr(u.info.FocusX, 0),
+		FocusY:      util.PtrValueOr(u.info.FocusY, 0),
+	})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(u.path+uploadStateSuffix, b, 0o600)
+}
+
+// removeState removes the upload
+// state file for this upload.
+func (u *mediaUpload) removeState(ctx context.Context) {
+	path := u.path + uploadStateSuffix
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		log.Errorf(ctx, "error removing upload state %s: %v", path, err)
+	}
+}
+
+// mediaUploads tracks in-progress
+// resumable uploads, keyed by ID.
+type mediaUploads struct {
+	m  map[string]*mediaUpload
+	mu sync.Mutex
+}
+
+func (u *mediaUploads) get(id string) *mediaUpload {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.m[id]
+}
+
+func (u *mediaUploads) put(upload *mediaUpload) {
+	u.mu.Lock()
+	if u.m == nil {
+		u.m = make(map[string]*mediaUpload)
+	}
+	u.m[upload.id] = upload
+	u.mu.Unlock()
+}
+
+func (u *mediaUploads) remove(id string) {
+	u.mu.Lock()
+	delete(u.m, id)
+	u.mu.Unlock()
+}
+
+// count returns the number of in-progress
+// uploads owned by account with given ID.
+func (u *mediaUploads) count(accountID string) int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	var n int
+	for _, upload := range u.m {
+		if upload.accountID == accountID {
+			n++
+		}
+	}
+	return n
+}
+
+// delete stops tracking upload and removes its
+// spooled file. This MUST be called under upload lock.
+func (u *mediaUploads) delete(ctx context.Context, upload *mediaUpload) {
+	u.remove(upload.id)
+
+	if upload.file == nil {
+		return
+	}
+
+	upload.file.Close()
+	upload.file = nil
+	upload.removeState(ctx)
+
+	if err := os.Remove(upload.path); err != nil {
+		log.Errorf(ctx, "error removing upload file %s: %v", upload.path, err)
+	}
+}
+
+// load restores in-progress uploads from the state
+// files in the upload directory, returning the number
+// of uploads restored. Expired uploads are skipped.
+func (u *mediaUploads) load(ctx context.Context) int {
+	paths, err := filepath.Glob(filepath.Join(uploadDir(), uploadFilePattern+uploadStateSuffix))
+	if err != nil {
+		log.Errorf(ctx, "error listing upload state files: %v", err)
+		return 0
+	}
+
+	var n int
+	for _, path := range paths {
+		upload, err := loadUpload(path)
+		if err != nil {
+			log.Errorf(ctx, "error loading upload state %s: %v", path, err)
+			continue
+		}
+
+		if upload == nil {
+			// Expired.
+			continue
+		}
+
+		u.put(upload)
+		n++
+	}
+
+	return n
+}
+
+// loadUpload loads the resumable upload described by the state file at
+// path, reopening its file for writing. Returns nil if it has expired.
+func loadUpload(path string) (*mediaUpload, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var state uploadState
+	if err := json.Unmarshal(b, &state); err != nil {
+		return nil, err
+	}
+
+	upload := &mediaUpload{
+		id:        state.ID,
+		accountID: state.AccountID,
+		path:      strings.TrimSuffix(path, uploadStateSuffix),
+		size:      state.Size,
+		info: media.AdditionalMediaInfo{
+			Description: &state.Description,
+			FocusX:      &state.FocusX,
+			FocusY:      &state.FocusY,
+		},
+	}
+
+	info, err := os.Stat(upload.path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pick up from wherever the last
+	// received chunk left the file.
+	upload.offset = info.Size()
+	upload.expiresAt = info.ModTime().Add(uploadExpiry)
+
+	if upload.offset > upload.size ||
+		time.Now().After(upload.expiresAt) {
+		return nil, nil
+	}
+
+	upload.file, err = os.OpenFile(upload.path, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return upload, nil
+}
+
+// sweep deletes all uploads which
+// have passed their expiry time.
+func (u *mediaUploads) sweep(ctx context.Context) {
+	u.mu.Lock()
+	uploads := make([]*mediaUpload, 0, len(u.m))
+	for _, upload := range u.m {
+		uploads = append(uploads, upload)
+	}
+	u.mu.Unlock()
+
+	now := time.Now()
+	for _, upload := range uploads {
+		// Only lock if we can, an upload
+		// in use is clearly not expired.
+		if !upload.mu.TryLock() {
+			continue
+		}
+
+		if now.After(upload.expiresAt) {
+			u.delete(ctx, upload)
+		}
+
+		upload.mu.Unlock()
+	}
+}
+
+// sweepFiles removes upload files in the upload directory
+// which are not tracked as an in-progress upload, and which
+// were last modified before the given time. This also catches
+// spooled files for uploads which never reached the media
+// workers. Returns the number of files removed.
+func (u *mediaUploads) sweepFiles(ctx context.Context, before time.Time) int {
+	paths, err := filepath.Glob(filepath.Join(uploadDir(), uploadFilePattern))
+	if err != nil {
+		log.Errorf(ctx, "error listing upload files: %v", err)
+		return 0
+	}
+
+	u.mu.Lock()
+	tracked := make(map[string]struct{}, 2*len(u.m))
+	for _, upload := range u.m {
+		tracked[upload.path] = struct{}{}
+		tracked[upload.path+uploadStateSuffix] = struct{}{}
+	}
+	u.mu.Unlock()
+
+	var n int
+	for _, path := range paths {
+		if _, ok := tracked[path]; ok {
+			continue
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			// Probably removed
+			// in the meantime.
+			continue
+		}
+
+		if info.IsDir() || !info.ModTime().Before(before) {
+			continue
+		}
+
+		if err := os.Remove(path); err != nil {
+			log.Errorf(ctx, "error removing upload file %s: %v", path, err)
+			continue
+		}
+		n++
+	}
+
+	return n
+}
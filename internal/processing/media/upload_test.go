@@ -0,0 +1,235 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	mediaprocessing "github.com/superseriousbusiness/gotosocial/internal/processing/media"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+)
+
+type UploadTestSuite struct {
+	MediaStandardTestSuite
+}
+
+func (suite *UploadTestSuite) TestUploadResume() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	// Completed uploads are
+	// processed by media workers.
+	suite.state.Workers.Media.Start(1)
+	defer suite.state.Workers.Media.Stop()
+
+	b, err := os.ReadFile("../../../testrig/media/test-jpeg.jpg")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	upload, errWithCode := suite.mediaProcessor.UploadCreate(ctx, testAccount, &apimodel.MediaUploadRequest{
+		Size:        int64(len(b)),
+		Description: "a cool background from somewhere",
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.EqualValues(0, upload.Offset)
+
+	// Send the first half, but only some of it "arrives".
+	half := int64(len(b) / 2)
+	upload, errWithCode = suite.mediaProcessor.UploadAppend(ctx, testAccount, upload.ID, 0, bytes.NewReader(b[:half/2]))
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(half/2, upload.Offset)
+	suite.Nil(upload.Attachment)
+
+	// Retrying from the start should conflict.
+	_, errWithCode = suite.mediaProcessor.UploadAppend(ctx, testAccount, upload.ID, 0, bytes.NewReader(b))
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+
+	// Resume from the offset reported by the server.
+	upload, errWithCode = suite.mediaProcessor.UploadGet(ctx, testAccount, upload.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	upload, errWithCode = suite.mediaProcessor.UploadAppend(ctx, testAccount, upload.ID, upload.Offset, bytes.NewReader(b[upload.Offset:]))
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.EqualValues(len(b), upload.Offset)
+
+	attachment := upload.Attachment
+	if suite.NotNil(attachment) {
+		suite.Equal("image", attachment.Type)
+		suite.Equal("a cool background from somewhere", *attachment.Description)
+		suite.NotNil(attachment.URL)
+	}
+
+	// Upload should be gone now.
+	_, errWithCode = suite.mediaProcessor.UploadGet(ctx, testAccount, upload.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *UploadTestSuite) TestUploadTooLarge() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	upload, errWithCode := suite.mediaProcessor.UploadCreate(ctx, testAccount, &apimodel.MediaUploadRequest{
+		Size: 4,
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	_, errWithCode = suite.mediaProcessor.UploadAppend(ctx, testAccount, upload.ID, 0, bytes.NewReader([]byte("too many bytes")))
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	// Invalid upload should be gone.
+	_, errWithCode = suite.mediaProcessor.UploadGet(ctx, testAccount, upload.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *UploadTestSuite) TestUploadSweep() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	upload, errWithCode := suite.mediaProcessor.UploadCreate(ctx, testAccount, &apimodel.MediaUploadRequest{
+		Size: 4,
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Leave behind an old upload file,
+	// as though from before a restart.
+	orphan, err := os.CreateTemp(suite.uploadDir(), "gotosocial-upload-*")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	orphan.Close()
+	defer os.Remove(orphan.Name())
+
+	old := time.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(orphan.Name(), old, old); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// And a fresh one that may
+	// still be waiting on workers.
+	fresh, err := os.CreateTemp(suite.uploadDir(), "gotosocial-upload-*")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	fresh.Close()
+	defer os.Remove(fresh.Name())
+
+	suite.mediaProcessor.SweepUploads(ctx, time.Now())
+
+	// Old file should be gone.
+	_, err = os.Stat(orphan.Name())
+	suite.True(os.IsNotExist(err))
+
+	// Fresh file should remain.
+	_, err = os.Stat(fresh.Name())
+	suite.NoError(err)
+
+	// Upload in progress should be untouched.
+	upload, errWithCode = suite.mediaProcessor.UploadGet(ctx, testAccount, upload.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.EqualValues(0, upload.Offset)
+}
+
+func (suite *UploadTestSuite) TestUploadRestore() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	suite.state.Workers.Media.Start(1)
+	defer suite.state.Workers.Media.Stop()
+
+	b, err := os.ReadFile("../../../testrig/media/test-jpeg.jpg")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	upload, errWithCode := suite.mediaProcessor.UploadCreate(ctx, testAccount, &apimodel.MediaUploadRequest{
+		Size:        int64(len(b)),
+		Description: "a cool background from somewhere",
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	half := int64(len(b) / 2)
+	upload, errWithCode = suite.mediaProcessor.UploadAppend(ctx, testAccount, upload.ID, 0, bytes.NewReader(b[:half]))
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// A new processor, as though after a
+	// restart, should pick up the upload.
+	processor := mediaprocessing.New(&suite.state, suite.tc, suite.mediaManager, suite.transportController)
+	if err := processor.ScheduleUploadSweep(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+	defer suite.state.Workers.Scheduler.Cancel("@mediauploadsweep")
+
+	upload, errWithCode = processor.UploadGet(ctx, testAccount, upload.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(half, upload.Offset)
+
+	// Finish the upload from where it left off.
+	upload, errWithCode = processor.UploadAppend(ctx, testAccount, upload.ID, upload.Offset, bytes.NewReader(b[upload.Offset:]))
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.EqualValues(len(b), upload.Offset)
+
+	if attachment := upload.Attachment; suite.NotNil(attachment) {
+		suite.Equal("a cool background from somewhere", *attachment.Description)
+	}
+}
+
+// uploadDir returns the directory in which
+// uploads are spooled, creating it if needed.
+func (suite *UploadTestSuite) uploadDir() string {
+	dir := filepath.Join(config.GetStorageLocalBasePath(), storage.UploadsDir)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		suite.FailNow(err.Error())
+	}
+	return dir
+}
+
+func TestUploadTestSuite(t *testing.T) {
+	suite.Run(t, &UploadTestSuite{})
+}
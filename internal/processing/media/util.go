@@ -21,8 +21,20 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 )
 
+// expiresAt returns the time at which media should
+// expire given expiresIn seconds from now, or nil if
+// expiresIn is 0, meaning the media should not expire.
+func expiresAt(expiresIn int) *time.Time {
+	if expiresIn == 0 {
+		return nil
+	}
+	t := time.Now().Add(time.Duration(expiresIn) * time.Second)
+	return &t
+}
+
 func parseFocus(focus string) (focusx, focusy float32, err error) {
 	if focus == "" {
 		return
@@ -27,23 +27,34 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/account"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/admin"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/announcements"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/common"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/contentreveals"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/conversations"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/exports"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/fedi"
 	filtersv1 "github.com/superseriousbusiness/gotosocial/internal/processing/filters/v1"
+	filtersv2 "github.com/superseriousbusiness/gotosocial/internal/processing/filters/v2"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/imports"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/interactionrequests"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/list"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/markers"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/media"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/polls"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/push"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/report"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/search"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/status"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/stream"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/tags"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/timeline"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/trends"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/user"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/workers"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 )
 
 // Processor groups together processing functions and
@@ -69,21 +80,31 @@ type Processor struct {
 		SUB-PROCESSORS
 	*/
 
-	account   account.Processor
-	admin     admin.Processor
-	fedi      fedi.Processor
-	filtersv1 filtersv1.Processor
-	list      list.Processor
-	markers   markers.Processor
-	media     media.Processor
-	polls     polls.Processor
-	report    report.Processor
-	search    search.Processor
-	status    status.Processor
-	stream    stream.Processor
-	timeline  timeline.Processor
-	user      user.Processor
-	workers   workers.Processor
+	account             account.Processor
+	admin               admin.Processor
+	announcements       announcements.Processor
+	contentreveals      contentreveals.Processor
+	conversations       conversations.Processor
+	exports             exports.Processor
+	fedi                fedi.Processor
+	filtersv1           filtersv1.Processor
+	filtersv2           filtersv2.Processor
+	imports             imports.Processor
+	interactionrequests interactionrequests.Processor
+	list                list.Processor
+	markers             markers.Processor
+	media               media.Processor
+	polls               polls.Processor
+	push                push.Processor
+	report              report.Processor
+	search              search.Processor
+	status              status.Processor
+	stream              stream.Processor
+	timeline            timeline.Processor
+	tags                tags.Processor
+	trends              trends.Processor
+	user                user.Processor
+	workers             workers.Processor
 }
 
 func (p *Processor) Account() *account.Processor {
@@ -94,6 +115,22 @@ func (p *Processor) Admin() *admin.Processor {
 	return &p.admin
 }
 
+func (p *Processor) Announcements() *announcements.Processor {
+	return &p.announcements
+}
+
+func (p *Processor) ContentReveals() *contentreveals.Processor {
+	return &p.contentreveals
+}
+
+func (p *Processor) Conversations() *conversations.Processor {
+	return &p.conversations
+}
+
+func (p *Processor) Exports() *exports.Processor {
+	return &p.exports
+}
+
 func (p *Processor) Fedi() *fedi.Processor {
 	return &p.fedi
 }
@@ -102,6 +139,18 @@ func (p *Processor) FiltersV1() *filtersv1.Processor {
 	return &p.filtersv1
 }
 
+func (p *Processor) FiltersV2() *filtersv2.Processor {
+	return &p.filtersv2
+}
+
+func (p *Processor) Imports() *imports.Processor {
+	return &p.imports
+}
+
+func (p *Processor) InteractionRequests() *interactionrequests.Processor {
+	return &p.interactionrequests
+}
+
 func (p *Processor) List() *list.Processor {
 	return &p.list
 }
@@ -118,6 +167,10 @@ func (p *Processor) Polls() *polls.Processor {
 	return &p.polls
 }
 
+func (p *Processor) Push() *push.Processor {
+	return &p.push
+}
+
 func (p *Processor) Report() *report.Processor {
 	return &p.report
 }
@@ -138,6 +191,14 @@ func (p *Processor) Timeline() *timeline.Processor {
 	return &p.timeline
 }
 
+func (p *Processor) Tags() *tags.Processor {
+	return &p.tags
+}
+
+func (p *Processor) Trends() *trends.Processor {
+	return &p.trends
+}
+
 func (p *Processor) User() *user.Processor {
 	return &p.user
 }
@@ -155,6 +216,7 @@ func NewProcessor(
 	mediaManager *mm.Manager,
 	state *state.State,
 	emailSender email.Sender,
+	webPushSender webpush.Sender,
 ) *Processor {
 	var (
 		parseMentionFunc = GetParseMentionFunc(state, federator)
@@ -181,14 +243,24 @@ func NewProcessor(
 	// Instantiate the rest of the sub
 	// processors + pin them to this struct.
 	processor.account = account.New(&common, state, converter, mediaManager, oauthServer, federator, filter, parseMentionFunc)
-	processor.admin = admin.New(state, cleaner, converter, mediaManager, federator.TransportController(), emailSender)
+	processor.admin = admin.New(state, cleaner, converter, mediaManager, federator.TransportController(), emailSender, parseMentionFunc, &processor.account, &processor.stream, &processor.status)
+	processor.announcements = announcements.New(state, converter, &processor.stream)
 	processor.fedi = fedi.New(state, &common, converter, federator, filter)
 	processor.filtersv1 = filtersv1.New(state, converter)
+	processor.filtersv2 = filtersv2.New(state, converter)
+	processor.contentreveals = contentreveals.New(&common, state, converter)
+	processor.conversations = conversations.New(state, converter)
+	processor.exports = exports.New(state, converter)
+	processor.imports = imports.New(state, federator, converter, &processor.account, &processor.list)
+	processor.interactionrequests = interactionrequests.New(state, converter)
 	processor.list = list.New(state, converter)
 	processor.markers = markers.New(state, converter)
 	processor.polls = polls.New(&common, state, converter)
+	processor.push = push.New(state, converter)
 	processor.report = report.New(state, converter)
 	processor.timeline = timeline.New(state, converter, filter)
+	processor.tags = tags.New(state, converter)
+	processor.trends = trends.New(state, converter)
 	processor.search = search.New(state, federator, converter, filter)
 	processor.status = status.New(state, &common, &processor.polls, federator, converter, filter, parseMentionFunc)
 	processor.user = user.New(state, emailSender)
@@ -202,9 +274,12 @@ func NewProcessor(
 		converter,
 		filter,
 		emailSender,
+		webPushSender,
 		&processor.account,
 		&processor.media,
 		&processor.stream,
+		&processor.status,
+		&processor.search,
 	)
 
 	return processor
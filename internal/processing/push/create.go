@@ -0,0 +1,99 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package push
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
+)
+
+// CreateSubscription creates a Web Push subscription for the
+// given account's token with the given access, replacing
+// any existing subscription of that token.
+func (p *Processor) CreateSubscription(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	accessToken string,
+	form *apimodel.WebPushSubscriptionCreateRequest,
+) (*apimodel.WebPushSubscription, gtserror.WithCode) {
+	if form.Subscription == nil {
+		const text = "subscription must be provided"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	endpoint, err := url.Parse(form.Subscription.Endpoint)
+	if err != nil || endpoint.Scheme != "https" || endpoint.Host == "" {
+		const text = "subscription endpoint must be an https URL"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	keys := form.Subscription.Keys
+	if err := webpush.ValidateKeys(keys.P256dh, keys.Auth); err != nil {
+		text := "invalid subscription keys: " + err.Error()
+		return nil, gtserror.NewErrorBadRequest(err, text)
+	}
+
+	token, errWithCode := p.getToken(ctx, accessToken)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	subscription := &gtsmodel.WebPushSubscription{
+		ID:                 id.NewULID(),
+		AccountID:          account.ID,
+		TokenID:            token.ID,
+		Endpoint:           endpoint.String(),
+		P256dh:             keys.P256dh,
+		Auth:               keys.Auth,
+		AlertFollow:        util.Ptr(false),
+		AlertFollowRequest: util.Ptr(false),
+		AlertFavourite:     util.Ptr(false),
+		AlertMention:       util.Ptr(false),
+		AlertReblog:        util.Ptr(false),
+		AlertPoll:          util.Ptr(false),
+		AlertStatus:        util.Ptr(false),
+		AlertSignup:        util.Ptr(false),
+		Policy:             gtsmodel.WebPushSubscriptionPolicyAll,
+	}
+
+	if errWithCode := applyData(subscription, form.Data); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// A token can only have one subscription,
+	// so the new one replaces any existing.
+	if err := p.state.DB.DeleteWebPushSubscriptionByTokenID(ctx, token.ID); err != nil {
+		err := gtserror.Newf("db error deleting existing push subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.PutWebPushSubscription(ctx, subscription); err != nil {
+		err := gtserror.Newf("db error putting push subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiSubscription(ctx, subscription)
+}
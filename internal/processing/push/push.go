@@ -0,0 +1,130 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package push
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type Processor struct {
+	state     *state.State
+	converter *typeutils.Converter
+}
+
+func New(state *state.State, converter *typeutils.Converter) Processor {
+	return Processor{
+		state:     state,
+		converter: converter,
+	}
+}
+
+// getToken gets the database
+// token with the given access.
+func (p *Processor) getToken(ctx context.Context, accessToken string) (*gtsmodel.Token, gtserror.WithCode) {
+	token, err := p.state.DB.GetTokenByAccess(ctx, accessToken)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			const text = "token not found"
+			return nil, gtserror.NewErrorUnauthorized(errors.New(text), text)
+		}
+		err := gtserror.Newf("db error getting token: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return token, nil
+}
+
+// getSubscription gets the web push
+// subscription of the given token.
+func (p *Processor) getSubscription(ctx context.Context, token *gtsmodel.Token) (*gtsmodel.WebPushSubscription, gtserror.WithCode) {
+	subscription, err := p.state.DB.GetWebPushSubscriptionByTokenID(ctx, token.ID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			const text = "push subscription not found"
+			return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+		}
+		err := gtserror.Newf("db error getting push subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return subscription, nil
+}
+
+// apiSubscription converts the given subscription to its API model.
+func (p *Processor) apiSubscription(ctx context.Context, subscription *gtsmodel.WebPushSubscription) (*apimodel.WebPushSubscription, gtserror.WithCode) {
+	apiSubscription, err := p.converter.WebPushSubscriptionToAPIWebPushSubscription(ctx, subscription)
+	if err != nil {
+		err := gtserror.Newf("error converting push subscription to api: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiSubscription, nil
+}
+
+// applyData applies the alerts and policy from the given
+// request data to the given subscription, leaving values
+// not provided as they are.
+func applyData(subscription *gtsmodel.WebPushSubscription, data *apimodel.WebPushSubscriptionRequestData) gtserror.WithCode {
+	if data == nil {
+		return nil
+	}
+
+	if alerts := data.Alerts; alerts != nil {
+		for _, alert := range []struct {
+			value *bool
+			field **bool
+		}{
+			{alerts.Follow, &subscription.AlertFollow},
+			{alerts.FollowRequest, &subscription.AlertFollowRequest},
+			{alerts.Favourite, &subscription.AlertFavourite},
+			{alerts.Mention, &subscription.AlertMention},
+			{alerts.Reblog, &subscription.AlertReblog},
+			{alerts.Poll, &subscription.AlertPoll},
+			{alerts.Status, &subscription.AlertStatus},
+			{alerts.AdminSignup, &subscription.AlertSignup},
+		} {
+			if alert.value != nil {
+				*alert.field = util.Ptr(*alert.value)
+			}
+		}
+	}
+
+	if data.Policy != nil {
+		switch policy := gtsmodel.WebPushSubscriptionPolicy(*data.Policy); policy {
+		case gtsmodel.WebPushSubscriptionPolicyAll,
+			gtsmodel.WebPushSubscriptionPolicyFollowed,
+			gtsmodel.WebPushSubscriptionPolicyFollower,
+			gtsmodel.WebPushSubscriptionPolicyNone:
+			subscription.Policy = policy
+		default:
+			const text = "policy must be one of all, followed, follower, none"
+			return gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+	}
+
+	return nil
+}
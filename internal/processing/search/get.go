@@ -71,6 +71,12 @@ func (p *Processor) Get(
 		resolve   = req.Resolve
 		following = req.Following
 
+		// Narrow down status results to
+		// statuses from the given account.
+		filter = statusFilter{
+			fromAccountID: req.AccountID,
+		}
+
 		// Include instance accounts in the first
 		// parts of this search. This will be
 		// changed to 'false' when doing text
@@ -114,24 +120,10 @@ func (p *Processor) Get(
 			{"queryType", queryType},
 			{"resolve", resolve},
 			{"following", following},
+			{"accountID", req.AccountID},
 		}...).
 		Debugf("beginning search")
 
-	// todo: Currently we don't support offset for paging;
-	// a caller can page using maxID or minID, but if they
-	// supply an offset greater than 0, return nothing as
-	// though there were no additional results.
-	if req.Offset > 0 {
-		return p.packageSearchResult(
-			ctx,
-			account,
-			nil, nil, nil, // No results.
-			req.APIv1,
-			includeInstanceAccounts,
-			includeBlockedAccounts,
-		)
-	}
-
 	var (
 		foundStatuses = make([]*gtsmodel.Status, 0, limit)
 		foundAccounts = make([]*gtsmodel.Account, 0, limit)
@@ -142,6 +134,58 @@ func (p *Processor) Get(
 		err           error
 	)
 
+	// Search operators only apply to statuses,
+	// so only look for them if search type
+	// includes statuses.
+	if includeStatuses(queryType) {
+		statusQuery, from, has := parseOperators(query)
+		if from != "" || len(has) > 0 {
+			// Query contained operators, so
+			// caller is looking for statuses,
+			// and nothing else. Search for
+			// statuses using remaining text.
+			possible, errWithCode := p.applyOperators(
+				ctx,
+				account,
+				&filter,
+				from,
+				has,
+				resolve,
+			)
+			if errWithCode != nil {
+				return nil, errWithCode
+			}
+
+			if possible {
+				if err := p.statusesByText(
+					ctx,
+					account.ID,
+					maxID,
+					minID,
+					limit,
+					offset,
+					statusQuery,
+					filter,
+					appendStatus,
+				); err != nil && !errors.Is(err, db.ErrNoEntries) {
+					err = gtserror.Newf("error searching by text: %w", err)
+					return nil, gtserror.NewErrorInternalError(err)
+				}
+			}
+
+			return p.packageSearchResult(
+				ctx,
+				account,
+				foundAccounts,
+				foundStatuses,
+				foundTags,
+				req.APIv1,
+				includeInstanceAccounts,
+				includeBlockedAccounts,
+			)
+		}
+	}
+
 	// Only try to search by namestring if search type includes
 	// accounts, since this is all namestring search can return.
 	if includeAccounts(queryType) {
@@ -219,17 +263,22 @@ func (p *Processor) Get(
 		// caller wants to include blocked accounts too.
 		includeBlockedAccounts = true
 
-		if err := p.byURI(
-			ctx,
-			account,
-			uri,
-			queryType,
-			resolve,
-			appendAccount,
-			appendStatus,
-		); err != nil && !errors.Is(err, db.ErrNoEntries) {
-			err = gtserror.Newf("error searching by URI: %w", err)
-			return nil, gtserror.NewErrorInternalError(err)
+		// A URI can only ever match one
+		// result, so there's nothing to
+		// return for later offsets.
+		if offset == 0 {
+			if err := p.byURI(
+				ctx,
+				account,
+				uri,
+				queryType,
+				resolve,
+				appendAccount,
+				appendStatus,
+			); err != nil && !errors.Is(err, db.ErrNoEntries) {
+				err = gtserror.Newf("error searching by URI: %w", err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
 		}
 
 		// This was a URI, so at this point just return
@@ -309,6 +358,7 @@ func (p *Processor) Get(
 		query,
 		queryType,
 		following,
+		filter,
 		appendAccount,
 		appendStatus,
 	); err != nil && !errors.Is(err, db.ErrNoEntries) {
@@ -369,6 +419,13 @@ func (p *Processor) accountsByUsernameDomain(
 		)
 	}
 
+	if offset > 0 {
+		// Domain and username were both set,
+		// so this can only ever match one
+		// result; nothing for later offsets.
+		return nil
+	}
+
 	// Domain and username were both set.
 	// Caller is likely trying to search for an exact
 	// match, from either a remote instance or local.
@@ -743,6 +800,7 @@ func (p *Processor) byText(
 	query string,
 	queryType string,
 	following bool,
+	filter statusFilter,
 	appendAccount func(*gtsmodel.Account),
 	appendStatus func(*gtsmodel.Status),
 ) error {
@@ -779,6 +837,7 @@ func (p *Processor) byText(
 			limit,
 			offset,
 			query,
+			filter,
 			appendStatus,
 		); err != nil {
 			return err
@@ -817,7 +876,12 @@ func (p *Processor) accountsByText(
 }
 
 // statusesByText searches in the database for limit
-// number of statuses using the given query text.
+// number of statuses using the given query text, and
+// narrowed down by the given status filter. Empty
+// query text matches all statuses.
+//
+// If the status search index is enabled, it's used
+// to search bookmarked and faved statuses as well.
 func (p *Processor) statusesByText(
 	ctx context.Context,
 	requestingAccountID string,
@@ -826,12 +890,22 @@ func (p *Processor) statusesByText(
 	limit int,
 	offset int,
 	query string,
+	filter statusFilter,
 	appendStatus func(*gtsmodel.Status),
 ) error {
-	statuses, err := p.state.DB.SearchForStatuses(
+	searchForStatuses := p.state.DB.SearchForStatuses
+	if config.GetStatusesSearchIndex() {
+		searchForStatuses = p.state.DB.SearchForIndexedStatuses
+	}
+
+	statuses, err := searchForStatuses(
 		ctx,
 		requestingAccountID,
-		query, maxID, minID, limit, offset)
+		query,
+		filter.fromAccountID,
+		filter.hasMedia,
+		filter.hasPoll,
+		maxID, minID, limit, offset)
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
 		return gtserror.Newf("error checking database for statuses using text %s: %w", query, err)
 	}
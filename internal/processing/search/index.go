@@ -0,0 +1,184 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package search
+
+import (
+	"context"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+// indexPageSize is the number of statuses
+// put in the status search index at a time
+// when building it from scratch.
+const indexPageSize = 100
+
+// separateBlocks ensures text in consecutive HTML
+// lines and paragraphs doesn't get run together
+// into a single word when HTML is removed.
+var separateBlocks = strings.NewReplacer(
+	"<br", " <br",
+	"</p>", "</p> ",
+	"</li>", "</li> ",
+)
+
+// IndexStatus puts the plaintext of the given status in
+// the status search index, if statuses-search-index is
+// enabled. Boosts have no text of their own, so are skipped.
+func (p *Processor) IndexStatus(ctx context.Context, status *gtsmodel.Status) error {
+	if !config.GetStatusesSearchIndex() || status.BoostOfID != "" {
+		return nil
+	}
+
+	if !status.AttachmentsPopulated() {
+		// Media descriptions are indexed too,
+		// so make sure we have attachments.
+		if err := p.state.DB.PopulateStatus(ctx, status); err != nil {
+			log.Debugf(ctx, "error populating status %s: %v", status.ID, err)
+		}
+	}
+
+	if err := p.state.DB.PutStatusSearchText(ctx,
+		status.ID,
+		statusSearchText(status),
+	); err != nil {
+		return gtserror.Newf("db error indexing status %s: %w", status.ID, err)
+	}
+
+	return nil
+}
+
+// UnindexStatus removes the status with the given ID from
+// the status search index, if statuses-search-index is enabled.
+func (p *Processor) UnindexStatus(ctx context.Context, statusID string) error {
+	if !config.GetStatusesSearchIndex() {
+		return nil
+	}
+
+	if err := p.state.DB.DeleteStatusSearchText(ctx, statusID); err != nil {
+		return gtserror.Newf("db error unindexing status %s: %w", statusID, err)
+	}
+
+	return nil
+}
+
+// PrepareStatusIndex brings the status search index in line
+// with statuses-search-index on startup. When the index is
+// enabled but empty, building it is queued on the processing
+// workers. When it's disabled, any previous index is cleared,
+// so it doesn't go stale while unmaintained.
+func (p *Processor) PrepareStatusIndex(ctx context.Context) error {
+	count, err := p.state.DB.CountStatusSearchTexts(ctx)
+	if err != nil {
+		return gtserror.Newf("db error counting indexed statuses: %w", err)
+	}
+
+	if !config.GetStatusesSearchIndex() {
+		if count == 0 {
+			// Nothing to clear.
+			return nil
+		}
+
+		log.Infof(ctx, "clearing status search index of %d statuses", count)
+		if err := p.state.DB.DeleteAllStatusSearchTexts(ctx); err != nil {
+			return gtserror.Newf("db error clearing status search index: %w", err)
+		}
+
+		return nil
+	}
+
+	if count != 0 {
+		// Index already built, and
+		// kept up to date since.
+		return nil
+	}
+
+	p.state.Workers.Processing.Queue.Push(p.buildStatusIndex)
+	return nil
+}
+
+// buildStatusIndex puts all statuses in
+// the status search index, newest first.
+func (p *Processor) buildStatusIndex(ctx context.Context) {
+	log.Info(ctx, "building status search index")
+
+	var (
+		maxID   string
+		indexed int
+	)
+
+	for {
+		statuses, err := p.state.DB.GetStatusesToIndex(ctx, maxID, indexPageSize)
+		if err != nil {
+			log.Errorf(ctx, "db error getting statuses to index: %v", err)
+			return
+		}
+
+		if len(statuses) == 0 {
+			// Reached the end.
+			break
+		}
+
+		for _, status := range statuses {
+			if err := p.IndexStatus(ctx, status); err != nil {
+				log.Errorf(ctx, "error indexing status: %v", err)
+				continue
+			}
+			indexed++
+		}
+
+		// Page down from oldest status of this page.
+		maxID = statuses[len(statuses)-1].ID
+	}
+
+	log.Infof(ctx, "built status search index of %d statuses", indexed)
+}
+
+// statusSearchText returns the plaintext of all
+// searchable parts of the given status, that is its
+// content warning, content, media descriptions and
+// poll options.
+func statusSearchText(status *gtsmodel.Status) string {
+	parts := make([]string, 0, 2+len(status.Attachments))
+
+	if status.ContentWarning != "" {
+		parts = append(parts, text.SanitizeToPlaintext(status.ContentWarning))
+	}
+
+	if status.Content != "" {
+		content := separateBlocks.Replace(status.Content)
+		parts = append(parts, text.SanitizeToPlaintext(content))
+	}
+
+	for _, attachment := range status.Attachments {
+		if attachment != nil && attachment.Description != "" {
+			parts = append(parts, attachment.Description)
+		}
+	}
+
+	if status.Poll != nil {
+		parts = append(parts, status.Poll.Options...)
+	}
+
+	return strings.Join(parts, "\n")
+}
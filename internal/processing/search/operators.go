@@ -0,0 +1,161 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+const (
+	operatorFrom = "from:"
+	operatorHas  = "has:"
+
+	fromMe = "me"
+
+	hasMedia = "media"
+	hasPoll  = "poll"
+)
+
+// statusFilter narrows down
+// status search results.
+type statusFilter struct {
+	fromAccountID string // Only statuses created by this account.
+	hasMedia      bool   // Only statuses with media attachments.
+	hasPoll       bool   // Only statuses with a poll.
+}
+
+// parseOperators splits `from:` and `has:` search
+// operators out of the given query, returning the
+// remaining query text, and the operators' values.
+// Later operators of the same kind override earlier
+// ones, other than `has:`, which may be repeated.
+func parseOperators(query string) (text string, from string, has []string) {
+	var words []string
+
+	for _, word := range strings.Fields(query) {
+		lower := strings.ToLower(word)
+
+		switch {
+		case strings.HasPrefix(lower, operatorFrom) && len(word) > len(operatorFrom):
+			from = word[len(operatorFrom):]
+
+		case strings.HasPrefix(lower, operatorHas) && len(word) > len(operatorHas):
+			has = append(has, lower[len(operatorHas):])
+
+		default:
+			words = append(words, word)
+		}
+	}
+
+	return strings.Join(words, " "), from, has
+}
+
+// applyOperators sets the given `from:` and `has:`
+// operator values on the given status filter, looking
+// up the `from:` account, resolving it if allowed.
+//
+// The boolean return value indicates to the caller
+// whether results are possible at all, since a
+// `from:` account that can't be found, or that
+// conflicts with an existing fromAccountID on the
+// filter, means no statuses can match.
+func (p *Processor) applyOperators(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	filter *statusFilter,
+	from string,
+	has []string,
+	resolve bool,
+) (bool, gtserror.WithCode) {
+	for _, h := range has {
+		switch h {
+		case hasMedia:
+			filter.hasMedia = true
+		case hasPoll:
+			filter.hasPoll = true
+		default:
+			err := fmt.Errorf(
+				"search operator %s%s was not recognized, valid options are ['%s%s', '%s%s']",
+				operatorHas, h, operatorHas, hasMedia, operatorHas, hasPoll,
+			)
+			return false, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+	}
+
+	if from == "" {
+		// No account
+		// to look up.
+		return true, nil
+	}
+
+	var fromAccountID string
+	if strings.ToLower(from) == fromMe {
+		fromAccountID = requestingAccount.ID
+	} else {
+		// Be generous and accept
+		// from: account namestrings
+		// without leading '@'.
+		namestring := "@" + strings.TrimPrefix(from, "@")
+		username, domain, err := util.ExtractNamestringParts(namestring)
+		if err != nil {
+			err := fmt.Errorf("search operator %s%s was not a valid account", operatorFrom, from)
+			return false, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		account, err := p.accountByUsernameDomain(
+			ctx,
+			requestingAccount,
+			username,
+			domain,
+			resolve,
+		)
+		if err != nil {
+			// Check for semi-expected error types.
+			// On one of these, there can't be any
+			// statuses from this account to find.
+			if gtserror.IsUnretrievable(err) ||
+				gtserror.IsWrongType(err) ||
+				errors.Is(err, db.ErrNoEntries) {
+				return false, nil
+			}
+
+			err = gtserror.Newf("error looking up %s as account: %w", namestring, err)
+			return false, gtserror.NewErrorInternalError(err)
+		}
+
+		fromAccountID = account.ID
+	}
+
+	if filter.fromAccountID != "" &&
+		filter.fromAccountID != fromAccountID {
+		// Statuses can't be
+		// from two accounts.
+		return false, nil
+	}
+
+	filter.fromAccountID = fromAccountID
+	return true, nil
+}
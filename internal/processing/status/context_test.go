@@ -0,0 +1,60 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type StatusContextTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusContextTestSuite) TestContextGetPaged() {
+	ctx := context.Background()
+
+	requester := suite.testAccounts["local_account_1"]
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	// Get the whole context first.
+	full, errWithCode := suite.status.ContextGet(ctx, requester, targetStatus.ID, 0, 0)
+	suite.NoError(errWithCode)
+	suite.Len(full.Descendants, 2)
+
+	// Page through descendants one at a time,
+	// and make sure they come back in thread order.
+	for i, expected := range full.Descendants {
+		paged, errWithCode := suite.status.ContextGet(ctx, requester, targetStatus.ID, 1, i)
+		suite.NoError(errWithCode)
+		suite.Len(paged.Descendants, 1)
+		suite.Equal(expected.ID, paged.Descendants[0].ID)
+		suite.Equal(full.Ancestors, paged.Ancestors)
+	}
+
+	// Paging off the end should give no descendants.
+	paged, errWithCode := suite.status.ContextGet(ctx, requester, targetStatus.ID, 1, len(full.Descendants))
+	suite.NoError(errWithCode)
+	suite.Empty(paged.Descendants)
+}
+
+func TestStatusContextTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusContextTestSuite))
+}
@@ -21,17 +21,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/common"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
@@ -40,6 +43,9 @@ import (
 
 // Create processes the given form to create a new status, returning the api model representation of that status if it's OK.
 //
+// If an idempotency key is set on the context, and a status was already created by requester with
+// that key within the last hour, then the existing status will be returned instead of a new one.
+//
 // Precondition: the form's fields should have already been validated and normalized by the caller.
 func (p *Processor) Create(
 	ctx context.Context,
@@ -49,12 +55,53 @@ func (p *Processor) Create(
 ) (
 	*apimodel.Status,
 	gtserror.WithCode,
+) {
+	key := common.IdempotencyKey(ctx, "status", requester.ID)
+	if key == "" {
+		// No idempotency
+		// key, just create.
+		return p.create(ctx, requester, application, form)
+	}
+
+	// Lock on key so that concurrent
+	// retries wait for the first one.
+	unlock := p.state.ProcessingLocks.Lock(key)
+	defer unlock()
+
+	if statusID, ok := p.state.Caches.Idempotency.Get(key); ok {
+		// Status already created
+		// with this key, return it.
+		return p.Get(ctx, requester, statusID)
+	}
+
+	apiStatus, errWithCode := p.create(ctx, requester, application, form)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	p.state.Caches.Idempotency.Set(key, apiStatus.ID)
+	return apiStatus, nil
+}
+
+func (p *Processor) create(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	application *gtsmodel.Application,
+	form *apimodel.AdvancedStatusCreateForm,
+) (
+	*apimodel.Status,
+	gtserror.WithCode,
 ) {
 	// Ensure account populated; we'll need settings.
 	if err := p.state.DB.PopulateAccount(ctx, requester); err != nil {
 		log.Errorf(ctx, "error(s) populating account, will continue: %s", err)
 	}
 
+	// Ensure account hasn't hit its posting limit.
+	if errWithCode := p.checkStatusRateLimit(ctx, requester); errWithCode != nil {
+		return nil, errWithCode
+	}
+
 	// Generate new ID for status.
 	statusID := id.NewULID()
 
@@ -109,6 +156,15 @@ func (p *Processor) Create(
 		return nil, errWithCode
 	}
 
+	// Check + attach quoted status.
+	if errWithCode := p.processQuote(ctx,
+		requester,
+		status,
+		form.QuoteID,
+	); errWithCode != nil {
+		return nil, errWithCode
+	}
+
 	if errWithCode := p.processThreadID(ctx, status); errWithCode != nil {
 		return nil, errWithCode
 	}
@@ -129,6 +185,18 @@ func (p *Processor) Create(
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	if err := p.processDirectRecipients(ctx, status, nil); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Inline images count as attachments too,
+	// so check the limit now we've got them all.
+	maxMediaFiles := config.GetStatusesMediaMaxFiles()
+	if len(status.AttachmentIDs) > maxMediaFiles {
+		text := fmt.Sprintf("too many media files attached to status, %d attached but limit is %d", len(status.AttachmentIDs), maxMediaFiles)
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
 	if status.Poll != nil {
 		// Try to insert the new status poll in the database.
 		if err := p.state.DB.PutPoll(ctx, status.Poll); err != nil {
@@ -161,6 +229,33 @@ func (p *Processor) Create(
 	return p.c.GetAPIStatus(ctx, requester, status)
 }
 
+// checkStatusRateLimit returns an error if requester has a
+// status rate limit set in their settings (eg., a bot account
+// created via the CLI), and has already created that many
+// statuses in the last hour.
+func (p *Processor) checkStatusRateLimit(ctx context.Context, requester *gtsmodel.Account) gtserror.WithCode {
+	if requester.Settings == nil || requester.Settings.StatusRateLimit <= 0 {
+		// No limit set.
+		return nil
+	}
+
+	limit := requester.Settings.StatusRateLimit
+	since := time.Now().Add(-time.Hour)
+
+	count, err := p.state.DB.CountAccountStatusesSince(ctx, requester.ID, since)
+	if err != nil {
+		err := gtserror.Newf("db error counting statuses: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if count >= limit {
+		err := fmt.Errorf("this account may create at most %d statuses per hour; try again later", limit)
+		return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	return nil
+}
+
 func (p *Processor) processInReplyTo(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status, inReplyToID string) gtserror.WithCode {
 	if inReplyToID == "" {
 		return nil
@@ -185,7 +280,13 @@ func (p *Processor) processInReplyTo(ctx context.Context, requester *gtsmodel.Ac
 		return errWithCode
 	}
 
-	if !*inReplyTo.Replyable {
+	replyable, err := p.filter.StatusInteractable(ctx, requester, inReplyTo, gtsmodel.InteractionReply)
+	if err != nil {
+		err := gtserror.Newf("error checking status replyability: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if !replyable {
 		const text = "in-reply-to status marked as not replyable"
 		return gtserror.NewErrorForbidden(errors.New(text), text)
 	}
@@ -199,6 +300,59 @@ func (p *Processor) processInReplyTo(ctx context.Context, requester *gtsmodel.Ac
 	return nil
 }
 
+func (p *Processor) processQuote(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status, quoteID string) gtserror.WithCode {
+	if quoteID == "" {
+		return nil
+	}
+
+	// Fetch target quoted status (checking visibility).
+	quoteOf, errWithCode := p.c.GetVisibleTargetStatus(ctx,
+		requester,
+		quoteID,
+		nil,
+	)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	// If this is a boost, unwrap it to get source status.
+	quoteOf, errWithCode = p.c.UnwrapIfBoost(ctx,
+		requester,
+		quoteOf,
+	)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	// Quoting is effectively boosting with commentary,
+	// so only allow quoting statuses that could be boosted
+	// by anyone, ie., boostable public or unlisted statuses.
+	if !*quoteOf.Boostable ||
+		(quoteOf.Visibility != gtsmodel.VisibilityPublic &&
+			quoteOf.Visibility != gtsmodel.VisibilityUnlocked) {
+		const text = "quoted status must be a boostable public or unlisted status"
+		return gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	boostable, err := p.filter.StatusInteractable(ctx, requester, quoteOf, gtsmodel.InteractionAnnounce)
+	if err != nil {
+		err := gtserror.Newf("error checking status boostability: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if !boostable {
+		const text = "quoted status interaction policy does not permit boosting"
+		return gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	// Set status fields from quoted status.
+	status.QuoteOfID = quoteOf.ID
+	status.QuoteOf = quoteOf
+	status.QuoteOfURI = quoteOf.URI
+
+	return nil
+}
+
 func (p *Processor) processThreadID(ctx context.Context, status *gtsmodel.Status) gtserror.WithCode {
 	// Status takes the thread ID of
 	// whatever it replies to, if set.
@@ -267,7 +421,10 @@ func (p *Processor) processMediaIDs(ctx context.Context, form *apimodel.Advanced
 			return gtserror.NewErrorBadRequest(errors.New(text), text)
 		}
 
-		if attachment.StatusID != "" || attachment.ScheduledStatusID != "" {
+		// Media may only be attached to this status
+		// already, which is the case when editing.
+		if (attachment.StatusID != "" && attachment.StatusID != status.ID) ||
+			attachment.ScheduledStatusID != "" {
 			text := fmt.Sprintf("media %s already attached to status", mediaID)
 			return gtserror.NewErrorBadRequest(errors.New(text), text)
 		}
@@ -356,6 +513,14 @@ func processVisibility(form *apimodel.AdvancedStatusCreateForm, accountDefaultVi
 	status.Boostable = &boostable
 	status.Replyable = &replyable
 	status.Likeable = &likeable
+
+	if vis != gtsmodel.VisibilityDirect {
+		// Direct statuses are only ever interacted with by
+		// their participants, any other status may further
+		// restrict who is permitted to interact with it.
+		status.InteractionPolicy = typeutils.APIInteractionPolicyToInteractionPolicy(form.InteractionPolicy)
+	}
+
 	return nil
 }
 
@@ -416,6 +581,14 @@ func (p *Processor) processContent(ctx context.Context, parseMention gtsmodel.Pa
 	status.Emojis = append(status.Emojis, contentRes.Emojis...)
 	status.Tags = append(status.Tags, contentRes.Tags...)
 
+	// Attach any inline images not already attached.
+	for _, attachment := range contentRes.Attachments {
+		if !slices.Contains(status.AttachmentIDs, attachment.ID) {
+			status.Attachments = append(status.Attachments, attachment)
+			status.AttachmentIDs = append(status.AttachmentIDs, attachment.ID)
+		}
+	}
+
 	// From here-on-out just use emoji-only
 	// plain-text formatting as the FormatFunc.
 	format = p.formatter.FromPlainEmojiOnly
@@ -448,6 +621,144 @@ func (p *Processor) processContent(ctx context.Context, parseMention gtsmodel.Pa
 	return nil
 }
 
+// processDirectRecipients ensures that a direct reply to a direct status
+// stays addressed to everyone taking part in the conversation, even
+// those not mentioned in the reply text, by adding implicit mentions.
+//
+// This lets a direct status addressed to several accounts behave as a
+// lightweight group conversation, where all recipients see each other's
+// replies, both here and (via the addressing of mentions) when federated.
+//
+// Implicit mentions are only added when the reply mentions none of the
+// conversation's participants: if it mentions any, the author chose the
+// audience explicitly, and anyone left out was left out on purpose.
+// Participants blocking or blocked by the author are never added.
+//
+// When editing, existing should be the mentions of the status before the
+// edit, which are reused for recipients they target instead of new ones.
+func (p *Processor) processDirectRecipients(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	existing []*gtsmodel.Mention,
+) error {
+	if status.Visibility != gtsmodel.VisibilityDirect ||
+		status.InReplyToID == "" {
+		// Not a direct reply.
+		return nil
+	}
+
+	inReplyTo := status.InReplyTo
+	if inReplyTo == nil {
+		// Parent status not set (eg., on edit), fetch from database.
+		var err error
+		inReplyTo, err = p.state.DB.GetStatusByID(
+			gtscontext.SetBarebones(ctx),
+			status.InReplyToID,
+		)
+		if err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				// Parent has since
+				// been deleted.
+				return nil
+			}
+			return gtserror.Newf("error getting in-reply-to status %s: %w", status.InReplyToID, err)
+		}
+	}
+
+	if inReplyTo.Visibility != gtsmodel.VisibilityDirect {
+		// Not a reply in
+		// a conversation.
+		return nil
+	}
+
+	if !inReplyTo.MentionsPopulated() {
+		// Parent status needs its mentions populating, fetch these from database.
+		mentions, err := p.state.DB.GetMentions(ctx, inReplyTo.MentionIDs)
+		if err != nil {
+			return gtserror.Newf("error populating in-reply-to status %s mentions: %w", inReplyTo.ID, err)
+		}
+		inReplyTo.Mentions = mentions
+	}
+
+	// Gather everyone the parent was addressed
+	// to, plus its author, except the replier.
+	recipientIDs := make([]string, 0, 1+len(inReplyTo.Mentions))
+	recipientIDs = append(recipientIDs, inReplyTo.AccountID)
+	for _, mention := range inReplyTo.Mentions {
+		recipientIDs = append(recipientIDs, mention.TargetAccountID)
+	}
+	recipientIDs = slices.DeleteFunc(recipientIDs, func(id string) bool {
+		return id == status.AccountID
+	})
+
+	if slices.ContainsFunc(recipientIDs, status.MentionsAccount) {
+		// Audience chosen
+		// explicitly, leave it.
+		return nil
+	}
+
+	for _, recipientID := range recipientIDs {
+		if status.MentionsAccount(recipientID) {
+			// Already added.
+			continue
+		}
+
+		blocked, err := p.state.DB.IsEitherBlocked(ctx, status.AccountID, recipientID)
+		if err != nil {
+			return gtserror.Newf("error checking block between %s and %s: %w", status.AccountID, recipientID, err)
+		}
+
+		if blocked {
+			// Don't address
+			// across blocks.
+			continue
+		}
+
+		// Reuse existing mention
+		// of recipient, if any.
+		var mention *gtsmodel.Mention
+		for _, m := range existing {
+			if m.TargetAccountID == recipientID {
+				mention = m
+				break
+			}
+		}
+
+		if mention == nil {
+			recipient, err := p.state.DB.GetAccountByID(
+				gtscontext.SetBarebones(ctx),
+				recipientID,
+			)
+			if err != nil {
+				// Account may have since been deleted.
+				log.Debugf(ctx, "skipping direct recipient %s: %v", recipientID, err)
+				continue
+			}
+
+			mention = &gtsmodel.Mention{
+				ID:               id.NewULID(),
+				StatusID:         status.ID,
+				OriginAccountID:  status.AccountID,
+				OriginAccountURI: status.AccountURI,
+				OriginAccount:    status.Account,
+				TargetAccountID:  recipient.ID,
+				TargetAccountURI: recipient.URI,
+				TargetAccountURL: recipient.URL,
+				TargetAccount:    recipient,
+			}
+
+			if err := p.state.DB.PutMention(ctx, mention); err != nil {
+				return gtserror.Newf("error putting direct recipient mention in db: %w", err)
+			}
+		}
+
+		status.Mentions = append(status.Mentions, mention)
+		status.MentionIDs = append(status.MentionIDs, mention.ID)
+	}
+
+	return nil
+}
+
 // gatherIDs is a small utility function to gather IDs from a slice of type T.
 func gatherIDs[T any](in []T, getID func(T) string) []string {
 	if getID == nil {
@@ -19,13 +19,16 @@ package status_test
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 )
 
 type StatusCreateTestSuite struct {
@@ -174,6 +177,43 @@ func (suite *StatusCreateTestSuite) TestProcessStatusMarkdownWithSpoilerTextEmoj
 	suite.NotEmpty(apiStatus.Emojis)
 }
 
+func (suite *StatusCreateTestSuite) TestProcessStatusMarkdownWithInlineImage() {
+	ctx := context.Background()
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+	attachment := suite.testAttachments["local_account_1_unattached_1"]
+
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "look at this!\n\n![an image](" + attachment.URL + ")",
+			MediaIDs:    []string{},
+			Poll:        nil,
+			InReplyToID: "",
+			Sensitive:   false,
+			Visibility:  apimodel.VisibilityPublic,
+			ScheduledAt: "",
+			Language:    "en",
+			ContentType: apimodel.StatusContentTypeMarkdown,
+		},
+		AdvancedVisibilityFlagsForm: apimodel.AdvancedVisibilityFlagsForm{
+			Federated: nil,
+			Boostable: nil,
+			Replyable: nil,
+			Likeable:  nil,
+		},
+	}
+
+	apiStatus, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(err)
+	suite.NotNil(apiStatus)
+
+	suite.Equal("<p>look at this!</p><p><img src=\""+attachment.URL+"\" alt=\"an image\" class=\"inline-image\"></p>", apiStatus.Content)
+
+	// The inline image should be attached to the status.
+	suite.Len(apiStatus.MediaAttachments, 1)
+	suite.Equal(attachment.ID, apiStatus.MediaAttachments[0].ID)
+}
+
 func (suite *StatusCreateTestSuite) TestProcessMediaDescriptionTooShort() {
 	ctx := context.Background()
 
@@ -286,6 +326,306 @@ func (suite *StatusCreateTestSuite) TestProcessReplyToUnthreadedRemoteStatus() {
 	suite.NotEmpty(dbStatus.ThreadID)
 }
 
+func (suite *StatusCreateTestSuite) TestProcessIdempotencyKey() {
+	ctx := gtscontext.SetIdempotencyKey(context.Background(), "some-retried-request")
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "posting from a train with terrible wifi",
+			Visibility:  apimodel.VisibilityPublic,
+			Language:    "en",
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+	}
+
+	// Create the status.
+	apiStatus1, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(err)
+	suite.NotNil(apiStatus1)
+
+	// Retry the request with the same
+	// key; we should get the same status.
+	apiStatus2, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(err)
+	suite.NotNil(apiStatus2)
+	suite.Equal(apiStatus1.ID, apiStatus2.ID)
+
+	// Same request with a different
+	// key should create a new status.
+	ctx = gtscontext.SetIdempotencyKey(context.Background(), "some-other-request")
+	apiStatus3, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(err)
+	suite.NotNil(apiStatus3)
+	suite.NotEqual(apiStatus1.ID, apiStatus3.ID)
+}
+
+func (suite *StatusCreateTestSuite) TestProcessStatusRateLimit() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	// Limit the account to 2 statuses per hour.
+	settings, err := suite.state.DB.GetAccountSettings(ctx, creatingAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	settings.StatusRateLimit = 2
+	if err := suite.state.DB.UpdateAccountSettings(ctx, settings, "status_rate_limit"); err != nil {
+		suite.FailNow(err.Error())
+	}
+	creatingAccount.Settings = settings
+
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "beep boop",
+			Visibility:  apimodel.VisibilityPublic,
+			Language:    "en",
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+	}
+
+	// First two should be fine.
+	for i := 0; i < 2; i++ {
+		apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+		suite.NoError(errWithCode)
+		suite.NotNil(apiStatus)
+	}
+
+	// Third should be refused.
+	apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.Nil(apiStatus)
+	suite.EqualError(errWithCode, "this account may create at most 2 statuses per hour; try again later")
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func (suite *StatusCreateTestSuite) TestProcessQuote() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+	quoteOf := suite.testStatuses["local_account_2_status_1"]
+
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "turtles are the best",
+			QuoteID:     quoteOf.ID,
+			Visibility:  apimodel.VisibilityPublic,
+			Language:    "en",
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+	}
+
+	apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(errWithCode)
+	suite.NotNil(apiStatus)
+
+	if apiStatus.Quote == nil {
+		suite.FailNow("expected quote to be set")
+	}
+	suite.Equal(quoteOf.ID, apiStatus.Quote.ID)
+
+	dbStatus, err := suite.state.DB.GetStatusByID(ctx, apiStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(quoteOf.ID, dbStatus.QuoteOfID)
+	suite.Equal(quoteOf.URI, dbStatus.QuoteOfURI)
+}
+
+func (suite *StatusCreateTestSuite) TestProcessQuoteNotBoostable() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+	quoteOf := suite.testStatuses["local_account_2_status_4"]
+
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "turtles are the best",
+			QuoteID:     quoteOf.ID,
+			Visibility:  apimodel.VisibilityPublic,
+			Language:    "en",
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+	}
+
+	apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.Nil(apiStatus)
+	suite.EqualError(errWithCode, "quoted status must be a boostable public or unlisted status")
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+}
+
+func (suite *StatusCreateTestSuite) TestProcessDirectReplyAddressesAllParticipants() {
+	ctx := context.Background()
+
+	zork := suite.testAccounts["local_account_1"]
+	turtle := suite.testAccounts["local_account_2"]
+	admin := suite.testAccounts["admin_account"]
+	application := suite.testApplications["application_1"]
+
+	// Zork starts a group direct
+	// message with turtle and admin.
+	parent, errWithCode := suite.status.Create(ctx, zork, application, &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "@1happyturtle @admin let's plan the party",
+			Visibility:  apimodel.VisibilityDirect,
+			Language:    "en",
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+	})
+	suite.NoError(errWithCode)
+	suite.Len(parent.Mentions, 2)
+
+	// Admin replies, mentioning nobody.
+	reply, errWithCode := suite.status.Create(ctx, admin, application, &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "sounds good",
+			InReplyToID: parent.ID,
+			Visibility:  apimodel.VisibilityDirect,
+			Language:    "en",
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+	})
+	suite.NoError(errWithCode)
+
+	// The reply should still be addressed
+	// to everyone else in the conversation.
+	dbReply, err := suite.state.DB.GetStatusByID(ctx, reply.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	mentioned := make([]string, 0, len(dbReply.Mentions))
+	for _, mention := range dbReply.Mentions {
+		mentioned = append(mentioned, mention.TargetAccountID)
+	}
+	suite.ElementsMatch([]string{zork.ID, turtle.ID}, mentioned)
+}
+
+// directStatus creates a direct status by account
+// with the given text, in reply to inReplyToID if set,
+// returning the IDs of the accounts it mentions.
+func (suite *StatusCreateTestSuite) directStatus(
+	account *gtsmodel.Account,
+	text string,
+	inReplyToID string,
+) (*apimodel.Status, []string) {
+	ctx := context.Background()
+
+	apiStatus, errWithCode := suite.status.Create(ctx, account, suite.testApplications["application_1"], &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      text,
+			InReplyToID: inReplyToID,
+			Visibility:  apimodel.VisibilityDirect,
+			Language:    "en",
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	return apiStatus, suite.mentionedIDs(apiStatus.ID)
+}
+
+// mentionedIDs returns the IDs of the accounts
+// mentioned by the status with the given ID.
+func (suite *StatusCreateTestSuite) mentionedIDs(statusID string) []string {
+	dbStatus, err := suite.state.DB.GetStatusByID(context.Background(), statusID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	mentioned := make([]string, 0, len(dbStatus.Mentions))
+	for _, mention := range dbStatus.Mentions {
+		mentioned = append(mentioned, mention.TargetAccountID)
+	}
+
+	// Every mention ID should
+	// resolve to one mention.
+	suite.Len(dbStatus.Mentions, len(dbStatus.MentionIDs))
+	return mentioned
+}
+
+func (suite *StatusCreateTestSuite) TestProcessDirectReplyExplicitAudience() {
+	zork := suite.testAccounts["local_account_1"]
+	turtle := suite.testAccounts["local_account_2"]
+	admin := suite.testAccounts["admin_account"]
+
+	parent, _ := suite.directStatus(zork, "@1happyturtle @admin let's plan the party", "")
+
+	// Admin replies only to zork, leaving turtle
+	// out on purpose; turtle shouldn't be added.
+	_, mentioned := suite.directStatus(admin, "@the_mighty_zork don't tell turtle", parent.ID)
+	suite.ElementsMatch([]string{zork.ID}, mentioned)
+	suite.NotContains(mentioned, turtle.ID)
+}
+
+func (suite *StatusCreateTestSuite) TestProcessDirectReplySkipsBlocks() {
+	ctx := context.Background()
+
+	zork := suite.testAccounts["local_account_1"]
+	turtle := suite.testAccounts["local_account_2"]
+	admin := suite.testAccounts["admin_account"]
+
+	parent, _ := suite.directStatus(zork, "@1happyturtle @admin let's plan the party", "")
+
+	// Turtle blocks admin after the
+	// conversation has been started.
+	if err := suite.state.DB.PutBlock(ctx, &gtsmodel.Block{
+		ID:              id.NewULID(),
+		URI:             "http://localhost:8080/users/1happyturtle/blocks/" + id.NewULID(),
+		AccountID:       turtle.ID,
+		TargetAccountID: admin.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Admin's reply shouldn't
+	// be addressed to turtle.
+	_, mentioned := suite.directStatus(admin, "sounds good", parent.ID)
+	suite.ElementsMatch([]string{zork.ID}, mentioned)
+}
+
+func (suite *StatusCreateTestSuite) TestProcessDirectReplyEdit() {
+	ctx := context.Background()
+
+	zork := suite.testAccounts["local_account_1"]
+	turtle := suite.testAccounts["local_account_2"]
+	admin := suite.testAccounts["admin_account"]
+
+	parent, _ := suite.directStatus(zork, "@1happyturtle @admin let's plan the party", "")
+	reply, mentioned := suite.directStatus(admin, "sounds good", parent.ID)
+	suite.ElementsMatch([]string{zork.ID, turtle.ID}, mentioned)
+
+	dbReply, err := suite.state.DB.GetStatusByID(ctx, reply.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Edit the reply, still mentioning nobody.
+	if _, errWithCode := suite.status.Edit(ctx, admin, reply.ID, &apimodel.StatusEditRequest{
+		Status:      "sounds great",
+		Language:    "en",
+		ContentType: apimodel.StatusContentTypePlain,
+	}); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// The same mentions should be kept,
+	// not duplicated or replaced.
+	dbEdited, err := suite.state.DB.GetStatusByID(ctx, reply.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.ElementsMatch(dbReply.MentionIDs, dbEdited.MentionIDs)
+	suite.ElementsMatch([]string{zork.ID, turtle.ID}, suite.mentionedIDs(reply.ID))
+}
+
 func TestStatusCreateTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusCreateTestSuite))
 }
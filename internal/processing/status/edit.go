@@ -0,0 +1,215 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+// Edit processes the given form to edit the target status, returning the api model
+// representation of the edited status if it's OK. The previous revision of the status
+// is stored, to make up the edit history of the status.
+//
+// Only the text, content warning, sensitivity, language, and media of a status can
+// be edited; its visibility, poll, and what it replies to all stay as they were.
+//
+// Precondition: the form's fields should have already been validated and normalized by the caller.
+func (p *Processor) Edit(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	targetStatusID string,
+	form *apimodel.StatusEditRequest,
+) (*apimodel.Status, gtserror.WithCode) {
+	// Ensure account populated; we'll need settings.
+	if err := p.state.DB.PopulateAccount(ctx, requester); err != nil {
+		log.Errorf(ctx, "error(s) populating account, will continue: %s", err)
+	}
+
+	status, errWithCode := p.c.GetVisibleTargetStatus(ctx,
+		requester,
+		targetStatusID,
+		nil, // default freshness
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if status.AccountID != requester.ID {
+		err := gtserror.Newf(
+			"status %s does not belong to account %s",
+			targetStatusID, requester.ID,
+		)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if status.BoostOfID != "" {
+		const text = "boosts cannot be edited"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	// Ensure status populated; we'll need its
+	// mentions to compare them with edited ones.
+	if err := p.state.DB.PopulateStatus(ctx, status); err != nil {
+		log.Errorf(ctx, "error(s) populating status, will continue: %s", err)
+	}
+
+	// Get current time.
+	now := time.Now()
+
+	// Keep the current revision
+	// of the status as an edit.
+	edit := &gtsmodel.StatusEdit{
+		ID:             id.NewULID(),
+		CreatedAt:      now,
+		StatusID:       status.ID,
+		Content:        status.Content,
+		ContentWarning: status.ContentWarning,
+		Text:           status.Text,
+		Language:       status.Language,
+		Sensitive:      status.Sensitive,
+		AttachmentIDs:  status.AttachmentIDs,
+		EmojiIDs:       status.EmojiIDs,
+	}
+
+	if status.Poll != nil {
+		edit.PollOptions = status.Poll.Options
+	}
+
+	// Edit a copy of the status, so that
+	// the cached model isn't changed if
+	// anything goes wrong along the way.
+	edited := new(gtsmodel.Status)
+	*edited = *status
+	edited.Account = requester
+	edited.EditedAt = now
+	edited.Text = form.Status
+	edited.Sensitive = &form.Sensitive
+	edited.Attachments, edited.AttachmentIDs = nil, nil
+	edited.Mentions, edited.MentionIDs = nil, nil
+	edited.Emojis, edited.EmojiIDs = nil, nil
+	edited.Tags, edited.TagIDs = nil, nil
+
+	// Polls can't be edited, so keep
+	// the poll out of content processing.
+	edited.Poll = nil
+
+	// Reuse status creation processing
+	// for the editable parts of the form.
+	createForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      form.Status,
+			MediaIDs:    form.MediaIDs,
+			Sensitive:   form.Sensitive,
+			SpoilerText: form.SpoilerText,
+			Language:    form.Language,
+			ContentType: form.ContentType,
+		},
+	}
+
+	if errWithCode := p.processMediaIDs(ctx, createForm, requester.ID, edited); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := processLanguage(createForm, requester.Settings.Language, edited); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.processContent(ctx, p.parseMention, createForm, edited); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.processDirectRecipients(ctx, edited, status.Mentions); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	edited.Poll = status.Poll
+
+	// Inline images count as attachments too,
+	// so check the limit now we've got them all.
+	maxMediaFiles := config.GetStatusesMediaMaxFiles()
+	if len(edited.AttachmentIDs) > maxMediaFiles {
+		text := fmt.Sprintf("too many media files attached to status, %d attached but limit is %d", len(edited.AttachmentIDs), maxMediaFiles)
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	// Content processing created new mentions; where
+	// the status already mentioned the same account,
+	// keep using the existing mention instead.
+	p.reuseMentions(ctx, status, edited)
+
+	// Store the previous revision, then the edited status.
+	if err := p.state.DB.PutStatusEdit(ctx, edit); err != nil {
+		err := gtserror.Newf("error inserting status edit in db: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.UpdateStatus(ctx, edited); err != nil {
+		err := gtserror.Newf("error updating status in db: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Send it back to the client API worker for async
+	// side-effects, ie., federating the Update.
+	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityUpdate,
+		GTSModel:       edited,
+		Origin:         requester,
+	})
+
+	return p.c.GetAPIStatus(ctx, requester, edited)
+}
+
+// reuseMentions replaces mentions of the edited status with
+// mentions of the same accounts from the existing status, if
+// any, deleting the new (duplicate) mentions from the database.
+func (p *Processor) reuseMentions(ctx context.Context, existing *gtsmodel.Status, edited *gtsmodel.Status) {
+	for i, mention := range edited.Mentions {
+		for _, existingMention := range existing.Mentions {
+			if existingMention.TargetAccountID != mention.TargetAccountID {
+				continue
+			}
+
+			if existingMention.ID == mention.ID {
+				// Already reused.
+				break
+			}
+
+			if err := p.state.DB.DeleteMentionByID(ctx, mention.ID); err != nil {
+				log.Errorf(ctx, "error deleting duplicate mention: %v", err)
+			}
+
+			edited.Mentions[i] = existingMention
+			break
+		}
+	}
+
+	edited.MentionIDs = gatherIDs(edited.Mentions, func(mention *gtsmodel.Mention) string { return mention.ID })
+}
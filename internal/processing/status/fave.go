@@ -62,7 +62,13 @@ func (p *Processor) getFaveableStatus(
 		return nil, nil, errWithCode
 	}
 
-	if !*target.Likeable {
+	likeable, err := p.filter.StatusInteractable(ctx, requester, target, gtsmodel.InteractionLike)
+	if err != nil {
+		err = gtserror.Newf("error checking status likeability: %w", err)
+		return nil, nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if !likeable {
 		err := errors.New("status is not faveable")
 		return nil, nil, gtserror.NewErrorForbidden(err, err.Error())
 	}
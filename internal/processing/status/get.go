@@ -19,18 +19,19 @@ package status
 
 import (
 	"context"
+	"errors"
 	"slices"
 	"strings"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
-	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // HistoryGet gets edit history for the target status, taking account of privacy settings and blocks etc.
-// TODO: currently this just returns the latest version of the status.
+// The history is made up of all previous revisions of the status, oldest first, followed by the latest version.
 func (p *Processor) HistoryGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) ([]*apimodel.StatusEdit, gtserror.WithCode) {
 	targetStatus, errWithCode := p.c.GetVisibleTargetStatus(ctx,
 		requestingAccount,
@@ -46,18 +47,19 @@ func (p *Processor) HistoryGet(ctx context.Context, requestingAccount *gtsmodel.
 		return nil, errWithCode
 	}
 
-	return []*apimodel.StatusEdit{
-		{
-			Content:          apiStatus.Content,
-			SpoilerText:      apiStatus.SpoilerText,
-			Sensitive:        apiStatus.Sensitive,
-			CreatedAt:        util.FormatISO8601(targetStatus.UpdatedAt),
-			Account:          apiStatus.Account,
-			Poll:             apiStatus.Poll,
-			MediaAttachments: apiStatus.MediaAttachments,
-			Emojis:           apiStatus.Emojis,
-		},
-	}, nil
+	edits, err := p.state.DB.GetStatusEditsByStatusID(ctx, targetStatus.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting status edits: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	history, err := p.converter.StatusEditsToAPIEdits(ctx, targetStatus, edits, apiStatus)
+	if err != nil {
+		err := gtserror.Newf("error converting status edits: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return history, nil
 }
 
 // Get gets the given status, taking account of privacy settings and blocks etc.
@@ -74,6 +76,26 @@ func (p *Processor) Get(ctx context.Context, requestingAccount *gtsmodel.Account
 	return p.c.GetAPIStatus(ctx, requestingAccount, targetStatus)
 }
 
+// GTSGet gets GoToSocial-specific information about the
+// given status, taking account of privacy settings and blocks etc.
+func (p *Processor) GTSGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) (*apimodel.GTSStatus, gtserror.WithCode) {
+	targetStatus, errWithCode := p.c.GetVisibleTargetStatus(ctx,
+		requestingAccount,
+		targetStatusID,
+		nil, // default freshness
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	gtsStatus, err := p.converter.StatusToGTSStatus(ctx, targetStatus)
+	if err != nil {
+		err = gtserror.Newf("error converting status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	return gtsStatus, nil
+}
+
 // SourceGet returns the *apimodel.StatusSource version of the targetStatusID.
 // Status must belong to the requester, and must not be a boost.
 func (p *Processor) SourceGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) (*apimodel.StatusSource, gtserror.WithCode) {
@@ -131,10 +153,41 @@ func (p *Processor) WebGet(ctx context.Context, targetStatusID string) (*apimode
 	return webStatus, nil
 }
 
+// WebTextGet gets a plaintext rendition of the given status (or gemtext,
+// if gemtext is true) for text browsers and gemini proxies, taking
+// account of privacy settings.
+func (p *Processor) WebTextGet(ctx context.Context, targetStatusID string, gemtext bool) (string, gtserror.WithCode) {
+	targetStatus, errWithCode := p.c.GetVisibleTargetStatus(ctx,
+		nil, // requester
+		targetStatusID,
+		nil, // default freshness
+	)
+	if errWithCode != nil {
+		return "", errWithCode
+	}
+
+	convert := p.converter.StatusToPlaintext
+	if gemtext {
+		convert = p.converter.StatusToGemtext
+	}
+
+	text, err := convert(ctx, targetStatus)
+	if err != nil {
+		err = gtserror.Newf("error converting status: %w", err)
+		return "", gtserror.NewErrorInternalError(err)
+	}
+	return text, nil
+}
+
+// contextGet fetches the visible ancestors and descendants of the
+// target status. If limit is greater than zero, then at most limit
+// descendants are returned, starting from offset in thread order.
 func (p *Processor) contextGet(
 	ctx context.Context,
 	requestingAccount *gtsmodel.Account,
 	targetStatusID string,
+	limit int,
+	offset int,
 	convert func(context.Context, *gtsmodel.Status, *gtsmodel.Account) (*apimodel.Status, error),
 ) (*apimodel.Context, gtserror.WithCode) {
 	targetStatus, errWithCode := p.c.GetVisibleTargetStatus(ctx,
@@ -182,6 +235,15 @@ func (p *Processor) contextGet(
 
 	TopoSort(descendants, targetStatus.AccountID)
 
+	// Page through descendants *after* sorting and
+	// visibility / filter checks, so that offsets line
+	// up with what the requester actually gets to see.
+	if limit > 0 {
+		offset = min(max(offset, 0), len(descendants))
+		end := min(offset+limit, len(descendants))
+		descendants = descendants[offset:end]
+	}
+
 	context := &apimodel.Context{
 		Ancestors:   make([]apimodel.Status, 0, len(ancestors)),
 		Descendants: make([]apimodel.Status, 0, len(descendants)),
@@ -280,7 +342,17 @@ func TopoSort(apiStatuses []*apimodel.Status, targetAccountID string) {
 }
 
 // ContextGet returns the context (previous and following posts) from the given status ID.
-func (p *Processor) ContextGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) (*apimodel.Context, gtserror.WithCode) {
+//
+// If limit is greater than zero, descendants are paged through using
+// limit and offset; otherwise all visible descendants are returned.
+// Ancestors are always returned in full.
+func (p *Processor) ContextGet(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	targetStatusID string,
+	limit int,
+	offset int,
+) (*apimodel.Context, gtserror.WithCode) {
 	filters, err := p.state.DB.GetFiltersForAccountID(ctx, requestingAccount.ID)
 	if err != nil {
 		err = gtserror.Newf("couldn't retrieve filters for account %s: %w", requestingAccount.ID, err)
@@ -289,7 +361,7 @@ func (p *Processor) ContextGet(ctx context.Context, requestingAccount *gtsmodel.
 	convert := func(ctx context.Context, status *gtsmodel.Status, requestingAccount *gtsmodel.Account) (*apimodel.Status, error) {
 		return p.converter.StatusToAPIStatus(ctx, status, requestingAccount, statusfilter.FilterContextThread, filters)
 	}
-	return p.contextGet(ctx, requestingAccount, targetStatusID, convert)
+	return p.contextGet(ctx, requestingAccount, targetStatusID, limit, offset, convert)
 }
 
 // WebContextGet is like ContextGet, but is explicitly
@@ -297,5 +369,5 @@ func (p *Processor) ContextGet(ctx context.Context, requestingAccount *gtsmodel.
 //
 // TODO: a more advanced threading model could be implemented here.
 func (p *Processor) WebContextGet(ctx context.Context, targetStatusID string) (*apimodel.Context, gtserror.WithCode) {
-	return p.contextGet(ctx, nil, targetStatusID, p.converter.StatusToWebStatus)
+	return p.contextGet(ctx, nil, targetStatusID, 0, 0, p.converter.StatusToWebStatus)
 }
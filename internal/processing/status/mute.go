@@ -35,7 +35,10 @@ import (
 //   - Status exists and is visible to requester.
 //   - Status belongs to or mentions requesting account.
 //   - Status is not a boost.
-//   - Status has a thread ID.
+//
+// If the status isn't threaded yet (eg., it was created
+// before threads existed), a new thread is created from
+// it so that it (and any future replies) can be muted.
 func (p *Processor) getMuteableStatus(
 	ctx context.Context,
 	requestingAccount *gtsmodel.Account,
@@ -62,13 +65,36 @@ func (p *Processor) getMuteableStatus(
 	}
 
 	if targetStatus.ThreadID == "" {
-		err := gtserror.New("cannot mute or unmute status with no threadID")
-		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		if errWithCode := p.threadStatus(ctx, targetStatus); errWithCode != nil {
+			return nil, errWithCode
+		}
 	}
 
 	return targetStatus, nil
 }
 
+// threadStatus creates a new thread starting from
+// the given unthreaded status, and stores its ID on
+// the status. Replies created or dereferenced after
+// this will inherit the thread ID from the status.
+func (p *Processor) threadStatus(ctx context.Context, status *gtsmodel.Status) gtserror.WithCode {
+	threadID := id.NewULID()
+	if err := p.state.DB.PutThread(ctx, &gtsmodel.Thread{
+		ID: threadID,
+	}); err != nil {
+		err := gtserror.Newf("db error inserting new thread for status %s: %w", status.ID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	status.ThreadID = threadID
+	if err := p.state.DB.UpdateStatus(ctx, status, "thread_id"); err != nil {
+		err := gtserror.Newf("db error updating thread of status %s: %w", status.ID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
 func (p *Processor) MuteCreate(
 	ctx context.Context,
 	requestingAccount *gtsmodel.Account,
@@ -0,0 +1,208 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+const (
+	// oEmbedDefaultWidth is the iframe width used when
+	// the consumer doesn't request a smaller maxwidth.
+	oEmbedDefaultWidth = 400
+
+	// oEmbedCacheAge is the suggested
+	// oEmbed cache lifetime (1 day).
+	oEmbedCacheAge = 86400
+
+	// oEmbedSandbox restricts what the embedded status page may do
+	// in the consumer's website: scripts may run (for spoilers and
+	// media), and links may open in a new unsandboxed tab, but it
+	// never runs with the origin of this instance.
+	oEmbedSandbox = "allow-scripts allow-popups allow-popups-to-escape-sandbox"
+)
+
+// OEmbedGet returns an oEmbed representation of the local status at the given
+// web or ActivityPub URL, if the status is public and its author allows embeds.
+func (p *Processor) OEmbedGet(
+	ctx context.Context,
+	statusURL string,
+	maxWidth int,
+	maxHeight int,
+) (*apimodel.OEmbed, gtserror.WithCode) {
+	username, statusID, errWithCode := parseStatusURL(statusURL)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	targetStatus, errWithCode := p.getEmbeddableStatus(ctx, username, statusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		err := gtserror.Newf("db error getting instance: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	width := oEmbedDefaultWidth
+	if maxWidth > 0 && maxWidth < width {
+		width = maxWidth
+	}
+
+	var height *int
+	if maxHeight > 0 {
+		height = &maxHeight
+	}
+
+	author := targetStatus.Account
+	authorName := author.DisplayName
+	if authorName == "" {
+		authorName = author.Username
+	}
+
+	embedHTML := fmt.Sprintf(
+		`<iframe src="%s" class="gotosocial-embed" style="max-width: 100%%; border: 0" width="%d"`,
+		html.EscapeString(targetStatus.URL+"/embed"), width,
+	)
+	if height != nil {
+		embedHTML += fmt.Sprintf(` height="%d"`, *height)
+	}
+	embedHTML += ` sandbox="` + oEmbedSandbox + `"></iframe>`
+
+	return &apimodel.OEmbed{
+		Type:         "rich",
+		Version:      "1.0",
+		Title:        "Post by @" + author.Username,
+		AuthorName:   authorName,
+		AuthorURL:    author.URL,
+		ProviderName: instance.Title,
+		ProviderURL:  config.GetProtocol() + "://" + config.GetHost(),
+		CacheAge:     oEmbedCacheAge,
+		HTML:         embedHTML,
+		Width:        width,
+		Height:       height,
+	}, nil
+}
+
+// WebEmbedGet returns the web view of the given status by the given local
+// account, for rendering in an iframe, if the status may be embedded.
+func (p *Processor) WebEmbedGet(
+	ctx context.Context,
+	username string,
+	statusID string,
+) (*apimodel.Status, gtserror.WithCode) {
+	targetStatus, errWithCode := p.getEmbeddableStatus(ctx, username, statusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	webStatus, err := p.converter.StatusToWebStatus(ctx, targetStatus, nil)
+	if err != nil {
+		err = gtserror.Newf("error converting status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return webStatus, nil
+}
+
+// parseStatusURL parses the username and status ID from
+// the given web URL or ActivityPub URI of a local status.
+func parseStatusURL(statusURL string) (string, string, gtserror.WithCode) {
+	u, err := url.Parse(statusURL)
+	if err != nil {
+		err := fmt.Errorf("invalid url %s: %w", statusURL, err)
+		return "", "", gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if u.Host != config.GetHost() {
+		err := fmt.Errorf("url %s does not point to this instance", statusURL)
+		return "", "", gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	var username, statusID string
+	switch {
+	case uris.IsStatusesWebPath(u):
+		username, statusID, err = uris.ParseStatusesWebPath(u)
+	case uris.IsStatusesPath(u):
+		username, statusID, err = uris.ParseStatusesPath(u)
+	default:
+		err = errors.New("unrecognized path")
+	}
+	if err != nil {
+		err := fmt.Errorf("url %s is not a status url: %w", statusURL, err)
+		return "", "", gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	// Normalize as the web status handler does.
+	return strings.ToLower(username), strings.ToUpper(statusID), nil
+}
+
+// getEmbeddableStatus returns the given status by the given local
+// account, or a 404 if the status isn't visible, isn't public, or
+// its author hasn't opted in to having their statuses embedded.
+func (p *Processor) getEmbeddableStatus(
+	ctx context.Context,
+	username string,
+	statusID string,
+) (*gtsmodel.Status, gtserror.WithCode) {
+	targetStatus, errWithCode := p.c.GetVisibleTargetStatus(ctx,
+		nil, // requester
+		statusID,
+		nil, // default freshness
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if !targetStatus.IsLocal() ||
+		targetStatus.Account.Username != username ||
+		targetStatus.BoostOfID != "" ||
+		targetStatus.Visibility != gtsmodel.VisibilityPublic {
+		err := fmt.Errorf("status %s cannot be embedded", statusID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if targetStatus.Account.Settings == nil {
+		var err error
+		targetStatus.Account.Settings, err = p.state.DB.GetAccountSettings(ctx, targetStatus.AccountID)
+		if err != nil {
+			err := gtserror.Newf("db error getting account settings: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	if !*targetStatus.Account.Settings.EnableEmbeds {
+		err := fmt.Errorf("account %s does not allow embeds", targetStatus.AccountID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return targetStatus, nil
+}
@@ -0,0 +1,101 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type StatusOEmbedTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusOEmbedTestSuite) enableEmbeds(accountID string) {
+	settings, err := suite.db.GetAccountSettings(context.Background(), accountID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	settings.EnableEmbeds = util.Ptr(true)
+	if err := suite.db.UpdateAccountSettings(context.Background(), settings, "enable_embeds"); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *StatusOEmbedTestSuite) TestOEmbedGet() {
+	ctx := context.Background()
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+	suite.enableEmbeds(targetStatus.AccountID)
+
+	// Both the web URL and the AP URI should work.
+	for _, statusURL := range []string{targetStatus.URL, targetStatus.URI} {
+		oEmbed, errWithCode := suite.status.OEmbedGet(ctx, statusURL, 0, 0)
+		if errWithCode != nil {
+			suite.FailNow(errWithCode.Error())
+		}
+
+		suite.Equal("rich", oEmbed.Type)
+		suite.Equal("1.0", oEmbed.Version)
+		suite.Equal("original zork (he/they)", oEmbed.AuthorName)
+		suite.Equal("http://localhost:8080/@the_mighty_zork", oEmbed.AuthorURL)
+		suite.Equal("http://localhost:8080", oEmbed.ProviderURL)
+		suite.Equal(400, oEmbed.Width)
+		suite.Nil(oEmbed.Height)
+		suite.Equal(`<iframe src="http://localhost:8080/@the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY/embed" class="gotosocial-embed" style="max-width: 100%; border: 0" width="400" sandbox="allow-scripts allow-popups allow-popups-to-escape-sandbox"></iframe>`, oEmbed.HTML)
+	}
+
+	// Smaller maxwidth and a maxheight should be respected.
+	oEmbed, errWithCode := suite.status.OEmbedGet(ctx, targetStatus.URL, 300, 200)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(300, oEmbed.Width)
+	suite.Equal(200, *oEmbed.Height)
+}
+
+func (suite *StatusOEmbedTestSuite) TestOEmbedGetNotEmbeddable() {
+	ctx := context.Background()
+	suite.enableEmbeds(suite.testAccounts["local_account_1"].ID)
+
+	for _, statusURL := range []string{
+		// Author hasn't enabled embeds.
+		suite.testStatuses["local_account_2_status_1"].URL,
+		// Not public.
+		suite.testStatuses["local_account_1_status_2"].URL,
+		// Username doesn't match status author.
+		"http://localhost:8080/@1happyturtle/statuses/01F8MHAMCHF6Y650WCRSCP4WMY",
+		// Not a status on this instance.
+		"https://example.org/@the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY",
+		// Not a status URL.
+		"http://localhost:8080/@the_mighty_zork",
+	} {
+		_, errWithCode := suite.status.OEmbedGet(ctx, statusURL, 0, 0)
+		if suite.NotNil(errWithCode, statusURL) {
+			suite.Equal(http.StatusNotFound, errWithCode.Code(), statusURL)
+		}
+	}
+}
+
+func TestStatusOEmbedTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusOEmbedTestSuite))
+}
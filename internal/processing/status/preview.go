@@ -0,0 +1,82 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+)
+
+// WebMediaPreviewGet returns a blurred JPEG preview of the given
+// attachment of the given status, decoded from its blurhash, taking
+// account of privacy settings. These previews are used in link
+// previews of sensitive statuses, so as not to reveal their media.
+func (p *Processor) WebMediaPreviewGet(
+	ctx context.Context,
+	targetUsername string,
+	targetStatusID string,
+	targetAttachmentID string,
+) (io.Reader, gtserror.WithCode) {
+	targetStatus, errWithCode := p.c.GetVisibleTargetStatus(ctx,
+		nil, // requester
+		targetStatusID,
+		nil, // default freshness
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Web views are only served for local
+	// accounts' statuses under their own name.
+	if !targetStatus.IsLocal() || targetStatus.Account.Username != targetUsername {
+		const text = "target status not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	for _, attachment := range targetStatus.Attachments {
+		if attachment.ID != targetAttachmentID {
+			continue
+		}
+
+		if attachment.Blurhash == "" {
+			const text = "attachment has no preview"
+			return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+		}
+
+		// Match the dimensions of the thumbnail,
+		// so the preview can stand in for it.
+		preview, err := media.BlurhashPreview(
+			attachment.Blurhash,
+			attachment.FileMeta.Small.Width,
+			attachment.FileMeta.Small.Height,
+		)
+		if err != nil {
+			err := gtserror.Newf("error generating preview for attachment %s: %w", attachment.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		return preview, nil
+	}
+
+	const text = "target attachment not found"
+	return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+}
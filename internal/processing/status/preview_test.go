@@ -0,0 +1,85 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status_test
+
+import (
+	"context"
+	"image/jpeg"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type StatusMediaPreviewTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusMediaPreviewTestSuite) TestWebMediaPreviewGet() {
+	ctx := context.Background()
+	targetStatus := suite.testStatuses["admin_account_status_1"]
+	targetAttachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+
+	preview, errWithCode := suite.status.WebMediaPreviewGet(ctx,
+		"admin",
+		targetStatus.ID,
+		targetAttachment.ID,
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Preview should be a valid JPEG
+	// the same size as the thumbnail.
+	img, err := jpeg.Decode(preview)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(256, img.Bounds().Dx())
+	suite.Equal(134, img.Bounds().Dy())
+}
+
+func (suite *StatusMediaPreviewTestSuite) TestWebMediaPreviewGetWrongAccount() {
+	ctx := context.Background()
+	targetStatus := suite.testStatuses["admin_account_status_1"]
+	targetAttachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+
+	_, errWithCode := suite.status.WebMediaPreviewGet(ctx,
+		"the_mighty_zork",
+		targetStatus.ID,
+		targetAttachment.ID,
+	)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *StatusMediaPreviewTestSuite) TestWebMediaPreviewGetWrongAttachment() {
+	ctx := context.Background()
+	targetStatus := suite.testStatuses["admin_account_status_1"]
+	targetAttachment := suite.testAttachments["local_account_1_status_4_attachment_1"]
+
+	_, errWithCode := suite.status.WebMediaPreviewGet(ctx,
+		"admin",
+		targetStatus.ID,
+		targetAttachment.ID,
+	)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestStatusMediaPreviewTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusMediaPreviewTestSuite))
+}
@@ -0,0 +1,281 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/regexes"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+// getReactableStatus returns the target status, unwrapped
+// if it's a boost, ensuring that it can be reacted to by
+// the requester. Reactions follow the status' like policy.
+func (p *Processor) getReactableStatus(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	targetID string,
+) (*gtsmodel.Status, gtserror.WithCode) {
+	target, errWithCode := p.c.GetVisibleTargetStatus(
+		ctx,
+		requester,
+		targetID,
+		nil, // default freshness
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	target, errWithCode = p.c.UnwrapIfBoost(
+		ctx,
+		requester,
+		target,
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	likeable, err := p.filter.StatusInteractable(ctx, requester, target, gtsmodel.InteractionLike)
+	if err != nil {
+		err = gtserror.Newf("error checking status likeability: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if !likeable {
+		err := errors.New("status is not reactable")
+		return nil, gtserror.NewErrorForbidden(err, err.Error())
+	}
+
+	return target, nil
+}
+
+// parseReactionEmoji parses the given emoji from a reaction request
+// into a reaction name, and the local custom emoji (if any). Custom
+// emojis may be given with or without surrounding colons.
+func (p *Processor) parseReactionEmoji(
+	ctx context.Context,
+	emoji string,
+) (string, *gtsmodel.Emoji, gtserror.WithCode) {
+	emoji = strings.TrimSpace(emoji)
+
+	if ap.IsUnicodeEmojiReaction(emoji) {
+		return emoji, nil, nil
+	}
+
+	shortcode := strings.Trim(emoji, ":")
+	if !regexes.EmojiValidator.MatchString(shortcode) {
+		const text = "emoji must be a single unicode emoji or a custom emoji shortcode"
+		return "", nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	customEmoji, err := p.state.DB.GetEmojiByShortcodeDomain(ctx, shortcode, "")
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting emoji %s: %w", shortcode, err)
+		return "", nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if customEmoji == nil || *customEmoji.Disabled {
+		text := "custom emoji " + shortcode + " not found"
+		return "", nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	return shortcode, customEmoji, nil
+}
+
+// ReactionsGet returns the emoji reactions to the given status, summarized
+// per emoji, along with the accounts that reacted with each emoji. Accounts
+// blocking or blocked by the requester are left out (but still counted).
+func (p *Processor) ReactionsGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	targetID string,
+) ([]apimodel.EmojiReaction, gtserror.WithCode) {
+	target, errWithCode := p.c.GetVisibleTargetStatus(ctx,
+		requester,
+		targetID,
+		nil, // default freshness
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	reactions, err := p.state.DB.GetStatusReactions(ctx, target.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting reactions: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Summarize before filtering so
+	// that counts are left untouched.
+	apiReactions, err := p.converter.StatusReactionsToAPIReactions(ctx,
+		reactions,
+		requester,
+		true, // with accounts
+	)
+	if err != nil {
+		err := gtserror.Newf("error converting reactions: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if requester == nil {
+		// No blocks to check.
+		return apiReactions, nil
+	}
+
+	for i := range apiReactions {
+		var errs gtserror.MultiError
+
+		apiReactions[i].Accounts = slices.DeleteFunc(
+			apiReactions[i].Accounts,
+			func(a *apimodel.Account) bool {
+				blocked, err := p.state.DB.IsEitherBlocked(ctx, requester.ID, a.ID)
+				if err != nil {
+					errs.Appendf("error checking blocks: %w", err)
+				}
+				return blocked
+			},
+		)
+
+		if err := errs.Combine(); err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return apiReactions, nil
+}
+
+// ReactionCreate adds an emoji reaction for the requester, targeting
+// the given status (no-op if the same reaction already exists).
+func (p *Processor) ReactionCreate(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	targetID string,
+	emoji string,
+) (*apimodel.Status, gtserror.WithCode) {
+	target, errWithCode := p.getReactableStatus(ctx, requester, targetID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	name, customEmoji, errWithCode := p.parseReactionEmoji(ctx, emoji)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	existing, err := p.state.DB.GetStatusReaction(ctx, requester.ID, target.ID, name)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error checking existing reaction: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if existing != nil {
+		// Already reacted with this emoji.
+		return p.c.GetAPIStatus(ctx, requester, target)
+	}
+
+	// Create and store a new reaction.
+	reactionID := id.NewULID()
+	reaction := &gtsmodel.StatusReaction{
+		ID:              reactionID,
+		AccountID:       requester.ID,
+		Account:         requester,
+		TargetAccountID: target.AccountID,
+		TargetAccount:   target.Account,
+		StatusID:        target.ID,
+		Status:          target,
+		Name:            name,
+		URI:             uris.GenerateURIForLike(requester.Username, reactionID),
+	}
+
+	if customEmoji != nil {
+		reaction.EmojiID = customEmoji.ID
+		reaction.Emoji = customEmoji
+	}
+
+	if err := p.state.DB.PutStatusReaction(ctx, reaction); err != nil {
+		err := gtserror.Newf("db error putting reaction: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Process new reaction side effects.
+	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   ap.ActivityEmojiReact,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       reaction,
+		Origin:         requester,
+		Target:         target.Account,
+	})
+
+	return p.c.GetAPIStatus(ctx, requester, target)
+}
+
+// ReactionRemove removes an emoji reaction of the requester, targeting
+// the given status (no-op if the reaction doesn't exist).
+func (p *Processor) ReactionRemove(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	targetID string,
+	emoji string,
+) (*apimodel.Status, gtserror.WithCode) {
+	target, errWithCode := p.getReactableStatus(ctx, requester, targetID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Don't require a usable custom emoji
+	// here, it may since have been disabled.
+	name := strings.Trim(strings.TrimSpace(emoji), ":")
+
+	existing, err := p.state.DB.GetStatusReaction(ctx, requester.ID, target.ID, name)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error checking existing reaction: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if existing == nil {
+		// Not reacted with this emoji.
+		return p.c.GetAPIStatus(ctx, requester, target)
+	}
+
+	if err := p.state.DB.DeleteStatusReactionByID(ctx, existing.ID); err != nil {
+		err := gtserror.Newf("db error deleting reaction: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Process removed reaction side effects.
+	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   ap.ActivityEmojiReact,
+		APActivityType: ap.ActivityUndo,
+		GTSModel:       existing,
+		Origin:         requester,
+		Target:         target.Account,
+	})
+
+	return p.c.GetAPIStatus(ctx, requester, target)
+}
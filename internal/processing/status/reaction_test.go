@@ -0,0 +1,127 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type StatusReactionTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusReactionTestSuite) TestReactionCreateRemove() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_1"]
+		target  = suite.testStatuses["admin_account_status_1"]
+	)
+
+	// React with a unicode emoji and a custom emoji.
+	apiStatus, errWithCode := suite.status.ReactionCreate(ctx, account, target.ID, "👍")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(apiStatus.EmojiReactions, 1)
+
+	apiStatus, errWithCode = suite.status.ReactionCreate(ctx, account, target.ID, ":rainbow:")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	if !suite.Len(apiStatus.EmojiReactions, 2) {
+		suite.FailNow("")
+	}
+
+	var rainbowURL string
+	for _, reaction := range apiStatus.EmojiReactions {
+		suite.Equal(1, reaction.Count)
+		suite.True(reaction.Me)
+		suite.Empty(reaction.Accounts)
+		if reaction.Name == "rainbow" {
+			rainbowURL = reaction.URL
+		}
+	}
+	suite.Equal(testrig.NewTestEmojis()["rainbow"].ImageURL, rainbowURL)
+
+	// Reacting again with the same emoji is a no-op.
+	apiStatus, errWithCode = suite.status.ReactionCreate(ctx, account, target.ID, "👍")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(apiStatus.EmojiReactions, 2)
+
+	// Another account reacting is counted,
+	// but doesn't count as the requester's.
+	otherAccount := suite.testAccounts["local_account_2"]
+	if _, errWithCode := suite.status.ReactionCreate(ctx, otherAccount, target.ID, "👍"); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	apiReactions, errWithCode := suite.status.ReactionsGet(ctx, account, target.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	for _, reaction := range apiReactions {
+		if reaction.Name == "👍" {
+			suite.Equal(2, reaction.Count)
+			suite.Len(reaction.Accounts, 2)
+		}
+	}
+
+	// Remove the custom emoji reaction.
+	apiStatus, errWithCode = suite.status.ReactionRemove(ctx, account, target.ID, "rainbow")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	if suite.Len(apiStatus.EmojiReactions, 1) {
+		suite.Equal("👍", apiStatus.EmojiReactions[0].Name)
+		suite.Equal(2, apiStatus.EmojiReactions[0].Count)
+		suite.True(apiStatus.EmojiReactions[0].Me)
+	}
+}
+
+func (suite *StatusReactionTestSuite) TestReactionCreateInvalid() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_1"]
+		target  = suite.testStatuses["admin_account_status_1"]
+	)
+
+	for emoji, code := range map[string]int{
+		"not an emoji":     http.StatusBadRequest,
+		"<b>hi</b>":        http.StatusBadRequest,
+		":does_not_exist:": http.StatusNotFound,
+	} {
+		_, errWithCode := suite.status.ReactionCreate(ctx, account, target.ID, emoji)
+		if suite.NotNil(errWithCode, emoji) {
+			suite.Equal(code, errWithCode.Code(), emoji)
+		}
+	}
+}
+
+func TestStatusReactionTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusReactionTestSuite))
+}
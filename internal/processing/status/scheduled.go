@@ -0,0 +1,396 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/common"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+const (
+	// scheduledStatusMinDelay is how far
+	// in the future statuses may be scheduled
+	// for at the earliest, as in Mastodon.
+	scheduledStatusMinDelay = 5 * time.Minute
+
+	// scheduledStatusesMax is the max number
+	// of scheduled statuses that one account
+	// may have pending at once, as in Mastodon.
+	scheduledStatusesMax = 300
+)
+
+// ScheduledCreate processes the given form to schedule a new status for publishing at
+// form.ScheduledAt, returning the api model representation of the scheduled status.
+//
+// If an idempotency key is set on the context, and a status was already scheduled by requester
+// with that key within the last hour, then the existing scheduled status will be returned instead.
+//
+// Precondition: the form's fields should have already been validated and normalized by the caller.
+func (p *Processor) ScheduledCreate(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	application *gtsmodel.Application,
+	form *apimodel.AdvancedStatusCreateForm,
+) (
+	*apimodel.ScheduledStatus,
+	gtserror.WithCode,
+) {
+	key := common.IdempotencyKey(ctx, "scheduled_status", requester.ID)
+	if key == "" {
+		// No idempotency
+		// key, just create.
+		return p.scheduledCreate(ctx, requester, application, form)
+	}
+
+	// Lock on key so that concurrent
+	// retries wait for the first one.
+	unlock := p.state.ProcessingLocks.Lock(key)
+	defer unlock()
+
+	if scheduledID, ok := p.state.Caches.Idempotency.Get(key); ok {
+		// Status already scheduled
+		// with this key, return it.
+		return p.ScheduledGet(ctx, requester, scheduledID)
+	}
+
+	apiScheduled, errWithCode := p.scheduledCreate(ctx, requester, application, form)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	p.state.Caches.Idempotency.Set(key, apiScheduled.ID)
+	return apiScheduled, nil
+}
+
+func (p *Processor) scheduledCreate(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	application *gtsmodel.Application,
+	form *apimodel.AdvancedStatusCreateForm,
+) (
+	*apimodel.ScheduledStatus,
+	gtserror.WithCode,
+) {
+	scheduledAt, errWithCode := parseScheduledAt(form.ScheduledAt)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Ensure account populated; we'll need settings.
+	if err := p.state.DB.PopulateAccount(ctx, requester); err != nil {
+		log.Errorf(ctx, "error(s) populating account, will continue: %s", err)
+	}
+
+	count, err := p.state.DB.CountAccountScheduledStatuses(ctx, requester.ID)
+	if err != nil {
+		err := gtserror.Newf("db error counting scheduled statuses: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if count >= scheduledStatusesMax {
+		err := fmt.Errorf("this account may have at most %d scheduled statuses", scheduledStatusesMax)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	scheduled := &gtsmodel.ScheduledStatus{
+		ID:             id.NewULID(),
+		ScheduledAt:    scheduledAt,
+		AccountID:      requester.ID,
+		Account:        requester,
+		ApplicationID:  application.ID,
+		Application:    application,
+		Text:           form.Status,
+		ContentType:    string(form.ContentType),
+		ContentWarning: form.SpoilerText,
+		Sensitive:      &form.Sensitive,
+		Federated:      form.Federated,
+		Boostable:      form.Boostable,
+		Replyable:      form.Replyable,
+		Likeable:       form.Likeable,
+		Language:       form.Language,
+
+		InteractionPolicy: typeutils.APIInteractionPolicyToInteractionPolicy(form.InteractionPolicy),
+	}
+
+	if form.Poll != nil {
+		scheduled.PollOptions = form.Poll.Options
+		scheduled.PollExpiresIn = form.Poll.ExpiresIn
+		scheduled.PollMultiple = &form.Poll.Multiple
+		scheduled.PollHideCounts = &form.Poll.HideTotals
+	}
+
+	// The status will only be created on publishing, but
+	// use a placeholder now to check the form the same way.
+	status := &gtsmodel.Status{}
+
+	// Check in-reply-to status is
+	// there + can be replied to.
+	if errWithCode := p.processInReplyTo(ctx,
+		requester,
+		status,
+		form.InReplyToID,
+	); errWithCode != nil {
+		return nil, errWithCode
+	}
+	scheduled.InReplyToID = status.InReplyToID
+
+	// Check media is there + can be attached.
+	if errWithCode := p.processMediaIDs(ctx, form, requester.ID, status); errWithCode != nil {
+		return nil, errWithCode
+	}
+	scheduled.AttachmentIDs = status.AttachmentIDs
+	scheduled.Attachments = status.Attachments
+
+	// Settle visibility now, so it can be shown
+	// to the client, and won't change if the account
+	// default visibility is changed in the meantime.
+	if err := processVisibility(form, requester.Settings.Privacy, status); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	scheduled.Visibility = status.Visibility
+
+	if err := p.state.DB.PutScheduledStatus(ctx, scheduled); err != nil {
+		err := gtserror.Newf("db error inserting scheduled status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Mark attachments as belonging to
+	// the scheduled status, so they can't
+	// be attached to anything else meanwhile.
+	for _, attachment := range scheduled.Attachments {
+		attachment.ScheduledStatusID = scheduled.ID
+		if err := p.state.DB.UpdateAttachment(ctx, attachment, "scheduled_status_id"); err != nil {
+			err := gtserror.Newf("db error updating attachment: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return p.apiScheduledStatus(ctx, scheduled)
+}
+
+// ScheduledGetPage gets a page of the requester's scheduled statuses.
+func (p *Processor) ScheduledGetPage(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	scheduledStatuses, err := p.state.DB.GetAccountScheduledStatuses(ctx, requester.ID, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting scheduled statuses: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Check for empty response.
+	count := len(scheduledStatuses)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo := scheduledStatuses[count-1].ID
+	hi := scheduledStatuses[0].ID
+
+	items := make([]interface{}, 0, count)
+	for _, scheduled := range scheduledStatuses {
+		apiScheduled, err := p.converter.ScheduledStatusToAPIScheduledStatus(ctx, scheduled)
+		if err != nil {
+			log.Errorf(ctx, "error converting scheduled status to api scheduled status: %v", err)
+			continue
+		}
+
+		items = append(items, apiScheduled)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/scheduled_statuses",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// ScheduledGet gets one of the requester's scheduled statuses.
+func (p *Processor) ScheduledGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	scheduledID string,
+) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	scheduled, errWithCode := p.getOwnScheduledStatus(ctx, requester, scheduledID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiScheduledStatus(ctx, scheduled)
+}
+
+// ScheduledUpdate reschedules one of the requester's
+// scheduled statuses for publishing at the given time.
+func (p *Processor) ScheduledUpdate(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	scheduledID string,
+	form *apimodel.ScheduledStatusUpdateRequest,
+) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	scheduledAt, errWithCode := parseScheduledAt(form.ScheduledAt)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	scheduled, errWithCode := p.getOwnScheduledStatus(ctx, requester, scheduledID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	scheduled.ScheduledAt = scheduledAt
+	if err := p.state.DB.UpdateScheduledStatus(ctx, scheduled, "scheduled_at"); err != nil {
+		err := gtserror.Newf("db error updating scheduled status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiScheduledStatus(ctx, scheduled)
+}
+
+// ScheduledDelete cancels one of the requester's scheduled
+// statuses, freeing up its attachments to be used elsewhere.
+func (p *Processor) ScheduledDelete(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	scheduledID string,
+) gtserror.WithCode {
+	scheduled, errWithCode := p.getOwnScheduledStatus(ctx, requester, scheduledID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := p.state.DB.DeleteScheduledStatusByID(ctx, scheduled.ID); err != nil {
+		err := gtserror.Newf("db error deleting scheduled status: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.DetachScheduledAttachments(ctx, scheduled); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// DetachScheduledAttachments unmarks the attachments of the
+// given scheduled status as belonging to it, to be done once
+// the scheduled status is deleted, or about to be published.
+func (p *Processor) DetachScheduledAttachments(ctx context.Context, scheduled *gtsmodel.ScheduledStatus) error {
+	for _, attachment := range scheduled.Attachments {
+		if attachment.ScheduledStatusID != scheduled.ID {
+			// Not (or no
+			// longer) ours.
+			continue
+		}
+
+		attachment.ScheduledStatusID = ""
+		if err := p.state.DB.UpdateAttachment(ctx, attachment, "scheduled_status_id"); err != nil {
+			return gtserror.Newf("db error updating attachment: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// AttachScheduledAttachments marks the attachments of the given
+// scheduled status as belonging to it again, after they've been
+// detached for publishing but the status couldn't be published.
+func (p *Processor) AttachScheduledAttachments(ctx context.Context, scheduled *gtsmodel.ScheduledStatus) error {
+	for _, attachment := range scheduled.Attachments {
+		if attachment.StatusID != "" || attachment.ScheduledStatusID != "" {
+			// Already attached
+			// to something else.
+			continue
+		}
+
+		attachment.ScheduledStatusID = scheduled.ID
+		if err := p.state.DB.UpdateAttachment(ctx, attachment, "scheduled_status_id"); err != nil {
+			return gtserror.Newf("db error updating attachment: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (p *Processor) getOwnScheduledStatus(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	scheduledID string,
+) (*gtsmodel.ScheduledStatus, gtserror.WithCode) {
+	scheduled, err := p.state.DB.GetScheduledStatusByID(ctx, scheduledID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting scheduled status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Other accounts' scheduled
+	// statuses don't exist for
+	// the requester, as such.
+	if scheduled == nil || scheduled.AccountID != requester.ID {
+		const text = "scheduled status not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	return scheduled, nil
+}
+
+func (p *Processor) apiScheduledStatus(
+	ctx context.Context,
+	scheduled *gtsmodel.ScheduledStatus,
+) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	apiScheduled, err := p.converter.ScheduledStatusToAPIScheduledStatus(ctx, scheduled)
+	if err != nil {
+		err := gtserror.Newf("error converting scheduled status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiScheduled, nil
+}
+
+// parseScheduledAt parses the given scheduled_at
+// form value, checking it's far enough in the future.
+func parseScheduledAt(scheduledAtStr string) (time.Time, gtserror.WithCode) {
+	scheduledAt, err := util.ParseISO8601(scheduledAtStr)
+	if err != nil {
+		text := fmt.Sprintf("could not parse scheduled_at value %s as ISO 8601 datetime", scheduledAtStr)
+		return time.Time{}, gtserror.NewErrorBadRequest(err, text)
+	}
+
+	if scheduledAt.Before(time.Now().Add(scheduledStatusMinDelay)) {
+		const text = "scheduled_at must be at least 5 minutes in the future"
+		return time.Time{}, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	return scheduledAt, nil
+}
@@ -0,0 +1,220 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type StatusScheduledTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusScheduledTestSuite) scheduleForm(scheduledAt time.Time, mediaIDs ...string) *apimodel.AdvancedStatusCreateForm {
+	return &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "this is from the past!",
+			MediaIDs:    mediaIDs,
+			Visibility:  apimodel.VisibilityUnlisted,
+			ScheduledAt: util.FormatISO8601(scheduledAt),
+			Language:    "en",
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+	}
+}
+
+func (suite *StatusScheduledTestSuite) TestScheduledCreate() {
+	var (
+		ctx         = context.Background()
+		requester   = suite.testAccounts["local_account_1"]
+		application = suite.testApplications["application_1"]
+		attachment  = suite.testAttachments["local_account_1_unattached_1"]
+		scheduledAt = time.Now().Add(time.Hour).Truncate(time.Second)
+	)
+
+	apiScheduled, errWithCode := suite.status.ScheduledCreate(ctx,
+		requester,
+		application,
+		suite.scheduleForm(scheduledAt, attachment.ID),
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Equal(util.FormatISO8601(scheduledAt), apiScheduled.ScheduledAt)
+	suite.Equal("this is from the past!", apiScheduled.Params.Text)
+	suite.Equal("unlisted", apiScheduled.Params.Visibility)
+	suite.Equal(application.ID, apiScheduled.Params.ApplicationID)
+	suite.Equal([]string{attachment.ID}, apiScheduled.Params.MediaIDs)
+	suite.Len(apiScheduled.MediaAttachments, 1)
+
+	// Scheduled status should be stored.
+	count, err := suite.db.CountAccountScheduledStatuses(ctx, requester.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(1, count)
+
+	// Attachment should now belong to the scheduled status.
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(apiScheduled.ID, dbAttachment.ScheduledStatusID)
+
+	// So it can't be attached to anything else.
+	_, errWithCode = suite.status.ScheduledCreate(ctx,
+		requester,
+		application,
+		suite.scheduleForm(scheduledAt, attachment.ID),
+	)
+	suite.EqualError(errWithCode, "media 01F8MH8RMYQ6MSNY3JM2XT1CQ5 already attached to status")
+}
+
+func (suite *StatusScheduledTestSuite) TestScheduledCreateIdempotencyKey() {
+	var (
+		ctx         = gtscontext.SetIdempotencyKey(context.Background(), "some-retried-request")
+		requester   = suite.testAccounts["local_account_1"]
+		application = suite.testApplications["application_1"]
+		scheduledAt = time.Now().Add(time.Hour).Truncate(time.Second)
+	)
+
+	// Schedule the status.
+	apiScheduled1, errWithCode := suite.status.ScheduledCreate(ctx,
+		requester,
+		application,
+		suite.scheduleForm(scheduledAt),
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Retry the request with the same key;
+	// we should get the same scheduled status.
+	apiScheduled2, errWithCode := suite.status.ScheduledCreate(ctx,
+		requester,
+		application,
+		suite.scheduleForm(scheduledAt),
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(apiScheduled1.ID, apiScheduled2.ID)
+
+	// Only one should be stored.
+	count, err := suite.db.CountAccountScheduledStatuses(ctx, requester.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(1, count)
+
+	// Same request with a different key
+	// should schedule a new status.
+	ctx = gtscontext.SetIdempotencyKey(context.Background(), "some-other-request")
+	apiScheduled3, errWithCode := suite.status.ScheduledCreate(ctx,
+		requester,
+		application,
+		suite.scheduleForm(scheduledAt),
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.NotEqual(apiScheduled1.ID, apiScheduled3.ID)
+}
+
+func (suite *StatusScheduledTestSuite) TestScheduledCreateTooSoon() {
+	var (
+		ctx         = context.Background()
+		requester   = suite.testAccounts["local_account_1"]
+		application = suite.testApplications["application_1"]
+	)
+
+	apiScheduled, errWithCode := suite.status.ScheduledCreate(ctx,
+		requester,
+		application,
+		suite.scheduleForm(time.Now().Add(time.Minute)),
+	)
+	suite.Nil(apiScheduled)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+	suite.EqualError(errWithCode, "scheduled_at must be at least 5 minutes in the future")
+}
+
+func (suite *StatusScheduledTestSuite) TestScheduledUpdateAndDelete() {
+	var (
+		ctx         = context.Background()
+		requester   = suite.testAccounts["local_account_1"]
+		application = suite.testApplications["application_1"]
+		attachment  = suite.testAttachments["local_account_1_unattached_1"]
+		scheduledAt = time.Now().Add(time.Hour).Truncate(time.Second)
+	)
+
+	apiScheduled, errWithCode := suite.status.ScheduledCreate(ctx,
+		requester,
+		application,
+		suite.scheduleForm(scheduledAt, attachment.ID),
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Other accounts can't see or touch it.
+	_, errWithCode = suite.status.ScheduledGet(ctx, suite.testAccounts["local_account_2"], apiScheduled.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	// Reschedule for a day later.
+	rescheduledAt := scheduledAt.Add(24 * time.Hour)
+	apiScheduled, errWithCode = suite.status.ScheduledUpdate(ctx,
+		requester,
+		apiScheduled.ID,
+		&apimodel.ScheduledStatusUpdateRequest{
+			ScheduledAt: util.FormatISO8601(rescheduledAt),
+		},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(util.FormatISO8601(rescheduledAt), apiScheduled.ScheduledAt)
+
+	// Now cancel it.
+	if errWithCode := suite.status.ScheduledDelete(ctx, requester, apiScheduled.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	_, err := suite.db.GetScheduledStatusByID(ctx, apiScheduled.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Attachment should be free again.
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(dbAttachment.ScheduledStatusID)
+}
+
+func TestStatusScheduledTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusScheduledTestSuite))
+}
@@ -19,6 +19,7 @@ package stream
 
 import (
 	"context"
+	"errors"
 
 	"codeberg.org/gruf/go-kv"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
@@ -34,5 +35,22 @@ func (p *Processor) Open(ctx context.Context, account *gtsmodel.Account, streamT
 		{"streamType", streamType},
 	}...)
 	l.Debug("received open stream request")
-	return p.streams.Open(account.ID, streamType), nil
+
+	str, err := p.streams.Open(account.ID, streamType)
+	switch {
+	case errors.Is(err, stream.ErrTooManyAccountStreams):
+		// This account has too many streams
+		// open already, eg., a buggy client is
+		// opening new streams without closing.
+		l.Warn("rejected open stream request: too many streams open for account")
+		return nil, gtserror.NewErrorTooManyRequests(err, err.Error())
+
+	case errors.Is(err, stream.ErrTooManyStreams):
+		// The instance as a whole
+		// has too many streams open.
+		l.Warn("rejected open stream request: too many streams open")
+		return nil, gtserror.NewErrorServiceUnavailable(err, err.Error())
+	}
+
+	return str, nil
 }
@@ -19,9 +19,12 @@ package stream_test
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/stream"
 )
 
 type OpenStreamTestSuite struct {
@@ -35,6 +38,45 @@ func (suite *OpenStreamTestSuite) TestOpenStream() {
 	suite.NoError(errWithCode)
 }
 
+func (suite *OpenStreamTestSuite) TestOpenStreamLimits() {
+	config.SetAdvancedStreamingMaxAccountConnections(2)
+	config.SetAdvancedStreamingMaxConnections(3)
+	streamProcessor := stream.New(&suite.state, suite.oauthServer)
+
+	var (
+		ctx      = context.Background()
+		account1 = suite.testAccounts["local_account_1"]
+		account2 = suite.testAccounts["local_account_2"]
+	)
+
+	// Open the max streams for one account.
+	for i := 0; i < 2; i++ {
+		_, errWithCode := streamProcessor.Open(ctx, account1, "user")
+		suite.NoError(errWithCode)
+	}
+
+	// Another one is rejected for the account...
+	_, errWithCode := streamProcessor.Open(ctx, account1, "user")
+	suite.Equal(http.StatusTooManyRequests, errWithCode.Code())
+
+	// ...but other accounts can still open streams,
+	// up to the max streams for the instance.
+	str, errWithCode := streamProcessor.Open(ctx, account2, "user")
+	suite.NoError(errWithCode)
+
+	_, errWithCode = streamProcessor.Open(ctx, account2, "user")
+	suite.Equal(http.StatusServiceUnavailable, errWithCode.Code())
+
+	stats := streamProcessor.Stats()
+	suite.Equal(3, stats.Open)
+	suite.Equal(uint64(2), stats.Rejected)
+
+	// Closing a stream frees up a slot.
+	str.Close()
+	_, errWithCode = streamProcessor.Open(ctx, account2, "user")
+	suite.NoError(errWithCode)
+}
+
 func TestOpenStreamTestSuite(t *testing.T) {
 	suite.Run(t, &OpenStreamTestSuite{})
 }
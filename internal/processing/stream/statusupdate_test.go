@@ -54,6 +54,7 @@ func (suite *StatusUpdateTestSuite) TestStreamNotification() {
 	suite.Equal(`{
   "id": "01FVW7JHQFSFK166WWKR8CBA6M",
   "created_at": "2021-09-20T10:40:37.000Z",
+  "edited_at": null,
   "in_reply_to_id": null,
   "in_reply_to_account_id": null,
   "sensitive": false,
@@ -69,9 +70,22 @@ func (suite *StatusUpdateTestSuite) TestStreamNotification() {
   "reblogged": false,
   "muted": false,
   "bookmarked": false,
+  "content_revealed": false,
   "pinned": false,
   "content": "dark souls status bot: \"thoughts of dog\"",
   "reblog": null,
+  "quote": null,
+  "interaction_policy": {
+    "can_reply": [
+      "public"
+    ],
+    "can_announce": [
+      "public"
+    ],
+    "can_like": [
+      "public"
+    ]
+  },
   "account": {
     "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
     "username": "foss_satan",
@@ -122,7 +136,8 @@ func (suite *StatusUpdateTestSuite) TestStreamNotification() {
         }
       },
       "description": "tweet from thoughts of dog: i drank. all the water. in my bowl. earlier. but just now. i returned. to the same bowl. and it was. full again.. the bowl. is haunted",
-      "blurhash": "LARysgM_IU_3~pD%M_Rj_39FIAt6"
+      "blurhash": "LARysgM_IU_3~pD%M_Rj_39FIAt6",
+      "sensitive": false
     }
   ],
   "mentions": [],
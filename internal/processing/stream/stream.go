@@ -18,6 +18,7 @@
 package stream
 
 import (
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/stream"
@@ -33,6 +34,14 @@ func New(state *state.State, oauthServer oauth.Server) Processor {
 	return Processor{
 		state:       state,
 		oauthServer: oauthServer,
-		streams:     stream.Streams{},
+		streams: stream.Streams{
+			MaxPerAccount: config.GetAdvancedStreamingMaxAccountConnections(),
+			MaxTotal:      config.GetAdvancedStreamingMaxConnections(),
+		},
 	}
 }
+
+// Stats returns current statistics about open streams.
+func (p *Processor) Stats() stream.Stats {
+	return p.streams.Stats()
+}
@@ -0,0 +1,173 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tags
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// maxFeaturedTagSuggestions is the maximum
+// amount of hashtags suggested for featuring.
+const maxFeaturedTagSuggestions = 10
+
+// FeaturedTagsGet gets all hashtags featured by
+// the given account ID, oldest features first.
+func (p *Processor) FeaturedTagsGet(
+	ctx context.Context,
+	accountID string,
+) ([]*apimodel.FeaturedTag, gtserror.WithCode) {
+	featuredTags, err := p.state.DB.GetAccountFeaturedTags(ctx, accountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting featured tags: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiFeaturedTags := make([]*apimodel.FeaturedTag, 0, len(featuredTags))
+	for _, featuredTag := range featuredTags {
+		apiFeaturedTag, err := p.converter.FeaturedTagToAPIFeaturedTag(ctx, featuredTag)
+		if err != nil {
+			log.Errorf(ctx, "error converting featured tag to api: %v", err)
+			continue
+		}
+
+		apiFeaturedTags = append(apiFeaturedTags, &apiFeaturedTag)
+	}
+
+	return apiFeaturedTags, nil
+}
+
+// FeaturedTagCreate makes the given account feature the
+// hashtag with the given name on its profile, creating
+// the hashtag if it doesn't exist yet.
+func (p *Processor) FeaturedTagCreate(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	tagName string,
+) (*apimodel.FeaturedTag, gtserror.WithCode) {
+	tag, errWithCode := p.getOrCreateTag(ctx, tagName)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	featuredTags, err := p.state.DB.GetAccountFeaturedTags(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting featured tags: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	for _, featuredTag := range featuredTags {
+		if featuredTag.TagID == tag.ID {
+			const text = "hashtag is already featured"
+			return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+	}
+
+	if len(featuredTags) >= gtsmodel.MaxFeaturedTags {
+		text := fmt.Sprintf("cannot feature more than %d hashtags", gtsmodel.MaxFeaturedTags)
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	featuredTag := &gtsmodel.FeaturedTag{
+		ID:        id.NewULID(),
+		AccountID: account.ID,
+		TagID:     tag.ID,
+		Tag:       tag,
+	}
+
+	if err := p.state.DB.PutFeaturedTag(ctx, featuredTag); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			// Featured concurrently by
+			// another request, just bail.
+			const text = "hashtag is already featured"
+			return nil, gtserror.NewErrorUnprocessableEntity(err, text)
+		}
+
+		err := gtserror.Newf("db error putting featured tag: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiFeaturedTag, err := p.converter.FeaturedTagToAPIFeaturedTag(ctx, featuredTag)
+	if err != nil {
+		err := gtserror.Newf("error converting featured tag to api: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apiFeaturedTag, nil
+}
+
+// FeaturedTagDelete makes the given account stop featuring
+// the hashtag, using the ID of the featured tag (not the tag).
+func (p *Processor) FeaturedTagDelete(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	featuredTagID string,
+) gtserror.WithCode {
+	featuredTag, err := p.state.DB.GetFeaturedTagByID(ctx, featuredTagID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting featured tag: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if featuredTag == nil || featuredTag.AccountID != account.ID {
+		// Don't reveal existence of other accounts' featured tags.
+		err := gtserror.Newf("featured tag %s not found", featuredTagID)
+		return gtserror.NewErrorNotFound(err)
+	}
+
+	if err := p.state.DB.DeleteFeaturedTagByID(ctx, featuredTag.ID); err != nil {
+		err := gtserror.Newf("db error deleting featured tag: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// FeaturedTagSuggestionsGet gets the hashtags most used
+// by the given account that it isn't featuring yet.
+func (p *Processor) FeaturedTagSuggestionsGet(
+	ctx context.Context,
+	account *gtsmodel.Account,
+) ([]*apimodel.Tag, gtserror.WithCode) {
+	tags, err := p.state.DB.GetAccountMostUsedTags(ctx, account.ID, maxFeaturedTagSuggestions)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting most used tags: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiTags := make([]*apimodel.Tag, 0, len(tags))
+	for _, tag := range tags {
+		apiTag, err := p.converter.TagToAPITag(ctx, tag, true)
+		if err != nil {
+			log.Errorf(ctx, "error converting tag to api: %v", err)
+			continue
+		}
+
+		apiTags = append(apiTags, &apiTag)
+	}
+
+	return apiTags, nil
+}
@@ -0,0 +1,204 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tags
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// Follow makes the given account follow the hashtag with
+// the given name, creating the hashtag if it doesn't exist
+// yet. Following an already followed hashtag is a no-op.
+func (p *Processor) Follow(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	tagName string,
+) (*apimodel.Tag, gtserror.WithCode) {
+	tag, errWithCode := p.getOrCreateTag(ctx, tagName)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Check whether account already follows this tag.
+	followedTag, err := p.state.DB.GetFollowedTag(ctx, account.ID, tag.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error checking followed tag: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if followedTag == nil {
+		// Not followed yet, do it now.
+		followedTag = &gtsmodel.FollowedTag{
+			ID:        id.NewULID(),
+			AccountID: account.ID,
+			TagID:     tag.ID,
+			Tag:       tag,
+		}
+
+		if err := p.state.DB.PutFollowedTag(ctx, followedTag); err != nil {
+			err := gtserror.Newf("db error putting followed tag: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return p.apiTag(ctx, tag, true)
+}
+
+// Unfollow makes the given account stop following the hashtag
+// with the given name. Unfollowing a hashtag that isn't followed
+// is a no-op, but the hashtag itself must exist.
+func (p *Processor) Unfollow(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	tagName string,
+) (*apimodel.Tag, gtserror.WithCode) {
+	tag, errWithCode := p.getTag(ctx, tagName)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if tag == nil {
+		err := gtserror.Newf("tag %s not found", tagName)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if err := p.state.DB.DeleteFollowedTag(ctx, account.ID, tag.ID); err != nil {
+		err := gtserror.Newf("db error deleting followed tag: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiTag(ctx, tag, false)
+}
+
+// FollowedTagsGet gets a page of the hashtags
+// followed by the given account, newest follows first.
+func (p *Processor) FollowedTagsGet(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	followedTags, err := p.state.DB.GetAccountFollowedTags(ctx, account.ID, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting followed tags: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Check for empty response.
+	count := len(followedTags)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo := followedTags[count-1].ID
+	hi := followedTags[0].ID
+
+	items := make([]interface{}, 0, count)
+	for _, followedTag := range followedTags {
+		apiTag, errWithCode := p.apiTag(ctx, followedTag.Tag, true)
+		if errWithCode != nil {
+			log.Errorf(ctx, "error converting followed tag to api: %v", errWithCode)
+			continue
+		}
+
+		items = append(items, apiTag)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/followed_tags",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// getTag normalizes the given tag name and returns the
+// tag with that name, or nil if it's not in the db yet.
+func (p *Processor) getTag(ctx context.Context, tagName string) (*gtsmodel.Tag, gtserror.WithCode) {
+	// Normalize + validate tag name.
+	tagNameNormal, ok := text.NormalizeHashtag(tagName)
+	if !ok {
+		err := gtserror.Newf("string '%s' could not be normalized to a valid hashtag", tagName)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	tag, err := p.state.DB.GetTagByName(ctx, tagNameNormal)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		// Real db error.
+		err = gtserror.Newf("db error getting tag by name: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return tag, nil
+}
+
+// getOrCreateTag is like getTag, but it
+// creates the tag if it's not in the db yet.
+func (p *Processor) getOrCreateTag(ctx context.Context, tagName string) (*gtsmodel.Tag, gtserror.WithCode) {
+	tag, errWithCode := p.getTag(ctx, tagName)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if tag != nil {
+		// We had it!
+		return tag, nil
+	}
+
+	// We didn't have a tag with
+	// this name, create one.
+	tagNameNormal, _ := text.NormalizeHashtag(tagName)
+	tag = &gtsmodel.Tag{
+		ID:       id.NewULID(),
+		Name:     tagNameNormal,
+		Useable:  util.Ptr(true),
+		Listable: util.Ptr(true),
+	}
+
+	if err := p.state.DB.PutTag(ctx, tag); err != nil {
+		err := gtserror.Newf("db error putting new tag %s: %w", tagNameNormal, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return tag, nil
+}
+
+// apiTag converts the given tag to its api
+// representation, with the given following value.
+func (p *Processor) apiTag(ctx context.Context, tag *gtsmodel.Tag, following bool) (*apimodel.Tag, gtserror.WithCode) {
+	apiTag, err := p.converter.TagToAPITag(ctx, tag, false)
+	if err != nil {
+		err := gtserror.Newf("error converting tag %s to api tag: %w", tag.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiTag.Following = util.Ptr(following)
+	return &apiTag, nil
+}
@@ -28,11 +28,16 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
-	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
-func (p *Processor) FavedTimelineGet(ctx context.Context, authed *oauth.Auth, maxID string, minID string, limit int) (*apimodel.PageableResponse, gtserror.WithCode) {
-	statuses, nextMaxID, prevMinID, err := p.state.DB.GetFavedTimeline(ctx, authed.Account.ID, maxID, minID, limit)
+// FavedTimelineGet returns a page of statuses faved by the requesting account.
+// Paging for this response is done based on fave ID rather than status ID.
+func (p *Processor) FavedTimelineGet(ctx context.Context, authed *oauth.Auth, page *paging.Page) (*apimodel.PageableResponse, gtserror.WithCode) {
+	// Get the lowest and highest fave
+	// ID values, used for paging, along
+	// with the faved statuses themselves.
+	statuses, lo, hi, err := p.state.DB.GetFavedTimeline(ctx, authed.Account.ID, page)
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
 		err = fmt.Errorf("FavedTimelineGet: db error getting statuses: %w", err)
 		return nil, gtserror.NewErrorInternalError(err)
@@ -40,7 +45,7 @@ func (p *Processor) FavedTimelineGet(ctx context.Context, authed *oauth.Auth, ma
 
 	count := len(statuses)
 	if count == 0 {
-		return util.EmptyPageableResponse(), nil
+		return paging.EmptyResponse(), nil
 	}
 
 	items := make([]interface{}, 0, count)
@@ -64,11 +69,10 @@ func (p *Processor) FavedTimelineGet(ctx context.Context, authed *oauth.Auth, ma
 		items = append(items, apiStatus)
 	}
 
-	return util.PackagePageableResponse(util.PageableResponseParams{
-		Items:          items,
-		Path:           "/api/v1/favourites",
-		NextMaxIDValue: nextMaxID,
-		PrevMinIDValue: prevMinID,
-		Limit:          limit,
-	})
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/favourites",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
@@ -80,8 +81,50 @@ func HomeTimelineFilter(state *state.State, filter *visibility.Filter) timeline.
 			return false, err
 		}
 
-		return timelineable, nil
+		if !timelineable {
+			return false, nil
+		}
+
+		// Posts from members of exclusive lists
+		// are only shown in those list timelines.
+		exclusive, err := inExclusiveList(ctx, state, accountID, status.AccountID)
+		if err != nil {
+			err = gtserror.Newf("error checking exclusive lists of account %s: %w", accountID, err)
+			return false, err
+		}
+
+		return !exclusive, nil
+	}
+}
+
+// inExclusiveList returns whether targetAccountID is a
+// member of any exclusive list owned by accountID.
+func inExclusiveList(ctx context.Context, state *state.State, accountID string, targetAccountID string) (bool, error) {
+	lists, err := state.DB.GetListsForAccountID(
+		// We only need the lists themselves.
+		gtscontext.SetBarebones(ctx),
+		accountID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, err
 	}
+
+	for _, list := range lists {
+		if !*list.Exclusive {
+			continue
+		}
+
+		includes, err := state.DB.ListIncludesAccount(ctx, list.ID, targetAccountID)
+		if err != nil {
+			return false, err
+		}
+
+		if includes {
+			return true, nil
+		}
+	}
+
+	return false, nil
 }
 
 // HomeTimelineStatusPrepare returns a function that satisfies PrepareFunction for home timelines.
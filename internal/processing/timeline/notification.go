@@ -21,6 +21,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -94,6 +96,135 @@ func (p *Processor) NotificationsGet(ctx context.Context, authed *oauth.Auth, ma
 	})
 }
 
+// NotificationsGetGrouped returns a page of the authed account's notifications,
+// as for NotificationsGet, with similar notifications grouped together, along
+// with the Link header value for paging through them.
+func (p *Processor) NotificationsGetGrouped(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, minID string, limit int, excludeTypes []string) (*apimodel.GroupedNotificationsResults, string, gtserror.WithCode) {
+	notifs, err := p.state.DB.GetAccountNotifications(ctx, authed.Account.ID, maxID, sinceID, minID, limit, excludeTypes)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting notifications: %w", err)
+		return nil, "", gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(notifs)
+	if count == 0 {
+		return &apimodel.GroupedNotificationsResults{
+			Accounts:           []*apimodel.Account{},
+			Statuses:           []*apimodel.Status{},
+			NotificationGroups: []*apimodel.NotificationGroup{},
+		}, "", nil
+	}
+
+	filters, err := p.state.DB.GetFiltersForAccountID(ctx, authed.Account.ID)
+	if err != nil {
+		err = gtserror.Newf("couldn't retrieve filters for account %s: %w", authed.Account.ID, err)
+		return nil, "", gtserror.NewErrorInternalError(err)
+	}
+
+	// Set next + prev values before filtering and API
+	// converting, so caller can still page properly.
+	var (
+		nextMaxIDValue = notifs[count-1].ID
+		prevMinIDValue = notifs[0].ID
+	)
+
+	notifs = p.visibleNotifs(ctx, notifs, authed.Account)
+
+	results, err := p.converter.NotificationsToAPIGroupedNotifications(ctx, notifs, filters)
+	if err != nil {
+		err = gtserror.Newf("error converting notifications to api representation: %w", err)
+		return nil, "", gtserror.NewErrorInternalError(err)
+	}
+
+	resp, errWithCode := util.PackagePageableResponse(util.PageableResponseParams{
+		Path:           "api/v2/notifications",
+		NextMaxIDValue: nextMaxIDValue,
+		PrevMinIDValue: prevMinIDValue,
+		Limit:          limit,
+	})
+	if errWithCode != nil {
+		return nil, "", errWithCode
+	}
+
+	return results, resp.LinkHeader, nil
+}
+
+// NotificationGroupGet returns the notification group
+// with the given key of the given account, if it exists.
+func (p *Processor) NotificationGroupGet(ctx context.Context, account *gtsmodel.Account, groupKey string) (*apimodel.GroupedNotificationsResults, gtserror.WithCode) {
+	notifs, errWithCode := p.getNotifGroup(ctx, account, groupKey)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	filters, err := p.state.DB.GetFiltersForAccountID(ctx, account.ID)
+	if err != nil {
+		err = gtserror.Newf("couldn't retrieve filters for account %s: %w", account.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	results, err := p.converter.NotificationsToAPIGroupedNotifications(ctx, notifs, filters)
+	if err != nil {
+		err = gtserror.Newf("error converting notifications to api representation: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if len(results.NotificationGroups) == 0 {
+		err := gtserror.Newf("no visible notifications in group %s", groupKey)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return results, nil
+}
+
+// NotificationGroupAccountsGet returns all the accounts that performed the
+// actions that generated the notification group with the given key of the
+// given account, most recent first.
+func (p *Processor) NotificationGroupAccountsGet(ctx context.Context, account *gtsmodel.Account, groupKey string) ([]*apimodel.Account, gtserror.WithCode) {
+	notifs, errWithCode := p.getNotifGroup(ctx, account, groupKey)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	accounts := make([]*apimodel.Account, 0, len(notifs))
+	for _, n := range notifs {
+		if slices.ContainsFunc(accounts, func(a *apimodel.Account) bool {
+			return a.ID == n.OriginAccountID
+		}) {
+			// Already included.
+			continue
+		}
+
+		apiAccount, err := p.converter.AccountToAPIAccountPublic(ctx, n.OriginAccount)
+		if err != nil {
+			log.Debugf(ctx, "skipping account %s because it couldn't be converted to its api representation: %v", n.OriginAccountID, err)
+			continue
+		}
+
+		accounts = append(accounts, apiAccount)
+	}
+
+	return accounts, nil
+}
+
+// NotificationGroupDismiss deletes all notifications in the
+// notification group with the given key of the given account.
+func (p *Processor) NotificationGroupDismiss(ctx context.Context, account *gtsmodel.Account, groupKey string) gtserror.WithCode {
+	notifs, errWithCode := p.getNotifGroup(ctx, account, groupKey)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	for _, n := range notifs {
+		if err := p.state.DB.DeleteNotificationByID(ctx, n.ID); err != nil {
+			err = gtserror.Newf("db error deleting notification %s: %w", n.ID, err)
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return nil
+}
+
 func (p *Processor) NotificationGet(ctx context.Context, account *gtsmodel.Account, targetNotifID string) (*apimodel.Notification, gtserror.WithCode) {
 	notif, err := p.state.DB.GetNotificationByID(ctx, targetNotifID)
 	if err != nil {
@@ -138,6 +269,57 @@ func (p *Processor) NotificationsClear(ctx context.Context, authed *oauth.Auth)
 	return nil
 }
 
+// getNotifGroup returns the visible notifications in the notification
+// group of the given account with the given key, most recent first.
+func (p *Processor) getNotifGroup(ctx context.Context, account *gtsmodel.Account, groupKey string) ([]*gtsmodel.Notification, gtserror.WithCode) {
+	var (
+		notifs []*gtsmodel.Notification
+		err    error
+	)
+
+	if notifID, ok := strings.CutPrefix(groupKey, gtsmodel.NotificationUngroupedPrefix); ok {
+		// Group of one ungrouped
+		// notification, by its ID.
+		var notif *gtsmodel.Notification
+		notif, err = p.state.DB.GetNotificationByID(ctx, notifID)
+		if notif != nil && notif.TargetAccountID == account.ID {
+			notifs = []*gtsmodel.Notification{notif}
+		}
+	} else {
+		notifs, err = p.state.DB.GetAccountNotificationsByGroupKey(ctx, account.ID, groupKey)
+	}
+
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting notification group %s: %w", groupKey, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	notifs = p.visibleNotifs(ctx, notifs, account)
+	if len(notifs) == 0 {
+		err := gtserror.Newf("notification group %s not found", groupKey)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return notifs, nil
+}
+
+// visibleNotifs returns the given notifications
+// that are visible to the given account, in place.
+func (p *Processor) visibleNotifs(
+	ctx context.Context,
+	notifs []*gtsmodel.Notification,
+	acct *gtsmodel.Account,
+) []*gtsmodel.Notification {
+	return slices.DeleteFunc(notifs, func(n *gtsmodel.Notification) bool {
+		visible, err := p.notifVisible(ctx, n, acct)
+		if err != nil {
+			log.Debugf(ctx, "skipping notification %s because of an error checking notification visibility: %v", n.ID, err)
+			return true
+		}
+		return !visible
+	})
+}
+
 func (p *Processor) notifVisible(
 	ctx context.Context,
 	n *gtsmodel.Notification,
@@ -0,0 +1,199 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package timeline
+
+import (
+	"context"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// NotificationPolicyGet gets the notification policy of the given account.
+func (p *Processor) NotificationPolicyGet(
+	ctx context.Context,
+	account *gtsmodel.Account,
+) (*apimodel.NotificationPolicy, gtserror.WithCode) {
+	settings, errWithCode := p.getAccountSettings(ctx, account)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiNotificationPolicy(ctx, settings)
+}
+
+// NotificationPolicyGetV1 gets the notification policy
+// of the given account, in the format of v1 of the API.
+func (p *Processor) NotificationPolicyGetV1(
+	ctx context.Context,
+	account *gtsmodel.Account,
+) (*apimodel.NotificationPolicyV1, gtserror.WithCode) {
+	policy, errWithCode := p.NotificationPolicyGet(ctx, account)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return notificationPolicyToV1(policy), nil
+}
+
+// NotificationPolicyUpdate updates the notification policy of the given
+// account with the non-nil fields of the form, and returns the result.
+func (p *Processor) NotificationPolicyUpdate(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	form *apimodel.NotificationPolicyUpdateRequest,
+) (*apimodel.NotificationPolicy, gtserror.WithCode) {
+	settings, errWithCode := p.getAccountSettings(ctx, account)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	var columns []string
+	for _, field := range []struct {
+		value  *string
+		policy *gtsmodel.NotificationPolicy
+		column string
+	}{
+		{form.ForNotFollowing, &settings.NotifPolicyNotFollowing, "notif_policy_not_following"},
+		{form.ForNotFollowers, &settings.NotifPolicyNotFollowers, "notif_policy_not_followers"},
+		{form.ForNewAccounts, &settings.NotifPolicyNewAccounts, "notif_policy_new_accounts"},
+		{form.ForPrivateMentions, &settings.NotifPolicyPrivateMentions, "notif_policy_private_mentions"},
+	} {
+		if field.value == nil {
+			continue
+		}
+
+		policy := gtsmodel.NotificationPolicy(*field.value)
+		switch policy {
+		case gtsmodel.NotificationPolicyAccept,
+			gtsmodel.NotificationPolicyFilter,
+			gtsmodel.NotificationPolicyDrop:
+		default:
+			text := fmt.Sprintf("invalid notification policy %q, must be one of accept, filter, drop", *field.value)
+			return nil, gtserror.NewErrorBadRequest(gtserror.New(text), text)
+		}
+
+		*field.policy = policy
+		columns = append(columns, field.column)
+	}
+
+	if len(columns) != 0 {
+		if err := p.state.DB.UpdateAccountSettings(ctx, settings, columns...); err != nil {
+			err := gtserror.Newf("db error updating account settings: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return p.apiNotificationPolicy(ctx, settings)
+}
+
+// NotificationPolicyUpdateV1 updates the notification policy of the
+// given account from a form in the format of v1 of the API, where
+// true means "filter" and false means "accept", and returns the result.
+func (p *Processor) NotificationPolicyUpdateV1(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	form *apimodel.NotificationPolicyUpdateRequestV1,
+) (*apimodel.NotificationPolicyV1, gtserror.WithCode) {
+	policy, errWithCode := p.NotificationPolicyUpdate(ctx, account, &apimodel.NotificationPolicyUpdateRequest{
+		ForNotFollowing:    filterToPolicy(form.FilterNotFollowing),
+		ForNotFollowers:    filterToPolicy(form.FilterNotFollowers),
+		ForNewAccounts:     filterToPolicy(form.FilterNewAccounts),
+		ForPrivateMentions: filterToPolicy(form.FilterPrivateMentions),
+	})
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return notificationPolicyToV1(policy), nil
+}
+
+func (p *Processor) getAccountSettings(
+	ctx context.Context,
+	account *gtsmodel.Account,
+) (*gtsmodel.AccountSettings, gtserror.WithCode) {
+	settings, err := p.state.DB.GetAccountSettings(ctx, account.ID)
+	if err != nil {
+		err := gtserror.Newf("db error getting account settings: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return settings, nil
+}
+
+func (p *Processor) apiNotificationPolicy(
+	ctx context.Context,
+	settings *gtsmodel.AccountSettings,
+) (*apimodel.NotificationPolicy, gtserror.WithCode) {
+	requests, err := p.state.DB.CountAccountNotificationRequests(ctx, settings.AccountID)
+	if err != nil {
+		err := gtserror.Newf("db error counting notification requests: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	notifs, err := p.state.DB.CountFilteredNotifications(ctx, settings.AccountID, "")
+	if err != nil {
+		err := gtserror.Newf("db error counting filtered notifications: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apimodel.NotificationPolicy{
+		ForNotFollowing:    string(settings.NotifPolicyNotFollowing),
+		ForNotFollowers:    string(settings.NotifPolicyNotFollowers),
+		ForNewAccounts:     string(settings.NotifPolicyNewAccounts),
+		ForPrivateMentions: string(settings.NotifPolicyPrivateMentions),
+		Summary: apimodel.NotificationPolicySummary{
+			PendingRequestsCount:      requests,
+			PendingNotificationsCount: notifs,
+		},
+	}, nil
+}
+
+// notificationPolicyToV1 converts the given notification policy to the
+// format of v1 of the API, which has no notion of dropping notifications:
+// dropped notifications are reported as filtered, since they don't notify.
+func notificationPolicyToV1(policy *apimodel.NotificationPolicy) *apimodel.NotificationPolicyV1 {
+	filters := func(policy string) bool {
+		return policy != string(gtsmodel.NotificationPolicyAccept)
+	}
+
+	return &apimodel.NotificationPolicyV1{
+		FilterNotFollowing:    filters(policy.ForNotFollowing),
+		FilterNotFollowers:    filters(policy.ForNotFollowers),
+		FilterNewAccounts:     filters(policy.ForNewAccounts),
+		FilterPrivateMentions: filters(policy.ForPrivateMentions),
+		Summary:               policy.Summary,
+	}
+}
+
+// filterToPolicy converts a v1 filter flag to a policy value, if set.
+func filterToPolicy(filter *bool) *string {
+	if filter == nil {
+		return nil
+	}
+
+	policy := gtsmodel.NotificationPolicyAccept
+	if *filter {
+		policy = gtsmodel.NotificationPolicyFilter
+	}
+
+	value := string(policy)
+	return &value
+}
@@ -0,0 +1,194 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package timeline
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// NotificationRequestsGetPage gets a page of
+// the account's pending notification requests.
+func (p *Processor) NotificationRequestsGetPage(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	requests, err := p.state.DB.GetAccountNotificationRequests(ctx, account.ID, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting notification requests: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Check for empty response.
+	count := len(requests)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo := requests[count-1].ID
+	hi := requests[0].ID
+
+	items := make([]interface{}, 0, count)
+	for _, req := range requests {
+		apiReq, err := p.converter.NotificationRequestToAPINotificationRequest(ctx, req)
+		if err != nil {
+			log.Errorf(ctx, "error converting notification request to api: %v", err)
+			continue
+		}
+
+		items = append(items, apiReq)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/notifications/requests",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// NotificationRequestGet gets one of the account's pending notification requests.
+func (p *Processor) NotificationRequestGet(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	requestID string,
+) (*apimodel.NotificationRequest, gtserror.WithCode) {
+	req, errWithCode := p.getNotificationRequest(ctx, account, requestID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiReq, err := p.converter.NotificationRequestToAPINotificationRequest(ctx, req)
+	if err != nil {
+		err := gtserror.Newf("error converting notification request to api: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiReq, nil
+}
+
+// NotificationRequestsAccept accepts the given pending notification
+// requests of the account: their filtered notifications are moved to
+// the account's notifications, and further notifications from the
+// requesting accounts won't be filtered by the notification policy.
+func (p *Processor) NotificationRequestsAccept(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	requestIDs []string,
+) gtserror.WithCode {
+	for _, requestID := range requestIDs {
+		req, errWithCode := p.getNotificationRequest(ctx, account, requestID)
+		if errWithCode != nil {
+			return errWithCode
+		}
+
+		notifs, err := p.state.DB.GetFilteredNotifications(ctx, account.ID, req.FromAccountID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting filtered notifications: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		for _, notif := range notifs {
+			notif.Filtered = util.Ptr(false)
+			if err := p.state.DB.UpdateNotification(ctx, notif, "filtered"); err != nil {
+				err := gtserror.Newf("db error updating notification %s: %w", notif.ID, err)
+				return gtserror.NewErrorInternalError(err)
+			}
+		}
+
+		req.Accepted = util.Ptr(true)
+		if err := p.state.DB.UpdateNotificationRequest(ctx, req, "accepted"); err != nil {
+			err := gtserror.Newf("db error updating notification request: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return nil
+}
+
+// NotificationRequestsDismiss dismisses the given pending notification
+// requests of the account, deleting their filtered notifications.
+// Further notifications from the requesting accounts will still be
+// filtered by the notification policy, in new notification requests.
+func (p *Processor) NotificationRequestsDismiss(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	requestIDs []string,
+) gtserror.WithCode {
+	for _, requestID := range requestIDs {
+		req, errWithCode := p.getNotificationRequest(ctx, account, requestID)
+		if errWithCode != nil {
+			return errWithCode
+		}
+
+		notifs, err := p.state.DB.GetFilteredNotifications(ctx, account.ID, req.FromAccountID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting filtered notifications: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		for _, notif := range notifs {
+			if err := p.state.DB.DeleteNotificationByID(ctx, notif.ID); err != nil {
+				err := gtserror.Newf("db error deleting notification %s: %w", notif.ID, err)
+				return gtserror.NewErrorInternalError(err)
+			}
+		}
+
+		if err := p.state.DB.DeleteNotificationRequestByID(ctx, req.ID); err != nil {
+			err := gtserror.Newf("db error deleting notification request: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return nil
+}
+
+// getNotificationRequest gets one of the account's pending notification
+// requests, returning 404 if it doesn't exist, is someone else's, or
+// was already accepted.
+func (p *Processor) getNotificationRequest(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	requestID string,
+) (*gtsmodel.NotificationRequest, gtserror.WithCode) {
+	req, err := p.state.DB.GetNotificationRequestByID(ctx, requestID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting notification request: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if req == nil ||
+		req.AccountID != account.ID ||
+		*req.Accepted {
+		const text = "notification request not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	return req, nil
+}
@@ -21,6 +21,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
+	"strconv"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -36,80 +38,88 @@ import (
 // tagName and given paging parameters. It will ensure
 // that each status in the timeline is actually visible
 // to requestingAcct before returning it.
+//
+// Statuses using any of the tags in anyTagNames are
+// included alongside those using tagName, and statuses
+// are further narrowed to those using every tag in
+// allTagNames, and none of the tags in noneTagNames.
 func (p *Processor) TagTimelineGet(
 	ctx context.Context,
 	requestingAcct *gtsmodel.Account,
 	tagName string,
+	anyTagNames []string,
+	allTagNames []string,
+	noneTagNames []string,
 	maxID string,
 	sinceID string,
 	minID string,
 	limit int,
+	local bool,
 ) (*apimodel.PageableResponse, gtserror.WithCode) {
 	tag, errWithCode := p.getTag(ctx, tagName)
 	if errWithCode != nil {
 		return nil, errWithCode
 	}
 
-	if tag == nil || !*tag.Useable || !*tag.Listable {
+	if !tagTimelineable(tag) {
 		// Obey mastodon API by returning 404 for this.
 		err := fmt.Errorf("tag was not found, or not useable/listable on this instance")
 		return nil, gtserror.NewErrorNotFound(err, err.Error())
 	}
 
-	statuses, err := p.state.DB.GetTagTimeline(ctx, tag.ID, maxID, sinceID, minID, limit)
-	if err != nil && !errors.Is(err, db.ErrNoEntries) {
-		err = gtserror.Newf("db error getting statuses: %w", err)
-		return nil, gtserror.NewErrorInternalError(err)
+	anyTags, errWithCode := p.getTags(ctx, anyTagNames)
+	if errWithCode != nil {
+		return nil, errWithCode
 	}
 
-	return p.packageTagResponse(
-		ctx,
-		requestingAcct,
-		statuses,
-		limit,
-		// Use API URL for tag.
-		"/api/v1/timelines/tag/"+tagName,
-	)
-}
+	allTags, errWithCode := p.getTags(ctx, allTagNames)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
 
-func (p *Processor) getTag(ctx context.Context, tagName string) (*gtsmodel.Tag, gtserror.WithCode) {
-	// Normalize + validate tag name.
-	tagNameNormal, ok := text.NormalizeHashtag(tagName)
-	if !ok {
-		err := gtserror.Newf("string '%s' could not be normalized to a valid hashtag", tagName)
-		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	noneTags, errWithCode := p.getTags(ctx, noneTagNames)
+	if errWithCode != nil {
+		return nil, errWithCode
 	}
 
-	// Ensure we have tag with this name in the db.
-	tag, err := p.state.DB.GetTagByName(ctx, tagNameNormal)
-	if err != nil && !errors.Is(err, db.ErrNoEntries) {
-		// Real db error.
-		err = gtserror.Newf("db error getting tag by name: %w", err)
-		return nil, gtserror.NewErrorInternalError(err)
+	// Build query params for paging links.
+	extraQueryParams := []string{
+		"local=" + strconv.FormatBool(local),
 	}
 
-	return tag, nil
-}
+	anyTagIDs := []string{tag.ID}
+	for i, anyTag := range anyTags {
+		if tagTimelineable(anyTag) {
+			anyTagIDs = append(anyTagIDs, anyTag.ID)
+		}
+		extraQueryParams = append(extraQueryParams, "any[]="+url.QueryEscape(anyTagNames[i]))
+	}
 
-func (p *Processor) packageTagResponse(
-	ctx context.Context,
-	requestingAcct *gtsmodel.Account,
-	statuses []*gtsmodel.Status,
-	limit int,
-	requestPath string,
-) (*apimodel.PageableResponse, gtserror.WithCode) {
-	count := len(statuses)
-	if count == 0 {
-		return util.EmptyPageableResponse(), nil
+	allTagIDs := make([]string, 0, len(allTags))
+	for i, allTag := range allTags {
+		if !tagTimelineable(allTag) {
+			// No status can be shown that
+			// uses this tag, so no status
+			// can possibly match all tags.
+			return util.EmptyPageableResponse(), nil
+		}
+		allTagIDs = append(allTagIDs, allTag.ID)
+		extraQueryParams = append(extraQueryParams, "all[]="+url.QueryEscape(allTagNames[i]))
 	}
 
-	var (
-		items = make([]interface{}, 0, count)
+	noneTagIDs := make([]string, 0, len(noneTags))
+	for i, noneTag := range noneTags {
+		if noneTag != nil {
+			noneTagIDs = append(noneTagIDs, noneTag.ID)
+		}
+		extraQueryParams = append(extraQueryParams, "none[]="+url.QueryEscape(noneTagNames[i]))
+	}
 
-		// Set next + prev values before filtering and API
-		// converting, so caller can still page properly.
-		nextMaxIDValue = statuses[count-1].ID
-		prevMinIDValue = statuses[0].ID
+	const maxAttempts = 3
+	var (
+		nextMaxIDValue string
+		prevMinIDValue string
+		items          = make([]any, 0, limit)
 	)
 
 	filters, err := p.state.DB.GetFiltersForAccountID(ctx, requestingAcct.ID)
@@ -118,34 +128,161 @@ func (p *Processor) packageTagResponse(
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	for _, s := range statuses {
-		timelineable, err := p.filter.StatusTagTimelineable(ctx, requestingAcct, s)
-		if err != nil {
-			log.Errorf(ctx, "error checking status visibility: %v", err)
-			continue
+	// Try a few times to select appropriate tagged
+	// statuses from the db, paging up or down to
+	// reattempt if nothing suitable is found.
+outer:
+	for attempts := 1; ; attempts++ {
+		// Select slightly more than the limit to try to avoid situations where
+		// we filter out all the entries, and have to make another db call.
+		// It's cheaper to select more in 1 query than it is to do multiple queries.
+		statuses, err := p.state.DB.GetTagTimeline(ctx,
+			anyTagIDs,
+			allTagIDs,
+			noneTagIDs,
+			maxID,
+			sinceID,
+			minID,
+			limit+5,
+			local,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err = gtserror.Newf("db error getting statuses: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		count := len(statuses)
+		if count == 0 {
+			// Nothing relevant (left) in the db.
+			return util.EmptyPageableResponse(), nil
 		}
 
-		if !timelineable {
-			continue
+		// Page up from first status in slice
+		// (ie., one with the highest ID).
+		prevMinIDValue = statuses[0].ID
+
+	inner:
+		for _, s := range statuses {
+			// Push back the next page down ID to
+			// this status, regardless of whether
+			// we end up filtering it out or not.
+			nextMaxIDValue = s.ID
+
+			timelineable, err := p.filter.StatusTagTimelineable(ctx, requestingAcct, s)
+			if err != nil {
+				log.Errorf(ctx, "error checking status visibility: %v", err)
+				continue inner
+			}
+
+			if !timelineable {
+				continue inner
+			}
+
+			apiStatus, err := p.converter.StatusToAPIStatus(ctx, s, requestingAcct, statusfilter.FilterContextPublic, filters)
+			if errors.Is(err, statusfilter.ErrHideStatus) {
+				continue
+			}
+			if err != nil {
+				log.Errorf(ctx, "error converting to api status: %v", err)
+				continue inner
+			}
+
+			// Looks good, add this.
+			items = append(items, apiStatus)
+
+			// We called the db with a little
+			// more than the desired limit.
+			//
+			// Ensure we don't return more
+			// than the caller asked for.
+			if len(items) == limit {
+				break outer
+			}
 		}
 
-		apiStatus, err := p.converter.StatusToAPIStatus(ctx, s, requestingAcct, statusfilter.FilterContextPublic, filters)
-		if errors.Is(err, statusfilter.ErrHideStatus) {
-			continue
+		if len(items) != 0 {
+			// We've got some items left after
+			// filtering, happily break + return.
+			break
 		}
-		if err != nil {
-			log.Errorf(ctx, "error converting to api status: %v", err)
-			continue
+
+		if attempts >= maxAttempts {
+			// We reached our attempts limit.
+			// Be nice + warn about it.
+			log.Warn(ctx, "reached max attempts to find items in tag timeline")
+			break
 		}
 
-		items = append(items, apiStatus)
+		// We filtered out all items before we
+		// found anything we could return, but
+		// we still have attempts left to try
+		// fetching again. Set paging params
+		// and allow loop to continue.
+		if minID != "" {
+			// Paging up.
+			minID = prevMinIDValue
+		} else {
+			// Paging down.
+			maxID = nextMaxIDValue
+		}
 	}
 
 	return util.PackagePageableResponse(util.PageableResponseParams{
-		Items:          items,
-		Path:           requestPath,
-		NextMaxIDValue: nextMaxIDValue,
-		PrevMinIDValue: prevMinIDValue,
-		Limit:          limit,
+		Items: items,
+		// Use API URL for tag.
+		Path:             "/api/v1/timelines/tag/" + tagName,
+		NextMaxIDValue:   nextMaxIDValue,
+		PrevMinIDValue:   prevMinIDValue,
+		Limit:            limit,
+		ExtraQueryParams: extraQueryParams,
 	})
 }
+
+// tagTimelineable returns whether the given
+// tag exists, and is useable and listable.
+func tagTimelineable(tag *gtsmodel.Tag) bool {
+	return tag != nil && *tag.Useable && *tag.Listable
+}
+
+// maxExtraTags is the maximum number of tags that
+// may be given for each of any[], all[] and none[],
+// to keep tag timeline queries reasonably cheap.
+const maxExtraTags = 4
+
+// getTags gets the tags with the given names, in order,
+// with a nil entry for each tag that isn't in the db.
+func (p *Processor) getTags(ctx context.Context, tagNames []string) ([]*gtsmodel.Tag, gtserror.WithCode) {
+	if len(tagNames) > maxExtraTags {
+		err := fmt.Errorf("too many tags provided, maximum is %d", maxExtraTags)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	tags := make([]*gtsmodel.Tag, len(tagNames))
+	for i, tagName := range tagNames {
+		tag, errWithCode := p.getTag(ctx, tagName)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		tags[i] = tag
+	}
+	return tags, nil
+}
+
+func (p *Processor) getTag(ctx context.Context, tagName string) (*gtsmodel.Tag, gtserror.WithCode) {
+	// Normalize + validate tag name.
+	tagNameNormal, ok := text.NormalizeHashtag(tagName)
+	if !ok {
+		err := gtserror.Newf("string '%s' could not be normalized to a valid hashtag", tagName)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Ensure we have tag with this name in the db.
+	tag, err := p.state.DB.GetTagByName(ctx, tagNameNormal)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		// Real db error.
+		err = gtserror.Newf("db error getting tag by name: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return tag, nil
+}
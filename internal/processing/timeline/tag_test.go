@@ -0,0 +1,126 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package timeline_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TagTestSuite struct {
+	TimelineStandardTestSuite
+}
+
+func (suite *TagTestSuite) TestTagTimelineGetAny() {
+	var (
+		ctx       = context.Background()
+		requester = suite.testAccounts["local_account_1"]
+	)
+
+	resp, errWithCode := suite.timeline.TagTimelineGet(
+		ctx,
+		requester,
+		"hashtag",
+		[]string{"welcome"},
+		nil,
+		nil,
+		"",
+		"",
+		"",
+		1,
+		false,
+	)
+
+	// Admin's #welcome status should
+	// be returned via the any[] tag,
+	// and kept in the paging links.
+	suite.NoError(errWithCode)
+	suite.Len(resp.Items, 1)
+	suite.Equal(`<http://localhost:8080/api/v1/timelines/tag/hashtag?limit=1&max_id=01F8MH75CBF9JFX4ZAD54N0W0R&local=false&any[]=welcome>; rel="next", <http://localhost:8080/api/v1/timelines/tag/hashtag?limit=1&min_id=01F8MH75CBF9JFX4ZAD54N0W0R&local=false&any[]=welcome>; rel="prev"`, resp.LinkHeader)
+}
+
+func (suite *TagTestSuite) TestTagTimelineGetAllNone() {
+	var (
+		ctx       = context.Background()
+		requester = suite.testAccounts["local_account_1"]
+	)
+
+	// Unknown tag in all[], so
+	// nothing can possibly match.
+	resp, errWithCode := suite.timeline.TagTimelineGet(
+		ctx,
+		requester,
+		"welcome",
+		nil,
+		[]string{"nonexistenttag"},
+		nil,
+		"",
+		"",
+		"",
+		20,
+		false,
+	)
+	suite.NoError(errWithCode)
+	suite.Empty(resp.Items)
+
+	// Status excluded by none[].
+	resp, errWithCode = suite.timeline.TagTimelineGet(
+		ctx,
+		requester,
+		"welcome",
+		nil,
+		nil,
+		[]string{"welcome"},
+		"",
+		"",
+		"",
+		20,
+		false,
+	)
+	suite.NoError(errWithCode)
+	suite.Empty(resp.Items)
+}
+
+func (suite *TagTestSuite) TestTagTimelineGetTooManyTags() {
+	var (
+		ctx       = context.Background()
+		requester = suite.testAccounts["local_account_1"]
+	)
+
+	_, errWithCode := suite.timeline.TagTimelineGet(
+		ctx,
+		requester,
+		"welcome",
+		[]string{"one", "two", "three", "four", "five"},
+		nil,
+		nil,
+		"",
+		"",
+		"",
+		20,
+		false,
+	)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func TestTagTestSuite(t *testing.T) {
+	suite.Run(t, new(TagTestSuite))
+}
@@ -0,0 +1,82 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends
+
+import (
+	"context"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+const (
+	exploreStatusesLimit = 20
+	exploreTagsLimit     = 10
+	exploreAccountsLimit = 10
+)
+
+// WebExploreGet returns the trending posts and hashtags
+// of the last week, along with some suggested accounts,
+// for showing on the public web explore page.
+func (p *Processor) WebExploreGet(ctx context.Context) (*apimodel.Explore, gtserror.WithCode) {
+	tags, errWithCode := p.TagsGet(ctx, exploreTagsLimit)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	statuses, err := p.state.DB.GetTrendingStatuses(ctx, trendsSince(), exploreStatusesLimit)
+	if err != nil {
+		err := gtserror.Newf("db error getting trending statuses: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	webStatuses := make([]*apimodel.Status, 0, len(statuses))
+	for _, status := range statuses {
+		webStatus, err := p.converter.StatusToWebStatus(ctx, status, nil)
+		if err != nil {
+			log.Errorf(ctx, "error converting status %s to web status: %v", status.ID, err)
+			continue
+		}
+
+		webStatuses = append(webStatuses, webStatus)
+	}
+
+	accounts, err := p.state.DB.GetSuggestedAccounts(ctx, exploreAccountsLimit)
+	if err != nil {
+		err := gtserror.Newf("db error getting suggested accounts: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiAccounts := make([]*apimodel.Account, 0, len(accounts))
+	for _, account := range accounts {
+		apiAccount, err := p.converter.AccountToAPIAccountPublic(ctx, account)
+		if err != nil {
+			log.Errorf(ctx, "error converting account %s to api account: %v", account.ID, err)
+			continue
+		}
+
+		apiAccounts = append(apiAccounts, apiAccount)
+	}
+
+	return &apimodel.Explore{
+		Statuses: webStatuses,
+		Tags:     tags,
+		Accounts: apiAccounts,
+	}, nil
+}
@@ -0,0 +1,61 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// TagsGet returns up to limit hashtags most used by local
+// statuses over the last week, along with their usage history.
+func (p *Processor) TagsGet(ctx context.Context, limit int) ([]*apimodel.Tag, gtserror.WithCode) {
+	tags, err := p.state.DB.GetTrendingTags(ctx, trendsSince(), limit)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting trending tags: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiTags := make([]*apimodel.Tag, 0, len(tags))
+	for _, tag := range tags {
+		apiTag, err := p.converter.TagToAPITag(ctx, tag, true)
+		if err != nil {
+			log.Errorf(ctx, "error converting tag %s to api tag: %v", tag.ID, err)
+			continue
+		}
+
+		apiTags = append(apiTags, &apiTag)
+	}
+
+	return apiTags, nil
+}
+
+// trendsSince returns the start of the (UTC) day six
+// days ago, so that trends cover a whole week, today
+// included.
+func trendsSince() time.Time {
+	return time.Now().UTC().
+		Truncate(24*time.Hour).
+		AddDate(0, 0, -6)
+}
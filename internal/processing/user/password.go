@@ -19,7 +19,11 @@ package user
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/validate"
@@ -68,3 +72,96 @@ func (p *Processor) PasswordChange(ctx context.Context, user *gtsmodel.User, old
 
 	return nil
 }
+
+// PasswordResetGetUser retrieves the user (with account) from
+// the database for the given "reset your password" token string.
+func (p *Processor) PasswordResetGetUser(ctx context.Context, token string) (*gtsmodel.User, gtserror.WithCode) {
+	if token == "" {
+		err := errors.New("no token provided")
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	user, err := p.state.DB.GetUserByResetPasswordToken(ctx, token)
+	if err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			// Real error.
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		// No user found for this token.
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if user.Account == nil {
+		user.Account, err = p.state.DB.GetAccountByID(ctx, user.AccountID)
+		if err != nil {
+			// We need the account for a local user.
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	if !user.Account.SuspendedAt.IsZero() {
+		err := fmt.Errorf("account %s is suspended", user.AccountID)
+		return nil, gtserror.NewErrorForbidden(err, err.Error())
+	}
+
+	// Ensure token not expired.
+	const oneDay = 24 * time.Hour
+	if user.ResetPasswordSentAt.Before(time.Now().Add(-oneDay)) {
+		err := errors.New("password reset token expired (older than one day)")
+		return nil, gtserror.NewErrorForbidden(err, err.Error())
+	}
+
+	return user, nil
+}
+
+// PasswordReset processes a password reset request,
+// usually initiated as a result of clicking on a link
+// generated by the "admin account password-reset" command.
+//
+// The token is single use, and is cleared from
+// the user once the new password has been set.
+func (p *Processor) PasswordReset(ctx context.Context, token string, newPassword string) (*gtsmodel.User, gtserror.WithCode) {
+	user, errWithCode := p.PasswordResetGetUser(ctx, token)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Ensure new password is strong enough.
+	if err := validate.Password(newPassword); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Hash the new password.
+	encryptedPassword, err := bcrypt.GenerateFromPassword(
+		[]byte(newPassword),
+		bcrypt.DefaultCost,
+	)
+	if err != nil {
+		err := gtserror.Newf("%w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Set new password on user,
+	// and remove the used token.
+	user.EncryptedPassword = string(encryptedPassword)
+	user.ResetPasswordToken = ""
+	user.ResetPasswordSentAt = time.Time{}
+
+	if err := p.state.DB.UpdateUser(
+		ctx, user,
+		"encrypted_password",
+		"reset_password_token",
+		"reset_password_sent_at",
+	); err != nil {
+		err := gtserror.Newf("db error updating user: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// The updated user no longer has a token, so it won't be
+	// stored under this token key; make sure the previously
+	// cached copy doesn't linger there and allow token reuse.
+	p.state.Caches.GTS.User.Invalidate("ResetPasswordToken", token)
+
+	return user, nil
+}
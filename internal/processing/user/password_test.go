@@ -21,6 +21,7 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -86,6 +87,63 @@ func (suite *ChangePasswordTestSuite) TestChangePasswordWeakNew() {
 	suite.NoError(err)
 }
 
+func (suite *ChangePasswordTestSuite) TestPasswordReset() {
+	ctx := context.Background()
+
+	user := suite.testUsers["local_account_1"]
+
+	// set a reset token on the user as though it was generated 5 minutes ago
+	user.ResetPasswordToken = "0c4d0a83-5b3e-4c2e-9a27-7a1d3f3c9c1e"
+	user.ResetPasswordSentAt = time.Now().Add(-5 * time.Minute)
+	err := suite.db.UpdateUser(ctx, user, "reset_password_token", "reset_password_sent_at")
+	suite.NoError(err)
+
+	// a weak password should be rejected, leaving the token intact
+	_, errWithCode := suite.user.PasswordReset(ctx, "0c4d0a83-5b3e-4c2e-9a27-7a1d3f3c9c1e", "1234")
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	updatedUser, errWithCode := suite.user.PasswordReset(ctx, "0c4d0a83-5b3e-4c2e-9a27-7a1d3f3c9c1e", "verygoodnewpassword")
+	suite.NoError(errWithCode)
+	suite.Empty(updatedUser.ResetPasswordToken)
+	suite.True(updatedUser.ResetPasswordSentAt.IsZero())
+
+	// get user from the db again
+	dbUser, err := suite.db.GetUserByID(ctx, user.ID)
+	suite.NoError(err)
+
+	// check the password has changed
+	err = bcrypt.CompareHashAndPassword([]byte(dbUser.EncryptedPassword), []byte("verygoodnewpassword"))
+	suite.NoError(err)
+
+	// the token should not be usable a second time
+	_, errWithCode = suite.user.PasswordReset(ctx, "0c4d0a83-5b3e-4c2e-9a27-7a1d3f3c9c1e", "anotherverygoodnewpassword")
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *ChangePasswordTestSuite) TestPasswordResetOldToken() {
+	ctx := context.Background()
+
+	user := suite.testUsers["local_account_1"]
+
+	// set a reset token on the user as though it was generated 2 days ago
+	user.ResetPasswordToken = "0c4d0a83-5b3e-4c2e-9a27-7a1d3f3c9c1e"
+	user.ResetPasswordSentAt = time.Now().Add(-48 * time.Hour)
+	err := suite.db.UpdateUser(ctx, user, "reset_password_token", "reset_password_sent_at")
+	suite.NoError(err)
+
+	updatedUser, errWithCode := suite.user.PasswordReset(ctx, "0c4d0a83-5b3e-4c2e-9a27-7a1d3f3c9c1e", "verygoodnewpassword")
+	suite.Nil(updatedUser)
+	suite.EqualError(errWithCode, "password reset token expired (older than one day)")
+
+	// get user from the db again
+	dbUser, err := suite.db.GetUserByID(ctx, user.ID)
+	suite.NoError(err)
+
+	// check the password has not changed
+	err = bcrypt.CompareHashAndPassword([]byte(dbUser.EncryptedPassword), []byte("password"))
+	suite.NoError(err)
+}
+
 func TestChangePasswordTestSuite(t *testing.T) {
 	suite.Run(t, &ChangePasswordTestSuite{})
 }
@@ -0,0 +1,112 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// TermsGet returns the current version of the instance
+// terms, and whether the given user has accepted it.
+func (p *Processor) TermsGet(ctx context.Context, user *gtsmodel.User) (*apimodel.Terms, gtserror.WithCode) {
+	version, errWithCode := p.currentTermsVersion(ctx)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return termsToAPITerms(version, user), nil
+}
+
+// TermsAccept marks the version of the instance terms with the given
+// ID as accepted by the given user. Only the current version of the
+// terms can be accepted, so that users can't unknowingly accept terms
+// that changed after they were shown to them.
+func (p *Processor) TermsAccept(ctx context.Context, user *gtsmodel.User, versionID string) (*apimodel.Terms, gtserror.WithCode) {
+	version, errWithCode := p.currentTermsVersion(ctx)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if versionID != version.ID {
+		err := fmt.Errorf("terms version %s is not the current version of the terms", versionID)
+		return nil, gtserror.NewErrorConflict(err, err.Error())
+	}
+
+	if user.TermsAcceptedVersionID != version.ID {
+		user.TermsAcceptedVersionID = version.ID
+		user.TermsAcceptedAt = time.Now()
+		if err := p.state.DB.UpdateUser(
+			ctx, user,
+			"terms_accepted_version_id",
+			"terms_accepted_at",
+		); err != nil {
+			err := gtserror.Newf("db error updating user: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return termsToAPITerms(version, user), nil
+}
+
+// currentTermsVersion gets the current version of the
+// instance terms, returning 404 if there's none.
+func (p *Processor) currentTermsVersion(ctx context.Context) (*gtsmodel.TermsVersion, gtserror.WithCode) {
+	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		err := gtserror.Newf("db error getting instance: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if instance.TermsVersionID == "" {
+		const text = "this instance has no terms"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	version, err := p.state.DB.GetTermsVersionByID(ctx, instance.TermsVersionID)
+	if err != nil {
+		err := gtserror.Newf("db error getting terms version %s: %w", instance.TermsVersionID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return version, nil
+}
+
+func termsToAPITerms(version *gtsmodel.TermsVersion, user *gtsmodel.User) *apimodel.Terms {
+	terms := &apimodel.Terms{
+		ID:        version.ID,
+		CreatedAt: util.FormatISO8601(version.CreatedAt),
+		Content:   version.Terms,
+		Accepted:  user.TermsAcceptedVersionID == version.ID,
+	}
+
+	if !user.TermsAcceptedAt.IsZero() {
+		acceptedAt := util.FormatISO8601(user.TermsAcceptedAt)
+		terms.AcceptedAt = &acceptedAt
+	}
+
+	return terms
+}
@@ -0,0 +1,107 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type TermsTestSuite struct {
+	UserStandardTestSuite
+}
+
+// putTerms stores a new version of the instance
+// terms, and makes it the current version.
+func (suite *TermsTestSuite) putTerms(ctx context.Context, id string) {
+	version := &gtsmodel.TermsVersion{
+		ID:        id,
+		Terms:     "<p>Be nice to each other.</p>",
+		TermsText: "Be nice to each other.",
+	}
+	if err := suite.db.PutTermsVersion(ctx, version); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	instance, err := suite.db.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	instance.TermsVersionID = version.ID
+	if err := suite.db.UpdateInstance(ctx, instance, "terms_version_id"); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *TermsTestSuite) TestTermsGetNoTerms() {
+	user := suite.testUsers["local_account_1"]
+
+	_, errWithCode := suite.user.TermsGet(context.Background(), user)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+	suite.Equal("Not Found: this instance has no terms", errWithCode.Safe())
+}
+
+func (suite *TermsTestSuite) TestTermsAccept() {
+	var (
+		ctx  = context.Background()
+		user = suite.testUsers["local_account_1"]
+	)
+
+	suite.putTerms(ctx, "01HXRA4ZVX0ZB2SNAJ0BSD0K2A")
+
+	terms, errWithCode := suite.user.TermsGet(ctx, user)
+	suite.NoError(errWithCode)
+	suite.Equal("01HXRA4ZVX0ZB2SNAJ0BSD0K2A", terms.ID)
+	suite.Equal("<p>Be nice to each other.</p>", terms.Content)
+	suite.False(terms.Accepted)
+	suite.Nil(terms.AcceptedAt)
+
+	// Accepting a version that isn't current should fail.
+	_, errWithCode = suite.user.TermsAccept(ctx, user, "01HXRA3B5A4YQZ5Y0VBF9PMJ8T")
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+
+	terms, errWithCode = suite.user.TermsAccept(ctx, user, "01HXRA4ZVX0ZB2SNAJ0BSD0K2A")
+	suite.NoError(errWithCode)
+	suite.True(terms.Accepted)
+	suite.NotNil(terms.AcceptedAt)
+
+	// Acceptance should be stored.
+	dbUser, err := suite.db.GetUserByID(ctx, user.ID)
+	suite.NoError(err)
+	suite.Equal("01HXRA4ZVX0ZB2SNAJ0BSD0K2A", dbUser.TermsAcceptedVersionID)
+	suite.False(dbUser.TermsAcceptedAt.IsZero())
+
+	// New terms should need accepting again.
+	suite.putTerms(ctx, "01HXRA6WJ1H5YBZ4RZ1N8WZ3QX")
+
+	terms, errWithCode = suite.user.TermsGet(ctx, dbUser)
+	suite.NoError(errWithCode)
+	suite.Equal("01HXRA6WJ1H5YBZ4RZ1N8WZ3QX", terms.ID)
+	suite.False(terms.Accepted)
+	suite.NotNil(terms.AcceptedAt)
+}
+
+func TestTermsTestSuite(t *testing.T) {
+	suite.Run(t, &TermsTestSuite{})
+}
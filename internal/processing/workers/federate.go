@@ -25,10 +25,12 @@ import (
 	"github.com/superseriousbusiness/activity/streams"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
 // federate wraps functions for federating
@@ -286,28 +288,29 @@ func (f *federate) UpdateStatus(ctx context.Context, status *gtsmodel.Status) er
 	return nil
 }
 
-func (f *federate) Follow(ctx context.Context, follow *gtsmodel.Follow) error {
+func (f *federate) Follow(ctx context.Context, followReq *gtsmodel.FollowRequest) error {
 	// Populate model.
-	if err := f.state.DB.PopulateFollow(ctx, follow); err != nil {
-		return gtserror.Newf("error populating follow: %w", err)
+	if err := f.state.DB.PopulateFollowRequest(ctx, followReq); err != nil {
+		return gtserror.Newf("error populating follow request: %w", err)
 	}
 
 	// Do nothing if both accounts are local.
-	if follow.Account.IsLocal() &&
-		follow.TargetAccount.IsLocal() {
+	if followReq.Account.IsLocal() &&
+		followReq.TargetAccount.IsLocal() {
 		return nil
 	}
 
 	// Parse relevant URI(s).
-	outboxIRI, err := parseURI(follow.Account.OutboxURI)
+	outboxIRI, err := parseURI(followReq.Account.OutboxURI)
 	if err != nil {
 		return err
 	}
 
-	// Convert follow to ActivityStreams Follow.
-	asFollow, err := f.converter.FollowToAS(ctx, follow)
+	// Convert follow request to ActivityStreams Follow
+	// (requests are sent as follows, including any note).
+	asFollow, err := f.converter.FollowRequestToAS(ctx, followReq)
 	if err != nil {
-		return gtserror.Newf("error converting follow to AS: %s", err)
+		return gtserror.Newf("error converting follow request to AS: %s", err)
 	}
 
 	// Send the Follow via the Actor's outbox.
@@ -672,6 +675,125 @@ func (f *federate) RejectFollow(ctx context.Context, follow *gtsmodel.Follow) er
 	return nil
 }
 
+// AcceptInteraction sends an Accept of the interaction (reply or announce)
+// with the given URI by the given remote account, targeting the given
+// local status, when the interaction was held pending approval.
+func (f *federate) AcceptInteraction(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	interacting *gtsmodel.Account,
+	interactionURI string,
+) error {
+	return f.respondToInteraction(ctx,
+		streams.NewActivityStreamsAccept(),
+		status,
+		interacting,
+		interactionURI,
+	)
+}
+
+// RejectInteraction sends a Reject of the interaction (reply, announce
+// or like) with the given URI by the given remote account, targeting the
+// given local status, when the status interaction policy doesn't permit it.
+func (f *federate) RejectInteraction(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	interacting *gtsmodel.Account,
+	interactionURI string,
+) error {
+	return f.respondToInteraction(ctx,
+		streams.NewActivityStreamsReject(),
+		status,
+		interacting,
+		interactionURI,
+	)
+}
+
+// respondToInteraction sends the given response activity (Accept
+// or Reject) of the interaction with the given URI by the given
+// remote account, from the author of the given local status.
+func (f *federate) respondToInteraction(
+	ctx context.Context,
+	response ap.Activityable,
+	status *gtsmodel.Status,
+	interacting *gtsmodel.Account,
+	interactionURI string,
+) error {
+	// Bail if interacting account is ours:
+	// interactions from our own accounts
+	// are checked before they're created.
+	if interacting.IsLocal() {
+		return nil
+	}
+
+	// Ensure status author populated.
+	if status.Account == nil {
+		var err error
+		status.Account, err = f.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			status.AccountID,
+		)
+		if err != nil {
+			return gtserror.Newf("error getting status author: %w", err)
+		}
+	}
+
+	// Bail if status author isn't ours:
+	// we can't respond to an interaction
+	// on another instance's behalf.
+	if status.Account.IsRemote() {
+		return nil
+	}
+
+	// Parse relevant URI(s).
+	outboxIRI, err := parseURI(status.Account.OutboxURI)
+	if err != nil {
+		return err
+	}
+
+	respondingAccountIRI, err := parseURI(status.Account.URI)
+	if err != nil {
+		return err
+	}
+
+	interactingAccountIRI, err := parseURI(interacting.URI)
+	if err != nil {
+		return err
+	}
+
+	interactionIRI, err := parseURI(interactionURI)
+	if err != nil {
+		return err
+	}
+
+	// Set the status author as Actor of the response.
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorProp.AppendIRI(respondingAccountIRI)
+	response.SetActivityStreamsActor(actorProp)
+
+	// Set the interaction IRI as the 'object' property.
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendIRI(interactionIRI)
+	response.SetActivityStreamsObject(objectProp)
+
+	// Address the response To the interacting account.
+	toProp := streams.NewActivityStreamsToProperty()
+	toProp.AppendIRI(interactingAccountIRI)
+	response.SetActivityStreamsTo(toProp)
+
+	// Send the response via the Actor's outbox.
+	if _, err := f.FederatingActor().Send(
+		ctx, outboxIRI, response,
+	); err != nil {
+		return gtserror.Newf(
+			"error sending activity %T via outbox %s: %w",
+			response, outboxIRI, err,
+		)
+	}
+
+	return nil
+}
+
 func (f *federate) Like(ctx context.Context, fave *gtsmodel.StatusFave) error {
 	// Populate model.
 	if err := f.state.DB.PopulateStatusFave(ctx, fave); err != nil {
@@ -709,6 +831,102 @@ func (f *federate) Like(ctx context.Context, fave *gtsmodel.StatusFave) error {
 	return nil
 }
 
+func (f *federate) EmojiReact(ctx context.Context, reaction *gtsmodel.StatusReaction) error {
+	// Populate model.
+	if err := f.state.DB.PopulateStatusReaction(ctx, reaction); err != nil {
+		return gtserror.Newf("error populating reaction: %w", err)
+	}
+
+	// Do nothing if both accounts are local.
+	if reaction.Account.IsLocal() &&
+		reaction.TargetAccount.IsLocal() {
+		return nil
+	}
+
+	// Parse relevant URI(s).
+	outboxIRI, err := parseURI(reaction.Account.OutboxURI)
+	if err != nil {
+		return err
+	}
+
+	// Create the ActivityStreams Like.
+	like, err := f.converter.ReactionToAS(ctx, reaction)
+	if err != nil {
+		return gtserror.Newf("error converting reaction to AS Like: %w", err)
+	}
+
+	// Send the Like via the Actor's outbox.
+	if _, err := f.FederatingActor().Send(
+		ctx, outboxIRI, like,
+	); err != nil {
+		return gtserror.Newf(
+			"error sending activity %T via outbox %s: %w",
+			like, outboxIRI, err,
+		)
+	}
+
+	return nil
+}
+
+func (f *federate) UndoEmojiReact(ctx context.Context, reaction *gtsmodel.StatusReaction) error {
+	// Populate model.
+	if err := f.state.DB.PopulateStatusReaction(ctx, reaction); err != nil {
+		return gtserror.Newf("error populating reaction: %w", err)
+	}
+
+	// Do nothing if both accounts are local.
+	if reaction.Account.IsLocal() &&
+		reaction.TargetAccount.IsLocal() {
+		return nil
+	}
+
+	// Parse relevant URI(s).
+	outboxIRI, err := parseURI(reaction.Account.OutboxURI)
+	if err != nil {
+		return err
+	}
+
+	targetAccountIRI, err := parseURI(reaction.TargetAccount.URI)
+	if err != nil {
+		return err
+	}
+
+	// Recreate the ActivityStreams Like.
+	like, err := f.converter.ReactionToAS(ctx, reaction)
+	if err != nil {
+		return gtserror.Newf("error converting reaction to AS: %w", err)
+	}
+
+	// Create a new Undo.
+	undo := streams.NewActivityStreamsUndo()
+
+	// Set the Actor for the Undo:
+	// same as the actor for the Like.
+	undo.SetActivityStreamsActor(like.GetActivityStreamsActor())
+
+	// Set recreated Like as the 'object' property.
+	undoObject := streams.NewActivityStreamsObjectProperty()
+	undoObject.AppendActivityStreamsLike(like)
+	undo.SetActivityStreamsObject(undoObject)
+
+	// Address the Undo To the target account.
+	undoTo := streams.NewActivityStreamsToProperty()
+	undoTo.AppendIRI(targetAccountIRI)
+	undo.SetActivityStreamsTo(undoTo)
+
+	// Send the Undo via the Actor's outbox.
+	if _, err := f.FederatingActor().Send(
+		ctx, outboxIRI, undo,
+	); err != nil {
+		return gtserror.Newf(
+			"error sending activity %T via outbox %s: %w",
+			undo, outboxIRI, err,
+		)
+	}
+
+	return nil
+}
+
 func (f *federate) Announce(ctx context.Context, boost *gtsmodel.Status) error {
 	// Populate model.
 	if err := f.state.DB.PopulateStatus(ctx, boost); err != nil {
@@ -1015,3 +1233,96 @@ func (f *federate) MoveAccount(ctx context.Context, account *gtsmodel.Account) e
 
 	return nil
 }
+
+// MoveUsername sends a Move from the given account's old actor
+// URI to its current one, after its username was changed with
+// the Move strategy. Remotes expect a Move to be signed by the
+// actor that's moving, so rather than going via the outbox, the
+// Move is delivered directly using the key ID of the old actor,
+// which we keep serving alongside the account's new actor.
+func (f *federate) MoveUsername(ctx context.Context, account *gtsmodel.Account, change *gtsmodel.UsernameChange) error {
+	// Do nothing if it's not our
+	// account that's been moved.
+	if !account.IsLocal() {
+		return nil
+	}
+
+	// Actor doing the Move.
+	actorIRI, err := parseURI(change.OldURI)
+	if err != nil {
+		return err
+	}
+
+	// The old actor's URIs are all
+	// based on the username in its URI.
+	oldUsername, err := uris.ParseUserPath(actorIRI)
+	if err != nil {
+		return gtserror.Newf("error parsing old uri %s: %w", change.OldURI, err)
+	}
+	oldURIs := uris.GenerateURIsForAccount(oldUsername)
+
+	// Destination Actor of the Move.
+	targetIRI, err := parseURI(account.URI)
+	if err != nil {
+		return err
+	}
+
+	followersIRI, err := parseURI(oldURIs.FollowersURI)
+	if err != nil {
+		return err
+	}
+
+	publicIRI, err := parseURI(pub.PublicActivityPubIRI)
+	if err != nil {
+		return err
+	}
+
+	// Create a new move.
+	move := streams.NewActivityStreamsMove()
+
+	// Set the Move ID.
+	if err := ap.SetJSONLDIdStr(
+		move,
+		uris.GenerateURIForMove(oldUsername, change.ID),
+	); err != nil {
+		return err
+	}
+
+	// Set the old actor as Actor and
+	// Object, and the current one as Target.
+	ap.AppendActorIRIs(move, actorIRI)
+	ap.AppendObjectIRIs(move, actorIRI)
+	ap.AppendTargetIRIs(move, targetIRI)
+
+	// Address the move To followers,
+	// and CC public.
+	ap.AppendTo(move, followersIRI)
+	ap.AppendCc(move, publicIRI)
+
+	data, err := ap.Serialize(move)
+	if err != nil {
+		return gtserror.Newf("error serializing move: %w", err)
+	}
+
+	// Old followers collection still
+	// resolves to the account's followers.
+	inboxes, err := f.FederatingDB().InboxesForIRI(ctx, followersIRI)
+	if err != nil {
+		return gtserror.Newf("error getting follower inboxes: %w", err)
+	}
+
+	if len(inboxes) == 0 {
+		// Nobody to tell.
+		return nil
+	}
+
+	tsport, err := f.TransportController().NewTransport(
+		oldURIs.PublicKeyURI,
+		account.PrivateKey,
+	)
+	if err != nil {
+		return gtserror.Newf("error creating transport: %w", err)
+	}
+
+	return tsport.BatchDeliver(ctx, data, inboxes)
+}
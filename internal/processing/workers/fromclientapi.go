@@ -56,7 +56,7 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, cMsg *messages.Fro
 
 	// Include GTSModel in logs if appropriate.
 	if cMsg.GTSModel != nil &&
-		log.Level() >= level.DEBUG {
+		log.CallerLevel() >= level.DEBUG {
 		fields = append(fields, kv.Field{
 			"model", cMsg.GTSModel,
 		})
@@ -94,6 +94,10 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, cMsg *messages.Fro
 		case ap.ActivityLike:
 			return p.clientAPI.CreateLike(ctx, cMsg)
 
+		// CREATE EMOJI REACTION
+		case ap.ActivityEmojiReact:
+			return p.clientAPI.CreateEmojiReact(ctx, cMsg)
+
 		// CREATE ANNOUNCE/BOOST
 		case ap.ActivityAnnounce:
 			return p.clientAPI.CreateAnnounce(ctx, cMsg)
@@ -122,12 +126,16 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, cMsg *messages.Fro
 
 	// ACCEPT SOMETHING
 	case ap.ActivityAccept:
-		switch cMsg.APObjectType { //nolint:gocritic
+		switch cMsg.APObjectType {
 
 		// ACCEPT FOLLOW (request)
 		case ap.ActivityFollow:
 			return p.clientAPI.AcceptFollow(ctx, cMsg)
 
+		// ACCEPT NOTE/ANNOUNCE (pending interaction)
+		case ap.ObjectNote, ap.ActivityAnnounce:
+			return p.clientAPI.AcceptInteraction(ctx, cMsg)
+
 		// ACCEPT PROFILE/ACCOUNT (sign-up)
 		case ap.ObjectProfile, ap.ActorPerson:
 			return p.clientAPI.AcceptAccount(ctx, cMsg)
@@ -135,12 +143,16 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, cMsg *messages.Fro
 
 	// REJECT SOMETHING
 	case ap.ActivityReject:
-		switch cMsg.APObjectType { //nolint:gocritic
+		switch cMsg.APObjectType {
 
 		// REJECT FOLLOW (request)
 		case ap.ActivityFollow:
 			return p.clientAPI.RejectFollowRequest(ctx, cMsg)
 
+		// REJECT NOTE/ANNOUNCE (pending interaction)
+		case ap.ObjectNote, ap.ActivityAnnounce:
+			return p.clientAPI.RejectInteraction(ctx, cMsg)
+
 		// REJECT PROFILE/ACCOUNT (sign-up)
 		case ap.ObjectProfile, ap.ActorPerson:
 			return p.clientAPI.RejectAccount(ctx, cMsg)
@@ -162,6 +174,10 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, cMsg *messages.Fro
 		case ap.ActivityLike:
 			return p.clientAPI.UndoFave(ctx, cMsg)
 
+		// UNDO EMOJI REACTION
+		case ap.ActivityEmojiReact:
+			return p.clientAPI.UndoEmojiReact(ctx, cMsg)
+
 		// UNDO ANNOUNCE/BOOST
 		case ap.ActivityAnnounce:
 			return p.clientAPI.UndoAnnounce(ctx, cMsg)
@@ -195,6 +211,11 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, cMsg *messages.Fro
 
 		// MOVE PROFILE/ACCOUNT
 		case ap.ObjectProfile, ap.ActorPerson:
+			if _, ok := cMsg.GTSModel.(*gtsmodel.UsernameChange); ok {
+				// Move to a new username
+				// on the same account.
+				return p.clientAPI.MoveUsername(ctx, cMsg)
+			}
 			return p.clientAPI.MoveAccount(ctx, cMsg)
 		}
 	}
@@ -237,6 +258,16 @@ func (p *clientAPI) CreateStatus(ctx context.Context, cMsg *messages.FromClientA
 		log.Errorf(ctx, "error updating account stats: %v", err)
 	}
 
+	// Record hashtag use for trends.
+	if err := p.utils.incrementTagHistory(ctx, cMsg.Origin, status); err != nil {
+		log.Errorf(ctx, "error updating tag history: %v", err)
+	}
+
+	// Make status text searchable.
+	if err := p.utils.search.IndexStatus(ctx, status); err != nil {
+		log.Errorf(ctx, "error indexing status: %v", err)
+	}
+
 	if err := p.surface.timelineAndNotifyStatus(ctx, status); err != nil {
 		log.Errorf(ctx, "error timelining and notifying status: %v", err)
 	}
@@ -251,6 +282,15 @@ func (p *clientAPI) CreateStatus(ctx context.Context, cMsg *messages.FromClientA
 		log.Errorf(ctx, "error federating status: %v", err)
 	}
 
+	// Fetch a preview card for any link in the status.
+	if changed, err := p.federate.FetchStatusCard(ctx, "", status); err != nil {
+		log.Errorf(ctx, "error fetching status card: %v", err)
+	} else if changed {
+		// Card was added, uncache the prepared
+		// version without card from timelines.
+		p.surface.invalidateStatusFromTimelines(ctx, status.ID)
+	}
+
 	return nil
 }
 
@@ -310,12 +350,9 @@ func (p *clientAPI) CreateFollowReq(ctx context.Context, cMsg *messages.FromClie
 		log.Errorf(ctx, "error notifying follow request: %v", err)
 	}
 
-	// Convert the follow request to follow model (requests are sent as follows).
-	follow := p.converter.FollowRequestToFollow(ctx, followRequest)
-
 	if err := p.federate.Follow(
 		ctx,
-		follow,
+		followRequest,
 	); err != nil {
 		log.Errorf(ctx, "error federating follow request: %v", err)
 	}
@@ -349,6 +386,23 @@ func (p *clientAPI) CreateLike(ctx context.Context, cMsg *messages.FromClientAPI
 	return nil
 }
 
+func (p *clientAPI) CreateEmojiReact(ctx context.Context, cMsg *messages.FromClientAPI) error {
+	reaction, ok := cMsg.GTSModel.(*gtsmodel.StatusReaction)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.StatusReaction", cMsg.GTSModel)
+	}
+
+	// Interaction counts changed on the reacted-to status;
+	// uncache the prepared version from all timelines.
+	p.surface.invalidateStatusFromTimelines(ctx, reaction.StatusID)
+
+	if err := p.federate.EmojiReact(ctx, reaction); err != nil {
+		log.Errorf(ctx, "error federating emoji reaction: %v", err)
+	}
+
+	return nil
+}
+
 func (p *clientAPI) CreateAnnounce(ctx context.Context, cMsg *messages.FromClientAPI) error {
 	boost, ok := cMsg.GTSModel.(*gtsmodel.Status)
 	if !ok {
@@ -427,6 +481,16 @@ func (p *clientAPI) UpdateStatus(ctx context.Context, cMsg *messages.FromClientA
 		log.Errorf(ctx, "error federating status update: %v", err)
 	}
 
+	// Text may have changed, reindex status.
+	if err := p.utils.search.IndexStatus(ctx, status); err != nil {
+		log.Errorf(ctx, "error indexing status: %v", err)
+	}
+
+	// Links may have changed, update the preview card.
+	if _, err := p.federate.FetchStatusCard(ctx, "", status); err != nil {
+		log.Errorf(ctx, "error fetching status card: %v", err)
+	}
+
 	// Status representation has changed, invalidate from timelines.
 	p.surface.invalidateStatusFromTimelines(ctx, status.ID)
 
@@ -499,6 +563,14 @@ func (p *clientAPI) AcceptFollow(ctx context.Context, cMsg *messages.FromClientA
 		log.Errorf(ctx, "error updating account stats: %v", err)
 	}
 
+	if err := p.account.RecordFollowerEvent(ctx,
+		cMsg.Target,
+		follow.AccountID,
+		gtsmodel.FollowerEventFollow,
+	); err != nil {
+		log.Errorf(ctx, "error recording follower event: %v", err)
+	}
+
 	if err := p.surface.notifyFollow(ctx, follow); err != nil {
 		log.Errorf(ctx, "error notifying follow: %v", err)
 	}
@@ -531,6 +603,92 @@ func (p *clientAPI) RejectFollowRequest(ctx context.Context, cMsg *messages.From
 	return nil
 }
 
+func (p *clientAPI) AcceptInteraction(ctx context.Context, cMsg *messages.FromClientAPI) error {
+	req, ok := cMsg.GTSModel.(*gtsmodel.InteractionRequest)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.InteractionRequest", cMsg.GTSModel)
+	}
+
+	// Ensure interaction request populated.
+	if err := p.state.DB.PopulateInteractionRequest(ctx, req); err != nil {
+		return gtserror.Newf("error populating interaction request: %w", err)
+	}
+
+	if req.Interaction == nil {
+		// Interaction was deleted in
+		// the meantime, nothing to do.
+		return nil
+	}
+
+	// Show the interaction now it's approved.
+	req.Interaction.PendingApproval = util.Ptr(false)
+	if err := p.state.DB.UpdateStatus(ctx, req.Interaction, "pending_approval"); err != nil {
+		return gtserror.Newf("db error updating interaction %s: %w", req.InteractionID, err)
+	}
+
+	// Update stats for the interacting account.
+	if err := p.utils.incrementStatusesCount(ctx, req.InteractingAccount, req.Interaction); err != nil {
+		log.Errorf(ctx, "error updating account stats: %v", err)
+	}
+
+	// Timeline and notify the interaction.
+	if err := p.surface.timelineAndNotifyStatus(ctx, req.Interaction); err != nil {
+		log.Errorf(ctx, "error timelining and notifying status: %v", err)
+	}
+
+	if req.InteractionType == gtsmodel.InteractionAnnounce {
+		if err := p.surface.notifyAnnounce(ctx, req.Interaction); err != nil {
+			log.Errorf(ctx, "error notifying announce: %v", err)
+		}
+	}
+
+	// Interaction counts changed on the original status;
+	// uncache the prepared version from all timelines.
+	p.surface.invalidateStatusFromTimelines(ctx, req.StatusID)
+
+	if err := p.federate.AcceptInteraction(ctx,
+		req.Status,
+		req.InteractingAccount,
+		req.InteractionURI,
+	); err != nil {
+		log.Errorf(ctx, "error federating interaction accept: %v", err)
+	}
+
+	return nil
+}
+
+func (p *clientAPI) RejectInteraction(ctx context.Context, cMsg *messages.FromClientAPI) error {
+	req, ok := cMsg.GTSModel.(*gtsmodel.InteractionRequest)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.InteractionRequest", cMsg.GTSModel)
+	}
+
+	// Ensure interaction request populated.
+	if err := p.state.DB.PopulateInteractionRequest(ctx, req); err != nil {
+		return gtserror.Newf("error populating interaction request: %w", err)
+	}
+
+	if req.Interaction != nil {
+		// Wipe the rejected interaction.
+		if err := p.utils.wipeStatus(ctx,
+			req.Interaction,
+			req.InteractionType == gtsmodel.InteractionReply,
+		); err != nil {
+			log.Errorf(ctx, "error wiping rejected interaction: %v", err)
+		}
+	}
+
+	if err := p.federate.RejectInteraction(ctx,
+		req.Status,
+		req.InteractingAccount,
+		req.InteractionURI,
+	); err != nil {
+		log.Errorf(ctx, "error federating interaction reject: %v", err)
+	}
+
+	return nil
+}
+
 func (p *clientAPI) UndoFollow(ctx context.Context, cMsg *messages.FromClientAPI) error {
 	follow, ok := cMsg.GTSModel.(*gtsmodel.Follow)
 	if !ok {
@@ -547,6 +705,18 @@ func (p *clientAPI) UndoFollow(ctx context.Context, cMsg *messages.FromClientAPI
 		log.Errorf(ctx, "error updating account stats: %v", err)
 	}
 
+	// Only an undone follow has an ID
+	// set, not an undone follow request.
+	if follow.ID != "" {
+		if err := p.account.RecordFollowerEvent(ctx,
+			cMsg.Target,
+			follow.AccountID,
+			gtsmodel.FollowerEventUnfollow,
+		); err != nil {
+			log.Errorf(ctx, "error recording follower event: %v", err)
+		}
+	}
+
 	if err := p.federate.UndoFollow(ctx, follow); err != nil {
 		log.Errorf(ctx, "error federating follow undo: %v", err)
 	}
@@ -584,6 +754,23 @@ func (p *clientAPI) UndoFave(ctx context.Context, cMsg *messages.FromClientAPI)
 	return nil
 }
 
+func (p *clientAPI) UndoEmojiReact(ctx context.Context, cMsg *messages.FromClientAPI) error {
+	reaction, ok := cMsg.GTSModel.(*gtsmodel.StatusReaction)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.StatusReaction", cMsg.GTSModel)
+	}
+
+	// Interaction counts changed on the reacted-to status;
+	// uncache the prepared version from all timelines.
+	p.surface.invalidateStatusFromTimelines(ctx, reaction.StatusID)
+
+	if err := p.federate.UndoEmojiReact(ctx, reaction); err != nil {
+		log.Errorf(ctx, "error federating emoji reaction undo: %v", err)
+	}
+
+	return nil
+}
+
 func (p *clientAPI) UndoAnnounce(ctx context.Context, cMsg *messages.FromClientAPI) error {
 	status, ok := cMsg.GTSModel.(*gtsmodel.Status)
 	if !ok {
@@ -768,6 +955,22 @@ func (p *clientAPI) MoveAccount(ctx context.Context, cMsg *messages.FromClientAP
 	return nil
 }
 
+func (p *clientAPI) MoveUsername(ctx context.Context, cMsg *messages.FromClientAPI) error {
+	change, ok := cMsg.GTSModel.(*gtsmodel.UsernameChange)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.UsernameChange", cMsg.GTSModel)
+	}
+
+	// Local followers follow the account by
+	// ID, so only remote followers need to
+	// be told about the account's new URI.
+	if err := p.federate.MoveUsername(ctx, cMsg.Origin, change); err != nil {
+		return gtserror.Newf("error federating username move: %w", err)
+	}
+
+	return nil
+}
+
 func (p *clientAPI) AcceptAccount(ctx context.Context, cMsg *messages.FromClientAPI) error {
 	newUser, ok := cMsg.GTSModel.(*gtsmodel.User)
 	if !ok {
@@ -280,6 +280,80 @@ func (suite *FromClientAPITestSuite) TestProcessCreateStatusWithNotification() {
 	)
 }
 
+func (suite *FromClientAPITestSuite) TestProcessCreateStatusWithNewFromNotification() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	var (
+		ctx              = context.Background()
+		postingAccount   = suite.testAccounts["admin_account"]
+		receivingAccount = suite.testAccounts["local_account_1"]
+
+		// Admin account posts a new top-level
+		// status, after a long time away.
+		status = suite.newStatus(
+			ctx,
+			testStructs.State,
+			postingAccount,
+			gtsmodel.VisibilityPublic,
+			nil,
+			nil,
+		)
+	)
+	status.CreatedAt = time.Now()
+
+	// Receiving account doesn't have notify set on
+	// their follow, but does want to know when
+	// accounts post after 30 days of inactivity.
+	settings, err := testStructs.State.DB.GetAccountSettings(ctx, receivingAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	settings.NotifyNewFromDays = 30
+	if err := testStructs.State.DB.UpdateAccountSettings(ctx, settings, "notify_new_from_days"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Process the new status.
+	if err := testStructs.Processor.Workers().ProcessFromClientAPI(
+		ctx,
+		&messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityCreate,
+			GTSModel:       status,
+			Origin:         postingAccount,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Wait for a new_from notification to appear for the status.
+	if !testrig.WaitFor(func() bool {
+		_, err := testStructs.State.DB.GetNotification(
+			ctx,
+			gtsmodel.NotificationNewFrom,
+			receivingAccount.ID,
+			postingAccount.ID,
+			status.ID,
+		)
+		return err == nil
+	}) {
+		suite.FailNow("timed out waiting for new from notification")
+	}
+
+	// There should be no regular
+	// status notification though.
+	_, err = testStructs.State.DB.GetNotification(
+		ctx,
+		gtsmodel.NotificationStatus,
+		receivingAccount.ID,
+		postingAccount.ID,
+		status.ID,
+	)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
 func (suite *FromClientAPITestSuite) TestProcessCreateStatusReply() {
 	testStructs := suite.SetupTestStructs()
 	defer suite.TearDownTestStructs(testStructs)
@@ -345,6 +419,84 @@ func (suite *FromClientAPITestSuite) TestProcessCreateStatusReply() {
 	)
 }
 
+func (suite *FromClientAPITestSuite) TestProcessCreateStatusFollowedTag() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	var (
+		ctx              = context.Background()
+		postingAccount   = suite.testAccounts["admin_account"]
+		receivingAccount = suite.testAccounts["local_account_2"]
+		tag              = suite.testTags["welcome"]
+		streams          = suite.openStreams(ctx, testStructs.Processor, receivingAccount, nil)
+		homeStream       = streams[stream.TimelineHome]
+	)
+
+	// Turtle doesn't follow the admin
+	// account, but does follow #welcome.
+	if err := testStructs.State.DB.PutFollowedTag(ctx, &gtsmodel.FollowedTag{
+		ID:        id.NewULID(),
+		AccountID: receivingAccount.ID,
+		TagID:     tag.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	for _, test := range []struct {
+		visibility    gtsmodel.Visibility
+		expectMessage bool
+	}{
+		// Public post using the tag should be
+		// shown in the tag follower's home timeline.
+		{gtsmodel.VisibilityPublic, true},
+		// Unlisted post shouldn't, as it
+		// wouldn't show on the tag timeline.
+		{gtsmodel.VisibilityUnlocked, false},
+	} {
+		status := suite.newStatus(
+			ctx,
+			testStructs.State,
+			postingAccount,
+			test.visibility,
+			nil,
+			nil,
+		)
+		status.TagIDs = []string{tag.ID}
+		status.Tags = []*gtsmodel.Tag{tag}
+
+		// Process the new status.
+		if err := testStructs.Processor.Workers().ProcessFromClientAPI(
+			ctx,
+			&messages.FromClientAPI{
+				APObjectType:   ap.ObjectNote,
+				APActivityType: ap.ActivityCreate,
+				GTSModel:       status,
+				Origin:         postingAccount,
+			},
+		); err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		var statusJSON string
+		if test.expectMessage {
+			statusJSON = suite.statusJSON(
+				ctx,
+				testStructs.TypeConverter,
+				status,
+				receivingAccount,
+			)
+		}
+
+		// Check message in home stream.
+		suite.checkStreamed(
+			homeStream,
+			test.expectMessage,
+			statusJSON,
+			"",
+		)
+	}
+}
+
 func (suite *FromClientAPITestSuite) TestProcessCreateStatusReplyMuted() {
 	testStructs := suite.SetupTestStructs()
 	defer suite.TearDownTestStructs(testStructs)
@@ -685,6 +837,78 @@ func (suite *FromClientAPITestSuite) TestProcessCreateStatusReplyListRepliesPoli
 	)
 }
 
+func (suite *FromClientAPITestSuite) TestProcessCreateStatusListExclusive() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	// We're modifying the test list so take a copy.
+	testList := new(gtsmodel.List)
+	*testList = *suite.testLists["local_account_1_list_1"]
+
+	var (
+		ctx              = context.Background()
+		postingAccount   = suite.testAccounts["admin_account"]
+		receivingAccount = suite.testAccounts["local_account_1"]
+		streams          = suite.openStreams(ctx, testStructs.Processor, receivingAccount, []string{testList.ID})
+		homeStream       = streams[stream.TimelineHome]
+		listStream       = streams[stream.TimelineList+":"+testList.ID]
+
+		// Admin account posts a new top-level status.
+		status = suite.newStatus(
+			ctx,
+			testStructs.State,
+			postingAccount,
+			gtsmodel.VisibilityPublic,
+			nil,
+			nil,
+		)
+	)
+
+	// Make test list exclusive. Since admin is
+	// in the list, this means the status should
+	// be shown in the list but not in home.
+	testList.Exclusive = util.Ptr(true)
+	if err := testStructs.State.DB.UpdateList(ctx, testList, "exclusive"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Process the new status.
+	if err := testStructs.Processor.Workers().ProcessFromClientAPI(
+		ctx,
+		&messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityCreate,
+			GTSModel:       status,
+			Origin:         postingAccount,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	statusJSON := suite.statusJSON(
+		ctx,
+		testStructs.TypeConverter,
+		status,
+		receivingAccount,
+	)
+
+	// Check message NOT in home stream.
+	suite.checkStreamed(
+		homeStream,
+		false,
+		"",
+		"",
+	)
+
+	// Check message in list stream.
+	suite.checkStreamed(
+		listStream,
+		true,
+		statusJSON,
+		stream.EventTypeUpdate,
+	)
+}
+
 func (suite *FromClientAPITestSuite) TestProcessCreateStatusBoost() {
 	testStructs := suite.SetupTestStructs()
 	defer suite.TearDownTestStructs(testStructs)
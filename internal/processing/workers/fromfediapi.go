@@ -24,15 +24,18 @@ import (
 	"codeberg.org/gruf/go-kv"
 	"codeberg.org/gruf/go-logger/v2/level"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/federation/dereferencing"
-
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/account"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
@@ -40,11 +43,12 @@ import (
 // specifically for messages originating
 // from the federation/ActivityPub API.
 type fediAPI struct {
-	state    *state.State
-	surface  *Surface
-	federate *federate
-	account  *account.Processor
-	utils    *utils
+	state     *state.State
+	converter *typeutils.Converter
+	surface   *Surface
+	federate  *federate
+	account   *account.Processor
+	utils     *utils
 }
 
 func (p *Processor) ProcessFromFediAPI(ctx context.Context, fMsg *messages.FromFediAPI) error {
@@ -63,7 +67,7 @@ func (p *Processor) ProcessFromFediAPI(ctx context.Context, fMsg *messages.FromF
 
 	// Include GTSModel in logs if appropriate.
 	if fMsg.GTSModel != nil &&
-		log.Level() >= level.DEBUG {
+		log.CallerLevel() >= level.DEBUG {
 		fields = append(fields, kv.Field{
 			"model", fMsg.GTSModel,
 		})
@@ -72,6 +76,10 @@ func (p *Processor) ProcessFromFediAPI(ctx context.Context, fMsg *messages.FromF
 	l := log.WithContext(ctx).WithFields(fields...)
 	l.Info("processing from fedi API")
 
+	// Limit the remote fetches this activity can trigger
+	// while it's processed, anything beyond is deferred.
+	ctx = gtscontext.SetDerefBudget(ctx, config.GetAdvancedDereferenceBudget())
+
 	switch fMsg.APActivityType {
 
 	// CREATE SOMETHING
@@ -90,6 +98,10 @@ func (p *Processor) ProcessFromFediAPI(ctx context.Context, fMsg *messages.FromF
 		case ap.ActivityLike:
 			return p.fediAPI.CreateLike(ctx, fMsg)
 
+		// CREATE EMOJI REACTION
+		case ap.ActivityEmojiReact:
+			return p.fediAPI.CreateEmojiReact(ctx, fMsg)
+
 		// CREATE ANNOUNCE/BOOST
 		case ap.ActivityAnnounce:
 			return p.fediAPI.CreateAnnounce(ctx, fMsg)
@@ -142,6 +154,19 @@ func (p *Processor) ProcessFromFediAPI(ctx context.Context, fMsg *messages.FromF
 			return p.fediAPI.DeleteAccount(ctx, fMsg)
 		}
 
+	// UNDO SOMETHING
+	case ap.ActivityUndo:
+		switch fMsg.APObjectType {
+
+		// UNDO FOLLOW
+		case ap.ActivityFollow:
+			return p.fediAPI.UndoFollow(ctx, fMsg)
+
+		// UNDO EMOJI REACTION
+		case ap.ActivityEmojiReact:
+			return p.fediAPI.UndoEmojiReact(ctx, fMsg)
+		}
+
 	// MOVE SOMETHING
 	case ap.ActivityMove:
 
@@ -216,6 +241,65 @@ func (p *fediAPI) CreateStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 		return nil
 	}
 
+	// Make status text searchable.
+	if err := p.utils.search.IndexStatus(ctx, status); err != nil {
+		log.Errorf(ctx, "error indexing status: %v", err)
+	}
+
+	if status.InReplyToID != "" {
+		if status.InReplyTo == nil {
+			// Ensure the replied status is populated.
+			status.InReplyTo, err = p.state.DB.GetStatusByID(
+				gtscontext.SetBarebones(ctx),
+				status.InReplyToID,
+			)
+			if err != nil {
+				return gtserror.Newf("error getting replied status: %w", err)
+			}
+		}
+
+		// Check the reply is permitted by the
+		// replied status' interaction policy.
+		permitted, err := p.interactionPermitted(ctx,
+			status.InReplyTo,
+			status.Account,
+			gtsmodel.InteractionReply,
+		)
+		if err != nil {
+			return err
+		}
+
+		if !permitted {
+			// Hold the reply pending approval if possible.
+			held, err := p.holdInteraction(ctx,
+				status.InReplyTo,
+				status,
+				gtsmodel.InteractionReply,
+			)
+			if err != nil {
+				return err
+			}
+
+			if held {
+				// Timelining + notifying
+				// happens once approved.
+				return nil
+			}
+
+			// Wipe the reply and let the
+			// author know it was rejected.
+			if err := p.utils.wipeStatus(ctx, status, true); err != nil {
+				log.Errorf(ctx, "error wiping rejected reply: %v", err)
+			}
+
+			return p.federate.RejectInteraction(ctx,
+				status.InReplyTo,
+				status.Account,
+				status.URI,
+			)
+		}
+	}
+
 	// Update stats for the remote account.
 	if err := p.utils.incrementStatusesCount(ctx, fMsg.Requesting, status); err != nil {
 		log.Errorf(ctx, "error updating account stats: %v", err)
@@ -232,9 +316,114 @@ func (p *fediAPI) CreateStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 		log.Errorf(ctx, "error timelining and notifying status: %v", err)
 	}
 
+	// Fetch a preview card for any link in the status.
+	if changed, err := p.federate.FetchStatusCard(ctx, "", status); err != nil {
+		log.Errorf(ctx, "error fetching status card: %v", err)
+	} else if changed {
+		// Card was added, uncache the prepared
+		// version without card from timelines.
+		p.surface.invalidateStatusFromTimelines(ctx, status.ID)
+	}
+
 	return nil
 }
 
+// interactionPermitted returns whether the given interaction
+// by the given account is permitted by the interaction policy
+// of the given status. Interactions with remote statuses are
+// always permitted, as enforcing those is up to their origin.
+func (p *fediAPI) interactionPermitted(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	interacting *gtsmodel.Account,
+	interaction gtsmodel.InteractionType,
+) (bool, error) {
+	if !*status.Local {
+		return true, nil
+	}
+
+	permitted, err := p.surface.Filter.StatusInteractable(ctx,
+		interacting,
+		status,
+		interaction,
+	)
+	if err != nil {
+		return false, gtserror.Newf("error checking status interaction policy: %w", err)
+	}
+
+	return permitted, nil
+}
+
+// holdInteraction holds the given interaction (reply or boost) with
+// the given local status pending approval by the status author, if
+// the author's account is locked and the interacting account doesn't
+// follow them. It returns whether the interaction was held.
+func (p *fediAPI) holdInteraction(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	interaction *gtsmodel.Status,
+	interactionType gtsmodel.InteractionType,
+) (bool, error) {
+	if status.Account == nil {
+		// Ensure the status author is populated.
+		var err error
+		status.Account, err = p.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			status.AccountID,
+		)
+		if err != nil {
+			return false, gtserror.Newf("error getting status author: %w", err)
+		}
+	}
+
+	if !util.PtrValueOr(status.Account.Locked, false) {
+		// Only locked accounts
+		// approve interactions.
+		return false, nil
+	}
+
+	following, err := p.state.DB.IsFollowing(ctx,
+		interaction.AccountID,
+		status.AccountID,
+	)
+	if err != nil {
+		return false, gtserror.Newf("error checking follow: %w", err)
+	}
+
+	if following {
+		// Followers not permitted by the
+		// interaction policy are rejected.
+		return false, nil
+	}
+
+	// Hide the interaction until it's approved.
+	interaction.PendingApproval = util.Ptr(true)
+	if err := p.state.DB.UpdateStatus(ctx, interaction, "pending_approval"); err != nil {
+		return false, gtserror.Newf("error marking interaction pending approval: %w", err)
+	}
+
+	req := &gtsmodel.InteractionRequest{
+		ID:                   id.NewULID(),
+		StatusID:             status.ID,
+		Status:               status,
+		TargetAccountID:      status.AccountID,
+		TargetAccount:        status.Account,
+		InteractingAccountID: interaction.AccountID,
+		InteractingAccount:   interaction.Account,
+		InteractionType:      interactionType,
+		InteractionID:        interaction.ID,
+		Interaction:          interaction,
+		InteractionURI:       interaction.URI,
+	}
+
+	if err := p.state.DB.PutInteractionRequest(ctx, req); err != nil &&
+		!errors.Is(err, db.ErrAlreadyExists) {
+		return false, gtserror.Newf("error putting interaction request: %w", err)
+	}
+
+	return true, nil
+}
+
 func (p *fediAPI) CreatePollVote(ctx context.Context, fMsg *messages.FromFediAPI) error {
 	// Cast poll vote type from the worker message.
 	vote, ok := fMsg.GTSModel.(*gtsmodel.PollVote)
@@ -290,22 +479,39 @@ func (p *fediAPI) CreateFollowReq(ctx context.Context, fMsg *messages.FromFediAP
 		return gtserror.Newf("error populating follow request: %w", err)
 	}
 
+	if followRequest.TargetAccount.IsMoving() {
+		// A Moving account can't be followed,
+		// reject the request straight away so
+		// the requester isn't left hanging.
+		return p.rejectFollowRequest(ctx, followRequest)
+	}
+
 	if *followRequest.TargetAccount.Locked {
-		// Local account is locked: just notify the follow request.
-		if err := p.surface.notifyFollowRequest(ctx, followRequest); err != nil {
-			log.Errorf(ctx, "error notifying follow request: %v", err)
+		// Local account is locked, but the follow
+		// request may match one of its auto-approval
+		// rules, in which case accept it as below.
+		autoApprove, err := p.account.AutoApproveFollowRequest(ctx, followRequest)
+		if err != nil {
+			log.Errorf(ctx, "error checking follow request auto-approval: %v", err)
 		}
 
-		// And update stats for the local account.
-		if err := p.utils.incrementFollowRequestsCount(ctx, fMsg.Receiving); err != nil {
-			log.Errorf(ctx, "error updating account stats: %v", err)
-		}
+		if !autoApprove {
+			// Just notify the follow request.
+			if err := p.surface.notifyFollowRequest(ctx, followRequest); err != nil {
+				log.Errorf(ctx, "error notifying follow request: %v", err)
+			}
 
-		return nil
+			// And update stats for the local account.
+			if err := p.utils.incrementFollowRequestsCount(ctx, fMsg.Receiving); err != nil {
+				log.Errorf(ctx, "error updating account stats: %v", err)
+			}
+
+			return nil
+		}
 	}
 
-	// Local account is not locked:
-	// Automatically accept the follow request
+	// Local account is not locked, or the request
+	// was auto-approved: accept the follow request
 	// and notify about the new follower.
 	follow, err := p.state.DB.AcceptFollowRequest(
 		ctx,
@@ -326,6 +532,14 @@ func (p *fediAPI) CreateFollowReq(ctx context.Context, fMsg *messages.FromFediAP
 		log.Errorf(ctx, "error updating account stats: %v", err)
 	}
 
+	if err := p.account.RecordFollowerEvent(ctx,
+		fMsg.Receiving,
+		follow.AccountID,
+		gtsmodel.FollowerEventFollow,
+	); err != nil {
+		log.Errorf(ctx, "error recording follower event: %v", err)
+	}
+
 	if err := p.federate.AcceptFollow(ctx, follow); err != nil {
 		log.Errorf(ctx, "error federating follow request accept: %v", err)
 	}
@@ -337,6 +551,27 @@ func (p *fediAPI) CreateFollowReq(ctx context.Context, fMsg *messages.FromFediAP
 	return nil
 }
 
+// rejectFollowRequest removes the given incoming
+// follow request, and federates a Reject of it.
+func (p *fediAPI) rejectFollowRequest(ctx context.Context, followRequest *gtsmodel.FollowRequest) error {
+	if err := p.state.DB.RejectFollowRequest(
+		ctx,
+		followRequest.AccountID,
+		followRequest.TargetAccountID,
+	); err != nil {
+		return gtserror.Newf("error rejecting follow request: %w", err)
+	}
+
+	if err := p.federate.RejectFollow(
+		ctx,
+		p.converter.FollowRequestToFollow(ctx, followRequest),
+	); err != nil {
+		log.Errorf(ctx, "error federating follow reject: %v", err)
+	}
+
+	return nil
+}
+
 func (p *fediAPI) CreateLike(ctx context.Context, fMsg *messages.FromFediAPI) error {
 	fave, ok := fMsg.GTSModel.(*gtsmodel.StatusFave)
 	if !ok {
@@ -348,6 +583,31 @@ func (p *fediAPI) CreateLike(ctx context.Context, fMsg *messages.FromFediAPI) er
 		return gtserror.Newf("error populating status fave: %w", err)
 	}
 
+	// Check the like is permitted by the
+	// faved status' interaction policy.
+	permitted, err := p.interactionPermitted(ctx,
+		fave.Status,
+		fave.Account,
+		gtsmodel.InteractionLike,
+	)
+	if err != nil {
+		return err
+	}
+
+	if !permitted {
+		// Delete the fave and let
+		// the faver know it was rejected.
+		if err := p.state.DB.DeleteStatusFaveByID(ctx, fave.ID); err != nil {
+			log.Errorf(ctx, "error deleting rejected fave: %v", err)
+		}
+
+		return p.federate.RejectInteraction(ctx,
+			fave.Status,
+			fave.Account,
+			fave.URI,
+		)
+	}
+
 	if err := p.surface.notifyFave(ctx, fave); err != nil {
 		log.Errorf(ctx, "error notifying fave: %v", err)
 	}
@@ -359,6 +619,68 @@ func (p *fediAPI) CreateLike(ctx context.Context, fMsg *messages.FromFediAPI) er
 	return nil
 }
 
+func (p *fediAPI) CreateEmojiReact(ctx context.Context, fMsg *messages.FromFediAPI) error {
+	reaction, ok := fMsg.GTSModel.(*gtsmodel.StatusReaction)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.StatusReaction", fMsg.GTSModel)
+	}
+
+	// Fetch the custom emoji used, if
+	// this is one we haven't seen yet.
+	if err := p.federate.DereferenceReactionEmoji(ctx,
+		fMsg.Receiving.Username,
+		reaction,
+	); err != nil {
+		log.Errorf(ctx, "error dereferencing reaction emoji: %v", err)
+	}
+
+	// Interaction counts changed on the reacted-to status;
+	// uncache the prepared version from all timelines.
+	p.surface.invalidateStatusFromTimelines(ctx, reaction.StatusID)
+
+	return nil
+}
+
+func (p *fediAPI) UndoFollow(ctx context.Context, fMsg *messages.FromFediAPI) error {
+	follow, ok := fMsg.GTSModel.(*gtsmodel.Follow)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.Follow", fMsg.GTSModel)
+	}
+
+	// Update stats for the local account.
+	if err := p.utils.decrementFollowersCount(ctx, fMsg.Receiving); err != nil {
+		log.Errorf(ctx, "error updating account stats: %v", err)
+	}
+
+	// Update stats for the remote account.
+	if err := p.utils.decrementFollowingCount(ctx, fMsg.Requesting); err != nil {
+		log.Errorf(ctx, "error updating account stats: %v", err)
+	}
+
+	if err := p.account.RecordFollowerEvent(ctx,
+		fMsg.Receiving,
+		follow.AccountID,
+		gtsmodel.FollowerEventUnfollow,
+	); err != nil {
+		log.Errorf(ctx, "error recording follower event: %v", err)
+	}
+
+	return nil
+}
+
+func (p *fediAPI) UndoEmojiReact(ctx context.Context, fMsg *messages.FromFediAPI) error {
+	reaction, ok := fMsg.GTSModel.(*gtsmodel.StatusReaction)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.StatusReaction", fMsg.GTSModel)
+	}
+
+	// Interaction counts changed on the reacted-to status;
+	// uncache the prepared version from all timelines.
+	p.surface.invalidateStatusFromTimelines(ctx, reaction.StatusID)
+
+	return nil
+}
+
 func (p *fediAPI) CreateAnnounce(ctx context.Context, fMsg *messages.FromFediAPI) error {
 	boost, ok := fMsg.GTSModel.(*gtsmodel.Status)
 	if !ok {
@@ -386,6 +708,47 @@ func (p *fediAPI) CreateAnnounce(ctx context.Context, fMsg *messages.FromFediAPI
 		return gtserror.Newf("error dereferencing announce: %w", err)
 	}
 
+	// Check the boost is permitted by the
+	// boosted status' interaction policy.
+	permitted, err := p.interactionPermitted(ctx,
+		boost.BoostOf,
+		boost.Account,
+		gtsmodel.InteractionAnnounce,
+	)
+	if err != nil {
+		return err
+	}
+
+	if !permitted {
+		// Hold the boost pending approval if possible.
+		held, err := p.holdInteraction(ctx,
+			boost.BoostOf,
+			boost,
+			gtsmodel.InteractionAnnounce,
+		)
+		if err != nil {
+			return err
+		}
+
+		if held {
+			// Timelining + notifying
+			// happens once approved.
+			return nil
+		}
+
+		// Wipe the boost and let the
+		// booster know it was rejected.
+		if err := p.utils.wipeStatus(ctx, boost, false); err != nil {
+			log.Errorf(ctx, "error wiping rejected boost: %v", err)
+		}
+
+		return p.federate.RejectInteraction(ctx,
+			boost.BoostOf,
+			boost.Account,
+			boost.URI,
+		)
+	}
+
 	// Update stats for the remote account.
 	if err := p.utils.incrementStatusesCount(ctx, fMsg.Requesting, boost); err != nil {
 		log.Errorf(ctx, "error updating account stats: %v", err)
@@ -572,6 +935,16 @@ func (p *fediAPI) UpdateStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 		log.Errorf(ctx, "error refreshing status: %v", err)
 	}
 
+	// Text may have changed, reindex status.
+	if err := p.utils.search.IndexStatus(ctx, status); err != nil {
+		log.Errorf(ctx, "error indexing status: %v", err)
+	}
+
+	// Links may have changed, update the preview card.
+	if _, err := p.federate.FetchStatusCard(ctx, "", status); err != nil {
+		log.Errorf(ctx, "error fetching status card: %v", err)
+	}
+
 	// Status representation was refetched, uncache from timelines.
 	p.surface.invalidateStatusFromTimelines(ctx, status.ID)
 
@@ -546,6 +546,163 @@ func (suite *FromFediAPITestSuite) TestProcessFollowRequestUnlocked() {
 	suite.Equal(originAccount.ID, notif.Account.ID)
 }
 
+func (suite *FromFediAPITestSuite) TestProcessFollowRequestMoved() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	ctx := context.Background()
+
+	originAccount := suite.testAccounts["remote_account_1"]
+
+	// target is an account that has Moved.
+	targetAccount := new(gtsmodel.Account)
+	*targetAccount = *suite.testAccounts["local_account_1"]
+	targetAccount.MovedToURI = "http://fossbros-anonymous.io/users/foss_satan"
+	err := testStructs.State.DB.UpdateAccount(ctx, targetAccount, "moved_to_uri")
+	suite.NoError(err)
+
+	// put the follow request in the database as though it had passed through the federating db already
+	satanFollowRequestTurtle := &gtsmodel.FollowRequest{
+		ID:              "01FGRYAVAWWPP926J175QGM0WV",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		AccountID:       originAccount.ID,
+		Account:         originAccount,
+		TargetAccountID: targetAccount.ID,
+		TargetAccount:   targetAccount,
+		ShowReblogs:     util.Ptr(true),
+		URI:             fmt.Sprintf("%s/follows/01FGRYAVAWWPP926J175QGM0WV", originAccount.URI),
+		Notify:          util.Ptr(false),
+	}
+
+	err = testStructs.State.DB.Put(ctx, satanFollowRequestTurtle)
+	suite.NoError(err)
+
+	err = testStructs.Processor.Workers().ProcessFromFediAPI(ctx, &messages.FromFediAPI{
+		APObjectType:   ap.ActivityFollow,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       satanFollowRequestTurtle,
+		Receiving:      targetAccount,
+		Requesting:     originAccount,
+	})
+	suite.NoError(err)
+
+	reject := &struct {
+		Actor  string `json:"actor"`
+		Object struct {
+			ID string `json:"id"`
+		}
+		Type string `json:"type"`
+	}{}
+
+	// a reject message should be sent to satan's inbox
+	if !testrig.WaitFor(func() bool {
+		delivery, ok := testStructs.State.Workers.Delivery.Queue.Pop()
+		if !ok {
+			return false
+		}
+		sent, err := io.ReadAll(delivery.Request.Body)
+		if err != nil {
+			panic("error reading body: " + err.Error())
+		}
+		if err := json.Unmarshal(sent, reject); err != nil {
+			panic("error unmarshaling json: " + err.Error())
+		}
+		return true
+	}) {
+		suite.FailNow("timed out waiting for message")
+	}
+
+	suite.Equal(targetAccount.URI, reject.Actor)
+	suite.Equal(satanFollowRequestTurtle.URI, reject.Object.ID)
+	suite.Equal("Reject", reject.Type)
+
+	// The follow request should be gone, with no follow created.
+	_, err = testStructs.State.DB.GetFollowRequestByID(ctx, satanFollowRequestTurtle.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	follows, err := testStructs.State.DB.IsFollowing(ctx, originAccount.ID, targetAccount.ID)
+	suite.NoError(err)
+	suite.False(follows)
+}
+
+func (suite *FromFediAPITestSuite) TestProcessFollowRequestLockedAutoApproved() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	ctx := context.Background()
+
+	originAccount := suite.testAccounts["remote_account_1"]
+
+	// target is a locked account, which
+	// auto-approves requests from accounts
+	// created at least a day ago.
+	targetAccount := new(gtsmodel.Account)
+	*targetAccount = *suite.testAccounts["local_account_2"]
+	targetAccount.Settings = new(gtsmodel.AccountSettings)
+	*targetAccount.Settings = *suite.testAccounts["local_account_2"].Settings
+	targetAccount.Settings.AutoApproveMinAgeDays = 1
+	if err := testStructs.State.DB.UpdateAccountSettings(ctx,
+		targetAccount.Settings,
+		"auto_approve_min_age_days",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	wssStream, errWithCode := testStructs.Processor.Stream().Open(context.Background(), targetAccount, stream.TimelineHome)
+	suite.NoError(errWithCode)
+
+	// put the follow request in the database as though it had passed through the federating db already
+	followRequest := &gtsmodel.FollowRequest{
+		ID:              "01FGRYAVAWWPP926J175QGM0WV",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		AccountID:       originAccount.ID,
+		Account:         originAccount,
+		TargetAccountID: targetAccount.ID,
+		TargetAccount:   targetAccount,
+		ShowReblogs:     util.Ptr(true),
+		URI:             fmt.Sprintf("%s/follows/01FGRYAVAWWPP926J175QGM0WV", originAccount.URI),
+		Notify:          util.Ptr(false),
+	}
+
+	err := testStructs.State.DB.Put(ctx, followRequest)
+	suite.NoError(err)
+
+	err = testStructs.Processor.Workers().ProcessFromFediAPI(ctx, &messages.FromFediAPI{
+		APObjectType:   ap.ActivityFollow,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       followRequest,
+		Receiving:      targetAccount,
+		Requesting:     originAccount,
+	})
+	suite.NoError(err)
+
+	// the follow request should have been accepted
+	following, err := testStructs.State.DB.IsFollowing(ctx, originAccount.ID, targetAccount.ID)
+	suite.NoError(err)
+	suite.True(following)
+
+	// and an accept should be sent to the origin account
+	if !testrig.WaitFor(func() bool {
+		delivery, ok := testStructs.State.Workers.Delivery.Queue.Pop()
+		return ok && testrig.EqualRequestURIs(delivery.Request.URL, *originAccount.SharedInboxURI)
+	}) {
+		suite.FailNow("timed out waiting for message")
+	}
+
+	// target is notified of a follow, not a follow request
+	msg, ok := wssStream.Recv(context.Background())
+	suite.True(ok)
+
+	suite.Equal(stream.EventTypeNotification, msg.Event)
+	notif := &apimodel.Notification{}
+	err = json.Unmarshal([]byte(msg.Payload), notif)
+	suite.NoError(err)
+	suite.Equal("follow", notif.Type)
+	suite.Equal(originAccount.ID, notif.Account.ID)
+}
+
 // TestCreateStatusFromIRI checks if a forwarded status can be dereferenced by the processor.
 func (suite *FromFediAPITestSuite) TestCreateStatusFromIRI() {
 	testStructs := suite.SetupTestStructs()
@@ -644,6 +801,103 @@ func (suite *FromFediAPITestSuite) TestMoveAccount() {
 	suite.WithinDuration(time.Now(), move.SucceededAt, 1*time.Minute)
 }
 
+// remote_account_1 boosts the first status of local_account_1, which is
+// locked, and only permits boosts by followers: the boost should be held
+// pending approval, and only notified once it's accepted.
+func (suite *FromFediAPITestSuite) TestProcessAnnounceHeldPendingApproval() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	ctx := context.Background()
+
+	boostedAccount := new(gtsmodel.Account)
+	*boostedAccount = *suite.testAccounts["local_account_1"]
+	boostedAccount.Locked = util.Ptr(true)
+	if err := testStructs.State.DB.UpdateAccount(ctx, boostedAccount, "locked"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	boostedStatus := new(gtsmodel.Status)
+	*boostedStatus = *suite.testStatuses["local_account_1_status_1"]
+	boostedStatus.InteractionPolicy = &gtsmodel.InteractionPolicy{
+		CanAnnounce: gtsmodel.PolicyValues{gtsmodel.PolicyValueFollowers},
+	}
+	if err := testStructs.State.DB.UpdateStatus(ctx, boostedStatus, "interaction_policy"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	boostingAccount := suite.testAccounts["remote_account_1"]
+	announceStatus := &gtsmodel.Status{}
+	announceStatus.URI = "https://example.org/some-announce-uri"
+	announceStatus.BoostOfURI = boostedStatus.URI
+	announceStatus.CreatedAt = time.Now()
+	announceStatus.UpdatedAt = time.Now()
+	announceStatus.AccountID = boostingAccount.ID
+	announceStatus.AccountURI = boostingAccount.URI
+	announceStatus.Account = boostingAccount
+	announceStatus.Visibility = boostedStatus.Visibility
+
+	err := testStructs.Processor.Workers().ProcessFromFediAPI(ctx, &messages.FromFediAPI{
+		APObjectType:   ap.ActivityAnnounce,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       announceStatus,
+		Receiving:      boostedAccount,
+		Requesting:     boostingAccount,
+	})
+	suite.NoError(err)
+
+	// The boost should be stored, but pending approval.
+	boost, err := testStructs.State.DB.GetStatusByID(ctx, announceStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(*boost.PendingApproval)
+
+	// An interaction request should exist for it.
+	req, err := testStructs.State.DB.GetInteractionRequestByInteractionID(ctx, boost.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(req.IsPending())
+	suite.Equal(gtsmodel.InteractionAnnounce, req.InteractionType)
+	suite.Equal(boostedAccount.ID, req.TargetAccountID)
+	suite.Equal(boostingAccount.ID, req.InteractingAccountID)
+
+	// There should be no notification for it yet.
+	where := []db.Where{{Key: "status_id", Value: boost.ID}}
+	notif := &gtsmodel.Notification{}
+	err = testStructs.State.DB.GetWhere(ctx, where, notif)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Accept the interaction request.
+	req.AcceptedAt = time.Now()
+	if err := testStructs.State.DB.UpdateInteractionRequest(ctx, req, "accepted_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	err = testStructs.Processor.Workers().ProcessFromClientAPI(ctx, &messages.FromClientAPI{
+		APObjectType:   ap.ActivityAnnounce,
+		APActivityType: ap.ActivityAccept,
+		GTSModel:       req,
+		Origin:         boostedAccount,
+		Target:         boostingAccount,
+	})
+	suite.NoError(err)
+
+	// The boost should no longer be pending approval.
+	boost, err = testStructs.State.DB.GetStatusByID(ctx, announceStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(*boost.PendingApproval)
+
+	// And the booster should now be notified.
+	err = testStructs.State.DB.GetWhere(ctx, where, notif)
+	suite.NoError(err)
+	suite.Equal(gtsmodel.NotificationReblog, notif.NotificationType)
+	suite.Equal(boostingAccount.ID, notif.OriginAccountID)
+}
+
 func TestFromFederatorTestSuite(t *testing.T) {
 	suite.Run(t, &FromFediAPITestSuite{})
 }
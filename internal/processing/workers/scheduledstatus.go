@@ -0,0 +1,174 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package workers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/status"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// scheduledStatuses wraps logic for publishing
+// statuses scheduled by local accounts once
+// they're due, by creating them as though they
+// had just been posted via the client API.
+type scheduledStatuses struct {
+	state     *state.State
+	converter *typeutils.Converter
+	status    *status.Processor
+}
+
+// ScheduleStatusPublishing adds a job to the scheduler
+// which checks every minute for scheduled statuses that
+// are due, and publishes them.
+func (p *Processor) ScheduleStatusPublishing() error {
+	// Start checking from the next whole minute.
+	start := time.Now().Truncate(time.Minute).Add(time.Minute)
+
+	if !p.workers.Scheduler.AddRecurring(
+		"@scheduledstatuses",
+		start,
+		time.Minute,
+		p.PublishScheduledStatuses,
+	) {
+		return gtserror.New("failed to schedule @scheduledstatuses")
+	}
+
+	return nil
+}
+
+// PublishScheduledStatuses publishes all scheduled
+// statuses that are due as of the given time.
+func (p *Processor) PublishScheduledStatuses(ctx context.Context, now time.Time) {
+	scheduledStatuses, err := p.scheduled.state.DB.GetDueScheduledStatuses(ctx, now)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		log.Errorf(ctx, "db error getting due scheduled statuses: %v", err)
+		return
+	}
+
+	for _, scheduled := range scheduledStatuses {
+		if err := p.scheduled.publish(ctx, scheduled); err != nil {
+			log.Errorf(ctx, "error publishing scheduled status %s: %v", scheduled.ID, err)
+		}
+	}
+}
+
+// publish creates a status from the parameters of the given
+// scheduled status, and deletes the latter once it's published.
+// If publishing fails for a reason that may be temporary, the
+// scheduled status is kept, so that it's retried next time.
+func (s *scheduledStatuses) publish(ctx context.Context, scheduled *gtsmodel.ScheduledStatus) error {
+	account := scheduled.Account
+	if account == nil || account.IsSuspended() || account.IsMoving() {
+		// Account can't post
+		// anymore, just drop it.
+		return s.drop(ctx, scheduled)
+	}
+
+	application := scheduled.Application
+	if application == nil {
+		// Application has since been
+		// removed, create without it.
+		application = new(gtsmodel.Application)
+	}
+
+	form := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      scheduled.Text,
+			MediaIDs:    scheduled.AttachmentIDs,
+			InReplyToID: scheduled.InReplyToID,
+			Sensitive:   util.PtrValueOr(scheduled.Sensitive, false),
+			SpoilerText: scheduled.ContentWarning,
+			Visibility:  s.converter.VisToAPIVis(ctx, scheduled.Visibility),
+			Language:    scheduled.Language,
+			ContentType: apimodel.StatusContentType(scheduled.ContentType),
+		},
+		AdvancedVisibilityFlagsForm: apimodel.AdvancedVisibilityFlagsForm{
+			Federated: scheduled.Federated,
+			Boostable: scheduled.Boostable,
+			Replyable: scheduled.Replyable,
+			Likeable:  scheduled.Likeable,
+
+			InteractionPolicy: typeutils.InteractionPolicyToAPIInteractionPolicyRequest(scheduled.InteractionPolicy),
+		},
+	}
+
+	if scheduled.HasPoll() {
+		form.Poll = &apimodel.PollRequest{
+			Options:    scheduled.PollOptions,
+			ExpiresIn:  scheduled.PollExpiresIn,
+			Multiple:   util.PtrValueOr(scheduled.PollMultiple, false),
+			HideTotals: util.PtrValueOr(scheduled.PollHideCounts, false),
+		}
+	}
+
+	// Free up the attachments, so
+	// they can be attached to the
+	// status when it's created.
+	if err := s.status.DetachScheduledAttachments(ctx, scheduled); err != nil {
+		return err
+	}
+
+	if _, errWithCode := s.status.Create(ctx, account, application, form); errWithCode != nil {
+		if errWithCode.Code() < http.StatusInternalServerError {
+			// The status can never be created
+			// as scheduled, eg., the status it
+			// replies to is gone, so drop it.
+			log.Warnf(ctx, "dropping scheduled status %s: %v", scheduled.ID, errWithCode)
+			return s.drop(ctx, scheduled)
+		}
+
+		// Failed for some other reason, put
+		// the attachments back for next time.
+		if err := s.status.AttachScheduledAttachments(ctx, scheduled); err != nil {
+			log.Errorf(ctx, "error reattaching scheduled attachments: %v", err)
+		}
+
+		return errWithCode
+	}
+
+	// Published, the scheduled
+	// status can be deleted now.
+	if err := s.state.DB.DeleteScheduledStatusByID(ctx, scheduled.ID); err != nil {
+		return gtserror.Newf("db error deleting scheduled status: %w", err)
+	}
+
+	return nil
+}
+
+// drop deletes the given scheduled status
+// without publishing it, freeing up its
+// attachments to be used elsewhere.
+func (s *scheduledStatuses) drop(ctx context.Context, scheduled *gtsmodel.ScheduledStatus) error {
+	if err := s.state.DB.DeleteScheduledStatusByID(ctx, scheduled.ID); err != nil {
+		return gtserror.Newf("db error deleting scheduled status: %w", err)
+	}
+
+	return s.status.DetachScheduledAttachments(ctx, scheduled)
+}
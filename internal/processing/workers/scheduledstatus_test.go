@@ -0,0 +1,152 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package workers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type ScheduledStatusTestSuite struct {
+	WorkersTestSuite
+}
+
+func (suite *ScheduledStatusTestSuite) TestPublishScheduledStatuses() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	var (
+		ctx         = context.Background()
+		account     = suite.testAccounts["local_account_1"]
+		application = suite.testApplications["application_1"]
+		attachment  = suite.testAttachments["local_account_1_unattached_1"]
+		scheduledAt = time.Now().Add(time.Hour)
+	)
+
+	apiScheduled, errWithCode := testStructs.Processor.Status().ScheduledCreate(ctx,
+		account,
+		application,
+		&apimodel.AdvancedStatusCreateForm{
+			StatusCreateRequest: apimodel.StatusCreateRequest{
+				Status:      "hello from an hour ago",
+				MediaIDs:    []string{attachment.ID},
+				Visibility:  apimodel.VisibilityPrivate,
+				ScheduledAt: util.FormatISO8601(scheduledAt),
+			},
+		},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Not due yet, so nothing should be published.
+	testStructs.Processor.Workers().PublishScheduledStatuses(ctx, time.Now())
+
+	if _, err := testStructs.State.DB.GetScheduledStatusByID(ctx, apiScheduled.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Now it's due, so should be published.
+	testStructs.Processor.Workers().PublishScheduledStatuses(ctx, scheduledAt.Add(time.Minute))
+
+	_, err := testStructs.State.DB.GetScheduledStatusByID(ctx, apiScheduled.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Attachment should now be
+	// attached to the new status.
+	dbAttachment, err := testStructs.State.DB.GetAttachmentByID(ctx, attachment.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(dbAttachment.ScheduledStatusID)
+	suite.NotEmpty(dbAttachment.StatusID)
+
+	status, err := testStructs.State.DB.GetStatusByID(ctx, dbAttachment.StatusID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(account.ID, status.AccountID)
+	suite.Equal(application.ID, status.CreatedWithApplicationID)
+	suite.Equal("hello from an hour ago", status.Text)
+	suite.Equal(gtsmodel.VisibilityFollowersOnly, status.Visibility)
+}
+
+func (suite *ScheduledStatusTestSuite) TestPublishScheduledStatusReplyGone() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	var (
+		ctx         = context.Background()
+		account     = suite.testAccounts["local_account_1"]
+		application = suite.testApplications["application_1"]
+		attachment  = suite.testAttachments["local_account_1_unattached_1"]
+		inReplyTo   = suite.testStatuses["local_account_2_status_1"]
+		scheduledAt = time.Now().Add(time.Hour)
+	)
+
+	apiScheduled, errWithCode := testStructs.Processor.Status().ScheduledCreate(ctx,
+		account,
+		application,
+		&apimodel.AdvancedStatusCreateForm{
+			StatusCreateRequest: apimodel.StatusCreateRequest{
+				Status:      "replying from an hour ago",
+				MediaIDs:    []string{attachment.ID},
+				InReplyToID: inReplyTo.ID,
+				Visibility:  apimodel.VisibilityPublic,
+				ScheduledAt: util.FormatISO8601(scheduledAt),
+			},
+		},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Delete the status being replied to
+	// before the scheduled reply is due.
+	if err := testStructs.State.DB.DeleteStatusByID(ctx, inReplyTo.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	testStructs.Processor.Workers().PublishScheduledStatuses(ctx, scheduledAt.Add(time.Minute))
+
+	// Reply can never be published, so
+	// the scheduled status is dropped.
+	_, err := testStructs.State.DB.GetScheduledStatusByID(ctx, apiScheduled.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Attachment should be freed
+	// up, and not attached to
+	// any status either.
+	dbAttachment, err := testStructs.State.DB.GetAttachmentByID(ctx, attachment.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(dbAttachment.ScheduledStatusID)
+	suite.Empty(dbAttachment.StatusID)
+}
+
+func TestScheduledStatusTestSuite(t *testing.T) {
+	suite.Run(t, &ScheduledStatusTestSuite{})
+}
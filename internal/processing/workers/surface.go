@@ -23,6 +23,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/processing/stream"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 )
 
 // Surface wraps functions for 'surfacing' the result
@@ -31,10 +32,12 @@ import (
 //   - removing a status from timelines
 //   - sending a notification to a user
 //   - sending an email
+//   - sending a web push notification
 type Surface struct {
-	State       *state.State
-	Converter   *typeutils.Converter
-	Stream      *stream.Processor
-	Filter      *visibility.Filter
-	EmailSender email.Sender
+	State         *state.State
+	Converter     *typeutils.Converter
+	Stream        *stream.Processor
+	Filter        *visibility.Filter
+	EmailSender   email.Sender
+	WebPushSender webpush.Sender
 }
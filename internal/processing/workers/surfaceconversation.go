@@ -0,0 +1,247 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package workers
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// updateConversationsForStatus updates the conversation of each
+// local account taking part in the given direct status, creating
+// it if necessary, and streams the updated conversation to them.
+//
+// Everyone the status is addressed to, as well as its author, is
+// taken to be taking part, so direct statuses addressed to several
+// accounts form a group conversation between all of them.
+func (s *Surface) updateConversationsForStatus(ctx context.Context, status *gtsmodel.Status) error {
+	if status.Visibility != gtsmodel.VisibilityDirect ||
+		status.ThreadID == "" {
+		// Not a (threaded) direct
+		// status, nothing to do.
+		return nil
+	}
+
+	if util.PtrValueOr(status.PendingApproval, false) {
+		// Not yet accepted into the thread.
+		return nil
+	}
+
+	// Gather participants; status
+	// author, plus mentioned accounts.
+	participants := make([]*gtsmodel.Account, 0, 1+len(status.Mentions))
+	participants = append(participants, status.Account)
+	for _, mention := range status.Mentions {
+		if mention.TargetAccount == nil {
+			// Not (yet) dereferenced.
+			continue
+		}
+
+		if slices.ContainsFunc(participants, func(a *gtsmodel.Account) bool {
+			return a.ID == mention.TargetAccountID
+		}) {
+			// Already included.
+			continue
+		}
+
+		participants = append(participants, mention.TargetAccount)
+	}
+
+	var errs gtserror.MultiError
+
+	for _, owner := range participants {
+		if !owner.IsLocal() {
+			// Only local accounts
+			// have conversations.
+			continue
+		}
+
+		if err := s.updateConversation(ctx, owner, participants, status); err != nil {
+			errs.Appendf("error updating conversation of %s: %w", owner.ID, err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+// updateConversation updates (or creates) owner's conversation for
+// the thread of the given direct status, with the given participants.
+func (s *Surface) updateConversation(
+	ctx context.Context,
+	owner *gtsmodel.Account,
+	participants []*gtsmodel.Account,
+	status *gtsmodel.Status,
+) error {
+	// Ensure status is actually visible to
+	// the owner (eg., they haven't blocked
+	// the author), else don't surface it.
+	visible, err := s.Filter.StatusVisible(ctx, owner, status)
+	if err != nil {
+		return gtserror.Newf("error checking status visibility: %w", err)
+	}
+
+	if !visible {
+		return nil
+	}
+
+	// Lock on this owner + thread combo, so
+	// concurrent statuses in the same thread
+	// don't race to create the conversation.
+	unlock := s.State.ProcessingLocks.Lock("conversation:" + owner.ID + ":" + status.ThreadID)
+	defer unlock()
+
+	conversation, err := s.State.DB.GetConversationByThreadID(
+		gtscontext.SetBarebones(ctx),
+		owner.ID,
+		status.ThreadID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting conversation: %w", err)
+	}
+
+	isNew := (conversation == nil)
+	if isNew {
+		conversation = &gtsmodel.Conversation{
+			ID:        id.NewULID(),
+			AccountID: owner.ID,
+			ThreadID:  status.ThreadID,
+		}
+	}
+
+	// Include any new participants.
+	for _, participant := range participants {
+		if participant.ID != owner.ID &&
+			!slices.Contains(conversation.OtherAccountIDs, participant.ID) {
+			conversation.OtherAccountIDs = append(conversation.OtherAccountIDs, participant.ID)
+		}
+	}
+
+	if status.ID > conversation.LastStatusID {
+		// Status is the latest in the thread (ie., it's
+		// not an older ancestor only just dereferenced),
+		// so it's unread unless it's the owner's own.
+		conversation.LastStatusID = status.ID
+		conversation.Read = util.Ptr(status.AccountID == owner.ID)
+	}
+
+	if isNew {
+		err = s.State.DB.PutConversation(ctx, conversation)
+	} else {
+		err = s.State.DB.UpdateConversation(ctx, conversation)
+	}
+	if err != nil {
+		return gtserror.Newf("error storing conversation: %w", err)
+	}
+
+	// Stream the updated conversation to the owner.
+	conversation.Account = owner
+	apiConversation, err := s.Converter.ConversationToAPIConversation(ctx, conversation, owner)
+	if err != nil {
+		return gtserror.Newf("error converting conversation to api: %w", err)
+	}
+
+	s.Stream.Conversation(ctx, owner, apiConversation)
+	return nil
+}
+
+// updateConversationsForDeletedStatus points any conversations whose last
+// status was the given (now deleted) status at the latest remaining direct
+// status in the thread visible to their owner, or deletes them if none remain.
+func (s *Surface) updateConversationsForDeletedStatus(ctx context.Context, status *gtsmodel.Status) error {
+	if status.Visibility != gtsmodel.VisibilityDirect ||
+		status.ThreadID == "" {
+		// Not a (threaded) direct
+		// status, nothing to do.
+		return nil
+	}
+
+	conversations, err := s.State.DB.GetConversationsByLastStatusID(
+		gtscontext.SetBarebones(ctx),
+		status.ID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting conversations: %w", err)
+	}
+
+	if len(conversations) == 0 {
+		// Nothing to do.
+		return nil
+	}
+
+	// Get remaining direct statuses in the thread, newest first.
+	statusIDs, err := s.State.DB.GetThreadDirectStatusIDs(ctx, status.ThreadID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting thread statuses: %w", err)
+	}
+
+	var errs gtserror.MultiError
+
+	for _, conversation := range conversations {
+		owner, err := s.State.DB.GetAccountByID(ctx, conversation.AccountID)
+		if err != nil {
+			errs.Appendf("error getting conversation owner: %w", err)
+			continue
+		}
+
+		// Find latest status in
+		// thread visible to owner.
+		var lastStatusID string
+		for _, statusID := range statusIDs {
+			threadStatus, err := s.State.DB.GetStatusByID(ctx, statusID)
+			if err != nil {
+				log.Debugf(ctx, "error getting thread status %s: %v", statusID, err)
+				continue
+			}
+
+			visible, err := s.Filter.StatusVisible(ctx, owner, threadStatus)
+			if err != nil {
+				log.Debugf(ctx, "error checking thread status %s visibility: %v", statusID, err)
+				continue
+			}
+
+			if visible {
+				lastStatusID = statusID
+				break
+			}
+		}
+
+		if lastStatusID == "" {
+			// Nothing left in the conversation.
+			if err := s.State.DB.DeleteConversationByID(ctx, conversation.ID); err != nil {
+				errs.Appendf("error deleting conversation: %w", err)
+			}
+			continue
+		}
+
+		conversation.LastStatusID = lastStatusID
+		if err := s.State.DB.UpdateConversation(ctx, conversation, "last_status_id"); err != nil {
+			errs.Appendf("error updating conversation: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
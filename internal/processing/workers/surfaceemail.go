@@ -26,8 +26,11 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
@@ -265,3 +268,222 @@ func (s *Surface) emailAdminNewSignup(ctx context.Context, newUser *gtsmodel.Use
 
 	return nil
 }
+
+const (
+	// awayEmailDelay is how long to wait after a user
+	// who is away gets mentioned before emailing them,
+	// so that mentions arriving in quick succession (eg.,
+	// a busy direct message thread) go in one email.
+	awayEmailDelay = 15 * time.Minute
+
+	// awayEmailMaxMentions is the maximum
+	// number of mentions to list in one email.
+	awayEmailMaxMentions = 20
+)
+
+// awayEmailTaskID returns the scheduler
+// task ID for the away email of given user.
+func awayEmailTaskID(userID string) string {
+	return "away-email:" + userID
+}
+
+// userAway returns whether the given user has opted in to
+// away emails, and has been inactive for long enough to get one.
+func userAway(user *gtsmodel.User, settings *gtsmodel.AccountSettings) bool {
+	if settings.EmailWhenAwayHours <= 0 {
+		// Not opted in.
+		return false
+	}
+
+	away := time.Duration(settings.EmailWhenAwayHours) * time.Hour
+	return time.Since(user.LastActiveAt) >= away
+}
+
+// scheduleAwayEmail schedules an email to the user of the given
+// local account about their recent mentions, if they're away
+// and have opted in. If an email is already scheduled, the
+// mention will simply be included in that one instead.
+func (s *Surface) scheduleAwayEmail(ctx context.Context, account *gtsmodel.Account) error {
+	// Account may be barebones,
+	// so fetch settings separately.
+	settings := account.Settings
+	if settings == nil {
+		var err error
+		settings, err = s.State.DB.GetAccountSettings(ctx, account.ID)
+		if err != nil {
+			return gtserror.Newf("error getting settings of account %s: %w", account.ID, err)
+		}
+	}
+
+	if settings.EmailWhenAwayHours <= 0 {
+		// Not opted in, don't
+		// bother fetching user.
+		return nil
+	}
+
+	user, err := s.State.DB.GetUserByAccountID(
+		gtscontext.SetBarebones(ctx),
+		account.ID,
+	)
+	if err != nil {
+		return gtserror.Newf("db error getting user: %w", err)
+	}
+
+	if !userAway(user, settings) {
+		return nil
+	}
+
+	// Add a task to email the user, this will
+	// fail if one is already scheduled (ie., the
+	// mention will be included in that batch).
+	_ = s.State.Workers.Scheduler.AddOnce(
+		awayEmailTaskID(user.ID),
+		time.Now().Add(awayEmailDelay),
+		func(ctx context.Context, _ time.Time) {
+			if err := s.emailUserAway(ctx, user.ID); err != nil {
+				log.Errorf(ctx, "error emailing away user %s: %v", user.ID, err)
+			}
+		},
+	)
+
+	return nil
+}
+
+// emailUserAway emails the user with given ID about
+// the mentions they received since they were last
+// active, or last emailed, if they're still away.
+func (s *Surface) emailUserAway(ctx context.Context, userID string) error {
+	// Get the latest version of user
+	// (they may have come back since).
+	user, err := s.State.DB.GetUserByID(ctx, userID)
+	if err != nil {
+		return gtserror.Newf("db error getting user: %w", err)
+	}
+
+	if user.ConfirmedAt.IsZero() ||
+		!*user.Approved ||
+		*user.Disabled ||
+		user.Email == "" {
+		// Only email users who:
+		// - are confirmed
+		// - are approved
+		// - are not disabled
+		// - have an email address
+		return nil
+	}
+
+	settings, err := s.State.DB.GetAccountSettings(ctx, user.AccountID)
+	if err != nil {
+		return gtserror.Newf("error getting settings of account %s: %w", user.AccountID, err)
+	}
+
+	if !userAway(user, settings) {
+		// User came back or
+		// opted out meanwhile.
+		return nil
+	}
+
+	// Only include mentions they haven't
+	// been active or been emailed since.
+	since := user.LastActiveAt
+	if user.LastEmailedAt.After(since) {
+		since = user.LastEmailedAt
+	}
+
+	var sinceID string
+	if !since.IsZero() {
+		sinceID, err = id.NewULIDFromTime(since)
+		if err != nil {
+			return gtserror.Newf("error generating since id: %w", err)
+		}
+	}
+
+	notifs, err := s.State.DB.GetAccountNotifications(
+		ctx,
+		user.AccountID,
+		"",                      // maxID
+		sinceID,                 // sinceID
+		"",                      // minID
+		awayEmailMaxMentions+1,  // limit
+		awayEmailExcludeTypes(), // excludeTypes
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting notifications: %w", err)
+	}
+
+	awayData := email.AwayNotificationsData{
+		Username: user.Account.Username,
+		More:     len(notifs) > awayEmailMaxMentions,
+	}
+
+	for _, notif := range notifs {
+		if len(awayData.Mentions) == awayEmailMaxMentions {
+			break
+		}
+
+		if notif.NotificationType != gtsmodel.NotificationMention ||
+			notif.OriginAccount == nil || notif.Status == nil {
+			// Not a mention, or
+			// missing something.
+			continue
+		}
+
+		from := "@" + notif.OriginAccount.Username
+		if notif.OriginAccount.IsRemote() {
+			from += "@" + notif.OriginAccount.Domain
+		}
+
+		awayData.Mentions = append(awayData.Mentions, email.AwayMention{
+			From:      from,
+			Direct:    notif.Status.Visibility == gtsmodel.VisibilityDirect,
+			StatusURL: notif.Status.URL,
+		})
+	}
+
+	if len(awayData.Mentions) == 0 {
+		// Nothing (left)
+		// to email about.
+		return nil
+	}
+
+	instance, err := s.State.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return gtserror.Newf("db error getting instance: %w", err)
+	}
+	awayData.InstanceURL = instance.URI
+	awayData.InstanceName = instance.Title
+
+	if err := s.EmailSender.SendAwayNotificationsEmail(user.Email, awayData); err != nil {
+		return err
+	}
+
+	// Email sent, update the user
+	// entry with the emailed time.
+	user.LastEmailedAt = time.Now()
+
+	if err := s.State.DB.UpdateUser(
+		ctx,
+		user,
+		"last_emailed_at",
+	); err != nil {
+		return gtserror.Newf("error updating user entry after email sent: %w", err)
+	}
+
+	return nil
+}
+
+// awayEmailExcludeTypes returns the notification
+// types to exclude when looking up mentions for
+// an away email, ie., everything except mentions.
+func awayEmailExcludeTypes() []string {
+	return []string{
+		string(gtsmodel.NotificationFollow),
+		string(gtsmodel.NotificationFollowRequest),
+		string(gtsmodel.NotificationReblog),
+		string(gtsmodel.NotificationFave),
+		string(gtsmodel.NotificationPoll),
+		string(gtsmodel.NotificationStatus),
+		string(gtsmodel.NotificationSignup),
+		string(gtsmodel.NotificationNewFrom),
+	}
+}
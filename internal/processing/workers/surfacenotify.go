@@ -20,13 +20,16 @@ package workers
 import (
 	"context"
 	"errors"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
@@ -299,7 +302,22 @@ func (s *Surface) notifyPollClose(ctx context.Context, status *gtsmodel.Status)
 
 	var errs gtserror.MultiError
 
-	if status.Account.IsLocal() {
+	// threadMuted returns whether the given account has
+	// muted the thread that the poll status is part of.
+	threadMuted := func(accountID string) bool {
+		muted, err := s.State.DB.IsThreadMutedByAccount(
+			ctx,
+			status.ThreadID,
+			accountID,
+		)
+		if err != nil {
+			errs.Appendf("error checking status thread mute %s: %w", status.ThreadID, err)
+			return true
+		}
+		return muted
+	}
+
+	if status.Account.IsLocal() && !threadMuted(status.AccountID) {
 		// Send a notification to the status
 		// author that their poll has closed!
 		if err := s.Notify(ctx,
@@ -319,6 +337,13 @@ func (s *Surface) notifyPollClose(ctx context.Context, status *gtsmodel.Status)
 			continue
 		}
 
+		if threadMuted(vote.AccountID) {
+			// This voter has muted
+			// the thread. Don't
+			// pester them.
+			continue
+		}
+
 		// notify voter that
 		// poll has been closed.
 		if err := s.Notify(ctx,
@@ -335,6 +360,86 @@ func (s *Surface) notifyPollClose(ctx context.Context, status *gtsmodel.Status)
 	return errs.Combine()
 }
 
+// notifyNewFrom notifies the owner of the given follow of
+// the given top-level status, if they've opted in to "new
+// from" notifications, and the status author hasn't posted
+// anything top-level for at least as many days as they set.
+//
+// prevPostAt is used to cache the time of the author's
+// previous post between calls for the same status.
+func (s *Surface) notifyNewFrom(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	follow *gtsmodel.Follow,
+	prevPostAt **time.Time,
+) error {
+	if follow.AccountID == status.AccountID {
+		// Don't notify
+		// account of itself.
+		return nil
+	}
+
+	// Follow account may be barebones,
+	// so fetch settings separately.
+	settings := follow.Account.Settings
+	if settings == nil {
+		var err error
+		settings, err = s.State.DB.GetAccountSettings(ctx, follow.AccountID)
+		if err != nil {
+			return gtserror.Newf("error getting settings of account %s: %w", follow.AccountID, err)
+		}
+	}
+
+	if settings.NotifyNewFromDays <= 0 {
+		// Not opted in.
+		return nil
+	}
+
+	if *prevPostAt == nil {
+		// Load the most recent top-level status
+		// by the author from before this one.
+		prev, err := s.State.DB.GetAccountStatuses(
+			gtscontext.SetBarebones(ctx),
+			status.AccountID,
+			1,         // limit
+			true,      // excludeReplies
+			true,      // excludeReblogs
+			status.ID, // maxID
+			"",        // minID
+			false,     // mediaOnly
+			false,     // publicOnly
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("error getting previous status of account %s: %w", status.AccountID, err)
+		}
+
+		var t time.Time
+		if len(prev) > 0 {
+			t = prev[0].CreatedAt
+		}
+		*prevPostAt = &t
+	}
+
+	if (*prevPostAt).IsZero() {
+		// We've never seen this account post
+		// before, so it's not "returning".
+		return nil
+	}
+
+	inactive := time.Duration(settings.NotifyNewFromDays) * 24 * time.Hour
+	if status.CreatedAt.Sub(**prevPostAt) < inactive {
+		// Not been away for long enough.
+		return nil
+	}
+
+	return s.Notify(ctx,
+		gtsmodel.NotificationNewFrom,
+		follow.Account,
+		status.Account,
+		status.ID,
+	)
+}
+
 func (s *Surface) notifySignup(ctx context.Context, newUser *gtsmodel.User) error {
 	modAccounts, err := s.State.DB.GetInstanceModerators(ctx)
 	if err != nil {
@@ -391,6 +496,255 @@ func getNotifyLockURI(
 	return builder.String()
 }
 
+// notifGroupSpan is the longest span of time that one group
+// of notifications may cover, as in Mastodon: notifications
+// that would extend a group further start a new group instead.
+const notifGroupSpan = 12 * time.Hour
+
+// notifGroupKey returns the group key for a new notification
+// of the given type targeting the given account, about the given
+// status, if any. Faves or boosts of the same status, and follows,
+// are grouped within notifGroupSpan of the start of their group;
+// notifications of other types aren't grouped, and get no key.
+//
+// Keys take the form {type}-[{statusID}-]{hour}, where hour
+// is the hour since the unix epoch at the start of the group.
+func (s *Surface) notifGroupKey(
+	ctx context.Context,
+	notificationType gtsmodel.NotificationType,
+	targetAccountID string,
+	statusID string,
+) (string, error) {
+	var prefix string
+
+	switch notificationType {
+	case gtsmodel.NotificationFave:
+		prefix = string(notificationType) + "-" + statusID + "-"
+
+	case gtsmodel.NotificationReblog:
+		// Group by the boosted status,
+		// rather than by the boost itself.
+		boost, err := s.State.DB.GetStatusByID(
+			gtscontext.SetBarebones(ctx),
+			statusID,
+		)
+		if err != nil {
+			return "", gtserror.Newf("error getting boost %s: %w", statusID, err)
+		}
+		prefix = string(notificationType) + "-" + boost.BoostOfID + "-"
+
+	case gtsmodel.NotificationFollow:
+		prefix = string(notificationType) + "-"
+
+	default:
+		// Not grouped.
+		return "", nil
+	}
+
+	hour := time.Now().Unix() / int64(time.Hour/time.Second)
+
+	// Join the latest group with this prefix,
+	// provided it started recently enough.
+	latest, err := s.State.DB.GetLatestNotificationGroupKey(ctx,
+		targetAccountID,
+		prefix,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return "", gtserror.Newf("error getting latest notification group key: %w", err)
+	}
+
+	if latest != "" {
+		start, err := strconv.ParseInt(strings.TrimPrefix(latest, prefix), 10, 64)
+		if err == nil && hour < start+int64(notifGroupSpan/time.Hour) {
+			hour = start
+		}
+	}
+
+	return prefix + strconv.FormatInt(hour, 10), nil
+}
+
+// notifNewAccountAge is the age under which accounts are
+// considered new accounts, for notification policies.
+const notifNewAccountAge = 30 * 24 * time.Hour
+
+// notifPolicy returns what the notification policy of the given
+// target account says to do with a new notification of the given
+// type from the given origin account, about the given status, if
+// any. Where several conditions match, the strictest policy applies.
+func (s *Surface) notifPolicy(
+	ctx context.Context,
+	notificationType gtsmodel.NotificationType,
+	targetAccount *gtsmodel.Account,
+	originAccount *gtsmodel.Account,
+	statusID string,
+) (gtsmodel.NotificationPolicy, error) {
+	switch {
+	case notificationType == gtsmodel.NotificationPoll,
+		notificationType == gtsmodel.NotificationSignup,
+		originAccount.ID == targetAccount.ID:
+		// Not unsolicited,
+		// so never filtered.
+		return gtsmodel.NotificationPolicyAccept, nil
+	}
+
+	// Target account may be barebones,
+	// so fetch settings separately.
+	settings := targetAccount.Settings
+	if settings == nil {
+		var err error
+		settings, err = s.State.DB.GetAccountSettings(ctx, targetAccount.ID)
+		if err != nil {
+			return "", gtserror.Newf("error getting settings of account %s: %w", targetAccount.ID, err)
+		}
+	}
+
+	if settings.NotifPolicyNotFollowing == gtsmodel.NotificationPolicyAccept &&
+		settings.NotifPolicyNotFollowers == gtsmodel.NotificationPolicyAccept &&
+		settings.NotifPolicyNewAccounts == gtsmodel.NotificationPolicyAccept &&
+		settings.NotifPolicyPrivateMentions == gtsmodel.NotificationPolicyAccept {
+		// Nothing filtered,
+		// nothing to check.
+		return gtsmodel.NotificationPolicyAccept, nil
+	}
+
+	// Notifications from an account the target has
+	// accepted a notification request from get through.
+	req, err := s.State.DB.GetNotificationRequest(
+		gtscontext.SetBarebones(ctx),
+		targetAccount.ID,
+		originAccount.ID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return "", gtserror.Newf("error getting notification request: %w", err)
+	}
+
+	if req != nil && *req.Accepted {
+		return gtsmodel.NotificationPolicyAccept, nil
+	}
+
+	if originAccount.IsLocal() {
+		// So do notifications from
+		// this instance's moderators.
+		user, err := s.State.DB.GetUserByAccountID(
+			gtscontext.SetBarebones(ctx),
+			originAccount.ID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return "", gtserror.Newf("error getting user of account %s: %w", originAccount.ID, err)
+		}
+
+		if user != nil && (*user.Admin || *user.Moderator) {
+			return gtsmodel.NotificationPolicyAccept, nil
+		}
+	}
+
+	policy := gtsmodel.NotificationPolicyAccept
+
+	// apply applies the given policy of a
+	// matching condition, if it's stricter.
+	apply := func(p gtsmodel.NotificationPolicy) {
+		switch {
+		case p == gtsmodel.NotificationPolicyDrop:
+			policy = p
+		case p == gtsmodel.NotificationPolicyFilter &&
+			policy == gtsmodel.NotificationPolicyAccept:
+			policy = p
+		}
+	}
+
+	following, err := s.State.DB.IsFollowing(ctx, targetAccount.ID, originAccount.ID)
+	if err != nil {
+		return "", gtserror.Newf("error checking follow: %w", err)
+	}
+
+	if !following {
+		apply(settings.NotifPolicyNotFollowing)
+	}
+
+	if settings.NotifPolicyNotFollowers != gtsmodel.NotificationPolicyAccept {
+		followed, err := s.State.DB.IsFollowing(ctx, originAccount.ID, targetAccount.ID)
+		if err != nil {
+			return "", gtserror.Newf("error checking follow: %w", err)
+		}
+
+		if !followed {
+			apply(settings.NotifPolicyNotFollowers)
+		}
+	}
+
+	if time.Since(originAccount.CreatedAt) < notifNewAccountAge {
+		apply(settings.NotifPolicyNewAccounts)
+	}
+
+	if notificationType == gtsmodel.NotificationMention && !following &&
+		settings.NotifPolicyPrivateMentions != gtsmodel.NotificationPolicyAccept {
+		status, err := s.State.DB.GetStatusByID(
+			gtscontext.SetBarebones(ctx),
+			statusID,
+		)
+		if err != nil {
+			return "", gtserror.Newf("error getting status %s: %w", statusID, err)
+		}
+
+		// Private mentions are unsolicited,
+		// unless in reply to the target.
+		if status.Visibility == gtsmodel.VisibilityDirect &&
+			status.InReplyToAccountID != targetAccount.ID {
+			apply(settings.NotifPolicyPrivateMentions)
+		}
+	}
+
+	return policy, nil
+}
+
+// requestNotif files the given filtered notification under
+// the notification request of notifications to its target
+// from its origin, creating the request if necessary.
+func (s *Surface) requestNotif(
+	ctx context.Context,
+	notif *gtsmodel.Notification,
+) error {
+	req, err := s.State.DB.GetNotificationRequest(
+		gtscontext.SetBarebones(ctx),
+		notif.TargetAccountID,
+		notif.OriginAccountID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting notification request: %w", err)
+	}
+
+	if req == nil {
+		// First filtered notification
+		// from origin, create request.
+		req = &gtsmodel.NotificationRequest{
+			ID:            id.NewULID(),
+			AccountID:     notif.TargetAccountID,
+			FromAccountID: notif.OriginAccountID,
+			LastStatusID:  notif.StatusID,
+		}
+
+		err := s.State.DB.PutNotificationRequest(ctx, req)
+		if err != nil && !errors.Is(err, db.ErrAlreadyExists) {
+			return gtserror.Newf("error putting notification request: %w", err)
+		}
+
+		return nil
+	}
+
+	if notif.StatusID == "" {
+		// Nothing
+		// to update.
+		return nil
+	}
+
+	req.LastStatusID = notif.StatusID
+	if err := s.State.DB.UpdateNotificationRequest(ctx, req, "last_status_id"); err != nil {
+		return gtserror.Newf("error updating notification request: %w", err)
+	}
+
+	return nil
+}
+
 // Notify creates, inserts, and streams a new
 // notification to the target account if it
 // doesn't yet exist with the given parameters.
@@ -446,6 +800,55 @@ func (s *Surface) Notify(
 		return gtserror.Newf("error checking existence of notification: %w", err)
 	}
 
+	// Check whether the target has muted
+	// notifications from the origin account.
+	mute, err := s.State.DB.GetMute(
+		gtscontext.SetBarebones(ctx),
+		targetAccount.ID,
+		originAccount.ID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error checking mute: %w", err)
+	}
+
+	if mute != nil && *mute.Notifications &&
+		!mute.Expired(time.Now()) {
+		// Target doesn't want
+		// to hear from origin.
+		return nil
+	}
+
+	// Check what the target's notification
+	// policy says to do with this notification.
+	policy, err := s.notifPolicy(ctx,
+		notificationType,
+		targetAccount,
+		originAccount,
+		statusID,
+	)
+	if err != nil {
+		return gtserror.Newf("error checking notification policy: %w", err)
+	}
+
+	if policy == gtsmodel.NotificationPolicyDrop {
+		// Target doesn't
+		// want to know.
+		return nil
+	}
+
+	filtered := (policy == gtsmodel.NotificationPolicyFilter)
+
+	// Group with similar notifications, if
+	// possible, else just leave it ungrouped.
+	groupKey, err := s.notifGroupKey(ctx,
+		notificationType,
+		targetAccount.ID,
+		statusID,
+	)
+	if err != nil {
+		log.Errorf(ctx, "error getting notification group key: %v", err)
+	}
+
 	// Notification doesn't yet exist, so
 	// we need to create + store one.
 	notif := &gtsmodel.Notification{
@@ -456,16 +859,35 @@ func (s *Surface) Notify(
 		OriginAccountID:  originAccount.ID,
 		OriginAccount:    originAccount,
 		StatusID:         statusID,
+		GroupKey:         groupKey,
+		Filtered:         &filtered,
 	}
 
 	if err := s.State.DB.PutNotification(ctx, notif); err != nil {
 		return gtserror.Newf("error putting notification in database: %w", err)
 	}
 
+	if filtered {
+		// Set the notification aside for the target
+		// to review, rather than streaming it etc.
+		if err := s.requestNotif(ctx, notif); err != nil {
+			return gtserror.Newf("error filing filtered notification: %w", err)
+		}
+		return nil
+	}
+
 	// Unlock already, we're done
 	// with the state-y stuff.
 	unlock()
 
+	if notificationType == gtsmodel.NotificationMention {
+		// Mentions (which includes direct messages)
+		// may also need emailing if the user is away.
+		if err := s.scheduleAwayEmail(ctx, targetAccount); err != nil {
+			log.Errorf(ctx, "error scheduling away email: %v", err)
+		}
+	}
+
 	// Stream notification to the user.
 	filters, err := s.State.DB.GetFiltersForAccountID(ctx, targetAccount.ID)
 	if err != nil {
@@ -478,5 +900,11 @@ func (s *Surface) Notify(
 	}
 	s.Stream.Notify(ctx, targetAccount, apiNotif)
 
+	// Push notification to any Web Push
+	// subscriptions of the user's clients.
+	if err := s.WebPushSender.Send(ctx, notif, apiNotif); err != nil {
+		log.Errorf(ctx, "error sending web push notification: %v", err)
+	}
+
 	return nil
 }
@@ -24,10 +24,12 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/workers"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 )
 
 type SurfaceNotifyTestSuite struct {
@@ -39,11 +41,12 @@ func (suite *SurfaceNotifyTestSuite) TestSpamNotifs() {
 	defer suite.TearDownTestStructs(testStructs)
 
 	surface := &workers.Surface{
-		State:       testStructs.State,
-		Converter:   testStructs.TypeConverter,
-		Stream:      testStructs.Processor.Stream(),
-		Filter:      visibility.NewFilter(testStructs.State),
-		EmailSender: testStructs.EmailSender,
+		State:         testStructs.State,
+		Converter:     testStructs.TypeConverter,
+		Stream:        testStructs.Processor.Stream(),
+		Filter:        visibility.NewFilter(testStructs.State),
+		EmailSender:   testStructs.EmailSender,
+		WebPushSender: webpush.NewNoopSender(nil),
 	}
 
 	var (
@@ -110,6 +113,296 @@ func (suite *SurfaceNotifyTestSuite) TestSpamNotifs() {
 	}
 }
 
+func (suite *SurfaceNotifyTestSuite) TestNotifyMentionAway() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	surface := &workers.Surface{
+		State:         testStructs.State,
+		Converter:     testStructs.TypeConverter,
+		Stream:        testStructs.Processor.Stream(),
+		Filter:        visibility.NewFilter(testStructs.State),
+		EmailSender:   testStructs.EmailSender,
+		WebPushSender: webpush.NewNoopSender(nil),
+	}
+
+	var (
+		ctx           = context.Background()
+		targetAccount = suite.testAccounts["local_account_1"]
+		originAccount = suite.testAccounts["local_account_2"]
+		user          = new(gtsmodel.User)
+	)
+	*user = *suite.testUsers["local_account_1"]
+
+	// Target wants mentions emailed after
+	// a day away, and has been away for two.
+	settings, err := testStructs.State.DB.GetAccountSettings(ctx, targetAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	settings.EmailWhenAwayHours = 24
+	if err := testStructs.State.DB.UpdateAccountSettings(ctx, settings, "email_when_away_hours"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	user.LastActiveAt = time.Now().Add(-48 * time.Hour)
+	if err := testStructs.State.DB.UpdateUser(ctx, user, "last_active_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Refetch target with updated settings.
+	targetAccount, err = testStructs.State.DB.GetAccountByID(ctx, targetAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Mention the target twice.
+	for _, status := range []*gtsmodel.Status{
+		suite.testStatuses["local_account_2_status_1"],
+		suite.testStatuses["local_account_2_status_5"],
+	} {
+		if err := surface.Notify(ctx,
+			gtsmodel.NotificationMention,
+			targetAccount,
+			originAccount,
+			status.ID,
+		); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// Only one email should be scheduled,
+	// so cancelling twice should fail.
+	taskID := "away-email:" + user.ID
+	suite.True(testStructs.State.Workers.Scheduler.Cancel(taskID))
+	suite.False(testStructs.State.Workers.Scheduler.Cancel(taskID))
+
+	// Once the target has been active
+	// again, no email should be scheduled.
+	user.LastActiveAt = time.Now()
+	if err := testStructs.State.DB.UpdateUser(ctx, user, "last_active_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := surface.Notify(ctx,
+		gtsmodel.NotificationMention,
+		targetAccount,
+		originAccount,
+		suite.testStatuses["local_account_2_status_6"].ID,
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(testStructs.State.Workers.Scheduler.Cancel(taskID))
+}
+
+func (suite *SurfaceNotifyTestSuite) TestNotifyGroupKeys() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	surface := &workers.Surface{
+		State:         testStructs.State,
+		Converter:     testStructs.TypeConverter,
+		Stream:        testStructs.Processor.Stream(),
+		Filter:        visibility.NewFilter(testStructs.State),
+		EmailSender:   testStructs.EmailSender,
+		WebPushSender: webpush.NewNoopSender(nil),
+	}
+
+	var (
+		ctx           = context.Background()
+		targetAccount = suite.testAccounts["local_account_1"]
+		status        = suite.testStatuses["local_account_1_status_1"]
+	)
+
+	// Fave the target's status from two accounts,
+	// follow them, and mention them in a status.
+	for _, originAccount := range []*gtsmodel.Account{
+		suite.testAccounts["local_account_2"],
+		suite.testAccounts["remote_account_1"],
+	} {
+		if err := surface.Notify(ctx,
+			gtsmodel.NotificationFave,
+			targetAccount,
+			originAccount,
+			status.ID,
+		); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	if err := surface.Notify(ctx,
+		gtsmodel.NotificationFollow,
+		targetAccount,
+		suite.testAccounts["local_account_2"],
+		"",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := surface.Notify(ctx,
+		gtsmodel.NotificationMention,
+		targetAccount,
+		suite.testAccounts["local_account_2"],
+		suite.testStatuses["local_account_2_status_1"].ID,
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	notifs, err := testStructs.State.DB.GetAccountNotifications(
+		gtscontext.SetBarebones(ctx),
+		targetAccount.ID,
+		"", "", "", 4, nil,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if !suite.Len(notifs, 4) {
+		suite.FailNow("")
+	}
+
+	// IDs created within the same millisecond
+	// aren't strictly ordered, so sort by type.
+	byType := make(map[gtsmodel.NotificationType][]*gtsmodel.Notification, 3)
+	for _, notif := range notifs {
+		byType[notif.NotificationType] = append(byType[notif.NotificationType], notif)
+	}
+
+	// The mention isn't grouped, the follow
+	// is grouped by type, and the faves by
+	// type and status, sharing one group key.
+	faves := byType[gtsmodel.NotificationFave]
+	if !suite.Len(faves, 2) {
+		suite.FailNow("")
+	}
+	suite.Empty(byType[gtsmodel.NotificationMention][0].GroupKey)
+	suite.Regexp(`^follow-[0-9]+$`, byType[gtsmodel.NotificationFollow][0].GroupKey)
+	suite.Regexp(`^favourite-`+status.ID+`-[0-9]+$`, faves[0].GroupKey)
+	suite.Equal(faves[0].GroupKey, faves[1].GroupKey)
+}
+
+func (suite *SurfaceNotifyTestSuite) TestNotifyPolicy() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	surface := &workers.Surface{
+		State:         testStructs.State,
+		Converter:     testStructs.TypeConverter,
+		Stream:        testStructs.Processor.Stream(),
+		Filter:        visibility.NewFilter(testStructs.State),
+		EmailSender:   testStructs.EmailSender,
+		WebPushSender: webpush.NewNoopSender(nil),
+	}
+
+	var (
+		ctx           = context.Background()
+		targetAccount = new(gtsmodel.Account)
+		status        = suite.testStatuses["local_account_1_status_1"]
+	)
+	*targetAccount = *suite.testAccounts["local_account_1"]
+
+	// Filter notifications from accounts the target doesn't follow.
+	settings, err := testStructs.State.DB.GetAccountSettings(ctx, targetAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	settings.NotifPolicyNotFollowing = gtsmodel.NotificationPolicyFilter
+	if err := testStructs.State.DB.UpdateAccountSettings(ctx, settings); err != nil {
+		suite.FailNow(err.Error())
+	}
+	targetAccount.Settings = settings
+
+	// Fave the target's status from a followed
+	// account, and from a not followed account.
+	for _, originAccount := range []*gtsmodel.Account{
+		suite.testAccounts["local_account_2"],
+		suite.testAccounts["remote_account_1"],
+	} {
+		if err := surface.Notify(ctx,
+			gtsmodel.NotificationFave,
+			targetAccount,
+			originAccount,
+			status.ID,
+		); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// Only the followed account's fave is notified as usual.
+	notifs, err := testStructs.State.DB.GetAccountNotifications(
+		gtscontext.SetBarebones(ctx),
+		targetAccount.ID,
+		"", "", "", 2, nil,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(suite.testAccounts["local_account_2"].ID, notifs[0].OriginAccountID)
+	suite.Equal(gtsmodel.NotificationFave, notifs[0].NotificationType)
+	suite.NotEqual(suite.testAccounts["remote_account_1"].ID, notifs[1].OriginAccountID)
+
+	// The other fave is filtered, pending a notification request.
+	filtered, err := testStructs.State.DB.GetFilteredNotifications(ctx,
+		targetAccount.ID,
+		suite.testAccounts["remote_account_1"].ID,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(filtered, 1)
+
+	req, err := testStructs.State.DB.GetNotificationRequest(ctx,
+		targetAccount.ID,
+		suite.testAccounts["remote_account_1"].ID,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(*req.Accepted)
+
+	// Drop notifications from accounts the target doesn't
+	// follow instead: another fave isn't stored at all.
+	settings.NotifPolicyNotFollowing = gtsmodel.NotificationPolicyDrop
+	if err := surface.Notify(ctx,
+		gtsmodel.NotificationFave,
+		targetAccount,
+		suite.testAccounts["remote_account_2"],
+		status.ID,
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = testStructs.State.DB.GetNotification(ctx,
+		gtsmodel.NotificationFave,
+		targetAccount.ID,
+		suite.testAccounts["remote_account_2"].ID,
+		status.ID,
+	)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Accepting the request moves the filtered
+	// fave to the target's notifications.
+	if errWithCode := testStructs.Processor.Timeline().NotificationRequestsAccept(ctx,
+		targetAccount,
+		[]string{req.ID},
+	); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	count, err := testStructs.State.DB.CountFilteredNotifications(ctx, targetAccount.ID, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Zero(count)
+
+	notif, err := testStructs.State.DB.GetNotificationByID(ctx, filtered[0].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(*notif.Filtered)
+}
+
 func TestSurfaceNotifyTestSuite(t *testing.T) {
 	suite.Run(t, new(SurfaceNotifyTestSuite))
 }
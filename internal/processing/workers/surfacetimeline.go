@@ -20,6 +20,8 @@ package workers
 import (
 	"context"
 	"errors"
+	"slices"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
@@ -32,11 +34,12 @@ import (
 )
 
 // timelineAndNotifyStatus inserts the given status into the HOME
-// and LIST timelines of accounts that follow the status author.
+// and LIST timelines of accounts that follow the status author,
+// and into the HOME timelines of accounts following its hashtags.
 //
 // It will also handle notifications for any mentions attached to
-// the account, and notifications for any local accounts that want
-// to know when this account posts.
+// the account, notifications for any local accounts that want
+// to know when this account posts, and direct conversations.
 func (s *Surface) timelineAndNotifyStatus(ctx context.Context, status *gtsmodel.Status) error {
 	// Ensure status fully populated; including account, mentions, etc.
 	if err := s.State.DB.PopulateStatus(ctx, status); err != nil {
@@ -67,11 +70,22 @@ func (s *Surface) timelineAndNotifyStatus(ctx context.Context, status *gtsmodel.
 		return gtserror.Newf("error timelining status %s for followers: %w", status.ID, err)
 	}
 
+	// Timeline the status for each local account following one of its
+	// hashtags, who didn't already get it by following the author.
+	if err := s.timelineStatusForTagFollowers(ctx, status, follows); err != nil {
+		return gtserror.Newf("error timelining status %s for tag followers: %w", status.ID, err)
+	}
+
 	// Notify each local account that's mentioned by this status.
 	if err := s.notifyMentions(ctx, status); err != nil {
 		return gtserror.Newf("error notifying status mentions for status %s: %w", status.ID, err)
 	}
 
+	// Update the conversations of local accounts taking part, if direct.
+	if err := s.updateConversationsForStatus(ctx, status); err != nil {
+		return gtserror.Newf("error updating conversations for status %s: %w", status.ID, err)
+	}
+
 	return nil
 }
 
@@ -89,9 +103,19 @@ func (s *Surface) timelineAndNotifyStatusForFollowers(
 		errs  gtserror.MultiError
 		boost = status.BoostOfID != ""
 		reply = status.InReplyToURI != ""
+
+		// Time of the author's previous top-level
+		// post, loaded only if a follower needs it.
+		prevPostAt *time.Time
 	)
 
 	for _, follow := range follows {
+		if boost && !*follow.ShowReblogs {
+			// Follower doesn't want to
+			// see boosts from this account.
+			continue
+		}
+
 		// Check to see if the status is timelineable for this follower,
 		// taking account of its visibility, who it replies to, and, if
 		// it's a reblog, whether follower account wants to see reblogs.
@@ -119,7 +143,7 @@ func (s *Surface) timelineAndNotifyStatusForFollowers(
 
 		// Add status to any relevant lists
 		// for this follow, if applicable.
-		s.listTimelineStatusForFollow(
+		exclusive := s.listTimelineStatusForFollow(
 			ctx,
 			status,
 			follow,
@@ -127,6 +151,13 @@ func (s *Surface) timelineAndNotifyStatusForFollowers(
 			filters,
 		)
 
+		if exclusive {
+			// Follow is in at least one exclusive
+			// list, so status should only appear
+			// in list timelines, not in home.
+			continue
+		}
+
 		// Add status to home timeline for owner
 		// of this follow, if applicable.
 		homeTimelined, err := s.timelineStatus(
@@ -149,14 +180,19 @@ func (s *Surface) timelineAndNotifyStatusForFollowers(
 			continue
 		}
 
-		if !*follow.Notify {
-			// This follower doesn't have notifs
-			// set for this account's new posts.
+		if boost || reply {
+			// Don't notify for boosts or replies.
 			continue
 		}
 
-		if boost || reply {
-			// Don't notify for boosts or replies.
+		if !*follow.Notify {
+			// This follower doesn't have notifs
+			// set for this account's new posts,
+			// but they may still want to know
+			// when it posts after a long break.
+			if err := s.notifyNewFrom(ctx, status, follow, &prevPostAt); err != nil {
+				errs.Appendf("error notifying account %s about returning poster: %w", follow.AccountID, err)
+			}
 			continue
 		}
 
@@ -181,15 +217,98 @@ func (s *Surface) timelineAndNotifyStatusForFollowers(
 	return errs.Combine()
 }
 
+// timelineStatusForTagFollowers adds the given status to the
+// home timelines of local accounts following any of its hashtags,
+// skipping accounts in the given slice of follows, since they've
+// already been handled by timelineAndNotifyStatusForFollowers.
+//
+// Only public, original (non-boost) statuses are eligible, the
+// same as for the tag timeline. No notifications are created.
+func (s *Surface) timelineStatusForTagFollowers(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	follows []*gtsmodel.Follow,
+) error {
+	if len(status.TagIDs) == 0 ||
+		status.BoostOfID != "" ||
+		status.Visibility != gtsmodel.VisibilityPublic {
+		// Not eligible, nothing to do.
+		return nil
+	}
+
+	// Get all local accounts following any of this status' tags.
+	accountIDs, err := s.State.DB.GetTagFollowerAccountIDs(ctx, status.TagIDs)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting tag followers of status %s: %w", status.ID, err)
+	}
+
+	var errs gtserror.MultiError
+
+	for _, accountID := range accountIDs {
+		if slices.ContainsFunc(follows, func(follow *gtsmodel.Follow) bool {
+			return follow.AccountID == accountID
+		}) {
+			// Already handled as a follower
+			// (or author) of this account.
+			continue
+		}
+
+		account, err := s.State.DB.GetAccountByID(ctx, accountID)
+		if err != nil {
+			errs.Appendf("error getting tag follower account %s: %w", accountID, err)
+			continue
+		}
+
+		// Check status is visible to this account, in the
+		// same way as it would be on the tag timeline.
+		timelineable, err := s.Filter.StatusTagTimelineable(ctx, account, status)
+		if err != nil {
+			errs.Appendf("error checking status %s tagtimelineability: %w", status.ID, err)
+			continue
+		}
+
+		if !timelineable {
+			// Nothing to do.
+			continue
+		}
+
+		filters, err := s.State.DB.GetFiltersForAccountID(ctx, accountID)
+		if err != nil {
+			errs.Appendf("couldn't retrieve filters for account %s: %w", accountID, err)
+			continue
+		}
+
+		// Add status to home timeline
+		// for this tag follower.
+		if _, err := s.timelineStatus(
+			ctx,
+			s.State.Timelines.Home.IngestOne,
+			accountID, // home timelines are keyed by account ID
+			account,
+			status,
+			stream.TimelineHome,
+			filters,
+		); err != nil {
+			errs.Appendf("error home timelining status for tag follower: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
 // listTimelineStatusForFollow puts the given status
 // in any eligible lists owned by the given follower.
+//
+// Returns true if the follow belongs to any exclusive
+// list, in which case the status should be kept out
+// of the follower's home timeline.
 func (s *Surface) listTimelineStatusForFollow(
 	ctx context.Context,
 	status *gtsmodel.Status,
 	follow *gtsmodel.Follow,
 	errs *gtserror.MultiError,
 	filters []*gtsmodel.Filter,
-) {
+) (exclusive bool) {
 	// To put this status in appropriate list timelines,
 	// we need to get each listEntry that pertains to
 	// this follow. Then, we want to iterate through all
@@ -205,12 +324,30 @@ func (s *Surface) listTimelineStatusForFollow(
 	)
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
 		errs.Appendf("error getting list entries: %w", err)
-		return
+		return false
 	}
 
 	// Check eligibility for each list entry (if any).
 	for _, listEntry := range listEntries {
-		eligible, err := s.listEligible(ctx, listEntry, status)
+		list, err := s.State.DB.GetListByID(
+			// We only need the list itself.
+			gtscontext.SetBarebones(ctx),
+			listEntry.ListID,
+		)
+		if err != nil {
+			errs.Appendf("db error getting list %s: %w", listEntry.ListID, err)
+			continue
+		}
+
+		if *list.Exclusive {
+			// Regardless of whether the status
+			// is eligible for this list, posts
+			// from members of exclusive lists
+			// never go in the home timeline.
+			exclusive = true
+		}
+
+		eligible, err := s.listEligible(ctx, list, status)
 		if err != nil {
 			errs.Appendf("error checking list eligibility: %w", err)
 			continue
@@ -237,14 +374,16 @@ func (s *Surface) listTimelineStatusForFollow(
 			// implicit continue
 		}
 	}
+
+	return exclusive
 }
 
 // listEligible checks if the given status is eligible
-// for inclusion in the list that that the given listEntry
-// belongs to, based on the replies policy of the list.
+// for inclusion in the given list, based on the replies
+// policy of the list.
 func (s *Surface) listEligible(
 	ctx context.Context,
-	listEntry *gtsmodel.ListEntry,
+	list *gtsmodel.List,
 	status *gtsmodel.Status,
 ) (bool, error) {
 	if status.InReplyToURI == "" {
@@ -259,18 +398,8 @@ func (s *Surface) listEligible(
 		return false, nil
 	}
 
-	// Status is a reply to a known account.
-	// We need to fetch the list that this
-	// entry belongs to, in order to check
-	// the list's replies policy.
-	list, err := s.State.DB.GetListByID(
-		ctx, listEntry.ListID,
-	)
-	if err != nil {
-		err := gtserror.Newf("db error getting list %s: %w", listEntry.ListID, err)
-		return false, err
-	}
-
+	// Status is a reply to a known account,
+	// check the list's replies policy.
 	switch list.RepliesPolicy {
 	case gtsmodel.RepliesPolicyNone:
 		// This list should not show
@@ -291,7 +420,7 @@ func (s *Surface) listEligible(
 		if err != nil {
 			err := gtserror.Newf(
 				"db error checking if account %s in list %s: %w",
-				status.InReplyToAccountID, listEntry.ListID, err,
+				status.InReplyToAccountID, list.ID, err,
 			)
 			return false, err
 		}
@@ -480,7 +609,7 @@ func (s *Surface) timelineStatusUpdateForFollowers(
 
 		// Add status to any relevant lists
 		// for this follow, if applicable.
-		s.listTimelineStatusUpdateForFollow(
+		exclusive := s.listTimelineStatusUpdateForFollow(
 			ctx,
 			status,
 			follow,
@@ -488,6 +617,13 @@ func (s *Surface) timelineStatusUpdateForFollowers(
 			filters,
 		)
 
+		if exclusive {
+			// Follow is in at least one exclusive
+			// list, so status should only appear
+			// in list timelines, not in home.
+			continue
+		}
+
 		// Add status to home timeline for owner
 		// of this follow, if applicable.
 		err = s.timelineStreamStatusUpdate(
@@ -508,13 +644,15 @@ func (s *Surface) timelineStatusUpdateForFollowers(
 
 // listTimelineStatusUpdateForFollow pushes edits of the given status
 // into any eligible lists streams opened by the given follower.
+//
+// Returns true if the follow belongs to any exclusive list.
 func (s *Surface) listTimelineStatusUpdateForFollow(
 	ctx context.Context,
 	status *gtsmodel.Status,
 	follow *gtsmodel.Follow,
 	errs *gtserror.MultiError,
 	filters []*gtsmodel.Filter,
-) {
+) (exclusive bool) {
 	// To put this status in appropriate list timelines,
 	// we need to get each listEntry that pertains to
 	// this follow. Then, we want to iterate through all
@@ -530,12 +668,30 @@ func (s *Surface) listTimelineStatusUpdateForFollow(
 	)
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
 		errs.Appendf("error getting list entries: %w", err)
-		return
+		return false
 	}
 
 	// Check eligibility for each list entry (if any).
 	for _, listEntry := range listEntries {
-		eligible, err := s.listEligible(ctx, listEntry, status)
+		list, err := s.State.DB.GetListByID(
+			// We only need the list itself.
+			gtscontext.SetBarebones(ctx),
+			listEntry.ListID,
+		)
+		if err != nil {
+			errs.Appendf("db error getting list %s: %w", listEntry.ListID, err)
+			continue
+		}
+
+		if *list.Exclusive {
+			// Regardless of whether the status
+			// is eligible for this list, posts
+			// from members of exclusive lists
+			// never go in the home timeline.
+			exclusive = true
+		}
+
+		eligible, err := s.listEligible(ctx, list, status)
 		if err != nil {
 			errs.Appendf("error checking list eligibility: %w", err)
 			continue
@@ -560,6 +716,8 @@ func (s *Surface) listTimelineStatusUpdateForFollow(
 			// implicit continue
 		}
 	}
+
+	return exclusive
 }
 
 // timelineStatusUpdate streams the edited status to the user using the
@@ -20,6 +20,7 @@ package workers
 import (
 	"context"
 	"errors"
+	"slices"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -29,6 +30,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/account"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/media"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/search"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 )
 
@@ -38,6 +40,7 @@ type utils struct {
 	state   *state.State
 	media   *media.Processor
 	account *account.Processor
+	search  *search.Processor
 	surface *Surface
 }
 
@@ -52,6 +55,22 @@ func (u *utils) wipeStatus(
 ) error {
 	var errs gtserror.MultiError
 
+	// Previous revisions of the status may have
+	// had other attachments, include those too.
+	attachmentIDs := statusToDelete.AttachmentIDs
+	edits, err := u.state.DB.GetStatusEditsByStatusID(ctx, statusToDelete.ID)
+	if err != nil {
+		errs.Appendf("error fetching status edits: %w", err)
+	}
+
+	for _, edit := range edits {
+		for _, id := range edit.AttachmentIDs {
+			if !slices.Contains(attachmentIDs, id) {
+				attachmentIDs = append(attachmentIDs, id)
+			}
+		}
+	}
+
 	// Either delete all attachments for this status,
 	// or simply unattach + clean them separately later.
 	//
@@ -60,14 +79,14 @@ func (u *utils) wipeStatus(
 	// status immediately (in case of delete + redraft)
 	if deleteAttachments {
 		// todo:u.state.DB.DeleteAttachmentsForStatus
-		for _, id := range statusToDelete.AttachmentIDs {
+		for _, id := range attachmentIDs {
 			if err := u.media.Delete(ctx, id); err != nil {
 				errs.Appendf("error deleting media: %w", err)
 			}
 		}
 	} else {
 		// todo:u.state.DB.UnattachAttachmentsForStatus
-		for _, id := range statusToDelete.AttachmentIDs {
+		for _, id := range attachmentIDs {
 			if _, err := u.media.Unattach(ctx, statusToDelete.Account, id); err != nil {
 				errs.Appendf("error unattaching media: %w", err)
 			}
@@ -82,11 +101,21 @@ func (u *utils) wipeStatus(
 		}
 	}
 
+	// delete all previous revisions of this status
+	if err := u.state.DB.DeleteStatusEditsByStatusID(ctx, statusToDelete.ID); err != nil {
+		errs.Appendf("error deleting status edits: %w", err)
+	}
+
 	// delete all notification entries generated by this status
 	if err := u.state.DB.DeleteNotificationsForStatus(ctx, statusToDelete.ID); err != nil {
 		errs.Appendf("error deleting status notifications: %w", err)
 	}
 
+	// remove this status from the search index
+	if err := u.search.UnindexStatus(ctx, statusToDelete.ID); err != nil {
+		errs.Appendf("error unindexing status: %w", err)
+	}
+
 	// delete all bookmarks that point to this status
 	if err := u.state.DB.DeleteStatusBookmarksForStatus(ctx, statusToDelete.ID); err != nil {
 		errs.Appendf("error deleting status bookmarks: %w", err)
@@ -97,6 +126,16 @@ func (u *utils) wipeStatus(
 		errs.Appendf("error deleting status faves: %w", err)
 	}
 
+	// delete all interaction requests of, or for, this status
+	if err := u.state.DB.DeleteInteractionRequestsByStatusID(ctx, statusToDelete.ID); err != nil {
+		errs.Appendf("error deleting status interaction requests: %w", err)
+	}
+
+	// delete all emoji reactions to this status
+	if err := u.state.DB.DeleteStatusReactionsForStatus(ctx, statusToDelete.ID); err != nil {
+		errs.Appendf("error deleting status reactions: %w", err)
+	}
+
 	if pollID := statusToDelete.PollID; pollID != "" {
 		// Delete this poll by ID from the database.
 		if err := u.state.DB.DeletePollByID(ctx, pollID); err != nil {
@@ -142,6 +181,11 @@ func (u *utils) wipeStatus(
 		errs.Appendf("error deleting status: %w", err)
 	}
 
+	// update any conversations this status was the latest in
+	if err := u.surface.updateConversationsForDeletedStatus(ctx, statusToDelete); err != nil {
+		errs.Appendf("error updating conversations: %w", err)
+	}
+
 	return errs.Combine()
 }
 
@@ -271,6 +315,35 @@ func (u *utils) incrementStatusesCount(
 	return nil
 }
 
+// incrementTagHistory records use of the tags in the
+// given status in daily tag history, used for trends.
+// Only public, original statuses of local accounts count.
+func (u *utils) incrementTagHistory(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	status *gtsmodel.Status,
+) error {
+	if !*status.Local ||
+		status.BoostOfID != "" ||
+		status.Visibility != gtsmodel.VisibilityPublic {
+		return nil
+	}
+
+	// Lock on this account so concurrent statuses
+	// don't both count the account as new today.
+	unlock := u.state.ProcessingLocks.Lock(account.URI)
+	defer unlock()
+
+	var errs gtserror.MultiError
+	for _, tagID := range status.TagIDs {
+		if err := u.state.DB.IncrementTagHistory(ctx, tagID, status); err != nil {
+			errs.Appendf("db error incrementing history of tag %s: %w", tagID, err)
+		}
+	}
+
+	return errs.Combine()
+}
+
 func (u *utils) decrementStatusesCount(
 	ctx context.Context,
 	account *gtsmodel.Account,
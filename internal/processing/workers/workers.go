@@ -23,15 +23,19 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/account"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/media"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/search"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/status"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/stream"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 	"github.com/superseriousbusiness/gotosocial/internal/workers"
 )
 
 type Processor struct {
 	clientAPI clientAPI
 	fediAPI   fediAPI
+	scheduled scheduledStatuses
 	workers   *workers.Workers
 }
 
@@ -41,9 +45,12 @@ func New(
 	converter *typeutils.Converter,
 	filter *visibility.Filter,
 	emailSender email.Sender,
+	webPushSender webpush.Sender,
 	account *account.Processor,
 	media *media.Processor,
 	stream *stream.Processor,
+	status *status.Processor,
+	search *search.Processor,
 ) Processor {
 	// Init federate logic
 	// wrapper struct.
@@ -56,11 +63,12 @@ func New(
 	// Init surface logic
 	// wrapper struct.
 	surface := &Surface{
-		State:       state,
-		Converter:   converter,
-		Stream:      stream,
-		Filter:      filter,
-		EmailSender: emailSender,
+		State:         state,
+		Converter:     converter,
+		Stream:        stream,
+		Filter:        filter,
+		EmailSender:   emailSender,
+		WebPushSender: webPushSender,
 	}
 
 	// Init shared util funcs.
@@ -68,6 +76,7 @@ func New(
 		state:   state,
 		media:   media,
 		account: account,
+		search:  search,
 		surface: surface,
 	}
 
@@ -82,11 +91,17 @@ func New(
 			utils:     utils,
 		},
 		fediAPI: fediAPI{
-			state:    state,
-			surface:  surface,
-			federate: federate,
-			account:  account,
-			utils:    utils,
+			state:     state,
+			converter: converter,
+			surface:   surface,
+			federate:  federate,
+			account:   account,
+			utils:     utils,
+		},
+		scheduled: scheduledStatuses{
+			state:     state,
+			converter: converter,
+			status:    status,
 		},
 	}
 }
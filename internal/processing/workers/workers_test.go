@@ -30,6 +30,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/stream"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -159,7 +160,7 @@ func (suite *WorkersTestSuite) SetupTestStructs() *TestStructs {
 	oauthServer := testrig.NewTestOauthServer(db)
 	emailSender := testrig.NewEmailSender("../../../web/template/", nil)
 
-	processor := processing.NewProcessor(cleaner.New(&state), typeconverter, federator, oauthServer, mediaManager, &state, emailSender)
+	processor := processing.NewProcessor(cleaner.New(&state), typeconverter, federator, oauthServer, mediaManager, &state, emailSender, webpush.NewNoopSender(nil))
 	testrig.StartWorkers(&state, processor.Workers())
 
 	testrig.StandardDBSetup(db, suite.testAccounts)
@@ -71,6 +71,7 @@ const (
 	followPath        = userPathPrefix + `/` + follow + `/(` + ulid + `)$`
 	likePath          = userPathPrefix + `/` + liked + `/(` + ulid + `)$`
 	statusesPath      = userPathPrefix + `/` + statuses + `/(` + ulid + `)$`
+	statusesWebPath   = userWebPathPrefix + `/` + statuses + `/(` + ulid + `)$`
 	blockPath         = userPathPrefix + `/` + blocks + `/(` + ulid + `)$`
 	reportPath        = `^/?` + reports + `/(` + ulid + `)$`
 	filePath          = `^/?(` + ulid + `)/([a-z]+)/([a-z]+)/(` + ulid + `)\.([a-z0-9]+)$`
@@ -150,6 +151,10 @@ var (
 	// The regex can be played with here: https://regex101.com/r/G9zuxQ/1
 	StatusesPath = regexp.MustCompile(statusesPath)
 
+	// StatusesWebPath parses a path that validates and captures the username part and the ulid part
+	// from eg /@example_username/statuses/01F7XT5JZW1WMVSW1KADS8PVDH
+	StatusesWebPath = regexp.MustCompile(statusesWebPath)
+
 	// BlockPath parses a path that validates and captures the username part and the ulid part
 	// from eg /users/example_username/blocks/01F7XT5JZW1WMVSW1KADS8PVDH
 	BlockPath = regexp.MustCompile(blockPath)
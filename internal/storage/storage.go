@@ -39,6 +39,11 @@ import (
 const (
 	urlCacheTTL             = time.Hour * 24
 	urlCacheExpiryFrequency = time.Minute * 5
+
+	// UploadsDir is the directory beneath the local storage
+	// base path in which in-progress media uploads are spooled
+	// to disk. This is used regardless of the storage backend.
+	UploadsDir = "uploads"
 )
 
 // PresignedURL represents a pre signed S3 URL with
@@ -19,6 +19,7 @@ package stream
 
 import (
 	"context"
+	"errors"
 	"maps"
 	"slices"
 	"sync"
@@ -42,6 +43,22 @@ const (
 	// user's timeline has been edited (yes this
 	// is a confusing name, blame Mastodon ...).
 	EventTypeStatusUpdate = "status.update"
+
+	// EventTypeAnnouncement -- an instance
+	// announcement has been published or edited.
+	EventTypeAnnouncement = "announcement"
+
+	// EventTypeAnnouncementReaction -- the
+	// reactions to an announcement changed.
+	EventTypeAnnouncementReaction = "announcement.reaction"
+
+	// EventTypeAnnouncementDelete -- an instance
+	// announcement has been deleted or unpublished.
+	EventTypeAnnouncementDelete = "announcement.delete"
+
+	// EventTypeConversation -- a direct
+	// conversation of the user was updated.
+	EventTypeConversation = "conversation"
 )
 
 const (
@@ -84,13 +101,58 @@ var AllStatusTimelines = []string{
 	TimelineList,
 }
 
+var (
+	// ErrTooManyAccountStreams is returned when opening a stream
+	// would exceed the limit of open streams for one account.
+	ErrTooManyAccountStreams = errors.New("too many open streams for account")
+
+	// ErrTooManyStreams is returned when opening a stream
+	// would exceed the limit of open streams in total.
+	ErrTooManyStreams = errors.New("too many open streams")
+)
+
 type Streams struct {
-	streams map[string][]*Stream
-	mutex   sync.Mutex
+	// MaxPerAccount is the maximum number of streams
+	// that may be open for one account at a time.
+	// 0 or less means no limit.
+	MaxPerAccount int
+
+	// MaxTotal is the maximum number of streams
+	// that may be open in total at a time.
+	// 0 or less means no limit.
+	MaxTotal int
+
+	streams  map[string][]*Stream
+	total    int
+	rejected atomic.Uint64
+	mutex    sync.Mutex
+}
+
+// Stats contains statistics about Streams.
+type Stats struct {
+	// Number of currently open streams.
+	Open int
+
+	// Number of streams that were not
+	// opened because of the limits.
+	Rejected uint64
+}
+
+// Stats returns current statistics about the streams.
+func (s *Streams) Stats() Stats {
+	s.mutex.Lock()
+	open := s.total
+	s.mutex.Unlock()
+
+	return Stats{
+		Open:     open,
+		Rejected: s.rejected.Load(),
+	}
 }
 
 // Open will open open a new Stream for given account ID and stream types, the given context will be passed to Stream.
-func (s *Streams) Open(accountID string, streamTypes ...string) *Stream {
+// If opening the stream would exceed MaxPerAccount or MaxTotal, ErrTooManyAccountStreams or ErrTooManyStreams is returned.
+func (s *Streams) Open(accountID string, streamTypes ...string) (*Stream, error) {
 	if len(streamTypes) == 0 {
 		panic("no stream types given")
 	}
@@ -103,9 +165,6 @@ func (s *Streams) Open(accountID string, streamTypes ...string) *Stream {
 		str.Subscribe(streamType)
 	}
 
-	// TODO: add configurable
-	// max streams per account.
-
 	// Acquire lock.
 	s.mutex.Lock()
 
@@ -114,10 +173,24 @@ func (s *Streams) Open(accountID string, streamTypes ...string) *Stream {
 		s.streams = make(map[string][]*Stream)
 	}
 
-	// Add new stream for account.
+	// Check stream limits before adding.
 	strs := s.streams[accountID]
+	switch {
+	case s.MaxPerAccount > 0 && len(strs) >= s.MaxPerAccount:
+		s.mutex.Unlock()
+		s.rejected.Add(1)
+		return nil, ErrTooManyAccountStreams
+
+	case s.MaxTotal > 0 && s.total >= s.MaxTotal:
+		s.mutex.Unlock()
+		s.rejected.Add(1)
+		return nil, ErrTooManyStreams
+	}
+
+	// Add new stream for account.
 	strs = append(strs, str)
 	s.streams[accountID] = strs
+	s.total++
 
 	// Register close callback
 	// to remove stream from our
@@ -128,14 +201,21 @@ func (s *Streams) Open(accountID string, streamTypes ...string) *Stream {
 		strs = slices.DeleteFunc(strs, func(s *Stream) bool {
 			return s == str // remove 'str' ptr
 		})
-		s.streams[accountID] = strs
+		if len(strs) == 0 {
+			// Don't keep empty
+			// slices around.
+			delete(s.streams, accountID)
+		} else {
+			s.streams[accountID] = strs
+		}
+		s.total--
 		s.mutex.Unlock()
 	}
 
 	// Done with lock.
 	s.mutex.Unlock()
 
-	return str
+	return str, nil
 }
 
 // Post will post the given message to all streams of given account ID matching type.
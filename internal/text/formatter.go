@@ -48,8 +48,9 @@ func NewFormatter(db db.DB) *Formatter {
 }
 
 type FormatResult struct {
-	HTML     string
-	Mentions []*gtsmodel.Mention
-	Tags     []*gtsmodel.Tag
-	Emojis   []*gtsmodel.Emoji
+	HTML        string
+	Mentions    []*gtsmodel.Mention
+	Tags        []*gtsmodel.Tag
+	Emojis      []*gtsmodel.Emoji
+	Attachments []*gtsmodel.MediaAttachment
 }
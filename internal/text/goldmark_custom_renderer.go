@@ -46,14 +46,15 @@ import (
 //
 // The custom renderer extracts and re-renders mentions, hashtags,
 // and emojis that are encountered during parsing, writing out valid
-// HTML representations of these elements.
+// HTML representations of these elements. If images is set, it also
+// renders markdown images of the author's attachments inline.
 //
 // The customRenderer has the following side effects:
 //
 //   - May use its db connection to retrieve existing and/or
 //     store new mentions, hashtags, and emojis.
 //   - May update its *FormatResult to append discovered
-//     mentions, hashtags, and emojis to it.
+//     mentions, hashtags, emojis, and attachments to it.
 type customRenderer struct {
 	ctx          context.Context
 	db           db.DB
@@ -62,6 +63,7 @@ type customRenderer struct {
 	statusID     string
 	emojiOnly    bool
 	result       *FormatResult
+	images       *inlineImages
 }
 
 func (cr *customRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
@@ -97,6 +99,16 @@ func (cr *customRenderer) Extend(markdown goldmark.Markdown) {
 			mdutil.Prioritized(cr, prio),
 		),
 	)
+
+	if cr.images != nil {
+		// Add image renderer, with a higher priority
+		// than the default HTML renderer so it's used.
+		markdown.Renderer().AddOptions(
+			renderer.WithNodeRenderers(
+				mdutil.Prioritized(imageRenderer{cr}, prio-1),
+			),
+		)
+	}
 }
 
 /*
@@ -0,0 +1,215 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package text
+
+import (
+	"errors"
+	"html"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/regexes"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+	mdutil "github.com/yuin/goldmark/util"
+)
+
+// inlineImages holds the <img> elements for markdown images
+// referencing the author's own attachments. These are rendered
+// as placeholders, and only swapped in for the placeholders
+// after sanitization, as the sanitizer would otherwise either
+// have to strip them, or let through <img> elements from raw
+// HTML in the input. The placeholders include a random nonce,
+// so they can't be guessed and written into the input.
+type inlineImages struct {
+	nonce string
+	imgs  []string
+}
+
+func newInlineImages() *inlineImages {
+	return &inlineImages{nonce: id.NewULID()}
+}
+
+// placeholder returns the placeholder for the i'th image.
+func (ii *inlineImages) placeholder(i int) string {
+	return "gts-inline-image-" + ii.nonce + "-" + strconv.Itoa(i) + "-"
+}
+
+// add adds the given <img> element, returning its placeholder.
+func (ii *inlineImages) add(img string) string {
+	ii.imgs = append(ii.imgs, img)
+	return ii.placeholder(len(ii.imgs) - 1)
+}
+
+// replace replaces all image placeholders
+// in the given HTML with their images.
+func (ii *inlineImages) replace(in string) string {
+	for i, img := range ii.imgs {
+		in = strings.ReplaceAll(in, ii.placeholder(i), img)
+	}
+	return in
+}
+
+// imageRenderer fulfils renderer.NodeRenderer, rendering
+// markdown images with its customRenderer. It's registered
+// separately from the customRenderer, with a higher priority,
+// so that it replaces the default HTML image rendering.
+type imageRenderer struct{ *customRenderer }
+
+func (ir imageRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindImage, ir.renderImage)
+}
+
+// renderImage takes an image ast.Node and renders it either as a
+// placeholder for an inline image, if it references one of the
+// author's attachments, or as a plain link to its destination.
+func (cr *customRenderer) renderImage(
+	w mdutil.BufWriter,
+	source []byte,
+	node ast.Node,
+	entering bool,
+) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkSkipChildren, nil
+	}
+
+	// This function is registered
+	// only for ast.KindImage, and
+	// should not be called for
+	// any other node type.
+	n, ok := node.(*ast.Image)
+	if !ok {
+		log.Panic(cr.ctx, "type assertion failed")
+	}
+
+	dest := string(n.Destination)
+	alt := string(n.Text(source))
+
+	var text string
+	if attachment := cr.handleImage(dest); attachment != nil {
+		if alt == "" {
+			// Fall back to
+			// description.
+			alt = attachment.Description
+		}
+
+		// Render image, eg. `![a sloth](https://example.org/fileserver/.../01F8MH8RMYQ6MSNY3JM2XT1CQ5.jpg)` becomes:
+		// `<img src="https://example.org/fileserver/.../01F8MH8RMYQ6MSNY3JM2XT1CQ5.jpg" alt="a sloth" class="inline-image">`
+		var b strings.Builder
+		b.WriteString(`<img src="`)
+		b.WriteString(html.EscapeString(attachment.URL))
+		b.WriteString(`" alt="`)
+		b.WriteString(html.EscapeString(alt))
+		b.WriteString(`" class="inline-image">`)
+		text = cr.images.add(b.String())
+	} else {
+		// Not an inline image, just link to it.
+		var b strings.Builder
+		b.WriteString(`<a href="`)
+		b.WriteString(html.EscapeString(dest))
+		b.WriteString(`">`)
+		if alt == "" {
+			alt = dest
+		}
+		b.WriteString(html.EscapeString(alt))
+		b.WriteString(`</a>`)
+		text = b.String()
+	}
+
+	// Write returned text into HTML.
+	if _, err := w.WriteString(text); err != nil {
+		// We don't have much recourse if this fails.
+		log.Errorf(cr.ctx, "error writing HTML: %s", err)
+	}
+
+	return ast.WalkSkipChildren, nil
+}
+
+// handleImage takes an image destination URL, and does the following:
+//
+//   - Check if it's the URL of a local attachment owned by the author.
+//   - Check the attachment isn't already attached to another status.
+//   - Add attachment to cr.results.Attachments slice.
+//
+// The attachment is returned if found and usable, otherwise nil.
+func (cr *customRenderer) handleImage(dest string) *gtsmodel.MediaAttachment {
+	u, err := url.Parse(dest)
+	if err != nil || u.Host != config.GetHost() {
+		// Not local.
+		return nil
+	}
+
+	// Check for our expected fileserver URL path format.
+	path, ok := strings.CutPrefix(u.Path, "/"+uris.FileserverPath+"/")
+	if !ok {
+		return nil
+	}
+
+	parts := regexes.FilePath.FindStringSubmatch(path)
+	if len(parts) != 6 ||
+		parts[1] != cr.accountID ||
+		parts[2] != "attachment" {
+		return nil
+	}
+
+	attachment, err := cr.db.GetAttachmentByID(cr.ctx, parts[4])
+	if err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			log.Errorf(cr.ctx, "db error getting attachment %s: %s", parts[4], err)
+		}
+		return nil
+	}
+
+	if attachment.AccountID != cr.accountID ||
+		attachment.Type != gtsmodel.FileTypeImage {
+		// Not usable inline.
+		return nil
+	}
+
+	if (attachment.StatusID != "" && attachment.StatusID != cr.statusID) ||
+		attachment.ScheduledStatusID != "" {
+		// Already attached elsewhere.
+		return nil
+	}
+
+	// Append attachment to result if not done already.
+	//
+	// This prevents multiple uses of an image
+	// in the same status generating multiple
+	// entries for the same attachment in result.
+	func() {
+		for _, a := range cr.result.Attachments {
+			if attachment.ID == a.ID {
+				// Already appended.
+				return
+			}
+		}
+
+		// Not appended yet.
+		cr.result.Attachments = append(cr.result.Attachments, attachment)
+	}()
+
+	return attachment
+}
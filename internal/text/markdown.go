@@ -40,6 +40,7 @@ func (f *Formatter) FromMarkdown(
 	input string,
 ) *FormatResult {
 	result := new(FormatResult)
+	images := newInlineImages()
 
 	// Instantiate goldmark parser for
 	// markdown, using custom renderer
@@ -61,6 +62,7 @@ func (f *Formatter) FromMarkdown(
 				statusID,
 				false, // emojiOnly = false.
 				result,
+				images,
 			},
 			// Turns URLs into links.
 			extension.NewLinkify(
@@ -86,6 +88,7 @@ func (f *Formatter) FromMarkdown(
 	// Clean and shrink HTML.
 	result.HTML = byteutil.B2S(htmlBytes.Bytes())
 	result.HTML = SanitizeToHTML(result.HTML)
+	result.HTML = images.replace(result.HTML)
 	result.HTML = MinifyHTML(result.HTML)
 
 	return result
@@ -90,6 +90,13 @@ const (
 	// the first ö is one rune, the second ö is an o with a combining diacritic.
 	mdUnnormalizedHashtag         = "#hellöthere #hellöthere"
 	mdUnnormalizedHashtagExpected = "<p><a href=\"http://localhost:8080/tags/hell%C3%B6there\" class=\"mention hashtag\" rel=\"tag nofollow noreferrer noopener\" target=\"_blank\">#<span>hellöthere</span></a> <a href=\"http://localhost:8080/tags/hell%C3%B6there\" class=\"mention hashtag\" rel=\"tag nofollow noreferrer noopener\" target=\"_blank\">#<span>hellöthere</span></a></p>"
+	// Inline images are only rendered for the author's own attachments.
+	mdWithInlineImage                 = "Here's my cat:\n\n![A tabby cat asleep.](http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/attachment/original/01F8MH8RMYQ6MSNY3JM2XT1CQ5.jpg)"
+	mdWithInlineImageExpected         = "<p>Here's my cat:</p><p><img src=\"http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/attachment/original/01F8MH8RMYQ6MSNY3JM2XT1CQ5.jpg\" alt=\"A tabby cat asleep.\" class=\"inline-image\"></p>"
+	mdWithInlineImageNotOwned         = "Here's someone else's cat:\n\n![A cat.](http://localhost:8080/fileserver/01F8MH17FWEB39HZJ76B6VXSKF/attachment/original/01F8MH6NEM8D7527KZAECTCR76.jpg)"
+	mdWithInlineImageNotOwnedExpected = "<p>Here's someone else's cat:</p><p><a href=\"http://localhost:8080/fileserver/01F8MH17FWEB39HZJ76B6VXSKF/attachment/original/01F8MH6NEM8D7527KZAECTCR76.jpg\" rel=\"nofollow noreferrer noopener\" target=\"_blank\">A cat.</a></p>"
+	mdWithInlineImageRawHTML          = "Here's my cat:\n\n<img src=\"http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/attachment/original/01F8MH8RMYQ6MSNY3JM2XT1CQ5.jpg\" alt=\"A tabby cat asleep.\">"
+	mdWithInlineImageRawHTMLExpected  = "<p>Here's my cat:</p>"
 )
 
 type MarkdownTestSuite struct {
@@ -190,6 +197,25 @@ func (suite *MarkdownTestSuite) TestParseObjectInCodeBlock() {
 	suite.Empty(formatted.Emojis)
 }
 
+func (suite *MarkdownTestSuite) TestParseInlineImage() {
+	formatted := suite.FromMarkdown(mdWithInlineImage)
+	suite.Equal(mdWithInlineImageExpected, formatted.HTML)
+	suite.Len(formatted.Attachments, 1)
+	suite.Equal(suite.testAttachments["local_account_1_unattached_1"].ID, formatted.Attachments[0].ID)
+}
+
+func (suite *MarkdownTestSuite) TestParseInlineImageNotOwned() {
+	formatted := suite.FromMarkdown(mdWithInlineImageNotOwned)
+	suite.Equal(mdWithInlineImageNotOwnedExpected, formatted.HTML)
+	suite.Empty(formatted.Attachments)
+}
+
+func (suite *MarkdownTestSuite) TestParseInlineImageRawHTML() {
+	formatted := suite.FromMarkdown(mdWithInlineImageRawHTML)
+	suite.Equal(mdWithInlineImageRawHTMLExpected, formatted.HTML)
+	suite.Empty(formatted.Attachments)
+}
+
 func (suite *MarkdownTestSuite) TestParseItalicHashtag() {
 	formatted := suite.FromMarkdown(mdItalicHashtag)
 	suite.Equal(mdItalicHashtagExpected, formatted.HTML)
@@ -158,6 +158,7 @@ func (f *Formatter) fromPlain(
 				statusID,
 				emojiOnly,
 				result,
+				nil, // no inline images.
 			},
 			// Turns URLs into links.
 			extension.NewLinkify(
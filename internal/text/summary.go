@@ -0,0 +1,82 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package text
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// summaryBreaks matches the html elements
+// that end a line or paragraph, which would
+// otherwise glue words together when the
+// html is converted to plaintext.
+var summaryBreaks = regexp.MustCompile(`(?i)<br\s*/?>|</p>|</li>|</h[1-6]>|</blockquote>|</pre>`)
+
+// Summarize converts the given html to plaintext
+// with all whitespace collapsed into single spaces,
+// and truncates the result to maxRunes, see Truncate.
+//
+// The returned string is plaintext, so it should be
+// escaped appropriately before inclusion in html/xml.
+func Summarize(in string, maxRunes int) string {
+	in = summaryBreaks.ReplaceAllString(in, " ")
+	summary := SanitizeToPlaintext(in)
+	summary = strings.Join(strings.Fields(summary), " ")
+	return Truncate(summary, maxRunes)
+}
+
+// Truncate trims the given plaintext to at most maxRunes
+// runes, including the ellipsis (`...`) it's suffixed with
+// to indicate omission. Where possible without throwing away
+// too much text, it's cut at a word boundary.
+//
+// The reason for using runes is to avoid cutting
+// off UTF-8 characters in the middle, and to cut
+// plaintext before it's escaped, to avoid cutting
+// off html entities in the middle.
+func Truncate(in string, maxRunes int) string {
+	const ellipsis = "..."
+
+	runes := []rune(in)
+	if len(runes) <= maxRunes {
+		// Fine as-is.
+		return in
+	}
+
+	if maxRunes <= len(ellipsis) {
+		// No room for anything else.
+		return ellipsis[:max(maxRunes, 0)]
+	}
+
+	// Look for a word boundary to cut at, but
+	// don't throw away over a quarter of the
+	// text for it, eg., when it's a language
+	// that doesn't use spaces between words.
+	cut := maxRunes - len(ellipsis)
+	for i := cut; i > cut*3/4; i-- {
+		if unicode.IsSpace(runes[i]) {
+			cut = i
+			break
+		}
+	}
+
+	truncated := strings.TrimRightFunc(string(runes[:cut]), unicode.IsSpace)
+	return truncated + ellipsis
+}
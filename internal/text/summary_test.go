@@ -0,0 +1,83 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package text_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+type SummaryTestSuite struct {
+	suite.Suite
+}
+
+func (suite *SummaryTestSuite) TestSummarize() {
+	for _, test := range []struct {
+		name     string
+		in       string
+		maxRunes int
+		expect   string
+	}{
+		{
+			name:     "short",
+			in:       "<p>hello everyone!</p>",
+			maxRunes: 20,
+			expect:   "hello everyone!",
+		},
+		{
+			name:     "paragraphs and breaks",
+			in:       "<p>first line<br>second line</p><p>second paragraph</p>",
+			maxRunes: 100,
+			expect:   "first line second line second paragraph",
+		},
+		{
+			name:     "word boundary",
+			in:       "<p>the quick brown fox jumps over the lazy dog</p>",
+			maxRunes: 24,
+			expect:   "the quick brown fox...",
+		},
+		{
+			name:     "entities not cut",
+			in:       "<p>this &amp; that &lt;3 &amp; more</p>",
+			maxRunes: 14,
+			expect:   "this & that...",
+		},
+		{
+			name:     "no spaces",
+			in:       "<p>这是简体中文帖子的一些示例内容</p>",
+			maxRunes: 10,
+			expect:   "这是简体中文帖...",
+		},
+		{
+			name:     "tiny limit",
+			in:       "<p>hello everyone!</p>",
+			maxRunes: 2,
+			expect:   "..",
+		},
+	} {
+		suite.Run(test.name, func() {
+			suite.Equal(test.expect, text.Summarize(test.in, test.maxRunes))
+		})
+	}
+}
+
+func TestSummaryTestSuite(t *testing.T) {
+	suite.Run(t, new(SummaryTestSuite))
+}
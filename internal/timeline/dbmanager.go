@@ -0,0 +1,391 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package timeline
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+const (
+	// dbPruneLength is the number of entries that
+	// database timelines are pruned to every hour.
+	// Older items are grabbed again if paged to.
+	dbPruneLength = 800
+
+	// dbSkipInsertDepth is the number of most recent
+	// entries that a newly ingested item is checked
+	// against with the SkipInsertFunction.
+	dbSkipInsertDepth = 100
+)
+
+// NewDBManager returns a new timeline manager which, rather than
+// keeping timelines in memory, materializes them in the database as
+// items are ingested (fan-out on write). This means timelines survive
+// restarts, and can be shared by multiple GoToSocial processes using
+// the same database.
+//
+// Items are prepared each time they're fetched, rather than cached,
+// so UnprepareItem and UnprepareItemFromAllTimelines are no-ops.
+func NewDBManager(
+	entries db.TimelineEntry,
+	timelineType gtsmodel.TimelineType,
+	grabFunction GrabFunction,
+	filterFunction FilterFunction,
+	prepareFunction PrepareFunction,
+	skipInsertFunction SkipInsertFunction,
+) Manager {
+	return &dbManager{
+		entries:            entries,
+		timelineType:       timelineType,
+		grabFunction:       grabFunction,
+		filterFunction:     filterFunction,
+		prepareFunction:    prepareFunction,
+		skipInsertFunction: skipInsertFunction,
+	}
+}
+
+type dbManager struct {
+	entries            db.TimelineEntry
+	timelineType       gtsmodel.TimelineType
+	grabFunction       GrabFunction
+	filterFunction     FilterFunction
+	prepareFunction    PrepareFunction
+	skipInsertFunction SkipInsertFunction
+	stop               chan struct{}
+}
+
+func (m *dbManager) Start() error {
+	m.stop = make(chan struct{})
+
+	// Start a background goroutine which
+	// prunes all stored timelines once per
+	// hour, so the table doesn't grow forever.
+	go func(stop <-chan struct{}) {
+		ticker := time.NewTicker(1 * time.Hour)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				m.pruneAll(context.Background())
+			}
+		}
+	}(m.stop)
+
+	return nil
+}
+
+func (m *dbManager) Stop() error {
+	if m.stop != nil {
+		close(m.stop)
+		m.stop = nil
+	}
+	return nil
+}
+
+func (m *dbManager) pruneAll(ctx context.Context) {
+	timelineIDs, err := m.entries.GetTimelineIDs(ctx, m.timelineType)
+	if err != nil {
+		log.Errorf(ctx, "error getting %s timeline IDs: %v", m.timelineType, err)
+		return
+	}
+
+	for _, timelineID := range timelineIDs {
+		amountPruned, err := m.entries.PruneTimelineEntries(ctx, m.timelineType, timelineID, dbPruneLength)
+		if err != nil {
+			log.Errorf(ctx, "error pruning %s timeline %s: %v", m.timelineType, timelineID, err)
+			continue
+		}
+
+		if amountPruned > 0 {
+			log.WithField("timelineID", timelineID).Infof("pruned %d entries from %s timeline", amountPruned, m.timelineType)
+		}
+	}
+}
+
+func (m *dbManager) IngestOne(ctx context.Context, timelineID string, item Timelineable) (bool, error) {
+	// Check the item against the most recent
+	// entries, as the in-memory timeline would.
+	recent, err := m.entries.GetTimelineEntries(ctx,
+		m.timelineType,
+		timelineID,
+		"", "", "",
+		dbSkipInsertDepth,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, gtserror.Newf("db error getting recent timeline entries: %w", err)
+	}
+
+	if skip, err := m.skipInsert(ctx, item, recent); err != nil {
+		return false, err
+	} else if skip {
+		return false, nil
+	}
+
+	if err := m.entries.PutTimelineEntry(ctx, m.newEntry(timelineID, item)); err != nil {
+		return false, gtserror.Newf("db error putting timeline entry: %w", err)
+	}
+
+	return true, nil
+}
+
+func (m *dbManager) GetTimeline(ctx context.Context, timelineID string, maxID string, sinceID string, minID string, limit int, local bool) ([]Preparable, error) {
+	entries, err := m.entries.GetTimelineEntries(ctx,
+		m.timelineType,
+		timelineID,
+		maxID,
+		sinceID,
+		minID,
+		limit,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting timeline entries: %w", err)
+	}
+
+	if len(entries) < limit && sinceID == "" && minID == "" {
+		// Not enough entries stored when paging down, eg., this
+		// timeline hasn't been used since timelines were stored
+		// in the database, or was pruned. Make up the difference
+		// using the grab function, and store what we find for
+		// next time. Anything newer than the stored entries was
+		// ingested as it arrived, so polls for newer items with
+		// since_id / min_id never need to grab anything.
+		entries, err = m.backfill(ctx, timelineID, entries, maxID, limit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	items := make([]Preparable, 0, len(entries))
+	for _, entry := range entries {
+		prepared, err := m.prepareFunction(ctx, timelineID, entry.StatusID)
+		if err != nil {
+			if errors.Is(err, statusfilter.ErrHideStatus) ||
+				errors.Is(err, db.ErrNoEntries) {
+				// Filtered out or deleted, so we won't be
+				// able to show this, remove and skip it.
+				if _, err := m.entries.DeleteTimelineEntry(ctx, m.timelineType, timelineID, entry.StatusID); err != nil {
+					log.Errorf(ctx, "db error removing timeline entry %s: %v", entry.StatusID, err)
+				}
+				continue
+			}
+
+			return nil, gtserror.Newf("error preparing %s: %w", entry.StatusID, err)
+		}
+
+		items = append(items, prepared)
+	}
+
+	return items, nil
+}
+
+// backfill grabs items older than the given entries (sorted newest
+// first) to top them up to limit, stores the grabbed items as new
+// entries, and returns the page of entries that was asked for.
+func (m *dbManager) backfill(
+	ctx context.Context,
+	timelineID string,
+	entries []*gtsmodel.TimelineEntry,
+	maxID string,
+	limit int,
+) ([]*gtsmodel.TimelineEntry, error) {
+	behindID := maxID
+	if behindID == "" {
+		behindID = id.Highest
+	}
+
+	// Grab only beyond
+	// the stored entries.
+	if len(entries) > 0 {
+		behindID = entries[len(entries)-1].StatusID
+	}
+
+	grabbed, err := grab(ctx,
+		m.grabFunction,
+		m.filterFunction,
+		timelineID,
+		limit-len(entries),
+		behindID,
+		id.Lowest,
+		true,
+	)
+	if err != nil {
+		return nil, gtserror.Newf("error grabbing items: %w", err)
+	}
+
+	for _, item := range grabbed {
+		if slices.ContainsFunc(entries, func(e *gtsmodel.TimelineEntry) bool {
+			return e.StatusID == item.GetID()
+		}) {
+			// Already have it.
+			continue
+		}
+
+		// Check against the entries which
+		// are newer than the grabbed item.
+		newer := make([]*gtsmodel.TimelineEntry, 0, len(entries))
+		for _, e := range entries {
+			if e.StatusID > item.GetID() {
+				newer = append(newer, e)
+			}
+		}
+
+		if skip, err := m.skipInsert(ctx, item, newer); err != nil {
+			return nil, err
+		} else if skip {
+			continue
+		}
+
+		entry := m.newEntry(timelineID, item)
+		if err := m.entries.PutTimelineEntry(ctx, entry); err != nil {
+			return nil, gtserror.Newf("db error putting timeline entry: %w", err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	// Return entries newest first, keeping the
+	// limit entries closest to where we paged from.
+	slices.SortFunc(entries, func(a, b *gtsmodel.TimelineEntry) int {
+		return strings.Compare(b.StatusID, a.StatusID)
+	})
+
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	return entries, nil
+}
+
+// skipInsert calls the SkipInsertFunction for item against
+// each of the given entries that are newer than it, sorted
+// newest first, with depth counted from the item upwards.
+func (m *dbManager) skipInsert(ctx context.Context, item Timelineable, newer []*gtsmodel.TimelineEntry) (bool, error) {
+	for i := len(newer) - 1; i >= 0; i-- {
+		entry := newer[i]
+		depth := len(newer) - i
+
+		skip, err := m.skipInsertFunction(
+			ctx,
+			item.GetID(),
+			item.GetAccountID(),
+			item.GetBoostOfID(),
+			item.GetBoostOfAccountID(),
+			entry.StatusID,
+			entry.AccountID,
+			entry.BoostOfID,
+			entry.BoostOfAccountID,
+			depth,
+		)
+		if err != nil {
+			return false, gtserror.Newf("error calling skipInsert: %w", err)
+		}
+
+		if skip {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (m *dbManager) newEntry(timelineID string, item Timelineable) *gtsmodel.TimelineEntry {
+	return &gtsmodel.TimelineEntry{
+		TimelineType:     m.timelineType,
+		TimelineID:       timelineID,
+		StatusID:         item.GetID(),
+		AccountID:        item.GetAccountID(),
+		BoostOfID:        item.GetBoostOfID(),
+		BoostOfAccountID: item.GetBoostOfAccountID(),
+	}
+}
+
+func (m *dbManager) GetIndexedLength(ctx context.Context, timelineID string) int {
+	count, err := m.entries.CountTimelineEntries(ctx, m.timelineType, timelineID)
+	if err != nil {
+		log.Errorf(ctx, "db error counting timeline entries: %v", err)
+	}
+	return count
+}
+
+func (m *dbManager) GetOldestIndexedID(ctx context.Context, timelineID string) string {
+	// Page up from the lowest possible
+	// ID to get just the oldest entry.
+	entries, err := m.entries.GetTimelineEntries(ctx,
+		m.timelineType,
+		timelineID,
+		"", "", id.Lowest,
+		1,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		log.Errorf(ctx, "db error getting oldest timeline entry: %v", err)
+	}
+
+	if len(entries) == 0 {
+		return ""
+	}
+
+	return entries[0].StatusID
+}
+
+func (m *dbManager) Remove(ctx context.Context, timelineID string, itemID string) (int, error) {
+	return m.entries.DeleteTimelineEntry(ctx, m.timelineType, timelineID, itemID)
+}
+
+func (m *dbManager) RemoveTimeline(ctx context.Context, timelineID string) error {
+	return m.entries.DeleteTimelineEntries(ctx, m.timelineType, timelineID)
+}
+
+func (m *dbManager) WipeItemFromAllTimelines(ctx context.Context, itemID string) error {
+	return m.entries.DeleteTimelineEntriesByStatusID(ctx, m.timelineType, itemID)
+}
+
+func (m *dbManager) WipeItemsFromAccountID(ctx context.Context, timelineID string, accountID string) error {
+	_, err := m.entries.DeleteTimelineEntriesByAccountID(ctx, m.timelineType, timelineID, accountID)
+	return err
+}
+
+func (m *dbManager) WipeBoostsFromAccountID(ctx context.Context, timelineID string, accountID string) error {
+	_, err := m.entries.DeleteTimelineBoostsByAccountID(ctx, m.timelineType, timelineID, accountID)
+	return err
+}
+
+func (m *dbManager) UnprepareItem(ctx context.Context, timelineID string, itemID string) error {
+	return nil
+}
+
+func (m *dbManager) UnprepareItemFromAllTimelines(ctx context.Context, itemID string) error {
+	return nil
+}
+
+func (m *dbManager) Prune(ctx context.Context, timelineID string, desiredPreparedItemsLength int, desiredIndexedItemsLength int) (int, error) {
+	return m.entries.PruneTimelineEntries(ctx, m.timelineType, timelineID, desiredIndexedItemsLength)
+}
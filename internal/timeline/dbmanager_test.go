@@ -0,0 +1,155 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package timeline_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/timeline"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+// DBGetTestSuite runs the get tests
+// against database-stored timelines.
+type DBGetTestSuite struct {
+	GetTestSuite
+}
+
+func (suite *DBGetTestSuite) SetupTest() {
+	suite.GetTestSuite.SetupTest()
+
+	// Replace in-memory timelines.
+	config.SetAdvancedTimelineStorage(config.TimelineStorageDatabase)
+	testrig.StartTimelines(
+		suite.state,
+		visibility.NewFilter(suite.state),
+		typeutils.NewConverter(suite.state),
+	)
+}
+
+func (suite *DBGetTestSuite) TestTimelineSurvivesRestart() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_1"]
+		timelineID  = testAccount.ID
+		status      = suite.testStatuses["local_account_2_status_1"]
+	)
+
+	if _, err := suite.state.Timelines.Home.IngestOne(ctx, timelineID, status); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// "Restart" by creating new timelines.
+	testrig.StartTimelines(
+		suite.state,
+		visibility.NewFilter(suite.state),
+		typeutils.NewConverter(suite.state),
+	)
+
+	statuses, err := suite.state.Timelines.Home.GetTimeline(ctx, timelineID, "", "", "", 20, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.True(containsID(statuses, status.ID))
+
+	// Entry should be gone when the status
+	// is wiped from all timelines.
+	if err := suite.state.Timelines.Home.WipeItemFromAllTimelines(ctx, status.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	entries, err := suite.state.DB.GetTimelineEntries(ctx, gtsmodel.TimelineTypeHome, timelineID, "", "", "", 0)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	for _, entry := range entries {
+		suite.NotEqual(status.ID, entry.StatusID)
+	}
+}
+
+func (suite *DBGetTestSuite) TestGetNewTimelinePageUp() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_1"]
+	)
+
+	// Paging up a timeline with nothing stored
+	// shouldn't grab anything: newer items are
+	// stored as they arrive, so a since_id or
+	// min_id poll never needs to backfill.
+	statuses, err := suite.state.Timelines.Home.GetTimeline(ctx, testAccount.ID, "", "", id.Lowest, 5, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(statuses)
+
+	entries, err := suite.state.DB.GetTimelineEntries(ctx, gtsmodel.TimelineTypeHome, testAccount.ID, "", "", "", 0)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(entries)
+
+	// Paging down from the top does backfill, after
+	// which paging up from the back finds the items.
+	statuses, err = suite.state.Timelines.Home.GetTimeline(ctx, testAccount.ID, "", "", "", 5, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.checkStatuses(statuses, id.Highest, id.Lowest, 5)
+
+	statuses, err = suite.state.Timelines.Home.GetTimeline(ctx, testAccount.ID, "", "", id.Lowest, 5, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.checkStatuses(statuses, id.Highest, id.Lowest, 5)
+}
+
+func (suite *DBGetTestSuite) TestGetNewTimelineMoreThanPossiblePageUp() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	// Nothing stored yet, so nothing to page up through.
+	statuses, err := suite.state.Timelines.Home.GetTimeline(context.Background(), testAccount.ID, "", "", id.Lowest, 100, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(statuses)
+}
+
+func containsID(items []timeline.Preparable, id string) bool {
+	for _, item := range items {
+		if item.GetID() == id {
+			return true
+		}
+	}
+	return false
+}
+
+func TestDBGetTestSuite(t *testing.T) {
+	suite.Run(t, new(DBGetTestSuite))
+}
@@ -145,6 +145,30 @@ func (t *timeline) indexXBetweenIDs(ctx context.Context, amount int, behindID st
 
 // grab wraps the timeline's grabFunction in paging + filtering logic.
 func (t *timeline) grab(ctx context.Context, amount int, behindID string, beforeID string, frontToBack bool) ([]Timelineable, error) {
+	return grab(ctx,
+		t.grabFunction,
+		t.filterFunction,
+		t.timelineID,
+		amount,
+		behindID,
+		beforeID,
+		frontToBack,
+	)
+}
+
+// grab uses the given grab and filter functions to fetch up
+// to amount filtered items between behindID and beforeID for
+// the given timeline, paging down if frontToBack, else up.
+func grab(
+	ctx context.Context,
+	grabFunction GrabFunction,
+	filterFunction FilterFunction,
+	timelineID string,
+	amount int,
+	behindID string,
+	beforeID string,
+	frontToBack bool,
+) ([]Timelineable, error) {
 	var (
 		sinceID  string
 		minID    string
@@ -165,9 +189,9 @@ func (t *timeline) grab(ctx context.Context, amount int, behindID string, before
 			break
 		}
 
-		items, stop, err := t.grabFunction(
+		items, stop, err := grabFunction(
 			ctx,
-			t.timelineID,
+			timelineID,
 			maxID,
 			sinceID,
 			minID,
@@ -204,7 +228,7 @@ func (t *timeline) grab(ctx context.Context, amount int, behindID string, before
 		}
 
 		for _, item := range items {
-			ok, err := t.filterFunction(ctx, t.timelineID, item)
+			ok, err := filterFunction(ctx, timelineID, item)
 			if err != nil {
 				if !errors.Is(err, db.ErrNoEntries) {
 					// Real error here.
@@ -22,7 +22,10 @@ import (
 	"sync"
 	"time"
 
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
 
@@ -75,6 +78,9 @@ type Manager interface {
 	// WipeStatusesFromAccountID removes all items by the given accountID from the given timeline.
 	WipeItemsFromAccountID(ctx context.Context, timelineID string, accountID string) error
 
+	// WipeBoostsFromAccountID removes all boosts created by the given accountID from the given timeline.
+	WipeBoostsFromAccountID(ctx context.Context, timelineID string, accountID string) error
+
 	// UnprepareItem unprepares/uncaches the prepared version fo the given itemID from the given timelineID.
 	// Use this for cache invalidation when the prepared representation of an item has changed.
 	UnprepareItem(ctx context.Context, timelineID string, itemID string) error
@@ -104,6 +110,37 @@ func NewManager(grabFunction GrabFunction, filterFunction FilterFunction, prepar
 	}
 }
 
+// NewConfiguredManager returns a new timeline manager using the
+// timeline storage set by advanced-timeline-storage; either an
+// in-memory manager, or a database manager using entries to store
+// timelines of timelineType.
+func NewConfiguredManager(
+	entries db.TimelineEntry,
+	timelineType gtsmodel.TimelineType,
+	grabFunction GrabFunction,
+	filterFunction FilterFunction,
+	prepareFunction PrepareFunction,
+	skipInsertFunction SkipInsertFunction,
+) Manager {
+	if config.GetAdvancedTimelineStorage() == config.TimelineStorageDatabase {
+		return NewDBManager(
+			entries,
+			timelineType,
+			grabFunction,
+			filterFunction,
+			prepareFunction,
+			skipInsertFunction,
+		)
+	}
+
+	return NewManager(
+		grabFunction,
+		filterFunction,
+		prepareFunction,
+		skipInsertFunction,
+	)
+}
+
 type manager struct {
 	timelines          sync.Map
 	grabFunction       GrabFunction
@@ -213,6 +250,11 @@ func (m *manager) WipeItemsFromAccountID(ctx context.Context, timelineID string,
 	return err
 }
 
+func (m *manager) WipeBoostsFromAccountID(ctx context.Context, timelineID string, accountID string) error {
+	_, err := m.getOrCreateTimeline(ctx, timelineID).RemoveBoostsBy(ctx, accountID)
+	return err
+}
+
 func (m *manager) UnprepareItemFromAllTimelines(ctx context.Context, itemID string) error {
 	errs := new(gtserror.MultiError)
 
@@ -0,0 +1,76 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package timeline
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// regeneratePageSize is the number of items
+// requested per page when regenerating a timeline.
+const regeneratePageSize = 40
+
+// Regenerate purges the given timeline from the manager, and then rebuilds
+// it from the database by paging down through it with the manager's grab,
+// filter and prepare functions, until amount items have been prepared or
+// there are no more items to prepare.
+//
+// If progress is set, it is called after each page with the total number
+// of items prepared so far. The final number of items is also returned.
+func Regenerate(
+	ctx context.Context,
+	m Manager,
+	timelineID string,
+	amount int,
+	progress func(prepared int),
+) (int, error) {
+	if err := m.RemoveTimeline(ctx, timelineID); err != nil {
+		return 0, gtserror.Newf("error removing timeline %s: %w", timelineID, err)
+	}
+
+	var (
+		prepared int
+		maxID    string
+	)
+
+	for prepared < amount {
+		limit := min(regeneratePageSize, amount-prepared)
+
+		items, err := m.GetTimeline(ctx, timelineID, maxID, "", "", limit, false)
+		if err != nil {
+			return prepared, gtserror.Newf("error preparing timeline %s: %w", timelineID, err)
+		}
+
+		if len(items) == 0 {
+			// Nothing
+			// left to grab.
+			break
+		}
+
+		prepared += len(items)
+		maxID = items[len(items)-1].GetID()
+
+		if progress != nil {
+			progress(prepared)
+		}
+	}
+
+	return prepared, nil
+}
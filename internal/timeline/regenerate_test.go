@@ -0,0 +1,82 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package timeline_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/timeline"
+)
+
+type RegenerateTestSuite struct {
+	TimelineStandardTestSuite
+}
+
+func (suite *RegenerateTestSuite) TestRegenerate() {
+	var (
+		ctx           = context.Background()
+		testAccountID = suite.testAccounts["local_account_1"].ID
+	)
+
+	// Fill the timeline with all test
+	// statuses, even ones the account
+	// wouldn't normally see, to simulate
+	// a timeline with stale entries.
+	suite.fillTimeline(testAccountID)
+	suite.Equal(23, suite.state.Timelines.Home.GetIndexedLength(ctx, testAccountID))
+
+	var progress []int
+	prepared, err := timeline.Regenerate(ctx,
+		suite.state.Timelines.Home,
+		testAccountID,
+		400,
+		func(n int) { progress = append(progress, n) },
+	)
+	suite.NoError(err)
+
+	// Only statuses that actually belong in
+	// the timeline should be there now.
+	suite.Equal(19, prepared)
+	suite.Equal([]int{19}, progress)
+
+	items, err := suite.state.Timelines.Home.GetTimeline(ctx, testAccountID, "", "", "", 40, false)
+	suite.NoError(err)
+	suite.Len(items, prepared)
+}
+
+func (suite *RegenerateTestSuite) TestRegenerateAmount() {
+	var (
+		ctx           = context.Background()
+		testAccountID = suite.testAccounts["local_account_1"].ID
+	)
+
+	prepared, err := timeline.Regenerate(ctx,
+		suite.state.Timelines.Home,
+		testAccountID,
+		5,
+		nil,
+	)
+	suite.NoError(err)
+	suite.Equal(5, prepared)
+}
+
+func TestRegenerateTestSuite(t *testing.T) {
+	suite.Run(t, new(RegenerateTestSuite))
+}
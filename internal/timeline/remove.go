@@ -95,3 +95,39 @@ func (t *timeline) RemoveAllByOrBoosting(ctx context.Context, accountID string)
 
 	return len(toRemove), nil
 }
+
+func (t *timeline) RemoveBoostsBy(ctx context.Context, accountID string) (int, error) {
+	l := log.
+		WithContext(ctx).
+		WithFields(kv.Fields{
+			{"accountTimeline", t.timelineID},
+			{"accountID", accountID},
+		}...)
+
+	t.Lock()
+	defer t.Unlock()
+
+	if t.items == nil || t.items.data == nil {
+		// Nothing to do.
+		return 0, nil
+	}
+
+	var toRemove []*list.Element
+	for e := t.items.data.Front(); e != nil; e = e.Next() {
+		entry := e.Value.(*indexedItemsEntry)
+
+		if entry.accountID != accountID || entry.boostOfID == "" {
+			// Not relevant.
+			continue
+		}
+
+		l.Debug("removing item")
+		toRemove = append(toRemove, e)
+	}
+
+	for _, e := range toRemove {
+		t.items.data.Remove(e)
+	}
+
+	return len(toRemove), nil
+}
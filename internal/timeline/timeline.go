@@ -132,6 +132,11 @@ type Timeline interface {
 	//
 	// The returned int indicates the amount of entries that were removed.
 	RemoveAllByOrBoosting(ctx context.Context, accountID string) (int, error)
+
+	// RemoveBoostsBy removes all boosts created by the given accountID.
+	//
+	// The returned int indicates the amount of entries that were removed.
+	RemoveBoostsBy(ctx context.Context, accountID string) (int, error)
 }
 
 // timeline fulfils the Timeline interface
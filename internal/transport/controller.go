@@ -49,12 +49,13 @@ type Controller interface {
 }
 
 type controller struct {
-	state     *state.State
-	fedDB     federatingdb.DB
-	clock     pub.Clock
-	client    pub.HttpClient
-	trspCache cache.TTLCache[string, *transport]
-	userAgent string
+	state      *state.State
+	fedDB      federatingdb.DB
+	clock      pub.Clock
+	client     pub.HttpClient
+	trspCache  cache.TTLCache[string, *transport]
+	derefCache cache.TTLCache[string, derefCacheEntry]
+	userAgent  string
 }
 
 // NewController returns an implementation of the Controller interface for creating new transports
@@ -66,12 +67,13 @@ func NewController(state *state.State, federatingDB federatingdb.DB, clock pub.C
 	)
 
 	c := &controller{
-		state:     state,
-		fedDB:     federatingDB,
-		clock:     clock,
-		client:    client,
-		trspCache: cache.NewTTL[string, *transport](0, 100, 0),
-		userAgent: fmt.Sprintf("gotosocial/%s (+%s://%s)", version, proto, host),
+		state:      state,
+		fedDB:      federatingDB,
+		clock:      clock,
+		client:     client,
+		trspCache:  cache.NewTTL[string, *transport](0, 100, 0),
+		derefCache: newDerefCache(),
+		userAgent:  fmt.Sprintf("gotosocial/%s (+%s://%s)", version, proto, host),
 	}
 
 	return c
@@ -20,15 +20,18 @@ package transport
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/url"
 
 	"codeberg.org/gruf/go-byteutil"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/transport/delivery"
 )
 
@@ -55,6 +58,7 @@ func (t *transport) BatchDeliver(ctx context.Context, obj map[string]interface{}
 	actID := getActorID(obj)
 	objID := getObjectID(obj)
 	tgtID := getTargetID(obj)
+	typ := getType(obj)
 
 	for _, to := range recipients {
 		// Skip delivery to recipient if it is "us".
@@ -67,6 +71,7 @@ func (t *transport) BatchDeliver(ctx context.Context, obj map[string]interface{}
 			actID,
 			objID,
 			tgtID,
+			typ,
 			b,
 			to,
 		)
@@ -79,7 +84,8 @@ func (t *transport) BatchDeliver(ctx context.Context, obj map[string]interface{}
 		reqs = append(reqs, req)
 	}
 
-	// Push prepared request list to the delivery queue.
+	// Log and push prepared request list to the delivery queue.
+	t.controller.state.Workers.Delivery.Log.Queued(reqs...)
 	t.controller.state.Workers.Delivery.Queue.Push(reqs...)
 
 	// Return combined err.
@@ -103,6 +109,7 @@ func (t *transport) Deliver(ctx context.Context, obj map[string]interface{}, to
 		getActorID(obj),
 		getObjectID(obj),
 		getTargetID(obj),
+		getType(obj),
 		b,
 		to,
 	)
@@ -110,7 +117,8 @@ func (t *transport) Deliver(ctx context.Context, obj map[string]interface{}, to
 		return err
 	}
 
-	// Push prepared request to the delivery queue.
+	// Log and push prepared request to the delivery queue.
+	t.controller.state.Workers.Delivery.Log.Queued(req)
 	t.controller.state.Workers.Delivery.Queue.Push(req)
 
 	return nil
@@ -124,13 +132,15 @@ func (t *transport) prepare(
 	actorID string,
 	objectID string,
 	targetID string,
+	activityType string,
 	data []byte,
 	to *url.URL,
 ) (
 	*delivery.Delivery,
 	error,
 ) {
-	url := to.String()
+	// Prefer delivering over Tor where possible.
+	url := t.onionTarget(ctx, to).String()
 
 	// Use rewindable reader for body.
 	var body byteutil.ReadNopCloser
@@ -159,13 +169,52 @@ func (t *transport) prepare(
 	}
 
 	return &delivery.Delivery{
-		ActorID:  actorID,
-		ObjectID: objectID,
-		TargetID: targetID,
-		Request:  httpclient.WrapRequest(r),
+		ActorID:      actorID,
+		ObjectID:     objectID,
+		TargetID:     targetID,
+		ActivityType: activityType,
+		Request:      httpclient.WrapRequest(r),
 	}, nil
 }
 
+// onionTarget returns the given delivery target with its scheme
+// and host swapped for the onion service advertised by the target
+// instance, if any. This is only done when this instance is also
+// served as an onion service, as then it's expected to reach onion
+// hosts, (ie., http-client.proxy-rules are set for *.onion).
+func (t *transport) onionTarget(ctx context.Context, to *url.URL) *url.URL {
+	if config.GetOnionHost() == "" {
+		return to
+	}
+
+	instance, err := t.controller.state.DB.GetInstance(
+		gtscontext.SetBarebones(ctx),
+		to.Host,
+	)
+	if err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			log.Errorf(ctx, "error getting instance %s: %v", to.Host, err)
+		}
+		return to
+	}
+
+	if instance.OnionURI == "" {
+		return to
+	}
+
+	onion, err := url.Parse(instance.OnionURI)
+	if err != nil {
+		log.Errorf(ctx, "invalid onion uri for instance %s: %v", to.Host, err)
+		return to
+	}
+
+	onionTo := new(url.URL)
+	*onionTo = *to
+	onionTo.Scheme = onion.Scheme
+	onionTo.Host = onion.Host
+	return onionTo
+}
+
 // getObjectID extracts an object ID from 'serialized' ActivityPub object map.
 func getObjectID(obj map[string]interface{}) string {
 	switch t := obj["object"].(type) {
@@ -204,3 +253,9 @@ func getTargetID(obj map[string]interface{}) string {
 		return ""
 	}
 }
+
+// getType extracts the type from 'serialized' ActivityPub object map.
+func getType(obj map[string]interface{}) string {
+	t, _ := obj["type"].(string)
+	return t
+}
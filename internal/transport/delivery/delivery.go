@@ -20,6 +20,7 @@ package delivery
 import (
 	"context"
 	"slices"
+	"strings"
 	"time"
 
 	"codeberg.org/gruf/go-runners"
@@ -53,13 +54,20 @@ type Delivery struct {
 	// being sent out by this request.
 	TargetID string
 
+	// ActivityType contains the
+	// ActivityStreams type (if any)
+	// of the activity being sent out
+	// by this request, for logging.
+	ActivityType string
+
 	// Request is the prepared (+ wrapped)
 	// httpclient.Client{} request that
 	// constitutes this ActivtyPub delivery.
 	Request httpclient.Request
 
 	// internal fields.
-	next time.Time
+	next  time.Time
+	entry *LogEntry
 }
 
 func (dlv *Delivery) backoff() time.Duration {
@@ -81,6 +89,15 @@ type WorkerPool struct {
 	// passed to each of delivery pool Worker{}s.
 	Queue queue.StructQueue[*Delivery]
 
+	// Stats contains per-domain delivery statistics,
+	// updated by each of delivery pool Worker{}s.
+	Stats Stats
+
+	// Log contains a short-lived per-actor log
+	// of deliveries, updated by each of delivery
+	// pool Worker{}s.
+	Log Log
+
 	// internal fields.
 	workers []*Worker
 }
@@ -116,6 +133,8 @@ func (p *WorkerPool) Start(n int) {
 		p.workers[i] = new(Worker)
 		p.workers[i].Client = p.Client
 		p.workers[i].Queue = &p.Queue
+		p.workers[i].Stats = &p.Stats
+		p.workers[i].Log = &p.Log
 
 		// Attempt to start worker.
 		// Return bool not useful
@@ -161,6 +180,14 @@ type Worker struct {
 	// that delivery worker will feed from.
 	Queue *queue.StructQueue[*Delivery]
 
+	// Stats is where delivery worker will record
+	// per-domain delivery statistics (if set).
+	Stats *Stats
+
+	// Log is where delivery worker will record
+	// the outcome of logged deliveries (if set).
+	Log *Log
+
 	// internal fields.
 	backlog []*Delivery
 	service runners.Service
@@ -239,9 +266,12 @@ loop:
 		if err == nil {
 			// Ensure body closed.
 			_ = rsp.Body.Close()
+			w.success(dlv)
 			continue loop
 		}
 
+		w.failure(dlv, err, retry)
+
 		if !retry {
 			// Drop deliveries when no
 			// retry requested, or they
@@ -258,6 +288,33 @@ loop:
 	}
 }
 
+// success records a successful delivery in stats and log, if set.
+func (w *Worker) success(dlv *Delivery) {
+	if w.Stats != nil {
+		w.Stats.success(statsDomain(dlv))
+	}
+	if w.Log != nil {
+		w.Log.success(dlv)
+	}
+}
+
+// failure records a failed delivery in stats and log, if set.
+func (w *Worker) failure(dlv *Delivery, err error, retry bool) {
+	if w.Stats != nil {
+		w.Stats.failure(statsDomain(dlv))
+	}
+	if w.Log != nil {
+		w.Log.failure(dlv, err, retry)
+	}
+}
+
+// statsDomain returns the domain to record delivery
+// stats under, matching the instance domain format,
+// ie. lowercase and without any port.
+func statsDomain(dlv *Delivery) string {
+	return strings.ToLower(dlv.Request.URL.Hostname())
+}
+
 // next gets the next available delivery, blocking until available if necessary.
 func (w *Worker) next(ctx context.Context) (*Delivery, bool) {
 loop:
@@ -0,0 +1,218 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package delivery
+
+import (
+	"sync"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+// maxLogEntries is the maximum number of
+// entries kept in the log per actor, on top
+// of the configured retention window, to
+// keep memory usage of very busy actors
+// (eg., the instance actor) in check.
+const maxLogEntries = 500
+
+// LogStatus describes the
+// state of a logged delivery.
+type LogStatus string
+
+const (
+	LogStatusQueued    LogStatus = "queued"    // Not attempted yet.
+	LogStatusRetrying  LogStatus = "retrying"  // Failed, will be retried.
+	LogStatusDelivered LogStatus = "delivered" // Delivered successfully.
+	LogStatusFailed    LogStatus = "failed"    // Failed, and gave up.
+)
+
+// LogEntry contains details of
+// one logged outgoing delivery.
+type LogEntry struct {
+	// ActivityType is the type
+	// of the delivered activity.
+	ActivityType string
+
+	// ObjectID is the ActivityPub
+	// object ID IRI (if any) of
+	// the delivered activity.
+	ObjectID string
+
+	// Inbox is the URL of the
+	// inbox delivered to.
+	Inbox string
+
+	// Status is the current
+	// status of the delivery.
+	Status LogStatus
+
+	// Attempts is the number of
+	// delivery attempts so far.
+	Attempts int
+
+	// Error is the error returned
+	// by the last failed attempt.
+	Error string
+
+	// CreatedAt is the time
+	// the delivery was queued.
+	CreatedAt time.Time
+
+	// UpdatedAt is the time
+	// of the last attempt.
+	UpdatedAt time.Time
+}
+
+// Log keeps a short-lived log of outgoing
+// deliveries per-actor, so that admins can
+// debug deliveries that didn't make it to
+// their destination. Entries are kept for
+// the configured delivery log retention.
+type Log struct {
+	m  map[string][]*LogEntry
+	mu sync.Mutex
+}
+
+// Get returns copies of the logged deliveries
+// for the given actor ID IRI, newest first.
+func (l *Log) Get(actorID string) []LogEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entries := l.prune(actorID, time.Now())
+	out := make([]LogEntry, len(entries))
+	for i, entry := range entries {
+		out[len(out)-1-i] = *entry
+	}
+
+	return out
+}
+
+// Queued adds an entry to the log for each of
+// the given deliveries, marking them as queued.
+// It is a no-op if the delivery log is disabled.
+func (l *Log) Queued(dlvs ...*Delivery) {
+	if config.GetAdvancedDeliveryLogRetention() <= 0 {
+		return
+	}
+
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.m == nil {
+		l.m = make(map[string][]*LogEntry)
+	}
+
+	for _, dlv := range dlvs {
+		if dlv.ActorID == "" {
+			// Nothing to
+			// log it under.
+			continue
+		}
+
+		dlv.entry = &LogEntry{
+			ActivityType: dlv.ActivityType,
+			ObjectID:     dlv.ObjectID,
+			Inbox:        dlv.Request.URL.String(),
+			Status:       LogStatusQueued,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}
+
+		entries := l.prune(dlv.ActorID, now)
+		if len(entries) >= maxLogEntries {
+			// Drop the oldest entry to make room.
+			entries = entries[len(entries)-maxLogEntries+1:]
+		}
+
+		l.m[dlv.ActorID] = append(entries, dlv.entry)
+	}
+}
+
+// Prune drops entries older than the configured retention
+// for all actors, freeing the logs of actors that haven't
+// delivered anything since. This should be called regularly.
+func (l *Log) Prune(now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for actorID := range l.m {
+		_ = l.prune(actorID, now)
+	}
+}
+
+// success marks the delivery as delivered, if logged.
+func (l *Log) success(dlv *Delivery) {
+	l.update(dlv, LogStatusDelivered, nil)
+}
+
+// failure marks the delivery as failed or
+// retrying depending on retry, if logged.
+func (l *Log) failure(dlv *Delivery, err error, retry bool) {
+	status := LogStatusFailed
+	if retry {
+		status = LogStatusRetrying
+	}
+	l.update(dlv, status, err)
+}
+
+// update updates the log entry of delivery (if any)
+// after a delivery attempt, with given status and err.
+func (l *Log) update(dlv *Delivery, status LogStatus, err error) {
+	if dlv.entry == nil {
+		// Not logged.
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	dlv.entry.Status = status
+	dlv.entry.Attempts++
+	dlv.entry.UpdatedAt = time.Now()
+	if err != nil {
+		dlv.entry.Error = err.Error()
+	}
+}
+
+// prune drops entries for actor older than the configured
+// retention, returning those remaining (oldest first).
+// This MUST be called under lock.
+func (l *Log) prune(actorID string, now time.Time) []*LogEntry {
+	entries := l.m[actorID]
+	cutoff := now.Add(-config.GetAdvancedDeliveryLogRetention())
+
+	// Entries are appended in order of creation,
+	// so find the first one that's not expired.
+	var i int
+	for i < len(entries) && entries[i].CreatedAt.Before(cutoff) {
+		i++
+	}
+
+	entries = entries[i:]
+	if len(entries) == 0 {
+		delete(l.m, actorID)
+		return nil
+	}
+
+	l.m[actorID] = entries
+	return entries
+}
@@ -0,0 +1,182 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package delivery_test
+
+import (
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
+	"github.com/superseriousbusiness/gotosocial/internal/transport/delivery"
+)
+
+func TestDeliveryLog(t *testing.T) {
+	config.SetAdvancedDeliveryLogRetention(time.Hour)
+	defer config.SetAdvancedDeliveryLogRetention(config.Defaults.AdvancedDeliveryLogRetention)
+
+	// Prepare an HTTP test handler that accepts deliveries
+	// to one inbox, and temporarily refuses the other.
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok/inbox", func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusAccepted)
+	})
+	mux.HandleFunc("/down/inbox", func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Retry-After", "3600")
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	srv := new(http.Server)
+	srv.Handler = mux
+	go srv.Serve(l)
+	defer srv.Close()
+
+	wp := new(delivery.WorkerPool)
+	wp.Init(httpclient.New(httpclient.Config{
+		AllowRanges: config.MustParseIPPrefixes([]string{
+			"127.0.0.0/8",
+		}),
+	}))
+	wp.Start(1)
+	defer wp.Stop()
+
+	const actorID = "http://localhost:8080/users/the_mighty_zork"
+
+	var dlvs []*delivery.Delivery
+	for _, inbox := range []string{"/ok/inbox", "/down/inbox"} {
+		req, err := http.NewRequest(http.MethodPost, "http://"+l.Addr().String()+inbox, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		dlvs = append(dlvs, &delivery.Delivery{
+			ActorID:      actorID,
+			ObjectID:     actorID + "/statuses/01F8MHAMCHF6Y650WCRSCP4WMY",
+			ActivityType: "Create",
+			Request:      httpclient.WrapRequest(req),
+		})
+	}
+
+	wp.Log.Queued(dlvs...)
+
+	// Nothing attempted yet, so both should be queued.
+	entries := wp.Log.Get(actorID)
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 log entries, got %d", len(entries))
+	}
+	for _, entry := range entries {
+		if entry.Status != delivery.LogStatusQueued {
+			t.Fatalf("expected status %q, got %q", delivery.LogStatusQueued, entry.Status)
+		}
+	}
+
+	wp.Queue.Push(dlvs...)
+
+	// Wait for both deliveries to be attempted.
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		entries = wp.Log.Get(actorID)
+		if entries[0].Attempts > 0 && entries[1].Attempts > 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for delivery attempts")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Newest first, so the refused
+	// inbox should be at the top.
+	if entries[0].Status != delivery.LogStatusRetrying {
+		t.Errorf("expected status %q, got %q", delivery.LogStatusRetrying, entries[0].Status)
+	}
+	if entries[0].Error == "" {
+		t.Error("expected error to be set for refused delivery")
+	}
+	if entries[1].Status != delivery.LogStatusDelivered {
+		t.Errorf("expected status %q, got %q", delivery.LogStatusDelivered, entries[1].Status)
+	}
+	if entries[1].ActivityType != "Create" {
+		t.Errorf("expected activity type %q, got %q", "Create", entries[1].ActivityType)
+	}
+
+	// Other actors should have nothing logged.
+	if entries := wp.Log.Get("http://localhost:8080/users/admin"); len(entries) != 0 {
+		t.Errorf("expected no log entries, got %d", len(entries))
+	}
+}
+
+func TestDeliveryLogDisabled(t *testing.T) {
+	config.SetAdvancedDeliveryLogRetention(0)
+	defer config.SetAdvancedDeliveryLogRetention(config.Defaults.AdvancedDeliveryLogRetention)
+
+	req, err := http.NewRequest(http.MethodPost, "http://example.org/inbox", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const actorID = "http://localhost:8080/users/the_mighty_zork"
+
+	var log delivery.Log
+	log.Queued(&delivery.Delivery{
+		ActorID: actorID,
+		Request: httpclient.WrapRequest(req),
+	})
+
+	if entries := log.Get(actorID); len(entries) != 0 {
+		t.Errorf("expected no log entries, got %d", len(entries))
+	}
+}
+
+func TestDeliveryLogPrune(t *testing.T) {
+	config.SetAdvancedDeliveryLogRetention(time.Hour)
+	defer config.SetAdvancedDeliveryLogRetention(config.Defaults.AdvancedDeliveryLogRetention)
+
+	req, err := http.NewRequest(http.MethodPost, "http://example.org/inbox", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const actorID = "http://localhost:8080/users/the_mighty_zork"
+
+	var log delivery.Log
+	log.Queued(&delivery.Delivery{
+		ActorID: actorID,
+		Request: httpclient.WrapRequest(req),
+	})
+
+	// Not expired yet, should be kept.
+	log.Prune(time.Now())
+	if entries := log.Get(actorID); len(entries) != 1 {
+		t.Fatalf("expected 1 log entry, got %d", len(entries))
+	}
+
+	// Past retention, should be dropped.
+	log.Prune(time.Now().Add(2 * time.Hour))
+	if entries := log.Get(actorID); len(entries) != 0 {
+		t.Errorf("expected no log entries, got %d", len(entries))
+	}
+}
@@ -0,0 +1,111 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package delivery
+
+import (
+	"sync"
+	"time"
+)
+
+// DomainStats contains outgoing
+// delivery statistics for one domain.
+type DomainStats struct {
+	// Delivered is the number of
+	// successful delivery attempts.
+	Delivered uint64
+
+	// Failed is the number of
+	// failed delivery attempts.
+	Failed uint64
+
+	// LastDeliveredAt is the time of
+	// the last successful delivery.
+	LastDeliveredAt time.Time
+
+	// LastFailedAt is the time of
+	// the last failed delivery.
+	LastFailedAt time.Time
+}
+
+// Stats tracks outgoing delivery statistics
+// per-domain, until they are flushed for
+// persisting to the database.
+type Stats struct {
+	m  map[string]*DomainStats
+	mu sync.Mutex
+}
+
+// Get returns a copy of the delivery statistics
+// for given domain (if any) not yet flushed.
+func (s *Stats) Get(domain string) DomainStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if stats := s.m[domain]; stats != nil {
+		return *stats
+	}
+	return DomainStats{}
+}
+
+// Flush returns all delivery statistics
+// recorded since the last flush, by domain,
+// and resets them.
+func (s *Stats) Flush() map[string]DomainStats {
+	s.mu.Lock()
+	m := s.m
+	s.m = nil
+	s.mu.Unlock()
+
+	out := make(map[string]DomainStats, len(m))
+	for domain, stats := range m {
+		out[domain] = *stats
+	}
+
+	return out
+}
+
+// success marks a successful delivery to domain.
+func (s *Stats) success(domain string) {
+	s.mu.Lock()
+	stats := s.get(domain)
+	stats.Delivered++
+	stats.LastDeliveredAt = time.Now()
+	s.mu.Unlock()
+}
+
+// failure marks a failed delivery to domain.
+func (s *Stats) failure(domain string) {
+	s.mu.Lock()
+	stats := s.get(domain)
+	stats.Failed++
+	stats.LastFailedAt = time.Now()
+	s.mu.Unlock()
+}
+
+// get returns the stats for domain, allocating
+// if needed. This MUST be called under lock.
+func (s *Stats) get(domain string) *DomainStats {
+	if s.m == nil {
+		s.m = make(map[string]*DomainStats)
+	}
+	stats := s.m[domain]
+	if stats == nil {
+		stats = new(DomainStats)
+		s.m[domain] = stats
+	}
+	return stats
+}
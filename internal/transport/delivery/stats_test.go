@@ -0,0 +1,85 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package delivery_test
+
+import (
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
+	"github.com/superseriousbusiness/gotosocial/internal/transport/delivery"
+)
+
+func TestDeliveryStats(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/inbox", func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusAccepted)
+	})
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	srv := new(http.Server)
+	srv.Handler = mux
+	go srv.Serve(l)
+	defer srv.Close()
+
+	wp := new(delivery.WorkerPool)
+	wp.Init(httpclient.New(httpclient.Config{
+		AllowRanges: config.MustParseIPPrefixes([]string{
+			"127.0.0.0/8",
+		}),
+	}))
+	wp.Start(1)
+	defer wp.Stop()
+
+	req, err := http.NewRequest(http.MethodPost, "http://"+l.Addr().String()+"/inbox", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wp.Queue.Push(&delivery.Delivery{
+		Request: httpclient.WrapRequest(req),
+	})
+
+	// Wait for the delivery to be recorded. Stats
+	// should be keyed by hostname, without port.
+	deadline := time.Now().Add(10 * time.Second)
+	for wp.Stats.Get("127.0.0.1").Delivered == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for delivery")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	flushed := wp.Stats.Flush()
+	if stats := flushed["127.0.0.1"]; stats.Delivered != 1 || stats.LastDeliveredAt.IsZero() {
+		t.Errorf("unexpected flushed stats: %+v", stats)
+	}
+
+	// Stats should be reset after flushing.
+	if stats := wp.Stats.Get("127.0.0.1"); stats.Delivered != 0 {
+		t.Errorf("expected stats to be reset, got %+v", stats)
+	}
+}
@@ -0,0 +1,206 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"codeberg.org/gruf/go-cache/v3"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+const (
+	// maxDerefCacheBodySize is the maximum size of response
+	// body that we will keep around for dereference caching.
+	maxDerefCacheBodySize = 64 * 1024
+
+	// maxDerefCacheEntries bounds the
+	// number of bodies kept in memory.
+	maxDerefCacheEntries = 1000
+
+	// derefCacheTTL is how long we keep
+	// dereferenced bodies for revalidation.
+	derefCacheTTL = 6 * time.Hour
+
+	// defaultDerefFreshness is how long a dereferenced
+	// body is reused without contacting the remote at all,
+	// when the remote gives no Cache-Control max-age. This
+	// is short, but enough to cover bursts of fetches for
+	// the same actor or status, eg., when fetching a thread.
+	defaultDerefFreshness = 30 * time.Second
+
+	// maxDerefFreshness caps any max-age given by remotes,
+	// so that we don't hold on to stale objects for long.
+	maxDerefFreshness = 5 * time.Minute
+)
+
+// derefCacheEntry holds a previously dereferenced
+// response body, along with the validators that the
+// remote gave us for it.
+type derefCacheEntry struct {
+	url          *url.URL
+	eTag         string
+	lastModified string
+	contentType  string
+	body         []byte
+	freshUntil   time.Time
+}
+
+// response returns a new 200 OK
+// response serving the cached body.
+func (e *derefCacheEntry) response() *http.Response {
+	rsp := craftResponse(e.url, http.StatusOK)
+	rsp.Header = http.Header{"Content-Type": {e.contentType}}
+	rsp.ContentLength = int64(len(e.body))
+	rsp.Body = io.NopCloser(bytes.NewReader(e.body))
+	return rsp
+}
+
+// newDerefCache returns a started cache for dereferenced
+// response bodies, with entries expiring after derefCacheTTL.
+func newDerefCache() cache.TTLCache[string, derefCacheEntry] {
+	derefCache := cache.NewTTL[string, derefCacheEntry](0, maxDerefCacheEntries, 0)
+	derefCache.SetTTL(derefCacheTTL, false)
+	if !derefCache.Start(time.Minute) {
+		log.Panic(nil, "could not start derefCache")
+	}
+	return derefCache
+}
+
+// cacheDereferenced stores the body of rsp under key, if it can either
+// be reused for a short while, or revalidated using the ETag or
+// Last-Modified validators the remote gave us. Since this needs to
+// read the body, the body of rsp is replaced with one replaying it.
+func (c *controller) cacheDereferenced(key string, rsp *http.Response) error {
+	var (
+		eTag         = rsp.Header.Get("ETag")
+		lastModified = rsp.Header.Get("Last-Modified")
+		fresh        = freshUntil(rsp.Header)
+	)
+
+	if noStore(rsp.Header) ||
+		(eTag == "" && lastModified == "" && !time.Now().Before(fresh)) {
+		// Either we're not allowed to keep
+		// this, or it would be of no use.
+		c.derefCache.Invalidate(key)
+		return nil
+	}
+
+	if rsp.ContentLength > maxDerefCacheBodySize {
+		// Too big to bother keeping.
+		c.derefCache.Invalidate(key)
+		return nil
+	}
+
+	// Read up to one byte more than
+	// max size, to detect large bodies
+	// of unknown length.
+	body, err := io.ReadAll(io.LimitReader(
+		rsp.Body,
+		maxDerefCacheBodySize+1,
+	))
+	if err != nil {
+		return gtserror.Newf("error reading response body: %w", err)
+	}
+
+	if len(body) > maxDerefCacheBodySize {
+		// Too big; put back what we read in
+		// front of the rest of the body.
+		c.derefCache.Invalidate(key)
+		rsp.Body = struct {
+			io.Reader
+			io.Closer
+		}{
+			Reader: io.MultiReader(bytes.NewReader(body), rsp.Body),
+			Closer: rsp.Body,
+		}
+		return nil
+	}
+
+	_ = rsp.Body.Close() // fully read
+	rsp.Body = io.NopCloser(bytes.NewReader(body))
+
+	c.derefCache.Set(key, derefCacheEntry{
+		url:          rsp.Request.URL,
+		eTag:         eTag,
+		lastModified: lastModified,
+		contentType:  rsp.Header.Get("Content-Type"),
+		body:         body,
+		freshUntil:   fresh,
+	})
+
+	return nil
+}
+
+// freshUntil returns the time until which a response with
+// the given headers may be reused without revalidation, based
+// on its Cache-Control header, capped at maxDerefFreshness.
+func freshUntil(h http.Header) time.Time {
+	freshness := defaultDerefFreshness
+
+	for _, directive := range cacheControlDirectives(h) {
+		switch {
+		case directive == "no-cache":
+			// Must always revalidate.
+			return time.Time{}
+
+		case strings.HasPrefix(directive, "max-age="):
+			secs, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age="))
+			if err != nil || secs <= 0 {
+				return time.Time{}
+			}
+			freshness = time.Duration(secs) * time.Second
+		}
+	}
+
+	freshness = min(freshness, maxDerefFreshness)
+	return time.Now().Add(freshness)
+}
+
+// noStore returns whether the given headers
+// forbid us from storing the response at all.
+func noStore(h http.Header) bool {
+	for _, directive := range cacheControlDirectives(h) {
+		if directive == "no-store" {
+			return true
+		}
+	}
+	return false
+}
+
+// cacheControlDirectives returns the normalized,
+// lowercase directives of the Cache-Control header.
+func cacheControlDirectives(h http.Header) []string {
+	var directives []string
+	for _, value := range h.Values("Cache-Control") {
+		for _, directive := range strings.Split(value, ",") {
+			directive = strings.ToLower(strings.TrimSpace(directive))
+			if directive != "" {
+				directives = append(directives, directive)
+			}
+		}
+	}
+	return directives
+}
@@ -0,0 +1,390 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// maxCardBodySize is the maximum number of bytes read
+// from a linked page or oEmbed response when looking
+// for preview card metadata. Metadata is expected to
+// be in the page <head>, so this is more than enough.
+const maxCardBodySize = 1 << 20 // 1MiB
+
+func (t *transport) DereferenceCard(ctx context.Context, iri *url.URL) (*gtsmodel.Card, error) {
+	// Build IRI just once
+	iriStr := iri.String()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", iriStr, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Accept", apiutil.TextHTML)
+
+	rsp, err := t.GET(req)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	// Ensure a non-error status response.
+	if rsp.StatusCode != http.StatusOK {
+		return nil, gtserror.NewFromResponse(rsp)
+	}
+
+	// Ensure that the response is a page we can parse.
+	ct, _, _ := mime.ParseMediaType(rsp.Header.Get("Content-Type"))
+	if ct != apiutil.TextHTML && ct != "application/xhtml+xml" {
+		err := gtserror.Newf("non html response type: %s", ct)
+		return nil, gtserror.SetMalformed(err)
+	}
+
+	doc, err := html.Parse(io.LimitReader(rsp.Body, maxCardBodySize))
+	if err != nil {
+		err := gtserror.Newf("error parsing html: %w", err)
+		return nil, gtserror.SetMalformed(err)
+	}
+
+	// Relative links on the page are relative
+	// to where we ended up after any redirects.
+	base := iri
+	if rsp.Request != nil {
+		base = rsp.Request.URL
+	}
+
+	var meta cardMeta
+	meta.parse(doc)
+
+	card := meta.toCard(base)
+	card.URL = iriStr
+
+	if meta.oEmbed != "" {
+		// The page advertises an oEmbed endpoint,
+		// which will give us richer info than the
+		// page tags, so try to add that to the card.
+		if u := resolveCardURL(base, meta.oEmbed); u != nil {
+			o, err := t.dereferenceOEmbed(ctx, u)
+			if err != nil {
+				log.Debugf(ctx, "couldn't dereference oembed %s: %v", u, err)
+			} else {
+				o.applyTo(card, base)
+			}
+		}
+	}
+
+	if card.Title == "" {
+		err := gtserror.Newf("no title found for %s", iriStr)
+		return nil, gtserror.SetMalformed(err)
+	}
+
+	// Mark when card was fetched.
+	card.FetchedAt = time.Now()
+	return card, nil
+}
+
+func (t *transport) dereferenceOEmbed(ctx context.Context, iri *url.URL) (*oEmbed, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", iri.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Accept", apiutil.AppJSON)
+
+	rsp, err := t.GET(req)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	// Ensure a non-error status response.
+	if rsp.StatusCode != http.StatusOK {
+		return nil, gtserror.NewFromResponse(rsp)
+	}
+
+	var o oEmbed
+	if err := json.NewDecoder(
+		io.LimitReader(rsp.Body, maxCardBodySize),
+	).Decode(&o); err != nil {
+		err := gtserror.Newf("error decoding oembed: %w", err)
+		return nil, gtserror.SetMalformed(err)
+	}
+
+	return &o, nil
+}
+
+// cardMeta contains the preview card
+// metadata found in the tags of a page.
+type cardMeta struct {
+	title         string
+	ogTitle       string
+	description   string
+	ogDescription string
+	siteName      string
+	author        string
+	creator       string
+	image         string
+	imageWidth    int
+	imageHeight   int
+	oEmbed        string
+}
+
+// parse walks the given html node tree,
+// picking out preview card metadata.
+func (m *cardMeta) parse(n *html.Node) {
+	if n.Type == html.ElementNode {
+		switch n.DataAtom {
+		case atom.Title:
+			if m.title == "" && n.FirstChild != nil &&
+				n.FirstChild.Type == html.TextNode {
+				m.title = strings.TrimSpace(n.FirstChild.Data)
+			}
+
+		case atom.Meta:
+			m.parseMeta(n)
+
+		case atom.Link:
+			m.parseLink(n)
+		}
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		m.parse(c)
+	}
+}
+
+// parseMeta handles OpenGraph and
+// standard <meta> tags on the page.
+func (m *cardMeta) parseMeta(n *html.Node) {
+	key := htmlAttr(n, "property")
+	if key == "" {
+		key = htmlAttr(n, "name")
+	}
+
+	content := strings.TrimSpace(htmlAttr(n, "content"))
+	if content == "" {
+		return
+	}
+
+	// Only take the first of any
+	// tag, eg., the first og:image.
+	setOnce := func(s *string) {
+		if *s == "" {
+			*s = content
+		}
+	}
+
+	switch strings.ToLower(key) {
+	case "og:title":
+		setOnce(&m.ogTitle)
+	case "og:description":
+		setOnce(&m.ogDescription)
+	case "description":
+		setOnce(&m.description)
+	case "og:site_name":
+		setOnce(&m.siteName)
+	case "author":
+		setOnce(&m.author)
+	case "fediverse:creator":
+		setOnce(&m.creator)
+	case "og:image", "og:image:url", "og:image:secure_url":
+		setOnce(&m.image)
+	case "og:image:width":
+		if m.imageWidth == 0 {
+			m.imageWidth, _ = strconv.Atoi(content)
+		}
+	case "og:image:height":
+		if m.imageHeight == 0 {
+			m.imageHeight, _ = strconv.Atoi(content)
+		}
+	}
+}
+
+// parseLink handles oEmbed discovery <link> tags, see:
+// https://oembed.com/#section4
+func (m *cardMeta) parseLink(n *html.Node) {
+	if m.oEmbed != "" {
+		return
+	}
+
+	if !strings.EqualFold(htmlAttr(n, "rel"), "alternate") ||
+		!strings.EqualFold(htmlAttr(n, "type"), "application/json+oembed") {
+		return
+	}
+
+	m.oEmbed = htmlAttr(n, "href")
+}
+
+// toCard returns a new link card from the page metadata,
+// with any links resolved relative to the given page URL.
+func (m *cardMeta) toCard(base *url.URL) *gtsmodel.Card {
+	card := &gtsmodel.Card{
+		Type:         gtsmodel.CardTypeLink,
+		Title:        m.ogTitle,
+		Description:  m.ogDescription,
+		ProviderName: m.siteName,
+		AuthorName:   m.author,
+		Creator:      m.creator,
+	}
+
+	if card.Title == "" {
+		card.Title = m.title
+	}
+
+	if card.Description == "" {
+		card.Description = m.description
+	}
+
+	if u := resolveCardURL(base, m.image); u != nil {
+		card.ImageURL = u.String()
+		card.Width = m.imageWidth
+		card.Height = m.imageHeight
+	}
+
+	return card
+}
+
+// oEmbed models the oEmbed response fields used for cards, see:
+// https://oembed.com/#section2.3
+type oEmbed struct {
+	Type         string     `json:"type"`
+	Title        string     `json:"title"`
+	AuthorName   string     `json:"author_name"`
+	AuthorURL    string     `json:"author_url"`
+	ProviderName string     `json:"provider_name"`
+	ProviderURL  string     `json:"provider_url"`
+	URL          string     `json:"url"`
+	HTML         string     `json:"html"`
+	Width        oEmbedSize `json:"width"`
+	Height       oEmbedSize `json:"height"`
+	ThumbnailURL string     `json:"thumbnail_url"`
+}
+
+// oEmbedSize is a pixel size in an oEmbed response. The spec
+// says these are integers, but some providers send strings.
+type oEmbedSize int
+
+func (s *oEmbedSize) UnmarshalJSON(b []byte) error {
+	// Ignore any values we can't make sense of.
+	i, _ := strconv.Atoi(strings.Trim(string(b), `"`))
+	*s = oEmbedSize(i)
+	return nil
+}
+
+// applyTo updates the given card with oEmbed data, with any
+// links resolved relative to the given page URL. Any embed
+// html is set as-is, and must be sanitized by the caller.
+func (o *oEmbed) applyTo(card *gtsmodel.Card, base *url.URL) {
+	if o.Title != "" {
+		card.Title = o.Title
+	}
+
+	if o.AuthorName != "" {
+		card.AuthorName = o.AuthorName
+	}
+
+	if u := resolveCardURL(base, o.AuthorURL); u != nil {
+		card.AuthorURL = u.String()
+	}
+
+	if o.ProviderName != "" {
+		card.ProviderName = o.ProviderName
+	}
+
+	if u := resolveCardURL(base, o.ProviderURL); u != nil {
+		card.ProviderURL = u.String()
+	}
+
+	if u := resolveCardURL(base, o.ThumbnailURL); u != nil {
+		card.ImageURL = u.String()
+	}
+
+	switch gtsmodel.CardType(o.Type) {
+	case gtsmodel.CardTypePhoto:
+		u := resolveCardURL(base, o.URL)
+		if u == nil {
+			return
+		}
+
+		card.Type = gtsmodel.CardTypePhoto
+		card.EmbedURL = u.String()
+		if card.ImageURL == "" {
+			card.ImageURL = card.EmbedURL
+		}
+
+	case gtsmodel.CardTypeVideo, gtsmodel.CardTypeRich:
+		if o.HTML == "" {
+			return
+		}
+
+		card.Type = gtsmodel.CardType(o.Type)
+		card.HTML = o.HTML
+
+	default:
+		return
+	}
+
+	card.Width = int(o.Width)
+	card.Height = int(o.Height)
+}
+
+// resolveCardURL parses the given link relative to the given
+// page URL, returning nil if it's not a valid http(s) URL.
+func resolveCardURL(base *url.URL, link string) *url.URL {
+	if link == "" {
+		return nil
+	}
+
+	u, err := base.Parse(link)
+	if err != nil {
+		return nil
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil
+	}
+
+	return u
+}
+
+// htmlAttr returns the value of the
+// given attribute on the html node.
+func htmlAttr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
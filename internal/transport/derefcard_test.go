@@ -0,0 +1,186 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package transport_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type DerefCardTestSuite struct {
+	TransportTestSuite
+}
+
+// cardTransport returns a transport which
+// serves the given bodies by request URL.
+func (suite *DerefCardTestSuite) cardTransport(bodies map[string]string) transport.Transport {
+	httpClient := testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		body, ok := bodies[req.URL.String()]
+		if !ok {
+			return &http.Response{
+				Request:    req,
+				StatusCode: http.StatusNotFound,
+				Body:       io.NopCloser(bytes.NewReader(nil)),
+			}, nil
+		}
+
+		contentType := "text/html; charset=utf-8"
+		if req.Header.Get("Accept") == "application/json" {
+			contentType = "application/json"
+		}
+
+		return &http.Response{
+			Request:       req,
+			StatusCode:    http.StatusOK,
+			Body:          io.NopCloser(bytes.NewReader([]byte(body))),
+			ContentLength: int64(len(body)),
+			Header:        http.Header{"Content-Type": {contentType}},
+		}, nil
+	}, "")
+
+	tc := testrig.NewTestTransportController(&suite.state, httpClient)
+	ts, err := tc.NewTransportForUsername(context.Background(), "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return ts
+}
+
+func (suite *DerefCardTestSuite) TestDereferenceCardOpenGraph() {
+	ts := suite.cardTransport(map[string]string{
+		"https://example.org/article": `<!DOCTYPE html>
+<html>
+<head>
+<title>Page title</title>
+<meta property="og:title" content="Is Water Wet?">
+<meta property="og:description" content="We ask an expert.">
+<meta property="og:site_name" content="Example News">
+<meta property="og:image" content="/images/water.jpg">
+<meta property="og:image:width" content="1200">
+<meta property="og:image:height" content="630">
+<meta name="author" content="Some Writer">
+<meta name="fediverse:creator" content="@writer@example.org">
+</head>
+<body><p>Hello.</p></body>
+</html>`,
+	})
+
+	card, err := ts.DereferenceCard(context.Background(), testrig.URLMustParse("https://example.org/article"))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal("https://example.org/article", card.URL)
+	suite.Equal(gtsmodel.CardTypeLink, card.Type)
+	suite.Equal("Is Water Wet?", card.Title)
+	suite.Equal("We ask an expert.", card.Description)
+	suite.Equal("Example News", card.ProviderName)
+	suite.Equal("Some Writer", card.AuthorName)
+	suite.Equal("@writer@example.org", card.Creator)
+	suite.Equal("https://example.org/images/water.jpg", card.ImageURL)
+	suite.Equal(1200, card.Width)
+	suite.Equal(630, card.Height)
+	suite.False(card.FetchedAt.IsZero())
+}
+
+func (suite *DerefCardTestSuite) TestDereferenceCardOEmbed() {
+	ts := suite.cardTransport(map[string]string{
+		"https://video.example.org/watch/1": `<html><head>
+<title>A video</title>
+<link rel="alternate" type="application/json+oembed" href="https://video.example.org/oembed?url=1">
+</head></html>`,
+		"https://video.example.org/oembed?url=1": `{
+  "type": "video",
+  "title": "A really good video",
+  "author_name": "Video Maker",
+  "author_url": "https://video.example.org/@maker",
+  "provider_name": "Example Video",
+  "html": "<p>embedded video</p>",
+  "width": "640",
+  "height": 360,
+  "thumbnail_url": "https://video.example.org/thumb/1.jpg"
+}`,
+	})
+
+	card, err := ts.DereferenceCard(context.Background(), testrig.URLMustParse("https://video.example.org/watch/1"))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(gtsmodel.CardTypeVideo, card.Type)
+	suite.Equal("A really good video", card.Title)
+	suite.Equal("Video Maker", card.AuthorName)
+	suite.Equal("https://video.example.org/@maker", card.AuthorURL)
+	suite.Equal("Example Video", card.ProviderName)
+	suite.Equal("<p>embedded video</p>", card.HTML)
+	suite.Equal("https://video.example.org/thumb/1.jpg", card.ImageURL)
+	suite.Equal(640, card.Width)
+	suite.Equal(360, card.Height)
+}
+
+func (suite *DerefCardTestSuite) TestDereferenceCardNoCard() {
+	ts := suite.cardTransport(map[string]string{
+		"https://example.org/untitled": `<html><body><p>No metadata here.</p></body></html>`,
+	})
+
+	// A page without any title can't make a card.
+	_, err := ts.DereferenceCard(context.Background(), testrig.URLMustParse("https://example.org/untitled"))
+	suite.Error(err)
+
+	// Neither can a page that isn't there.
+	_, err = ts.DereferenceCard(context.Background(), testrig.URLMustParse("https://example.org/missing"))
+	suite.Error(err)
+}
+
+func (suite *DerefCardTestSuite) TestDereferenceRelMe() {
+	ts := suite.cardTransport(map[string]string{
+		"https://example.org/about": `<!DOCTYPE html>
+<html>
+<head>
+<link rel="me" href="https://fossbros-anonymous.io/@foss_satan">
+</head>
+<body>
+<a href="/contact">Contact</a>
+<a rel="nofollow me" href="/users/someone">Me, elsewhere</a>
+<a rel="me" href="javascript:alert(1)">Nope</a>
+</body>
+</html>`,
+	})
+
+	links, err := ts.DereferenceRelMe(context.Background(), testrig.URLMustParse("https://example.org/about"))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Len(links, 2)
+	suite.Equal("https://fossbros-anonymous.io/@foss_satan", links[0].String())
+	suite.Equal("https://example.org/users/someone", links[1].String())
+}
+
+func TestDerefCardTestSuite(t *testing.T) {
+	suite.Run(t, &DerefCardTestSuite{})
+}
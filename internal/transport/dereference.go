@@ -21,6 +21,7 @@ import (
 	"context"
 	"net/http"
 	"net/url"
+	"time"
 
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
@@ -55,12 +56,46 @@ func (t *transport) Dereference(ctx context.Context, iri *url.URL) (*http.Respon
 	req.Header.Add("Accept", string(apiutil.AppActivityLDJSON)+","+string(apiutil.AppActivityJSON))
 	req.Header.Add("Accept-Charset", "utf-8")
 
+	// Responses may differ depending on
+	// who is asking, so key cached bodies
+	// by the key ID of the signing account.
+	cacheKey := t.pubKeyID + " " + iriStr
+
+	entry, cached := t.controller.derefCache.Get(cacheKey)
+	if cached && time.Now().Before(entry.freshUntil) {
+		// We fetched this recently enough that
+		// we don't need to bother the remote.
+		return entry.response(), nil
+	}
+
+	if cached {
+		// We have the last representation of this
+		// IRI, so ask the remote to only send it
+		// again if it changed since we last saw it.
+		if entry.eTag != "" {
+			req.Header.Set("If-None-Match", entry.eTag)
+		}
+		if entry.lastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.lastModified)
+		}
+	}
+
 	// Perform the HTTP request
 	rsp, err := t.GET(req)
 	if err != nil {
 		return nil, err
 	}
 
+	if cached && rsp.StatusCode == http.StatusNotModified {
+		// Unchanged, serve the response from our
+		// cached copy of the body, and extend its
+		// freshness by whatever the remote allows.
+		_ = rsp.Body.Close()
+		entry.freshUntil = freshUntil(rsp.Header)
+		t.controller.derefCache.Set(cacheKey, entry)
+		return entry.response(), nil
+	}
+
 	// Ensure a non-error status response.
 	if rsp.StatusCode != http.StatusOK {
 		err := gtserror.NewFromResponse(rsp)
@@ -75,5 +110,10 @@ func (t *transport) Dereference(ctx context.Context, iri *url.URL) (*http.Respon
 		return nil, gtserror.SetMalformed(err)
 	}
 
+	if err := t.controller.cacheDereferenced(cacheKey, rsp); err != nil {
+		_ = rsp.Body.Close() // done with body
+		return nil, err
+	}
+
 	return rsp, nil
 }
@@ -0,0 +1,144 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package transport_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type DereferenceTestSuite struct {
+	TransportTestSuite
+}
+
+func (suite *DereferenceTestSuite) TestDereferenceNotModified() {
+	const (
+		body = `{"@context":"https://www.w3.org/ns/activitystreams","id":"https://example.org/users/someone","type":"Person"}`
+		eTag = `"v1"`
+	)
+
+	var ifNoneMatch []string
+	httpClient := testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		ifNoneMatch = append(ifNoneMatch, req.Header.Get("If-None-Match"))
+
+		header := make(http.Header)
+		header.Set("ETag", eTag)
+		header.Set("Cache-Control", "private, no-cache")
+
+		if req.Header.Get("If-None-Match") == eTag {
+			return &http.Response{
+				StatusCode: http.StatusNotModified,
+				Header:     header,
+				Body:       http.NoBody,
+				Request:    req,
+			}, nil
+		}
+
+		header.Set("Content-Type", "application/activity+json")
+		return &http.Response{
+			StatusCode:    http.StatusOK,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader([]byte(body))),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}, "")
+
+	ts, err := testrig.NewTestTransportController(&suite.state, httpClient).NewTransportForUsername(context.Background(), "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	iri, _ := url.Parse("https://example.org/users/someone")
+	for i := 0; i < 2; i++ {
+		rsp, err := ts.Dereference(context.Background(), iri)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		b, err := io.ReadAll(rsp.Body)
+		rsp.Body.Close()
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		// Both the full and the not modified
+		// response should give us the body.
+		suite.Equal(http.StatusOK, rsp.StatusCode)
+		suite.Equal(body, string(b))
+	}
+
+	// Second request should have been conditional.
+	suite.Equal([]string{"", eTag}, ifNoneMatch)
+}
+
+func (suite *DereferenceTestSuite) TestDereferenceFresh() {
+	const body = `{"@context":"https://www.w3.org/ns/activitystreams","id":"https://example.org/users/someone/statuses/01","type":"Note"}`
+
+	var requests int
+	httpClient := testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		requests++
+
+		header := make(http.Header)
+		header.Set("Content-Type", "application/activity+json")
+		header.Set("Cache-Control", "max-age=60")
+		return &http.Response{
+			StatusCode:    http.StatusOK,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader([]byte(body))),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}, "")
+
+	ts, err := testrig.NewTestTransportController(&suite.state, httpClient).NewTransportForUsername(context.Background(), "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	iri, _ := url.Parse("https://example.org/users/someone/statuses/01")
+	for i := 0; i < 3; i++ {
+		rsp, err := ts.Dereference(context.Background(), iri)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		b, err := io.ReadAll(rsp.Body)
+		rsp.Body.Close()
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.Equal(body, string(b))
+		suite.Equal(iri.String(), rsp.Request.URL.String())
+	}
+
+	// Only the first dereference should
+	// have actually reached the remote.
+	suite.Equal(1, requests)
+}
+
+func TestDereferenceTestSuite(t *testing.T) {
+	suite.Run(t, new(DereferenceTestSuite))
+}
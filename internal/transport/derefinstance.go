@@ -26,6 +26,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
@@ -38,6 +39,17 @@ import (
 )
 
 func (t *transport) DereferenceInstance(ctx context.Context, iri *url.URL) (*gtsmodel.Instance, error) {
+	i, err := t.dereferenceInstance(ctx, iri)
+	if err != nil {
+		return nil, err
+	}
+
+	// Mark when instance was fetched.
+	i.FetchedAt = time.Now()
+	return i, nil
+}
+
+func (t *transport) dereferenceInstance(ctx context.Context, iri *url.URL) (*gtsmodel.Instance, error) {
 	var i *gtsmodel.Instance
 	var err error
 
@@ -49,6 +61,16 @@ func (t *transport) DereferenceInstance(ctx context.Context, iri *url.URL) (*gts
 	i, err = dereferenceByAPIV1Instance(ctx, t, iri)
 	if err == nil {
 		log.Debugf(ctx, "successfully dereferenced instance using /api/v1/instance")
+
+		// The Mastodon API doesn't tell us which software an instance
+		// is running (and the version is often a Mastodon-compatible
+		// one), so supplement this with nodeinfo software if available.
+		if ni, _, err := dereferenceNodeInfo(ctx, t, iri); err != nil {
+			log.Debugf(ctx, "couldn't dereference instance software using /.well-known/nodeinfo: %s", err)
+		} else if software, version := nodeInfoSoftware(ni); software != "" {
+			i.Software, i.Version = software, version
+		}
+
 		return i, nil
 	}
 	log.Debugf(ctx, "couldn't dereference instance using /api/v1/instance: %s", err)
@@ -144,20 +166,68 @@ func dereferenceByAPIV1Instance(ctx context.Context, t *transport, iri *url.URL)
 		ContactEmail:           apiResp.Email,
 		ContactAccountUsername: contactUsername,
 		Version:                apiResp.Version,
+		OnionURI:               onionURI(resp),
 	}
 
 	return i, nil
 }
 
-func dereferenceByNodeInfo(c context.Context, t *transport, iri *url.URL) (*gtsmodel.Instance, error) {
-	niIRI, err := callNodeInfoWellKnown(c, t, iri)
+// dereferenceNodeInfo performs the two calls necessary to fetch
+// nodeinfo of the instance at the given iri: first to the well-known
+// nodeinfo endpoint, and then to the nodeinfo schema 2.x document.
+// Also returned is the onion URI advertised by the instance, if any.
+func dereferenceNodeInfo(c context.Context, t *transport, iri *url.URL) (*apimodel.Nodeinfo, string, error) {
+	niIRI, onionURI, err := callNodeInfoWellKnown(c, t, iri)
 	if err != nil {
-		return nil, fmt.Errorf("dereferenceByNodeInfo: error during initial call to well-known nodeinfo: %s", err)
+		return nil, "", fmt.Errorf("error during initial call to well-known nodeinfo: %w", err)
 	}
 
 	ni, err := callNodeInfo(c, t, niIRI)
 	if err != nil {
-		return nil, fmt.Errorf("dereferenceByNodeInfo: error doing second call to nodeinfo uri %s: %s", niIRI.String(), err)
+		return nil, "", fmt.Errorf("error doing second call to nodeinfo uri %s: %w", niIRI.String(), err)
+	}
+
+	return ni, onionURI, nil
+}
+
+// onionURI returns the base URI of the Tor onion service
+// advertised in the Onion-Location header of the given
+// response, if any, eg. "http://example.onion".
+func onionURI(resp *http.Response) string {
+	location := resp.Header.Get("Onion-Location")
+	if location == "" {
+		return ""
+	}
+
+	u, err := url.Parse(location)
+	if err != nil {
+		return ""
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") ||
+		!strings.HasSuffix(u.Hostname(), ".onion") {
+		return ""
+	}
+
+	return u.Scheme + "://" + u.Host
+}
+
+// nodeInfoSoftware returns the software name
+// and combined "name version" version string
+// from the given nodeinfo document.
+func nodeInfoSoftware(ni *apimodel.Nodeinfo) (software string, version string) {
+	software = ni.Software.Name
+	version = software
+	if ni.Software.Version != "" {
+		version = version + " " + ni.Software.Version
+	}
+	return software, version
+}
+
+func dereferenceByNodeInfo(c context.Context, t *transport, iri *url.URL) (*gtsmodel.Instance, error) {
+	ni, onionURI, err := dereferenceNodeInfo(c, t, iri)
+	if err != nil {
+		return nil, fmt.Errorf("dereferenceByNodeInfo: %w", err)
 	}
 
 	// we got a response of some kind! take what we can from it...
@@ -168,9 +238,10 @@ func dereferenceByNodeInfo(c context.Context, t *transport, iri *url.URL) (*gtsm
 
 	// this is the bare minimum instance we'll return, and we'll add more stuff to it if we can
 	i := &gtsmodel.Instance{
-		ID:     id,
-		Domain: iri.Host,
-		URI:    iri.String(),
+		ID:       id,
+		Domain:   iri.Host,
+		URI:      iri.String(),
+		OnionURI: onionURI,
 	}
 
 	var title string
@@ -222,19 +293,12 @@ func dereferenceByNodeInfo(c context.Context, t *transport, iri *url.URL) (*gtsm
 	i.ContactEmail = contactEmail
 	i.ContactAccountUsername = contactAccountUsername
 
-	var software string
-	if ni.Software.Name != "" {
-		software = ni.Software.Name
-	}
-	if ni.Software.Version != "" {
-		software = software + " " + ni.Software.Version
-	}
-	i.Version = software
+	i.Software, i.Version = nodeInfoSoftware(ni)
 
 	return i, nil
 }
 
-func callNodeInfoWellKnown(ctx context.Context, t *transport, iri *url.URL) (*url.URL, error) {
+func callNodeInfoWellKnown(ctx context.Context, t *transport, iri *url.URL) (*url.URL, string, error) {
 	cleanIRI := &url.URL{
 		Scheme: iri.Scheme,
 		Host:   iri.Host,
@@ -246,37 +310,37 @@ func callNodeInfoWellKnown(ctx context.Context, t *transport, iri *url.URL) (*ur
 
 	req, err := http.NewRequestWithContext(ctx, "GET", iriStr, nil)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	req.Header.Add("Accept", string(apiutil.AppJSON))
 
 	resp, err := t.GET(req)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	defer resp.Body.Close()
 
 	// Ensure a non-error status response.
 	if resp.StatusCode != http.StatusOK {
-		return nil, gtserror.NewFromResponse(resp)
+		return nil, "", gtserror.NewFromResponse(resp)
 	}
 
 	// Ensure that the incoming request content-type is expected.
 	if ct := resp.Header.Get("Content-Type"); !apiutil.JSONContentType(ct) {
 		err := gtserror.Newf("non json response type: %s", ct)
-		return nil, gtserror.SetMalformed(err)
+		return nil, "", gtserror.SetMalformed(err)
 	}
 
 	b, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	} else if len(b) == 0 {
-		return nil, gtserror.New("response bytes was len 0")
+		return nil, "", gtserror.New("response bytes was len 0")
 	}
 
 	wellKnownResp := &apimodel.WellKnownResponse{}
 	if err := json.Unmarshal(b, wellKnownResp); err != nil {
-		return nil, gtserror.Newf("could not unmarshal server response as WellKnownResponse: %w", err)
+		return nil, "", gtserror.Newf("could not unmarshal server response as WellKnownResponse: %w", err)
 	}
 
 	// look through the links for the first one that matches the nodeinfo schema, this is what we need
@@ -287,14 +351,14 @@ func callNodeInfoWellKnown(ctx context.Context, t *transport, iri *url.URL) (*ur
 		}
 		nodeinfoHref, err = url.Parse(l.Href)
 		if err != nil {
-			return nil, gtserror.Newf("couldn't parse url %s: %w", l.Href, err)
+			return nil, "", gtserror.Newf("couldn't parse url %s: %w", l.Href, err)
 		}
 	}
 	if nodeinfoHref == nil {
-		return nil, gtserror.New("could not find nodeinfo rel in well known response")
+		return nil, "", gtserror.New("could not find nodeinfo rel in well known response")
 	}
 
-	return nodeinfoHref, nil
+	return nodeinfoHref, onionURI(resp), nil
 }
 
 func callNodeInfo(ctx context.Context, t *transport, iri *url.URL) (*apimodel.Nodeinfo, error) {
@@ -0,0 +1,106 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// maxRelMeBodySize is the maximum number of bytes
+// read from a linked page when looking for rel="me"
+// links. These are usually near the top of a page.
+const maxRelMeBodySize = 1 << 20 // 1MiB
+
+func (t *transport) DereferenceRelMe(ctx context.Context, iri *url.URL) ([]*url.URL, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", iri.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Accept", apiutil.TextHTML)
+
+	rsp, err := t.GET(req)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	// Ensure a non-error status response.
+	if rsp.StatusCode != http.StatusOK {
+		return nil, gtserror.NewFromResponse(rsp)
+	}
+
+	// Ensure that the response is a page we can parse.
+	ct, _, _ := mime.ParseMediaType(rsp.Header.Get("Content-Type"))
+	if ct != apiutil.TextHTML && ct != "application/xhtml+xml" {
+		err := gtserror.Newf("non html response type: %s", ct)
+		return nil, gtserror.SetMalformed(err)
+	}
+
+	doc, err := html.Parse(io.LimitReader(rsp.Body, maxRelMeBodySize))
+	if err != nil {
+		err := gtserror.Newf("error parsing html: %w", err)
+		return nil, gtserror.SetMalformed(err)
+	}
+
+	// Relative links on the page are relative
+	// to where we ended up after any redirects.
+	base := iri
+	if rsp.Request != nil {
+		base = rsp.Request.URL
+	}
+
+	var links []*url.URL
+	parseRelMe(doc, base, &links)
+	return links, nil
+}
+
+// parseRelMe walks the given html node tree, appending
+// the targets of any <a> or <link> tags with rel="me".
+func parseRelMe(n *html.Node, base *url.URL, links *[]*url.URL) {
+	if n.Type == html.ElementNode &&
+		(n.DataAtom == atom.A || n.DataAtom == atom.Link) {
+		// The rel attribute is a space
+		// separated list of link types.
+		for _, rel := range strings.Fields(htmlAttr(n, "rel")) {
+			if !strings.EqualFold(rel, "me") {
+				continue
+			}
+
+			if u := resolveCardURL(base, htmlAttr(n, "href")); u != nil {
+				*links = append(*links, u)
+			}
+
+			break
+		}
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		parseRelMe(c, base, links)
+	}
+}
@@ -70,6 +70,12 @@ type Transport interface {
 	// DereferenceMedia fetches the given media attachment IRI, returning the reader and filesize.
 	DereferenceMedia(ctx context.Context, iri *url.URL) (io.ReadCloser, int64, error)
 
+	// DereferenceCard fetches the page at the given link, returning a preview card from its OpenGraph and oEmbed metadata.
+	DereferenceCard(ctx context.Context, iri *url.URL) (*gtsmodel.Card, error)
+
+	// DereferenceRelMe fetches the page at the given link, returning the targets of any rel="me" links on it.
+	DereferenceRelMe(ctx context.Context, iri *url.URL) ([]*url.URL, error)
+
 	// DereferenceInstance dereferences remote instance information, first by checking /api/v1/instance, and then by checking /.well-known/nodeinfo.
 	DereferenceInstance(ctx context.Context, iri *url.URL) (*gtsmodel.Instance, error)
 
@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"net/url"
+	"strings"
 
 	"github.com/miekg/dns"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
@@ -29,6 +30,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
@@ -210,6 +212,18 @@ func (c *Converter) ASRepresentationToAccount(ctx context.Context, accountable a
 		acct.AlsoKnownAsURIs = append(acct.AlsoKnownAsURIs, uri.String())
 	}
 
+	// Domains on which content may be credited
+	// to this account, used to verify authorship.
+	for i, domain := range ap.GetAttributionDomains(accountable) {
+		// Same again,
+		// cap at 20.
+		if i >= 20 {
+			break
+		}
+
+		acct.AttributionDomains = append(acct.AttributionDomains, domain)
+	}
+
 	// Extract account public key and verify ownership to account.
 	pkey, pkeyURL, pkeyOwnerID, err := ap.ExtractPubKeyFromActor(accountable)
 	if err != nil {
@@ -331,6 +345,14 @@ func (c *Converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 		log.Warnf(ctx, "unusable published property on %s", uri)
 	}
 
+	// status.EditedAt
+	//
+	// Extract updated time for the status,
+	// only set if it's been edited since publishing.
+	if upd := ap.GetUpdated(statusable); upd.After(status.CreatedAt) {
+		status.EditedAt = upd
+	}
+
 	// status.AccountURI
 	// status.AccountID
 	// status.Account
@@ -383,6 +405,30 @@ func (c *Converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 		}
 	}
 
+	// status.QuoteOfURI
+	// status.QuoteOfID
+	// status.QuoteOf
+	//
+	// Status that this status quotes, if applicable.
+	// As with inReplyTo, if we don't have the quoted
+	// status in the database, just set the URI for now.
+	if quoteOf := ap.ExtractQuoteURI(statusable); quoteOf != nil {
+		quoteOfURI := quoteOf.String()
+		status.QuoteOfURI = quoteOfURI
+
+		// Check if we already have the quoted status.
+		quoteOf, err := c.state.DB.GetStatusByURI(ctx, quoteOfURI)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("error getting quoted status %s from db: %w", quoteOfURI, err)
+			return nil, err
+		}
+
+		if quoteOf != nil {
+			status.QuoteOfID = quoteOf.ID
+			status.QuoteOf = quoteOf
+		}
+	}
+
 	// Calculate intended visibility of the status.
 	status.Visibility, err = ap.ExtractVisibility(
 		statusable,
@@ -407,6 +453,19 @@ func (c *Converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 	sensitive := ap.ExtractSensitive(statusable)
 	status.Sensitive = &sensitive
 
+	// status.RemoteFavesCount
+	// status.RemoteBoostsCount
+	//
+	// Interaction counts, if the likes / shares
+	// collections are embedded in the status; any
+	// that aren't can be dereferenced separately.
+	if count, _ := ap.ExtractLikes(statusable); count != nil {
+		status.RemoteFavesCount = *count
+	}
+	if count, _ := ap.ExtractShares(statusable); count != nil {
+		status.RemoteBoostsCount = *count
+	}
+
 	// ActivityStreamsType
 	status.ActivityStreamsType = statusable.GetTypeName()
 
@@ -440,9 +499,41 @@ func (c *Converter) ASFollowToFollowRequest(ctx context.Context, followable ap.F
 		TargetAccountID: target.ID,
 	}
 
+	// Some software allows users to attach a
+	// short message to follow requests, which
+	// is sent as content; keep it as plaintext.
+	if withContent, ok := followable.(ap.WithContent); ok {
+		followRequest.Note = followRequestNote(ap.ExtractContent(withContent))
+	}
+
 	return followRequest, nil
 }
 
+// maxFollowRequestNoteChars is the maximum length
+// in runes of a note on an incoming follow request.
+const maxFollowRequestNoteChars = 500
+
+// followRequestNote returns a trimmed, plaintext
+// note from the given follow request content.
+func followRequestNote(content gtsmodel.Content) string {
+	raw := content.Content
+	if raw == "" {
+		// Fall back to any
+		// contentMap value.
+		for _, v := range content.ContentMap {
+			raw = v
+			break
+		}
+	}
+
+	note := []rune(strings.TrimSpace(text.SanitizeToPlaintext(raw)))
+	if len(note) > maxFollowRequestNoteChars {
+		note = note[:maxFollowRequestNoteChars]
+	}
+
+	return string(note)
+}
+
 // ASFollowToFollowRequest converts a remote activitystreams `follow` representation into gts model follow.
 func (c *Converter) ASFollowToFollow(ctx context.Context, followable ap.Followable) (*gtsmodel.Follow, error) {
 	uriObj := ap.GetJSONLDId(followable)
@@ -505,6 +596,81 @@ func (c *Converter) ASLikeToFave(ctx context.Context, likeable ap.Likeable) (*gt
 	}, nil
 }
 
+// ASLikeToStatusReaction converts a remote activitystreams 'like' carrying
+// an emoji reaction into a gts model status reaction. If the reaction
+// uses a custom emoji we don't have yet, the barebones emoji will be set
+// on the returned reaction without an EmojiID, for the caller to fetch.
+//
+// Returns a gtserror.WrongType error if the like isn't an emoji reaction.
+func (c *Converter) ASLikeToStatusReaction(ctx context.Context, reactable ap.EmojiReactable) (*gtsmodel.StatusReaction, error) {
+	uriObj := ap.GetJSONLDId(reactable)
+	if uriObj == nil {
+		err := gtserror.New("unusable iri property")
+		return nil, gtserror.SetMalformed(err)
+	}
+
+	// Stringify uri obj.
+	uri := uriObj.String()
+
+	name, emoji := ap.ExtractEmojiReaction(reactable)
+	if name == "" {
+		err := gtserror.Newf("%s is not an emoji reaction", uri)
+		return nil, gtserror.SetWrongType(err)
+	}
+
+	origin, err := c.getASActorAccount(ctx, uri, reactable)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := c.getASObjectStatus(ctx, uri, reactable)
+	if err != nil {
+		return nil, err
+	}
+
+	reaction := &gtsmodel.StatusReaction{
+		AccountID:       origin.ID,
+		Account:         origin,
+		TargetAccountID: target.AccountID,
+		TargetAccount:   target.Account,
+		StatusID:        target.ID,
+		Status:          target,
+		Name:            name,
+		URI:             uri,
+	}
+
+	if emoji != nil {
+		domain := emoji.Domain
+		if domain == config.GetHost() {
+			// Our own emoji, stored
+			// without a domain.
+			domain = ""
+		}
+
+		// See if we already have this emoji.
+		known, err := c.state.DB.GetEmojiByShortcodeDomain(ctx,
+			emoji.Shortcode,
+			domain,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.Newf("db error getting emoji %s: %w", emoji.Shortcode, err)
+		}
+
+		if known != nil {
+			reaction.EmojiID = known.ID
+			reaction.Emoji = known
+		} else if domain != "" {
+			// Remote emoji to fetch.
+			reaction.Emoji = emoji
+		} else {
+			err := gtserror.Newf("%s reacts with unknown local emoji %s", uri, emoji.Shortcode)
+			return nil, gtserror.SetWrongType(err)
+		}
+	}
+
+	return reaction, nil
+}
+
 // ASBlockToBlock converts a remote activity streams 'block' representation into a gts model block.
 func (c *Converter) ASBlockToBlock(ctx context.Context, blockable ap.Blockable) (*gtsmodel.Block, error) {
 	uriObj := ap.GetJSONLDId(blockable)
@@ -515,6 +515,35 @@ func (suite *ASToInternalTestSuite) TestParseAnnounce() {
 	suite.Nil(boost.BoostOfAccount)
 }
 
+func (suite *ASToInternalTestSuite) TestParseFollowWithNote() {
+	followingAccount := suite.testAccounts["remote_account_1"]
+	targetAccount := suite.testAccounts["local_account_2"]
+
+	raw := `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "actor": "` + followingAccount.URI + `",
+  "id": "http://fossbros-anonymous.io/follows/9f4a2a8e-5a4e-4a2b-8a63-0a9d7c3c1c2e",
+  "object": "` + targetAccount.URI + `",
+  "content": "<p>hey! we met at <b>the conference</b> :)</p>",
+  "type": "Follow"
+  }`
+
+	t := suite.jsonToType(raw)
+	asFollow, ok := t.(ap.Followable)
+	if !ok {
+		suite.FailNow("type not coercible")
+	}
+
+	fr, err := suite.typeconverter.ASFollowToFollowRequest(context.Background(), asFollow)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(followingAccount.ID, fr.AccountID)
+	suite.Equal(targetAccount.ID, fr.TargetAccountID)
+	suite.Equal("hey! we met at the conference :)", fr.Note)
+}
+
 func (suite *ASToInternalTestSuite) TestParseHonkAccount() {
 	// Hopefully comprehensive checks for
 	// https://github.com/superseriousbusiness/gotosocial/issues/2527.
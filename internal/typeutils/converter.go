@@ -20,6 +20,7 @@ package typeutils
 import (
 	"sync"
 
+	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 )
 
@@ -27,11 +28,13 @@ type Converter struct {
 	state          *state.State
 	defaultAvatars []string
 	randAvatars    sync.Map
+	visFilter      *visibility.Filter
 }
 
 func NewConverter(state *state.State) *Converter {
 	return &Converter{
 		state:          state,
 		defaultAvatars: populateDefaultAvatars(),
+		visFilter:      visibility.NewFilter(state),
 	}
 }
@@ -47,3 +47,27 @@ func APIMarkerNameToMarkerName(m apimodel.MarkerName) gtsmodel.MarkerName {
 	}
 	return ""
 }
+
+func APIPolicyValuesToPolicyValues(m []apimodel.PolicyValue) gtsmodel.PolicyValues {
+	if len(m) == 0 {
+		return nil
+	}
+
+	values := make(gtsmodel.PolicyValues, 0, len(m))
+	for _, value := range m {
+		values = append(values, gtsmodel.PolicyValue(value))
+	}
+	return values
+}
+
+func APIInteractionPolicyToInteractionPolicy(m *apimodel.InteractionPolicyRequest) *gtsmodel.InteractionPolicy {
+	if m == nil {
+		return nil
+	}
+
+	return &gtsmodel.InteractionPolicy{
+		CanReply:    APIPolicyValuesToPolicyValues(m.CanReply),
+		CanAnnounce: APIPolicyValuesToPolicyValues(m.CanAnnounce),
+		CanLike:     APIPolicyValuesToPolicyValues(m.CanLike),
+	}
+}
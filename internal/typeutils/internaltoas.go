@@ -23,7 +23,9 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"html"
 	"net/url"
+	"slices"
 	"strings"
 
 	"github.com/superseriousbusiness/activity/pub"
@@ -36,6 +38,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // AccountToAS converts a gts model account into an activity streams person, suitable for federation
@@ -171,9 +174,20 @@ func (c *Converter) AccountToAS(ctx context.Context, a *gtsmodel.Account) (vocab
 
 	// alsoKnownAs
 	// Required for Move activity.
-	if l := len(a.AlsoKnownAsURIs); l != 0 {
+	alsoKnownAs := a.AlsoKnownAsURIs
+	if a.IsLocal() && a.MovedToURI == "" {
+		// Local accounts that changed username with
+		// a Move are also known as their old actors.
+		oldURIs, err := c.movedUsernameURIs(ctx, a)
+		if err != nil {
+			return nil, err
+		}
+		alsoKnownAs = append(slices.Clip(alsoKnownAs), oldURIs...)
+	}
+
+	if l := len(alsoKnownAs); l != 0 {
 		alsoKnownAsURIs := make([]*url.URL, l)
-		for i, rawURL := range a.AlsoKnownAsURIs {
+		for i, rawURL := range alsoKnownAs {
 			uri, err := url.Parse(rawURL)
 			if err != nil {
 				return nil, err
@@ -185,6 +199,13 @@ func (c *Converter) AccountToAS(ctx context.Context, a *gtsmodel.Account) (vocab
 		ap.SetAlsoKnownAs(person, alsoKnownAsURIs)
 	}
 
+	// attributionDomains
+	// Used to verify authorship of content
+	// credited to this account on other sites.
+	if len(a.AttributionDomains) != 0 {
+		ap.SetAttributionDomains(person, a.AttributionDomains)
+	}
+
 	// movedTo
 	// Required for Move activity.
 	if a.MovedToURI != "" {
@@ -488,6 +509,11 @@ func (c *Converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Stat
 	publishedProp.Set(s.CreatedAt)
 	status.SetActivityStreamsPublished(publishedProp)
 
+	// updated
+	if !s.EditedAt.IsZero() {
+		ap.SetUpdated(status, s.EditedAt)
+	}
+
 	// url
 	if s.URL != "" {
 		sURL, err := url.Parse(s.URL)
@@ -559,6 +585,15 @@ func (c *Converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Stat
 		}
 		tagProp.AppendTootHashtag(asHashtag)
 	}
+
+	// tag -- quoted status
+	if s.QuoteOfURI != "" {
+		asQuote, err := c.QuoteToASLink(s.QuoteOfURI)
+		if err != nil {
+			return nil, gtserror.Newf("error converting quote to AS link: %w", err)
+		}
+		tagProp.AppendActivityStreamsLink(asQuote)
+	}
 	status.SetActivityStreamsTag(tagProp)
 
 	// parse out some URIs we need here
@@ -649,6 +684,12 @@ func (c *Converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Stat
 		}
 	}
 	for _, a := range attachments {
+		if a.IsExpired() {
+			// Don't federate
+			// expired media.
+			continue
+		}
+
 		doc, err := c.AttachmentToAS(ctx, a)
 		if err != nil {
 			return nil, gtserror.Newf("error converting attachment: %w", err)
@@ -667,6 +708,26 @@ func (c *Converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Stat
 	repliesProp.SetActivityStreamsCollection(repliesCollection)
 	status.SetActivityStreamsReplies(repliesProp)
 
+	// likes
+	likesCollection, err := c.StatusToASLikesCollection(ctx, s)
+	if err != nil {
+		return nil, gtserror.Newf("error creating likesCollection: %w", err)
+	}
+
+	likesProp := streams.NewActivityStreamsLikesProperty()
+	likesProp.SetActivityStreamsCollection(likesCollection)
+	status.SetActivityStreamsLikes(likesProp)
+
+	// shares
+	sharesCollection, err := c.StatusToASSharesCollection(ctx, s)
+	if err != nil {
+		return nil, gtserror.Newf("error creating sharesCollection: %w", err)
+	}
+
+	sharesProp := streams.NewActivityStreamsSharesProperty()
+	sharesProp.SetActivityStreamsCollection(sharesCollection)
+	status.SetActivityStreamsShares(sharesProp)
+
 	// sensitive
 	sensitiveProp := streams.NewActivityStreamsSensitiveProperty()
 	sensitiveProp.AppendXMLSchemaBoolean(*s.Sensitive)
@@ -932,7 +993,61 @@ func (c *Converter) FollowToAS(ctx context.Context, f *gtsmodel.Follow) (vocab.A
 	return follow, nil
 }
 
+// FollowRequestToAS converts a gts model follow request into an activity streams
+// Follow, suitable for federation. Any note attached to the follow request is set
+// as the content of the Follow, for software that displays it to the target.
+func (c *Converter) FollowRequestToAS(ctx context.Context, fr *gtsmodel.FollowRequest) (vocab.ActivityStreamsFollow, error) {
+	follow, err := c.FollowToAS(ctx, c.FollowRequestToFollow(ctx, fr))
+	if err != nil {
+		return nil, err
+	}
+
+	if fr.Note != "" {
+		// Notes are stored as plaintext, so
+		// escape + wrap in a paragraph for html.
+		content := "<p>" + strings.ReplaceAll(html.EscapeString(fr.Note), "\n", "<br>") + "</p>"
+		contentProp := streams.NewActivityStreamsContentProperty()
+		contentProp.AppendXMLSchemaString(content)
+		follow.SetActivityStreamsContent(contentProp)
+	}
+
+	return follow, nil
+}
+
 // MentionToAS converts a gts model mention into an activity streams Mention, suitable for federation
+// QuoteToASLink converts the URI of a quoted status into an
+// FEP-e232 object link, for inclusion in the quoting status' tags.
+func (c *Converter) QuoteToASLink(quoteOfURI string) (vocab.ActivityStreamsLink, error) {
+	hrefURI, err := url.Parse(quoteOfURI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing uri %s: %w", quoteOfURI, err)
+	}
+
+	link := streams.NewActivityStreamsLink()
+
+	// href -- the AP URI of the quoted status
+	hrefProp := streams.NewActivityStreamsHrefProperty()
+	hrefProp.SetIRI(hrefURI)
+	link.SetActivityStreamsHref(hrefProp)
+
+	// mediaType -- marks this as a link to an AS object
+	mediaTypeProp := streams.NewActivityStreamsMediaTypeProperty()
+	mediaTypeProp.Set(ap.ObjectLinkMediaType)
+	link.SetActivityStreamsMediaType(mediaTypeProp)
+
+	// rel -- marks the linked object as quoted
+	relProp := streams.NewActivityStreamsRelProperty()
+	relProp.AppendRFCRfc5988(ap.QuoteLinkRel)
+	link.SetActivityStreamsRel(relProp)
+
+	// name -- inline fallback text, as per FEP-e232
+	nameProp := streams.NewActivityStreamsNameProperty()
+	nameProp.AppendXMLSchemaString("RE: " + quoteOfURI)
+	link.SetActivityStreamsName(nameProp)
+
+	return link, nil
+}
+
 func (c *Converter) MentionToAS(ctx context.Context, m *gtsmodel.Mention) (vocab.ActivityStreamsMention, error) {
 	if m.TargetAccount == nil {
 		a, err := c.state.DB.GetAccountByID(ctx, m.TargetAccountID)
@@ -1086,6 +1201,14 @@ func (c *Converter) AttachmentToAS(ctx context.Context, a *gtsmodel.MediaAttachm
 	blurProp.Set(a.Blurhash)
 	doc.SetTootBlurhash(blurProp)
 
+	// sensitive -- only set on the attachment if
+	// true, else it's up to the status to decide
+	if util.PtrValueOr(a.Sensitive, false) {
+		sensitiveProp := streams.NewActivityStreamsSensitiveProperty()
+		sensitiveProp.AppendXMLSchemaBoolean(true)
+		doc.SetActivityStreamsSensitive(sensitiveProp)
+	}
+
 	// focalpoint
 	// TODO
 
@@ -1172,6 +1295,69 @@ func (c *Converter) FaveToAS(ctx context.Context, f *gtsmodel.StatusFave) (vocab
 	return like, nil
 }
 
+// ReactionToAS converts a gts model emoji reaction into an activityStreams
+// LIKE with the reaction emoji set as content, ie., the way Misskey federates
+// reactions. Custom emoji reactions will also have the emoji set as a tag.
+func (c *Converter) ReactionToAS(ctx context.Context, r *gtsmodel.StatusReaction) (vocab.ActivityStreamsLike, error) {
+	// Ensure reaction fully populated.
+	if err := c.state.DB.PopulateStatusReaction(ctx, r); err != nil {
+		return nil, gtserror.Newf("error populating reaction: %w", err)
+	}
+
+	like := streams.NewActivityStreamsLike()
+
+	// set the actor property to the reacting account's URI
+	actorIRI, err := url.Parse(r.Account.URI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing uri %s: %w", r.Account.URI, err)
+	}
+	ap.AppendActorIRIs(like, actorIRI)
+
+	// set the ID property to the reaction's URI
+	idIRI, err := url.Parse(r.URI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing uri %s: %w", r.URI, err)
+	}
+	ap.SetJSONLDId(like, idIRI)
+
+	// set the object property to the target status's URI
+	statusIRI, err := url.Parse(r.Status.URI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing uri %s: %w", r.Status.URI, err)
+	}
+	ap.AppendObjectIRIs(like, statusIRI)
+
+	// set the TO property to the target account's IRI
+	toIRI, err := url.Parse(r.TargetAccount.URI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing uri %s: %w", r.TargetAccount.URI, err)
+	}
+	ap.AppendTo(like, toIRI)
+
+	// set the content property to the reaction emoji
+	content := r.Name
+	if r.IsCustom() {
+		content = ":" + r.Name + ":"
+
+		// Custom emoji must be
+		// included as a tag too.
+		asEmoji, err := c.EmojiToAS(ctx, r.Emoji)
+		if err != nil {
+			return nil, gtserror.Newf("error converting emoji to AS: %w", err)
+		}
+
+		tagProp := streams.NewActivityStreamsTagProperty()
+		tagProp.AppendTootEmoji(asEmoji)
+		like.SetActivityStreamsTag(tagProp)
+	}
+
+	contentProp := streams.NewActivityStreamsContentProperty()
+	contentProp.AppendXMLSchemaString(content)
+	like.SetActivityStreamsContent(contentProp)
+
+	return like, nil
+}
+
 // BoostToAS converts a gts model boost into an activityStreams ANNOUNCE, suitable for federation
 func (c *Converter) BoostToAS(ctx context.Context, boostWrapperStatus *gtsmodel.Status, boostingAccount *gtsmodel.Account, boostedAccount *gtsmodel.Account) (vocab.ActivityStreamsAnnounce, error) {
 	// the boosted status is probably pinned to the boostWrapperStatus but double check to make sure
@@ -1383,6 +1569,65 @@ func (c *Converter) StatusToASRepliesCollection(ctx context.Context, status *gts
 	return collection, nil
 }
 
+// StatusToASLikesCollection converts a gts model status into an activityStreams LIKES
+// collection. Only the number of likes is given, not the likes themselves, eg:
+//
+//	{
+//		"@context": "https://www.w3.org/ns/activitystreams",
+//		"id": "https://example.org/users/whatever/statuses/01FCNEXAGAKPEX1J7VJRPJP490/likes",
+//		"type": "Collection",
+//		"totalItems": 3
+//	}
+func (c *Converter) StatusToASLikesCollection(ctx context.Context, status *gtsmodel.Status) (vocab.ActivityStreamsCollection, error) {
+	count, err := c.state.DB.CountStatusFaves(ctx, status.ID)
+	if err != nil {
+		return nil, gtserror.Newf("error counting faves: %w", err)
+	}
+
+	return countCollection(status.URI+"/likes", count)
+}
+
+// StatusToASSharesCollection converts a gts model status into an activityStreams SHARES
+// collection. Only the number of shares is given, not the shares themselves, eg:
+//
+//	{
+//		"@context": "https://www.w3.org/ns/activitystreams",
+//		"id": "https://example.org/users/whatever/statuses/01FCNEXAGAKPEX1J7VJRPJP490/shares",
+//		"type": "Collection",
+//		"totalItems": 1
+//	}
+func (c *Converter) StatusToASSharesCollection(ctx context.Context, status *gtsmodel.Status) (vocab.ActivityStreamsCollection, error) {
+	count, err := c.state.DB.CountStatusBoosts(ctx, status.ID)
+	if err != nil {
+		return nil, gtserror.Newf("error counting boosts: %w", err)
+	}
+
+	return countCollection(status.URI+"/shares", count)
+}
+
+// countCollection returns an items-less activityStreams
+// collection with the given id and totalItems count.
+func countCollection(id string, count int) (vocab.ActivityStreamsCollection, error) {
+	idURI, err := url.Parse(id)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing collection id: %w", err)
+	}
+
+	collection := streams.NewActivityStreamsCollection()
+
+	// collection.id
+	idProp := streams.NewJSONLDIdProperty()
+	idProp.SetIRI(idURI)
+	collection.SetJSONLDId(idProp)
+
+	// collection.totalItems
+	totalItemsProp := streams.NewActivityStreamsTotalItemsProperty()
+	totalItemsProp.Set(count)
+	collection.SetActivityStreamsTotalItems(totalItemsProp)
+
+	return collection, nil
+}
+
 // StatusURIsToASRepliesPage returns a collection page with appropriate next/part of pagination.
 // the goal is to end up with something like this:
 //
@@ -1593,9 +1838,9 @@ func (c *Converter) OutboxToASCollection(ctx context.Context, outboxID string) (
 	return collection, nil
 }
 
-// StatusesToASFeaturedCollection converts a slice of statuses into an ordered collection
-// of URIs, suitable for serializing and serving via the activitypub API.
-func (c *Converter) StatusesToASFeaturedCollection(ctx context.Context, featuredCollectionID string, statuses []*gtsmodel.Status) (vocab.ActivityStreamsOrderedCollection, error) {
+// StatusesToASFeaturedCollection converts a slice of statuses and featured tags into an ordered collection
+// of status URIs followed by Hashtag objects, suitable for serializing and serving via the activitypub API.
+func (c *Converter) StatusesToASFeaturedCollection(ctx context.Context, featuredCollectionID string, statuses []*gtsmodel.Status, featuredTags []*gtsmodel.FeaturedTag) (vocab.ActivityStreamsOrderedCollection, error) {
 	collection := streams.NewActivityStreamsOrderedCollection()
 
 	collectionIDProp := streams.NewJSONLDIdProperty()
@@ -1614,10 +1859,24 @@ func (c *Converter) StatusesToASFeaturedCollection(ctx context.Context, featured
 		}
 		itemsProp.AppendIRI(uri)
 	}
+	for _, f := range featuredTags {
+		if f.Tag == nil {
+			f.Tag, err = c.state.DB.GetTag(ctx, f.TagID)
+			if err != nil {
+				return nil, gtserror.Newf("error getting tag %s: %w", f.TagID, err)
+			}
+		}
+
+		tag, err := c.TagToAS(ctx, f.Tag)
+		if err != nil {
+			return nil, gtserror.Newf("error converting tag %s: %w", f.TagID, err)
+		}
+		itemsProp.AppendTootHashtag(tag)
+	}
 	collection.SetActivityStreamsOrderedItems(itemsProp)
 
 	totalItemsProp := streams.NewActivityStreamsTotalItemsProperty()
-	totalItemsProp.Set(len(statuses))
+	totalItemsProp.Set(len(statuses) + len(featuredTags))
 	collection.SetActivityStreamsTotalItems(totalItemsProp)
 
 	return collection, nil
@@ -29,6 +29,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -410,6 +411,11 @@ func (suite *InternalToASTestSuite) TestStatusToAS() {
     "en": "hello everyone!"
   },
   "id": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY",
+  "likes": {
+    "id": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY/likes",
+    "totalItems": 1,
+    "type": "Collection"
+  },
   "published": "2021-10-20T12:40:37+02:00",
   "replies": {
     "first": {
@@ -422,6 +428,11 @@ func (suite *InternalToASTestSuite) TestStatusToAS() {
     "type": "Collection"
   },
   "sensitive": true,
+  "shares": {
+    "id": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY/shares",
+    "totalItems": 1,
+    "type": "Collection"
+  },
   "summary": "introduction post",
   "tag": [],
   "to": "https://www.w3.org/ns/activitystreams#Public",
@@ -464,6 +475,11 @@ func (suite *InternalToASTestSuite) TestStatusWithTagsToASWithIDs() {
     "en": "hello world! #welcome ! first post on the instance :rainbow: !"
   },
   "id": "http://localhost:8080/users/admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R",
+  "likes": {
+    "id": "http://localhost:8080/users/admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R/likes",
+    "totalItems": 1,
+    "type": "Collection"
+  },
   "published": "2021-10-20T11:36:45Z",
   "replies": {
     "first": {
@@ -476,6 +492,11 @@ func (suite *InternalToASTestSuite) TestStatusWithTagsToASWithIDs() {
     "type": "Collection"
   },
   "sensitive": false,
+  "shares": {
+    "id": "http://localhost:8080/users/admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R/shares",
+    "totalItems": 0,
+    "type": "Collection"
+  },
   "summary": "",
   "tag": [
     {
@@ -536,6 +557,11 @@ func (suite *InternalToASTestSuite) TestStatusWithTagsToASFromDB() {
     "en": "hello world! #welcome ! first post on the instance :rainbow: !"
   },
   "id": "http://localhost:8080/users/admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R",
+  "likes": {
+    "id": "http://localhost:8080/users/admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R/likes",
+    "totalItems": 1,
+    "type": "Collection"
+  },
   "published": "2021-10-20T11:36:45Z",
   "replies": {
     "first": {
@@ -548,6 +574,11 @@ func (suite *InternalToASTestSuite) TestStatusWithTagsToASFromDB() {
     "type": "Collection"
   },
   "sensitive": false,
+  "shares": {
+    "id": "http://localhost:8080/users/admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R/shares",
+    "totalItems": 0,
+    "type": "Collection"
+  },
   "summary": "",
   "tag": [
     {
@@ -603,6 +634,11 @@ func (suite *InternalToASTestSuite) TestStatusToASWithMentions() {
   },
   "id": "http://localhost:8080/users/admin/statuses/01FF25D5Q0DH7CHD57CTRS6WK0",
   "inReplyTo": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY",
+  "likes": {
+    "id": "http://localhost:8080/users/admin/statuses/01FF25D5Q0DH7CHD57CTRS6WK0/likes",
+    "totalItems": 0,
+    "type": "Collection"
+  },
   "published": "2021-11-20T13:32:16Z",
   "replies": {
     "first": {
@@ -615,6 +651,11 @@ func (suite *InternalToASTestSuite) TestStatusToASWithMentions() {
     "type": "Collection"
   },
   "sensitive": false,
+  "shares": {
+    "id": "http://localhost:8080/users/admin/statuses/01FF25D5Q0DH7CHD57CTRS6WK0/shares",
+    "totalItems": 0,
+    "type": "Collection"
+  },
   "summary": "",
   "tag": {
     "href": "http://localhost:8080/users/the_mighty_zork",
@@ -627,6 +668,92 @@ func (suite *InternalToASTestSuite) TestStatusToASWithMentions() {
 }`, string(bytes))
 }
 
+func (suite *InternalToASTestSuite) TestStatusToASWithQuote() {
+	var (
+		ctx        = context.Background()
+		testStatus = new(gtsmodel.Status)
+		quoteOf    = suite.testStatuses["local_account_2_status_1"]
+	)
+	*testStatus = *suite.testStatuses["admin_account_status_1"]
+	testStatus.QuoteOfID = quoteOf.ID
+	testStatus.QuoteOfURI = quoteOf.URI
+
+	asStatus, err := suite.typeconverter.StatusToAS(ctx, testStatus)
+	suite.NoError(err)
+
+	ser, err := ap.Serialize(asStatus)
+	suite.NoError(err)
+
+	// Quote link should be
+	// appended to the tags.
+	tags, ok := ser["tag"].([]any)
+	if !ok || len(tags) == 0 {
+		suite.FailNow("expected tags")
+	}
+
+	bytes, err := json.MarshalIndent(tags[len(tags)-1], "", "  ")
+	suite.NoError(err)
+
+	suite.Equal(`{
+  "href": "http://localhost:8080/users/1happyturtle/statuses/01F8MHBQCBTDKN6X5VHGMMN4MA",
+  "mediaType": "application/ld+json; profile=\"https://www.w3.org/ns/activitystreams\"",
+  "name": "RE: http://localhost:8080/users/1happyturtle/statuses/01F8MHBQCBTDKN6X5VHGMMN4MA",
+  "rel": "https://misskey-hub.net/ns#_misskey_quote",
+  "type": "Link"
+}`, string(bytes))
+}
+
+func (suite *InternalToASTestSuite) TestReactionToAS() {
+	var (
+		ctx      = context.Background()
+		account  = suite.testAccounts["local_account_1"]
+		status   = suite.testStatuses["admin_account_status_1"]
+		reaction = &gtsmodel.StatusReaction{
+			ID:              "01HZ8QB0K3DZ6R9RS0T0D7W1YH",
+			AccountID:       account.ID,
+			TargetAccountID: status.AccountID,
+			StatusID:        status.ID,
+			Name:            "rainbow",
+			EmojiID:         suite.testEmojis["rainbow"].ID,
+			URI:             "http://localhost:8080/users/the_mighty_zork/liked/01HZ8QB0K3DZ6R9RS0T0D7W1YH",
+		}
+	)
+
+	asLike, err := suite.typeconverter.ReactionToAS(ctx, reaction)
+	suite.NoError(err)
+
+	ser, err := ap.Serialize(asLike)
+	suite.NoError(err)
+
+	bytes, err := json.MarshalIndent(ser, "", "  ")
+	suite.NoError(err)
+
+	suite.Equal(`{
+  "@context": [
+    "https://www.w3.org/ns/activitystreams",
+    "http://joinmastodon.org/ns"
+  ],
+  "_misskey_reaction": ":rainbow:",
+  "actor": "http://localhost:8080/users/the_mighty_zork",
+  "content": ":rainbow:",
+  "id": "http://localhost:8080/users/the_mighty_zork/liked/01HZ8QB0K3DZ6R9RS0T0D7W1YH",
+  "object": "http://localhost:8080/users/admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R",
+  "tag": {
+    "icon": {
+      "mediaType": "image/png",
+      "type": "Image",
+      "url": "http://localhost:8080/fileserver/01AY6P665V14JJR0AFVRT7311Y/emoji/original/01F8MH9H8E4VG3KDYJR9EGPXCQ.png"
+    },
+    "id": "http://localhost:8080/emoji/01F8MH9H8E4VG3KDYJR9EGPXCQ",
+    "name": ":rainbow:",
+    "type": "Emoji",
+    "updated": "2021-09-20T10:40:37Z"
+  },
+  "to": "http://localhost:8080/users/admin",
+  "type": "Like"
+}`, string(bytes))
+}
+
 func (suite *InternalToASTestSuite) TestStatusToASDeletePublicReply() {
 	testStatus := suite.testStatuses["admin_account_status_3"]
 	ctx := context.Background()
@@ -854,6 +981,39 @@ func (suite *InternalToASTestSuite) TestReportToAS() {
 }`, string(bytes))
 }
 
+func (suite *InternalToASTestSuite) TestFollowRequestToASWithNote() {
+	ctx := context.Background()
+
+	fr := &gtsmodel.FollowRequest{
+		ID:              "01HY2N4ZD2Q8F0MPW8Y7RCN5TT",
+		URI:             "http://localhost:8080/users/admin/follow/01HY2N4ZD2Q8F0MPW8Y7RCN5TT",
+		AccountID:       suite.testAccounts["admin_account"].ID,
+		TargetAccountID: suite.testAccounts["remote_account_1"].ID,
+		ShowReblogs:     util.Ptr(true),
+		Notify:          util.Ptr(false),
+		Note:            "hey it's me <from the forum>\nplease accept!",
+	}
+
+	follow, err := suite.typeconverter.FollowRequestToAS(ctx, fr)
+	suite.NoError(err)
+
+	ser, err := ap.Serialize(follow)
+	suite.NoError(err)
+
+	bytes, err := json.MarshalIndent(ser, "", "  ")
+	suite.NoError(err)
+
+	suite.Equal(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "actor": "http://localhost:8080/users/admin",
+  "content": "\u003cp\u003ehey it\u0026#39;s me \u0026lt;from the forum\u0026gt;\u003cbr\u003eplease accept!\u003c/p\u003e",
+  "id": "http://localhost:8080/users/admin/follow/01HY2N4ZD2Q8F0MPW8Y7RCN5TT",
+  "object": "http://fossbros-anonymous.io/users/foss_satan",
+  "to": "http://fossbros-anonymous.io/users/foss_satan",
+  "type": "Follow"
+}`, string(bytes))
+}
+
 func (suite *InternalToASTestSuite) TestPinnedStatusesToASSomeItems() {
 	ctx := context.Background()
 
@@ -863,7 +1023,7 @@ func (suite *InternalToASTestSuite) TestPinnedStatusesToASSomeItems() {
 		suite.FailNow(err.Error())
 	}
 
-	collection, err := suite.typeconverter.StatusesToASFeaturedCollection(ctx, testAccount.FeaturedCollectionURI, statuses)
+	collection, err := suite.typeconverter.StatusesToASFeaturedCollection(ctx, testAccount.FeaturedCollectionURI, statuses, nil)
 	if err != nil {
 		suite.FailNow(err.Error())
 	}
@@ -895,7 +1055,7 @@ func (suite *InternalToASTestSuite) TestPinnedStatusesToASNoItems() {
 		suite.FailNow(err.Error())
 	}
 
-	collection, err := suite.typeconverter.StatusesToASFeaturedCollection(ctx, testAccount.FeaturedCollectionURI, statuses)
+	collection, err := suite.typeconverter.StatusesToASFeaturedCollection(ctx, testAccount.FeaturedCollectionURI, statuses, nil)
 	if err != nil {
 		suite.FailNow(err.Error())
 	}
@@ -924,7 +1084,7 @@ func (suite *InternalToASTestSuite) TestPinnedStatusesToASOneItem() {
 		suite.FailNow(err.Error())
 	}
 
-	collection, err := suite.typeconverter.StatusesToASFeaturedCollection(ctx, testAccount.FeaturedCollectionURI, statuses)
+	collection, err := suite.typeconverter.StatusesToASFeaturedCollection(ctx, testAccount.FeaturedCollectionURI, statuses, nil)
 	if err != nil {
 		suite.FailNow(err.Error())
 	}
@@ -946,6 +1106,58 @@ func (suite *InternalToASTestSuite) TestPinnedStatusesToASOneItem() {
 }`, string(bytes))
 }
 
+func (suite *InternalToASTestSuite) TestPinnedStatusesToASWithFeaturedTags() {
+	ctx := context.Background()
+
+	testAccount := suite.testAccounts["local_account_2"]
+	statuses, err := suite.db.GetAccountPinnedStatuses(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	tag, err := suite.db.GetTagByName(ctx, "welcome")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	featuredTags := []*gtsmodel.FeaturedTag{
+		{
+			ID:        "01J1BX7DWVDXTQ2NN4P1WD4ZGF",
+			AccountID: testAccount.ID,
+			TagID:     tag.ID,
+		},
+	}
+
+	collection, err := suite.typeconverter.StatusesToASFeaturedCollection(ctx, testAccount.FeaturedCollectionURI, statuses, featuredTags)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ser, err := ap.Serialize(collection)
+	suite.NoError(err)
+
+	bytes, err := json.MarshalIndent(ser, "", "  ")
+	suite.NoError(err)
+
+	suite.Equal(`{
+  "@context": [
+    "https://www.w3.org/ns/activitystreams",
+    "http://joinmastodon.org/ns"
+  ],
+  "id": "http://localhost:8080/users/1happyturtle/collections/featured",
+  "orderedItems": [
+    "http://localhost:8080/users/1happyturtle/statuses/01G20ZM733MGN8J344T4ZDDFY1",
+    {
+      "href": "http://localhost:8080/tags/welcome",
+      "name": "#welcome",
+      "type": "Hashtag"
+    }
+  ],
+  "totalItems": 2,
+  "type": "OrderedCollection"
+}`, string(bytes))
+}
+
 func (suite *InternalToASTestSuite) TestPollVoteToASCreate() {
 	vote := suite.testPollVotes["remote_account_1_status_2_poll_vote_local_account_1"]
 
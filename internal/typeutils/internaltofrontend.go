@@ -23,14 +23,17 @@ import (
 	"fmt"
 	"math"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/language"
@@ -48,7 +51,7 @@ const (
 	instanceMediaAttachmentsVideoFrameRateLimit = 60
 	instancePollsMinExpiration                  = 300     // seconds
 	instancePollsMaxExpiration                  = 2629746 // seconds
-	instanceAccountsMaxFeaturedTags             = 10
+	instanceAccountsMaxFeaturedTags             = gtsmodel.MaxFeaturedTags
 	instanceAccountsMaxProfileFields            = 6 // FIXME: https://github.com/superseriousbusiness/gotosocial/issues/1876
 	instanceSourceURL                           = "https://github.com/superseriousbusiness/gotosocial"
 	instanceMastodonVersion                     = "3.5.3"
@@ -90,20 +93,74 @@ func (c *Converter) AccountToAPIAccountSensitive(ctx context.Context, a *gtsmode
 		statusContentType = a.Settings.StatusContentType
 	}
 
+	// Always serialize as
+	// array, never as null.
+	attributionDomains := a.AttributionDomains
+	if attributionDomains == nil {
+		attributionDomains = []string{}
+	}
+
 	apiAccount.Source = &apimodel.Source{
-		Privacy:             c.VisToAPIVis(ctx, a.Settings.Privacy),
-		Sensitive:           *a.Settings.Sensitive,
-		Language:            a.Settings.Language,
-		StatusContentType:   statusContentType,
-		Note:                a.NoteRaw,
-		Fields:              c.fieldsToAPIFields(a.FieldsRaw),
-		FollowRequestsCount: *a.Stats.FollowRequestsCount,
-		AlsoKnownAsURIs:     a.AlsoKnownAsURIs,
+		Privacy:               c.VisToAPIVis(ctx, a.Settings.Privacy),
+		Sensitive:             *a.Settings.Sensitive,
+		Language:              a.Settings.Language,
+		StatusContentType:     statusContentType,
+		NotifyNewFromDays:     a.Settings.NotifyNewFromDays,
+		EmailWhenAwayHours:    a.Settings.EmailWhenAwayHours,
+		AutoApproveFollowed:   util.PtrValueOr(a.Settings.AutoApproveFollowed, false),
+		AutoApproveLocal:      util.PtrValueOr(a.Settings.AutoApproveLocal, false),
+		AutoApproveMinAgeDays: a.Settings.AutoApproveMinAgeDays,
+		RecordFollowerEvents:  util.PtrValueOr(a.Settings.RecordFollowerEvents, false),
+		Note:                  a.NoteRaw,
+		Fields:                c.fieldsToAPIFields(a.FieldsRaw),
+		FollowRequestsCount:   *a.Stats.FollowRequestsCount,
+		AlsoKnownAsURIs:       a.AlsoKnownAsURIs,
+		Aliases:               c.AccountToAPIAliases(a),
+		AttributionDomains:    attributionDomains,
 	}
 
 	return apiAccount, nil
 }
 
+// AccountToAPIAliases converts the alsoKnownAs aliases of the given
+// account to api aliases, noting which ones have been verified, ie.,
+// which aliased accounts list the given account as an alias in return.
+// Aliased accounts that aren't (yet) stored are included, unverified.
+func (c *Converter) AccountToAPIAliases(a *gtsmodel.Account) []apimodel.AccountAlias {
+	if len(a.AlsoKnownAsURIs) == 0 {
+		return nil
+	}
+
+	aliases := make([]apimodel.AccountAlias, 0, len(a.AlsoKnownAsURIs))
+	for _, uri := range a.AlsoKnownAsURIs {
+		alias := apimodel.AccountAlias{URI: uri}
+
+		for _, aka := range a.AlsoKnownAs {
+			if aka.URI != uri {
+				continue
+			}
+
+			alias.URL = aka.URL
+			alias.Acct = aka.Username
+			if aka.IsRemote() {
+				// De-punify domain if we can,
+				// else just use it as it is.
+				d, err := util.DePunify(aka.Domain)
+				if err != nil {
+					d = aka.Domain
+				}
+				alias.Acct += "@" + d
+			}
+			alias.Verified = aka.IsAliasedTo(a.URI)
+			break
+		}
+
+		aliases = append(aliases, alias)
+	}
+
+	return aliases
+}
+
 // AccountToAPIAccountPublic takes a db model account as a param, and returns a populated apitype account, or an error
 // if something goes wrong. The returned account should be ready to serialize on an API level, and may NOT have sensitive fields.
 // In other words, this is the public record that the server has of an account.
@@ -179,7 +236,7 @@ func (c *Converter) AccountToAPIAccountPublic(ctx context.Context, a *gtsmodel.A
 	// Bits that vary between remote + local accounts:
 	//   - Account (acct) string.
 	//   - Role.
-	//   - Settings things (enableRSS, theme, customCSS, hideCollections).
+	//   - Settings things (enableRSS, theme, customCSS, hideCollections, hideApplication, enableEmbeds).
 
 	var (
 		acct            string
@@ -188,6 +245,8 @@ func (c *Converter) AccountToAPIAccountPublic(ctx context.Context, a *gtsmodel.A
 		theme           string
 		customCSS       string
 		hideCollections bool
+		hideApplication bool
+		enableEmbeds    bool
 	)
 
 	if a.IsRemote() {
@@ -222,6 +281,8 @@ func (c *Converter) AccountToAPIAccountPublic(ctx context.Context, a *gtsmodel.A
 			theme = a.Settings.Theme
 			customCSS = a.Settings.CustomCSS
 			hideCollections = *a.Settings.HideCollections
+			hideApplication = *a.Settings.HideApplication
+			enableEmbeds = *a.Settings.EnableEmbeds
 		}
 
 		acct = a.Username // omit domain
@@ -236,6 +297,31 @@ func (c *Converter) AccountToAPIAccountPublic(ctx context.Context, a *gtsmodel.A
 		}
 	}
 
+	// Populate alsoKnownAs with those aliases
+	// that alias back to the account, so that
+	// accounts can't claim others they don't own.
+	var alsoKnownAs []*apimodel.Account
+	for _, aka := range a.AlsoKnownAs {
+		if !aka.IsAliasedTo(a.URI) {
+			continue
+		}
+
+		// Convert without aliases of its own,
+		// since those lead back to this account.
+		akaCopy := new(gtsmodel.Account)
+		*akaCopy = *aka
+		akaCopy.AlsoKnownAsURIs = nil
+		akaCopy.AlsoKnownAs = nil
+
+		apiAKA, err := c.AccountToAPIAccountPublic(ctx, akaCopy)
+		if err != nil {
+			log.Errorf(ctx, "error converting account alsoKnownAs: %v", err)
+			continue
+		}
+
+		alsoKnownAs = append(alsoKnownAs, apiAKA)
+	}
+
 	// Bool ptrs should be set, but warn
 	// and use a default if they're not.
 	var boolPtrDef = func(
@@ -289,8 +375,11 @@ func (c *Converter) AccountToAPIAccountPublic(ctx context.Context, a *gtsmodel.A
 		CustomCSS:       customCSS,
 		EnableRSS:       enableRSS,
 		HideCollections: hideCollections,
+		HideApplication: hideApplication,
+		EnableEmbeds:    enableEmbeds,
 		Role:            role,
 		Moved:           moved,
+		AlsoKnownAs:     alsoKnownAs,
 	}
 
 	// Bodge default avatar + header in,
@@ -392,8 +481,10 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		inviteRequest          *string
 		approved               bool
 		disabled               bool
+		frozenUntil            string
 		role                   = apimodel.AccountRole{Name: apimodel.AccountRoleUser} // assume user by default
 		createdByApplicationID string
+		acknowledgedRules      []apimodel.AdminRuleAcknowledgement
 	)
 
 	if err := c.state.DB.PopulateAccount(ctx, a); err != nil {
@@ -441,7 +532,15 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		confirmed = !user.ConfirmedAt.IsZero()
 		approved = *user.Approved
 		disabled = *user.Disabled
+		if !user.FrozenUntil.IsZero() {
+			frozenUntil = util.FormatISO8601(user.FrozenUntil)
+		}
 		createdByApplicationID = user.CreatedByApplicationID
+
+		acknowledgedRules, err = c.ruleAcksToAdminAPIRuleAcks(ctx, user.ID)
+		if err != nil {
+			return nil, fmt.Errorf("AccountToAdminAPIAccount: error converting rule acknowledgements for account id %s: %w", a.ID, err)
+		}
 	}
 
 	apiAccount, err := c.AccountToAPIAccountPublic(ctx, a)
@@ -463,14 +562,51 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		Confirmed:              confirmed,
 		Approved:               approved,
 		Disabled:               disabled,
+		FrozenUntil:            frozenUntil,
 		Silenced:               !a.SilencedAt.IsZero(),
 		Suspended:              !a.SuspendedAt.IsZero(),
 		Account:                apiAccount,
 		CreatedByApplicationID: createdByApplicationID,
 		InvitedByAccountID:     "", // not implemented (yet)
+		AcknowledgedRules:      acknowledgedRules,
 	}, nil
 }
 
+// ruleAcksToAdminAPIRuleAcks converts the rule acknowledgements of the
+// given user to their admin api representation, noting for each whether
+// the acknowledged version of the rule is still the current one.
+func (c *Converter) ruleAcksToAdminAPIRuleAcks(ctx context.Context, userID string) ([]apimodel.AdminRuleAcknowledgement, error) {
+	acks, err := c.state.DB.GetRuleAcknowledgementsByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, err
+	}
+
+	if len(acks) == 0 {
+		return nil, nil
+	}
+
+	apiAcks := make([]apimodel.AdminRuleAcknowledgement, len(acks))
+	for i, ack := range acks {
+		apiAcks[i] = apimodel.AdminRuleAcknowledgement{
+			RuleID:         ack.RuleID,
+			Text:           ack.RuleText,
+			RuleUpdatedAt:  util.FormatISO8601(ack.RuleUpdatedAt),
+			AcknowledgedAt: util.FormatISO8601(ack.CreatedAt),
+		}
+
+		rule, err := c.state.DB.GetRuleByID(ctx, ack.RuleID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, err
+		}
+
+		apiAcks[i].Current = rule != nil &&
+			!*rule.Deleted &&
+			rule.UpdatedAt.Equal(ack.RuleUpdatedAt)
+	}
+
+	return apiAcks, nil
+}
+
 func (c *Converter) AppToAPIAppSensitive(ctx context.Context, a *gtsmodel.Application) (*apimodel.Application, error) {
 	return &apimodel.Application{
 		ID:           a.ID,
@@ -499,6 +635,23 @@ func (c *Converter) AttachmentToAPIAttachment(ctx context.Context, a *gtsmodel.M
 		Type: strings.ToLower(string(a.Type)),
 	}
 
+	if !a.ExpiresAt.IsZero() {
+		apiAttachment.ExpiresAt = util.Ptr(util.FormatISO8601(a.ExpiresAt))
+	}
+
+	if a.IsExpired() {
+		// Expired attachments are just a tombstone;
+		// don't serialize anything that could be used
+		// to retrieve the file, even if the cleaner
+		// hasn't gotten around to deleting it yet.
+		apiAttachment.Type = strings.ToLower(string(gtsmodel.FileTypeUnknown))
+		if i := a.Description; i != "" {
+			apiAttachment.Description = &i
+		}
+		apiAttachment.Sensitive = util.PtrValueOr(a.Sensitive, false)
+		return apiAttachment, nil
+	}
+
 	// Don't try to serialize meta for
 	// unknown attachments, there's no point.
 	if a.Type != gtsmodel.FileTypeUnknown {
@@ -541,6 +694,8 @@ func (c *Converter) AttachmentToAPIAttachment(ctx context.Context, a *gtsmodel.M
 		apiAttachment.Description = &i
 	}
 
+	apiAttachment.Sensitive = util.PtrValueOr(a.Sensitive, false)
+
 	// Type-specific fields.
 	switch a.Type {
 
@@ -668,20 +823,134 @@ func (c *Converter) EmojiCategoryToAPIEmojiCategory(ctx context.Context, categor
 }
 
 // TagToAPITag converts a gts model tag into its api (frontend) representation for serialization on the API.
-// If stubHistory is set to 'true', then the 'history' field of the tag will be populated with a pointer to an empty slice, for API compatibility reasons.
-func (c *Converter) TagToAPITag(ctx context.Context, t *gtsmodel.Tag, stubHistory bool) (apimodel.Tag, error) {
-	return apimodel.Tag{
+// If withHistory is set to 'true', then the 'history' field of the tag will be populated with its daily usage
+// over the last week, as provided in search results and trends; otherwise the field is omitted.
+func (c *Converter) TagToAPITag(ctx context.Context, t *gtsmodel.Tag, withHistory bool) (apimodel.Tag, error) {
+	apiTag := apimodel.Tag{
 		Name: strings.ToLower(t.Name),
 		URL:  uris.URIForTag(t.Name),
-		History: func() *[]any {
-			if !stubHistory {
-				return nil
+	}
+
+	if !withHistory {
+		return apiTag, nil
+	}
+
+	history, err := c.TagHistoryToAPITagHistory(ctx, t.ID)
+	if err != nil {
+		return apiTag, err
+	}
+
+	apiTag.History = &history
+	return apiTag, nil
+}
+
+// FeaturedTagToAPIFeaturedTag converts a gts model featured tag into its api (frontend) representation,
+// including stats on the public and unlisted statuses the featuring account made using the tag.
+func (c *Converter) FeaturedTagToAPIFeaturedTag(ctx context.Context, f *gtsmodel.FeaturedTag) (apimodel.FeaturedTag, error) {
+	if f.Tag == nil {
+		var err error
+		f.Tag, err = c.state.DB.GetTag(ctx, f.TagID)
+		if err != nil {
+			return apimodel.FeaturedTag{}, gtserror.Newf("error getting tag %s: %w", f.TagID, err)
+		}
+	}
+
+	count, lastStatusAt, err := c.state.DB.GetAccountTagStats(ctx, f.AccountID, f.TagID)
+	if err != nil {
+		return apimodel.FeaturedTag{}, gtserror.Newf("error getting tag stats: %w", err)
+	}
+
+	apiFeaturedTag := apimodel.FeaturedTag{
+		ID:            f.ID,
+		Name:          strings.ToLower(f.Tag.Name),
+		URL:           uris.URIForTag(f.Tag.Name),
+		StatusesCount: count,
+	}
+
+	if !lastStatusAt.IsZero() {
+		apiFeaturedTag.LastStatusAt = util.Ptr(util.FormatISO8601(lastStatusAt))
+	}
+
+	return apiFeaturedTag, nil
+}
+
+// TagHistoryToAPITagHistory returns the api (frontend) representation of the daily usage history
+// of the tag with the given ID, for the last week, newest first. Days without use are included as zero.
+func (c *Converter) TagHistoryToAPITagHistory(ctx context.Context, tagID string) ([]apimodel.TagHistory, error) {
+	const days = 7
+
+	// Start of the (UTC) day, today.
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	since := today.AddDate(0, 0, -(days - 1))
+
+	history, err := c.state.DB.GetTagHistory(ctx, tagID, since)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("error getting tag history: %w", err)
+	}
+
+	apiHistory := make([]apimodel.TagHistory, days)
+	for i := range apiHistory {
+		day := today.AddDate(0, 0, -i)
+
+		var uses, accounts int
+		for _, h := range history {
+			if h.Day.Equal(day) {
+				uses, accounts = h.Uses, h.Accounts
+				break
 			}
+		}
 
-			h := make([]any, 0)
-			return &h
-		}(),
-	}, nil
+		apiHistory[i] = apimodel.TagHistory{
+			Day:      strconv.FormatInt(day.Unix(), 10),
+			Uses:     strconv.Itoa(uses),
+			Accounts: strconv.Itoa(accounts),
+		}
+	}
+
+	return apiHistory, nil
+}
+
+// CardToAPICard converts a gts model preview card into its api (frontend) representation for serialization on the API.
+func (c *Converter) CardToAPICard(ctx context.Context, card *gtsmodel.Card) (*apimodel.Card, error) {
+	apiCard := &apimodel.Card{
+		URL:          card.URL,
+		Title:        card.Title,
+		Description:  card.Description,
+		Type:         string(card.Type),
+		AuthorName:   card.AuthorName,
+		AuthorURL:    card.AuthorURL,
+		Authors:      []apimodel.CardAuthor{},
+		ProviderName: card.ProviderName,
+		ProviderURL:  card.ProviderURL,
+		HTML:         card.HTML,
+		Width:        card.Width,
+		Height:       card.Height,
+		Image:        card.ImageURL,
+		EmbedURL:     card.EmbedURL,
+	}
+
+	if err := c.state.DB.PopulateCard(ctx, card); err != nil {
+		return nil, gtserror.Newf("error populating card: %w", err)
+	}
+
+	var account *apimodel.Account
+	if card.CreatorAccount != nil {
+		var err error
+		account, err = c.AccountToAPIAccountPublic(ctx, card.CreatorAccount)
+		if err != nil {
+			return nil, gtserror.Newf("error converting card creator account: %w", err)
+		}
+	}
+
+	if card.AuthorName != "" || card.AuthorURL != "" || account != nil {
+		apiCard.Authors = append(apiCard.Authors, apimodel.CardAuthor{
+			Name:    card.AuthorName,
+			URL:     card.AuthorURL,
+			Account: account,
+		})
+	}
+
+	return apiCard, nil
 }
 
 // StatusToAPIStatus converts a gts model status into its api
@@ -706,10 +975,11 @@ func (c *Converter) StatusToAPIStatus(
 	}
 
 	// Normalize status for the API by pruning
-	// out unknown attachment types and replacing
-	// them with a helpful message.
+	// out unknown attachment types into their
+	// own field, and placeholding them in the
+	// content for clients that don't know it.
 	var aside string
-	aside, apiStatus.MediaAttachments = placeholdUnknownAttachments(apiStatus.MediaAttachments)
+	aside, apiStatus.MediaAttachments, apiStatus.UnknownMediaAttachments = placeholdUnknownAttachments(apiStatus.MediaAttachments)
 	apiStatus.Content += aside
 
 	return apiStatus, nil
@@ -929,13 +1199,21 @@ func (c *Converter) StatusToWebStatus(
 	}
 
 	// Set additional templating
-	// variables on media attachments.
+	// variables on media attachments,
+	// hiding all of them if the status
+	// itself is sensitive.
 	for _, a := range webStatus.MediaAttachments {
-		a.Sensitive = webStatus.Sensitive
+		a.Sensitive = a.Sensitive || webStatus.Sensitive
 	}
 
 	webStatus.Local = *s.Local
 
+	// Count words and estimate reading time,
+	// so templates can show eg., "3 min read"
+	// on article-length statuses.
+	webStatus.WordCount = statusWordCount(webStatus.SpoilerText, webStatus.Content)
+	webStatus.ReadingTime = statusReadingTime(webStatus.WordCount)
+
 	return webStatus, nil
 }
 
@@ -943,17 +1221,209 @@ func (c *Converter) StatusToWebStatus(
 // Callers should check beforehand whether a requester has permission to view the
 // source of the status, and ensure they're passing only a local status into this function.
 func (c *Converter) StatusToAPIStatusSource(ctx context.Context, s *gtsmodel.Status) (*apimodel.StatusSource, error) {
-	// TODO: remove this when edit support is added.
-	text := "**STATUS EDITS ARE NOT CURRENTLY SUPPORTED IN GOTOSOCIAL (coming in 2024)**\n" +
-		"You can review the original text of your status below, but you will not be able to submit this edit.\n\n---\n\n" + s.Text
-
 	return &apimodel.StatusSource{
 		ID:          s.ID,
-		Text:        text,
-		SpoilerText: s.ContentWarning,
+		Text:        s.Text,
+		SpoilerText: text.SanitizeToPlaintext(s.ContentWarning),
 	}, nil
 }
 
+// StatusEditsToAPIEdits converts the edit history of a status into its api model
+// representation, ie., the given previous revisions of the status (oldest first)
+// followed by the latest revision, given as the api model apiStatus of the status.
+func (c *Converter) StatusEditsToAPIEdits(
+	ctx context.Context,
+	s *gtsmodel.Status,
+	edits []*gtsmodel.StatusEdit,
+	apiStatus *apimodel.Status,
+) ([]*apimodel.StatusEdit, error) {
+	apiEdits := make([]*apimodel.StatusEdit, 0, len(edits)+1)
+
+	// Each revision was made when the
+	// one before it was edited, starting
+	// from when the status was created.
+	createdAt := s.CreatedAt
+	for _, edit := range edits {
+		apiEdit, err := c.statusEditToAPIEdit(ctx, edit, createdAt, apiStatus)
+		if err != nil {
+			return nil, err
+		}
+
+		apiEdits = append(apiEdits, apiEdit)
+		createdAt = edit.CreatedAt
+	}
+
+	if !s.EditedAt.IsZero() {
+		createdAt = s.EditedAt
+	}
+
+	// Finally add the latest revision.
+	apiEdits = append(apiEdits, &apimodel.StatusEdit{
+		Content:          apiStatus.Content,
+		SpoilerText:      apiStatus.SpoilerText,
+		Sensitive:        apiStatus.Sensitive,
+		CreatedAt:        util.FormatISO8601(createdAt),
+		Account:          apiStatus.Account,
+		Poll:             apiStatus.Poll,
+		MediaAttachments: apiStatus.MediaAttachments,
+		Emojis:           apiStatus.Emojis,
+	})
+
+	return apiEdits, nil
+}
+
+// statusEditToAPIEdit converts a previous revision of a status,
+// made at createdAt, into its api model representation, taking
+// the author and poll details from the latest apiStatus.
+func (c *Converter) statusEditToAPIEdit(
+	ctx context.Context,
+	edit *gtsmodel.StatusEdit,
+	createdAt time.Time,
+	apiStatus *apimodel.Status,
+) (*apimodel.StatusEdit, error) {
+	apiAttachments, err := c.convertAttachmentsToAPIAttachments(ctx, nil, edit.AttachmentIDs)
+	if err != nil {
+		log.Errorf(ctx, "error converting status edit attachments: %v", err)
+	}
+
+	apiEmojis, err := c.convertEmojisToAPIEmojis(ctx, nil, edit.EmojiIDs)
+	if err != nil {
+		log.Errorf(ctx, "error converting status edit emojis: %v", err)
+	}
+
+	var apiPoll *apimodel.Poll
+	if len(edit.PollOptions) != 0 {
+		// Only the options of a previous
+		// revision of a poll are kept, so
+		// include those without any votes.
+		apiPoll = &apimodel.Poll{
+			Options: make([]apimodel.PollOption, len(edit.PollOptions)),
+			Emojis:  []apimodel.Emoji{},
+		}
+
+		if apiStatus.Poll != nil {
+			apiPoll.ID = apiStatus.Poll.ID
+			apiPoll.ExpiresAt = apiStatus.Poll.ExpiresAt
+			apiPoll.Expired = apiStatus.Poll.Expired
+			apiPoll.Multiple = apiStatus.Poll.Multiple
+		}
+
+		for i, option := range edit.PollOptions {
+			apiPoll.Options[i].Title = option
+		}
+	}
+
+	return &apimodel.StatusEdit{
+		Content:          edit.Content,
+		SpoilerText:      edit.ContentWarning,
+		Sensitive:        util.PtrValueOr(edit.Sensitive, false),
+		CreatedAt:        util.FormatISO8601(createdAt),
+		Account:          apiStatus.Account,
+		Poll:             apiPoll,
+		MediaAttachments: apiAttachments,
+		Emojis:           apiEmojis,
+	}, nil
+}
+
+// StatusToGTSStatus converts a gts model status into an api model status
+// with GoToSocial-specific information, for serving at /api/v1/gotosocial/statuses.
+func (c *Converter) StatusToGTSStatus(ctx context.Context, s *gtsmodel.Status) (*apimodel.GTSStatus, error) {
+	return &apimodel.GTSStatus{
+		ID:                s.ID,
+		LocalOnly:         !util.PtrValueOr(s.Federated, true),
+		InteractionPolicy: statusInteractionPolicyToFrontend(s),
+	}, nil
+}
+
+// InteractionPolicyToAPIInteractionPolicyRequest converts the given
+// interaction policy back into the form in which it was requested.
+func InteractionPolicyToAPIInteractionPolicyRequest(p *gtsmodel.InteractionPolicy) *apimodel.InteractionPolicyRequest {
+	if p == nil {
+		return nil
+	}
+
+	return &apimodel.InteractionPolicyRequest{
+		CanReply:    policyValuesToFrontend(p.CanReply),
+		CanAnnounce: policyValuesToFrontend(p.CanAnnounce),
+		CanLike:     policyValuesToFrontend(p.CanLike),
+	}
+}
+
+func policyValuesToFrontend(values gtsmodel.PolicyValues) []apimodel.PolicyValue {
+	if len(values) == 0 {
+		return nil
+	}
+
+	apiValues := make([]apimodel.PolicyValue, 0, len(values))
+	for _, value := range values {
+		apiValues = append(apiValues, apimodel.PolicyValue(value))
+	}
+	return apiValues
+}
+
+// statusInteractionPolicyToFrontend returns the effective
+// interaction policy of the given status. Interactions that
+// the status' replyable, boostable, or likeable flags disallow
+// are shown as permitted for the status author only.
+func statusInteractionPolicyToFrontend(s *gtsmodel.Status) *apimodel.InteractionPolicy {
+	values := func(allowed *bool, interaction gtsmodel.InteractionType) []apimodel.PolicyValue {
+		if !util.PtrValueOr(allowed, true) {
+			return []apimodel.PolicyValue{apimodel.PolicyValueAuthor}
+		}
+
+		return policyValuesToFrontend(s.InteractionPolicy.Values(interaction))
+	}
+
+	return &apimodel.InteractionPolicy{
+		CanReply:    values(s.Replyable, gtsmodel.InteractionReply),
+		CanAnnounce: values(s.Boostable, gtsmodel.InteractionAnnounce),
+		CanLike:     values(s.Likeable, gtsmodel.InteractionLike),
+	}
+}
+
+// statusQuoteToFrontend converts the given quoted status
+// to its frontend representation, returning nil if it's not
+// visible to the requesting account or would be filtered out.
+//
+// Quotes are only rendered one level deep, so the quoted
+// status' own quote (if any) is never included.
+func (c *Converter) statusQuoteToFrontend(
+	ctx context.Context,
+	quoteOf *gtsmodel.Status,
+	requestingAccount *gtsmodel.Account,
+	filterContext statusfilter.FilterContext,
+	filters []*gtsmodel.Filter,
+) (*apimodel.StatusQuoted, error) {
+	visible, err := c.visFilter.StatusVisible(ctx, requestingAccount, quoteOf)
+	if err != nil {
+		return nil, gtserror.Newf("error checking visibility: %w", err)
+	}
+
+	if !visible {
+		return nil, nil
+	}
+
+	// Take a shallow copy without the
+	// quoted status' own quote set, to
+	// avoid recursing into quote chains.
+	quoteOf2 := new(gtsmodel.Status)
+	*quoteOf2 = *quoteOf
+	quoteOf2.QuoteOfID = ""
+	quoteOf2.QuoteOf = nil
+
+	quote, err := c.StatusToAPIStatus(ctx, quoteOf2, requestingAccount, filterContext, filters)
+	if errors.Is(err, statusfilter.ErrHideStatus) {
+		// Hide just the quote,
+		// not the quoting status.
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &apimodel.StatusQuoted{Status: quote}, nil
+}
+
 // statusToFrontend is a package internal function for
 // parsing a status into its initial frontend representation.
 //
@@ -1002,6 +1472,13 @@ func (c *Converter) statusToFrontend(
 		return nil, gtserror.Newf("error counting faves: %w", err)
 	}
 
+	// We only know about the faves and boosts of
+	// remote statuses that reached us, so prefer
+	// the counts reported by the origin server
+	// if they're higher than our own.
+	reblogsCount = max(reblogsCount, s.RemoteBoostsCount)
+	favesCount = max(favesCount, s.RemoteFavesCount)
+
 	apiAttachments, err := c.convertAttachmentsToAPIAttachments(ctx, s.Attachments, s.AttachmentIDs)
 	if err != nil {
 		log.Errorf(ctx, "error converting status attachments: %v", err)
@@ -1025,6 +1502,7 @@ func (c *Converter) statusToFrontend(
 	apiStatus := &apimodel.Status{
 		ID:                 s.ID,
 		CreatedAt:          util.FormatISO8601(s.CreatedAt),
+		EditedAt:           nil, // Set below.
 		InReplyToID:        nil, // Set below.
 		InReplyToAccountID: nil, // Set below.
 		Sensitive:          *s.Sensitive,
@@ -1038,17 +1516,23 @@ func (c *Converter) statusToFrontend(
 		FavouritesCount:    favesCount,
 		Content:            s.Content,
 		Reblog:             nil, // Set below.
+		Quote:              nil, // Set below.
+		InteractionPolicy:  statusInteractionPolicyToFrontend(s),
 		Application:        nil, // Set below.
 		Account:            apiAuthorAccount,
 		MediaAttachments:   apiAttachments,
 		Mentions:           apiMentions,
 		Tags:               apiTags,
 		Emojis:             apiEmojis,
-		Card:               nil, // TODO: implement cards
+		Card:               nil, // Set below.
 		Text:               s.Text,
 	}
 
 	// Nullable fields.
+	if !s.EditedAt.IsZero() {
+		apiStatus.EditedAt = util.Ptr(util.FormatISO8601(s.EditedAt))
+	}
+
 	if s.InReplyToID != "" {
 		apiStatus.InReplyToID = util.Ptr(s.InReplyToID)
 	}
@@ -1061,6 +1545,13 @@ func (c *Converter) statusToFrontend(
 		apiStatus.Language = util.Ptr(s.Language)
 	}
 
+	if s.Card != nil {
+		apiStatus.Card, err = c.CardToAPICard(ctx, s.Card)
+		if err != nil {
+			log.Errorf(ctx, "error converting status card: %v", err)
+		}
+	}
+
 	if s.BoostOf != nil {
 		reblog, err := c.StatusToAPIStatus(ctx, s.BoostOf, requestingAccount, filterContext, filters)
 		if errors.Is(err, statusfilter.ErrHideStatus) {
@@ -1074,7 +1565,19 @@ func (c *Converter) statusToFrontend(
 		apiStatus.Reblog = &apimodel.StatusReblogged{reblog}
 	}
 
-	if app := s.CreatedWithApplication; app != nil {
+	if s.QuoteOf != nil {
+		apiStatus.Quote, err = c.statusQuoteToFrontend(ctx, s.QuoteOf, requestingAccount, filterContext, filters)
+		if err != nil {
+			log.Errorf(ctx, "error converting quoted status: %v", err)
+		}
+	}
+
+	// Author may have opted to hide
+	// which application they posted with.
+	hideApplication := s.Account.Settings != nil &&
+		*s.Account.Settings.HideApplication
+
+	if app := s.CreatedWithApplication; app != nil && !hideApplication {
 		apiStatus.Application, err = c.AppToAPIAppPublic(ctx, app)
 		if err != nil {
 			return nil, gtserror.Newf(
@@ -1104,6 +1607,7 @@ func (c *Converter) statusToFrontend(
 		apiStatus.Favourited = apiStatus.Reblog.Favourited
 		apiStatus.Bookmarked = apiStatus.Reblog.Bookmarked
 		apiStatus.Muted = apiStatus.Reblog.Muted
+		apiStatus.ContentRevealed = apiStatus.Reblog.ContentRevealed
 		apiStatus.Reblogged = apiStatus.Reblog.Reblogged
 		apiStatus.Pinned = apiStatus.Reblog.Pinned
 	} else {
@@ -1120,10 +1624,25 @@ func (c *Converter) statusToFrontend(
 		apiStatus.Favourited = interacts.Favourited
 		apiStatus.Bookmarked = interacts.Bookmarked
 		apiStatus.Muted = interacts.Muted
+		apiStatus.ContentRevealed = interacts.Revealed
 		apiStatus.Reblogged = interacts.Reblogged
 		apiStatus.Pinned = interacts.Pinned
 	}
 
+	// Emoji reactions, only relevant
+	// for the status itself, not boosts.
+	if s.BoostOfID == "" {
+		reactions, err := c.state.DB.GetStatusReactions(gtscontext.SetBarebones(ctx), s.ID)
+		if err != nil {
+			log.Errorf(ctx, "error getting reactions for status %s: %v", s.ID, err)
+		}
+
+		apiStatus.EmojiReactions, err = c.StatusReactionsToAPIReactions(ctx, reactions, requestingAccount, false)
+		if err != nil {
+			log.Errorf(ctx, "error converting reactions for status %s: %v", s.ID, err)
+		}
+	}
+
 	// If web URL is empty for whatever
 	// reason, provide AP URI as fallback.
 	if s.URL == "" {
@@ -1140,6 +1659,115 @@ func (c *Converter) statusToFrontend(
 	return apiStatus, nil
 }
 
+// StatusReactionsToAPIReactions converts the given emoji reactions
+// to a status into per-emoji summaries, in order of first use. If
+// withAccounts is set, each summary will also include the reacting
+// accounts, in which case reaction accounts must be populated.
+//
+// Reactions with a custom emoji that couldn't be fetched (yet),
+// or that has since been disabled, are skipped.
+func (c *Converter) StatusReactionsToAPIReactions(
+	ctx context.Context,
+	reactions []*gtsmodel.StatusReaction,
+	requestingAccount *gtsmodel.Account,
+	withAccounts bool,
+) ([]apimodel.EmojiReaction, error) {
+	if len(reactions) == 0 {
+		return nil, nil
+	}
+
+	var (
+		apiReactions []apimodel.EmojiReaction
+
+		// Index of each emoji
+		// summary in apiReactions.
+		indices = make(map[string]int)
+	)
+
+	for _, r := range reactions {
+		// Key on emoji ID too, as remote
+		// shortcodes are not unique.
+		key := r.Name + "/" + r.EmojiID
+
+		idx, ok := indices[key]
+		if !ok {
+			apiReaction, ok, err := c.reactionToAPIReaction(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+
+			if !ok {
+				// Not usable.
+				continue
+			}
+
+			idx = len(apiReactions)
+			indices[key] = idx
+			apiReactions = append(apiReactions, apiReaction)
+		}
+
+		apiReaction := &apiReactions[idx]
+		apiReaction.Count++
+
+		if requestingAccount != nil &&
+			r.AccountID == requestingAccount.ID {
+			apiReaction.Me = true
+		}
+
+		if withAccounts {
+			apiAccount, err := c.AccountToAPIAccountPublic(ctx, r.Account)
+			if err != nil {
+				return nil, gtserror.Newf("error converting account %s: %w", r.AccountID, err)
+			}
+			apiReaction.Accounts = append(apiReaction.Accounts, apiAccount)
+		}
+	}
+
+	return apiReactions, nil
+}
+
+// reactionToAPIReaction returns an empty summary for the
+// emoji of the given reaction, or false if it's not usable.
+func (c *Converter) reactionToAPIReaction(
+	ctx context.Context,
+	r *gtsmodel.StatusReaction,
+) (apimodel.EmojiReaction, bool, error) {
+	if !r.IsCustom() {
+		// Unicode emoji, or a custom emoji we
+		// haven't (yet) managed to fetch.
+		ok := ap.IsUnicodeEmojiReaction(r.Name)
+		return apimodel.EmojiReaction{Name: r.Name}, ok, nil
+	}
+
+	if r.Emoji == nil {
+		var err error
+		r.Emoji, err = c.state.DB.GetEmojiByID(ctx, r.EmojiID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return apimodel.EmojiReaction{}, false, gtserror.Newf("db error getting emoji %s: %w", r.EmojiID, err)
+		}
+
+		if r.Emoji == nil {
+			// Emoji deleted.
+			return apimodel.EmojiReaction{}, false, nil
+		}
+	}
+
+	if *r.Emoji.Disabled {
+		return apimodel.EmojiReaction{}, false, nil
+	}
+
+	name := r.Emoji.Shortcode
+	if !r.Emoji.IsLocal() {
+		name += "@" + r.Emoji.Domain
+	}
+
+	return apimodel.EmojiReaction{
+		Name:      name,
+		URL:       r.Emoji.ImageURL,
+		StaticURL: r.Emoji.ImageStaticURL,
+	}, true, nil
+}
+
 // VisToAPIVis converts a gts visibility into its api equivalent
 func (c *Converter) VisToAPIVis(ctx context.Context, m gtsmodel.Visibility) apimodel.Visibility {
 	switch m {
@@ -1215,7 +1843,7 @@ func (c *Converter) InstanceToAPIV1Instance(ctx context.Context, i *gtsmodel.Ins
 	instance.Configuration.Statuses.MaxMediaAttachments = config.GetStatusesMediaMaxFiles()
 	instance.Configuration.Statuses.CharactersReservedPerURL = instanceStatusesCharactersReservedPerURL
 	instance.Configuration.Statuses.SupportedMimeTypes = instanceStatusesSupportedMimeTypes
-	instance.Configuration.MediaAttachments.SupportedMimeTypes = media.SupportedMIMETypes
+	instance.Configuration.MediaAttachments.SupportedMimeTypes = media.AllowedMIMETypes()
 	instance.Configuration.MediaAttachments.ImageSizeLimit = int(config.GetMediaImageMaxSize())
 	instance.Configuration.MediaAttachments.ImageMatrixLimit = instanceMediaAttachmentsImageMatrixLimit
 	instance.Configuration.MediaAttachments.VideoSizeLimit = int(config.GetMediaVideoMaxSize())
@@ -1350,7 +1978,7 @@ func (c *Converter) InstanceToAPIV2Instance(ctx context.Context, i *gtsmodel.Ins
 	instance.Configuration.Statuses.MaxMediaAttachments = config.GetStatusesMediaMaxFiles()
 	instance.Configuration.Statuses.CharactersReservedPerURL = instanceStatusesCharactersReservedPerURL
 	instance.Configuration.Statuses.SupportedMimeTypes = instanceStatusesSupportedMimeTypes
-	instance.Configuration.MediaAttachments.SupportedMimeTypes = media.SupportedMIMETypes
+	instance.Configuration.MediaAttachments.SupportedMimeTypes = media.AllowedMIMETypes()
 	instance.Configuration.MediaAttachments.ImageSizeLimit = int(config.GetMediaImageMaxSize())
 	instance.Configuration.MediaAttachments.ImageMatrixLimit = instanceMediaAttachmentsImageMatrixLimit
 	instance.Configuration.MediaAttachments.VideoSizeLimit = int(config.GetMediaVideoMaxSize())
@@ -1364,11 +1992,22 @@ func (c *Converter) InstanceToAPIV2Instance(ctx context.Context, i *gtsmodel.Ins
 	instance.Configuration.Accounts.MaxFeaturedTags = instanceAccountsMaxFeaturedTags
 	instance.Configuration.Accounts.MaxProfileFields = instanceAccountsMaxProfileFields
 	instance.Configuration.Emojis.EmojiSizeLimit = int(config.GetMediaEmojiLocalMaxSize())
+	instance.Configuration.VAPID.PublicKey = config.GetWebPushVAPIDPublicKey()
 
 	// registrations
 	instance.Registrations.Enabled = config.GetAccountsRegistrationOpen()
 	instance.Registrations.ApprovalRequired = true // always required
 	instance.Registrations.Message = nil           // todo: not implemented
+	if minAge := config.GetAccountsMinimumAge(); minAge != 0 {
+		instance.Registrations.MinAge = &minAge
+	}
+	instance.Registrations.Usernames.MinLength = config.GetAccountsUsernameMinLength()
+	instance.Registrations.Usernames.MaxLength = config.GetAccountsUsernameMaxLength()
+	instance.Registrations.Usernames.Pattern = config.GetAccountsUsernamePattern()
+	instance.Registrations.Usernames.Reserved = config.GetAccountsReservedUsernames()
+	if instance.Registrations.Usernames.Reserved == nil {
+		instance.Registrations.Usernames.Reserved = []string{}
+	}
 
 	// contact
 	instance.Contact.Email = i.ContactEmail
@@ -1391,8 +2030,76 @@ func (c *Converter) InstanceToAPIV2Instance(ctx context.Context, i *gtsmodel.Ins
 	return instance, nil
 }
 
+// InstanceToAdminAPIInstance converts a gts instance into an admin view instance, for serving at /api/v1/admin/instances
+func (c *Converter) InstanceToAdminAPIInstance(ctx context.Context, i *gtsmodel.Instance) (*apimodel.AdminInstance, error) {
+	instance := &apimodel.AdminInstance{
+		ID:        i.ID,
+		Domain:    i.Domain,
+		Title:     i.Title,
+		Software:  i.Software,
+		Version:   i.Version,
+		CreatedAt: util.FormatISO8601(i.CreatedAt),
+	}
+
+	if !i.FetchedAt.IsZero() {
+		instance.FetchedAt = util.FormatISO8601(i.FetchedAt)
+	}
+
+	if !i.LastSeenAt.IsZero() {
+		instance.LastSeenAt = util.FormatISO8601(i.LastSeenAt)
+	}
+
+	if !i.SuspendedAt.IsZero() {
+		instance.SuspendedAt = util.FormatISO8601(i.SuspendedAt)
+	}
+
+	// Fill in outgoing delivery stats for this domain,
+	// combining those already stored with any not yet
+	// flushed to the database.
+	stats := c.state.Workers.Delivery.Stats.Get(i.Domain)
+	instance.Delivery.Delivered = uint64(i.DeliveredCount) + stats.Delivered
+	instance.Delivery.Failed = uint64(i.FailedCount) + stats.Failed
+
+	lastDeliveredAt := i.LastDeliveredAt
+	if stats.LastDeliveredAt.After(lastDeliveredAt) {
+		lastDeliveredAt = stats.LastDeliveredAt
+	}
+
+	if !lastDeliveredAt.IsZero() {
+		instance.Delivery.LastDeliveredAt = util.FormatISO8601(lastDeliveredAt)
+	}
+
+	lastFailedAt := i.LastFailedAt
+	if stats.LastFailedAt.After(lastFailedAt) {
+		lastFailedAt = stats.LastFailedAt
+	}
+
+	if !lastFailedAt.IsZero() {
+		instance.Delivery.LastFailedAt = util.FormatISO8601(lastFailedAt)
+	}
+
+	return instance, nil
+}
+
 // RelationshipToAPIRelationship converts a gts relationship into its api equivalent for serving in various places
 func (c *Converter) RelationshipToAPIRelationship(ctx context.Context, r *gtsmodel.Relationship) (*apimodel.Relationship, error) {
+	var blockingExpiresIn *int
+	if r.Blocking && !r.BlockingExpiresAt.IsZero() {
+		// Round up to the nearest second, and don't
+		// go negative if the block is due to expire.
+		remaining := time.Until(r.BlockingExpiresAt)
+		seconds := max(0, int((remaining+time.Second-1)/time.Second))
+		blockingExpiresIn = &seconds
+	}
+
+	var mutingExpiresIn *int
+	if r.Muting && !r.MutingExpiresAt.IsZero() {
+		// As above, but for the mute.
+		remaining := time.Until(r.MutingExpiresAt)
+		seconds := max(0, int((remaining+time.Second-1)/time.Second))
+		mutingExpiresIn = &seconds
+	}
+
 	return &apimodel.Relationship{
 		ID:                  r.ID,
 		Following:           r.Following,
@@ -1400,9 +2107,11 @@ func (c *Converter) RelationshipToAPIRelationship(ctx context.Context, r *gtsmod
 		Notifying:           r.Notifying,
 		FollowedBy:          r.FollowedBy,
 		Blocking:            r.Blocking,
+		BlockingExpiresIn:   blockingExpiresIn,
 		BlockedBy:           r.BlockedBy,
 		Muting:              r.Muting,
 		MutingNotifications: r.MutingNotifications,
+		MutingExpiresIn:     mutingExpiresIn,
 		Requested:           r.Requested,
 		RequestedBy:         r.RequestedBy,
 		DomainBlocking:      r.DomainBlocking,
@@ -1473,6 +2182,89 @@ func (c *Converter) NotificationToAPINotification(ctx context.Context, n *gtsmod
 	}, nil
 }
 
+// notifGroupSampleAccounts is the most sample
+// accounts included in a notification group.
+const notifGroupSampleAccounts = 8
+
+// NotificationsToAPIGroupedNotifications converts the given notifications,
+// ordered ID descending (ie., a page of notifications), into grouped api
+// notifications: notifications with the same group key are gathered into
+// one group, placed by its most recent notification. Notifications that
+// can't be converted (eg., about a filtered status) are skipped.
+func (c *Converter) NotificationsToAPIGroupedNotifications(
+	ctx context.Context,
+	notifs []*gtsmodel.Notification,
+	filters []*gtsmodel.Filter,
+) (*apimodel.GroupedNotificationsResults, error) {
+	var (
+		results = &apimodel.GroupedNotificationsResults{
+			Accounts:           make([]*apimodel.Account, 0, len(notifs)),
+			Statuses:           make([]*apimodel.Status, 0, len(notifs)),
+			NotificationGroups: make([]*apimodel.NotificationGroup, 0, len(notifs)),
+		}
+
+		// Groups, accounts and statuses
+		// added to results so far, by key.
+		groups   = make(map[string]*apimodel.NotificationGroup, len(notifs))
+		accounts = make(map[string]struct{}, len(notifs))
+		statuses = make(map[string]struct{}, len(notifs))
+	)
+
+	for _, n := range notifs {
+		apiNotif, err := c.NotificationToAPINotification(ctx, n, filters)
+		if err != nil {
+			log.Debugf(ctx, "skipping notification %s because it couldn't be converted to its api representation: %v", n.ID, err)
+			continue
+		}
+
+		groupKey := n.GroupKey
+		if groupKey == "" {
+			// Not grouped, so
+			// a group of one.
+			groupKey = gtsmodel.NotificationUngroupedPrefix + n.ID
+		}
+
+		group, ok := groups[groupKey]
+		if !ok {
+			// First (ie., most recent)
+			// notification of this group.
+			group = &apimodel.NotificationGroup{
+				GroupKey:                 groupKey,
+				Type:                     apiNotif.Type,
+				MostRecentNotificationID: apiNotif.ID,
+				PageMaxID:                apiNotif.ID,
+				LatestPageNotificationAt: apiNotif.CreatedAt,
+				SampleAccountIDs:         make([]string, 0, 1),
+			}
+
+			if apiNotif.Status != nil {
+				group.StatusID = apiNotif.Status.ID
+				if _, ok := statuses[apiNotif.Status.ID]; !ok {
+					statuses[apiNotif.Status.ID] = struct{}{}
+					results.Statuses = append(results.Statuses, apiNotif.Status)
+				}
+			}
+
+			groups[groupKey] = group
+			results.NotificationGroups = append(results.NotificationGroups, group)
+		}
+
+		group.NotificationsCount++
+		group.PageMinID = apiNotif.ID
+
+		if len(group.SampleAccountIDs) < notifGroupSampleAccounts &&
+			!slices.Contains(group.SampleAccountIDs, apiNotif.Account.ID) {
+			group.SampleAccountIDs = append(group.SampleAccountIDs, apiNotif.Account.ID)
+			if _, ok := accounts[apiNotif.Account.ID]; !ok {
+				accounts[apiNotif.Account.ID] = struct{}{}
+				results.Accounts = append(results.Accounts, apiNotif.Account)
+			}
+		}
+	}
+
+	return results, nil
+}
+
 // DomainPermToAPIDomainPerm converts a gts model domin block or allow into an api domain permission.
 func (c *Converter) DomainPermToAPIDomainPerm(
 	ctx context.Context,
@@ -1509,6 +2301,55 @@ func (c *Converter) DomainPermToAPIDomainPerm(
 	return domainPerm, nil
 }
 
+// DomainPermsToGTSDomainPermSubscriptions groups the given gts model domain
+// blocks or allows by the subscription that created them into api model domain
+// permission subscriptions, sorted by ID. Perms without a subscription are skipped.
+func (c *Converter) DomainPermsToGTSDomainPermSubscriptions(
+	ctx context.Context,
+	perms []gtsmodel.DomainPermission,
+) ([]*apimodel.GTSDomainPermissionSubscription, error) {
+	subsByID := make(map[string]*apimodel.GTSDomainPermissionSubscription)
+
+	for _, perm := range perms {
+		subID := perm.GetSubscriptionID()
+		if subID == "" {
+			// Not created
+			// by subscription.
+			continue
+		}
+
+		// Domain may be in Punycode,
+		// de-punify it just in case.
+		domain, err := util.DePunify(perm.GetDomain())
+		if err != nil {
+			return nil, gtserror.Newf("error de-punifying domain %s: %w", perm.GetDomain(), err)
+		}
+
+		sub, ok := subsByID[subID]
+		if !ok {
+			sub = &apimodel.GTSDomainPermissionSubscription{
+				ID:             subID,
+				PermissionType: perm.GetType().String(),
+			}
+			subsByID[subID] = sub
+		}
+
+		sub.Domains = append(sub.Domains, domain)
+	}
+
+	subs := make([]*apimodel.GTSDomainPermissionSubscription, 0, len(subsByID))
+	for _, sub := range subsByID {
+		slices.Sort(sub.Domains)
+		subs = append(subs, sub)
+	}
+
+	slices.SortFunc(subs, func(a, b *apimodel.GTSDomainPermissionSubscription) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return subs, nil
+}
+
 // ReportToAPIReport converts a gts model report into an api model report, for serving at /api/v1/reports
 func (c *Converter) ReportToAPIReport(ctx context.Context, r *gtsmodel.Report) (*apimodel.Report, error) {
 	report := &apimodel.Report{
@@ -1656,6 +2497,7 @@ func (c *Converter) ListToAPIList(ctx context.Context, l *gtsmodel.List) (*apimo
 		ID:            l.ID,
 		Title:         l.Title,
 		RepliesPolicy: string(l.RepliesPolicy),
+		Exclusive:     util.PtrValueOr(l.Exclusive, false),
 	}, nil
 }
 
@@ -1795,6 +2637,302 @@ func (c *Converter) PollToAPIPoll(ctx context.Context, requester *gtsmodel.Accou
 	}, nil
 }
 
+// ScheduledStatusToAPIScheduledStatus converts a database (gtsmodel) ScheduledStatus into an API model representation.
+func (c *Converter) ScheduledStatusToAPIScheduledStatus(ctx context.Context, s *gtsmodel.ScheduledStatus) (*apimodel.ScheduledStatus, error) {
+	// Ensure the scheduled status model is fully populated.
+	if err := c.state.DB.PopulateScheduledStatus(ctx, s); err != nil {
+		return nil, gtserror.Newf("error populating scheduled status: %w", err)
+	}
+
+	attachments := make([]apimodel.Attachment, 0, len(s.Attachments))
+	for _, attachment := range s.Attachments {
+		apiAttachment, err := c.AttachmentToAPIAttachment(ctx, attachment)
+		if err != nil {
+			log.Errorf(ctx, "error converting scheduled status attachment: %v", err)
+			continue
+		}
+		attachments = append(attachments, apiAttachment)
+	}
+
+	scheduledAt := util.FormatISO8601(s.ScheduledAt)
+
+	params := &apimodel.StatusParams{
+		Text:          s.Text,
+		InReplyToID:   s.InReplyToID,
+		MediaIDs:      s.AttachmentIDs,
+		Sensitive:     util.PtrValueOr(s.Sensitive, false),
+		SpoilerText:   s.ContentWarning,
+		Visibility:    string(c.VisToAPIVis(ctx, s.Visibility)),
+		ScheduledAt:   scheduledAt,
+		ApplicationID: s.ApplicationID,
+		Language:      s.Language,
+	}
+
+	if s.HasPoll() {
+		params.Poll = &apimodel.StatusParamsPoll{
+			Options:    s.PollOptions,
+			ExpiresIn:  s.PollExpiresIn,
+			Multiple:   util.PtrValueOr(s.PollMultiple, false),
+			HideTotals: util.PtrValueOr(s.PollHideCounts, false),
+		}
+	}
+
+	return &apimodel.ScheduledStatus{
+		ID:               s.ID,
+		ScheduledAt:      scheduledAt,
+		Params:           params,
+		MediaAttachments: attachments,
+	}, nil
+}
+
+// NotificationRequestToAPINotificationRequest converts a database (gtsmodel) NotificationRequest into an API model representation.
+func (c *Converter) NotificationRequestToAPINotificationRequest(ctx context.Context, r *gtsmodel.NotificationRequest) (*apimodel.NotificationRequest, error) {
+	// Ensure the notification request model is fully populated.
+	if err := c.state.DB.PopulateNotificationRequest(ctx, r); err != nil {
+		return nil, gtserror.Newf("error populating notification request: %w", err)
+	}
+
+	apiAccount, err := c.AccountToAPIAccountPublic(ctx, r.FromAccount)
+	if err != nil {
+		return nil, gtserror.Newf("error converting account to api: %w", err)
+	}
+
+	count, err := c.state.DB.CountFilteredNotifications(ctx, r.AccountID, r.FromAccountID)
+	if err != nil {
+		return nil, gtserror.Newf("error counting filtered notifications: %w", err)
+	}
+
+	var apiStatus *apimodel.Status
+	if r.LastStatus != nil {
+		apiStatus, err = c.StatusToAPIStatus(ctx, r.LastStatus, r.Account, statusfilter.FilterContextNotifications, nil)
+		if err != nil {
+			return nil, gtserror.Newf("error converting status to api: %w", err)
+		}
+	}
+
+	return &apimodel.NotificationRequest{
+		ID:                 r.ID,
+		CreatedAt:          util.FormatISO8601(r.CreatedAt),
+		UpdatedAt:          util.FormatISO8601(r.UpdatedAt),
+		Account:            apiAccount,
+		NotificationsCount: strconv.Itoa(count),
+		LastStatus:         apiStatus,
+	}, nil
+}
+
+// FollowerEventToAPIFollowerEvent converts a database (gtsmodel) FollowerEvent into an API model representation.
+func (c *Converter) FollowerEventToAPIFollowerEvent(ctx context.Context, e *gtsmodel.FollowerEvent) (*apimodel.GTSFollowerEvent, error) {
+	// Ensure the follower event model is fully populated.
+	if err := c.state.DB.PopulateFollowerEvent(ctx, e); err != nil {
+		return nil, gtserror.Newf("error populating follower event: %w", err)
+	}
+
+	apiAccount, err := c.AccountToAPIAccountPublic(ctx, e.FollowerAccount)
+	if err != nil {
+		return nil, gtserror.Newf("error converting account to api: %w", err)
+	}
+
+	return &apimodel.GTSFollowerEvent{
+		ID:        e.ID,
+		Type:      string(e.Type),
+		CreatedAt: util.FormatISO8601(e.CreatedAt),
+		Account:   apiAccount,
+	}, nil
+}
+
+// InteractionRequestToAPIInteractionRequest converts a database (gtsmodel) InteractionRequest
+// into an API model representation, from the perspective of the given requesting account.
+func (c *Converter) InteractionRequestToAPIInteractionRequest(
+	ctx context.Context,
+	r *gtsmodel.InteractionRequest,
+	requestingAccount *gtsmodel.Account,
+) (*apimodel.InteractionRequest, error) {
+	// Ensure the interaction request model is fully populated.
+	if err := c.state.DB.PopulateInteractionRequest(ctx, r); err != nil {
+		return nil, gtserror.Newf("error populating interaction request: %w", err)
+	}
+
+	apiAccount, err := c.AccountToAPIAccountPublic(ctx, r.InteractingAccount)
+	if err != nil {
+		return nil, gtserror.Newf("error converting account to api: %w", err)
+	}
+
+	apiStatus, err := c.StatusToAPIStatus(ctx, r.Status, requestingAccount, statusfilter.FilterContextNone, nil)
+	if err != nil {
+		return nil, gtserror.Newf("error converting status to api: %w", err)
+	}
+
+	apiReq := &apimodel.InteractionRequest{
+		ID:        r.ID,
+		CreatedAt: util.FormatISO8601(r.CreatedAt),
+		Account:   apiAccount,
+		Status:    apiStatus,
+	}
+
+	switch r.InteractionType {
+	case gtsmodel.InteractionAnnounce:
+		apiReq.Type = "reblog"
+	default:
+		apiReq.Type = "reply"
+		if r.Interaction != nil {
+			apiReq.Reply, err = c.StatusToAPIStatus(ctx, r.Interaction, requestingAccount, statusfilter.FilterContextNone, nil)
+			if err != nil {
+				return nil, gtserror.Newf("error converting reply to api: %w", err)
+			}
+		}
+	}
+
+	if !r.AcceptedAt.IsZero() {
+		apiReq.AcceptedAt = util.FormatISO8601(r.AcceptedAt)
+	}
+
+	if !r.RejectedAt.IsZero() {
+		apiReq.RejectedAt = util.FormatISO8601(r.RejectedAt)
+	}
+
+	return apiReq, nil
+}
+
+// WebPushSubscriptionToAPIWebPushSubscription converts a database (gtsmodel) WebPushSubscription into an API model representation.
+func (c *Converter) WebPushSubscriptionToAPIWebPushSubscription(_ context.Context, s *gtsmodel.WebPushSubscription) (*apimodel.WebPushSubscription, error) {
+	return &apimodel.WebPushSubscription{
+		ID:       s.ID,
+		Endpoint: s.Endpoint,
+		Alerts: apimodel.WebPushSubscriptionAlerts{
+			Follow:        util.PtrValueOr(s.AlertFollow, false),
+			FollowRequest: util.PtrValueOr(s.AlertFollowRequest, false),
+			Favourite:     util.PtrValueOr(s.AlertFavourite, false),
+			Mention:       util.PtrValueOr(s.AlertMention, false),
+			Reblog:        util.PtrValueOr(s.AlertReblog, false),
+			Poll:          util.PtrValueOr(s.AlertPoll, false),
+			Status:        util.PtrValueOr(s.AlertStatus, false),
+			AdminSignup:   util.PtrValueOr(s.AlertSignup, false),
+		},
+		ServerKey: config.GetWebPushVAPIDPublicKey(),
+		Policy:    string(s.Policy),
+	}, nil
+}
+
+// AnnouncementToAPIAnnouncement converts a database (gtsmodel) Announcement into an API model representation appropriate for the given requesting account.
+func (c *Converter) AnnouncementToAPIAnnouncement(ctx context.Context, requester *gtsmodel.Account, a *gtsmodel.Announcement) (*apimodel.Announcement, error) {
+	// Ensure the announcement model is fully populated.
+	if err := c.state.DB.PopulateAnnouncement(ctx, a); err != nil {
+		return nil, gtserror.Newf("error populating announcement: %w", err)
+	}
+
+	var (
+		startsAt    *string
+		endsAt      *string
+		publishedAt = a.CreatedAt
+		read        bool
+	)
+
+	if !a.StartsAt.IsZero() {
+		str := util.FormatISO8601(a.StartsAt)
+		startsAt = &str
+	}
+
+	if !a.EndsAt.IsZero() {
+		str := util.FormatISO8601(a.EndsAt)
+		endsAt = &str
+	}
+
+	if !a.PublishedAt.IsZero() {
+		publishedAt = a.PublishedAt
+	}
+
+	if requester != nil {
+		var err error
+
+		// Check whether requester has dismissed this announcement.
+		read, err = c.state.DB.IsAnnouncementReadByAccount(ctx, a.ID, requester.ID)
+		if err != nil {
+			return nil, gtserror.Newf("error checking announcement read: %w", err)
+		}
+	}
+
+	// Mentioned accounts are stored directly on the
+	// announcement, so wrap each in a transient mention.
+	mentions := make([]apimodel.Mention, 0, len(a.MentionedAccounts))
+	for _, account := range a.MentionedAccounts {
+		mention, err := c.MentionToAPIMention(ctx, &gtsmodel.Mention{
+			TargetAccountID: account.ID,
+			TargetAccount:   account,
+		})
+		if err != nil {
+			log.Errorf(ctx, "error converting announcement mention: %v", err)
+			continue
+		}
+		mentions = append(mentions, mention)
+	}
+
+	tags, err := c.convertTagsToAPITags(ctx, a.Tags, a.TagIDs)
+	if err != nil {
+		log.Errorf(ctx, "error converting announcement tags: %v", err)
+	}
+
+	emojis, err := c.convertEmojisToAPIEmojis(ctx, a.Emojis, a.EmojiIDs)
+	if err != nil {
+		log.Errorf(ctx, "error converting announcement emojis: %v", err)
+	}
+
+	reactions, err := c.AnnouncementReactionsToAPIReactions(ctx, requester, a.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apimodel.Announcement{
+		ID:          a.ID,
+		Content:     a.Content,
+		StartsAt:    startsAt,
+		EndsAt:      endsAt,
+		AllDay:      util.PtrValueOr(a.AllDay, false),
+		PublishedAt: util.FormatISO8601(publishedAt),
+		UpdatedAt:   util.FormatISO8601(a.UpdatedAt),
+		Published:   a.IsPublished(),
+		Read:        read,
+		Mentions:    mentions,
+		Statuses:    []apimodel.Status{},
+		Tags:        tags,
+		Emojis:      emojis,
+		Reactions:   reactions,
+	}, nil
+}
+
+// AnnouncementReactionsToAPIReactions aggregates the reactions to the given announcement into
+// one API model reaction per reaction name, in order of first use, with "me" set for requester.
+func (c *Converter) AnnouncementReactionsToAPIReactions(ctx context.Context, requester *gtsmodel.Account, announcementID string) ([]apimodel.AnnouncementReaction, error) {
+	reactions, err := c.state.DB.GetAnnouncementReactions(ctx, announcementID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("error getting announcement reactions: %w", err)
+	}
+
+	apiReactions := make([]apimodel.AnnouncementReaction, 0, len(reactions))
+	byName := make(map[string]int, len(reactions))
+
+	for _, reaction := range reactions {
+		idx, ok := byName[reaction.Name]
+		if !ok {
+			apiReaction := apimodel.AnnouncementReaction{Name: reaction.Name}
+			if reaction.Emoji != nil {
+				apiReaction.URL = reaction.Emoji.ImageURL
+				apiReaction.StaticURL = reaction.Emoji.ImageStaticURL
+			}
+
+			idx = len(apiReactions)
+			byName[reaction.Name] = idx
+			apiReactions = append(apiReactions, apiReaction)
+		}
+
+		apiReactions[idx].Count++
+		if requester != nil && reaction.AccountID == requester.ID {
+			apiReactions[idx].Me = true
+		}
+	}
+
+	return apiReactions, nil
+}
+
 // convertAttachmentsToAPIAttachments will convert a slice of GTS model attachments to frontend API model attachments, falling back to IDs if no GTS models supplied.
 func (c *Converter) convertAttachmentsToAPIAttachments(ctx context.Context, attachments []*gtsmodel.MediaAttachment, attachmentIDs []string) ([]*apimodel.Attachment, error) {
 	var errs gtserror.MultiError
@@ -1888,6 +3026,26 @@ func (c *Converter) FilterToAPIFilterV2(ctx context.Context, filter *gtsmodel.Fi
 	}, nil
 }
 
+// FilterToAPIFilterExportV2 converts one GTS model filter
+// into a portable API v2 filter, suitable for an export.
+func (c *Converter) FilterToAPIFilterExportV2(ctx context.Context, filter *gtsmodel.Filter) (*apimodel.FilterExportV2, error) {
+	apiFilterKeywords := make([]apimodel.FilterKeywordExportV2, 0, len(filter.Keywords))
+	for _, filterKeyword := range filter.Keywords {
+		apiFilterKeywords = append(apiFilterKeywords, apimodel.FilterKeywordExportV2{
+			Keyword:   filterKeyword.Keyword,
+			WholeWord: util.PtrValueOr(filterKeyword.WholeWord, false),
+		})
+	}
+
+	return &apimodel.FilterExportV2{
+		Title:        filter.Title,
+		Context:      filterToAPIFilterContexts(filter),
+		ExpiresAt:    filterExpiresAtToAPIFilterExpiresAt(filter.ExpiresAt),
+		FilterAction: filterActionToAPIFilterAction(filter.Action),
+		Keywords:     apiFilterKeywords,
+	}, nil
+}
+
 func filterExpiresAtToAPIFilterExpiresAt(expiresAt time.Time) *string {
 	if expiresAt.IsZero() {
 		return nil
@@ -2030,3 +3188,119 @@ func (c *Converter) ThemesToAPIThemes(themes []*gtsmodel.Theme) []apimodel.Theme
 	}
 	return apiThemes
 }
+
+// ConversationToAPIConversation converts a database (gtsmodel) Conversation
+// into an API model representation, from the perspective of its owner.
+func (c *Converter) ConversationToAPIConversation(
+	ctx context.Context,
+	conversation *gtsmodel.Conversation,
+	requestingAccount *gtsmodel.Account,
+) (*apimodel.Conversation, error) {
+	// Ensure the conversation model is fully populated.
+	if err := c.state.DB.PopulateConversation(ctx, conversation); err != nil {
+		return nil, gtserror.Newf("error populating conversation: %w", err)
+	}
+
+	otherAccounts := conversation.OtherAccounts
+	if len(otherAccounts) == 0 {
+		// Conversation with oneself,
+		// show the owner's account.
+		otherAccounts = []*gtsmodel.Account{conversation.Account}
+	}
+
+	apiAccounts := make([]apimodel.Account, 0, len(otherAccounts))
+	for _, account := range otherAccounts {
+		apiAccount, err := c.AccountToAPIAccountPublic(ctx, account)
+		if err != nil {
+			log.Errorf(ctx, "error converting account %s to api: %v", account.ID, err)
+			continue
+		}
+		apiAccounts = append(apiAccounts, *apiAccount)
+	}
+
+	apiStatus, err := c.StatusToAPIStatus(ctx, conversation.LastStatus, requestingAccount, statusfilter.FilterContextNone, nil)
+	if err != nil {
+		return nil, gtserror.Newf("error converting status to api: %w", err)
+	}
+
+	return &apimodel.Conversation{
+		ID:         conversation.ID,
+		Unread:     !*conversation.Read,
+		Accounts:   apiAccounts,
+		LastStatus: apiStatus,
+	}, nil
+}
+
+// AccountExportToAPIAccountExport converts a gts model account export into its api equivalent.
+func (c *Converter) AccountExportToAPIAccountExport(ctx context.Context, e *gtsmodel.AccountExport) *apimodel.AccountExport {
+	apiExport := &apimodel.AccountExport{
+		ID:        e.ID,
+		CreatedAt: util.FormatISO8601(e.CreatedAt),
+		State:     string(e.State),
+	}
+
+	if e.State == gtsmodel.AccountExportStateDone {
+		downloadURL := config.GetProtocol() + "://" + config.GetHost() + "/api/v1/exports/" + e.ID + "/download"
+		apiExport.Size = e.FileSize
+		apiExport.DownloadURL = &downloadURL
+	}
+
+	return apiExport
+}
+
+// ContentRevealToAPIContentReveal converts a gts model content reveal into its api equivalent.
+func (c *Converter) ContentRevealToAPIContentReveal(ctx context.Context, r *gtsmodel.ContentReveal) (*apimodel.ContentReveal, error) {
+	apiReveal := &apimodel.ContentReveal{
+		ID:        r.ID,
+		CreatedAt: util.FormatISO8601(r.CreatedAt),
+		StatusID:  r.StatusID,
+	}
+
+	if r.TargetAccountID != "" {
+		if r.TargetAccount == nil {
+			var err error
+			r.TargetAccount, err = c.state.DB.GetAccountByID(ctx, r.TargetAccountID)
+			if err != nil {
+				return nil, gtserror.Newf("db error getting target account %s: %w", r.TargetAccountID, err)
+			}
+		}
+
+		apiAccount, err := c.AccountToAPIAccountPublic(ctx, r.TargetAccount)
+		if err != nil {
+			return nil, gtserror.Newf("error converting target account: %w", err)
+		}
+		apiReveal.Account = apiAccount
+	}
+
+	return apiReveal, nil
+}
+
+// AccountImportToAPIAccountImport converts a gts model account import
+// into its api equivalent, given the number of rows processed so far,
+// and the rows that failed to import.
+func (c *Converter) AccountImportToAPIAccountImport(
+	ctx context.Context,
+	i *gtsmodel.AccountImport,
+	processed int,
+	failed []*gtsmodel.AccountImportRow,
+) *apimodel.AccountImport {
+	apiImport := &apimodel.AccountImport{
+		ID:            i.ID,
+		CreatedAt:     util.FormatISO8601(i.CreatedAt),
+		Type:          string(i.Type),
+		State:         string(i.State),
+		TotalRows:     i.TotalRows,
+		ProcessedRows: processed,
+		Failures:      make([]apimodel.AccountImportFailure, 0, len(failed)),
+	}
+
+	for _, row := range failed {
+		apiImport.Failures = append(apiImport.Failures, apimodel.AccountImportFailure{
+			Line:   row.Line,
+			Record: row.Record,
+			Error:  row.Error,
+		})
+	}
+
+	return apiImport
+}
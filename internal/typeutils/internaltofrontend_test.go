@@ -27,6 +27,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -121,10 +122,25 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendAliasedAndMoved()
     "status_content_type": "text/plain",
     "note": "hey yo this is my profile!",
     "fields": [],
+    "notify_new_from_days": 0,
+    "email_when_away_hours": 0,
+    "auto_approve_followed": false,
+    "auto_approve_local": false,
+    "auto_approve_min_age_days": 0,
+    "record_follower_events": false,
     "follow_requests_count": 0,
     "also_known_as_uris": [
       "http://localhost:8080/users/1happyturtle"
-    ]
+    ],
+    "aliases": [
+      {
+        "uri": "http://localhost:8080/users/1happyturtle",
+        "url": "http://localhost:8080/@1happyturtle",
+        "acct": "1happyturtle",
+        "verified": false
+      }
+    ],
+    "attribution_domains": []
   },
   "enable_rss": true,
   "role": {
@@ -304,7 +320,14 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendSensitive() {
     "status_content_type": "text/plain",
     "note": "hey yo this is my profile!",
     "fields": [],
-    "follow_requests_count": 0
+    "notify_new_from_days": 0,
+    "email_when_away_hours": 0,
+    "auto_approve_followed": false,
+    "auto_approve_local": false,
+    "auto_approve_min_age_days": 0,
+    "record_follower_events": false,
+    "follow_requests_count": 0,
+    "attribution_domains": []
   },
   "enable_rss": true,
   "role": {
@@ -425,6 +448,137 @@ func (suite *InternalToFrontendTestSuite) TestLocalInstanceAccountToFrontendBloc
 }`, string(b))
 }
 
+func (suite *InternalToFrontendTestSuite) TestStatusToFrontendWithCard() {
+	ctx := context.Background()
+
+	card := &gtsmodel.Card{
+		ID:           "01HZXKR3E8DAWP1MRCHVT8F5QZ",
+		URL:          "https://example.org/article",
+		Title:        "Is Water Wet?",
+		Description:  "We ask an expert.",
+		Type:         gtsmodel.CardTypeLink,
+		AuthorName:   "Some Writer",
+		ProviderName: "Example News",
+		ImageURL:     "https://example.org/images/water.jpg",
+		Width:        1200,
+		Height:       630,
+	}
+	if err := suite.db.PutCard(ctx, card); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	testStatus := &gtsmodel.Status{}
+	*testStatus = *suite.testStatuses["admin_account_status_1"]
+	testStatus.CardID = card.ID
+
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(ctx, testStatus, nil, statusfilter.FilterContextNone, nil)
+	suite.NoError(err)
+
+	b, err := json.MarshalIndent(apiStatus.Card, "", "  ")
+	suite.NoError(err)
+
+	suite.Equal(`{
+  "url": "https://example.org/article",
+  "title": "Is Water Wet?",
+  "description": "We ask an expert.",
+  "type": "link",
+  "author_name": "Some Writer",
+  "author_url": "",
+  "authors": [
+    {
+      "name": "Some Writer",
+      "url": "",
+      "account": null
+    }
+  ],
+  "provider_name": "Example News",
+  "provider_url": "",
+  "html": "",
+  "width": 1200,
+  "height": 630,
+  "image": "https://example.org/images/water.jpg",
+  "embed_url": "",
+  "blurhash": ""
+}`, string(b))
+}
+
+func (suite *InternalToFrontendTestSuite) TestCardToFrontendWithCreator() {
+	ctx := context.Background()
+
+	card := &gtsmodel.Card{
+		ID:               "01J2RZ0JWAKN5B6XN2S8FT1J4P",
+		URL:              "https://fossbros-anonymous.io/blog/post",
+		Title:            "A blog post",
+		Type:             gtsmodel.CardTypeLink,
+		Creator:          "@foss_satan@fossbros-anonymous.io",
+		CreatorAccountID: suite.testAccounts["remote_account_1"].ID,
+	}
+	if err := suite.db.PutCard(ctx, card); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	apiCard, err := suite.typeconverter.CardToAPICard(ctx, card)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Creator account should be filled in
+	// on the author, even without a name.
+	if suite.Len(apiCard.Authors, 1) {
+		author := apiCard.Authors[0]
+		suite.Empty(author.Name)
+		suite.Empty(author.URL)
+		if suite.NotNil(author.Account) {
+			suite.Equal(card.CreatorAccountID, author.Account.ID)
+			suite.Equal("foss_satan@fossbros-anonymous.io", author.Account.Acct)
+		}
+	}
+}
+
+func (suite *InternalToFrontendTestSuite) TestStatusToFrontendQuote() {
+	var (
+		ctx               = context.Background()
+		testStatus        = new(gtsmodel.Status)
+		quoteOf           = suite.testStatuses["local_account_2_status_1"]
+		requestingAccount = suite.testAccounts["local_account_1"]
+	)
+	*testStatus = *suite.testStatuses["admin_account_status_1"]
+	testStatus.QuoteOfID = quoteOf.ID
+	testStatus.QuoteOfURI = quoteOf.URI
+
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(ctx, testStatus, requestingAccount, statusfilter.FilterContextNone, nil)
+	suite.NoError(err)
+
+	if apiStatus.Quote == nil {
+		suite.FailNow("expected quote to be set")
+	}
+	suite.Equal(quoteOf.ID, apiStatus.Quote.ID)
+	suite.Equal(quoteOf.Content, apiStatus.Quote.Content)
+	suite.Nil(apiStatus.Quote.Quote)
+}
+
+func (suite *InternalToFrontendTestSuite) TestStatusToFrontendQuoteNotVisible() {
+	var (
+		ctx        = context.Background()
+		testStatus = new(gtsmodel.Status)
+
+		// Direct message to zork.
+		quoteOf           = suite.testStatuses["local_account_2_status_6"]
+		requestingAccount = suite.testAccounts["admin_account"]
+	)
+	*testStatus = *suite.testStatuses["local_account_1_status_1"]
+	testStatus.QuoteOfID = quoteOf.ID
+	testStatus.QuoteOfURI = quoteOf.URI
+
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(ctx, testStatus, requestingAccount, statusfilter.FilterContextNone, nil)
+	suite.NoError(err)
+
+	// Quoting status should be
+	// returned without the quote.
+	suite.Equal(testStatus.ID, apiStatus.ID)
+	suite.Nil(apiStatus.Quote)
+}
+
 func (suite *InternalToFrontendTestSuite) TestStatusToFrontend() {
 	testStatus := suite.testStatuses["admin_account_status_1"]
 	requestingAccount := suite.testAccounts["local_account_1"]
@@ -437,6 +591,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontend() {
 	suite.Equal(`{
   "id": "01F8MH75CBF9JFX4ZAD54N0W0R",
   "created_at": "2021-10-20T11:36:45.000Z",
+  "edited_at": null,
   "in_reply_to_id": null,
   "in_reply_to_account_id": null,
   "sensitive": false,
@@ -452,9 +607,22 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontend() {
   "reblogged": false,
   "muted": false,
   "bookmarked": true,
+  "content_revealed": false,
   "pinned": false,
   "content": "hello world! #welcome ! first post on the instance :rainbow: !",
   "reblog": null,
+  "quote": null,
+  "interaction_policy": {
+    "can_reply": [
+      "public"
+    ],
+    "can_announce": [
+      "public"
+    ],
+    "can_like": [
+      "public"
+    ]
+  },
   "application": {
     "name": "superseriousbusiness",
     "website": "https://superserious.business"
@@ -513,7 +681,8 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontend() {
         }
       },
       "description": "Black and white image of some 50's style text saying: Welcome On Board",
-      "blurhash": "LNJRdVM{00Rj%Mayt7j[4nWBofRj"
+      "blurhash": "LNJRdVM{00Rj%Mayt7j[4nWBofRj",
+      "sensitive": false
     }
   ],
   "mentions": [],
@@ -538,6 +707,31 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontend() {
 }`, string(b))
 }
 
+func (suite *InternalToFrontendTestSuite) TestStatusToFrontendHideApplication() {
+	ctx := context.Background()
+	testStatus := suite.testStatuses["admin_account_status_1"]
+	requestingAccount := suite.testAccounts["local_account_1"]
+
+	// Have admin opt out of
+	// application attribution.
+	settings, err := suite.db.GetAccountSettings(ctx, testStatus.AccountID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	settings.HideApplication = util.Ptr(true)
+	if err := suite.db.UpdateAccountSettings(ctx, settings, "hide_application"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(ctx, testStatus, requestingAccount, statusfilter.FilterContextNone, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Nil(apiStatus.Application)
+	suite.True(apiStatus.Account.HideApplication)
+}
+
 // Test that a status which is filtered with a warn filter by the requesting user has `filtered` set correctly.
 func (suite *InternalToFrontendTestSuite) TestWarnFilteredStatusToFrontend() {
 	testStatus := suite.testStatuses["admin_account_status_1"]
@@ -564,6 +758,7 @@ func (suite *InternalToFrontendTestSuite) TestWarnFilteredStatusToFrontend() {
 	suite.Equal(`{
   "id": "01F8MH75CBF9JFX4ZAD54N0W0R",
   "created_at": "2021-10-20T11:36:45.000Z",
+  "edited_at": null,
   "in_reply_to_id": null,
   "in_reply_to_account_id": null,
   "sensitive": false,
@@ -579,9 +774,22 @@ func (suite *InternalToFrontendTestSuite) TestWarnFilteredStatusToFrontend() {
   "reblogged": false,
   "muted": false,
   "bookmarked": true,
+  "content_revealed": false,
   "pinned": false,
   "content": "hello world! #welcome ! first post on the instance :rainbow: ! fnord",
   "reblog": null,
+  "quote": null,
+  "interaction_policy": {
+    "can_reply": [
+      "public"
+    ],
+    "can_announce": [
+      "public"
+    ],
+    "can_like": [
+      "public"
+    ]
+  },
   "application": {
     "name": "superseriousbusiness",
     "website": "https://superserious.business"
@@ -640,7 +848,8 @@ func (suite *InternalToFrontendTestSuite) TestWarnFilteredStatusToFrontend() {
         }
       },
       "description": "Black and white image of some 50's style text saying: Welcome On Board",
-      "blurhash": "LNJRdVM{00Rj%Mayt7j[4nWBofRj"
+      "blurhash": "LNJRdVM{00Rj%Mayt7j[4nWBofRj",
+      "sensitive": false
     }
   ],
   "mentions": [],
@@ -726,6 +935,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownAttachments
 	suite.Equal(`{
   "id": "01HE7XJ1CG84TBKH5V9XKBVGF5",
   "created_at": "2023-11-02T10:44:25.000Z",
+  "edited_at": null,
   "in_reply_to_id": "01F8MH75CBF9JFX4ZAD54N0W0R",
   "in_reply_to_account_id": "01F8MH17FWEB39HZJ76B6VXSKF",
   "sensitive": true,
@@ -741,9 +951,22 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownAttachments
   "reblogged": false,
   "muted": false,
   "bookmarked": false,
+  "content_revealed": false,
   "pinned": false,
   "content": "\u003cp\u003ehi \u003cspan class=\"h-card\"\u003e\u003ca href=\"http://localhost:8080/@admin\" class=\"u-url mention\" rel=\"nofollow noreferrer noopener\" target=\"_blank\"\u003e@\u003cspan\u003eadmin\u003c/span\u003e\u003c/a\u003e\u003c/span\u003e here's some media for ya\u003c/p\u003e\u003chr\u003e\u003cp\u003e\u003ci lang=\"en\"\u003eℹ️ Note from localhost:8080: 2 attachments in this status could not be downloaded. Treat the following external links with care:\u003c/i\u003e\u003c/p\u003e\u003cul\u003e\u003cli\u003e\u003ca href=\"http://example.org/fileserver/01HE7Y659ZWZ02JM4AWYJZ176Q/attachment/original/01HE7ZGJYTSYMXF927GF9353KR.svg\" rel=\"nofollow noreferrer noopener\" target=\"_blank\"\u003e01HE7ZGJYTSYMXF927GF9353KR.svg\u003c/a\u003e [SVG line art of a sloth, public domain]\u003c/li\u003e\u003cli\u003e\u003ca href=\"http://example.org/fileserver/01HE7Y659ZWZ02JM4AWYJZ176Q/attachment/original/01HE892Y8ZS68TQCNPX7J888P3.mp3\" rel=\"nofollow noreferrer noopener\" target=\"_blank\"\u003e01HE892Y8ZS68TQCNPX7J888P3.mp3\u003c/a\u003e [Jolly salsa song, public domain.]\u003c/li\u003e\u003c/ul\u003e",
   "reblog": null,
+  "quote": null,
+  "interaction_policy": {
+    "can_reply": [
+      "public"
+    ],
+    "can_announce": [
+      "public"
+    ],
+    "can_like": [
+      "public"
+    ]
+  },
   "account": {
     "id": "01FHMQX3GAABWSM0S2VZEC2SWC",
     "username": "Some_User",
@@ -794,7 +1017,36 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownAttachments
         }
       },
       "description": "Photograph of a sloth, Public Domain.",
-      "blurhash": "LNEC{|w}0K9GsEtPM|j[NFbHoeof"
+      "blurhash": "LNEC{|w}0K9GsEtPM|j[NFbHoeof",
+      "sensitive": false
+    }
+  ],
+  "unknown_media_attachments": [
+    {
+      "id": "01HE7ZFX9GKA5ZZVD4FACABSS9",
+      "type": "unknown",
+      "url": "http://localhost:8080/fileserver/01FHMQX3GAABWSM0S2VZEC2SWC/attachment/original/01HE7ZFX9GKA5ZZVD4FACABSS9.svg",
+      "text_url": "http://localhost:8080/fileserver/01FHMQX3GAABWSM0S2VZEC2SWC/attachment/original/01HE7ZFX9GKA5ZZVD4FACABSS9.svg",
+      "preview_url": "http://localhost:8080/fileserver/01FHMQX3GAABWSM0S2VZEC2SWC/attachment/small/01HE7ZFX9GKA5ZZVD4FACABSS9.jpg",
+      "remote_url": "http://example.org/fileserver/01HE7Y659ZWZ02JM4AWYJZ176Q/attachment/original/01HE7ZGJYTSYMXF927GF9353KR.svg",
+      "preview_remote_url": null,
+      "meta": null,
+      "description": "SVG line art of a sloth, public domain",
+      "blurhash": "L26*j+~qE1RP?wxut7ofRlM{R*of",
+      "sensitive": false
+    },
+    {
+      "id": "01HE88YG74PVAB81PX2XA9F3FG",
+      "type": "unknown",
+      "url": "http://localhost:8080/fileserver/01FHMQX3GAABWSM0S2VZEC2SWC/attachment/original/01HE88YG74PVAB81PX2XA9F3FG.mp3",
+      "text_url": "http://localhost:8080/fileserver/01FHMQX3GAABWSM0S2VZEC2SWC/attachment/original/01HE88YG74PVAB81PX2XA9F3FG.mp3",
+      "preview_url": "http://localhost:8080/fileserver/01FHMQX3GAABWSM0S2VZEC2SWC/attachment/small/01HE88YG74PVAB81PX2XA9F3FG.jpg",
+      "remote_url": "http://example.org/fileserver/01HE7Y659ZWZ02JM4AWYJZ176Q/attachment/original/01HE892Y8ZS68TQCNPX7J888P3.mp3",
+      "preview_remote_url": null,
+      "meta": null,
+      "description": "Jolly salsa song, public domain.",
+      "blurhash": null,
+      "sensitive": false
     }
   ],
   "mentions": [
@@ -835,6 +1087,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToWebStatus() {
 	suite.Equal(`{
   "id": "01HE7XJ1CG84TBKH5V9XKBVGF5",
   "created_at": "2023-11-02T10:44:25.000Z",
+  "edited_at": null,
   "in_reply_to_id": "01F8MH75CBF9JFX4ZAD54N0W0R",
   "in_reply_to_account_id": "01F8MH17FWEB39HZJ76B6VXSKF",
   "sensitive": true,
@@ -850,9 +1103,22 @@ func (suite *InternalToFrontendTestSuite) TestStatusToWebStatus() {
   "reblogged": false,
   "muted": false,
   "bookmarked": false,
+  "content_revealed": false,
   "pinned": false,
   "content": "\u003cp\u003ehi \u003cspan class=\"h-card\"\u003e\u003ca href=\"http://localhost:8080/@admin\" class=\"u-url mention\" rel=\"nofollow noreferrer noopener\" target=\"_blank\"\u003e@\u003cspan\u003eadmin\u003c/span\u003e\u003c/a\u003e\u003c/span\u003e here's some media for ya\u003c/p\u003e",
   "reblog": null,
+  "quote": null,
+  "interaction_policy": {
+    "can_reply": [
+      "public"
+    ],
+    "can_announce": [
+      "public"
+    ],
+    "can_like": [
+      "public"
+    ]
+  },
   "account": {
     "id": "01FHMQX3GAABWSM0S2VZEC2SWC",
     "username": "Some_User",
@@ -903,7 +1169,8 @@ func (suite *InternalToFrontendTestSuite) TestStatusToWebStatus() {
         }
       },
       "description": "Photograph of a sloth, Public Domain.",
-      "blurhash": "LNEC{|w}0K9GsEtPM|j[NFbHoeof"
+      "blurhash": "LNEC{|w}0K9GsEtPM|j[NFbHoeof",
+      "sensitive": false
     },
     {
       "id": "01HE7ZFX9GKA5ZZVD4FACABSS9",
@@ -915,7 +1182,8 @@ func (suite *InternalToFrontendTestSuite) TestStatusToWebStatus() {
       "preview_remote_url": null,
       "meta": null,
       "description": "SVG line art of a sloth, public domain",
-      "blurhash": "L26*j+~qE1RP?wxut7ofRlM{R*of"
+      "blurhash": "L26*j+~qE1RP?wxut7ofRlM{R*of",
+      "sensitive": false
     },
     {
       "id": "01HE88YG74PVAB81PX2XA9F3FG",
@@ -927,7 +1195,8 @@ func (suite *InternalToFrontendTestSuite) TestStatusToWebStatus() {
       "preview_remote_url": null,
       "meta": null,
       "description": "Jolly salsa song, public domain.",
-      "blurhash": null
+      "blurhash": null,
+      "sensitive": false
     }
   ],
   "mentions": [
@@ -959,6 +1228,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownLanguage()
 	suite.Equal(`{
   "id": "01F8MH75CBF9JFX4ZAD54N0W0R",
   "created_at": "2021-10-20T11:36:45.000Z",
+  "edited_at": null,
   "in_reply_to_id": null,
   "in_reply_to_account_id": null,
   "sensitive": false,
@@ -974,9 +1244,22 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownLanguage()
   "reblogged": false,
   "muted": false,
   "bookmarked": true,
+  "content_revealed": false,
   "pinned": false,
   "content": "hello world! #welcome ! first post on the instance :rainbow: !",
   "reblog": null,
+  "quote": null,
+  "interaction_policy": {
+    "can_reply": [
+      "public"
+    ],
+    "can_announce": [
+      "public"
+    ],
+    "can_like": [
+      "public"
+    ]
+  },
   "application": {
     "name": "superseriousbusiness",
     "website": "https://superserious.business"
@@ -1035,7 +1318,8 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownLanguage()
         }
       },
       "description": "Black and white image of some 50's style text saying: Welcome On Board",
-      "blurhash": "LNJRdVM{00Rj%Mayt7j[4nWBofRj"
+      "blurhash": "LNJRdVM{00Rj%Mayt7j[4nWBofRj",
+      "sensitive": false
     }
   ],
   "mentions": [],
@@ -1092,7 +1376,8 @@ func (suite *InternalToFrontendTestSuite) TestVideoAttachmentToFrontend() {
     }
   },
   "description": "A cow adorably licking another cow!",
-  "blurhash": null
+  "blurhash": null,
+  "sensitive": false
 }`, string(b))
 }
 
@@ -1290,12 +1575,36 @@ func (suite *InternalToFrontendTestSuite) TestInstanceV2ToFrontend() {
     },
     "emojis": {
       "emoji_size_limit": 51200
+    },
+    "vapid": {
+      "public_key": "BBDzY9cbLilM1pm9BIGKeEup9nbTJJWHOKu2Rb9Z1xceRDovyXcfivc8SP61qWRiPBdy3CwGCOmHTdYfKYPnX_A"
     }
   },
   "registrations": {
     "enabled": true,
     "approval_required": true,
-    "message": null
+    "message": null,
+    "min_age": null,
+    "usernames": {
+      "min_length": 1,
+      "max_length": 64,
+      "pattern": "",
+      "reserved": [
+        "admin",
+        "administrator",
+        "root",
+        "webmaster",
+        "hostmaster",
+        "postmaster",
+        "abuse",
+        "security",
+        "support",
+        "moderator",
+        "staff",
+        "noreply",
+        "no_reply"
+      ]
+    }
   },
   "contact": {
     "email": "admin@example.org",
@@ -1832,6 +2141,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
     {
       "id": "01FVW7JHQFSFK166WWKR8CBA6M",
       "created_at": "2021-09-20T10:40:37.000Z",
+      "edited_at": null,
       "in_reply_to_id": null,
       "in_reply_to_account_id": null,
       "sensitive": false,
@@ -1847,9 +2157,22 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
       "reblogged": false,
       "muted": false,
       "bookmarked": false,
+      "content_revealed": false,
       "pinned": false,
       "content": "dark souls status bot: \"thoughts of dog\"",
       "reblog": null,
+      "quote": null,
+      "interaction_policy": {
+        "can_reply": [
+          "public"
+        ],
+        "can_announce": [
+          "public"
+        ],
+        "can_like": [
+          "public"
+        ]
+      },
       "account": {
         "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
         "username": "foss_satan",
@@ -1900,7 +2223,8 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
             }
           },
           "description": "tweet from thoughts of dog: i drank. all the water. in my bowl. earlier. but just now. i returned. to the same bowl. and it was. full again.. the bowl. is haunted",
-          "blurhash": "LARysgM_IU_3~pD%M_Rj_39FIAt6"
+          "blurhash": "LARysgM_IU_3~pD%M_Rj_39FIAt6",
+          "sensitive": false
         }
       ],
       "mentions": [],
@@ -2236,6 +2560,122 @@ func (suite *InternalToFrontendTestSuite) TestRelationshipFollowRequested() {
 }`, string(b))
 }
 
+func (suite *InternalToFrontendTestSuite) TestStatusToGTSStatusLocalOnly() {
+	testStatus := suite.testStatuses["local_account_1_status_2"]
+
+	gtsStatus, err := suite.typeconverter.StatusToGTSStatus(context.Background(), testStatus)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	b, err := json.MarshalIndent(gtsStatus, "", "  ")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(`{
+  "id": "01F8MHAYFKS4KMXF8K5Y1C0KRN",
+  "local_only": true,
+  "interaction_policy": {
+    "can_reply": [
+      "public"
+    ],
+    "can_announce": [
+      "public"
+    ],
+    "can_like": [
+      "public"
+    ]
+  }
+}`, string(b))
+}
+
+func (suite *InternalToFrontendTestSuite) TestStatusToFrontendRemoteCounts() {
+	testStatus := &gtsmodel.Status{}
+	*testStatus = *suite.testStatuses["remote_account_1_status_1"]
+
+	// Counts reported by the origin server
+	// are used in preference to our own.
+	testStatus.RemoteFavesCount = 42
+	testStatus.RemoteBoostsCount = 7
+
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(context.Background(), testStatus, nil, statusfilter.FilterContextNone, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(42, apiStatus.FavouritesCount)
+	suite.Equal(7, apiStatus.ReblogsCount)
+}
+
+func (suite *InternalToFrontendTestSuite) TestNotificationsToAPIGroupedNotifications() {
+	var (
+		ctx           = context.Background()
+		targetAccount = suite.testAccounts["local_account_1"]
+		localAccount2 = suite.testAccounts["local_account_2"]
+		adminAccount  = suite.testAccounts["admin_account"]
+		status        = suite.testStatuses["local_account_1_status_1"]
+		groupKey      = "favourite-" + status.ID + "-475000"
+	)
+
+	// Two faves of one status, around
+	// a follow, ordered newest first.
+	notifs := []*gtsmodel.Notification{
+		{
+			ID:               "01J0Y3J4CZ8Y4PSM1S4WCY4SA3",
+			NotificationType: gtsmodel.NotificationFave,
+			TargetAccountID:  targetAccount.ID,
+			OriginAccountID:  adminAccount.ID,
+			StatusID:         status.ID,
+			GroupKey:         groupKey,
+		},
+		{
+			ID:               "01J0Y3HY6C5N8X1PJRJ1B5QPDZ",
+			NotificationType: gtsmodel.NotificationFollow,
+			TargetAccountID:  targetAccount.ID,
+			OriginAccountID:  localAccount2.ID,
+		},
+		{
+			ID:               "01J0Y3HQ5XWQ8K9GJ4WBP9G2N1",
+			NotificationType: gtsmodel.NotificationFave,
+			TargetAccountID:  targetAccount.ID,
+			OriginAccountID:  localAccount2.ID,
+			StatusID:         status.ID,
+			GroupKey:         groupKey,
+		},
+	}
+
+	results, err := suite.typeconverter.NotificationsToAPIGroupedNotifications(ctx, notifs, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if !suite.Len(results.NotificationGroups, 2) {
+		suite.FailNow("")
+	}
+
+	faves := results.NotificationGroups[0]
+	suite.Equal(groupKey, faves.GroupKey)
+	suite.Equal("favourite", faves.Type)
+	suite.Equal(2, faves.NotificationsCount)
+	suite.Equal(notifs[0].ID, faves.MostRecentNotificationID)
+	suite.Equal(notifs[0].ID, faves.PageMaxID)
+	suite.Equal(notifs[2].ID, faves.PageMinID)
+	suite.Equal([]string{adminAccount.ID, localAccount2.ID}, faves.SampleAccountIDs)
+	suite.Equal(status.ID, faves.StatusID)
+
+	follow := results.NotificationGroups[1]
+	suite.Equal("ungrouped-"+notifs[1].ID, follow.GroupKey)
+	suite.Equal("follow", follow.Type)
+	suite.Equal(1, follow.NotificationsCount)
+	suite.Equal([]string{localAccount2.ID}, follow.SampleAccountIDs)
+	suite.Empty(follow.StatusID)
+
+	// Accounts and statuses are included once each.
+	suite.Len(results.Accounts, 2)
+	suite.Len(results.Statuses, 1)
+}
+
 func TestInternalToFrontendTestSuite(t *testing.T) {
 	suite.Run(t, new(InternalToFrontendTestSuite))
 }
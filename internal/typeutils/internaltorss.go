@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/gorilla/feeds"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
@@ -31,23 +32,24 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/text"
 )
 
-const (
-	rssTitleMaxRunes       = 128
-	rssDescriptionMaxRunes = 256
-)
-
 func (c *Converter) StatusToRSSItem(ctx context.Context, s *gtsmodel.Status) (*feeds.Item, error) {
 	// see https://cyber.harvard.edu/rss/rss.html
 
-	// Title -- The title of the item.
-	// example: Venice Film Festival Tries to Quit Sinking
-	var title string
+	// Summary of the status to use in title and
+	// description. If the status has a content
+	// warning, use that instead of the content,
+	// so as not to reveal what it's hiding.
+	var summary string
 	if s.ContentWarning != "" {
-		title = trimTo(s.ContentWarning, rssTitleMaxRunes)
+		summary = s.ContentWarning
 	} else {
-		title = trimTo(s.Text, rssTitleMaxRunes)
+		summary = s.Content
 	}
 
+	// Title -- The title of the item.
+	// example: Venice Film Festival Tries to Quit Sinking
+	title := text.Summarize(summary, config.GetWebRSSTitleLength())
+
 	// Link -- The URL of the item.
 	// example: http://nytimes.com/2004/12/07FEST.html
 	link := &feeds.Link{
@@ -91,13 +93,26 @@ func (c *Converter) StatusToRSSItem(ctx context.Context, s *gtsmodel.Status) (*f
 		descriptionBuilder.WriteString("made a new post")
 	}
 
-	if s.Text != "" {
-		descriptionBuilder.WriteString(": \"")
-		descriptionBuilder.WriteString(s.Text)
-		descriptionBuilder.WriteString("\"")
+	// Mark content warnings as such in the
+	// description, where it follows the author.
+	if s.ContentWarning != "" {
+		summary = "CW: " + summary
 	}
 
-	description := trimTo(descriptionBuilder.String(), rssDescriptionMaxRunes)
+	// Fit as much of the summary as possible in
+	// the remaining length, leaving room for quotes.
+	remaining := config.GetWebRSSDescriptionLength() -
+		utf8.RuneCountInString(descriptionBuilder.String()) -
+		len(`: ""`)
+	if remaining > len("...") {
+		if quote := text.Summarize(summary, remaining); quote != "" {
+			descriptionBuilder.WriteString(": \"")
+			descriptionBuilder.WriteString(quote)
+			descriptionBuilder.WriteString("\"")
+		}
+	}
+
+	description := text.Truncate(descriptionBuilder.String(), config.GetWebRSSDescriptionLength())
 
 	// ID -- A string that uniquely identifies the item.
 	// example: http://inessential.com/2002/09/01.php#a2
@@ -166,27 +181,3 @@ func (c *Converter) StatusToRSSItem(ctx context.Context, s *gtsmodel.Status) (*f
 		Content:     content,
 	}, nil
 }
-
-// trimTo trims the given `in` string to
-// the length `to`, measured in runes.
-//
-// The reason for using runes is to avoid
-// cutting off UTF-8 characters in the
-// middle, and generating garbled bytes.
-//
-// If trimming was necessary, the returned
-// string will be suffixed with ellipsis
-// (`...`) to indicate omission.
-func trimTo(in string, to int) string {
-	var (
-		runes    = []rune(in)
-		runesLen = len(runes)
-	)
-
-	if runesLen <= to {
-		// Fine as-is.
-		return in
-	}
-
-	return string(runes[:to-3]) + "..."
-}
@@ -48,7 +48,7 @@ func (suite *InternalToRSSTestSuite) TestStatusToRSSItem1() {
 	suite.Equal("", item.Source.Type)
 	suite.Equal("", item.Author.Email)
 	suite.Equal("@the_mighty_zork@localhost:8080", item.Author.Name)
-	suite.Equal("@the_mighty_zork@localhost:8080 made a new post: \"hello everyone!\"", item.Description)
+	suite.Equal("@the_mighty_zork@localhost:8080 made a new post: \"CW: introduction post\"", item.Description)
 	suite.Equal("http://localhost:8080/@the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY", item.Id)
 	suite.EqualValues(1634726437, item.Updated.Unix())
 	suite.EqualValues(1634726437, item.Created.Unix())
@@ -111,7 +111,7 @@ func (suite *InternalToRSSTestSuite) TestStatusToRSSItem3() {
 	}
 
 	suite.Equal(`<Item>
-  <Title>这是简体中文帖子的一些示例内容。&#xA;&#xA;我希望我能读到这个，因为与无聊的旧 ASCII 相比，这些字符绝对漂亮。 不幸的是，我是一个愚蠢的西方人。&#xA;&#xA;无论如何，无论是谁读到这篇文章，你今天过得怎么样？ 希望你过得愉快！ 如果您有一段时间没有这样做，请从椅...</Title>
+  <Title>这是简体中文帖子的一些示例内容。 我希望我能读到这个，因为与无聊的旧 ASCII 相比，这些字符绝对漂亮。 不幸的是，我是一个愚蠢的西方人。 无论如何，无论是谁读到这篇文章，你今天过得怎么样？ 希望你过得愉快！...</Title>
   <Link>
     <Href>http://localhost:8080/@admin/statuses/01H7G0VW1ACBZTRHN6RSA4JWVH</Href>
     <Rel></Rel>
@@ -128,7 +128,7 @@ func (suite *InternalToRSSTestSuite) TestStatusToRSSItem3() {
     <Name>@admin@localhost:8080</Name>
     <Email></Email>
   </Author>
-  <Description>@admin@localhost:8080 made a new post</Description>
+  <Description>@admin@localhost:8080 made a new post: &#34;CW: 这是简体中文帖子的一些示例内容。 我希望我能读到这个，因为与无聊的旧 ASCII 相比，这些字符绝对漂亮。 不幸的是，我是一个愚蠢的西方人。 无论如何，无论是谁读到这篇文章，你今天过得怎么样？ 希望你过得愉快！ 如果您有一段时间没有这样做，请从椅子上站起来，喝一杯水，并将您的眼睛集中在远处的物体上，而不是电脑屏幕上！&#34;</Description>
   <Id>http://localhost:8080/@admin/statuses/01H7G0VW1ACBZTRHN6RSA4JWVH</Id>
   <Updated>0001-01-01T00:00:00Z</Updated>
   <Created>0001-01-01T00:00:00Z</Created>
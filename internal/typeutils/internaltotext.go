@@ -0,0 +1,427 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package typeutils
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// textFormat is the format
+// of a text rendition.
+type textFormat int
+
+const (
+	textFormatPlain  textFormat = iota // text/plain
+	textFormatGemini                   // text/gemini
+)
+
+// StatusToPlaintext converts a gts model status into a plaintext rendition,
+// for text-based browsers. HTML is stripped from the content, and links are
+// replaced by numbered footnotes, which are listed after the status.
+func (c *Converter) StatusToPlaintext(ctx context.Context, s *gtsmodel.Status) (string, error) {
+	return c.statusToText(ctx, s, textFormatPlain)
+}
+
+// StatusToGemtext converts a gts model status into a gemtext rendition, for
+// gemini proxies. It's like StatusToPlaintext, but as gemtext only supports
+// links on lines of their own, footnotes are listed as gemtext link lines.
+//
+// See https://geminiprotocol.net/docs/gemtext-specification.gmi
+func (c *Converter) StatusToGemtext(ctx context.Context, s *gtsmodel.Status) (string, error) {
+	return c.statusToText(ctx, s, textFormatGemini)
+}
+
+func (c *Converter) statusToText(ctx context.Context, s *gtsmodel.Status, format textFormat) (string, error) {
+	if err := c.state.DB.PopulateStatus(ctx, s); err != nil {
+		if s.Account == nil {
+			err = gtserror.Newf("error(s) populating status, cannot continue (status.Account not set): %w", err)
+			return "", err
+		}
+
+		// We can render the rest
+		// without all sub-models.
+		log.Errorf(ctx, "recoverable error(s) populating status: %v", err)
+	}
+
+	r := &textRenderer{format: format}
+
+	// Author and date header.
+	author := s.Account.DisplayName
+	if author == "" {
+		author = s.Account.Username
+	}
+
+	domain := s.Account.Domain
+	if domain == "" {
+		domain = config.GetAccountDomain()
+	}
+
+	r.heading(1, author+" (@"+s.Account.Username+"@"+domain+")")
+	r.writeLine(util.FormatISO8601(s.CreatedAt), true)
+	r.pendingBlank = true
+
+	if s.ContentWarning != "" {
+		r.line.WriteString("CW: " + s.ContentWarning)
+		r.endBlock()
+	}
+
+	doc, err := html.Parse(strings.NewReader(s.Content))
+	if err != nil {
+		return "", gtserror.Newf("error parsing content of status %s: %w", s.ID, err)
+	}
+	r.render(doc)
+	r.endBlock()
+
+	if s.Poll != nil {
+		for _, option := range s.Poll.Options {
+			r.item = "* "
+			r.line.WriteString(option)
+			r.flush()
+		}
+		r.pendingBlank = true
+	}
+
+	for _, attachment := range s.Attachments {
+		if attachment == nil {
+			continue
+		}
+
+		text := "Attachment"
+		if attachment.Description != "" {
+			text += ": " + attachment.Description
+		}
+
+		r.line.WriteString(text)
+		r.footnote(attachment.URL, text)
+		r.flush()
+	}
+	r.pendingBlank = true
+
+	r.footnotes()
+	return r.out.String(), nil
+}
+
+// textRenderer renders html into plaintext or
+// gemtext, with links replaced by footnotes.
+type textRenderer struct {
+	format textFormat
+	out    strings.Builder // rendered lines
+	line   strings.Builder // current line
+
+	// Prefix for the next line
+	// written, eg., a list marker.
+	item string
+
+	// Depth of blockquotes
+	// we're currently in.
+	quote int
+
+	// Currently in preformatted
+	// text, so keep whitespace.
+	pre bool
+
+	// Write a blank line before
+	// the next line written, to
+	// separate paragraphs.
+	pendingBlank bool
+
+	// Link hrefs and texts,
+	// for footnotes.
+	hrefs []string
+	texts []string
+}
+
+// render renders the given node and its children.
+func (r *textRenderer) render(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		r.text(n.Data)
+		return
+	case html.ElementNode:
+		// Handled below.
+	default:
+		r.children(n)
+		return
+	}
+
+	switch n.DataAtom {
+	case atom.Script, atom.Style:
+		// Never render these.
+
+	case atom.Br:
+		if r.pre {
+			r.line.WriteByte('\n')
+		} else {
+			r.flush()
+		}
+
+	case atom.A:
+		r.link(n)
+
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		r.flush()
+		r.children(n)
+		text := strings.TrimSpace(r.line.String())
+		r.line.Reset()
+		r.heading(int(n.Data[1]-'0'), text)
+		r.pendingBlank = true
+
+	case atom.Li:
+		r.flush()
+		r.item = "* "
+		r.children(n)
+		r.flush()
+
+	case atom.Blockquote:
+		r.flush()
+		r.quote++
+		r.children(n)
+		r.flush()
+		r.quote--
+		r.pendingBlank = true
+
+	case atom.Pre:
+		r.preformatted(n)
+
+	case atom.P, atom.Div, atom.Ul, atom.Ol:
+		r.flush()
+		r.children(n)
+		r.endBlock()
+
+	default:
+		r.children(n)
+	}
+}
+
+func (r *textRenderer) children(n *html.Node) {
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		r.render(child)
+	}
+}
+
+// text adds the given text to the current line,
+// collapsing whitespace like a browser would,
+// unless we're in preformatted text.
+func (r *textRenderer) text(data string) {
+	if r.pre {
+		r.line.WriteString(data)
+		return
+	}
+
+	fields := strings.Fields(data)
+	if len(fields) == 0 {
+		if data != "" {
+			r.space()
+		}
+		return
+	}
+
+	if strings.TrimLeft(data, " \t\r\n") != data {
+		r.space()
+	}
+
+	r.line.WriteString(strings.Join(fields, " "))
+
+	if strings.TrimRight(data, " \t\r\n") != data {
+		r.space()
+	}
+}
+
+// space adds a space to the current line,
+// if it's not empty and doesn't end with one.
+func (r *textRenderer) space() {
+	line := r.line.String()
+	if line != "" && !strings.HasSuffix(line, " ") {
+		r.line.WriteByte(' ')
+	}
+}
+
+// link renders the text of the given
+// link, followed by a footnote marker.
+func (r *textRenderer) link(n *html.Node) {
+	start := r.line.Len()
+	r.children(n)
+
+	var href string
+	for _, attr := range n.Attr {
+		if attr.Key == "href" {
+			href = attr.Val
+		}
+	}
+
+	if href == "" {
+		return
+	}
+
+	var text string
+	if line := r.line.String(); start <= len(line) {
+		text = strings.TrimSpace(line[start:])
+	}
+
+	if r.format == textFormatPlain && text == href {
+		// Bare link in plaintext,
+		// no need for a footnote.
+		return
+	}
+
+	r.footnote(href, text)
+}
+
+// footnote adds a footnote with the given href and text,
+// writing a footnote marker to the end of the current line.
+func (r *textRenderer) footnote(href string, text string) {
+	r.hrefs = append(r.hrefs, href)
+	r.texts = append(r.texts, text)
+	r.line.WriteString("[" + strconv.Itoa(len(r.hrefs)) + "]")
+}
+
+// footnotes writes out the
+// footnotes added so far.
+func (r *textRenderer) footnotes() {
+	for i, href := range r.hrefs {
+		num := "[" + strconv.Itoa(i+1) + "]"
+
+		if r.format == textFormatGemini {
+			line := "=> " + href + " " + num
+			if text := r.texts[i]; text != "" {
+				line += " " + text
+			}
+			r.writeLine(line, false)
+			continue
+		}
+
+		r.writeLine(num+": "+href, false)
+	}
+}
+
+// heading writes out the given heading text.
+func (r *textRenderer) heading(level int, text string) {
+	if text == "" {
+		return
+	}
+
+	if r.format == textFormatGemini {
+		// Gemtext only has
+		// three heading levels.
+		level = min(level, 3)
+		r.writeLine(strings.Repeat("#", level)+" "+text, false)
+		return
+	}
+
+	r.writeLine(text, true)
+}
+
+// preformatted renders the given
+// preformatted node, keeping whitespace.
+func (r *textRenderer) preformatted(n *html.Node) {
+	r.flush()
+
+	if r.format == textFormatGemini {
+		r.writeLine("```", false)
+	}
+
+	r.pre = true
+	r.children(n)
+	r.pre = false
+
+	text := strings.Trim(r.line.String(), "\n")
+	r.line.Reset()
+
+	for _, line := range strings.Split(text, "\n") {
+		r.writeLine(line, false)
+	}
+
+	if r.format == textFormatGemini {
+		r.writeLine("```", false)
+	}
+
+	r.pendingBlank = true
+}
+
+// endBlock writes out the current line,
+// and separates it from the next one.
+func (r *textRenderer) endBlock() {
+	r.flush()
+	r.pendingBlank = true
+}
+
+// flush writes out the current line, if any.
+func (r *textRenderer) flush() {
+	text := strings.TrimSpace(r.line.String())
+	r.line.Reset()
+
+	if text != "" {
+		r.writeLine(text, true)
+	}
+}
+
+// writeLine writes the given line out, with
+// quote or list item prefix. If escape is true,
+// the line is escaped so that it can't be taken
+// for gemtext markup, eg., a #hashtag at the
+// start of the line being taken for a heading.
+func (r *textRenderer) writeLine(text string, escape bool) {
+	if r.pendingBlank && r.out.Len() != 0 {
+		r.out.WriteByte('\n')
+	}
+	r.pendingBlank = false
+
+	var prefix string
+	if r.quote > 0 {
+		prefix = "> "
+	}
+
+	if r.item != "" {
+		prefix += r.item
+		r.item = ""
+	}
+
+	if escape && prefix == "" &&
+		r.format == textFormatGemini &&
+		isGemtextMarkup(text) {
+		// Leading whitespace
+		// makes it a text line.
+		prefix = " "
+	}
+
+	r.out.WriteString(prefix)
+	r.out.WriteString(text)
+	r.out.WriteByte('\n')
+}
+
+// isGemtextMarkup returns whether the given
+// line would be taken for a gemtext link,
+// heading, list item, quote or pre toggle.
+func isGemtextMarkup(line string) bool {
+	for _, markup := range []string{"=>", "#", "* ", ">", "```"} {
+		if strings.HasPrefix(line, markup) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package typeutils_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type InternalToTextTestSuite struct {
+	TypeUtilsTestSuite
+}
+
+func (suite *InternalToTextTestSuite) richStatus() *gtsmodel.Status {
+	s := new(gtsmodel.Status)
+	*s = *suite.testStatuses["local_account_1_status_1"]
+	s.ContentWarning = "long post"
+	s.Content = `<p>hello <span class="h-card"><a href="http://localhost:8080/@admin" class="u-url mention">@<span>admin</span></a></span>, see <a href="https://example.org/some/page" rel="nofollow noreferrer noopener" target="_blank">this   page</a>!<br>and https://example.org</p>` +
+		`<p><a href="http://localhost:8080/tags/welcome" class="mention hashtag" rel="tag">#<span>welcome</span></a> to the fedi</p>` +
+		`<blockquote><p>a quote</p></blockquote>` +
+		`<ul><li>one</li><li>two</li></ul>` +
+		`<pre><code>func main() {
+	fmt.Println("hi")
+}</code></pre>`
+	return s
+}
+
+func (suite *InternalToTextTestSuite) TestStatusToPlaintext() {
+	text, err := suite.typeconverter.StatusToPlaintext(context.Background(), suite.richStatus())
+	suite.NoError(err)
+	suite.Equal(`original zork (he/they) (@the_mighty_zork@localhost:8080)
+2021-10-20T10:40:37.000Z
+
+CW: long post
+
+hello @admin[1], see this page[2]!
+and https://example.org
+
+#welcome[3] to the fedi
+
+> a quote
+
+* one
+* two
+
+func main() {
+	fmt.Println("hi")
+}
+
+[1]: http://localhost:8080/@admin
+[2]: https://example.org/some/page
+[3]: http://localhost:8080/tags/welcome
+`, text)
+}
+
+func (suite *InternalToTextTestSuite) TestStatusToGemtext() {
+	text, err := suite.typeconverter.StatusToGemtext(context.Background(), suite.richStatus())
+	suite.NoError(err)
+	suite.Equal(`# original zork (he/they) (@the_mighty_zork@localhost:8080)
+2021-10-20T10:40:37.000Z
+
+CW: long post
+
+hello @admin[1], see this page[2]!
+and https://example.org
+
+ #welcome[3] to the fedi
+
+> a quote
+
+* one
+* two
+
+`+"```"+`
+func main() {
+	fmt.Println("hi")
+}
+`+"```"+`
+
+=> http://localhost:8080/@admin [1] @admin
+=> https://example.org/some/page [2] this page
+=> http://localhost:8080/tags/welcome [3] #welcome
+`, text)
+}
+
+func TestInternalToTextTestSuite(t *testing.T) {
+	suite.Run(t, new(InternalToTextTestSuite))
+}
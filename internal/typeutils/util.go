@@ -19,6 +19,7 @@ package typeutils
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"path"
@@ -28,6 +29,8 @@ import (
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/language"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -41,6 +44,7 @@ type statusInteractions struct {
 	Bookmarked bool
 	Reblogged  bool
 	Pinned     bool
+	Revealed   bool
 }
 
 func (c *Converter) interactionsWithStatusForAccount(ctx context.Context, s *gtsmodel.Status, requestingAccount *gtsmodel.Account) (*statusInteractions, error) {
@@ -71,6 +75,16 @@ func (c *Converter) interactionsWithStatusForAccount(ctx context.Context, s *gts
 		}
 		si.Bookmarked = bookmarked
 
+		// Only check content reveals for statuses
+		// that actually have anything to reveal.
+		if s.ContentWarning != "" || (s.Sensitive != nil && *s.Sensitive) {
+			revealed, err := c.state.DB.IsContentRevealed(ctx, requestingAccount.ID, s.AccountID, s.ThreadID)
+			if err != nil {
+				return nil, fmt.Errorf("error checking if requesting account has revealed status: %s", err)
+			}
+			si.Revealed = revealed
+		}
+
 		// The only time 'pinned' should be true is if the
 		// requesting account is looking at its OWN status.
 		if s.AccountID == requestingAccount.ID {
@@ -80,6 +94,27 @@ func (c *Converter) interactionsWithStatusForAccount(ctx context.Context, s *gts
 	return si, nil
 }
 
+// movedUsernameURIs returns the old actor URIs that the given
+// local account moved away from by changing its username.
+func (c *Converter) movedUsernameURIs(ctx context.Context, a *gtsmodel.Account) ([]string, error) {
+	changes, err := c.state.DB.GetUsernameChangesByAccountID(ctx, a.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting username changes: %w", err)
+	}
+
+	var oldURIs []string
+	for _, change := range changes {
+		if change.Strategy != gtsmodel.UsernameChangeStrategyMove ||
+			change.OldURI == a.URI ||
+			slices.Contains(a.AlsoKnownAsURIs, change.OldURI) {
+			continue
+		}
+		oldURIs = append(oldURIs, change.OldURI)
+	}
+
+	return oldURIs, nil
+}
+
 func misskeyReportInlineURLs(content string) []*url.URL {
 	m := regexes.MisskeyReportNotes.FindAllStringSubmatch(content, -1)
 	urls := make([]*url.URL, 0, len(m))
@@ -92,16 +127,65 @@ func misskeyReportInlineURLs(content string) []*url.URL {
 	return urls
 }
 
+const (
+	// Average silent reading speed of
+	// adults, used to estimate reading time.
+	readingWordsPerMinute = 200
+
+	// Don't estimate reading time for statuses
+	// shorter than this, as it's just noise on
+	// the "normal" short posts most people make.
+	readingTimeMinWords = 500
+)
+
+// blockBoundaries replaces the ends of html block elements
+// with spaces, so that stripping html from content doesn't
+// glue together the last and first words of adjacent blocks.
+var blockBoundaries = strings.NewReplacer(
+	"</p>", " ",
+	"<br>", " ",
+	"<br/>", " ",
+	"<br />", " ",
+	"</li>", " ",
+	"</pre>", " ",
+	"</blockquote>", " ",
+)
+
+// statusWordCount returns the number of words in the
+// given content warning and html content of a status,
+// after stripping html from the content.
+func statusWordCount(spoilerText string, content string) int {
+	content = text.SanitizeToPlaintext(blockBoundaries.Replace(content))
+	return len(strings.Fields(spoilerText)) + len(strings.Fields(content))
+}
+
+// statusReadingTime returns the estimated reading time in
+// whole minutes of a status with the given word count,
+// rounding up, or 0 if the status is too short to bother.
+func statusReadingTime(wordCount int) int {
+	if wordCount < readingTimeMinWords {
+		return 0
+	}
+	return (wordCount + readingWordsPerMinute - 1) / readingWordsPerMinute
+}
+
 // placeholdUnknownAttachments separates any attachments with type `unknown`
-// out of the given slice, and returns a piece of text containing links to
-// those attachments, as well as the slice of remaining "known" attachments.
-// If there are no unknown-type attachments in the provided slice, an empty
-// string and the original slice will be returned.
+// out of the given slice, and returns a piece of text to append to status
+// content as a placeholder for them, as well as the slice of remaining "known"
+// attachments, and the slice of unknown attachments. If there are no unknown-
+// type attachments in the provided slice, an empty string, the original slice,
+// and nil will be returned.
+//
+// Unknown-type attachments without a remote URL are local attachments that
+// have expired; these can't be linked to, since their files no longer exist.
 //
-// Returned text will be run through the sanitizer before being returned, to
-// ensure that malicious links don't cause issues.
+// The returned text depends on the configured media-unknown-placeholder:
+// a note with a list of links to the attachments ("note"), just the list of
+// links ("links"), or nothing at all ("none"). Returned text will be run
+// through the sanitizer before being returned, to ensure that malicious links
+// don't cause issues.
 //
-// Example:
+// Example of "note":
 //
 //	<hr>
 //	<p><i lang="en">ℹ️ Note from your.instance.com: 2 attachments in this status could not be downloaded. Treat the following external links with care:</i></p>
@@ -109,7 +193,16 @@ func misskeyReportInlineURLs(content string) []*url.URL {
 //	   <li><a href="http://example.org/fileserver/01HE7Y659ZWZ02JM4AWYJZ176Q/attachment/original/01HE7ZGJYTSYMXF927GF9353KR.svg" rel="nofollow noreferrer noopener" target="_blank">01HE7ZGJYTSYMXF927GF9353KR.svg</a> [SVG line art of a sloth, public domain]</li>
 //	   <li><a href="http://example.org/fileserver/01HE7Y659ZWZ02JM4AWYJZ176Q/attachment/original/01HE892Y8ZS68TQCNPX7J888P3.mp3" rel="nofollow noreferrer noopener" target="_blank">01HE892Y8ZS68TQCNPX7J888P3.mp3</a> [Jolly salsa song, public domain.]</li>
 //	</ul>
-func placeholdUnknownAttachments(arr []*apimodel.Attachment) (string, []*apimodel.Attachment) {
+//	<p><i lang="en">ℹ️ Note from your.instance.com: 1 attachment in this status has expired and is no longer available.</i></p>
+//
+// Example of "links":
+//
+//	<hr>
+//	<ul>
+//	   <li><a href="http://example.org/fileserver/01HE7Y659ZWZ02JM4AWYJZ176Q/attachment/original/01HE7ZGJYTSYMXF927GF9353KR.svg" rel="nofollow noreferrer noopener" target="_blank">01HE7ZGJYTSYMXF927GF9353KR.svg</a> [SVG line art of a sloth, public domain]</li>
+//	   <li><a href="http://example.org/fileserver/01HE7Y659ZWZ02JM4AWYJZ176Q/attachment/original/01HE892Y8ZS68TQCNPX7J888P3.mp3" rel="nofollow noreferrer noopener" target="_blank">01HE892Y8ZS68TQCNPX7J888P3.mp3</a> [Jolly salsa song, public domain.]</li>
+//	</ul>
+func placeholdUnknownAttachments(arr []*apimodel.Attachment) (string, []*apimodel.Attachment, []*apimodel.Attachment) {
 	// Extract unknown-type attachments into a separate
 	// slice, deleting them from arr in the process.
 	var unknowns []*apimodel.Attachment
@@ -123,34 +216,92 @@ func placeholdUnknownAttachments(arr []*apimodel.Attachment) (string, []*apimode
 		return unknown
 	})
 
-	unknownsLen := len(unknowns)
-	if unknownsLen == 0 {
+	if len(unknowns) == 0 {
 		// No unknown attachments,
 		// nothing to do.
-		return "", arr
+		return "", arr, nil
 	}
 
-	// Plural / singular.
-	var (
-		attachments string
-		links       string
-	)
-
-	if unknownsLen == 1 {
-		attachments = "1 attachment"
-		links = "link"
-	} else {
-		attachments = strconv.Itoa(unknownsLen) + " attachments"
-		links = "links"
+	// Split unknowns into those we can
+	// still link to, and expired ones.
+	var linkable []*apimodel.Attachment
+	for _, a := range unknowns {
+		if a.RemoteURL != nil {
+			linkable = append(linkable, a)
+		}
 	}
+	expiredLen := len(unknowns) - len(linkable)
 
 	var note strings.Builder
-	note.WriteString(`<hr>`)
-	note.WriteString(`<p><i lang="en">`)
-	note.WriteString(`ℹ️ Note from ` + config.GetHost() + `: ` + attachments + ` in this status could not be downloaded. Treat the following external ` + links + ` with care:`)
-	note.WriteString(`</i></p>`)
+	switch config.GetMediaUnknownPlaceholder() {
+
+	case config.MediaUnknownPlaceholderNone:
+		// Leave content alone.
+		return "", arr, unknowns
+
+	case config.MediaUnknownPlaceholderLinks:
+		if len(linkable) == 0 {
+			// Nothing to link to.
+			return "", arr, unknowns
+		}
+
+		note.WriteString(`<hr>`)
+		writeAttachmentLinks(&note, linkable)
+
+	default: // config.MediaUnknownPlaceholderNote
+		note.WriteString(`<hr>`)
+
+		if linkableLen := len(linkable); linkableLen != 0 {
+			// Plural / singular.
+			var (
+				attachments string
+				links       string
+			)
+
+			if linkableLen == 1 {
+				attachments = "1 attachment"
+				links = "link"
+			} else {
+				attachments = strconv.Itoa(linkableLen) + " attachments"
+				links = "links"
+			}
+
+			note.WriteString(`<p><i lang="en">`)
+			note.WriteString(`ℹ️ Note from ` + config.GetHost() + `: ` + attachments + ` in this status could not be downloaded. Treat the following external ` + links + ` with care:`)
+			note.WriteString(`</i></p>`)
+			writeAttachmentLinks(&note, linkable)
+		}
+
+		if expiredLen != 0 {
+			// Plural / singular.
+			var (
+				attachments string
+				hasIs       string
+			)
+
+			if expiredLen == 1 {
+				attachments = "1 attachment"
+				hasIs = "has expired and is"
+			} else {
+				attachments = strconv.Itoa(expiredLen) + " attachments"
+				hasIs = "have expired and are"
+			}
+
+			note.WriteString(`<p><i lang="en">`)
+			note.WriteString(`ℹ️ Note from ` + config.GetHost() + `: ` + attachments + ` in this status ` + hasIs + ` no longer available.`)
+			note.WriteString(`</i></p>`)
+		}
+	}
+
+	return text.SanitizeToHTML(note.String()), arr, unknowns
+}
+
+// writeAttachmentLinks writes an HTML list of links to the
+// remote URLs of the given attachments to the given builder,
+// with each attachment's description (if any) after its link.
+func writeAttachmentLinks(note *strings.Builder, attachments []*apimodel.Attachment) {
 	note.WriteString(`<ul>`)
-	for _, a := range unknowns {
+	for _, a := range attachments {
 		var (
 			remoteURL = *a.RemoteURL
 			base      = path.Base(remoteURL)
@@ -162,8 +313,6 @@ func placeholdUnknownAttachments(arr []*apimodel.Attachment) (string, []*apimode
 		note.WriteString(`<li>` + entry + `</li>`)
 	}
 	note.WriteString(`</ul>`)
-
-	return text.SanitizeToHTML(note.String()), arr
 }
 
 // ContentToContentLanguage tries to
@@ -19,8 +19,10 @@ package typeutils
 
 import (
 	"context"
+	"strings"
 	"testing"
 
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/language"
@@ -50,6 +52,99 @@ misskey-formatted`
 	}
 }
 
+func TestStatusWordCount(t *testing.T) {
+	words := statusWordCount(
+		"long post",
+		`<p>hello <a href="https://example.org">world</a>,</p><p>how are you?</p>`,
+	)
+	if words != 7 {
+		t.Fatalf("wanted 7 words, got %d", words)
+	}
+}
+
+func TestStatusReadingTime(t *testing.T) {
+	for _, test := range []struct {
+		words   int
+		minutes int
+	}{
+		{0, 0},
+		{499, 0},
+		{500, 3},
+		{600, 3},
+		{601, 4},
+	} {
+		if minutes := statusReadingTime(test.words); minutes != test.minutes {
+			t.Errorf("%d words: wanted %d minutes, got %d", test.words, test.minutes, minutes)
+		}
+	}
+}
+
+func TestPlaceholdUnknownAttachments(t *testing.T) {
+	config.SetHost("localhost:8080")
+	defer config.SetMediaUnknownPlaceholder("")
+
+	remoteURL := "http://example.org/fileserver/01HE7Y659ZWZ02JM4AWYJZ176Q/attachment/original/01HE7ZGJYTSYMXF927GF9353KR.svg"
+	for _, test := range []struct {
+		placeholder string
+		contains    []string
+		excludes    []string
+	}{
+		{
+			placeholder: config.MediaUnknownPlaceholderNote,
+			contains: []string{
+				"1 attachment in this status could not be downloaded",
+				">01HE7ZGJYTSYMXF927GF9353KR.svg</a>",
+				"1 attachment in this status has expired and is no longer available.",
+			},
+		},
+		{
+			placeholder: config.MediaUnknownPlaceholderLinks,
+			contains: []string{
+				">01HE7ZGJYTSYMXF927GF9353KR.svg</a>",
+			},
+			excludes: []string{
+				"Note from",
+			},
+		},
+		{
+			placeholder: config.MediaUnknownPlaceholderNone,
+		},
+	} {
+		config.SetMediaUnknownPlaceholder(test.placeholder)
+
+		arr := []*apimodel.Attachment{
+			{ID: "01J2PZ0K7NBZ4J8M9V0DKRVR4A", Type: "image"},
+			{ID: "01J2PZ0RM5Q3W7TQJPV7HBB1ZX", Type: "unknown"},
+			{ID: "01J2PZ0XG3A6F8SN8RE0X5QSDM", Type: "unknown", RemoteURL: &remoteURL},
+		}
+
+		note, known, unknown := placeholdUnknownAttachments(arr)
+		if len(known) != 1 || known[0].ID != "01J2PZ0K7NBZ4J8M9V0DKRVR4A" {
+			t.Errorf("%s: expected only the image attachment to remain, got %d attachments", test.placeholder, len(known))
+		}
+
+		if len(unknown) != 2 {
+			t.Errorf("%s: expected 2 unknown attachments, got %d", test.placeholder, len(unknown))
+		}
+
+		if len(test.contains) == 0 && note != "" {
+			t.Errorf("%s: expected no note, got %s", test.placeholder, note)
+		}
+
+		for _, c := range test.contains {
+			if !strings.Contains(note, c) {
+				t.Errorf("%s: expected note to contain %q, got %s", test.placeholder, c, note)
+			}
+		}
+
+		for _, e := range test.excludes {
+			if strings.Contains(note, e) {
+				t.Errorf("%s: expected note not to contain %q, got %s", test.placeholder, e, note)
+			}
+		}
+	}
+}
+
 func TestContentToContentLanguage(t *testing.T) {
 	type testcase struct {
 		content           gtsmodel.Content
@@ -89,6 +89,11 @@ func (suite *WrapTestSuite) TestWrapNoteInCreate() {
       "en": "hello everyone!"
     },
     "id": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY",
+    "likes": {
+      "id": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY/likes",
+      "totalItems": 1,
+      "type": "Collection"
+    },
     "published": "2021-10-20T12:40:37+02:00",
     "replies": {
       "first": {
@@ -101,6 +106,11 @@ func (suite *WrapTestSuite) TestWrapNoteInCreate() {
       "type": "Collection"
     },
     "sensitive": true,
+    "shares": {
+      "id": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY/shares",
+      "totalItems": 1,
+      "type": "Collection"
+    },
     "summary": "introduction post",
     "tag": [],
     "to": "https://www.w3.org/ns/activitystreams#Public",
@@ -0,0 +1,62 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package uris
+
+import "strings"
+
+// Normalize returns the given URI string with its scheme and
+// host lowercased, and any port that is the default for the
+// scheme (443 for https, 80 for http) removed, so that URIs
+// that only differ in these ways compare as equal.
+//
+// Everything after the host (path, query, fragment) is left
+// untouched, as remotes are free to treat those as case
+// sensitive. Strings that aren't absolute URIs are returned
+// as-is.
+func Normalize(uri string) string {
+	scheme, rest, ok := strings.Cut(uri, "://")
+	if !ok || scheme == "" || strings.ContainsAny(scheme, "/?#") {
+		// Not an absolute URI.
+		return uri
+	}
+
+	// Split the authority off from path / query / fragment.
+	end := strings.IndexAny(rest, "/?#")
+	if end == -1 {
+		end = len(rest)
+	}
+	authority, tail := rest[:end], rest[end:]
+
+	// Leave any userinfo untouched.
+	var userinfo string
+	if i := strings.LastIndexByte(authority, '@'); i != -1 {
+		userinfo, authority = authority[:i+1], authority[i+1:]
+	}
+
+	scheme = strings.ToLower(scheme)
+	host := strings.ToLower(authority)
+
+	switch scheme {
+	case "https":
+		host = strings.TrimSuffix(host, ":443")
+	case "http":
+		host = strings.TrimSuffix(host, ":80")
+	}
+
+	return scheme + "://" + userinfo + host + tail
+}
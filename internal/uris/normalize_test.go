@@ -0,0 +1,54 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package uris_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+type NormalizeTestSuite struct {
+	suite.Suite
+}
+
+func (suite *NormalizeTestSuite) TestNormalize() {
+	for _, test := range []struct {
+		in, expect string
+	}{
+		{in: "https://example.org/users/someone", expect: "https://example.org/users/someone"},
+		{in: "https://Example.ORG/users/SomeOne", expect: "https://example.org/users/SomeOne"},
+		{in: "HTTPS://example.org:443/users/someone", expect: "https://example.org/users/someone"},
+		{in: "http://example.org:80/users/someone", expect: "http://example.org/users/someone"},
+		{in: "https://example.org:80/users/someone", expect: "https://example.org:80/users/someone"},
+		{in: "https://example.org:8443/users/someone", expect: "https://example.org:8443/users/someone"},
+		{in: "https://EXAMPLE.org:443", expect: "https://example.org"},
+		{in: "https://example.org:443?q=Thing#Frag", expect: "https://example.org?q=Thing#Frag"},
+		{in: "https://[::1]:443/objects/ABC", expect: "https://[::1]/objects/ABC"},
+		{in: "https://User@Example.org/a", expect: "https://User@example.org/a"},
+		{in: "acct:someone@Example.org", expect: "acct:someone@Example.org"},
+		{in: "", expect: ""},
+	} {
+		suite.Equal(test.expect, uris.Normalize(test.in), test.in)
+	}
+}
+
+func TestNormalizeTestSuite(t *testing.T) {
+	suite.Run(t, new(NormalizeTestSuite))
+}
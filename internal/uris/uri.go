@@ -27,25 +27,26 @@ import (
 )
 
 const (
-	UsersPath        = "users"         // UsersPath is for serving users info
-	StatusesPath     = "statuses"      // StatusesPath is for serving statuses
-	InboxPath        = "inbox"         // InboxPath represents the activitypub inbox location
-	OutboxPath       = "outbox"        // OutboxPath represents the activitypub outbox location
-	FollowersPath    = "followers"     // FollowersPath represents the activitypub followers location
-	FollowingPath    = "following"     // FollowingPath represents the activitypub following location
-	LikedPath        = "liked"         // LikedPath represents the activitypub liked location
-	CollectionsPath  = "collections"   // CollectionsPath represents the activitypub collections location
-	FeaturedPath     = "featured"      // FeaturedPath represents the activitypub featured location
-	PublicKeyPath    = "main-key"      // PublicKeyPath is for serving an account's public key
-	FollowPath       = "follow"        // FollowPath used to generate the URI for an individual follow or follow request
-	UpdatePath       = "updates"       // UpdatePath is used to generate the URI for an account update
-	BlocksPath       = "blocks"        // BlocksPath is used to generate the URI for a block
-	MovesPath        = "moves"         // MovesPath is used to generate the URI for a move
-	ReportsPath      = "reports"       // ReportsPath is used to generate the URI for a report/flag
-	ConfirmEmailPath = "confirm_email" // ConfirmEmailPath is used to generate the URI for an email confirmation link
-	FileserverPath   = "fileserver"    // FileserverPath is a path component for serving attachments + media
-	EmojiPath        = "emoji"         // EmojiPath represents the activitypub emoji location
-	TagsPath         = "tags"          // TagsPath represents the activitypub tags location
+	UsersPath         = "users"          // UsersPath is for serving users info
+	StatusesPath      = "statuses"       // StatusesPath is for serving statuses
+	InboxPath         = "inbox"          // InboxPath represents the activitypub inbox location
+	OutboxPath        = "outbox"         // OutboxPath represents the activitypub outbox location
+	FollowersPath     = "followers"      // FollowersPath represents the activitypub followers location
+	FollowingPath     = "following"      // FollowingPath represents the activitypub following location
+	LikedPath         = "liked"          // LikedPath represents the activitypub liked location
+	CollectionsPath   = "collections"    // CollectionsPath represents the activitypub collections location
+	FeaturedPath      = "featured"       // FeaturedPath represents the activitypub featured location
+	PublicKeyPath     = "main-key"       // PublicKeyPath is for serving an account's public key
+	FollowPath        = "follow"         // FollowPath used to generate the URI for an individual follow or follow request
+	UpdatePath        = "updates"        // UpdatePath is used to generate the URI for an account update
+	BlocksPath        = "blocks"         // BlocksPath is used to generate the URI for a block
+	MovesPath         = "moves"          // MovesPath is used to generate the URI for a move
+	ReportsPath       = "reports"        // ReportsPath is used to generate the URI for a report/flag
+	ConfirmEmailPath  = "confirm_email"  // ConfirmEmailPath is used to generate the URI for an email confirmation link
+	ResetPasswordPath = "reset_password" // ResetPasswordPath is used to generate the URI for a password reset link
+	FileserverPath    = "fileserver"     // FileserverPath is a path component for serving attachments + media
+	EmojiPath         = "emoji"          // EmojiPath represents the activitypub emoji location
+	TagsPath          = "tags"           // TagsPath represents the activitypub tags location
 )
 
 // UserURIs contains a bunch of UserURIs and URLs for a user, host, account, etc.
@@ -136,6 +137,14 @@ func GenerateURIForEmailConfirm(token string) string {
 	return fmt.Sprintf("%s://%s/%s?token=%s", protocol, host, ConfirmEmailPath, token)
 }
 
+// GenerateURIForPasswordReset returns a link for password reset -- something like:
+// https://example.org/reset_password?token=490e337c-0162-454f-ac48-4b22bb92a205
+func GenerateURIForPasswordReset(token string) string {
+	protocol := config.GetProtocol()
+	host := config.GetHost()
+	return fmt.Sprintf("%s://%s/%s?token=%s", protocol, host, ResetPasswordPath, token)
+}
+
 // GenerateURIsForAccount throws together a bunch of URIs for the given username, with the given protocol and host.
 func GenerateURIsForAccount(username string) *UserURIs {
 	protocol := config.GetProtocol()
@@ -302,6 +311,11 @@ func IsStatusesPath(id *url.URL) bool {
 	return regexes.StatusesPath.MatchString(id.Path)
 }
 
+// IsStatusesWebPath returns true if the given URL path corresponds to eg /@example_username/statuses/SOME_ULID_OF_A_STATUS
+func IsStatusesWebPath(id *url.URL) bool {
+	return regexes.StatusesWebPath.MatchString(id.Path)
+}
+
 // IsPublicKeyPath returns true if the given URL path corresponds to eg /users/example_username/main-key
 func IsPublicKeyPath(id *url.URL) bool {
 	return regexes.PublicKeyPath.MatchString(id.Path)
@@ -329,6 +343,18 @@ func ParseStatusesPath(id *url.URL) (username string, ulid string, err error) {
 	return
 }
 
+// ParseStatusesWebPath returns the username and ulid from a path such as /@example_username/statuses/SOME_ULID_OF_A_STATUS
+func ParseStatusesWebPath(id *url.URL) (username string, ulid string, err error) {
+	matches := regexes.StatusesWebPath.FindStringSubmatch(id.Path)
+	if len(matches) != 3 {
+		err = fmt.Errorf("expected 3 matches but matches length was %d", len(matches))
+		return
+	}
+	username = matches[1]
+	ulid = matches[2]
+	return
+}
+
 // ParseUserPath returns the username from a path such as /users/example_username
 func ParseUserPath(id *url.URL) (username string, err error) {
 	matches := regexes.UserPath.FindStringSubmatch(id.Path)
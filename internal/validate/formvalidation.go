@@ -21,30 +21,37 @@ import (
 	"errors"
 	"fmt"
 	"net/mail"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/regexes"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	pwv "github.com/wagslane/go-password-validator"
 	"golang.org/x/text/language"
 )
 
 const (
-	maximumPasswordLength         = 72 // 72 bytes is the maximum length afforded by bcrypt. See https://pkg.go.dev/golang.org/x/crypto/bcrypt#GenerateFromPassword.
-	minimumPasswordEntropy        = 60 // Heuristic for password strength. See https://github.com/wagslane/go-password-validator.
-	minimumReasonLength           = 40
-	maximumReasonLength           = 500
-	maximumSiteTitleLength        = 40
-	maximumShortDescriptionLength = 500
-	maximumDescriptionLength      = 5000
-	maximumSiteTermsLength        = 5000
-	maximumUsernameLength         = 64
-	maximumEmojiCategoryLength    = 64
-	maximumProfileFieldLength     = 255
-	maximumProfileFields          = 6
-	maximumListTitleLength        = 200
-	maximumFilterKeywordLength    = 40
+	maximumPasswordLength          = 72 // 72 bytes is the maximum length afforded by bcrypt. See https://pkg.go.dev/golang.org/x/crypto/bcrypt#GenerateFromPassword.
+	minimumPasswordEntropy         = 60 // Heuristic for password strength. See https://github.com/wagslane/go-password-validator.
+	minimumReasonLength            = 40
+	maximumReasonLength            = 500
+	maximumSiteTitleLength         = 40
+	maximumShortDescriptionLength  = 500
+	maximumDescriptionLength       = 5000
+	maximumSiteTermsLength         = 5000
+	maximumUsernameLength          = 64
+	maximumEmojiCategoryLength     = 64
+	maximumProfileFieldLength      = 255
+	maximumProfileFields           = 6
+	maximumListTitleLength         = 200
+	maximumFilterKeywordLength     = 40
+	maximumFollowRequestNoteLength = 500
+	maximumAttributionDomains      = 20
 )
 
 // Password returns a helpful error if the given password
@@ -89,6 +96,45 @@ func Username(username string) error {
 	return nil
 }
 
+// NewUsername makes sure that a given username is valid (see Username),
+// and that it's allowed for a new account by the instance's username
+// policy (length, pattern, and reserved usernames). Returns an error if not.
+func NewUsername(username string) error {
+	if err := Username(username); err != nil {
+		return err
+	}
+
+	if minLen := config.GetAccountsUsernameMinLength(); len(username) < minLen {
+		return fmt.Errorf("given username %s was invalid: must be at least %d characters", username, minLen)
+	}
+
+	if maxLen := config.GetAccountsUsernameMaxLength(); len(username) > maxLen {
+		return fmt.Errorf("given username %s was invalid: must be at most %d characters", username, maxLen)
+	}
+
+	if pattern := config.GetAccountsUsernamePattern(); pattern != "" {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("error compiling %s: %w", config.AccountsUsernamePatternFlag(), err)
+		}
+
+		if !re.MatchString(username) {
+			return fmt.Errorf("given username %s was invalid: not allowed on this instance", username)
+		}
+	}
+
+	if slices.ContainsFunc(
+		config.GetAccountsReservedUsernames(),
+		func(reserved string) bool {
+			return strings.EqualFold(reserved, username)
+		},
+	) {
+		return fmt.Errorf("username %s is reserved", username)
+	}
+
+	return nil
+}
+
 // Email makes sure that a given email address is a valid address.
 // Returns an error if not.
 func Email(email string) error {
@@ -151,6 +197,15 @@ func Note(note string) error {
 	return nil
 }
 
+// FollowRequestNote checks that a message
+// attached to a follow request is not too long.
+func FollowRequestNote(note string) error {
+	if length := len([]rune(note)); length > maximumFollowRequestNoteLength {
+		return fmt.Errorf("follow request note should be no more than %d chars but given note was %d", maximumFollowRequestNoteLength, length)
+	}
+	return nil
+}
+
 // Privacy checks that the desired privacy setting is valid
 func Privacy(privacy string) error {
 	if privacy == "" {
@@ -271,6 +326,73 @@ func ProfileFields(fields []*gtsmodel.Field) error {
 	return nil
 }
 
+// AttributionDomains checks that the given attribution domains are
+// valid domain names, and not too many, and returns them normalized:
+// lowercase, punycoded, and deduplicated. Each entry may hold several
+// domains separated by whitespace or commas, eg., one per line of a
+// textarea; empty entries are dropped.
+func AttributionDomains(entries []string) ([]string, error) {
+	var domains []string
+	for _, entry := range entries {
+		domains = append(domains, strings.FieldsFunc(entry, func(r rune) bool {
+			return r == ',' || unicode.IsSpace(r)
+		})...)
+	}
+
+	normalized := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		puny, err := util.Punify(domain)
+		if err != nil {
+			return nil, fmt.Errorf("attribution domain %s could not be punified: %w", domain, err)
+		}
+
+		if !isDomainName(puny) {
+			return nil, fmt.Errorf("attribution domain %s is not a valid domain name", domain)
+		}
+
+		if !slices.Contains(normalized, puny) {
+			normalized = append(normalized, puny)
+		}
+	}
+
+	if len(normalized) > maximumAttributionDomains {
+		return nil, fmt.Errorf("too many attribution domains; maximum is %d", maximumAttributionDomains)
+	}
+
+	return normalized, nil
+}
+
+// isDomainName returns whether the given (punycoded)
+// string is a domain name with at least two labels,
+// each made of letters, digits and inner hyphens.
+func isDomainName(domain string) bool {
+	if len(domain) > 253 {
+		return false
+	}
+
+	labels := strings.Split(domain, ".")
+	if len(labels) < 2 {
+		return false
+	}
+
+	for _, label := range labels {
+		if label == "" || len(label) > 63 ||
+			label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' ||
+				r >= '0' && r <= '9' ||
+				r == '-') {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
 // ListTitle validates the title of a new or updated List.
 func ListTitle(title string) error {
 	if title == "" {
@@ -363,7 +485,7 @@ func CreateAccount(form *apimodel.AccountCreateRequest) error {
 		return errors.New("registration is not open for this server")
 	}
 
-	if err := Username(form.Username); err != nil {
+	if err := NewUsername(form.Username); err != nil {
 		return err
 	}
 
@@ -379,6 +501,10 @@ func CreateAccount(form *apimodel.AccountCreateRequest) error {
 		return errors.New("agreement to terms and conditions not given")
 	}
 
+	if minAge := config.GetAccountsMinimumAge(); minAge != 0 && !form.AgeConfirmed {
+		return fmt.Errorf("confirmation of being at least %d years old not given", minAge)
+	}
+
 	locale, err := Language(form.Locale)
 	if err != nil {
 		return err
@@ -20,6 +20,7 @@ package validate_test
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -124,6 +125,49 @@ func (suite *ValidationTestSuite) TestValidateUsername() {
 	suite.NoError(err)
 }
 
+func (suite *ValidationTestSuite) TestValidateNewUsername() {
+	config.SetAccountsReservedUsernames([]string{"admin", "webmaster"})
+	config.SetAccountsUsernameMinLength(3)
+	config.SetAccountsUsernameMaxLength(10)
+	config.SetAccountsUsernamePattern("^[a-z]")
+
+	for _, test := range []struct {
+		username    string
+		expectedErr string
+	}{
+		{
+			username: "zork",
+		},
+		{
+			username:    "Zork",
+			expectedErr: "given username Zork was invalid: must contain only lowercase letters, numbers, and underscores, max 64 characters",
+		},
+		{
+			username:    "zk",
+			expectedErr: "given username zk was invalid: must be at least 3 characters",
+		},
+		{
+			username:    "the_mighty_zork",
+			expectedErr: "given username the_mighty_zork was invalid: must be at most 10 characters",
+		},
+		{
+			username:    "1zork",
+			expectedErr: "given username 1zork was invalid: not allowed on this instance",
+		},
+		{
+			username:    "webmaster",
+			expectedErr: "username webmaster is reserved",
+		},
+	} {
+		err := validate.NewUsername(test.username)
+		if test.expectedErr == "" {
+			suite.NoError(err)
+		} else {
+			suite.EqualError(err, test.expectedErr)
+		}
+	}
+}
+
 func (suite *ValidationTestSuite) TestValidateEmail() {
 	empty := ""
 	notAnEmailAddress := "this-is-no-email-address!"
@@ -254,6 +298,22 @@ func (suite *ValidationTestSuite) TestValidateReason() {
 	}
 }
 
+func (suite *ValidationTestSuite) TestValidateFollowRequestNote() {
+	var err error
+
+	err = validate.FollowRequestNote("")
+	suite.NoError(err)
+
+	err = validate.FollowRequestNote("hi! we met at the zine fair last week :)")
+	suite.NoError(err)
+
+	err = validate.FollowRequestNote(strings.Repeat("⏀", 500))
+	suite.NoError(err)
+
+	err = validate.FollowRequestNote(strings.Repeat("a", 501))
+	suite.EqualError(err, "follow request note should be no more than 500 chars but given note was 501")
+}
+
 func (suite *ValidationTestSuite) TestValidateProfileField() {
 	var (
 		shortProfileField   = "pronouns"
@@ -381,6 +441,42 @@ func (suite *ValidationTestSuite) TestValidateEmojiShortcode() {
 	}
 }
 
+func (suite *ValidationTestSuite) TestValidateAttributionDomains() {
+	domains, err := validate.AttributionDomains([]string{
+		"Example.org",
+		" blog.example.org ",
+		"",
+		"example.org",
+		"bücher.example",
+	})
+	suite.NoError(err)
+	suite.Equal([]string{
+		"example.org",
+		"blog.example.org",
+		"xn--bcher-kva.example",
+	}, domains)
+
+	// Not domains.
+	for _, domain := range []string{
+		"https://example.org",
+		"localhost",
+		"example .org",
+	} {
+		_, err := validate.AttributionDomains([]string{domain})
+		if !suite.Error(err) {
+			suite.T().Logf("fail on %s", domain)
+		}
+	}
+
+	// Too many.
+	tooMany := make([]string, 21)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("%d.example.org", i)
+	}
+	_, err = validate.AttributionDomains(tooMany)
+	suite.EqualError(err, "too many attribution domains; maximum is 20")
+}
+
 func TestValidationTestSuite(t *testing.T) {
 	suite.Run(t, new(ValidationTestSuite))
 }
@@ -0,0 +1,126 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package web
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+const (
+	// embedSandbox is appended to the Content-Security-Policy of
+	// status embed pages, so that they're always rendered sandboxed
+	// (without access to this instance's origin), even if the website
+	// embedding them leaves out the sandbox attribute on the iframe.
+	embedSandbox = "sandbox allow-scripts allow-popups allow-popups-to-escape-sandbox"
+)
+
+// statusEmbedGETHandler serves a standalone view of a single status,
+// for showing inside an iframe on other websites, if the status is
+// public and its author has opted in to having their posts embedded.
+func (m *Module) statusEmbedGETHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	instance, errWithCode := m.processor.InstanceGetV1(ctx)
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// Return instance we already got from the db,
+	// don't try to fetch it again when erroring.
+	instanceGet := func(ctx context.Context) (*apimodel.InstanceV1, gtserror.WithCode) {
+		return instance, nil
+	}
+
+	targetUsername, errWithCode := apiutil.ParseUsername(c.Param(apiutil.UsernameKey))
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
+	targetStatusID, errWithCode := apiutil.ParseWebStatusID(c.Param(apiutil.WebStatusIDKey))
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
+	status, errWithCode := m.processor.Status().WebEmbedGet(ctx,
+		strings.ToLower(targetUsername),
+		strings.ToUpper(targetStatusID),
+	)
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
+	// Prepare stylesheets for the embed.
+	stylesheets := []string{
+		cssFA,
+		cssStatus,
+		cssThread,
+	}
+
+	// User-selected theme if set.
+	if theme := status.Account.Theme; theme != "" {
+		stylesheets = append(
+			stylesheets,
+			themesPathPrefix+"/"+theme,
+		)
+	}
+
+	// Custom CSS for this user last in cascade.
+	stylesheets = append(
+		stylesheets,
+		"/@"+status.Account.Username+"/custom.css",
+	)
+
+	// Sandbox the embed page on top of the usual policy.
+	csp := c.Writer.Header().Get("Content-Security-Policy")
+	if csp != "" {
+		csp += "; "
+	}
+	c.Header("Content-Security-Policy", csp+embedSandbox)
+
+	page := apiutil.WebPage{
+		Template:    "status_embed.tmpl",
+		Instance:    instance,
+		Stylesheets: stylesheets,
+		Javascript:  []string{jsFrontend},
+		Extra: map[string]any{
+			"status": status,
+		},
+	}
+
+	apiutil.TemplateEmbedPage(c, page)
+}
+
+// oEmbedDiscoveryURL returns the URL of the oEmbed
+// endpoint for the given status URL, for linking to
+// from the head of the status's web page.
+func oEmbedDiscoveryURL(statusURL string) string {
+	return config.GetProtocol() + "://" + config.GetHost() +
+		"/api/oembed?url=" + url.QueryEscape(statusURL)
+}
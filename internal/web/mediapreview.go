@@ -0,0 +1,68 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package web
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+)
+
+const imageJPEG = "image/jpeg"
+
+// mediaPreviewGETHandler serves a blurred preview of one
+// of a status' attachments, for use in link previews of
+// statuses whose media is marked as sensitive.
+func (m *Module) mediaPreviewGETHandler(c *gin.Context) {
+	targetUsername, errWithCode := apiutil.ParseUsername(c.Param(apiutil.UsernameKey))
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	targetStatusID, errWithCode := apiutil.ParseWebStatusID(c.Param(apiutil.WebStatusIDKey))
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	targetAttachmentID, errWithCode := apiutil.ParseWebAttachmentID(c.Param(apiutil.WebAttachmentIDKey))
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	preview, errWithCode := m.processor.Status().WebMediaPreviewGet(
+		c.Request.Context(),
+		strings.ToLower(targetUsername),
+		strings.ToUpper(targetStatusID),
+		strings.ToUpper(targetAttachmentID),
+	)
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// Previews are derived from the blurhash,
+	// which doesn't change for an attachment,
+	// but the status may yet become private.
+	c.Header(cacheControlHeader, "public, max-age=3600")
+	c.DataFromReader(http.StatusOK, -1, imageJPEG, preview, nil)
+}
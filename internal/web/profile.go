@@ -94,6 +94,17 @@ func (m *Module) profileGETHandler(c *gin.Context) {
 		return
 	}
 
+	// If the account has changed its username since,
+	// redirect to the profile under its current one.
+	if targetAccount.Username != targetUsername {
+		redirect := "/@" + targetAccount.Username
+		if query := c.Request.URL.RawQuery; query != "" {
+			redirect += "?" + query
+		}
+		c.Redirect(http.StatusMovedPermanently, redirect)
+		return
+	}
+
 	// If target account is suspended, this page should not be visible.
 	// TODO: change this to 410?
 	if targetAccount.Suspended {
@@ -102,6 +113,15 @@ func (m *Module) profileGETHandler(c *gin.Context) {
 		return
 	}
 
+	// If the account has Moved, redirect to the profile of
+	// the account it moved to. Only done for the web view:
+	// the AP representation must still be served, so that
+	// remote instances can verify the Move via movedTo.
+	if targetAccount.Moved != nil && targetAccount.Moved.URL != "" {
+		c.Redirect(http.StatusMovedPermanently, targetAccount.Moved.URL)
+		return
+	}
+
 	// Only generate RSS link if account has RSS enabled.
 	var rssFeed string
 	if targetAccount.EnableRSS {
@@ -133,6 +153,13 @@ func (m *Module) profileGETHandler(c *gin.Context) {
 		}
 	}
 
+	// Get hashtags featured by the account.
+	featuredTags, errWithCode := m.processor.Tags().FeaturedTagsGet(ctx, targetAccount.ID)
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
 	// Get statuses from maxStatusID onwards (or from top if empty string).
 	statusResp, errWithCode := m.processor.Account().WebStatusesGet(ctx, targetAccount.ID, maxStatusID)
 	if errWithCode != nil {
@@ -181,6 +208,7 @@ func (m *Module) profileGETHandler(c *gin.Context) {
 			"statuses":         statusResp.Items,
 			"statuses_next":    statusResp.NextLink,
 			"pinned_statuses":  pinnedStatuses,
+			"featured_tags":    featuredTags,
 			"show_back_to_top": paging,
 		},
 	}
@@ -59,6 +59,7 @@ func (m *Module) signupGETHandler(c *gin.Context) {
 		OGMeta:   apiutil.OGBase(instance),
 		Extra: map[string]any{
 			"reasonRequired": config.GetAccountsReasonRequired(),
+			"minimumAge":     config.GetAccountsMinimumAge(),
 		},
 	}
 
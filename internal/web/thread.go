@@ -74,7 +74,7 @@ func (m *Module) threadGETHandler(c *gin.Context) {
 
 	// Check what type of content is being requested. If we're getting an AP
 	// request on this endpoint we should render the AP representation instead.
-	accept, err := apiutil.NegotiateAccept(c, apiutil.HTMLOrActivityPubHeaders...)
+	accept, err := apiutil.NegotiateAccept(c, apiutil.HTMLOrActivityPubOrTextHeaders...)
 	if err != nil {
 		apiutil.WebErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), instanceGet)
 		return
@@ -86,7 +86,9 @@ func (m *Module) threadGETHandler(c *gin.Context) {
 		return
 	}
 
-	// text/html has been requested. Proceed with getting the web view of the status.
+	// text/html, text/gemini or text/plain has been
+	// requested. Proceed with getting the web view of
+	// the status, or a text rendition of it.
 
 	// Don't require auth for web endpoints, but do take it if it was provided.
 	// authed.Account might end up nil here, but that's fine in case of public pages.
@@ -103,6 +105,17 @@ func (m *Module) threadGETHandler(c *gin.Context) {
 		return
 	}
 
+	// If the account has changed its username
+	// since, redirect to the status under the
+	// account's current username.
+	if targetAccount.Username != targetUsername {
+		c.Redirect(
+			http.StatusMovedPermanently,
+			"/@"+targetAccount.Username+"/statuses/"+targetStatusID,
+		)
+		return
+	}
+
 	// If target account is suspended, this page should not be visible.
 	if targetAccount.Suspended {
 		err := fmt.Errorf("target account %s is suspended", targetUsername)
@@ -131,6 +144,12 @@ func (m *Module) threadGETHandler(c *gin.Context) {
 		return
 	}
 
+	if accept == apiutil.TextGemini || accept == apiutil.TextPlain {
+		// Text rendition of the status has been requested.
+		m.returnTextStatus(c, targetStatusID, accept, instanceGet)
+		return
+	}
+
 	// Fill in the rest of the thread context.
 	context, errWithCode := m.processor.Status().WebContextGet(ctx, targetStatusID)
 	if errWithCode != nil {
@@ -177,6 +196,12 @@ func (m *Module) threadGETHandler(c *gin.Context) {
 		},
 	}
 
+	// Let oEmbed consumers discover how to embed this
+	// status, if it's public and the author allows it.
+	if targetAccount.EnableEmbeds && status.Visibility == apimodel.VisibilityPublic {
+		page.Extra["oEmbed"] = oEmbedDiscoveryURL(status.URL)
+	}
+
 	apiutil.TemplateWebPage(c, page)
 }
 
@@ -204,3 +229,24 @@ func (m *Module) returnAPStatus(
 
 	c.Data(http.StatusOK, accept, b)
 }
+
+// returnTextStatus returns a plaintext or gemtext
+// rendition of target status, depending on accept.
+func (m *Module) returnTextStatus(
+	c *gin.Context,
+	targetStatusID string,
+	accept string,
+	instanceGet func(ctx context.Context) (*apimodel.InstanceV1, gtserror.WithCode),
+) {
+	text, errWithCode := m.processor.Status().WebTextGet(
+		c.Request.Context(),
+		targetStatusID,
+		accept == apiutil.TextGemini,
+	)
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
+	apiutil.Data(c, http.StatusOK, accept+"; charset=utf-8", []byte(text))
+}
@@ -37,8 +37,11 @@ import (
 
 const (
 	confirmEmailPath   = "/" + uris.ConfirmEmailPath
+	resetPasswordPath  = "/" + uris.ResetPasswordPath
 	profileGroupPath   = "/@:username"
 	statusPath         = "/statuses/:" + apiutil.WebStatusIDKey // leave out the '/@:username' prefix as this will be served within the profile group
+	statusEmbedPath    = statusPath + "/embed"
+	mediaPreviewPath   = profileGroupPath + statusPath + "/media/:" + apiutil.WebAttachmentIDKey + "/preview"
 	tagsPath           = "/tags/:" + apiutil.TagNameKey
 	customCSSPath      = profileGroupPath + "/custom.css"
 	rssFeedPath        = profileGroupPath + "/feed.rss"
@@ -66,6 +69,7 @@ const (
 	cssProfile  = distPathPrefix + "/profile.css"
 	cssSettings = distPathPrefix + "/settings-style.css"
 	cssTag      = distPathPrefix + "/tag.css"
+	cssExplore  = distPathPrefix + "/explore.css"
 
 	jsFrontend = distPathPrefix + "/frontend.js" // Progressive enhancement frontend JS.
 	jsSettings = distPathPrefix + "/settings.js" // Settings panel React application.
@@ -108,6 +112,7 @@ func (m *Module) Route(r *router.Router, mi ...gin.HandlerFunc) {
 	}))
 	profileGroup.Handle(http.MethodGet, "", m.profileGETHandler) // use empty path here since it's the base of the group
 	profileGroup.Handle(http.MethodGet, statusPath, m.threadGETHandler)
+	profileGroup.Handle(http.MethodGet, statusEmbedPath, m.statusEmbedGETHandler)
 
 	// Attach individual web handlers which require no specific middlewares
 	r.AttachHandler(http.MethodGet, "/", m.indexHandler) // front-page
@@ -115,12 +120,16 @@ func (m *Module) Route(r *router.Router, mi ...gin.HandlerFunc) {
 	r.AttachHandler(http.MethodGet, settingsPanelGlob, m.SettingsPanelHandler)
 	r.AttachHandler(http.MethodGet, customCSSPath, m.customCSSGETHandler)
 	r.AttachHandler(http.MethodGet, rssFeedPath, m.rssFeedGETHandler)
+	r.AttachHandler(http.MethodGet, mediaPreviewPath, m.mediaPreviewGETHandler)
 	r.AttachHandler(http.MethodGet, confirmEmailPath, m.confirmEmailGETHandler)
 	r.AttachHandler(http.MethodPost, confirmEmailPath, m.confirmEmailPOSTHandler)
+	r.AttachHandler(http.MethodGet, resetPasswordPath, m.resetPasswordGETHandler)
+	r.AttachHandler(http.MethodPost, resetPasswordPath, m.resetPasswordPOSTHandler)
 	r.AttachHandler(http.MethodGet, robotsPath, m.robotsGETHandler)
 	r.AttachHandler(http.MethodGet, aboutPath, m.aboutGETHandler)
 	r.AttachHandler(http.MethodGet, domainBlockListPath, m.domainBlockListGETHandler)
 	r.AttachHandler(http.MethodGet, tagsPath, m.tagGETHandler)
+	r.AttachHandler(http.MethodGet, explorePath, m.exploreGETHandler)
 	r.AttachHandler(http.MethodGet, signupPath, m.signupGETHandler)
 	r.AttachHandler(http.MethodPost, signupPath, m.signupPOSTHandler)
 
@@ -0,0 +1,170 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// recordSize is the record size given in the header of
+// encrypted payloads. The whole payload must fit in one
+// record, as that's all that push services have to accept.
+const recordSize = 4096
+
+// maxPayloadSize is the maximum size of a plaintext
+// payload: the record size, minus the padding delimiter
+// and the AES-GCM authentication tag.
+const maxPayloadSize = recordSize - 1 - 16
+
+// encrypt encrypts the given payload for the client
+// with the given base64url encoded P-256 public key
+// and auth secret, as per RFC 8291, returning a body
+// ready to be sent with content-encoding aes128gcm.
+func encrypt(payload []byte, p256dh string, auth string) ([]byte, error) {
+	if len(payload) > maxPayloadSize {
+		return nil, fmt.Errorf("payload too large: %d bytes", len(payload))
+	}
+
+	uaPublic, authSecret, err := parseKeys(p256dh, auth)
+	if err != nil {
+		return nil, err
+	}
+	uaPublicBytes := uaPublic.Bytes()
+
+	// Generate a new key pair for
+	// this message only, and a salt.
+	asPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	asPublicBytes := asPrivate.PublicKey().Bytes()
+
+	salt := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+
+	ecdhSecret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, err
+	}
+
+	// Derive the input keying material
+	// from the shared secret and auth.
+	keyInfo := append([]byte("WebPush: info\x00"), uaPublicBytes...)
+	keyInfo = append(keyInfo, asPublicBytes...)
+	ikm := hkdf(authSecret, ecdhSecret, keyInfo, 32)
+
+	// Derive the content encryption
+	// key and nonce from that.
+	cek := hkdf(salt, ikm, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	nonce := hkdf(salt, ikm, []byte("Content-Encoding: nonce\x00"), 12)
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// Header is salt, record size,
+	// and our public key as key ID.
+	body := make([]byte, 0, 16+4+1+len(asPublicBytes)+len(payload)+1+gcm.Overhead())
+	body = append(body, salt...)
+	body = binary.BigEndian.AppendUint32(body, recordSize)
+	body = append(body, byte(len(asPublicBytes)))
+	body = append(body, asPublicBytes...)
+
+	// Payload is followed by the delimiter of
+	// the last (and only) record, no padding.
+	plaintext := append(payload[:len(payload):len(payload)], 0x02)
+	body = gcm.Seal(body, nonce, plaintext, nil)
+
+	return body, nil
+}
+
+// ValidateKeys checks whether the given base64url encoded
+// P-256 public key and auth secret, as provided by a client
+// when subscribing, can be used to encrypt notifications.
+func ValidateKeys(p256dh string, auth string) error {
+	_, _, err := parseKeys(p256dh, auth)
+	return err
+}
+
+// parseKeys parses the given base64url encoded
+// P-256 public key and auth secret of a client.
+func parseKeys(p256dh string, auth string) (*ecdh.PublicKey, []byte, error) {
+	uaPublicBytes, err := decodeBase64(p256dh)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error decoding p256dh key: %w", err)
+	}
+
+	uaPublic, err := ecdh.P256().NewPublicKey(uaPublicBytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing p256dh key: %w", err)
+	}
+
+	authSecret, err := decodeBase64(auth)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error decoding auth secret: %w", err)
+	}
+
+	if len(authSecret) != 16 {
+		return nil, nil, errors.New("auth secret should be 16 bytes")
+	}
+
+	return uaPublic, authSecret, nil
+}
+
+// hkdf derives a key of given length (at most
+// 32 bytes, so a single round of expansion is
+// enough) from the given salt, secret and info,
+// using HKDF with SHA-256 as per RFC 5869.
+func hkdf(salt []byte, secret []byte, info []byte, length int) []byte {
+	extract := hmac.New(sha256.New, salt)
+	extract.Write(secret)
+	prk := extract.Sum(nil)
+
+	expand := hmac.New(sha256.New, prk)
+	expand.Write(info)
+	expand.Write([]byte{0x01})
+	return expand.Sum(nil)[:length]
+}
+
+// decodeBase64 decodes base64url encoded
+// bytes, with or without padding, which
+// is how clients tend to provide keys.
+func decodeBase64(s string) ([]byte, error) {
+	if b, err := base64.RawURLEncoding.DecodeString(s); err == nil {
+		return b, nil
+	}
+	return base64.URLEncoding.DecodeString(s)
+}
@@ -0,0 +1,221 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+// decrypt decrypts the given aes128gcm body
+// with the given client private key and auth
+// secret, the way a user agent would.
+func decrypt(t *testing.T, body []byte, uaPrivate *ecdh.PrivateKey, authSecret []byte) []byte {
+	salt := body[:16]
+	if rs := binary.BigEndian.Uint32(body[16:20]); rs != recordSize {
+		t.Fatalf("unexpected record size %d", rs)
+	}
+
+	idlen := int(body[20])
+	asPublicBytes := body[21 : 21+idlen]
+	ciphertext := body[21+idlen:]
+
+	asPublic, err := ecdh.P256().NewPublicKey(asPublicBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ecdhSecret, err := uaPrivate.ECDH(asPublic)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyInfo := append([]byte("WebPush: info\x00"), uaPrivate.PublicKey().Bytes()...)
+	keyInfo = append(keyInfo, asPublicBytes...)
+	ikm := hkdf(authSecret, ecdhSecret, keyInfo, 32)
+	cek := hkdf(salt, ikm, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	nonce := hkdf(salt, ikm, []byte("Content-Encoding: nonce\x00"), 12)
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Strip the last record delimiter.
+	if plaintext[len(plaintext)-1] != 0x02 {
+		t.Fatalf("missing record delimiter")
+	}
+	return plaintext[:len(plaintext)-1]
+}
+
+func TestEncrypt(t *testing.T) {
+	uaPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authSecret := make([]byte, 16)
+	if _, err := rand.Read(authSecret); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		p256dh  = base64.RawURLEncoding.EncodeToString(uaPrivate.PublicKey().Bytes())
+		auth    = base64.URLEncoding.EncodeToString(authSecret) // padded is fine too
+		payload = []byte(`{"title":"hello"}`)
+	)
+
+	body, err := encrypt(payload, p256dh, auth)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := decrypt(t, body, uaPrivate, authSecret); !bytes.Equal(got, payload) {
+		t.Fatalf("expected %q, got %q", payload, got)
+	}
+
+	if _, err := encrypt(make([]byte, maxPayloadSize+1), p256dh, auth); err == nil {
+		t.Fatal("expected error encrypting too large payload")
+	}
+}
+
+func TestValidateKeys(t *testing.T) {
+	uaPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p256dh := base64.RawURLEncoding.EncodeToString(uaPrivate.PublicKey().Bytes())
+
+	for _, test := range []struct {
+		p256dh string
+		auth   string
+		valid  bool
+	}{
+		{p256dh, "AAAAAAAAAAAAAAAAAAAAAA", true},
+		{p256dh, "AAAAAAAAAAAAAAAAAAAAAA==", true},
+		{p256dh, "AAAAAAAA", false},
+		{p256dh, "not base64!", false},
+		{"AAAA", "AAAAAAAAAAAAAAAAAAAAAA", false},
+		{"", "", false},
+	} {
+		err := ValidateKeys(test.p256dh, test.auth)
+		if test.valid && err != nil {
+			t.Errorf("%q %q: unexpected error: %v", test.p256dh, test.auth, err)
+		} else if !test.valid && err == nil {
+			t.Errorf("%q %q: expected error", test.p256dh, test.auth)
+		}
+	}
+}
+
+func TestVAPIDAuthorization(t *testing.T) {
+	publicKey, privateKey, err := GenerateVAPIDKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := parseVAPIDKeyPair(publicKey, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	authorization, err := key.authorization(
+		"https://push.example.org/send/some-id",
+		"https://example.org",
+		now,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, k, ok := strings.Cut(strings.TrimPrefix(authorization, "vapid t="), ", k=")
+	if !ok || k != publicKey {
+		t.Fatalf("unexpected authorization %q", authorization)
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("unexpected token %q", token)
+	}
+
+	// Check the claims.
+	claimsBytes, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var claims struct {
+		Aud string `json:"aud"`
+		Exp int64  `json:"exp"`
+		Sub string `json:"sub"`
+	}
+	if err := json.Unmarshal(claimsBytes, &claims); err != nil {
+		t.Fatal(err)
+	}
+
+	if claims.Aud != "https://push.example.org" ||
+		claims.Exp != now.Add(vapidExpiry).Unix() ||
+		claims.Sub != "https://example.org" {
+		t.Fatalf("unexpected claims %s", claimsBytes)
+	}
+
+	// Check the signature.
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:])
+	if !ecdsa.Verify(&key.private.PublicKey, digest[:], r, s) {
+		t.Fatal("signature did not verify")
+	}
+
+	// Mismatched keys are refused.
+	otherPublicKey, _, err := GenerateVAPIDKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := parseVAPIDKeyPair(otherPublicKey, privateKey); err == nil {
+		t.Fatal("expected error parsing mismatched keys")
+	}
+}
@@ -0,0 +1,308 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+// ttl is how long push services should
+// keep trying to deliver a notification
+// to an offline client before dropping it.
+const ttl = 48 * time.Hour
+
+// maxBodyLength is the max length in
+// runes of the notification body, as
+// clients only show a preview anyway.
+const maxBodyLength = 500
+
+// Sender wraps functionality
+// for sending Web Push notifications.
+type Sender interface {
+	// Send delivers the given notification, along with its API
+	// representation, to every Web Push subscription of its
+	// target account which wants notifications of its type.
+	Send(ctx context.Context, notification *gtsmodel.Notification, apiNotification *apimodel.Notification) error
+}
+
+// HTTPClient is the subset of
+// *httpclient.Client used to make
+// requests to push services.
+type HTTPClient interface {
+	Do(r *http.Request) (*http.Response, error)
+}
+
+// NewSender returns a new Web Push Sender, which uses the
+// given client to deliver notifications to push services.
+func NewSender(httpClient HTTPClient, state *state.State) Sender {
+	return &sender{
+		httpClient: httpClient,
+		state:      state,
+	}
+}
+
+type sender struct {
+	httpClient HTTPClient
+	state      *state.State
+}
+
+// payload is the JSON delivered (encrypted) to
+// clients, in the format Mastodon clients expect.
+type payload struct {
+	AccessToken      string `json:"access_token"`
+	PreferredLocale  string `json:"preferred_locale"`
+	NotificationID   string `json:"notification_id"`
+	NotificationType string `json:"notification_type"`
+	Icon             string `json:"icon"`
+	Title            string `json:"title"`
+	Body             string `json:"body"`
+}
+
+func (s *sender) Send(
+	ctx context.Context,
+	notification *gtsmodel.Notification,
+	apiNotification *apimodel.Notification,
+) error {
+	subscriptions, err := s.state.DB.GetWebPushSubscriptionsByAccountID(ctx, notification.TargetAccountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting subscriptions: %w", err)
+	}
+
+	if len(subscriptions) == 0 {
+		// Nobody to deliver to.
+		return nil
+	}
+
+	key, err := parseVAPIDKeyPair(
+		config.GetWebPushVAPIDPublicKey(),
+		config.GetWebPushVAPIDPrivateKey(),
+	)
+	if err != nil {
+		return gtserror.Newf("error parsing vapid keys: %w", err)
+	}
+
+	errs := gtserror.NewMultiError(len(subscriptions))
+	for _, subscription := range subscriptions {
+		if !subscription.Alerts(notification.NotificationType) {
+			// Client doesn't want this type.
+			continue
+		}
+
+		allowed, err := s.policyAllows(ctx, subscription, notification)
+		if err != nil {
+			errs.Appendf("error checking policy of subscription %s: %w", subscription.ID, err)
+			continue
+		}
+
+		if !allowed {
+			continue
+		}
+
+		if err := s.sendTo(ctx, key, subscription, notification, apiNotification); err != nil {
+			errs.Appendf("error sending to subscription %s: %w", subscription.ID, err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+// policyAllows returns whether the policy of the given subscription
+// allows delivering the given notification, going by whether its
+// target follows, or is followed by, the notification's origin.
+func (s *sender) policyAllows(
+	ctx context.Context,
+	subscription *gtsmodel.WebPushSubscription,
+	notification *gtsmodel.Notification,
+) (bool, error) {
+	switch subscription.Policy {
+	case gtsmodel.WebPushSubscriptionPolicyNone:
+		return false, nil
+	case gtsmodel.WebPushSubscriptionPolicyFollowed:
+		return s.state.DB.IsFollowing(ctx, notification.TargetAccountID, notification.OriginAccountID)
+	case gtsmodel.WebPushSubscriptionPolicyFollower:
+		return s.state.DB.IsFollowing(ctx, notification.OriginAccountID, notification.TargetAccountID)
+	default:
+		return true, nil
+	}
+}
+
+// sendTo encrypts and delivers the given
+// notification to the given subscription.
+func (s *sender) sendTo(
+	ctx context.Context,
+	key *vapidKey,
+	subscription *gtsmodel.WebPushSubscription,
+	notification *gtsmodel.Notification,
+	apiNotification *apimodel.Notification,
+) error {
+	// Clients use the token to fetch
+	// the full notification, so make
+	// sure it's (still) a valid one.
+	token, err := s.state.DB.GetTokenByID(ctx, subscription.TokenID)
+	if errors.Is(err, db.ErrNoEntries) {
+		// Token has since been revoked,
+		// so clean up the subscription.
+		return s.delete(ctx, subscription)
+	} else if err != nil {
+		return gtserror.Newf("error getting token: %w", err)
+	}
+
+	var locale string
+	if notification.TargetAccount != nil && notification.TargetAccount.Settings != nil {
+		locale = notification.TargetAccount.Settings.Language
+	}
+
+	title, body := titleAndBody(apiNotification)
+	b, err := json.Marshal(&payload{
+		AccessToken:      token.Access,
+		PreferredLocale:  locale,
+		NotificationID:   apiNotification.ID,
+		NotificationType: apiNotification.Type,
+		Icon:             apiNotification.Account.Avatar,
+		Title:            title,
+		Body:             body,
+	})
+	if err != nil {
+		return gtserror.Newf("error marshaling payload: %w", err)
+	}
+
+	ciphertext, err := encrypt(b, subscription.P256dh, subscription.Auth)
+	if err != nil {
+		return gtserror.Newf("error encrypting payload: %w", err)
+	}
+
+	authorization, err := key.authorization(
+		subscription.Endpoint,
+		"https://"+config.GetHost(),
+		time.Now(),
+	)
+	if err != nil {
+		return gtserror.Newf("error signing vapid token: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx,
+		http.MethodPost,
+		subscription.Endpoint,
+		bytes.NewReader(ciphertext),
+	)
+	if err != nil {
+		return gtserror.Newf("error creating request: %w", err)
+	}
+
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", fmt.Sprint(int(ttl.Seconds())))
+	req.Header.Set("Urgency", "normal")
+
+	rsp, err := s.httpClient.Do(req)
+	if err != nil {
+		return gtserror.Newf("error sending request: %w", err)
+	}
+
+	defer rsp.Body.Close()
+	_, _ = io.Copy(io.Discard, rsp.Body)
+
+	switch {
+	case rsp.StatusCode == http.StatusNotFound ||
+		rsp.StatusCode == http.StatusGone:
+		// Subscription has expired or been
+		// unsubscribed at the push service.
+		return s.delete(ctx, subscription)
+
+	case rsp.StatusCode < 200 || rsp.StatusCode > 299:
+		return gtserror.Newf("push service returned %s", rsp.Status)
+	}
+
+	return nil
+}
+
+// delete removes the given no longer valid subscription.
+func (s *sender) delete(ctx context.Context, subscription *gtsmodel.WebPushSubscription) error {
+	log.Debugf(ctx, "deleting stale web push subscription %s", subscription.ID)
+	if err := s.state.DB.DeleteWebPushSubscriptionByTokenID(ctx, subscription.TokenID); err != nil {
+		return gtserror.Newf("error deleting stale subscription: %w", err)
+	}
+	return nil
+}
+
+// titleAndBody returns a human
+// readable title and body text
+// for the given notification.
+func titleAndBody(n *apimodel.Notification) (string, string) {
+	name := n.Account.DisplayName
+	if name == "" {
+		name = n.Account.Username
+	}
+
+	var title string
+	switch gtsmodel.NotificationType(n.Type) {
+	case gtsmodel.NotificationFollow:
+		title = name + " followed you"
+	case gtsmodel.NotificationFollowRequest:
+		title = name + " requested to follow you"
+	case gtsmodel.NotificationMention:
+		title = name + " mentioned you"
+	case gtsmodel.NotificationReblog:
+		title = name + " boosted your post"
+	case gtsmodel.NotificationFave:
+		title = name + " favourited your post"
+	case gtsmodel.NotificationPoll:
+		title = "A poll has ended"
+	case gtsmodel.NotificationStatus, gtsmodel.NotificationNewFrom:
+		title = name + " just posted"
+	case gtsmodel.NotificationSignup:
+		title = name + " signed up"
+	default:
+		title = "New notification from " + name
+	}
+
+	var body string
+	if n.Status != nil {
+		if n.Status.SpoilerText != "" {
+			// Don't spoil anything.
+			body = n.Status.SpoilerText
+		} else {
+			body = text.SanitizeToPlaintext(n.Status.Content)
+		}
+	} else {
+		body = text.SanitizeToPlaintext(n.Account.Note)
+	}
+
+	if r := []rune(body); len(r) > maxBodyLength {
+		body = string(r[:maxBodyLength-1]) + "…"
+	}
+
+	return title, body
+}
@@ -0,0 +1,213 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush_test
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type SenderTestSuite struct {
+	suite.Suite
+	db    db.DB
+	state state.State
+
+	testTokens   map[string]*gtsmodel.Token
+	testAccounts map[string]*gtsmodel.Account
+
+	// Requests received by the
+	// test push service, and the
+	// status code it responds with.
+	requests   []*http.Request
+	bodies     [][]byte
+	statusCode int
+	server     *httptest.Server
+
+	sender webpush.Sender
+}
+
+func (suite *SenderTestSuite) SetupSuite() {
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testAccounts = testrig.NewTestAccounts()
+}
+
+func (suite *SenderTestSuite) SetupTest() {
+	suite.state.Caches.Init()
+
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+
+	suite.db = testrig.NewTestDB(&suite.state)
+	testrig.StandardDBSetup(suite.db, nil)
+
+	suite.requests = nil
+	suite.bodies = nil
+	suite.statusCode = http.StatusCreated
+	suite.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		suite.requests = append(suite.requests, r)
+		suite.bodies = append(suite.bodies, body)
+		w.WriteHeader(suite.statusCode)
+	}))
+
+	suite.sender = webpush.NewSender(suite.server.Client(), &suite.state)
+}
+
+func (suite *SenderTestSuite) TearDownTest() {
+	suite.server.Close()
+	testrig.StandardDBTeardown(suite.db)
+}
+
+// putSubscription puts a subscription for
+// local_account_1's token, with the given
+// alert for mentions and the given policy.
+func (suite *SenderTestSuite) putSubscription(
+	alertMention bool,
+	policy gtsmodel.WebPushSubscriptionPolicy,
+) *gtsmodel.WebPushSubscription {
+	uaPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	subscription := &gtsmodel.WebPushSubscription{
+		ID:           "01J0F8Q9ZGY0N8T6Y2H7VAXWQD",
+		AccountID:    suite.testAccounts["local_account_1"].ID,
+		TokenID:      suite.testTokens["local_account_1"].ID,
+		Endpoint:     suite.server.URL + "/push/some-id",
+		P256dh:       base64.RawURLEncoding.EncodeToString(uaPrivate.PublicKey().Bytes()),
+		Auth:         "AAAAAAAAAAAAAAAAAAAAAA",
+		AlertMention: util.Ptr(alertMention),
+		Policy:       policy,
+	}
+
+	if err := suite.db.PutWebPushSubscription(context.Background(), subscription); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return subscription
+}
+
+// send sends a mention notification
+// to local_account_1 from the given
+// origin account.
+func (suite *SenderTestSuite) send(originAccount *gtsmodel.Account) error {
+	notification := &gtsmodel.Notification{
+		ID:               "01J0F8RJ4B8P5C7XMM3T6QZ0QN",
+		NotificationType: gtsmodel.NotificationMention,
+		TargetAccountID:  suite.testAccounts["local_account_1"].ID,
+		OriginAccountID:  originAccount.ID,
+	}
+
+	apiNotification := &apimodel.Notification{
+		ID:   notification.ID,
+		Type: string(notification.NotificationType),
+		Account: &apimodel.Account{
+			Username:    originAccount.Username,
+			DisplayName: originAccount.DisplayName,
+		},
+		Status: &apimodel.Status{
+			Content: "<p>hey <span class=\"h-card\">@the_mighty_zork</span>!</p>",
+		},
+	}
+
+	return suite.sender.Send(context.Background(), notification, apiNotification)
+}
+
+func (suite *SenderTestSuite) TestSend() {
+	suite.putSubscription(true, gtsmodel.WebPushSubscriptionPolicyAll)
+
+	if err := suite.send(suite.testAccounts["local_account_2"]); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if !suite.Len(suite.requests, 1) {
+		suite.FailNow("")
+	}
+
+	req := suite.requests[0]
+	suite.Equal(http.MethodPost, req.Method)
+	suite.Equal("/push/some-id", req.URL.Path)
+	suite.Equal("aes128gcm", req.Header.Get("Content-Encoding"))
+	suite.Equal("application/octet-stream", req.Header.Get("Content-Type"))
+	suite.Equal("172800", req.Header.Get("TTL"))
+	suite.True(strings.HasPrefix(req.Header.Get("Authorization"), "vapid t="))
+	suite.True(strings.HasSuffix(req.Header.Get("Authorization"), ", k="+config.GetWebPushVAPIDPublicKey()))
+	suite.NotEmpty(suite.bodies[0])
+}
+
+func (suite *SenderTestSuite) TestSendAlertDisabled() {
+	suite.putSubscription(false, gtsmodel.WebPushSubscriptionPolicyAll)
+
+	if err := suite.send(suite.testAccounts["local_account_2"]); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Empty(suite.requests)
+}
+
+func (suite *SenderTestSuite) TestSendPolicyFollowed() {
+	suite.putSubscription(true, gtsmodel.WebPushSubscriptionPolicyFollowed)
+
+	// Not followed by local_account_1.
+	if err := suite.send(suite.testAccounts["remote_account_1"]); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(suite.requests)
+
+	// Followed by local_account_1.
+	if err := suite.send(suite.testAccounts["local_account_2"]); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(suite.requests, 1)
+}
+
+func (suite *SenderTestSuite) TestSendGone() {
+	subscription := suite.putSubscription(true, gtsmodel.WebPushSubscriptionPolicyAll)
+	suite.statusCode = http.StatusGone
+
+	if err := suite.send(suite.testAccounts["local_account_2"]); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(suite.requests, 1)
+
+	// The expired subscription should be gone.
+	_, err := suite.db.GetWebPushSubscriptionByTokenID(context.Background(), subscription.TokenID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func TestSenderTestSuite(t *testing.T) {
+	suite.Run(t, new(SenderTestSuite))
+}
@@ -0,0 +1,143 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"time"
+)
+
+// vapidExpiry is how long signed VAPID
+// tokens are valid for. Push services
+// reject tokens valid for over 24 hours.
+const vapidExpiry = 12 * time.Hour
+
+// GenerateVAPIDKeyPair generates a new P-256 key pair for
+// identifying this instance to push services with VAPID
+// (RFC 8292), returning the public and private keys
+// encoded as unpadded base64url, as they're configured.
+func GenerateVAPIDKeyPair() (publicKey string, privateKey string, err error) {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+
+	publicKey = base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes())
+	privateKey = base64.RawURLEncoding.EncodeToString(key.Bytes())
+	return publicKey, privateKey, nil
+}
+
+// vapidKey wraps a parsed VAPID key
+// pair, ready for signing tokens.
+type vapidKey struct {
+	private *ecdsa.PrivateKey
+	public  string // base64url encoded, as sent in headers
+}
+
+// parseVAPIDKeyPair parses the given base64url
+// encoded key pair, checking that they match.
+func parseVAPIDKeyPair(publicKey string, privateKey string) (*vapidKey, error) {
+	privateBytes, err := decodeBase64(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding private key: %w", err)
+	}
+
+	private, err := ecdh.P256().NewPrivateKey(privateBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing private key: %w", err)
+	}
+
+	publicBytes, err := decodeBase64(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding public key: %w", err)
+	}
+
+	public, err := ecdh.P256().NewPublicKey(publicBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing public key: %w", err)
+	}
+
+	if !private.PublicKey().Equal(public) {
+		return nil, errors.New("public key does not match private key")
+	}
+
+	// Convert to ecdsa for signing; uncompressed
+	// point bytes are 0x04 || X (32) || Y (32).
+	key := &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(publicBytes[1:33]),
+			Y:     new(big.Int).SetBytes(publicBytes[33:]),
+		},
+		D: new(big.Int).SetBytes(privateBytes),
+	}
+
+	return &vapidKey{
+		private: key,
+		public:  base64.RawURLEncoding.EncodeToString(publicBytes),
+	}, nil
+}
+
+// authorization returns the value of the Authorization header
+// for a request to the given push service endpoint, containing
+// a freshly signed VAPID token with the given subject (a mailto:
+// or https: URL at which the push service can contact us).
+func (k *vapidKey) authorization(endpoint string, subject string, now time.Time) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("error parsing endpoint: %w", err)
+	}
+
+	claims, err := json.Marshal(map[string]any{
+		"aud": u.Scheme + "://" + u.Host,
+		"exp": now.Add(vapidExpiry).Unix(),
+		"sub": subject,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// Signing input is the encoded JWT header and claims.
+	token := base64.RawURLEncoding.EncodeToString([]byte(`{"typ":"JWT","alg":"ES256"}`)) +
+		"." + base64.RawURLEncoding.EncodeToString(claims)
+
+	digest := sha256.Sum256([]byte(token))
+	r, s, err := ecdsa.Sign(rand.Reader, k.private, digest[:])
+	if err != nil {
+		return "", err
+	}
+
+	// ES256 signatures are the two 32
+	// byte big endian integers r and s.
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+	token += "." + base64.RawURLEncoding.EncodeToString(sig)
+
+	return "vapid t=" + token + ", k=" + k.public, nil
+}
@@ -52,6 +52,11 @@ type Workers struct {
 	// asynchronous media processing jobs.
 	Media FnWorkerPool
 
+	// Processing provides a worker pool for
+	// long-running processing jobs requested
+	// by users, eg., generating data exports.
+	Processing FnWorkerPool
+
 	// prevent pass-by-value.
 	_ nocopy
 }
@@ -69,6 +74,7 @@ func (w *Workers) Start() {
 	w.Federator.Start(4 * maxprocs)
 	w.Dereference.Start(4 * maxprocs)
 	w.Media.Start(8 * maxprocs)
+	w.Processing.Start(maxprocs)
 }
 
 // Stop will stop all of the contained worker pools (and global scheduler).
@@ -79,6 +85,7 @@ func (w *Workers) Stop() {
 	w.Federator.Stop()
 	w.Dereference.Stop()
 	w.Media.Stop()
+	w.Processing.Stop()
 }
 
 // nocopy when embedded will signal linter to
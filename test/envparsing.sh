@@ -7,10 +7,33 @@ EXPECT=$(cat << "EOF"
     "account-domain": "peepee",
     "accounts-allow-custom-css": true,
     "accounts-custom-css-length": 5000,
+    "accounts-minimum-age": 0,
     "accounts-reason-required": false,
     "accounts-registration-open": true,
+    "accounts-reserved-usernames": [
+        "admin",
+        "administrator",
+        "root",
+        "webmaster",
+        "hostmaster",
+        "postmaster",
+        "abuse",
+        "security",
+        "support",
+        "moderator",
+        "staff",
+        "noreply",
+        "no_reply"
+    ],
+    "accounts-username-max-length": 64,
+    "accounts-username-min-length": 1,
+    "accounts-username-pattern": "",
     "advanced-cookies-samesite": "strict",
+    "advanced-cors-allow-origins": [],
+    "advanced-cors-web-clients": [],
     "advanced-csp-extra-uris": [],
+    "advanced-delivery-log-retention": 3600000000000,
+    "advanced-dereference-budget": 8,
     "advanced-header-filter-mode": "",
     "advanced-rate-limit-exceptions": [
         "192.0.2.0/24",
@@ -18,8 +41,15 @@ EXPECT=$(cat << "EOF"
     ],
     "advanced-rate-limit-requests": 6969,
     "advanced-sender-multiplier": -1,
+    "advanced-streaming-idle-timeout": 60000000000,
+    "advanced-streaming-max-account-connections": 5,
+    "advanced-streaming-max-connections": 1000,
+    "advanced-thread-max-ancestors": 20,
+    "advanced-thread-max-depth": 10,
+    "advanced-thread-max-descendants": 50,
     "advanced-throttling-multiplier": -1,
     "advanced-throttling-retry-after": 10000000000,
+    "advanced-timeline-storage": "database",
     "application-name": "gts",
     "bind-address": "127.0.0.1",
     "cache": {
@@ -30,9 +60,11 @@ EXPECT=$(cat << "EOF"
         "application-mem-ratio": 0.1,
         "block-mem-ratio": 3,
         "boost-of-ids-mem-ratio": 3,
+        "card-mem-ratio": 1,
         "client-mem-ratio": 0.1,
         "emoji-category-mem-ratio": 0.1,
         "emoji-mem-ratio": 3,
+        "featured-tag-mem-ratio": 1,
         "filter-keyword-mem-ratio": 0.5,
         "filter-mem-ratio": 0.5,
         "filter-status-mem-ratio": 0.5,
@@ -40,6 +72,7 @@ EXPECT=$(cat << "EOF"
         "follow-mem-ratio": 2,
         "follow-request-ids-mem-ratio": 2,
         "follow-request-mem-ratio": 2,
+        "followed-tag-mem-ratio": 1,
         "in-reply-to-ids-mem-ratio": 3,
         "instance-mem-ratio": 1,
         "list-entry-mem-ratio": 2,
@@ -57,6 +90,8 @@ EXPECT=$(cat << "EOF"
         "status-fave-ids-mem-ratio": 3,
         "status-fave-mem-ratio": 2,
         "status-mem-ratio": 5,
+        "status-reaction-ids-mem-ratio": 2,
+        "status-reaction-mem-ratio": 1,
         "tag-mem-ratio": 2,
         "thread-mute-mem-ratio": 0.2,
         "token-mem-ratio": 0.75,
@@ -85,16 +120,29 @@ EXPECT=$(cat << "EOF"
     "http-client": {
         "allow-ips": [],
         "block-ips": [],
+        "happy-eyeballs-delay": 300000000,
+        "ip-version": "",
+        "proxy": "",
+        "proxy-rules": [],
+        "resolver": "",
         "timeout": 10000000000,
         "tls-insecure-skip-verify": false
     },
+    "instance-blocked-interactions-threshold": 5,
+    "instance-blocked-interactions-window": 43200000000000,
     "instance-deliver-to-shared-inboxes": false,
+    "instance-expose-explore": true,
     "instance-expose-peers": true,
     "instance-expose-public-timeline": true,
     "instance-expose-suspended": true,
     "instance-expose-suspended-web": true,
     "instance-federation-mode": "allowlist",
     "instance-federation-spam-filter": true,
+    "instance-inbox-max-attachments": 8,
+    "instance-inbox-max-emojis": 64,
+    "instance-inbox-max-recipients": 512,
+    "instance-inbox-max-size": 65536,
+    "instance-inbox-max-tags": 64,
     "instance-inject-mastodon-version": true,
     "instance-languages": [
         "nl",
@@ -110,6 +158,8 @@ EXPECT=$(cat << "EOF"
     "log-db-queries": true,
     "log-level": "info",
     "log-timestamp-format": "banana",
+    "media-account-lazy-fetch": true,
+    "media-allowed-mime-types": [],
     "media-cleanup-every": 86400000000000,
     "media-cleanup-from": "00:00",
     "media-description-max-chars": 5000,
@@ -117,7 +167,9 @@ EXPECT=$(cat << "EOF"
     "media-emoji-local-max-size": 420,
     "media-emoji-remote-max-size": 420,
     "media-image-max-size": 420,
+    "media-preserve-orientation": false,
     "media-remote-cache-days": 30,
+    "media-unknown-placeholder": "links",
     "media-video-max-size": 420,
     "metrics-auth-enabled": false,
     "metrics-auth-password": "",
@@ -140,12 +192,20 @@ EXPECT=$(cat << "EOF"
         "write"
     ],
     "oidc-skip-verification": true,
+    "onion-host": "",
     "password": "",
     "path": "",
     "port": 6969,
     "protocol": "http",
     "remote-only": false,
     "request-id-header": "X-Trace-Id",
+    "scopes": "read write",
+    "seed": 1,
+    "seed-accounts": 100,
+    "seed-follows": 20,
+    "seed-media": 2,
+    "seed-notifications": 20,
+    "seed-statuses": 50,
     "smtp-disclose-recipients": true,
     "smtp-from": "queen.rip.in.piss@terfisland.org",
     "smtp-host": "example.com",
@@ -153,10 +213,12 @@ EXPECT=$(cat << "EOF"
     "smtp-port": 4269,
     "smtp-username": "sex-haver",
     "software-version": "",
+    "status-rate-limit": 0,
     "statuses-max-chars": 69,
     "statuses-media-max-files": 1,
     "statuses-poll-max-options": 1,
     "statuses-poll-option-max-chars": 50,
+    "statuses-search-index": true,
     "storage-backend": "local",
     "storage-local-base-path": "/root/store",
     "storage-s3-access-key": "minio",
@@ -180,6 +242,11 @@ EXPECT=$(cat << "EOF"
     ],
     "username": "",
     "web-asset-base-dir": "/root",
+    "web-opengraph-description-length": 300,
+    "web-push-vapid-private-key": "vapid-private",
+    "web-push-vapid-public-key": "vapid-public",
+    "web-rss-description-length": 256,
+    "web-rss-title-length": 128,
     "web-template-base-dir": "/root"
 }
 EOF
@@ -217,21 +284,32 @@ GTS_WEB_ASSET_BASE_DIR='/root' \
 GTS_INSTANCE_EXPOSE_PEERS=true \
 GTS_INSTANCE_EXPOSE_SUSPENDED=true \
 GTS_INSTANCE_EXPOSE_SUSPENDED_WEB=true \
+GTS_INSTANCE_EXPOSE_EXPLORE=true \
 GTS_INSTANCE_EXPOSE_PUBLIC_TIMELINE=true \
 GTS_INSTANCE_FEDERATION_MODE='allowlist' \
 GTS_INSTANCE_FEDERATION_SPAM_FILTER=true \
 GTS_INSTANCE_DELIVER_TO_SHARED_INBOXES=false \
 GTS_INSTANCE_INJECT_MASTODON_VERSION=true \
 GTS_INSTANCE_LANGUAGES="nl,en-gb" \
+GTS_INSTANCE_BLOCKED_INTERACTIONS_THRESHOLD=5 \
+GTS_INSTANCE_BLOCKED_INTERACTIONS_WINDOW='12h' \
+GTS_INSTANCE_INBOX_MAX_SIZE=65536 \
+GTS_INSTANCE_INBOX_MAX_RECIPIENTS=512 \
+GTS_INSTANCE_INBOX_MAX_TAGS=64 \
+GTS_INSTANCE_INBOX_MAX_ATTACHMENTS=8 \
+GTS_INSTANCE_INBOX_MAX_EMOJIS=64 \
 GTS_ACCOUNTS_ALLOW_CUSTOM_CSS=true \
 GTS_ACCOUNTS_CUSTOM_CSS_LENGTH=5000 \
 GTS_ACCOUNTS_REGISTRATION_OPEN=true \
 GTS_ACCOUNTS_REASON_REQUIRED=false \
 GTS_MEDIA_IMAGE_MAX_SIZE=420 \
+GTS_MEDIA_PRESERVE_ORIENTATION=false \
 GTS_MEDIA_VIDEO_MAX_SIZE=420 \
 GTS_MEDIA_DESCRIPTION_MIN_CHARS=69 \
 GTS_MEDIA_DESCRIPTION_MAX_CHARS=5000 \
 GTS_MEDIA_REMOTE_CACHE_DAYS=30 \
+GTS_MEDIA_ACCOUNT_LAZY_FETCH=true \
+GTS_MEDIA_UNKNOWN_PLACEHOLDER=links \
 GTS_MEDIA_EMOJI_LOCAL_MAX_SIZE=420 \
 GTS_MEDIA_EMOJI_REMOTE_MAX_SIZE=420 \
 GTS_METRICS_AUTH_ENABLED=false \
@@ -249,6 +327,7 @@ GTS_STATUSES_CW_MAX_CHARS=420 \
 GTS_STATUSES_POLL_MAX_OPTIONS=1 \
 GTS_STATUSES_POLL_OPTIONS_MAX_CHARS=69 \
 GTS_STATUSES_MEDIA_MAX_FILES=1 \
+GTS_STATUSES_SEARCH_INDEX=true \
 GTS_LETS_ENCRYPT_ENABLED=false \
 GTS_LETS_ENCRYPT_PORT=8080 \
 GTS_LETS_ENCRYPT_CERT_DIR='/root/certs' \
@@ -269,15 +348,26 @@ GTS_SMTP_USERNAME='sex-haver' \
 GTS_SMTP_PASSWORD='hunter2' \
 GTS_SMTP_FROM='queen.rip.in.piss@terfisland.org' \
 GTS_SMTP_DISCLOSE_RECIPIENTS=true \
+GTS_WEB_PUSH_VAPID_PUBLIC_KEY='vapid-public' \
+GTS_WEB_PUSH_VAPID_PRIVATE_KEY='vapid-private' \
 GTS_SYSLOG_ENABLED=true \
 GTS_SYSLOG_PROTOCOL='udp' \
 GTS_SYSLOG_ADDRESS='127.0.0.1:6969' \
 GTS_TRACING_ENDPOINT='localhost:4317' \
 GTS_TRACING_INSECURE_TRANSPORT=true \
 GTS_ADVANCED_COOKIES_SAMESITE='strict' \
+GTS_ADVANCED_DELIVERY_LOG_RETENTION='1h' \
+GTS_ADVANCED_DEREFERENCE_BUDGET=8 \
 GTS_ADVANCED_RATE_LIMIT_EXCEPTIONS="192.0.2.0/24,127.0.0.1/32" \
 GTS_ADVANCED_RATE_LIMIT_REQUESTS=6969 \
 GTS_ADVANCED_SENDER_MULTIPLIER=-1 \
+GTS_ADVANCED_STREAMING_MAX_CONNECTIONS=1000 \
+GTS_ADVANCED_STREAMING_MAX_ACCOUNT_CONNECTIONS=5 \
+GTS_ADVANCED_STREAMING_IDLE_TIMEOUT='1m' \
+GTS_ADVANCED_THREAD_MAX_ANCESTORS=20 \
+GTS_ADVANCED_THREAD_MAX_DEPTH=10 \
+GTS_ADVANCED_THREAD_MAX_DESCENDANTS=50 \
+GTS_ADVANCED_TIMELINE_STORAGE='database' \
 GTS_ADVANCED_THROTTLING_MULTIPLIER=-1 \
 GTS_ADVANCED_THROTTLING_RETRY_AFTER='10s' \
 GTS_REQUEST_ID_HEADER='X-Trace-Id' \
@@ -20,6 +20,8 @@ package testrig
 import (
 	"cmp"
 	"os"
+	"path/filepath"
+	"strconv"
 	"time"
 
 	"codeberg.org/gruf/go-bytesize"
@@ -63,14 +65,18 @@ var testDefaults = config.Configuration{
 	DbSqliteCacheSize:        8 * bytesize.MiB,
 	DbSqliteBusyTimeout:      time.Minute * 5,
 
-	WebTemplateBaseDir: "./web/template/",
-	WebAssetBaseDir:    "./web/assets/",
+	WebTemplateBaseDir:            "./web/template/",
+	WebAssetBaseDir:               "./web/assets/",
+	WebRSSTitleLength:             128,
+	WebRSSDescriptionLength:       256,
+	WebOpenGraphDescriptionLength: 300,
 
 	InstanceFederationMode:         config.InstanceFederationModeDefault,
 	InstanceFederationSpamFilter:   true,
 	InstanceExposePeers:            true,
 	InstanceExposeSuspended:        true,
 	InstanceExposeSuspendedWeb:     true,
+	InstanceExposeExplore:          true,
 	InstanceDeliverToSharedInboxes: true,
 	InstanceLanguages: language.Languages{
 		{
@@ -80,17 +86,41 @@ var testDefaults = config.Configuration{
 			TagStr: "en-gb",
 		},
 	},
+	InstanceBlockedInteractionsThreshold: 0,
+	InstanceBlockedInteractionsWindow:    24 * time.Hour,
 
 	AccountsRegistrationOpen: true,
 	AccountsReasonRequired:   true,
 	AccountsAllowCustomCSS:   true,
 	AccountsCustomCSSLength:  10000,
 
+	AccountsReservedUsernames: []string{
+		"admin",
+		"administrator",
+		"root",
+		"webmaster",
+		"hostmaster",
+		"postmaster",
+		"abuse",
+		"security",
+		"support",
+		"moderator",
+		"staff",
+		"noreply",
+		"no_reply",
+	},
+	AccountsUsernameMinLength: 1,
+	AccountsUsernameMaxLength: 64,
+	AccountsUsernamePattern:   "",
+	AccountsMinimumAge:        0,
+
 	MediaImageMaxSize:        10485760, // 10MiB
 	MediaVideoMaxSize:        41943040, // 40MiB
 	MediaDescriptionMinChars: 0,
 	MediaDescriptionMaxChars: 500,
+	MediaPreserveOrientation: true,
 	MediaRemoteCacheDays:     7,
+	MediaUnknownPlaceholder:  config.MediaUnknownPlaceholderNote,
 	MediaEmojiLocalMaxSize:   51200,          // 50KiB
 	MediaEmojiRemoteMaxSize:  102400,         // 100KiB
 	MediaCleanupFrom:         "00:00",        // midnight.
@@ -98,14 +128,17 @@ var testDefaults = config.Configuration{
 
 	// the testrig only uses in-memory storage, so we can
 	// safely set this value to 'test' to avoid running storage
-	// migrations, and other silly things like that
+	// migrations, and other silly things like that. The base
+	// path is still used for spooling uploads, so give each
+	// test process its own to avoid them treading on each other.
 	StorageBackend:       "test",
-	StorageLocalBasePath: "",
+	StorageLocalBasePath: filepath.Join(os.TempDir(), "gotosocial-test-"+strconv.Itoa(os.Getpid())),
 
 	StatusesMaxChars:           5000,
 	StatusesPollMaxOptions:     6,
 	StatusesPollOptionMaxChars: 50,
 	StatusesMediaMaxFiles:      6,
+	StatusesSearchIndex:        false,
 
 	LetsEncryptEnabled:      false,
 	LetsEncryptPort:         0,
@@ -130,6 +163,9 @@ var testDefaults = config.Configuration{
 	SMTPFrom:               "GoToSocial",
 	SMTPDiscloseRecipients: false,
 
+	WebPushVAPIDPublicKey:  "BBDzY9cbLilM1pm9BIGKeEup9nbTJJWHOKu2Rb9Z1xceRDovyXcfivc8SP61qWRiPBdy3CwGCOmHTdYfKYPnX_A",
+	WebPushVAPIDPrivateKey: "0sX_EzWauq4GROEWUywG1Ulx96QiNUp0o3j5Fkzux_8",
+
 	TracingEnabled:           false,
 	TracingEndpoint:          "localhost:4317",
 	TracingTransport:         "grpc",
@@ -142,11 +178,19 @@ var testDefaults = config.Configuration{
 	SyslogProtocol: "udp",
 	SyslogAddress:  "localhost:514",
 
-	AdvancedCookiesSamesite:      "lax",
-	AdvancedRateLimitRequests:    0, // disabled
-	AdvancedThrottlingMultiplier: 0, // disabled
-	AdvancedSenderMultiplier:     0, // 1 sender only, regardless of CPU
-	AdvancedHeaderFilterMode:     config.RequestHeaderFilterModeBlock,
+	AdvancedCookiesSamesite:                "lax",
+	AdvancedRateLimitRequests:              0, // disabled
+	AdvancedThrottlingMultiplier:           0, // disabled
+	AdvancedSenderMultiplier:               0, // 1 sender only, regardless of CPU
+	AdvancedHeaderFilterMode:               config.RequestHeaderFilterModeBlock,
+	AdvancedThreadMaxAncestors:             256,
+	AdvancedThreadMaxDepth:                 64,
+	AdvancedThreadMaxDescendants:           256,
+	AdvancedTimelineStorage:                "memory",
+	AdvancedDeliveryLogRetention:           24 * time.Hour,
+	AdvancedStreamingMaxConnections:        0,
+	AdvancedStreamingMaxAccountConnections: 20,
+	AdvancedStreamingIdleTimeout:           5 * time.Minute,
 
 	SoftwareVersion: "0.0.0-testrig",
 
@@ -53,6 +53,7 @@ var testModels = []interface{}{
 	&gtsmodel.StatusToEmoji{},
 	&gtsmodel.StatusToTag{},
 	&gtsmodel.StatusFave{},
+	&gtsmodel.StatusReaction{},
 	&gtsmodel.StatusBookmark{},
 	&gtsmodel.Tag{},
 	&gtsmodel.Thread{},
@@ -71,6 +72,29 @@ var testModels = []interface{}{
 	&gtsmodel.Rule{},
 	&gtsmodel.AccountNote{},
 	&gtsmodel.AccountSettings{},
+	&gtsmodel.Announcement{},
+	&gtsmodel.AnnouncementRead{},
+	&gtsmodel.AnnouncementReaction{},
+	&gtsmodel.TimelineEntry{},
+	&gtsmodel.UsernameChange{},
+	&gtsmodel.TermsVersion{},
+	&gtsmodel.StatusEdit{},
+	&gtsmodel.Card{},
+	&gtsmodel.TagHistory{},
+	&gtsmodel.ScheduledStatus{},
+	&gtsmodel.NotificationRequest{},
+	&gtsmodel.InteractionRequest{},
+	&gtsmodel.FollowerEvent{},
+	&gtsmodel.UserMute{},
+	&gtsmodel.Conversation{},
+	&gtsmodel.AccountExport{},
+	&gtsmodel.AccountImport{},
+	&gtsmodel.AccountImportRow{},
+	&gtsmodel.ContentReveal{},
+	&gtsmodel.FollowedTag{},
+	&gtsmodel.FeaturedTag{},
+	&gtsmodel.RuleAcknowledgement{},
+	&gtsmodel.WebPushSubscription{},
 }
 
 // NewTestDB returns a new initialized, empty database for testing.
@@ -650,44 +650,80 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 func NewTestAccountSettings() map[string]*gtsmodel.AccountSettings {
 	return map[string]*gtsmodel.AccountSettings{
 		"unconfirmed_account": {
-			AccountID:       "01F8MH0BBE4FHXPH513MBVFHB0",
-			CreatedAt:       TimeMustParse("2022-06-04T13:12:00Z"),
-			UpdatedAt:       TimeMustParse("2022-06-04T13:12:00Z"),
-			Privacy:         gtsmodel.VisibilityPublic,
-			Sensitive:       util.Ptr(false),
-			Language:        "en",
-			EnableRSS:       util.Ptr(false),
-			HideCollections: util.Ptr(false),
+			AccountID:                  "01F8MH0BBE4FHXPH513MBVFHB0",
+			CreatedAt:                  TimeMustParse("2022-06-04T13:12:00Z"),
+			UpdatedAt:                  TimeMustParse("2022-06-04T13:12:00Z"),
+			Privacy:                    gtsmodel.VisibilityPublic,
+			Sensitive:                  util.Ptr(false),
+			Language:                   "en",
+			EnableRSS:                  util.Ptr(false),
+			HideCollections:            util.Ptr(false),
+			HideApplication:            util.Ptr(false),
+			EnableEmbeds:               util.Ptr(false),
+			AutoApproveFollowed:        util.Ptr(false),
+			AutoApproveLocal:           util.Ptr(false),
+			RecordFollowerEvents:       util.Ptr(false),
+			NotifPolicyNotFollowing:    gtsmodel.NotificationPolicyAccept,
+			NotifPolicyNotFollowers:    gtsmodel.NotificationPolicyAccept,
+			NotifPolicyNewAccounts:     gtsmodel.NotificationPolicyAccept,
+			NotifPolicyPrivateMentions: gtsmodel.NotificationPolicyAccept,
 		},
 		"admin_account": {
-			AccountID:       "01F8MH17FWEB39HZJ76B6VXSKF",
-			CreatedAt:       TimeMustParse("2022-05-17T13:10:59Z"),
-			UpdatedAt:       TimeMustParse("2022-05-17T13:10:59Z"),
-			Privacy:         gtsmodel.VisibilityPublic,
-			Sensitive:       util.Ptr(false),
-			Language:        "en",
-			EnableRSS:       util.Ptr(true),
-			HideCollections: util.Ptr(false),
+			AccountID:                  "01F8MH17FWEB39HZJ76B6VXSKF",
+			CreatedAt:                  TimeMustParse("2022-05-17T13:10:59Z"),
+			UpdatedAt:                  TimeMustParse("2022-05-17T13:10:59Z"),
+			Privacy:                    gtsmodel.VisibilityPublic,
+			Sensitive:                  util.Ptr(false),
+			Language:                   "en",
+			EnableRSS:                  util.Ptr(true),
+			HideCollections:            util.Ptr(false),
+			HideApplication:            util.Ptr(false),
+			EnableEmbeds:               util.Ptr(false),
+			AutoApproveFollowed:        util.Ptr(false),
+			AutoApproveLocal:           util.Ptr(false),
+			RecordFollowerEvents:       util.Ptr(false),
+			NotifPolicyNotFollowing:    gtsmodel.NotificationPolicyAccept,
+			NotifPolicyNotFollowers:    gtsmodel.NotificationPolicyAccept,
+			NotifPolicyNewAccounts:     gtsmodel.NotificationPolicyAccept,
+			NotifPolicyPrivateMentions: gtsmodel.NotificationPolicyAccept,
 		},
 		"local_account_1": {
-			AccountID:       "01F8MH1H7YV1Z7D2C8K2730QBF",
-			CreatedAt:       TimeMustParse("2022-05-20T11:09:18Z"),
-			UpdatedAt:       TimeMustParse("2022-05-20T11:09:18Z"),
-			Privacy:         gtsmodel.VisibilityPublic,
-			Sensitive:       util.Ptr(false),
-			Language:        "en",
-			EnableRSS:       util.Ptr(true),
-			HideCollections: util.Ptr(false),
+			AccountID:                  "01F8MH1H7YV1Z7D2C8K2730QBF",
+			CreatedAt:                  TimeMustParse("2022-05-20T11:09:18Z"),
+			UpdatedAt:                  TimeMustParse("2022-05-20T11:09:18Z"),
+			Privacy:                    gtsmodel.VisibilityPublic,
+			Sensitive:                  util.Ptr(false),
+			Language:                   "en",
+			EnableRSS:                  util.Ptr(true),
+			HideCollections:            util.Ptr(false),
+			HideApplication:            util.Ptr(false),
+			EnableEmbeds:               util.Ptr(false),
+			AutoApproveFollowed:        util.Ptr(false),
+			AutoApproveLocal:           util.Ptr(false),
+			RecordFollowerEvents:       util.Ptr(false),
+			NotifPolicyNotFollowing:    gtsmodel.NotificationPolicyAccept,
+			NotifPolicyNotFollowers:    gtsmodel.NotificationPolicyAccept,
+			NotifPolicyNewAccounts:     gtsmodel.NotificationPolicyAccept,
+			NotifPolicyPrivateMentions: gtsmodel.NotificationPolicyAccept,
 		},
 		"local_account_2": {
-			AccountID:       "01F8MH5NBDF2MV7CTC4Q5128HF",
-			CreatedAt:       TimeMustParse("2022-06-04T13:12:00Z"),
-			UpdatedAt:       TimeMustParse("2022-06-04T13:12:00Z"),
-			Privacy:         gtsmodel.VisibilityFollowersOnly,
-			Sensitive:       util.Ptr(true),
-			Language:        "fr",
-			EnableRSS:       util.Ptr(false),
-			HideCollections: util.Ptr(true),
+			AccountID:                  "01F8MH5NBDF2MV7CTC4Q5128HF",
+			CreatedAt:                  TimeMustParse("2022-06-04T13:12:00Z"),
+			UpdatedAt:                  TimeMustParse("2022-06-04T13:12:00Z"),
+			Privacy:                    gtsmodel.VisibilityFollowersOnly,
+			Sensitive:                  util.Ptr(true),
+			Language:                   "fr",
+			EnableRSS:                  util.Ptr(false),
+			HideCollections:            util.Ptr(true),
+			HideApplication:            util.Ptr(false),
+			EnableEmbeds:               util.Ptr(false),
+			AutoApproveFollowed:        util.Ptr(false),
+			AutoApproveLocal:           util.Ptr(false),
+			RecordFollowerEvents:       util.Ptr(false),
+			NotifPolicyNotFollowing:    gtsmodel.NotificationPolicyAccept,
+			NotifPolicyNotFollowers:    gtsmodel.NotificationPolicyAccept,
+			NotifPolicyNewAccounts:     gtsmodel.NotificationPolicyAccept,
+			NotifPolicyPrivateMentions: gtsmodel.NotificationPolicyAccept,
 		},
 	}
 }
@@ -749,9 +785,10 @@ func NewTestAttachments() map[string]*gtsmodel.MediaAttachment {
 				URL:         "http://localhost:8080/fileserver/01F8MH17FWEB39HZJ76B6VXSKF/attachment/small/01F8MH6NEM8D7527KZAECTCR76.jpg",
 				RemoteURL:   "",
 			},
-			Avatar: util.Ptr(false),
-			Header: util.Ptr(false),
-			Cached: util.Ptr(true),
+			Avatar:    util.Ptr(false),
+			Header:    util.Ptr(false),
+			Cached:    util.Ptr(true),
+			Sensitive: util.Ptr(false),
 		},
 		"local_account_1_status_4_attachment_1": {
 			ID:        "01F8MH7TDVANYKWVE8VVKFPJTJ",
@@ -798,9 +835,10 @@ func NewTestAttachments() map[string]*gtsmodel.MediaAttachment {
 				URL:         "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/attachment/small/01F8MH7TDVANYKWVE8VVKFPJTJ.jpg",
 				RemoteURL:   "",
 			},
-			Avatar: util.Ptr(false),
-			Header: util.Ptr(false),
-			Cached: util.Ptr(true),
+			Avatar:    util.Ptr(false),
+			Header:    util.Ptr(false),
+			Cached:    util.Ptr(true),
+			Sensitive: util.Ptr(false),
 		},
 		"local_account_1_status_4_attachment_2": {
 			ID:        "01CDR64G398ADCHXK08WWTHEZ5",
@@ -850,9 +888,10 @@ func NewTestAttachments() map[string]*gtsmodel.MediaAttachment {
 				URL:         "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/attachment/small/01CDR64G398ADCHXK08WWTHEZ5.jpg",
 				RemoteURL:   "",
 			},
-			Avatar: util.Ptr(false),
-			Header: util.Ptr(false),
-			Cached: util.Ptr(true),
+			Avatar:    util.Ptr(false),
+			Header:    util.Ptr(false),
+			Cached:    util.Ptr(true),
+			Sensitive: util.Ptr(false),
 		},
 		"local_account_1_unattached_1": {
 			ID:        "01F8MH8RMYQ6MSNY3JM2XT1CQ5",
@@ -899,9 +938,10 @@ func NewTestAttachments() map[string]*gtsmodel.MediaAttachment {
 				URL:         "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/attachment/small/01F8MH8RMYQ6MSNY3JM2XT1CQ5.jpg",
 				RemoteURL:   "",
 			},
-			Avatar: util.Ptr(false),
-			Header: util.Ptr(false),
-			Cached: util.Ptr(true),
+			Avatar:    util.Ptr(false),
+			Header:    util.Ptr(false),
+			Cached:    util.Ptr(true),
+			Sensitive: util.Ptr(false),
 		},
 		"local_account_1_avatar": {
 			ID:        "01F8MH58A357CV5K7R7TJMSH6S",
@@ -948,9 +988,10 @@ func NewTestAttachments() map[string]*gtsmodel.MediaAttachment {
 				URL:         "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/avatar/small/01F8MH58A357CV5K7R7TJMSH6S.jpg",
 				RemoteURL:   "",
 			},
-			Avatar: util.Ptr(true),
-			Header: util.Ptr(false),
-			Cached: util.Ptr(true),
+			Avatar:    util.Ptr(true),
+			Header:    util.Ptr(false),
+			Cached:    util.Ptr(true),
+			Sensitive: util.Ptr(false),
 		},
 		"local_account_1_header": {
 			ID:        "01PFPMWK2FF0D9WMHEJHR07C3Q",
@@ -997,9 +1038,10 @@ func NewTestAttachments() map[string]*gtsmodel.MediaAttachment {
 				URL:         "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/small/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg",
 				RemoteURL:   "",
 			},
-			Avatar: util.Ptr(false),
-			Header: util.Ptr(true),
-			Cached: util.Ptr(true),
+			Avatar:    util.Ptr(false),
+			Header:    util.Ptr(true),
+			Cached:    util.Ptr(true),
+			Sensitive: util.Ptr(false),
 		},
 		"remote_account_1_status_1_attachment_1": {
 			ID:        "01FVW7RXPQ8YJHTEXYPE7Q8ZY0",
@@ -1046,9 +1088,10 @@ func NewTestAttachments() map[string]*gtsmodel.MediaAttachment {
 				URL:         "http://localhost:8080/fileserver/01F8MH5ZK5VRH73AKHQM6Y9VNX/attachment/small/01FVW7RXPQ8YJHTEXYPE7Q8ZY0.jpg",
 				RemoteURL:   "http://fossbros-anonymous.io/attachments/small/a499f55b-2d1e-4acd-98d2-1ac2ba6d79b9.jpg",
 			},
-			Avatar: util.Ptr(false),
-			Header: util.Ptr(false),
-			Cached: util.Ptr(true),
+			Avatar:    util.Ptr(false),
+			Header:    util.Ptr(false),
+			Cached:    util.Ptr(true),
+			Sensitive: util.Ptr(false),
 		},
 		"remote_account_3_header": {
 			ID:        "01PFPMWK2FF0D9WMHEJHR07C3R",
@@ -1095,9 +1138,10 @@ func NewTestAttachments() map[string]*gtsmodel.MediaAttachment {
 				URL:         "http://localhost:8080/fileserver/062G5WYKY35KKD12EMSM3F8PJ8/header/small/01PFPMWK2FF0D9WMHEJHR07C3R.jpg",
 				RemoteURL:   "http://fossbros-anonymous.io/attachments/small/a499f55b-2d1e-4acd-98d2-1ac2ba6d79b9.jpg",
 			},
-			Avatar: util.Ptr(false),
-			Header: util.Ptr(true),
-			Cached: util.Ptr(true),
+			Avatar:    util.Ptr(false),
+			Header:    util.Ptr(true),
+			Cached:    util.Ptr(true),
+			Sensitive: util.Ptr(false),
 		},
 		"remote_account_2_status_1_attachment_1": {
 			ID:        "01HE7Y3C432WRSNS10EZM86SA5",
@@ -1142,9 +1186,10 @@ func NewTestAttachments() map[string]*gtsmodel.MediaAttachment {
 				UpdatedAt:   TimeMustParse("2023-11-02T12:44:25+02:00"),
 				URL:         "http://localhost:8080/fileserver/01FHMQX3GAABWSM0S2VZEC2SWC/attachment/small/01HE7Y3C432WRSNS10EZM86SA5.jpg",
 			},
-			Avatar: util.Ptr(false),
-			Header: util.Ptr(false),
-			Cached: util.Ptr(true),
+			Avatar:    util.Ptr(false),
+			Header:    util.Ptr(false),
+			Cached:    util.Ptr(true),
+			Sensitive: util.Ptr(false),
 		},
 		"remote_account_2_status_1_attachment_2": {
 			ID:          "01HE7ZFX9GKA5ZZVD4FACABSS9",
@@ -1172,9 +1217,10 @@ func NewTestAttachments() map[string]*gtsmodel.MediaAttachment {
 				UpdatedAt:   TimeMustParse("2023-11-02T12:44:25+02:00"),
 				URL:         "http://localhost:8080/fileserver/01FHMQX3GAABWSM0S2VZEC2SWC/attachment/small/01HE7ZFX9GKA5ZZVD4FACABSS9.jpg",
 			},
-			Avatar: util.Ptr(false),
-			Header: util.Ptr(false),
-			Cached: util.Ptr(false),
+			Avatar:    util.Ptr(false),
+			Header:    util.Ptr(false),
+			Cached:    util.Ptr(false),
+			Sensitive: util.Ptr(false),
 		},
 		"remote_account_2_status_1_attachment_3": {
 			ID:          "01HE88YG74PVAB81PX2XA9F3FG",
@@ -1202,9 +1248,10 @@ func NewTestAttachments() map[string]*gtsmodel.MediaAttachment {
 				UpdatedAt:   TimeMustParse("2023-11-02T12:44:25+02:00"),
 				URL:         "http://localhost:8080/fileserver/01FHMQX3GAABWSM0S2VZEC2SWC/attachment/small/01HE88YG74PVAB81PX2XA9F3FG.jpg",
 			},
-			Avatar: util.Ptr(false),
-			Header: util.Ptr(false),
-			Cached: util.Ptr(false),
+			Avatar:    util.Ptr(false),
+			Header:    util.Ptr(false),
+			Cached:    util.Ptr(false),
+			Sensitive: util.Ptr(false),
 		},
 	}
 }
@@ -2480,6 +2527,7 @@ func NewTestLists() map[string]*gtsmodel.List {
 			Title:         "Cool Ass Posters From This Instance",
 			AccountID:     "01F8MH1H7YV1Z7D2C8K2730QBF",
 			RepliesPolicy: gtsmodel.RepliesPolicyFollowed,
+			Exclusive:     util.Ptr(false),
 		},
 	}
 }
@@ -3219,6 +3267,22 @@ func NewTestDereferenceRequests(accounts map[string]*gtsmodel.Account) map[strin
 		DateHeader:      date,
 	}
 
+	target = URLMustParse(statuses["local_account_1_status_1"].URI + "/likes")
+	sig, digest, date = GetSignatureForDereference(accounts["remote_account_1"].PublicKeyURI, accounts["remote_account_1"].PrivateKey, target)
+	fossSatanDereferenceLocalAccount1Status1Likes := ActivityWithSignature{
+		SignatureHeader: sig,
+		DigestHeader:    digest,
+		DateHeader:      date,
+	}
+
+	target = URLMustParse(statuses["local_account_1_status_1"].URI + "/shares")
+	sig, digest, date = GetSignatureForDereference(accounts["remote_account_1"].PublicKeyURI, accounts["remote_account_1"].PrivateKey, target)
+	fossSatanDereferenceLocalAccount1Status1Shares := ActivityWithSignature{
+		SignatureHeader: sig,
+		DigestHeader:    digest,
+		DateHeader:      date,
+	}
+
 	target = URLMustParse(accounts["local_account_1"].OutboxURI)
 	sig, digest, date = GetSignatureForDereference(accounts["remote_account_1"].PublicKeyURI, accounts["remote_account_1"].PrivateKey, target)
 	fossSatanDereferenceZorkOutbox := ActivityWithSignature{
@@ -3259,6 +3323,8 @@ func NewTestDereferenceRequests(accounts map[string]*gtsmodel.Account) map[strin
 		"foss_satan_dereference_local_account_1_status_1_replies":      fossSatanDereferenceLocalAccount1Status1Replies,
 		"foss_satan_dereference_local_account_1_status_1_replies_next": fossSatanDereferenceLocalAccount1Status1RepliesNext,
 		"foss_satan_dereference_local_account_1_status_1_replies_last": fossSatanDereferenceLocalAccount1Status1RepliesLast,
+		"foss_satan_dereference_local_account_1_status_1_likes":        fossSatanDereferenceLocalAccount1Status1Likes,
+		"foss_satan_dereference_local_account_1_status_1_shares":       fossSatanDereferenceLocalAccount1Status1Shares,
 		"foss_satan_dereference_zork_outbox":                           fossSatanDereferenceZorkOutbox,
 		"foss_satan_dereference_zork_outbox_first":                     fossSatanDereferenceZorkOutboxFirst,
 		"foss_satan_dereference_zork_outbox_next":                      fossSatanDereferenceZorkOutboxNext,
@@ -30,6 +30,7 @@ import (
 	"codeberg.org/gruf/go-byteutil"
 	"codeberg.org/gruf/go-kv/format"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	tlprocessor "github.com/superseriousbusiness/gotosocial/internal/processing/timeline"
@@ -56,6 +57,7 @@ func StartNoopWorkers(state *state.State) {
 	// _ = state.Workers.Federator.Start(1)
 	// _ = state.Workers.Dereference.Start(1)
 	// _ = state.Workers.Media.Start(1)
+	// _ = state.Workers.Processing.Start(1)
 	//
 	// (except for the scheduler, that's fine)
 	_ = state.Workers.Scheduler.Start()
@@ -83,6 +85,7 @@ func StartWorkers(state *state.State, processor *workers.Processor) {
 	state.Workers.Federator.Start(1)
 	state.Workers.Dereference.Start(1)
 	state.Workers.Media.Start(1)
+	state.Workers.Processing.Start(1)
 }
 
 func StopWorkers(state *state.State) {
@@ -91,10 +94,13 @@ func StopWorkers(state *state.State) {
 	state.Workers.Federator.Stop()
 	state.Workers.Dereference.Stop()
 	state.Workers.Media.Stop()
+	state.Workers.Processing.Stop()
 }
 
 func StartTimelines(state *state.State, filter *visibility.Filter, converter *typeutils.Converter) {
-	state.Timelines.Home = timeline.NewManager(
+	state.Timelines.Home = timeline.NewConfiguredManager(
+		state.DB,
+		gtsmodel.TimelineTypeHome,
 		tlprocessor.HomeTimelineGrab(state),
 		tlprocessor.HomeTimelineFilter(state, filter),
 		tlprocessor.HomeTimelineStatusPrepare(state, converter),
@@ -104,7 +110,9 @@ func StartTimelines(state *state.State, filter *visibility.Filter, converter *ty
 		panic(fmt.Sprintf("error starting home timeline: %s", err))
 	}
 
-	state.Timelines.List = timeline.NewManager(
+	state.Timelines.List = timeline.NewConfiguredManager(
+		state.DB,
+		gtsmodel.TimelineTypeList,
 		tlprocessor.ListTimelineGrab(state),
 		tlprocessor.ListTimelineFilter(state, filter),
 		tlprocessor.ListTimelineStatusPrepare(state, converter),
@@ -38,6 +38,15 @@ export interface AdminAccount {
 	suspended: boolean,
 	created_by_application_id: string,
 	account: Account,
+	acknowledged_rules?: AdminRuleAcknowledgement[],
+}
+
+export interface AdminRuleAcknowledgement {
+	rule_id: string,
+	text: string,
+	rule_updated_at: string,
+	acknowledged_at: string,
+	current: boolean,
 }
 
 export interface Account {
@@ -201,6 +201,18 @@ function LocalAccountDetails({ adminAcct }: { adminAcct: AdminAccount }) {
 						<dt>Locale</dt>
 						<dd>{adminAcct.locale}</dd>
 					</div> }
+				{ adminAcct.acknowledged_rules?.map((ack) =>
+					<div className="info-list-entry" key={ack.rule_id}>
+						<dt>Acknowledged Rule</dt>
+						<dd>
+							{ack.text}
+							{" "}
+							<b>{ack.current ? "(current version)" : `(version of ${new Date(ack.rule_updated_at).toLocaleString()}, since changed)`}</b>
+							<br/>
+							<small>Acknowledged {new Date(ack.acknowledged_at).toLocaleString()}</small>
+						</dd>
+					</div>
+				)}
 			</dl>
 		</> 
 	);
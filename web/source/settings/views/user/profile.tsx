@@ -35,7 +35,8 @@ import {
 	TextArea,
 	FileInput,
 	Checkbox,
-	RadioGroup
+	RadioGroup,
+	Select
 } from "../../components/form/inputs";
 
 import FormWithData from "../../lib/form/form-with-data";
@@ -61,12 +62,19 @@ function UserProfileForm({ data: profile }) {
 		User profile update form keys
 		- bool bot
 		- bool locked
+		- bool source[auto_approve_followed]
+		- bool source[auto_approve_local]
+		- number source[auto_approve_min_age_days]
 		- string display_name
 		- string note
 		- file avatar
 		- file header
 		- bool enable_rss
 		- bool hide_collections
+		- bool hide_application
+		- bool enable_embeds
+		- bool source[record_follower_events]
+		- string attribution_domains[]
 		- string custom_css (if enabled)
 		- string theme
 	*/
@@ -98,9 +106,19 @@ function UserProfileForm({ data: profile }) {
 		note: useTextInput("note", { source: profile, valueSelector: (p) => p.source?.note }),
 		bot: useBoolInput("bot", { source: profile }),
 		locked: useBoolInput("locked", { source: profile }),
+		autoApproveFollowed: useBoolInput("source[auto_approve_followed]", { source: profile, valueSelector: (p) => p.source?.auto_approve_followed }),
+		autoApproveLocal: useBoolInput("source[auto_approve_local]", { source: profile, valueSelector: (p) => p.source?.auto_approve_local }),
+		autoApproveMinAgeDays: useTextInput("source[auto_approve_min_age_days]", { source: profile, valueSelector: (p) => String(p.source?.auto_approve_min_age_days ?? 0) }),
 		discoverable: useBoolInput("discoverable", { source: profile}),
 		enableRSS: useBoolInput("enable_rss", { source: profile }),
 		hideCollections: useBoolInput("hide_collections", { source: profile }),
+		hideApplication: useBoolInput("hide_application", { source: profile }),
+		enableEmbeds: useBoolInput("enable_embeds", { source: profile }),
+		recordFollowerEvents: useBoolInput("source[record_follower_events]", { source: profile, valueSelector: (p) => p.source?.record_follower_events }),
+		attributionDomains: useTextInput("attribution_domains[]", {
+			source: profile,
+			valueSelector: (p) => p.source?.attribution_domains?.join("\n")
+		}),
 		fields: useFieldArrayInput("fields_attributes", {
 			defaultValue: profile?.source?.fields,
 			length: instanceConfig.maxPinnedFields
@@ -203,6 +221,24 @@ function UserProfileForm({ data: profile }) {
 				field={form.locked}
 				label="Manually approve follow requests"
 			/>
+			<Checkbox
+				field={form.autoApproveFollowed}
+				label="Automatically approve requests from accounts you follow"
+			/>
+			<Checkbox
+				field={form.autoApproveLocal}
+				label="Automatically approve requests from accounts on this instance"
+			/>
+			<Select field={form.autoApproveMinAgeDays} label="Automatically approve requests from accounts older than" options={
+				<>
+					<option value="0">Never (default)</option>
+					<option value="30">1 month</option>
+					<option value="90">3 months</option>
+					<option value="180">6 months</option>
+					<option value="365">1 year</option>
+				</>
+			}>
+			</Select>
 			<Checkbox
 				field={form.discoverable}
 				label="Mark account as discoverable by search engines and directories"
@@ -215,6 +251,25 @@ function UserProfileForm({ data: profile }) {
 				field={form.hideCollections}
 				label="Hide who you follow / are followed by"
 			/>
+			<Checkbox
+				field={form.hideApplication}
+				label="Hide which application you used to post"
+			/>
+			<Checkbox
+				field={form.enableEmbeds}
+				label="Allow your public posts to be embedded in other websites"
+			/>
+			<Checkbox
+				field={form.recordFollowerEvents}
+				label="Keep a log of accounts following and unfollowing you"
+			/>
+
+			<TextArea
+				field={form.attributionDomains}
+				label="Websites allowed to credit you as author (one domain per line)"
+				placeholder={"example.org\nblog.example.org"}
+				rows={3}
+			/>
 
 			<div className="form-section-docs">
 				<h3>Advanced</h3>
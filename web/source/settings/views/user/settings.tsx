@@ -42,6 +42,8 @@ function UserSettingsForm({ data }) {
 		- bool source[sensitive]
 		- string source[language]
 		- string source[status_content_type]
+		- number source[notify_new_from_days]
+		- number source[email_when_away_hours]
 	 */
 
 	const form = {
@@ -49,6 +51,8 @@ function UserSettingsForm({ data }) {
 		isSensitive: useBoolInput("source[sensitive]", { source: data }),
 		language: useTextInput("source[language]", { source: data, valueSelector: (s) => s.source.language?.toUpperCase() ?? "EN" }),
 		statusContentType: useTextInput("source[status_content_type]", { source: data, defaultValue: "text/plain" }),
+		notifyNewFromDays: useTextInput("source[notify_new_from_days]", { source: data, valueSelector: (s) => String(s.source?.notify_new_from_days ?? 0) }),
+		emailWhenAwayHours: useTextInput("source[email_when_away_hours]", { source: data, valueSelector: (s) => String(s.source?.email_when_away_hours ?? 0) }),
 	};
 
 	const [submitForm, result] = useFormSubmit(form, useUpdateCredentialsMutation());
@@ -91,6 +95,26 @@ function UserSettingsForm({ data }) {
 					field={form.isSensitive}
 					label="Mark my posts as sensitive by default"
 				/>
+				<Select field={form.notifyNewFromDays} label="Notify me when an account I follow posts after a break of" options={
+					<>
+						<option value="0">Never notify (default)</option>
+						<option value="7">1 week</option>
+						<option value="30">1 month</option>
+						<option value="90">3 months</option>
+						<option value="365">1 year</option>
+					</>
+				}>
+				</Select>
+				<Select field={form.emailWhenAwayHours} label="Email me about new mentions and direct messages when I haven't been active for" options={
+					<>
+						<option value="0">Never email (default)</option>
+						<option value="6">6 hours</option>
+						<option value="24">1 day</option>
+						<option value="72">3 days</option>
+						<option value="168">1 week</option>
+					</>
+				}>
+				</Select>
 				<MutationButton
 					disabled={false}
 					label="Save settings"